@@ -0,0 +1,174 @@
+package chainfailover
+
+import (
+	"encoding/hex"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+)
+
+// RPCChainIO is a chain IO backed by the JSON-RPC interface of a btcd or
+// bitcoind node. As the client should be created in HTTP POST mode, it
+// doesn't require the node to be reachable when it's created, which makes it
+// suitable for checking the health of a backend.
+type RPCChainIO struct {
+	client *rpcclient.Client
+}
+
+// Compile-time check to ensure RPCChainIO implements the
+// lnwallet.BlockChainIO interface.
+var _ lnwallet.BlockChainIO = (*RPCChainIO)(nil)
+
+// NewRPCChainIO creates a new chain IO backed by the given RPC client.
+func NewRPCChainIO(client *rpcclient.Client) *RPCChainIO {
+	return &RPCChainIO{client: client}
+}
+
+// GetBestBlock returns the current height and hash of the best known block.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (r *RPCChainIO) GetBestBlock() (*chainhash.Hash, int32, error) {
+	hash, err := r.client.GetBestBlockHash()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	header, err := r.client.GetBlockHeaderVerbose(hash)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return hash, header.Height, nil
+}
+
+// GetUtxo returns the original output referenced by the passed outpoint.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (r *RPCChainIO) GetUtxo(op *wire.OutPoint, pkScript []byte,
+	heightHint uint32) (*wire.TxOut, error) {
+
+	txout, err := r.client.GetTxOut(&op.Hash, op.Index, false)
+	if err != nil {
+		return nil, err
+	} else if txout == nil {
+		return nil, btcwallet.ErrOutputSpent
+	}
+
+	pkScript, err = hex.DecodeString(txout.ScriptPubKey.Hex)
+	if err != nil {
+		return nil, err
+	}
+
+	// gettxout returns the output value in BTC instead of satoshis.
+	amt, err := btcutil.NewAmount(txout.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wire.TxOut{
+		Value:    int64(amt),
+		PkScript: pkScript,
+	}, nil
+}
+
+// GetBlockHash returns the hash of the block in the best blockchain at the
+// given height.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (r *RPCChainIO) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
+	return r.client.GetBlockHash(blockHeight)
+}
+
+// GetBlock returns the block in the main chain identified by the given hash.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (r *RPCChainIO) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+	return r.client.GetBlock(blockHash)
+}
+
+// PublishTransaction broadcasts the passed transaction through the node.
+func (r *RPCChainIO) PublishTransaction(tx *wire.MsgTx) error {
+	_, err := r.client.SendRawTransaction(tx, false)
+	return err
+}
+
+// chainIO is a chain IO that delegates to the active backend of the
+// failover.
+type chainIO struct {
+	f *Failover
+}
+
+// Compile-time check to ensure chainIO implements the lnwallet.BlockChainIO
+// interface.
+var _ lnwallet.BlockChainIO = (*chainIO)(nil)
+
+// GetBestBlock returns the current height and hash of the best known block.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (c *chainIO) GetBestBlock() (*chainhash.Hash, int32, error) {
+	return c.f.activeBackend().ChainIO.GetBestBlock()
+}
+
+// GetUtxo returns the original output referenced by the passed outpoint.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (c *chainIO) GetUtxo(op *wire.OutPoint, pkScript []byte,
+	heightHint uint32) (*wire.TxOut, error) {
+
+	return c.f.activeBackend().ChainIO.GetUtxo(op, pkScript, heightHint)
+}
+
+// GetBlockHash returns the hash of the block in the best blockchain at the
+// given height.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (c *chainIO) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
+	return c.f.activeBackend().ChainIO.GetBlockHash(blockHeight)
+}
+
+// GetBlock returns the block in the main chain identified by the given hash.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (c *chainIO) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+	return c.f.activeBackend().ChainIO.GetBlock(blockHash)
+}
+
+// feeEstimator is a fee estimator that delegates to the active backend of the
+// failover. The fee estimators of the backends are started and stopped by
+// the failover itself.
+type feeEstimator struct {
+	f *Failover
+}
+
+// Compile-time check to ensure feeEstimator implements the
+// lnwallet.FeeEstimator interface.
+var _ lnwallet.FeeEstimator = (*feeEstimator)(nil)
+
+// EstimateFeePerKW returns the estimated fee rate of the active backend.
+//
+// NOTE: This is part of the lnwallet.FeeEstimator interface.
+func (e *feeEstimator) EstimateFeePerKW(
+	numBlocks uint32) (lnwallet.SatPerKWeight, error) {
+
+	return e.f.activeBackend().FeeEstimator.EstimateFeePerKW(numBlocks)
+}
+
+// Start is a no-op, as the failover starts the fee estimator of the active
+// backend.
+//
+// NOTE: This is part of the lnwallet.FeeEstimator interface.
+func (e *feeEstimator) Start() error {
+	return nil
+}
+
+// Stop is a no-op, as the failover stops the fee estimator of the active
+// backend.
+//
+// NOTE: This is part of the lnwallet.FeeEstimator interface.
+func (e *feeEstimator) Stop() error {
+	return nil
+}
@@ -0,0 +1,481 @@
+package chainfailover
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/routing/chainview"
+)
+
+// ErrChainViewShuttingDown is returned when using a chain view that has been
+// stopped.
+var ErrChainViewShuttingDown = errors.New("chain view shutting down")
+
+// blockEvent is a block that is connected to or disconnected from the chain.
+type blockEvent struct {
+	block        *chainview.FilteredBlock
+	disconnected bool
+}
+
+// FilteredChainView is a filtered chain view backed by the active backend of
+// the failover. After a switch, the filter is applied to the new backend,
+// blocks that were missed in between are dispatched, and blocks that were
+// already dispatched are suppressed.
+type FilteredChainView struct {
+	// switchMtx is held for writing while switching backends, and for
+	// reading while the chain view is used.
+	switchMtx sync.RWMutex
+	started   bool
+	stopped   bool
+	view      chainview.FilteredChainView
+	chainIO   lnwallet.BlockChainIO
+
+	// stopForwarder is closed to stop the goroutine forwarding the blocks
+	// of the active backend.
+	stopForwarder chan struct{}
+	forwarder     sync.WaitGroup
+
+	// mtx guards the filter and the dispatched blocks.
+	mtx    sync.Mutex
+	filter map[wire.OutPoint][]byte
+
+	// bestHeight is the height of the last dispatched block. It's only
+	// valid once bestKnown is set.
+	bestHeight uint32
+	bestKnown  bool
+
+	// delivered maps the heights of the recently dispatched blocks to
+	// their hashes.
+	delivered map[uint32]chainhash.Hash
+
+	filteredBlocks     chan *chainview.FilteredBlock
+	disconnectedBlocks chan *chainview.FilteredBlock
+
+	quit chan struct{}
+}
+
+// Compile-time check to ensure FilteredChainView implements the
+// chainview.FilteredChainView interface.
+var _ chainview.FilteredChainView = (*FilteredChainView)(nil)
+
+// newFilteredChainView creates a new failover chain view backed by the given
+// chain view.
+func newFilteredChainView(view chainview.FilteredChainView,
+	chainIO lnwallet.BlockChainIO) *FilteredChainView {
+
+	return &FilteredChainView{
+		view:               view,
+		chainIO:            chainIO,
+		stopForwarder:      make(chan struct{}),
+		filter:             make(map[wire.OutPoint][]byte),
+		delivered:          make(map[uint32]chainhash.Hash),
+		filteredBlocks:     make(chan *chainview.FilteredBlock),
+		disconnectedBlocks: make(chan *chainview.FilteredBlock),
+		quit:               make(chan struct{}),
+	}
+}
+
+// Start starts the chain view of the active backend.
+//
+// NOTE: This is part of the chainview.FilteredChainView interface.
+func (c *FilteredChainView) Start() error {
+	c.switchMtx.Lock()
+	defer c.switchMtx.Unlock()
+
+	if c.started {
+		return nil
+	}
+	c.started = true
+
+	if err := c.view.Start(); err != nil {
+		return err
+	}
+
+	c.forwarder.Add(1)
+	go c.forwardBlocks(c.view, c.chainIO, nil, c.stopForwarder)
+
+	return nil
+}
+
+// Stop stops the chain view of the active backend.
+//
+// NOTE: This is part of the chainview.FilteredChainView interface.
+func (c *FilteredChainView) Stop() error {
+	c.switchMtx.Lock()
+	defer c.switchMtx.Unlock()
+
+	if c.stopped {
+		return nil
+	}
+	c.stopped = true
+
+	close(c.quit)
+	close(c.stopForwarder)
+	c.forwarder.Wait()
+
+	if !c.started {
+		return nil
+	}
+
+	return c.view.Stop()
+}
+
+// FilteredBlocks returns the channel that filtered blocks are sent over.
+//
+// NOTE: This is part of the chainview.FilteredChainView interface.
+func (c *FilteredChainView) FilteredBlocks() <-chan *chainview.FilteredBlock {
+	return c.filteredBlocks
+}
+
+// DisconnectedBlocks returns the channel that disconnected blocks are sent
+// over.
+//
+// NOTE: This is part of the chainview.FilteredChainView interface.
+func (c *FilteredChainView) DisconnectedBlocks() <-chan *chainview.FilteredBlock {
+	return c.disconnectedBlocks
+}
+
+// UpdateFilter adds the given outpoints to the filter. The filter is kept
+// so it can be applied to the next backend after a switch.
+//
+// NOTE: This is part of the chainview.FilteredChainView interface.
+func (c *FilteredChainView) UpdateFilter(ops []channeldb.EdgePoint,
+	updateHeight uint32) error {
+
+	c.switchMtx.RLock()
+	defer c.switchMtx.RUnlock()
+
+	if c.stopped {
+		return ErrChainViewShuttingDown
+	}
+
+	c.mtx.Lock()
+	for _, op := range ops {
+		c.filter[op.OutPoint] = op.FundingPkScript
+	}
+	c.mtx.Unlock()
+
+	return c.view.UpdateFilter(ops, updateHeight)
+}
+
+// FilterBlock applies the filter to the block with the given hash.
+//
+// NOTE: This is part of the chainview.FilteredChainView interface.
+func (c *FilteredChainView) FilterBlock(
+	blockHash *chainhash.Hash) (*chainview.FilteredBlock, error) {
+
+	c.switchMtx.RLock()
+	defer c.switchMtx.RUnlock()
+
+	if c.stopped {
+		return nil, ErrChainViewShuttingDown
+	}
+
+	block, err := c.view.FilterBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	c.pruneFilter(block)
+	c.mtx.Unlock()
+
+	return block, nil
+}
+
+// pruneFilter removes the outpoints spent within the block from the filter.
+//
+// NOTE: The mutex MUST be held when calling this method.
+func (c *FilteredChainView) pruneFilter(block *chainview.FilteredBlock) {
+	for _, tx := range block.Transactions {
+		for _, txIn := range tx.TxIn {
+			delete(c.filter, txIn.PreviousOutPoint)
+		}
+	}
+}
+
+// forwardBlocks dispatches the pending blocks, followed by the blocks of the
+// given backend chain view.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *FilteredChainView) forwardBlocks(view chainview.FilteredChainView,
+	chainIO lnwallet.BlockChainIO, pending []blockEvent,
+	stop chan struct{}) {
+
+	defer c.forwarder.Done()
+
+	for _, event := range pending {
+		if !c.dispatch(view, chainIO, event, stop) {
+			return
+		}
+	}
+
+	filteredBlocks := view.FilteredBlocks()
+	disconnectedBlocks := view.DisconnectedBlocks()
+	for {
+		var event blockEvent
+		select {
+		case block, ok := <-filteredBlocks:
+			if !ok {
+				return
+			}
+			event = blockEvent{block: block}
+
+		case block, ok := <-disconnectedBlocks:
+			if !ok {
+				return
+			}
+			event = blockEvent{block: block, disconnected: true}
+
+		case <-stop:
+			return
+
+		case <-c.quit:
+			return
+		}
+
+		if !c.dispatch(view, chainIO, event, stop) {
+			return
+		}
+	}
+}
+
+// dispatch sends the block event to the client, unless it was already
+// dispatched. If blocks are missing before a connected block, they're
+// dispatched first. False is returned if the forwarder should exit.
+func (c *FilteredChainView) dispatch(view chainview.FilteredChainView,
+	chainIO lnwallet.BlockChainIO, event blockEvent,
+	stop chan struct{}) bool {
+
+	block := event.block
+
+	c.mtx.Lock()
+	bestHeight, bestKnown := c.bestHeight, c.bestKnown
+	hash, delivered := c.delivered[block.Height]
+	c.mtx.Unlock()
+
+	if event.disconnected {
+		// We only disconnect the tip we've dispatched, anything else
+		// was already disconnected or never dispatched.
+		if !delivered || hash != block.Hash ||
+			block.Height != bestHeight {
+
+			return true
+		}
+
+		if !c.send(c.disconnectedBlocks, block, stop) {
+			return false
+		}
+
+		c.mtx.Lock()
+		delete(c.delivered, block.Height)
+		c.bestHeight = block.Height - 1
+		c.mtx.Unlock()
+
+		return true
+	}
+
+	if bestKnown && block.Height <= bestHeight {
+		// A block we've already dispatched is only sent again as an
+		// update following a rescan.
+		if !delivered || hash != block.Hash {
+			log.Debugf("Skipping stale block %v (height=%d)",
+				block.Hash, block.Height)
+			return true
+		}
+		if len(block.Transactions) == 0 {
+			return true
+		}
+
+		c.mtx.Lock()
+		c.pruneFilter(block)
+		c.mtx.Unlock()
+
+		return c.send(c.filteredBlocks, block, stop)
+	}
+
+	// If the backend skipped any blocks, we'll filter them ourselves so
+	// the client won't miss any.
+	if bestKnown {
+		for height := bestHeight + 1; height < block.Height; height++ {
+			missing, err := filterBlockByHeight(
+				view, chainIO, height,
+			)
+			if err != nil {
+				log.Errorf("Unable to filter block at height "+
+					"%d: %v", height, err)
+				return true
+			}
+
+			if !c.connect(missing, stop) {
+				return false
+			}
+		}
+	}
+
+	return c.connect(block, stop)
+}
+
+// connect sends the connected block to the client, and marks it as
+// dispatched.
+func (c *FilteredChainView) connect(block *chainview.FilteredBlock,
+	stop chan struct{}) bool {
+
+	if !c.send(c.filteredBlocks, block, stop) {
+		return false
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.pruneFilter(block)
+	c.bestHeight = block.Height
+	c.bestKnown = true
+	c.delivered[block.Height] = block.Hash
+	delete(c.delivered, block.Height-reorgSafetyLimit)
+
+	return true
+}
+
+// send sends the block over the given channel.
+func (c *FilteredChainView) send(blocks chan *chainview.FilteredBlock,
+	block *chainview.FilteredBlock, stop chan struct{}) bool {
+
+	select {
+	case blocks <- block:
+		return true
+	case <-stop:
+		return false
+	case <-c.quit:
+		return false
+	}
+}
+
+// filterBlockByHeight applies the filter of the chain view to the block at
+// the given height.
+func filterBlockByHeight(view chainview.FilteredChainView,
+	chainIO lnwallet.BlockChainIO,
+	height uint32) (*chainview.FilteredBlock, error) {
+
+	hash, err := chainIO.GetBlockHash(int64(height))
+	if err != nil {
+		return nil, err
+	}
+
+	return view.FilterBlock(hash)
+}
+
+// switchChainView makes the passed chain view the active one, and applies
+// the filter to it. Blocks that were dispatched but aren't part of the chain
+// of the new backend are disconnected, and the blocks up to its tip are
+// dispatched. The previously active chain view is returned.
+//
+// NOTE: The switch mutex MUST be held for writing, and the new chain view
+// MUST already be started if the failover chain view is.
+func (c *FilteredChainView) switchChainView(view chainview.FilteredChainView,
+	chainIO lnwallet.BlockChainIO) chainview.FilteredChainView {
+
+	// First, we'll wait for the blocks of the previous backend to be
+	// dispatched, so our state won't change anymore.
+	close(c.stopForwarder)
+	c.forwarder.Wait()
+	c.stopForwarder = make(chan struct{})
+
+	oldView := c.view
+	c.view = view
+	c.chainIO = chainIO
+
+	if !c.started {
+		return oldView
+	}
+
+	_, tipHeight, err := chainIO.GetBestBlock()
+	if err != nil {
+		log.Errorf("Unable to get best block of new backend: %v", err)
+	}
+
+	c.mtx.Lock()
+	bestHeight, bestKnown := c.bestHeight, c.bestKnown
+	ops := make([]channeldb.EdgePoint, 0, len(c.filter))
+	for op, pkScript := range c.filter {
+		ops = append(ops, channeldb.EdgePoint{
+			FundingPkScript: pkScript,
+			OutPoint:        op,
+		})
+	}
+	delivered := make(map[uint32]chainhash.Hash, len(c.delivered))
+	for height, hash := range c.delivered {
+		delivered[height] = hash
+	}
+	c.mtx.Unlock()
+
+	// Any blocks we've dispatched that aren't part of the chain of the
+	// new backend have been reorged out while we weren't watching.
+	var pending []blockEvent
+	forkHeight := bestHeight
+	for bestKnown && err == nil {
+		hash, ok := delivered[forkHeight]
+		if !ok {
+			break
+		}
+
+		if int32(forkHeight) <= tipHeight {
+			newHash, err := chainIO.GetBlockHash(int64(forkHeight))
+			if err != nil {
+				log.Errorf("Unable to get block hash at height "+
+					"%d: %v", forkHeight, err)
+				break
+			}
+			if *newHash == hash {
+				break
+			}
+		}
+
+		pending = append(pending, blockEvent{
+			block: &chainview.FilteredBlock{
+				Hash:   hash,
+				Height: forkHeight,
+			},
+			disconnected: true,
+		})
+		forkHeight--
+	}
+
+	// We'll apply the filter to the new backend without a rescan, as
+	// we'll filter the missing blocks ourselves.
+	updateHeight := uint32(tipHeight)
+	if bestKnown && forkHeight > updateHeight {
+		updateHeight = forkHeight
+	}
+	if len(ops) != 0 {
+		if err := view.UpdateFilter(ops, updateHeight); err != nil {
+			log.Errorf("Unable to update filter of new backend: %v",
+				err)
+		}
+	}
+
+	if bestKnown {
+		for height := forkHeight + 1; height <= uint32(tipHeight); height++ {
+			block, err := filterBlockByHeight(view, chainIO, height)
+			if err != nil {
+				log.Errorf("Unable to filter block at height "+
+					"%d: %v", height, err)
+				break
+			}
+
+			pending = append(pending, blockEvent{block: block})
+		}
+	}
+
+	log.Infof("Applied filter of %d outpoints to new backend, "+
+		"dispatching %d missed blocks", len(ops), len(pending))
+
+	c.forwarder.Add(1)
+	go c.forwardBlocks(view, chainIO, pending, c.stopForwarder)
+
+	return oldView
+}
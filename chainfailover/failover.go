@@ -0,0 +1,515 @@
+package chainfailover
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/routing/chainview"
+)
+
+const (
+	// DefaultCheckInterval is the default interval between two health
+	// checks of the backends.
+	DefaultCheckInterval = 30 * time.Second
+
+	// DefaultMaxTipLag is the default number of blocks the active backend
+	// may lag behind the best backend before we fail over.
+	DefaultMaxTipLag = 3
+
+	// reorgSafetyLimit is the number of blocks after which we assume a
+	// block can no longer be reorged out of the chain.
+	reorgSafetyLimit = 100
+)
+
+// ErrNoHealthyBackend is returned if none of the backends can be connected
+// to.
+var ErrNoHealthyBackend = errors.New("no healthy chain backend available")
+
+// Connection is the set of subsystems that require a live connection to a
+// backend. As stopped subsystems can't be restarted, a new connection is
+// created each time a backend becomes active.
+type Connection struct {
+	// Notifier is the chain notifier of the backend. It's started once
+	// the failover notifier is started.
+	Notifier chainntnfs.ChainNotifier
+
+	// ChainView is the filtered chain view of the backend. It's started
+	// once the failover chain view is started.
+	ChainView chainview.FilteredChainView
+
+	// Close releases any resources held by the connection. It's called
+	// after the notifier and the chain view have been stopped.
+	Close func()
+}
+
+// Backend is a single chain backend, such as a btcd or bitcoind node.
+type Backend struct {
+	// Name identifies the backend in logs.
+	Name string
+
+	// ChainIO serves chain queries while the backend is active, and is
+	// used to check the health of the backend. It must not require the
+	// backend to be reachable when it's created.
+	ChainIO lnwallet.BlockChainIO
+
+	// FeeEstimator is the fee estimator of the backend. It's started the
+	// first time the backend becomes active, and stopped along with the
+	// failover.
+	FeeEstimator lnwallet.FeeEstimator
+
+	// Connect connects to the backend and returns the subsystems that
+	// depend on the connection.
+	Connect func() (*Connection, error)
+
+	// PublishTx broadcasts a transaction through the backend.
+	PublishTx func(*wire.MsgTx) error
+}
+
+// Config houses the parameters of the failover.
+type Config struct {
+	// Backends are the backends to choose from, in order of preference.
+	Backends []*Backend
+
+	// CheckInterval is the interval between two health checks. A backend
+	// that doesn't respond within the interval is deemed unhealthy.
+	CheckInterval time.Duration
+
+	// MaxTipLag is the number of blocks the active backend may lag behind
+	// the best backend before we fail over.
+	MaxTipLag uint32
+}
+
+// Failover switches between multiple chain backends. The best block of each
+// backend is checked periodically. Once the active backend fails to respond
+// or lags behind the others, the next healthy backend in order of preference
+// becomes active. The chain notifier, filtered chain view, fee estimator,
+// chain IO and transaction publishing handed out by the failover always use
+// the active backend, and carry all registered notifications over to the new
+// backend after a switch.
+//
+// NOTE: We won't switch back to a preferred backend once it has recovered, as
+// long as the active backend stays healthy.
+type Failover struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	cfg Config
+
+	// mtx guards the active backend and its connection. It's held for
+	// writing while switching backends.
+	mtx    sync.RWMutex
+	active int
+	conn   *Connection
+
+	notifier  *ChainNotifier
+	chainView *FilteredChainView
+
+	// feeEstimatorStarted tracks the backends whose fee estimator has
+	// been started. It's only accessed while connecting to a backend, and
+	// once the health checks have been stopped.
+	feeEstimatorStarted []bool
+
+	// checking tracks the backends whose health check is still pending,
+	// so we won't pile up requests to a backend that doesn't respond.
+	checking []int32
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// New creates a new failover across the given backends, and connects to the
+// first one that is reachable.
+func New(cfg *Config) (*Failover, error) {
+	if len(cfg.Backends) == 0 {
+		return nil, fmt.Errorf("at least one backend is required")
+	}
+
+	f := &Failover{
+		cfg:                 *cfg,
+		feeEstimatorStarted: make([]bool, len(cfg.Backends)),
+		checking:            make([]int32, len(cfg.Backends)),
+		quit:                make(chan struct{}),
+	}
+	if f.cfg.CheckInterval == 0 {
+		f.cfg.CheckInterval = DefaultCheckInterval
+	}
+
+	for i, backend := range cfg.Backends {
+		conn, err := f.connect(i)
+		if err != nil {
+			log.Warnf("Unable to connect to chain backend %v: %v",
+				backend.Name, err)
+			continue
+		}
+
+		log.Infof("Using chain backend %v", backend.Name)
+
+		f.active = i
+		f.conn = conn
+		f.notifier = newChainNotifier(conn.Notifier)
+		f.chainView = newFilteredChainView(
+			conn.ChainView, backend.ChainIO,
+		)
+
+		return f, nil
+	}
+
+	return nil, ErrNoHealthyBackend
+}
+
+// connect connects to the backend with the given index, and starts its fee
+// estimator if it hasn't been started yet.
+func (f *Failover) connect(index int) (*Connection, error) {
+	backend := f.cfg.Backends[index]
+	if _, _, err := backend.ChainIO.GetBestBlock(); err != nil {
+		return nil, err
+	}
+
+	conn, err := backend.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	if !f.feeEstimatorStarted[index] {
+		if err := backend.FeeEstimator.Start(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		f.feeEstimatorStarted[index] = true
+	}
+
+	return conn, nil
+}
+
+// Start launches the goroutine checking the health of the backends.
+func (f *Failover) Start() error {
+	if atomic.AddInt32(&f.started, 1) != 1 {
+		return nil
+	}
+
+	f.wg.Add(1)
+	go f.healthChecker()
+
+	return nil
+}
+
+// Stop stops the health checks and closes the connection to the active
+// backend. The notifier and chain view must have been stopped already.
+func (f *Failover) Stop() error {
+	if atomic.AddInt32(&f.stopped, 1) != 1 {
+		return nil
+	}
+
+	close(f.quit)
+	f.wg.Wait()
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	for i, started := range f.feeEstimatorStarted {
+		if started {
+			f.cfg.Backends[i].FeeEstimator.Stop()
+		}
+	}
+	f.conn.Close()
+
+	return nil
+}
+
+// Notifier returns the chain notifier backed by the active backend.
+func (f *Failover) Notifier() *ChainNotifier {
+	return f.notifier
+}
+
+// ChainView returns the filtered chain view backed by the active backend.
+func (f *Failover) ChainView() *FilteredChainView {
+	return f.chainView
+}
+
+// ChainIO returns the chain IO backed by the active backend.
+func (f *Failover) ChainIO() lnwallet.BlockChainIO {
+	return &chainIO{f: f}
+}
+
+// FeeEstimator returns the fee estimator backed by the active backend.
+func (f *Failover) FeeEstimator() lnwallet.FeeEstimator {
+	return &feeEstimator{f: f}
+}
+
+// PublishTransaction broadcasts the passed transaction through the active
+// backend.
+func (f *Failover) PublishTransaction(tx *wire.MsgTx) error {
+	return f.activeBackend().PublishTx(tx)
+}
+
+// activeBackend returns the active backend.
+func (f *Failover) activeBackend() *Backend {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+
+	return f.cfg.Backends[f.active]
+}
+
+// healthChecker periodically checks the health of all backends, and fails
+// over to another backend if the active one is unhealthy.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *Failover) healthChecker() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.checkHealth()
+
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// healthCheck is the result of checking the health of a single backend.
+type healthCheck struct {
+	index  int
+	height int32
+	err    error
+}
+
+// errNotResponding is the health check error of a backend that didn't respond
+// in time.
+var errNotResponding = errors.New("backend not responding")
+
+// checkHealth queries the best block of all backends, and fails over if the
+// active backend doesn't respond or lags behind.
+func (f *Failover) checkHealth() {
+	checks := make([]healthCheck, len(f.cfg.Backends))
+	results := make(chan healthCheck, len(f.cfg.Backends))
+	pending := 0
+	for i, backend := range f.cfg.Backends {
+		checks[i] = healthCheck{index: i, err: errNotResponding}
+
+		// If the previous check of this backend is still pending,
+		// it's clearly not healthy.
+		if !atomic.CompareAndSwapInt32(&f.checking[i], 0, 1) {
+			continue
+		}
+
+		pending++
+		go func(i int, backend *Backend) {
+			defer atomic.StoreInt32(&f.checking[i], 0)
+
+			_, height, err := backend.ChainIO.GetBestBlock()
+			results <- healthCheck{index: i, height: height, err: err}
+		}(i, backend)
+	}
+
+	// Backends that don't respond before the next check is due are deemed
+	// unhealthy.
+	timeout := time.After(f.cfg.CheckInterval)
+	for ; pending > 0; pending-- {
+		select {
+		case check := <-results:
+			checks[check.index] = check
+
+		case <-timeout:
+			log.Warnf("Chain backend health checks timed out")
+			pending = 0
+
+		case <-f.quit:
+			return
+		}
+	}
+
+	f.failover(checks)
+}
+
+// failover switches to the next healthy backend in order of preference, if
+// the active backend is unhealthy. A backend is healthy if it responded, and
+// doesn't lag behind the best backend by more than the maximum tip lag.
+func (f *Failover) failover(checks []healthCheck) {
+	var bestHeight int32
+	for _, check := range checks {
+		if check.err == nil && check.height > bestHeight {
+			bestHeight = check.height
+		}
+	}
+
+	healthy := func(check healthCheck) bool {
+		return check.err == nil &&
+			uint32(bestHeight-check.height) <= f.cfg.MaxTipLag
+	}
+
+	f.mtx.RLock()
+	active := f.active
+	f.mtx.RUnlock()
+
+	activeCheck := checks[active]
+	if healthy(activeCheck) {
+		return
+	}
+
+	backend := f.cfg.Backends[active]
+	if activeCheck.err != nil {
+		log.Warnf("Chain backend %v is unhealthy: %v", backend.Name,
+			activeCheck.err)
+	} else {
+		log.Warnf("Chain backend %v lags behind: height %d, best "+
+			"height %d", backend.Name, activeCheck.height, bestHeight)
+	}
+
+	for _, check := range checks {
+		if check.index == active || !healthy(check) {
+			continue
+		}
+
+		err := f.switchBackend(check.index)
+		if err != nil {
+			log.Warnf("Unable to switch to chain backend %v: %v",
+				f.cfg.Backends[check.index].Name, err)
+			continue
+		}
+
+		return
+	}
+
+	log.Errorf("No healthy chain backend to fail over to, staying with %v",
+		backend.Name)
+}
+
+// switchBackend makes the backend with the given index the active one. The
+// notifications registered with the failover notifier and chain view are
+// carried over to the new backend.
+func (f *Failover) switchBackend(index int) error {
+	backend := f.cfg.Backends[index]
+
+	log.Infof("Switching to chain backend %v", backend.Name)
+
+	conn, err := f.connect(index)
+	if err != nil {
+		return err
+	}
+	_, bestHeight, err := backend.ChainIO.GetBestBlock()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	replaced, err := f.swapConnection(index, conn, bestHeight)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	// Now that the new backend is active, we'll stop the subsystems of the
+	// previous one. We do so without holding the switch mutexes, as
+	// stopping them may block while they're shutting down, which would
+	// hold up the registration of new notifications.
+	if replaced.notifierStarted {
+		if err := replaced.notifier.Stop(); err != nil {
+			log.Warnf("Unable to stop notifier of %v: %v",
+				replaced.backend.Name, err)
+		}
+	}
+	if replaced.chainViewStarted {
+		if err := replaced.chainView.Stop(); err != nil {
+			log.Warnf("Unable to stop chain view of %v: %v",
+				replaced.backend.Name, err)
+		}
+	}
+	replaced.conn.Close()
+
+	log.Infof("Switched to chain backend %v", backend.Name)
+
+	return nil
+}
+
+// replacedConnection is the connection to a backend that was replaced by a
+// switch, along with the state of its subsystems.
+type replacedConnection struct {
+	backend          *Backend
+	conn             *Connection
+	notifier         chainntnfs.ChainNotifier
+	chainView        chainview.FilteredChainView
+	notifierStarted  bool
+	chainViewStarted bool
+}
+
+// swapConnection starts the subsystems of the given connection, and makes
+// them back the failover notifier and chain view. The connection that was
+// active before is returned, and it's up to the caller to stop its
+// subsystems. If the switch fails, then the previous connection remains
+// active, and the subsystems of the new one are stopped again.
+func (f *Failover) swapConnection(index int, conn *Connection,
+	bestHeight int32) (*replacedConnection, error) {
+
+	backend := f.cfg.Backends[index]
+
+	f.notifier.switchMtx.Lock()
+	defer f.notifier.switchMtx.Unlock()
+	f.chainView.switchMtx.Lock()
+	defer f.chainView.switchMtx.Unlock()
+
+	// There's no point in switching once we're shutting down.
+	if f.notifier.stopped || f.chainView.stopped {
+		return nil, errors.New("failover shutting down")
+	}
+
+	// The subsystems of the new backend need to be started if the ones
+	// they replace are.
+	notifierStarted := f.notifier.started
+	chainViewStarted := f.chainView.started
+	if notifierStarted {
+		if err := conn.Notifier.Start(); err != nil {
+			return nil, err
+		}
+	}
+	if chainViewStarted {
+		if err := conn.ChainView.Start(); err != nil {
+			if notifierStarted {
+				conn.Notifier.Stop()
+			}
+			return nil, err
+		}
+	}
+
+	// If any of the notifications can't be registered with the new
+	// backend, then we'll abort the switch rather than silently dropping
+	// them, and stay with the active backend.
+	oldNotifier, err := f.notifier.switchNotifier(conn.Notifier, bestHeight)
+	if err != nil {
+		if notifierStarted {
+			conn.Notifier.Stop()
+		}
+		if chainViewStarted {
+			conn.ChainView.Stop()
+		}
+		return nil, err
+	}
+	oldChainView := f.chainView.switchChainView(
+		conn.ChainView, backend.ChainIO,
+	)
+
+	f.mtx.Lock()
+	replaced := &replacedConnection{
+		backend:          f.cfg.Backends[f.active],
+		conn:             f.conn,
+		notifier:         oldNotifier,
+		chainView:        oldChainView,
+		notifierStarted:  notifierStarted,
+		chainViewStarted: chainViewStarted,
+	}
+	f.active = index
+	f.conn = conn
+	f.mtx.Unlock()
+
+	return replaced, nil
+}
@@ -0,0 +1,976 @@
+package chainfailover
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/routing/chainview"
+)
+
+var errUnreachable = errors.New("backend unreachable")
+
+// blockHash returns the hash of the block at the given height on the given
+// fork of the test chain.
+func blockHash(height uint32, fork byte) chainhash.Hash {
+	var hash chainhash.Hash
+	hash[0] = byte(height)
+	hash[1] = byte(height >> 8)
+	hash[2] = fork
+	return hash
+}
+
+// makeChain returns the hashes of a test chain of the given length, which
+// forks off at the given height.
+func makeChain(length, forkHeight uint32, fork byte) []chainhash.Hash {
+	hashes := make([]chainhash.Hash, length)
+	for height := range hashes {
+		if uint32(height) < forkHeight {
+			hashes[height] = blockHash(uint32(height), 0)
+		} else {
+			hashes[height] = blockHash(uint32(height), fork)
+		}
+	}
+	return hashes
+}
+
+// mockChainIO is a chain IO serving a chain of block hashes.
+type mockChainIO struct {
+	mtx    sync.Mutex
+	hashes []chainhash.Hash
+	err    error
+}
+
+func (m *mockChainIO) setChain(hashes []chainhash.Hash) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.hashes = hashes
+}
+
+func (m *mockChainIO) setErr(err error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.err = err
+}
+
+func (m *mockChainIO) GetBestBlock() (*chainhash.Hash, int32, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+
+	height := len(m.hashes) - 1
+	return &m.hashes[height], int32(height), nil
+}
+
+func (m *mockChainIO) GetUtxo(op *wire.OutPoint, pkScript []byte,
+	heightHint uint32) (*wire.TxOut, error) {
+
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockChainIO) GetBlockHash(blockHeight int64) (*chainhash.Hash,
+	error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.err != nil {
+		return nil, m.err
+	}
+	if blockHeight >= int64(len(m.hashes)) {
+		return nil, errors.New("block not found")
+	}
+
+	return &m.hashes[blockHeight], nil
+}
+
+func (m *mockChainIO) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock,
+	error) {
+
+	return nil, errors.New("not implemented")
+}
+
+// mockEpochReg is a block epoch registration with the mock notifier.
+type mockEpochReg struct {
+	bestBlock *chainntnfs.BlockEpoch
+	epochs    chan *chainntnfs.BlockEpoch
+}
+
+// mockNotifier is a chain notifier whose notifications are triggered by the
+// test.
+type mockNotifier struct {
+	mtx     sync.Mutex
+	started bool
+	stopped bool
+	confs   []*chainntnfs.ConfirmationEvent
+	spends  []chan *chainntnfs.SpendDetail
+	reorgs  []chan struct{}
+	epochs  []*mockEpochReg
+
+	// canceledConfs is the number of canceled confirmation
+	// registrations.
+	canceledConfs int
+
+	// failSpends is the number of upcoming spend registrations that
+	// fail.
+	failSpends int
+}
+
+func (m *mockNotifier) setFailSpends(n int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.failSpends = n
+}
+
+func (m *mockNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	_ []byte, numConfs, _ uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	event := chainntnfs.NewConfirmationEvent(numConfs, func() {
+		m.mtx.Lock()
+		m.canceledConfs++
+		m.mtx.Unlock()
+	})
+	m.confs = append(m.confs, event)
+
+	return event, nil
+}
+
+func (m *mockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint, _ []byte,
+	_ uint32) (*chainntnfs.SpendEvent, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.failSpends > 0 {
+		m.failSpends--
+		return nil, errUnreachable
+	}
+
+	spend := make(chan *chainntnfs.SpendDetail, 1)
+	m.spends = append(m.spends, spend)
+	reorg := make(chan struct{}, 1)
+	m.reorgs = append(m.reorgs, reorg)
+
+	return &chainntnfs.SpendEvent{
+		Spend:  spend,
+		Reorg:  reorg,
+		Cancel: func() {},
+	}, nil
+}
+
+func (m *mockNotifier) RegisterBlockEpochNtfn(
+	bestBlock *chainntnfs.BlockEpoch) (*chainntnfs.BlockEpochEvent, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	reg := &mockEpochReg{
+		bestBlock: bestBlock,
+		epochs:    make(chan *chainntnfs.BlockEpoch, 20),
+	}
+	m.epochs = append(m.epochs, reg)
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: reg.epochs,
+		Cancel: func() {},
+	}, nil
+}
+
+func (m *mockNotifier) Start() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.started = true
+	return nil
+}
+
+func (m *mockNotifier) Stop() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.stopped = true
+	return nil
+}
+
+// numRegistrations returns the number of confirmation, spend and block
+// epoch registrations.
+func (m *mockNotifier) numRegistrations() (int, int, int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return len(m.confs), len(m.spends), len(m.epochs)
+}
+
+func (m *mockNotifier) confirm(height uint32) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, event := range m.confs {
+		event.Confirmed <- &chainntnfs.TxConfirmation{
+			BlockHeight: height,
+		}
+	}
+}
+
+func (m *mockNotifier) reorg() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, event := range m.confs {
+		event.NegativeConf <- 1
+	}
+}
+
+func (m *mockNotifier) spend(height int32) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, spend := range m.spends {
+		spend <- &chainntnfs.SpendDetail{SpendingHeight: height}
+	}
+}
+
+func (m *mockNotifier) reorgSpend() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, reorg := range m.reorgs {
+		reorg <- struct{}{}
+	}
+}
+
+func (m *mockNotifier) numCanceledConfs() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.canceledConfs
+}
+
+func (m *mockNotifier) connectBlock(height int32, fork byte) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	hash := blockHash(uint32(height), fork)
+	for _, reg := range m.epochs {
+		reg.epochs <- &chainntnfs.BlockEpoch{
+			Height: height,
+			Hash:   &hash,
+		}
+	}
+}
+
+// mockChainView is a filtered chain view whose blocks are triggered by the
+// test.
+type mockChainView struct {
+	chainIO *mockChainIO
+
+	filteredBlocks     chan *chainview.FilteredBlock
+	disconnectedBlocks chan *chainview.FilteredBlock
+
+	mtx           sync.Mutex
+	started       bool
+	stopped       bool
+	filter        map[wire.OutPoint]struct{}
+	updateHeights []uint32
+	blockTxs      map[chainhash.Hash][]*wire.MsgTx
+}
+
+func newMockChainView(chainIO *mockChainIO) *mockChainView {
+	return &mockChainView{
+		chainIO:            chainIO,
+		filteredBlocks:     make(chan *chainview.FilteredBlock, 20),
+		disconnectedBlocks: make(chan *chainview.FilteredBlock, 20),
+		filter:             make(map[wire.OutPoint]struct{}),
+		blockTxs:           make(map[chainhash.Hash][]*wire.MsgTx),
+	}
+}
+
+func (m *mockChainView) FilteredBlocks() <-chan *chainview.FilteredBlock {
+	return m.filteredBlocks
+}
+
+func (m *mockChainView) DisconnectedBlocks() <-chan *chainview.FilteredBlock {
+	return m.disconnectedBlocks
+}
+
+func (m *mockChainView) UpdateFilter(ops []channeldb.EdgePoint,
+	updateHeight uint32) error {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, op := range ops {
+		m.filter[op.OutPoint] = struct{}{}
+	}
+	m.updateHeights = append(m.updateHeights, updateHeight)
+
+	return nil
+}
+
+func (m *mockChainView) FilterBlock(
+	blockHash *chainhash.Hash) (*chainview.FilteredBlock, error) {
+
+	m.chainIO.mtx.Lock()
+	height := -1
+	for h, hash := range m.chainIO.hashes {
+		if hash == *blockHash {
+			height = h
+		}
+	}
+	m.chainIO.mtx.Unlock()
+
+	if height == -1 {
+		return nil, errors.New("block not found")
+	}
+
+	return m.filterBlock(uint32(height), *blockHash), nil
+}
+
+func (m *mockChainView) filterBlock(height uint32,
+	hash chainhash.Hash) *chainview.FilteredBlock {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	block := &chainview.FilteredBlock{Hash: hash, Height: height}
+	for _, tx := range m.blockTxs[hash] {
+		prevOut := tx.TxIn[0].PreviousOutPoint
+		if _, ok := m.filter[prevOut]; !ok {
+			continue
+		}
+
+		delete(m.filter, prevOut)
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block
+}
+
+func (m *mockChainView) Start() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.started = true
+	return nil
+}
+
+func (m *mockChainView) Stop() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.stopped = true
+	return nil
+}
+
+// connectBlock dispatches the block at the given height.
+func (m *mockChainView) connectBlock(height uint32, fork byte) {
+	m.filteredBlocks <- m.filterBlock(height, blockHash(height, fork))
+}
+
+// mockBackend is a test backend consisting of mock subsystems.
+type mockBackend struct {
+	*Backend
+
+	chainIO  *mockChainIO
+	notifier *mockNotifier
+	view     *mockChainView
+
+	mtx       sync.Mutex
+	closed    bool
+	published []*wire.MsgTx
+}
+
+func newMockBackend(name string, hashes []chainhash.Hash) *mockBackend {
+	chainIO := &mockChainIO{hashes: hashes}
+	m := &mockBackend{
+		chainIO:  chainIO,
+		notifier: &mockNotifier{},
+		view:     newMockChainView(chainIO),
+	}
+	m.Backend = &Backend{
+		Name:         name,
+		ChainIO:      chainIO,
+		FeeEstimator: lnwallet.StaticFeeEstimator{FeePerKW: 250},
+		Connect: func() (*Connection, error) {
+			return &Connection{
+				Notifier:  m.notifier,
+				ChainView: m.view,
+				Close: func() {
+					m.mtx.Lock()
+					m.closed = true
+					m.mtx.Unlock()
+				},
+			}, nil
+		},
+		PublishTx: func(tx *wire.MsgTx) error {
+			if _, _, err := chainIO.GetBestBlock(); err != nil {
+				return err
+			}
+
+			m.mtx.Lock()
+			m.published = append(m.published, tx)
+			m.mtx.Unlock()
+
+			return nil
+		},
+	}
+
+	return m
+}
+
+func (m *mockBackend) isClosed() bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.closed
+}
+
+func (m *mockBackend) numPublished() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return len(m.published)
+}
+
+// newTestFailover creates a failover across the given backends.
+func newTestFailover(t *testing.T, backends ...*mockBackend) *Failover {
+	t.Helper()
+
+	cfg := &Config{
+		CheckInterval: time.Second,
+		MaxTipLag:     3,
+	}
+	for _, backend := range backends {
+		cfg.Backends = append(cfg.Backends, backend.Backend)
+	}
+
+	f, err := New(cfg)
+	if err != nil {
+		t.Fatalf("unable to create failover: %v", err)
+	}
+
+	return f
+}
+
+// assertActive asserts that the given backend is the active one.
+func assertActive(t *testing.T, f *Failover, backend *mockBackend) {
+	t.Helper()
+
+	if active := f.activeBackend(); active != backend.Backend {
+		t.Fatalf("expected backend %v to be active, got %v",
+			backend.Name, active.Name)
+	}
+}
+
+// assertNoEvent asserts that nothing is received from the given channel.
+func assertNoEvent(t *testing.T, recv func() bool) {
+	t.Helper()
+
+	if recv() {
+		t.Fatalf("unexpected event")
+	}
+}
+
+// TestFailoverHealth asserts that we fail over to the next healthy backend
+// once the active one stops responding or lags behind.
+func TestFailoverHealth(t *testing.T) {
+	t.Parallel()
+
+	first := newMockBackend("first", makeChain(11, 0, 0))
+	second := newMockBackend("second", makeChain(11, 0, 0))
+	third := newMockBackend("third", makeChain(11, 0, 0))
+
+	// If the first backend is unreachable on startup, the next one
+	// should be used.
+	first.chainIO.setErr(errUnreachable)
+	f := newTestFailover(t, first, second, third)
+	assertActive(t, f, second)
+	first.chainIO.setErr(nil)
+
+	// As long as the active backend is healthy, we shouldn't switch, even
+	// if a preferred backend is available.
+	f.checkHealth()
+	assertActive(t, f, second)
+
+	// A backend lagging behind within the limit is still healthy.
+	third.chainIO.setChain(makeChain(14, 0, 0))
+	f.checkHealth()
+	assertActive(t, f, second)
+
+	// Once it lags behind further, we should switch to the first healthy
+	// backend in order of preference.
+	first.chainIO.setChain(makeChain(15, 0, 0))
+	third.chainIO.setChain(makeChain(15, 0, 0))
+	f.checkHealth()
+	assertActive(t, f, first)
+	if !second.isClosed() {
+		t.Fatalf("connection to previous backend not closed")
+	}
+
+	// If none of the other backends is healthy, we should stay with the
+	// active one.
+	first.chainIO.setErr(errUnreachable)
+	second.chainIO.setErr(errUnreachable)
+	third.chainIO.setErr(errUnreachable)
+	f.checkHealth()
+	assertActive(t, f, first)
+
+	// If all backends are unreachable, the failover can't be created.
+	_, err := New(&Config{
+		Backends: []*Backend{first.Backend, second.Backend},
+	})
+	if err != ErrNoHealthyBackend {
+		t.Fatalf("expected ErrNoHealthyBackend, got %v", err)
+	}
+}
+
+// TestFailoverPublish asserts that transactions are published through the
+// active backend.
+func TestFailoverPublish(t *testing.T) {
+	t.Parallel()
+
+	first := newMockBackend("first", makeChain(11, 0, 0))
+	second := newMockBackend("second", makeChain(11, 0, 0))
+	f := newTestFailover(t, first, second)
+
+	if err := f.PublishTransaction(&wire.MsgTx{}); err != nil {
+		t.Fatalf("unable to publish tx: %v", err)
+	}
+	if first.numPublished() != 1 || second.numPublished() != 0 {
+		t.Fatalf("tx not published through active backend")
+	}
+
+	// Once the first backend becomes unreachable, transactions should be
+	// published through the second one.
+	first.chainIO.setErr(errUnreachable)
+	f.checkHealth()
+	assertActive(t, f, second)
+
+	if err := f.PublishTransaction(&wire.MsgTx{}); err != nil {
+		t.Fatalf("unable to publish tx: %v", err)
+	}
+	if first.numPublished() != 1 || second.numPublished() != 1 {
+		t.Fatalf("tx not published through new backend")
+	}
+}
+
+// TestFailoverNotifier asserts that notifications are registered with the new
+// backend after a switch, without dispatching notifications twice.
+func TestFailoverNotifier(t *testing.T) {
+	t.Parallel()
+
+	first := newMockBackend("first", makeChain(11, 0, 0))
+	second := newMockBackend("second", makeChain(11, 0, 0))
+	f := newTestFailover(t, first, second)
+
+	notifier := f.Notifier()
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+	defer notifier.Stop()
+
+	var txid chainhash.Hash
+	confEvent, err := notifier.RegisterConfirmationsNtfn(&txid, nil, 1, 5)
+	if err != nil {
+		t.Fatalf("unable to register confirmation: %v", err)
+	}
+	spendEvent, err := notifier.RegisterSpendNtfn(&wire.OutPoint{}, nil, 5)
+	if err != nil {
+		t.Fatalf("unable to register spend: %v", err)
+	}
+	epochEvent, err := notifier.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		t.Fatalf("unable to register block epochs: %v", err)
+	}
+
+	recvConf := func() *chainntnfs.TxConfirmation {
+		select {
+		case conf := <-confEvent.Confirmed:
+			return conf
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		}
+	}
+	recvEpoch := func() *chainntnfs.BlockEpoch {
+		select {
+		case epoch := <-epochEvent.Epochs:
+			return epoch
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		}
+	}
+	recvSpend := func() *chainntnfs.SpendDetail {
+		select {
+		case spend := <-spendEvent.Spend:
+			return spend
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		}
+	}
+
+	first.notifier.confirm(8)
+	if conf := recvConf(); conf == nil || conf.BlockHeight != 8 {
+		t.Fatalf("expected confirmation at height 8, got %v", conf)
+	}
+	first.notifier.connectBlock(9, 0)
+	first.notifier.connectBlock(10, 0)
+	for height := int32(9); height <= 10; height++ {
+		epoch := recvEpoch()
+		if epoch == nil || epoch.Height != height {
+			t.Fatalf("expected block at height %d, got %v", height,
+				epoch)
+		}
+	}
+
+	// Once the first backend becomes unreachable, all notifications
+	// should be registered with the second one.
+	first.chainIO.setErr(errUnreachable)
+	f.checkHealth()
+	assertActive(t, f, second)
+
+	if !second.notifier.started {
+		t.Fatalf("notifier of new backend not started")
+	}
+	confs, spends, epochs := second.notifier.numRegistrations()
+	if confs != 1 || spends != 1 || epochs != 1 {
+		t.Fatalf("expected 1 registration each, got %d confirmation, "+
+			"%d spend and %d block registrations", confs, spends,
+			epochs)
+	}
+	bestBlock := second.notifier.epochs[0].bestBlock
+	if bestBlock == nil || bestBlock.Height != 10 {
+		t.Fatalf("block epochs not registered from last delivered "+
+			"block: %v", bestBlock)
+	}
+
+	// The new backend will dispatch the confirmation and the last block
+	// again, which must not reach the clients.
+	second.notifier.confirm(8)
+	assertNoEvent(t, func() bool { return recvConf() != nil })
+	second.notifier.connectBlock(10, 0)
+	second.notifier.connectBlock(11, 0)
+	if epoch := recvEpoch(); epoch == nil || epoch.Height != 11 {
+		t.Fatalf("expected block at height 11, got %v", epoch)
+	}
+	assertNoEvent(t, func() bool { return recvEpoch() != nil })
+
+	// A reorg and a new confirmation should be dispatched as usual.
+	second.notifier.reorg()
+	select {
+	case <-confEvent.NegativeConf:
+	case <-time.After(time.Second):
+		t.Fatalf("negative confirmation not received")
+	}
+	second.notifier.confirm(11)
+	if conf := recvConf(); conf == nil || conf.BlockHeight != 11 {
+		t.Fatalf("expected confirmation at height 11, got %v", conf)
+	}
+
+	// The spend is only dispatched by the new backend.
+	second.notifier.spend(11)
+	if spend := recvSpend(); spend == nil || spend.SpendingHeight != 11 {
+		t.Fatalf("expected spend at height 11, got %v", spend)
+	}
+
+	// Notifications dispatched by the previous backend after the switch
+	// must be ignored.
+	first.notifier.confirm(12)
+	first.notifier.connectBlock(12, 0)
+	assertNoEvent(t, func() bool { return recvConf() != nil })
+	assertNoEvent(t, func() bool { return recvEpoch() != nil })
+}
+
+// TestFailoverNotifierCancelAndSpendReorg asserts that canceled confirmation
+// notifications are canceled at the backend and not registered again after a
+// switch, and that reorgs of spends are forwarded.
+func TestFailoverNotifierCancelAndSpendReorg(t *testing.T) {
+	t.Parallel()
+
+	first := newMockBackend("first", makeChain(11, 0, 0))
+	second := newMockBackend("second", makeChain(11, 0, 0))
+	f := newTestFailover(t, first, second)
+
+	notifier := f.Notifier()
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+	defer notifier.Stop()
+
+	var txid chainhash.Hash
+	confEvent, err := notifier.RegisterConfirmationsNtfn(&txid, nil, 1, 5)
+	if err != nil {
+		t.Fatalf("unable to register confirmation: %v", err)
+	}
+	spendEvent, err := notifier.RegisterSpendNtfn(&wire.OutPoint{}, nil, 5)
+	if err != nil {
+		t.Fatalf("unable to register spend: %v", err)
+	}
+
+	recvSpend := func() *chainntnfs.SpendDetail {
+		select {
+		case spend := <-spendEvent.Spend:
+			return spend
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		}
+	}
+	recvReorg := func() bool {
+		select {
+		case <-spendEvent.Reorg:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}
+
+	// Canceling the confirmation notification must cancel it at the
+	// backend, after which no confirmation reaches the client.
+	confEvent.Cancel()
+	if canceled := first.notifier.numCanceledConfs(); canceled != 1 {
+		t.Fatalf("expected 1 canceled registration, got %d", canceled)
+	}
+	first.notifier.confirm(8)
+	assertNoEvent(t, func() bool {
+		select {
+		case <-confEvent.Confirmed:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	})
+
+	// The spend is registered again after a switch, but as it was
+	// already delivered, the new backend dispatching it again must not
+	// reach the client.
+	first.notifier.spend(10)
+	if spend := recvSpend(); spend == nil || spend.SpendingHeight != 10 {
+		t.Fatalf("expected spend at height 10, got %v", spend)
+	}
+
+	first.chainIO.setErr(errUnreachable)
+	f.checkHealth()
+	assertActive(t, f, second)
+
+	confs, spends, _ := second.notifier.numRegistrations()
+	if confs != 0 || spends != 1 {
+		t.Fatalf("expected only a spend registration, got %d "+
+			"confirmation and %d spend registrations", confs,
+			spends)
+	}
+	second.notifier.spend(10)
+	assertNoEvent(t, func() bool { return recvSpend() != nil })
+
+	// A reorg of the spend must be forwarded, after which the
+	// notification ends.
+	second.notifier.reorgSpend()
+	if !recvReorg() {
+		t.Fatalf("spend reorg not received")
+	}
+
+	notifier.mtx.Lock()
+	numSpendClients := len(notifier.spendClients)
+	notifier.mtx.Unlock()
+	if numSpendClients != 0 {
+		t.Fatalf("expected spend client to be removed")
+	}
+}
+
+// TestFailoverNotifierRegistrationFailure asserts that failed registrations
+// with a new backend are retried, and that the switch is aborted if they keep
+// failing, such that no notification is dropped.
+func TestFailoverNotifierRegistrationFailure(t *testing.T) {
+	t.Parallel()
+
+	first := newMockBackend("first", makeChain(11, 0, 0))
+	second := newMockBackend("second", makeChain(11, 0, 0))
+	third := newMockBackend("third", makeChain(11, 0, 0))
+	f := newTestFailover(t, first, second, third)
+
+	notifier := f.Notifier()
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+	defer notifier.Stop()
+
+	spendEvent, err := notifier.RegisterSpendNtfn(&wire.OutPoint{}, nil, 5)
+	if err != nil {
+		t.Fatalf("unable to register spend: %v", err)
+	}
+
+	// The second backend keeps failing to register the spend, so we
+	// should skip it and switch to the third one instead.
+	second.notifier.setFailSpends(maxRegistrationAttempts)
+	third.notifier.setFailSpends(maxRegistrationAttempts - 1)
+
+	first.chainIO.setErr(errUnreachable)
+	f.checkHealth()
+	assertActive(t, f, third)
+
+	if !second.notifier.stopped || !second.isClosed() {
+		t.Fatalf("subsystems of aborted backend not stopped")
+	}
+	if !first.notifier.stopped || !first.isClosed() {
+		t.Fatalf("subsystems of previous backend not stopped")
+	}
+
+	// The spend should be dispatched by the third backend, as its
+	// registration succeeded on the last attempt.
+	third.notifier.spend(11)
+	select {
+	case spend := <-spendEvent.Spend:
+		if spend.SpendingHeight != 11 {
+			t.Fatalf("expected spend at height 11, got %v",
+				spend.SpendingHeight)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("spend not received")
+	}
+
+	// If none of the backends accepts the registration, then we'll stay
+	// with the active one, which keeps dispatching notifications.
+	spendEvent, err = notifier.RegisterSpendNtfn(&wire.OutPoint{}, nil, 5)
+	if err != nil {
+		t.Fatalf("unable to register spend: %v", err)
+	}
+
+	first.chainIO.setErr(nil)
+	first.notifier.setFailSpends(maxRegistrationAttempts)
+	second.chainIO.setErr(errUnreachable)
+	third.chainIO.setErr(errUnreachable)
+	f.checkHealth()
+	assertActive(t, f, third)
+
+	third.notifier.spend(12)
+	select {
+	case spend := <-spendEvent.Spend:
+		if spend.SpendingHeight != 12 {
+			t.Fatalf("expected spend at height 12, got %v",
+				spend.SpendingHeight)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("spend not received")
+	}
+}
+
+// TestFailoverChainView asserts that the filter is applied to the new backend
+// after a switch, and that blocks are dispatched exactly once, including the
+// ones that were missed or reorged out while switching.
+func TestFailoverChainView(t *testing.T) {
+	t.Parallel()
+
+	// The second backend has seen a reorg of the last two blocks of the
+	// first one, and is one block ahead.
+	first := newMockBackend("first", makeChain(11, 0, 0))
+	second := newMockBackend("second", makeChain(12, 9, 1))
+	f := newTestFailover(t, first, second)
+
+	view := f.ChainView()
+	if err := view.Start(); err != nil {
+		t.Fatalf("unable to start chain view: %v", err)
+	}
+	defer view.Stop()
+
+	spentOp := wire.OutPoint{Index: 1}
+	ops := []channeldb.EdgePoint{
+		{OutPoint: spentOp},
+		{OutPoint: wire.OutPoint{Index: 2}},
+	}
+	if err := view.UpdateFilter(ops, 10); err != nil {
+		t.Fatalf("unable to update filter: %v", err)
+	}
+
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(&wire.TxIn{PreviousOutPoint: spentOp})
+	second.view.blockTxs[blockHash(10, 1)] = []*wire.MsgTx{spendTx}
+
+	recv := func(blocks <-chan *chainview.FilteredBlock) *chainview.FilteredBlock {
+		select {
+		case block := <-blocks:
+			return block
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		}
+	}
+	assertBlock := func(blocks <-chan *chainview.FilteredBlock,
+		height uint32, fork byte, numTxns int) {
+
+		t.Helper()
+
+		block := recv(blocks)
+		switch {
+		case block == nil:
+			t.Fatalf("expected block at height %d", height)
+
+		case block.Hash != blockHash(height, fork):
+			t.Fatalf("expected block %v at height %d, got %v",
+				blockHash(height, fork), height, block.Hash)
+
+		case len(block.Transactions) != numTxns:
+			t.Fatalf("expected %d transactions in block at "+
+				"height %d, got %d", numTxns, height,
+				len(block.Transactions))
+		}
+	}
+
+	first.view.connectBlock(9, 0)
+	first.view.connectBlock(10, 0)
+	assertBlock(view.FilteredBlocks(), 9, 0, 0)
+	assertBlock(view.FilteredBlocks(), 10, 0, 0)
+
+	first.chainIO.setErr(errUnreachable)
+	f.checkHealth()
+	assertActive(t, f, second)
+
+	// The filter should be applied to the new backend without a rescan.
+	second.view.mtx.Lock()
+	numOps := len(second.view.filter)
+	updateHeights := second.view.updateHeights
+	second.view.mtx.Unlock()
+	if numOps != 1 || len(updateHeights) != 1 || updateHeights[0] != 11 {
+		t.Fatalf("unexpected filter update of new backend: %d "+
+			"outpoints at heights %v", numOps, updateHeights)
+	}
+
+	// The blocks of the stale fork should be disconnected, followed by
+	// the blocks of the new chain.
+	assertBlock(view.DisconnectedBlocks(), 10, 0, 0)
+	assertBlock(view.DisconnectedBlocks(), 9, 0, 0)
+	assertBlock(view.FilteredBlocks(), 9, 1, 0)
+	assertBlock(view.FilteredBlocks(), 10, 1, 1)
+	assertBlock(view.FilteredBlocks(), 11, 1, 0)
+
+	// Blocks dispatched by the new backend that were already dispatched
+	// should be ignored, while skipped blocks should be filled in.
+	second.chainIO.setChain(makeChain(15, 9, 1))
+	second.view.connectBlock(11, 1)
+	second.view.connectBlock(12, 1)
+	second.view.connectBlock(14, 1)
+	assertBlock(view.FilteredBlocks(), 12, 1, 0)
+	assertBlock(view.FilteredBlocks(), 13, 1, 0)
+	assertBlock(view.FilteredBlocks(), 14, 1, 0)
+	if block := recv(view.FilteredBlocks()); block != nil {
+		t.Fatalf("unexpected block at height %d", block.Height)
+	}
+
+	// Disconnecting the tip should be dispatched as well.
+	second.view.disconnectedBlocks <- &chainview.FilteredBlock{
+		Hash:   blockHash(14, 1),
+		Height: 14,
+	}
+	assertBlock(view.DisconnectedBlocks(), 14, 1, 0)
+}
@@ -0,0 +1,782 @@
+package chainfailover
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// ErrNotifierShuttingDown is returned when registering a notification with a
+// notifier that has been stopped.
+var ErrNotifierShuttingDown = errors.New("chain notifier shutting down")
+
+const (
+	// maxRegistrationAttempts is the number of times we'll try to register
+	// a notification with a new backend before aborting the switch.
+	maxRegistrationAttempts = 3
+
+	// registrationRetryDelay is the time we'll wait before retrying to
+	// register a notification with a new backend.
+	registrationRetryDelay = 100 * time.Millisecond
+)
+
+// confClient is a confirmation notification registered with the failover
+// notifier.
+type confClient struct {
+	// txid is the hash of the watched transaction, or nil if the
+	// notification is keyed on pkScript.
+	txid       *chainhash.Hash
+	pkScript   []byte
+	numConfs   uint32
+	heightHint uint32
+
+	// event is the confirmation event handed to the client.
+	event *chainntnfs.ConfirmationEvent
+
+	// confHeight is the height of the block the transaction confirmed in,
+	// as delivered to the client, or zero if it's unconfirmed.
+	confHeight uint32
+
+	// cancelBackend cancels the notification at the active backend.
+	cancelBackend func()
+
+	// cancel is closed once the client cancels the notification.
+	cancel chan struct{}
+}
+
+// spendClient is a spend notification registered with the failover notifier.
+type spendClient struct {
+	// outpoint is the watched outpoint, or nil if the notification is
+	// keyed on pkScript.
+	outpoint   *wire.OutPoint
+	pkScript   []byte
+	heightHint uint32
+
+	// spend is the channel handed to the client.
+	spend chan *chainntnfs.SpendDetail
+
+	// reorg is the channel handed to the client to report a reorg of the
+	// spend.
+	reorg chan struct{}
+
+	// spendHeight is the height of the block the spending transaction was
+	// included in, as delivered to the client, or zero if the output is
+	// unspent.
+	spendHeight int32
+
+	// cancelBackend cancels the notification at the active backend.
+	cancelBackend func()
+
+	// cancel is closed once the client cancels the notification.
+	cancel chan struct{}
+}
+
+// epochClient is a block epoch notification registered with the failover
+// notifier.
+type epochClient struct {
+	// epochs is the channel handed to the client.
+	epochs chan *chainntnfs.BlockEpoch
+
+	// bestBlock is the last block delivered to the client. It's guarded
+	// by the notifier's mutex, as it's read while switching backends.
+	bestBlock *chainntnfs.BlockEpoch
+
+	// delivered maps the heights of the recently delivered blocks to
+	// their hashes, so blocks that are delivered again by a new backend
+	// can be skipped.
+	delivered map[int32]chainhash.Hash
+
+	// cancelBackend cancels the notification at the active backend.
+	cancelBackend func()
+
+	// cancel is closed once the client cancels the notification.
+	cancel chan struct{}
+}
+
+// ChainNotifier is a chain notifier backed by the active backend of the
+// failover. All registered notifications are forwarded from the notifier of
+// the active backend. After a switch, they are registered again with the new
+// backend, while notifications that were already delivered are suppressed.
+type ChainNotifier struct {
+	// switchMtx is held for writing while switching backends, and for
+	// reading while registering notifications.
+	switchMtx sync.RWMutex
+	started   bool
+	stopped   bool
+	notifier  chainntnfs.ChainNotifier
+
+	// stopForwarders is closed to stop all goroutines forwarding the
+	// notifications of the active backend.
+	stopForwarders chan struct{}
+	forwarders     sync.WaitGroup
+
+	// mtx guards the registered clients.
+	mtx           sync.Mutex
+	clientCounter uint64
+	confClients   map[uint64]*confClient
+	spendClients  map[uint64]*spendClient
+	epochClients  map[uint64]*epochClient
+
+	quit chan struct{}
+}
+
+// Compile-time check to ensure ChainNotifier implements the
+// chainntnfs.ChainNotifier interface.
+var _ chainntnfs.ChainNotifier = (*ChainNotifier)(nil)
+
+// newChainNotifier creates a new failover notifier backed by the given
+// notifier.
+func newChainNotifier(notifier chainntnfs.ChainNotifier) *ChainNotifier {
+	return &ChainNotifier{
+		notifier:       notifier,
+		stopForwarders: make(chan struct{}),
+		confClients:    make(map[uint64]*confClient),
+		spendClients:   make(map[uint64]*spendClient),
+		epochClients:   make(map[uint64]*epochClient),
+		quit:           make(chan struct{}),
+	}
+}
+
+// Start starts the notifier of the active backend.
+//
+// NOTE: This is part of the chainntnfs.ChainNotifier interface.
+func (n *ChainNotifier) Start() error {
+	n.switchMtx.Lock()
+	defer n.switchMtx.Unlock()
+
+	if n.started {
+		return nil
+	}
+	n.started = true
+
+	return n.notifier.Start()
+}
+
+// Stop stops the notifier of the active backend, and closes the channels of
+// all pending notifications.
+//
+// NOTE: This is part of the chainntnfs.ChainNotifier interface.
+func (n *ChainNotifier) Stop() error {
+	n.switchMtx.Lock()
+	defer n.switchMtx.Unlock()
+
+	if n.stopped {
+		return nil
+	}
+	n.stopped = true
+
+	close(n.quit)
+	close(n.stopForwarders)
+	n.forwarders.Wait()
+
+	var err error
+	if n.started {
+		err = n.notifier.Stop()
+	}
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	for _, client := range n.confClients {
+		close(client.event.Confirmed)
+		close(client.event.Updates)
+		close(client.event.NegativeConf)
+	}
+	for _, client := range n.spendClients {
+		close(client.spend)
+	}
+	for _, client := range n.epochClients {
+		close(client.epochs)
+	}
+
+	return err
+}
+
+// RegisterConfirmationsNtfn registers a confirmation notification with the
+// active backend.
+//
+// NOTE: This is part of the chainntnfs.ChainNotifier interface.
+func (n *ChainNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	pkScript []byte, numConfs,
+	heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	n.switchMtx.RLock()
+	defer n.switchMtx.RUnlock()
+
+	if n.stopped {
+		return nil, ErrNotifierShuttingDown
+	}
+
+	// The client ID is assigned once the notification is registered with
+	// the backend, which happens before the event is handed to the
+	// client.
+	var clientID uint64
+	client := &confClient{
+		txid:       txid,
+		pkScript:   pkScript,
+		numConfs:   numConfs,
+		heightHint: heightHint,
+		cancel:     make(chan struct{}),
+	}
+	client.event = chainntnfs.NewConfirmationEvent(numConfs, func() {
+		n.cancelConf(clientID)
+	})
+
+	confEvent, err := n.registerConf(n.notifier, client)
+	if err != nil {
+		return nil, err
+	}
+
+	n.mtx.Lock()
+	n.clientCounter++
+	clientID = n.clientCounter
+	client.cancelBackend = confEvent.Cancel
+	n.confClients[clientID] = client
+	n.mtx.Unlock()
+
+	n.forwarders.Add(1)
+	go n.forwardConfs(client, confEvent, n.stopForwarders)
+
+	return client.event, nil
+}
+
+// registerConf registers the confirmation notification of the client with
+// the given backend notifier. It's up to the caller to forward the returned
+// event to the client.
+func (n *ChainNotifier) registerConf(notifier chainntnfs.ChainNotifier,
+	client *confClient) (*chainntnfs.ConfirmationEvent, error) {
+
+	return notifier.RegisterConfirmationsNtfn(
+		client.txid, client.pkScript, client.numConfs,
+		client.heightHint,
+	)
+}
+
+// forwardConfs forwards the confirmation notifications of a backend to the
+// client. Confirmations that were already delivered are skipped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (n *ChainNotifier) forwardConfs(client *confClient,
+	confEvent *chainntnfs.ConfirmationEvent, stop chan struct{}) {
+
+	defer n.forwarders.Done()
+
+	for {
+		select {
+		case conf, ok := <-confEvent.Confirmed:
+			if !ok {
+				return
+			}
+
+			if client.confHeight != 0 {
+				log.Debugf("Skipping duplicate confirmation of "+
+					"tx %v", client.txid)
+				continue
+			}
+
+			select {
+			case client.event.Confirmed <- conf:
+			case <-client.cancel:
+				return
+			case <-stop:
+				return
+			case <-n.quit:
+				return
+			}
+			client.confHeight = conf.BlockHeight
+
+		case numConfsLeft, ok := <-confEvent.Updates:
+			if !ok {
+				return
+			}
+
+			select {
+			case client.event.Updates <- numConfsLeft:
+			default:
+			}
+
+		case reorgDepth, ok := <-confEvent.NegativeConf:
+			if !ok {
+				return
+			}
+
+			client.confHeight = 0
+			select {
+			case client.event.NegativeConf <- reorgDepth:
+			default:
+			}
+
+		case <-client.cancel:
+			return
+
+		case <-stop:
+			return
+
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// cancelConf cancels the confirmation notification of the given client.
+func (n *ChainNotifier) cancelConf(clientID uint64) {
+	n.mtx.Lock()
+	client, ok := n.confClients[clientID]
+	if !ok {
+		n.mtx.Unlock()
+		return
+	}
+	delete(n.confClients, clientID)
+	close(client.cancel)
+	cancelBackend := client.cancelBackend
+	n.mtx.Unlock()
+
+	cancelBackend()
+}
+
+// RegisterSpendNtfn registers a spend notification with the active backend.
+//
+// NOTE: This is part of the chainntnfs.ChainNotifier interface.
+func (n *ChainNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
+	pkScript []byte, heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	n.switchMtx.RLock()
+	defer n.switchMtx.RUnlock()
+
+	if n.stopped {
+		return nil, ErrNotifierShuttingDown
+	}
+
+	client := &spendClient{
+		outpoint:   outpoint,
+		pkScript:   pkScript,
+		heightHint: heightHint,
+		spend:      make(chan *chainntnfs.SpendDetail, 1),
+		reorg:      make(chan struct{}, 1),
+		cancel:     make(chan struct{}),
+	}
+
+	spendEvent, err := n.registerSpend(n.notifier, client)
+	if err != nil {
+		return nil, err
+	}
+
+	n.mtx.Lock()
+	n.clientCounter++
+	clientID := n.clientCounter
+	client.cancelBackend = spendEvent.Cancel
+	n.spendClients[clientID] = client
+	n.mtx.Unlock()
+
+	n.forwarders.Add(1)
+	go n.forwardSpend(clientID, client, spendEvent, n.stopForwarders)
+
+	return &chainntnfs.SpendEvent{
+		Spend: client.spend,
+		Reorg: client.reorg,
+		Cancel: func() {
+			n.cancelSpend(clientID)
+		},
+	}, nil
+}
+
+// registerSpend registers the spend notification of the client with the
+// given backend notifier. It's up to the caller to forward the returned event
+// to the client.
+func (n *ChainNotifier) registerSpend(notifier chainntnfs.ChainNotifier,
+	client *spendClient) (*chainntnfs.SpendEvent, error) {
+
+	return notifier.RegisterSpendNtfn(
+		client.outpoint, client.pkScript, client.heightHint,
+	)
+}
+
+// forwardSpend forwards the spend notification of a backend to the client,
+// along with a reorg of the spend. A spend that was already delivered is
+// skipped. As a reorg ends the notification, the client is removed
+// afterwards.
+//
+// NOTE: This MUST be run as a goroutine.
+func (n *ChainNotifier) forwardSpend(clientID uint64, client *spendClient,
+	spendEvent *chainntnfs.SpendEvent, stop chan struct{}) {
+
+	defer n.forwarders.Done()
+
+	spendChan := spendEvent.Spend
+	for {
+		select {
+		case spend, ok := <-spendChan:
+			if !ok {
+				return
+			}
+
+			// The backend closes the channel once the spend is
+			// dispatched, so we'll only wait for a reorg from now
+			// on.
+			spendChan = nil
+
+			if client.spendHeight != 0 {
+				log.Debugf("Skipping duplicate spend of %v",
+					client.outpoint)
+				continue
+			}
+
+			select {
+			case client.spend <- spend:
+			case <-client.cancel:
+				return
+			case <-stop:
+				return
+			case <-n.quit:
+				return
+			}
+			client.spendHeight = spend.SpendingHeight
+
+		case <-spendEvent.Reorg:
+			client.spendHeight = 0
+			select {
+			case client.reorg <- struct{}{}:
+			default:
+			}
+
+			n.mtx.Lock()
+			delete(n.spendClients, clientID)
+			n.mtx.Unlock()
+			return
+
+		case <-client.cancel:
+			return
+
+		case <-stop:
+			return
+
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// cancelSpend cancels the spend notification of the given client.
+func (n *ChainNotifier) cancelSpend(clientID uint64) {
+	n.mtx.Lock()
+	client, ok := n.spendClients[clientID]
+	if !ok {
+		n.mtx.Unlock()
+		return
+	}
+	delete(n.spendClients, clientID)
+	close(client.cancel)
+	cancelBackend := client.cancelBackend
+	n.mtx.Unlock()
+
+	cancelBackend()
+}
+
+// RegisterBlockEpochNtfn registers a block epoch notification with the active
+// backend.
+//
+// NOTE: This is part of the chainntnfs.ChainNotifier interface.
+func (n *ChainNotifier) RegisterBlockEpochNtfn(
+	bestBlock *chainntnfs.BlockEpoch) (*chainntnfs.BlockEpochEvent, error) {
+
+	n.switchMtx.RLock()
+	defer n.switchMtx.RUnlock()
+
+	if n.stopped {
+		return nil, ErrNotifierShuttingDown
+	}
+
+	client := &epochClient{
+		epochs:    make(chan *chainntnfs.BlockEpoch, 20),
+		bestBlock: bestBlock,
+		delivered: make(map[int32]chainhash.Hash),
+		cancel:    make(chan struct{}),
+	}
+
+	epochEvent, err := n.registerEpoch(n.notifier, client)
+	if err != nil {
+		return nil, err
+	}
+
+	n.mtx.Lock()
+	n.clientCounter++
+	clientID := n.clientCounter
+	client.cancelBackend = epochEvent.Cancel
+	n.epochClients[clientID] = client
+	n.mtx.Unlock()
+
+	n.forwarders.Add(1)
+	go n.forwardEpochs(client, epochEvent, n.stopForwarders)
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: client.epochs,
+		Cancel: func() {
+			n.cancelEpoch(clientID)
+		},
+	}, nil
+}
+
+// registerEpoch registers the block epoch notification of the client with
+// the given backend notifier, starting after the last block delivered to the
+// client. It's up to the caller to forward the returned event to the client.
+func (n *ChainNotifier) registerEpoch(notifier chainntnfs.ChainNotifier,
+	client *epochClient) (*chainntnfs.BlockEpochEvent, error) {
+
+	n.mtx.Lock()
+	bestBlock := client.bestBlock
+	n.mtx.Unlock()
+
+	return notifier.RegisterBlockEpochNtfn(bestBlock)
+}
+
+// forwardEpochs forwards the blocks of a backend to the client. Blocks that
+// were already delivered are skipped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (n *ChainNotifier) forwardEpochs(client *epochClient,
+	epochEvent *chainntnfs.BlockEpochEvent, stop chan struct{}) {
+
+	defer n.forwarders.Done()
+
+	for {
+		select {
+		case epoch, ok := <-epochEvent.Epochs:
+			if !ok {
+				return
+			}
+
+			hash, ok := client.delivered[epoch.Height]
+			if ok && hash == *epoch.Hash {
+				continue
+			}
+
+			select {
+			case client.epochs <- epoch:
+			case <-client.cancel:
+				return
+			case <-stop:
+				return
+			case <-n.quit:
+				return
+			}
+
+			// Any blocks we've delivered above this one are no
+			// longer part of the main chain.
+			n.mtx.Lock()
+			if client.bestBlock != nil {
+				for height := client.bestBlock.Height; height >
+					epoch.Height; height-- {
+
+					delete(client.delivered, height)
+				}
+			}
+			client.bestBlock = epoch
+			n.mtx.Unlock()
+
+			client.delivered[epoch.Height] = *epoch.Hash
+			delete(client.delivered, epoch.Height-reorgSafetyLimit)
+
+		case <-client.cancel:
+			return
+
+		case <-stop:
+			return
+
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// cancelEpoch cancels the block epoch notification of the given client.
+func (n *ChainNotifier) cancelEpoch(clientID uint64) {
+	n.mtx.Lock()
+	client, ok := n.epochClients[clientID]
+	if !ok {
+		n.mtx.Unlock()
+		return
+	}
+	delete(n.epochClients, clientID)
+	close(client.cancel)
+	cancelBackend := client.cancelBackend
+	n.mtx.Unlock()
+
+	cancelBackend()
+}
+
+// switchNotifier makes the passed notifier the active one, and registers all
+// pending notifications with it. Confirmations and spends that are buried
+// deeper than the reorg safety limit below the given height are dropped. The
+// previously active notifier is returned.
+//
+// All notifications are registered with the new notifier before the previous
+// one stops forwarding, so no notification is lost in between. If any of them
+// can't be registered, then the switch is aborted and the previous notifier
+// stays active. In that case, the caller should stop the new notifier to
+// release the registrations that did succeed.
+//
+// NOTE: The switch mutex MUST be held for writing, and the new notifier MUST
+// already be started if the failover notifier is.
+func (n *ChainNotifier) switchNotifier(notifier chainntnfs.ChainNotifier,
+	bestHeight int32) (chainntnfs.ChainNotifier, error) {
+
+	n.mtx.Lock()
+	confClients := make(map[uint64]*confClient, len(n.confClients))
+	for clientID, client := range n.confClients {
+		confClients[clientID] = client
+	}
+	spendClients := make(map[uint64]*spendClient, len(n.spendClients))
+	for clientID, client := range n.spendClients {
+		spendClients[clientID] = client
+	}
+	epochClients := make(map[uint64]*epochClient, len(n.epochClients))
+	for clientID, client := range n.epochClients {
+		epochClients[clientID] = client
+	}
+	n.mtx.Unlock()
+
+	log.Infof("Registering %d confirmation, %d spend and %d block "+
+		"notifications with new backend", len(confClients),
+		len(spendClients), len(epochClients))
+
+	// We'll register all notifications with the new backend first, while
+	// the previous backend keeps forwarding. Anything delivered in the
+	// meantime will be dispatched again by the new backend, and is
+	// suppressed as a duplicate.
+	confEvents := make(map[uint64]*chainntnfs.ConfirmationEvent)
+	for clientID, client := range confClients {
+		err := retryRegistration(func() error {
+			confEvent, err := n.registerConf(notifier, client)
+			confEvents[clientID] = confEvent
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to register "+
+				"confirmation notification for tx %v: %v",
+				client.txid, err)
+		}
+	}
+	spendEvents := make(map[uint64]*chainntnfs.SpendEvent)
+	for clientID, client := range spendClients {
+		err := retryRegistration(func() error {
+			spendEvent, err := n.registerSpend(notifier, client)
+			spendEvents[clientID] = spendEvent
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to register spend "+
+				"notification for %v: %v", client.outpoint, err)
+		}
+	}
+	epochEvents := make(map[uint64]*chainntnfs.BlockEpochEvent)
+	for clientID, client := range epochClients {
+		err := retryRegistration(func() error {
+			epochEvent, err := n.registerEpoch(notifier, client)
+			epochEvents[clientID] = epochEvent
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to register block "+
+				"notification: %v", err)
+		}
+	}
+
+	// With all notifications registered, we'll wait for the notifications
+	// of the previous backend to be forwarded, so the clients' state
+	// won't change anymore.
+	close(n.stopForwarders)
+	n.forwarders.Wait()
+	n.stopForwarders = make(chan struct{})
+
+	oldNotifier := n.notifier
+	n.notifier = notifier
+
+	// Finally, we'll forward the notifications of the new backend to all
+	// clients that are still registered. Clients that completed or
+	// canceled their notification in the meantime are canceled at the new
+	// backend.
+	var cancels []func()
+	n.mtx.Lock()
+
+	for clientID, client := range confClients {
+		confEvent := confEvents[clientID]
+		if _, ok := n.confClients[clientID]; !ok {
+			cancels = append(cancels, confEvent.Cancel)
+			continue
+		}
+		if client.confHeight != 0 && int32(client.confHeight)+
+			reorgSafetyLimit <= bestHeight {
+
+			delete(n.confClients, clientID)
+			cancels = append(cancels, confEvent.Cancel)
+			continue
+		}
+		client.cancelBackend = confEvent.Cancel
+
+		n.forwarders.Add(1)
+		go n.forwardConfs(client, confEvent, n.stopForwarders)
+	}
+	for clientID, client := range spendClients {
+		spendEvent := spendEvents[clientID]
+		if _, ok := n.spendClients[clientID]; !ok {
+			cancels = append(cancels, spendEvent.Cancel)
+			continue
+		}
+		if client.spendHeight != 0 && client.spendHeight+
+			reorgSafetyLimit <= bestHeight {
+
+			delete(n.spendClients, clientID)
+			cancels = append(cancels, spendEvent.Cancel)
+			continue
+		}
+		client.cancelBackend = spendEvent.Cancel
+
+		n.forwarders.Add(1)
+		go n.forwardSpend(
+			clientID, client, spendEvent, n.stopForwarders,
+		)
+	}
+	for clientID, client := range epochClients {
+		epochEvent := epochEvents[clientID]
+		if _, ok := n.epochClients[clientID]; !ok {
+			cancels = append(cancels, epochEvent.Cancel)
+			continue
+		}
+		client.cancelBackend = epochEvent.Cancel
+
+		n.forwarders.Add(1)
+		go n.forwardEpochs(client, epochEvent, n.stopForwarders)
+	}
+	n.mtx.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+
+	return oldNotifier, nil
+}
+
+// retryRegistration calls register until it succeeds, giving up after
+// maxRegistrationAttempts attempts.
+func retryRegistration(register func() error) error {
+	var err error
+	for i := 0; i < maxRegistrationAttempts; i++ {
+		if i > 0 {
+			time.Sleep(registrationRetryDelay)
+		}
+
+		if err = register(); err == nil {
+			return nil
+		}
+
+		log.Debugf("Registration with new backend failed (attempt "+
+			"%d/%d): %v", i+1, maxRegistrationAttempts, err)
+	}
+
+	return err
+}
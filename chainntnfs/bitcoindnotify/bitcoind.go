@@ -70,6 +70,8 @@ type BitcoindNotifier struct {
 
 	spendNotifications map[wire.OutPoint]map[uint64]*spendNotification
 
+	scriptNotifier *chainntnfs.ScriptNotifier
+
 	txConfNotifier *chainntnfs.TxConfNotifier
 
 	blockEpochClients map[uint64]*blockEpochRegistration
@@ -145,6 +147,16 @@ func (b *BitcoindNotifier) Start() error {
 		uint32(currentHeight), reorgSafetyLimit, b.confirmHintCache,
 	)
 
+	b.scriptNotifier = chainntnfs.NewScriptNotifier(
+		chainntnfs.ScriptNotifierConfig{
+			Fetcher:        chainntnfs.NewChainBlockFetcher(b.chainConn),
+			TxConfNotifier: b.txConfNotifier,
+			ScanResults:    b.notificationRegistry,
+			Quit:           b.quit,
+			Wg:             &b.wg,
+		},
+	)
+
 	b.bestBlock = chainntnfs.BlockEpoch{
 		Height: currentHeight,
 		Hash:   currentHash,
@@ -177,6 +189,7 @@ func (b *BitcoindNotifier) Stop() error {
 			close(spendClient.spendChan)
 		}
 	}
+	b.scriptNotifier.TearDown()
 	for _, epochClient := range b.blockEpochClients {
 		close(epochClient.cancelChan)
 		epochClient.wg.Wait()
@@ -208,6 +221,10 @@ out:
 					"notification for out_point=%v, "+
 					"spend_id=%v", msg.op, msg.spendID)
 
+				// The spend may have been dispatched already, so
+				// we'll stop watching it for reorgs.
+				b.txConfNotifier.CancelSpendReorg(msg.spendID)
+
 				// Before we attempt to close the spendChan,
 				// ensure that the notification hasn't already
 				// yet been dispatched.
@@ -216,6 +233,9 @@ out:
 					delete(b.spendNotifications[msg.op], msg.spendID)
 				}
 
+			case *chainntnfs.ScriptSpendCancel:
+				b.scriptNotifier.CancelSpend(msg.SpendID)
+
 			case *epochCancel:
 				chainntnfs.Log.Infof("Cancelling epoch "+
 					"notification, epoch_id=%v", msg.epochID)
@@ -253,13 +273,23 @@ out:
 				}
 				b.spendNotifications[op][msg.spendID] = msg
 
+			case *chainntnfs.ScriptSpendNtfn:
+				b.scriptNotifier.RegisterSpend(
+					msg, uint32(b.bestBlock.Height),
+				)
+
+			case *chainntnfs.ScriptConfNtfn:
+				b.scriptNotifier.RegisterConf(
+					msg, uint32(b.bestBlock.Height),
+				)
+
 			case *confirmationNotification:
+				currentHeight := uint32(b.bestBlock.Height)
+
 				chainntnfs.Log.Infof("New confirmation "+
 					"subscription: txid=%v, numconfs=%v",
 					msg.TxID, msg.NumConfirmations)
 
-				currentHeight := uint32(b.bestBlock.Height)
-
 				// Look up whether the transaction is already
 				// included in the active chain. We'll do this
 				// in a goroutine to prevent blocking
@@ -310,6 +340,11 @@ out:
 
 			case chain.RelevantTx:
 				b.handleRelevantTx(msg, b.bestBlock.Height)
+
+			case *chainntnfs.ScriptScanResult:
+				b.scriptNotifier.HandleScanResult(
+					msg, uint32(b.bestBlock.Height),
+				)
 			}
 
 		case ntfn := <-b.chainConn.Notifications():
@@ -438,6 +473,12 @@ func (b *BitcoindNotifier) handleRelevantTx(tx chain.RelevantTx, bestHeight int3
 				// since the channel is buffered, and the
 				// message can still be read by the receiver.
 				close(ntfn.spendChan)
+
+				b.txConfNotifier.WatchSpendReorg(
+					ntfn.spendID,
+					uint32(spendDetails.SpendingHeight),
+					ntfn.reorgChan,
+				)
 			}
 			delete(b.spendNotifications, prevOut)
 		}
@@ -632,6 +673,12 @@ func (b *BitcoindNotifier) handleBlockConnected(block chainntnfs.BlockEpoch) err
 	chainntnfs.Log.Infof("New block: height=%v, sha=%v", block.Height,
 		block.Hash)
 
+	// Then, we'll check for spends of outputs paying to the scripts of our
+	// script spend notifications.
+	scriptSpends := b.scriptNotifier.ConnectTip(
+		uint32(block.Height), txns,
+	)
+
 	// Finally, we'll update the spend height hint for all of our watched
 	// outpoints that have not been spent yet. This is safe to do as we do
 	// not watch already spent outpoints for spend notifications.
@@ -658,9 +705,12 @@ func (b *BitcoindNotifier) handleBlockConnected(block chainntnfs.BlockEpoch) err
 	// our state is fully updated in time.
 	b.bestBlock = block
 
-	// Lastly we'll notify any subscribed clients of the block.
+	// Lastly we'll notify any subscribed clients of the block, and send
+	// off the details of the script spends.
 	b.notifyBlockEpochs(block.Height, block.Hash)
 
+	b.scriptNotifier.DispatchSpends(scriptSpends)
+
 	return nil
 }
 
@@ -696,6 +746,8 @@ type spendNotification struct {
 
 	spendChan chan *chainntnfs.SpendDetail
 
+	reorgChan chan struct{}
+
 	spendID uint64
 
 	heightHint uint32
@@ -716,9 +768,16 @@ type spendCancel struct {
 // outpoint has been detected, the details of the spending event will be sent
 // across the 'Spend' channel. The heightHint should represent the earliest
 // height in the chain where the transaction could have been spent in.
+//
+// If no outpoint is given, the notification is keyed on pkScript instead, and
+// is triggered by the first spend of any output paying to it.
 func (b *BitcoindNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 	pkScript []byte, heightHint uint32) (*chainntnfs.SpendEvent, error) {
 
+	if outpoint == nil {
+		return b.registerScriptSpendNtfn(pkScript, heightHint)
+	}
+
 	// Before proceeding to register the notification, we'll query our
 	// height hint cache to determine whether a better one exists.
 	if hint, err := b.spendHintCache.QuerySpendHint(*outpoint); err == nil {
@@ -734,6 +793,7 @@ func (b *BitcoindNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 	ntfn := &spendNotification{
 		targetOutpoint: outpoint,
 		spendChan:      make(chan *chainntnfs.SpendDetail, 1),
+		reorgChan:      make(chan struct{}, 1),
 		spendID:        atomic.AddUint64(&b.spendClientCounter, 1),
 	}
 
@@ -846,6 +906,7 @@ func (b *BitcoindNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 
 	return &chainntnfs.SpendEvent{
 		Spend: ntfn.spendChan,
+		Reorg: ntfn.reorgChan,
 		Cancel: func() {
 			cancel := &spendCancel{
 				op:      *outpoint,
@@ -952,8 +1013,16 @@ type confirmationNotification struct {
 // RegisterConfirmationsNtfn registers a notification with BitcoindNotifier
 // which will be triggered once the txid reaches numConfs number of
 // confirmations.
+//
+// If no txid is given, the notification is keyed on pkScript instead, and the
+// first transaction paying to it becomes the target transaction.
 func (b *BitcoindNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
-	_ []byte, numConfs, heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+	pkScript []byte, numConfs,
+	heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	if txid == nil {
+		return b.registerScriptConfNtfn(pkScript, numConfs, heightHint)
+	}
 
 	// Before proceeding to register the notification, we'll query our
 	// height hint cache to determine whether a better one exists.
@@ -972,10 +1041,12 @@ func (b *BitcoindNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 			ConfID:           atomic.AddUint64(&b.confClientCounter, 1),
 			TxID:             txid,
 			NumConfirmations: numConfs,
-			Event:            chainntnfs.NewConfirmationEvent(numConfs),
 		},
 		heightHint: heightHint,
 	}
+	ntfn.Event = chainntnfs.NewConfirmationEvent(numConfs, func() {
+		b.txConfNotifier.CancelConf(&ntfn.ConfNtfn)
+	})
 
 	if err := b.txConfNotifier.Register(&ntfn.ConfNtfn); err != nil {
 		return nil, err
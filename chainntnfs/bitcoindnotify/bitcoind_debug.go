@@ -34,6 +34,16 @@ func (b *BitcoindNotifier) UnsafeStart(bestHeight int32, bestHash *chainhash.Has
 		uint32(bestHeight), reorgSafetyLimit, b.confirmHintCache,
 	)
 
+	b.scriptNotifier = chainntnfs.NewScriptNotifier(
+		chainntnfs.ScriptNotifierConfig{
+			Fetcher:        chainntnfs.NewChainBlockFetcher(b.chainConn),
+			TxConfNotifier: b.txConfNotifier,
+			ScanResults:    b.notificationRegistry,
+			Quit:           b.quit,
+			Wg:             &b.wg,
+		},
+	)
+
 	if generateBlocks != nil {
 		// Ensure no block notifications are pending when we start the
 		// notification dispatcher goroutine.
@@ -75,6 +75,8 @@ type BtcdNotifier struct {
 
 	spendNotifications map[wire.OutPoint]map[uint64]*spendNotification
 
+	scriptNotifier *chainntnfs.ScriptNotifier
+
 	txConfNotifier *chainntnfs.TxConfNotifier
 
 	blockEpochClients map[uint64]*blockEpochRegistration
@@ -169,6 +171,16 @@ func (b *BtcdNotifier) Start() error {
 		uint32(currentHeight), reorgSafetyLimit, b.confirmHintCache,
 	)
 
+	b.scriptNotifier = chainntnfs.NewScriptNotifier(
+		chainntnfs.ScriptNotifierConfig{
+			Fetcher:        chainntnfs.NewChainBlockFetcher(b.chainConn),
+			TxConfNotifier: b.txConfNotifier,
+			ScanResults:    b.notificationRegistry,
+			Quit:           b.quit,
+			Wg:             &b.wg,
+		},
+	)
+
 	b.bestBlock = chainntnfs.BlockEpoch{
 		Height: currentHeight,
 		Hash:   currentHash,
@@ -207,6 +219,7 @@ func (b *BtcdNotifier) Stop() error {
 			close(spendClient.spendChan)
 		}
 	}
+	b.scriptNotifier.TearDown()
 	for _, epochClient := range b.blockEpochClients {
 		close(epochClient.cancelChan)
 		epochClient.wg.Wait()
@@ -279,6 +292,10 @@ out:
 					"notification for out_point=%v, "+
 					"spend_id=%v", msg.op, msg.spendID)
 
+				// The spend may have been dispatched already, so
+				// we'll stop watching it for reorgs.
+				b.txConfNotifier.CancelSpendReorg(msg.spendID)
+
 				// Before we attempt to close the spendChan,
 				// ensure that the notification hasn't already
 				// yet been dispatched.
@@ -287,6 +304,9 @@ out:
 					delete(b.spendNotifications[msg.op], msg.spendID)
 				}
 
+			case *chainntnfs.ScriptSpendCancel:
+				b.scriptNotifier.CancelSpend(msg.SpendID)
+
 			case *epochCancel:
 				chainntnfs.Log.Infof("Cancelling epoch "+
 					"notification, epoch_id=%v", msg.epochID)
@@ -323,13 +343,23 @@ out:
 				}
 				b.spendNotifications[op][msg.spendID] = msg
 
+			case *chainntnfs.ScriptSpendNtfn:
+				b.scriptNotifier.RegisterSpend(
+					msg, uint32(b.bestBlock.Height),
+				)
+
+			case *chainntnfs.ScriptConfNtfn:
+				b.scriptNotifier.RegisterConf(
+					msg, uint32(b.bestBlock.Height),
+				)
+
 			case *confirmationNotification:
+				bestHeight := uint32(b.bestBlock.Height)
+
 				chainntnfs.Log.Infof("New confirmation "+
 					"subscription: txid=%v, numconfs=%v",
 					msg.TxID, msg.NumConfirmations)
 
-				bestHeight := uint32(b.bestBlock.Height)
-
 				// Look up whether the transaction is already
 				// included in the active chain. We'll do this
 				// in a goroutine to prevent blocking
@@ -378,6 +408,11 @@ out:
 
 				}
 				msg.errorChan <- nil
+
+			case *chainntnfs.ScriptScanResult:
+				b.scriptNotifier.HandleScanResult(
+					msg, uint32(b.bestBlock.Height),
+				)
 			}
 
 		case item := <-b.chainUpdates.ChanOut():
@@ -502,6 +537,12 @@ out:
 						// can still be read by the
 						// receiver.
 						close(ntfn.spendChan)
+
+						b.txConfNotifier.WatchSpendReorg(
+							ntfn.spendID,
+							uint32(spendDetails.SpendingHeight),
+							ntfn.reorgChan,
+						)
 					}
 					delete(b.spendNotifications, prevOut)
 				}
@@ -757,6 +798,12 @@ func (b *BtcdNotifier) handleBlockConnected(epoch chainntnfs.BlockEpoch) error {
 		}
 	}
 
+	// We'll also check for spends of outputs paying to the scripts of our
+	// script spend notifications.
+	scriptSpends := b.scriptNotifier.ConnectTip(
+		newBlock.height, newBlock.txns,
+	)
+
 	// Finally, we'll update the spend height hint for all of our watched
 	// outpoints that have not been spent yet. This is safe to do as we do
 	// not watch already spent outpoints for spend notifications.
@@ -800,8 +847,13 @@ func (b *BtcdNotifier) handleBlockConnected(epoch chainntnfs.BlockEpoch) error {
 			// since the channel is buffered, and the
 			// message can still be read by the receiver.
 			close(ntfn.spendChan)
+
+			b.txConfNotifier.WatchSpendReorg(
+				ntfn.spendID, newBlock.height, ntfn.reorgChan,
+			)
 		}
 	}
+	b.scriptNotifier.DispatchSpends(scriptSpends)
 
 	return nil
 }
@@ -838,6 +890,8 @@ type spendNotification struct {
 
 	spendChan chan *chainntnfs.SpendDetail
 
+	reorgChan chan struct{}
+
 	spendID uint64
 
 	heightHint uint32
@@ -858,9 +912,16 @@ type spendCancel struct {
 // outpoint has been detected, the details of the spending event will be sent
 // across the 'Spend' channel. The heightHint should represent the earliest
 // height in the chain where the transaction could have been spent in.
+//
+// If no outpoint is given, the notification is keyed on pkScript instead, and
+// is triggered by the first spend of any output paying to it.
 func (b *BtcdNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 	pkScript []byte, heightHint uint32) (*chainntnfs.SpendEvent, error) {
 
+	if outpoint == nil {
+		return b.registerScriptSpendNtfn(pkScript, heightHint)
+	}
+
 	// Before proceeding to register the notification, we'll query our
 	// height hint cache to determine whether a better one exists.
 	if hint, err := b.spendHintCache.QuerySpendHint(*outpoint); err == nil {
@@ -876,6 +937,7 @@ func (b *BtcdNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 	ntfn := &spendNotification{
 		targetOutpoint: outpoint,
 		spendChan:      make(chan *chainntnfs.SpendDetail, 1),
+		reorgChan:      make(chan struct{}, 1),
 		spendID:        atomic.AddUint64(&b.spendClientCounter, 1),
 		heightHint:     heightHint,
 	}
@@ -975,6 +1037,7 @@ func (b *BtcdNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 
 	return &chainntnfs.SpendEvent{
 		Spend: ntfn.spendChan,
+		Reorg: ntfn.reorgChan,
 		Cancel: func() {
 			cancel := &spendCancel{
 				op:      *outpoint,
@@ -1012,8 +1075,16 @@ type confirmationNotification struct {
 // RegisterConfirmationsNtfn registers a notification with BtcdNotifier
 // which will be triggered once the txid reaches numConfs number of
 // confirmations.
-func (b *BtcdNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash, _ []byte,
-	numConfs, heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+//
+// If no txid is given, the notification is keyed on pkScript instead, and the
+// first transaction paying to it becomes the target transaction.
+func (b *BtcdNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	pkScript []byte, numConfs,
+	heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	if txid == nil {
+		return b.registerScriptConfNtfn(pkScript, numConfs, heightHint)
+	}
 
 	// Before proceeding to register the notification, we'll query our
 	// height hint cache to determine whether a better one exists.
@@ -1032,10 +1103,12 @@ func (b *BtcdNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash, _ []byte,
 			ConfID:           atomic.AddUint64(&b.confClientCounter, 1),
 			TxID:             txid,
 			NumConfirmations: numConfs,
-			Event:            chainntnfs.NewConfirmationEvent(numConfs),
 		},
 		heightHint: heightHint,
 	}
+	ntfn.Event = chainntnfs.NewConfirmationEvent(numConfs, func() {
+		b.txConfNotifier.CancelConf(&ntfn.ConfNtfn)
+	})
 
 	if err := b.txConfNotifier.Register(&ntfn.ConfNtfn); err != nil {
 		return nil, err
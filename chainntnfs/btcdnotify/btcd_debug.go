@@ -33,6 +33,16 @@ func (b *BtcdNotifier) UnsafeStart(bestHeight int32, bestHash *chainhash.Hash,
 		uint32(bestHeight), reorgSafetyLimit, b.confirmHintCache,
 	)
 
+	b.scriptNotifier = chainntnfs.NewScriptNotifier(
+		chainntnfs.ScriptNotifierConfig{
+			Fetcher:        chainntnfs.NewChainBlockFetcher(b.chainConn),
+			TxConfNotifier: b.txConfNotifier,
+			ScanResults:    b.notificationRegistry,
+			Quit:           b.quit,
+			Wg:             &b.wg,
+		},
+	)
+
 	b.chainUpdates.Start()
 	b.txUpdates.Start()
 
@@ -0,0 +1,56 @@
+package btcdnotify
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// registerScriptSpendNtfn registers an intent to be notified once any output
+// paying to the given script, created at or after the height hint, is spent.
+func (b *BtcdNotifier) registerScriptSpendNtfn(pkScript []byte,
+	heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	if len(pkScript) == 0 {
+		return nil, errors.New("either an outpoint or a pkScript " +
+			"must be provided")
+	}
+
+	ntfn := chainntnfs.NewScriptSpendNtfn(
+		atomic.AddUint64(&b.spendClientCounter, 1), pkScript,
+		heightHint, b.notificationCancels, b.quit,
+	)
+
+	select {
+	case <-b.quit:
+		return nil, ErrChainNotifierShuttingDown
+	case b.notificationRegistry <- ntfn:
+	}
+
+	return ntfn.Event, nil
+}
+
+// registerScriptConfNtfn registers an intent to be notified once the first
+// transaction paying to the given script, included at or after the height
+// hint, reaches numConfs confirmations.
+func (b *BtcdNotifier) registerScriptConfNtfn(pkScript []byte, numConfs,
+	heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	if len(pkScript) == 0 {
+		return nil, errors.New("either a txid or a pkScript must be " +
+			"provided")
+	}
+
+	ntfn := chainntnfs.NewScriptConfNtfn(
+		atomic.AddUint64(&b.confClientCounter, 1), pkScript, numConfs,
+		heightHint, b.txConfNotifier,
+	)
+
+	select {
+	case b.notificationRegistry <- ntfn:
+		return ntfn.Event, nil
+	case <-b.quit:
+		return nil, ErrChainNotifierShuttingDown
+	}
+}
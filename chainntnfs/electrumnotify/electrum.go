@@ -0,0 +1,1107 @@
+package electrumnotify
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/electrum"
+)
+
+const (
+	// notifierType uniquely identifies this concrete implementation of the
+	// ChainNotifier interface.
+	notifierType = "electrum"
+
+	// reorgSafetyLimit is the chain depth beyond which it is assumed a block
+	// will not be reorganized out of the chain. This is used to determine when
+	// to prune old confirmation requests so that reorgs are handled correctly.
+	// The coinbase maturity period is a reasonable value to use.
+	reorgSafetyLimit = 100
+)
+
+var (
+	// ErrChainNotifierShuttingDown is used when we are trying to
+	// measure a spend notification when notifier is already stopped.
+	ErrChainNotifierShuttingDown = errors.New("chainntnfs: system interrupt " +
+		"while attempting to register for spend notification.")
+
+	// ErrPkScriptRequired is returned when a notification is registered
+	// without a pkScript. As Electrum servers index transactions by their
+	// scripts, the script is required to watch the chain.
+	ErrPkScriptRequired = errors.New("electrum notifier requires the " +
+		"pkScript of the watched output")
+)
+
+// ElectrumNotifier is a version of ChainNotifier that's backed by an Electrum
+// server. As the server only indexes transactions by the scripts they pay to
+// or spend from, the notifier watches the histories of the scripts of all
+// registered transactions and outpoints, and queries them whenever a new
+// block is connected.
+type ElectrumNotifier struct {
+	confClientCounter  uint64 // To be used atomically.
+	spendClientCounter uint64 // To be used atomically.
+	epochClientCounter uint64 // To be used atomically.
+
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	heightMtx sync.RWMutex
+	bestBlock chainntnfs.BlockEpoch
+
+	// blockHashes are the hashes of the recently connected blocks, which
+	// are used to detect reorgs.
+	blockHashes map[int32]chainhash.Hash
+
+	client    *electrum.Client
+	headerSub *electrum.HeaderSubscription
+
+	notificationCancels  chan interface{}
+	notificationRegistry chan interface{}
+
+	// confWatches maps the transactions we're watching for confirmations
+	// to their pkScripts.
+	confWatches map[chainhash.Hash][]byte
+
+	spendNotifications map[wire.OutPoint]map[uint64]*spendNotification
+
+	scriptNotifier *chainntnfs.ScriptNotifier
+
+	txConfNotifier *chainntnfs.TxConfNotifier
+
+	blockEpochClients map[uint64]*blockEpochRegistration
+
+	// spendHintCache is a cache used to query and update the latest height
+	// hints for an outpoint. Each height hint represents the earliest
+	// height at which the outpoint could have been spent within the chain.
+	spendHintCache chainntnfs.SpendHintCache
+
+	// confirmHintCache is a cache used to query the latest height hints for
+	// a transaction. Each height hint represents the earliest height at
+	// which the transaction could have confirmed within the chain.
+	confirmHintCache chainntnfs.ConfirmHintCache
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// Ensure ElectrumNotifier implements the ChainNotifier interface at compile
+// time.
+var _ chainntnfs.ChainNotifier = (*ElectrumNotifier)(nil)
+
+// New creates a new instance of the ElectrumNotifier concrete implementation
+// of the ChainNotifier interface.
+//
+// NOTE: The passed client should already be running before being passed into
+// this function.
+func New(client *electrum.Client, spendHintCache chainntnfs.SpendHintCache,
+	confirmHintCache chainntnfs.ConfirmHintCache) (*ElectrumNotifier, error) {
+
+	notifier := &ElectrumNotifier{
+		notificationCancels:  make(chan interface{}),
+		notificationRegistry: make(chan interface{}),
+
+		blockHashes: make(map[int32]chainhash.Hash),
+
+		blockEpochClients: make(map[uint64]*blockEpochRegistration),
+
+		confWatches: make(map[chainhash.Hash][]byte),
+
+		spendNotifications: make(map[wire.OutPoint]map[uint64]*spendNotification),
+
+		client: client,
+
+		spendHintCache:   spendHintCache,
+		confirmHintCache: confirmHintCache,
+
+		quit: make(chan struct{}),
+	}
+
+	return notifier, nil
+}
+
+// Start subscribes to new blocks of the server and launches the goroutine
+// dispatching notifications.
+func (n *ElectrumNotifier) Start() error {
+	// Already started?
+	if atomic.AddInt32(&n.started, 1) != 1 {
+		return nil
+	}
+
+	// We'll subscribe to new blocks before fetching our starting point,
+	// so we won't miss any block connected in between.
+	n.headerSub = n.client.SubscribeHeaders()
+
+	bestHash, bestHeight, err := n.client.GetBestBlock()
+	if err != nil {
+		n.headerSub.Cancel()
+		return err
+	}
+	n.bestBlock = chainntnfs.BlockEpoch{
+		Height: bestHeight,
+		Hash:   bestHash,
+	}
+	n.blockHashes[bestHeight] = *bestHash
+
+	n.txConfNotifier = chainntnfs.NewTxConfNotifier(
+		uint32(bestHeight), reorgSafetyLimit, n.confirmHintCache,
+	)
+
+	n.scriptNotifier = chainntnfs.NewScriptNotifier(
+		chainntnfs.ScriptNotifierConfig{
+			Fetcher:        n,
+			TxConfNotifier: n.txConfNotifier,
+			ScanResults:    n.notificationRegistry,
+			Quit:           n.quit,
+			Wg:             &n.wg,
+		},
+	)
+
+	n.wg.Add(1)
+	go n.notificationDispatcher()
+
+	return nil
+}
+
+// Stop shuts down the ElectrumNotifier.
+func (n *ElectrumNotifier) Stop() error {
+	// Already shutting down?
+	if atomic.AddInt32(&n.stopped, 1) != 1 {
+		return nil
+	}
+
+	close(n.quit)
+	n.wg.Wait()
+
+	n.headerSub.Cancel()
+
+	// Notify all pending clients of our shutdown by closing the related
+	// notification channels.
+	for _, spendClients := range n.spendNotifications {
+		for _, spendClient := range spendClients {
+			close(spendClient.spendChan)
+		}
+	}
+	n.scriptNotifier.TearDown()
+	for _, epochClient := range n.blockEpochClients {
+		close(epochClient.cancelChan)
+		epochClient.wg.Wait()
+
+		close(epochClient.epochChan)
+	}
+	n.txConfNotifier.TearDown()
+
+	return nil
+}
+
+// historicalSpend is sent to the dispatcher when a historical spend of a
+// watched outpoint has been found.
+type historicalSpend struct {
+	details *chainntnfs.SpendDetail
+}
+
+// notificationDispatcher is the primary goroutine which handles client
+// notification registrations, as well as notification dispatches.
+func (n *ElectrumNotifier) notificationDispatcher() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case cancelMsg := <-n.notificationCancels:
+			switch msg := cancelMsg.(type) {
+			case *spendCancel:
+				chainntnfs.Log.Infof("Cancelling spend "+
+					"notification for out_point=%v, "+
+					"spend_id=%v", msg.op, msg.spendID)
+
+				// The spend may have been dispatched already, so
+				// we'll stop watching it for reorgs.
+				n.txConfNotifier.CancelSpendReorg(msg.spendID)
+
+				// Before we attempt to close the spendChan,
+				// ensure that the notification hasn't already
+				// yet been dispatched.
+				outPointClients, ok := n.spendNotifications[msg.op]
+				if !ok {
+					continue
+				}
+				ntfn, ok := outPointClients[msg.spendID]
+				if !ok {
+					continue
+				}
+				close(ntfn.spendChan)
+				delete(outPointClients, msg.spendID)
+				if len(outPointClients) == 0 {
+					delete(n.spendNotifications, msg.op)
+				}
+
+			case *chainntnfs.ScriptSpendCancel:
+				n.scriptNotifier.CancelSpend(msg.SpendID)
+
+			case *epochCancel:
+				chainntnfs.Log.Infof("Cancelling epoch "+
+					"notification, epoch_id=%v", msg.epochID)
+
+				// First, we'll lookup the original
+				// registration in order to stop the active
+				// queue goroutine.
+				reg := n.blockEpochClients[msg.epochID]
+				reg.epochQueue.Stop()
+
+				// Next, close the cancel channel for this
+				// specific client, and wait for the client to
+				// exit.
+				close(reg.cancelChan)
+				reg.wg.Wait()
+
+				// Once the client has exited, we can then
+				// safely close the channel used to send epoch
+				// notifications, in order to notify any
+				// listeners that the intent has been
+				// cancelled.
+				close(reg.epochChan)
+				delete(n.blockEpochClients, msg.epochID)
+			}
+
+		case registerMsg := <-n.notificationRegistry:
+			switch msg := registerMsg.(type) {
+			case *spendNotification:
+				chainntnfs.Log.Infof("New spend subscription: "+
+					"utxo=%v, height_hint=%v",
+					msg.targetOutpoint, msg.heightHint)
+				op := *msg.targetOutpoint
+
+				if _, ok := n.spendNotifications[op]; !ok {
+					n.spendNotifications[op] = make(map[uint64]*spendNotification)
+				}
+				n.spendNotifications[op][msg.spendID] = msg
+
+				// Now that the outpoint is watched within new
+				// blocks, we'll check whether it has already
+				// been spent. We'll do this in a goroutine to
+				// not block the dispatcher on the server.
+				n.heightMtx.RLock()
+				currentHeight := n.bestBlock.Height
+				n.heightMtx.RUnlock()
+
+				n.wg.Add(1)
+				go n.dispatchHistoricalSpend(msg, currentHeight)
+
+			case *historicalSpend:
+				op := *msg.details.SpentOutPoint
+				n.dispatchSpend(op, msg.details)
+
+			case *chainntnfs.ScriptSpendNtfn:
+				n.heightMtx.RLock()
+				currentHeight := n.bestBlock.Height
+				n.heightMtx.RUnlock()
+
+				n.scriptNotifier.RegisterSpend(
+					msg, uint32(currentHeight),
+				)
+
+			case *chainntnfs.ScriptConfNtfn:
+				n.heightMtx.RLock()
+				currentHeight := n.bestBlock.Height
+				n.heightMtx.RUnlock()
+
+				n.scriptNotifier.RegisterConf(
+					msg, uint32(currentHeight),
+				)
+
+			case *confirmationsNotification:
+				chainntnfs.Log.Infof("New confirmations subscription: "+
+					"txid=%v, numconfs=%v, height_hint=%v",
+					msg.TxID, msg.NumConfirmations, msg.heightHint)
+
+				n.confWatches[*msg.TxID] = msg.pkScript
+
+				// Look up whether the transaction is already
+				// included in the active chain. We'll do this
+				// in a goroutine to not block the dispatcher
+				// on the server.
+				n.heightMtx.RLock()
+				currentHeight := n.bestBlock.Height
+				n.heightMtx.RUnlock()
+
+				n.wg.Add(1)
+				go func() {
+					defer n.wg.Done()
+
+					confDetails, err := n.historicalConfDetails(
+						msg.TxID, msg.pkScript, currentHeight,
+					)
+					if err != nil {
+						chainntnfs.Log.Error(err)
+						return
+					}
+					if confDetails == nil {
+						return
+					}
+
+					err = n.txConfNotifier.UpdateConfDetails(
+						*msg.TxID, msg.ConfID, confDetails,
+					)
+					if err != nil {
+						chainntnfs.Log.Error(err)
+					}
+				}()
+
+			case *blockEpochRegistration:
+				chainntnfs.Log.Infof("New block epoch subscription")
+				n.blockEpochClients[msg.epochID] = msg
+				if msg.bestBlock != nil {
+					n.heightMtx.RLock()
+					bestHeight := n.bestBlock.Height
+					n.heightMtx.RUnlock()
+					missedBlocks, err :=
+						chainntnfs.GetClientMissedBlocks(
+							n.client, msg.bestBlock,
+							bestHeight, true,
+						)
+					if err != nil {
+						msg.errorChan <- err
+						continue
+					}
+					for _, block := range missedBlocks {
+						n.notifyBlockEpochClient(msg,
+							block.Height, block.Hash)
+					}
+				}
+				msg.errorChan <- nil
+
+			case *chainntnfs.ScriptScanResult:
+				n.heightMtx.RLock()
+				currentHeight := n.bestBlock.Height
+				n.heightMtx.RUnlock()
+
+				n.scriptNotifier.HandleScanResult(
+					msg, uint32(currentHeight),
+				)
+			}
+
+		case item := <-n.headerSub.Updates():
+			update := item.(*electrum.TipUpdate)
+			if err := n.handleTipUpdate(update); err != nil {
+				chainntnfs.Log.Errorf("Unable to handle new "+
+					"chain tip %v: %v",
+					update.Header.BlockHash(), err)
+			}
+
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// historicalConfDetails looks up whether a transaction is already included in
+// a block in the active chain and, if so, returns details about the
+// confirmation.
+func (n *ElectrumNotifier) historicalConfDetails(txid *chainhash.Hash,
+	pkScript []byte, currentHeight int32) (*chainntnfs.TxConfirmation, error) {
+
+	history, err := n.client.ScriptHashHistory(
+		electrum.ScriptHash(pkScript),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch history of %x: %v",
+			pkScript, err)
+	}
+
+	for _, entry := range history {
+		if entry.TxHash != txid.String() {
+			continue
+		}
+
+		// The transaction is either still unconfirmed, or confirmed
+		// in a block we haven't processed yet, in which case it'll be
+		// handled once the block is connected.
+		if entry.Height <= 0 || entry.Height > currentHeight {
+			return nil, nil
+		}
+
+		blockHash, err := n.client.GetBlockHash(int64(entry.Height))
+		if err != nil {
+			return nil, err
+		}
+		txIndex, err := n.client.TxPosition(txid, entry.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		return &chainntnfs.TxConfirmation{
+			BlockHash:   blockHash,
+			BlockHeight: uint32(entry.Height),
+			TxIndex:     txIndex,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// dispatchHistoricalSpend looks up whether the outpoint of the given
+// notification has already been spent within the active chain and, if so,
+// hands the spend details to the dispatcher.
+//
+// NOTE: This MUST be run as a goroutine.
+func (n *ElectrumNotifier) dispatchHistoricalSpend(ntfn *spendNotification,
+	currentHeight int32) {
+
+	defer n.wg.Done()
+
+	op := *ntfn.targetOutpoint
+	history, err := n.client.ScriptHashHistory(
+		electrum.ScriptHash(ntfn.pkScript),
+	)
+	if err != nil {
+		chainntnfs.Log.Errorf("Unable to fetch history of %x: %v",
+			ntfn.pkScript, err)
+		return
+	}
+
+	// The history of the script contains all transactions spending from
+	// it, so we'll check each of the confirmed ones for a spend of the
+	// outpoint. Spends in blocks we haven't processed yet will be handled
+	// once the blocks are connected.
+	for _, entry := range history {
+		if entry.Height <= 0 || entry.Height > currentHeight ||
+			entry.TxHash == op.Hash.String() {
+
+			continue
+		}
+
+		txid, err := chainhash.NewHashFromStr(entry.TxHash)
+		if err != nil {
+			chainntnfs.Log.Error(err)
+			return
+		}
+		tx, err := n.client.GetTransaction(txid)
+		if err != nil {
+			chainntnfs.Log.Errorf("Unable to fetch transaction "+
+				"%v: %v", txid, err)
+			return
+		}
+
+		for i, txIn := range tx.TxIn {
+			if txIn.PreviousOutPoint != op {
+				continue
+			}
+
+			spend := &historicalSpend{
+				details: &chainntnfs.SpendDetail{
+					SpentOutPoint:     &op,
+					SpenderTxHash:     txid,
+					SpendingTx:        tx,
+					SpenderInputIndex: uint32(i),
+					SpendingHeight:    entry.Height,
+				},
+			}
+
+			select {
+			case n.notificationRegistry <- spend:
+			case <-n.quit:
+			}
+			return
+		}
+	}
+
+	// The outpoint is still unspent, so we'll add a spend hint with the
+	// current height to the cache in order to better keep track of when
+	// this outpoint is spent.
+	err = n.spendHintCache.CommitSpendHint(uint32(currentHeight), op)
+	if err != nil {
+		// The error is not fatal, so we'll only log it.
+		chainntnfs.Log.Errorf("Unable to update spend hint to %d for "+
+			"%v: %v", currentHeight, op, err)
+	}
+}
+
+// handleTipUpdate processes a new chain tip announced by the server. Blocks
+// we've processed that are no longer part of the main chain are
+// disconnected, after which all blocks up to the new tip are connected.
+func (n *ElectrumNotifier) handleTipUpdate(update *electrum.TipUpdate) error {
+	n.heightMtx.Lock()
+	defer n.heightMtx.Unlock()
+
+	// First, we'll find the most recent block we've processed that's
+	// still part of the main chain.
+	forkHeight := n.bestBlock.Height
+	if update.Height-1 < forkHeight {
+		forkHeight = update.Height - 1
+	}
+	for ; forkHeight > n.bestBlock.Height-reorgSafetyLimit; forkHeight-- {
+		hash, ok := n.blockHashes[forkHeight]
+		if !ok {
+			break
+		}
+
+		if forkHeight == update.Height-1 &&
+			update.Header.PrevBlock == hash {
+
+			break
+		}
+
+		mainHash, err := n.client.GetBlockHash(int64(forkHeight))
+		if err != nil {
+			return err
+		}
+		if *mainHash == hash {
+			break
+		}
+	}
+
+	if forkHeight < n.bestBlock.Height {
+		chainntnfs.Log.Infof("Chain reorganization detected, "+
+			"rewinding from height %d to height %d",
+			n.bestBlock.Height, forkHeight)
+
+		newBestBlock, err := chainntnfs.RewindChain(
+			n.client, n.txConfNotifier, n.bestBlock, forkHeight,
+		)
+
+		// Set the best block here in case a chain rewind partially
+		// completed.
+		for height := newBestBlock.Height + 1; height <=
+			n.bestBlock.Height; height++ {
+
+			delete(n.blockHashes, height)
+		}
+		n.bestBlock = newBestBlock
+		if err != nil {
+			return err
+		}
+	}
+
+	if update.Height <= n.bestBlock.Height {
+		return nil
+	}
+
+	// Next, we'll gather the transactions confirming or spending any of
+	// our watched transactions and outpoints within the new blocks.
+	txns, err := n.relevantTxns(n.bestBlock.Height+1, update.Height)
+	if err != nil {
+		return err
+	}
+
+	for height := n.bestBlock.Height + 1; height <= update.Height; height++ {
+		var hash chainhash.Hash
+		if height == update.Height {
+			hash = update.Header.BlockHash()
+		} else {
+			blockHash, err := n.client.GetBlockHash(int64(height))
+			if err != nil {
+				return err
+			}
+			hash = *blockHash
+		}
+
+		err := n.handleBlockConnected(height, &hash, txns[height])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// relevantTxns returns the transactions confirmed within the given range of
+// heights that are part of the histories of our watched scripts, keyed by the
+// height of their block. The transactions of each block are sorted by their
+// position within it.
+func (n *ElectrumNotifier) relevantTxns(startHeight,
+	endHeight int32) (map[int32][]*btcutil.Tx, error) {
+
+	// The same script may be watched by several requests, so we'll only
+	// query its history once.
+	scripts := make(map[string]struct{})
+	for _, pkScript := range n.confWatches {
+		scripts[electrum.ScriptHash(pkScript)] = struct{}{}
+	}
+	for _, clients := range n.spendNotifications {
+		for _, ntfn := range clients {
+			scripts[electrum.ScriptHash(ntfn.pkScript)] = struct{}{}
+		}
+	}
+	for _, pkScript := range n.txConfNotifier.UnconfirmedScripts() {
+		scripts[electrum.ScriptHash(pkScript)] = struct{}{}
+	}
+	for _, pkScript := range n.scriptNotifier.PkScripts() {
+		scripts[electrum.ScriptHash(pkScript)] = struct{}{}
+	}
+
+	heights := make(map[chainhash.Hash]int32)
+	for scriptHash := range scripts {
+		history, err := n.client.ScriptHashHistory(scriptHash)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range history {
+			if entry.Height <= 0 {
+				continue
+			}
+
+			txid, err := chainhash.NewHashFromStr(entry.TxHash)
+			if err != nil {
+				return nil, err
+			}
+
+			// Once a watched transaction is buried deep enough, it
+			// can't be reorged out of the chain anymore, so we can
+			// stop watching it.
+			if _, ok := n.confWatches[*txid]; ok &&
+				entry.Height+reorgSafetyLimit <= endHeight {
+
+				delete(n.confWatches, *txid)
+			}
+
+			if entry.Height < startHeight || entry.Height > endHeight {
+				continue
+			}
+			heights[*txid] = entry.Height
+		}
+	}
+
+	txns := make(map[int32][]*btcutil.Tx)
+	for txid, height := range heights {
+		txid := txid
+
+		msgTx, err := n.client.GetTransaction(&txid)
+		if err != nil {
+			return nil, err
+		}
+		txIndex, err := n.client.TxPosition(&txid, height)
+		if err != nil {
+			return nil, err
+		}
+
+		tx := btcutil.NewTx(msgTx)
+		tx.SetIndex(int(txIndex))
+		txns[height] = append(txns[height], tx)
+	}
+
+	for _, blockTxns := range txns {
+		blockTxns := blockTxns
+		sort.Slice(blockTxns, func(i, j int) bool {
+			return blockTxns[i].Index() < blockTxns[j].Index()
+		})
+	}
+
+	return txns, nil
+}
+
+// handleBlockConnected applies a chain update for a new block. Any watched
+// transactions included this block will processed to either send notifications
+// now or after numConfirmations confs.
+//
+// NOTE: The height mutex MUST be held.
+func (n *ElectrumNotifier) handleBlockConnected(height int32,
+	hash *chainhash.Hash, txns []*btcutil.Tx) error {
+
+	// First process the block for our internal state. A new block has
+	// been connected to the main chain. Send out any N confirmation
+	// notifications which may have been triggered by this new block.
+	err := n.txConfNotifier.ConnectTip(hash, uint32(height), txns)
+	if err != nil {
+		return fmt.Errorf("unable to connect tip: %v", err)
+	}
+
+	chainntnfs.Log.Infof("New block: height=%v, sha=%v", height, hash)
+
+	// Scan over the list of relevant transactions and dispatch the spend
+	// notifications of any watched outpoints they spend.
+	for _, tx := range txns {
+		mtx := tx.MsgTx()
+		txSha := tx.Hash()
+
+		for i, txIn := range mtx.TxIn {
+			prevOut := txIn.PreviousOutPoint
+			if _, ok := n.spendNotifications[prevOut]; !ok {
+				continue
+			}
+
+			n.dispatchSpend(prevOut, &chainntnfs.SpendDetail{
+				SpentOutPoint:     &prevOut,
+				SpenderTxHash:     txSha,
+				SpendingTx:        mtx,
+				SpenderInputIndex: uint32(i),
+				SpendingHeight:    height,
+			})
+		}
+	}
+
+	// We'll also check for spends of outputs paying to the scripts of our
+	// script spend notifications.
+	scriptSpends := n.scriptNotifier.ConnectTip(
+		uint32(height), txns,
+	)
+
+	// Now, we'll update the spend height hint for all of our watched
+	// outpoints that have not been spent yet. This is safe to do as we do
+	// not watch already spent outpoints for spend notifications.
+	ops := make([]wire.OutPoint, 0, len(n.spendNotifications))
+	for op := range n.spendNotifications {
+		ops = append(ops, op)
+	}
+
+	if len(ops) > 0 {
+		err := n.spendHintCache.CommitSpendHint(uint32(height), ops...)
+		if err != nil {
+			// The error is not fatal since we are connecting a
+			// block, and advancing the spend hint is an optimistic
+			// optimization.
+			chainntnfs.Log.Errorf("Unable to update spend hint to "+
+				"%d for %v: %v", height, ops, err)
+		}
+	}
+
+	// We want to set the best block before dispatching notifications so
+	// if any subscribers make queries based on their received block
+	// epoch, our state is fully updated in time.
+	n.bestBlock = chainntnfs.BlockEpoch{
+		Height: height,
+		Hash:   hash,
+	}
+	n.blockHashes[height] = *hash
+	delete(n.blockHashes, height-reorgSafetyLimit)
+
+	// With all persistent changes committed, notify any subscribed clients
+	// of the block, and send off the details of the script spends.
+	n.notifyBlockEpochs(height, hash)
+
+	n.scriptNotifier.DispatchSpends(scriptSpends)
+
+	return nil
+}
+
+// dispatchSpend sends the given spend details to all clients watching the
+// spent outpoint, and watches the spending transaction for reorgs.
+func (n *ElectrumNotifier) dispatchSpend(op wire.OutPoint,
+	details *chainntnfs.SpendDetail) {
+
+	clients, ok := n.spendNotifications[op]
+	if !ok {
+		return
+	}
+	delete(n.spendNotifications, op)
+
+	for _, ntfn := range clients {
+		chainntnfs.Log.Infof("Dispatching spend notification for "+
+			"outpoint=%v", ntfn.targetOutpoint)
+
+		ntfn.spendChan <- details
+
+		// Close spendChan to ensure that any calls to Cancel will not
+		// block. This is safe to do since the channel is buffered, and
+		// the message can still be read by the receiver.
+		close(ntfn.spendChan)
+
+		n.txConfNotifier.WatchSpendReorg(
+			ntfn.spendID, uint32(details.SpendingHeight),
+			ntfn.reorgChan,
+		)
+	}
+}
+
+// notifyBlockEpochs notifies all registered block epoch clients of the newly
+// connected block to the main chain.
+func (n *ElectrumNotifier) notifyBlockEpochs(newHeight int32, newSha *chainhash.Hash) {
+	for _, client := range n.blockEpochClients {
+		n.notifyBlockEpochClient(client, newHeight, newSha)
+	}
+}
+
+// notifyBlockEpochClient sends a registered block epoch client a notification
+// about a specific block.
+func (n *ElectrumNotifier) notifyBlockEpochClient(epochClient *blockEpochRegistration,
+	height int32, sha *chainhash.Hash) {
+
+	epoch := &chainntnfs.BlockEpoch{
+		Height: height,
+		Hash:   sha,
+	}
+
+	select {
+	case epochClient.epochQueue.ChanIn() <- epoch:
+	case <-epochClient.cancelChan:
+	case <-n.quit:
+	}
+}
+
+// spendNotification couples a target outpoint along with the channel used for
+// notifications once a spend of the outpoint has been detected.
+type spendNotification struct {
+	targetOutpoint *wire.OutPoint
+
+	pkScript []byte
+
+	spendChan chan *chainntnfs.SpendDetail
+
+	reorgChan chan struct{}
+
+	spendID uint64
+
+	heightHint uint32
+}
+
+// spendCancel is a message sent to the ElectrumNotifier when a client wishes
+// to cancel an outstanding spend notification that has yet to be dispatched.
+type spendCancel struct {
+	// op is the target outpoint of the notification to be cancelled.
+	op wire.OutPoint
+
+	// spendID the ID of the notification to cancel.
+	spendID uint64
+}
+
+// RegisterSpendNtfn registers an intent to be notified once the target
+// outpoint has been spent by a transaction on-chain. Once a spend of the
+// target outpoint has been detected, the details of the spending event will be
+// sent across the 'Spend' channel.
+//
+// If no outpoint is given, the notification is keyed on pkScript instead, and
+// is triggered by the first spend of any output paying to it.
+func (n *ElectrumNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
+	pkScript []byte, heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	if outpoint == nil {
+		return n.registerScriptSpendNtfn(pkScript, heightHint)
+	}
+
+	if len(pkScript) == 0 {
+		return nil, ErrPkScriptRequired
+	}
+
+	// Before proceeding to register the notification, we'll query our
+	// height hint cache to determine whether a better one exists.
+	if hint, err := n.spendHintCache.QuerySpendHint(*outpoint); err == nil {
+		if hint > heightHint {
+			chainntnfs.Log.Debugf("Using height hint %d retrieved "+
+				"from cache for %v", hint, outpoint)
+			heightHint = hint
+		}
+	}
+
+	ntfn := &spendNotification{
+		targetOutpoint: outpoint,
+		pkScript:       pkScript,
+		spendChan:      make(chan *chainntnfs.SpendDetail, 1),
+		reorgChan:      make(chan struct{}, 1),
+		spendID:        atomic.AddUint64(&n.spendClientCounter, 1),
+		heightHint:     heightHint,
+	}
+
+	select {
+	case n.notificationRegistry <- ntfn:
+	case <-n.quit:
+		return nil, ErrChainNotifierShuttingDown
+	}
+
+	return &chainntnfs.SpendEvent{
+		Spend: ntfn.spendChan,
+		Reorg: ntfn.reorgChan,
+		Cancel: func() {
+			cancel := &spendCancel{
+				op:      *outpoint,
+				spendID: ntfn.spendID,
+			}
+
+			// Submit spend cancellation to notification dispatcher.
+			select {
+			case n.notificationCancels <- cancel:
+				// Cancellation is being handled, drain the
+				// spend chan until it is closed before yielding
+				// to the caller.
+				for {
+					select {
+					case _, ok := <-ntfn.spendChan:
+						if !ok {
+							return
+						}
+					case <-n.quit:
+						return
+					}
+				}
+			case <-n.quit:
+			}
+		},
+	}, nil
+}
+
+// confirmationNotification represents a client's intent to receive a
+// notification once the target txid reaches numConfirmations confirmations.
+type confirmationsNotification struct {
+	chainntnfs.ConfNtfn
+	heightHint uint32
+	pkScript   []byte
+}
+
+// RegisterConfirmationsNtfn registers a notification with ElectrumNotifier
+// which will be triggered once the txid reaches numConfs number of
+// confirmations.
+//
+// If no txid is given, the notification is keyed on pkScript instead, and the
+// first transaction paying to it becomes the target transaction.
+func (n *ElectrumNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	pkScript []byte,
+	numConfs, heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	if txid == nil {
+		return n.registerScriptConfNtfn(pkScript, numConfs, heightHint)
+	}
+
+	if len(pkScript) == 0 {
+		return nil, ErrPkScriptRequired
+	}
+
+	// Before proceeding to register the notification, we'll query our
+	// height hint cache to determine whether a better one exists.
+	if hint, err := n.confirmHintCache.QueryConfirmHint(*txid); err == nil {
+		if hint > heightHint {
+			chainntnfs.Log.Debugf("Using height hint %d retrieved "+
+				"from cache for %v", hint, txid)
+			heightHint = hint
+		}
+	}
+
+	// Construct a notification request for the transaction and send it to
+	// the main event loop.
+	ntfn := &confirmationsNotification{
+		ConfNtfn: chainntnfs.ConfNtfn{
+			ConfID:           atomic.AddUint64(&n.confClientCounter, 1),
+			TxID:             txid,
+			NumConfirmations: numConfs,
+		},
+		heightHint: heightHint,
+		pkScript:   pkScript,
+	}
+	ntfn.Event = chainntnfs.NewConfirmationEvent(numConfs, func() {
+		n.txConfNotifier.CancelConf(&ntfn.ConfNtfn)
+	})
+
+	if err := n.txConfNotifier.Register(&ntfn.ConfNtfn); err != nil {
+		return nil, err
+	}
+
+	select {
+	case n.notificationRegistry <- ntfn:
+		return ntfn.Event, nil
+	case <-n.quit:
+		return nil, ErrChainNotifierShuttingDown
+	}
+}
+
+// blockEpochRegistration represents a client's intent to receive a
+// notification with each newly connected block.
+type blockEpochRegistration struct {
+	epochID uint64
+
+	epochChan chan *chainntnfs.BlockEpoch
+
+	epochQueue *chainntnfs.ConcurrentQueue
+
+	cancelChan chan struct{}
+
+	bestBlock *chainntnfs.BlockEpoch
+
+	errorChan chan error
+
+	wg sync.WaitGroup
+}
+
+// epochCancel is a message sent to the ElectrumNotifier when a client wishes
+// to cancel an outstanding epoch notification that has yet to be dispatched.
+type epochCancel struct {
+	epochID uint64
+}
+
+// RegisterBlockEpochNtfn returns a BlockEpochEvent which subscribes the
+// caller to receive notifications, of each new block connected to the main
+// chain. Clients have the option of passing in their best known block, which
+// the notifier uses to check if they are behind on blocks and catch them up.
+func (n *ElectrumNotifier) RegisterBlockEpochNtfn(
+	bestBlock *chainntnfs.BlockEpoch) (*chainntnfs.BlockEpochEvent, error) {
+
+	reg := &blockEpochRegistration{
+		epochQueue: chainntnfs.NewConcurrentQueue(20),
+		epochChan:  make(chan *chainntnfs.BlockEpoch, 20),
+		cancelChan: make(chan struct{}),
+		epochID:    atomic.AddUint64(&n.epochClientCounter, 1),
+		bestBlock:  bestBlock,
+		errorChan:  make(chan error, 1),
+	}
+	reg.epochQueue.Start()
+
+	// Before we send the request to the main goroutine, we'll launch a new
+	// goroutine to proxy items added to our queue to the client itself.
+	// This ensures that all notifications are received *in order*.
+	reg.wg.Add(1)
+	go func() {
+		defer reg.wg.Done()
+
+		for {
+			select {
+			case ntfn := <-reg.epochQueue.ChanOut():
+				blockNtfn := ntfn.(*chainntnfs.BlockEpoch)
+				select {
+				case reg.epochChan <- blockNtfn:
+
+				case <-reg.cancelChan:
+					return
+
+				case <-n.quit:
+					return
+				}
+
+			case <-reg.cancelChan:
+				return
+
+			case <-n.quit:
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-n.quit:
+		// As we're exiting before the registration could be sent,
+		// we'll stop the queue now ourselves.
+		reg.epochQueue.Stop()
+
+		return nil, errors.New("chainntnfs: system interrupt while " +
+			"attempting to register for block epoch notification.")
+	case n.notificationRegistry <- reg:
+		return &chainntnfs.BlockEpochEvent{
+			Epochs: reg.epochChan,
+			Cancel: func() {
+				cancel := &epochCancel{
+					epochID: reg.epochID,
+				}
+
+				// Submit epoch cancellation to notification dispatcher.
+				select {
+				case n.notificationCancels <- cancel:
+					// Cancellation is being handled, drain the epoch channel until it is
+					// closed before yielding to caller.
+					for {
+						select {
+						case _, ok := <-reg.epochChan:
+							if !ok {
+								return
+							}
+						case <-n.quit:
+							return
+						}
+					}
+				case <-n.quit:
+				}
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,141 @@
+package electrumnotify
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/electrum"
+)
+
+// FetchScriptBlocks passes the transactions paying to or spending from
+// pkScript of the blocks from startHeight up to and including endHeight to
+// process in order, until it returns true. The transactions are taken from the
+// history of the script, so blocks without any of them are skipped. The first
+// height that hasn't been scanned is returned.
+//
+// NOTE: This is part of the chainntnfs.ScriptBlockFetcher interface.
+func (n *ElectrumNotifier) FetchScriptBlocks(pkScript []byte, startHeight,
+	endHeight uint32,
+	process func(*chainhash.Hash, uint32, []*btcutil.Tx) bool) (uint32,
+	error) {
+
+	history, err := n.client.ScriptHashHistory(
+		electrum.ScriptHash(pkScript),
+	)
+	if err != nil {
+		return startHeight, fmt.Errorf("unable to fetch history of "+
+			"%x: %v", pkScript, err)
+	}
+
+	txns := make(map[uint32][]*btcutil.Tx)
+	for _, entry := range history {
+		if entry.Height <= 0 || uint32(entry.Height) < startHeight ||
+			uint32(entry.Height) > endHeight {
+
+			continue
+		}
+
+		txid, err := chainhash.NewHashFromStr(entry.TxHash)
+		if err != nil {
+			return startHeight, err
+		}
+		msgTx, err := n.client.GetTransaction(txid)
+		if err != nil {
+			return startHeight, fmt.Errorf("unable to fetch "+
+				"transaction %v: %v", txid, err)
+		}
+		txIndex, err := n.client.TxPosition(txid, entry.Height)
+		if err != nil {
+			return startHeight, err
+		}
+
+		tx := btcutil.NewTx(msgTx)
+		tx.SetIndex(int(txIndex))
+		height := uint32(entry.Height)
+		txns[height] = append(txns[height], tx)
+	}
+
+	heights := make([]uint32, 0, len(txns))
+	for height, blockTxns := range txns {
+		blockTxns := blockTxns
+		sort.Slice(blockTxns, func(i, j int) bool {
+			return blockTxns[i].Index() < blockTxns[j].Index()
+		})
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	for _, height := range heights {
+		select {
+		case <-n.quit:
+			return height, ErrChainNotifierShuttingDown
+		default:
+		}
+
+		blockHash, err := n.client.GetBlockHash(int64(height))
+		if err != nil {
+			return height, err
+		}
+
+		if process(blockHash, height, txns[height]) {
+			return height + 1, nil
+		}
+	}
+
+	if endHeight < startHeight {
+		return startHeight, nil
+	}
+	return endHeight + 1, nil
+}
+
+// registerScriptSpendNtfn registers an intent to be notified once any output
+// paying to the given script, created at or after the height hint, is spent.
+func (n *ElectrumNotifier) registerScriptSpendNtfn(pkScript []byte,
+	heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	if len(pkScript) == 0 {
+		return nil, ErrPkScriptRequired
+	}
+
+	ntfn := chainntnfs.NewScriptSpendNtfn(
+		atomic.AddUint64(&n.spendClientCounter, 1), pkScript,
+		heightHint, n.notificationCancels, n.quit,
+	)
+
+	select {
+	case <-n.quit:
+		return nil, ErrChainNotifierShuttingDown
+	case n.notificationRegistry <- ntfn:
+	}
+
+	return ntfn.Event, nil
+}
+
+// registerScriptConfNtfn registers an intent to be notified once the first
+// transaction paying to the given script, included at or after the height
+// hint, reaches numConfs confirmations.
+func (n *ElectrumNotifier) registerScriptConfNtfn(pkScript []byte, numConfs,
+	heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	if len(pkScript) == 0 {
+		return nil, ErrPkScriptRequired
+	}
+
+	ntfn := chainntnfs.NewScriptConfNtfn(
+		atomic.AddUint64(&n.confClientCounter, 1), pkScript, numConfs,
+		heightHint, n.txConfNotifier,
+	)
+
+	select {
+	case n.notificationRegistry <- ntfn:
+		return ntfn.Event, nil
+	case <-n.quit:
+		return nil, ErrChainNotifierShuttingDown
+	}
+}
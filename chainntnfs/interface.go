@@ -81,6 +81,11 @@ type ChainNotifier interface {
 	// when checking to see if a notification can immediately be dispatched
 	// due to historical data.
 	//
+	// If txid is nil, the notification is instead keyed on pkScript: the
+	// first transaction paying to pkScript that is included in the chain
+	// at or after heightHint is tracked as the target transaction. This
+	// allows watching addresses that haven't been paid to yet.
+	//
 	// NOTE: Dispatching notifications to multiple clients subscribed to
 	// the same (txid, numConfs) tuple MUST be supported.
 	RegisterConfirmationsNtfn(txid *chainhash.Hash, pkScript []byte, numConfs,
@@ -96,6 +101,10 @@ type ChainNotifier interface {
 	// The heightHint denotes the earliest height in the blockchain in
 	// which the target output could have been created.
 	//
+	// If outpoint is nil, the notification is instead keyed on pkScript:
+	// it's triggered by the first spend of any output paying to pkScript
+	// that is created at or after heightHint.
+	//
 	// NOTE: The notification should only be triggered when the spending
 	// transaction receives a single confirmation.
 	//
@@ -140,6 +149,11 @@ type TxConfirmation struct {
 	// TxIndex is the index within the block of the ultimate confirmed
 	// transaction.
 	TxIndex uint32
+
+	// Tx is the confirmed transaction. It's only set for notifications
+	// registered by script, as the caller doesn't know the transaction in
+	// advance.
+	Tx *wire.MsgTx
 }
 
 // ConfirmationEvent encapsulates a confirmation notification. With this struct,
@@ -172,6 +186,11 @@ type ConfirmationEvent struct {
 	// channel after confs.
 
 	NegativeConf chan int32 // MUST be buffered.
+
+	// Cancel is a closure that should be executed by the caller in the
+	// case that they wish to abandon their registered confirmation
+	// notification.
+	Cancel func()
 }
 
 // SpendDetail contains details pertaining to a spent output. This struct itself
@@ -187,9 +206,12 @@ type SpendDetail struct {
 	SpendingHeight    int32
 }
 
-// SpendEvent encapsulates a spentness notification. Its only field 'Spend' will
-// be sent upon once the target output passed into RegisterSpendNtfn has been
-// spent on the blockchain.
+// SpendEvent encapsulates a spentness notification. Its field 'Spend' will be
+// sent upon once the target output passed into RegisterSpendNtfn has been spent
+// on the blockchain. If the spending transaction is then reorged out of the
+// chain, the 'Reorg' channel will be sent upon. The notification isn't
+// dispatched again if the output is spent anew, so the caller has to register
+// a new spend notification for that.
 //
 // NOTE: If the caller wishes to cancel their registered spend notification,
 // the Cancel closure MUST be called.
@@ -198,6 +220,11 @@ type SpendEvent struct {
 	// target outpoint has been spent.
 	Spend <-chan *SpendDetail // MUST be buffered.
 
+	// Reorg is a receive only channel which will be sent upon once the
+	// spending transaction has been reorged out of the chain after the
+	// spend was dispatched.
+	Reorg <-chan struct{} // MUST be buffered.
+
 	// Cancel is a closure that should be executed by the caller in the
 	// case that they wish to prematurely abandon their registered spend
 	// notification.
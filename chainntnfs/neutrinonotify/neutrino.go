@@ -69,6 +69,8 @@ type NeutrinoNotifier struct {
 
 	spendNotifications map[wire.OutPoint]map[uint64]*spendNotification
 
+	scriptNotifier *chainntnfs.ScriptNotifier
+
 	txConfNotifier *chainntnfs.TxConfNotifier
 
 	blockEpochClients map[uint64]*blockEpochRegistration
@@ -168,6 +170,16 @@ func (n *NeutrinoNotifier) Start() error {
 		bestHeight, reorgSafetyLimit, n.confirmHintCache,
 	)
 
+	n.scriptNotifier = chainntnfs.NewScriptNotifier(
+		chainntnfs.ScriptNotifierConfig{
+			Fetcher:        n,
+			TxConfNotifier: n.txConfNotifier,
+			ScanResults:    n.notificationRegistry,
+			Quit:           n.quit,
+			Wg:             &n.wg,
+		},
+	)
+
 	n.chainConn = &NeutrinoChainConn{n.p2pNode}
 
 	// Finally, we'll create our rescan struct, start it, and launch all
@@ -202,6 +214,7 @@ func (n *NeutrinoNotifier) Stop() error {
 			close(spendClient.spendChan)
 		}
 	}
+	n.scriptNotifier.TearDown()
 	for _, epochClient := range n.blockEpochClients {
 		close(epochClient.cancelChan)
 		epochClient.wg.Wait()
@@ -270,6 +283,10 @@ out:
 					"notification for out_point=%v, "+
 					"spend_id=%v", msg.op, msg.spendID)
 
+				// The spend may have been dispatched already, so
+				// we'll stop watching it for reorgs.
+				n.txConfNotifier.CancelSpendReorg(msg.spendID)
+
 				// Before we attempt to close the spendChan,
 				// ensure that the notification hasn't already
 				// yet been dispatched.
@@ -278,6 +295,9 @@ out:
 					delete(n.spendNotifications[msg.op], msg.spendID)
 				}
 
+			case *chainntnfs.ScriptSpendCancel:
+				n.scriptNotifier.CancelSpend(msg.SpendID)
+
 			case *epochCancel:
 				chainntnfs.Log.Infof("Cancelling epoch "+
 					"notification, epoch_id=%v", msg.epochID)
@@ -316,11 +336,25 @@ out:
 				}
 				n.spendNotifications[op][msg.spendID] = msg
 
-			case *confirmationsNotification:
-				chainntnfs.Log.Infof("New confirmations subscription: "+
-					"txid=%v, numconfs=%v, height_hint=%v",
-					msg.TxID, msg.NumConfirmations, msg.heightHint)
+			case *chainntnfs.ScriptSpendNtfn:
+				n.heightMtx.RLock()
+				currentHeight := n.bestHeight
+				n.heightMtx.RUnlock()
+
+				n.scriptNotifier.RegisterSpend(
+					msg, currentHeight,
+				)
 
+			case *chainntnfs.ScriptConfNtfn:
+				n.heightMtx.RLock()
+				currentHeight := n.bestHeight
+				n.heightMtx.RUnlock()
+
+				n.scriptNotifier.RegisterConf(
+					msg, currentHeight,
+				)
+
+			case *confirmationsNotification:
 				// If the notification can be partially or
 				// fully dispatched, then we can skip the first
 				// phase for ntfns.
@@ -328,6 +362,10 @@ out:
 				currentHeight := n.bestHeight
 				n.heightMtx.RUnlock()
 
+				chainntnfs.Log.Infof("New confirmations subscription: "+
+					"txid=%v, numconfs=%v, height_hint=%v",
+					msg.TxID, msg.NumConfirmations, msg.heightHint)
+
 				// Look up whether the transaction is already
 				// included in the active chain. We'll do this
 				// in a goroutine to prevent blocking
@@ -406,6 +444,15 @@ out:
 					}
 				}
 				msg.errorChan <- nil
+
+			case *chainntnfs.ScriptScanResult:
+				n.heightMtx.RLock()
+				currentHeight := n.bestHeight
+				n.heightMtx.RUnlock()
+
+				n.scriptNotifier.HandleScanResult(
+					msg, currentHeight,
+				)
 			}
 
 		case item := <-n.chainUpdates.ChanOut():
@@ -601,6 +648,12 @@ func (n *NeutrinoNotifier) historicalConfDetails(targetHash *chainhash.Hash,
 // transactions included this block will processed to either send notifications
 // now or after numConfirmations confs.
 func (n *NeutrinoNotifier) handleBlockConnected(newBlock *filteredBlock) error {
+	// Before processing the block, we'll make sure it contains the
+	// transactions relevant to the notifications keyed on a script.
+	if err := n.fetchScriptTxns(newBlock); err != nil {
+		return err
+	}
+
 	// First process the block for our internal state. A new block has
 	// been connected to the main chain. Send out any N confirmation
 	// notifications which may have been triggered by this new block.
@@ -657,6 +710,12 @@ func (n *NeutrinoNotifier) handleBlockConnected(newBlock *filteredBlock) error {
 		}
 	}
 
+	// We'll also check for spends of outputs paying to the scripts of our
+	// script spend notifications.
+	scriptSpends := n.scriptNotifier.ConnectTip(
+		newBlock.height, newBlock.txns,
+	)
+
 	// Now, we'll update the spend height hint for all of our watched
 	// outpoints that have not been spent yet. This is safe to do as we do
 	// not watch already spent outpoints for spend notifications.
@@ -699,8 +758,13 @@ func (n *NeutrinoNotifier) handleBlockConnected(newBlock *filteredBlock) error {
 			// since the channel is buffered, and the
 			// message can still be read by the receiver.
 			close(ntfn.spendChan)
+
+			n.txConfNotifier.WatchSpendReorg(
+				ntfn.spendID, newBlock.height, ntfn.reorgChan,
+			)
 		}
 	}
+	n.scriptNotifier.DispatchSpends(scriptSpends)
 
 	return nil
 }
@@ -755,6 +819,8 @@ type spendNotification struct {
 
 	spendChan chan *chainntnfs.SpendDetail
 
+	reorgChan chan struct{}
+
 	spendID uint64
 
 	heightHint uint32
@@ -774,9 +840,16 @@ type spendCancel struct {
 // outpoint has been spent by a transaction on-chain. Once a spend of the
 // target outpoint has been detected, the details of the spending event will be
 // sent across the 'Spend' channel.
+//
+// If no outpoint is given, the notification is keyed on pkScript instead, and
+// is triggered by the first spend of any output paying to it.
 func (n *NeutrinoNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 	pkScript []byte, heightHint uint32) (*chainntnfs.SpendEvent, error) {
 
+	if outpoint == nil {
+		return n.registerScriptSpendNtfn(pkScript, heightHint)
+	}
+
 	n.heightMtx.RLock()
 	currentHeight := n.bestHeight
 	n.heightMtx.RUnlock()
@@ -797,12 +870,14 @@ func (n *NeutrinoNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 	ntfn := &spendNotification{
 		targetOutpoint: outpoint,
 		spendChan:      make(chan *chainntnfs.SpendDetail, 1),
+		reorgChan:      make(chan struct{}, 1),
 		spendID:        atomic.AddUint64(&n.spendClientCounter, 1),
 		heightHint:     heightHint,
 	}
 
 	spendEvent := &chainntnfs.SpendEvent{
 		Spend: ntfn.spendChan,
+		Reorg: ntfn.reorgChan,
 		Cancel: func() {
 			cancel := &spendCancel{
 				op:      *outpoint,
@@ -882,6 +957,10 @@ func (n *NeutrinoNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 				return
 			}
 
+			n.txConfNotifier.WatchSpendReorg(
+				ntfn.spendID, spendReport.SpendingTxHeight,
+				ntfn.reorgChan,
+			)
 		}()
 
 		return spendEvent, nil
@@ -929,10 +1008,17 @@ type confirmationsNotification struct {
 // RegisterConfirmationsNtfn registers a notification with NeutrinoNotifier
 // which will be triggered once the txid reaches numConfs number of
 // confirmations.
+//
+// If no txid is given, the notification is keyed on pkScript instead, and the
+// first transaction paying to it becomes the target transaction.
 func (n *NeutrinoNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 	pkScript []byte,
 	numConfs, heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
 
+	if txid == nil {
+		return n.registerScriptConfNtfn(pkScript, numConfs, heightHint)
+	}
+
 	// Before proceeding to register the notification, we'll query our
 	// height hint cache to determine whether a better one exists.
 	if hint, err := n.confirmHintCache.QueryConfirmHint(*txid); err == nil {
@@ -950,11 +1036,13 @@ func (n *NeutrinoNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 			ConfID:           atomic.AddUint64(&n.confClientCounter, 1),
 			TxID:             txid,
 			NumConfirmations: numConfs,
-			Event:            chainntnfs.NewConfirmationEvent(numConfs),
 		},
 		heightHint: heightHint,
 		pkScript:   pkScript,
 	}
+	ntfn.Event = chainntnfs.NewConfirmationEvent(numConfs, func() {
+		n.txConfNotifier.CancelConf(&ntfn.ConfNtfn)
+	})
 
 	if err := n.txConfNotifier.Register(&ntfn.ConfNtfn); err != nil {
 		return nil, err
@@ -56,6 +56,16 @@ func (n *NeutrinoNotifier) UnsafeStart(bestHeight int32, bestHash *chainhash.Has
 		uint32(bestHeight), reorgSafetyLimit, n.confirmHintCache,
 	)
 
+	n.scriptNotifier = chainntnfs.NewScriptNotifier(
+		chainntnfs.ScriptNotifierConfig{
+			Fetcher:        n,
+			TxConfNotifier: n.txConfNotifier,
+			ScanResults:    n.notificationRegistry,
+			Quit:           n.quit,
+			Wg:             &n.wg,
+		},
+	)
+
 	n.chainConn = &NeutrinoChainConn{n.p2pNode}
 
 	// Finally, we'll create our rescan struct, start it, and launch all
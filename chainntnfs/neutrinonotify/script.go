@@ -0,0 +1,169 @@
+package neutrinonotify
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/gcs/builder"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// FetchScriptBlocks passes the transactions of the blocks from startHeight up
+// to and including endHeight to process in order, until it returns true.
+// Only the blocks whose compact filter matches pkScript are fetched, as no
+// other block can pay to or spend from the script. The first height that
+// hasn't been scanned is returned.
+//
+// NOTE: This is part of the chainntnfs.ScriptBlockFetcher interface.
+func (n *NeutrinoNotifier) FetchScriptBlocks(pkScript []byte, startHeight,
+	endHeight uint32,
+	process func(*chainhash.Hash, uint32, []*btcutil.Tx) bool) (uint32,
+	error) {
+
+	for height := startHeight; height <= endHeight; height++ {
+		select {
+		case <-n.quit:
+			return height, ErrChainNotifierShuttingDown
+		default:
+		}
+
+		header, err := n.p2pNode.BlockHeaders.FetchHeaderByHeight(height)
+		if err != nil {
+			return height, fmt.Errorf("unable to get header for "+
+				"height=%v: %v", height, err)
+		}
+		blockHash := header.BlockHash()
+
+		match, err := n.matchScripts(&blockHash, [][]byte{pkScript})
+		if err != nil {
+			return height, err
+		}
+		if !match {
+			continue
+		}
+
+		block, err := n.p2pNode.GetBlock(blockHash)
+		if err != nil {
+			return height, fmt.Errorf("unable to get block from "+
+				"network: %v", err)
+		}
+
+		if process(&blockHash, height, block.Transactions()) {
+			return height + 1, nil
+		}
+	}
+
+	if endHeight < startHeight {
+		return startHeight, nil
+	}
+	return endHeight + 1, nil
+}
+
+// matchScripts returns whether the compact filter of the given block matches
+// any of the scripts.
+func (n *NeutrinoNotifier) matchScripts(blockHash *chainhash.Hash,
+	scripts [][]byte) (bool, error) {
+
+	regFilter, err := n.p2pNode.GetCFilter(
+		*blockHash, wire.GCSFilterRegular,
+	)
+	if err != nil {
+		return false, fmt.Errorf("unable to retrieve regular filter "+
+			"for block %v: %v", blockHash, err)
+	}
+
+	// If the block has no transactions other than the coinbase
+	// transaction, then the filter may be nil.
+	if regFilter == nil {
+		return false, nil
+	}
+
+	key := builder.DeriveKey(blockHash)
+	match, err := regFilter.MatchAny(key, scripts)
+	if err != nil {
+		return false, fmt.Errorf("unable to query filter: %v", err)
+	}
+
+	return match, nil
+}
+
+// fetchScriptTxns replaces the transactions of a filtered block with those of
+// the full block if its compact filter matches any of the scripts of the
+// active notifications keyed on a script. The rescan only filters blocks for
+// the transactions and outputs watched by txid and outpoint, so the full
+// block is needed to find the transactions paying to or spending from the
+// scripts.
+//
+// NOTE: This must only be called from the notification dispatcher.
+func (n *NeutrinoNotifier) fetchScriptTxns(newBlock *filteredBlock) error {
+	scripts := n.txConfNotifier.UnconfirmedScripts()
+	scripts = append(scripts, n.scriptNotifier.PkScripts()...)
+	if len(scripts) == 0 {
+		return nil
+	}
+
+	match, err := n.matchScripts(&newBlock.hash, scripts)
+	if err != nil || !match {
+		return err
+	}
+
+	block, err := n.p2pNode.GetBlock(newBlock.hash)
+	if err != nil {
+		return fmt.Errorf("unable to get block from network: %v", err)
+	}
+	newBlock.txns = block.Transactions()
+
+	return nil
+}
+
+// registerScriptSpendNtfn registers an intent to be notified once any output
+// paying to the given script, created at or after the height hint, is spent.
+func (n *NeutrinoNotifier) registerScriptSpendNtfn(pkScript []byte,
+	heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	if len(pkScript) == 0 {
+		return nil, errors.New("either an outpoint or a pkScript " +
+			"must be provided")
+	}
+
+	ntfn := chainntnfs.NewScriptSpendNtfn(
+		atomic.AddUint64(&n.spendClientCounter, 1), pkScript,
+		heightHint, n.notificationCancels, n.quit,
+	)
+
+	select {
+	case <-n.quit:
+		return nil, ErrChainNotifierShuttingDown
+	case n.notificationRegistry <- ntfn:
+	}
+
+	return ntfn.Event, nil
+}
+
+// registerScriptConfNtfn registers an intent to be notified once the first
+// transaction paying to the given script, included at or after the height
+// hint, reaches numConfs confirmations.
+func (n *NeutrinoNotifier) registerScriptConfNtfn(pkScript []byte, numConfs,
+	heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	if len(pkScript) == 0 {
+		return nil, errors.New("either a txid or a pkScript must be " +
+			"provided")
+	}
+
+	ntfn := chainntnfs.NewScriptConfNtfn(
+		atomic.AddUint64(&n.confClientCounter, 1), pkScript, numConfs,
+		heightHint, n.txConfNotifier,
+	)
+
+	select {
+	case n.notificationRegistry <- ntfn:
+		return ntfn.Event, nil
+	case <-n.quit:
+		return nil, ErrChainNotifierShuttingDown
+	}
+}
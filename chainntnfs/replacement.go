@@ -0,0 +1,331 @@
+package chainntnfs
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// txReplacement describes a transaction that replaced another transaction by
+// double spending (some of) its inputs, for example to bump its fee.
+type txReplacement struct {
+	// txid is the hash of the replacement transaction.
+	txid chainhash.Hash
+
+	// pkScript is one of the output scripts of the replacement
+	// transaction, which light clients need to match the transaction.
+	pkScript []byte
+}
+
+// replacementConfClient is a confirmation notification request that follows
+// the registered transaction and all of its known replacements.
+type replacementConfClient struct {
+	numConfs   uint32
+	heightHint uint32
+
+	// event is the confirmation event handed to the client.
+	event *ConfirmationEvent
+
+	// watched maps the transactions within the replacement chain that a
+	// confirmation notification has been registered for to the closure
+	// cancelling it.
+	watched map[chainhash.Hash]func()
+
+	// done is closed once one of the watched transactions confirmed, or
+	// the client cancelled the notification.
+	done chan struct{}
+}
+
+// TxReplacementNotifier wraps a ChainNotifier to make confirmation
+// notifications follow replacements of the registered transaction. Once a
+// transaction is replaced, for example by a fee bump, callers waiting for its
+// confirmation are notified of the confirmation of whichever version of the
+// transaction makes it into the chain. All other notifications are passed
+// through to the wrapped notifier.
+//
+// NOTE: Replacements are only tracked in memory, so they need to be reported
+// again after a restart.
+type TxReplacementNotifier struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	ChainNotifier
+
+	mtx sync.Mutex
+
+	// replacements maps the hash of a replaced transaction to all known
+	// transactions that replace it.
+	replacements map[chainhash.Hash][]txReplacement
+
+	// clients maps the hash of a transaction to the clients that are
+	// waiting for its confirmation.
+	clients map[chainhash.Hash][]*replacementConfClient
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewTxReplacementNotifier returns a TxReplacementNotifier that wraps the
+// passed notifier.
+func NewTxReplacementNotifier(notifier ChainNotifier) *TxReplacementNotifier {
+	return &TxReplacementNotifier{
+		ChainNotifier: notifier,
+		replacements:  make(map[chainhash.Hash][]txReplacement),
+		clients:       make(map[chainhash.Hash][]*replacementConfClient),
+		quit:          make(chan struct{}),
+	}
+}
+
+// Start starts the wrapped notifier.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (n *TxReplacementNotifier) Start() error {
+	if atomic.AddInt32(&n.started, 1) != 1 {
+		return nil
+	}
+
+	return n.ChainNotifier.Start()
+}
+
+// Stop stops the wrapped notifier and all goroutines forwarding confirmation
+// notifications.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (n *TxReplacementNotifier) Stop() error {
+	if atomic.AddInt32(&n.stopped, 1) != 1 {
+		return nil
+	}
+
+	close(n.quit)
+	err := n.ChainNotifier.Stop()
+	n.wg.Wait()
+
+	return err
+}
+
+// RegisterConfirmationsNtfn registers a notification that is dispatched once
+// either the passed transaction or one of its replacements reaches numConfs
+// confirmations. Replacements that are reported after the registration are
+// taken into account as well. Notifications keyed on a script are passed
+// through to the wrapped notifier, as there's no transaction to replace yet.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (n *TxReplacementNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	pkScript []byte, numConfs, heightHint uint32) (*ConfirmationEvent,
+	error) {
+
+	if txid == nil {
+		return n.ChainNotifier.RegisterConfirmationsNtfn(
+			nil, pkScript, numConfs, heightHint,
+		)
+	}
+
+	client := &replacementConfClient{
+		numConfs:   numConfs,
+		heightHint: heightHint,
+		watched:    make(map[chainhash.Hash]func()),
+		done:       make(chan struct{}),
+	}
+	client.event = NewConfirmationEvent(numConfs, func() {
+		n.cancelClient(client, nil)
+	})
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if err := n.watchChain(client, *txid, pkScript); err != nil {
+		return nil, err
+	}
+
+	return client.event, nil
+}
+
+// NotifyReplacement reports that the passed transaction replaces the
+// transaction with the given hash. All clients waiting for the confirmation
+// of the replaced transaction will also be notified of the confirmation of
+// the replacement.
+func (n *TxReplacementNotifier) NotifyReplacement(replacedTxid chainhash.Hash,
+	replacementTx *wire.MsgTx) error {
+
+	replacement := txReplacement{
+		txid: replacementTx.TxHash(),
+	}
+	if len(replacementTx.TxOut) > 0 {
+		replacement.pkScript = replacementTx.TxOut[0].PkScript
+	}
+
+	Log.Debugf("Tx %v replaced by %v", replacedTxid, replacement.txid)
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	for _, r := range n.replacements[replacedTxid] {
+		if r.txid == replacement.txid {
+			return nil
+		}
+	}
+	n.replacements[replacedTxid] = append(
+		n.replacements[replacedTxid], replacement,
+	)
+
+	for _, client := range n.clients[replacedTxid] {
+		err := n.watchChain(
+			client, replacement.txid, replacement.pkScript,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// watchChain registers the client for the confirmation of the passed
+// transaction and all of its known replacements.
+//
+// NOTE: The mutex MUST be held when calling this method.
+func (n *TxReplacementNotifier) watchChain(client *replacementConfClient,
+	txid chainhash.Hash, pkScript []byte) error {
+
+	if _, ok := client.watched[txid]; ok {
+		return nil
+	}
+
+	confEvent, err := n.ChainNotifier.RegisterConfirmationsNtfn(
+		&txid, pkScript, client.numConfs, client.heightHint,
+	)
+	if err != nil {
+		return err
+	}
+
+	client.watched[txid] = confEvent.Cancel
+	n.clients[txid] = append(n.clients[txid], client)
+
+	n.wg.Add(1)
+	go n.forwardConfs(client, txid, confEvent)
+
+	for _, r := range n.replacements[txid] {
+		if err := n.watchChain(client, r.txid, r.pkScript); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forwardConfs forwards the notifications of a single transaction within the
+// replacement chain to the client. Once the transaction confirms, the client
+// stops watching all other transactions of the chain.
+//
+// NOTE: This MUST be run as a goroutine.
+func (n *TxReplacementNotifier) forwardConfs(client *replacementConfClient,
+	txid chainhash.Hash, confEvent *ConfirmationEvent) {
+
+	defer n.wg.Done()
+
+	for {
+		select {
+		case conf, ok := <-confEvent.Confirmed:
+			if !ok {
+				return
+			}
+
+			if !n.cancelClient(client, &txid) {
+				return
+			}
+
+			Log.Debugf("Dispatching confirmation of tx %v to "+
+				"replacement chain client", txid)
+
+			client.event.Confirmed <- conf
+			return
+
+		case numConfsLeft, ok := <-confEvent.Updates:
+			if !ok {
+				return
+			}
+
+			select {
+			case client.event.Updates <- numConfsLeft:
+			default:
+			}
+
+		case reorgDepth, ok := <-confEvent.NegativeConf:
+			if !ok {
+				return
+			}
+
+			select {
+			case client.event.NegativeConf <- reorgDepth:
+			default:
+			}
+
+		case <-client.done:
+			return
+
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// cancelClient stops the client from waiting for a confirmation, and cancels
+// the confirmation notifications of all watched transactions, except for the
+// given one if it's not nil. False is returned if the client had already
+// stopped.
+func (n *TxReplacementNotifier) cancelClient(client *replacementConfClient,
+	except *chainhash.Hash) bool {
+
+	n.mtx.Lock()
+	select {
+	case <-client.done:
+		n.mtx.Unlock()
+		return false
+	default:
+	}
+	close(client.done)
+	n.removeClient(client)
+
+	var cancels []func()
+	for txid, cancel := range client.watched {
+		if except != nil && txid == *except {
+			continue
+		}
+		cancels = append(cancels, cancel)
+	}
+	n.mtx.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+
+	return true
+}
+
+// removeClient removes the client from the index of clients waiting for a
+// confirmation.
+//
+// NOTE: The mutex MUST be held when calling this method.
+func (n *TxReplacementNotifier) removeClient(client *replacementConfClient) {
+	for txid := range client.watched {
+		clients := n.clients[txid]
+		for i, c := range clients {
+			if c == client {
+				clients = append(clients[:i], clients[i+1:]...)
+				break
+			}
+		}
+
+		if len(clients) == 0 {
+			delete(n.clients, txid)
+			continue
+		}
+		n.clients[txid] = clients
+	}
+}
+
+// Compile-time check to ensure TxReplacementNotifier implements the
+// ChainNotifier interface.
+var _ ChainNotifier = (*TxReplacementNotifier)(nil)
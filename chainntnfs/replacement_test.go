@@ -0,0 +1,189 @@
+package chainntnfs_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// mockConfNotifier is a ChainNotifier that only supports confirmation
+// notifications, which are triggered by the test.
+type mockConfNotifier struct {
+	chainntnfs.ChainNotifier
+
+	mtx    sync.Mutex
+	events map[chainhash.Hash][]*chainntnfs.ConfirmationEvent
+}
+
+func newMockConfNotifier() *mockConfNotifier {
+	return &mockConfNotifier{
+		events: make(map[chainhash.Hash][]*chainntnfs.ConfirmationEvent),
+	}
+}
+
+func (m *mockConfNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	_ []byte, numConfs, _ uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	event := chainntnfs.NewConfirmationEvent(numConfs, func() {})
+	m.events[*txid] = append(m.events[*txid], event)
+
+	return event, nil
+}
+
+func (m *mockConfNotifier) Start() error {
+	return nil
+}
+
+func (m *mockConfNotifier) Stop() error {
+	return nil
+}
+
+// confirm dispatches the confirmation of the passed tx to all registered
+// clients.
+func (m *mockConfNotifier) confirm(txid chainhash.Hash, height uint32) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, event := range m.events[txid] {
+		event.Confirmed <- &chainntnfs.TxConfirmation{
+			BlockHeight: height,
+		}
+	}
+}
+
+// numRegistrations returns the number of registrations for the passed tx.
+func (m *mockConfNotifier) numRegistrations(txid chainhash.Hash) int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return len(m.events[txid])
+}
+
+// makeTestTx creates a unique tx spending an output of the passed tx hash.
+func makeTestTx(prevHash chainhash.Hash, value int64) *wire.MsgTx {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: prevHash},
+	})
+	tx.AddTxOut(&wire.TxOut{
+		Value:    value,
+		PkScript: []byte{0x00, 0x14},
+	})
+
+	return tx
+}
+
+// expectConf asserts that a confirmation at the given height is received.
+func expectConf(t *testing.T, event *chainntnfs.ConfirmationEvent,
+	height uint32) {
+
+	t.Helper()
+
+	select {
+	case conf := <-event.Confirmed:
+		if conf.BlockHeight != height {
+			t.Fatalf("expected confirmation at height %v, got %v",
+				height, conf.BlockHeight)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("confirmation not received")
+	}
+}
+
+// expectNoConf asserts that no confirmation is received.
+func expectNoConf(t *testing.T, event *chainntnfs.ConfirmationEvent) {
+	t.Helper()
+
+	select {
+	case <-event.Confirmed:
+		t.Fatalf("unexpected confirmation")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestTxReplacementNotifier asserts that confirmation notifications follow
+// replacements of the registered transaction, regardless of whether the
+// replacement is reported before or after the registration.
+func TestTxReplacementNotifier(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockConfNotifier()
+	notifier := chainntnfs.NewTxReplacementNotifier(mock)
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+	defer notifier.Stop()
+
+	origTx := makeTestTx(zeroHash, 1000)
+	origTxid := origTx.TxHash()
+	firstBump := makeTestTx(zeroHash, 900)
+	secondBump := makeTestTx(zeroHash, 800)
+
+	// A client registering before any replacement is known should be
+	// notified of the confirmation of a later replacement.
+	early, err := notifier.RegisterConfirmationsNtfn(
+		&origTxid, nil, 1, 100,
+	)
+	if err != nil {
+		t.Fatalf("unable to register: %v", err)
+	}
+
+	err = notifier.NotifyReplacement(origTxid, firstBump)
+	if err != nil {
+		t.Fatalf("unable to notify replacement: %v", err)
+	}
+	err = notifier.NotifyReplacement(firstBump.TxHash(), secondBump)
+	if err != nil {
+		t.Fatalf("unable to notify replacement: %v", err)
+	}
+
+	// A client registering after the replacements should watch the entire
+	// replacement chain as well.
+	late, err := notifier.RegisterConfirmationsNtfn(
+		&origTxid, nil, 1, 100,
+	)
+	if err != nil {
+		t.Fatalf("unable to register: %v", err)
+	}
+
+	for _, tx := range []*wire.MsgTx{origTx, firstBump, secondBump} {
+		if n := mock.numRegistrations(tx.TxHash()); n != 2 {
+			t.Fatalf("expected 2 registrations for %v, got %v",
+				tx.TxHash(), n)
+		}
+	}
+
+	// Confirming the last replacement should notify both clients.
+	mock.confirm(secondBump.TxHash(), 101)
+	expectConf(t, early, 101)
+	expectConf(t, late, 101)
+
+	// A conflicting confirmation of another version, for example after a
+	// reorg, must not be dispatched a second time.
+	mock.confirm(origTxid, 102)
+	expectNoConf(t, early)
+	expectNoConf(t, late)
+
+	// Clients waiting for the confirmation of an intermediate version
+	// only follow its replacements, not the tx it replaced.
+	firstBumpTxid := firstBump.TxHash()
+	intermediate, err := notifier.RegisterConfirmationsNtfn(
+		&firstBumpTxid, nil, 1, 100,
+	)
+	if err != nil {
+		t.Fatalf("unable to register: %v", err)
+	}
+	if n := mock.numRegistrations(origTxid); n != 2 {
+		t.Fatalf("replaced tx unexpectedly watched")
+	}
+
+	mock.confirm(firstBumpTxid, 103)
+	expectConf(t, intermediate, 103)
+}
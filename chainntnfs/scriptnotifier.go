@@ -0,0 +1,585 @@
+package chainntnfs
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// ScriptBlockFetcher fetches the blocks of the active chain that are scanned
+// for transactions paying to or spending from a script.
+type ScriptBlockFetcher interface {
+	// FetchScriptBlocks passes the hash, height and transactions of the
+	// blocks from startHeight up to and including endHeight to process in
+	// order, until it returns true. Blocks and transactions that can't pay
+	// to or spend from pkScript may be skipped. The first height that
+	// hasn't been scanned is returned.
+	FetchScriptBlocks(pkScript []byte, startHeight, endHeight uint32,
+		process func(*chainhash.Hash, uint32, []*btcutil.Tx) bool) (
+		uint32, error)
+}
+
+// BlockChainConn is a chain backend that serves full blocks.
+type BlockChainConn interface {
+	// GetBlockHash returns the hash from a block height.
+	GetBlockHash(blockHeight int64) (*chainhash.Hash, error)
+
+	// GetBlock returns the block for a hash.
+	GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error)
+}
+
+// ChainBlockFetcher is a ScriptBlockFetcher that fetches every block of the
+// scanned range from a chain backend serving full blocks.
+type ChainBlockFetcher struct {
+	conn BlockChainConn
+}
+
+// Ensure ChainBlockFetcher implements the ScriptBlockFetcher interface at
+// compile time.
+var _ ScriptBlockFetcher = (*ChainBlockFetcher)(nil)
+
+// NewChainBlockFetcher creates a new block fetcher backed by the given chain
+// connection.
+func NewChainBlockFetcher(conn BlockChainConn) *ChainBlockFetcher {
+	return &ChainBlockFetcher{conn: conn}
+}
+
+// FetchScriptBlocks passes the transactions of the blocks from startHeight up
+// to and including endHeight to process in order, until it returns true.
+//
+// NOTE: This is part of the ScriptBlockFetcher interface.
+func (f *ChainBlockFetcher) FetchScriptBlocks(_ []byte, startHeight,
+	endHeight uint32,
+	process func(*chainhash.Hash, uint32, []*btcutil.Tx) bool) (uint32,
+	error) {
+
+	for height := startHeight; height <= endHeight; height++ {
+		blockHash, err := f.conn.GetBlockHash(int64(height))
+		if err != nil {
+			return height, fmt.Errorf("unable to get hash from "+
+				"block with height %d", height)
+		}
+
+		block, err := f.conn.GetBlock(blockHash)
+		if err != nil {
+			return height, fmt.Errorf("unable to get block with "+
+				"hash %v: %v", blockHash, err)
+		}
+
+		txns := btcutil.NewBlock(block).Transactions()
+		if process(blockHash, height, txns) {
+			return height + 1, nil
+		}
+	}
+
+	if endHeight < startHeight {
+		return startHeight, nil
+	}
+	return endHeight + 1, nil
+}
+
+// ScriptSpendNtfn represents a client's intent to be notified of the first
+// spend of any output paying to a script.
+type ScriptSpendNtfn struct {
+	// SpendID uniquely identifies the notification among the spend
+	// notifications of the notifier.
+	SpendID uint64
+
+	// HeightHint is the earliest height an output paying to the script
+	// could have been created at.
+	HeightHint uint32
+
+	// Event contains the channels the client is notified through.
+	Event *SpendEvent
+
+	tracker *ScriptSpendTracker
+
+	spendChan chan *SpendDetail
+
+	reorgChan chan struct{}
+
+	// scanning is true while the chain is being scanned for historical
+	// outputs paying to the script and their spends. New blocks aren't
+	// processed for the notification until the scan is complete.
+	scanning bool
+}
+
+// ScriptSpendCancel is a message sent to the notification dispatcher when a
+// client wishes to cancel an outstanding script spend notification that has
+// yet to be dispatched.
+type ScriptSpendCancel struct {
+	// SpendID is the ID of the notification to cancel.
+	SpendID uint64
+}
+
+// NewScriptSpendNtfn creates a new notification for the first spend of any
+// output paying to pkScript. Cancelling its event sends a ScriptSpendCancel
+// to the notification dispatcher through cancels.
+func NewScriptSpendNtfn(spendID uint64, pkScript []byte, heightHint uint32,
+	cancels chan<- interface{}, quit <-chan struct{}) *ScriptSpendNtfn {
+
+	ntfn := &ScriptSpendNtfn{
+		SpendID:    spendID,
+		HeightHint: heightHint,
+		tracker:    NewScriptSpendTracker(pkScript),
+		spendChan:  make(chan *SpendDetail, 1),
+		reorgChan:  make(chan struct{}, 1),
+	}
+	ntfn.Event = &SpendEvent{
+		Spend: ntfn.spendChan,
+		Reorg: ntfn.reorgChan,
+		Cancel: func() {
+			cancel := &ScriptSpendCancel{
+				SpendID: spendID,
+			}
+
+			// Submit spend cancellation to notification dispatcher.
+			select {
+			case cancels <- cancel:
+				// Cancellation is being handled, drain the
+				// spend chan until it is closed before
+				// yielding to the caller.
+				for {
+					select {
+					case _, ok := <-ntfn.spendChan:
+						if !ok {
+							return
+						}
+					case <-quit:
+						return
+					}
+				}
+			case <-quit:
+			}
+		},
+	}
+
+	return ntfn
+}
+
+// ScriptConfNtfn represents a client's intent to be notified once the first
+// transaction paying to a script reaches a number of confirmations.
+type ScriptConfNtfn struct {
+	ConfNtfn
+
+	// HeightHint is the earliest height a transaction paying to the script
+	// could have been included at.
+	HeightHint uint32
+}
+
+// NewScriptConfNtfn creates a new notification for the first transaction
+// paying to pkScript reaching numConfs confirmations. The notification is
+// registered with the TxConfNotifier once the chain has been scanned for
+// historical transactions paying to the script.
+func NewScriptConfNtfn(confID uint64, pkScript []byte, numConfs,
+	heightHint uint32, txConfNotifier *TxConfNotifier) *ScriptConfNtfn {
+
+	ntfn := &ScriptConfNtfn{
+		ConfNtfn: ConfNtfn{
+			ConfID:           confID,
+			PkScript:         pkScript,
+			NumConfirmations: numConfs,
+		},
+		HeightHint: heightHint,
+	}
+	ntfn.Event = NewConfirmationEvent(numConfs, func() {
+		txConfNotifier.CancelConf(&ntfn.ConfNtfn)
+	})
+
+	return ntfn
+}
+
+// scriptScan is a historical scan of the chain for a notification keyed on a
+// script.
+type scriptScan struct {
+	// Exactly one of confNtfn and spendNtfn is set.
+	confNtfn  *ScriptConfNtfn
+	spendNtfn *ScriptSpendNtfn
+
+	// endHeight is the last height scanned in the background.
+	endHeight uint32
+
+	// reorgHeight is the lowest height at or below endHeight at which a
+	// block was connected during the scan, replacing a block that may
+	// have been scanned already. It's zero if there was no such reorg.
+	reorgHeight uint32
+}
+
+// pkScript returns the script the scan looks for.
+func (s *scriptScan) pkScript() []byte {
+	if s.confNtfn != nil {
+		return s.confNtfn.PkScript
+	}
+	return s.spendNtfn.tracker.PkScript()
+}
+
+// startHeight returns the first height of the scan.
+func (s *scriptScan) startHeight() uint32 {
+	if s.confNtfn != nil {
+		return s.confNtfn.HeightHint
+	}
+	return s.spendNtfn.HeightHint
+}
+
+// ScriptScanResult is handed to the notification dispatcher once the
+// historical scan for a notification keyed on a script is complete.
+type ScriptScanResult struct {
+	scan *scriptScan
+
+	// nextHeight is the first height that hasn't been scanned.
+	nextHeight uint32
+
+	// confDetails is set if a transaction paying to the script of a
+	// confirmation notification was found.
+	confDetails *TxConfirmation
+
+	// spendDetails is set if a spend of an output paying to the script of
+	// a spend notification was found.
+	spendDetails *SpendDetail
+}
+
+// process records the notification's details found in the passed block. It
+// returns true once they're found.
+func (r *ScriptScanResult) process(blockHash *chainhash.Hash, height uint32,
+	txns []*btcutil.Tx) bool {
+
+	if r.scan.confNtfn != nil {
+		r.confDetails = ScriptConfDetails(
+			r.scan.confNtfn.PkScript, blockHash, height, txns,
+		)
+		return r.confDetails != nil
+	}
+
+	r.spendDetails = r.scan.spendNtfn.tracker.ProcessBlock(
+		int32(height), txns,
+	)
+	return r.spendDetails != nil
+}
+
+// rewind discards everything the scan found at or above the given height, as
+// the blocks have been disconnected from the chain.
+func (r *ScriptScanResult) rewind(height uint32) {
+	if height >= r.nextHeight {
+		return
+	}
+	r.nextHeight = height
+
+	if r.confDetails != nil && r.confDetails.BlockHeight >= height {
+		r.confDetails = nil
+	}
+	if r.scan.spendNtfn != nil {
+		details := r.spendDetails
+		if details != nil && uint32(details.SpendingHeight) >= height {
+			r.spendDetails = nil
+		}
+		r.scan.spendNtfn.tracker.DisconnectBlocks(int32(height))
+	}
+}
+
+// ScriptNotifierConfig contains the dependencies of a ScriptNotifier.
+type ScriptNotifierConfig struct {
+	// Fetcher fetches the blocks scanned for historical transactions
+	// paying to or spending from the scripts.
+	Fetcher ScriptBlockFetcher
+
+	// TxConfNotifier is the TxConfNotifier of the chain notifier.
+	// Confirmation notifications are registered with it once a
+	// transaction paying to their script is found, and dispatched spends
+	// are watched for reorgs.
+	TxConfNotifier *TxConfNotifier
+
+	// ScanResults delivers the results of historical scans to the
+	// notification dispatcher, which must pass them to HandleScanResult.
+	ScanResults chan<- interface{}
+
+	// Quit is closed once the chain notifier is shutting down.
+	Quit <-chan struct{}
+
+	// Wg tracks the goroutines of historical scans.
+	Wg *sync.WaitGroup
+}
+
+// ScriptNotifier implements the notifications keyed on a script for a chain
+// notifier. It scans the chain for historical transactions paying to or
+// spending from the scripts when they're registered, and processes the
+// blocks connected afterwards.
+//
+// NOTE: Apart from the constructors of notifications, the ScriptNotifier
+// must only be used from the notification dispatcher of the chain notifier.
+type ScriptNotifier struct {
+	cfg ScriptNotifierConfig
+
+	spendNtfns map[uint64]*ScriptSpendNtfn
+
+	// scans is the set of historical scans that are in progress.
+	scans map[*scriptScan]struct{}
+}
+
+// NewScriptNotifier creates a new ScriptNotifier from the given config.
+func NewScriptNotifier(cfg ScriptNotifierConfig) *ScriptNotifier {
+	return &ScriptNotifier{
+		cfg:        cfg,
+		spendNtfns: make(map[uint64]*ScriptSpendNtfn),
+		scans:      make(map[*scriptScan]struct{}),
+	}
+}
+
+// RegisterSpend adds a script spend notification and scans the chain up to
+// bestHeight for historical spends.
+func (n *ScriptNotifier) RegisterSpend(ntfn *ScriptSpendNtfn,
+	bestHeight uint32) {
+
+	Log.Infof("New spend subscription: script=%x, height_hint=%v",
+		ntfn.tracker.PkScript(), ntfn.HeightHint)
+
+	// The notification is only processed for new blocks once the chain
+	// has been scanned for historical spends.
+	ntfn.scanning = true
+	n.spendNtfns[ntfn.SpendID] = ntfn
+
+	n.startScan(&scriptScan{spendNtfn: ntfn, endHeight: bestHeight})
+}
+
+// RegisterConf scans the chain up to bestHeight for the first transaction
+// paying to the script of a confirmation notification, and registers the
+// notification with the TxConfNotifier once done.
+func (n *ScriptNotifier) RegisterConf(ntfn *ScriptConfNtfn,
+	bestHeight uint32) {
+
+	Log.Infof("New confirmation subscription: script=%x, numconfs=%v",
+		ntfn.PkScript, ntfn.NumConfirmations)
+
+	n.startScan(&scriptScan{confNtfn: ntfn, endHeight: bestHeight})
+}
+
+// CancelSpend cancels the script spend notification with the given ID.
+func (n *ScriptNotifier) CancelSpend(spendID uint64) {
+	Log.Infof("Cancelling script spend notification, spend_id=%v",
+		spendID)
+
+	// The spend may have been dispatched already, so we'll stop watching
+	// it for reorgs.
+	n.cfg.TxConfNotifier.CancelSpendReorg(spendID)
+
+	ntfn, ok := n.spendNtfns[spendID]
+	if !ok {
+		return
+	}
+	close(ntfn.spendChan)
+	delete(n.spendNtfns, spendID)
+}
+
+// startScan scans the chain for the notification of the passed scan in the
+// background.
+func (n *ScriptNotifier) startScan(scan *scriptScan) {
+	n.scans[scan] = struct{}{}
+
+	n.cfg.Wg.Add(1)
+	go n.scanHistory(scan)
+}
+
+// scanHistory scans the chain from the notification's height hint up to the
+// end height of the scan. The result is handed to the notification
+// dispatcher, which catches up with the blocks connected in the meantime.
+//
+// NOTE: This must be run as a goroutine.
+func (n *ScriptNotifier) scanHistory(scan *scriptScan) {
+	defer n.cfg.Wg.Done()
+
+	result := &ScriptScanResult{scan: scan}
+	nextHeight, err := n.scanBlocks(
+		result, scan.startHeight(), scan.endHeight,
+	)
+	if err != nil {
+		Log.Errorf("Unable to scan chain for script: %v", err)
+	}
+	result.nextHeight = nextHeight
+
+	select {
+	case n.cfg.ScanResults <- result:
+	case <-n.cfg.Quit:
+	}
+}
+
+// scanBlocks processes the blocks from startHeight up to and including
+// endHeight for the notification of the passed result, until its details are
+// found. The first height that hasn't been scanned is returned.
+func (n *ScriptNotifier) scanBlocks(result *ScriptScanResult, startHeight,
+	endHeight uint32) (uint32, error) {
+
+	return n.cfg.Fetcher.FetchScriptBlocks(
+		result.scan.pkScript(), startHeight, endHeight,
+		func(blockHash *chainhash.Hash, height uint32,
+			txns []*btcutil.Tx) bool {
+
+			// Abort the scan if we're shutting down.
+			select {
+			case <-n.cfg.Quit:
+				return true
+			default:
+			}
+
+			return result.process(blockHash, height, txns)
+		},
+	)
+}
+
+// HandleScanResult completes the registration of a notification keyed on a
+// script once its historical scan is done. As blocks may have been connected
+// during the scan, they're scanned first up to bestHeight.
+func (n *ScriptNotifier) HandleScanResult(result *ScriptScanResult,
+	bestHeight uint32) {
+
+	scan := result.scan
+	delete(n.scans, scan)
+
+	// If blocks within the scanned range have been replaced during the
+	// scan, we'll rescan them.
+	if scan.reorgHeight != 0 {
+		result.rewind(scan.reorgHeight)
+	}
+
+	if result.confDetails == nil && result.spendDetails == nil {
+		_, err := n.scanBlocks(result, result.nextHeight, bestHeight)
+		if err != nil {
+			Log.Errorf("Unable to scan chain for script: %v", err)
+		}
+	}
+
+	if ntfn := scan.confNtfn; ntfn != nil {
+		// If a transaction paying to the script has been found, it
+		// becomes the target of the notification. Otherwise, the
+		// notification remains keyed on the script until one is
+		// included in a new block.
+		details := result.confDetails
+		if details != nil {
+			txid := details.Tx.TxHash()
+			ntfn.TxID = &txid
+		}
+
+		err := n.cfg.TxConfNotifier.Register(&ntfn.ConfNtfn)
+		if err != nil {
+			Log.Error(err)
+			return
+		}
+
+		if details != nil {
+			err := n.cfg.TxConfNotifier.UpdateConfDetails(
+				*ntfn.TxID, ntfn.ConfID, details,
+			)
+			if err != nil {
+				Log.Error(err)
+			}
+		}
+		return
+	}
+
+	// The notification may have been cancelled during the scan.
+	ntfn := scan.spendNtfn
+	if _, ok := n.spendNtfns[ntfn.SpendID]; !ok {
+		return
+	}
+
+	if result.spendDetails == nil {
+		ntfn.scanning = false
+		return
+	}
+
+	delete(n.spendNtfns, ntfn.SpendID)
+	n.dispatchSpend(ntfn, result.spendDetails)
+}
+
+// ConnectTip processes a newly connected block for the active script spend
+// notifications. The notifications for which a spend was found are removed
+// and returned along with the spend details, which must be dispatched with
+// DispatchSpends.
+//
+// A block at a height that has been processed before replaces a block that
+// has been disconnected from the chain, so the outputs found in the
+// disconnected blocks are forgotten before the block is processed.
+func (n *ScriptNotifier) ConnectTip(height uint32,
+	txns []*btcutil.Tx) map[*ScriptSpendNtfn]*SpendDetail {
+
+	for scan := range n.scans {
+		if height > scan.endHeight {
+			continue
+		}
+		if scan.reorgHeight == 0 || height < scan.reorgHeight {
+			scan.reorgHeight = height
+		}
+	}
+
+	spends := make(map[*ScriptSpendNtfn]*SpendDetail)
+	for spendID, ntfn := range n.spendNtfns {
+		if ntfn.scanning {
+			continue
+		}
+
+		ntfn.tracker.DisconnectBlocks(int32(height))
+
+		details := ntfn.tracker.ProcessBlock(int32(height), txns)
+		if details == nil {
+			continue
+		}
+
+		delete(n.spendNtfns, spendID)
+		spends[ntfn] = details
+	}
+
+	return spends
+}
+
+// DispatchSpends sends the spend details returned by ConnectTip to the
+// clients of the notifications.
+func (n *ScriptNotifier) DispatchSpends(
+	spends map[*ScriptSpendNtfn]*SpendDetail) {
+
+	for ntfn, details := range spends {
+		n.dispatchSpend(ntfn, details)
+	}
+}
+
+// dispatchSpend sends the spend details to the client of a script spend
+// notification, and watches the spending transaction for reorgs.
+func (n *ScriptNotifier) dispatchSpend(ntfn *ScriptSpendNtfn,
+	details *SpendDetail) {
+
+	Log.Infof("Dispatching spend notification for script=%x, "+
+		"outpoint=%v", ntfn.tracker.PkScript(), details.SpentOutPoint)
+
+	ntfn.spendChan <- details
+
+	// Close spendChan to ensure that any calls to Cancel will not block.
+	// This is safe to do since the channel is buffered, and the message
+	// can still be read by the receiver.
+	close(ntfn.spendChan)
+
+	n.cfg.TxConfNotifier.WatchSpendReorg(
+		ntfn.SpendID, uint32(details.SpendingHeight), ntfn.reorgChan,
+	)
+}
+
+// PkScripts returns the scripts of the spend notifications that process new
+// blocks.
+func (n *ScriptNotifier) PkScripts() [][]byte {
+	scripts := make([][]byte, 0, len(n.spendNtfns))
+	for _, ntfn := range n.spendNtfns {
+		if ntfn.scanning {
+			continue
+		}
+		scripts = append(scripts, ntfn.tracker.PkScript())
+	}
+
+	return scripts
+}
+
+// TearDown notifies the clients of all pending spend notifications of the
+// shutdown by closing their spend channels.
+func (n *ScriptNotifier) TearDown() {
+	for _, ntfn := range n.spendNtfns {
+		close(ntfn.spendChan)
+	}
+}
@@ -0,0 +1,153 @@
+package chainntnfs_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// mockBlockFetcher is a ScriptBlockFetcher serving the blocks of a mock chain.
+type mockBlockFetcher struct {
+	mu     sync.Mutex
+	blocks map[uint32][]*wire.MsgTx
+}
+
+func (f *mockBlockFetcher) setBlock(height uint32, txs ...*wire.MsgTx) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.blocks[height] = txs
+}
+
+func (f *mockBlockFetcher) FetchScriptBlocks(_ []byte, startHeight,
+	endHeight uint32,
+	process func(*chainhash.Hash, uint32, []*btcutil.Tx) bool) (uint32,
+	error) {
+
+	for height := startHeight; height <= endHeight; height++ {
+		f.mu.Lock()
+		txs := f.blocks[height]
+		f.mu.Unlock()
+
+		if process(&zeroHash, height, newBlockTxns(txs...)) {
+			return height + 1, nil
+		}
+	}
+
+	if endHeight < startHeight {
+		return startHeight, nil
+	}
+	return endHeight + 1, nil
+}
+
+// newBlockTxns returns the transactions of a block containing the given ones.
+func newBlockTxns(txs ...*wire.MsgTx) []*btcutil.Tx {
+	return btcutil.NewBlock(&wire.MsgBlock{
+		Transactions: txs,
+	}).Transactions()
+}
+
+// TestScriptNotifierReorg tests that outputs paying to the script of a spend
+// notification are forgotten once the blocks that created them are
+// disconnected, both while the chain is scanned for historical spends and
+// afterwards.
+func TestScriptNotifierReorg(t *testing.T) {
+	t.Parallel()
+
+	pkScript := []byte{0x00, 0x14, 0x01}
+	fundingTx := &wire.MsgTx{
+		Version: 1,
+		TxOut:   []*wire.TxOut{{PkScript: pkScript}},
+	}
+	spendingTx := &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{
+				Hash: fundingTx.TxHash(),
+			},
+		}},
+	}
+
+	// The historical scan will find the output paying to the script at
+	// height 5.
+	fetcher := &mockBlockFetcher{
+		blocks: map[uint32][]*wire.MsgTx{
+			5: {fundingTx},
+		},
+	}
+	scanResults := make(chan interface{}, 1)
+	quit := make(chan struct{})
+	defer close(quit)
+
+	var wg sync.WaitGroup
+	notifier := chainntnfs.NewScriptNotifier(chainntnfs.ScriptNotifierConfig{
+		Fetcher: fetcher,
+		TxConfNotifier: chainntnfs.NewTxConfNotifier(
+			6, 100, newMockHintCache(),
+		),
+		ScanResults: scanResults,
+		Quit:        quit,
+		Wg:          &wg,
+	})
+
+	ntfn := chainntnfs.NewScriptSpendNtfn(
+		1, pkScript, 1, make(chan interface{}), quit,
+	)
+	notifier.RegisterSpend(ntfn, 6)
+	wg.Wait()
+
+	// Before the scan result is handled, block 5 is replaced by a block
+	// without the output, and the output is spent in a new block. As the
+	// output doesn't exist in the new chain, the spend must not be
+	// detected.
+	fetcher.setBlock(5)
+	fetcher.setBlock(7, spendingTx)
+	connectTip := func(height uint32, txs ...*wire.MsgTx) {
+		spends := notifier.ConnectTip(height, newBlockTxns(txs...))
+		notifier.DispatchSpends(spends)
+	}
+	connectTip(5)
+	connectTip(6)
+	connectTip(7, spendingTx)
+
+	result := (<-scanResults).(*chainntnfs.ScriptScanResult)
+	notifier.HandleScanResult(result, 7)
+
+	select {
+	case details := <-ntfn.Event.Spend:
+		t.Fatalf("unexpected spend of disconnected output: %v",
+			details)
+	default:
+	}
+
+	// The same applies to an output found in a new block that is
+	// disconnected afterwards.
+	connectTip(8, fundingTx)
+	connectTip(8)
+	connectTip(9, spendingTx)
+
+	select {
+	case details := <-ntfn.Event.Spend:
+		t.Fatalf("unexpected spend of disconnected output: %v",
+			details)
+	default:
+	}
+
+	// Finally, a spend of an output within the active chain is detected.
+	connectTip(10, fundingTx)
+	connectTip(11, spendingTx)
+
+	select {
+	case details := <-ntfn.Event.Spend:
+		if details.SpendingHeight != 11 {
+			t.Fatalf("expected spend at height 11, got %d",
+				details.SpendingHeight)
+		}
+	default:
+		t.Fatalf("expected spend to be detected")
+	}
+}
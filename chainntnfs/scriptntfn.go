@@ -0,0 +1,111 @@
+package chainntnfs
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// ScriptSpendTracker tracks the outputs paying to a script in order to detect
+// the first spend of any of them. As these outputs aren't known in advance,
+// the blocks must be passed to the tracker in order, starting at the earliest
+// height an output paying to the script could have been created at. Blocks
+// that are disconnected from the chain must be rolled back with
+// DisconnectBlocks.
+type ScriptSpendTracker struct {
+	pkScript []byte
+
+	// outpoints is the set of outputs paying to the script that have been
+	// found so far, mapped to the height of the block that created them.
+	outpoints map[wire.OutPoint]int32
+}
+
+// NewScriptSpendTracker creates a new tracker for the given output script.
+func NewScriptSpendTracker(pkScript []byte) *ScriptSpendTracker {
+	return &ScriptSpendTracker{
+		pkScript:  pkScript,
+		outpoints: make(map[wire.OutPoint]int32),
+	}
+}
+
+// PkScript returns the output script that's being tracked.
+func (t *ScriptSpendTracker) PkScript() []byte {
+	return t.pkScript
+}
+
+// ProcessBlock scans the transactions of the block at the given height in
+// order. It returns the details of the first spend of an output paying to the
+// script, or nil if the block doesn't contain one.
+func (t *ScriptSpendTracker) ProcessBlock(height int32,
+	txns []*btcutil.Tx) *SpendDetail {
+
+	for _, tx := range txns {
+		msgTx := tx.MsgTx()
+		for i, txIn := range msgTx.TxIn {
+			prevOut := txIn.PreviousOutPoint
+			if _, ok := t.outpoints[prevOut]; !ok {
+				continue
+			}
+
+			return &SpendDetail{
+				SpentOutPoint:     &prevOut,
+				SpenderTxHash:     tx.Hash(),
+				SpendingTx:        msgTx,
+				SpenderInputIndex: uint32(i),
+				SpendingHeight:    height,
+			}
+		}
+
+		// Outputs created by this transaction could be spent by a
+		// later transaction within the same block, so we'll track them
+		// before moving on.
+		for i, txOut := range msgTx.TxOut {
+			if !bytes.Equal(txOut.PkScript, t.pkScript) {
+				continue
+			}
+
+			t.outpoints[wire.OutPoint{
+				Hash:  *tx.Hash(),
+				Index: uint32(i),
+			}] = height
+		}
+	}
+
+	return nil
+}
+
+// DisconnectBlocks forgets the outputs created by the blocks at or above the
+// given height, as they have been disconnected from the chain. The blocks
+// replacing them must be processed again.
+func (t *ScriptSpendTracker) DisconnectBlocks(height int32) {
+	for op, opHeight := range t.outpoints {
+		if opHeight >= height {
+			delete(t.outpoints, op)
+		}
+	}
+}
+
+// ScriptConfDetails returns the confirmation details of the first transaction
+// of a block that pays to the given output script, or nil if there is none.
+func ScriptConfDetails(pkScript []byte, blockHash *chainhash.Hash,
+	height uint32, txns []*btcutil.Tx) *TxConfirmation {
+
+	for _, tx := range txns {
+		for _, txOut := range tx.MsgTx().TxOut {
+			if !bytes.Equal(txOut.PkScript, pkScript) {
+				continue
+			}
+
+			return &TxConfirmation{
+				BlockHash:   blockHash,
+				BlockHeight: height,
+				TxIndex:     uint32(tx.Index()),
+				Tx:          tx.MsgTx(),
+			}
+		}
+	}
+
+	return nil
+}
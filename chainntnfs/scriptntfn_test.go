@@ -0,0 +1,142 @@
+package chainntnfs_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// TestScriptSpendTracker tests that the tracker detects the first spend of
+// any output paying to its script, including outputs that are created and
+// spent within the same block.
+func TestScriptSpendTracker(t *testing.T) {
+	t.Parallel()
+
+	pkScript := []byte{0x00, 0x14, 0x01}
+
+	// fundingTx pays to the script with its second output, which
+	// spendingTx spends. otherTx spends an unrelated output.
+	fundingTx := &wire.MsgTx{
+		Version: 1,
+		TxOut: []*wire.TxOut{
+			{PkScript: []byte{0x51}},
+			{PkScript: pkScript},
+		},
+	}
+	otherTx := &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{
+				Hash:  fundingTx.TxHash(),
+				Index: 0,
+			},
+		}},
+	}
+	fundingOutPoint := wire.OutPoint{Hash: fundingTx.TxHash(), Index: 1}
+	spendingTx := &wire.MsgTx{
+		Version: 3,
+		TxIn: []*wire.TxIn{
+			{PreviousOutPoint: wire.OutPoint{Index: 5}},
+			{PreviousOutPoint: fundingOutPoint},
+		},
+	}
+
+	newTxns := func(txs ...*wire.MsgTx) []*btcutil.Tx {
+		return btcutil.NewBlock(&wire.MsgBlock{
+			Transactions: txs,
+		}).Transactions()
+	}
+
+	// Spends of outputs that don't pay to the script, as well as spends
+	// preceding the output within a block, shouldn't be detected.
+	tracker := chainntnfs.NewScriptSpendTracker(pkScript)
+	details := tracker.ProcessBlock(
+		10, newTxns(spendingTx, fundingTx, otherTx),
+	)
+	if details != nil {
+		t.Fatalf("unexpected spend detected: %v", details)
+	}
+
+	details = tracker.ProcessBlock(11, newTxns(otherTx, spendingTx))
+	if details == nil {
+		t.Fatalf("expected spend to be detected")
+	}
+	if *details.SpentOutPoint != fundingOutPoint {
+		t.Fatalf("expected spent outpoint %v, got %v",
+			fundingOutPoint, details.SpentOutPoint)
+	}
+	if *details.SpenderTxHash != spendingTx.TxHash() {
+		t.Fatalf("expected spender %v, got %v", spendingTx.TxHash(),
+			details.SpenderTxHash)
+	}
+	if details.SpenderInputIndex != 1 || details.SpendingHeight != 11 {
+		t.Fatalf("unexpected spend details: input index %d, height "+
+			"%d", details.SpenderInputIndex, details.SpendingHeight)
+	}
+
+	// Once the block that created the output is disconnected, its spend
+	// shouldn't be detected anymore.
+	tracker = chainntnfs.NewScriptSpendTracker(pkScript)
+	tracker.ProcessBlock(10, newTxns(fundingTx))
+	tracker.DisconnectBlocks(10)
+	details = tracker.ProcessBlock(10, newTxns(otherTx, spendingTx))
+	if details != nil {
+		t.Fatalf("unexpected spend of disconnected output: %v",
+			details)
+	}
+
+	// Outputs created and spent within the same block should be detected
+	// as well.
+	tracker = chainntnfs.NewScriptSpendTracker(pkScript)
+	details = tracker.ProcessBlock(12, newTxns(fundingTx, spendingTx))
+	if details == nil {
+		t.Fatalf("expected spend to be detected")
+	}
+}
+
+// TestScriptConfDetails tests that the confirmation details of the first
+// transaction paying to a script within a block are returned.
+func TestScriptConfDetails(t *testing.T) {
+	t.Parallel()
+
+	pkScript := []byte{0x00, 0x14, 0x01}
+
+	tx1 := &wire.MsgTx{
+		Version: 1,
+		TxOut:   []*wire.TxOut{{PkScript: []byte{0x51}}},
+	}
+	tx2 := &wire.MsgTx{
+		Version: 2,
+		TxOut:   []*wire.TxOut{{PkScript: pkScript}},
+	}
+	tx3 := &wire.MsgTx{
+		Version: 3,
+		TxOut:   []*wire.TxOut{{PkScript: pkScript}},
+	}
+
+	var blockHash chainhash.Hash
+	txns := btcutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{tx1},
+	}).Transactions()
+	details := chainntnfs.ScriptConfDetails(pkScript, &blockHash, 5, txns)
+	if details != nil {
+		t.Fatalf("unexpected confirmation details: %v", details)
+	}
+
+	txns = btcutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{tx1, tx2, tx3},
+	}).Transactions()
+	details = chainntnfs.ScriptConfDetails(pkScript, &blockHash, 5, txns)
+	if details == nil {
+		t.Fatalf("expected confirmation details")
+	}
+	if details.BlockHeight != 5 || details.TxIndex != 1 ||
+		details.Tx != tx2 {
+
+		t.Fatalf("unexpected confirmation details: height %d, "+
+			"index %d", details.BlockHeight, details.TxIndex)
+	}
+}
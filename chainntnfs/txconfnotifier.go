@@ -27,6 +27,12 @@ type ConfNtfn struct {
 	// are requested.
 	TxID *chainhash.Hash
 
+	// PkScript is the output script the notification is keyed on if it
+	// was registered by script rather than by txid. In that case, TxID is
+	// nil until the first transaction paying to the script is included in
+	// the chain, and is reset if that transaction is reorged out.
+	PkScript []byte
+
 	// NumConfirmations is the number of confirmations after which the
 	// notification is to be sent.
 	NumConfirmations uint32
@@ -41,15 +47,21 @@ type ConfNtfn struct {
 
 	// dispatched is false if the confirmed notification has not been sent yet.
 	dispatched bool
+
+	// cancelled is true once the notification has been cancelled, so that
+	// it's not registered anymore if it was cancelled before registration.
+	cancelled bool
 }
 
 // NewConfirmationEvent constructs a new ConfirmationEvent with newly opened
-// channels.
-func NewConfirmationEvent(numConfs uint32) *ConfirmationEvent {
+// channels. The cancel closure is executed when the caller cancels the
+// notification.
+func NewConfirmationEvent(numConfs uint32, cancel func()) *ConfirmationEvent {
 	return &ConfirmationEvent{
 		Confirmed:    make(chan *TxConfirmation, 1),
 		Updates:      make(chan uint32, numConfs),
 		NegativeConf: make(chan int32, 1),
+		Cancel:       cancel,
 	}
 }
 
@@ -79,6 +91,11 @@ type TxConfNotifier struct {
 	// hash.
 	confNotifications map[chainhash.Hash]map[uint64]*ConfNtfn
 
+	// scriptConfNotifications is an index of notification requests keyed
+	// on an output script, for which no transaction paying to the script
+	// has been included in the chain yet.
+	scriptConfNotifications map[string]map[uint64]*ConfNtfn
+
 	// txsByInitialHeight is an index of watched transactions by the height
 	// that they are included at in the blockchain. This is tracked so that
 	// incorrect notifications are not sent if a transaction is reorganized
@@ -89,6 +106,13 @@ type TxConfNotifier struct {
 	// at which the transaction will have sufficient confirmations.
 	ntfnsByConfirmHeight map[uint32]map[*ConfNtfn]struct{}
 
+	// spendReorgs is an index of the reorg channels of dispatched spend
+	// notifications by the height their spending transaction was included
+	// at, and then by the ID of the spend notification. These are tracked
+	// until the spending transaction can no longer be reorged out of the
+	// chain.
+	spendReorgs map[uint32]map[uint64]chan<- struct{}
+
 	// hintCache is a cache used to maintain the latest height hints for
 	// transactions. Each height hint represents the earliest height at
 	// which the transactions could have been confirmed within the chain.
@@ -107,13 +131,15 @@ func NewTxConfNotifier(startHeight uint32, reorgSafetyLimit uint32,
 	hintCache ConfirmHintCache) *TxConfNotifier {
 
 	return &TxConfNotifier{
-		currentHeight:        startHeight,
-		reorgSafetyLimit:     reorgSafetyLimit,
-		confNotifications:    make(map[chainhash.Hash]map[uint64]*ConfNtfn),
-		txsByInitialHeight:   make(map[uint32]map[chainhash.Hash]struct{}),
-		ntfnsByConfirmHeight: make(map[uint32]map[*ConfNtfn]struct{}),
-		hintCache:            hintCache,
-		quit:                 make(chan struct{}),
+		currentHeight:           startHeight,
+		reorgSafetyLimit:        reorgSafetyLimit,
+		confNotifications:       make(map[chainhash.Hash]map[uint64]*ConfNtfn),
+		scriptConfNotifications: make(map[string]map[uint64]*ConfNtfn),
+		txsByInitialHeight:      make(map[uint32]map[chainhash.Hash]struct{}),
+		ntfnsByConfirmHeight:    make(map[uint32]map[*ConfNtfn]struct{}),
+		spendReorgs:             make(map[uint32]map[uint64]chan<- struct{}),
+		hintCache:               hintCache,
+		quit:                    make(chan struct{}),
 	}
 }
 
@@ -124,6 +150,10 @@ func NewTxConfNotifier(startHeight uint32, reorgSafetyLimit uint32,
 // the confirmation details must be provided with the UpdateConfDetails method,
 // otherwise we will wait for the transaction to confirm even though it already
 // has.
+//
+// If the notification has no TxID, it's keyed on its PkScript instead, and the
+// first transaction paying to the script in a block connected after
+// registration becomes the target transaction.
 func (tcn *TxConfNotifier) Register(ntfn *ConfNtfn) error {
 	select {
 	case <-tcn.quit:
@@ -134,6 +164,20 @@ func (tcn *TxConfNotifier) Register(ntfn *ConfNtfn) error {
 	tcn.Lock()
 	defer tcn.Unlock()
 
+	if ntfn.cancelled {
+		return nil
+	}
+
+	if ntfn.TxID == nil {
+		if len(ntfn.PkScript) == 0 {
+			return errors.New("either a txid or a pkScript must " +
+				"be provided")
+		}
+
+		tcn.addScriptNtfn(ntfn)
+		return nil
+	}
+
 	ntfns, ok := tcn.confNotifications[*ntfn.TxID]
 	if !ok {
 		ntfns = make(map[uint64]*ConfNtfn)
@@ -155,6 +199,86 @@ func (tcn *TxConfNotifier) Register(ntfn *ConfNtfn) error {
 	return nil
 }
 
+// CancelConf cancels a confirmation notification. No further notifications
+// are sent to the client afterwards, but its channels are left open, as the
+// client may still be reading from them. If the notification hasn't been
+// registered yet, it won't be.
+func (tcn *TxConfNotifier) CancelConf(ntfn *ConfNtfn) {
+	tcn.Lock()
+	defer tcn.Unlock()
+
+	Log.Infof("Cancelling conf notification, conf_id=%v", ntfn.ConfID)
+
+	ntfn.cancelled = true
+
+	// A notification keyed on a script that has no target transaction yet
+	// is only found within the index of script notifications.
+	if ntfn.TxID == nil {
+		script := string(ntfn.PkScript)
+		ntfns := tcn.scriptConfNotifications[script]
+		delete(ntfns, ntfn.ConfID)
+		if len(ntfns) == 0 {
+			delete(tcn.scriptConfNotifications, script)
+		}
+		return
+	}
+
+	ntfns := tcn.confNotifications[*ntfn.TxID]
+	delete(ntfns, ntfn.ConfID)
+	if len(ntfns) == 0 {
+		delete(tcn.confNotifications, *ntfn.TxID)
+	}
+
+	// If the transaction is already included in the chain, we'll also
+	// make sure the confirmation notification isn't dispatched.
+	if ntfn.details != nil {
+		confHeight := ntfn.details.BlockHeight +
+			ntfn.NumConfirmations - 1
+		delete(tcn.ntfnsByConfirmHeight[confHeight], ntfn)
+	}
+}
+
+// WatchSpendReorg watches the spending transaction of a dispatched spend
+// notification, which was included in the chain at spendHeight. If the block
+// of the spending transaction is disconnected before it can no longer be
+// reorged out of the chain, the reorg channel is sent upon.
+func (tcn *TxConfNotifier) WatchSpendReorg(spendID uint64, spendHeight uint32,
+	reorg chan<- struct{}) {
+
+	tcn.Lock()
+	defer tcn.Unlock()
+
+	if spendHeight+tcn.reorgSafetyLimit <= tcn.currentHeight {
+		return
+	}
+
+	spends, ok := tcn.spendReorgs[spendHeight]
+	if !ok {
+		spends = make(map[uint64]chan<- struct{})
+		tcn.spendReorgs[spendHeight] = spends
+	}
+	spends[spendID] = reorg
+}
+
+// CancelSpendReorg stops watching the spending transaction of the spend
+// notification with the given ID for reorgs.
+func (tcn *TxConfNotifier) CancelSpendReorg(spendID uint64) {
+	tcn.Lock()
+	defer tcn.Unlock()
+
+	for height, spends := range tcn.spendReorgs {
+		if _, ok := spends[spendID]; !ok {
+			continue
+		}
+
+		delete(spends, spendID)
+		if len(spends) == 0 {
+			delete(tcn.spendReorgs, height)
+		}
+		return
+	}
+}
+
 // UpdateConfDetails attempts to update the confirmation details for an active
 // notification within the notifier. This should only be used in the case of a
 // transaction that has confirmed before the notifier's current height.
@@ -292,6 +416,16 @@ func (tcn *TxConfNotifier) ConnectTip(blockHash *chainhash.Hash,
 	tcn.currentHeight++
 	tcn.reorgDepth = 0
 
+	// Before looking for watched transactions, we'll bind the
+	// notifications keyed on a script to the first transaction in this
+	// block paying to it, so that they're handled like any other watched
+	// transaction from here on.
+	if len(tcn.scriptConfNotifications) > 0 {
+		for _, tx := range txns {
+			tcn.bindScriptNtfns(tx)
+		}
+	}
+
 	// Record any newly confirmed transactions by their confirmed height so
 	// that notifications get dispatched when the transactions reach their
 	// required number of confirmations. We'll also watch these transactions
@@ -305,6 +439,9 @@ func (tcn *TxConfNotifier) ConnectTip(blockHash *chainhash.Hash,
 				BlockHeight: blockHeight,
 				TxIndex:     uint32(tx.Index()),
 			}
+			if ntfn.PkScript != nil {
+				ntfn.details.Tx = tx.MsgTx()
+			}
 
 			confHeight := blockHeight + ntfn.NumConfirmations - 1
 			ntfnSet, exists := tcn.ntfnsByConfirmHeight[confHeight]
@@ -425,6 +562,7 @@ out:
 			delete(tcn.confNotifications, txHash)
 		}
 		delete(tcn.txsByInitialHeight, matureBlockHeight)
+		delete(tcn.spendReorgs, matureBlockHeight)
 	}
 
 	return nil
@@ -510,24 +648,39 @@ func (tcn *TxConfNotifier) DisconnectTip(blockHeight uint32) error {
 						case <-tcn.quit:
 							return ErrTxConfNotifierExiting
 						}
-
-						continue
+					} else {
+						// Otherwise, since the transactions was reorged
+						// out of the chain, we can safely remove its
+						// accompanying confirmation notification.
+						confHeight := blockHeight +
+							ntfn.NumConfirmations - 1
+						ntfnSet := tcn.ntfnsByConfirmHeight[confHeight]
+						delete(ntfnSet, ntfn)
 					}
 
-					// Otherwise, since the transactions was reorged out
-					// of the chain, we can safely remove its accompanying
-					// confirmation notification.
-					confHeight := blockHeight + ntfn.NumConfirmations - 1
-					ntfnSet, exists := tcn.ntfnsByConfirmHeight[confHeight]
-					if !exists {
-						continue
+					// A notification keyed on a script no longer
+					// has a target transaction, so it's watching
+					// the script again.
+					if ntfn.PkScript != nil {
+						tcn.unbindScriptNtfn(ntfn)
 					}
-					delete(ntfnSet, ntfn)
 				}
 			}
 		}
 	}
 
+	// Any spending transactions included in this block have been reorged
+	// out of the chain, so we'll notify the clients of their spend
+	// notifications. The reorg channels are buffered and only sent upon
+	// once, so this never blocks.
+	for spendID, reorg := range tcn.spendReorgs[blockHeight] {
+		Log.Infof("Dispatching spend reorg notification, "+
+			"spend_id=%v", spendID)
+
+		reorg <- struct{}{}
+	}
+	delete(tcn.spendReorgs, blockHeight)
+
 	// Finally, we can remove the transactions we're currently watching that
 	// were included in this block height.
 	delete(tcn.txsByInitialHeight, blockHeight)
@@ -555,4 +708,88 @@ func (tcn *TxConfNotifier) TearDown() {
 			close(ntfn.Event.NegativeConf)
 		}
 	}
+
+	for _, ntfns := range tcn.scriptConfNotifications {
+		for _, ntfn := range ntfns {
+			close(ntfn.Event.Confirmed)
+			close(ntfn.Event.Updates)
+			close(ntfn.Event.NegativeConf)
+		}
+	}
+}
+
+// UnconfirmedScripts returns the output scripts of the notifications keyed on
+// a script for which no paying transaction has been included in the chain
+// yet.
+func (tcn *TxConfNotifier) UnconfirmedScripts() [][]byte {
+	tcn.Lock()
+	defer tcn.Unlock()
+
+	scripts := make([][]byte, 0, len(tcn.scriptConfNotifications))
+	for script := range tcn.scriptConfNotifications {
+		scripts = append(scripts, []byte(script))
+	}
+
+	return scripts
+}
+
+// addScriptNtfn adds a notification to the index of notifications keyed on a
+// script.
+//
+// NOTE: This method must be called with the TxConfNotifier's lock held.
+func (tcn *TxConfNotifier) addScriptNtfn(ntfn *ConfNtfn) {
+	script := string(ntfn.PkScript)
+	ntfns, ok := tcn.scriptConfNotifications[script]
+	if !ok {
+		ntfns = make(map[uint64]*ConfNtfn)
+		tcn.scriptConfNotifications[script] = ntfns
+	}
+	ntfns[ntfn.ConfID] = ntfn
+}
+
+// bindScriptNtfns sets the given transaction as the target transaction of all
+// notifications keyed on a script it pays to, moving them to the index of
+// notifications by transaction hash.
+//
+// NOTE: This method must be called with the TxConfNotifier's lock held.
+func (tcn *TxConfNotifier) bindScriptNtfns(tx *btcutil.Tx) {
+	txHash := tx.Hash()
+	for _, txOut := range tx.MsgTx().TxOut {
+		script := string(txOut.PkScript)
+		scriptNtfns, ok := tcn.scriptConfNotifications[script]
+		if !ok {
+			continue
+		}
+		delete(tcn.scriptConfNotifications, script)
+
+		ntfns, ok := tcn.confNotifications[*txHash]
+		if !ok {
+			ntfns = make(map[uint64]*ConfNtfn)
+			tcn.confNotifications[*txHash] = ntfns
+		}
+		for _, ntfn := range scriptNtfns {
+			Log.Debugf("Tx %v pays to script of conf notification "+
+				"%d", txHash, ntfn.ConfID)
+
+			ntfn.TxID = txHash
+			ntfns[ntfn.ConfID] = ntfn
+		}
+	}
+}
+
+// unbindScriptNtfn resets the target transaction of a notification keyed on a
+// script after the transaction has been reorged out of the chain, moving it
+// back to the index of notifications keyed on a script.
+//
+// NOTE: This method must be called with the TxConfNotifier's lock held.
+func (tcn *TxConfNotifier) unbindScriptNtfn(ntfn *ConfNtfn) {
+	ntfns := tcn.confNotifications[*ntfn.TxID]
+	delete(ntfns, ntfn.ConfID)
+	if len(ntfns) == 0 {
+		delete(tcn.confNotifications, *ntfn.TxID)
+	}
+
+	ntfn.TxID = nil
+	ntfn.details = nil
+	tcn.addScriptNtfn(ntfn)
 }
@@ -1,6 +1,7 @@
 package chainntnfs_test
 
 import (
+	"bytes"
 	"sync"
 	"testing"
 
@@ -122,7 +123,7 @@ func TestTxConfFutureDispatch(t *testing.T) {
 	ntfn1 := chainntnfs.ConfNtfn{
 		TxID:             &tx1Hash,
 		NumConfirmations: tx1NumConfs,
-		Event:            chainntnfs.NewConfirmationEvent(tx1NumConfs),
+		Event:            chainntnfs.NewConfirmationEvent(tx1NumConfs, nil),
 	}
 	if err := txConfNotifier.Register(&ntfn1); err != nil {
 		t.Fatalf("unable to register ntfn: %v", err)
@@ -132,7 +133,7 @@ func TestTxConfFutureDispatch(t *testing.T) {
 	ntfn2 := chainntnfs.ConfNtfn{
 		TxID:             &tx2Hash,
 		NumConfirmations: tx2NumConfs,
-		Event:            chainntnfs.NewConfirmationEvent(tx2NumConfs),
+		Event:            chainntnfs.NewConfirmationEvent(tx2NumConfs, nil),
 	}
 	if err := txConfNotifier.Register(&ntfn2); err != nil {
 		t.Fatalf("unable to register ntfn: %v", err)
@@ -296,7 +297,7 @@ func TestTxConfHistoricalDispatch(t *testing.T) {
 		ConfID:           0,
 		TxID:             &tx1Hash,
 		NumConfirmations: tx1NumConfs,
-		Event:            chainntnfs.NewConfirmationEvent(tx1NumConfs),
+		Event:            chainntnfs.NewConfirmationEvent(tx1NumConfs, nil),
 	}
 	if err := txConfNotifier.Register(&ntfn1); err != nil {
 		t.Fatalf("unable to register ntfn: %v", err)
@@ -307,7 +308,7 @@ func TestTxConfHistoricalDispatch(t *testing.T) {
 		ConfID:           1,
 		TxID:             &tx2Hash,
 		NumConfirmations: tx2NumConfs,
-		Event:            chainntnfs.NewConfirmationEvent(tx2NumConfs),
+		Event:            chainntnfs.NewConfirmationEvent(tx2NumConfs, nil),
 	}
 	if err := txConfNotifier.Register(&ntfn2); err != nil {
 		t.Fatalf("unable to register ntfn: %v", err)
@@ -446,7 +447,7 @@ func TestTxConfChainReorg(t *testing.T) {
 	ntfn1 := chainntnfs.ConfNtfn{
 		TxID:             &tx1Hash,
 		NumConfirmations: tx1NumConfs,
-		Event:            chainntnfs.NewConfirmationEvent(tx1NumConfs),
+		Event:            chainntnfs.NewConfirmationEvent(tx1NumConfs, nil),
 	}
 	if err := txConfNotifier.Register(&ntfn1); err != nil {
 		t.Fatalf("unable to register ntfn: %v", err)
@@ -457,7 +458,7 @@ func TestTxConfChainReorg(t *testing.T) {
 	ntfn2 := chainntnfs.ConfNtfn{
 		TxID:             &tx2Hash,
 		NumConfirmations: tx2NumConfs,
-		Event:            chainntnfs.NewConfirmationEvent(tx2NumConfs),
+		Event:            chainntnfs.NewConfirmationEvent(tx2NumConfs, nil),
 	}
 	if err := txConfNotifier.Register(&ntfn2); err != nil {
 		t.Fatalf("unable to register ntfn: %v", err)
@@ -468,7 +469,7 @@ func TestTxConfChainReorg(t *testing.T) {
 	ntfn3 := chainntnfs.ConfNtfn{
 		TxID:             &tx3Hash,
 		NumConfirmations: tx3NumConfs,
-		Event:            chainntnfs.NewConfirmationEvent(tx3NumConfs),
+		Event:            chainntnfs.NewConfirmationEvent(tx3NumConfs, nil),
 	}
 	if err := txConfNotifier.Register(&ntfn3); err != nil {
 		t.Fatalf("unable to register ntfn: %v", err)
@@ -697,7 +698,7 @@ func TestTxConfHeightHintCache(t *testing.T) {
 	ntfn1 := &chainntnfs.ConfNtfn{
 		TxID:             &tx1Hash,
 		NumConfirmations: 1,
-		Event:            chainntnfs.NewConfirmationEvent(1),
+		Event:            chainntnfs.NewConfirmationEvent(1, nil),
 	}
 
 	tx2 := wire.MsgTx{Version: 2}
@@ -705,7 +706,7 @@ func TestTxConfHeightHintCache(t *testing.T) {
 	ntfn2 := &chainntnfs.ConfNtfn{
 		TxID:             &tx2Hash,
 		NumConfirmations: 2,
-		Event:            chainntnfs.NewConfirmationEvent(2),
+		Event:            chainntnfs.NewConfirmationEvent(2, nil),
 	}
 
 	if err := txConfNotifier.Register(ntfn1); err != nil {
@@ -832,7 +833,7 @@ func TestTxConfTearDown(t *testing.T) {
 	ntfn1 := chainntnfs.ConfNtfn{
 		TxID:             &tx1Hash,
 		NumConfirmations: 1,
-		Event:            chainntnfs.NewConfirmationEvent(1),
+		Event:            chainntnfs.NewConfirmationEvent(1, nil),
 	}
 	if err := txConfNotifier.Register(&ntfn1); err != nil {
 		t.Fatalf("unable to register ntfn: %v", err)
@@ -842,7 +843,7 @@ func TestTxConfTearDown(t *testing.T) {
 	ntfn2 := chainntnfs.ConfNtfn{
 		TxID:             &tx2Hash,
 		NumConfirmations: 2,
-		Event:            chainntnfs.NewConfirmationEvent(2),
+		Event:            chainntnfs.NewConfirmationEvent(2, nil),
 	}
 	if err := txConfNotifier.Register(&ntfn2); err != nil {
 		t.Fatalf("unable to register ntfn: %v", err)
@@ -918,6 +919,188 @@ func TestTxConfTearDown(t *testing.T) {
 	}
 }
 
+// TestTxConfScriptDispatch tests that a notification keyed on a script tracks
+// the first transaction paying to the script, and the first one in the new
+// chain after it has been reorged out.
+func TestTxConfScriptDispatch(t *testing.T) {
+	t.Parallel()
+
+	const numConfs uint32 = 2
+
+	pkScript := []byte{0x00, 0x14, 0x01}
+
+	var (
+		// tx1 doesn't pay to the script, while tx2 and tx3 do.
+		tx1 = wire.MsgTx{
+			Version: 1,
+			TxOut:   []*wire.TxOut{{PkScript: []byte{0x51}}},
+		}
+		tx2 = wire.MsgTx{
+			Version: 2,
+			TxOut:   []*wire.TxOut{{PkScript: pkScript}},
+		}
+		tx3 = wire.MsgTx{
+			Version: 3,
+			TxOut: []*wire.TxOut{
+				{PkScript: []byte{0x51}},
+				{PkScript: pkScript},
+			},
+		}
+	)
+
+	hintCache := newMockHintCache()
+	txConfNotifier := chainntnfs.NewTxConfNotifier(7, 100, hintCache)
+
+	// A notification needs either a txid or a script.
+	err := txConfNotifier.Register(&chainntnfs.ConfNtfn{
+		NumConfirmations: numConfs,
+		Event:            chainntnfs.NewConfirmationEvent(numConfs, nil),
+	})
+	if err == nil {
+		t.Fatalf("expected registration without txid and script to fail")
+	}
+
+	ntfn := chainntnfs.ConfNtfn{
+		PkScript:         pkScript,
+		NumConfirmations: numConfs,
+		Event:            chainntnfs.NewConfirmationEvent(numConfs, nil),
+	}
+	if err := txConfNotifier.Register(&ntfn); err != nil {
+		t.Fatalf("unable to register ntfn: %v", err)
+	}
+
+	scripts := txConfNotifier.UnconfirmedScripts()
+	if len(scripts) != 1 || !bytes.Equal(scripts[0], pkScript) {
+		t.Fatalf("expected unconfirmed script %x, got %x", pkScript,
+			scripts)
+	}
+
+	// A block without a transaction paying to the script shouldn't affect
+	// the notification.
+	block1 := btcutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{&tx1},
+	})
+	err = txConfNotifier.ConnectTip(nil, 8, block1.Transactions())
+	if err != nil {
+		t.Fatalf("Failed to connect block: %v", err)
+	}
+
+	select {
+	case <-ntfn.Event.Updates:
+		t.Fatal("Received unexpected confirmation update")
+	default:
+	}
+
+	// Both tx2 and tx3 pay to the script, so the notification should track
+	// tx2 as it comes first within the block.
+	block2 := btcutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{&tx2, &tx3},
+	})
+	err = txConfNotifier.ConnectTip(nil, 9, block2.Transactions())
+	if err != nil {
+		t.Fatalf("Failed to connect block: %v", err)
+	}
+
+	tx2Hash := tx2.TxHash()
+	if ntfn.TxID == nil || *ntfn.TxID != tx2Hash {
+		t.Fatalf("expected notification to track tx %v, got %v",
+			tx2Hash, ntfn.TxID)
+	}
+	if len(txConfNotifier.UnconfirmedScripts()) != 0 {
+		t.Fatalf("expected no unconfirmed scripts")
+	}
+
+	select {
+	case numConfsLeft := <-ntfn.Event.Updates:
+		if numConfsLeft != 1 {
+			t.Fatalf("Received incorrect confirmation update: "+
+				"expected %d, got %d", 1, numConfsLeft)
+		}
+	default:
+		t.Fatal("Expected confirmation update")
+	}
+
+	err = txConfNotifier.ConnectTip(nil, 10, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect block: %v", err)
+	}
+
+	select {
+	case <-ntfn.Event.Updates:
+	default:
+		t.Fatal("Expected confirmation update")
+	}
+
+	select {
+	case txConf := <-ntfn.Event.Confirmed:
+		expectedConf := chainntnfs.TxConfirmation{
+			BlockHeight: 9,
+			TxIndex:     0,
+		}
+		assertEqualTxConf(t, txConf, &expectedConf)
+		if txConf.Tx == nil || txConf.Tx.TxHash() != tx2Hash {
+			t.Fatalf("expected confirmation of tx %v", tx2Hash)
+		}
+	default:
+		t.Fatal("Expected confirmation")
+	}
+
+	// We'll now reorg out the block including tx2. The client should be
+	// notified, and the notification should track the script again.
+	if err := txConfNotifier.DisconnectTip(10); err != nil {
+		t.Fatalf("Failed to disconnect block: %v", err)
+	}
+	if err := txConfNotifier.DisconnectTip(9); err != nil {
+		t.Fatalf("Failed to disconnect block: %v", err)
+	}
+
+	select {
+	case reorgDepth := <-ntfn.Event.NegativeConf:
+		if reorgDepth != 2 {
+			t.Fatalf("Incorrect value for negative conf notification: "+
+				"expected %d, got %d", 2, reorgDepth)
+		}
+	default:
+		t.Fatal("Expected negative confirmation")
+	}
+
+	if ntfn.TxID != nil {
+		t.Fatalf("expected notification to track the script again")
+	}
+	if len(txConfNotifier.UnconfirmedScripts()) != 1 {
+		t.Fatalf("expected unconfirmed script")
+	}
+
+	// In the new chain, only tx3 pays to the script, so it should be
+	// tracked instead.
+	block3 := btcutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{&tx1, &tx3},
+	})
+	err = txConfNotifier.ConnectTip(nil, 9, block3.Transactions())
+	if err != nil {
+		t.Fatalf("Failed to connect block: %v", err)
+	}
+	err = txConfNotifier.ConnectTip(nil, 10, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect block: %v", err)
+	}
+
+	tx3Hash := tx3.TxHash()
+	select {
+	case txConf := <-ntfn.Event.Confirmed:
+		expectedConf := chainntnfs.TxConfirmation{
+			BlockHeight: 9,
+			TxIndex:     1,
+		}
+		assertEqualTxConf(t, txConf, &expectedConf)
+		if txConf.Tx == nil || txConf.Tx.TxHash() != tx3Hash {
+			t.Fatalf("expected confirmation of tx %v", tx3Hash)
+		}
+	default:
+		t.Fatal("Expected confirmation")
+	}
+}
+
 func assertEqualTxConf(t *testing.T,
 	actualConf, expectedConf *chainntnfs.TxConfirmation) {
 
@@ -935,3 +1118,136 @@ func assertEqualTxConf(t *testing.T,
 			"expected %d, got %d", expectedConf.TxIndex, actualConf.TxIndex)
 	}
 }
+
+// TestTxConfCancel tests that no notifications are dispatched for canceled
+// confirmation notifications.
+func TestTxConfCancel(t *testing.T) {
+	t.Parallel()
+
+	const numConfs = 1
+
+	var (
+		tx1 = wire.MsgTx{Version: 1}
+		tx2 = wire.MsgTx{Version: 2}
+	)
+
+	hintCache := newMockHintCache()
+	txConfNotifier := chainntnfs.NewTxConfNotifier(10, 100, hintCache)
+
+	// We'll register two notifications for tx1 and cancel the second one.
+	tx1Hash := tx1.TxHash()
+	ntfn1 := chainntnfs.ConfNtfn{
+		ConfID:           1,
+		TxID:             &tx1Hash,
+		NumConfirmations: numConfs,
+		Event:            chainntnfs.NewConfirmationEvent(numConfs, nil),
+	}
+	if err := txConfNotifier.Register(&ntfn1); err != nil {
+		t.Fatalf("unable to register ntfn: %v", err)
+	}
+	ntfn2 := chainntnfs.ConfNtfn{
+		ConfID:           2,
+		TxID:             &tx1Hash,
+		NumConfirmations: numConfs,
+		Event:            chainntnfs.NewConfirmationEvent(numConfs, nil),
+	}
+	if err := txConfNotifier.Register(&ntfn2); err != nil {
+		t.Fatalf("unable to register ntfn: %v", err)
+	}
+	txConfNotifier.CancelConf(&ntfn2)
+
+	// A notification that is canceled before it's registered shouldn't be
+	// registered at all.
+	tx2Hash := tx2.TxHash()
+	ntfn3 := chainntnfs.ConfNtfn{
+		ConfID:           3,
+		TxID:             &tx2Hash,
+		NumConfirmations: numConfs,
+		Event:            chainntnfs.NewConfirmationEvent(numConfs, nil),
+	}
+	txConfNotifier.CancelConf(&ntfn3)
+	if err := txConfNotifier.Register(&ntfn3); err != nil {
+		t.Fatalf("unable to register ntfn: %v", err)
+	}
+
+	block := btcutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{&tx1, &tx2},
+	})
+	err := txConfNotifier.ConnectTip(nil, 11, block.Transactions())
+	if err != nil {
+		t.Fatalf("Failed to connect block: %v", err)
+	}
+
+	select {
+	case <-ntfn1.Event.Confirmed:
+	default:
+		t.Fatal("Expected confirmation")
+	}
+	for _, ntfn := range []chainntnfs.ConfNtfn{ntfn2, ntfn3} {
+		select {
+		case <-ntfn.Event.Updates:
+			t.Fatal("Received unexpected confirmation update")
+		case <-ntfn.Event.Confirmed:
+			t.Fatal("Received unexpected confirmation")
+		default:
+		}
+	}
+}
+
+// TestTxConfSpendReorg tests that the clients of dispatched spend
+// notifications are notified once the spending transaction is reorged out,
+// unless they canceled the notification.
+func TestTxConfSpendReorg(t *testing.T) {
+	t.Parallel()
+
+	hintCache := newMockHintCache()
+	txConfNotifier := chainntnfs.NewTxConfNotifier(10, 2, hintCache)
+
+	// We'll watch a spend at the current height, a spend that is canceled,
+	// and a spend that is already buried too deep to be reorged out.
+	reorg1 := make(chan struct{}, 1)
+	reorg2 := make(chan struct{}, 1)
+	reorg3 := make(chan struct{}, 1)
+	txConfNotifier.WatchSpendReorg(1, 10, reorg1)
+	txConfNotifier.WatchSpendReorg(2, 10, reorg2)
+	txConfNotifier.WatchSpendReorg(3, 8, reorg3)
+	txConfNotifier.CancelSpendReorg(2)
+
+	if err := txConfNotifier.DisconnectTip(10); err != nil {
+		t.Fatalf("Failed to disconnect block: %v", err)
+	}
+
+	select {
+	case <-reorg1:
+	default:
+		t.Fatal("Expected spend reorg notification")
+	}
+	select {
+	case <-reorg2:
+		t.Fatal("Received unexpected spend reorg notification")
+	default:
+	}
+
+	// Once the spend is buried at the reorg safety limit, it's no longer
+	// watched.
+	txConfNotifier.WatchSpendReorg(1, 10, reorg1)
+	for height := uint32(10); height <= 12; height++ {
+		err := txConfNotifier.ConnectTip(nil, height, nil)
+		if err != nil {
+			t.Fatalf("Failed to connect block: %v", err)
+		}
+	}
+	for height := uint32(12); height >= 9; height-- {
+		if err := txConfNotifier.DisconnectTip(height); err != nil {
+			t.Fatalf("Failed to disconnect block: %v", err)
+		}
+	}
+
+	select {
+	case <-reorg1:
+		t.Fatal("Received unexpected spend reorg notification")
+	case <-reorg3:
+		t.Fatal("Received unexpected spend reorg notification")
+	default:
+	}
+}
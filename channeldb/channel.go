@@ -120,8 +120,47 @@ const (
 	// funds towards the total capacity of the channel. The channel may be
 	// funded symmetrically or asymmetrically.
 	DualFunder = 1
+
+	// SingleFunderTweakless is similar to the basic SingleFunder channel
+	// type, but it omits the tweak for one's key in the commitment
+	// transaction of the remote party. As a result, the output paying to
+	// the non-delayed party is static across all commitment states, which
+	// allows funds to be swept without the remote party's commitment point
+	// after data loss.
+	SingleFunderTweakless = 2
+
+	// SingleFunderAnchors is a single funder channel that uses the
+	// tweakless commitment format, and in addition attaches a small anchor
+	// output for each party to the commitment transaction. The anchors
+	// allow either party to bump the fee of the commitment transaction
+	// using CPFP once it has been broadcast.
+	SingleFunderAnchors = 3
 )
 
+// IsSingleFunder returns true if the channel type is one of the known single
+// funder variants.
+func (c ChannelType) IsSingleFunder() bool {
+	return c == SingleFunder || c == SingleFunderTweakless ||
+		c == SingleFunderAnchors
+}
+
+// IsDualFunder returns true if the ChannelType is DualFunder.
+func (c ChannelType) IsDualFunder() bool {
+	return c == DualFunder
+}
+
+// IsTweakless returns true if the target channel uses a commitment that
+// doesn't tweak the key for the remote party's non-delay output.
+func (c ChannelType) IsTweakless() bool {
+	return c == SingleFunderTweakless || c == SingleFunderAnchors
+}
+
+// HasAnchors returns true if the commitment transactions of the target
+// channel carry an anchor output for each party.
+func (c ChannelType) HasAnchors() bool {
+	return c == SingleFunderAnchors
+}
+
 // ChannelConstraints represents a set of constraints meant to allow a node to
 // limit their exposure, enact flow control and ensure that all HTLCs are
 // economically relevant. This struct will be mirrored for both sides of the
@@ -257,6 +296,9 @@ type ChannelCommitment struct {
 	// with the channel in order to allow the fee amount to be removed and
 	// recalculated with each channel state update, including updates that
 	// happen after a system restart.
+	//
+	// NOTE: For channels with anchor outputs, the value of both anchors is
+	// included in this amount, as they're paid for by the initiator.
 	CommitFee btcutil.Amount
 
 	// FeePerKw is the min satoshis/kilo-weight that should be paid within
@@ -310,6 +352,19 @@ var (
 	// TODO(halseh): actually enforce that we are not force closing such a
 	// channel.
 	LocalDataLoss ChannelStatus = 1 << 2
+
+	// Restored indicates that the channel has been restored from a static
+	// channel backup. Such channels only carry the information required to
+	// recover our funds once the remote party force closes, and should
+	// never be used for any off-chain updates.
+	Restored ChannelStatus = 1 << 3
+
+	// SplicePending indicates that both parties have agreed upon a splice
+	// transaction that spends the current funding output, which hasn't
+	// yet confirmed. Until it does, both the commitments spending the
+	// current funding output, and those spending the output of the splice
+	// transaction are valid, so no off-chain updates should be made.
+	SplicePending ChannelStatus = 1 << 4
 )
 
 // String returns a human-readable representation of the ChannelStatus.
@@ -323,6 +378,10 @@ func (c ChannelStatus) String() string {
 		return "CommitmentBroadcasted"
 	case LocalDataLoss:
 		return "LocalDataLoss"
+	case Restored:
+		return "Restored"
+	case SplicePending:
+		return "SplicePending"
 	default:
 		return fmt.Sprintf("Unknown(%08b)", c)
 	}
@@ -405,6 +464,17 @@ type OpenChannel struct {
 	// RemoteChanCfg is the channel configuration for the remote node.
 	RemoteChanCfg ChannelConfig
 
+	// LocalShutdownScript is the script that we committed to paying our
+	// funds out to upon a cooperative close of the channel during the
+	// funding workflow. If empty, we haven't committed to any script.
+	LocalShutdownScript lnwire.DeliveryAddress
+
+	// RemoteShutdownScript is the script that the remote party committed
+	// to paying their funds out to upon a cooperative close of the
+	// channel during the funding workflow. If set, we'll refuse to
+	// cooperatively close the channel to any other script.
+	RemoteShutdownScript lnwire.DeliveryAddress
+
 	// LocalCommitment is the current local commitment state for the local
 	// party. This is stored distinct from the state of the remote party
 	// as there are certain asymmetric parameters which affect the
@@ -432,7 +502,8 @@ type OpenChannel struct {
 	// RevocationProducer is used to generate the revocation in such a way
 	// that remote side might store it efficiently and have the ability to
 	// restore the revocation by index if needed. Current implementation of
-	// secret producer is shachain producer.
+	// secret producer is shachain producer. It is nil if a remote signer
+	// keeps our revocation secrets.
 	RevocationProducer shachain.Producer
 
 	// RevocationStore is used to efficiently store the revocations for
@@ -487,6 +558,15 @@ func (c *OpenChannel) ChanStatus() ChannelStatus {
 	return c.chanStatus
 }
 
+// HasChanStatus returns true if the ChannelStatus of this channel has the
+// passed status bit set.
+func (c *OpenChannel) HasChanStatus(status ChannelStatus) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.chanStatus&status == status
+}
+
 // RefreshShortChanID updates the in-memory short channel ID using the latest
 // value observed on disk.
 func (c *OpenChannel) RefreshShortChanID() error {
@@ -2166,7 +2246,7 @@ func putChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 	}
 
 	// For single funder channels that we initiated, write the funding txn.
-	if channel.ChanType == SingleFunder && channel.IsInitiator {
+	if channel.ChanType.IsSingleFunder() && channel.IsInitiator {
 		if err := WriteElement(&w, channel.FundingTxn); err != nil {
 			return err
 		}
@@ -2179,6 +2259,15 @@ func putChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
+	// Finally, we'll write out the upfront shutdown scripts committed to
+	// during the funding workflow. These are always the last items
+	// within the buffer.
+	if err := WriteElements(&w,
+		channel.LocalShutdownScript, channel.RemoteShutdownScript,
+	); err != nil {
+		return err
+	}
+
 	return chanBucket.Put(chanInfoKey, w.Bytes())
 }
 
@@ -2224,9 +2313,17 @@ func putChanCommitments(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 
 func putChanRevocationState(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 
+	// Channels of a node that uses a remote signer don't carry a
+	// revocation producer, as the signer keeps our revocation secrets, so
+	// we store an empty root in its place.
+	var producer interface{} = channel.RevocationProducer
+	if channel.RevocationProducer == nil {
+		producer = [32]byte{}
+	}
+
 	var b bytes.Buffer
 	err := WriteElements(
-		&b, channel.RemoteCurrentRevocation, channel.RevocationProducer,
+		&b, channel.RemoteCurrentRevocation, producer,
 		channel.RevocationStore,
 	)
 	if err != nil {
@@ -2276,7 +2373,7 @@ func fetchChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 	}
 
 	// For single funder channels that we initiated, read the funding txn.
-	if channel.ChanType == SingleFunder && channel.IsInitiator {
+	if channel.ChanType.IsSingleFunder() && channel.IsInitiator {
 		if err := ReadElement(r, &channel.FundingTxn); err != nil {
 			return err
 		}
@@ -2289,6 +2386,18 @@ func fetchChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
+	// Channels created before upfront shutdown scripts were introduced
+	// won't have them stored, so if there aren't any bytes left in the
+	// buffer, we can exit early here.
+	if r.Len() != 0 {
+		if err := ReadElements(r,
+			&channel.LocalShutdownScript,
+			&channel.RemoteShutdownScript,
+		); err != nil {
+			return err
+		}
+	}
+
 	channel.Packager = NewChannelPackager(channel.ShortChannelID)
 
 	return nil
@@ -215,6 +215,9 @@ func createTestChannelState(cdb *DB) (*OpenChannel, error) {
 		RemoteChanCfg:     remoteCfg,
 		TotalMSatSent:     8,
 		TotalMSatReceived: 2,
+		RemoteShutdownScript: lnwire.DeliveryAddress(
+			bytes.Repeat([]byte{2}, 22),
+		),
 		LocalCommitment: ChannelCommitment{
 			CommitHeight:  0,
 			LocalBalance:  lnwire.MilliSatoshi(9000),
@@ -953,3 +956,137 @@ func TestRefreshShortChanID(t *testing.T) {
 			pendingChannel.Packager.(*ChannelPackager).source)
 	}
 }
+
+// TestCompleteSplice tests that a pending splice is properly stored, and that
+// once it completes the channel is moved to its new channel point with the
+// commitments of the splice.
+func TestCompleteSplice(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+
+	addr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 18555,
+	}
+	if err := state.SyncPending(addr, 99); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+	err = state.MarkAsOpen(lnwire.NewShortChanIDFromInt(1))
+	if err != nil {
+		t.Fatalf("unable to mark channel open: %v", err)
+	}
+
+	// With no splice stored, we should be notified of this.
+	if _, err := state.PendingSplice(); err != ErrNoPendingSplice {
+		t.Fatalf("expected ErrNoPendingSplice, got %v", err)
+	}
+
+	// We'll now create a splice that moves the channel to a new channel
+	// point with a lower capacity.
+	oldChanPoint := state.FundingOutpoint
+	spliceTx := testTx.Copy()
+	spliceTx.TxIn[0].PreviousOutPoint = oldChanPoint
+	splice := &PendingSplice{
+		SpliceTx: spliceTx,
+		FundingOutpoint: wire.OutPoint{
+			Hash:  spliceTx.TxHash(),
+			Index: 0,
+		},
+		Capacity:         5000,
+		BroadcastHeight:  120,
+		LocalCommitment:  state.LocalCommitment,
+		RemoteCommitment: state.RemoteCommitment,
+	}
+	splice.LocalCommitment.LocalBalance = lnwire.MilliSatoshi(2000)
+	splice.RemoteCommitment.RemoteBalance = lnwire.MilliSatoshi(2000)
+
+	if err := state.MarkSplicePending(splice); err != nil {
+		t.Fatalf("unable to mark splice pending: %v", err)
+	}
+	if !state.HasChanStatus(SplicePending) {
+		t.Fatalf("expected channel to have status SplicePending, "+
+			"has %v", state.ChanStatus())
+	}
+
+	// The stored splice should match the one we created.
+	dbSplice, err := state.PendingSplice()
+	if err != nil {
+		t.Fatalf("unable to fetch pending splice: %v", err)
+	}
+	if dbSplice.FundingOutpoint != splice.FundingOutpoint ||
+		dbSplice.Capacity != splice.Capacity ||
+		dbSplice.BroadcastHeight != splice.BroadcastHeight ||
+		dbSplice.SpliceTx.TxHash() != spliceTx.TxHash() {
+
+		t.Fatalf("splice mismatch: expected %v, got %v",
+			spew.Sdump(splice), spew.Sdump(dbSplice))
+	}
+	assertCommitmentEqual(
+		t, &splice.LocalCommitment, &dbSplice.LocalCommitment,
+	)
+	assertCommitmentEqual(
+		t, &splice.RemoteCommitment, &dbSplice.RemoteCommitment,
+	)
+
+	// Once the splice completes, the channel should only be found under
+	// its new channel point.
+	newShortChanID := lnwire.NewShortChanIDFromInt(2)
+	if err := state.CompleteSplice(newShortChanID); err != nil {
+		t.Fatalf("unable to complete splice: %v", err)
+	}
+	if state.FundingOutpoint != splice.FundingOutpoint {
+		t.Fatalf("expected channel point %v, got %v",
+			splice.FundingOutpoint, state.FundingOutpoint)
+	}
+
+	if _, err := cdb.FetchChannel(oldChanPoint); err == nil {
+		t.Fatalf("channel should no longer be found under " +
+			"prior channel point")
+	}
+	dbChan, err := cdb.FetchChannel(splice.FundingOutpoint)
+	if err != nil {
+		t.Fatalf("unable to fetch spliced channel: %v", err)
+	}
+
+	if dbChan.Capacity != splice.Capacity {
+		t.Fatalf("expected capacity %v, got %v", splice.Capacity,
+			dbChan.Capacity)
+	}
+	if dbChan.ShortChanID() != newShortChanID {
+		t.Fatalf("expected short chan id %v, got %v", newShortChanID,
+			dbChan.ShortChanID())
+	}
+	if dbChan.FundingBroadcastHeight != splice.BroadcastHeight {
+		t.Fatalf("expected broadcast height %v, got %v",
+			splice.BroadcastHeight, dbChan.FundingBroadcastHeight)
+	}
+	if dbChan.ChanStatus() != Default {
+		t.Fatalf("expected channel status Default, got %v",
+			dbChan.ChanStatus())
+	}
+	if dbChan.FundingTxn.TxHash() != spliceTx.TxHash() {
+		t.Fatalf("expected funding txn to be the splice tx")
+	}
+	assertCommitmentEqual(
+		t, &splice.LocalCommitment, &dbChan.LocalCommitment,
+	)
+	assertCommitmentEqual(
+		t, &splice.RemoteCommitment, &dbChan.RemoteCommitment,
+	)
+
+	// The pending splice should have been removed along with the prior
+	// channel state.
+	if _, err := dbChan.PendingSplice(); err != ErrNoPendingSplice {
+		t.Fatalf("expected ErrNoPendingSplice, got %v", err)
+	}
+}
@@ -157,6 +157,11 @@ func WriteElement(w io.Writer, element interface{}) error {
 			return err
 		}
 
+	case lnwire.DeliveryAddress:
+		if err := wire.WriteVarBytes(w, 0, e); err != nil {
+			return err
+		}
+
 	case lnwire.Message:
 		if _, err := lnwire.WriteMessage(w, e, 0); err != nil {
 			return err
@@ -312,6 +317,13 @@ func ReadElement(r io.Reader, element interface{}) error {
 			return err
 		}
 
+		// An empty root is stored for channels whose revocation
+		// secrets are kept by a remote signer.
+		if root == [32]byte{} {
+			*e = nil
+			return nil
+		}
+
 		// TODO(roasbeef): remove
 		producer, err := shachain.NewRevocationProducerFromBytes(root[:])
 		if err != nil {
@@ -349,6 +361,22 @@ func ReadElement(r io.Reader, element interface{}) error {
 
 		*e = bytes
 
+	case *lnwire.DeliveryAddress:
+		script, err := wire.ReadVarBytes(
+			r, 0, 66000, "lnwire.DeliveryAddress",
+		)
+		if err != nil {
+			return err
+		}
+
+		// An empty script signals that no script was committed to, so
+		// we'll normalize it to nil.
+		if len(script) == 0 {
+			script = nil
+		}
+
+		*e = script
+
 	case *lnwire.Message:
 		msg, err := lnwire.ReadMessage(r, 0)
 		if err != nil {
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/wire"
@@ -705,6 +707,165 @@ func (d *DB) PruneLinkNodes() error {
 	})
 }
 
+// FetchChannel attempts to locate a channel specified by the passed channel
+// point. If the channel cannot be found, then an error will be returned.
+func (d *DB) FetchChannel(chanPoint wire.OutPoint) (*OpenChannel, error) {
+	channels, err := d.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, channel := range channels {
+		if channel.FundingOutpoint == chanPoint {
+			return channel, nil
+		}
+	}
+
+	return nil, ErrChannelNotFound
+}
+
+// ChannelShell is a shell of a channel that is meant to be used for channel
+// recovery purposes. It contains a minimal OpenChannel instance along with
+// the addresses the remote node is known to be reachable at.
+type ChannelShell struct {
+	// NodeAddrs is the set of addresses that the remote node has been
+	// known to be reachable at in the past.
+	NodeAddrs []net.Addr
+
+	// Chan is a shell of an OpenChannel. It only contains the items
+	// required to restore the channel on disk.
+	Chan *OpenChannel
+}
+
+// RestoreChannelShells reconstructs the on-disk state of the passed channel
+// shells. Each channel is written to disk marked with the Restored status,
+// and a LinkNode is created for the remote node using the passed addresses.
+// Channels that already exist within the database are left untouched, making
+// this method idempotent.
+func (d *DB) RestoreChannelShells(channelShells ...*ChannelShell) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		for _, channelShell := range channelShells {
+			channel := channelShell.Chan
+
+			// If we already know of this channel, then we'll skip it,
+			// as we don't want to overwrite any existing state.
+			_, err := fetchChanBucket(
+				tx, channel.IdentityPub, &channel.FundingOutpoint,
+				channel.ChainHash,
+			)
+			switch err {
+			case nil:
+				continue
+			case ErrNoChanDBExists, ErrNoActiveChannels,
+				ErrChannelNotFound:
+			default:
+				return err
+			}
+
+			// We mark the channel as restored, which signals to
+			// the other sub-systems that they shouldn't attempt
+			// to use the channel as if it were a regular one.
+			channel.chanStatus |= Restored
+			channel.Db = d
+
+			// As we don't know the latest commitments of the
+			// channel, we'll store blank ones in their place.
+			if channel.LocalCommitment.CommitTx == nil {
+				channel.LocalCommitment.CommitTx = blankCommitTx(
+					channel.FundingOutpoint,
+				)
+			}
+			if channel.RemoteCommitment.CommitTx == nil {
+				channel.RemoteCommitment.CommitTx = blankCommitTx(
+					channel.FundingOutpoint,
+				)
+			}
+
+			if err := channel.fullSync(tx); err != nil {
+				return err
+			}
+
+			// Finally, we'll create a LinkNode for the remote
+			// party, such that we'll attempt to reconnect to it.
+			nodeInfoBucket, err := tx.CreateBucketIfNotExists(
+				nodeInfoBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			nodePub := channel.IdentityPub.SerializeCompressed()
+			if nodeInfoBucket.Get(nodePub) != nil {
+				continue
+			}
+
+			linkNode := &LinkNode{
+				Network:     wire.MainNet,
+				IdentityPub: channel.IdentityPub,
+				LastSeen:    time.Now(),
+				Addresses:   channelShell.NodeAddrs,
+				db:          d,
+			}
+			if err := putLinkNode(nodeInfoBucket, linkNode); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// blankCommitTx returns a placeholder commitment transaction spending the
+// passed funding outpoint, used for channels whose commitments are unknown.
+func blankCommitTx(fundingOutpoint wire.OutPoint) *wire.MsgTx {
+	commitTx := wire.NewMsgTx(2)
+	commitTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: fundingOutpoint,
+	})
+
+	return commitTx
+}
+
+// AddrsForNode returns all the addresses the target node is known to be
+// reachable at, based on both its LinkNode and the latest node announcement
+// within the channel graph.
+func (d *DB) AddrsForNode(nodePub *btcec.PublicKey) ([]net.Addr, error) {
+	var addrs []net.Addr
+
+	linkNode, err := d.FetchLinkNode(nodePub)
+	switch err {
+	case nil:
+		addrs = append(addrs, linkNode.Addresses...)
+	case ErrLinkNodesNotFound, ErrNodeNotFound:
+	default:
+		return nil, err
+	}
+
+	graphNode, err := d.ChannelGraph().FetchLightningNode(nodePub)
+	switch err {
+	case nil:
+	case ErrGraphNodesNotFound, ErrGraphNodeNotFound, ErrGraphNotFound:
+		return addrs, nil
+	default:
+		return nil, err
+	}
+
+	// We'll only add the graph addresses we don't already know of from
+	// the LinkNode.
+	known := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		known[addr.String()] = struct{}{}
+	}
+	for _, addr := range graphNode.Addresses {
+		if _, ok := known[addr.String()]; ok {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+
+	return addrs, nil
+}
+
 // syncVersions function is used for safe db version synchronization. It
 // applies migration functions to the current database and recovers the
 // previous state of db if at least one error/panic appeared during migration.
@@ -2,6 +2,7 @@ package channeldb
 
 import (
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -33,3 +34,69 @@ func TestOpenWithCreate(t *testing.T) {
 		t.Fatalf("channeldb failed to create data directory")
 	}
 }
+
+// TestRestoreChannelShells tests that we're able to properly restore a set of
+// channel shells into an empty database, and that doing so is idempotent.
+func TestRestoreChannelShells(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// We'll create a test channel to use as the basis of our shell,
+	// blanking out the commitments, as they're unknown when restoring a
+	// channel.
+	channel, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	channel.IsPending = false
+	channel.LocalCommitment = ChannelCommitment{}
+	channel.RemoteCommitment = ChannelCommitment{}
+
+	addr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 9735}
+	channelShell := &ChannelShell{
+		NodeAddrs: []net.Addr{addr},
+		Chan:      channel,
+	}
+	if err := cdb.RestoreChannelShells(channelShell); err != nil {
+		t.Fatalf("unable to restore channel shell: %v", err)
+	}
+
+	// The channel should now be found within the database, marked as
+	// restored.
+	restoredChan, err := cdb.FetchChannel(channel.FundingOutpoint)
+	if err != nil {
+		t.Fatalf("unable to fetch restored channel: %v", err)
+	}
+	if !restoredChan.HasChanStatus(Restored) {
+		t.Fatalf("channel should be marked as restored, has status %v",
+			restoredChan.ChanStatus())
+	}
+
+	// A link node for the remote party should have been created as well,
+	// using the addresses within the shell.
+	addrs, err := cdb.AddrsForNode(channel.IdentityPub)
+	if err != nil {
+		t.Fatalf("unable to fetch node addrs: %v", err)
+	}
+	if len(addrs) != 1 || addrs[0].String() != addr.String() {
+		t.Fatalf("expected addrs %v, got %v", addr, addrs)
+	}
+
+	// Restoring the same shell again shouldn't result in an error, nor a
+	// duplicate channel.
+	if err := cdb.RestoreChannelShells(channelShell); err != nil {
+		t.Fatalf("unable to restore channel shell: %v", err)
+	}
+	channels, err := cdb.FetchAllChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch channels: %v", err)
+	}
+	if len(channels) != 1 {
+		t.Fatalf("expected 1 channel, found %v", len(channels))
+	}
+}
@@ -97,6 +97,14 @@ var (
 	// ErrNoForwardingEvents is returned in the case that a query fails due
 	// to the log not having any recorded events.
 	ErrNoForwardingEvents = fmt.Errorf("no recorded forwarding events")
+
+	// ErrTxLabelExists is returned when attempting to label a transaction
+	// that already has a label without requesting to overwrite it.
+	ErrTxLabelExists = fmt.Errorf("transaction already labelled")
+
+	// ErrEmptyTxLabel is returned when attempting to store an empty
+	// transaction label.
+	ErrEmptyTxLabel = fmt.Errorf("cannot store empty transaction label")
 )
 
 // ErrTooManyExtraOpaqueBytes creates an error which should be returned if the
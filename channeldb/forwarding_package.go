@@ -35,6 +35,20 @@ const (
 	FwdStateCompleted
 )
 
+// String returns a human-readable representation of the FwdState.
+func (s FwdState) String() string {
+	switch s {
+	case FwdStateLockedIn:
+		return "LockedIn"
+	case FwdStateProcessed:
+		return "Processed"
+	case FwdStateCompleted:
+		return "Completed"
+	default:
+		return fmt.Sprintf("Unknown(%d)", s)
+	}
+}
+
 var (
 	// fwdPackagesKey is the root-level bucket that all forwarding packages
 	// are written. This bucket is further subdivided based on the short
@@ -0,0 +1,125 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// outputLeaseBucket is the name of the bucket that stores all leases of
+	// wallet outputs. The leases are keyed by the leased outpoint.
+	outputLeaseBucket = []byte("output-leases")
+)
+
+// OutputLease is a lease of a wallet output. While leased, an output is
+// excluded from automatic coin selection.
+type OutputLease struct {
+	// OutPoint is the leased output.
+	OutPoint wire.OutPoint
+
+	// LockID identifies the party that holds the lease. Only this party
+	// can release the output before the lease expires.
+	LockID [32]byte
+
+	// Expiration is the time at which the lease expires.
+	Expiration time.Time
+}
+
+// PutOutputLease persists the passed lease, overwriting any existing lease of
+// the same output.
+func (d *DB) PutOutputLease(lease *OutputLease) error {
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &lease.OutPoint); err != nil {
+		return err
+	}
+
+	var value bytes.Buffer
+	if err := serializeOutputLease(&value, lease); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		leases, err := tx.CreateBucketIfNotExists(outputLeaseBucket)
+		if err != nil {
+			return err
+		}
+
+		return leases.Put(key.Bytes(), value.Bytes())
+	})
+}
+
+// DeleteOutputLease removes the lease of the passed output, if any.
+func (d *DB) DeleteOutputLease(op wire.OutPoint) error {
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &op); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		leases := tx.Bucket(outputLeaseBucket)
+		if leases == nil {
+			return nil
+		}
+
+		return leases.Delete(key.Bytes())
+	})
+}
+
+// FetchOutputLeases returns all persisted output leases, including the ones
+// that have already expired.
+func (d *DB) FetchOutputLeases() ([]*OutputLease, error) {
+	var outputLeases []*OutputLease
+	err := d.View(func(tx *bolt.Tx) error {
+		leases := tx.Bucket(outputLeaseBucket)
+		if leases == nil {
+			return nil
+		}
+
+		return leases.ForEach(func(k, v []byte) error {
+			lease := &OutputLease{}
+			err := readOutpoint(bytes.NewReader(k), &lease.OutPoint)
+			if err != nil {
+				return err
+			}
+
+			err = deserializeOutputLease(bytes.NewReader(v), lease)
+			if err != nil {
+				return err
+			}
+
+			outputLeases = append(outputLeases, lease)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outputLeases, nil
+}
+
+func serializeOutputLease(w io.Writer, lease *OutputLease) error {
+	if _, err := w.Write(lease.LockID[:]); err != nil {
+		return err
+	}
+
+	return WriteElement(w, uint64(lease.Expiration.Unix()))
+}
+
+func deserializeOutputLease(r io.Reader, lease *OutputLease) error {
+	if _, err := io.ReadFull(r, lease.LockID[:]); err != nil {
+		return err
+	}
+
+	var expiration uint64
+	if err := ReadElement(r, &expiration); err != nil {
+		return err
+	}
+	lease.Expiration = time.Unix(int64(expiration), 0)
+
+	return nil
+}
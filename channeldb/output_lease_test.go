@@ -0,0 +1,83 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// TestOutputLeases tests that output leases can be added, overwritten,
+// fetched and deleted.
+func TestOutputLeases(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// Initially, no leases should be found.
+	leases, err := cdb.FetchOutputLeases()
+	if err != nil {
+		t.Fatalf("unable to fetch leases: %v", err)
+	}
+	if len(leases) != 0 {
+		t.Fatalf("expected no leases, got %v", len(leases))
+	}
+
+	lease1 := &OutputLease{
+		OutPoint:   wire.OutPoint{Hash: rev, Index: 1},
+		LockID:     [32]byte{1},
+		Expiration: time.Unix(1000, 0),
+	}
+	lease2 := &OutputLease{
+		OutPoint:   wire.OutPoint{Hash: rev, Index: 2},
+		LockID:     [32]byte{2},
+		Expiration: time.Unix(2000, 0),
+	}
+	for _, lease := range []*OutputLease{lease1, lease2} {
+		if err := cdb.PutOutputLease(lease); err != nil {
+			t.Fatalf("unable to put lease: %v", err)
+		}
+	}
+
+	// Extending the first lease should overwrite the existing one.
+	lease1.Expiration = time.Unix(3000, 0)
+	if err := cdb.PutOutputLease(lease1); err != nil {
+		t.Fatalf("unable to put lease: %v", err)
+	}
+
+	leases, err = cdb.FetchOutputLeases()
+	if err != nil {
+		t.Fatalf("unable to fetch leases: %v", err)
+	}
+	expected := map[wire.OutPoint]*OutputLease{
+		lease1.OutPoint: lease1,
+		lease2.OutPoint: lease2,
+	}
+	if len(leases) != len(expected) {
+		t.Fatalf("expected %v leases, got %v", len(expected),
+			len(leases))
+	}
+	for _, lease := range leases {
+		if !reflect.DeepEqual(lease, expected[lease.OutPoint]) {
+			t.Fatalf("lease mismatch: expected %v, got %v",
+				expected[lease.OutPoint], lease)
+		}
+	}
+
+	// After deleting the first lease, only the second should remain.
+	if err := cdb.DeleteOutputLease(lease1.OutPoint); err != nil {
+		t.Fatalf("unable to delete lease: %v", err)
+	}
+	leases, err = cdb.FetchOutputLeases()
+	if err != nil {
+		t.Fatalf("unable to fetch leases: %v", err)
+	}
+	if len(leases) != 1 || !reflect.DeepEqual(leases[0], lease2) {
+		t.Fatalf("expected only lease %v, got %v", lease2, leases)
+	}
+}
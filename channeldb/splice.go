@@ -0,0 +1,280 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// pendingSpliceKey stores the pending splice of a channel, if any.
+	// This key should be accessed from within the sub-bucket of a target
+	// channel, identified by its channel point.
+	pendingSpliceKey = []byte("pending-splice-key")
+
+	// ErrNoPendingSplice is returned when a channel has no pending splice
+	// stored within the database.
+	ErrNoPendingSplice = fmt.Errorf("no pending splice found")
+)
+
+// PendingSplice houses the state of a splice that both parties have agreed
+// upon, but that hasn't yet confirmed. Until the splice transaction
+// confirms, both the current commitments of the channel, and the commitments
+// stored within the PendingSplice are valid.
+type PendingSplice struct {
+	// SpliceTx is the splice transaction, which spends the current funding
+	// output of the channel. Before the remote party's signature for the
+	// splice transaction has been received, this transaction won't carry
+	// a witness.
+	SpliceTx *wire.MsgTx
+
+	// FundingOutpoint is the outpoint of the new funding output created
+	// by the splice transaction.
+	FundingOutpoint wire.OutPoint
+
+	// Capacity is the capacity of the channel once the splice
+	// transaction confirms.
+	Capacity btcutil.Amount
+
+	// BroadcastHeight is the height at which the splice transaction was
+	// broadcast.
+	BroadcastHeight uint32
+
+	// LocalCommitment is our version of the commitment transaction that
+	// spends the new funding output, along with the remote party's
+	// signature for it.
+	LocalCommitment ChannelCommitment
+
+	// RemoteCommitment is the remote party's version of the commitment
+	// transaction that spends the new funding output.
+	RemoteCommitment ChannelCommitment
+}
+
+func serializePendingSplice(w io.Writer, s *PendingSplice) error {
+	if err := WriteElements(w,
+		s.SpliceTx, s.FundingOutpoint, s.Capacity, s.BroadcastHeight,
+	); err != nil {
+		return err
+	}
+
+	if err := serializeChanCommit(w, &s.LocalCommitment); err != nil {
+		return err
+	}
+
+	return serializeChanCommit(w, &s.RemoteCommitment)
+}
+
+func deserializePendingSplice(r io.Reader) (*PendingSplice, error) {
+	s := &PendingSplice{}
+	if err := ReadElements(r,
+		&s.SpliceTx, &s.FundingOutpoint, &s.Capacity, &s.BroadcastHeight,
+	); err != nil {
+		return nil, err
+	}
+
+	var err error
+	s.LocalCommitment, err = deserializeChanCommit(r)
+	if err != nil {
+		return nil, err
+	}
+	s.RemoteCommitment, err = deserializeChanCommit(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// fetchPendingSplice reads the pending splice stored within the passed
+// channel bucket.
+func fetchPendingSplice(chanBucket *bolt.Bucket) (*PendingSplice, error) {
+	spliceBytes := chanBucket.Get(pendingSpliceKey)
+	if spliceBytes == nil {
+		return nil, ErrNoPendingSplice
+	}
+
+	return deserializePendingSplice(bytes.NewReader(spliceBytes))
+}
+
+// MarkSplicePending stores the passed splice, and adds the SplicePending
+// status to the channel. Any previously stored splice for the channel is
+// overwritten, which allows the splice to be updated once the final,
+// signed splice transaction is known.
+func (c *OpenChannel) MarkSplicePending(splice *PendingSplice) error {
+	c.Lock()
+	defer c.Unlock()
+
+	var status ChannelStatus
+	if err := c.Db.Update(func(tx *bolt.Tx) error {
+		chanBucket, err := fetchChanBucket(
+			tx, c.IdentityPub, &c.FundingOutpoint, c.ChainHash,
+		)
+		if err != nil {
+			return err
+		}
+
+		channel, err := fetchOpenChannel(chanBucket, &c.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+
+		status = channel.chanStatus | SplicePending
+		channel.chanStatus = status
+
+		var b bytes.Buffer
+		if err := serializePendingSplice(&b, splice); err != nil {
+			return err
+		}
+		if err := chanBucket.Put(pendingSpliceKey, b.Bytes()); err != nil {
+			return err
+		}
+
+		return putOpenChannel(chanBucket, channel)
+	}); err != nil {
+		return err
+	}
+
+	c.chanStatus = status
+
+	return nil
+}
+
+// PendingSplice returns the pending splice of the channel. If the channel
+// has no pending splice, ErrNoPendingSplice is returned.
+func (c *OpenChannel) PendingSplice() (*PendingSplice, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	var splice *PendingSplice
+	err := c.Db.View(func(tx *bolt.Tx) error {
+		chanBucket, err := fetchChanBucket(
+			tx, c.IdentityPub, &c.FundingOutpoint, c.ChainHash,
+		)
+		switch err {
+		case nil:
+		case ErrNoChanDBExists, ErrNoActiveChannels, ErrChannelNotFound:
+			return ErrNoPendingSplice
+		default:
+			return err
+		}
+
+		splice, err = fetchPendingSplice(chanBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return splice, nil
+}
+
+// CompleteSplice is to be called once the pending splice of the channel has
+// confirmed at the location described by the passed short channel ID. The
+// channel is moved to its new channel point, and its capacity and
+// commitments are replaced with those of the splice. As the revocation log
+// only concerns commitments spending the prior funding output, it is
+// discarded along with the rest of the channel's prior state.
+func (c *OpenChannel) CompleteSplice(shortChanID lnwire.ShortChannelID) error {
+	c.Lock()
+	defer c.Unlock()
+
+	var newChannel *OpenChannel
+	err := c.Db.Update(func(tx *bolt.Tx) error {
+		openChanBucket := tx.Bucket(openChannelBucket)
+		if openChanBucket == nil {
+			return ErrNoChanDBExists
+		}
+
+		nodePub := c.IdentityPub.SerializeCompressed()
+		nodeChanBucket := openChanBucket.Bucket(nodePub)
+		if nodeChanBucket == nil {
+			return ErrNoActiveChannels
+		}
+
+		chainBucket := nodeChanBucket.Bucket(c.ChainHash[:])
+		if chainBucket == nil {
+			return ErrNoActiveChannels
+		}
+
+		var oldChanPoint bytes.Buffer
+		err := writeOutpoint(&oldChanPoint, &c.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+		oldChanBucket := chainBucket.Bucket(oldChanPoint.Bytes())
+		if oldChanBucket == nil {
+			return ErrChannelNotFound
+		}
+
+		splice, err := fetchPendingSplice(oldChanBucket)
+		if err != nil {
+			return err
+		}
+
+		channel, err := fetchOpenChannel(
+			oldChanBucket, &c.FundingOutpoint,
+		)
+		if err != nil {
+			return err
+		}
+
+		// With the prior state read, we'll swap in the funding output
+		// and commitments of the splice.
+		channel.FundingOutpoint = splice.FundingOutpoint
+		channel.ShortChannelID = shortChanID
+		channel.Capacity = splice.Capacity
+		channel.FundingBroadcastHeight = splice.BroadcastHeight
+		channel.LocalCommitment = splice.LocalCommitment
+		channel.RemoteCommitment = splice.RemoteCommitment
+		channel.chanStatus &^= SplicePending
+		if channel.ChanType.IsSingleFunder() && channel.IsInitiator {
+			channel.FundingTxn = splice.SpliceTx
+		}
+
+		var newChanPoint bytes.Buffer
+		err = writeOutpoint(&newChanPoint, &channel.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+		newChanBucket, err := chainBucket.CreateBucket(
+			newChanPoint.Bytes(),
+		)
+		if err != nil {
+			return err
+		}
+		if err := putOpenChannel(newChanBucket, channel); err != nil {
+			return err
+		}
+
+		// Finally, we'll remove all prior state of the channel,
+		// including the revocation log and the pending splice itself.
+		err = chainBucket.DeleteBucket(oldChanPoint.Bytes())
+		if err != nil {
+			return err
+		}
+
+		newChannel = channel
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.FundingOutpoint = newChannel.FundingOutpoint
+	c.ShortChannelID = newChannel.ShortChannelID
+	c.Capacity = newChannel.Capacity
+	c.FundingBroadcastHeight = newChannel.FundingBroadcastHeight
+	c.LocalCommitment = newChannel.LocalCommitment
+	c.RemoteCommitment = newChannel.RemoteCommitment
+	c.FundingTxn = newChannel.FundingTxn
+	c.chanStatus = newChannel.chanStatus
+	c.Packager = NewChannelPackager(newChannel.ShortChannelID)
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package channeldb
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/coreos/bbolt"
+)
+
+// MaxTxLabelLength is the maximum length of a transaction label.
+const MaxTxLabelLength = 500
+
+var (
+	// txLabelBucket is the name of the bucket that stores the labels of
+	// on-chain transactions, keyed by txid.
+	txLabelBucket = []byte("tx-labels")
+)
+
+// PutTxLabel stores the label of the transaction with the given hash. If the
+// transaction already has a label, it is only replaced if overwrite is set.
+// Otherwise ErrTxLabelExists is returned.
+func (d *DB) PutTxLabel(txid chainhash.Hash, label string,
+	overwrite bool) error {
+
+	switch {
+	case len(label) == 0:
+		return ErrEmptyTxLabel
+
+	case len(label) > MaxTxLabelLength:
+		return fmt.Errorf("label length %v exceeds maximum of %v",
+			len(label), MaxTxLabelLength)
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		labels, err := tx.CreateBucketIfNotExists(txLabelBucket)
+		if err != nil {
+			return err
+		}
+
+		if !overwrite && labels.Get(txid[:]) != nil {
+			return ErrTxLabelExists
+		}
+
+		return labels.Put(txid[:], []byte(label))
+	})
+}
+
+// FetchTxLabel returns the label of the transaction with the given hash. An
+// empty string is returned if the transaction isn't labelled.
+func (d *DB) FetchTxLabel(txid chainhash.Hash) (string, error) {
+	var label string
+	err := d.View(func(tx *bolt.Tx) error {
+		labels := tx.Bucket(txLabelBucket)
+		if labels == nil {
+			return nil
+		}
+
+		label = string(labels.Get(txid[:]))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return label, nil
+}
+
+// FetchTxLabels returns the labels of all labelled transactions, keyed by
+// txid.
+func (d *DB) FetchTxLabels() (map[chainhash.Hash]string, error) {
+	txLabels := make(map[chainhash.Hash]string)
+	err := d.View(func(tx *bolt.Tx) error {
+		labels := tx.Bucket(txLabelBucket)
+		if labels == nil {
+			return nil
+		}
+
+		return labels.ForEach(func(k, v []byte) error {
+			var txid chainhash.Hash
+			copy(txid[:], k)
+			txLabels[txid] = string(v)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return txLabels, nil
+}
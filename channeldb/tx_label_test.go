@@ -0,0 +1,68 @@
+package channeldb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// TestTxLabels tests that transaction labels can be stored, fetched and only
+// overwritten when requested.
+func TestTxLabels(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	txid1 := chainhash.Hash{1}
+	txid2 := chainhash.Hash{2}
+
+	// Transactions without a label should have an empty label.
+	label, err := cdb.FetchTxLabel(txid1)
+	if err != nil {
+		t.Fatalf("unable to fetch label: %v", err)
+	}
+	if label != "" {
+		t.Fatalf("expected empty label, got %v", label)
+	}
+
+	if err := cdb.PutTxLabel(txid1, "sweep", false); err != nil {
+		t.Fatalf("unable to put label: %v", err)
+	}
+	if err := cdb.PutTxLabel(txid2, "justice", false); err != nil {
+		t.Fatalf("unable to put label: %v", err)
+	}
+
+	// Labelling a transaction again must fail unless the existing label
+	// should be overwritten.
+	err = cdb.PutTxLabel(txid1, "deposit", false)
+	if err != ErrTxLabelExists {
+		t.Fatalf("expected ErrTxLabelExists, got %v", err)
+	}
+	if err := cdb.PutTxLabel(txid1, "deposit", true); err != nil {
+		t.Fatalf("unable to overwrite label: %v", err)
+	}
+
+	// Empty and oversized labels are rejected.
+	if err := cdb.PutTxLabel(txid1, "", true); err != ErrEmptyTxLabel {
+		t.Fatalf("expected ErrEmptyTxLabel, got %v", err)
+	}
+	longLabel := strings.Repeat("a", MaxTxLabelLength+1)
+	if err := cdb.PutTxLabel(txid1, longLabel, true); err == nil {
+		t.Fatalf("expected oversized label to be rejected")
+	}
+
+	labels, err := cdb.FetchTxLabels()
+	if err != nil {
+		t.Fatalf("unable to fetch labels: %v", err)
+	}
+	if len(labels) != 2 || labels[txid1] != "deposit" ||
+		labels[txid2] != "justice" {
+
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+}
@@ -0,0 +1,350 @@
+package channelnotifier
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// ChannelNotifier is a subsystem which all active, pending, and closing
+// channel events pipe through. It takes subscriptions for its events, and
+// whenever it receives a new event it notifies its subscribers over the proper
+// channel.
+type ChannelNotifier struct {
+	started uint32
+	stopped uint32
+
+	chanDB *channeldb.DB
+
+	clientMtx    sync.Mutex
+	nextClientID uint64
+	clients      map[uint64]*subscription
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// PendingOpenChannelEvent represents a new event where a new channel has
+// entered a pending open state.
+type PendingOpenChannelEvent struct {
+	// ChannelPoint is the channel outpoint for the new channel.
+	ChannelPoint *wire.OutPoint
+
+	// PendingChannel is the channel configuration for the newly created
+	// channel. This might not have been persisted to the channel DB yet
+	// because we are still waiting for the final message from the remote
+	// peer.
+	PendingChannel *channeldb.OpenChannel
+}
+
+// OpenChannelEvent represents a new event where a channel goes from pending
+// open to open.
+type OpenChannelEvent struct {
+	// Channel is the channel that has become open.
+	Channel *channeldb.OpenChannel
+}
+
+// ActiveChannelEvent represents a new event where a channel becomes active,
+// as its link has been added to the switch.
+type ActiveChannelEvent struct {
+	// ChannelPoint is the channel point for the newly active channel.
+	ChannelPoint *wire.OutPoint
+}
+
+// InactiveChannelEvent represents a new event where a channel becomes
+// inactive, as its link has been removed from the switch.
+type InactiveChannelEvent struct {
+	// ChannelPoint is the channel point for the newly inactive channel.
+	ChannelPoint *wire.OutPoint
+}
+
+// ClosingChannelEvent represents a new event where a channel has begun
+// closing. This happens once a cooperative or local force close transaction
+// has been broadcast, or once a remote force close or breach has been
+// detected on chain.
+type ClosingChannelEvent struct {
+	// ChannelPoint is the channel point of the closing channel.
+	ChannelPoint *wire.OutPoint
+
+	// CloseType is the manner in which the channel is being closed.
+	CloseType channeldb.ClosureType
+}
+
+// ClosedChannelEvent represents a new event where a channel has been fully
+// closed, and all of its outputs have been resolved.
+type ClosedChannelEvent struct {
+	// CloseSummary is the summary of the channel close that has occurred.
+	CloseSummary *channeldb.ChannelCloseSummary
+}
+
+// BalanceUpdateEvent represents a new event where the balances of a channel
+// have changed, as a new commitment has been locked in.
+type BalanceUpdateEvent struct {
+	// ChannelPoint is the channel point of the updated channel.
+	ChannelPoint *wire.OutPoint
+
+	// LocalBalance is our balance within the new commitment.
+	LocalBalance lnwire.MilliSatoshi
+
+	// RemoteBalance is the remote party's balance within the new
+	// commitment.
+	RemoteBalance lnwire.MilliSatoshi
+
+	// CommitHeight is the height of the new commitment.
+	CommitHeight uint64
+}
+
+// SplicedChannelEvent represents a new event where the splice transaction of
+// a channel has confirmed, and the channel has moved to its new channel
+// point.
+type SplicedChannelEvent struct {
+	// OldChannelPoint is the channel point the channel had before the
+	// splice.
+	OldChannelPoint *wire.OutPoint
+
+	// Channel is the channel at its new channel point.
+	Channel *channeldb.OpenChannel
+}
+
+// Subscription is a single subscription to the events of the ChannelNotifier.
+type Subscription struct {
+	// Updates is the channel that all events will be sent over. Each
+	// event is one of the event types defined within this package.
+	Updates <-chan interface{}
+
+	// Cancel is a closure that should be executed once the caller no
+	// longer wishes to receive events.
+	Cancel func()
+}
+
+// subscription is the internal state of a single subscriber.
+type subscription struct {
+	cancelled uint32
+
+	id uint64
+
+	// updates is the channel that the events of this subscription are
+	// ultimately delivered on.
+	updates chan interface{}
+
+	// ntfnQueue buffers all events for this subscriber, such that the
+	// notifier is never blocked on a slow client.
+	ntfnQueue *chainntnfs.ConcurrentQueue
+
+	cancelChan chan struct{}
+}
+
+// New creates a new channel notifier. The ChannelNotifier gets channel
+// events from peers and from the chain arbitrator, and dispatches them to its
+// clients.
+func New(chanDB *channeldb.DB) *ChannelNotifier {
+	return &ChannelNotifier{
+		chanDB:  chanDB,
+		clients: make(map[uint64]*subscription),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start starts the ChannelNotifier.
+func (c *ChannelNotifier) Start() error {
+	if !atomic.CompareAndSwapUint32(&c.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("ChannelNotifier starting")
+
+	return nil
+}
+
+// Stop signals the ChannelNotifier to exit, and waits for all client
+// goroutines to do the same.
+func (c *ChannelNotifier) Stop() error {
+	if !atomic.CompareAndSwapUint32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("ChannelNotifier shutting down")
+
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// SubscribeChannelEvents returns a new subscription to all channel events
+// dispatched after the call returns.
+func (c *ChannelNotifier) SubscribeChannelEvents() (*Subscription, error) {
+	client := &subscription{
+		updates:    make(chan interface{}),
+		ntfnQueue:  chainntnfs.NewConcurrentQueue(20),
+		cancelChan: make(chan struct{}),
+	}
+	client.ntfnQueue.Start()
+
+	c.clientMtx.Lock()
+	client.id = c.nextClientID
+	c.nextClientID++
+	c.clients[client.id] = client
+	c.clientMtx.Unlock()
+
+	// With the client registered, we'll launch a goroutine that proxies
+	// all events appended to its queue to the channel the caller will
+	// feed off of.
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for {
+			select {
+			case event := <-client.ntfnQueue.ChanOut():
+				select {
+				case client.updates <- event:
+				case <-client.cancelChan:
+					return
+				case <-c.quit:
+					return
+				}
+
+			case <-client.cancelChan:
+				return
+
+			case <-c.quit:
+				return
+			}
+		}
+	}()
+
+	return &Subscription{
+		Updates: client.updates,
+		Cancel: func() {
+			c.cancelSubscription(client)
+		},
+	}, nil
+}
+
+// cancelSubscription removes the passed client from the set of active
+// subscribers, and frees its resources.
+func (c *ChannelNotifier) cancelSubscription(client *subscription) {
+	if !atomic.CompareAndSwapUint32(&client.cancelled, 0, 1) {
+		return
+	}
+
+	c.clientMtx.Lock()
+	delete(c.clients, client.id)
+	c.clientMtx.Unlock()
+
+	client.ntfnQueue.Stop()
+	close(client.cancelChan)
+}
+
+// dispatchEvent sends the passed event to all active subscribers.
+func (c *ChannelNotifier) dispatchEvent(event interface{}) {
+	c.clientMtx.Lock()
+	defer c.clientMtx.Unlock()
+
+	for _, client := range c.clients {
+		select {
+		case client.ntfnQueue.ChanIn() <- event:
+		case <-client.cancelChan:
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// NotifyPendingOpenChannelEvent notifies all subscribers that the channel
+// identified by the passed channel point has entered the pending open state.
+func (c *ChannelNotifier) NotifyPendingOpenChannelEvent(chanPoint wire.OutPoint,
+	pendingChan *channeldb.OpenChannel) {
+
+	c.dispatchEvent(PendingOpenChannelEvent{
+		ChannelPoint:   &chanPoint,
+		PendingChannel: pendingChan,
+	})
+}
+
+// NotifyOpenChannelEvent notifies all subscribers that the channel identified
+// by the passed channel point has been opened.
+func (c *ChannelNotifier) NotifyOpenChannelEvent(chanPoint wire.OutPoint) {
+	openChan, err := c.chanDB.FetchChannel(chanPoint)
+	if err != nil {
+		log.Errorf("Unable to fetch new ChannelPoint(%v): %v",
+			chanPoint, err)
+		return
+	}
+
+	c.dispatchEvent(OpenChannelEvent{Channel: openChan})
+}
+
+// NotifyActiveChannelEvent notifies all subscribers that the channel
+// identified by the passed channel point has become active.
+func (c *ChannelNotifier) NotifyActiveChannelEvent(chanPoint wire.OutPoint) {
+	c.dispatchEvent(ActiveChannelEvent{ChannelPoint: &chanPoint})
+}
+
+// NotifyInactiveChannelEvent notifies all subscribers that the channel
+// identified by the passed channel point has become inactive.
+func (c *ChannelNotifier) NotifyInactiveChannelEvent(chanPoint wire.OutPoint) {
+	c.dispatchEvent(InactiveChannelEvent{ChannelPoint: &chanPoint})
+}
+
+// NotifyClosingChannelEvent notifies all subscribers that the channel
+// identified by the passed channel point has begun closing.
+func (c *ChannelNotifier) NotifyClosingChannelEvent(chanPoint wire.OutPoint,
+	closeType channeldb.ClosureType) {
+
+	c.dispatchEvent(ClosingChannelEvent{
+		ChannelPoint: &chanPoint,
+		CloseType:    closeType,
+	})
+}
+
+// NotifyClosedChannelEvent notifies all subscribers that the channel
+// identified by the passed channel point has been fully closed.
+func (c *ChannelNotifier) NotifyClosedChannelEvent(chanPoint wire.OutPoint) {
+	closeSummary, err := c.chanDB.FetchClosedChannel(&chanPoint)
+	if err != nil {
+		log.Errorf("Unable to fetch closed ChannelPoint(%v): %v",
+			chanPoint, err)
+		return
+	}
+
+	c.dispatchEvent(ClosedChannelEvent{CloseSummary: closeSummary})
+}
+
+// NotifyBalanceUpdateEvent notifies all subscribers that a new commitment
+// has been locked in for the channel described by the passed snapshot.
+func (c *ChannelNotifier) NotifyBalanceUpdateEvent(
+	snapshot *channeldb.ChannelSnapshot) {
+
+	chanPoint := snapshot.ChannelPoint
+	c.dispatchEvent(BalanceUpdateEvent{
+		ChannelPoint:  &chanPoint,
+		LocalBalance:  snapshot.LocalBalance,
+		RemoteBalance: snapshot.RemoteBalance,
+		CommitHeight:  snapshot.CommitHeight,
+	})
+}
+
+// NotifySplicedChannelEvent notifies all subscribers that the channel
+// formerly identified by the passed channel point has moved to the new
+// channel point.
+func (c *ChannelNotifier) NotifySplicedChannelEvent(oldChanPoint,
+	newChanPoint wire.OutPoint) {
+
+	newChan, err := c.chanDB.FetchChannel(newChanPoint)
+	if err != nil {
+		log.Errorf("Unable to fetch spliced ChannelPoint(%v): %v",
+			newChanPoint, err)
+		return
+	}
+
+	c.dispatchEvent(SplicedChannelEvent{
+		OldChannelPoint: &oldChanPoint,
+		Channel:         newChan,
+	})
+}
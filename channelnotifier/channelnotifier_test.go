@@ -0,0 +1,121 @@
+package channelnotifier
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	testChanPoint = wire.OutPoint{
+		Hash:  [32]byte{0x1},
+		Index: 1,
+	}
+)
+
+// receiveEvent waits for the next event of the passed subscription, failing
+// the test if none arrives in time.
+func receiveEvent(t *testing.T, sub *Subscription) interface{} {
+	t.Helper()
+
+	select {
+	case event := <-sub.Updates:
+		return event
+	case <-time.After(time.Second * 5):
+		t.Fatalf("no event received")
+		return nil
+	}
+}
+
+// TestChannelNotifierDispatch tests that all events are delivered in order to
+// every active subscriber, and that a cancelled subscription no longer
+// receives any events.
+func TestChannelNotifierDispatch(t *testing.T) {
+	t.Parallel()
+
+	// None of the events we dispatch require the channel database.
+	notifier := New(nil)
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+	defer notifier.Stop()
+
+	sub1, err := notifier.SubscribeChannelEvents()
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	defer sub1.Cancel()
+
+	sub2, err := notifier.SubscribeChannelEvents()
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+
+	pendingChan := &channeldb.OpenChannel{FundingOutpoint: testChanPoint}
+	snapshot := &channeldb.ChannelSnapshot{
+		ChannelPoint: testChanPoint,
+		ChannelCommitment: channeldb.ChannelCommitment{
+			CommitHeight:  3,
+			LocalBalance:  lnwire.MilliSatoshi(1000),
+			RemoteBalance: lnwire.MilliSatoshi(2000),
+		},
+	}
+
+	notifier.NotifyPendingOpenChannelEvent(testChanPoint, pendingChan)
+	notifier.NotifyActiveChannelEvent(testChanPoint)
+	notifier.NotifyBalanceUpdateEvent(snapshot)
+	notifier.NotifyInactiveChannelEvent(testChanPoint)
+	notifier.NotifyClosingChannelEvent(
+		testChanPoint, channeldb.CooperativeClose,
+	)
+
+	chanPoint := testChanPoint
+	expectedEvents := []interface{}{
+		PendingOpenChannelEvent{
+			ChannelPoint:   &chanPoint,
+			PendingChannel: pendingChan,
+		},
+		ActiveChannelEvent{ChannelPoint: &chanPoint},
+		BalanceUpdateEvent{
+			ChannelPoint:  &chanPoint,
+			LocalBalance:  lnwire.MilliSatoshi(1000),
+			RemoteBalance: lnwire.MilliSatoshi(2000),
+			CommitHeight:  3,
+		},
+		InactiveChannelEvent{ChannelPoint: &chanPoint},
+		ClosingChannelEvent{
+			ChannelPoint: &chanPoint,
+			CloseType:    channeldb.CooperativeClose,
+		},
+	}
+
+	for _, sub := range []*Subscription{sub1, sub2} {
+		for i, expected := range expectedEvents {
+			event := receiveEvent(t, sub)
+			if !reflect.DeepEqual(event, expected) {
+				t.Fatalf("event #%v mismatch: expected %v, "+
+					"got %v", i, expected, event)
+			}
+		}
+	}
+
+	// Once the second subscription has been cancelled, only the first
+	// should receive new events.
+	sub2.Cancel()
+	notifier.NotifyActiveChannelEvent(testChanPoint)
+
+	event := receiveEvent(t, sub1)
+	if _, ok := event.(ActiveChannelEvent); !ok {
+		t.Fatalf("expected ActiveChannelEvent, got %T", event)
+	}
+
+	select {
+	case event := <-sub2.Updates:
+		t.Fatalf("received event after cancel: %v", event)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -154,6 +154,10 @@ var sendCoinsCommand = cli.Command{
 	Fees used when sending the transaction can be specified via the --conf_target, or
 	--sat_per_byte optional flags.
 
+	To empty the wallet, set the --sweepall flag instead of an amount. All
+	confirmed coins, or the ones selected with --outpoint, are then sent to
+	addr in a single output, minus the fee.
+
 	Positional arguments and flags can be used interchangeably but not at the same time!
 	`,
 	Flags: []cli.Flag{
@@ -166,6 +170,13 @@ var sendCoinsCommand = cli.Command{
 			Name:  "amt",
 			Usage: "the number of bitcoin denominated in satoshis to send",
 		},
+		cli.BoolFlag{
+			Name: "sweepall",
+			Usage: "if set, then the amount field will be ignored, " +
+				"and the wallet will attempt to sweep all " +
+				"outputs within the wallet to the target " +
+				"address",
+		},
 		cli.Int64Flag{
 			Name: "conf_target",
 			Usage: "(optional) the number of blocks that the " +
@@ -178,6 +189,23 @@ var sendCoinsCommand = cli.Command{
 				"sat/byte that should be used when crafting " +
 				"the transaction",
 		},
+		cli.StringSliceFlag{
+			Name: "outpoint",
+			Usage: "(optional) a wallet output in the format " +
+				"txid:output_index to spend, can be specified " +
+				"multiple times. If set, exactly these outputs " +
+				"are spent instead of selecting coins " +
+				"automatically",
+		},
+		cli.StringFlag{
+			Name: "lock_id",
+			Usage: "(optional) the hex encoded lock ID the " +
+				"outputs set with --outpoint are leased under",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "(optional) a label for the transaction",
+		},
 	},
 	Action: actionDecorator(sendCoins),
 }
@@ -211,6 +239,12 @@ func sendCoins(ctx *cli.Context) error {
 	}
 
 	switch {
+	case ctx.Bool("sweepall"):
+		if ctx.IsSet("amt") || args.Present() {
+			return fmt.Errorf("amount cannot be set if " +
+				"attempting to sweep all coins out of the " +
+				"wallet")
+		}
 	case ctx.IsSet("amt"):
 		amt = ctx.Int64("amt")
 	case args.Present():
@@ -223,6 +257,15 @@ func sendCoins(ctx *cli.Context) error {
 		return fmt.Errorf("unable to decode amount: %v", err)
 	}
 
+	outpoints, err := parseOutPoints(ctx.StringSlice("outpoint"))
+	if err != nil {
+		return err
+	}
+	lockID, err := hex.DecodeString(ctx.String("lock_id"))
+	if err != nil {
+		return fmt.Errorf("unable to decode lock_id: %v", err)
+	}
+
 	ctxb := context.Background()
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
@@ -232,6 +275,10 @@ func sendCoins(ctx *cli.Context) error {
 		Amount:     amt,
 		TargetConf: int32(ctx.Int64("conf_target")),
 		SatPerByte: ctx.Int64("sat_per_byte"),
+		Outpoints:  outpoints,
+		LockId:     lockID,
+		SendAll:    ctx.Bool("sweepall"),
+		Label:      ctx.String("label"),
 	}
 	txid, err := client.SendCoins(ctxb, req)
 	if err != nil {
@@ -266,6 +313,23 @@ var sendManyCommand = cli.Command{
 			Usage: "(optional) a manual fee expressed in sat/byte that should be " +
 				"used when crafting the transaction",
 		},
+		cli.StringSliceFlag{
+			Name: "outpoint",
+			Usage: "(optional) a wallet output in the format " +
+				"txid:output_index to spend, can be specified " +
+				"multiple times. If set, exactly these outputs " +
+				"are spent instead of selecting coins " +
+				"automatically",
+		},
+		cli.StringFlag{
+			Name: "lock_id",
+			Usage: "(optional) the hex encoded lock ID the " +
+				"outputs set with --outpoint are leased under",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "(optional) a label for the transaction",
+		},
 	},
 	Action: actionDecorator(sendMany),
 }
@@ -283,6 +347,15 @@ func sendMany(ctx *cli.Context) error {
 			"set, but not both")
 	}
 
+	outpoints, err := parseOutPoints(ctx.StringSlice("outpoint"))
+	if err != nil {
+		return err
+	}
+	lockID, err := hex.DecodeString(ctx.String("lock_id"))
+	if err != nil {
+		return fmt.Errorf("unable to decode lock_id: %v", err)
+	}
+
 	ctxb := context.Background()
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
@@ -291,6 +364,9 @@ func sendMany(ctx *cli.Context) error {
 		AddrToAmount: amountToAddr,
 		TargetConf:   int32(ctx.Int64("conf_target")),
 		SatPerByte:   ctx.Int64("sat_per_byte"),
+		Outpoints:    outpoints,
+		LockId:       lockID,
+		Label:        ctx.String("label"),
 	})
 	if err != nil {
 		return err
@@ -300,6 +376,298 @@ func sendMany(ctx *cli.Context) error {
 	return nil
 }
 
+var bumpFeeCommand = cli.Command{
+	Name:      "bumpfee",
+	Category:  "On-chain",
+	Usage:     "Bump the fee of an unconfirmed transaction.",
+	ArgsUsage: "outpoint [--conf_target=N] [--sat_per_byte=P]",
+	Description: `
+	Speed up the confirmation of an unconfirmed transaction created by lnd,
+	identified by one of its outputs in the format 'txid:output_index'.
+
+	If the transaction is a sweep of lnd that signals replaceability, it is
+	replaced by a transaction spending the same inputs at the new fee rate
+	(RBF). Otherwise, the passed output, which must belong to the wallet, is
+	spent in a child transaction that pays for both itself and its parent
+	(CPFP).
+	`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the transaction *should* " +
+				"confirm in, will be used for fee estimation",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) a manual fee expressed in sat/byte that should be " +
+				"used when bumping the transaction",
+		},
+	},
+	Action: actionDecorator(bumpFee),
+}
+
+func bumpFee(ctx *cli.Context) error {
+	// Show command help if no arguments were provided.
+	if ctx.NArg() != 1 {
+		cli.ShowCommandHelp(ctx, "bumpfee")
+		return nil
+	}
+
+	if ctx.IsSet("conf_target") && ctx.IsSet("sat_per_byte") {
+		return fmt.Errorf("either conf_target or sat_per_byte should be " +
+			"set, but not both")
+	}
+
+	outpoint, err := parseOutPoint(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.BumpFee(ctxb, &lnrpc.BumpFeeRequest{
+		Outpoint:   outpoint,
+		TargetConf: int32(ctx.Int64("conf_target")),
+		SatPerByte: ctx.Int64("sat_per_byte"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+// parseOutPoint parses an outpoint in the format 'txid:output_index'.
+func parseOutPoint(s string) (*lnrpc.OutPoint, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected outpoint in format "+
+			"txid:output_index, got %v", s)
+	}
+
+	index, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode output index: %v",
+			err)
+	}
+
+	return &lnrpc.OutPoint{
+		Txid: &lnrpc.OutPoint_TxidStr{
+			TxidStr: parts[0],
+		},
+		OutputIndex: uint32(index),
+	}, nil
+}
+
+// parseOutPoints parses a list of outpoints in the format 'txid:output_index'.
+func parseOutPoints(strs []string) ([]*lnrpc.OutPoint, error) {
+	var outpoints []*lnrpc.OutPoint
+	for _, s := range strs {
+		outpoint, err := parseOutPoint(s)
+		if err != nil {
+			return nil, err
+		}
+		outpoints = append(outpoints, outpoint)
+	}
+
+	return outpoints, nil
+}
+
+var labelTxCommand = cli.Command{
+	Name:      "labeltx",
+	Category:  "On-chain",
+	Usage:     "Label a transaction of the wallet.",
+	ArgsUsage: "txid label [--overwrite]",
+	Description: `
+	Assign a label to a transaction of the wallet, for example to describe
+	an external deposit. Labels are returned by listchaintxns.
+
+	If the transaction already has a label, the --overwrite flag must be set
+	to replace it.
+	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "overwrite",
+			Usage: "replace an existing label of the transaction",
+		},
+	},
+	Action: actionDecorator(labelTx),
+}
+
+func labelTx(ctx *cli.Context) error {
+	// Show command help if no arguments were provided.
+	if ctx.NArg() != 2 {
+		cli.ShowCommandHelp(ctx, "labeltx")
+		return nil
+	}
+
+	txid, err := chainhash.NewHashFromStr(ctx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("unable to decode txid: %v", err)
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.LabelTransaction(ctxb, &lnrpc.LabelTransactionRequest{
+		Txid:      txid[:],
+		Label:     ctx.Args().Get(1),
+		Overwrite: ctx.Bool("overwrite"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listUnspentCommand = cli.Command{
+	Name:      "listunspent",
+	Category:  "On-chain",
+	Usage:     "List utxos available for spending.",
+	ArgsUsage: "[--min_confs=N] [--max_confs=N]",
+	Description: `
+	List all witness outputs of the wallet that have between min_confs and
+	max_confs confirmations. Leased outputs are not listed.
+
+	To include unconfirmed outputs, set min_confs to 0. If max_confs is not
+	set, there is no upper limit.
+	`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "min_confs",
+			Usage: "the minimum number of confirmations for a utxo",
+			Value: 1,
+		},
+		cli.Int64Flag{
+			Name:  "max_confs",
+			Usage: "(optional) the maximum number of confirmations for a utxo",
+		},
+	},
+	Action: actionDecorator(listUnspent),
+}
+
+func listUnspent(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListUnspent(ctxb, &lnrpc.ListUnspentRequest{
+		MinConfs: int32(ctx.Int64("min_confs")),
+		MaxConfs: int32(ctx.Int64("max_confs")),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var leaseOutputCommand = cli.Command{
+	Name:      "leaseoutput",
+	Category:  "On-chain",
+	Usage:     "Lock a utxo to exclude it from coin selection.",
+	ArgsUsage: "lock_id outpoint [--expiry=N]",
+	Description: `
+	Lease the wallet output in the format 'txid:output_index' under the hex
+	encoded 32 byte lock_id. While leased, the output is not used for
+	automatic coin selection. Leasing an output again under the same
+	lock_id extends the lease.
+	`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "expiry",
+			Usage: "(optional) the duration of the lease in " +
+				"seconds, defaults to 600",
+		},
+	},
+	Action: actionDecorator(leaseOutput),
+}
+
+func leaseOutput(ctx *cli.Context) error {
+	// Show command help if no arguments were provided.
+	if ctx.NArg() != 2 {
+		cli.ShowCommandHelp(ctx, "leaseoutput")
+		return nil
+	}
+
+	lockID, err := hex.DecodeString(ctx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("unable to decode lock id: %v", err)
+	}
+	outpoint, err := parseOutPoint(ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.LeaseOutput(ctxb, &lnrpc.LeaseOutputRequest{
+		Id:                lockID,
+		Outpoint:          outpoint,
+		ExpirationSeconds: ctx.Uint64("expiry"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var releaseOutputCommand = cli.Command{
+	Name:      "releaseoutput",
+	Category:  "On-chain",
+	Usage:     "Release a leased utxo.",
+	ArgsUsage: "lock_id outpoint",
+	Description: `
+	Release the lease of the wallet output in the format
+	'txid:output_index' that was leased under the hex encoded lock_id,
+	making it available for automatic coin selection again.
+	`,
+	Action: actionDecorator(releaseOutput),
+}
+
+func releaseOutput(ctx *cli.Context) error {
+	// Show command help if no arguments were provided.
+	if ctx.NArg() != 2 {
+		cli.ShowCommandHelp(ctx, "releaseoutput")
+		return nil
+	}
+
+	lockID, err := hex.DecodeString(ctx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("unable to decode lock id: %v", err)
+	}
+	outpoint, err := parseOutPoint(ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ReleaseOutput(ctxb, &lnrpc.ReleaseOutputRequest{
+		Id:       lockID,
+		Outpoint: outpoint,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var connectCommand = cli.Command{
 	Name:      "connect",
 	Category:  "Peers",
@@ -476,6 +844,49 @@ var openChannelCommand = cli.Command{
 				"transaction must satisfy",
 			Value: 1,
 		},
+		cli.StringFlag{
+			Name: "close_address",
+			Usage: "(optional) an address to commit to paying our " +
+				"funds out to upon a cooperative close of the " +
+				"channel. If set, the remote peer will refuse to " +
+				"cooperatively close the channel to any other " +
+				"address",
+		},
+		cli.Int64Flag{
+			Name: "remote_chan_reserve_sat",
+			Usage: "(optional) the channel reserve in satoshis we " +
+				"will require our channel counterparty to " +
+				"maintain. If this is not set, we will require " +
+				"1% of the channel size",
+		},
+		cli.Uint64Flag{
+			Name: "remote_max_value_in_flight_msat",
+			Usage: "(optional) the maximum value in millisatoshi " +
+				"we will allow our channel counterparty to have " +
+				"in outstanding HTLCs towards us. If this is not " +
+				"set, we will allow the full channel size minus " +
+				"the channel reserve",
+		},
+		cli.Uint64Flag{
+			Name: "remote_max_htlcs",
+			Usage: "(optional) the maximum number of concurrent " +
+				"HTLCs we will allow our channel counterparty " +
+				"to offer us. If this is not set, we will allow " +
+				"the protocol maximum of 483",
+		},
+		cli.StringSliceFlag{
+			Name: "outpoint",
+			Usage: "(optional) a wallet output in the format " +
+				"txid:output_index to fund the channel with, " +
+				"can be specified multiple times. If set, " +
+				"exactly these outputs are spent instead of " +
+				"selecting coins automatically",
+		},
+		cli.StringFlag{
+			Name: "lock_id",
+			Usage: "(optional) the hex encoded lock ID the " +
+				"outputs set with --outpoint are leased under",
+		},
 	},
 	Action: actionDecorator(openChannel),
 }
@@ -501,6 +912,23 @@ func openChannel(ctx *cli.Context) error {
 		MinHtlcMsat:    ctx.Int64("min_htlc_msat"),
 		RemoteCsvDelay: uint32(ctx.Uint64("remote_csv_delay")),
 		MinConfs:       int32(ctx.Uint64("min_confs")),
+		CloseAddress:   ctx.String("close_address"),
+		RemoteChanReserveSat: ctx.Int64(
+			"remote_chan_reserve_sat",
+		),
+		RemoteMaxValueInFlightMsat: ctx.Uint64(
+			"remote_max_value_in_flight_msat",
+		),
+		RemoteMaxHtlcs: uint32(ctx.Uint64("remote_max_htlcs")),
+	}
+
+	req.Outpoints, err = parseOutPoints(ctx.StringSlice("outpoint"))
+	if err != nil {
+		return err
+	}
+	req.LockId, err = hex.DecodeString(ctx.String("lock_id"))
+	if err != nil {
+		return fmt.Errorf("unable to decode lock_id: %v", err)
 	}
 
 	switch {
@@ -653,7 +1081,11 @@ var closeChannelCommand = cli.Command{
 	In the case of a cooperative closure, One can manually set the fee to
 	be used for the closing transaction via either the --conf_target or
 	--sat_per_byte arguments. This will be the starting value used during
-	fee negotiation. This is optional.
+	fee negotiation. This is optional. The --max_sat_per_byte argument caps
+	the fee rate we're willing to pay, offers of the remote party above it
+	won't be signed. The remote party's last offer is printed, so one can
+	decide whether to keep waiting or force close the channel instead. Our
+	settled funds can be sent to a specific address via --delivery_addr.
 
 	To view which funding_txids/output_indexes can be used for a channel close,
 	see the channel_point values within the listchannels command output.
@@ -695,6 +1127,17 @@ var closeChannelCommand = cli.Command{
 				"sat/byte that should be used when crafting " +
 				"the transaction",
 		},
+		cli.Int64Flag{
+			Name: "max_sat_per_byte",
+			Usage: "(optional) the highest fee expressed in " +
+				"sat/byte that we're willing to pay for the " +
+				"transaction",
+		},
+		cli.StringFlag{
+			Name: "delivery_addr",
+			Usage: "(optional) an address to deliver our settled " +
+				"funds to in a cooperative close",
+		},
 	},
 	Action: actionDecorator(closeChannel),
 }
@@ -716,10 +1159,12 @@ func closeChannel(ctx *cli.Context) error {
 
 	// TODO(roasbeef): implement time deadline within server
 	req := &lnrpc.CloseChannelRequest{
-		ChannelPoint: channelPoint,
-		Force:        ctx.Bool("force"),
-		TargetConf:   int32(ctx.Int64("conf_target")),
-		SatPerByte:   ctx.Int64("sat_per_byte"),
+		ChannelPoint:    channelPoint,
+		Force:           ctx.Bool("force"),
+		TargetConf:      int32(ctx.Int64("conf_target")),
+		SatPerByte:      ctx.Int64("sat_per_byte"),
+		DeliveryAddress: ctx.String("delivery_addr"),
+		MaxSatPerByte:   ctx.Int64("max_sat_per_byte"),
 	}
 
 	// After parsing the request, we'll spin up a goroutine that will
@@ -787,6 +1232,8 @@ func executeChannelClose(client lnrpc.LightningClient, req *lnrpc.CloseChannelRe
 			if !block {
 				return nil
 			}
+		case *lnrpc.CloseStatusUpdate_FeeOffer:
+			printRespJSON(update.FeeOffer)
 		case *lnrpc.CloseStatusUpdate_ChanClose:
 			return nil
 		}
@@ -811,7 +1258,13 @@ var closeAllChannelsCommand = cli.Command{
 	By default, one is prompted for confirmation every time an inactive
 	channel is requested to be closed. To avoid this, one can set the
 	--force flag, which will only prompt for confirmation once for all
-	inactive channels and proceed to close them.`,
+	inactive channels and proceed to close them.
+
+	If --sweep_addr is set, the command waits for all closing transactions
+	to confirm and then sweeps all confirmed funds of the wallet to the
+	given address, e.g. to move them to cold storage. Funds of unilaterally
+	closed channels are only included once they are no longer time locked
+	and have been swept back into the wallet.`,
 	Flags: []cli.Flag{
 		cli.BoolFlag{
 			Name:  "inactive_only",
@@ -822,6 +1275,18 @@ var closeAllChannelsCommand = cli.Command{
 			Usage: "ask for confirmation once before attempting " +
 				"to close existing channels",
 		},
+		cli.StringFlag{
+			Name: "sweep_addr",
+			Usage: "(optional) an address to sweep all confirmed " +
+				"wallet funds to once the closing transactions " +
+				"confirmed",
+		},
+		cli.Int64Flag{
+			Name: "sweep_conf_target",
+			Usage: "(optional) the number of blocks that the sweep " +
+				"transaction *should* confirm in, will be used " +
+				"for fee estimation",
+		},
 	},
 	Action: actionDecorator(closeAllChannels),
 }
@@ -911,6 +1376,8 @@ func closeAllChannels(ctx *cli.Context) error {
 		}
 	}
 
+	sweepAddr := ctx.String("sweep_addr")
+
 	// result defines the result of closing a channel. The closing
 	// transaction ID is populated if a channel is successfully closed.
 	// Otherwise, the error that prevented closing the channel is populated.
@@ -959,8 +1426,12 @@ func closeAllChannels(ctx *cli.Context) error {
 				Force: !channel.GetActive(),
 			}
 
+			// If we're sweeping the funds afterwards, we'll wait
+			// for the closing transaction to confirm.
 			txidChan := make(chan string, 1)
-			err = executeChannelClose(client, req, txidChan, false)
+			err = executeChannelClose(
+				client, req, txidChan, sweepAddr != "",
+			)
 			if err != nil {
 				res.FailErr = fmt.Sprintf("unable to close "+
 					"channel: %v", err)
@@ -976,6 +1447,20 @@ func closeAllChannels(ctx *cli.Context) error {
 		printJSON(res)
 	}
 
+	if sweepAddr == "" {
+		return nil
+	}
+
+	resp, err := client.SendCoins(context.Background(), &lnrpc.SendCoinsRequest{
+		Addr:       sweepAddr,
+		TargetConf: int32(ctx.Int64("sweep_conf_target")),
+		SendAll:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to sweep wallet: %v", err)
+	}
+
+	printRespJSON(resp)
 	return nil
 }
 
@@ -992,32 +1477,199 @@ func promptForConfirmation(msg string) bool {
 			return false
 		}
 
-		answer = strings.ToLower(strings.TrimSpace(answer))
+		answer = strings.ToLower(strings.TrimSpace(answer))
+
+		switch {
+		case answer == "yes":
+			return true
+		case answer == "no":
+			return false
+		default:
+			continue
+		}
+	}
+}
+
+var abandonChannelCommand = cli.Command{
+	Name:     "abandonchannel",
+	Category: "Channels",
+	Usage:    "Abandons an existing channel.",
+	Description: `
+	Removes all channel state from the database except for a close 
+	summary. This method can be used to get rid of permanently unusable
+	channels due to bugs fixed in newer versions of lnd. 
+	
+	Only available when lnd is built in debug mode.
+
+	To view which funding_txids/output_indexes can be used for this command,
+	see the channel_point values within the listchannels command output.
+	The format for a channel_point is 'funding_txid:output_index'.`,
+	ArgsUsage: "funding_txid [output_index]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+	},
+	Action: actionDecorator(abandonChannel),
+}
+
+func abandonChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments and flags were provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "abandonchannel")
+		return nil
+	}
+
+	channelPoint, err := parseChannelPoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.AbandonChannelRequest{
+		ChannelPoint: channelPoint,
+	}
+
+	resp, err := client.AbandonChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var spliceOutCommand = cli.Command{
+	Name:     "spliceout",
+	Category: "Channels",
+	Usage:    "Move funds out of an existing channel without closing it.",
+	Description: `
+	Splice funds out of an existing channel. Both parties agree upon a
+	splice transaction, which spends the current funding output into a
+	smaller funding output, and an output paying the spliced out amount
+	on-chain. The channel keeps its prior state until the splice
+	transaction confirms, after which it moves to its new channel point.
+
+	Only the initiator of a channel can splice out funds, and both peers
+	must support splicing. If no address is specified, the funds are paid
+	to a new address of the wallet. One can manually set the fee to be used
+	for the splice transaction via either the --conf_target or
+	--sat_per_byte arguments. This is optional.
+
+	To view which funding_txids/output_indexes can be used for a splice,
+	see the channel_point values within the listchannels command output.
+	The format for a channel_point is 'funding_txid:output_index'.`,
+	ArgsUsage: "--amt=X funding_txid [output_index]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the number of satoshis to splice out of the channel",
+		},
+		cli.StringFlag{
+			Name: "addr",
+			Usage: "(optional) the address the spliced out funds " +
+				"should be sent to",
+		},
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the " +
+				"transaction *should* confirm in, will be " +
+				"used for fee estimation",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) a manual fee expressed in " +
+				"sat/byte that should be used when crafting " +
+				"the transaction",
+		},
+	},
+	Action: actionDecorator(spliceOut),
+}
+
+func spliceOut(ctx *cli.Context) error {
+	ctxb := context.Background()
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments and flags were provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "spliceout")
+		return nil
+	}
+
+	// Check that only the field sat_per_byte or conf_target was set.
+	if ctx.IsSet("conf_target") && ctx.IsSet("sat_per_byte") {
+		return fmt.Errorf("either conf_target or sat_per_byte should be " +
+			"set, but not both")
+	}
+
+	if !ctx.IsSet("amt") {
+		return fmt.Errorf("amount argument missing")
+	}
+
+	channelPoint, err := parseChannelPoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.SpliceOutRequest{
+		ChannelPoint: channelPoint,
+		Amount:       ctx.Int64("amt"),
+		Addr:         ctx.String("addr"),
+		TargetConf:   int32(ctx.Int64("conf_target")),
+		SatPerByte:   ctx.Int64("sat_per_byte"),
+	}
 
-		switch {
-		case answer == "yes":
-			return true
-		case answer == "no":
-			return false
-		default:
-			continue
-		}
+	resp, err := client.SpliceOut(ctxb, req)
+	if err != nil {
+		return err
 	}
+
+	printRespJSON(resp)
+	return nil
 }
 
-var abandonChannelCommand = cli.Command{
-	Name:     "abandonchannel",
+var bumpPendingChannelCommand = cli.Command{
+	Name:     "bumppendingchannel",
 	Category: "Channels",
-	Usage:    "Abandons an existing channel.",
+	Usage:    "Unstick the funding transaction of a pending channel.",
 	Description: `
-	Removes all channel state from the database except for a close 
-	summary. This method can be used to get rid of permanently unusable
-	channels due to bugs fixed in newer versions of lnd. 
-	
-	Only available when lnd is built in debug mode.
-
-	To view which funding_txids/output_indexes can be used for this command,
-	see the channel_point values within the listchannels command output.
+	Bump the fee of the unconfirmed funding transaction of a pending channel
+	that we initiated. By default, the change output of the funding
+	transaction is spent in a child transaction (CPFP), such that the
+	transactions together pay the requested fee rate.
+
+	If the --double_spend flag is set, all inputs of the funding transaction
+	are instead spent back to the wallet at the requested fee rate. This is
+	only accepted by the network if the funding transaction signals
+	replaceability or has been evicted from the mempool. Once the double
+	spend confirms, the pending channel is forgotten.
+
+	One can manually set the fee to be used via either the --conf_target or
+	--sat_per_byte arguments. This is optional.
+
+	To view which funding_txids/output_indexes can be used, see the
+	channel_point values within the pendingchannels command output.
 	The format for a channel_point is 'funding_txid:output_index'.`,
 	ArgsUsage: "funding_txid [output_index]",
 	Flags: []cli.Flag{
@@ -1030,11 +1682,28 @@ var abandonChannelCommand = cli.Command{
 			Usage: "the output index for the funding output of the funding " +
 				"transaction",
 		},
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the " +
+				"transaction *should* confirm in, will be " +
+				"used for fee estimation",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) a manual fee expressed in " +
+				"sat/byte that should be used when crafting " +
+				"the transaction",
+		},
+		cli.BoolFlag{
+			Name: "double_spend",
+			Usage: "double spend the inputs of the funding " +
+				"transaction instead of bumping its fee",
+		},
 	},
-	Action: actionDecorator(abandonChannel),
+	Action: actionDecorator(bumpPendingChannel),
 }
 
-func abandonChannel(ctx *cli.Context) error {
+func bumpPendingChannel(ctx *cli.Context) error {
 	ctxb := context.Background()
 
 	client, cleanUp := getClient(ctx)
@@ -1042,20 +1711,29 @@ func abandonChannel(ctx *cli.Context) error {
 
 	// Show command help if no arguments and flags were provided.
 	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
-		cli.ShowCommandHelp(ctx, "abandonchannel")
+		cli.ShowCommandHelp(ctx, "bumppendingchannel")
 		return nil
 	}
 
+	// Check that only the field sat_per_byte or conf_target was set.
+	if ctx.IsSet("conf_target") && ctx.IsSet("sat_per_byte") {
+		return fmt.Errorf("either conf_target or sat_per_byte should be " +
+			"set, but not both")
+	}
+
 	channelPoint, err := parseChannelPoint(ctx)
 	if err != nil {
 		return err
 	}
 
-	req := &lnrpc.AbandonChannelRequest{
+	req := &lnrpc.BumpPendingChannelRequest{
 		ChannelPoint: channelPoint,
+		TargetConf:   int32(ctx.Int64("conf_target")),
+		SatPerByte:   ctx.Int64("sat_per_byte"),
+		DoubleSpend:  ctx.Bool("double_spend"),
 	}
 
-	resp, err := client.AbandonChannel(ctxb, req)
+	resp, err := client.BumpPendingChannel(ctxb, req)
 	if err != nil {
 		return err
 	}
@@ -1589,6 +2267,32 @@ func getInfo(ctx *cli.Context) error {
 	return nil
 }
 
+var getRecoveryInfoCommand = cli.Command{
+	Name:  "getrecoveryinfo",
+	Usage: "Display the progress of the wallet recovery.",
+	Description: `
+	Returns whether the wallet was restored from a seed with a recovery
+	window and, if so, the height the chain has been scanned up to, the
+	number of addresses found per key scope and an estimate of when the
+	recovery will be finished.`,
+	Action: actionDecorator(getRecoveryInfo),
+}
+
+func getRecoveryInfo(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.GetRecoveryInfoRequest{}
+	resp, err := client.GetRecoveryInfo(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var pendingChannelsCommand = cli.Command{
 	Name:     "pendingchannels",
 	Category: "Channels",
@@ -2794,6 +3498,62 @@ func debugLevel(ctx *cli.Context) error {
 	return nil
 }
 
+var debugChannelStateCommand = cli.Command{
+	Name:     "debugchannelstate",
+	Category: "Channels",
+	Usage:    "Dump the persisted state of a channel for debugging.",
+	Description: `
+	Dump the persisted state of an existing channel: both commitment
+	transactions along with their HTLCs, the update log indexes, the
+	revocation heights and all forwarding packages of the channel.
+
+	The dump contains our latest signed commitment transaction, so it
+	should only be shared with trusted parties. The format for a
+	channel_point is 'funding_txid:output_index'.`,
+	ArgsUsage: "funding_txid [output_index]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+	},
+	Action: actionDecorator(debugChannelState),
+}
+
+func debugChannelState(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments and flags were provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "debugchannelstate")
+		return nil
+	}
+
+	channelPoint, err := parseChannelPoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.DebugChannelStateRequest{
+		ChannelPoint: channelPoint,
+	}
+
+	resp, err := client.DebugChannelState(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var decodePayReqCommand = cli.Command{
 	Name:        "decodepayreq",
 	Category:    "Payments",
@@ -3280,3 +4040,269 @@ func forwardingHistory(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var exportChanBackupCommand = cli.Command{
+	Name:     "exportchanbackup",
+	Category: "Channels",
+	Usage:    "Obtain a static channel backup for a target channel.",
+	Description: `
+	This command allows a user to export a Static Channel Backup (SCB) for
+	a selected channel. SCB's are encrypted backups of a channel's initial
+	state that are encrypted with a key derived from the seed of a user. In
+	the case of partial or complete data loss, the SCB will allow the user
+	to reclaim settled funds in the channel at its final state. The
+	exported channel backup can be restored at a later time using the
+	restorechanbackup command.
+
+	The format for a chan_point is 'funding_txid:output_index'.`,
+	ArgsUsage: "[chan_point]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "chan_point",
+			Usage: "the target channel to obtain an SCB for",
+		},
+		cli.StringFlag{
+			Name: "output_file",
+			Usage: "if specified, then the backup will be written " +
+				"to the target file",
+		},
+	},
+	Action: actionDecorator(exportChanBackup),
+}
+
+func exportChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "exportchanbackup")
+		return nil
+	}
+
+	var chanPointStr string
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPointStr = ctx.String("chan_point")
+
+	case args.Present():
+		chanPointStr = args.First()
+
+	default:
+		return fmt.Errorf("chan_point argument missing")
+	}
+
+	chanPointParts := strings.Split(chanPointStr, ":")
+	if len(chanPointParts) != 2 {
+		return fmt.Errorf("expecting chan_point to be in format of: " +
+			"txid:index")
+	}
+	index, err := strconv.ParseUint(chanPointParts[1], 10, 32)
+	if err != nil {
+		return fmt.Errorf("unable to decode output index: %v", err)
+	}
+
+	chanPoint := &lnrpc.ChannelPoint{
+		FundingTxid: &lnrpc.ChannelPoint_FundingTxidStr{
+			FundingTxidStr: chanPointParts[0],
+		},
+		OutputIndex: uint32(index),
+	}
+
+	chanBackup, err := client.ExportChannelBackup(
+		ctxb, &lnrpc.ExportChannelBackupRequest{
+			ChanPoint: chanPoint,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsSet("output_file") {
+		return ioutil.WriteFile(
+			ctx.String("output_file"), chanBackup.ChanBackup, 0600,
+		)
+	}
+
+	printJSON(struct {
+		ChanPoint  string `json:"chan_point"`
+		ChanBackup string `json:"chan_backup"`
+	}{
+		ChanPoint:  chanPointStr,
+		ChanBackup: hex.EncodeToString(chanBackup.ChanBackup),
+	})
+	return nil
+}
+
+var verifyChanBackupCommand = cli.Command{
+	Name:     "verifychanbackup",
+	Category: "Channels",
+	Usage:    "Verify an existing channel backup.",
+	Description: `
+	This command allows a user to verify an existing Single or Multi channel
+	backup for integrity. This is useful when a user has a backup, but is
+	unsure as to if it's valid or for the target node.
+
+	The command will accept either a single hex-encoded channel backup, or
+	the path to a multi-channel backup file, such as the channel.backup
+	file that lnd keeps up to date within its data directory.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "single_backup",
+			Usage: "a hex encoded single channel backup obtained " +
+				"from exportchanbackup",
+		},
+		cli.StringFlag{
+			Name:  "multi_file",
+			Usage: "the path to a multi-channel back up file",
+		},
+	},
+	Action: actionDecorator(verifyChanBackup),
+}
+
+func verifyChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "verifychanbackup")
+		return nil
+	}
+
+	var verifyReq lnrpc.ChanBackupSnapshot
+	switch {
+	case ctx.IsSet("single_backup"):
+		packedBackup, err := hex.DecodeString(
+			ctx.String("single_backup"),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to decode single packed "+
+				"backup: %v", err)
+		}
+
+		verifyReq.SingleChanBackups = &lnrpc.ChannelBackups{
+			ChanBackups: []*lnrpc.ChannelBackup{
+				{
+					ChanBackup: packedBackup,
+				},
+			},
+		}
+
+	case ctx.IsSet("multi_file"):
+		packedMulti, err := ioutil.ReadFile(ctx.String("multi_file"))
+		if err != nil {
+			return fmt.Errorf("unable to read multi packed "+
+				"backup: %v", err)
+		}
+
+		verifyReq.MultiChanBackup = &lnrpc.MultiChanBackup{
+			MultiChanBackup: packedMulti,
+		}
+
+	default:
+		return fmt.Errorf("either --single_backup or --multi_file " +
+			"must be specified")
+	}
+
+	resp, err := client.VerifyChanBackup(ctxb, &verifyReq)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var restoreChanBackupCommand = cli.Command{
+	Name:     "restorechanbackup",
+	Category: "Channels",
+	Usage: "Restore an existing single or multi-channel static channel " +
+		"backup.",
+	Description: `
+	Allows a user to restore a Static Channel Backup (SCB) that was
+	obtained either via the exportchanbackup command, or from lnd's
+	automatically managed channel.backup file. This command should be used
+	if a user is attempting to restore a channel due to data loss on a
+	running node restored with the same seed as the node that created the
+	channel. If successful, this command will allow the user to recover the
+	settled funds stored in the recovered channels.
+
+	The command will accept either a single hex-encoded channel backup, or
+	the path to a multi-channel backup file.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "single_backup",
+			Usage: "a hex encoded single channel backup obtained " +
+				"from exportchanbackup",
+		},
+		cli.StringFlag{
+			Name:  "multi_file",
+			Usage: "the path to a multi-channel back up file",
+		},
+	},
+	Action: actionDecorator(restoreChanBackup),
+}
+
+func restoreChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "restorechanbackup")
+		return nil
+	}
+
+	var req lnrpc.RestoreChanBackupRequest
+	switch {
+	case ctx.IsSet("single_backup"):
+		packedBackup, err := hex.DecodeString(
+			ctx.String("single_backup"),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to decode single packed "+
+				"backup: %v", err)
+		}
+
+		req.Backup = &lnrpc.RestoreChanBackupRequest_ChanBackups{
+			ChanBackups: &lnrpc.ChannelBackups{
+				ChanBackups: []*lnrpc.ChannelBackup{
+					{
+						ChanBackup: packedBackup,
+					},
+				},
+			},
+		}
+
+	case ctx.IsSet("multi_file"):
+		packedMulti, err := ioutil.ReadFile(ctx.String("multi_file"))
+		if err != nil {
+			return fmt.Errorf("unable to read multi packed "+
+				"backup: %v", err)
+		}
+
+		req.Backup = &lnrpc.RestoreChanBackupRequest_MultiChanBackup{
+			MultiChanBackup: packedMulti,
+		}
+
+	default:
+		return fmt.Errorf("either --single_backup or --multi_file " +
+			"must be specified")
+	}
+
+	resp, err := client.RestoreChannelBackups(ctxb, &req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -258,6 +258,11 @@ func main() {
 		changePasswordCommand,
 		newAddressCommand,
 		sendManyCommand,
+		bumpFeeCommand,
+		listUnspentCommand,
+		leaseOutputCommand,
+		releaseOutputCommand,
+		labelTxCommand,
 		sendCoinsCommand,
 		connectCommand,
 		disconnectCommand,
@@ -265,10 +270,13 @@ func main() {
 		closeChannelCommand,
 		closeAllChannelsCommand,
 		abandonChannelCommand,
+		spliceOutCommand,
+		bumpPendingChannelCommand,
 		listPeersCommand,
 		walletBalanceCommand,
 		channelBalanceCommand,
 		getInfoCommand,
+		getRecoveryInfoCommand,
 		pendingChannelsCommand,
 		sendPaymentCommand,
 		payInvoiceCommand,
@@ -285,6 +293,7 @@ func main() {
 		queryRoutesCommand,
 		getNetworkInfoCommand,
 		debugLevelCommand,
+		debugChannelStateCommand,
 		decodePayReqCommand,
 		listChainTxnsCommand,
 		stopCommand,
@@ -293,6 +302,9 @@ func main() {
 		feeReportCommand,
 		updateChannelPolicyCommand,
 		forwardingHistoryCommand,
+		exportChanBackupCommand,
+		verifyChanBackupCommand,
+		restoreChanBackupCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
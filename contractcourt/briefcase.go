@@ -7,7 +7,9 @@ import (
 	"io"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
 	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -92,6 +94,20 @@ type ArbitratorLog interface {
 	// state machine forward.
 	FetchChainActions() (ChainActionMap, error)
 
+	// LogAnchorSweep stores the sweep of our anchor output that we're
+	// about to broadcast to bump the fee of the commitment, replacing any
+	// previously stored sweep. We'll use it upon restart to replace the
+	// sweep, rather than creating a conflicting one.
+	LogAnchorSweep(*lnwallet.AnchorSweep) error
+
+	// FetchAnchorSweep attempts to fetch the previously stored anchor
+	// sweep.
+	FetchAnchorSweep() (*lnwallet.AnchorSweep, error)
+
+	// DeleteAnchorSweep removes the stored anchor sweep, once it can no
+	// longer confirm.
+	DeleteAnchorSweep() error
+
 	// WipeHistory is to be called ONLY once *all* contracts have been
 	// fully resolved, and the channel closure if finalized. This method
 	// will delete all on-disk state within the persistent log.
@@ -258,6 +274,10 @@ var (
 	// actionsBucketKey is the key under the logScope that we'll use to
 	// store all chain actions once they're determined.
 	actionsBucketKey = []byte("chain-actions")
+
+	// anchorSweepKey is the key under the logScope that we'll use to store
+	// the sweep of our anchor output while the commitment is unconfirmed.
+	anchorSweepKey = []byte("anchor-sweep")
 )
 
 var (
@@ -276,6 +296,10 @@ var (
 	// errNoActions is retuned when the log doesn't contain any stored
 	// chain actions.
 	errNoActions = fmt.Errorf("no chain actions exist")
+
+	// errNoAnchorSweep is returned when the log doesn't contain an anchor
+	// sweep.
+	errNoAnchorSweep = fmt.Errorf("no anchor sweep exists")
 )
 
 // boltArbitratorLog is an implementation of the ArbitratorLog interface backed
@@ -630,6 +654,17 @@ func (b *boltArbitratorLog) LogContractResolutions(c *ContractResolutions) error
 			}
 		}
 
+		// The channel type of the commit resolution trails the HTLC
+		// resolutions, as it was added later.
+		if c.CommitResolution != nil {
+			err := binary.Write(
+				&b, endian, c.CommitResolution.ChanType,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		return scopeBucket.Put(resolutionsKey, b.Bytes())
 	})
 }
@@ -710,7 +745,15 @@ func (b *boltArbitratorLog) FetchContractResolutions() (*ContractResolutions, er
 			}
 		}
 
-		return nil
+		// Resolutions written before the channel type was stored
+		// belong to channels of the legacy commitment format.
+		if c.CommitResolution == nil || resReader.Len() == 0 {
+			return nil
+		}
+
+		return binary.Read(
+			resReader, endian, &c.CommitResolution.ChanType,
+		)
 	})
 	if err != nil {
 		return nil, err
@@ -801,6 +844,66 @@ func (b *boltArbitratorLog) FetchChainActions() (ChainActionMap, error) {
 	return actionsMap, nil
 }
 
+// LogAnchorSweep stores the sweep of our anchor output that we're about to
+// broadcast to bump the fee of the commitment, replacing any previously
+// stored sweep.
+//
+// NOTE: Part of the ContractResolver interface.
+func (b *boltArbitratorLog) LogAnchorSweep(sweep *lnwallet.AnchorSweep) error {
+	var buf bytes.Buffer
+	if err := encodeAnchorSweep(&buf, sweep); err != nil {
+		return err
+	}
+
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		scopeBucket, err := tx.CreateBucketIfNotExists(b.scopeKey[:])
+		if err != nil {
+			return err
+		}
+
+		return scopeBucket.Put(anchorSweepKey, buf.Bytes())
+	})
+}
+
+// FetchAnchorSweep attempts to fetch the previously stored anchor sweep.
+//
+// NOTE: Part of the ContractResolver interface.
+func (b *boltArbitratorLog) FetchAnchorSweep() (*lnwallet.AnchorSweep, error) {
+	sweep := &lnwallet.AnchorSweep{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		scopeBucket := tx.Bucket(b.scopeKey[:])
+		if scopeBucket == nil {
+			return errScopeBucketNoExist
+		}
+
+		sweepBytes := scopeBucket.Get(anchorSweepKey)
+		if sweepBytes == nil {
+			return errNoAnchorSweep
+		}
+
+		return decodeAnchorSweep(bytes.NewReader(sweepBytes), sweep)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sweep, nil
+}
+
+// DeleteAnchorSweep removes the stored anchor sweep.
+//
+// NOTE: Part of the ContractResolver interface.
+func (b *boltArbitratorLog) DeleteAnchorSweep() error {
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		scopeBucket := tx.Bucket(b.scopeKey[:])
+		if scopeBucket == nil {
+			return nil
+		}
+
+		return scopeBucket.Delete(anchorSweepKey)
+	})
+}
+
 // WipeHistory is to be called ONLY once *all* contracts have been fully
 // resolved, and the channel closure if finalized. This method will delete all
 // on-disk state within the persistent log.
@@ -844,6 +947,11 @@ func (b *boltArbitratorLog) WipeHistory() error {
 			return err
 		}
 
+		// The anchor sweep, if any, is no longer needed either.
+		if err := scopeBucket.Delete(anchorSweepKey); err != nil {
+			return err
+		}
+
 		// Before we delta the enclosing bucket itself, we'll delta any
 		// chain actions that are still stored.
 		actionsBucket, err := scopeBucket.CreateBucketIfNotExists(
@@ -1051,3 +1159,196 @@ func decodeCommitResolution(r io.Reader,
 
 	return binary.Read(r, endian, &c.MaturityDelay)
 }
+
+func encodeAnchorResolution(w io.Writer, a *lnwallet.AnchorResolution) error {
+	if _, err := w.Write(a.CommitAnchor.Hash[:]); err != nil {
+		return err
+	}
+	err := binary.Write(w, endian, a.CommitAnchor.Index)
+	if err != nil {
+		return err
+	}
+
+	err = lnwallet.WriteSignDescriptor(w, &a.AnchorSignDesc)
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, endian, a.CommitWeight)
+	if err != nil {
+		return err
+	}
+	err = binary.Write(w, endian, int64(a.CommitFee))
+	if err != nil {
+		return err
+	}
+
+	// The remote anchor is optional, so we prefix it with a flag that
+	// signals its presence.
+	if a.RemoteAnchor == nil {
+		return binary.Write(w, endian, false)
+	}
+	if err := binary.Write(w, endian, true); err != nil {
+		return err
+	}
+	if _, err := w.Write(a.RemoteAnchor.Hash[:]); err != nil {
+		return err
+	}
+	err = binary.Write(w, endian, a.RemoteAnchor.Index)
+	if err != nil {
+		return err
+	}
+
+	return lnwallet.WriteSignDescriptor(w, &a.RemoteAnchorSignDesc)
+}
+
+func decodeAnchorResolution(r io.Reader, a *lnwallet.AnchorResolution) error {
+	_, err := io.ReadFull(r, a.CommitAnchor.Hash[:])
+	if err != nil {
+		return err
+	}
+	err = binary.Read(r, endian, &a.CommitAnchor.Index)
+	if err != nil {
+		return err
+	}
+
+	err = lnwallet.ReadSignDescriptor(r, &a.AnchorSignDesc)
+	if err != nil {
+		return err
+	}
+
+	err = binary.Read(r, endian, &a.CommitWeight)
+	if err != nil {
+		return err
+	}
+	var commitFee int64
+	if err := binary.Read(r, endian, &commitFee); err != nil {
+		return err
+	}
+	a.CommitFee = btcutil.Amount(commitFee)
+
+	var haveRemoteAnchor bool
+	if err := binary.Read(r, endian, &haveRemoteAnchor); err != nil {
+		return err
+	}
+	if !haveRemoteAnchor {
+		return nil
+	}
+
+	a.RemoteAnchor = &wire.OutPoint{}
+	_, err = io.ReadFull(r, a.RemoteAnchor.Hash[:])
+	if err != nil {
+		return err
+	}
+	err = binary.Read(r, endian, &a.RemoteAnchor.Index)
+	if err != nil {
+		return err
+	}
+
+	return lnwallet.ReadSignDescriptor(r, &a.RemoteAnchorSignDesc)
+}
+
+func encodeAnchorSweep(w io.Writer, s *lnwallet.AnchorSweep) error {
+	if err := encodeAnchorResolution(w, s.Anchor); err != nil {
+		return err
+	}
+
+	if err := s.Tx.Serialize(w); err != nil {
+		return err
+	}
+
+	numCoins := uint32(len(s.Coins))
+	if err := binary.Write(w, endian, numCoins); err != nil {
+		return err
+	}
+	for _, coin := range s.Coins {
+		if _, err := w.Write(coin.OutPoint.Hash[:]); err != nil {
+			return err
+		}
+		err := binary.Write(w, endian, coin.OutPoint.Index)
+		if err != nil {
+			return err
+		}
+		err = binary.Write(w, endian, uint8(coin.AddressType))
+		if err != nil {
+			return err
+		}
+		err = binary.Write(w, endian, int64(coin.Value))
+		if err != nil {
+			return err
+		}
+		if err := wire.WriteVarBytes(w, 0, coin.PkScript); err != nil {
+			return err
+		}
+	}
+
+	if err := binary.Write(w, endian, int64(s.Fee)); err != nil {
+		return err
+	}
+
+	return binary.Write(w, endian, int64(s.FeeRate))
+}
+
+func decodeAnchorSweep(r io.Reader, s *lnwallet.AnchorSweep) error {
+	s.Anchor = &lnwallet.AnchorResolution{}
+	if err := decodeAnchorResolution(r, s.Anchor); err != nil {
+		return err
+	}
+
+	s.Tx = &wire.MsgTx{}
+	if err := s.Tx.Deserialize(r); err != nil {
+		return err
+	}
+
+	var numCoins uint32
+	if err := binary.Read(r, endian, &numCoins); err != nil {
+		return err
+	}
+	s.Coins = make([]*lnwallet.Utxo, numCoins)
+	for i := range s.Coins {
+		coin := &lnwallet.Utxo{}
+
+		_, err := io.ReadFull(r, coin.OutPoint.Hash[:])
+		if err != nil {
+			return err
+		}
+		err = binary.Read(r, endian, &coin.OutPoint.Index)
+		if err != nil {
+			return err
+		}
+
+		var addrType uint8
+		if err := binary.Read(r, endian, &addrType); err != nil {
+			return err
+		}
+		coin.AddressType = lnwallet.AddressType(addrType)
+
+		var value int64
+		if err := binary.Read(r, endian, &value); err != nil {
+			return err
+		}
+		coin.Value = btcutil.Amount(value)
+
+		coin.PkScript, err = wire.ReadVarBytes(
+			r, 0, txscript.MaxScriptSize, "pkScript",
+		)
+		if err != nil {
+			return err
+		}
+
+		s.Coins[i] = coin
+	}
+
+	var fee, feeRate int64
+	if err := binary.Read(r, endian, &fee); err != nil {
+		return err
+	}
+	s.Fee = btcutil.Amount(fee)
+
+	if err := binary.Read(r, endian, &feeRate); err != nil {
+		return err
+	}
+	s.FeeRate = lnwallet.SatPerKWeight(feeRate)
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package contractcourt
 
 import (
+	"bytes"
 	"crypto/rand"
 	"io/ioutil"
 	"os"
@@ -289,6 +290,7 @@ func TestContractInsertionRetrieval(t *testing.T) {
 				SelfOutPoint:       testChanPoint2,
 				SelfOutputSignDesc: testSignDesc,
 				MaturityDelay:      99,
+				ChanType:           channeldb.SingleFunderTweakless,
 			},
 			resolved:        false,
 			broadcastHeight: 109,
@@ -509,6 +511,7 @@ func TestContractResolutionsStorage(t *testing.T) {
 			SelfOutPoint:       testChanPoint2,
 			SelfOutputSignDesc: testSignDesc,
 			MaturityDelay:      101,
+			ChanType:           channeldb.SingleFunderTweakless,
 		},
 		HtlcResolutions: lnwallet.HtlcResolutions{
 			IncomingHTLCs: []lnwallet.IncomingHtlcResolution{
@@ -565,6 +568,153 @@ func TestContractResolutionsStorage(t *testing.T) {
 	}
 }
 
+// TestCommitSweepResolverChanType asserts that the channel type of a commit
+// sweep resolver is persisted, and that resolvers written before it was stored
+// are read as resolvers of legacy channels.
+func TestCommitSweepResolverChanType(t *testing.T) {
+	t.Parallel()
+
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: testChanPoint2,
+		SignatureScript:  []byte{1},
+	})
+	sweepTx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0}})
+
+	for _, tx := range []*wire.MsgTx{nil, sweepTx} {
+		resolver := &commitSweepResolver{
+			commitResolution: lnwallet.CommitOutputResolution{
+				SelfOutPoint:       testChanPoint2,
+				SelfOutputSignDesc: testSignDesc,
+				ChanType:           channeldb.SingleFunderTweakless,
+			},
+			broadcastHeight: 109,
+			chanPoint:       testChanPoint1,
+			sweepTx:         tx,
+		}
+
+		var b bytes.Buffer
+		if err := resolver.Encode(&b); err != nil {
+			t.Fatalf("unable to encode resolver: %v", err)
+		}
+
+		diskRes := &commitSweepResolver{}
+		if err := diskRes.Decode(bytes.NewReader(b.Bytes())); err != nil {
+			t.Fatalf("unable to decode resolver: %v", err)
+		}
+		assertResolversEqual(t, resolver, diskRes)
+		if !reflect.DeepEqual(resolver.sweepTx, diskRes.sweepTx) {
+			t.Fatalf("sweep tx mismatch: expected %v, got %v",
+				resolver.sweepTx, diskRes.sweepTx)
+		}
+
+		// Without the trailing channel type, the resolver belongs to
+		// a channel of the legacy commitment format.
+		legacy := b.Bytes()[:b.Len()-chanTypeSize]
+		diskRes = &commitSweepResolver{}
+		if err := diskRes.Decode(bytes.NewReader(legacy)); err != nil {
+			t.Fatalf("unable to decode legacy resolver: %v", err)
+		}
+		if diskRes.commitResolution.ChanType != channeldb.SingleFunder {
+			t.Fatalf("expected legacy channel type, got %v",
+				diskRes.commitResolution.ChanType)
+		}
+		if !reflect.DeepEqual(resolver.sweepTx, diskRes.sweepTx) {
+			t.Fatalf("sweep tx mismatch: expected %v, got %v",
+				resolver.sweepTx, diskRes.sweepTx)
+		}
+	}
+}
+
+// TestAnchorSweepStorage tests that we're able to store our anchor sweep,
+// retrieve it from disk after it's been replaced, and delete it again.
+func TestAnchorSweepStorage(t *testing.T) {
+	t.Parallel()
+
+	testLog, cleanUp, err := newTestBoltArbLog(
+		testChainHash, testChanPoint1,
+	)
+	if err != nil {
+		t.Fatalf("unable to create test log: %v", err)
+	}
+	defer cleanUp()
+
+	if _, err := testLog.FetchAnchorSweep(); err != errScopeBucketNoExist {
+		t.Fatalf("expected no anchor sweep, instead got: %v", err)
+	}
+
+	newSweep := func(remoteAnchor *wire.OutPoint,
+		feeRate lnwallet.SatPerKWeight) *lnwallet.AnchorSweep {
+
+		anchor := &lnwallet.AnchorResolution{
+			CommitAnchor:   testChanPoint2,
+			AnchorSignDesc: testSignDesc,
+			CommitWeight:   1000,
+			CommitFee:      253,
+		}
+		if remoteAnchor != nil {
+			anchor.RemoteAnchor = remoteAnchor
+			anchor.RemoteAnchorSignDesc = testSignDesc
+		}
+
+		coin := &lnwallet.Utxo{
+			AddressType: lnwallet.NestedWitnessPubKey,
+			Value:       100000,
+			PkScript:    []byte{0xa9, 0x14},
+			OutPoint: wire.OutPoint{
+				Hash:  chainhash.Hash{1},
+				Index: 2,
+			},
+		}
+
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: anchor.CommitAnchor,
+			SignatureScript:  []byte{1},
+		})
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: coin.OutPoint,
+			SignatureScript:  []byte{2},
+		})
+		tx.AddTxOut(&wire.TxOut{Value: 99000, PkScript: []byte{0}})
+
+		return &lnwallet.AnchorSweep{
+			Anchor:  anchor,
+			Tx:      tx,
+			Coins:   []*lnwallet.Utxo{coin},
+			Fee:     1330,
+			FeeRate: feeRate,
+		}
+	}
+
+	// We'll log a sweep, then replace it with one that also carries the
+	// remote anchor. Only the replacement should be returned.
+	for _, sweep := range []*lnwallet.AnchorSweep{
+		newSweep(nil, 5000), newSweep(&testChanPoint1, 10000),
+	} {
+		if err := testLog.LogAnchorSweep(sweep); err != nil {
+			t.Fatalf("unable to log anchor sweep: %v", err)
+		}
+
+		diskSweep, err := testLog.FetchAnchorSweep()
+		if err != nil {
+			t.Fatalf("unable to fetch anchor sweep: %v", err)
+		}
+		if !reflect.DeepEqual(sweep, diskSweep) {
+			t.Fatalf("anchor sweep mismatch: expected %v, got %v",
+				spew.Sdump(sweep), spew.Sdump(diskSweep))
+		}
+	}
+
+	// Once deleted, the sweep should no longer be found.
+	if err := testLog.DeleteAnchorSweep(); err != nil {
+		t.Fatalf("unable to delete anchor sweep: %v", err)
+	}
+	if _, err := testLog.FetchAnchorSweep(); err != errNoAnchorSweep {
+		t.Fatalf("expected no anchor sweep, instead got: %v", err)
+	}
+}
+
 // TestChainActionStorage tests that were able to properly store a set of chain
 // actions, and then retrieve the same set of chain actions from disk.
 func TestChainActionStorage(t *testing.T) {
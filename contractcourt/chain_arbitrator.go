@@ -13,6 +13,7 @@ import (
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/sweep"
 )
 
 // ErrChainArbExiting signals that the chain arbitrator is shutting down.
@@ -65,10 +66,11 @@ type ChainArbitratorConfig struct {
 	// NOTE: This SHOULD return a p2wkh script.
 	NewSweepAddr func() ([]byte, error)
 
-	// PublishTx reliably broadcasts a transaction to the network. Once
-	// this function exits without an error, then they transaction MUST
-	// continually be rebroadcast if needed.
-	PublishTx func(*wire.MsgTx) error
+	// PublishTx reliably broadcasts a transaction to the network, and
+	// labels it with the passed label. Once this function exits without
+	// an error, then they transaction MUST continually be rebroadcast if
+	// needed.
+	PublishTx func(tx *wire.MsgTx, label string) error
 
 	// DeliverResolutionMsg is a function that will append an outgoing
 	// message to the "out box" for a ChannelLink. This is used to cancel
@@ -92,6 +94,17 @@ type ChainArbitratorConfig struct {
 	// the channel as pending close in the database.
 	ContractBreach func(wire.OutPoint, *lnwallet.BreachRetribution) error
 
+	// NotifyClosingChannel is an optional function closure that will be
+	// called once a channel begins closing, along with the manner in
+	// which it is being closed. This happens when we broadcast our
+	// commitment, or when a remote commitment or breach is detected.
+	NotifyClosingChannel func(wire.OutPoint, channeldb.ClosureType)
+
+	// NotifyFullyClosedChannel is an optional function closure that will
+	// be called once all contracts of a closed channel have been resolved,
+	// and the channel has been marked as fully closed within the database.
+	NotifyFullyClosedChannel func(wire.OutPoint)
+
 	// IsOurAddress is a function that returns true if the passed address
 	// is known to the underlying wallet. Otherwise, false should be
 	// returned.
@@ -127,9 +140,28 @@ type ChainArbitratorConfig struct {
 	// ChainIO allows us to query the state of the current main chain.
 	ChainIO lnwallet.BlockChainIO
 
+	// Sweeper allows resolvers to sweep their final outputs. The sweeper
+	// batches the inputs of all resolvers into as few transactions as
+	// possible.
+	Sweeper *sweep.UtxoSweeper
+
 	// DisableChannel disables a channel, resulting in it not being able to
 	// forward payments.
 	DisableChannel func(wire.OutPoint) error
+
+	// CreateAnchorSweep creates a fully signed transaction that spends the
+	// passed anchor output along with funds from the wallet, such that the
+	// fee rate of the commitment transaction and the sweep combined is
+	// bumped to the given fee rate (CPFP). If a prior sweep of the same
+	// anchor is passed, then the new sweep is created to replace it.
+	CreateAnchorSweep func(*lnwallet.AnchorResolution,
+		lnwallet.SatPerKWeight, *lnwallet.AnchorSweep) (
+		*lnwallet.AnchorSweep, error)
+
+	// CancelAnchorSweep releases the wallet coins of an anchor sweep
+	// created by CreateAnchorSweep that won't confirm. Coins also spent by
+	// the second sweep, if non-nil, remain leased.
+	CancelAnchorSweep func(sweep, keep *lnwallet.AnchorSweep)
 }
 
 // ChainArbitrator is a sub-system that oversees the on-chain resolution of all
@@ -207,31 +239,36 @@ func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
 
 	// Next we'll create the matching configuration struct that contains
 	// all interfaces and methods the arbitrator needs to do its job.
+	// fetchChannel fetches the latest state of the channel from the
+	// database, as the arbitrator needs to act upon the most recent
+	// commitments.
+	fetchChannel := func() (*channeldb.OpenChannel, error) {
+		// With the channels fetched, attempt to locate the target
+		// channel according to its channel point.
+		dbChannels, err := c.chanSource.FetchAllChannels()
+		if err != nil {
+			return nil, err
+		}
+		for _, dbChannel := range dbChannels {
+			if dbChannel.FundingOutpoint == chanPoint {
+				return dbChannel, nil
+			}
+		}
+
+		// If the channel cannot be located, then we exit with an
+		// error to the channel.
+		return nil, fmt.Errorf("unable to find channel")
+	}
+
 	arbCfg := ChannelArbitratorConfig{
 		ChanPoint:   chanPoint,
 		ShortChanID: channel.ShortChanID(),
 		BlockEpochs: blockEpoch,
 		ForceCloseChan: func() (*lnwallet.LocalForceCloseSummary, error) {
-			// With the channels fetched, attempt to locate
-			// the target channel according to its channel
-			// point.
-			dbChannels, err := c.chanSource.FetchAllChannels()
+			channel, err := fetchChannel()
 			if err != nil {
 				return nil, err
 			}
-			var channel *channeldb.OpenChannel
-			for _, dbChannel := range dbChannels {
-				if dbChannel.FundingOutpoint == chanPoint {
-					channel = dbChannel
-					break
-				}
-			}
-
-			// If the channel cannot be located, then we
-			// exit with an error to the channel.
-			if channel == nil {
-				return nil, fmt.Errorf("unable to find channel")
-			}
 
 			chanMachine, err := lnwallet.NewLightningChannel(
 				c.cfg.Signer, c.cfg.PreimageDB, channel)
@@ -246,11 +283,42 @@ func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
 
 			return chanMachine.ForceClose()
 		},
+		FetchAnchorResolutions: func() (*lnwallet.AnchorResolutions,
+			error) {
+
+			channel, err := fetchChannel()
+			if err != nil {
+				return nil, err
+			}
+
+			return lnwallet.NewAnchorResolutions(channel)
+		},
 		MarkCommitmentBroadcasted: channel.MarkCommitmentBroadcasted,
-		MarkChannelClosed:         channel.CloseChannel,
-		IsPendingClose:            false,
-		ChainArbitratorConfig:     c.cfg,
-		ChainEvents:               chanEvents,
+		MarkChannelClosed: func(summary *channeldb.ChannelCloseSummary) error {
+			if err := channel.CloseChannel(summary); err != nil {
+				return err
+			}
+
+			// A remote force close is only seen once it confirms,
+			// so that's the earliest point at which the channel
+			// can be reported as closing. Our own closes were
+			// already reported when we broadcast them.
+			remoteClose := summary.CloseType ==
+				channeldb.RemoteForceClose &&
+				!channel.HasChanStatus(
+					channeldb.CommitmentBroadcasted,
+				)
+			if remoteClose && c.cfg.NotifyClosingChannel != nil {
+				c.cfg.NotifyClosingChannel(
+					summary.ChanPoint, summary.CloseType,
+				)
+			}
+
+			return nil
+		},
+		IsPendingClose:        false,
+		ChainArbitratorConfig: c.cfg,
+		ChainEvents:           chanEvents,
 	}
 
 	// The final component needed is an arbitrator log that the arbitrator
@@ -293,6 +361,10 @@ func (c *ChainArbitrator) resolveContract(chanPoint wire.OutPoint,
 		return err
 	}
 
+	if c.cfg.NotifyFullyClosedChannel != nil {
+		c.cfg.NotifyFullyClosedChannel(chanPoint)
+	}
+
 	if arbLog != nil {
 		// Once this has been marked as resolved, we'll wipe the log
 		// that the channel arbitrator was using to store its
@@ -347,11 +419,12 @@ func (c *ChainArbitrator) Start() error {
 		// to ensure that we detect any relevant on chain events.
 		chainWatcher, err := newChainWatcher(
 			chainWatcherConfig{
-				chanState: channel,
-				notifier:  c.cfg.Notifier,
-				pCache:    c.cfg.PreimageDB,
-				signer:    c.cfg.Signer,
-				isOurAddr: c.cfg.IsOurAddress,
+				chanState:            channel,
+				notifier:             c.cfg.Notifier,
+				pCache:               c.cfg.PreimageDB,
+				signer:               c.cfg.Signer,
+				isOurAddr:            c.cfg.IsOurAddress,
+				notifyClosingChannel: c.cfg.NotifyClosingChannel,
 				contractBreach: func(retInfo *lnwallet.BreachRetribution) error {
 					return c.cfg.ContractBreach(chanPoint, retInfo)
 				},
@@ -667,11 +740,12 @@ func (c *ChainArbitrator) WatchNewChannel(newChan *channeldb.OpenChannel) error
 	// that we detect any relevant on chain events.
 	chainWatcher, err := newChainWatcher(
 		chainWatcherConfig{
-			chanState: newChan,
-			notifier:  c.cfg.Notifier,
-			pCache:    c.cfg.PreimageDB,
-			signer:    c.cfg.Signer,
-			isOurAddr: c.cfg.IsOurAddress,
+			chanState:            newChan,
+			notifier:             c.cfg.Notifier,
+			pCache:               c.cfg.PreimageDB,
+			signer:               c.cfg.Signer,
+			isOurAddr:            c.cfg.IsOurAddress,
+			notifyClosingChannel: c.cfg.NotifyClosingChannel,
 			contractBreach: func(retInfo *lnwallet.BreachRetribution) error {
 				return c.cfg.ContractBreach(chanPoint, retInfo)
 			},
@@ -703,6 +777,45 @@ func (c *ChainArbitrator) WatchNewChannel(newChan *channeldb.OpenChannel) error
 	return chainWatcher.Start()
 }
 
+// SpliceChannel is to be called once the splice of a channel has confirmed,
+// moving the channel from oldChanPoint to the funding output of the splice.
+// The arbitrator and chain watcher of the prior channel point are torn down,
+// and the channel is watched at its new channel point instead.
+func (c *ChainArbitrator) SpliceChannel(oldChanPoint wire.OutPoint,
+	newChan *channeldb.OpenChannel) error {
+
+	log.Infof("Moving ChannelArbitrator for ChannelPoint(%v) to spliced "+
+		"ChannelPoint(%v)", oldChanPoint, newChan.FundingOutpoint)
+
+	c.Lock()
+	channelArb, ok := c.activeChannels[oldChanPoint]
+	delete(c.activeChannels, oldChanPoint)
+
+	chainWatcher, watcherOk := c.activeWatchers[oldChanPoint]
+	delete(c.activeWatchers, oldChanPoint)
+	c.Unlock()
+
+	if watcherOk {
+		if err := chainWatcher.Stop(); err != nil {
+			return err
+		}
+	}
+
+	// As the prior funding output has been spent by the splice, there's
+	// nothing left to resolve for it, so we'll wipe the state of its
+	// arbitrator.
+	if ok {
+		if err := channelArb.Stop(); err != nil {
+			return err
+		}
+		if err := channelArb.log.WipeHistory(); err != nil {
+			return err
+		}
+	}
+
+	return c.WatchNewChannel(newChan)
+}
+
 // SubscribeChannelEvents returns a new active subscription for the set of
 // possible on-chain events for a particular channel. The struct can be used by
 // callers to be notified whenever an event that changes the state of the
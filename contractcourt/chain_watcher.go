@@ -93,6 +93,11 @@ type chainWatcherConfig struct {
 	// isOurAddr is a function that returns true if the passed address is
 	// known to us.
 	isOurAddr func(btcutil.Address) bool
+
+	// notifyClosingChannel is an optional function closure that will be
+	// called once a breach has been detected and the channel has been
+	// marked as pending closed within the database.
+	notifyClosingChannel func(wire.OutPoint, channeldb.ClosureType)
 }
 
 // chainWatcher is a system that's assigned to every active channel. The duty
@@ -321,6 +326,27 @@ func (c *chainWatcher) closeObserver(spendNtfn *chainntnfs.SpendEvent) {
 			return
 		}
 
+		// If the funding output was spent by the splice
+		// transaction both parties agreed upon, then the channel
+		// hasn't been closed, but will move to the new funding
+		// output once the splice confirms. The chain arbitrator
+		// will be handed the new channel at that point, so we
+		// can exit here.
+		splice, err := c.cfg.chanState.PendingSplice()
+		switch {
+		case err == nil && splice.SpliceTx.TxHash() == *commitSpend.SpenderTxHash:
+			log.Infof("ChannelPoint(%v) spent by splice "+
+				"txid=%v", c.cfg.chanState.FundingOutpoint,
+				commitSpend.SpenderTxHash)
+			return
+
+		case err != nil && err != channeldb.ErrNoPendingSplice:
+			log.Errorf("Unable to fetch pending splice for "+
+				"chan_point=%v: %v",
+				c.cfg.chanState.FundingOutpoint, err)
+			return
+		}
+
 		// Next, we'll check to see if this is a cooperative
 		// channel closure or not. This is characterized by
 		// having an input sequence number that's finalized.
@@ -354,13 +380,21 @@ func (c *chainWatcher) closeObserver(spendNtfn *chainntnfs.SpendEvent) {
 			return
 		}
 
+		// If this channel has been restored from a static backup,
+		// then we don't know any of its commitments, so the only
+		// thing we can do is attempt to recover our funds as if we
+		// had lost state.
+		isRestoredChan := c.cfg.chanState.HasChanStatus(
+			channeldb.Restored,
+		)
+
 		switch {
 		// If state number spending transaction matches the
 		// current latest state, then they've initiated a
 		// unilateral close. So we'll trigger the unilateral
 		// close signal so subscribers can clean up the state
 		// as necessary.
-		case broadcastStateNum == remoteStateNum:
+		case broadcastStateNum == remoteStateNum && !isRestoredChan:
 			err := c.dispatchRemoteForceClose(
 				commitSpend, *remoteCommit,
 				c.cfg.chanState.RemoteCurrentRevocation,
@@ -378,7 +412,7 @@ func (c *chainWatcher) closeObserver(spendNtfn *chainntnfs.SpendEvent) {
 		// has a fail crash _after_ accepting the new state,
 		// but _before_ sending their signature to us.
 		case broadcastStateNum == remoteStateNum+1 &&
-			remoteChainTip != nil:
+			remoteChainTip != nil && !isRestoredChan:
 
 			err := c.dispatchRemoteForceClose(
 				commitSpend, remoteChainTip.Commitment,
@@ -393,8 +427,9 @@ func (c *chainWatcher) closeObserver(spendNtfn *chainntnfs.SpendEvent) {
 		// This is the case that somehow the commitment broadcast is
 		// actually greater than even one beyond our best known state
 		// number. This should ONLY happen in case we experienced some
-		// sort of data loss.
-		case broadcastStateNum > remoteStateNum+1:
+		// sort of data loss, or if the channel was restored from a
+		// static backup.
+		case broadcastStateNum > remoteStateNum+1 || isRestoredChan:
 			log.Warnf("Remote node broadcast state #%v, "+
 				"which is more than 1 beyond best known "+
 				"state #%v!!! Attempting recovery...",
@@ -406,19 +441,32 @@ func (c *chainWatcher) closeObserver(spendNtfn *chainntnfs.SpendEvent) {
 			// TODO(halseth): must handle the case where we haven't
 			// yet processed the chan sync message.
 			commitPoint, err := c.cfg.chanState.DataLossCommitPoint()
-			if err != nil {
+			switch {
+			// If the channel uses the tweakless commitment format,
+			// then our output doesn't depend on the commitment
+			// point, so we can sweep it using any point.
+			case err != nil && c.cfg.chanState.ChanType.IsTweakless():
+				log.Infof("Unable to retrieve commitment "+
+					"point for tweakless channel(%v), "+
+					"sweeping our funds without it",
+					c.cfg.chanState.FundingOutpoint)
+
+				commitPoint = c.cfg.chanState.RemoteCurrentRevocation
+
+			case err != nil:
 				log.Errorf("Unable to retrieve commitment "+
 					"point for channel(%v) with lost "+
 					"state: %v",
 					c.cfg.chanState.FundingOutpoint, err)
 				return
-			}
 
-			log.Infof("Recovered commit point(%x) for "+
-				"channel(%v)! Now attempting to use it to "+
-				"sweep our funds...",
-				commitPoint.SerializeCompressed(),
-				c.cfg.chanState.FundingOutpoint)
+			default:
+				log.Infof("Recovered commit point(%x) for "+
+					"channel(%v)! Now attempting to use "+
+					"it to sweep our funds...",
+					commitPoint.SerializeCompressed(),
+					c.cfg.chanState.FundingOutpoint)
+			}
 
 			// Since we don't have the commitment stored for this
 			// state, we'll just pass an empty commitment. Note
@@ -756,5 +804,11 @@ func (c *chainWatcher) dispatchContractBreach(spendEvent *chainntnfs.SpendDetail
 	log.Infof("Breached channel=%v marked pending-closed",
 		c.cfg.chanState.FundingOutpoint)
 
+	if c.cfg.notifyClosingChannel != nil {
+		c.cfg.notifyClosingChannel(
+			c.cfg.chanState.FundingOutpoint, channeldb.BreachClose,
+		)
+	}
+
 	return nil
 }
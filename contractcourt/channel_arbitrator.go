@@ -4,12 +4,14 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/sweep"
 )
 
 const (
@@ -19,6 +21,12 @@ const (
 	// value, as when redeeming we want to ensure that we have enough time
 	// to redeem the HTLC, well before it times out.
 	broadcastRedeemMultiplier = 2
+
+	// maxAnchorConfTarget is the confirmation target we'll use when
+	// bumping the fee of a commitment transaction with anchor outputs that
+	// has no HTLCs at stake. It also serves as an upper bound on the
+	// target when HTLCs are present.
+	maxAnchorConfTarget = 144
 )
 
 // WitnessSubscription represents an intent to be notified once new witnesses
@@ -90,6 +98,12 @@ type ChannelArbitratorConfig struct {
 	// outputs on chain.
 	ForceCloseChan func() (*lnwallet.LocalForceCloseSummary, error)
 
+	// FetchAnchorResolutions returns the resolutions of our anchor outputs
+	// on all commitments of the channel that may currently be in the
+	// mempool, such that we can bump the fee of whichever of them made
+	// it.
+	FetchAnchorResolutions func() (*lnwallet.AnchorResolutions, error)
+
 	// MarkCommitmentBroadcasted should mark the channel as the commitment
 	// being broadcast, and we are waiting for the commitment to confirm.
 	MarkCommitmentBroadcasted func() error
@@ -177,6 +191,13 @@ type ChannelArbitrator struct {
 	// commitment transaction.
 	activeHTLCs htlcSet
 
+	// anchorSweep is the sweep of our anchor output we've broadcast to
+	// bump the fee of the commitment while it's unconfirmed. It's
+	// replaced with one paying a higher fee as the deadline of the
+	// commitment approaches. The sweep is persisted within the log, so
+	// that we'll keep replacing it after a restart.
+	anchorSweep *lnwallet.AnchorSweep
+
 	// cfg contains all the functionality that the ChannelArbitrator requires
 	// to do its duty.
 	cfg ChannelArbitratorConfig
@@ -256,6 +277,22 @@ func (c *ChannelArbitrator) Start() error {
 	log.Infof("ChannelArbitrator(%v): starting state=%v", c.cfg.ChanPoint,
 		c.state)
 
+	// If we've broadcast a sweep of our anchor before the restart, then
+	// we'll adopt it, such that we replace it rather than creating a
+	// conflicting sweep the mempool would reject.
+	c.anchorSweep, err = c.log.FetchAnchorSweep()
+	switch err {
+	case nil:
+		log.Infof("ChannelArbitrator(%v): adopted anchor sweep %v",
+			c.cfg.ChanPoint, c.anchorSweep.Tx.TxHash())
+
+	case errNoAnchorSweep, errScopeBucketNoExist:
+
+	default:
+		c.cfg.BlockEpochs.Cancel()
+		return err
+	}
+
 	_, bestHeight, err := c.cfg.ChainIO.GetBestBlock()
 	if err != nil {
 		c.cfg.BlockEpochs.Cancel()
@@ -562,7 +599,8 @@ func (c *ChannelArbitrator) stateStep(triggerHeight uint32,
 
 		// At this point, we'll now broadcast the commitment
 		// transaction itself.
-		if err := c.cfg.PublishTx(closeTx); err != nil {
+		label := lnwallet.ForceCloseLabel(c.cfg.ChanPoint)
+		if err := c.cfg.PublishTx(closeTx, label); err != nil {
 			log.Errorf("ChannelArbitrator(%v): unable to broadcast "+
 				"close tx: %v", c.cfg.ChanPoint, err)
 			if err != lnwallet.ErrDoubleSpend {
@@ -576,6 +614,42 @@ func (c *ChannelArbitrator) stateStep(triggerHeight uint32,
 				c.cfg.ChanPoint, err)
 		}
 
+		if c.cfg.NotifyClosingChannel != nil {
+			c.cfg.NotifyClosingChannel(
+				c.cfg.ChanPoint, channeldb.LocalForceClose,
+			)
+		}
+
+		// If the channel uses anchor outputs, then we'll attach a
+		// child transaction to the commitment if its fee isn't
+		// sufficient to confirm before our HTLCs expire. We prefer
+		// the signed commitment we just broadcast, but may fall back
+		// to one of the remote party's if theirs made it instead.
+		if closeSummary.AnchorResolution != nil &&
+			c.cfg.CreateAnchorSweep != nil {
+
+			anchors := &lnwallet.AnchorResolutions{}
+			if c.cfg.FetchAnchorResolutions != nil {
+				var err error
+				anchors, err = c.cfg.FetchAnchorResolutions()
+				if err != nil {
+					log.Errorf("ChannelArbitrator(%v): "+
+						"unable to fetch anchor "+
+						"resolutions: %v",
+						c.cfg.ChanPoint, err)
+					anchors = &lnwallet.AnchorResolutions{}
+				}
+			}
+			anchors.Local = closeSummary.AnchorResolution
+
+			err := c.bumpCommitFee(triggerHeight, anchors)
+			if err != nil {
+				log.Errorf("ChannelArbitrator(%v): unable to "+
+					"bump commitment fee: %v",
+					c.cfg.ChanPoint, err)
+			}
+		}
+
 		// We go to the StateCommitmentBroadcasted state, where we'll
 		// be waiting for the commitment to be confirmed.
 		nextState = StateCommitmentBroadcasted
@@ -884,6 +958,290 @@ func (c *ChannelArbitrator) shouldGoOnChain(htlcExpiry, broadcastDelta,
 	return currentHeight >= broadcastCutOff
 }
 
+// anchorConfTarget returns the number of blocks within which our commitment
+// transaction needs to confirm, based on the expiry of the active HTLCs on it.
+// If there are no HTLCs at stake, then we'll use a relaxed target.
+func (c *ChannelArbitrator) anchorConfTarget(height uint32) uint32 {
+	deadline := uint32(maxAnchorConfTarget)
+
+	// Our commitment needs to confirm before the first of the HTLCs on it
+	// expires, as otherwise the remote party could claim them, or we
+	// could be forced to cancel them back before they're resolved.
+	htlcs := make([]channeldb.HTLC, 0, len(c.activeHTLCs.incomingHTLCs)+
+		len(c.activeHTLCs.outgoingHTLCs))
+	for _, htlc := range c.activeHTLCs.incomingHTLCs {
+		htlcs = append(htlcs, htlc)
+	}
+	for _, htlc := range c.activeHTLCs.outgoingHTLCs {
+		htlcs = append(htlcs, htlc)
+	}
+	for _, htlc := range htlcs {
+		// Dust HTLCs aren't on the commitment, so there's nothing we
+		// can enforce on-chain.
+		if htlc.OutputIndex < 0 {
+			continue
+		}
+
+		// If the HTLC already expired, then we'll aim for the next
+		// block.
+		if htlc.RefundTimeout <= height {
+			return 1
+		}
+
+		if htlc.RefundTimeout-height < deadline {
+			deadline = htlc.RefundTimeout - height
+		}
+	}
+
+	return deadline
+}
+
+// bumpCommitFee attempts to bump the fee of our broadcast commitment
+// transaction by attaching a child transaction spending our anchor output
+// (CPFP). The fee rate of the package is chosen such that it's expected to
+// confirm before the first of our HTLCs on the commitment expires. It's
+// called again for each new block while the commitment is unconfirmed, such
+// that the sweep is replaced with one paying a higher fee as the deadline
+// approaches.
+//
+// As we don't know which of the commitments made it into the mempool, we'll
+// try to bump them in order of the passed resolutions, until one of the
+// sweeps is accepted. If no resolutions are passed, they're fetched from the
+// channel state.
+func (c *ChannelArbitrator) bumpCommitFee(height uint32,
+	anchors *lnwallet.AnchorResolutions) error {
+
+	confTarget := c.anchorConfTarget(height)
+	feeRate, err := c.cfg.FeeEstimator.EstimateFeePerKW(confTarget)
+	if err != nil {
+		return err
+	}
+
+	// If we've already broadcast a sweep, then we know which commitment
+	// it bumps, so we'll only replace it if the deadline requires a
+	// higher fee rate by now.
+	if c.anchorSweep != nil {
+		return c.replaceAnchorSweep(feeRate, confTarget)
+	}
+
+	if anchors == nil {
+		if c.cfg.FetchAnchorResolutions == nil {
+			return nil
+		}
+
+		anchors, err = c.cfg.FetchAnchorResolutions()
+		if err != nil {
+			return err
+		}
+	}
+
+	candidates := []*lnwallet.AnchorResolution{
+		anchors.Local, anchors.Remote, anchors.RemotePending,
+	}
+
+	var publishErr error
+	for _, anchor := range candidates {
+		if anchor == nil {
+			continue
+		}
+
+		// If the commitment already pays a sufficient fee rate, then
+		// there's no need to spend any additional funds.
+		commitFeeRate := anchor.CommitFeeRate()
+		if commitFeeRate >= feeRate {
+			log.Infof("ChannelArbitrator(%v): fee rate of "+
+				"commitment %v of %v sat/kw is sufficient for "+
+				"conf target %v, not bumping", c.cfg.ChanPoint,
+				anchor.CommitAnchor.Hash, int64(commitFeeRate),
+				confTarget)
+			continue
+		}
+
+		log.Infof("ChannelArbitrator(%v): bumping fee rate of "+
+			"commitment %v from %v sat/kw to %v sat/kw for conf "+
+			"target %v", c.cfg.ChanPoint, anchor.CommitAnchor.Hash,
+			int64(commitFeeRate), int64(feeRate), confTarget)
+
+		anchorSweep, err := c.cfg.CreateAnchorSweep(anchor, feeRate, nil)
+		if err != nil {
+			return err
+		}
+
+		// We'll log the sweep before broadcasting it, so we won't lose
+		// track of it if we go down right after.
+		if err := c.log.LogAnchorSweep(anchorSweep); err != nil {
+			c.cancelAnchorSweep(anchorSweep, nil)
+			return err
+		}
+
+		// If the sweep is rejected, then the commitment likely isn't
+		// the one in the mempool, so we'll release the coins of the
+		// sweep and move on to the next one.
+		if err := c.publishAnchorSweep(anchorSweep); err != nil {
+			c.cancelAnchorSweep(anchorSweep, nil)
+			if logErr := c.log.DeleteAnchorSweep(); logErr != nil {
+				return logErr
+			}
+			publishErr = err
+			continue
+		}
+
+		c.anchorSweep = anchorSweep
+		return nil
+	}
+
+	return publishErr
+}
+
+// replaceAnchorSweep replaces our broadcast anchor sweep with one bumping the
+// commitment to the passed fee rate, if it's higher than the fee rate the
+// current sweep targets.
+func (c *ChannelArbitrator) replaceAnchorSweep(feeRate lnwallet.SatPerKWeight,
+	confTarget uint32) error {
+
+	prevSweep := c.anchorSweep
+	if feeRate <= prevSweep.FeeRate {
+		return nil
+	}
+
+	log.Infof("ChannelArbitrator(%v): replacing anchor sweep %v of "+
+		"commitment %v to bump its fee rate from %v sat/kw to %v sat/kw "+
+		"for conf target %v", c.cfg.ChanPoint, prevSweep.Tx.TxHash(),
+		prevSweep.Anchor.CommitAnchor.Hash, int64(prevSweep.FeeRate),
+		int64(feeRate), confTarget)
+
+	anchorSweep, err := c.cfg.CreateAnchorSweep(
+		prevSweep.Anchor, feeRate, prevSweep,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := c.log.LogAnchorSweep(anchorSweep); err != nil {
+		c.cancelAnchorSweep(anchorSweep, prevSweep)
+		return err
+	}
+
+	// If the replacement is rejected, then the prior sweep remains in
+	// the mempool, so only the coins added by the replacement are
+	// released.
+	if err := c.publishAnchorSweep(anchorSweep); err != nil {
+		c.cancelAnchorSweep(anchorSweep, prevSweep)
+		if logErr := c.log.LogAnchorSweep(prevSweep); logErr != nil {
+			log.Errorf("ChannelArbitrator(%v): unable to log "+
+				"anchor sweep: %v", c.cfg.ChanPoint, logErr)
+		}
+		return err
+	}
+
+	// Otherwise, the prior sweep has been evicted, so the coins it spent
+	// that aren't re-used by the replacement are available again.
+	c.cancelAnchorSweep(prevSweep, anchorSweep)
+	c.anchorSweep = anchorSweep
+
+	return nil
+}
+
+// publishAnchorSweep broadcasts the passed anchor sweep.
+func (c *ChannelArbitrator) publishAnchorSweep(
+	anchorSweep *lnwallet.AnchorSweep) error {
+
+	log.Infof("ChannelArbitrator(%v): broadcasting anchor sweep: %v",
+		c.cfg.ChanPoint, newLogClosure(func() string {
+			return spew.Sdump(anchorSweep.Tx)
+		}))
+
+	err := c.cfg.PublishTx(
+		anchorSweep.Tx, lnwallet.AnchorSweepLabel(c.cfg.ChanPoint),
+	)
+	if err != nil {
+		log.Warnf("ChannelArbitrator(%v): unable to broadcast anchor "+
+			"sweep %v: %v", c.cfg.ChanPoint, anchorSweep.Tx.TxHash(),
+			err)
+	}
+
+	return err
+}
+
+// cancelAnchorSweep releases the wallet coins spent by the passed anchor
+// sweep, except for those also spent by keep.
+func (c *ChannelArbitrator) cancelAnchorSweep(anchorSweep,
+	keep *lnwallet.AnchorSweep) {
+
+	if c.cfg.CancelAnchorSweep != nil {
+		c.cfg.CancelAnchorSweep(anchorSweep, keep)
+	}
+}
+
+// resolveAnchor is called once a commitment transaction of the channel
+// confirmed. If our anchor sweep bumped a different commitment, then it can
+// no longer confirm, so its coins are released. If we don't have a sweep
+// spending our anchor on the confirmed commitment, then we'll hand it to the
+// sweeper to reclaim its value. The anchor of the remote party is handed to
+// the sweeper as well, to be swept once anyone may spend it.
+func (c *ChannelArbitrator) resolveAnchor(commitHash chainhash.Hash,
+	anchor *lnwallet.AnchorResolution, height uint32) {
+
+	if c.anchorSweep != nil &&
+		c.anchorSweep.Anchor.CommitAnchor.Hash != commitHash {
+
+		log.Infof("ChannelArbitrator(%v): commitment %v confirmed, "+
+			"releasing coins of anchor sweep %v", c.cfg.ChanPoint,
+			commitHash, c.anchorSweep.Tx.TxHash())
+
+		c.cancelAnchorSweep(c.anchorSweep, nil)
+		c.anchorSweep = nil
+
+		if err := c.log.DeleteAnchorSweep(); err != nil {
+			log.Errorf("ChannelArbitrator(%v): unable to delete "+
+				"anchor sweep: %v", c.cfg.ChanPoint, err)
+		}
+	}
+
+	if anchor == nil || c.cfg.Sweeper == nil {
+		return
+	}
+
+	feePref := sweep.FeePreference{ConfTarget: sweepConfTarget}
+	if c.anchorSweep == nil {
+		log.Infof("ChannelArbitrator(%v): sweeping anchor %v of "+
+			"confirmed commitment", c.cfg.ChanPoint,
+			anchor.CommitAnchor)
+
+		anchorInput := sweep.MakeBaseInput(
+			&anchor.CommitAnchor, lnwallet.CommitmentAnchor,
+			&anchor.AnchorSignDesc, height,
+		)
+		_, err := c.cfg.Sweeper.SweepInput(&anchorInput, feePref)
+		if err != nil {
+			log.Errorf("ChannelArbitrator(%v): unable to sweep "+
+				"anchor %v: %v", c.cfg.ChanPoint,
+				anchor.CommitAnchor, err)
+		}
+	}
+
+	// The anchor of the remote party becomes spendable by anyone after
+	// the CSV delay. If they don't claim it by then, we'll sweep it.
+	if anchor.RemoteAnchor == nil {
+		return
+	}
+
+	log.Infof("ChannelArbitrator(%v): sweeping remote anchor %v of "+
+		"confirmed commitment after %v blocks", c.cfg.ChanPoint,
+		anchor.RemoteAnchor, lnwallet.AnchorCSVDelay)
+
+	remoteAnchorInput := sweep.NewCsvInput(
+		anchor.RemoteAnchor, lnwallet.CommitmentAnchorAnyone,
+		&anchor.RemoteAnchorSignDesc, height, lnwallet.AnchorCSVDelay,
+	)
+	_, err := c.cfg.Sweeper.SweepInput(remoteAnchorInput, feePref)
+	if err != nil {
+		log.Errorf("ChannelArbitrator(%v): unable to sweep remote "+
+			"anchor %v: %v", c.cfg.ChanPoint, anchor.RemoteAnchor,
+			err)
+	}
+}
+
 // checkChainActions is called for each new block connected to the end of the
 // main chain. Given the new block height, this new method will examine all
 // active HTLC's, and determine if we need to go on-chain to claim any of them.
@@ -1416,6 +1774,20 @@ func (c *ChannelArbitrator) channelAttendant(bestHeight int32) {
 			}
 			bestHeight = blockEpoch.Height
 
+			// If we're waiting for our commitment to confirm,
+			// then we'll make sure it pays a fee rate that still
+			// meets the deadline of our HTLCs.
+			if c.state == StateCommitmentBroadcasted &&
+				c.cfg.CreateAnchorSweep != nil {
+
+				err := c.bumpCommitFee(uint32(bestHeight), nil)
+				if err != nil {
+					log.Errorf("ChannelArbitrator(%v): "+
+						"unable to bump commitment "+
+						"fee: %v", c.cfg.ChanPoint, err)
+				}
+			}
+
 			// If we're not in the default state, then we can
 			// ignore this signal as we're waiting for contract
 			// resolution.
@@ -1509,6 +1881,11 @@ func (c *ChannelArbitrator) channelAttendant(bestHeight int32) {
 			}
 			closeTx := closeInfo.CloseTx
 
+			c.resolveAnchor(
+				closeTx.TxHash(), closeInfo.AnchorResolution,
+				uint32(closeInfo.SpendingHeight),
+			)
+
 			contractRes := &ContractResolutions{
 				CommitHash:       closeTx.TxHash(),
 				CommitResolution: closeInfo.CommitResolution,
@@ -1578,6 +1955,12 @@ func (c *ChannelArbitrator) channelAttendant(bestHeight int32) {
 			// present on their commitment.
 			c.activeHTLCs = newHtlcSet(uniClosure.RemoteCommit.Htlcs)
 
+			c.resolveAnchor(
+				*uniClosure.SpenderTxHash,
+				uniClosure.AnchorResolution,
+				uint32(uniClosure.SpendingHeight),
+			)
+
 			// When processing a unilateral close event, we'll
 			// transition to the ContractClosed state. We'll log
 			// out the set of resolutions such that they are
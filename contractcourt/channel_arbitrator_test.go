@@ -24,6 +24,7 @@ type mockArbitratorLog struct {
 	resolutions     *ContractResolutions
 	chainActions    ChainActionMap
 	resolvers       map[ContractResolver]struct{}
+	anchorSweep     *lnwallet.AnchorSweep
 
 	sync.Mutex
 }
@@ -117,6 +118,23 @@ func (b *mockArbitratorLog) FetchChainActions() (ChainActionMap, error) {
 	return actionsMap, nil
 }
 
+func (b *mockArbitratorLog) LogAnchorSweep(sweep *lnwallet.AnchorSweep) error {
+	b.anchorSweep = sweep
+	return nil
+}
+
+func (b *mockArbitratorLog) FetchAnchorSweep() (*lnwallet.AnchorSweep, error) {
+	if b.anchorSweep == nil {
+		return nil, errNoAnchorSweep
+	}
+	return b.anchorSweep, nil
+}
+
+func (b *mockArbitratorLog) DeleteAnchorSweep() error {
+	b.anchorSweep = nil
+	return nil
+}
+
 func (b *mockArbitratorLog) WipeHistory() error {
 	return nil
 }
@@ -158,7 +176,7 @@ func createTestChannelArbitrator(log ArbitratorLog) (*ChannelArbitrator,
 	chainIO := &mockChainIO{}
 	chainArbCfg := ChainArbitratorConfig{
 		ChainIO: chainIO,
-		PublishTx: func(*wire.MsgTx) error {
+		PublishTx: func(*wire.MsgTx, string) error {
 			return nil
 		},
 		DeliverResolutionMsg: func(...ResolutionMsg) error {
@@ -355,6 +373,15 @@ func TestChannelArbitratorLocalForceClose(t *testing.T) {
 		t.Fatalf("unable to create ChannelArbitrator: %v", err)
 	}
 
+	// We'll capture the closing notification, which should be sent as
+	// soon as the commitment is broadcast rather than once it confirms.
+	closingChan := make(chan channeldb.ClosureType, 1)
+	chanArb.cfg.NotifyClosingChannel = func(_ wire.OutPoint,
+		closeType channeldb.ClosureType) {
+
+		closingChan <- closeType
+	}
+
 	if err := chanArb.Start(); err != nil {
 		t.Fatalf("unable to start ChannelArbitrator: %v", err)
 	}
@@ -366,7 +393,12 @@ func TestChannelArbitratorLocalForceClose(t *testing.T) {
 	// We create a channel we can use to pause the ChannelArbitrator at the
 	// point where it broadcasts the close tx, and check its state.
 	stateChan := make(chan ArbitratorState)
-	chanArb.cfg.PublishTx = func(*wire.MsgTx) error {
+	chanArb.cfg.PublishTx = func(_ *wire.MsgTx, label string) error {
+		// The force close tx must be labelled as such.
+		if label != lnwallet.ForceCloseLabel(chanArb.cfg.ChanPoint) {
+			return fmt.Errorf("unexpected label %q", label)
+		}
+
 		// When the force close tx is being broadcasted, check that the
 		// state is correct at that point.
 		select {
@@ -424,6 +456,16 @@ func TestChannelArbitratorLocalForceClose(t *testing.T) {
 	// StateCommitmentBroadcasted.
 	assertState(t, chanArb, StateCommitmentBroadcasted)
 
+	select {
+	case closeType := <-closingChan:
+		if closeType != channeldb.LocalForceClose {
+			t.Fatalf("expected local force close, got %v",
+				closeType)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("closing channel not notified")
+	}
+
 	// Now notify about the local force close getting confirmed.
 	chanArb.cfg.ChainEvents.LocalUnilateralClosure <- &LocalUnilateralCloseInfo{
 		&chainntnfs.SpendDetail{},
@@ -644,7 +686,7 @@ func TestChannelArbitratorLocalForceCloseRemoteConfirmed(t *testing.T) {
 	// Create a channel we can use to assert the state when it publishes
 	// the close tx.
 	stateChan := make(chan ArbitratorState)
-	chanArb.cfg.PublishTx = func(*wire.MsgTx) error {
+	chanArb.cfg.PublishTx = func(*wire.MsgTx, string) error {
 		// When the force close tx is being broadcasted, check that the
 		// state is correct at that point.
 		select {
@@ -750,7 +792,7 @@ func TestChannelArbitratorLocalForceDoubleSpend(t *testing.T) {
 
 	// Return ErrDoubleSpend when attempting to publish the tx.
 	stateChan := make(chan ArbitratorState)
-	chanArb.cfg.PublishTx = func(*wire.MsgTx) error {
+	chanArb.cfg.PublishTx = func(*wire.MsgTx, string) error {
 		// When the force close tx is being broadcasted, check that the
 		// state is correct at that point.
 		select {
@@ -1143,3 +1185,334 @@ func TestChannelArbitratorEmptyResolutions(t *testing.T) {
 	}
 	chanArb.Stop()
 }
+
+// TestChannelArbitratorAnchorSweepPublishFailure tests that the wallet coins
+// of an anchor sweep are released if the sweep can't be published.
+func TestChannelArbitratorAnchorSweepPublishFailure(t *testing.T) {
+	log := &mockArbitratorLog{
+		state:     StateDefault,
+		newStates: make(chan ArbitratorState, 5),
+	}
+
+	chanArb, _, err := createTestChannelArbitrator(log)
+	if err != nil {
+		t.Fatalf("unable to create ChannelArbitrator: %v", err)
+	}
+
+	chanArb.cfg.FeeEstimator = lnwallet.StaticFeeEstimator{
+		FeePerKW: 10000,
+	}
+
+	anchorSweep := &lnwallet.AnchorSweep{Tx: &wire.MsgTx{}}
+	chanArb.cfg.CreateAnchorSweep = func(*lnwallet.AnchorResolution,
+		lnwallet.SatPerKWeight, *lnwallet.AnchorSweep) (
+		*lnwallet.AnchorSweep, error) {
+
+		return anchorSweep, nil
+	}
+
+	var cancelled *lnwallet.AnchorSweep
+	chanArb.cfg.CancelAnchorSweep = func(sweep, _ *lnwallet.AnchorSweep) {
+		cancelled = sweep
+	}
+	chanArb.cfg.PublishTx = func(*wire.MsgTx, string) error {
+		return fmt.Errorf("unable to publish")
+	}
+
+	// The commitment pays a fee rate far below the estimate, so we'll
+	// attempt to bump it.
+	anchors := &lnwallet.AnchorResolutions{
+		Local: &lnwallet.AnchorResolution{
+			CommitWeight: 1000,
+			CommitFee:    253,
+		},
+	}
+	if err := chanArb.bumpCommitFee(100, anchors); err == nil {
+		t.Fatalf("expected publish failure")
+	}
+
+	if cancelled != anchorSweep {
+		t.Fatalf("expected anchor sweep to be cancelled")
+	}
+	if chanArb.anchorSweep != nil || log.anchorSweep != nil {
+		t.Fatalf("expected no active anchor sweep")
+	}
+}
+
+// TestChannelArbitratorAnchorSweepRemoteCommit tests that we'll bump the fee
+// of the remote commitment if the sweep of the anchor on our own commitment
+// is rejected.
+func TestChannelArbitratorAnchorSweepRemoteCommit(t *testing.T) {
+	log := &mockArbitratorLog{
+		state:     StateDefault,
+		newStates: make(chan ArbitratorState, 5),
+	}
+
+	chanArb, _, err := createTestChannelArbitrator(log)
+	if err != nil {
+		t.Fatalf("unable to create ChannelArbitrator: %v", err)
+	}
+
+	chanArb.cfg.FeeEstimator = lnwallet.StaticFeeEstimator{
+		FeePerKW: 10000,
+	}
+
+	anchors := &lnwallet.AnchorResolutions{
+		Local: &lnwallet.AnchorResolution{
+			CommitAnchor: wire.OutPoint{Index: 1},
+			CommitWeight: 1000,
+			CommitFee:    253,
+		},
+		Remote: &lnwallet.AnchorResolution{
+			CommitAnchor: wire.OutPoint{Index: 2},
+			CommitWeight: 1000,
+			CommitFee:    253,
+		},
+	}
+
+	chanArb.cfg.CreateAnchorSweep = func(anchor *lnwallet.AnchorResolution,
+		feeRate lnwallet.SatPerKWeight, _ *lnwallet.AnchorSweep) (
+		*lnwallet.AnchorSweep, error) {
+
+		tx := &wire.MsgTx{}
+		tx.AddTxIn(&wire.TxIn{PreviousOutPoint: anchor.CommitAnchor})
+
+		return &lnwallet.AnchorSweep{
+			Anchor:  anchor,
+			Tx:      tx,
+			FeeRate: feeRate,
+		}, nil
+	}
+
+	var cancelled []*lnwallet.AnchorSweep
+	chanArb.cfg.CancelAnchorSweep = func(sweep, _ *lnwallet.AnchorSweep) {
+		cancelled = append(cancelled, sweep)
+	}
+
+	// Only the remote commitment is in the mempool, so the sweep of our
+	// own anchor is rejected.
+	chanArb.cfg.PublishTx = func(tx *wire.MsgTx, label string) error {
+		if label != lnwallet.AnchorSweepLabel(chanArb.cfg.ChanPoint) {
+			return fmt.Errorf("unexpected label %q", label)
+		}
+		if tx.TxIn[0].PreviousOutPoint == anchors.Local.CommitAnchor {
+			return lnwallet.ErrDoubleSpend
+		}
+		return nil
+	}
+
+	if err := chanArb.bumpCommitFee(100, anchors); err != nil {
+		t.Fatalf("unable to bump commitment fee: %v", err)
+	}
+
+	if len(cancelled) != 1 || cancelled[0].Anchor != anchors.Local {
+		t.Fatalf("expected sweep of local anchor to be cancelled")
+	}
+	if chanArb.anchorSweep == nil ||
+		chanArb.anchorSweep.Anchor != anchors.Remote {
+
+		t.Fatalf("expected sweep of remote anchor to be active")
+	}
+	if log.anchorSweep != chanArb.anchorSweep {
+		t.Fatalf("expected sweep of remote anchor to be logged")
+	}
+
+	// If the remote commitment confirms, then we'll keep the sweep of
+	// our anchor on it.
+	chanArb.resolveAnchor(
+		anchors.Remote.CommitAnchor.Hash, anchors.Remote, 101,
+	)
+	if len(cancelled) != 1 || chanArb.anchorSweep == nil {
+		t.Fatalf("expected sweep of remote anchor to remain active")
+	}
+}
+
+// TestChannelArbitratorAnchorSweepDeadline tests that our anchor sweep is
+// replaced with one paying a higher fee as the deadline of the HTLCs on the
+// commitment approaches, and that its coins are released if another
+// commitment confirms.
+func TestChannelArbitratorAnchorSweepDeadline(t *testing.T) {
+	log := &mockArbitratorLog{
+		state:     StateDefault,
+		newStates: make(chan ArbitratorState, 5),
+	}
+
+	chanArb, _, err := createTestChannelArbitrator(log)
+	if err != nil {
+		t.Fatalf("unable to create ChannelArbitrator: %v", err)
+	}
+
+	// The HTLC on the commitment expires at height 110, so the estimated
+	// fee rate rises as we get closer to it.
+	chanArb.activeHTLCs = newHtlcSet([]channeldb.HTLC{{
+		RefundTimeout: 110,
+		OutputIndex:   2,
+	}})
+	chanArb.cfg.FeeEstimator = &deadlineFeeEstimator{}
+
+	var created []*lnwallet.AnchorSweep
+	chanArb.cfg.CreateAnchorSweep = func(anchor *lnwallet.AnchorResolution,
+		feeRate lnwallet.SatPerKWeight, replaces *lnwallet.AnchorSweep) (
+		*lnwallet.AnchorSweep, error) {
+
+		if len(created) > 0 && replaces != created[len(created)-1] {
+			t.Fatalf("expected replacement of prior sweep")
+		}
+
+		anchorSweep := &lnwallet.AnchorSweep{
+			Anchor:  anchor,
+			Tx:      &wire.MsgTx{LockTime: uint32(len(created))},
+			FeeRate: feeRate,
+		}
+		created = append(created, anchorSweep)
+
+		return anchorSweep, nil
+	}
+
+	type cancellation struct {
+		sweep, keep *lnwallet.AnchorSweep
+	}
+	var cancelled []cancellation
+	chanArb.cfg.CancelAnchorSweep = func(sweep, keep *lnwallet.AnchorSweep) {
+		cancelled = append(cancelled, cancellation{sweep, keep})
+	}
+
+	anchors := &lnwallet.AnchorResolutions{
+		Local: &lnwallet.AnchorResolution{
+			CommitWeight: 1000,
+			CommitFee:    253,
+		},
+	}
+	chanArb.cfg.FetchAnchorResolutions = func() (
+		*lnwallet.AnchorResolutions, error) {
+
+		return anchors, nil
+	}
+
+	if err := chanArb.bumpCommitFee(100, nil); err != nil {
+		t.Fatalf("unable to bump commitment fee: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("expected anchor sweep to be created")
+	}
+
+	// Within the same conf target, the sweep isn't replaced.
+	if err := chanArb.bumpCommitFee(100, nil); err != nil {
+		t.Fatalf("unable to bump commitment fee: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("expected anchor sweep not to be replaced")
+	}
+
+	// As the deadline approaches, the sweep is replaced and the coins of
+	// the prior sweep not re-used by the replacement are released.
+	if err := chanArb.bumpCommitFee(105, nil); err != nil {
+		t.Fatalf("unable to bump commitment fee: %v", err)
+	}
+	if len(created) != 2 || created[1].FeeRate <= created[0].FeeRate {
+		t.Fatalf("expected anchor sweep to be replaced at higher fee " +
+			"rate")
+	}
+	if len(cancelled) != 1 || cancelled[0].sweep != created[0] ||
+		cancelled[0].keep != created[1] {
+
+		t.Fatalf("expected prior sweep to be cancelled")
+	}
+	if chanArb.anchorSweep != created[1] || log.anchorSweep != created[1] {
+		t.Fatalf("expected replacement to be active")
+	}
+
+	// Once a different commitment confirms, the sweep can't confirm
+	// anymore, so its coins are released.
+	chanArb.resolveAnchor(chainhash.Hash{1}, nil, 106)
+	if len(cancelled) != 2 || cancelled[1].sweep != created[1] ||
+		cancelled[1].keep != nil {
+
+		t.Fatalf("expected active sweep to be cancelled")
+	}
+	if chanArb.anchorSweep != nil || log.anchorSweep != nil {
+		t.Fatalf("expected no active anchor sweep")
+	}
+}
+
+// TestChannelArbitratorAnchorSweepRestart tests that an anchor sweep we've
+// broadcast before a restart is adopted, such that it's replaced rather than
+// conflicted with by a new sweep.
+func TestChannelArbitratorAnchorSweepRestart(t *testing.T) {
+	prevSweep := &lnwallet.AnchorSweep{
+		Anchor: &lnwallet.AnchorResolution{
+			CommitWeight: 1000,
+			CommitFee:    253,
+		},
+		Tx:      &wire.MsgTx{},
+		FeeRate: 5000,
+	}
+	log := &mockArbitratorLog{
+		state:       StateCommitmentBroadcasted,
+		newStates:   make(chan ArbitratorState, 5),
+		anchorSweep: prevSweep,
+	}
+
+	chanArb, _, err := createTestChannelArbitrator(log)
+	if err != nil {
+		t.Fatalf("unable to create ChannelArbitrator: %v", err)
+	}
+	if err := chanArb.Start(); err != nil {
+		t.Fatalf("unable to start ChannelArbitrator: %v", err)
+	}
+	chanArb.Stop()
+
+	if chanArb.anchorSweep != prevSweep {
+		t.Fatalf("expected logged anchor sweep to be adopted")
+	}
+
+	chanArb.cfg.FeeEstimator = lnwallet.StaticFeeEstimator{
+		FeePerKW: 10000,
+	}
+
+	var replaced *lnwallet.AnchorSweep
+	chanArb.cfg.CreateAnchorSweep = func(anchor *lnwallet.AnchorResolution,
+		feeRate lnwallet.SatPerKWeight, replaces *lnwallet.AnchorSweep) (
+		*lnwallet.AnchorSweep, error) {
+
+		replaced = replaces
+		return &lnwallet.AnchorSweep{
+			Anchor:  anchor,
+			Tx:      &wire.MsgTx{LockTime: 1},
+			FeeRate: feeRate,
+		}, nil
+	}
+	chanArb.cfg.CancelAnchorSweep = func(_, _ *lnwallet.AnchorSweep) {}
+
+	// The fee rate went up, so the adopted sweep should be replaced, even
+	// though the anchor resolutions aren't fetched again.
+	if err := chanArb.bumpCommitFee(100, nil); err != nil {
+		t.Fatalf("unable to bump commitment fee: %v", err)
+	}
+	if replaced != prevSweep {
+		t.Fatalf("expected adopted sweep to be replaced")
+	}
+	if log.anchorSweep != chanArb.anchorSweep ||
+		log.anchorSweep.FeeRate != 10000 {
+
+		t.Fatalf("expected replacement to be logged")
+	}
+}
+
+// deadlineFeeEstimator is a fee estimator that returns a higher fee rate the
+// lower the conf target.
+type deadlineFeeEstimator struct{}
+
+func (e *deadlineFeeEstimator) EstimateFeePerKW(
+	numBlocks uint32) (lnwallet.SatPerKWeight, error) {
+
+	return lnwallet.SatPerKWeight(100000 / numBlocks), nil
+}
+
+func (e *deadlineFeeEstimator) Start() error {
+	return nil
+}
+
+func (e *deadlineFeeEstimator) Stop() error {
+	return nil
+}
@@ -8,18 +8,27 @@ import (
 	"io"
 	"io/ioutil"
 
-	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/sweep"
 )
 
 var (
 	endian = binary.BigEndian
 )
 
+const (
+	// sweepConfTarget is the default number of blocks that we'll use as a
+	// confirmation target when sweeping.
+	sweepConfTarget = 6
+
+	// chanTypeSize is the size of an encoded channel type.
+	chanTypeSize = 1
+)
+
 // ContractResolver is an interface which packages a state machine which is
 // able to carry out the necessary steps required to fully resolve a Bitcoin
 // contract on-chain. Resolvers are fully encodable to ensure callers are able
@@ -392,11 +401,9 @@ type htlcSuccessResolver struct {
 	// payHash is the payment hash of the original HTLC extended to us.
 	payHash [32]byte
 
-	// sweepTx will be non-nil if we've already crafted a transaction to
-	// sweep a direct HTLC output. This is only a concern if we're sweeping
-	// from the commitment transaction of the remote party.
-	//
-	// TODO(roasbeef): send off to utxobundler
+	// sweepTx will be non-nil once the sweeper has swept a direct HTLC
+	// output. This is only a concern if we're sweeping from the commitment
+	// transaction of the remote party.
 	sweepTx *wire.MsgTx
 
 	ResolverKit
@@ -424,10 +431,8 @@ func (h *htlcSuccessResolver) ResolverKey() []byte {
 
 // Resolve attempts to resolve an unresolved incoming HTLC that we know the
 // preimage to. If the HTLC is on the commitment of the remote party, then
-// we'll simply sweep it directly. Otherwise, we'll hand this off to the utxo
-// nursery to do its duty.
-//
-// TODO(roasbeef): create multi to batch
+// we'll hand it to the sweeper to sweep it directly. Otherwise, we'll hand
+// this off to the utxo nursery to do its duty.
 //
 // NOTE: Part of the ContractResolver interface.
 func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
@@ -439,91 +444,62 @@ func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
 	// If we don't have a success transaction, then this means that this is
 	// an output on the remote party's commitment transaction.
 	if h.htlcResolution.SignedSuccessTx == nil {
-		// If we don't already have the sweep transaction constructed,
-		// we'll do so and broadcast it.
+		// If we don't already have the sweep transaction, we'll hand
+		// the output off to the sweeper and wait for it to be swept.
 		if h.sweepTx == nil {
-			log.Infof("%T(%x): crafting sweep tx for "+
-				"incoming+remote htlc confirmed", h,
-				h.payHash[:])
+			log.Infof("%T(%x): offering incoming+remote htlc output "+
+				"to sweeper", h, h.payHash[:])
 
 			// In this case, we can sweep it directly from the
-			// commitment output. We'll first grab a fresh address
-			// from the wallet to sweep the output.
-			addr, err := h.NewSweepAddr()
-			if err != nil {
-				return nil, err
-			}
-
-			// With our address obtained, we'll query for an
-			// estimate to be confirmed at ease.
-			//
-			// TODO(roasbeef): signal up if fee would be too large
-			// to sweep singly, need to batch
-			feePerKw, err := h.FeeEstimator.EstimateFeePerKW(6)
-			if err != nil {
-				return nil, err
-			}
-
-			log.Debugf("%T(%x): using %v sat/kw to sweep htlc"+
-				"incoming+remote htlc confirmed", h,
-				h.payHash[:], int64(feePerKw))
-
-			// Using a weight estimator, we'll compute the total
-			// fee required, and from that the value we'll end up
-			// with.
-			totalWeight := (&lnwallet.TxWeightEstimator{}).
-				AddWitnessInput(lnwallet.OfferedHtlcSuccessWitnessSize).
-				AddP2WKHOutput().Weight()
-			totalFees := feePerKw.FeeForWeight(int64(totalWeight))
-			sweepAmt := h.htlcResolution.SweepSignDesc.Output.Value -
-				int64(totalFees)
-
-			// With the fee computation finished, we'll now
-			// construct the sweep transaction.
-			htlcPoint := h.htlcResolution.ClaimOutpoint
-			h.sweepTx = wire.NewMsgTx(2)
-			h.sweepTx.AddTxIn(&wire.TxIn{
-				PreviousOutPoint: htlcPoint,
-			})
-			h.sweepTx.AddTxOut(&wire.TxOut{
-				PkScript: addr,
-				Value:    sweepAmt,
-			})
-
-			// With the transaction fully assembled, we can now
-			// generate a valid witness for the transaction.
-			h.htlcResolution.SweepSignDesc.SigHashes = txscript.NewTxSigHashes(
-				h.sweepTx,
-			)
-			h.sweepTx.TxIn[0].Witness, err = lnwallet.SenderHtlcSpendRedeem(
-				h.Signer, &h.htlcResolution.SweepSignDesc, h.sweepTx,
+			// commitment output using the preimage. The sweeper
+			// will batch it with other inputs.
+			input := sweep.MakeHtlcSucceedInput(
+				&h.htlcResolution.ClaimOutpoint,
+				&h.htlcResolution.SweepSignDesc,
 				h.htlcResolution.Preimage[:],
+				h.broadcastHeight,
+			)
+			resultChan, err := h.Sweeper.SweepInput(
+				&input, sweep.FeePreference{
+					ConfTarget: sweepConfTarget,
+				},
 			)
 			if err != nil {
 				return nil, err
 			}
 
-			log.Infof("%T(%x): crafted sweep tx=%v", h,
-				h.payHash[:], spew.Sdump(h.sweepTx))
+			select {
+			case sweepResult := <-resultChan:
+				switch sweepResult.Err {
+				case nil:
 
-			// With the sweep transaction confirmed, we'll now
-			// Checkpoint our state.
-			if err := h.Checkpoint(h); err != nil {
-				log.Errorf("unable to Checkpoint: %v", err)
-			}
+				// If the remote party swept the output using
+				// the timeout clause, there's nothing left for
+				// us to claim.
+				case sweep.ErrRemoteSpend:
+					log.Warnf("%T(%x): htlc output swept "+
+						"by remote party with tx=%v",
+						h, h.payHash[:],
+						sweepResult.Tx.TxHash())
 
-			// Finally, we'll broadcast the sweep transaction to
-			// the network.
-			//
-			// TODO(roasbeef): validate first?
-			if err := h.PublishTx(h.sweepTx); err != nil {
-				log.Infof("%T(%x): unable to publish tx: %v",
-					h, h.payHash[:], err)
-				return nil, err
+					h.resolved = true
+					return nil, h.Checkpoint(h)
+
+				default:
+					return nil, sweepResult.Err
+				}
+
+				h.sweepTx = sweepResult.Tx
+
+			case <-h.Quit:
+				return nil, fmt.Errorf("quitting")
 			}
+
+			log.Infof("%T(%x): htlc output swept by tx=%v", h,
+				h.payHash[:], h.sweepTx.TxHash())
 		}
 
-		// With the sweep transaction broadcast, we'll wait for its
+		// With the sweep transaction known, we'll wait for its
 		// confirmation.
 		sweepTXID := h.sweepTx.TxHash()
 		sweepScript := h.sweepTx.TxOut[0].PkScript
@@ -560,7 +536,9 @@ func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
 	// the claiming process.
 	//
 	// TODO(roasbeef): after changing sighashes send to tx bundler
-	if err := h.PublishTx(h.htlcResolution.SignedSuccessTx); err != nil {
+	label := lnwallet.HtlcSuccessLabel(h.ChanPoint)
+	err := h.PublishTx(h.htlcResolution.SignedSuccessTx, label)
+	if err != nil {
 		return nil, err
 	}
 
@@ -1257,62 +1235,56 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 	// party broadcast the commitment transaction then we'll create it now.
 	case c.sweepTx == nil && !isLocalCommitTx:
 		// Now that the commitment transaction has confirmed, we'll
-		// craft a transaction to sweep this output into the wallet.
+		// hand the output to the sweeper, which sweeps it into the
+		// wallet. Channels using the tweakless commitment format pay
+		// this output directly to our payment base point.
 		signDesc := c.commitResolution.SelfOutputSignDesc
-
-		// First, we'll estimate the total weight so we can compute
-		// fees properly. We'll use a lax estimate, as this output is
-		// in no immediate danger.
-		feePerKw, err := c.FeeEstimator.EstimateFeePerKW(6)
-		if err != nil {
-			return nil, err
+		witnessType := lnwallet.CommitmentNoDelay
+		if c.commitResolution.ChanType.IsTweakless() {
+			witnessType = lnwallet.CommitSpendNoDelayTweakless
 		}
 
-		log.Debugf("%T(%v): using %v sat/kw for sweep tx", c,
-			c.chanPoint, int64(feePerKw))
-
-		totalWeight := (&lnwallet.TxWeightEstimator{}).
-			AddP2WKHInput().
-			AddP2WKHOutput().Weight()
-		totalFees := feePerKw.FeeForWeight(int64(totalWeight))
-		sweepAmt := signDesc.Output.Value - int64(totalFees)
+		input := sweep.MakeBaseInput(
+			&c.commitResolution.SelfOutPoint, witnessType,
+			&signDesc, c.broadcastHeight,
+		)
 
-		c.sweepTx = wire.NewMsgTx(2)
-		c.sweepTx.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: c.commitResolution.SelfOutPoint,
-		})
-		sweepAddr, err := c.NewSweepAddr()
-		if err != nil {
-			return nil, err
-		}
-		c.sweepTx.AddTxOut(&wire.TxOut{
-			PkScript: sweepAddr,
-			Value:    sweepAmt,
-		})
-
-		// With the transaction fully assembled, we can now generate a
-		// valid witness for the transaction.
-		signDesc.SigHashes = txscript.NewTxSigHashes(c.sweepTx)
-		c.sweepTx.TxIn[0].Witness, err = lnwallet.CommitSpendNoDelay(
-			c.Signer, &signDesc, c.sweepTx,
+		// We'll use a lax confirmation target, as this output is in no
+		// immediate danger.
+		resultChan, err := c.Sweeper.SweepInput(
+			&input, sweep.FeePreference{
+				ConfTarget: sweepConfTarget,
+			},
 		)
 		if err != nil {
+			log.Errorf("%T(%v): unable to sweep input: %v",
+				c, c.chanPoint, err)
 			return nil, err
 		}
 
-		log.Infof("%T(%v): sweeping commit output with tx=%v", c,
-			c.chanPoint, spew.Sdump(c.sweepTx))
+		log.Infof("%T(%v): waiting for commit output to be swept by "+
+			"the sweeper", c, c.chanPoint)
 
-		// Finally, we'll broadcast the sweep transaction to the
-		// network.
-		if err := c.PublishTx(c.sweepTx); err != nil {
-			log.Errorf("%T(%v): unable to publish sweep tx: %v",
-				c, c.chanPoint, err)
-			return nil, err
+		select {
+		case sweepResult := <-resultChan:
+			if sweepResult.Err != nil {
+				log.Errorf("%T(%v): unable to sweep commit "+
+					"output: %v", c, c.chanPoint,
+					sweepResult.Err)
+				return nil, sweepResult.Err
+			}
+
+			c.sweepTx = sweepResult.Tx
+
+		case <-c.Quit:
+			return nil, fmt.Errorf("quitting")
 		}
 
-		// With the sweep transaction confirmed, we'll now Checkpoint
-		// our state.
+		log.Infof("%T(%v): commit output swept by txid=%v", c,
+			c.chanPoint, c.sweepTx.TxHash())
+
+		// With the sweep transaction known, we'll now Checkpoint our
+		// state.
 		if err := c.Checkpoint(c); err != nil {
 			log.Errorf("unable to Checkpoint: %v", err)
 		}
@@ -1430,10 +1402,15 @@ func (c *commitSweepResolver) Encode(w io.Writer) error {
 	}
 
 	if c.sweepTx != nil {
-		return c.sweepTx.Serialize(w)
+		if err := c.sweepTx.Serialize(w); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	// The channel type trails the optional sweep transaction, as it was
+	// added later. Resolvers written before it are read as resolvers of
+	// channels of the legacy commitment format.
+	return binary.Write(w, endian, c.commitResolution.ChanType)
 }
 
 // Decode attempts to decode an encoded ContractResolver from the passed Reader
@@ -1465,17 +1442,24 @@ func (c *commitSweepResolver) Decode(r io.Reader) error {
 		return err
 	}
 
-	if len(txBytes) == 0 {
-		return nil
+	// A single remaining byte can't be a transaction, so it's the channel
+	// type of a resolver without a sweep transaction.
+	txReader := bytes.NewReader(txBytes)
+	if len(txBytes) > chanTypeSize {
+		tx := &wire.MsgTx{}
+		if err := tx.Deserialize(txReader); err != nil {
+			return nil
+		}
+
+		c.sweepTx = tx
 	}
 
-	txReader := bytes.NewReader(txBytes)
-	tx := &wire.MsgTx{}
-	if err := tx.Deserialize(txReader); err != nil {
-		return nil
+	if txReader.Len() == chanTypeSize {
+		return binary.Read(
+			txReader, endian, &c.commitResolution.ChanType,
+		)
 	}
 
-	c.sweepTx = tx
 	return nil
 }
 
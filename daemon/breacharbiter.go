@@ -8,7 +8,6 @@ import (
 	"sync"
 	"sync/atomic"
 
-	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
@@ -19,6 +18,7 @@ import (
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/sweep"
 )
 
 var (
@@ -104,6 +104,11 @@ type BreachConfig struct {
 	// breached channels. This is used in conjunction with DB to recover
 	// from crashes, restarts, or other failures.
 	Store RetributionStore
+
+	// NotifyFullyClosedChannel is an optional function closure that will
+	// be called once justice has been served, and the breached channel
+	// has been marked as fully closed within the database.
+	NotifyFullyClosedChannel func(wire.OutPoint)
 }
 
 // breachArbiter is a special subsystem which is responsible for watching and
@@ -607,6 +612,10 @@ justiceTxBroadcast:
 			return
 		}
 
+		if b.cfg.NotifyFullyClosedChannel != nil {
+			b.cfg.NotifyFullyClosedChannel(breachInfo.chanPoint)
+		}
+
 		// Justice has been carried out; we can safely delete the
 		// retribution info from the database.
 		err = b.cfg.Store.Remove(&breachInfo.chanPoint)
@@ -888,11 +897,18 @@ func newRetributionInfo(chanPoint *wire.OutPoint,
 	// First, record the breach information for the local channel point if
 	// it is not considered dust, which is signaled by a non-nil sign
 	// descriptor. Here we use CommitmentNoDelay since this output belongs
-	// to us and has no time-based constraints on spending.
+	// to us and has no time-based constraints on spending. If the channel
+	// uses the tweakless commitment format, then our output pays directly
+	// to our payment base point.
 	if breachInfo.LocalOutputSignDesc != nil {
+		witnessType := lnwallet.CommitmentNoDelay
+		if breachInfo.ChanType.IsTweakless() {
+			witnessType = lnwallet.CommitSpendNoDelayTweakless
+		}
+
 		localOutput := makeBreachedOutput(
 			&breachInfo.LocalOutpoint,
-			lnwallet.CommitmentNoDelay,
+			witnessType,
 			// No second level script as this is a commitment
 			// output.
 			nil,
@@ -955,161 +971,43 @@ func newRetributionInfo(chanPoint *wire.OutPoint,
 // the funds within the channel which we are now entitled to due to a breach of
 // the channel's contract by the counterparty. This function returns a *fully*
 // signed transaction with the witness for each input fully in place.
+//
+// NOTE: The justice transaction is not handed to the sweeper, as it must be
+// published immediately rather than batched with other inputs at the next
+// block.
 func (b *breachArbiter) createJusticeTx(
 	r *retributionInfo) (*wire.MsgTx, error) {
 
-	// We will assemble the breached outputs into a slice of spendable
-	// outputs, while simultaneously computing the estimated weight of the
-	// transaction.
-	var (
-		spendableOutputs []SpendableOutput
-		weightEstimate   lnwallet.TxWeightEstimator
-	)
-
-	// Allocate enough space to potentially hold each of the breached
-	// outputs in the retribution info.
-	spendableOutputs = make([]SpendableOutput, 0, len(r.breachedOutputs))
-
-	// The justice transaction we construct will be a segwit transaction
-	// that pays to a p2wkh output. Components such as the version,
-	// nLockTime, and output are already included in the TxWeightEstimator.
-	weightEstimate.AddP2WKHOutput()
-
-	// Next, we iterate over the breached outputs contained in the
-	// retribution info.  For each, we switch over the witness type such
-	// that we contribute the appropriate weight for each input and witness,
-	// finally adding to our list of spendable outputs.
+	// We will assemble the breached outputs into a slice of sweep inputs.
+	// Outputs with an unknown witness type will be left out of the
+	// transaction by the sweep tx generator.
+	inputs := make([]sweep.Input, 0, len(r.breachedOutputs))
 	for i := range r.breachedOutputs {
 		// Grab locally scoped reference to breached output.
-		input := &r.breachedOutputs[i]
-
-		// First, select the appropriate estimated witness weight for
-		// the give witness type of this breached output. If the witness
-		// type is unrecognized, we will omit it from the transaction.
-		var witnessWeight int
-		switch input.WitnessType() {
-		case lnwallet.CommitmentNoDelay:
-			witnessWeight = lnwallet.P2WKHWitnessSize
-
-		case lnwallet.CommitmentRevoke:
-			witnessWeight = lnwallet.ToLocalPenaltyWitnessSize
+		bo := &r.breachedOutputs[i]
 
-		case lnwallet.HtlcOfferedRevoke:
-			witnessWeight = lnwallet.OfferedHtlcPenaltyWitnessSize
-
-		case lnwallet.HtlcAcceptedRevoke:
-			witnessWeight = lnwallet.AcceptedHtlcPenaltyWitnessSize
-
-		case lnwallet.HtlcSecondLevelRevoke:
-			witnessWeight = lnwallet.ToLocalPenaltyWitnessSize
-
-		default:
-			brarLog.Warnf("breached output in retribution info "+
-				"contains unexpected witness type: %v",
-				input.WitnessType())
-			continue
-		}
-		weightEstimate.AddWitnessInput(witnessWeight)
-
-		// Finally, append this input to our list of spendable outputs.
-		spendableOutputs = append(spendableOutputs, input)
+		input := sweep.MakeBaseInput(
+			&bo.outpoint, bo.witnessType, &bo.signDesc,
+			r.breachHeight,
+		)
+		inputs = append(inputs, &input)
 	}
 
-	txWeight := int64(weightEstimate.Weight())
-	return b.sweepSpendableOutputsTxn(txWeight, spendableOutputs...)
-}
-
-// sweepSpendableOutputsTxn creates a signed transaction from a sequence of
-// spendable outputs by sweeping the funds into a single p2wkh output.
-func (b *breachArbiter) sweepSpendableOutputsTxn(txWeight int64,
-	inputs ...SpendableOutput) (*wire.MsgTx, error) {
-
-	// First, we obtain a new public key script from the wallet which we'll
+	// Next, we obtain a new public key script from the wallet which we'll
 	// sweep the funds to.
-	// TODO(roasbeef): possibly create many outputs to minimize change in
-	// the future?
 	pkScript, err := b.cfg.GenSweepScript()
 	if err != nil {
 		return nil, err
 	}
 
-	// Compute the total amount contained in the inputs.
-	var totalAmt btcutil.Amount
-	for _, input := range inputs {
-		totalAmt += input.Amount()
-	}
-
 	// We'll actually attempt to target inclusion within the next two
 	// blocks as we'd like to sweep these funds back into our wallet ASAP.
 	feePerKw, err := b.cfg.Estimator.EstimateFeePerKW(2)
 	if err != nil {
 		return nil, err
 	}
-	txFee := feePerKw.FeeForWeight(txWeight)
-
-	// TODO(roasbeef): already start to siphon their funds into fees
-	sweepAmt := int64(totalAmt - txFee)
-
-	// With the fee calculated, we can now create the transaction using the
-	// information gathered above and the provided retribution information.
-	txn := wire.NewMsgTx(2)
-
-	// We begin by adding the output to which our funds will be deposited.
-	txn.AddTxOut(&wire.TxOut{
-		PkScript: pkScript,
-		Value:    sweepAmt,
-	})
-
-	// Next, we add all of the spendable outputs as inputs to the
-	// transaction.
-	for _, input := range inputs {
-		txn.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: *input.OutPoint(),
-		})
-	}
-
-	// Before signing the transaction, check to ensure that it meets some
-	// basic validity requirements.
-	btx := btcutil.NewTx(txn)
-	if err := blockchain.CheckTransactionSanity(btx); err != nil {
-		return nil, err
-	}
-
-	// Create a sighash cache to improve the performance of hashing and
-	// signing SigHashAll inputs.
-	hashCache := txscript.NewTxSigHashes(txn)
-
-	// Create a closure that encapsulates the process of initializing a
-	// particular output's witness generation function, computing the
-	// witness, and attaching it to the transaction. This function accepts
-	// an integer index representing the intended txin index, and the
-	// breached output from which it will spend.
-	addWitness := func(idx int, so SpendableOutput) error {
-		// First, we construct a valid witness for this outpoint and
-		// transaction using the SpendableOutput's witness generation
-		// function.
-		witness, err := so.BuildWitness(b.cfg.Signer, txn, hashCache,
-			idx)
-		if err != nil {
-			return err
-		}
-
-		// Then, we add the witness to the transaction at the
-		// appropriate txin index.
-		txn.TxIn[idx].Witness = witness
-
-		return nil
-	}
-
-	// Finally, generate a witness for each output and attach it to the
-	// transaction.
-	for i, input := range inputs {
-		if err := addWitness(i, input); err != nil {
-			return nil, err
-		}
-	}
 
-	return txn, nil
+	return sweep.CreateSweepTx(inputs, pkScript, feePerKw, b.cfg.Signer)
 }
 
 // RetributionStore provides an interface for managing a persistent map from
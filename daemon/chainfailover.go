@@ -0,0 +1,250 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/lightningnetwork/lnd/chainfailover"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chainntnfs/bitcoindnotify"
+	"github.com/lightningnetwork/lnd/chainntnfs/btcdnotify"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/routing/chainview"
+)
+
+// newBtcdFailover creates a failover across the btcd/ltcd node configured as
+// the primary backend and its failover hosts, which share the credentials and
+// the TLS certificate of the primary node. If liveFees is false, the passed
+// static fee estimator is used instead of the fee estimates of the nodes.
+func newBtcdFailover(cfg *config, btcdMode *btcdConfig, primaryHost string,
+	rpcCert []byte, hintCache *chainntnfs.HeightHintCache, liveFees bool,
+	staticFees lnwallet.FeeEstimator) (*chainfailover.Failover, error) {
+
+	hosts := []string{primaryHost}
+	for _, host := range btcdMode.FailoverHosts {
+		if !strings.Contains(host, ":") {
+			host = fmt.Sprintf("%v:%v", host, activeNetParams.rpcPort)
+		}
+		hosts = append(hosts, host)
+	}
+
+	backends := make([]*chainfailover.Backend, 0, len(hosts))
+	for _, host := range hosts {
+		rpcConfig := rpcclient.ConnConfig{
+			Host:                 host,
+			Endpoint:             "ws",
+			User:                 btcdMode.RPCUser,
+			Pass:                 btcdMode.RPCPass,
+			Certificates:         rpcCert,
+			DisableTLS:           false,
+			DisableConnectOnNew:  true,
+			DisableAutoReconnect: false,
+		}
+
+		// The health checks and chain queries use a separate HTTP
+		// client, as it doesn't need to hold a connection.
+		httpConfig := rpcConfig
+		httpConfig.Endpoint = ""
+		httpConfig.HTTPPostMode = true
+		httpClient, err := rpcclient.New(&httpConfig, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		feeEstimator := staticFees
+		if liveFees {
+			fallBackFeeRate := lnwallet.SatPerKVByte(25 * 1000)
+			feeEstimator, err = lnwallet.NewBtcdFeeEstimator(
+				rpcConfig, fallBackFeeRate.FeePerKWeight(),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		chainIO := chainfailover.NewRPCChainIO(httpClient)
+		backends = append(backends, &chainfailover.Backend{
+			Name:         host,
+			ChainIO:      chainIO,
+			FeeEstimator: feeEstimator,
+			PublishTx:    chainIO.PublishTransaction,
+			Connect: func() (*chainfailover.Connection, error) {
+				notifier, err := btcdnotify.New(
+					&rpcConfig, hintCache, hintCache,
+				)
+				if err != nil {
+					return nil, err
+				}
+				chainView, err := chainview.NewBtcdFilteredChainView(
+					rpcConfig,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				return &chainfailover.Connection{
+					Notifier:  notifier,
+					ChainView: chainView,
+					Close:     func() {},
+				}, nil
+			},
+		})
+	}
+
+	return newChainFailover(cfg, backends)
+}
+
+// newBitcoindFailover creates a failover across the bitcoind/litecoind node
+// configured as the primary backend and its failover hosts, which share the
+// credentials of the primary node. The connection to the primary node is
+// shared with the wallet, so it's left open when failing over. If liveFees is
+// false, the passed static fee estimator is used instead of the fee estimates
+// of the nodes.
+func newBitcoindFailover(cfg *config, bitcoindMode *bitcoindConfig,
+	primaryHost string, primaryConn *chain.BitcoindConn,
+	hintCache *chainntnfs.HeightHintCache, liveFees bool,
+	staticFees lnwallet.FeeEstimator) (*chainfailover.Failover, error) {
+
+	newBackend := func(host string, connect func() (*chain.BitcoindConn,
+		func(), error)) (*chainfailover.Backend, error) {
+
+		rpcConfig := rpcclient.ConnConfig{
+			Host:                 host,
+			User:                 bitcoindMode.RPCUser,
+			Pass:                 bitcoindMode.RPCPass,
+			DisableConnectOnNew:  true,
+			DisableAutoReconnect: false,
+			DisableTLS:           true,
+			HTTPPostMode:         true,
+		}
+		httpClient, err := rpcclient.New(&rpcConfig, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		feeEstimator := staticFees
+		if liveFees {
+			fallBackFeeRate := lnwallet.SatPerKVByte(25 * 1000)
+			feeEstimator, err = lnwallet.NewBitcoindFeeEstimator(
+				rpcConfig, fallBackFeeRate.FeePerKWeight(),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		chainIO := chainfailover.NewRPCChainIO(httpClient)
+		return &chainfailover.Backend{
+			Name:         host,
+			ChainIO:      chainIO,
+			FeeEstimator: feeEstimator,
+			PublishTx:    chainIO.PublishTransaction,
+			Connect: func() (*chainfailover.Connection, error) {
+				conn, closeConn, err := connect()
+				if err != nil {
+					return nil, err
+				}
+
+				return &chainfailover.Connection{
+					Notifier: bitcoindnotify.New(
+						conn, hintCache, hintCache,
+					),
+					ChainView: chainview.NewBitcoindFilteredChainView(
+						conn,
+					),
+					Close: closeConn,
+				}, nil
+			},
+		}, nil
+	}
+
+	primary, err := newBackend(primaryHost,
+		func() (*chain.BitcoindConn, func(), error) {
+			return primaryConn, func() {}, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	backends := []*chainfailover.Backend{primary}
+
+	for _, failoverHost := range bitcoindMode.FailoverHosts {
+		host, zmqPubRawBlock, zmqPubRawTx, err := parseBitcoindFailoverHost(
+			failoverHost,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		backend, err := newBackend(host,
+			func() (*chain.BitcoindConn, func(), error) {
+				conn, err := chain.NewBitcoindConn(
+					activeNetParams.Params, host,
+					bitcoindMode.RPCUser,
+					bitcoindMode.RPCPass, zmqPubRawBlock,
+					zmqPubRawTx, 100*time.Millisecond,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				if err := conn.Start(); err != nil {
+					return nil, nil, err
+				}
+
+				return conn, conn.Stop, nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, backend)
+	}
+
+	return newChainFailover(cfg, backends)
+}
+
+// parseBitcoindFailoverHost parses a bitcoind failover host of the form
+// rpchost,zmqpubrawblock,zmqpubrawtx. If the RPC host has no port, the
+// default bitcoind port of the selected chain parameters is used.
+func parseBitcoindFailoverHost(failoverHost string) (string, string, string,
+	error) {
+
+	parts := strings.Split(failoverHost, ",")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid failover host %q, "+
+			"expected rpchost,zmqpubrawblock,zmqpubrawtx",
+			failoverHost)
+	}
+
+	host := parts[0]
+	if !strings.Contains(host, ":") {
+		// The RPC ports specified in chainparams.go assume btcd, so
+		// we convert them to the bitcoind port.
+		rpcPort, err := strconv.Atoi(activeNetParams.rpcPort)
+		if err != nil {
+			return "", "", "", err
+		}
+		host = fmt.Sprintf("%v:%d", host, rpcPort-2)
+	}
+
+	return host, parts[1], parts[2], nil
+}
+
+// newChainFailover creates a failover across the given backends, using the
+// configured health check parameters.
+func newChainFailover(cfg *config,
+	backends []*chainfailover.Backend) (*chainfailover.Failover, error) {
+
+	ltndLog.Infof("Enabling chain backend failover across %d nodes",
+		len(backends))
+
+	return chainfailover.New(&chainfailover.Config{
+		Backends:      backends,
+		CheckInterval: cfg.ChainFailover.CheckInterval,
+		MaxTipLag:     cfg.ChainFailover.MaxTipLag,
+	})
+}
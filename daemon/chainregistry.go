@@ -19,11 +19,14 @@ import (
 	"github.com/btcsuite/btcwallet/wallet"
 	"github.com/btcsuite/btcwallet/walletdb"
 	"github.com/lightninglabs/neutrino"
+	"github.com/lightningnetwork/lnd/chainfailover"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/chainntnfs/bitcoindnotify"
 	"github.com/lightningnetwork/lnd/chainntnfs/btcdnotify"
+	"github.com/lightningnetwork/lnd/chainntnfs/electrumnotify"
 	"github.com/lightningnetwork/lnd/chainntnfs/neutrinonotify"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/electrum"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -109,8 +112,15 @@ type chainControl struct {
 
 	msgSigner lnwallet.MessageSigner
 
+	keyRing keychain.SecretKeyRing
+
 	chainNotifier chainntnfs.ChainNotifier
 
+	// txReplacements wraps the chain notifier and is used to report fee
+	// bumped replacements of our transactions, so that confirmation
+	// notifications follow whichever version confirms.
+	txReplacements *chainntnfs.TxReplacementNotifier
+
 	chainView chainview.FilteredChainView
 
 	wallet *lnwallet.LightningWallet
@@ -179,6 +189,18 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 	var (
 		err     error
 		cleanUp func()
+
+		// chainFailover is set if failover hosts are configured for a
+		// btcd or bitcoind backend, in which case it replaces the
+		// notifier, chain view, fee estimator and chain IO of the
+		// primary node. The wallet keeps syncing from the primary
+		// node, but publishes its transactions through the active
+		// one.
+		chainFailover *chainfailover.Failover
+
+		// staticFeeEstimator is used by the failover backends if
+		// live fee estimates aren't available.
+		staticFeeEstimator = cc.feeEstimator
 	)
 
 	// Initialize disabled height hint cache within the chain directory.
@@ -273,6 +295,56 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 			svc.Stop()
 			nodeDatabase.Close()
 		}
+	case "electrum":
+		// We'll connect to the Electrum server, which is then shared
+		// by the chain notifier, the chain view, the wallet and the
+		// fee estimator.
+		electrumClient, err := electrum.NewClient(&electrum.Config{
+			Server:         cfg.ElectrumMode.Server,
+			UseTLS:         cfg.ElectrumMode.TLS,
+			TLSCertPath:    cfg.ElectrumMode.TLSCertPath,
+			NetParams:      activeNetParams.Params,
+			RequestTimeout: cfg.ElectrumMode.RequestTimeout,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := electrumClient.Start(); err != nil {
+			return nil, nil, fmt.Errorf("unable to connect to "+
+				"electrum server: %v", err)
+		}
+
+		cc.chainNotifier, err = electrumnotify.New(
+			electrumClient, hintCache, hintCache,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		cc.chainView, err = chainview.NewElectrumFilteredChainView(
+			electrumClient,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		walletConfig.ChainSource = electrum.NewWalletClient(
+			electrumClient, activeNetParams.Params,
+		)
+
+		// If we're not in simnet or regtest mode, then we'll use the
+		// fee estimates of the server's full node.
+		if !cfg.Bitcoin.SimNet && !cfg.Bitcoin.RegTest {
+			ltndLog.Infof("Initializing electrum backed fee " +
+				"estimator")
+
+			fallBackFeeRate := lnwallet.SatPerKVByte(25 * 1000)
+			cc.feeEstimator = electrum.NewFeeEstimator(
+				electrumClient, fallBackFeeRate.FeePerKWeight(),
+			)
+		}
+
+		cleanUp = func() {
+			electrumClient.Stop()
+		}
 	case "bitcoind", "litecoind":
 		var bitcoindMode *bitcoindConfig
 		switch {
@@ -362,9 +434,6 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 			if err != nil {
 				return nil, nil, err
 			}
-			if err := cc.feeEstimator.Start(); err != nil {
-				return nil, nil, err
-			}
 		} else if cfg.Litecoin.Active {
 			ltndLog.Infof("Initializing litecoind backed fee estimator")
 
@@ -379,7 +448,16 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 			if err != nil {
 				return nil, nil, err
 			}
-			if err := cc.feeEstimator.Start(); err != nil {
+		}
+
+		if len(bitcoindMode.FailoverHosts) != 0 {
+			liveFees := (cfg.Bitcoin.Active && !cfg.Bitcoin.RegTest) ||
+				cfg.Litecoin.Active
+			chainFailover, err = newBitcoindFailover(
+				cfg, bitcoindMode, bitcoindHost, bitcoindConn,
+				hintCache, liveFees, staticFeeEstimator,
+			)
+			if err != nil {
 				return nil, nil, err
 			}
 		}
@@ -484,7 +562,16 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 			if err != nil {
 				return nil, nil, err
 			}
-			if err := cc.feeEstimator.Start(); err != nil {
+		}
+
+		if len(btcdMode.FailoverHosts) != 0 {
+			liveFees := !cfg.Bitcoin.SimNet && !cfg.Litecoin.SimNet &&
+				!cfg.Bitcoin.RegTest && !cfg.Litecoin.RegTest
+			chainFailover, err = newBtcdFailover(
+				cfg, btcdMode, btcdHost, rpcCert, hintCache,
+				liveFees, staticFeeEstimator,
+			)
+			if err != nil {
 				return nil, nil, err
 			}
 		}
@@ -493,15 +580,119 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 			homeChainConfig.Node)
 	}
 
+	// With failover enabled, the subsystems of the primary node are
+	// replaced by the ones of the failover, which always use the active
+	// node. The failover itself only runs the health checks, so it can be
+	// started right away.
+	if chainFailover != nil {
+		cc.chainNotifier = chainFailover.Notifier()
+		cc.chainView = chainFailover.ChainView()
+		cc.feeEstimator = chainFailover.FeeEstimator()
+		walletConfig.TxPublisher = chainFailover.PublishTransaction
+
+		if err := chainFailover.Start(); err != nil {
+			return nil, nil, err
+		}
+
+		chainCleanUp := cleanUp
+		cleanUp = func() {
+			chainFailover.Stop()
+			if chainCleanUp != nil {
+				chainCleanUp()
+			}
+		}
+	}
+
+	// The fee estimator of the backend is combined with the optional fee
+	// web API, which is queried first, and the configured bounds.
+	feeSources := []lnwallet.FeeEstimator{cc.feeEstimator}
+	if cfg.Fee.URL != "" {
+		ltndLog.Infof("Using fee web API at %v", cfg.Fee.URL)
+
+		webEstimator := lnwallet.NewWebAPIFeeEstimator(
+			cfg.Fee.URL, cfg.Fee.URLTimeout,
+		)
+		feeSources = append(
+			[]lnwallet.FeeEstimator{webEstimator}, feeSources...,
+		)
+	}
+	cc.feeEstimator, err = lnwallet.NewCompositeFeeEstimator(
+		lnwallet.CompositeFeeEstimatorConfig{
+			Sources:         feeSources,
+			MinFeePerKW:     lnwallet.SatPerKWeight(cfg.Fee.MinFeeRate),
+			MaxFeePerKW:     lnwallet.SatPerKWeight(cfg.Fee.MaxFeeRate),
+			CacheDuration:   cfg.Fee.CacheDuration,
+			SmoothingFactor: cfg.Fee.SmoothingFactor,
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cc.feeEstimator.Start(); err != nil {
+		return nil, nil, err
+	}
+
+	// Wrap the chain notifier, so that confirmation notifications of our
+	// transactions follow their fee bumped replacements.
+	cc.txReplacements = chainntnfs.NewTxReplacementNotifier(
+		cc.chainNotifier,
+	)
+	cc.chainNotifier = cc.txReplacements
+
+	// In watch-only mode, the wallet is a copy of the wallet of the
+	// remote signer that only holds the account public keys. It's fetched
+	// from the signer the first time we start.
+	watchOnly := cfg.RemoteSigner.Mode == remoteSignerModeWatchOnly
+	if watchOnly {
+		walletConfig.WatchOnly = true
+		if err := importWatchOnlyWallet(cfg, walletConfig); err != nil {
+			fmt.Printf("unable to import watch-only wallet: %v\n",
+				err)
+			return nil, nil, err
+		}
+	}
+
 	wc, err := btcwallet.New(*walletConfig)
 	if err != nil {
 		fmt.Printf("unable to create wallet controller: %v\n", err)
 		return nil, nil, err
 	}
 
-	cc.msgSigner = wc
-	cc.signer = wc
 	cc.chainIO = wc
+	if chainFailover != nil {
+		cc.chainIO = chainFailover.ChainIO()
+	}
+
+	switch {
+	// In watch-only mode, all key derivation and signing, including the
+	// inputs of the on-chain wallet, is done by the remote signer.
+	case watchOnly:
+		signerClient, err := newRemoteSignerClient(cfg, wc)
+		if err != nil {
+			fmt.Printf("unable to connect to remote signer: %v\n",
+				err)
+			return nil, nil, err
+		}
+
+		cc.msgSigner = signerClient
+		cc.signer = signerClient
+		cc.keyRing = signerClient
+
+		chainCleanUp := cleanUp
+		cleanUp = func() {
+			signerClient.Close()
+			if chainCleanUp != nil {
+				chainCleanUp()
+			}
+		}
+
+	default:
+		cc.msgSigner = wc
+		cc.signer = wc
+		cc.keyRing = keychain.NewBtcWalletKeyRing(
+			wc.InternalWallet(), activeNetParams.CoinType,
+		)
+	}
 
 	// Select the default channel constraints for the primary chain.
 	channelConstraints := defaultBtcChannelConstraints
@@ -509,10 +700,6 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 		channelConstraints = defaultLtcChannelConstraints
 	}
 
-	keyRing := keychain.NewBtcWalletKeyRing(
-		wc.InternalWallet(), activeNetParams.CoinType,
-	)
-
 	// Create, and start the lnwallet, which handles the core payment
 	// channel logic, and exposes control via proxy state machines.
 	walletCfg := lnwallet.Config{
@@ -521,10 +708,11 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 		WalletController:   wc,
 		Signer:             cc.signer,
 		FeeEstimator:       cc.feeEstimator,
-		SecretKeyRing:      keyRing,
+		SecretKeyRing:      cc.keyRing,
 		ChainIO:            cc.chainIO,
 		DefaultConstraints: channelConstraints,
 		NetParams:          *activeNetParams.Params,
+		WatchOnly:          watchOnly,
 	}
 	lnWallet, err := lnwallet.NewLightningWallet(walletCfg)
 	if err != nil {
@@ -1,13 +1,16 @@
 package daemon
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
@@ -25,6 +28,13 @@ var (
 	// ErrInvalidState is returned when the closing state machine receives
 	// a message while it is in an unknown state.
 	ErrInvalidState = fmt.Errorf("invalid state")
+
+	// ErrUpfrontShutdownScriptMismatch is returned when the remote party
+	// attempts to cooperatively close a channel to a script other than
+	// the upfront shutdown script they committed to during the funding
+	// workflow.
+	ErrUpfrontShutdownScriptMismatch = fmt.Errorf("shutdown script does " +
+		"not match upfront shutdown script")
 )
 
 // closeState represents all the possible states the channel closer state
@@ -82,6 +92,10 @@ type chanCloseCfg struct {
 	// forward payments.
 	disableChannel func(wire.OutPoint) error
 
+	// notifyClosingChannel is an optional function closure that will be
+	// called once the closing transaction has been broadcast.
+	notifyClosingChannel func(wire.OutPoint, channeldb.ClosureType)
+
 	// quit is a channel that should be sent upon in the occasion the state
 	// machine should cease all progress and shutdown.
 	quit chan struct{}
@@ -116,6 +130,12 @@ type channelCloser struct {
 	// offer when starting negotiation. This will be used as a baseline.
 	idealFeeSat btcutil.Amount
 
+	// maxFeeSat is the highest fee that we're willing to propose, or
+	// accept, for the closing transaction. A value of zero signals that no
+	// cap applies. This will only be set if we're the initiator of this
+	// closing negotiation, and the caller specified a fee cap.
+	maxFeeSat btcutil.Amount
+
 	// lastFeeProposal is the last fee that we proposed to the remote
 	// party. We'll use this as a pivot point to rachet our next offer up,
 	// or down, or simply accept the remote party's prior offer.
@@ -173,6 +193,16 @@ func newChannelCloser(cfg chanCloseCfg, deliveryScript []byte,
 		idealFeeSat = channelCommitFee
 	}
 
+	// If the caller capped the fee rate they're willing to pay, then we'll
+	// ensure that our ideal fee doesn't exceed it either.
+	var maxFeeSat btcutil.Amount
+	if closeReq != nil && closeReq.MaxFeePerKw != 0 {
+		maxFeeSat = cfg.channel.CalcFee(closeReq.MaxFeePerKw)
+		if idealFeeSat > maxFeeSat {
+			idealFeeSat = maxFeeSat
+		}
+	}
+
 	peerLog.Infof("Ideal fee for closure of ChannelPoint(%v) is: %v sat",
 		cfg.channel.ChannelPoint(), int64(idealFeeSat))
 
@@ -185,6 +215,7 @@ func newChannelCloser(cfg chanCloseCfg, deliveryScript []byte,
 		cfg:                 cfg,
 		negotiationHeight:   negotiationHeight,
 		idealFeeSat:         idealFeeSat,
+		maxFeeSat:           maxFeeSat,
 		localDeliveryScript: deliveryScript,
 		priorFeeOffers:      make(map[btcutil.Amount]*lnwire.ClosingSigned),
 	}
@@ -239,6 +270,26 @@ func (c *channelCloser) ShutdownChan() (*lnwire.Shutdown, error) {
 	return shutdownMsg, nil
 }
 
+// validateRemoteScript ensures that the delivery script sent by the remote
+// party within their shutdown message matches the upfront shutdown script they
+// committed to during the funding workflow, if any.
+func (c *channelCloser) validateRemoteScript(script lnwire.DeliveryAddress) error {
+	upfrontScript := c.cfg.channel.State().RemoteShutdownScript
+	if len(upfrontScript) == 0 {
+		return nil
+	}
+
+	if !bytes.Equal(upfrontScript, script) {
+		peerLog.Warnf("ChannelPoint(%v): remote party attempted to "+
+			"close to %x, but committed to upfront shutdown "+
+			"script %x", c.chanPoint, script, upfrontScript)
+
+		return ErrUpfrontShutdownScriptMismatch
+	}
+
+	return nil
+}
+
 // ClosingTx returns the fully signed, final closing transaction.
 //
 // NOTE: This transaction is only available if the state machine is in the
@@ -282,6 +333,13 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 				"instead have %v", spew.Sdump(msg))
 		}
 
+		// If the other party committed to an upfront shutdown script,
+		// then we'll ensure that they aren't attempting to pay out to
+		// any other script.
+		if err := c.validateRemoteScript(shutDownMsg.Address); err != nil {
+			return nil, false, err
+		}
+
 		// Next, we'll note the other party's preference for their
 		// delivery address. We'll use this when we craft the closure
 		// transaction.
@@ -332,6 +390,13 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 				"instead have %v", spew.Sdump(msg))
 		}
 
+		// If the other party committed to an upfront shutdown script,
+		// then we'll ensure that they aren't attempting to pay out to
+		// any other script.
+		if err := c.validateRemoteScript(shutDownMsg.Address); err != nil {
+			return nil, false, err
+		}
+
 		// Now that we know this is a valid shutdown message, we'll
 		// record their preferred delivery closing script.
 		c.remoteDeliveryScript = shutDownMsg.Address
@@ -385,6 +450,31 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 				remoteProposedFee,
 			)
 
+			// If a fee cap applies, then we'll never propose, nor
+			// accept, a fee above it.
+			aboveMaxFee := c.maxFeeSat != 0 &&
+				remoteProposedFee > c.maxFeeSat
+			if c.maxFeeSat != 0 && feeProposal > c.maxFeeSat {
+				feeProposal = c.maxFeeSat
+			}
+
+			// If we've already offered our maximum fee, and the
+			// remote party still insists on a higher one, then
+			// there's nothing left for us to offer. We'll wait
+			// for the remote party to come down, leaving it up to
+			// the caller whether to escalate to a force close.
+			if aboveMaxFee && feeProposal == c.lastFeeProposal {
+				peerLog.Warnf("ChannelPoint(%v): remote fee "+
+					"offer of %v exceeds max fee of %v, "+
+					"refusing to sign", c.chanPoint,
+					int64(remoteProposedFee),
+					int64(c.maxFeeSat))
+
+				c.notifyFeeOffer(remoteProposedFee, aboveMaxFee)
+
+				return nil, false, nil
+			}
+
 			// With our new fee proposal calculated, we'll craft a
 			// new close signed signature to send to the other
 			// party so we can continue the fee negotiation
@@ -401,6 +491,9 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 				peerLog.Debugf("ChannelPoint(%v): close tx "+
 					"fee disagreement, continuing negotiation",
 					c.chanPoint)
+
+				c.notifyFeeOffer(remoteProposedFee, aboveMaxFee)
+
 				return []lnwire.Message{closeSigned}, false, nil
 			}
 		}
@@ -440,6 +533,12 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 			return nil, false, err
 		}
 
+		if c.cfg.notifyClosingChannel != nil {
+			c.cfg.notifyClosingChannel(
+				c.chanPoint, channeldb.CooperativeClose,
+			)
+		}
+
 		// We'll attempt to disable the channel in the background to
 		// avoid blocking due to sending the update message to all
 		// active peers.
@@ -479,6 +578,37 @@ func (c *channelCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message, b
 	}
 }
 
+// notifyFeeOffer sends the last fee offer of the remote party to the caller
+// that requested the closure, if any, so they can decide whether to wait for
+// the negotiation to proceed, or escalate to a force close.
+func (c *channelCloser) notifyFeeOffer(remoteFee btcutil.Amount,
+	aboveMaxFee bool) {
+
+	if c.closeReq == nil {
+		return
+	}
+
+	update := &lnrpc.CloseStatusUpdate{
+		Update: &lnrpc.CloseStatusUpdate_FeeOffer{
+			FeeOffer: &lnrpc.ClosingFeeOffer{
+				RemoteFeeSat: int64(remoteFee),
+				LocalFeeSat:  int64(c.lastFeeProposal),
+				MaxFeeSat:    int64(c.maxFeeSat),
+				AboveMaxFee:  aboveMaxFee,
+			},
+		},
+	}
+
+	// As fee offers are only informational, we won't block the
+	// negotiation if the caller isn't keeping up with them.
+	select {
+	case c.closeReq.Updates <- update:
+	default:
+		peerLog.Debugf("ChannelPoint(%v): dropping fee offer update, "+
+			"caller not ready", c.chanPoint)
+	}
+}
+
 // proposeCloseSigned attempts to propose a new signature for the closing
 // transaction for a channel based on the prior fee negotiations and our
 // current compromise fee.
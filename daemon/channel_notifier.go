@@ -0,0 +1,213 @@
+package daemon
+
+import (
+	"sync"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chanbackup"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channelnotifier"
+)
+
+// channelNotifier is the daemon's implementation of the
+// chanbackup.ChannelNotifier interface. It translates the events of the
+// channelnotifier.ChannelNotifier into the channel open and close events the
+// on-disk channel backup needs to be kept up to date.
+type channelNotifier struct {
+	chanNotifier *channelnotifier.ChannelNotifier
+
+	chanDB *channeldb.DB
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile-time check to ensure channelNotifier implements the
+// chanbackup.ChannelNotifier interface.
+var _ chanbackup.ChannelNotifier = (*channelNotifier)(nil)
+
+// newChannelNotifier creates a new channelNotifier that sources its events
+// from the passed channel notifier, backed by the passed channel database.
+func newChannelNotifier(chanNotifier *channelnotifier.ChannelNotifier,
+	chanDB *channeldb.DB) *channelNotifier {
+
+	return &channelNotifier{
+		chanNotifier: chanNotifier,
+		chanDB:       chanDB,
+		quit:         make(chan struct{}),
+	}
+}
+
+// Stop signals all client goroutines of the channelNotifier to exit, and
+// waits for them to do so.
+func (c *channelNotifier) Stop() {
+	close(c.quit)
+	c.wg.Wait()
+}
+
+// SubscribeChans returns a new subscription to all channel open and close
+// events. The passed set of known channels is compared against the current
+// state of the database, and an initial event is queued to notify the
+// caller of any channels that were opened or closed in the meantime.
+//
+// NOTE: This is part of the chanbackup.ChannelNotifier interface.
+func (c *channelNotifier) SubscribeChans(
+	knownChans map[wire.OutPoint]struct{}) (*chanbackup.ChannelSubscription,
+	error) {
+
+	// We'll subscribe to the channel notifier before computing the initial
+	// event, so no channel can be opened or closed without us noticing.
+	// At worst, a channel is reported twice, which the backup tolerates.
+	chanEvents, err := c.chanNotifier.SubscribeChannelEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	openChans, err := c.chanDB.FetchAllChannels()
+	if err != nil {
+		chanEvents.Cancel()
+		return nil, err
+	}
+
+	// Any channel we find within the database that the caller doesn't yet
+	// know of has been opened since, and any known channel that is no
+	// longer within the database has since been closed.
+	var initialEvent chanbackup.ChannelEvent
+	currentChans := make(map[wire.OutPoint]struct{}, len(openChans))
+	for _, openChan := range openChans {
+		chanPoint := openChan.FundingOutpoint
+		currentChans[chanPoint] = struct{}{}
+
+		if _, ok := knownChans[chanPoint]; ok {
+			continue
+		}
+
+		newChan, err := c.chanWithAddrs(openChan)
+		if err != nil {
+			chanEvents.Cancel()
+			return nil, err
+		}
+
+		initialEvent.NewChans = append(initialEvent.NewChans, *newChan)
+	}
+	for chanPoint := range knownChans {
+		if _, ok := currentChans[chanPoint]; ok {
+			continue
+		}
+
+		initialEvent.ClosedChans = append(
+			initialEvent.ClosedChans, chanPoint,
+		)
+	}
+
+	chanUpdates := make(chan chanbackup.ChannelEvent, 1)
+	if len(initialEvent.NewChans) != 0 || len(initialEvent.ClosedChans) != 0 {
+		chanUpdates <- initialEvent
+	}
+
+	// With the initial event queued, we'll launch a goroutine that
+	// translates all channel events that concern the backup into the
+	// format the caller expects.
+	cancelChan := make(chan struct{})
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer chanEvents.Cancel()
+
+		for {
+			var event chanbackup.ChannelEvent
+
+			select {
+			case e := <-chanEvents.Updates:
+				switch e := e.(type) {
+				case channelnotifier.OpenChannelEvent:
+					newChan, err := c.chanWithAddrs(e.Channel)
+					if err != nil {
+						srvrLog.Errorf("Unable to fetch "+
+							"addrs for "+
+							"ChannelPoint(%v): %v",
+							e.Channel.FundingOutpoint,
+							err)
+						continue
+					}
+
+					event.NewChans = append(
+						event.NewChans, *newChan,
+					)
+
+				case channelnotifier.ClosingChannelEvent:
+					event.ClosedChans = append(
+						event.ClosedChans,
+						*e.ChannelPoint,
+					)
+
+				// The backup of the prior channel point of a
+				// spliced channel is no longer of use, so
+				// we'll replace it with a backup of the
+				// channel at its new channel point.
+				case channelnotifier.SplicedChannelEvent:
+					newChan, err := c.chanWithAddrs(e.Channel)
+					if err != nil {
+						srvrLog.Errorf("Unable to fetch "+
+							"addrs for "+
+							"ChannelPoint(%v): %v",
+							e.Channel.FundingOutpoint,
+							err)
+						continue
+					}
+
+					event.ClosedChans = append(
+						event.ClosedChans,
+						*e.OldChannelPoint,
+					)
+					event.NewChans = append(
+						event.NewChans, *newChan,
+					)
+
+				default:
+					continue
+				}
+
+			case <-cancelChan:
+				return
+
+			case <-c.quit:
+				return
+			}
+
+			select {
+			case chanUpdates <- event:
+			case <-cancelChan:
+				return
+			case <-c.quit:
+				return
+			}
+		}
+	}()
+
+	var cancelOnce sync.Once
+	return &chanbackup.ChannelSubscription{
+		ChanUpdates: chanUpdates,
+		Cancel: func() {
+			cancelOnce.Do(func() {
+				close(cancelChan)
+			})
+		},
+	}, nil
+}
+
+// chanWithAddrs pairs the passed channel with the known addresses of its
+// remote peer.
+func (c *channelNotifier) chanWithAddrs(
+	openChan *channeldb.OpenChannel) (*chanbackup.ChannelWithAddrs, error) {
+
+	addrs, err := c.chanDB.AddrsForNode(openChan.IdentityPub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chanbackup.ChannelWithAddrs{
+		OpenChannel: openChan,
+		Addrs:       addrs,
+	}, nil
+}
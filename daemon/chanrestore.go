@@ -0,0 +1,207 @@
+package daemon
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/chanbackup"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/contractcourt"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/shachain"
+)
+
+// chanDBRestorer is an implementation of the chanbackup.ChannelRestorer
+// interface that is able to properly map a Single backup, into a
+// channeldb.ChannelShell which is required to fully restore a channel. We also
+// need the secret key chain in order obtain the prior shachain root so we can
+// verify the DLP protocol as initiated by the remote node.
+type chanDBRestorer struct {
+	db *channeldb.DB
+
+	secretKeys keychain.SecretKeyRing
+
+	chainArb *contractcourt.ChainArbitrator
+}
+
+// A compile-time check to ensure chanDBRestorer implements the
+// chanbackup.ChannelRestorer interface.
+var _ chanbackup.ChannelRestorer = (*chanDBRestorer)(nil)
+
+// openChannelShell maps the static channel back up into an open channel
+// "shell". We say shell as this doesn't include all the information required
+// to continue to use the channel, only the minimal amount of information to
+// insert this shell channel back into the database.
+func (c *chanDBRestorer) openChannelShell(backup chanbackup.Single) (
+	*channeldb.ChannelShell, error) {
+
+	// First, we'll also need to obtain the private key for the shachain
+	// root from the encoded public key. If a remote signer keeps our
+	// revocation secrets, the restored channel doesn't need a producer,
+	// as the signer derives the secrets from the multi-sig key.
+	var (
+		shaChainProducer shachain.Producer
+		err              error
+	)
+	if _, ok := c.secretKeys.(lnwallet.RevocationSigner); !ok {
+		privKey, err := c.secretKeys.DerivePrivKey(
+			backup.ShaChainRootDesc,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive shachain "+
+				"root key: %v", err)
+		}
+		revRoot, err := chainhash.NewHash(privKey.Serialize())
+		if err != nil {
+			return nil, err
+		}
+		shaChainProducer = shachain.NewRevocationProducer(*revRoot)
+	}
+
+	// As only the key locators of our local keys are stored within the
+	// backup, we'll re-derive the public keys for each of them.
+	localChanCfg := backup.LocalChanCfg
+	localKeys := []*keychain.KeyDescriptor{
+		&localChanCfg.MultiSigKey,
+		&localChanCfg.RevocationBasePoint,
+		&localChanCfg.PaymentBasePoint,
+		&localChanCfg.DelayBasePoint,
+		&localChanCfg.HtlcBasePoint,
+	}
+	for _, keyDesc := range localKeys {
+		*keyDesc, err = c.secretKeys.DeriveKey(keyDesc.KeyLocator)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive local "+
+				"key: %v", err)
+		}
+	}
+
+	// With all the information collected, we'll now assemble the channel
+	// shell. We use the node's public key as a placeholder for the
+	// remote revocation point, as the real one isn't known until the
+	// remote party sends it to us during channel sync.
+	chanShell := channeldb.ChannelShell{
+		NodeAddrs: backup.Addresses,
+		Chan: &channeldb.OpenChannel{
+			ChanType:                backup.ChanType,
+			ChainHash:               backup.ChainHash,
+			IsInitiator:             backup.IsInitiator,
+			Capacity:                backup.Capacity,
+			FundingOutpoint:         backup.FundingOutpoint,
+			ShortChannelID:          backup.ShortChannelID,
+			IdentityPub:             backup.RemoteNodePub,
+			IsPending:               false,
+			LocalChanCfg:            localChanCfg,
+			RemoteChanCfg:           backup.RemoteChanCfg,
+			RemoteCurrentRevocation: backup.RemoteNodePub,
+			RevocationStore:         shachain.NewRevocationStore(),
+			RevocationProducer:      shaChainProducer,
+		},
+	}
+
+	return &chanShell, nil
+}
+
+// RestoreChansFromSingles attempts to map the set of single channel backups
+// to channel shells that will be stored persistently. Once these shells have
+// been stored on disk, we'll be able to connect to the channel peer and
+// execute the data loss recovery protocol.
+//
+// NOTE: Part of the chanbackup.ChannelRestorer interface.
+func (c *chanDBRestorer) RestoreChansFromSingles(backups ...chanbackup.Single) error {
+	channelShells := make([]*channeldb.ChannelShell, 0, len(backups))
+	for _, backup := range backups {
+		chanShell, err := c.openChannelShell(backup)
+		if err != nil {
+			return err
+		}
+
+		channelShells = append(channelShells, chanShell)
+	}
+
+	ltndLog.Infof("Inserting %v SCB channel shells into DB",
+		len(channelShells))
+
+	// Now that we have all the backups mapped into a series of Singles,
+	// we'll insert them all into the database.
+	if err := c.db.RestoreChannelShells(channelShells...); err != nil {
+		return err
+	}
+
+	ltndLog.Infof("Informing chain watchers of new restored channels")
+
+	// Finally, we'll need to inform the chain arbitrator of these new
+	// channels so we'll properly watch for their ultimate closure on chain
+	// and sweep them via the DLP.
+	for _, restoredChannel := range channelShells {
+		chanPoint := restoredChannel.Chan.FundingOutpoint
+		dbChan, err := c.db.FetchChannel(chanPoint)
+		if err != nil {
+			return err
+		}
+
+		// If this channel already existed before the restore, then
+		// it's already being watched.
+		if !dbChan.HasChanStatus(channeldb.Restored) {
+			continue
+		}
+
+		if err := c.chainArb.WatchNewChannel(dbChan); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ConnectPeer attempts to connect to the target node at the set of available
+// addresses. Once this method returns with a nil error, the server will
+// continue to persistently connect to the target peer in the background.
+//
+// NOTE: This is part of the chanbackup.PeerConnector interface.
+func (s *server) ConnectPeer(nodePub *btcec.PublicKey, addrs []net.Addr) error {
+	// If we're already connected to this peer, then we'll disconnect
+	// first, as the peer only loads the restored channels when the
+	// connection is established.
+	if _, err := s.FindPeer(nodePub); err == nil {
+		if err := s.DisconnectPeer(nodePub); err != nil {
+			srvrLog.Warnf("Unable to disconnect from peer %x for "+
+				"SCB restore: %v", nodePub.SerializeCompressed(),
+				err)
+		}
+	}
+
+	// For each of the known addresses, we'll attempt to launch a
+	// persistent connection to the (pub, addr) pair. Once any of them
+	// connect, all the other requests will be canceled.
+	var connected bool
+	for _, addr := range addrs {
+		netAddr := &lnwire.NetAddress{
+			IdentityKey: nodePub,
+			Address:     addr,
+			ChainNet:    activeNetParams.Net,
+		}
+
+		srvrLog.Infof("Attempting to connect to %v for SCB restore",
+			netAddr)
+
+		if err := s.ConnectToPeer(netAddr, true); err != nil {
+			srvrLog.Errorf("Unable to connect to %v to complete "+
+				"SCB restore: %v", netAddr, err)
+			continue
+		}
+
+		connected = true
+	}
+
+	if !connected {
+		return fmt.Errorf("unable to connect to peer %x for SCB "+
+			"restore", nodePub.SerializeCompressed())
+	}
+
+	return nil
+}
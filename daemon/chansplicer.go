@@ -0,0 +1,375 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// ErrChanAlreadySplicing is returned when a splice is attempted for a
+	// channel that already has an active splice negotiation.
+	ErrChanAlreadySplicing = fmt.Errorf("channel splice already initiated")
+
+	// ErrSpliceNotSupported is returned when a splice is attempted with a
+	// peer that doesn't support the splice protocol.
+	ErrSpliceNotSupported = fmt.Errorf("splicing not supported by peer")
+)
+
+// spliceState represents all the possible states the channel splicer state
+// machine can be in. Each message will advance to the next state, until the
+// state machine reaches the spliceFinished state.
+type spliceState uint8
+
+const (
+	// spliceIdle is the initial starting state. If a state machine
+	// receives a message while in this state, then it is the responder
+	// to a splice initiated by the remote party.
+	spliceIdle spliceState = iota
+
+	// spliceInitSent is the state the initiator of a splice enters once
+	// it has sent the SpliceInit message. At this point, it waits for the
+	// responder's signature for its new commitment.
+	spliceInitSent
+
+	// spliceAckSent is the state the responder of a splice enters once it
+	// has sent its signature for the initiator's new commitment. At this
+	// point, it waits for the initiator's signatures for its own new
+	// commitment, and for the splice transaction.
+	spliceAckSent
+
+	// spliceCreatedSent is the state the initiator of a splice enters once
+	// it has sent its signatures for the responder's new commitment, and
+	// for the splice transaction. At this point, it waits for the
+	// responder's signature for the splice transaction.
+	spliceCreatedSent
+
+	// spliceFinished is the final state of the state machine. In this
+	// state, the fully signed splice transaction has been persisted and
+	// broadcast, and both parties wait for it to confirm.
+	spliceFinished
+)
+
+// spliceOutReq is a local request to splice funds out of an active channel.
+type spliceOutReq struct {
+	// chanPoint is the channel point of the channel to splice.
+	chanPoint wire.OutPoint
+
+	// amount is the amount that should be spliced out of the channel.
+	amount btcutil.Amount
+
+	// payoutScript is the script the spliced out funds are paid to.
+	payoutScript []byte
+
+	// feePerKw is the fee rate the splice transaction should pay.
+	feePerKw lnwallet.SatPerKWeight
+
+	// resp receives the txid of the splice transaction once it has been
+	// broadcast.
+	resp chan *chainhash.Hash
+
+	// err receives any error encountered while carrying out the splice.
+	err chan error
+}
+
+// chanSpliceCfg holds all the items that a channelSplicer requires to carry
+// out its duties.
+type chanSpliceCfg struct {
+	// channel is the channel that should be spliced.
+	channel *lnwallet.LightningChannel
+
+	// unregisterChannel is a function closure that allows the
+	// channelSplicer to remove the link of the channel from the switch,
+	// such that no further updates are made to the channel while the
+	// splice is carried out.
+	unregisterChannel func(lnwire.ChannelID)
+
+	// broadcastTx broadcasts the passed transaction to the network.
+	broadcastTx func(*wire.MsgTx) error
+
+	// broadcastHeight is the height at which the splice negotiation
+	// began, which is used as a height hint for its confirmation.
+	broadcastHeight uint32
+}
+
+// channelSplicer is a state machine that handles the splicing of funds out of
+// an active channel. Only the channel initiator can splice out funds. The
+// workflow is as follows:
+//
+//  1. The initiator sends SpliceInit, with the amount and payout script.
+//  2. The responder replies with SpliceAck, carrying its signature for the
+//     initiator's new commitment spending the output of the splice.
+//  3. The initiator persists the pending splice, and replies with
+//     SpliceCreated, carrying its signature for the responder's new
+//     commitment, and for the splice transaction.
+//  4. The responder persists and broadcasts the splice transaction, and
+//     replies with SpliceSigned, carrying its signature for the splice
+//     transaction, after which the initiator does the same.
+//
+// This ordering ensures that neither party signs the splice transaction
+// before it holds a valid commitment spending the new funding output.
+type channelSplicer struct {
+	// state is the current state of the state machine.
+	state spliceState
+
+	// cfg holds the configuration for this channelSplicer instance.
+	cfg chanSpliceCfg
+
+	// cid is the full channel ID of the target channel.
+	cid lnwire.ChannelID
+
+	// splice is the splice being negotiated. This is only populated once
+	// the splice parameters are known.
+	splice *lnwallet.SpliceOut
+
+	// spliceTx is the final, fully signed splice transaction. This will
+	// only be populated once the state machine shifts to the
+	// spliceFinished state.
+	spliceTx *wire.MsgTx
+
+	// spliceReq is the initial splice request. This will only be
+	// populated if we're the initiator of this splice.
+	spliceReq *spliceOutReq
+}
+
+// newChannelSplicer creates a new instance of the channel splicer given the
+// passed configuration. The final argument should only be populated iff,
+// we're the initiator of the splice.
+func newChannelSplicer(cfg chanSpliceCfg,
+	spliceReq *spliceOutReq) *channelSplicer {
+
+	return &channelSplicer{
+		state:     spliceIdle,
+		cfg:       cfg,
+		cid:       lnwire.NewChanIDFromOutPoint(cfg.channel.ChannelPoint()),
+		spliceReq: spliceReq,
+	}
+}
+
+// SpliceRequest returns the original splice request that prompted the
+// creation of the state machine. If we're the responder, nil is returned.
+func (s *channelSplicer) SpliceRequest() *spliceOutReq {
+	return s.spliceReq
+}
+
+// SpliceTx returns the fully signed splice transaction. If the splice
+// negotiation hasn't finished yet, an error is returned.
+func (s *channelSplicer) SpliceTx() (*wire.MsgTx, error) {
+	if s.state != spliceFinished {
+		return nil, fmt.Errorf("splice negotiation not finished")
+	}
+
+	return s.spliceTx, nil
+}
+
+// InitSplice begins a splice as the initiator. The link of the channel is
+// removed from the switch, and the SpliceInit message to be sent to the
+// remote party is returned.
+func (s *channelSplicer) InitSplice() (*lnwire.SpliceInit, error) {
+	if s.state != spliceIdle || s.spliceReq == nil {
+		return nil, ErrInvalidState
+	}
+
+	if !s.cfg.channel.IsInitiator() {
+		return nil, fmt.Errorf("only the channel initiator can " +
+			"splice out funds")
+	}
+
+	// Before we create the splice, we'll remove the link from the switch
+	// to ensure no further updates are made to the channel.
+	s.cfg.unregisterChannel(s.cid)
+
+	req := s.spliceReq
+	splice, err := s.cfg.channel.NewSpliceOut(
+		req.amount, req.payoutScript, req.feePerKw,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.splice = splice
+
+	s.state = spliceInitSent
+
+	return lnwire.NewSpliceInit(
+		s.cid, req.amount, uint32(req.feePerKw), req.payoutScript,
+	), nil
+}
+
+// ProcessSpliceMsg is the main entry point for the state machine. Each
+// message received from the remote party advances the state machine. The
+// messages to be sent to the remote party in response are returned, along
+// with a bool indicating whether the splice negotiation has finished.
+func (s *channelSplicer) ProcessSpliceMsg(msg lnwire.Message) (
+	[]lnwire.Message, bool, error) {
+
+	switch s.state {
+
+	// If we're idle, then the remote party is initiating a splice of the
+	// channel. We'll create the splice using their parameters, and reply
+	// with our signature for their new commitment.
+	case spliceIdle:
+		initMsg, ok := msg.(*lnwire.SpliceInit)
+		if !ok {
+			return nil, false, fmt.Errorf("expected "+
+				"lnwire.SpliceInit, instead have %v",
+				msg.MsgType())
+		}
+
+		if s.cfg.channel.IsInitiator() {
+			return nil, false, fmt.Errorf("only the channel " +
+				"initiator can splice out funds")
+		}
+
+		s.cfg.unregisterChannel(s.cid)
+
+		splice, err := s.cfg.channel.NewSpliceOut(
+			initMsg.PayoutAmount, initMsg.PayoutScript,
+			lnwallet.SatPerKWeight(initMsg.FeePerKiloWeight),
+		)
+		if err != nil {
+			return nil, false, err
+		}
+		s.splice = splice
+
+		commitSig, err := splice.SignRemoteCommit()
+		if err != nil {
+			return nil, false, err
+		}
+
+		peerLog.Infof("Accepted splice of %v out of ChannelPoint(%v), "+
+			"splice txid=%v", initMsg.PayoutAmount,
+			s.cfg.channel.ChannelPoint(), splice.SpliceTx().TxHash())
+
+		s.state = spliceAckSent
+
+		return []lnwire.Message{
+			lnwire.NewSpliceAck(s.cid, commitSig),
+		}, false, nil
+
+	// As the initiator, we're waiting for the remote party's signature
+	// for our new commitment. Once we have it, we'll persist the pending
+	// splice before handing out our signature for the splice
+	// transaction.
+	case spliceInitSent:
+		ackMsg, ok := msg.(*lnwire.SpliceAck)
+		if !ok {
+			return nil, false, fmt.Errorf("expected "+
+				"lnwire.SpliceAck, instead have %v",
+				msg.MsgType())
+		}
+
+		if err := s.splice.ReceiveCommitSig(ackMsg.CommitSig); err != nil {
+			return nil, false, err
+		}
+		commitSig, err := s.splice.SignRemoteCommit()
+		if err != nil {
+			return nil, false, err
+		}
+		spliceSig, err := s.splice.SignSpliceTx()
+		if err != nil {
+			return nil, false, err
+		}
+
+		err = s.cfg.channel.State().MarkSplicePending(
+			s.splice.PendingSplice(s.cfg.broadcastHeight),
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		s.state = spliceCreatedSent
+
+		return []lnwire.Message{
+			lnwire.NewSpliceCreated(s.cid, commitSig, spliceSig),
+		}, false, nil
+
+	// As the responder, we're waiting for the initiator's signatures for
+	// our new commitment and the splice transaction. With these, we'll
+	// be able to complete, persist and broadcast the splice transaction.
+	case spliceAckSent:
+		createdMsg, ok := msg.(*lnwire.SpliceCreated)
+		if !ok {
+			return nil, false, fmt.Errorf("expected "+
+				"lnwire.SpliceCreated, instead have %v",
+				msg.MsgType())
+		}
+
+		err := s.splice.ReceiveCommitSig(createdMsg.CommitSig)
+		if err != nil {
+			return nil, false, err
+		}
+		spliceSig, err := s.splice.SignSpliceTx()
+		if err != nil {
+			return nil, false, err
+		}
+		if err := s.completeSplice(createdMsg.SpliceSig); err != nil {
+			return nil, false, err
+		}
+
+		return []lnwire.Message{
+			lnwire.NewSpliceSigned(s.cid, spliceSig),
+		}, true, nil
+
+	// As the initiator, we're waiting for the responder's signature for
+	// the splice transaction, after which we'll complete, persist and
+	// broadcast the splice transaction.
+	case spliceCreatedSent:
+		signedMsg, ok := msg.(*lnwire.SpliceSigned)
+		if !ok {
+			return nil, false, fmt.Errorf("expected "+
+				"lnwire.SpliceSigned, instead have %v",
+				msg.MsgType())
+		}
+
+		if err := s.completeSplice(signedMsg.SpliceSig); err != nil {
+			return nil, false, err
+		}
+
+		return nil, true, nil
+
+	// If we receive a message while in the spliceFinished state, then we
+	// should ignore it.
+	case spliceFinished:
+		return nil, true, nil
+
+	default:
+		return nil, false, ErrInvalidState
+	}
+}
+
+// completeSplice assembles the fully signed splice transaction using the
+// passed signature of the remote party, then persists and broadcasts it.
+func (s *channelSplicer) completeSplice(remoteSig lnwire.Sig) error {
+	spliceTx, err := s.splice.CompleteSpliceTx(remoteSig)
+	if err != nil {
+		return err
+	}
+
+	err = s.cfg.channel.State().MarkSplicePending(
+		s.splice.PendingSplice(s.cfg.broadcastHeight),
+	)
+	if err != nil {
+		return err
+	}
+
+	peerLog.Infof("Broadcasting splice tx for ChannelPoint(%v): %v",
+		s.cfg.channel.ChannelPoint(), newLogClosure(func() string {
+			return spew.Sdump(spliceTx)
+		}))
+
+	if err := s.cfg.broadcastTx(spliceTx); err != nil &&
+		err != lnwallet.ErrDoubleSpend {
+
+		return err
+	}
+
+	s.spliceTx = spliceTx
+	s.state = spliceFinished
+
+	return nil
+}
@@ -21,8 +21,12 @@ import (
 
 	"github.com/btcsuite/btcutil"
 	flags "github.com/jessevdk/go-flags"
+	"github.com/lightningnetwork/lnd/chainfailover"
+	"github.com/lightningnetwork/lnd/chanbackup"
+	"github.com/lightningnetwork/lnd/electrum"
 	"github.com/lightningnetwork/lnd/htlcswitch/hodl"
 	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/tor"
@@ -38,6 +42,7 @@ const (
 	defaultAdminMacFilename    = "admin.macaroon"
 	defaultReadMacFilename     = "readonly.macaroon"
 	defaultInvoiceMacFilename  = "invoice.macaroon"
+	defaultSignerMacFilename   = "signer.macaroon"
 	defaultLogLevel            = "info"
 	defaultLogDirname          = "logs"
 	defaultLogFilename         = "lnd.log"
@@ -51,6 +56,7 @@ const (
 	defaultInactiveChanTimeout = 20 * time.Minute
 	defaultMaxLogFiles         = 3
 	defaultMaxLogFileSize      = 10
+	defaultFeeCacheDuration    = time.Minute
 
 	defaultTorSOCKSPort            = 9050
 	defaultTorDNSHost              = "soa.nodes.lightning.directory"
@@ -61,6 +67,11 @@ const (
 
 	defaultBroadcastDelta = 10
 
+	// minRemoteMaxHtlcs is the smallest maximum number of HTLCs we may
+	// allow our channel counterparty to offer us, as it will otherwise
+	// reject the channel.
+	minRemoteMaxHtlcs = 5
+
 	// minTimeLockDelta is the minimum timelock we require for incoming
 	// HTLCs on our channels.
 	minTimeLockDelta = 4
@@ -96,7 +107,7 @@ type chainConfig struct {
 	Active   bool   `long:"active" description:"If the chain should be active or not."`
 	ChainDir string `long:"chaindir" description:"The directory to store the chain's data within."`
 
-	Node string `long:"node" description:"The blockchain interface to use." choice:"btcd" choice:"bitcoind" choice:"neutrino" choice:"ltcd" choice:"litecoind"`
+	Node string `long:"node" description:"The blockchain interface to use." choice:"btcd" choice:"bitcoind" choice:"neutrino" choice:"electrum" choice:"ltcd" choice:"litecoind"`
 
 	MainNet  bool `long:"mainnet" description:"Use the main network"`
 	TestNet3 bool `long:"testnet" description:"Use the test network"`
@@ -109,6 +120,10 @@ type chainConfig struct {
 	BaseFee             lnwire.MilliSatoshi `long:"basefee" description:"The base fee in millisatoshi we will charge for forwarding payments on our channels"`
 	FeeRate             lnwire.MilliSatoshi `long:"feerate" description:"The fee rate used when forwarding payments on our channels. The total fee charged is basefee + (amount * feerate / 1000000), where amount is the forwarded amount."`
 	TimeLockDelta       uint32              `long:"timelockdelta" description:"The CLTV delta we will subtract from a forwarded HTLC's timelock value"`
+
+	DefaultRemoteChanReserve   btcutil.Amount      `long:"defaultremotechanreserve" description:"The default channel reserve in satoshis we will require our channel counterparty to maintain. If this is not set, we will require 1% of the channel size. The reserve is never set below the counterparty's dust limit"`
+	DefaultRemoteMaxPendingAmt lnwire.MilliSatoshi `long:"defaultremotemaxpendingamt" description:"The default maximum value in millisatoshi we will allow our channel counterparty to have in outstanding HTLCs towards us. If this is not set, we will allow the full channel size minus 1%"`
+	DefaultRemoteMaxHtlcs      uint16              `long:"defaultremotemaxhtlcs" description:"The default maximum number of concurrent HTLCs we will allow our channel counterparty to offer us. If this is not set, we will allow the protocol maximum of 483"`
 }
 
 type neutrinoConfig struct {
@@ -119,6 +134,13 @@ type neutrinoConfig struct {
 	BanThreshold uint32        `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
 }
 
+type electrumConfig struct {
+	Server         string        `long:"server" description:"The host:port of the Electrum server to connect to"`
+	TLS            bool          `long:"tls" description:"Connect to the Electrum server over TLS"`
+	TLSCertPath    string        `long:"tlscertpath" description:"Path to the Electrum server's TLS certificate. If set, only this certificate is accepted, otherwise the certificate is verified against the system's root CAs"`
+	RequestTimeout time.Duration `long:"requesttimeout" description:"How long to wait for a response from the Electrum server. Valid time units are {s, m, h}."`
+}
+
 type btcdConfig struct {
 	Dir        string `long:"dir" description:"The base directory that contains the node's data, logs, configuration file, etc."`
 	RPCHost    string `long:"rpchost" description:"The daemon's rpc listening address. If a port is omitted, then the default port for the selected chain parameters will be used."`
@@ -126,6 +148,8 @@ type btcdConfig struct {
 	RPCPass    string `long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
 	RPCCert    string `long:"rpccert" description:"File containing the daemon's certificate file"`
 	RawRPCCert string `long:"rawrpccert" description:"The raw bytes of the daemon's PEM-encoded certificate chain which will be used to authenticate the RPC connection."`
+
+	FailoverHosts []string `long:"failoverhost" description:"The rpc listening address of a node to fail over to if the node at rpchost becomes unhealthy. The node must accept the same credentials and certificate. Can be specified multiple times, in order of preference."`
 }
 
 type bitcoindConfig struct {
@@ -135,6 +159,13 @@ type bitcoindConfig struct {
 	RPCPass        string `long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
 	ZMQPubRawBlock string `long:"zmqpubrawblock" description:"The address listening for ZMQ connections to deliver raw block notifications"`
 	ZMQPubRawTx    string `long:"zmqpubrawtx" description:"The address listening for ZMQ connections to deliver raw transaction notifications"`
+
+	FailoverHosts []string `long:"failoverhost" description:"A node to fail over to if the node at rpchost becomes unhealthy, as rpchost,zmqpubrawblock,zmqpubrawtx. The node must accept the same credentials. Can be specified multiple times, in order of preference."`
+}
+
+type chainFailoverConfig struct {
+	CheckInterval time.Duration `long:"checkinterval" description:"The interval between two health checks of the chain backends configured with failoverhost. Valid time units are {s, m, h}."`
+	MaxTipLag     uint32        `long:"maxtiplag" description:"The number of blocks the active chain backend may lag behind the best one before failing over"`
 }
 
 type autoPilotConfig struct {
@@ -158,6 +189,33 @@ type torConfig struct {
 	PrivateKeyPath  string `long:"privatekeypath" description:"The path to the private key of the onion service being created"`
 }
 
+type feeConfig struct {
+	URL             string        `long:"url" description:"Optional URL of a web API serving a JSON fee table, which is queried before the fee estimator of the chain backend. Useful for neutrino nodes"`
+	URLTimeout      time.Duration `long:"urltimeout" description:"The time after which a request to the fee web API is aborted"`
+	MinFeeRate      int64         `long:"minfeerate" description:"The lowest fee rate in sat/kw that is used for on-chain transactions"`
+	MaxFeeRate      int64         `long:"maxfeerate" description:"The highest fee rate in sat/kw that is used for on-chain transactions, 0 disables the maximum"`
+	CacheDuration   time.Duration `long:"cacheduration" description:"The time a fee estimate is reused before the fee sources are queried again"`
+	SmoothingFactor float64       `long:"smoothingfactor" description:"The weight, between 0 and 1, of a new fee estimate when combined with the previous one. Lower values dampen sudden jumps, 1 disables smoothing"`
+}
+
+const (
+	// remoteSignerModeSigner is the remote signer mode of an lnd instance
+	// that holds the seed and only serves signing requests.
+	remoteSignerModeSigner = "signer-only"
+
+	// remoteSignerModeWatchOnly is the remote signer mode of an lnd
+	// instance that forwards all signing requests to a signer-only
+	// instance.
+	remoteSignerModeWatchOnly = "watch-only"
+)
+
+type remoteSignerConfig struct {
+	Mode         string `long:"mode" description:"Run lnd as a remote signer holding the seed (signer-only), or forward all key derivation and signing for channels and the node identity to a remote signer (watch-only)" choice:"signer-only" choice:"watch-only"`
+	Listen       string `long:"listen" description:"The host:port the signer-only instance listens on for connections of the watch-only instance"`
+	RPCHost      string `long:"rpchost" description:"The host:port of the signer-only instance the watch-only instance connects to"`
+	PeerCertPath string `long:"peertlscertpath" description:"Path to the pinned TLS certificate of the other instance, used to mutually authenticate the connection"`
+}
+
 // config defines the configuration options for lnd.
 //
 // See loadConfig for further details regarding the configuration
@@ -176,6 +234,8 @@ type config struct {
 	AdminMacPath   string `long:"adminmacaroonpath" description:"Path to write the admin macaroon for lnd's RPC and REST services if it doesn't exist"`
 	ReadMacPath    string `long:"readonlymacaroonpath" description:"Path to write the read-only macaroon for lnd's RPC and REST services if it doesn't exist"`
 	InvoiceMacPath string `long:"invoicemacaroonpath" description:"Path to the invoice-only macaroon for lnd's RPC and REST services if it doesn't exist"`
+	SignerMacPath  string `long:"signermacaroonpath" description:"Path to write the signer macaroon, which only grants access to the Signer service, if it doesn't exist"`
+	BackupFilePath string `long:"backupfilepath" description:"The target location of the channel backup file"`
 	LogDir         string `long:"logdir" description:"Directory to log output."`
 	MaxLogFiles    int    `long:"maxlogfiles" description:"Maximum logfiles to keep (0 for no rotation)"`
 	MaxLogFileSize int    `long:"maxlogfilesize" description:"Maximum logfile size in MB"`
@@ -210,15 +270,22 @@ type config struct {
 	BtcdMode     *btcdConfig     `group:"btcd" namespace:"btcd"`
 	BitcoindMode *bitcoindConfig `group:"bitcoind" namespace:"bitcoind"`
 	NeutrinoMode *neutrinoConfig `group:"neutrino" namespace:"neutrino"`
+	ElectrumMode *electrumConfig `group:"electrum" namespace:"electrum"`
 
 	Litecoin      *chainConfig    `group:"Litecoin" namespace:"litecoin"`
 	LtcdMode      *btcdConfig     `group:"ltcd" namespace:"ltcd"`
 	LitecoindMode *bitcoindConfig `group:"litecoind" namespace:"litecoind"`
 
+	ChainFailover *chainFailoverConfig `group:"chainfailover" namespace:"chainfailover"`
+
 	Autopilot *autoPilotConfig `group:"Autopilot" namespace:"autopilot"`
 
 	Tor *torConfig `group:"Tor" namespace:"tor"`
 
+	RemoteSigner *remoteSignerConfig `group:"remotesigner" namespace:"remotesigner"`
+
+	Fee *feeConfig `group:"fee" namespace:"fee"`
+
 	Hodl *hodl.Config `group:"hodl" namespace:"hodl"`
 
 	NoNetBootstrap bool `long:"nobootstrap" description:"If true, then automatic network bootstrapping will not be attempted."`
@@ -231,6 +298,11 @@ type config struct {
 	Alias       string `long:"alias" description:"The node alias. Used as a moniker by peers and intelligence services"`
 	Color       string `long:"color" description:"The color of the node in hex format (i.e. '#3399FF'). Used to customize node appearance in intelligence services"`
 	MinChanSize int64  `long:"minchansize" description:"The smallest channel size (in satoshis) that we should accept. Incoming channels smaller than this will be rejected"`
+	MaxChanSize int64  `long:"maxchansize" description:"The largest channel size (in satoshis) that we should accept or open. Values above the 2^24 satoshi soft-limit are only used with peers that also signal support for large channels. Defaults to the soft-limit of the active chain"`
+
+	Anchors bool `long:"anchors" description:"EXPERIMENTAL: Negotiate the anchor output commitment format with peers that support it, allowing our force close transactions to be fee bumped using CPFP"`
+
+	Splicing bool `long:"splicing" description:"EXPERIMENTAL: Allow funds to be spliced out of channels with peers that support it, without closing the channel"`
 
 	NoChanUpdates bool `long:"nochanupdates" description:"If specified, lnd will not request real-time channel updates from connected peers. This option should be used by routing nodes to save bandwidth."`
 
@@ -274,6 +346,9 @@ func loadConfig(appDir string) (*config, error) {
 			Dir:     defaultBitcoindDir,
 			RPCHost: defaultRPCHost,
 		},
+		ElectrumMode: &electrumConfig{
+			RequestTimeout: electrum.DefaultRequestTimeout,
+		},
 		Litecoin: &chainConfig{
 			MinHTLC:       defaultLitecoinMinHTLCMSat,
 			BaseFee:       defaultLitecoinBaseFeeMSat,
@@ -290,6 +365,10 @@ func loadConfig(appDir string) (*config, error) {
 			Dir:     defaultLitecoindDir,
 			RPCHost: defaultRPCHost,
 		},
+		ChainFailover: &chainFailoverConfig{
+			CheckInterval: chainfailover.DefaultCheckInterval,
+			MaxTipLag:     chainfailover.DefaultMaxTipLag,
+		},
 		MaxPendingChannels: defaultMaxPendingChannels,
 		NoSeedBackup:       defaultNoSeedBackup,
 		Autopilot: &autoPilotConfig{
@@ -308,6 +387,13 @@ func loadConfig(appDir string) (*config, error) {
 			DNS:     defaultTorDNS,
 			Control: defaultTorControl,
 		},
+		RemoteSigner: &remoteSignerConfig{},
+		Fee: &feeConfig{
+			URLTimeout:      lnwallet.DefaultWebAPITimeout,
+			MinFeeRate:      int64(lnwallet.FeePerKwFloor),
+			CacheDuration:   defaultFeeCacheDuration,
+			SmoothingFactor: 1,
+		},
 		net: &tor.ClearNet{},
 	}
 
@@ -391,12 +477,17 @@ func loadConfig(appDir string) (*config, error) {
 	cfg.AdminMacPath = cleanAndExpandPath(cfg.AdminMacPath)
 	cfg.ReadMacPath = cleanAndExpandPath(cfg.ReadMacPath)
 	cfg.InvoiceMacPath = cleanAndExpandPath(cfg.InvoiceMacPath)
+	cfg.SignerMacPath = cleanAndExpandPath(cfg.SignerMacPath)
+	cfg.BackupFilePath = cleanAndExpandPath(cfg.BackupFilePath)
 	cfg.LogDir = cleanAndExpandPath(cfg.LogDir)
 	cfg.BtcdMode.Dir = cleanAndExpandPath(cfg.BtcdMode.Dir)
 	cfg.LtcdMode.Dir = cleanAndExpandPath(cfg.LtcdMode.Dir)
 	cfg.BitcoindMode.Dir = cleanAndExpandPath(cfg.BitcoindMode.Dir)
 	cfg.LitecoindMode.Dir = cleanAndExpandPath(cfg.LitecoindMode.Dir)
 	cfg.Tor.PrivateKeyPath = cleanAndExpandPath(cfg.Tor.PrivateKeyPath)
+	cfg.RemoteSigner.PeerCertPath = cleanAndExpandPath(
+		cfg.RemoteSigner.PeerCertPath,
+	)
 
 	// Ensure that the user didn't attempt to specify negative values for
 	// any of the autopilot params.
@@ -436,8 +527,62 @@ func loadConfig(appDir string) (*config, error) {
 	if cfg.Autopilot.MinChannelSize < int64(minChanFundingSize) {
 		cfg.Autopilot.MinChannelSize = int64(minChanFundingSize)
 	}
-	if cfg.Autopilot.MaxChannelSize > int64(maxFundingAmount) {
-		cfg.Autopilot.MaxChannelSize = int64(maxFundingAmount)
+
+	// Both sides of a remote signer setup need the pinned certificate of
+	// the other side, as well as an address to listen on or connect to.
+	switch cfg.RemoteSigner.Mode {
+	case remoteSignerModeSigner:
+		if cfg.RemoteSigner.Listen == "" {
+			str := "%s: remotesigner.listen must be set in " +
+				"signer-only mode"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+
+	case remoteSignerModeWatchOnly:
+		if cfg.RemoteSigner.RPCHost == "" {
+			str := "%s: remotesigner.rpchost must be set in " +
+				"watch-only mode"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+	}
+	if cfg.RemoteSigner.Mode != "" && cfg.RemoteSigner.PeerCertPath == "" {
+		str := "%s: remotesigner.peertlscertpath must be set to " +
+			"authenticate the remote signer connection"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	if cfg.ChainFailover.CheckInterval < time.Second {
+		str := "%s: chainfailover.checkinterval must be at least 1s"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// Ensure the fee rate bounds and smoothing factor are sane.
+	switch {
+	case cfg.Fee.MinFeeRate < int64(lnwallet.FeePerKwFloor):
+		str := "%s: fee.minfeerate must be at least %v sat/kw"
+		err := fmt.Errorf(str, funcName, int64(lnwallet.FeePerKwFloor))
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+
+	case cfg.Fee.MaxFeeRate != 0 && cfg.Fee.MaxFeeRate < cfg.Fee.MinFeeRate:
+		str := "%s: fee.maxfeerate must not be below fee.minfeerate"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+
+	case cfg.Fee.SmoothingFactor <= 0 || cfg.Fee.SmoothingFactor > 1:
+		str := "%s: fee.smoothingfactor must be within (0, 1]"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
 	}
 
 	// Validate the Tor config parameters.
@@ -547,6 +692,10 @@ func loadConfig(appDir string) (*config, error) {
 				minTimeLockDelta)
 		}
 
+		if err := validateRemoteConstraints(cfg.Litecoin); err != nil {
+			return nil, err
+		}
+
 		// Multiple networks can't be selected simultaneously.  Count
 		// number of network flags passed; assign active network params
 		// while we're at it.
@@ -685,6 +834,10 @@ func loadConfig(appDir string) (*config, error) {
 				minTimeLockDelta)
 		}
 
+		if err := validateRemoteConstraints(cfg.Bitcoin); err != nil {
+			return nil, err
+		}
+
 		switch cfg.Bitcoin.Node {
 		case "btcd":
 			err := parseRPCParams(
@@ -712,9 +865,21 @@ func loadConfig(appDir string) (*config, error) {
 		case "neutrino":
 			// No need to get RPC parameters.
 
+		case "electrum":
+			if cfg.ElectrumMode.Server == "" {
+				str := "%s: electrum.server must be set when " +
+					"using the electrum backend"
+				return nil, fmt.Errorf(str, funcName)
+			}
+			if cfg.ElectrumMode.TLSCertPath != "" {
+				cfg.ElectrumMode.TLSCertPath = cleanAndExpandPath(
+					cfg.ElectrumMode.TLSCertPath,
+				)
+			}
+
 		default:
-			str := "%s: only btcd, bitcoind, and neutrino mode " +
-				"supported for bitcoin at this time"
+			str := "%s: only btcd, bitcoind, neutrino, and " +
+				"electrum mode supported for bitcoin at this time"
 			return nil, fmt.Errorf(str, funcName)
 		}
 
@@ -759,8 +924,33 @@ func loadConfig(appDir string) (*config, error) {
 	if cfg.Autopilot.MinChannelSize < int64(minChanFundingSize) {
 		cfg.Autopilot.MinChannelSize = int64(minChanFundingSize)
 	}
-	if cfg.Autopilot.MaxChannelSize > int64(maxFundingAmount) {
-		cfg.Autopilot.MaxChannelSize = int64(maxFundingAmount)
+
+	// If the user didn't specify their own upper bound on the size of the
+	// channels we'll create or accept, we'll default to the soft-limit of
+	// the active chain. Otherwise, we'll make sure the bound is sane.
+	if cfg.MaxChanSize == 0 {
+		cfg.MaxChanSize = int64(maxFundingAmount)
+	}
+	if cfg.MaxChanSize < cfg.MinChanSize {
+		str := "%s: maxchansize must be greater than or equal to " +
+			"minchansize"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.MaxChanSize > int64(btcutil.MaxSatoshi) {
+		str := "%s: maxchansize must not exceed the total supply " +
+			"of %v"
+		err := fmt.Errorf(str, funcName, btcutil.MaxSatoshi)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// The autopilot agent will only open channels that are within our
+	// configured upper bound. Whether channels above the soft-limit can
+	// actually be created is decided on a per-peer basis.
+	if cfg.Autopilot.MaxChannelSize > cfg.MaxChanSize {
+		cfg.Autopilot.MaxChannelSize = cfg.MaxChanSize
 	}
 
 	// Validate profile port number.
@@ -801,6 +991,19 @@ func loadConfig(appDir string) (*config, error) {
 			networkDir, defaultInvoiceMacFilename,
 		)
 	}
+	if cfg.SignerMacPath == "" {
+		cfg.SignerMacPath = filepath.Join(
+			networkDir, defaultSignerMacFilename,
+		)
+	}
+
+	// Similarly, if a custom back up file path wasn't specified, then
+	// we'll update the file location to match our set network directory.
+	if cfg.BackupFilePath == "" {
+		cfg.BackupFilePath = filepath.Join(
+			networkDir, chanbackup.DefaultBackupFileName,
+		)
+	}
 
 	// Append the network type to the log directory so it is "namespaced"
 	// per network in the same fashion as the data directory.
@@ -1061,6 +1264,31 @@ func supportedSubsystems() []string {
 	return subsystems
 }
 
+// validateRemoteConstraints ensures that the default channel constraints we
+// require of our channel counterparties are within the bounds set by BOLT #2
+// and the bounds our counterparties will check them against.
+func validateRemoteConstraints(cConfig *chainConfig) error {
+	maxHtlcs := cConfig.DefaultRemoteMaxHtlcs
+	switch {
+	case maxHtlcs > uint16(lnwallet.MaxHTLCNumber/2):
+		return fmt.Errorf("defaultremotemaxhtlcs must not exceed %v",
+			lnwallet.MaxHTLCNumber/2)
+
+	case maxHtlcs != 0 && maxHtlcs < minRemoteMaxHtlcs:
+		return fmt.Errorf("defaultremotemaxhtlcs must be at least %v",
+			minRemoteMaxHtlcs)
+	}
+
+	maxPendingAmt := cConfig.DefaultRemoteMaxPendingAmt
+	minMaxPendingAmt := minRemoteMaxHtlcs * cConfig.MinHTLC
+	if maxPendingAmt != 0 && maxPendingAmt < minMaxPendingAmt {
+		return fmt.Errorf("defaultremotemaxpendingamt must be at "+
+			"least %v", minMaxPendingAmt)
+	}
+
+	return nil
+}
+
 func parseRPCParams(cConfig *chainConfig, nodeConfig interface{}, net chainCode,
 	funcName string) error {
 
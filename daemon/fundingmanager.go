@@ -10,6 +10,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/coreos/bbolt"
@@ -75,9 +76,9 @@ var (
 	// while implementations are battle tested in the real world.
 	//
 	// At the moment, this value depends on which chain is active. It is set
-	// to the value under the Bitcoin chain as default.
-	//
-	// TODO(roasbeef): add command line param to modify
+	// to the value under the Bitcoin chain as default. Channels above this
+	// limit can only be created with peers that signal support for large
+	// channels, see maxChanSizeForPeer.
 	maxFundingAmount = maxBtcFundingAmount
 
 	// ErrFundingManagerShuttingDown is an error returned when attempting to
@@ -103,8 +104,11 @@ type reservationWithCtx struct {
 	chanAmt btcutil.Amount
 
 	// Constraints we require for the remote.
-	remoteCsvDelay uint16
-	remoteMinHtlc  lnwire.MilliSatoshi
+	remoteCsvDelay    uint16
+	remoteMinHtlc     lnwire.MilliSatoshi
+	remoteChanReserve btcutil.Amount
+	remoteMaxValue    lnwire.MilliSatoshi
+	remoteMaxHtlcs    uint16
 
 	updateMtx   sync.RWMutex
 	lastUpdated time.Time
@@ -314,11 +318,29 @@ type fundingConfig struct {
 	// node we're establishing a channel with for reconnection purposes.
 	WatchNewChannel func(*channeldb.OpenChannel, *btcec.PublicKey) error
 
+	// NotifyPendingOpenChanEvent informs the ChannelNotifier that a new
+	// channel has entered the pending open state, and now awaits the
+	// confirmation of its funding transaction.
+	NotifyPendingOpenChanEvent func(wire.OutPoint, *channeldb.OpenChannel)
+
+	// NotifyOpenChannelEvent informs the ChannelNotifier that the funding
+	// transaction of a channel has confirmed, and the channel has been
+	// marked as open within the database.
+	NotifyOpenChannelEvent func(wire.OutPoint)
+
 	// ReportShortChanID allows the funding manager to report the newly
 	// discovered short channel ID of a formerly pending channel to outside
 	// sub-systems.
 	ReportShortChanID func(wire.OutPoint) error
 
+	// SpliceChannel is to be called once the splice of a channel has
+	// confirmed, and the channel has been moved to its new channel point
+	// within the database. This method hands the channel at its new
+	// channel point to the ChainArbitrator, replacing the channel at the
+	// prior channel point.
+	SpliceChannel func(oldChanPoint wire.OutPoint,
+		newChan *channeldb.OpenChannel) error
+
 	// ZombieSweeperInterval is the periodic time interval in which the
 	// zombie sweeper is run.
 	ZombieSweeperInterval time.Duration
@@ -332,6 +354,82 @@ type fundingConfig struct {
 	// flood us with very small channels that would never really be usable
 	// due to fees.
 	MinChanSize btcutil.Amount
+
+	// MaxChanSize is the largest channel size that we'll accept as an
+	// inbound channel, or create as an outbound channel. Values above
+	// maxFundingAmount are only used if the remote peer also signals
+	// support for large channels.
+	MaxChanSize btcutil.Amount
+
+	// AnchorCommitments indicates whether we signal support for the
+	// anchor output commitment format, and should therefore use it with
+	// peers that signal support for it as well.
+	AnchorCommitments bool
+}
+
+// validateUpfrontShutdown ensures that the upfront shutdown script committed
+// to by the remote party is one of the standard script types that we're able
+// to pay out to within a cooperative close transaction. An empty script is
+// valid, as it signals that the remote party didn't commit to any script.
+func validateUpfrontShutdown(script lnwire.DeliveryAddress) error {
+	if len(script) == 0 {
+		return nil
+	}
+
+	switch txscript.GetScriptClass(script) {
+	case txscript.PubKeyHashTy, txscript.ScriptHashTy,
+		txscript.WitnessV0PubKeyHashTy, txscript.WitnessV0ScriptHashTy:
+
+		return nil
+
+	default:
+		return lnwallet.ErrInvalidUpfrontShutdown(script)
+	}
+}
+
+// maxChanSizeForPeer returns the largest channel size that can be used with
+// the given peer. If both we and the peer signal support for large channels,
+// then our configured max channel size is used, otherwise we'll fall back to
+// the soft-limit of maxFundingAmount.
+func maxChanSizeForPeer(peer lnpeer.Peer,
+	maxChanSize btcutil.Amount) btcutil.Amount {
+
+	if maxChanSize <= maxFundingAmount {
+		return maxChanSize
+	}
+
+	remoteFeatures := peer.RemoteLocalFeatures()
+	if remoteFeatures == nil ||
+		!remoteFeatures.HasFeature(lnwire.LargeChannelsOptional) {
+
+		return maxFundingAmount
+	}
+
+	return maxChanSize
+}
+
+// commitTypeForPeer returns the commitment format that should be used for a
+// channel with the given peer. As we always signal support for static remote
+// keys, the tweakless format is used if the peer signals support for it as
+// well. If both we and the peer additionally signal support for anchor
+// outputs, then the anchor format is used.
+func commitTypeForPeer(peer lnpeer.Peer,
+	anchorCommitments bool) lnwallet.CommitmentType {
+
+	remoteFeatures := peer.RemoteLocalFeatures()
+	if remoteFeatures == nil ||
+		!remoteFeatures.HasFeature(lnwire.StaticRemoteKeyOptional) {
+
+		return lnwallet.CommitmentTypeLegacy
+	}
+
+	if anchorCommitments &&
+		remoteFeatures.HasFeature(lnwire.AnchorOutputsOptional) {
+
+		return lnwallet.CommitmentTypeAnchors
+	}
+
+	return lnwallet.CommitmentTypeTweakless
 }
 
 // fundingManager acts as an orchestrator/bridge between the wallet's
@@ -499,8 +597,7 @@ func (f *fundingManager) Start() error {
 		// already broadcast this transaction. Otherwise, we simply log
 		// the error as there isn't anything we can currently do to
 		// recover.
-		if channel.ChanType == channeldb.SingleFunder &&
-			channel.IsInitiator {
+		if channel.ChanType.IsSingleFunder() && channel.IsInitiator {
 
 			err := f.cfg.PublishTransaction(channel.FundingTxn)
 			if err != nil && err != lnwallet.ErrDoubleSpend {
@@ -520,24 +617,9 @@ func (f *fundingManager) Start() error {
 				// Timeout channel will be triggered if the number of blocks
 				// mined since the channel was initiated reaches
 				// maxWaitNumBlocksFundingConf and we are not the channel
-				// initiator.
-				localBalance := ch.LocalCommitment.LocalBalance.ToSatoshis()
-				closeInfo := &channeldb.ChannelCloseSummary{
-					ChainHash:               ch.ChainHash,
-					ChanPoint:               ch.FundingOutpoint,
-					RemotePub:               ch.IdentityPub,
-					Capacity:                ch.Capacity,
-					SettledBalance:          localBalance,
-					CloseType:               channeldb.FundingCanceled,
-					RemoteCurrentRevocation: ch.RemoteCurrentRevocation,
-					RemoteNextRevocation:    ch.RemoteNextRevocation,
-					LocalChanConfig:         ch.LocalChanCfg,
-				}
-
-				if err := ch.CloseChannel(closeInfo); err != nil {
-					fndgLog.Errorf("Failed closing channel "+
-						"%v: %v", ch.FundingOutpoint, err)
-				}
+				// initiator, or if the funding transaction was double
+				// spent and we are.
+				f.deletePendingChannel(ch)
 
 			case <-f.quit:
 				// The fundingManager is shutting down, and will
@@ -691,6 +773,17 @@ func (f *fundingManager) Start() error {
 		}
 	}
 
+	// Finally, we'll resume waiting for the confirmation of any splices
+	// that were pending when the daemon last went down.
+	for _, channel := range openChannels {
+		if !channel.HasChanStatus(channeldb.SplicePending) {
+			continue
+		}
+
+		f.wg.Add(1)
+		go f.waitForSplice(channel, true)
+	}
+
 	f.wg.Add(1) // TODO(roasbeef): tune
 	go f.reservationCoordinator()
 
@@ -1011,9 +1104,9 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		return
 	}
 
-	// We'll reject any request to create a channel that's above the
-	// current soft-limit for channel size.
-	if msg.FundingAmount > maxFundingAmount {
+	// We'll reject any request to create a channel that's above the max
+	// channel size we've negotiated with this peer.
+	if msg.FundingAmount > maxChanSizeForPeer(fmsg.peer, f.cfg.MaxChanSize) {
 		f.failFundingFlow(
 			fmsg.peer, fmsg.msg.PendingChannelID,
 			lnwire.ErrChanTooLarge,
@@ -1031,6 +1124,13 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		return
 	}
 
+	// If the remote party committed to an upfront shutdown script, then
+	// we'll ensure that it's a script we're able to pay out to.
+	if err := validateUpfrontShutdown(msg.UpfrontShutdownScript); err != nil {
+		f.failFundingFlow(fmsg.peer, fmsg.msg.PendingChannelID, err)
+		return
+	}
+
 	fndgLog.Infof("Recv'd fundingRequest(amt=%v, push=%v, delay=%v, "+
 		"pendingId=%x) from peer(%x)", amt, msg.PushAmount,
 		msg.CsvDelay, msg.PendingChannelID,
@@ -1053,6 +1153,9 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		PushMSat:        msg.PushAmount,
 		Flags:           msg.ChannelFlags,
 		MinConfs:        1,
+		CommitType: commitTypeForPeer(
+			fmsg.peer, f.cfg.AnchorCommitments,
+		),
 	}
 
 	reservation, err := f.cfg.Wallet.InitChannelReservation(req)
@@ -1101,12 +1204,15 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		f.activeReservations[peerIDKey] = make(pendingChannels)
 	}
 	resCtx := &reservationWithCtx{
-		reservation:    reservation,
-		chanAmt:        amt,
-		remoteCsvDelay: remoteCsvDelay,
-		remoteMinHtlc:  minHtlc,
-		err:            make(chan error, 1),
-		peer:           fmsg.peer,
+		reservation:       reservation,
+		chanAmt:           amt,
+		remoteCsvDelay:    remoteCsvDelay,
+		remoteMinHtlc:     minHtlc,
+		remoteChanReserve: chanReserve,
+		remoteMaxValue:    maxValue,
+		remoteMaxHtlcs:    maxHtlcs,
+		err:               make(chan error, 1),
+		peer:              fmsg.peer,
 	}
 	f.activeReservations[peerIDKey][msg.PendingChannelID] = resCtx
 	f.resMtx.Unlock()
@@ -1144,6 +1250,7 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 				PubKey: copyPubKey(msg.HtlcPoint),
 			},
 		},
+		UpfrontShutdown: msg.UpfrontShutdownScript,
 	}
 	err = reservation.ProcessSingleContribution(remoteContribution)
 	if err != nil {
@@ -1161,20 +1268,21 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	// contribution in the next message of the workflow.
 	ourContribution := reservation.OurContribution()
 	fundingAccept := lnwire.AcceptChannel{
-		PendingChannelID:     msg.PendingChannelID,
-		DustLimit:            ourContribution.DustLimit,
-		MaxValueInFlight:     maxValue,
-		ChannelReserve:       chanReserve,
-		MinAcceptDepth:       uint32(numConfsReq),
-		HtlcMinimum:          minHtlc,
-		CsvDelay:             remoteCsvDelay,
-		MaxAcceptedHTLCs:     maxHtlcs,
-		FundingKey:           ourContribution.MultiSigKey.PubKey,
-		RevocationPoint:      ourContribution.RevocationBasePoint.PubKey,
-		PaymentPoint:         ourContribution.PaymentBasePoint.PubKey,
-		DelayedPaymentPoint:  ourContribution.DelayBasePoint.PubKey,
-		HtlcPoint:            ourContribution.HtlcBasePoint.PubKey,
-		FirstCommitmentPoint: ourContribution.FirstCommitmentPoint,
+		PendingChannelID:      msg.PendingChannelID,
+		DustLimit:             ourContribution.DustLimit,
+		MaxValueInFlight:      maxValue,
+		ChannelReserve:        chanReserve,
+		MinAcceptDepth:        uint32(numConfsReq),
+		HtlcMinimum:           minHtlc,
+		CsvDelay:              remoteCsvDelay,
+		MaxAcceptedHTLCs:      maxHtlcs,
+		FundingKey:            ourContribution.MultiSigKey.PubKey,
+		RevocationPoint:       ourContribution.RevocationBasePoint.PubKey,
+		PaymentPoint:          ourContribution.PaymentBasePoint.PubKey,
+		DelayedPaymentPoint:   ourContribution.DelayBasePoint.PubKey,
+		HtlcPoint:             ourContribution.HtlcBasePoint.PubKey,
+		FirstCommitmentPoint:  ourContribution.FirstCommitmentPoint,
+		UpfrontShutdownScript: ourContribution.UpfrontShutdown,
 	}
 	if err := fmsg.peer.SendMessage(false, &fundingAccept); err != nil {
 		fndgLog.Errorf("unable to send funding response to peer: %v", err)
@@ -1219,6 +1327,15 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 	// required confirmations, and also the set of channel constraints
 	// they've specified for commitment states we can create.
 	resCtx.reservation.SetNumConfsRequired(uint16(msg.MinAcceptDepth))
+
+	// If the remote party committed to an upfront shutdown script, then
+	// we'll ensure that it's a script we're able to pay out to.
+	if err := validateUpfrontShutdown(msg.UpfrontShutdownScript); err != nil {
+		fndgLog.Warnf("Unacceptable upfront shutdown script: %v", err)
+		f.failFundingFlow(fmsg.peer, fmsg.msg.PendingChannelID, err)
+		return
+	}
+
 	err = resCtx.reservation.CommitConstraints(
 		msg.CsvDelay, msg.MaxAcceptedHTLCs, msg.MaxValueInFlight,
 		msg.HtlcMinimum, msg.ChannelReserve, msg.DustLimit,
@@ -1229,12 +1346,16 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		return
 	}
 
-	// As they've accepted our channel constraints, we'll regenerate them
-	// here so we can properly commit their accepted constraints to the
-	// reservation.
-	chanReserve := f.cfg.RequiredRemoteChanReserve(resCtx.chanAmt, msg.DustLimit)
-	maxValue := f.cfg.RequiredRemoteMaxValue(resCtx.chanAmt)
-	maxHtlcs := f.cfg.RequiredRemoteMaxHTLCs(resCtx.chanAmt)
+	// As they've accepted our channel constraints, we'll commit them to
+	// the reservation. If the channel reserve we required dips below
+	// their dust limit, then we'll use the dust limit itself as the
+	// reserve as required by BOLT #2.
+	chanReserve := resCtx.remoteChanReserve
+	if chanReserve < msg.DustLimit {
+		chanReserve = msg.DustLimit
+	}
+	maxValue := resCtx.remoteMaxValue
+	maxHtlcs := resCtx.remoteMaxHtlcs
 
 	// The remote node has responded with their portion of the channel
 	// contribution. At this point, we can process their contribution which
@@ -1267,6 +1388,7 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 				PubKey: copyPubKey(msg.HtlcPoint),
 			},
 		},
+		UpfrontShutdown: msg.UpfrontShutdownScript,
 	}
 	err = resCtx.reservation.ProcessContribution(remoteContribution)
 	if err != nil {
@@ -1380,29 +1502,6 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 	// from the set of active reservations.
 	f.deleteReservationCtx(peerKey, fmsg.msg.PendingChannelID)
 
-	// If something goes wrong before the funding transaction is confirmed,
-	// we use this convenience method to delete the pending OpenChannel
-	// from the database.
-	deleteFromDatabase := func() {
-		localBalance := completeChan.LocalCommitment.LocalBalance.ToSatoshis()
-		closeInfo := &channeldb.ChannelCloseSummary{
-			ChanPoint:               completeChan.FundingOutpoint,
-			ChainHash:               completeChan.ChainHash,
-			RemotePub:               completeChan.IdentityPub,
-			CloseType:               channeldb.FundingCanceled,
-			Capacity:                completeChan.Capacity,
-			SettledBalance:          localBalance,
-			RemoteCurrentRevocation: completeChan.RemoteCurrentRevocation,
-			RemoteNextRevocation:    completeChan.RemoteNextRevocation,
-			LocalChanConfig:         completeChan.LocalChanCfg,
-		}
-
-		if err := completeChan.CloseChannel(closeInfo); err != nil {
-			fndgLog.Errorf("Failed closing channel %v: %v",
-				completeChan.FundingOutpoint, err)
-		}
-	}
-
 	// A new channel has almost finished the funding process. In order to
 	// properly synchronize with the writeHandler goroutine, we add a new
 	// channel to the barriers map which will be closed once the channel is
@@ -1423,7 +1522,7 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 	if err != nil {
 		fndgLog.Errorf("unable to parse signature: %v", err)
 		f.failFundingFlow(fmsg.peer, pendingChanID, err)
-		deleteFromDatabase()
+		f.deletePendingChannel(completeChan)
 		return
 	}
 
@@ -1434,7 +1533,7 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 	if err := fmsg.peer.SendMessage(false, fundingSigned); err != nil {
 		fndgLog.Errorf("unable to send FundingSigned message: %v", err)
 		f.failFundingFlow(fmsg.peer, pendingChanID, err)
-		deleteFromDatabase()
+		f.deletePendingChannel(completeChan)
 		return
 	}
 
@@ -1446,6 +1545,10 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 			"arbitration: %v", fundingOut, err)
 	}
 
+	// Inform the ChannelNotifier that the channel has entered the
+	// pending open state.
+	f.cfg.NotifyPendingOpenChanEvent(fundingOut, completeChan)
+
 	// Create an entry in the local discovery map so we can ensure that we
 	// process the channel confirmation fully before we receive a funding
 	// locked message.
@@ -1487,7 +1590,7 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 				"(%v) to confirm", completeChan.FundingOutpoint)
 			fndgLog.Warnf(err.Error())
 			f.failFundingFlow(fmsg.peer, pendingChanID, err)
-			deleteFromDatabase()
+			f.deletePendingChannel(completeChan)
 			return
 		case <-f.quit:
 			// The fundingManager is shutting down, will resume
@@ -1589,6 +1692,13 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 	fndgLog.Infof("Broadcasting funding tx for ChannelPoint(%v): %v",
 		completeChan.FundingOutpoint, spew.Sdump(fundingTx))
 
+	label := lnwallet.ChannelOpenLabel(completeChan.FundingOutpoint)
+	err = f.cfg.Wallet.LabelTransaction(fundingTx.TxHash(), label, false)
+	if err != nil {
+		fndgLog.Warnf("Unable to label funding tx for "+
+			"ChannelPoint(%v): %v", completeChan.FundingOutpoint, err)
+	}
+
 	err = f.cfg.PublishTransaction(fundingTx)
 	if err != nil {
 		fndgLog.Errorf("unable to broadcast funding "+
@@ -1609,6 +1719,10 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 			"arbitration: %v", fundingPoint, err)
 	}
 
+	// Inform the ChannelNotifier that the channel has entered the
+	// pending open state.
+	f.cfg.NotifyPendingOpenChanEvent(*fundingPoint, completeChan)
+
 	fndgLog.Infof("Finalizing pendingID(%x) over ChannelPoint(%v), "+
 		"waiting for channel open on-chain", pendingChanID[:],
 		fundingPoint)
@@ -1633,27 +1747,33 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 	}
 
 	// At this point we have broadcast the funding transaction and done all
-	// necessary processing.
+	// necessary processing. As we're the initiator, the wait for the
+	// funding transaction will only be cancelled if it's double spent.
 	f.wg.Add(1)
 	go func() {
 		defer f.wg.Done()
 		confChan := make(chan *lnwire.ShortChannelID)
-		cancelChan := make(chan struct{})
-
-		// In case the fundingManager is stopped at some point during
-		// the remaining part of the opening process, we must wait for
-		// this process to finish (either successfully or with some
-		// error), before the fundingManager can be shut down.
-		f.wg.Add(1)
-		go func() {
-			defer f.wg.Done()
-			f.waitForFundingConfirmation(completeChan, cancelChan,
-				confChan)
-		}()
+		timeoutChan := make(chan struct{})
+		go f.waitForFundingWithTimeout(completeChan, confChan,
+			timeoutChan)
 
 		var shortChanID *lnwire.ShortChannelID
 		var ok bool
 		select {
+		case <-timeoutChan:
+			// The funding transaction can no longer confirm, so
+			// we forget the channel.
+			err := fmt.Errorf("funding tx (%v) was double spent",
+				completeChan.FundingOutpoint)
+			fndgLog.Warnf(err.Error())
+			f.failFundingFlow(fmsg.peer, pendingChanID, err)
+			f.deletePendingChannel(completeChan)
+
+			select {
+			case resCtx.err <- err:
+			case <-f.quit:
+			}
+			return
 		case <-f.quit:
 			return
 		case shortChanID, ok = <-confChan:
@@ -1729,7 +1849,9 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 
 // waitForFundingWithTimeout is a wrapper around waitForFundingConfirmation that
 // will cancel the wait for confirmation if we are not the channel initiator and
-// the maxWaitNumBlocksFundingConf has passed from bestHeight.
+// the maxWaitNumBlocksFundingConf has passed from bestHeight. If we are the
+// initiator, the wait is instead cancelled once any of the inputs of the
+// funding transaction has been spent by a different, confirmed transaction.
 // In the case of timeout, the timeoutChan will be closed. In case of error,
 // confChan will be closed. In case of success, a *lnwire.ShortChannelID will be
 // passed to confChan.
@@ -1758,6 +1880,22 @@ func (f *fundingManager) waitForFundingWithTimeout(completeChan *channeldb.OpenC
 			waitingConfChan)
 	}()
 
+	// As the initiator, we can't time out the channel as our funds would
+	// be lost if the funding transaction confirmed afterwards. Instead,
+	// we'll watch the inputs of the funding transaction, such that the
+	// channel can be forgotten once it has been double spent.
+	var doubleSpendChan <-chan *chainhash.Hash
+	if completeChan.IsInitiator && completeChan.FundingTxn != nil {
+		doubleSpendChan, err = f.watchFundingDoubleSpend(
+			completeChan, cancelChan,
+		)
+		if err != nil {
+			fndgLog.Errorf("Unable to watch funding inputs of "+
+				"ChannelPoint(%v): %v",
+				completeChan.FundingOutpoint, err)
+		}
+	}
+
 	// On block maxHeight we will cancel the funding confirmation wait.
 	maxHeight := completeChan.FundingBroadcastHeight + maxWaitNumBlocksFundingConf
 	for {
@@ -1784,9 +1922,14 @@ func (f *fundingManager) waitForFundingWithTimeout(completeChan *channeldb.OpenC
 				return
 			}
 
-			// TODO: If we are the channel initiator implement
-			// a method for recovering the funds from the funding
-			// transaction
+		case spenderHash := <-doubleSpendChan:
+			fndgLog.Warnf("Funding tx of ChannelPoint(%v) was "+
+				"double spent by %v, cancelling.",
+				completeChan.FundingOutpoint, spenderHash)
+
+			close(cancelChan)
+			close(timeoutChan)
+			return
 
 		case <-f.quit:
 			// The fundingManager is shutting down, will resume
@@ -1809,6 +1952,87 @@ func (f *fundingManager) waitForFundingWithTimeout(completeChan *channeldb.OpenC
 	}
 }
 
+// watchFundingDoubleSpend registers for spend notifications of all inputs of
+// the funding transaction of the passed pending channel. The hash of the
+// spending transaction is sent over the returned channel once any input has
+// been spent by a transaction other than the funding transaction. Closing the
+// cancelChan stops the watch.
+func (f *fundingManager) watchFundingDoubleSpend(
+	completeChan *channeldb.OpenChannel,
+	cancelChan <-chan struct{}) (<-chan *chainhash.Hash, error) {
+
+	fundingTxid := completeChan.FundingTxn.TxHash()
+	doubleSpendChan := make(chan *chainhash.Hash, 1)
+
+	for _, txIn := range completeChan.FundingTxn.TxIn {
+		prevOut := txIn.PreviousOutPoint
+		txOut, err := f.cfg.Wallet.FetchInputInfo(&prevOut)
+		if err != nil {
+			return nil, err
+		}
+
+		spendNtfn, err := f.cfg.Notifier.RegisterSpendNtfn(
+			&prevOut, txOut.PkScript,
+			completeChan.FundingBroadcastHeight,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			defer spendNtfn.Cancel()
+
+			select {
+			case spend, ok := <-spendNtfn.Spend:
+				if !ok {
+					return
+				}
+
+				// The funding transaction itself spending
+				// the input is the happy path, which is
+				// handled by waitForFundingConfirmation.
+				if *spend.SpenderTxHash == fundingTxid {
+					return
+				}
+
+				select {
+				case doubleSpendChan <- spend.SpenderTxHash:
+				default:
+				}
+
+			case <-cancelChan:
+			case <-f.quit:
+			}
+		}()
+	}
+
+	return doubleSpendChan, nil
+}
+
+// deletePendingChannel forgets the passed pending channel, whose funding
+// transaction will never confirm, by closing it within the database.
+func (f *fundingManager) deletePendingChannel(ch *channeldb.OpenChannel) {
+	localBalance := ch.LocalCommitment.LocalBalance.ToSatoshis()
+	closeInfo := &channeldb.ChannelCloseSummary{
+		ChainHash:               ch.ChainHash,
+		ChanPoint:               ch.FundingOutpoint,
+		RemotePub:               ch.IdentityPub,
+		Capacity:                ch.Capacity,
+		SettledBalance:          localBalance,
+		CloseType:               channeldb.FundingCanceled,
+		RemoteCurrentRevocation: ch.RemoteCurrentRevocation,
+		RemoteNextRevocation:    ch.RemoteNextRevocation,
+		LocalChanConfig:         ch.LocalChanCfg,
+	}
+
+	if err := ch.CloseChannel(closeInfo); err != nil {
+		fndgLog.Errorf("Failed closing channel %v: %v",
+			ch.FundingOutpoint, err)
+	}
+}
+
 // makeFundingScript re-creates the funding script for the funding transaction
 // of the target channel.
 func makeFundingScript(channel *channeldb.OpenChannel) ([]byte, error) {
@@ -1925,6 +2149,10 @@ func (f *fundingManager) waitForFundingConfirmation(completeChan *channeldb.Open
 		return
 	}
 
+	// Inform the ChannelNotifier that the channel has transitioned from
+	// pending open to open.
+	f.cfg.NotifyOpenChannelEvent(completeChan.FundingOutpoint)
+
 	// As there might already be an active link in the switch with an
 	// outdated short chan ID, we'll instruct the switch to load the updated
 	// short chan id from disk.
@@ -2244,6 +2472,146 @@ func (f *fundingManager) annAfterSixConfs(completeChan *channeldb.OpenChannel,
 	return nil
 }
 
+// processSplice is to be called once both parties have agreed upon, and
+// broadcast, a splice transaction for the passed channel. The fundingManager
+// will wait for the splice to confirm, and move the channel to its new channel
+// point.
+func (f *fundingManager) processSplice(channel *channeldb.OpenChannel) {
+	f.wg.Add(1)
+	go f.waitForSplice(channel, false)
+}
+
+// waitForSplice waits for the pending splice of the passed channel to reach
+// the number of confirmations required for the channel itself. Once it has,
+// the channel is moved to the funding output of the splice, handed back to
+// the peer, and announced at its new location. If rebroadcast is true, the
+// splice transaction will be rebroadcast, if we hold the fully signed
+// transaction.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *fundingManager) waitForSplice(channel *channeldb.OpenChannel,
+	rebroadcast bool) {
+
+	defer f.wg.Done()
+
+	oldChanPoint := channel.FundingOutpoint
+	splice, err := channel.PendingSplice()
+	if err != nil {
+		fndgLog.Errorf("Unable to fetch pending splice for "+
+			"ChannelPoint(%v): %v", oldChanPoint, err)
+		return
+	}
+
+	// The splice transaction only carries a witness once we've received
+	// the signature of the remote party. If the splice was interrupted
+	// before this point, we'll rely on the remote party to broadcast it.
+	if rebroadcast && len(splice.SpliceTx.TxIn[0].Witness) != 0 {
+		publishTx := f.cfg.Wallet.LabelledPublisher(
+			lnwallet.SpliceLabel(oldChanPoint),
+		)
+		err := publishTx(splice.SpliceTx)
+		if err != nil && err != lnwallet.ErrDoubleSpend {
+			fndgLog.Warnf("Unable to rebroadcast splice txn: %v",
+				err)
+		}
+	}
+
+	fundingScript, err := makeFundingScript(channel)
+	if err != nil {
+		fndgLog.Errorf("Unable to create funding script for "+
+			"ChannelPoint(%v): %v", splice.FundingOutpoint, err)
+		return
+	}
+
+	txid := splice.FundingOutpoint.Hash
+	numConfs := uint32(channel.NumConfsRequired)
+	confNtfn, err := f.cfg.Notifier.RegisterConfirmationsNtfn(
+		&txid, fundingScript, numConfs, splice.BroadcastHeight,
+	)
+	if err != nil {
+		fndgLog.Errorf("Unable to register for confirmation of "+
+			"splice of ChannelPoint(%v): %v", oldChanPoint, err)
+		return
+	}
+
+	fndgLog.Infof("Waiting for splice tx (%v) of ChannelPoint(%v) to "+
+		"reach %v confirmations", txid, oldChanPoint, numConfs)
+
+	var confDetails *chainntnfs.TxConfirmation
+	select {
+	case confDetails = <-confNtfn.Confirmed:
+		if confDetails == nil {
+			fndgLog.Warnf("ChainNotifier shutting down, cannot "+
+				"complete splice of ChannelPoint(%v)",
+				oldChanPoint)
+			return
+		}
+
+	case <-f.quit:
+		return
+	}
+
+	shortChanID := lnwire.ShortChannelID{
+		BlockHeight: confDetails.BlockHeight,
+		TxIndex:     confDetails.TxIndex,
+		TxPosition:  uint16(splice.FundingOutpoint.Index),
+	}
+
+	// With the splice confirmed, we'll move the channel to its new
+	// channel point within the database. As both parties have already
+	// been operating the channel, the funding locked exchange is skipped.
+	if err := channel.CompleteSplice(shortChanID); err != nil {
+		fndgLog.Errorf("Unable to complete splice of "+
+			"ChannelPoint(%v): %v", oldChanPoint, err)
+		return
+	}
+	err = f.saveChannelOpeningState(
+		&channel.FundingOutpoint, fundingLockedSent, &shortChanID,
+	)
+	if err != nil {
+		fndgLog.Errorf("Unable to save channel opening state: %v", err)
+		return
+	}
+
+	fndgLog.Infof("ChannelPoint(%v) spliced into ChannelPoint(%v), "+
+		"short_chan_id=%v", oldChanPoint, channel.FundingOutpoint,
+		shortChanID)
+
+	if err := f.cfg.SpliceChannel(oldChanPoint, channel); err != nil {
+		fndgLog.Errorf("Unable to watch spliced ChannelPoint(%v): %v",
+			channel.FundingOutpoint, err)
+		return
+	}
+
+	// Now that the channel has moved, we'll hand it back to the peer, so
+	// it can be used for updates once again.
+	peerChan := make(chan lnpeer.Peer, 1)
+	f.cfg.NotifyWhenOnline(channel.IdentityPub, peerChan)
+
+	var peer lnpeer.Peer
+	select {
+	case peer = <-peerChan:
+	case <-f.quit:
+		return
+	}
+	if err := peer.AddNewChannel(channel, f.quit); err != nil {
+		fndgLog.Errorf("Unable to add spliced ChannelPoint(%v) to "+
+			"peer: %v", channel.FundingOutpoint, err)
+		return
+	}
+
+	// Finally, we'll add the channel to the graph at its new location,
+	// and announce it once the splice is sufficiently buried.
+	if err := f.addToRouterGraph(channel, &shortChanID); err != nil {
+		fndgLog.Errorf("Failed adding to router graph: %v", err)
+		return
+	}
+	if err := f.annAfterSixConfs(channel, &shortChanID); err != nil {
+		fndgLog.Errorf("Error sending channel announcement: %v", err)
+		return
+	}
+}
+
 // processFundingLocked sends a message to the fundingManager allowing it to
 // finish the funding workflow.
 func (f *fundingManager) processFundingLocked(msg *lnwire.FundingLocked,
@@ -2632,6 +3000,61 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		localAmt, msg.pushAmt, capacity, msg.chainHash,
 		peerKey.SerializeCompressed(), ourDustLimit, msg.minConfs)
 
+	// If the remote CSV delay was not set in the open channel request,
+	// we'll use the RequiredRemoteDelay closure to compute the delay we
+	// require given the total amount of funds within the channel.
+	if remoteCsvDelay == 0 {
+		remoteCsvDelay = f.cfg.RequiredRemoteDelay(capacity)
+	}
+
+	// If no minimum HTLC value was specified, use the default one.
+	if minHtlc == 0 {
+		minHtlc = f.cfg.DefaultRoutingPolicy.MinHTLC
+	}
+
+	// Likewise, we'll use the current value of the channel and our default
+	// policy to determine any of the remaining commitment constraints for
+	// the remote party that weren't set in the open channel request.
+	chanReserve := msg.remoteChanReserve
+	if chanReserve == 0 {
+		chanReserve = f.cfg.RequiredRemoteChanReserve(
+			capacity, ourDustLimit,
+		)
+	}
+	maxValue := msg.remoteMaxValue
+	if maxValue == 0 {
+		maxValue = f.cfg.RequiredRemoteMaxValue(capacity)
+	}
+	maxHtlcs := msg.remoteMaxHtlcs
+	if maxHtlcs == 0 {
+		maxHtlcs = f.cfg.RequiredRemoteMaxHTLCs(capacity)
+	}
+
+	// Before we reserve any funds, we'll ensure the constraints are
+	// within the bounds the remote party will check them against, as it
+	// would otherwise reject the channel.
+	if maxValue > lnwire.NewMSatFromSatoshis(capacity) {
+		msg.err <- fmt.Errorf("max value in flight of %v exceeds "+
+			"the channel capacity of %v", maxValue, capacity)
+		return
+	}
+	err := lnwallet.VerifyConstraints(
+		remoteCsvDelay, maxHtlcs, maxValue, minHtlc, chanReserve,
+		ourDustLimit, capacity,
+	)
+	if err != nil {
+		msg.err <- err
+		return
+	}
+
+	// Before we reserve any funds, we'll ensure that the channel isn't
+	// larger than what we've negotiated with this peer.
+	maxChanSize := maxChanSizeForPeer(msg.peer, f.cfg.MaxChanSize)
+	if capacity > maxChanSize {
+		msg.err <- lnwallet.ErrChanTooLarge(capacity, maxChanSize)
+		return
+	}
+
 	// First, we'll query the fee estimator for a fee that should get the
 	// commitment transaction confirmed by the next few blocks (conf target
 	// of 3). We target the near blocks here to ensure that we'll be able
@@ -2664,6 +3087,12 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		PushMSat:        msg.pushAmt,
 		Flags:           channelFlags,
 		MinConfs:        msg.minConfs,
+		Outpoints:       msg.outpoints,
+		LockID:          msg.lockID,
+		UpfrontShutdown: msg.shutdownScript,
+		CommitType: commitTypeForPeer(
+			msg.peer, f.cfg.AnchorCommitments,
+		),
 	}
 
 	reservation, err := f.cfg.Wallet.InitChannelReservation(req)
@@ -2679,18 +3108,6 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	fndgLog.Infof("Target commit tx sat/kw for pendingID(%x): %v", chanID,
 		int64(commitFeePerKw))
 
-	// If the remote CSV delay was not set in the open channel request,
-	// we'll use the RequiredRemoteDelay closure to compute the delay we
-	// require given the total amount of funds within the channel.
-	if remoteCsvDelay == 0 {
-		remoteCsvDelay = f.cfg.RequiredRemoteDelay(capacity)
-	}
-
-	// If no minimum HTLC value was specified, use the default one.
-	if minHtlc == 0 {
-		minHtlc = f.cfg.DefaultRoutingPolicy.MinHTLC
-	}
-
 	// If a pending channel map for this peer isn't already created, then
 	// we create one, ultimately allowing us to track this pending
 	// reservation within the target peer.
@@ -2701,13 +3118,16 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	}
 
 	resCtx := &reservationWithCtx{
-		chanAmt:        capacity,
-		remoteCsvDelay: remoteCsvDelay,
-		remoteMinHtlc:  minHtlc,
-		reservation:    reservation,
-		peer:           msg.peer,
-		updates:        msg.updates,
-		err:            msg.err,
+		chanAmt:           capacity,
+		remoteCsvDelay:    remoteCsvDelay,
+		remoteMinHtlc:     minHtlc,
+		remoteChanReserve: chanReserve,
+		remoteMaxValue:    maxValue,
+		remoteMaxHtlcs:    maxHtlcs,
+		reservation:       reservation,
+		peer:              msg.peer,
+		updates:           msg.updates,
+		err:               msg.err,
 	}
 	f.activeReservations[peerIDKey][chanID] = resCtx
 	f.resMtx.Unlock()
@@ -2719,35 +3139,29 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	// request to the remote peer, kicking off the funding workflow.
 	ourContribution := reservation.OurContribution()
 
-	// Finally, we'll use the current value of the channels and our default
-	// policy to determine of required commitment constraints for the
-	// remote party.
-	chanReserve := f.cfg.RequiredRemoteChanReserve(capacity, ourDustLimit)
-	maxValue := f.cfg.RequiredRemoteMaxValue(capacity)
-	maxHtlcs := f.cfg.RequiredRemoteMaxHTLCs(capacity)
-
 	fndgLog.Infof("Starting funding workflow with %v for pendingID(%x)",
 		msg.peer.Address(), chanID)
 
 	fundingOpen := lnwire.OpenChannel{
-		ChainHash:            *f.cfg.Wallet.Cfg.NetParams.GenesisHash,
-		PendingChannelID:     chanID,
-		FundingAmount:        capacity,
-		PushAmount:           msg.pushAmt,
-		DustLimit:            ourContribution.DustLimit,
-		MaxValueInFlight:     maxValue,
-		ChannelReserve:       chanReserve,
-		HtlcMinimum:          minHtlc,
-		FeePerKiloWeight:     uint32(commitFeePerKw),
-		CsvDelay:             remoteCsvDelay,
-		MaxAcceptedHTLCs:     maxHtlcs,
-		FundingKey:           ourContribution.MultiSigKey.PubKey,
-		RevocationPoint:      ourContribution.RevocationBasePoint.PubKey,
-		PaymentPoint:         ourContribution.PaymentBasePoint.PubKey,
-		HtlcPoint:            ourContribution.HtlcBasePoint.PubKey,
-		DelayedPaymentPoint:  ourContribution.DelayBasePoint.PubKey,
-		FirstCommitmentPoint: ourContribution.FirstCommitmentPoint,
-		ChannelFlags:         channelFlags,
+		ChainHash:             *f.cfg.Wallet.Cfg.NetParams.GenesisHash,
+		PendingChannelID:      chanID,
+		FundingAmount:         capacity,
+		PushAmount:            msg.pushAmt,
+		DustLimit:             ourContribution.DustLimit,
+		MaxValueInFlight:      maxValue,
+		ChannelReserve:        chanReserve,
+		HtlcMinimum:           minHtlc,
+		FeePerKiloWeight:      uint32(commitFeePerKw),
+		CsvDelay:              remoteCsvDelay,
+		MaxAcceptedHTLCs:      maxHtlcs,
+		FundingKey:            ourContribution.MultiSigKey.PubKey,
+		RevocationPoint:       ourContribution.RevocationBasePoint.PubKey,
+		PaymentPoint:          ourContribution.PaymentBasePoint.PubKey,
+		HtlcPoint:             ourContribution.HtlcBasePoint.PubKey,
+		DelayedPaymentPoint:   ourContribution.DelayBasePoint.PubKey,
+		FirstCommitmentPoint:  ourContribution.FirstCommitmentPoint,
+		ChannelFlags:          channelFlags,
+		UpfrontShutdownScript: ourContribution.UpfrontShutdown,
 	}
 	if err := msg.peer.SendMessage(false, &fundingOpen); err != nil {
 		e := fmt.Errorf("Unable to send funding request message: %v",
@@ -1,8 +1,10 @@
+//go:build !rpctest
 // +build !rpctest
 
 package daemon
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -17,6 +19,7 @@ import (
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btclog"
 	"github.com/btcsuite/btcutil"
@@ -97,6 +100,7 @@ type mockNotifier struct {
 	oneConfChannel chan *chainntnfs.TxConfirmation
 	sixConfChannel chan *chainntnfs.TxConfirmation
 	epochChan      chan *chainntnfs.BlockEpoch
+	spendChan      chan *chainntnfs.SpendDetail
 }
 
 func (m *mockNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
@@ -131,7 +135,7 @@ func (m *mockNotifier) Stop() error {
 func (m *mockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint, _ []byte,
 	heightHint uint32) (*chainntnfs.SpendEvent, error) {
 	return &chainntnfs.SpendEvent{
-		Spend:  make(chan *chainntnfs.SpendDetail),
+		Spend:  m.spendChan,
 		Cancel: func() {},
 	}, nil
 }
@@ -147,6 +151,7 @@ type testNode struct {
 	mockNotifier    *mockNotifier
 	testDir         string
 	shutdownChannel chan struct{}
+	localFeatures   *lnwire.RawFeatureVector
 
 	remotePeer  *testNode
 	sendMessage func(lnwire.Message) error
@@ -162,6 +167,10 @@ func (n *testNode) Address() net.Addr {
 	return n.addr.Address
 }
 
+func (n *testNode) RemoteLocalFeatures() *lnwire.FeatureVector {
+	return lnwire.NewFeatureVector(n.localFeatures, lnwire.LocalFeatures)
+}
+
 func (n *testNode) PubKey() [33]byte {
 	return newSerializedKey(n.addr.IdentityKey)
 }
@@ -246,6 +255,7 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		oneConfChannel: make(chan *chainntnfs.TxConfirmation, 1),
 		sixConfChannel: make(chan *chainntnfs.TxConfirmation, 1),
 		epochChan:      make(chan *chainntnfs.BlockEpoch, 1),
+		spendChan:      make(chan *chainntnfs.SpendDetail, 1),
 	}
 
 	sentMessages := make(chan lnwire.Message)
@@ -351,6 +361,10 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		WatchNewChannel: func(*channeldb.OpenChannel, *btcec.PublicKey) error {
 			return nil
 		},
+		NotifyPendingOpenChanEvent: func(wire.OutPoint,
+			*channeldb.OpenChannel) {
+		},
+		NotifyOpenChannelEvent: func(wire.OutPoint) {},
 		ReportShortChanID: func(wire.OutPoint) error {
 			return nil
 		},
@@ -360,6 +374,7 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		},
 		ZombieSweeperInterval: 1 * time.Hour,
 		ReservationTimeout:    1 * time.Nanosecond,
+		MaxChanSize:           maxFundingAmount,
 	})
 	if err != nil {
 		t.Fatalf("failed creating fundingManager: %v", err)
@@ -443,8 +458,11 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 			publishChan <- txn
 			return nil
 		},
-		ZombieSweeperInterval: oldCfg.ZombieSweeperInterval,
-		ReservationTimeout:    oldCfg.ReservationTimeout,
+		NotifyPendingOpenChanEvent: oldCfg.NotifyPendingOpenChanEvent,
+		NotifyOpenChannelEvent:     oldCfg.NotifyOpenChannelEvent,
+		ZombieSweeperInterval:      oldCfg.ZombieSweeperInterval,
+		ReservationTimeout:         oldCfg.ReservationTimeout,
+		MaxChanSize:                oldCfg.MaxChanSize,
 	})
 	if err != nil {
 		t.Fatalf("failed recreating aliceFundingManager: %v", err)
@@ -679,6 +697,8 @@ func assertFundingMsgSent(t *testing.T, msgChan chan lnwire.Message,
 		ok      bool
 	)
 	switch msgType {
+	case "OpenChannel":
+		sentMsg, ok = msg.(*lnwire.OpenChannel)
 	case "AcceptChannel":
 		sentMsg, ok = msg.(*lnwire.AcceptChannel)
 	case "FundingCreated":
@@ -1650,6 +1670,61 @@ func TestFundingManagerFundingNotTimeoutInitiator(t *testing.T) {
 	assertNumPendingChannelsBecomes(t, bob, 0)
 }
 
+// TestFundingManagerFundingDoubleSpendInitiator checks that if we are the
+// channel initiator, the pending channel is forgotten once the funding
+// transaction has been double spent.
+func TestFundingManagerFundingDoubleSpendInitiator(t *testing.T) {
+	alice, bob := setupFundingManagers(t, defaultMaxPendingChannels)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// We will consume the channel updates as we go, so no buffering is needed.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	// Run through the process of opening the channel, up until the funding
+	// transaction is broadcasted.
+	fundingOutPoint := openChannel(
+		t, alice, bob, 500000, 0, 1, updateChan, true,
+	)
+
+	// Alice will at this point be waiting for the funding transaction to
+	// be confirmed, so the channel should be considered pending.
+	assertNumPendingChannelsRemains(t, alice, 1)
+
+	pendingChannels, err := alice.fundingMgr.cfg.Wallet.Cfg.Database.FetchPendingChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch pending channels: %v", err)
+	}
+	fundingTx := pendingChannels[0].FundingTxn
+	if fundingTx.TxHash() != fundingOutPoint.Hash {
+		t.Fatalf("expected funding tx %v, got %v",
+			fundingOutPoint.Hash, fundingTx.TxHash())
+	}
+
+	// Notify Alice that the input of the funding transaction was spent by
+	// a different transaction.
+	doubleSpendTx := wire.NewMsgTx(2)
+	doubleSpendTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: fundingTx.TxIn[0].PreviousOutPoint,
+	})
+	doubleSpendHash := doubleSpendTx.TxHash()
+	alice.mockNotifier.spendChan <- &chainntnfs.SpendDetail{
+		SpentOutPoint: &fundingTx.TxIn[0].PreviousOutPoint,
+		SpenderTxHash: &doubleSpendHash,
+		SpendingTx:    doubleSpendTx,
+	}
+
+	// Alice should have sent an Error message to Bob.
+	assertErrorSent(t, alice.msgChan)
+
+	// Since the funding transaction can no longer confirm, Alice should
+	// forget the channel.
+	assertNumPendingChannelsBecomes(t, alice, 0)
+
+	// Bob, not having seen the double spend, should still consider the
+	// channel pending.
+	assertNumPendingChannelsRemains(t, bob, 1)
+}
+
 // TestFundingManagerReceiveFundingLockedTwice checks that the fundingManager
 // continues to operate as expected in case we receive a duplicate fundingLocked
 // message.
@@ -2063,6 +2138,9 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 	// This is the custom parameters we'll use.
 	const csvDelay = 67
 	const minHtlc = 1234
+	const chanReserve = 10000
+	const maxValueInFlight = 2000000
+	const maxHtlcs = 30
 
 	// We will consume the channel updates as we go, so no buffering is
 	// needed.
@@ -2072,15 +2150,18 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 	// workflow.
 	errChan := make(chan error, 1)
 	initReq := &openChanReq{
-		targetPubkey:    bob.privKey.PubKey(),
-		chainHash:       *activeNetParams.GenesisHash,
-		localFundingAmt: 5000000,
-		pushAmt:         lnwire.NewMSatFromSatoshis(0),
-		private:         false,
-		minHtlc:         minHtlc,
-		remoteCsvDelay:  csvDelay,
-		updates:         updateChan,
-		err:             errChan,
+		targetPubkey:      bob.privKey.PubKey(),
+		chainHash:         *activeNetParams.GenesisHash,
+		localFundingAmt:   5000000,
+		pushAmt:           lnwire.NewMSatFromSatoshis(0),
+		private:           false,
+		minHtlc:           minHtlc,
+		remoteCsvDelay:    csvDelay,
+		remoteChanReserve: chanReserve,
+		remoteMaxValue:    maxValueInFlight,
+		remoteMaxHtlcs:    maxHtlcs,
+		updates:           updateChan,
+		err:               errChan,
 	}
 
 	alice.fundingMgr.initFundingWorkflow(bob, initReq)
@@ -2119,6 +2200,22 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 			minHtlc, openChannelReq.HtlcMinimum)
 	}
 
+	// Check that the custom channel reserve, max value in flight and max
+	// accepted HTLCs are sent.
+	if openChannelReq.ChannelReserve != chanReserve {
+		t.Fatalf("expected OpenChannel to have chanReserve %v, got %v",
+			chanReserve, openChannelReq.ChannelReserve)
+	}
+	if openChannelReq.MaxValueInFlight != maxValueInFlight {
+		t.Fatalf("expected OpenChannel to have maxValueInFlight %v, "+
+			"got %v", maxValueInFlight,
+			openChannelReq.MaxValueInFlight)
+	}
+	if openChannelReq.MaxAcceptedHTLCs != maxHtlcs {
+		t.Fatalf("expected OpenChannel to have maxHtlcs %v, got %v",
+			maxHtlcs, openChannelReq.MaxAcceptedHTLCs)
+	}
+
 	chanID := openChannelReq.PendingChannelID
 
 	// Let Bob handle the init message.
@@ -2186,6 +2283,27 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 		return nil
 	}
 
+	// Helper method for checking the constraints Bob is required to
+	// adhere to, stored within the passed contribution.
+	assertBobConstraints := func(
+		contribution *lnwallet.ChannelContribution) error {
+
+		if contribution.ChanReserve != chanReserve {
+			return fmt.Errorf("expected chanReserve to be %v, "+
+				"was %v", chanReserve, contribution.ChanReserve)
+		}
+		if contribution.MaxPendingAmount != maxValueInFlight {
+			return fmt.Errorf("expected maxValueInFlight to be "+
+				"%v, was %v", maxValueInFlight,
+				contribution.MaxPendingAmount)
+		}
+		if contribution.MaxAcceptedHtlcs != maxHtlcs {
+			return fmt.Errorf("expected maxHtlcs to be %v, was %v",
+				maxHtlcs, contribution.MaxAcceptedHtlcs)
+		}
+		return nil
+	}
+
 	// Check that the custom channel parameters were properly set in the
 	// channel reservation.
 	resCtx, err := alice.fundingMgr.getReservationCtx(bobPubKey, chanID)
@@ -2205,6 +2323,11 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	err = assertBobConstraints(resCtx.reservation.TheirContribution())
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	// Also make sure the parameters are properly set on Bob's end.
 	resCtx, err = bob.fundingMgr.getReservationCtx(alicePubKey, chanID)
 	if err != nil {
@@ -2219,6 +2342,11 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	err = assertBobConstraints(resCtx.reservation.OurContribution())
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	// Give the message to Bob.
 	bob.fundingMgr.processFundingCreated(fundingCreated, alice)
 
@@ -2278,6 +2406,74 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 	waitForOpenUpdate(t, updateChan)
 }
 
+// TestFundingManagerInvalidChannelParameters checks that custom requirements
+// outside of the bounds the remote peer will accept are rejected before the
+// funding flow is started.
+func TestFundingManagerInvalidChannelParameters(t *testing.T) {
+	alice, bob := setupFundingManagers(t, defaultMaxPendingChannels)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	const localAmt = 5000000
+
+	testCases := []struct {
+		name              string
+		remoteChanReserve btcutil.Amount
+		remoteMaxValue    lnwire.MilliSatoshi
+		remoteMaxHtlcs    uint16
+	}{
+		{
+			name:              "reserve below dust limit",
+			remoteChanReserve: 1,
+		},
+		{
+			name:              "reserve above 20% of capacity",
+			remoteChanReserve: localAmt/5 + 1,
+		},
+		{
+			name:           "max value in flight above capacity",
+			remoteMaxValue: lnwire.NewMSatFromSatoshis(localAmt + 1),
+		},
+		{
+			name:           "max value in flight too small",
+			remoteMaxValue: 1,
+		},
+		{
+			name:           "max htlcs above protocol maximum",
+			remoteMaxHtlcs: lnwallet.MaxHTLCNumber/2 + 1,
+		},
+		{
+			name:           "max htlcs too small",
+			remoteMaxHtlcs: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		errChan := make(chan error, 1)
+		initReq := &openChanReq{
+			targetPubkey:      bob.privKey.PubKey(),
+			chainHash:         *activeNetParams.GenesisHash,
+			localFundingAmt:   localAmt,
+			pushAmt:           lnwire.NewMSatFromSatoshis(0),
+			remoteChanReserve: test.remoteChanReserve,
+			remoteMaxValue:    test.remoteMaxValue,
+			remoteMaxHtlcs:    test.remoteMaxHtlcs,
+			updates:           make(chan *lnrpc.OpenStatusUpdate),
+			err:               errChan,
+		}
+
+		alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+		select {
+		case <-errChan:
+		case msg := <-alice.msgChan:
+			t.Fatalf("%s: expected funding request to fail, "+
+				"instead alice sent %T", test.name, msg)
+		case <-time.After(time.Second * 5):
+			t.Fatalf("%s: funding request did not fail", test.name)
+		}
+	}
+}
+
 // TestFundingManagerMaxPendingChannels checks that trying to open another
 // channel with the same peer when MaxPending channels are pending fails.
 func TestFundingManagerMaxPendingChannels(t *testing.T) {
@@ -2438,3 +2634,179 @@ func TestFundingManagerMaxPendingChannels(t *testing.T) {
 		t, bob.msgChan, "AcceptChannel",
 	).(*lnwire.AcceptChannel)
 }
+
+// TestFundingManagerLargeChannels checks that channels above the soft-limit
+// for channel size are only created if both peers signal support for large
+// channels.
+func TestFundingManagerLargeChannels(t *testing.T) {
+	alice, bob := setupFundingManagers(t, defaultMaxPendingChannels)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	largeChanAmt := maxFundingAmount * 2
+
+	// Alice is willing to open large channels, but Bob doesn't signal
+	// support for them yet, so Alice should refuse to initiate the
+	// funding flow.
+	alice.fundingMgr.cfg.MaxChanSize = largeChanAmt
+	alice.localFeatures = lnwire.NewRawFeatureVector(
+		lnwire.LargeChannelsOptional,
+	)
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: largeChanAmt,
+		pushAmt:         lnwire.NewMSatFromSatoshis(0),
+		private:         false,
+		updates:         updateChan,
+		err:             errChan,
+	}
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+	select {
+	case err := <-errChan:
+		if _, ok := err.(lnwallet.ReservationError); !ok {
+			t.Fatalf("expected ReservationError, got %T: %v",
+				err, err)
+		}
+	case <-alice.msgChan:
+		t.Fatalf("alice should not send OpenChannel message")
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not fail the funding workflow")
+	}
+
+	// If Bob signals support for large channels, Alice will go ahead and
+	// send the OpenChannel message. As Bob's own max channel size is
+	// still at the soft-limit, he should reject the channel.
+	bob.localFeatures = lnwire.NewRawFeatureVector(
+		lnwire.LargeChannelsOptional,
+	)
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+	openChannelReq := assertFundingMsgSent(
+		t, alice.msgChan, "OpenChannel",
+	).(*lnwire.OpenChannel)
+
+	bob.fundingMgr.processFundingOpen(openChannelReq, alice)
+	errMsg := assertFundingMsgSent(
+		t, bob.msgChan, "Error",
+	).(*lnwire.Error)
+
+	// Forward the error to Alice, such that she'll cancel her pending
+	// reservation.
+	alice.fundingMgr.processFundingError(errMsg, bob.privKey.PubKey())
+	select {
+	case <-errChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not cancel the funding workflow")
+	}
+	assertNumPendingReservations(t, alice, bobPubKey, 0)
+
+	// Once Bob also raises his max channel size, the large channel should
+	// be created successfully.
+	bob.fundingMgr.cfg.MaxChanSize = largeChanAmt
+	openChannel(t, alice, bob, largeChanAmt, 0, 1, updateChan, true)
+
+	assertErrorNotSent(t, alice.msgChan)
+	assertErrorNotSent(t, bob.msgChan)
+}
+
+// TestFundingManagerUpfrontShutdown checks that an upfront shutdown script
+// committed to by the initiator is sent to the responder, validated, and
+// persisted by both parties.
+func TestFundingManagerUpfrontShutdown(t *testing.T) {
+	alice, bob := setupFundingManagers(t, defaultMaxPendingChannels)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Alice will commit to paying her funds out to a p2wkh script upon a
+	// cooperative close.
+	shutdownScript := append(
+		[]byte{txscript.OP_0, txscript.OP_DATA_20},
+		bytes.Repeat([]byte{1}, 20)...,
+	)
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: 500000,
+		pushAmt:         lnwire.NewMSatFromSatoshis(0),
+		private:         false,
+		shutdownScript:  shutdownScript,
+		updates:         updateChan,
+		err:             errChan,
+	}
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+	// The OpenChannel message sent by Alice should include her upfront
+	// shutdown script.
+	openChannelReq := assertFundingMsgSent(
+		t, alice.msgChan, "OpenChannel",
+	).(*lnwire.OpenChannel)
+	if !bytes.Equal(openChannelReq.UpfrontShutdownScript, shutdownScript) {
+		t.Fatalf("expected upfront shutdown script %x, got %x",
+			shutdownScript, openChannelReq.UpfrontShutdownScript)
+	}
+
+	// If the script isn't one of the standard script types, then Bob
+	// should reject the channel.
+	invalidReq := *openChannelReq
+	invalidReq.UpfrontShutdownScript = []byte{txscript.OP_RETURN}
+	bob.fundingMgr.processFundingOpen(&invalidReq, alice)
+	assertErrorSent(t, bob.msgChan)
+
+	// Otherwise, the funding flow should proceed as normal.
+	bob.fundingMgr.processFundingOpen(openChannelReq, alice)
+	acceptChannelResponse := assertFundingMsgSent(
+		t, bob.msgChan, "AcceptChannel",
+	).(*lnwire.AcceptChannel)
+	if len(acceptChannelResponse.UpfrontShutdownScript) != 0 {
+		t.Fatalf("expected no upfront shutdown script from bob, got %x",
+			acceptChannelResponse.UpfrontShutdownScript)
+	}
+
+	alice.fundingMgr.processFundingAccept(acceptChannelResponse, bob)
+	fundingCreated := assertFundingMsgSent(
+		t, alice.msgChan, "FundingCreated",
+	).(*lnwire.FundingCreated)
+
+	bob.fundingMgr.processFundingCreated(fundingCreated, alice)
+	fundingSigned := assertFundingMsgSent(
+		t, bob.msgChan, "FundingSigned",
+	).(*lnwire.FundingSigned)
+
+	alice.fundingMgr.processFundingSigned(fundingSigned, bob)
+	select {
+	case <-updateChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenStatusUpdate_ChanPending")
+	}
+
+	// Both parties should now have persisted the upfront shutdown script
+	// within their pending channel.
+	assertShutdownScript := func(node *testNode, local bool) {
+		db := node.fundingMgr.cfg.Wallet.Cfg.Database
+		pendingChannels, err := db.FetchPendingChannels()
+		if err != nil {
+			t.Fatalf("unable to fetch pending channels: %v", err)
+		}
+		if len(pendingChannels) != 1 {
+			t.Fatalf("expected 1 pending channel, had %v",
+				len(pendingChannels))
+		}
+
+		script := pendingChannels[0].RemoteShutdownScript
+		if local {
+			script = pendingChannels[0].LocalShutdownScript
+		}
+		if !bytes.Equal(script, shutdownScript) {
+			t.Fatalf("expected upfront shutdown script %x, got %x",
+				shutdownScript, script)
+		}
+	}
+	assertShutdownScript(alice, true)
+	assertShutdownScript(bob, false)
+}
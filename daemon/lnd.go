@@ -40,6 +40,9 @@ import (
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/chainrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
 	"github.com/lightningnetwork/lnd/macaroons"
@@ -210,8 +213,11 @@ func LndMain(appDir string, lightningLis net.Listener, unlockerLis net.Listener)
 
 	// We wait until the user provides a password over RPC. In case lnd is
 	// started with the --noseedbackup flag, we use the default password
-	// for wallet encryption.
-	if !cfg.NoSeedBackup {
+	// for wallet encryption. A watch-only instance has neither a seed nor
+	// private keys to encrypt, as its wallet only holds the account public
+	// keys exported by the remote signer.
+	watchOnly := cfg.RemoteSigner.Mode == remoteSignerModeWatchOnly
+	if !cfg.NoSeedBackup && !watchOnly {
 		walletInitParams, err := waitForWalletPassword(
 			cfg.RPCListeners, cfg.RESTListeners, serverOpts,
 			unlockerLis, proxyOpts, tlsConf,
@@ -266,6 +272,28 @@ func LndMain(appDir string, lightningLis net.Listener, unlockerLis net.Listener)
 				return err
 			}
 		}
+
+		// The signer macaroon is created separately, so that nodes
+		// that already have the other macaroons get one as well.
+		if !fileExists(cfg.SignerMacPath) {
+			err = genSignerMacaroon(
+				ctx, macaroonService, cfg.SignerMacPath,
+			)
+			if err != nil {
+				ltndLog.Errorf("unable to create signer "+
+					"macaroon: %v", err)
+				return err
+			}
+		}
+	}
+
+	// In signer-only mode we don't connect to the chain or the network,
+	// we only serve signing requests of our watch-only instance.
+	if cfg.RemoteSigner.Mode == remoteSignerModeSigner {
+		return runRemoteSigner(
+			cfg, privateWalletPw, publicWalletPw, birthday,
+			recoveryWindow, unlockedWallet,
+		)
 	}
 
 	// With the information parsed from the configuration, create valid
@@ -339,6 +367,26 @@ func LndMain(appDir string, lightningLis net.Listener, unlockerLis net.Listener)
 	grpcServer := grpc.NewServer(serverOpts...)
 	lnrpc.RegisterLightningServer(grpcServer, rpcServer)
 
+	// The sub-services share the listeners of the main server, which
+	// doesn't authenticate its calls, so they validate the macaroon of
+	// each request themselves.
+	signrpc.RegisterSignerServer(grpcServer, signrpc.New(&signrpc.Config{
+		Signer:        activeChainControl.signer,
+		MessageSigner: activeChainControl.msgSigner,
+		KeyRing:       activeChainControl.keyRing,
+		MacService:    macaroonService,
+	}))
+	walletrpc.RegisterWalletKitServer(grpcServer, walletrpc.New(&walletrpc.Config{
+		KeyRing:      activeChainControl.keyRing,
+		Wallet:       activeChainControl.wallet,
+		FeeEstimator: activeChainControl.feeEstimator,
+		MacService:   macaroonService,
+	}))
+	chainrpc.RegisterChainNotifierServer(grpcServer, chainrpc.New(&chainrpc.Config{
+		ChainNotifier: activeChainControl.chainNotifier,
+		MacService:    macaroonService,
+	}))
+
 	// If a listener was provided to main(), we listen on it. If not, we go
 	// on listening on the regular listeners.
 	if lightningLis != nil {
@@ -650,6 +698,7 @@ func genMacaroons(ctx context.Context, svc *macaroons.Service,
 
 	// Generate the admin macaroon and write it to a file.
 	adminPermissions := append(readPermissions, writePermissions...)
+	adminPermissions = append(adminPermissions, signrpc.MacaroonOps...)
 	admMacaroon, err := svc.Oven.NewMacaroon(
 		ctx, bakery.LatestVersion, nil, adminPermissions...,
 	)
@@ -667,6 +716,29 @@ func genMacaroons(ctx context.Context, svc *macaroons.Service,
 	return nil
 }
 
+// genSignerMacaroon generates a macaroon that only grants access to the
+// Signer service and writes it to the passed file.
+func genSignerMacaroon(ctx context.Context, svc *macaroons.Service,
+	signerFile string) error {
+
+	signerMac, err := svc.Oven.NewMacaroon(
+		ctx, bakery.LatestVersion, nil, signrpc.MacaroonOps...,
+	)
+	if err != nil {
+		return err
+	}
+	signerMacBytes, err := signerMac.M().MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(signerFile, signerMacBytes, 0600); err != nil {
+		os.Remove(signerFile)
+		return err
+	}
+
+	return nil
+}
+
 // WalletUnlockParams holds the variables used to parameterize the unlocking of
 // lnd's wallet after it has already been created.
 type WalletUnlockParams struct {
@@ -713,6 +785,7 @@ func waitForWalletPassword(grpcEndpoints, restEndpoints []net.Addr,
 	macaroonFiles := []string{
 		filepath.Join(networkDir, macaroons.DBFilename),
 		cfg.AdminMacPath, cfg.ReadMacPath, cfg.InvoiceMacPath,
+		cfg.SignerMacPath,
 	}
 	pwService := walletunlocker.New(
 		chainConfig.ChainDir, activeNetParams.Params, macaroonFiles,
@@ -14,14 +14,20 @@ import (
 	"github.com/lightninglabs/neutrino"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/autopilot"
+	"github.com/lightningnetwork/lnd/chainfailover"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channelnotifier"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/discovery"
+	"github.com/lightningnetwork/lnd/electrum"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/remotesigner"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/signal"
+	"github.com/lightningnetwork/lnd/sweep"
 )
 
 // logWriter implements an io.Writer that outputs to both standard output and
@@ -75,6 +81,12 @@ var (
 	atplLog = backendLog.Logger("ATPL")
 	cnctLog = backendLog.Logger("CNCT")
 	sphxLog = backendLog.Logger("SPHX")
+	chbuLog = backendLog.Logger("CHBU")
+	chnfLog = backendLog.Logger("CHNF")
+	swprLog = backendLog.Logger("SWPR")
+	rsgnLog = backendLog.Logger("RSGN")
+	elecLog = backendLog.Logger("ELEC")
+	chfoLog = backendLog.Logger("CHFO")
 )
 
 // Initialize package-global logger variables.
@@ -90,6 +102,12 @@ func init() {
 	autopilot.UseLogger(atplLog)
 	contractcourt.UseLogger(cnctLog)
 	sphinx.UseLogger(sphxLog)
+	chanbackup.UseLogger(chbuLog)
+	channelnotifier.UseLogger(chnfLog)
+	sweep.UseLogger(swprLog)
+	remotesigner.UseLogger(rsgnLog)
+	electrum.UseLogger(elecLog)
+	chainfailover.UseLogger(chfoLog)
 	signal.UseLogger(ltndLog)
 }
 
@@ -113,6 +131,12 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"ATPL": atplLog,
 	"CNCT": cnctLog,
 	"SPHX": sphxLog,
+	"CHBU": chbuLog,
+	"CHNF": chnfLog,
+	"SWPR": swprLog,
+	"RSGN": rsgnLog,
+	"ELEC": elecLog,
+	"CHFO": chfoLog,
 }
 
 // initLogRotator initializes the logging rotator to write logs to logFile and
@@ -264,6 +264,9 @@ func (*mockWalletController) SubscribeTransactions() (lnwallet.TransactionSubscr
 func (*mockWalletController) IsSynced() (bool, int64, error) {
 	return true, int64(0), nil
 }
+func (*mockWalletController) RecoveryProgress() (*lnwallet.RecoveryProgress, error) {
+	return &lnwallet.RecoveryProgress{}, nil
+}
 func (*mockWalletController) Start() error {
 	return nil
 }
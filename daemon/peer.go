@@ -157,6 +157,21 @@ type peer struct {
 	// well as lnwire.ClosingSigned messages.
 	chanCloseMsgs chan *closeMsg
 
+	// activeSplices is a map that keeps track of all the active splice
+	// negotiations with the peer. Any splice related messages are
+	// directed to one of these state machines. Once the splice
+	// transaction has been broadcast, the state machine is deleted from
+	// the map.
+	activeSplices map[lnwire.ChannelID]*channelSplicer
+
+	// localSpliceReqs is a channel in which any local requests to splice
+	// funds out of a particular channel are sent over.
+	localSpliceReqs chan *spliceOutReq
+
+	// chanSpliceMsgs is a channel that any message related to channel
+	// splices are sent over.
+	chanSpliceMsgs chan *closeMsg
+
 	server *server
 
 	// localFeatures is the set of local features that we advertised to the
@@ -179,6 +194,12 @@ type peer struct {
 	// TODO(halseth): remove when link failure is properly handled.
 	failedChannels map[lnwire.ChannelID]struct{}
 
+	// restoredChans is the set of channels with this peer that have been
+	// restored from a static channel backup. These channels can't be used
+	// for updates, and only exist to carry out the data loss protection
+	// protocol with the peer.
+	restoredChans map[lnwire.ChannelID]*channeldb.OpenChannel
+
 	// writeBuf is a buffer that we'll re-use in order to encode wire
 	// messages to write out directly on the socket. By re-using this
 	// buffer, we avoid needing to allocate more memory each time a new
@@ -222,7 +243,11 @@ func newPeer(conn net.Conn, connReq *connmgr.ConnReq, server *server,
 		localCloseChanReqs: make(chan *htlcswitch.ChanClose),
 		linkFailures:       make(chan linkFailureReport),
 		chanCloseMsgs:      make(chan *closeMsg),
+		activeSplices:      make(map[lnwire.ChannelID]*channelSplicer),
+		localSpliceReqs:    make(chan *spliceOutReq),
+		chanSpliceMsgs:     make(chan *closeMsg),
 		failedChannels:     make(map[lnwire.ChannelID]struct{}),
+		restoredChans:      make(map[lnwire.ChannelID]*channeldb.OpenChannel),
 
 		queueQuit: make(chan struct{}),
 		quit:      make(chan struct{}),
@@ -309,7 +334,8 @@ func (p *peer) Start() error {
 	peerLog.Debugf("Loaded %v active channels from database with "+
 		"NodeKey(%x)", len(activeChans), p.PubKey())
 
-	if err := p.loadActiveChannels(activeChans); err != nil {
+	msgs, err := p.loadActiveChannels(activeChans)
+	if err != nil {
 		return fmt.Errorf("unable to load channels: %v", err)
 	}
 
@@ -322,6 +348,17 @@ func (p *peer) Start() error {
 	go p.channelManager()
 	go p.pingHandler()
 
+	// Now that the peer has started up, we send any channel sync messages
+	// that must be resent for restored channels.
+	if len(msgs) > 0 {
+		peerLog.Infof("Sending %d channel sync messages to peer after "+
+			"loading active channels", len(msgs))
+		if err := p.SendMessage(false, msgs...); err != nil {
+			peerLog.Warnf("Failed sending channel sync "+
+				"messages to peer %v: %v", p, err)
+		}
+	}
+
 	return nil
 }
 
@@ -373,15 +410,51 @@ func (p *peer) QuitSignal() <-chan struct{} {
 }
 
 // loadActiveChannels creates indexes within the peer for tracking all active
-// channels returned by the database.
-func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
-	var activePublicChans []wire.OutPoint
+// channels returned by the database. It returns a slice of channel sync
+// messages that should be sent to the peer for any channels that were
+// restored from a static channel backup.
+func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) (
+	[]lnwire.Message, error) {
+
+	var (
+		activePublicChans []wire.OutPoint
+		msgs              []lnwire.Message
+	)
 	for _, dbChan := range chans {
+		// If this channel was restored from a static channel backup,
+		// then we don't know its latest state, so we won't create a
+		// link for it. Instead, we'll send a channel sync message
+		// signalling our data loss, which should prompt the remote
+		// party to force close the channel.
+		if dbChan.HasChanStatus(channeldb.Restored) {
+			chanID := lnwire.NewChanIDFromOutPoint(
+				&dbChan.FundingOutpoint,
+			)
+
+			peerLog.Infof("NodeKey(%x) has restored "+
+				"ChannelPoint(%v), sending channel sync",
+				p.PubKey(), dbChan.FundingOutpoint)
+
+			chanSync, err := lnwallet.ChanSyncMsg(
+				dbChan, p.server.cc.signer,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create "+
+					"channel sync message for restored "+
+					"ChannelPoint(%v): %v",
+					dbChan.FundingOutpoint, err)
+			}
+
+			p.restoredChans[chanID] = dbChan
+			msgs = append(msgs, chanSync)
+			continue
+		}
+
 		lnChan, err := lnwallet.NewLightningChannel(
 			p.server.cc.signer, p.server.witnessBeacon, dbChan,
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		chanPoint := &dbChan.FundingOutpoint
@@ -412,7 +485,7 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		_, currentHeight, err := p.server.cc.chainIO.GetBestBlock()
 		if err != nil {
 			lnChan.Stop()
-			return err
+			return nil, err
 		}
 
 		// Before we register this new link with the HTLC Switch, we'll
@@ -422,7 +495,7 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		info, p1, p2, err := graph.FetchChannelEdgesByOutpoint(chanPoint)
 		if err != nil && err != channeldb.ErrEdgeNotFound {
 			lnChan.Stop()
-			return err
+			return nil, err
 		}
 
 		// We'll filter out our policy from the directional channel
@@ -470,7 +543,7 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		)
 		if err != nil {
 			lnChan.Stop()
-			return err
+			return nil, err
 		}
 
 		// Create the link and add it to the switch.
@@ -480,8 +553,8 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		)
 		if err != nil {
 			lnChan.Stop()
-			return fmt.Errorf("unable to add link %v to switch: %v",
-				chanPoint, err)
+			return nil, fmt.Errorf("unable to add link %v to "+
+				"switch: %v", chanPoint, err)
 		}
 
 		p.activeChanMtx.Lock()
@@ -514,7 +587,33 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		}
 	}()
 
-	return nil
+	return msgs, nil
+}
+
+// handleRestoredChanSync processes a channel reestablish message received for
+// a channel that was restored from a static channel backup. If the remote
+// party included its current commitment point, we'll store it, such that we
+// can sweep our funds once the channel is force closed.
+func (p *peer) handleRestoredChanSync(dbChan *channeldb.OpenChannel,
+	msg *lnwire.ChannelReestablish) {
+
+	if msg.LocalUnrevokedCommitPoint == nil {
+		peerLog.Warnf("Peer %v didn't send a commitment point for "+
+			"restored ChannelPoint(%v), unable to recover funds "+
+			"unless channel type is tweakless", p,
+			dbChan.FundingOutpoint)
+		return
+	}
+
+	peerLog.Infof("Received commitment point for restored "+
+		"ChannelPoint(%v) from peer %v", dbChan.FundingOutpoint, p)
+
+	err := dbChan.MarkDataLoss(msg.LocalUnrevokedCommitPoint)
+	if err != nil {
+		peerLog.Errorf("Unable to store commitment point for "+
+			"restored ChannelPoint(%v): %v",
+			dbChan.FundingOutpoint, err)
+	}
 }
 
 // addLink creates and adds a new link from the specified channel.
@@ -572,6 +671,7 @@ func (p *peer) addLink(chanPoint *wire.OutPoint,
 		UnsafeReplay:        cfg.UnsafeReplay,
 		MinFeeUpdateTimeout: htlcswitch.DefaultMinLinkFeeUpdateTimeout,
 		MaxFeeUpdateTimeout: htlcswitch.DefaultMaxLinkFeeUpdateTimeout,
+		NotifyChannelUpdate: p.server.channelNotifier.NotifyBalanceUpdateEvent,
 	}
 
 	link := htlcswitch.NewChannelLink(linkCfg, lnChan)
@@ -1010,6 +1110,31 @@ out:
 				break out
 			}
 
+		case *lnwire.SpliceInit:
+			select {
+			case p.chanSpliceMsgs <- &closeMsg{msg.ChanID, msg}:
+			case <-p.quit:
+				break out
+			}
+		case *lnwire.SpliceAck:
+			select {
+			case p.chanSpliceMsgs <- &closeMsg{msg.ChanID, msg}:
+			case <-p.quit:
+				break out
+			}
+		case *lnwire.SpliceCreated:
+			select {
+			case p.chanSpliceMsgs <- &closeMsg{msg.ChanID, msg}:
+			case <-p.quit:
+				break out
+			}
+		case *lnwire.SpliceSigned:
+			select {
+			case p.chanSpliceMsgs <- &closeMsg{msg.ChanID, msg}:
+			case <-p.quit:
+				break out
+			}
+
 		case *lnwire.Error:
 			key := p.addr.IdentityKey
 
@@ -1067,6 +1192,16 @@ out:
 			isChanUpdate = true
 			targetChan = msg.ChanID
 		case *lnwire.ChannelReestablish:
+			// If this is a channel we've restored from a static
+			// channel backup, then there's no link to handle the
+			// message. Instead, we'll store the commitment point
+			// the remote party sent us, which we need to sweep our
+			// funds once they force close the channel.
+			if dbChan, ok := p.restoredChans[msg.ChanID]; ok {
+				p.handleRestoredChanSync(dbChan, msg)
+				break
+			}
+
 			isChanUpdate = true
 			targetChan = msg.ChanID
 
@@ -1154,6 +1289,20 @@ func messageSummary(msg lnwire.Message) string {
 		return fmt.Sprintf("chan_id=%v, fee_sat=%v", msg.ChannelID,
 			msg.FeeSatoshis)
 
+	case *lnwire.SpliceInit:
+		return fmt.Sprintf("chan_id=%v, payout_amt=%v, fee_per_kw=%v, "+
+			"script=%x", msg.ChanID, msg.PayoutAmount,
+			msg.FeePerKiloWeight, msg.PayoutScript[:])
+
+	case *lnwire.SpliceAck:
+		return fmt.Sprintf("chan_id=%v", msg.ChanID)
+
+	case *lnwire.SpliceCreated:
+		return fmt.Sprintf("chan_id=%v", msg.ChanID)
+
+	case *lnwire.SpliceSigned:
+		return fmt.Sprintf("chan_id=%v", msg.ChanID)
+
 	case *lnwire.UpdateAddHTLC:
 		return fmt.Sprintf("chan_id=%v, id=%v, amt=%v, expiry=%v, hash=%x",
 			msg.ChanID, msg.ID, msg.Amount, msg.Expiry, msg.PaymentHash[:])
@@ -1509,6 +1658,21 @@ func (p *peer) genDeliveryScript() ([]byte, error) {
 	return txscript.PayToAddrScript(deliveryAddr)
 }
 
+// chooseDeliveryScript returns the script that our settled funds should be
+// paid out to within a cooperative close of the target channel. If we
+// committed to an upfront shutdown script during the funding workflow, then we
+// must use it, as the remote party will refuse any other script. Otherwise,
+// we'll generate a fresh delivery script.
+func (p *peer) chooseDeliveryScript(
+	channel *lnwallet.LightningChannel) ([]byte, error) {
+
+	if script := channel.State().LocalShutdownScript; len(script) != 0 {
+		return script, nil
+	}
+
+	return p.genDeliveryScript()
+}
+
 // channelManager is goroutine dedicated to handling all requests/signals
 // pertaining to the opening, cooperative closing, and force closing of all
 // channels maintained with the remote peer.
@@ -1722,6 +1886,18 @@ out:
 			// relevant sub-systems and launching a goroutine to
 			// wait for close tx conf.
 			p.finalizeChanClosure(chanCloser)
+
+		// We've just received a local request to splice funds out of
+		// an active channel, so we'll kick off the splice negotiation.
+		case req := <-p.localSpliceReqs:
+			p.handleLocalSpliceReq(req)
+
+		// We've received a splice related message from the remote
+		// peer, we'll use this message to advance the splice state
+		// machine.
+		case spliceMsg := <-p.chanSpliceMsgs:
+			p.handleSpliceMsg(spliceMsg)
+
 		case <-p.quit:
 
 			// As, we've been signalled to exit, we'll reset all
@@ -1766,7 +1942,7 @@ func (p *peer) fetchActiveChanCloser(chanID lnwire.ChannelID) (*channelCloser, e
 
 		// We'll create a valid closing state machine in order to
 		// respond to the initiated cooperative channel closure.
-		deliveryAddr, err := p.genDeliveryScript()
+		deliveryAddr, err := p.chooseDeliveryScript(channel)
 		if err != nil {
 			peerLog.Errorf("unable to gen delivery script: %v", err)
 
@@ -1793,11 +1969,17 @@ func (p *peer) fetchActiveChanCloser(chanID lnwire.ChannelID) (*channelCloser, e
 			chanCloseCfg{
 				channel:           channel,
 				unregisterChannel: p.server.htlcSwitch.RemoveLink,
-				broadcastTx:       p.server.cc.wallet.PublishTransaction,
+				broadcastTx: p.server.cc.wallet.LabelledPublisher(
+					lnwallet.CoopCloseLabel(
+						*channel.ChannelPoint(),
+					),
+				),
 				disableChannel: func(op wire.OutPoint) error {
 					return p.server.announceChanStatus(op,
 						true)
 				},
+				notifyClosingChannel: p.server.channelNotifier.
+					NotifyClosingChannelEvent,
 				quit: p.quit,
 			},
 			deliveryAddr,
@@ -1833,10 +2015,29 @@ func (p *peer) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 	// out this channel on-chain, so we execute the cooperative channel
 	// closure workflow.
 	case htlcswitch.CloseRegular:
-		// First, we'll fetch a fresh delivery address that we'll use
-		// to send the funds to in the case of a successful
-		// negotiation.
-		deliveryAddr, err := p.genDeliveryScript()
+		// First, we'll fetch the delivery address that we'll use to
+		// send the funds to in the case of a successful negotiation.
+		// If the caller specified one, we'll use it, as long as it
+		// doesn't conflict with the upfront shutdown script we
+		// committed to.
+		var (
+			deliveryAddr []byte
+			err          error
+		)
+		upfrontScript := channel.State().LocalShutdownScript
+		switch {
+		case len(req.DeliveryScript) == 0:
+			deliveryAddr, err = p.chooseDeliveryScript(channel)
+
+		case len(upfrontScript) != 0 &&
+			!bytes.Equal(upfrontScript, req.DeliveryScript):
+
+			err = fmt.Errorf("delivery address conflicts with "+
+				"upfront shutdown script %x", upfrontScript[:])
+
+		default:
+			deliveryAddr = req.DeliveryScript
+		}
 		if err != nil {
 			peerLog.Errorf(err.Error())
 			req.Err <- err
@@ -1856,11 +2057,17 @@ func (p *peer) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 			chanCloseCfg{
 				channel:           channel,
 				unregisterChannel: p.server.htlcSwitch.RemoveLink,
-				broadcastTx:       p.server.cc.wallet.PublishTransaction,
+				broadcastTx: p.server.cc.wallet.LabelledPublisher(
+					lnwallet.CoopCloseLabel(
+						*channel.ChannelPoint(),
+					),
+				),
 				disableChannel: func(op wire.OutPoint) error {
 					return p.server.announceChanStatus(op,
 						true)
 				},
+				notifyClosingChannel: p.server.channelNotifier.
+					NotifyClosingChannelEvent,
 				quit: p.quit,
 			},
 			deliveryAddr,
@@ -2084,6 +2291,211 @@ func waitForChanToClose(bestHeight uint32, notifier chainntnfs.ChainNotifier,
 	cb()
 }
 
+// handleLocalSpliceReq kicks off the workflow to splice funds out of a channel
+// with the peer, initiated by a local subsystem.
+func (p *peer) handleLocalSpliceReq(req *spliceOutReq) {
+	chanID := lnwire.NewChanIDFromOutPoint(&req.chanPoint)
+
+	p.activeChanMtx.RLock()
+	channel, ok := p.activeChannels[chanID]
+	p.activeChanMtx.RUnlock()
+	if !ok {
+		err := fmt.Errorf("unable to splice channel, ChannelID(%v) is "+
+			"unknown", chanID)
+		peerLog.Errorf(err.Error())
+		req.err <- err
+		return
+	}
+
+	if !p.localFeatures.IsSet(lnwire.SpliceOptional) ||
+		!p.remoteLocalFeatures.HasFeature(lnwire.SpliceOptional) {
+
+		req.err <- ErrSpliceNotSupported
+		return
+	}
+
+	_, chanClosing := p.activeChanCloses[chanID]
+	_, chanSplicing := p.activeSplices[chanID]
+	if chanClosing || chanSplicing {
+		req.err <- ErrChanAlreadySplicing
+		return
+	}
+
+	_, startingHeight, err := p.server.cc.chainIO.GetBestBlock()
+	if err != nil {
+		peerLog.Errorf(err.Error())
+		req.err <- err
+		return
+	}
+
+	splicer := newChannelSplicer(
+		chanSpliceCfg{
+			channel:           channel,
+			unregisterChannel: p.server.htlcSwitch.RemoveLink,
+			broadcastTx: p.server.cc.wallet.LabelledPublisher(
+				lnwallet.SpliceLabel(*channel.ChannelPoint()),
+			),
+			broadcastHeight: uint32(startingHeight),
+		},
+		req,
+	)
+	p.activeSplices[chanID] = splicer
+
+	initMsg, err := splicer.InitSplice()
+	if err != nil {
+		p.failSplice(splicer, err)
+		return
+	}
+
+	p.queueMsg(initMsg, nil)
+}
+
+// handleSpliceMsg advances the splice state machine of the channel the passed
+// message is directed at. If the remote peer is initiating a splice, a new
+// state machine is created.
+func (p *peer) handleSpliceMsg(spliceMsg *closeMsg) {
+	splicer, ok := p.activeSplices[spliceMsg.cid]
+	if !ok {
+		p.activeChanMtx.RLock()
+		channel, ok := p.activeChannels[spliceMsg.cid]
+		p.activeChanMtx.RUnlock()
+
+		// If the channel is not known to us, we'll simply ignore this
+		// message.
+		if !ok {
+			return
+		}
+
+		var err error
+		switch {
+		case !p.localFeatures.IsSet(lnwire.SpliceOptional):
+			err = ErrSpliceNotSupported
+
+		case p.activeChanCloses[spliceMsg.cid] != nil:
+			err = fmt.Errorf("channel is being closed")
+		}
+
+		var startingHeight int32
+		if err == nil {
+			_, startingHeight, err = p.server.cc.chainIO.GetBestBlock()
+		}
+		if err != nil {
+			peerLog.Errorf("Unable to respond to remote splice "+
+				"msg: %v", err)
+
+			p.queueMsg(&lnwire.Error{
+				ChanID: spliceMsg.cid,
+				Data:   lnwire.ErrorData(err.Error()),
+			}, nil)
+			return
+		}
+
+		splicer = newChannelSplicer(
+			chanSpliceCfg{
+				channel:           channel,
+				unregisterChannel: p.server.htlcSwitch.RemoveLink,
+				broadcastTx: p.server.cc.wallet.LabelledPublisher(
+					lnwallet.SpliceLabel(*channel.ChannelPoint()),
+				),
+				broadcastHeight: uint32(startingHeight),
+			},
+			nil,
+		)
+		p.activeSplices[spliceMsg.cid] = splicer
+	}
+
+	msgs, spliceFin, err := splicer.ProcessSpliceMsg(spliceMsg.msg)
+	if err != nil {
+		err := fmt.Errorf("unable to process splice msg: %v", err)
+		p.failSplice(splicer, err)
+
+		p.queueMsg(&lnwire.Error{
+			ChanID: spliceMsg.cid,
+			Data:   lnwire.ErrorData(err.Error()),
+		}, nil)
+		return
+	}
+
+	for _, msg := range msgs {
+		p.queueMsg(msg, nil)
+	}
+
+	if !spliceFin {
+		return
+	}
+
+	p.finalizeSplice(splicer)
+}
+
+// failSplice aborts the splice negotiation of the passed state machine. If no
+// pending splice has been persisted yet, the channel is returned to its normal
+// state. Otherwise, we'll still wait for the splice to confirm, as the remote
+// party may hold all signatures needed to broadcast it.
+func (p *peer) failSplice(splicer *channelSplicer, err error) {
+	peerLog.Error(err)
+
+	delete(p.activeSplices, splicer.cid)
+
+	if req := splicer.SpliceRequest(); req != nil {
+		req.err <- err
+	}
+
+	channel := splicer.cfg.channel
+	chanPoint := channel.ChannelPoint()
+	if err := p.WipeChannel(chanPoint); err != nil {
+		peerLog.Errorf("Unable to wipe ChannelPoint(%v): %v",
+			chanPoint, err)
+	}
+
+	dbChan := channel.State()
+	if dbChan.HasChanStatus(channeldb.SplicePending) {
+		p.server.fundingMgr.processSplice(dbChan)
+		return
+	}
+
+	// As the link was removed from the switch when the splice was
+	// initiated, we'll add the channel back to ourselves in order to
+	// create a fresh link for it.
+	go func() {
+		if err := p.AddNewChannel(dbChan, p.quit); err != nil {
+			peerLog.Errorf("Unable to restore ChannelPoint(%v) "+
+				"after failed splice: %v", chanPoint, err)
+		}
+	}()
+}
+
+// finalizeSplice wraps up a splice negotiation once the splice transaction
+// has been broadcast. The channel is removed from the peer until the splice
+// transaction confirms, at which point the fundingManager will hand it back
+// at its new channel point.
+func (p *peer) finalizeSplice(splicer *channelSplicer) {
+	delete(p.activeSplices, splicer.cid)
+
+	spliceReq := splicer.SpliceRequest()
+
+	spliceTx, err := splicer.SpliceTx()
+	if err != nil {
+		peerLog.Error(err)
+		if spliceReq != nil {
+			spliceReq.err <- err
+		}
+		return
+	}
+
+	chanPoint := splicer.cfg.channel.ChannelPoint()
+	if err := p.WipeChannel(chanPoint); err != nil {
+		peerLog.Errorf("Unable to wipe ChannelPoint(%v): %v",
+			chanPoint, err)
+	}
+
+	p.server.fundingMgr.processSplice(splicer.cfg.channel.State())
+
+	if spliceReq != nil {
+		spliceTxid := spliceTx.TxHash()
+		spliceReq.resp <- &spliceTxid
+	}
+}
+
 // WipeChannel removes the passed channel point from all indexes associated with
 // the peer, and the switch.
 func (p *peer) WipeChannel(chanPoint *wire.OutPoint) error {
@@ -2196,6 +2608,14 @@ func (p *peer) Address() net.Addr {
 	return p.addr.Address
 }
 
+// RemoteLocalFeatures returns the local feature vector that the remote peer
+// advertised within its init message.
+//
+// NOTE: Part of the lnpeer.Peer interface.
+func (p *peer) RemoteLocalFeatures() *lnwire.FeatureVector {
+	return p.remoteLocalFeatures
+}
+
 // AddNewChannel adds a new channel to the peer. The channel should fail to be
 // added if the cancel channel is closed.
 //
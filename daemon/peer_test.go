@@ -3,6 +3,7 @@
 package daemon
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -601,3 +602,226 @@ func TestPeerChannelClosureFeeNegotiationsInitiator(t *testing.T) {
 		t.Fatalf("closing tx not broadcast")
 	}
 }
+
+// TestPeerChannelClosureUpfrontShutdownScript tests that the shutdown
+// responder enforces the upfront shutdown script committed to by the remote
+// party, and that it pays out to its own upfront shutdown script.
+func TestPeerChannelClosureUpfrontShutdownScript(t *testing.T) {
+	t.Parallel()
+
+	notifier := &mockNotfier{
+		confChannel: make(chan *chainntnfs.TxConfirmation),
+	}
+	broadcastTxChan := make(chan *wire.MsgTx)
+
+	responder, responderChan, _, cleanUp, err := createTestPeer(
+		notifier, broadcastTxChan)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	chanID := lnwire.NewChanIDFromOutPoint(responderChan.ChannelPoint())
+
+	// Both parties committed to an upfront shutdown script during the
+	// funding workflow.
+	localScript := append([]byte{0x00, 0x14}, bytes.Repeat([]byte{1}, 20)...)
+	remoteScript := append([]byte{0x00, 0x14}, bytes.Repeat([]byte{2}, 20)...)
+	responderChan.State().LocalShutdownScript = localScript
+	responderChan.State().RemoteShutdownScript = remoteScript
+
+	// We send a shutdown request to Alice that pays out to a script other
+	// than the one we committed to. She should refuse to respond with a
+	// Shutdown message of her own.
+	responder.chanCloseMsgs <- &closeMsg{
+		cid: chanID,
+		msg: lnwire.NewShutdown(chanID, dummyDeliveryScript),
+	}
+
+	select {
+	case outMsg := <-responder.outgoingQueue:
+		t.Fatalf("expected shutdown to be rejected, instead got %T",
+			outMsg.msg)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// If we instead use the script we committed to, she should respond
+	// with a Shutdown message paying out to her own upfront shutdown
+	// script.
+	responder.chanCloseMsgs <- &closeMsg{
+		cid: chanID,
+		msg: lnwire.NewShutdown(chanID, remoteScript),
+	}
+
+	var msg lnwire.Message
+	select {
+	case outMsg := <-responder.outgoingQueue:
+		msg = outMsg.msg
+	case <-time.After(time.Second * 5):
+		t.Fatalf("did not receive shutdown message")
+	}
+
+	shutdownMsg, ok := msg.(*lnwire.Shutdown)
+	if !ok {
+		t.Fatalf("expected Shutdown message, got %T", msg)
+	}
+	if !bytes.Equal(shutdownMsg.Address, localScript) {
+		t.Fatalf("expected shutdown to pay out to %x, instead got %x",
+			localScript, shutdownMsg.Address)
+	}
+}
+
+// TestPeerChannelClosureMaxFee tests that the shutdown initiator pays out to
+// the delivery script specified by the caller, refuses to sign offers of the
+// remote party above the fee cap specified by the caller, and surfaces those
+// offers to the caller.
+func TestPeerChannelClosureMaxFee(t *testing.T) {
+	t.Parallel()
+
+	notifier := &mockNotfier{
+		confChannel: make(chan *chainntnfs.TxConfirmation),
+	}
+	broadcastTxChan := make(chan *wire.MsgTx)
+
+	initiator, initiatorChan, responderChan, cleanUp, err := createTestPeer(
+		notifier, broadcastTxChan)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// We make the initiator send a shutdown request, capping the fee rate
+	// at the target fee rate, and delivering to a script of our choosing.
+	const maxFeePerKw = lnwallet.SatPerKWeight(2500)
+	deliveryScript := append(
+		[]byte{0x00, 0x14}, bytes.Repeat([]byte{3}, 20)...,
+	)
+	updateChan := make(chan *lnrpc.CloseStatusUpdate, 2)
+	errChan := make(chan error, 1)
+	closeCommand := &htlcswitch.ChanClose{
+		CloseType:      htlcswitch.CloseRegular,
+		ChanPoint:      initiatorChan.ChannelPoint(),
+		Updates:        updateChan,
+		TargetFeePerKw: maxFeePerKw,
+		MaxFeePerKw:    maxFeePerKw,
+		DeliveryScript: deliveryScript,
+		Err:            errChan,
+	}
+	initiator.localCloseChanReqs <- closeCommand
+
+	var msg lnwire.Message
+	select {
+	case outMsg := <-initiator.outgoingQueue:
+		msg = outMsg.msg
+	case <-time.After(time.Second * 5):
+		t.Fatalf("did not receive shutdown request")
+	}
+
+	shutdownMsg, ok := msg.(*lnwire.Shutdown)
+	if !ok {
+		t.Fatalf("expected Shutdown message, got %T", msg)
+	}
+	if !bytes.Equal(shutdownMsg.Address, deliveryScript) {
+		t.Fatalf("expected shutdown to pay out to %x, instead got %x",
+			deliveryScript, shutdownMsg.Address)
+	}
+
+	// We'll answer the shutdown message with our own, after which the
+	// initiator should send its initial offer at the max fee.
+	chanID := shutdownMsg.ChannelID
+	initiator.chanCloseMsgs <- &closeMsg{
+		cid: chanID,
+		msg: lnwire.NewShutdown(chanID, dummyDeliveryScript),
+	}
+
+	select {
+	case outMsg := <-initiator.outgoingQueue:
+		msg = outMsg.msg
+	case <-time.After(time.Second * 5):
+		t.Fatalf("did not receive closing signed message")
+	}
+
+	closingSignedMsg, ok := msg.(*lnwire.ClosingSigned)
+	if !ok {
+		t.Fatalf("expected ClosingSigned message, got %T", msg)
+	}
+	maxFee := initiatorChan.CalcFee(maxFeePerKw)
+	if closingSignedMsg.FeeSatoshis != maxFee {
+		t.Fatalf("expected ClosingSigned fee to be %v, instead got %v",
+			maxFee, closingSignedMsg.FeeSatoshis)
+	}
+
+	sendOffer := func(fee btcutil.Amount) {
+		closeSig, _, _, err := responderChan.CreateCloseProposal(fee,
+			dummyDeliveryScript, deliveryScript)
+		if err != nil {
+			t.Fatalf("unable to create close proposal: %v", err)
+		}
+		parsedSig, err := lnwire.NewSigFromRawSignature(closeSig)
+		if err != nil {
+			t.Fatalf("unable to parse signature: %v", err)
+		}
+
+		initiator.chanCloseMsgs <- &closeMsg{
+			cid: chanID,
+			msg: lnwire.NewClosingSigned(chanID, fee, parsedSig),
+		}
+	}
+
+	// We'll now insist on a fee well above the cap. As the initiator
+	// already offered its max fee, it has nothing left to offer, and
+	// should refuse to sign.
+	sendOffer(maxFee * 3)
+
+	select {
+	case outMsg := <-initiator.outgoingQueue:
+		t.Fatalf("expected offer to be refused, instead got %T",
+			outMsg.msg)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// The refused offer should be surfaced to the caller.
+	var update *lnrpc.CloseStatusUpdate
+	select {
+	case update = <-updateChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("did not receive fee offer update")
+	}
+
+	offer, ok := update.Update.(*lnrpc.CloseStatusUpdate_FeeOffer)
+	if !ok {
+		t.Fatalf("expected fee offer update, got %T", update.Update)
+	}
+	if offer.FeeOffer.RemoteFeeSat != int64(maxFee*3) {
+		t.Fatalf("expected remote fee of %v, instead got %v",
+			maxFee*3, offer.FeeOffer.RemoteFeeSat)
+	}
+	if offer.FeeOffer.MaxFeeSat != int64(maxFee) {
+		t.Fatalf("expected max fee of %v, instead got %v",
+			maxFee, offer.FeeOffer.MaxFeeSat)
+	}
+	if !offer.FeeOffer.AboveMaxFee {
+		t.Fatalf("expected offer to be above max fee")
+	}
+
+	// Once we come down to the max fee, the initiator should accept it,
+	// and broadcast the closing transaction.
+	sendOffer(maxFee)
+
+	select {
+	case closeTx := <-broadcastTxChan:
+		var found bool
+		for _, txOut := range closeTx.TxOut {
+			if bytes.Equal(txOut.PkScript, deliveryScript) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("closing tx doesn't pay out to delivery script")
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("closing tx not broadcast")
+	}
+
+	notifier.confChannel <- &chainntnfs.TxConfirmation{}
+}
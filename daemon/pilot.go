@@ -28,6 +28,18 @@ type chanController struct {
 func (c *chanController) OpenChannel(target *btcec.PublicKey,
 	amt btcutil.Amount) error {
 
+	// The autopilot agent sizes channels according to its own configured
+	// bounds, so we'll make sure that we don't attempt to open a channel
+	// that's larger than what we've negotiated with the target peer.
+	if peer, err := c.server.FindPeer(target); err == nil {
+		maxChanSize := maxChanSizeForPeer(
+			peer, btcutil.Amount(cfg.MaxChanSize),
+		)
+		if amt > maxChanSize {
+			amt = maxChanSize
+		}
+	}
+
 	// With the connection established, we'll now establish our connection
 	// to the target peer, waiting for the first update before we exit.
 	feePerKw, err := c.server.cc.feeEstimator.EstimateFeePerKW(3)
@@ -0,0 +1,141 @@
+package daemon
+
+import (
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcwallet/wallet"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc/remotesignerrpc"
+	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+	"github.com/lightningnetwork/lnd/remotesigner"
+	"github.com/lightningnetwork/lnd/signal"
+	"google.golang.org/grpc"
+)
+
+// remoteSignerDBName is the name of the database in which lnd stores its state
+// in signer-only mode.
+const remoteSignerDBName = "remotesigner.db"
+
+// runRemoteSigner runs lnd in signer-only mode. The unlocked wallet isn't
+// connected to any chain backend, it is only used to derive keys and sign on
+// behalf of the watch-only instance connecting to us. This blocks until lnd
+// is shut down.
+func runRemoteSigner(cfg *config, privateWalletPw, publicWalletPw []byte,
+	birthday time.Time, recoveryWindow uint32,
+	unlockedWallet *wallet.Wallet) error {
+
+	homeChainConfig := cfg.Bitcoin
+	if registeredChains.PrimaryChain() == litecoinChain {
+		homeChainConfig = cfg.Litecoin
+	}
+
+	wc, err := btcwallet.New(btcwallet.Config{
+		PrivatePass:    privateWalletPw,
+		PublicPass:     publicWalletPw,
+		Birthday:       birthday,
+		RecoveryWindow: recoveryWindow,
+		DataDir:        homeChainConfig.ChainDir,
+		NetParams:      activeNetParams.Params,
+		CoinType:       activeNetParams.CoinType,
+		Wallet:         unlockedWallet,
+	})
+	if err != nil {
+		return err
+	}
+	if err := wc.StartSigner(); err != nil {
+		return err
+	}
+
+	// The channels registered by the watch-only instance are persisted
+	// next to the channel database of the signer.
+	store, err := remotesigner.OpenStore(filepath.Join(
+		cfg.DataDir, defaultGraphSubDirname,
+		normalizeNetwork(activeNetParams.Name), remoteSignerDBName,
+	))
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	keyRing := keychain.NewBtcWalletKeyRing(
+		wc.InternalWallet(), activeNetParams.CoinType,
+	)
+	signerServer, err := remotesigner.NewServer(
+		wc, wc, wc, keyRing, store,
+	)
+	if err != nil {
+		return err
+	}
+
+	creds, err := remotesigner.ServerCredentials(
+		cfg.TLSCertPath, cfg.TLSKeyPath, cfg.RemoteSigner.PeerCertPath,
+	)
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer(grpc.Creds(creds))
+	remotesignerrpc.RegisterRemoteSignerServer(grpcServer, signerServer)
+
+	lis, err := net.Listen("tcp", cfg.RemoteSigner.Listen)
+	if err != nil {
+		return err
+	}
+	defer lis.Close()
+
+	go func() {
+		ltndLog.Infof("Remote signer listening on %s", lis.Addr())
+		grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	<-signal.ShutdownChannel()
+	return nil
+}
+
+// newRemoteSignerClient connects the watch-only instance to its remote signer.
+// The signer persists the channels registered with it, so they don't need to
+// be registered again. The passed watch-only wallet is used to look up the
+// keys of the wallet inputs the signer signs.
+func newRemoteSignerClient(cfg *config,
+	wallet remotesigner.WalletKeyPaths) (*remotesigner.Client, error) {
+
+	creds, err := remotesigner.ClientCredentials(
+		cfg.TLSCertPath, cfg.TLSKeyPath, cfg.RemoteSigner.PeerCertPath,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return remotesigner.Dial(cfg.RemoteSigner.RPCHost, creds, wallet)
+}
+
+// importWatchOnlyWallet makes sure the watch-only instance has a wallet. On
+// the first start, the watch-only copy of the wallet of the remote signer is
+// fetched and stored, after which the instance runs from it.
+func importWatchOnlyWallet(cfg *config,
+	walletConfig *btcwallet.Config) error {
+
+	walletExists, err := btcwallet.WalletExists(*walletConfig)
+	if err != nil || walletExists {
+		return err
+	}
+
+	creds, err := remotesigner.ClientCredentials(
+		cfg.TLSCertPath, cfg.TLSKeyPath, cfg.RemoteSigner.PeerCertPath,
+	)
+	if err != nil {
+		return err
+	}
+	walletDB, err := remotesigner.FetchWatchOnlyWallet(
+		cfg.RemoteSigner.RPCHost, creds,
+	)
+	if err != nil {
+		return err
+	}
+
+	ltndLog.Infof("Importing watch-only wallet of remote signer")
+
+	return btcwallet.ImportWatchOnly(*walletConfig, walletDB)
+}
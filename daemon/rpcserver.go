@@ -24,13 +24,16 @@ import (
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channelnotifier"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/signal"
+	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/tv42/zbase32"
 	"golang.org/x/net/context"
@@ -156,6 +159,26 @@ var (
 			Entity: "onchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/BumpFee": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/LabelTransaction": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ListUnspent": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/LeaseOutput": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ReleaseOutput": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/NewAddress": {{
 			Entity: "address",
 			Action: "write",
@@ -201,10 +224,28 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/SpliceOut": {{
+			Entity: "offchain",
+			Action: "write",
+		}, {
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/BumpPendingChannel": {{
+			Entity: "offchain",
+			Action: "write",
+		}, {
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/GetInfo": {{
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/GetRecoveryInfo": {{
+			Entity: "info",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/ListPeers": {{
 			Entity: "peers",
 			Action: "read",
@@ -229,6 +270,10 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/SubscribeChannelEvents": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/SendPayment": {{
 			Entity: "offchain",
 			Action: "write",
@@ -309,6 +354,13 @@ var (
 			Entity: "info",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/DebugChannelState": {{
+			Entity: "offchain",
+			Action: "write",
+		}, {
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/DecodePayReq": {{
 			Entity: "offchain",
 			Action: "read",
@@ -325,6 +377,18 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ExportChannelBackup": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/VerifyChanBackup": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/RestoreChannelBackups": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 	}
 )
 
@@ -398,8 +462,11 @@ func addrPairsToOutputs(addrPairs map[string]int64) ([]*wire.TxOut, error) {
 
 // sendCoinsOnChain makes an on-chain transaction in or to send coins to one or
 // more addresses specified in the passed payment map. The payment map maps an
-// address to a specified output value to be sent to that address.
+// address to a specified output value to be sent to that address. If
+// outpoints are passed, exactly these wallet outputs are spent. Outputs leased
+// under the passed lock ID may be spent as well.
 func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
+	outpoints []*lnrpc.OutPoint, lockID []byte,
 	feeRate lnwallet.SatPerKWeight) (*chainhash.Hash, error) {
 
 	outputs, err := addrPairsToOutputs(paymentMap)
@@ -407,7 +474,102 @@ func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
 		return nil, err
 	}
 
-	return r.server.cc.wallet.SendOutputs(outputs, feeRate)
+	if len(outpoints) == 0 {
+		return r.server.cc.wallet.SendOutputs(outputs, feeRate)
+	}
+
+	inputs, err := getOutPoints(outpoints)
+	if err != nil {
+		return nil, err
+	}
+	id, err := getOptionalLockID(lockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.server.cc.wallet.SendOutputsWithInputs(
+		inputs, id, outputs, feeRate,
+	)
+}
+
+// sweepWalletOnChain sends all confirmed outputs of the wallet, or exactly the
+// passed outpoints, to the given address in a single output. Outputs leased
+// under the passed lock ID may be spent as well.
+func (r *rpcServer) sweepWalletOnChain(address string,
+	outpoints []*lnrpc.OutPoint, lockID []byte,
+	feeRate lnwallet.SatPerKWeight) (*chainhash.Hash, error) {
+
+	addr, err := btcutil.DecodeAddress(address, activeNetParams.Params)
+	if err != nil {
+		return nil, err
+	}
+	if !addr.IsForNet(activeNetParams.Params) {
+		return nil, fmt.Errorf("address %v is not for the active "+
+			"network", address)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := getOutPoints(outpoints)
+	if err != nil {
+		return nil, err
+	}
+	id, err := getOptionalLockID(lockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.server.cc.wallet.SweepAllOutputs(inputs, id, pkScript, feeRate)
+}
+
+// validateTxLabel checks that the optional label of a transaction can be
+// stored, so that invalid labels are rejected before any coins are sent.
+func validateTxLabel(label string) error {
+	if len(label) > channeldb.MaxTxLabelLength {
+		return fmt.Errorf("label length %v exceeds maximum of %v",
+			len(label), channeldb.MaxTxLabelLength)
+	}
+
+	return nil
+}
+
+// labelSentTx stores the label the user supplied for a transaction that has
+// already been published. As the coins have been sent at this point, a failure
+// is only logged.
+func (r *rpcServer) labelSentTx(txid chainhash.Hash, label string) {
+	if label == "" {
+		return
+	}
+
+	err := r.server.cc.wallet.LabelTransaction(txid, label, false)
+	if err != nil {
+		rpcsLog.Errorf("Unable to label tx %v: %v", txid, err)
+	}
+}
+
+// parseUpfrontShutdownAddress converts the optional close address of an open
+// channel request into the upfront shutdown script that we'll commit to during
+// the funding workflow. If no address was specified, then a nil script is
+// returned, signalling that we won't commit to any particular script.
+func parseUpfrontShutdownAddress(address string) (lnwire.DeliveryAddress,
+	error) {
+
+	if address == "" {
+		return nil, nil
+	}
+
+	addr, err := btcutil.DecodeAddress(address, activeNetParams.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid close address: %v", err)
+	}
+	if !addr.IsForNet(activeNetParams.Params) {
+		return nil, fmt.Errorf("close address %v is not for the "+
+			"active network", address)
+	}
+
+	return txscript.PayToAddrScript(addr)
 }
 
 // determineFeePerKw will determine the fee in sat/kw that should be paid given
@@ -461,6 +623,10 @@ func determineFeePerKw(feeEstimator lnwallet.FeeEstimator, targetConf int32,
 func (r *rpcServer) SendCoins(ctx context.Context,
 	in *lnrpc.SendCoinsRequest) (*lnrpc.SendCoinsResponse, error) {
 
+	if err := validateTxLabel(in.Label); err != nil {
+		return nil, err
+	}
+
 	// Based on the passed fee related parameters, we'll determine an
 	// appropriate fee rate for this transaction.
 	feePerKw, err := determineFeePerKw(
@@ -470,17 +636,38 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		return nil, err
 	}
 
-	rpcsLog.Infof("[sendcoins] addr=%v, amt=%v, sat/kw=%v", in.Addr,
-		btcutil.Amount(in.Amount), int64(feePerKw))
+	var txid *chainhash.Hash
+	if in.SendAll {
+		// When sweeping the wallet, the amount is determined by the
+		// value of the inputs and the fee.
+		if in.Amount != 0 {
+			return nil, fmt.Errorf("amount must not be set when " +
+				"sending all coins")
+		}
+
+		rpcsLog.Infof("[sendcoins] addr=%v, send_all=true, sat/kw=%v",
+			in.Addr, int64(feePerKw))
+
+		txid, err = r.sweepWalletOnChain(
+			in.Addr, in.Outpoints, in.LockId, feePerKw,
+		)
+	} else {
+		rpcsLog.Infof("[sendcoins] addr=%v, amt=%v, sat/kw=%v", in.Addr,
+			btcutil.Amount(in.Amount), int64(feePerKw))
 
-	paymentMap := map[string]int64{in.Addr: in.Amount}
-	txid, err := r.sendCoinsOnChain(paymentMap, feePerKw)
+		paymentMap := map[string]int64{in.Addr: in.Amount}
+		txid, err = r.sendCoinsOnChain(
+			paymentMap, in.Outpoints, in.LockId, feePerKw,
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	rpcsLog.Infof("[sendcoins] spend generated txid: %v", txid.String())
 
+	r.labelSentTx(*txid, in.Label)
+
 	return &lnrpc.SendCoinsResponse{Txid: txid.String()}, nil
 }
 
@@ -489,6 +676,10 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 func (r *rpcServer) SendMany(ctx context.Context,
 	in *lnrpc.SendManyRequest) (*lnrpc.SendManyResponse, error) {
 
+	if err := validateTxLabel(in.Label); err != nil {
+		return nil, err
+	}
+
 	// Based on the passed fee related parameters, we'll determine an
 	// appropriate fee rate for this transaction.
 	feePerKw, err := determineFeePerKw(
@@ -501,16 +692,277 @@ func (r *rpcServer) SendMany(ctx context.Context,
 	rpcsLog.Infof("[sendmany] outputs=%v, sat/kw=%v",
 		spew.Sdump(in.AddrToAmount), int64(feePerKw))
 
-	txid, err := r.sendCoinsOnChain(in.AddrToAmount, feePerKw)
+	txid, err := r.sendCoinsOnChain(
+		in.AddrToAmount, in.Outpoints, in.LockId, feePerKw,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	rpcsLog.Infof("[sendmany] spend generated txid: %v", txid.String())
 
+	r.labelSentTx(*txid, in.Label)
+
 	return &lnrpc.SendManyResponse{Txid: txid.String()}, nil
 }
 
+// BumpFee attempts to speed up the confirmation of an unconfirmed transaction
+// created by lnd, identified by one of its outputs. Sweep transactions that
+// signal replaceability are replaced by a transaction with a higher fee
+// (RBF). Otherwise, the passed output is spent in a child transaction that
+// pays for the parent (CPFP).
+func (r *rpcServer) BumpFee(ctx context.Context,
+	in *lnrpc.BumpFeeRequest) (*lnrpc.BumpFeeResponse, error) {
+
+	if in.Outpoint == nil {
+		return nil, fmt.Errorf("outpoint must be set")
+	}
+	op, err := getOutPoint(in.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Based on the passed fee related parameters, we'll determine an
+	// appropriate fee rate for the bump.
+	feePerKw, err := determineFeePerKw(
+		r.server.cc.feeEstimator, in.TargetConf, in.SatPerByte,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[bumpfee] outpoint=%v, sat/kw=%v", op, int64(feePerKw))
+
+	// If the transaction is an unconfirmed sweep, we'll replace it. A
+	// confirmation target is handed to the sweeper as is, so that later
+	// sweeps of the inputs keep following the fee estimates.
+	feePref := sweep.FeePreference{FeeRate: feePerKw}
+	if in.TargetConf != 0 && in.SatPerByte == 0 {
+		feePref = sweep.FeePreference{ConfTarget: uint32(in.TargetConf)}
+	}
+	replacement, err := r.server.sweeper.BumpFee(op.Hash, feePref)
+	switch err {
+	case nil:
+		replacementTxid := replacement.TxHash()
+		rpcsLog.Infof("[bumpfee] replaced tx %v by %v", op.Hash,
+			replacementTxid)
+
+		return &lnrpc.BumpFeeResponse{
+			Txid:     replacementTxid.String(),
+			Replaced: true,
+		}, nil
+
+	// Otherwise, we'll fall back to CPFP.
+	case sweep.ErrTxNotFound, sweep.ErrNotReplaceable:
+
+	default:
+		return nil, err
+	}
+
+	txDetails, err := r.server.cc.wallet.ListTransactionDetails()
+	if err != nil {
+		return nil, err
+	}
+	var parent *lnwallet.TransactionDetail
+	for _, txDetail := range txDetails {
+		if txDetail.Hash == op.Hash {
+			parent = txDetail
+			break
+		}
+	}
+	switch {
+	case parent == nil || parent.RawTx == nil:
+		return nil, fmt.Errorf("transaction %v not found in wallet",
+			op.Hash)
+
+	case parent.NumConfirmations > 0:
+		return nil, fmt.Errorf("transaction %v is already confirmed",
+			op.Hash)
+	}
+
+	// The fee of the parent is only known if all of its inputs belong to
+	// the wallet. Otherwise, the child pays for the entire package.
+	childTx, err := r.server.cc.wallet.CreateCPFP(
+		parent.RawTx, op.Index, btcutil.Amount(parent.TotalFees),
+		feePerKw,
+	)
+	if err != nil {
+		rpcsLog.Errorf("[bumpfee] unable to create child tx for %v: %v",
+			op, err)
+		return nil, err
+	}
+
+	publishTx := r.server.cc.wallet.LabelledPublisher(lnwallet.CPFPLabel(*op))
+	if err := publishTx(childTx); err != nil {
+		rpcsLog.Errorf("[bumpfee] unable to publish child tx for %v: "+
+			"%v", op, err)
+		return nil, err
+	}
+
+	childTxid := childTx.TxHash()
+	rpcsLog.Infof("[bumpfee] published child tx %v for %v", childTxid, op)
+
+	return &lnrpc.BumpFeeResponse{
+		Txid: childTxid.String(),
+	}, nil
+}
+
+// ListUnspent returns all witness outputs of the wallet that have between
+// MinConfs and MaxConfs confirmations. A MaxConfs of zero means that there is
+// no upper limit.
+func (r *rpcServer) ListUnspent(ctx context.Context,
+	in *lnrpc.ListUnspentRequest) (*lnrpc.ListUnspentResponse, error) {
+
+	switch {
+	case in.MinConfs < 0:
+		return nil, fmt.Errorf("min_confs must be non-negative")
+
+	case in.MaxConfs < 0:
+		return nil, fmt.Errorf("max_confs must be non-negative")
+
+	case in.MaxConfs != 0 && in.MaxConfs < in.MinConfs:
+		return nil, fmt.Errorf("max_confs must be greater than or " +
+			"equal to min_confs")
+	}
+
+	// Unconfirmed outputs are only returned by the wallet if we ask for
+	// them explicitly.
+	minConfs := in.MinConfs
+	if minConfs == 0 {
+		minConfs = -1
+	}
+	utxos, err := r.server.cc.wallet.ListUnspentWitness(minConfs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListUnspentResponse{}
+	for _, utxo := range utxos {
+		if in.MaxConfs != 0 && utxo.Confirmations > int64(in.MaxConfs) {
+			continue
+		}
+
+		var addrType lnrpc.NewAddressRequest_AddressType
+		switch utxo.AddressType {
+		case lnwallet.WitnessPubKey:
+			addrType = lnrpc.NewAddressRequest_WITNESS_PUBKEY_HASH
+		case lnwallet.NestedWitnessPubKey:
+			addrType = lnrpc.NewAddressRequest_NESTED_PUBKEY_HASH
+		default:
+			continue
+		}
+
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			utxo.PkScript, activeNetParams.Params,
+		)
+		if err != nil {
+			return nil, err
+		}
+		var addr string
+		if len(addrs) > 0 {
+			addr = addrs[0].String()
+		}
+
+		resp.Utxos = append(resp.Utxos, &lnrpc.Utxo{
+			Type:      addrType,
+			Address:   addr,
+			AmountSat: int64(utxo.Value),
+			PkScript:  hex.EncodeToString(utxo.PkScript),
+			Outpoint: &lnrpc.OutPoint{
+				Txid: &lnrpc.OutPoint_TxidStr{
+					TxidStr: utxo.OutPoint.Hash.String(),
+				},
+				OutputIndex: utxo.OutPoint.Index,
+			},
+			Confirmations: utxo.Confirmations,
+		})
+	}
+
+	return resp, nil
+}
+
+// getLockID parses the lock ID of an output lease.
+func getLockID(id []byte) ([32]byte, error) {
+	var lockID [32]byte
+	if len(id) != len(lockID) {
+		return lockID, fmt.Errorf("lock ID must be %v bytes, got %v",
+			len(lockID), len(id))
+	}
+	copy(lockID[:], id)
+
+	return lockID, nil
+}
+
+// getOptionalLockID parses the passed lock ID, returning nil if it isn't set.
+func getOptionalLockID(id []byte) (*[32]byte, error) {
+	if len(id) == 0 {
+		return nil, nil
+	}
+
+	lockID, err := getLockID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &lockID, nil
+}
+
+// LeaseOutput locks an output of the wallet under the passed lock ID,
+// excluding it from automatic coin selection until the lease is released or
+// expires.
+func (r *rpcServer) LeaseOutput(ctx context.Context,
+	in *lnrpc.LeaseOutputRequest) (*lnrpc.LeaseOutputResponse, error) {
+
+	lockID, err := getLockID(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if in.Outpoint == nil {
+		return nil, fmt.Errorf("outpoint must be set")
+	}
+	op, err := getOutPoint(in.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(in.ExpirationSeconds) * time.Second
+	expiration, err := r.server.cc.wallet.LeaseOutput(lockID, *op, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[leaseoutput] outpoint=%v, expiration=%v", op,
+		expiration)
+
+	return &lnrpc.LeaseOutputResponse{
+		Expiration: uint64(expiration.Unix()),
+	}, nil
+}
+
+// ReleaseOutput releases a lease of an output before it expires.
+func (r *rpcServer) ReleaseOutput(ctx context.Context,
+	in *lnrpc.ReleaseOutputRequest) (*lnrpc.ReleaseOutputResponse, error) {
+
+	lockID, err := getLockID(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if in.Outpoint == nil {
+		return nil, fmt.Errorf("outpoint must be set")
+	}
+	op, err := getOutPoint(in.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.server.cc.wallet.ReleaseOutput(lockID, *op); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[releaseoutput] outpoint=%v", op)
+
+	return &lnrpc.ReleaseOutputResponse{}, nil
+}
+
 // NewAddress creates a new address under control of the local wallet.
 func (r *rpcServer) NewAddress(ctx context.Context,
 	in *lnrpc.NewAddressRequest) (*lnrpc.NewAddressResponse, error) {
@@ -727,6 +1179,9 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 	remoteInitialBalance := btcutil.Amount(in.PushSat)
 	minHtlc := lnwire.MilliSatoshi(in.MinHtlcMsat)
 	remoteCsvDelay := uint16(in.RemoteCsvDelay)
+	remoteChanReserve := btcutil.Amount(in.RemoteChanReserveSat)
+	remoteMaxValue := lnwire.MilliSatoshi(in.RemoteMaxValueInFlightMsat)
+	remoteMaxHtlcs := uint16(in.RemoteMaxHtlcs)
 
 	// Ensure that the initial balance of the remote party (if pushing
 	// satoshis) does not exceed the amount the local party has requested
@@ -738,12 +1193,13 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 			"state must be below the local funding amount")
 	}
 
-	// Ensure that the user doesn't exceed the current soft-limit for
-	// channel size. If the funding amount is above the soft-limit, then
-	// we'll reject the request.
-	if localFundingAmt > maxFundingAmount {
+	// Ensure that the user doesn't exceed our configured max channel
+	// size. If the funding amount is above it, then we'll reject the
+	// request.
+	maxChanSize := btcutil.Amount(cfg.MaxChanSize)
+	if localFundingAmt > maxChanSize {
 		return fmt.Errorf("funding amount is too large, the max "+
-			"channel size is: %v", maxFundingAmount)
+			"channel size is: %v", maxChanSize)
 	}
 
 	// Restrict the size of the channel we'll actually open. At a later
@@ -754,6 +1210,18 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 			"size is: %v SAT", int64(minChanFundingSize))
 	}
 
+	// Ensure the requested remote constraints fit within the bounds of
+	// the protocol. The remaining checks are left to the funding manager,
+	// which knows the full set of channel parameters.
+	if in.RemoteChanReserveSat < 0 {
+		return errors.New("channel reserve must be a " +
+			"non-negative number")
+	}
+	if in.RemoteMaxHtlcs > uint32(lnwallet.MaxHTLCNumber/2) {
+		return fmt.Errorf("max htlcs must not exceed %v",
+			lnwallet.MaxHTLCNumber/2)
+	}
+
 	// Ensure that the MinConfs parameter is non-negative.
 	if in.MinConfs < 0 {
 		return errors.New("minimum number of confirmations must be a " +
@@ -766,6 +1234,24 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 		err             error
 	)
 
+	// If the user specified a close address, then we'll commit to it as
+	// our upfront shutdown script.
+	shutdownScript, err := parseUpfrontShutdownAddress(in.CloseAddress)
+	if err != nil {
+		return err
+	}
+
+	// If the user picked the outputs to fund the channel with, we'll
+	// spend exactly these.
+	outpoints, err := getOutPoints(in.Outpoints)
+	if err != nil {
+		return err
+	}
+	lockID, err := getOptionalLockID(in.LockId)
+	if err != nil {
+		return err
+	}
+
 	// TODO(roasbeef): also return channel ID?
 
 	// Ensure that the NodePubKey is set before attempting to use it
@@ -786,6 +1272,19 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 		return fmt.Errorf("cannot open channel to self")
 	}
 
+	// If we're already connected to the peer, then we'll also ensure that
+	// the channel doesn't exceed the max channel size we've negotiated
+	// with them. Channels above the soft-limit require that both sides
+	// signal support for large channels.
+	if peer, err := r.server.FindPeer(nodePubKey); err == nil {
+		maxChanSize = maxChanSizeForPeer(peer, maxChanSize)
+		if localFundingAmt > maxChanSize {
+			return fmt.Errorf("funding amount is too large, the "+
+				"max channel size negotiated with the peer "+
+				"is: %v", maxChanSize)
+		}
+	}
+
 	nodePubKeyBytes = nodePubKey.SerializeCompressed()
 
 	// Based on the passed fee related parameters, we'll determine an
@@ -804,15 +1303,21 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 	// open a new channel. A stream is returned in place, this stream will
 	// be used to consume updates of the state of the pending channel.
 	req := &openChanReq{
-		targetPubkey:    nodePubKey,
-		chainHash:       *activeNetParams.GenesisHash,
-		localFundingAmt: localFundingAmt,
-		pushAmt:         lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		minHtlc:         minHtlc,
-		fundingFeePerKw: feeRate,
-		private:         in.Private,
-		remoteCsvDelay:  remoteCsvDelay,
-		minConfs:        in.MinConfs,
+		targetPubkey:      nodePubKey,
+		chainHash:         *activeNetParams.GenesisHash,
+		localFundingAmt:   localFundingAmt,
+		pushAmt:           lnwire.NewMSatFromSatoshis(remoteInitialBalance),
+		minHtlc:           minHtlc,
+		fundingFeePerKw:   feeRate,
+		private:           in.Private,
+		remoteCsvDelay:    remoteCsvDelay,
+		minConfs:          in.MinConfs,
+		outpoints:         outpoints,
+		lockID:            lockID,
+		shutdownScript:    shutdownScript,
+		remoteChanReserve: remoteChanReserve,
+		remoteMaxValue:    remoteMaxValue,
+		remoteMaxHtlcs:    remoteMaxHtlcs,
 	}
 
 	updateChan, errChan := r.server.OpenChannel(req)
@@ -910,6 +1415,9 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	remoteInitialBalance := btcutil.Amount(in.PushSat)
 	minHtlc := lnwire.MilliSatoshi(in.MinHtlcMsat)
 	remoteCsvDelay := uint16(in.RemoteCsvDelay)
+	remoteChanReserve := btcutil.Amount(in.RemoteChanReserveSat)
+	remoteMaxValue := lnwire.MilliSatoshi(in.RemoteMaxValueInFlightMsat)
+	remoteMaxHtlcs := uint16(in.RemoteMaxHtlcs)
 
 	// Ensure that the initial balance of the remote party (if pushing
 	// satoshis) does not exceed the amount the local party has requested
@@ -919,6 +1427,18 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 			"initial state must be below the local funding amount")
 	}
 
+	// Ensure that the user doesn't exceed the max channel size we're able
+	// to use with this peer. Channels above the soft-limit require that
+	// both sides signal support for large channels.
+	maxChanSize := btcutil.Amount(cfg.MaxChanSize)
+	if peer, err := r.server.FindPeer(nodepubKey); err == nil {
+		maxChanSize = maxChanSizeForPeer(peer, maxChanSize)
+	}
+	if localFundingAmt > maxChanSize {
+		return nil, fmt.Errorf("funding amount is too large, the max "+
+			"channel size is: %v", maxChanSize)
+	}
+
 	// Restrict the size of the channel we'll actually open. At a later
 	// level, we'll ensure that the output we create after accounting for
 	// fees that a dust output isn't created.
@@ -927,12 +1447,42 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 			"size is: %v SAT", int64(minChanFundingSize))
 	}
 
+	// Ensure the requested remote constraints fit within the bounds of
+	// the protocol. The remaining checks are left to the funding manager,
+	// which knows the full set of channel parameters.
+	if in.RemoteChanReserveSat < 0 {
+		return nil, errors.New("channel reserve must be a " +
+			"non-negative number")
+	}
+	if in.RemoteMaxHtlcs > uint32(lnwallet.MaxHTLCNumber/2) {
+		return nil, fmt.Errorf("max htlcs must not exceed %v",
+			lnwallet.MaxHTLCNumber/2)
+	}
+
 	// Ensure that the MinConfs parameter is non-negative.
 	if in.MinConfs < 0 {
 		return nil, errors.New("minimum number of confirmations must " +
 			"be a non-negative number")
 	}
 
+	// If the user specified a close address, then we'll commit to it as
+	// our upfront shutdown script.
+	shutdownScript, err := parseUpfrontShutdownAddress(in.CloseAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the user picked the outputs to fund the channel with, we'll
+	// spend exactly these.
+	outpoints, err := getOutPoints(in.Outpoints)
+	if err != nil {
+		return nil, err
+	}
+	lockID, err := getOptionalLockID(in.LockId)
+	if err != nil {
+		return nil, err
+	}
+
 	// Based on the passed fee related parameters, we'll determine an
 	// appropriate fee rate for the funding transaction.
 	feeRate, err := determineFeePerKw(
@@ -946,15 +1496,21 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 		int64(feeRate))
 
 	req := &openChanReq{
-		targetPubkey:    nodepubKey,
-		chainHash:       *activeNetParams.GenesisHash,
-		localFundingAmt: localFundingAmt,
-		pushAmt:         lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		minHtlc:         minHtlc,
-		fundingFeePerKw: feeRate,
-		private:         in.Private,
-		remoteCsvDelay:  remoteCsvDelay,
-		minConfs:        in.MinConfs,
+		targetPubkey:      nodepubKey,
+		chainHash:         *activeNetParams.GenesisHash,
+		localFundingAmt:   localFundingAmt,
+		pushAmt:           lnwire.NewMSatFromSatoshis(remoteInitialBalance),
+		minHtlc:           minHtlc,
+		fundingFeePerKw:   feeRate,
+		private:           in.Private,
+		remoteCsvDelay:    remoteCsvDelay,
+		minConfs:          in.MinConfs,
+		outpoints:         outpoints,
+		lockID:            lockID,
+		shutdownScript:    shutdownScript,
+		remoteChanReserve: remoteChanReserve,
+		remoteMaxValue:    remoteMaxValue,
+		remoteMaxHtlcs:    remoteMaxHtlcs,
 	}
 
 	updateChan, errChan := r.server.OpenChannel(req)
@@ -1010,6 +1566,44 @@ func getChanPointFundingTxid(chanPoint *lnrpc.ChannelPoint) ([]byte, error) {
 	return txid, nil
 }
 
+// getOutPoint returns the outpoint described by the passed rpc outpoint.
+func getOutPoint(in *lnrpc.OutPoint) (*wire.OutPoint, error) {
+	var (
+		hash *chainhash.Hash
+		err  error
+	)
+
+	// The txid can be set as a byte slice or a string. In the case it is
+	// a string, decode it.
+	switch in.GetTxid().(type) {
+	case *lnrpc.OutPoint_TxidBytes:
+		hash, err = chainhash.NewHash(in.GetTxidBytes())
+	case *lnrpc.OutPoint_TxidStr:
+		hash, err = chainhash.NewHashFromStr(in.GetTxidStr())
+	default:
+		err = fmt.Errorf("txid of outpoint must be set")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return wire.NewOutPoint(hash, in.OutputIndex), nil
+}
+
+// getOutPoints returns the outpoints described by the passed rpc outpoints.
+func getOutPoints(in []*lnrpc.OutPoint) ([]wire.OutPoint, error) {
+	outpoints := make([]wire.OutPoint, 0, len(in))
+	for _, rpcOutPoint := range in {
+		op, err := getOutPoint(rpcOutPoint)
+		if err != nil {
+			return nil, err
+		}
+		outpoints = append(outpoints, *op)
+	}
+
+	return outpoints, nil
+}
+
 // CloseChannel attempts to close an active channel identified by its channel
 // point. The actions of this method can additionally be augmented to attempt
 // a force close after a timeout period in the case of an inactive peer.
@@ -1136,6 +1730,37 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		rpcsLog.Debugf("Target sat/kw for closing transaction: %v",
 			int64(feeRate))
 
+		// If the caller capped the fee rate they're willing to pay,
+		// we'll ensure our own starting offer doesn't exceed it.
+		var maxFeeRate lnwallet.SatPerKWeight
+		if in.MaxSatPerByte != 0 {
+			maxFeeRate = lnwallet.SatPerKVByte(
+				in.MaxSatPerByte * 1000,
+			).FeePerKWeight()
+			if maxFeeRate < lnwallet.FeePerKwFloor {
+				return fmt.Errorf("max fee rate of %v sat/kw "+
+					"is below the minimum of %v sat/kw",
+					int64(maxFeeRate),
+					int64(lnwallet.FeePerKwFloor))
+			}
+			if feeRate > maxFeeRate {
+				rpcsLog.Infof("Target fee rate of %v sat/kw "+
+					"exceeds max fee rate, using %v sat/kw "+
+					"instead", int64(feeRate),
+					int64(maxFeeRate))
+				feeRate = maxFeeRate
+			}
+		}
+
+		// We'll also parse the address our settled funds should be
+		// delivered to, if the caller specified one.
+		deliveryScript, err := parseUpfrontShutdownAddress(
+			in.DeliveryAddress,
+		)
+		if err != nil {
+			return err
+		}
+
 		// Before we attempt the cooperative channel closure, we'll
 		// examine the channel to ensure that it doesn't have a
 		// lingering HTLC.
@@ -1150,6 +1775,7 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		// broadcast details.
 		updateChan, errChan = r.server.htlcSwitch.CloseLink(
 			chanPoint, htlcswitch.CloseRegular, feeRate,
+			maxFeeRate, deliveryScript,
 		)
 	}
 out:
@@ -1251,6 +1877,224 @@ func (r *rpcServer) AbandonChannel(ctx context.Context,
 	return &lnrpc.AbandonChannelResponse{}, nil
 }
 
+// SpliceOut attempts to move funds out of an active channel, without closing
+// the channel. Both parties agree upon a splice transaction spending the
+// current funding output into a smaller funding output, and an output paying
+// the spliced out funds on-chain. Once the splice transaction has been
+// broadcast, its txid is returned.
+func (r *rpcServer) SpliceOut(ctx context.Context,
+	in *lnrpc.SpliceOutRequest) (*lnrpc.SpliceOutResponse, error) {
+
+	if !cfg.Splicing {
+		return nil, fmt.Errorf("splicing is not enabled, start lnd " +
+			"with --splicing")
+	}
+
+	index := in.ChannelPoint.OutputIndex
+	txidHash, err := getChanPointFundingTxid(in.GetChannelPoint())
+	if err != nil {
+		rpcsLog.Errorf("[spliceout] unable to get funding txid: %v", err)
+		return nil, err
+	}
+	txid, err := chainhash.NewHash(txidHash)
+	if err != nil {
+		rpcsLog.Errorf("[spliceout] invalid txid: %v", err)
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, index)
+
+	if in.Amount <= 0 {
+		return nil, fmt.Errorf("splice amount must be positive")
+	}
+
+	rpcsLog.Tracef("[spliceout] request for ChannelPoint(%v), amt=%v",
+		chanPoint, btcutil.Amount(in.Amount))
+
+	dbChan, err := r.fetchOpenDbChannel(*chanPoint)
+	if err != nil {
+		return nil, err
+	}
+	if !dbChan.IsInitiator {
+		return nil, fmt.Errorf("only the channel initiator can " +
+			"splice out funds")
+	}
+
+	peer, err := r.server.FindPeer(dbChan.IdentityPub)
+	if err != nil {
+		return nil, fmt.Errorf("unable to splice channel while peer "+
+			"is offline: %v", err)
+	}
+
+	// If an address was specified, the spliced out funds will be paid to
+	// it. Otherwise, we'll pay them to a fresh address of our wallet.
+	var payoutScript []byte
+	if in.Addr != "" {
+		addr, err := btcutil.DecodeAddress(
+			in.Addr, activeNetParams.Params,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %v", err)
+		}
+		if !addr.IsForNet(activeNetParams.Params) {
+			return nil, fmt.Errorf("address %v is not for the "+
+				"active network", in.Addr)
+		}
+		payoutScript, err = txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		addr, err := r.server.cc.wallet.NewAddress(
+			lnwallet.WitnessPubKey, false,
+		)
+		if err != nil {
+			return nil, err
+		}
+		payoutScript, err = txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	feePerKw, err := determineFeePerKw(
+		r.server.cc.feeEstimator, in.TargetConf, in.SatPerByte,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &spliceOutReq{
+		chanPoint:    *chanPoint,
+		amount:       btcutil.Amount(in.Amount),
+		payoutScript: payoutScript,
+		feePerKw:     feePerKw,
+		resp:         make(chan *chainhash.Hash, 1),
+		err:          make(chan error, 1),
+	}
+
+	select {
+	case peer.localSpliceReqs <- req:
+	case <-peer.quit:
+		return nil, ErrPeerExiting
+	case <-r.quit:
+		return nil, fmt.Errorf("server shutting down")
+	}
+
+	select {
+	case spliceTxid := <-req.resp:
+		rpcsLog.Infof("[spliceout] spliced %v out of "+
+			"ChannelPoint(%v), splice txid=%v",
+			btcutil.Amount(in.Amount), chanPoint, spliceTxid)
+
+		return &lnrpc.SpliceOutResponse{
+			SpliceTxid: spliceTxid.String(),
+		}, nil
+
+	case err := <-req.err:
+		rpcsLog.Errorf("[spliceout] unable to splice "+
+			"ChannelPoint(%v): %v", chanPoint, err)
+		return nil, err
+
+	case <-peer.quit:
+		return nil, ErrPeerExiting
+
+	case <-r.quit:
+		return nil, fmt.Errorf("server shutting down")
+	}
+}
+
+// BumpPendingChannel attempts to unstick the unconfirmed funding transaction
+// of a pending channel we initiated, either by spending its change output in
+// a CPFP child transaction, or by double spending its inputs back to the
+// wallet.
+func (r *rpcServer) BumpPendingChannel(ctx context.Context,
+	in *lnrpc.BumpPendingChannelRequest) (*lnrpc.BumpPendingChannelResponse,
+	error) {
+
+	index := in.ChannelPoint.OutputIndex
+	txidHash, err := getChanPointFundingTxid(in.GetChannelPoint())
+	if err != nil {
+		rpcsLog.Errorf("[bumppendingchannel] unable to get funding "+
+			"txid: %v", err)
+		return nil, err
+	}
+	txid, err := chainhash.NewHash(txidHash)
+	if err != nil {
+		rpcsLog.Errorf("[bumppendingchannel] invalid txid: %v", err)
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, index)
+
+	rpcsLog.Tracef("[bumppendingchannel] request for ChannelPoint(%v), "+
+		"double_spend=%v", chanPoint, in.DoubleSpend)
+
+	pendingChans, err := r.server.chanDB.FetchPendingChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingChan *channeldb.OpenChannel
+	for _, dbChan := range pendingChans {
+		if dbChan.FundingOutpoint == *chanPoint {
+			pendingChan = dbChan
+			break
+		}
+	}
+	if pendingChan == nil {
+		return nil, fmt.Errorf("unable to find pending channel %v",
+			chanPoint)
+	}
+	if !pendingChan.IsInitiator || pendingChan.FundingTxn == nil {
+		return nil, fmt.Errorf("only the initiator of a channel can " +
+			"bump its funding transaction")
+	}
+
+	feePerKw, err := determineFeePerKw(
+		r.server.cc.feeEstimator, in.TargetConf, in.SatPerByte,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var bumpTx *wire.MsgTx
+	if in.DoubleSpend {
+		bumpTx, err = r.server.cc.wallet.CreateFundingDoubleSpend(
+			pendingChan.FundingTxn, feePerKw,
+		)
+	} else {
+		bumpTx, err = r.server.cc.wallet.CreateFundingCPFP(
+			pendingChan.FundingTxn, feePerKw,
+		)
+	}
+	if err != nil {
+		rpcsLog.Errorf("[bumppendingchannel] unable to create bump "+
+			"tx for ChannelPoint(%v): %v", chanPoint, err)
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[bumppendingchannel] publishing bump tx for "+
+		"ChannelPoint(%v): %v", chanPoint, newLogClosure(func() string {
+		return spew.Sdump(bumpTx)
+	}))
+
+	publishTx := r.server.cc.wallet.LabelledPublisher(
+		lnwallet.ChannelBumpLabel(*chanPoint),
+	)
+	if err := publishTx(bumpTx); err != nil {
+		rpcsLog.Errorf("[bumppendingchannel] unable to publish bump "+
+			"tx for ChannelPoint(%v): %v", chanPoint, err)
+		return nil, err
+	}
+
+	bumpTxid := bumpTx.TxHash()
+	rpcsLog.Infof("[bumppendingchannel] published bump tx %v for "+
+		"ChannelPoint(%v)", bumpTxid, chanPoint)
+
+	return &lnrpc.BumpPendingChannelResponse{
+		Txid: bumpTxid.String(),
+	}, nil
+}
+
 // fetchOpenDbChannel attempts to locate a channel identified by its channel
 // point from the database's set of all currently opened channels.
 func (r *rpcServer) fetchOpenDbChannel(chanPoint wire.OutPoint) (
@@ -1368,6 +2212,39 @@ func (r *rpcServer) GetInfo(ctx context.Context,
 	}, nil
 }
 
+// GetRecoveryInfo returns the progress of the wallet's address recovery when
+// it was restored from a seed with a recovery window.
+func (r *rpcServer) GetRecoveryInfo(ctx context.Context,
+	in *lnrpc.GetRecoveryInfoRequest) (*lnrpc.GetRecoveryInfoResponse, error) {
+
+	progress, err := r.server.cc.wallet.RecoveryProgress()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get recovery progress: %v",
+			err)
+	}
+
+	resp := &lnrpc.GetRecoveryInfoResponse{
+		RecoveryMode:     progress.RecoveryMode,
+		RecoveryFinished: progress.RecoveryFinished,
+		ScannedHeight:    uint32(progress.ScannedHeight),
+		BestHeight:       uint32(progress.BestHeight),
+		Progress:         progress.Progress,
+	}
+	for _, scope := range progress.Scopes {
+		resp.KeyScopes = append(resp.KeyScopes, &lnrpc.KeyScopeRecovery{
+			Purpose:           scope.Purpose,
+			CoinType:          scope.Coin,
+			ExternalAddresses: scope.ExternalAddrs,
+			InternalAddresses: scope.InternalAddrs,
+		})
+	}
+	if !progress.EstimatedCompletion.IsZero() {
+		resp.EstimatedCompletion = progress.EstimatedCompletion.Unix()
+	}
+
+	return resp, nil
+}
+
 // ListPeers returns a verbose listing of all currently active peers.
 func (r *rpcServer) ListPeers(ctx context.Context,
 	in *lnrpc.ListPeersRequest) (*lnrpc.ListPeersResponse, error) {
@@ -1498,6 +2375,11 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 		rpcsLog.Errorf("unable to fetch pending channels: %v", err)
 		return nil, err
 	}
+	_, currentHeight, err := r.server.cc.chainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
 	resp.PendingOpenChannels = make([]*lnrpc.PendingChannelsResponse_PendingOpenChannel,
 		len(pendingOpenChannels))
 	for i, pendingChan := range pendingOpenChannels {
@@ -1523,16 +2405,21 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 				LocalBalance:  int64(localCommitment.LocalBalance.ToSatoshis()),
 				RemoteBalance: int64(localCommitment.RemoteBalance.ToSatoshis()),
 			},
-			CommitWeight: commitWeight,
-			CommitFee:    int64(localCommitment.CommitFee),
-			FeePerKw:     int64(localCommitment.FeePerKw),
+			CommitWeight:           commitWeight,
+			CommitFee:              int64(localCommitment.CommitFee),
+			FeePerKw:               int64(localCommitment.FeePerKw),
+			FundingBroadcastHeight: pendingChan.FundingBroadcastHeight,
+			Initiator:              pendingChan.IsInitiator,
 			// TODO(roasbeef): need to track confirmation height
 		}
-	}
 
-	_, currentHeight, err := r.server.cc.chainIO.GetBestBlock()
-	if err != nil {
-		return nil, err
+		// The age of the funding transaction allows the initiator to
+		// spot a stuck funding transaction that needs to be bumped.
+		broadcastHeight := pendingChan.FundingBroadcastHeight
+		if uint32(currentHeight) > broadcastHeight {
+			resp.PendingOpenChannels[i].BlocksSinceBroadcast =
+				uint32(currentHeight) - broadcastHeight
+		}
 	}
 
 	// Next, we'll examine the channels that are soon to be closed so we
@@ -1709,60 +2596,80 @@ func (r *rpcServer) ClosedChannels(ctx context.Context,
 			continue
 		}
 
-		nodePub := dbChannel.RemotePub
-		nodeID := hex.EncodeToString(nodePub.SerializeCompressed())
-
-		var closeType lnrpc.ChannelCloseSummary_ClosureType
 		switch dbChannel.CloseType {
 		case channeldb.CooperativeClose:
 			if filterResults && !in.Cooperative {
 				continue
 			}
-			closeType = lnrpc.ChannelCloseSummary_COOPERATIVE_CLOSE
 		case channeldb.LocalForceClose:
 			if filterResults && !in.LocalForce {
 				continue
 			}
-			closeType = lnrpc.ChannelCloseSummary_LOCAL_FORCE_CLOSE
 		case channeldb.RemoteForceClose:
 			if filterResults && !in.RemoteForce {
 				continue
 			}
-			closeType = lnrpc.ChannelCloseSummary_REMOTE_FORCE_CLOSE
 		case channeldb.BreachClose:
 			if filterResults && !in.Breach {
 				continue
 			}
-			closeType = lnrpc.ChannelCloseSummary_BREACH_CLOSE
 		case channeldb.FundingCanceled:
 			if filterResults && !in.FundingCanceled {
 				continue
 			}
-			closeType = lnrpc.ChannelCloseSummary_FUNDING_CANCELED
 		case channeldb.Abandoned:
 			if filterResults && !in.Abandoned {
 				continue
 			}
-			closeType = lnrpc.ChannelCloseSummary_ABANDONED
-		}
-
-		channel := &lnrpc.ChannelCloseSummary{
-			Capacity:          int64(dbChannel.Capacity),
-			RemotePubkey:      nodeID,
-			CloseHeight:       dbChannel.CloseHeight,
-			CloseType:         closeType,
-			ChannelPoint:      dbChannel.ChanPoint.String(),
-			ChanId:            dbChannel.ShortChanID.ToUint64(),
-			SettledBalance:    int64(dbChannel.SettledBalance),
-			TimeLockedBalance: int64(dbChannel.TimeLockedBalance),
-			ChainHash:         dbChannel.ChainHash.String(),
-			ClosingTxHash:     dbChannel.ClosingTXID.String(),
 		}
 
+		channel := createRPCClosedChannel(dbChannel)
 		resp.Channels = append(resp.Channels, channel)
 	}
 
-	return resp, nil
+	return resp, nil
+}
+
+// rpcCloseType returns the RPC representation of the passed closure type.
+func rpcCloseType(
+	closeType channeldb.ClosureType) lnrpc.ChannelCloseSummary_ClosureType {
+
+	switch closeType {
+	case channeldb.LocalForceClose:
+		return lnrpc.ChannelCloseSummary_LOCAL_FORCE_CLOSE
+	case channeldb.RemoteForceClose:
+		return lnrpc.ChannelCloseSummary_REMOTE_FORCE_CLOSE
+	case channeldb.BreachClose:
+		return lnrpc.ChannelCloseSummary_BREACH_CLOSE
+	case channeldb.FundingCanceled:
+		return lnrpc.ChannelCloseSummary_FUNDING_CANCELED
+	case channeldb.Abandoned:
+		return lnrpc.ChannelCloseSummary_ABANDONED
+	default:
+		return lnrpc.ChannelCloseSummary_COOPERATIVE_CLOSE
+	}
+}
+
+// createRPCClosedChannel creates an *lnrpc.ChannelCloseSummary from a
+// *channeldb.ChannelCloseSummary.
+func createRPCClosedChannel(
+	dbChannel *channeldb.ChannelCloseSummary) *lnrpc.ChannelCloseSummary {
+
+	nodePub := dbChannel.RemotePub
+	nodeID := hex.EncodeToString(nodePub.SerializeCompressed())
+
+	return &lnrpc.ChannelCloseSummary{
+		Capacity:          int64(dbChannel.Capacity),
+		RemotePubkey:      nodeID,
+		CloseHeight:       dbChannel.CloseHeight,
+		CloseType:         rpcCloseType(dbChannel.CloseType),
+		ChannelPoint:      dbChannel.ChanPoint.String(),
+		ChanId:            dbChannel.ShortChanID.ToUint64(),
+		SettledBalance:    int64(dbChannel.SettledBalance),
+		TimeLockedBalance: int64(dbChannel.TimeLockedBalance),
+		ChainHash:         dbChannel.ChainHash.String(),
+		ClosingTxHash:     dbChannel.ClosingTXID.String(),
+	}
 }
 
 // ListChannels returns a description of all the open channels that this node
@@ -1782,8 +2689,6 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 
 	resp := &lnrpc.ListChannelsResponse{}
 
-	graph := r.server.chanDB.ChannelGraph()
-
 	dbChannels, err := r.server.chanDB.FetchAllOpenChannels()
 	if err != nil {
 		return nil, err
@@ -1793,32 +2698,9 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 		len(dbChannels))
 
 	for _, dbChannel := range dbChannels {
-		nodePub := dbChannel.IdentityPub
-		nodeID := hex.EncodeToString(nodePub.SerializeCompressed())
-		chanPoint := dbChannel.FundingOutpoint
-
-		// With the channel point known, retrieve the network channel
-		// ID from the database.
-		var chanID uint64
-		chanID, _ = graph.ChannelID(&chanPoint)
-
-		var peerOnline bool
-		if _, err := r.server.FindPeer(nodePub); err == nil {
-			peerOnline = true
-		}
-
-		channelID := lnwire.NewChanIDFromOutPoint(&chanPoint)
-		var linkActive bool
-		if link, err := r.server.htlcSwitch.GetLink(channelID); err == nil {
-			// A channel is only considered active if it is known
-			// by the switch *and* able to forward
-			// incoming/outgoing payments.
-			linkActive = link.EligibleToForward()
-		}
-
-		// Next, we'll determine whether we should add this channel to
-		// our list depending on the type of channels requested to us.
-		isActive := peerOnline && linkActive
+		// We'll determine whether we should add this channel to our
+		// list depending on the type of channels requested to us.
+		isActive := r.isChannelActive(dbChannel)
 		isPublic := dbChannel.ChannelFlags&lnwire.FFAnnounceChannel != 0
 
 		// We'll only skip returning this channel if we were requested
@@ -1834,66 +2716,245 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 			continue
 		}
 
-		// As this is required for display purposes, we'll calculate
-		// the weight of the commitment transaction. We also add on the
-		// estimated weight of the witness to calculate the weight of
-		// the transaction if it were to be immediately unilaterally
-		// broadcast.
-		localCommit := dbChannel.LocalCommitment
-		utx := btcutil.NewTx(localCommit.CommitTx)
-		commitBaseWeight := blockchain.GetTransactionWeight(utx)
-		commitWeight := commitBaseWeight + lnwallet.WitnessCommitmentTxWeight
+		channel := r.createRPCOpenChannel(dbChannel, isActive)
+		resp.Channels = append(resp.Channels, channel)
+	}
 
-		localBalance := localCommit.LocalBalance
-		remoteBalance := localCommit.RemoteBalance
-
-		// As an artifact of our usage of mSAT internally, either party
-		// may end up in a state where they're holding a fractional
-		// amount of satoshis which can't be expressed within the
-		// actual commitment output. Since we round down when going
-		// from mSAT -> SAT, we may at any point be adding an
-		// additional SAT to miners fees. As a result, we display a
-		// commitment fee that accounts for this externally.
-		var sumOutputs btcutil.Amount
-		for _, txOut := range localCommit.CommitTx.TxOut {
-			sumOutputs += btcutil.Amount(txOut.Value)
-		}
-		externalCommitFee := dbChannel.Capacity - sumOutputs
-
-		channel := &lnrpc.Channel{
-			Active:                isActive,
-			Private:               !isPublic,
-			RemotePubkey:          nodeID,
-			ChannelPoint:          chanPoint.String(),
-			ChanId:                chanID,
-			Capacity:              int64(dbChannel.Capacity),
-			LocalBalance:          int64(localBalance.ToSatoshis()),
-			RemoteBalance:         int64(remoteBalance.ToSatoshis()),
-			CommitFee:             int64(externalCommitFee),
-			CommitWeight:          commitWeight,
-			FeePerKw:              int64(localCommit.FeePerKw),
-			TotalSatoshisSent:     int64(dbChannel.TotalMSatSent.ToSatoshis()),
-			TotalSatoshisReceived: int64(dbChannel.TotalMSatReceived.ToSatoshis()),
-			NumUpdates:            localCommit.CommitHeight,
-			PendingHtlcs:          make([]*lnrpc.HTLC, len(localCommit.Htlcs)),
-			CsvDelay:              uint32(dbChannel.LocalChanCfg.CsvDelay),
+	return resp, nil
+}
+
+// isChannelActive returns whether the passed channel is considered active,
+// meaning its remote peer is online, and its link is known to the switch and
+// able to forward payments.
+func (r *rpcServer) isChannelActive(dbChannel *channeldb.OpenChannel) bool {
+	if _, err := r.server.FindPeer(dbChannel.IdentityPub); err != nil {
+		return false
+	}
+
+	channelID := lnwire.NewChanIDFromOutPoint(&dbChannel.FundingOutpoint)
+	link, err := r.server.htlcSwitch.GetLink(channelID)
+	if err != nil {
+		return false
+	}
+
+	// A channel is only considered active if it is known by the switch
+	// *and* able to forward incoming/outgoing payments.
+	return link.EligibleToForward()
+}
+
+// createRPCOpenChannel creates an *lnrpc.Channel from the
+// *channeldb.OpenChannel.
+func (r *rpcServer) createRPCOpenChannel(dbChannel *channeldb.OpenChannel,
+	isActive bool) *lnrpc.Channel {
+
+	nodePub := dbChannel.IdentityPub
+	nodeID := hex.EncodeToString(nodePub.SerializeCompressed())
+	chanPoint := dbChannel.FundingOutpoint
+	isPublic := dbChannel.ChannelFlags&lnwire.FFAnnounceChannel != 0
+
+	// With the channel point known, retrieve the network channel ID from
+	// the database.
+	var chanID uint64
+	chanID, _ = r.server.chanDB.ChannelGraph().ChannelID(&chanPoint)
+
+	// As this is required for display purposes, we'll calculate the
+	// weight of the commitment transaction. We also add on the estimated
+	// weight of the witness to calculate the weight of the transaction if
+	// it were to be immediately unilaterally broadcast.
+	localCommit := dbChannel.LocalCommitment
+	utx := btcutil.NewTx(localCommit.CommitTx)
+	commitBaseWeight := blockchain.GetTransactionWeight(utx)
+	commitWeight := commitBaseWeight + lnwallet.WitnessCommitmentTxWeight
+
+	localBalance := localCommit.LocalBalance
+	remoteBalance := localCommit.RemoteBalance
+
+	// As an artifact of our usage of mSAT internally, either party may end
+	// up in a state where they're holding a fractional amount of satoshis
+	// which can't be expressed within the actual commitment output. Since
+	// we round down when going from mSAT -> SAT, we may at any point be
+	// adding an additional SAT to miners fees. As a result, we display a
+	// commitment fee that accounts for this externally.
+	var sumOutputs btcutil.Amount
+	for _, txOut := range localCommit.CommitTx.TxOut {
+		sumOutputs += btcutil.Amount(txOut.Value)
+	}
+	externalCommitFee := dbChannel.Capacity - sumOutputs
+
+	channel := &lnrpc.Channel{
+		Active:                isActive,
+		Private:               !isPublic,
+		RemotePubkey:          nodeID,
+		ChannelPoint:          chanPoint.String(),
+		ChanId:                chanID,
+		Capacity:              int64(dbChannel.Capacity),
+		LocalBalance:          int64(localBalance.ToSatoshis()),
+		RemoteBalance:         int64(remoteBalance.ToSatoshis()),
+		CommitFee:             int64(externalCommitFee),
+		CommitWeight:          commitWeight,
+		FeePerKw:              int64(localCommit.FeePerKw),
+		TotalSatoshisSent:     int64(dbChannel.TotalMSatSent.ToSatoshis()),
+		TotalSatoshisReceived: int64(dbChannel.TotalMSatReceived.ToSatoshis()),
+		NumUpdates:            localCommit.CommitHeight,
+		PendingHtlcs:          make([]*lnrpc.HTLC, len(localCommit.Htlcs)),
+		CsvDelay:              uint32(dbChannel.LocalChanCfg.CsvDelay),
+	}
+
+	for i, htlc := range localCommit.Htlcs {
+		var rHash [32]byte
+		copy(rHash[:], htlc.RHash[:])
+		channel.PendingHtlcs[i] = &lnrpc.HTLC{
+			Incoming:         htlc.Incoming,
+			Amount:           int64(htlc.Amt.ToSatoshis()),
+			HashLock:         rHash[:],
+			ExpirationHeight: htlc.RefundTimeout,
 		}
+	}
+
+	return channel
+}
+
+// SubscribeChannelEvents returns a uni-directional stream (server -> client)
+// for notifying the client of newly active, inactive, closing or closed
+// channels, and of balance changes of open channels.
+func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
+	updateStream lnrpc.Lightning_SubscribeChannelEventsServer) error {
+
+	channelEventSub, err := r.server.channelNotifier.SubscribeChannelEvents()
+	if err != nil {
+		return err
+	}
+
+	// Ensure that the resources for the client is cleaned up once either
+	// the server, or client exits.
+	defer channelEventSub.Cancel()
+
+	for {
+		select {
+		// A new update has been sent by the channel notifier, we'll
+		// marshal it into the form expected by the gRPC client, then
+		// send it off to the client.
+		case e := <-channelEventSub.Updates:
+			var update *lnrpc.ChannelEventUpdate
+			switch event := e.(type) {
+			case channelnotifier.PendingOpenChannelEvent:
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_PENDING_OPEN_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_PendingOpenChannel{
+						PendingOpenChannel: &lnrpc.PendingUpdate{
+							Txid:        event.ChannelPoint.Hash[:],
+							OutputIndex: event.ChannelPoint.Index,
+						},
+					},
+				}
+
+			case channelnotifier.OpenChannelEvent:
+				channel := r.createRPCOpenChannel(
+					event.Channel, r.isChannelActive(event.Channel),
+				)
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_OPEN_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_OpenChannel{
+						OpenChannel: channel,
+					},
+				}
+
+			case channelnotifier.ActiveChannelEvent:
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_ACTIVE_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_ActiveChannel{
+						ActiveChannel: rpcChannelPoint(
+							event.ChannelPoint,
+						),
+					},
+				}
+
+			case channelnotifier.InactiveChannelEvent:
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_INACTIVE_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_InactiveChannel{
+						InactiveChannel: rpcChannelPoint(
+							event.ChannelPoint,
+						),
+					},
+				}
+
+			case channelnotifier.ClosingChannelEvent:
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_CLOSING_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_ClosingChannel{
+						ClosingChannel: &lnrpc.ClosingChannelUpdate{
+							ChannelPoint: event.ChannelPoint.String(),
+							CloseType: rpcCloseType(
+								event.CloseType,
+							),
+						},
+					},
+				}
+
+			case channelnotifier.ClosedChannelEvent:
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_CLOSED_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_ClosedChannel{
+						ClosedChannel: createRPCClosedChannel(
+							event.CloseSummary,
+						),
+					},
+				}
+
+			case channelnotifier.BalanceUpdateEvent:
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_BALANCE_UPDATE,
+					Channel: &lnrpc.ChannelEventUpdate_BalanceUpdate{
+						BalanceUpdate: &lnrpc.ChannelBalanceUpdate{
+							ChannelPoint:  event.ChannelPoint.String(),
+							LocalBalance:  int64(event.LocalBalance.ToSatoshis()),
+							RemoteBalance: int64(event.RemoteBalance.ToSatoshis()),
+							CommitHeight:  event.CommitHeight,
+						},
+					},
+				}
+
+			case channelnotifier.SplicedChannelEvent:
+				channel := r.createRPCOpenChannel(
+					event.Channel, r.isChannelActive(event.Channel),
+				)
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_SPLICED_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_SplicedChannel{
+						SplicedChannel: &lnrpc.SplicedChannelUpdate{
+							OldChannelPoint: event.OldChannelPoint.String(),
+							Channel:         channel,
+						},
+					},
+				}
 
-		for i, htlc := range localCommit.Htlcs {
-			var rHash [32]byte
-			copy(rHash[:], htlc.RHash[:])
-			channel.PendingHtlcs[i] = &lnrpc.HTLC{
-				Incoming:         htlc.Incoming,
-				Amount:           int64(htlc.Amt.ToSatoshis()),
-				HashLock:         rHash[:],
-				ExpirationHeight: htlc.RefundTimeout,
+			default:
+				return fmt.Errorf("unexpected channel event update: %v",
+					e)
 			}
-		}
 
-		resp.Channels = append(resp.Channels, channel)
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+
+		case <-updateStream.Context().Done():
+			return updateStream.Context().Err()
+
+		case <-r.quit:
+			return nil
+		}
 	}
+}
 
-	return resp, nil
+// rpcChannelPoint returns the RPC representation of the passed channel point.
+func rpcChannelPoint(chanPoint *wire.OutPoint) *lnrpc.ChannelPoint {
+	return &lnrpc.ChannelPoint{
+		FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+			FundingTxidBytes: chanPoint.Hash[:],
+		},
+		OutputIndex: chanPoint.Index,
+	}
 }
 
 // savePayment saves a successfully completed payment to the database for
@@ -3046,6 +4107,7 @@ func (r *rpcServer) SubscribeTransactions(req *lnrpc.GetTransactionsRequest,
 				BlockHash:        tx.BlockHash.String(),
 				TimeStamp:        tx.Timestamp,
 				TotalFees:        tx.TotalFees,
+				Label:            r.txLabel(tx.Hash),
 			}
 			if err := updateStream.Send(detail); err != nil {
 				return err
@@ -3057,6 +4119,7 @@ func (r *rpcServer) SubscribeTransactions(req *lnrpc.GetTransactionsRequest,
 				Amount:    int64(tx.Value),
 				TimeStamp: tx.Timestamp,
 				TotalFees: tx.TotalFees,
+				Label:     r.txLabel(tx.Hash),
 			}
 			if err := updateStream.Send(detail); err != nil {
 				return err
@@ -3104,12 +4167,61 @@ func (r *rpcServer) GetTransactions(ctx context.Context,
 			TimeStamp:        tx.Timestamp,
 			TotalFees:        tx.TotalFees,
 			DestAddresses:    destAddresses,
+			Label:            tx.Label,
 		}
 	}
 
 	return txDetails, nil
 }
 
+// txLabel returns the label of the given transaction. Labels are only
+// informational, so a failure to fetch one is logged rather than returned.
+func (r *rpcServer) txLabel(txid chainhash.Hash) string {
+	label, err := r.server.cc.wallet.TransactionLabel(txid)
+	if err != nil {
+		rpcsLog.Errorf("Unable to fetch label of tx %v: %v", txid, err)
+	}
+
+	return label
+}
+
+// LabelTransaction assigns a label to a transaction of the wallet.
+func (r *rpcServer) LabelTransaction(ctx context.Context,
+	in *lnrpc.LabelTransactionRequest) (*lnrpc.LabelTransactionResponse,
+	error) {
+
+	txid, err := chainhash.NewHash(in.Txid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only transactions that are relevant to the wallet can be labelled.
+	txDetails, err := r.server.cc.wallet.ListTransactionDetails()
+	if err != nil {
+		return nil, err
+	}
+	var found bool
+	for _, txDetail := range txDetails {
+		if txDetail.Hash == *txid {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("transaction %v not found in wallet",
+			txid)
+	}
+
+	err = r.server.cc.wallet.LabelTransaction(*txid, in.Label, in.Overwrite)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[labeltx] txid=%v, label=%v", txid, in.Label)
+
+	return &lnrpc.LabelTransactionResponse{}, nil
+}
+
 // DescribeGraph returns a description of the latest graph state from the PoV
 // of the node. The graph information is partitioned into two components: all
 // the nodes/vertexes, and all the edges that connect the vertexes themselves.
@@ -3787,6 +4899,200 @@ func (r *rpcServer) DebugLevel(ctx context.Context,
 	return &lnrpc.DebugLevelResponse{}, nil
 }
 
+// DebugChannelState returns a dump of the persisted state of the target
+// channel, including both commitment transactions, the update log indexes and
+// all forwarding packages. As the dump contains our latest signed commitment
+// transaction, access requires both offchain and onchain write permissions,
+// which only the admin macaroon grants.
+func (r *rpcServer) DebugChannelState(ctx context.Context,
+	in *lnrpc.DebugChannelStateRequest) (*lnrpc.DebugChannelStateResponse,
+	error) {
+
+	index := in.ChannelPoint.OutputIndex
+	txidHash, err := getChanPointFundingTxid(in.GetChannelPoint())
+	if err != nil {
+		return nil, err
+	}
+	txid, err := chainhash.NewHash(txidHash)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, index)
+
+	rpcsLog.Debugf("[debugchannelstate] ChannelPoint(%v)", chanPoint)
+
+	dbChan, err := r.fetchOpenDbChannel(*chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.DebugChannelStateResponse{
+		ChannelPoint: chanPoint.String(),
+		ChanId:       dbChan.ShortChanID().ToUint64(),
+		RemotePubkey: hex.EncodeToString(
+			dbChan.IdentityPub.SerializeCompressed(),
+		),
+		ChanStatus: dbChan.ChanStatus().String(),
+		// Our revocation producer has revealed the secrets of all our
+		// commitments below the current one.
+		RevocationProducerHeight: dbChan.LocalCommitment.CommitHeight,
+	}
+
+	resp.LocalCommitment, err = createDebugCommitment(
+		&dbChan.LocalCommitment,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp.RemoteCommitment, err = createDebugCommitment(
+		&dbChan.RemoteCommitment,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we've signed a new commitment for the remote party that they
+	// haven't yet revoked their prior state for, then we'll include it
+	// along with the updates it covers.
+	commitDiff, err := dbChan.RemoteCommitChainTip()
+	switch err {
+	case nil:
+		resp.PendingRemoteCommitment, err = createDebugCommitment(
+			&commitDiff.Commitment,
+		)
+		if err != nil {
+			return nil, err
+		}
+		resp.PendingRemoteLogUpdates = createDebugLogUpdates(
+			commitDiff.LogUpdates,
+		)
+
+	case channeldb.ErrNoPendingCommit:
+
+	default:
+		return nil, err
+	}
+
+	revokedCommit, err := dbChan.RevocationLogTail()
+	if err != nil && err != channeldb.ErrNoPastDeltas {
+		return nil, err
+	}
+	if revokedCommit != nil {
+		resp.RemoteRevocationHeight = revokedCommit.CommitHeight
+	}
+
+	fwdPkgs, err := dbChan.LoadFwdPkgs()
+	if err != nil {
+		return nil, err
+	}
+	for _, fwdPkg := range fwdPkgs {
+		resp.ForwardingPackages = append(
+			resp.ForwardingPackages, &lnrpc.DebugForwardingPackage{
+				SourceChanId: fwdPkg.Source.ToUint64(),
+				Height:       fwdPkg.Height,
+				State:        fwdPkg.State.String(),
+				Adds:         createDebugLogUpdates(fwdPkg.Adds),
+				SettleFails: createDebugLogUpdates(
+					fwdPkg.SettleFails,
+				),
+				NumAddsForwarded:    countPkgFilter(fwdPkg.FwdFilter),
+				NumAddsAcked:        countPkgFilter(fwdPkg.AckFilter),
+				NumSettleFailsAcked: countPkgFilter(fwdPkg.SettleFailFilter),
+			},
+		)
+	}
+
+	return resp, nil
+}
+
+// createDebugCommitment converts the passed commitment into its RPC
+// representation.
+func createDebugCommitment(
+	commit *channeldb.ChannelCommitment) (*lnrpc.DebugCommitment, error) {
+
+	var commitTx bytes.Buffer
+	if commit.CommitTx != nil {
+		if err := commit.CommitTx.Serialize(&commitTx); err != nil {
+			return nil, err
+		}
+	}
+
+	rpcCommit := &lnrpc.DebugCommitment{
+		CommitHeight:      commit.CommitHeight,
+		CommitTx:          hex.EncodeToString(commitTx.Bytes()),
+		LocalBalanceMsat:  int64(commit.LocalBalance),
+		RemoteBalanceMsat: int64(commit.RemoteBalance),
+		CommitFee:         int64(commit.CommitFee),
+		FeePerKw:          int64(commit.FeePerKw),
+		LocalLogIndex:     commit.LocalLogIndex,
+		LocalHtlcIndex:    commit.LocalHtlcIndex,
+		RemoteLogIndex:    commit.RemoteLogIndex,
+		RemoteHtlcIndex:   commit.RemoteHtlcIndex,
+	}
+	for _, htlc := range commit.Htlcs {
+		rHash := htlc.RHash
+		rpcCommit.Htlcs = append(rpcCommit.Htlcs, &lnrpc.DebugHTLC{
+			HtlcIndex:   htlc.HtlcIndex,
+			LogIndex:    htlc.LogIndex,
+			AmountMsat:  int64(htlc.Amt),
+			Expiry:      htlc.RefundTimeout,
+			Incoming:    htlc.Incoming,
+			HashLock:    rHash[:],
+			OutputIndex: htlc.OutputIndex,
+		})
+	}
+
+	return rpcCommit, nil
+}
+
+// createDebugLogUpdates converts the passed log updates into their RPC
+// representation.
+func createDebugLogUpdates(
+	logUpdates []channeldb.LogUpdate) []*lnrpc.DebugLogUpdate {
+
+	rpcUpdates := make([]*lnrpc.DebugLogUpdate, 0, len(logUpdates))
+	for _, logUpdate := range logUpdates {
+		rpcUpdate := &lnrpc.DebugLogUpdate{
+			LogIndex: logUpdate.LogIndex,
+			MsgType:  logUpdate.UpdateMsg.MsgType().String(),
+		}
+
+		switch msg := logUpdate.UpdateMsg.(type) {
+		case *lnwire.UpdateAddHTLC:
+			rpcUpdate.HtlcIndex = msg.ID
+			rpcUpdate.AmountMsat = int64(msg.Amount)
+		case *lnwire.UpdateFulfillHTLC:
+			rpcUpdate.HtlcIndex = msg.ID
+		case *lnwire.UpdateFailHTLC:
+			rpcUpdate.HtlcIndex = msg.ID
+		case *lnwire.UpdateFailMalformedHTLC:
+			rpcUpdate.HtlcIndex = msg.ID
+		}
+
+		rpcUpdates = append(rpcUpdates, rpcUpdate)
+	}
+
+	return rpcUpdates
+}
+
+// countPkgFilter returns the number of indexes set within the passed package
+// filter. A nil filter, as found in packages that haven't reached the
+// corresponding state yet, has no indexes set.
+func countPkgFilter(filter *channeldb.PkgFilter) uint32 {
+	if filter == nil {
+		return 0
+	}
+
+	var numSet uint32
+	for i := uint16(0); i < filter.Count(); i++ {
+		if filter.Contains(i) {
+			numSet++
+		}
+	}
+
+	return numSet
+}
+
 // DecodePayReq takes an encoded payment request string and attempts to decode
 // it, returning a full description of the conditions encoded within the
 // payment request.
@@ -4181,3 +5487,196 @@ func (r *rpcServer) ForwardingHistory(ctx context.Context,
 
 	return resp, nil
 }
+
+// ExportChannelBackup attempts to return an encrypted static channel backup
+// for the target channel identified by its channel point. The backup is
+// encrypted with a key generated from the aezeed seed of the user. The
+// returned backup can be restored using the RestoreChannelBackups method.
+func (r *rpcServer) ExportChannelBackup(ctx context.Context,
+	in *lnrpc.ExportChannelBackupRequest) (*lnrpc.ChannelBackup, error) {
+
+	if in.ChanPoint == nil {
+		return nil, fmt.Errorf("chan_point must be specified")
+	}
+
+	// First, we'll convert the lnrpc channel point into a wire.OutPoint
+	// that we can manipulate.
+	txidHash, err := getChanPointFundingTxid(in.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+	txid, err := chainhash.NewHash(txidHash)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.OutPoint{
+		Hash:  *txid,
+		Index: in.ChanPoint.OutputIndex,
+	}
+
+	// Next, we'll attempt to fetch a channel backup for this channel from
+	// the database. If this channel has been closed, or the outpoint is
+	// unknown, then we'll return an error.
+	unpackedBackup, err := chanbackup.FetchBackupForChan(
+		chanPoint, r.server.chanDB, r.server.chanDB,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// At this point, we have an unpacked backup (plaintext) so we'll now
+	// attempt to serialize and encrypt it in order to create a packed
+	// backup.
+	packedBackups, err := chanbackup.PackStaticChanBackups(
+		[]chanbackup.Single{*unpackedBackup},
+		r.server.cc.keyRing,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("packing of back ups failed: %v", err)
+	}
+
+	// Before we proceed, we'll ensure that we received a backup for this
+	// channel, otherwise, we'll bail out.
+	packedBackup, ok := packedBackups[chanPoint]
+	if !ok {
+		return nil, fmt.Errorf("expected single backup for "+
+			"ChannelPoint(%v)", chanPoint)
+	}
+
+	return &lnrpc.ChannelBackup{
+		ChanPoint:  in.ChanPoint,
+		ChanBackup: packedBackup,
+	}, nil
+}
+
+// VerifyChanBackup allows a caller to verify the integrity of a channel backup
+// snapshot. This method will accept both a set of packed Singles, as well as
+// a packed Multi. Specifying both will result in an error.
+func (r *rpcServer) VerifyChanBackup(ctx context.Context,
+	in *lnrpc.ChanBackupSnapshot) (*lnrpc.VerifyChanBackupResponse, error) {
+
+	switch {
+	// If neither a Single or Multi has been specified, then we have
+	// nothing to verify.
+	case in.GetSingleChanBackups() == nil && in.GetMultiChanBackup() == nil:
+		return nil, errors.New("either a Single or Multi channel " +
+			"backup must be specified")
+
+	// Either a Single or a Multi must be specified, but not both.
+	case in.GetSingleChanBackups() != nil && in.GetMultiChanBackup() != nil:
+		return nil, errors.New("either a Single or Multi channel " +
+			"backup must be specified, but not both")
+
+	// If a Single is specified then we'll only accept one of them to
+	// allow the caller to map the valid/invalid state for each
+	// individual Single.
+	case in.GetSingleChanBackups() != nil:
+		chanBackupsProtos := in.GetSingleChanBackups().ChanBackups
+		if len(chanBackupsProtos) != 1 {
+			return nil, errors.New("only one Single is accepted " +
+				"at a time")
+		}
+
+		// First, we'll convert the raw byte slice into a type we can
+		// work with a bit better.
+		chanBackup := chanbackup.PackedSingles(
+			[][]byte{chanBackupsProtos[0].ChanBackup},
+		)
+
+		// With our PackedSingles created, we'll attempt to unpack the
+		// backup. If this fails, then we know the backup is invalid
+		// for some reason.
+		_, err := chanBackup.Unpack(r.server.cc.keyRing)
+		if err != nil {
+			return nil, fmt.Errorf("invalid single channel "+
+				"backup: %v", err)
+		}
+
+	case in.GetMultiChanBackup() != nil:
+		// We'll convert the raw byte slice into a PackedMulti that we
+		// can easily work with.
+		packedMultiBackup := in.GetMultiChanBackup().MultiChanBackup
+		packedMulti := chanbackup.PackedMulti(packedMultiBackup)
+
+		// We'll now attempt to unpack the Multi. If this fails, then
+		// we know it's invalid.
+		_, err := packedMulti.Unpack(r.server.cc.keyRing)
+		if err != nil {
+			return nil, fmt.Errorf("invalid multi channel backup: "+
+				"%v", err)
+		}
+	}
+
+	return &lnrpc.VerifyChanBackupResponse{}, nil
+}
+
+// RestoreChannelBackups accepts a set of singular channel backups, or a
+// single encrypted multi-chan backup and attempts to recover any funds
+// remaining within the channel. If we're able to unpack the backup, then
+// we'll connect to each channel peer, and request that they force close the
+// channel, such that our funds can be swept once the commitment confirms.
+func (r *rpcServer) RestoreChannelBackups(ctx context.Context,
+	in *lnrpc.RestoreChanBackupRequest) (*lnrpc.RestoreBackupResponse, error) {
+
+	// First, we'll make our implementation of the
+	// chanbackup.ChannelRestorer interface which we'll use to properly
+	// restore either a set of chanbackup.Single or chanbackup.Multi
+	// backups.
+	chanRestorer := &chanDBRestorer{
+		db:         r.server.chanDB,
+		secretKeys: r.server.cc.keyRing,
+		chainArb:   r.server.chainArb,
+	}
+
+	// We'll accept either a list of Single backups, or a single Multi
+	// backup which contains several single backups.
+	switch {
+	case in.GetChanBackups() != nil:
+		chanBackupsProtos := in.GetChanBackups()
+
+		// Now that we know what type of backup we're working with,
+		// we'll parse them all out into a more suitable format.
+		packedBackups := make([][]byte, 0, len(chanBackupsProtos.ChanBackups))
+		for _, chanBackup := range chanBackupsProtos.ChanBackups {
+			packedBackups = append(
+				packedBackups, chanBackup.ChanBackup,
+			)
+		}
+
+		// With our backups obtained, we'll now restore them which will
+		// write the new backups to disk, and then attempt to connect
+		// out to any peers that we know of which were our prior
+		// channel peers.
+		err := chanbackup.UnpackAndRecoverSingles(
+			chanbackup.PackedSingles(packedBackups),
+			r.server.cc.keyRing, chanRestorer, r.server,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unpack single "+
+				"backups: %v", err)
+		}
+
+	case in.GetMultiChanBackup() != nil:
+		packedMultiBackup := in.GetMultiChanBackup()
+
+		// With our backups obtained, we'll now restore them which will
+		// write the new backups to disk, and then attempt to connect
+		// out to any peers that we know of which were our prior
+		// channel peers.
+		packedMulti := chanbackup.PackedMulti(packedMultiBackup)
+		err := chanbackup.UnpackAndRecoverMulti(
+			packedMulti, r.server.cc.keyRing, chanRestorer,
+			r.server,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unpack chan "+
+				"backup: %v", err)
+		}
+
+	default:
+		return nil, errors.New("either a set of single channel " +
+			"backups or a multi channel backup must be specified")
+	}
+
+	return &lnrpc.RestoreBackupResponse{}, nil
+}
@@ -25,7 +25,9 @@ import (
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channelnotifier"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/discovery"
 	"github.com/lightningnetwork/lnd/htlcswitch"
@@ -36,6 +38,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/nat"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/lightningnetwork/lnd/ticker"
 	"github.com/lightningnetwork/lnd/tor"
 )
@@ -152,8 +155,22 @@ type server struct {
 
 	utxoNursery *utxoNursery
 
+	sweeper *sweep.UtxoSweeper
+
 	chainArb *contractcourt.ChainArbitrator
 
+	// channelNotifier notifies subscribers of all lifecycle events of
+	// our channels.
+	channelNotifier *channelnotifier.ChannelNotifier
+
+	// chanBackupNotifier notifies the chanSubSwapper of any channels that
+	// have been opened or closed.
+	chanBackupNotifier *channelNotifier
+
+	// chanSubSwapper keeps the on-disk static channel backup file up to
+	// date with the latest set of open channels.
+	chanSubSwapper *chanbackup.SubSwapper
+
 	sphinx *htlcswitch.OnionProcessor
 
 	connMgr *connmgr.ConnManager
@@ -260,6 +277,8 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 
 		invoices: newInvoiceRegistry(chanDB),
 
+		channelNotifier: channelnotifier.New(chanDB),
+
 		identityPriv: privKey,
 		nodeSigner:   newNodeSigner(privKey),
 
@@ -342,6 +361,8 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 			htlcswitch.DefaultFwdEventInterval),
 		LogEventTicker: ticker.New(
 			htlcswitch.DefaultLogInterval),
+		NotifyActiveChannel:   s.channelNotifier.NotifyActiveChannelEvent,
+		NotifyInactiveChannel: s.channelNotifier.NotifyInactiveChannelEvent,
 	}, uint32(currentHeight))
 	if err != nil {
 		return nil, err
@@ -581,19 +602,36 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 		return nil, err
 	}
 
+	sweeperStore, err := sweep.NewSweeperStore(chanDB)
+	if err != nil {
+		srvrLog.Errorf("unable to create sweeper store: %v", err)
+		return nil, err
+	}
+
+	s.sweeper = sweep.New(&sweep.UtxoSweeperConfig{
+		FeeEstimator: cc.feeEstimator,
+		GenSweepScript: func() ([]byte, error) {
+			return newSweepPkScript(cc.wallet)
+		},
+		Signer:               cc.wallet.Cfg.Signer,
+		PublishTransaction:   cc.wallet.LabelledPublisher(lnwallet.LabelSweep),
+		ChainIO:              cc.chainIO,
+		Notifier:             cc.chainNotifier,
+		Store:                sweeperStore,
+		MaxInputsPerTx:       sweep.DefaultMaxInputsPerTx,
+		NextAttemptDeltaFunc: sweep.DefaultNextAttemptDeltaFunc,
+		NotifyReplacement:    cc.txReplacements.NotifyReplacement,
+	})
+
 	s.utxoNursery = newUtxoNursery(&NurseryConfig{
 		ChainIO:             cc.chainIO,
 		ConfDepth:           1,
 		FetchClosedChannels: chanDB.FetchClosedChannels,
 		FetchClosedChannel:  chanDB.FetchClosedChannel,
-		Estimator:           cc.feeEstimator,
-		GenSweepScript: func() ([]byte, error) {
-			return newSweepPkScript(cc.wallet)
-		},
-		Notifier:           cc.chainNotifier,
-		PublishTransaction: cc.wallet.PublishTransaction,
-		Signer:             cc.wallet.Cfg.Signer,
-		Store:              utxnStore,
+		Notifier:            cc.chainNotifier,
+		PublishTransaction:  cc.wallet.PublishLabelledTransaction,
+		Store:               utxnStore,
+		SweepInput:          s.sweeper.SweepInput,
 	})
 
 	// Construct a closure that wraps the htlcswitch's CloseLink method.
@@ -601,7 +639,7 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 		closureType htlcswitch.ChannelCloseType) {
 		// TODO(conner): Properly respect the update and error channels
 		// returned by CloseLink.
-		s.htlcSwitch.CloseLink(chanPoint, closureType, 0)
+		s.htlcSwitch.CloseLink(chanPoint, closureType, 0, 0, nil)
 	}
 
 	// We will use the following channel to reliably hand off contract
@@ -616,7 +654,7 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 		NewSweepAddr: func() ([]byte, error) {
 			return newSweepPkScript(cc.wallet)
 		},
-		PublishTx: cc.wallet.PublishTransaction,
+		PublishTx: cc.wallet.PublishLabelledTransaction,
 		DeliverResolutionMsg: func(msgs ...contractcourt.ResolutionMsg) error {
 			for _, msg := range msgs {
 				err := s.htlcSwitch.ProcessContractResolution(msg)
@@ -653,12 +691,15 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 		Signer:       cc.wallet.Cfg.Signer,
 		FeeEstimator: cc.feeEstimator,
 		ChainIO:      cc.chainIO,
+		Sweeper:      s.sweeper,
 		MarkLinkInactive: func(chanPoint wire.OutPoint) error {
 			chanID := lnwire.NewChanIDFromOutPoint(&chanPoint)
 			s.htlcSwitch.RemoveLink(chanID)
 			return nil
 		},
-		IsOurAddress: cc.wallet.IsOurAddress,
+		IsOurAddress:             cc.wallet.IsOurAddress,
+		NotifyClosingChannel:     s.channelNotifier.NotifyClosingChannelEvent,
+		NotifyFullyClosedChannel: s.channelNotifier.NotifyClosedChannelEvent,
 		ContractBreach: func(chanPoint wire.OutPoint,
 			breachRet *lnwallet.BreachRetribution) error {
 			event := &ContractBreachEvent{
@@ -685,6 +726,8 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 		DisableChannel: func(op wire.OutPoint) error {
 			return s.announceChanStatus(op, true)
 		},
+		CreateAnchorSweep: cc.wallet.CreateAnchorSweep,
+		CancelAnchorSweep: cc.wallet.CancelAnchorSweep,
 	}, chanDB)
 
 	s.breachArbiter = newBreachArbiter(&BreachConfig{
@@ -694,11 +737,12 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 		GenSweepScript: func() ([]byte, error) {
 			return newSweepPkScript(cc.wallet)
 		},
-		Notifier:           cc.chainNotifier,
-		PublishTransaction: cc.wallet.PublishTransaction,
-		ContractBreaches:   contractBreaches,
-		Signer:             cc.wallet.Cfg.Signer,
-		Store:              newRetributionStore(chanDB),
+		Notifier:                 cc.chainNotifier,
+		PublishTransaction:       cc.wallet.LabelledPublisher(lnwallet.LabelJustice),
+		ContractBreaches:         contractBreaches,
+		Signer:                   cc.wallet.Cfg.Signer,
+		Store:                    newRetributionStore(chanDB),
+		NotifyFullyClosedChannel: s.channelNotifier.NotifyClosedChannelEvent,
 	})
 
 	// Select the configuration and furnding parameters for Bitcoin or
@@ -840,19 +884,44 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 			// the chain arb so it can react to on-chain events.
 			return s.chainArb.WatchNewChannel(channel)
 		},
+		NotifyPendingOpenChanEvent: s.channelNotifier.NotifyPendingOpenChannelEvent,
+		NotifyOpenChannelEvent:     s.channelNotifier.NotifyOpenChannelEvent,
 		ReportShortChanID: func(chanPoint wire.OutPoint) error {
 			cid := lnwire.NewChanIDFromOutPoint(&chanPoint)
 			return s.htlcSwitch.UpdateShortChanID(cid)
 		},
+		SpliceChannel: func(oldChanPoint wire.OutPoint,
+			newChan *channeldb.OpenChannel) error {
+
+			err := s.chainArb.SpliceChannel(oldChanPoint, newChan)
+			if err != nil {
+				return err
+			}
+
+			s.channelNotifier.NotifySplicedChannelEvent(
+				oldChanPoint, newChan.FundingOutpoint,
+			)
+
+			return nil
+		},
 		RequiredRemoteChanReserve: func(chanAmt,
 			dustLimit btcutil.Amount) btcutil.Amount {
 
 			// By default, we'll require the remote peer to maintain
 			// at least 1% of the total channel capacity at all
-			// times. If this value ends up dipping below the dust
-			// limit, then we'll use the dust limit itself as the
-			// reserve as required by BOLT #2.
+			// times, unless the user has explicitly specified a
+			// default reserve, which we cap at 20% of the
+			// capacity, the most our peer will accept. If this
+			// value ends up dipping below the dust limit, then
+			// we'll use the dust limit itself as the reserve as
+			// required by BOLT #2.
 			reserve := chanAmt / 100
+			if chainCfg.DefaultRemoteChanReserve > 0 {
+				reserve = chainCfg.DefaultRemoteChanReserve
+				if reserve > chanAmt/5 {
+					reserve = chanAmt / 5
+				}
+			}
 			if reserve < dustLimit {
 				reserve = dustLimit
 			}
@@ -860,13 +929,28 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 			return reserve
 		},
 		RequiredRemoteMaxValue: func(chanAmt btcutil.Amount) lnwire.MilliSatoshi {
+			// In case the user has explicitly specified a default
+			// max pending amount, we use it, as long as it
+			// doesn't exceed the channel capacity.
+			capacity := lnwire.NewMSatFromSatoshis(chanAmt)
+			maxPending := chainCfg.DefaultRemoteMaxPendingAmt
+			if maxPending > 0 && maxPending <= capacity {
+				return maxPending
+			}
+
 			// By default, we'll allow the remote peer to fully
 			// utilize the full bandwidth of the channel, minus our
 			// required reserve.
 			reserve := lnwire.NewMSatFromSatoshis(chanAmt / 100)
-			return lnwire.NewMSatFromSatoshis(chanAmt) - reserve
+			return capacity - reserve
 		},
 		RequiredRemoteMaxHTLCs: func(chanAmt btcutil.Amount) uint16 {
+			// In case the user has explicitly specified a default
+			// max number of HTLCs, we use it.
+			if chainCfg.DefaultRemoteMaxHtlcs > 0 {
+				return chainCfg.DefaultRemoteMaxHtlcs
+			}
+
 			// By default, we'll permit them to utilize the full
 			// channel bandwidth.
 			return uint16(lnwallet.MaxHTLCNumber / 2)
@@ -874,6 +958,8 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 		ZombieSweeperInterval: 1 * time.Minute,
 		ReservationTimeout:    10 * time.Minute,
 		MinChanSize:           btcutil.Amount(cfg.MinChanSize),
+		MaxChanSize:           btcutil.Amount(cfg.MaxChanSize),
+		AnchorCommitments:     cfg.Anchors,
 	})
 	if err != nil {
 		return nil, err
@@ -895,6 +981,21 @@ func newServer(listenAddrs []net.Addr, chanDB *channeldb.DB, cc *chainControl,
 	}
 	s.connMgr = cmgr
 
+	// Finally, we'll assemble the sub-system that will keep an on-disk
+	// static backup of the latest channel state up to date.
+	startingChans, err := chanbackup.FetchStaticChanBackups(chanDB, chanDB)
+	if err != nil {
+		return nil, err
+	}
+	backupFile := chanbackup.NewMultiFile(cfg.BackupFilePath)
+	s.chanBackupNotifier = newChannelNotifier(s.channelNotifier, chanDB)
+	s.chanSubSwapper, err = chanbackup.NewSubSwapper(
+		startingChans, s.chanBackupNotifier, cc.keyRing, backupFile,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
 
@@ -938,6 +1039,9 @@ func (s *server) Start() error {
 	if err := s.htlcSwitch.Start(); err != nil {
 		return err
 	}
+	if err := s.sweeper.Start(); err != nil {
+		return err
+	}
 	if err := s.utxoNursery.Start(); err != nil {
 		return err
 	}
@@ -956,6 +1060,12 @@ func (s *server) Start() error {
 	if err := s.fundingMgr.Start(); err != nil {
 		return err
 	}
+	if err := s.channelNotifier.Start(); err != nil {
+		return err
+	}
+	if err := s.chanSubSwapper.Start(); err != nil {
+		return err
+	}
 	s.connMgr.Start()
 
 	if err := s.invoices.Start(); err != nil {
@@ -1024,12 +1134,16 @@ func (s *server) Stop() error {
 	s.breachArbiter.Stop()
 	s.authGossiper.Stop()
 	s.chainArb.Stop()
+	s.sweeper.Stop()
 	s.cc.wallet.Shutdown()
 	s.cc.chainView.Stop()
 	s.connMgr.Stop()
 	s.cc.feeEstimator.Stop()
 	s.invoices.Stop()
 	s.fundingMgr.Stop()
+	s.chanSubSwapper.Stop()
+	s.chanBackupNotifier.Stop()
+	s.channelNotifier.Stop()
 
 	// Disconnect from each active peers to ensure that
 	// peerTerminationWatchers signal completion to each peer.
@@ -2257,6 +2371,32 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 	localFeatures.Set(lnwire.DataLossProtectOptional)
 	localFeatures.Set(lnwire.GossipQueriesOptional)
 
+	// We'll also signal that we enforce any upfront shutdown script that
+	// the remote party commits to during the funding workflow.
+	localFeatures.Set(lnwire.UpfrontShutdownScriptOptional)
+
+	// We'll also signal that we're able to create channels that use the
+	// static remote key commitment format.
+	localFeatures.Set(lnwire.StaticRemoteKeyOptional)
+
+	// If the anchor output commitment format is enabled, then we'll signal
+	// that we can create channels using it.
+	if cfg.Anchors {
+		localFeatures.Set(lnwire.AnchorOutputsOptional)
+	}
+
+	// If splicing is enabled, then we'll signal that we're able to take
+	// part in the splice protocol.
+	if cfg.Splicing {
+		localFeatures.Set(lnwire.SpliceOptional)
+	}
+
+	// If we're willing to create channels above the soft-limit, then we'll
+	// signal that we support large channels.
+	if btcutil.Amount(cfg.MaxChanSize) > maxFundingAmount {
+		localFeatures.Set(lnwire.LargeChannelsOptional)
+	}
+
 	// Now that we've established a connection, create a peer, and it to
 	// the set of currently active peers.
 	p, err := newPeer(conn, connReq, s, peerAddr, inbound, localFeatures)
@@ -2573,7 +2713,32 @@ type openChanReq struct {
 	// output selected to fund the channel should satisfy.
 	minConfs int32
 
-	// TODO(roasbeef): add ability to specify channel constraints as well
+	// outpoints is an optional set of wallet outputs to fund the channel
+	// with. If set, all of them are spent instead of selecting coins
+	// automatically.
+	outpoints []wire.OutPoint
+
+	// lockID is the lock ID the outpoints are leased under, if any.
+	lockID *[32]byte
+
+	// shutdownScript is an optional script that we'll commit to paying
+	// our funds out to upon a cooperative close of the channel. If set,
+	// the remote party will refuse to cooperatively close the channel to
+	// any other script.
+	shutdownScript lnwire.DeliveryAddress
+
+	// remoteChanReserve is the channel reserve we require the remote
+	// party to maintain. If zero, our default policy is used.
+	remoteChanReserve btcutil.Amount
+
+	// remoteMaxValue is the maximum value we allow the remote party to
+	// have in outstanding HTLCs towards us. If zero, our default policy
+	// is used.
+	remoteMaxValue lnwire.MilliSatoshi
+
+	// remoteMaxHtlcs is the maximum number of HTLCs we allow the remote
+	// party to offer us. If zero, our default policy is used.
+	remoteMaxHtlcs uint16
 
 	updates chan *lnrpc.OpenStatusUpdate
 	err     chan error
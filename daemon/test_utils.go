@@ -16,6 +16,7 @@ import (
 	"github.com/btcsuite/btcutil"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channelnotifier"
 	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/keychain"
@@ -185,7 +186,7 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 
 	aliceCommitTx, bobCommitTx, err := lnwallet.CreateCommitmentTxns(channelBal,
 		channelBal, &aliceCfg, &bobCfg, aliceCommitPoint, bobCommitPoint,
-		*fundingTxIn)
+		*fundingTxIn, channeldb.SingleFunder)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -313,6 +314,9 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 
 	chainIO := &mockChainIO{}
 	wallet := &lnwallet.LightningWallet{
+		Cfg: lnwallet.Config{
+			Database: dbAlice,
+		},
 		WalletController: &mockWalletController{
 			rootKey:               aliceKeyPriv,
 			publishedTransactions: publTx,
@@ -340,6 +344,8 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 		cc:            cc,
 		breachArbiter: breachArbiter,
 		chainArb:      chainArb,
+
+		channelNotifier: channelnotifier.New(dbAlice),
 	}
 
 	_, currentHeight, err := s.cc.chainIO.GetBestBlock()
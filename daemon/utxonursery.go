@@ -8,7 +8,6 @@ import (
 	"sync"
 	"sync/atomic"
 
-	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
@@ -16,6 +15,7 @@ import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/sweep"
 )
 
 //                          SUMMARY OF OUTPUT STATES
@@ -164,6 +164,12 @@ import (
 
 var byteOrder = binary.BigEndian
 
+const (
+	// kgtnSweepConfTarget is the default confirmation target we'll use
+	// when sweeping kindergarten outputs.
+	kgtnSweepConfTarget = 6
+)
+
 var (
 	// ErrContractNotFound is returned when the nursery is unable to
 	// retrieve information about a queried contract.
@@ -191,30 +197,24 @@ type NurseryConfig struct {
 	FetchClosedChannel func(chanID *wire.OutPoint) (
 		*channeldb.ChannelCloseSummary, error)
 
-	// Estimator is used when crafting sweep transactions to estimate the
-	// necessary fee relative to the expected size of the sweep transaction.
-	Estimator lnwallet.FeeEstimator
-
-	// GenSweepScript generates a P2WKH script belonging to the wallet where
-	// funds can be swept.
-	GenSweepScript func() ([]byte, error)
-
 	// Notifier provides the utxo nursery the ability to subscribe to
 	// transaction confirmation events, which advance outputs through their
 	// persistence state transitions.
 	Notifier chainntnfs.ChainNotifier
 
 	// PublishTransaction facilitates the process of broadcasting a signed
-	// transaction to the appropriate network.
-	PublishTransaction func(*wire.MsgTx) error
-
-	// Signer is used by the utxo nursery to generate valid witnesses at the
-	// time the incubated outputs need to be spent.
-	Signer lnwallet.Signer
+	// transaction to the appropriate network, labelled with the passed
+	// label.
+	PublishTransaction func(tx *wire.MsgTx, label string) error
 
 	// Store provides access to and modification of the persistent state
 	// maintained about the utxo nursery's incubating outputs.
 	Store NurseryStore
+
+	// SweepInput hands mature outputs to the sweeper, which batches them
+	// with other inputs into a sweep transaction back to the wallet.
+	SweepInput func(sweep.Input, sweep.FeePreference) (chan sweep.Result,
+		error)
 }
 
 // utxoNursery is a system dedicated to incubating time-locked outputs created
@@ -746,25 +746,22 @@ func (u *utxoNursery) reloadClasses(lastGradHeight uint32) error {
 // transactions or signing are done as a result of this step.
 func (u *utxoNursery) regraduateClass(classHeight uint32) error {
 	// Fetch all information about the crib and kindergarten outputs at
-	// this height. In addition to the outputs, we also retrieve the
-	// finalized kindergarten sweep txn, which will be nil if we have not
-	// attempted this height before, or if no kindergarten outputs exist at
 	// this height.
-	finalTx, kgtnOutputs, cribOutputs, err := u.cfg.Store.FetchClass(
+	_, kgtnOutputs, cribOutputs, err := u.cfg.Store.FetchClass(
 		classHeight)
 	if err != nil {
 		return err
 	}
 
-	if finalTx != nil {
-		utxnLog.Infof("Re-registering confirmation for kindergarten "+
-			"sweep transaction at height=%d ", classHeight)
+	if len(kgtnOutputs) > 0 {
+		utxnLog.Infof("Re-offering %d kindergarten outputs at "+
+			"height=%d to the sweeper", len(kgtnOutputs),
+			classHeight)
 
-		err = u.sweepMatureOutputs(classHeight, finalTx, kgtnOutputs)
+		err = u.sweepMatureOutputs(classHeight, kgtnOutputs)
 		if err != nil {
-			utxnLog.Errorf("Failed to re-register for kindergarten "+
-				"sweep transaction at height=%d: %v",
-				classHeight, err)
+			utxnLog.Errorf("Failed to re-offer kindergarten "+
+				"outputs at height=%d: %v", classHeight, err)
 			return err
 		}
 	}
@@ -848,11 +845,8 @@ func (u *utxoNursery) graduateClass(classHeight uint32) error {
 	u.bestHeight = classHeight
 
 	// Fetch all information about the crib and kindergarten outputs at
-	// this height. In addition to the outputs, we also retrieve the
-	// finalized kindergarten sweep txn, which will be nil if we have not
-	// attempted this height before, or if no kindergarten outputs exist at
 	// this height.
-	finalTx, kgtnOutputs, cribOutputs, err := u.cfg.Store.FetchClass(
+	_, kgtnOutputs, cribOutputs, err := u.cfg.Store.FetchClass(
 		classHeight)
 	if err != nil {
 		return err
@@ -861,55 +855,10 @@ func (u *utxoNursery) graduateClass(classHeight uint32) error {
 	utxnLog.Infof("Attempting to graduate height=%v: num_kids=%v, "+
 		"num_babies=%v", classHeight, len(kgtnOutputs), len(cribOutputs))
 
-	// Load the last finalized height, so we can determine if the
-	// kindergarten sweep txn should be crafted.
-	lastFinalizedHeight, err := u.cfg.Store.LastFinalizedHeight()
-	if err != nil {
-		return err
-	}
-
-	// If we haven't processed this height before, we finalize the
-	// graduating kindergarten outputs, by signing a sweep transaction that
-	// spends from them. This txn is persisted such that we never broadcast
-	// a different txn for the same height. This allows us to recover from
-	// failures, and watch for the correct txid.
-	if classHeight > lastFinalizedHeight {
-		// If this height has never been finalized, we have never
-		// generated a sweep txn for this height. Generate one if there
-		// are kindergarten outputs or cltv crib outputs to be spent.
-		if len(kgtnOutputs) > 0 {
-			finalTx, err = u.createSweepTx(kgtnOutputs, classHeight)
-			if err != nil {
-				utxnLog.Errorf("Failed to create sweep txn at "+
-					"height=%d", classHeight)
-				return err
-			}
-		}
-
-		// Persist the kindergarten sweep txn to the nursery store. It
-		// is safe to store a nil finalTx, which happens if there are
-		// no graduating kindergarten outputs.
-		err = u.cfg.Store.FinalizeKinder(classHeight, finalTx)
-		if err != nil {
-			utxnLog.Errorf("Failed to finalize kindergarten at "+
-				"height=%d", classHeight)
-
-			return err
-		}
-
-		// Log if the finalized transaction is non-trivial.
-		if finalTx != nil {
-			utxnLog.Infof("Finalized kindergarten at height=%d ",
-				classHeight)
-		}
-	}
-
-	// Now that the kindergarten sweep txn has either been finalized or
-	// restored, broadcast the txn, and set up notifications that will
-	// transition the swept kindergarten outputs and cltvCrib into
-	// graduated outputs.
-	if finalTx != nil {
-		err := u.sweepMatureOutputs(classHeight, finalTx, kgtnOutputs)
+	// Offer the outputs to the sweeper and set up notifications that will
+	// transition the swept kindergarten outputs into graduated outputs.
+	if len(kgtnOutputs) > 0 {
+		err := u.sweepMatureOutputs(classHeight, kgtnOutputs)
 		if err != nil {
 			utxnLog.Errorf("Failed to sweep %d kindergarten "+
 				"outputs at height=%d: %v",
@@ -934,280 +883,78 @@ func (u *utxoNursery) graduateClass(classHeight uint32) error {
 	return u.cfg.Store.GraduateHeight(classHeight)
 }
 
-// craftSweepTx accepts a list of kindergarten outputs, and baby
-// outputs which don't require a second-layer claim, and signs and generates a
-// signed txn that spends from them. This method also makes an accurate fee
-// estimate before generating the required witnesses.
-func (u *utxoNursery) createSweepTx(kgtnOutputs []kidOutput,
-	classHeight uint32) (*wire.MsgTx, error) {
-
-	// Create a transaction which sweeps all the newly mature outputs into
-	// an output controlled by the wallet.
-
-	// TODO(roasbeef): can be more intelligent about buffering outputs to
-	// be more efficient on-chain.
-
-	// Assemble the kindergarten class into a slice csv spendable outputs,
-	// and also a set of regular spendable outputs. The set of regular
-	// outputs are CLTV locked outputs that have had their timelocks
-	// expire.
-	var (
-		csvOutputs     []CsvSpendableOutput
-		cltvOutputs    []SpendableOutput
-		weightEstimate lnwallet.TxWeightEstimator
-	)
+// sweepMatureOutputs hands the kindergarten outputs to the sweeper, which
+// transfers control of the funds from a prior channel commitment transaction
+// to the user's wallet. The outputs swept were previously time locked (either
+// absolute or relative), but are now mature enough to sweep into the wallet.
+func (u *utxoNursery) sweepMatureOutputs(classHeight uint32,
+	kgtnOutputs []kidOutput) error {
 
-	// Allocate enough room for both types of kindergarten outputs.
-	csvOutputs = make([]CsvSpendableOutput, 0, len(kgtnOutputs))
-	cltvOutputs = make([]SpendableOutput, 0, len(kgtnOutputs))
+	utxnLog.Infof("Sweeping %v CSV-delayed outputs at height=%d",
+		len(kgtnOutputs), classHeight)
 
-	// Our sweep transaction will pay to a single segwit p2wkh address,
-	// ensure it contributes to our weight estimate.
-	weightEstimate.AddP2WKHOutput()
+	feePref := sweep.FeePreference{ConfTarget: kgtnSweepConfTarget}
 
-	// For each kindergarten output, use its witness type to determine the
-	// estimate weight of its witness, and add it to the proper set of
-	// spendable outputs.
+	resultChans := make([]chan sweep.Result, 0, len(kgtnOutputs))
 	for i := range kgtnOutputs {
-		input := &kgtnOutputs[i]
-
-		switch input.WitnessType() {
-
-		// Outputs on a past commitment transaction that pay directly
-		// to us.
-		case lnwallet.CommitmentTimeLock:
-			weightEstimate.AddWitnessInput(
-				lnwallet.ToLocalTimeoutWitnessSize,
-			)
-			csvOutputs = append(csvOutputs, input)
-
-		// Outgoing second layer HTLC's that have confirmed within the
-		// chain, and the output they produced is now mature enough to
-		// sweep.
-		case lnwallet.HtlcOfferedTimeoutSecondLevel:
-			weightEstimate.AddWitnessInput(
-				lnwallet.ToLocalTimeoutWitnessSize,
-			)
-			csvOutputs = append(csvOutputs, input)
-
-		// Incoming second layer HTLC's that have confirmed within the
-		// chain, and the output they produced is now mature enough to
-		// sweep.
-		case lnwallet.HtlcAcceptedSuccessSecondLevel:
-			weightEstimate.AddWitnessInput(
-				lnwallet.ToLocalTimeoutWitnessSize,
-			)
-			csvOutputs = append(csvOutputs, input)
-
-		// An HTLC on the commitment transaction of the remote party,
-		// that has had its absolute timelock expire.
-		case lnwallet.HtlcOfferedRemoteTimeout:
-			weightEstimate.AddWitnessInput(
-				lnwallet.AcceptedHtlcTimeoutWitnessSize,
-			)
-			cltvOutputs = append(cltvOutputs, input)
-
-		default:
-			utxnLog.Warnf("kindergarten output in nursery store "+
-				"contains unexpected witness type: %v",
-				input.WitnessType())
-			continue
-		}
-	}
-
-	utxnLog.Infof("Creating sweep transaction for %v CSV inputs, %v CLTV "+
-		"inputs", len(csvOutputs), len(cltvOutputs))
-
-	txWeight := int64(weightEstimate.Weight())
-	return u.populateSweepTx(txWeight, classHeight, csvOutputs, cltvOutputs)
-}
-
-// populateSweepTx populate the final sweeping transaction with all witnesses
-// in place for all inputs using the provided txn fee. The created transaction
-// has a single output sending all the funds back to the source wallet, after
-// accounting for the fee estimate.
-func (u *utxoNursery) populateSweepTx(txWeight int64, classHeight uint32,
-	csvInputs []CsvSpendableOutput,
-	cltvInputs []SpendableOutput) (*wire.MsgTx, error) {
-
-	// Generate the receiving script to which the funds will be swept.
-	pkScript, err := u.cfg.GenSweepScript()
-	if err != nil {
-		return nil, err
-	}
-
-	// Sum up the total value contained in the inputs.
-	var totalSum btcutil.Amount
-	for _, o := range csvInputs {
-		totalSum += o.Amount()
-	}
-	for _, o := range cltvInputs {
-		totalSum += o.Amount()
-	}
-
-	// Using the txn weight estimate, compute the required txn fee.
-	feePerKw, err := u.cfg.Estimator.EstimateFeePerKW(6)
-	if err != nil {
-		return nil, err
-	}
-	txFee := feePerKw.FeeForWeight(txWeight)
-
-	// Sweep as much possible, after subtracting txn fees.
-	sweepAmt := int64(totalSum - txFee)
-
-	// Create the sweep transaction that we will be building. We use
-	// version 2 as it is required for CSV. The txn will sweep the amount
-	// after fees to the pkscript generated above.
-	sweepTx := wire.NewMsgTx(2)
-	sweepTx.AddTxOut(&wire.TxOut{
-		PkScript: pkScript,
-		Value:    sweepAmt,
-	})
-
-	// We'll also ensure that the transaction has the required lock time if
-	// we're sweeping any cltvInputs.
-	if len(cltvInputs) > 0 {
-		sweepTx.LockTime = classHeight
-	}
-
-	// Add all inputs to the sweep transaction. Ensure that for each
-	// csvInput, we set the sequence number properly.
-	for _, input := range csvInputs {
-		sweepTx.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: *input.OutPoint(),
-			Sequence:         input.BlocksToMaturity(),
-		})
-	}
-	for _, input := range cltvInputs {
-		sweepTx.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: *input.OutPoint(),
-		})
-	}
-
-	// Before signing the transaction, check to ensure that it meets some
-	// basic validity requirements.
-	// TODO(conner): add more control to sanity checks, allowing us to delay
-	// spending "problem" outputs, e.g. possibly batching with other classes
-	// if fees are too low.
-	btx := btcutil.NewTx(sweepTx)
-	if err := blockchain.CheckTransactionSanity(btx); err != nil {
-		return nil, err
-	}
-
-	hashCache := txscript.NewTxSigHashes(sweepTx)
-
-	// With all the inputs in place, use each output's unique witness
-	// function to generate the final witness required for spending.
-	addWitness := func(idx int, tso SpendableOutput) error {
-		witness, err := tso.BuildWitness(
-			u.cfg.Signer, sweepTx, hashCache, idx,
-		)
+		resultChan, err := u.cfg.SweepInput(&kgtnOutputs[i], feePref)
 		if err != nil {
 			return err
 		}
 
-		sweepTx.TxIn[idx].Witness = witness
-
-		return nil
-	}
-
-	// Finally we'll attach a valid witness to each csv and cltv input
-	// within the sweeping transaction.
-	for i, input := range csvInputs {
-		if err := addWitness(i, input); err != nil {
-			return nil, err
-		}
-	}
-
-	// Add offset to relative indexes so cltv witnesses don't overwrite csv
-	// witnesses.
-	offset := len(csvInputs)
-	for i, input := range cltvInputs {
-		if err := addWitness(offset+i, input); err != nil {
-			return nil, err
-		}
-	}
-
-	return sweepTx, nil
-}
-
-// sweepMatureOutputs generates and broadcasts the transaction that transfers
-// control of funds from a prior channel commitment transaction to the user's
-// wallet. The outputs swept were previously time locked (either absolute or
-// relative), but are not mature enough to sweep into the wallet.
-func (u *utxoNursery) sweepMatureOutputs(classHeight uint32, finalTx *wire.MsgTx,
-	kgtnOutputs []kidOutput) error {
-
-	utxnLog.Infof("Sweeping %v CSV-delayed outputs with sweep tx "+
-		"(txid=%v): %v", len(kgtnOutputs),
-		finalTx.TxHash(), newLogClosure(func() string {
-			return spew.Sdump(finalTx)
-		}),
-	)
-
-	// With the sweep transaction fully signed, broadcast the transaction
-	// to the network. Additionally, we can stop tracking these outputs as
-	// they've just been swept.
-	err := u.cfg.PublishTransaction(finalTx)
-	if err != nil && err != lnwallet.ErrDoubleSpend {
-		utxnLog.Errorf("unable to broadcast sweep tx: %v, %v",
-			err, spew.Sdump(finalTx))
-		return err
-	}
-
-	return u.registerSweepConf(finalTx, kgtnOutputs, classHeight)
-}
-
-// registerSweepConf is responsible for registering a finalized kindergarten
-// sweep transaction for confirmation notifications. If the confirmation was
-// successfully registered, a goroutine will be spawned that waits for the
-// confirmation, and graduates the provided kindergarten class within the
-// nursery store.
-func (u *utxoNursery) registerSweepConf(finalTx *wire.MsgTx,
-	kgtnOutputs []kidOutput, heightHint uint32) error {
-
-	finalTxID := finalTx.TxHash()
-
-	confChan, err := u.cfg.Notifier.RegisterConfirmationsNtfn(
-		&finalTxID, finalTx.TxOut[0].PkScript, u.cfg.ConfDepth,
-		heightHint,
-	)
-	if err != nil {
-		utxnLog.Errorf("unable to register notification for "+
-			"sweep confirmation: %v", finalTxID)
-		return err
+		resultChans = append(resultChans, resultChan)
 	}
 
-	utxnLog.Infof("Registering sweep tx %v for confs at height=%d",
-		finalTxID, heightHint)
-
 	u.wg.Add(1)
-	go u.waitForSweepConf(heightHint, kgtnOutputs, confChan)
+	go u.waitForSweepConf(classHeight, kgtnOutputs, resultChans)
 
 	return nil
 }
 
-// waitForSweepConf watches for the confirmation of a sweep transaction
-// containing a batch of kindergarten outputs. Once confirmation has been
-// received, the nursery will mark those outputs as fully graduated, and proceed
-// to mark any mature channels as fully closed in channeldb.
+// waitForSweepConf watches for the confirmation of the sweep transactions
+// spending a batch of kindergarten outputs. Once confirmation has been
+// received for all outputs, the nursery will mark those outputs as fully
+// graduated, and proceed to mark any mature channels as fully closed in
+// channeldb.
 // NOTE(conner): this method MUST be called as a go routine.
 func (u *utxoNursery) waitForSweepConf(classHeight uint32,
-	kgtnOutputs []kidOutput, confChan *chainntnfs.ConfirmationEvent) {
+	kgtnOutputs []kidOutput, resultChans []chan sweep.Result) {
 
 	defer u.wg.Done()
 
-	select {
-	case _, ok := <-confChan.Confirmed:
-		if !ok {
-			utxnLog.Errorf("Notification chan closed, can't"+
-				" advance %v graduating outputs",
-				len(kgtnOutputs))
+	for i, resultChan := range resultChans {
+		select {
+		case result, ok := <-resultChan:
+			if !ok {
+				utxnLog.Errorf("Notification chan closed, "+
+					"can't advance %v graduating outputs",
+					len(kgtnOutputs))
+				return
+			}
+
+			switch result.Err {
+
+			// The output was swept by us.
+			case nil:
+
+			// The output has been spent by the remote party. It
+			// is gone, so it graduates as well.
+			case sweep.ErrRemoteSpend:
+				utxnLog.Warnf("Kindergarten output %v at "+
+					"height=%d was spent by the remote "+
+					"party", kgtnOutputs[i].OutPoint(),
+					classHeight)
+
+			default:
+				utxnLog.Errorf("Unable to sweep kindergarten "+
+					"output %v: %v",
+					kgtnOutputs[i].OutPoint(), result.Err)
+				return
+			}
+
+		case <-u.quit:
 			return
 		}
-
-	case <-u.quit:
-		return
 	}
 
 	u.mu.Lock()
@@ -1257,7 +1004,9 @@ func (u *utxoNursery) sweepCribOutput(classHeight uint32, baby *babyOutput) erro
 
 	// We'll now broadcast the HTLC transaction, then wait for it to be
 	// confirmed before transitioning it to kindergarten.
-	err := u.cfg.PublishTransaction(baby.timeoutTx)
+	err := u.cfg.PublishTransaction(
+		baby.timeoutTx, lnwallet.HtlcTimeoutLabel(*baby.OriginChanPoint()),
+	)
 	if err != nil && err != lnwallet.ErrDoubleSpend {
 		utxnLog.Errorf("Unable to broadcast baby tx: "+
 			"%v, %v", err, spew.Sdump(baby.timeoutTx))
@@ -1810,9 +1559,26 @@ func (k *kidOutput) ConfHeight() uint32 {
 	return k.confHeight
 }
 
+// HeightHint returns the minimum height at which a confirmed spending tx can
+// occur, which is the height at which the output confirmed.
+func (k *kidOutput) HeightHint() uint32 {
+	return k.confHeight
+}
+
+// RequiredLockTime returns the absolute lock time that the sweeping
+// transaction must have to spend this output. Only outgoing HTLC outputs on
+// the remote commitment transaction are CLTV locked.
+func (k *kidOutput) RequiredLockTime() (uint32, bool) {
+	if k.WitnessType() == lnwallet.HtlcOfferedRemoteTimeout {
+		return k.absoluteMaturity, true
+	}
+
+	return 0, false
+}
+
 // Encode converts a KidOutput struct into a form suitable for on-disk database
 // storage. Note that the signDescriptor struct field is included so that the
-// output's witness can be generated by createSweepTx() when the output becomes
+// output's witness can be generated by the sweeper when the output becomes
 // spendable.
 func (k *kidOutput) Encode(w io.Writer) error {
 	var scratch [8]byte
@@ -1972,3 +1738,6 @@ func readTxOut(r io.Reader, txo *wire.TxOut) error {
 // CsvSpendableOutput interface.
 var _ CsvSpendableOutput = (*kidOutput)(nil)
 var _ CsvSpendableOutput = (*babyOutput)(nil)
+
+// Add compile-time constraint ensuring kidOutput implements sweep.Input.
+var _ sweep.Input = (*kidOutput)(nil)
@@ -20,6 +20,7 @@ import (
 	"github.com/btcsuite/btcutil"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/sweep"
 )
 
 var (
@@ -398,6 +399,7 @@ type nurseryTestContext struct {
 	notifier    *nurseryMockNotifier
 	publishChan chan wire.MsgTx
 	store       *nurseryStoreInterceptor
+	sweeper     *mockSweeper
 	restart     func() bool
 	receiveTx   func() wire.MsgTx
 	t           *testing.T
@@ -431,6 +433,8 @@ func createNurseryTestContext(t *testing.T,
 
 	notifier := newNurseryMockNotifier(t)
 
+	sweeper := newMockSweeper(t)
+
 	cfg := NurseryConfig{
 		Notifier: notifier,
 		FetchClosedChannels: func(pendingOnly bool) (
@@ -443,17 +447,13 @@ func createNurseryTestContext(t *testing.T,
 				CloseHeight: 0,
 			}, nil
 		},
-		Store:   storeIntercepter,
-		ChainIO: &mockChainIO{},
-		GenSweepScript: func() ([]byte, error) {
-			return []byte{}, nil
-		},
-		Estimator: &mockFeeEstimator{},
-		Signer:    &nurseryMockSigner{},
+		Store:      storeIntercepter,
+		ChainIO:    &mockChainIO{},
+		SweepInput: sweeper.sweepInput,
 	}
 
 	publishChan := make(chan wire.MsgTx, 1)
-	cfg.PublishTransaction = func(tx *wire.MsgTx) error {
+	cfg.PublishTransaction = func(tx *wire.MsgTx, _ string) error {
 		t.Logf("Publishing tx %v", tx.TxHash())
 		publishChan <- *tx
 		return nil
@@ -467,6 +467,7 @@ func createNurseryTestContext(t *testing.T,
 		notifier:    notifier,
 		store:       storeIntercepter,
 		publishChan: publishChan,
+		sweeper:     sweeper,
 		t:           t,
 	}
 
@@ -533,6 +534,14 @@ func (ctx *nurseryTestContext) finish() {
 	default:
 	}
 
+	// We should also have asserted all inputs that were offered to the
+	// sweeper.
+	select {
+	case <-ctx.sweeper.sweepChan:
+		ctx.t.Fatalf("unexpected inputs offered to sweeper")
+	default:
+	}
+
 	// Assert that the database is empty. All channels removed and height
 	// index cleared.
 	nurseryChannels, err := ctx.nursery.cfg.Store.ListChannels()
@@ -871,22 +880,19 @@ func testSweepHtlc(t *testing.T, ctx *nurseryTestContext) {
 
 func testSweep(t *testing.T, ctx *nurseryTestContext,
 	afterPublishAssert func()) {
-	// Wait for nursery to publish the sweep tx.
-	sweepTx := ctx.receiveTx()
+	// Wait for nursery to offer the output to the sweeper.
+	ctx.sweeper.expectSweep()
 
 	if ctx.restart() {
-		// Restart will trigger rebroadcast of sweep tx.
-		sweepTx = ctx.receiveTx()
+		// Restart will trigger the nursery to offer the output to the
+		// sweeper again.
+		ctx.sweeper.expectSweep()
 	}
 
 	afterPublishAssert()
 
-	// Confirm the sweep tx.
-	sweepTxHash := sweepTx.TxHash()
-	err := ctx.notifier.confirmTx(&sweepTxHash, 129)
-	if err != nil {
-		t.Fatal(err)
-	}
+	// Simulate the confirmation of the sweep tx.
+	ctx.sweeper.sweepAll()
 
 	// Wait for output to be promoted in store to GRAD.
 	select {
@@ -1006,36 +1012,6 @@ func (i *nurseryStoreInterceptor) RemoveChannel(chanPoint *wire.OutPoint) error
 	return i.ns.RemoveChannel(chanPoint)
 }
 
-type mockFeeEstimator struct{}
-
-func (m *mockFeeEstimator) EstimateFeePerKW(
-	numBlocks uint32) (lnwallet.SatPerKWeight, error) {
-
-	return lnwallet.SatPerKWeight(10000), nil
-}
-
-func (m *mockFeeEstimator) Start() error {
-	return nil
-}
-func (m *mockFeeEstimator) Stop() error {
-	return nil
-}
-
-type nurseryMockSigner struct {
-}
-
-func (m *nurseryMockSigner) SignOutputRaw(tx *wire.MsgTx,
-	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
-
-	return []byte{}, nil
-}
-
-func (m *nurseryMockSigner) ComputeInputScript(tx *wire.MsgTx,
-	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
-
-	return &lnwallet.InputScript{}, nil
-}
-
 type nurseryMockNotifier struct {
 	confChannel map[chainhash.Hash]chan *chainntnfs.TxConfirmation
 	epochChan   chan *chainntnfs.BlockEpoch
@@ -1124,3 +1100,65 @@ func (m *nurseryMockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 		Cancel: func() {},
 	}, nil
 }
+
+type mockSweeper struct {
+	lock sync.Mutex
+
+	resultChans map[wire.OutPoint]chan sweep.Result
+	t           *testing.T
+
+	sweepChan chan sweep.Input
+}
+
+func newMockSweeper(t *testing.T) *mockSweeper {
+	return &mockSweeper{
+		resultChans: make(map[wire.OutPoint]chan sweep.Result),
+		sweepChan:   make(chan sweep.Input, 1),
+		t:           t,
+	}
+}
+
+func (s *mockSweeper) sweepInput(input sweep.Input,
+	_ sweep.FeePreference) (chan sweep.Result, error) {
+
+	utxnLog.Debugf("mockSweeper sweepInput called for %v", *input.OutPoint())
+
+	select {
+	case s.sweepChan <- input:
+	case <-time.After(defaultTestTimeout):
+		s.t.Fatal("signal result timeout")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c := make(chan sweep.Result, 1)
+	s.resultChans[*input.OutPoint()] = c
+
+	return c, nil
+}
+
+func (s *mockSweeper) expectSweep() {
+	s.t.Helper()
+
+	select {
+	case <-s.sweepChan:
+	case <-time.After(defaultTestTimeout):
+		s.t.Fatal("signal result timeout")
+	}
+}
+
+func (s *mockSweeper) sweepAll() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for o, c := range s.resultChans {
+		utxnLog.Debugf("mockSweeper signal swept for %v", o)
+		select {
+		case c <- sweep.Result{}:
+		case <-time.After(defaultTestTimeout):
+			s.t.Fatal("signal result timeout")
+		}
+	}
+	s.resultChans = make(map[wire.OutPoint]chan sweep.Result)
+}
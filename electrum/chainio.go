@@ -0,0 +1,186 @@
+package electrum
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+// txIDBatchSize is the number of transaction hashes requested at once when
+// fetching the transactions of a block.
+const txIDBatchSize = 64
+
+var (
+	// ErrOutputSpent is returned by GetUtxo if the target output has
+	// already been spent.
+	ErrOutputSpent = errors.New("target output has been spent")
+
+	// ErrOutputNotFound is returned by GetUtxo if the target output could
+	// not be located.
+	ErrOutputNotFound = errors.New("target output was not found")
+)
+
+// A compile time check to ensure Client implements the lnwallet.BlockChainIO
+// interface.
+var _ lnwallet.BlockChainIO = (*Client)(nil)
+
+// GetUtxo returns the original output referenced by the passed outpoint that
+// creates the target pkScript. As the server indexes outputs by their script,
+// the script is required to look up the output.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (c *Client) GetUtxo(op *wire.OutPoint, pkScript []byte,
+	heightHint uint32) (*wire.TxOut, error) {
+
+	scriptHash := ScriptHash(pkScript)
+	unspent, err := c.ScriptHashUnspent(scriptHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, utxo := range unspent {
+		if utxo.TxHash == op.Hash.String() && utxo.TxPos == op.Index {
+			return &wire.TxOut{
+				Value:    utxo.Value,
+				PkScript: pkScript,
+			}, nil
+		}
+	}
+
+	// The output isn't unspent, so we'll check whether the transaction
+	// creating it is known to distinguish spent from unknown outputs.
+	history, err := c.ScriptHashHistory(scriptHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range history {
+		if entry.TxHash == op.Hash.String() {
+			return nil, ErrOutputSpent
+		}
+	}
+
+	return nil, ErrOutputNotFound
+}
+
+// GetBlock returns the block with the given hash. As Electrum servers don't
+// serve raw blocks, the block is assembled from its transactions, which are
+// looked up by their position within the block. The merkle root of the
+// assembled block is checked against its header, so a server can't omit or
+// alter any transactions.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (c *Client) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+	height, err := c.BlockHeight(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// The transactions are looked up by height, so we'll make sure the
+	// block is still part of the main chain.
+	header, err := c.GetBlockHeaderByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	if header.BlockHash() != *blockHash {
+		return nil, fmt.Errorf("block %v is no longer part of the "+
+			"main chain", blockHash)
+	}
+
+	txids, err := c.blockTxIDs(height)
+	if err != nil {
+		return nil, err
+	}
+	if merkleRoot(txids) != header.MerkleRoot {
+		return nil, fmt.Errorf("transactions of block %v don't match "+
+			"its merkle root", blockHash)
+	}
+
+	txns := make([]*wire.MsgTx, len(txids))
+	err = parallel(len(txids), func(i int) error {
+		tx, err := c.GetTransaction(&txids[i])
+		if err != nil {
+			return err
+		}
+		txns[i] = tx
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &wire.MsgBlock{
+		Header:       *header,
+		Transactions: txns,
+	}, nil
+}
+
+// blockTxIDs returns the hashes of all transactions of the block at the given
+// height, in the order they appear in the block. As the protocol provides no
+// way to learn the number of transactions in a block, we'll request the
+// transactions in batches until the server reports that there's no
+// transaction at a position.
+func (c *Client) blockTxIDs(height int32) ([]chainhash.Hash, error) {
+	var txids []chainhash.Hash
+	for start := uint32(0); ; start += txIDBatchSize {
+		batch := make([]*chainhash.Hash, txIDBatchSize)
+		batchErrs := make([]error, txIDBatchSize)
+		parallel(txIDBatchSize, func(i int) error {
+			batch[i], batchErrs[i] = c.TxIDFromPos(
+				height, start+uint32(i),
+			)
+			return nil
+		})
+
+		for i, txid := range batch {
+			switch err := batchErrs[i].(type) {
+			case nil:
+
+			// The server rejected the position, so we've reached
+			// the end of the block. Whether we've actually got all
+			// transactions is verified against the merkle root.
+			case *ServerError:
+				return txids, nil
+
+			default:
+				return nil, err
+			}
+
+			txids = append(txids, *txid)
+		}
+	}
+}
+
+// merkleRoot computes the merkle root of the given transaction hashes.
+func merkleRoot(txids []chainhash.Hash) chainhash.Hash {
+	if len(txids) == 0 {
+		return chainhash.Hash{}
+	}
+
+	level := make([]*chainhash.Hash, len(txids))
+	for i := range txids {
+		level[i] = &txids[i]
+	}
+
+	for len(level) > 1 {
+		// If there is an odd number of hashes, the last one is hashed
+		// with itself.
+		if len(level)%2 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+
+		next := make([]*chainhash.Hash, len(level)/2)
+		for i := range next {
+			next[i] = blockchain.HashMerkleBranches(
+				level[2*i], level[2*i+1],
+			)
+		}
+		level = next
+	}
+
+	return *level[0]
+}
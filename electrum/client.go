@@ -0,0 +1,877 @@
+package electrum
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+const (
+	// ProtocolVersion is the version of the Electrum protocol spoken by
+	// the client. It is negotiated with the server when connecting.
+	ProtocolVersion = "1.4"
+
+	// DefaultRequestTimeout is the default time we'll wait for the server
+	// to respond to a request before giving up.
+	DefaultRequestTimeout = 30 * time.Second
+
+	// DefaultReconnectInterval is the default time we'll wait between
+	// attempts to reconnect to the server after the connection was lost.
+	DefaultReconnectInterval = 5 * time.Second
+
+	// clientName is the name we report to the server during the version
+	// handshake.
+	clientName = "lnd"
+
+	// maxConcurrentRequests is the maximum number of requests a single
+	// call of the client keeps in flight at once, e.g. when fetching all
+	// transactions of a block.
+	maxConcurrentRequests = 32
+)
+
+var (
+	// ErrClientShuttingDown is returned for requests that couldn't be
+	// completed as the client is shutting down.
+	ErrClientShuttingDown = errors.New("electrum client shutting down")
+
+	// ErrRequestTimeout is returned if the server doesn't respond to a
+	// request within the configured timeout, or if no connection to the
+	// server could be established within that time.
+	ErrRequestTimeout = errors.New("electrum request timed out")
+
+	// ErrDisconnected is returned for requests whose connection to the
+	// server was lost before a response was received.
+	ErrDisconnected = errors.New("connection to electrum server lost")
+)
+
+// ServerError is an error returned by the Electrum server in response to a
+// request.
+type ServerError struct {
+	// Code is the JSON-RPC error code. It is zero if the server only
+	// returned an error message.
+	Code int `json:"code"`
+
+	// Message describes the error.
+	Message string `json:"message"`
+}
+
+// Error returns a human readable description of the server error.
+//
+// NOTE: This is part of the error interface.
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("electrum server error %d: %v", e.Code, e.Message)
+}
+
+// Config houses the parameters of the connection to an Electrum server.
+type Config struct {
+	// Server is the host:port of the Electrum server.
+	Server string
+
+	// UseTLS indicates whether the connection to the server is secured
+	// with TLS.
+	UseTLS bool
+
+	// TLSCertPath is the optional path to the certificate of the server.
+	// As Electrum servers commonly use self-signed certificates, the
+	// certificate is pinned if set. Otherwise the system's root
+	// certificates are used to verify the server.
+	TLSCertPath string
+
+	// NetParams are the parameters of the chain the server is expected to
+	// serve.
+	NetParams *chaincfg.Params
+
+	// RequestTimeout is the time we'll wait for the server to respond to
+	// a request. If zero, DefaultRequestTimeout is used.
+	RequestTimeout time.Duration
+
+	// ReconnectInterval is the time we'll wait between attempts to
+	// reconnect to the server. If zero, DefaultReconnectInterval is used.
+	ReconnectInterval time.Duration
+}
+
+// request is a JSON-RPC request sent to the server.
+type request struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// message is a JSON-RPC message received from the server. It's either the
+// response to one of our requests, or a notification of a subscription in
+// which case the ID is nil.
+type message struct {
+	ID     *uint64         `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// rpcResult is the outcome of a single request.
+type rpcResult struct {
+	result json.RawMessage
+	err    error
+}
+
+// Client is a client of the Electrum JSON-RPC protocol. It maintains a single
+// connection to an Electrum server, over which requests can be issued
+// concurrently, and which is re-established if it's lost. Subscriptions to
+// the chain tip and to script hashes are renewed whenever the client
+// reconnects.
+type Client struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	requestCounter uint64 // To be used atomically.
+
+	cfg       Config
+	tlsConfig *tls.Config
+
+	// conn is the current connection to the server, or nil while we're
+	// reconnecting. connected is closed once a new connection has been
+	// established.
+	connMtx   sync.RWMutex
+	conn      net.Conn
+	connected chan struct{}
+
+	writeMtx sync.Mutex
+
+	pendingMtx sync.Mutex
+	pending    map[uint64]chan *rpcResult
+
+	subMtx         sync.Mutex
+	subCounter     uint64
+	headerSubs     map[uint64]*HeaderSubscription
+	scriptHashSubs map[uint64]*ScriptHashSubscription
+	scriptHashes   map[string]struct{}
+
+	// tip is the current tip of the server's main chain, as reported by
+	// the last header notification.
+	tipMtx    sync.RWMutex
+	tipHeight int32
+	tipHeader wire.BlockHeader
+
+	headers *headerCache
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// NewClient creates a new Electrum client for the given configuration. The
+// connection to the server is established by Start.
+func NewClient(cfg *Config) (*Client, error) {
+	c := &Client{
+		cfg:            *cfg,
+		connected:      make(chan struct{}),
+		pending:        make(map[uint64]chan *rpcResult),
+		headerSubs:     make(map[uint64]*HeaderSubscription),
+		scriptHashSubs: make(map[uint64]*ScriptHashSubscription),
+		scriptHashes:   make(map[string]struct{}),
+		headers:        newHeaderCache(),
+		quit:           make(chan struct{}),
+	}
+
+	if c.cfg.RequestTimeout == 0 {
+		c.cfg.RequestTimeout = DefaultRequestTimeout
+	}
+	if c.cfg.ReconnectInterval == 0 {
+		c.cfg.ReconnectInterval = DefaultReconnectInterval
+	}
+
+	if !cfg.UseTLS {
+		return c, nil
+	}
+
+	host, _, err := net.SplitHostPort(cfg.Server)
+	if err != nil {
+		return nil, err
+	}
+	c.tlsConfig = &tls.Config{ServerName: host}
+
+	// If a certificate was given, we'll only accept a server presenting
+	// exactly that certificate.
+	if cfg.TLSCertPath != "" {
+		certBytes, err := ioutil.ReadFile(cfg.TLSCertPath)
+		if err != nil {
+			return nil, err
+		}
+		block, _ := pem.Decode(certBytes)
+		if block == nil {
+			return nil, fmt.Errorf("unable to parse certificate %v",
+				cfg.TLSCertPath)
+		}
+		pinnedCert := block.Bytes
+
+		// As self-signed certificates commonly don't match the host
+		// name of the server, we'll skip the default verification and
+		// compare the presented certificate instead.
+		c.tlsConfig.InsecureSkipVerify = true
+		c.tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte,
+			_ [][]*x509.Certificate) error {
+
+			if len(rawCerts) == 0 ||
+				!bytes.Equal(rawCerts[0], pinnedCert) {
+
+				return errors.New("server certificate doesn't " +
+					"match the pinned certificate")
+			}
+
+			return nil
+		}
+	}
+
+	return c, nil
+}
+
+// Start connects to the Electrum server, negotiates the protocol version and
+// subscribes to the chain tip. Once connected, the client will reconnect on
+// its own whenever the connection is lost.
+func (c *Client) Start() error {
+	if atomic.AddInt32(&c.started, 1) != 1 {
+		return nil
+	}
+
+	log.Infof("Connecting to Electrum server %v", c.cfg.Server)
+
+	readDone, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	c.wg.Add(1)
+	go c.connectionHandler(readDone)
+
+	return nil
+}
+
+// Stop disconnects from the server and cancels all subscriptions.
+func (c *Client) Stop() error {
+	if atomic.AddInt32(&c.stopped, 1) != 1 {
+		return nil
+	}
+
+	log.Infof("Disconnecting from Electrum server %v", c.cfg.Server)
+
+	close(c.quit)
+
+	c.connMtx.Lock()
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	c.connMtx.Unlock()
+
+	c.wg.Wait()
+
+	c.subMtx.Lock()
+	for id, sub := range c.headerSubs {
+		sub.queue.Stop()
+		delete(c.headerSubs, id)
+	}
+	for id, sub := range c.scriptHashSubs {
+		sub.queue.Stop()
+		delete(c.scriptHashSubs, id)
+	}
+	c.subMtx.Unlock()
+
+	return nil
+}
+
+// dial opens a new connection to the server.
+func (c *Client) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: c.cfg.RequestTimeout}
+	if c.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, "tcp", c.cfg.Server,
+			c.tlsConfig)
+	}
+
+	return dialer.Dial("tcp", c.cfg.Server)
+}
+
+// connect establishes a new connection to the server, performs the version
+// handshake and renews all subscriptions. The returned channel is closed once
+// the connection is lost.
+func (c *Client) connect() (chan struct{}, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to electrum server "+
+			"%v: %v", c.cfg.Server, err)
+	}
+
+	readDone := make(chan struct{})
+	c.wg.Add(1)
+	go c.readHandler(conn, readDone)
+
+	// The version must be the first message sent over the connection.
+	var version []string
+	err = c.callConn(
+		conn, "server.version", &version, clientName, ProtocolVersion,
+	)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to negotiate protocol version: "+
+			"%v", err)
+	}
+	if len(version) == 2 {
+		log.Infof("Connected to Electrum server %v (%v, protocol %v)",
+			c.cfg.Server, version[0], version[1])
+	}
+
+	// Subscribe to the chain tip. The server will notify us of each new
+	// tip from now on.
+	var tip headerResult
+	err = c.callConn(conn, "blockchain.headers.subscribe", &tip)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to subscribe to headers: %v",
+			err)
+	}
+
+	// Renew the subscriptions of all script hashes we're watching.
+	c.subMtx.Lock()
+	scriptHashes := make([]string, 0, len(c.scriptHashes))
+	for scriptHash := range c.scriptHashes {
+		scriptHashes = append(scriptHashes, scriptHash)
+	}
+	c.subMtx.Unlock()
+
+	statuses := make([]*string, len(scriptHashes))
+	err = parallel(len(scriptHashes), func(i int) error {
+		return c.callConn(
+			conn, "blockchain.scripthash.subscribe", &statuses[i],
+			scriptHashes[i],
+		)
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to renew script hash "+
+			"subscriptions: %v", err)
+	}
+
+	// Only now that the handshake is complete, we'll make the connection
+	// available to all other requests.
+	c.connMtx.Lock()
+	c.conn = conn
+	close(c.connected)
+	c.connMtx.Unlock()
+
+	// As we may have missed notifications while we were disconnected, we
+	// deliver the current state to all subscribers.
+	if err := c.handleTipUpdate(&tip); err != nil {
+		log.Errorf("Unable to handle tip update: %v", err)
+	}
+	for i, scriptHash := range scriptHashes {
+		c.dispatchScriptHashUpdate(scriptHash, statuses[i])
+	}
+
+	return readDone, nil
+}
+
+// connectionHandler waits for the current connection to be lost and then
+// reconnects to the server until the client is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *Client) connectionHandler(readDone chan struct{}) {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-readDone:
+		case <-c.quit:
+			return
+		}
+
+		c.connMtx.Lock()
+		c.conn = nil
+		c.connected = make(chan struct{})
+		c.connMtx.Unlock()
+
+		log.Warnf("Lost connection to Electrum server %v, "+
+			"reconnecting", c.cfg.Server)
+
+		for {
+			select {
+			case <-time.After(c.cfg.ReconnectInterval):
+			case <-c.quit:
+				return
+			}
+
+			var err error
+			readDone, err = c.connect()
+			if err != nil {
+				log.Errorf("Unable to reconnect: %v", err)
+				continue
+			}
+
+			break
+		}
+	}
+}
+
+// readHandler reads all messages sent by the server over the given
+// connection, and either delivers them to the pending request they respond
+// to, or dispatches them as notifications. Once the connection fails, all
+// pending requests are failed and the readDone channel is closed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *Client) readHandler(conn net.Conn, readDone chan struct{}) {
+	defer c.wg.Done()
+	defer close(readDone)
+
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			select {
+			case <-c.quit:
+			default:
+				log.Debugf("Unable to read from electrum "+
+					"server: %v", err)
+			}
+
+			conn.Close()
+			c.failPending()
+			return
+		}
+
+		var msg message
+		if err := json.Unmarshal(line, &msg); err != nil {
+			log.Errorf("Unable to parse message from electrum "+
+				"server: %v", err)
+			continue
+		}
+
+		if msg.ID == nil {
+			c.handleNotification(&msg)
+			continue
+		}
+
+		c.pendingMtx.Lock()
+		respChan, ok := c.pending[*msg.ID]
+		delete(c.pending, *msg.ID)
+		c.pendingMtx.Unlock()
+		if !ok {
+			log.Warnf("Received response for unknown request %v",
+				*msg.ID)
+			continue
+		}
+
+		respChan <- &rpcResult{
+			result: msg.Result,
+			err:    parseServerError(msg.Error),
+		}
+	}
+}
+
+// failPending fails all requests that are still waiting for a response.
+func (c *Client) failPending() {
+	c.pendingMtx.Lock()
+	defer c.pendingMtx.Unlock()
+
+	for id, respChan := range c.pending {
+		respChan <- &rpcResult{err: ErrDisconnected}
+		delete(c.pending, id)
+	}
+}
+
+// parseServerError parses the error field of a response. Servers either
+// return an object with an error code and message, or only a message.
+func parseServerError(rawErr json.RawMessage) error {
+	if len(rawErr) == 0 || string(rawErr) == "null" {
+		return nil
+	}
+
+	serverErr := &ServerError{}
+	if err := json.Unmarshal(rawErr, serverErr); err == nil {
+		return serverErr
+	}
+	if err := json.Unmarshal(rawErr, &serverErr.Message); err == nil {
+		return serverErr
+	}
+
+	serverErr.Message = string(rawErr)
+	return serverErr
+}
+
+// call issues a request to the server and decodes the result into the passed
+// value. If the client is currently reconnecting, the request is issued once
+// the connection has been re-established, unless the request times out
+// first.
+func (c *Client) call(method string, result interface{},
+	params ...interface{}) error {
+
+	c.connMtx.RLock()
+	conn, connected := c.conn, c.connected
+	c.connMtx.RUnlock()
+
+	if conn == nil {
+		select {
+		case <-connected:
+		case <-time.After(c.cfg.RequestTimeout):
+			return ErrRequestTimeout
+		case <-c.quit:
+			return ErrClientShuttingDown
+		}
+
+		c.connMtx.RLock()
+		conn = c.conn
+		c.connMtx.RUnlock()
+		if conn == nil {
+			return ErrDisconnected
+		}
+	}
+
+	return c.callConn(conn, method, result, params...)
+}
+
+// callConn issues a request over the given connection and decodes the result
+// into the passed value.
+func (c *Client) callConn(conn net.Conn, method string, result interface{},
+	params ...interface{}) error {
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	req := &request{
+		JSONRPC: "2.0",
+		ID:      atomic.AddUint64(&c.requestCounter, 1),
+		Method:  method,
+		Params:  params,
+	}
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	reqBytes = append(reqBytes, '\n')
+
+	respChan := make(chan *rpcResult, 1)
+	c.pendingMtx.Lock()
+	c.pending[req.ID] = respChan
+	c.pendingMtx.Unlock()
+
+	defer func() {
+		c.pendingMtx.Lock()
+		delete(c.pending, req.ID)
+		c.pendingMtx.Unlock()
+	}()
+
+	c.writeMtx.Lock()
+	conn.SetWriteDeadline(time.Now().Add(c.cfg.RequestTimeout))
+	_, err = conn.Write(reqBytes)
+	c.writeMtx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case resp := <-respChan:
+		if resp.err != nil {
+			return resp.err
+		}
+		if result == nil {
+			return nil
+		}
+
+		return json.Unmarshal(resp.result, result)
+
+	case <-time.After(c.cfg.RequestTimeout):
+		return ErrRequestTimeout
+
+	case <-c.quit:
+		return ErrClientShuttingDown
+	}
+}
+
+// parallel calls f for all indexes in [0, n), keeping at most
+// maxConcurrentRequests calls in flight at once. The first error encountered
+// is returned.
+func parallel(n int, f func(i int) error) error {
+	var (
+		wg       sync.WaitGroup
+		errMtx   sync.Mutex
+		firstErr error
+	)
+
+	semaphore := make(chan struct{}, maxConcurrentRequests)
+	for i := 0; i < n; i++ {
+		semaphore <- struct{}{}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			if err := f(i); err != nil {
+				errMtx.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				errMtx.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// handleNotification dispatches a notification sent by the server.
+func (c *Client) handleNotification(msg *message) {
+	switch msg.Method {
+	case "blockchain.headers.subscribe":
+		var params []headerResult
+		if err := json.Unmarshal(msg.Params, &params); err != nil ||
+			len(params) != 1 {
+
+			log.Errorf("Invalid header notification: %s", msg.Params)
+			return
+		}
+
+		if err := c.handleTipUpdate(&params[0]); err != nil {
+			log.Errorf("Unable to handle tip update: %v", err)
+		}
+
+	case "blockchain.scripthash.subscribe":
+		var params []*string
+		if err := json.Unmarshal(msg.Params, &params); err != nil ||
+			len(params) != 2 || params[0] == nil {
+
+			log.Errorf("Invalid script hash notification: %s",
+				msg.Params)
+			return
+		}
+
+		c.dispatchScriptHashUpdate(*params[0], params[1])
+
+	default:
+		log.Warnf("Received unknown notification %v", msg.Method)
+	}
+}
+
+// handleTipUpdate records the new chain tip and notifies all header
+// subscribers.
+func (c *Client) handleTipUpdate(tip *headerResult) error {
+	header, err := decodeHeader(tip.Hex)
+	if err != nil {
+		return err
+	}
+
+	c.tipMtx.Lock()
+	c.tipHeight = tip.Height
+	c.tipHeader = *header
+	c.tipMtx.Unlock()
+
+	c.headers.addHeader(header, tip.Height)
+
+	log.Debugf("New chain tip: height=%v, hash=%v", tip.Height,
+		header.BlockHash())
+
+	update := &TipUpdate{
+		Height: tip.Height,
+		Header: *header,
+	}
+
+	c.subMtx.Lock()
+	defer c.subMtx.Unlock()
+	for _, sub := range c.headerSubs {
+		select {
+		case sub.queue.ChanIn() <- update:
+		case <-c.quit:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// dispatchScriptHashUpdate notifies all script hash subscribers of a new
+// status of the given script hash.
+func (c *Client) dispatchScriptHashUpdate(scriptHash string, status *string) {
+	update := &ScriptHashUpdate{
+		ScriptHash: scriptHash,
+	}
+	if status != nil {
+		update.Status = *status
+	}
+
+	c.subMtx.Lock()
+	defer c.subMtx.Unlock()
+	for _, sub := range c.scriptHashSubs {
+		select {
+		case sub.queue.ChanIn() <- update:
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// TipUpdate is sent to header subscribers each time the server reports a new
+// chain tip. As the server may skip blocks or reorganize its chain between
+// updates, subscribers must check whether the new tip connects to the last
+// one they've seen.
+type TipUpdate struct {
+	// Height is the height of the new tip.
+	Height int32
+
+	// Header is the header of the new tip.
+	Header wire.BlockHeader
+}
+
+// HeaderSubscription is a subscription to the tip of the server's chain.
+type HeaderSubscription struct {
+	id     uint64
+	queue  *chainntnfs.ConcurrentQueue
+	client *Client
+}
+
+// Updates returns the channel over which the tip updates are delivered, in
+// the order they were received.
+func (s *HeaderSubscription) Updates() <-chan interface{} {
+	return s.queue.ChanOut()
+}
+
+// Cancel cancels the subscription.
+func (s *HeaderSubscription) Cancel() {
+	s.client.subMtx.Lock()
+	defer s.client.subMtx.Unlock()
+
+	// The subscription may already have been cancelled when the client
+	// was stopped.
+	if _, ok := s.client.headerSubs[s.id]; !ok {
+		return
+	}
+	delete(s.client.headerSubs, s.id)
+
+	s.queue.Stop()
+}
+
+// SubscribeHeaders subscribes to the tip of the server's main chain. A
+// *TipUpdate is delivered for each new tip, as well as after each reconnect.
+func (c *Client) SubscribeHeaders() *HeaderSubscription {
+	c.subMtx.Lock()
+	defer c.subMtx.Unlock()
+
+	c.subCounter++
+	sub := &HeaderSubscription{
+		id:     c.subCounter,
+		queue:  chainntnfs.NewConcurrentQueue(20),
+		client: c,
+	}
+	sub.queue.Start()
+	c.headerSubs[sub.id] = sub
+
+	return sub
+}
+
+// ScriptHashUpdate is sent to script hash subscribers each time the status of
+// a watched script hash changes, meaning a transaction paying to or spending
+// from the script was added to the mempool or confirmed.
+type ScriptHashUpdate struct {
+	// ScriptHash is the script hash whose status changed.
+	ScriptHash string
+
+	// Status is the new status of the script hash. It's empty if the
+	// script has no history.
+	Status string
+}
+
+// ScriptHashSubscription is a subscription to the status updates of all
+// script hashes watched by the client.
+type ScriptHashSubscription struct {
+	id     uint64
+	queue  *chainntnfs.ConcurrentQueue
+	client *Client
+}
+
+// Updates returns the channel over which the *ScriptHashUpdates are
+// delivered.
+func (s *ScriptHashSubscription) Updates() <-chan interface{} {
+	return s.queue.ChanOut()
+}
+
+// Cancel cancels the subscription.
+func (s *ScriptHashSubscription) Cancel() {
+	s.client.subMtx.Lock()
+	defer s.client.subMtx.Unlock()
+
+	// The subscription may already have been cancelled when the client
+	// was stopped.
+	if _, ok := s.client.scriptHashSubs[s.id]; !ok {
+		return
+	}
+	delete(s.client.scriptHashSubs, s.id)
+
+	s.queue.Stop()
+}
+
+// SubscribeScriptHashUpdates subscribes to the status updates of all script
+// hashes watched through WatchScriptHash.
+func (c *Client) SubscribeScriptHashUpdates() *ScriptHashSubscription {
+	c.subMtx.Lock()
+	defer c.subMtx.Unlock()
+
+	c.subCounter++
+	sub := &ScriptHashSubscription{
+		id:     c.subCounter,
+		queue:  chainntnfs.NewConcurrentQueue(20),
+		client: c,
+	}
+	sub.queue.Start()
+	c.scriptHashSubs[sub.id] = sub
+
+	return sub
+}
+
+// WatchScriptHash subscribes to the status of the given script hash at the
+// server and returns its current status, which is empty if the script has no
+// history yet. Subsequent changes are delivered to all script hash
+// subscribers.
+func (c *Client) WatchScriptHash(scriptHash string) (string, error) {
+	c.subMtx.Lock()
+	c.scriptHashes[scriptHash] = struct{}{}
+	c.subMtx.Unlock()
+
+	var status *string
+	err := c.call("blockchain.scripthash.subscribe", &status, scriptHash)
+	if err != nil {
+		return "", err
+	}
+	if status == nil {
+		return "", nil
+	}
+
+	return *status, nil
+}
+
+// WatchScriptHashes watches all of the given script hashes like
+// WatchScriptHash, and returns their current statuses.
+func (c *Client) WatchScriptHashes(scriptHashes []string) ([]string, error) {
+	statuses := make([]string, len(scriptHashes))
+	err := parallel(len(scriptHashes), func(i int) error {
+		var err error
+		statuses[i], err = c.WatchScriptHash(scriptHashes[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,843 @@
+package electrum_test
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chainntnfs/electrumnotify"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/electrum"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/routing/chainview"
+)
+
+// timeout is the time we'll wait for any notification before failing a test.
+const timeout = 10 * time.Second
+
+// newAddress returns a new random P2WKH address along with its output script.
+func newAddress(t *testing.T) (btcutil.Address, []byte) {
+	t.Helper()
+
+	var pkHash [20]byte
+	if _, err := rand.Read(pkHash[:]); err != nil {
+		t.Fatalf("unable to generate key hash: %v", err)
+	}
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(
+		pkHash[:], &chaincfg.RegressionNetParams,
+	)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	return addr, pkScript
+}
+
+// newTx returns a transaction spending the given outpoint and paying to the
+// given script. If no outpoint is given, a random one is spent.
+func newTx(t *testing.T, prevOut *wire.OutPoint, pkScript []byte) *wire.MsgTx {
+	t.Helper()
+
+	if prevOut == nil {
+		prevOut = &wire.OutPoint{}
+		if _, err := rand.Read(prevOut.Hash[:]); err != nil {
+			t.Fatalf("unable to generate outpoint: %v", err)
+		}
+	}
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: *prevOut})
+	tx.AddTxOut(&wire.TxOut{Value: 1e6, PkScript: pkScript})
+
+	return tx
+}
+
+// initHintCache creates a disabled height hint cache backed by a temporary
+// database.
+func initHintCache(t *testing.T) (*chainntnfs.HeightHintCache, func()) {
+	t.Helper()
+
+	tempDir, err := ioutil.TempDir("", "electrum")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+	hintCache, err := chainntnfs.NewHeightHintCache(db, true)
+	if err != nil {
+		t.Fatalf("unable to create hint cache: %v", err)
+	}
+
+	cleanUp := func() {
+		db.Close()
+		os.RemoveAll(tempDir)
+	}
+
+	return hintCache, cleanUp
+}
+
+// setUpNotifier starts a new notifier backed by the given client.
+func setUpNotifier(t *testing.T,
+	client *electrum.Client) (*electrumnotify.ElectrumNotifier, func()) {
+
+	t.Helper()
+
+	hintCache, cleanUpCache := initHintCache(t)
+	notifier, err := electrumnotify.New(client, hintCache, hintCache)
+	if err != nil {
+		t.Fatalf("unable to create notifier: %v", err)
+	}
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+
+	cleanUp := func() {
+		notifier.Stop()
+		cleanUpCache()
+	}
+
+	return notifier, cleanUp
+}
+
+// TestClientChainIO ensures that the client serves blocks, headers and
+// outputs of the server's chain.
+func TestClientChainIO(t *testing.T) {
+	t.Parallel()
+
+	server := newMockServer(t, 10)
+	defer server.stop()
+
+	client := server.newClient()
+	defer client.Stop()
+
+	_, pkScript := newAddress(t)
+	fundingTx := newTx(t, nil, pkScript)
+	server.addToMempool(fundingTx)
+	server.addToMempool(newTx(t, nil, pkScript))
+	server.mineBlocks(1)
+
+	waitForSync(t, server, client)
+	_, tipHeight := server.tip()
+
+	// The blocks assembled by the client should match those of the
+	// server.
+	for _, height := range []int32{0, 5, tipHeight} {
+		hash, err := client.GetBlockHash(int64(height))
+		if err != nil {
+			t.Fatalf("unable to get block hash: %v", err)
+		}
+		expected := server.block(height)
+		if *hash != expected.BlockHash() {
+			t.Fatalf("expected hash %v at height %d, got %v",
+				expected.BlockHash(), height, hash)
+		}
+
+		header, err := client.GetBlockHeader(hash)
+		if err != nil {
+			t.Fatalf("unable to get header: %v", err)
+		}
+		if header.BlockHash() != *hash {
+			t.Fatalf("header mismatch at height %d", height)
+		}
+
+		block, err := client.GetBlock(hash)
+		if err != nil {
+			t.Fatalf("unable to get block: %v", err)
+		}
+		if len(block.Transactions) != len(expected.Transactions) {
+			t.Fatalf("expected %d txns, got %d",
+				len(expected.Transactions),
+				len(block.Transactions))
+		}
+		for i, tx := range block.Transactions {
+			if tx.TxHash() != expected.Transactions[i].TxHash() {
+				t.Fatalf("tx %d mismatch at height %d", i,
+					height)
+			}
+		}
+	}
+
+	// The funding output should be unspent.
+	fundingOp := &wire.OutPoint{Hash: fundingTx.TxHash()}
+	txOut, err := client.GetUtxo(fundingOp, pkScript, 0)
+	if err != nil {
+		t.Fatalf("unable to get utxo: %v", err)
+	}
+	if txOut.Value != fundingTx.TxOut[0].Value {
+		t.Fatalf("expected value %v, got %v",
+			fundingTx.TxOut[0].Value, txOut.Value)
+	}
+
+	// An output that never existed can't be found.
+	unknownOp := &wire.OutPoint{Hash: chainhash.Hash{1}}
+	if _, err := client.GetUtxo(unknownOp, pkScript, 0); err !=
+		electrum.ErrOutputNotFound {
+
+		t.Fatalf("expected ErrOutputNotFound, got %v", err)
+	}
+
+	// Once spent, the funding output should be reported as such.
+	_, spendScript := newAddress(t)
+	server.addToMempool(newTx(t, fundingOp, spendScript))
+	if _, err := client.GetUtxo(fundingOp, pkScript, 0); err !=
+		electrum.ErrOutputSpent {
+
+		t.Fatalf("expected ErrOutputSpent, got %v", err)
+	}
+}
+
+// TestClientBroadcast ensures that transactions are broadcast to the server,
+// and that rejections are returned as errors.
+func TestClientBroadcast(t *testing.T) {
+	t.Parallel()
+
+	server := newMockServer(t, 1)
+	defer server.stop()
+
+	client := server.newClient()
+	defer client.Stop()
+
+	_, pkScript := newAddress(t)
+	tx := newTx(t, nil, pkScript)
+	txid, err := client.Broadcast(tx)
+	if err != nil {
+		t.Fatalf("unable to broadcast: %v", err)
+	}
+	if *txid != tx.TxHash() {
+		t.Fatalf("expected txid %v, got %v", tx.TxHash(), txid)
+	}
+
+	// A conflicting transaction should be rejected.
+	conflict := newTx(t, &tx.TxIn[0].PreviousOutPoint, []byte{0x51})
+	_, err = client.Broadcast(conflict)
+	if _, ok := err.(*electrum.ServerError); !ok {
+		t.Fatalf("expected server error, got %v", err)
+	}
+}
+
+// TestClientReconnect ensures that the client reconnects after losing its
+// connection, and delivers the state it missed to its subscribers.
+func TestClientReconnect(t *testing.T) {
+	t.Parallel()
+
+	server := newMockServer(t, 5)
+	defer server.stop()
+
+	client := server.newClient()
+	defer client.Stop()
+
+	_, pkScript := newAddress(t)
+	scriptHash := electrum.ScriptHash(pkScript)
+
+	headerSub := client.SubscribeHeaders()
+	defer headerSub.Cancel()
+	scriptHashSub := client.SubscribeScriptHashUpdates()
+	defer scriptHashSub.Cancel()
+
+	status, err := client.WatchScriptHash(scriptHash)
+	if err != nil {
+		t.Fatalf("unable to watch script hash: %v", err)
+	}
+	if status != "" {
+		t.Fatalf("expected empty status, got %v", status)
+	}
+
+	// While the client is disconnected, we'll extend the chain and pay to
+	// the watched script.
+	server.dropConns()
+	server.addToMempool(newTx(t, nil, pkScript))
+	server.mineBlocks(2)
+	_, tipHeight := server.tip()
+
+	// Once reconnected, the client should deliver the new tip...
+	for {
+		select {
+		case update := <-headerSub.Updates():
+			if update.(*electrum.TipUpdate).Height != tipHeight {
+				continue
+			}
+		case <-time.After(timeout):
+			t.Fatalf("tip update not received")
+		}
+		break
+	}
+
+	// ...as well as the new status of the watched script hash.
+	select {
+	case ntfn := <-scriptHashSub.Updates():
+		update := ntfn.(*electrum.ScriptHashUpdate)
+		if update.ScriptHash != scriptHash || update.Status == "" {
+			t.Fatalf("unexpected script hash update: %v", update)
+		}
+	case <-time.After(timeout):
+		t.Fatalf("script hash update not received")
+	}
+
+	history, err := client.ScriptHashHistory(scriptHash)
+	if err != nil {
+		t.Fatalf("unable to get history: %v", err)
+	}
+	if len(history) != 1 || history[0].Height != tipHeight-1 {
+		t.Fatalf("unexpected history: %v", history)
+	}
+}
+
+// TestFeeEstimator ensures that the fee estimator converts the server's
+// estimates and respects the fallback and minimum fee rates.
+func TestFeeEstimator(t *testing.T) {
+	t.Parallel()
+
+	server := newMockServer(t, 1)
+	defer server.stop()
+
+	client := server.newClient()
+	defer client.Stop()
+
+	const fallbackFee = lnwallet.SatPerKWeight(12500)
+	estimator := electrum.NewFeeEstimator(client, fallbackFee)
+	if err := estimator.Start(); err != nil {
+		t.Fatalf("unable to start fee estimator: %v", err)
+	}
+	defer estimator.Stop()
+
+	testCases := []struct {
+		name     string
+		feeRate  float64
+		expected lnwallet.SatPerKWeight
+	}{
+		{
+			name:     "no estimate",
+			feeRate:  -1,
+			expected: fallbackFee,
+		},
+		{
+			name:     "estimate",
+			feeRate:  0.0002,
+			expected: 5000,
+		},
+		{
+			name:     "below floor",
+			feeRate:  0.000001,
+			expected: lnwallet.FeePerKwFloor,
+		},
+	}
+
+	for _, test := range testCases {
+		server.setFeeRate(test.feeRate)
+
+		feeRate, err := estimator.EstimateFeePerKW(6)
+		if err != nil {
+			t.Fatalf("%v: unable to estimate fee: %v", test.name,
+				err)
+		}
+		if feeRate != test.expected {
+			t.Fatalf("%v: expected fee rate %v, got %v", test.name,
+				test.expected, feeRate)
+		}
+	}
+}
+
+// TestNotifierConfirmations ensures that the notifier dispatches
+// confirmations of transactions, both historical and new ones, and notifies
+// clients of confirmations reorged out of the chain.
+func TestNotifierConfirmations(t *testing.T) {
+	t.Parallel()
+
+	server := newMockServer(t, 10)
+	defer server.stop()
+
+	client := server.newClient()
+	defer client.Stop()
+
+	notifier, cleanUp := setUpNotifier(t, client)
+	defer cleanUp()
+
+	// A transaction confirmed before the registration should be
+	// dispatched right away.
+	_, pkScript := newAddress(t)
+	historicalTx := newTx(t, nil, pkScript)
+	server.addToMempool(newTx(t, nil, pkScript))
+	server.addToMempool(historicalTx)
+	block := server.mineBlocks(1)[0]
+	_, height := server.tip()
+
+	historicalTxid := historicalTx.TxHash()
+	confEvent, err := notifier.RegisterConfirmationsNtfn(
+		&historicalTxid, pkScript, 1, 1,
+	)
+	if err != nil {
+		t.Fatalf("unable to register conf ntfn: %v", err)
+	}
+	assertConfirmed(t, confEvent, block.BlockHash(), height, 2)
+
+	// A new transaction should only be dispatched once it reaches the
+	// requested number of confirmations.
+	tx := newTx(t, nil, pkScript)
+	txid := tx.TxHash()
+	confEvent, err = notifier.RegisterConfirmationsNtfn(
+		&txid, pkScript, 2, uint32(height),
+	)
+	if err != nil {
+		t.Fatalf("unable to register conf ntfn: %v", err)
+	}
+
+	server.addToMempool(tx)
+	block = server.mineBlocks(1)[0]
+	select {
+	case <-confEvent.Confirmed:
+		t.Fatalf("tx confirmed too early")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	server.mineBlocks(1)
+	_, height = server.tip()
+	assertConfirmed(t, confEvent, block.BlockHash(), height-1, 1)
+
+	// Reorging out the block confirming the transaction should be
+	// signaled to the client.
+	server.reorg(2, 3)
+	select {
+	case <-confEvent.NegativeConf:
+	case <-time.After(timeout):
+		t.Fatalf("negative conf not received")
+	}
+}
+
+// assertConfirmed asserts that the confirmation event is dispatched with the
+// given details.
+func assertConfirmed(t *testing.T, confEvent *chainntnfs.ConfirmationEvent,
+	blockHash chainhash.Hash, height int32, txIndex uint32) {
+
+	t.Helper()
+
+	select {
+	case conf := <-confEvent.Confirmed:
+		if *conf.BlockHash != blockHash {
+			t.Fatalf("expected block hash %v, got %v", blockHash,
+				conf.BlockHash)
+		}
+		if conf.BlockHeight != uint32(height) {
+			t.Fatalf("expected height %d, got %d", height,
+				conf.BlockHeight)
+		}
+		if conf.TxIndex != txIndex {
+			t.Fatalf("expected tx index %d, got %d", txIndex,
+				conf.TxIndex)
+		}
+	case <-time.After(timeout):
+		t.Fatalf("confirmation not received")
+	}
+}
+
+// TestNotifierSpends ensures that the notifier dispatches spends of
+// outpoints, both historical and new ones.
+func TestNotifierSpends(t *testing.T) {
+	t.Parallel()
+
+	server := newMockServer(t, 10)
+	defer server.stop()
+
+	client := server.newClient()
+	defer client.Stop()
+
+	notifier, cleanUp := setUpNotifier(t, client)
+	defer cleanUp()
+
+	_, pkScript := newAddress(t)
+	fundingTx := newTx(t, nil, pkScript)
+	fundingTx.AddTxOut(&wire.TxOut{Value: 1e6, PkScript: pkScript})
+	server.addToMempool(fundingTx)
+	server.mineBlocks(1)
+
+	// The first output is spent before we register for its spend, so the
+	// spend should be dispatched right away.
+	_, spendScript := newAddress(t)
+	historicalOp := wire.OutPoint{Hash: fundingTx.TxHash(), Index: 0}
+	historicalSpend := newTx(t, &historicalOp, spendScript)
+	server.addToMempool(historicalSpend)
+	server.mineBlocks(1)
+	_, height := server.tip()
+
+	spendEvent, err := notifier.RegisterSpendNtfn(
+		&historicalOp, pkScript, 1,
+	)
+	if err != nil {
+		t.Fatalf("unable to register spend ntfn: %v", err)
+	}
+	assertSpent(t, spendEvent, historicalSpend, height)
+
+	// The second output is spent after the registration.
+	op := wire.OutPoint{Hash: fundingTx.TxHash(), Index: 1}
+	spendEvent, err = notifier.RegisterSpendNtfn(&op, pkScript, 1)
+	if err != nil {
+		t.Fatalf("unable to register spend ntfn: %v", err)
+	}
+
+	spendTx := newTx(t, &op, spendScript)
+	server.addToMempool(spendTx)
+	server.mineBlocks(1)
+	assertSpent(t, spendEvent, spendTx, height+1)
+
+	// Registering without an output script isn't supported.
+	_, err = notifier.RegisterSpendNtfn(&op, nil, 1)
+	if err != electrumnotify.ErrPkScriptRequired {
+		t.Fatalf("expected ErrPkScriptRequired, got %v", err)
+	}
+}
+
+// assertSpent asserts that the spend event is dispatched with the given
+// spending transaction.
+func assertSpent(t *testing.T, spendEvent *chainntnfs.SpendEvent,
+	spendTx *wire.MsgTx, height int32) {
+
+	t.Helper()
+
+	select {
+	case spend := <-spendEvent.Spend:
+		if *spend.SpenderTxHash != spendTx.TxHash() {
+			t.Fatalf("expected spender %v, got %v",
+				spendTx.TxHash(), spend.SpenderTxHash)
+		}
+		if spend.SpendingHeight != height {
+			t.Fatalf("expected spend height %d, got %d", height,
+				spend.SpendingHeight)
+		}
+	case <-time.After(timeout):
+		t.Fatalf("spend not received")
+	}
+}
+
+// TestNotifierBlockEpochs ensures that the notifier dispatches new blocks,
+// including missed ones and those connected by a reorg.
+func TestNotifierBlockEpochs(t *testing.T) {
+	t.Parallel()
+
+	server := newMockServer(t, 10)
+	defer server.stop()
+
+	client := server.newClient()
+	defer client.Stop()
+
+	notifier, cleanUp := setUpNotifier(t, client)
+	defer cleanUp()
+
+	// We'll register with a best block in the past, so the missed blocks
+	// should be delivered first.
+	bestBlock := server.block(8).BlockHash()
+	epochEvent, err := notifier.RegisterBlockEpochNtfn(
+		&chainntnfs.BlockEpoch{Hash: &bestBlock, Height: 8},
+	)
+	if err != nil {
+		t.Fatalf("unable to register epoch ntfn: %v", err)
+	}
+	defer epochEvent.Cancel()
+
+	assertEpochs(t, epochEvent, server, 9, 10)
+
+	server.mineBlocks(2)
+	assertEpochs(t, epochEvent, server, 11, 12)
+
+	// After a reorg, the blocks of the new chain should be delivered.
+	server.reorg(1, 2)
+	assertEpochs(t, epochEvent, server, 12, 13)
+}
+
+// assertEpochs asserts that the blocks at the given heights of the server's
+// chain are delivered in order.
+func assertEpochs(t *testing.T, epochEvent *chainntnfs.BlockEpochEvent,
+	server *mockServer, from, to int32) {
+
+	t.Helper()
+
+	for height := from; height <= to; height++ {
+		select {
+		case epoch := <-epochEvent.Epochs:
+			expected := server.block(height).BlockHash()
+			if epoch.Height != height || *epoch.Hash != expected {
+				t.Fatalf("expected block %v at height %d, "+
+					"got %v at height %d", expected,
+					height, epoch.Hash, epoch.Height)
+			}
+		case <-time.After(timeout):
+			t.Fatalf("epoch at height %d not received", height)
+		}
+	}
+}
+
+// TestFilteredChainView ensures that the filtered chain view delivers
+// connected and disconnected blocks, along with the spends of the watched
+// outpoints.
+func TestFilteredChainView(t *testing.T) {
+	t.Parallel()
+
+	server := newMockServer(t, 10)
+	defer server.stop()
+
+	client := server.newClient()
+	defer client.Stop()
+
+	_, pkScript := newAddress(t)
+	fundingTx := newTx(t, nil, pkScript)
+	fundingTx.AddTxOut(&wire.TxOut{Value: 1e6, PkScript: pkScript})
+	server.addToMempool(fundingTx)
+	server.mineBlocks(1)
+	waitForSync(t, server, client)
+	_, height := server.tip()
+
+	chainView, err := chainview.NewElectrumFilteredChainView(client)
+	if err != nil {
+		t.Fatalf("unable to create chain view: %v", err)
+	}
+	if err := chainView.Start(); err != nil {
+		t.Fatalf("unable to start chain view: %v", err)
+	}
+	defer chainView.Stop()
+
+	fundingOp := wire.OutPoint{Hash: fundingTx.TxHash()}
+	err = chainView.UpdateFilter([]channeldb.EdgePoint{{
+		FundingPkScript: pkScript,
+		OutPoint:        fundingOp,
+	}}, uint32(height))
+	if err != nil {
+		t.Fatalf("unable to update filter: %v", err)
+	}
+
+	// We'll spend both funding outputs, of which only the first is
+	// watched, and mine an additional block, as the chain view may report
+	// the spend one block late.
+	spendTx := newTx(t, &fundingOp, []byte{0x51})
+	server.addToMempool(spendTx)
+	unwatchedOp := wire.OutPoint{Hash: fundingTx.TxHash(), Index: 1}
+	unwatchedSpendTx := newTx(t, &unwatchedOp, []byte{0x51})
+	server.addToMempool(unwatchedSpendTx)
+	server.mineBlocks(2)
+
+	var spends int
+	for i := int32(1); i <= 2; i++ {
+		select {
+		case block := <-chainView.FilteredBlocks():
+			expected := server.block(height + i).BlockHash()
+			if block.Height != uint32(height+i) ||
+				block.Hash != expected {
+
+				t.Fatalf("expected block %v at height %d, "+
+					"got %v at height %d", expected,
+					height+i, block.Hash, block.Height)
+			}
+			for _, tx := range block.Transactions {
+				if tx.TxHash() != spendTx.TxHash() {
+					t.Fatalf("unexpected tx %v",
+						tx.TxHash())
+				}
+				spends++
+			}
+		case <-time.After(timeout):
+			t.Fatalf("filtered block not received")
+		}
+	}
+	if spends != 1 {
+		t.Fatalf("expected spend to be reported once, got %d", spends)
+	}
+
+	// Once we watch the second output as of the current height, filtering
+	// the block that includes its spend should return it.
+	err = chainView.UpdateFilter([]channeldb.EdgePoint{{
+		FundingPkScript: pkScript,
+		OutPoint:        unwatchedOp,
+	}}, uint32(height+2))
+	if err != nil {
+		t.Fatalf("unable to update filter: %v", err)
+	}
+	spendBlockHash := server.block(height + 1).BlockHash()
+	filteredBlock, err := chainView.FilterBlock(&spendBlockHash)
+	if err != nil {
+		t.Fatalf("unable to filter block: %v", err)
+	}
+	if len(filteredBlock.Transactions) != 1 ||
+		filteredBlock.Transactions[0].TxHash() !=
+			unwatchedSpendTx.TxHash() {
+
+		t.Fatalf("expected spend in filtered block, got %v",
+			filteredBlock.Transactions)
+	}
+
+	// Reorging out the last block should disconnect it.
+	staleHash := server.block(height + 2).BlockHash()
+	server.reorg(1, 2)
+	select {
+	case block := <-chainView.DisconnectedBlocks():
+		if block.Hash != staleHash {
+			t.Fatalf("expected block %v to be disconnected, got "+
+				"%v", staleHash, block.Hash)
+		}
+	case <-time.After(timeout):
+		t.Fatalf("disconnected block not received")
+	}
+	for i := int32(2); i <= 3; i++ {
+		select {
+		case block := <-chainView.FilteredBlocks():
+			if block.Height != uint32(height+i) {
+				t.Fatalf("expected height %d, got %d",
+					height+i, block.Height)
+			}
+		case <-time.After(timeout):
+			t.Fatalf("filtered block not received")
+		}
+	}
+}
+
+// TestWalletClient ensures that the wallet client relays blocks and
+// transactions relevant to the wallet.
+func TestWalletClient(t *testing.T) {
+	t.Parallel()
+
+	server := newMockServer(t, 10)
+	defer server.stop()
+
+	client := server.newClient()
+	defer client.Stop()
+
+	// A transaction paying to an address before the wallet watches it
+	// should be found by a rescan.
+	addr, pkScript := newAddress(t)
+	historicalTx := newTx(t, nil, pkScript)
+	server.addToMempool(historicalTx)
+	server.mineBlocks(1)
+	waitForSync(t, server, client)
+
+	walletClient := electrum.NewWalletClient(
+		client, &chaincfg.RegressionNetParams,
+	)
+	if err := walletClient.Start(); err != nil {
+		t.Fatalf("unable to start wallet client: %v", err)
+	}
+	defer walletClient.Stop()
+
+	ntfn := nextNotification(t, walletClient)
+	if _, ok := ntfn.(chain.ClientConnected); !ok {
+		t.Fatalf("expected ClientConnected, got %T", ntfn)
+	}
+	if err := walletClient.NotifyBlocks(); err != nil {
+		t.Fatalf("unable to notify blocks: %v", err)
+	}
+
+	genesisHash := server.block(0).BlockHash()
+	err := walletClient.Rescan(
+		&genesisHash, []btcutil.Address{addr}, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to rescan: %v", err)
+	}
+	ntfn = nextNotification(t, walletClient)
+	assertRelevantTx(t, ntfn, historicalTx, 11)
+	ntfn = nextNotification(t, walletClient)
+	if _, ok := ntfn.(*chain.RescanFinished); !ok {
+		t.Fatalf("expected RescanFinished, got %T", ntfn)
+	}
+
+	// New transactions should be relayed once they enter the mempool, and
+	// once more when confirmed.
+	tx := newTx(t, nil, pkScript)
+	server.addToMempool(tx)
+	assertRelevantTx(t, nextNotification(t, walletClient), tx, 0)
+
+	// The block and the confirmed transaction are processed independently,
+	// so they may be relayed in either order.
+	server.mineBlocks(1)
+	var blockConnected bool
+	for i := 0; i < 2; i++ {
+		ntfn := nextNotification(t, walletClient)
+		block, ok := ntfn.(chain.BlockConnected)
+		if !ok {
+			assertRelevantTx(t, ntfn, tx, 12)
+			continue
+		}
+
+		if block.Height != 12 {
+			t.Fatalf("expected block at height 12, got %d",
+				block.Height)
+		}
+		blockConnected = true
+	}
+	if !blockConnected {
+		t.Fatalf("expected BlockConnected")
+	}
+}
+
+// nextNotification returns the next notification of the wallet client.
+func nextNotification(t *testing.T,
+	walletClient *electrum.WalletClient) interface{} {
+
+	t.Helper()
+
+	select {
+	case ntfn := <-walletClient.Notifications():
+		return ntfn
+	case <-time.After(timeout):
+		t.Fatalf("notification not received")
+	}
+
+	return nil
+}
+
+// assertRelevantTx asserts that the notification relays the given transaction
+// at the given height, where zero means unconfirmed.
+func assertRelevantTx(t *testing.T, ntfn interface{}, tx *wire.MsgTx,
+	height int32) {
+
+	t.Helper()
+
+	relevantTx, ok := ntfn.(chain.RelevantTx)
+	if !ok {
+		t.Fatalf("expected RelevantTx, got %T", ntfn)
+	}
+	if relevantTx.TxRecord.Hash != tx.TxHash() {
+		t.Fatalf("expected tx %v, got %v", tx.TxHash(),
+			relevantTx.TxRecord.Hash)
+	}
+
+	switch {
+	case height == 0 && relevantTx.Block != nil:
+		t.Fatalf("expected unconfirmed tx, got block %v",
+			relevantTx.Block.Height)
+
+	case height != 0 && (relevantTx.Block == nil ||
+		relevantTx.Block.Height != height):
+
+		t.Fatalf("expected tx confirmed at height %d, got %v", height,
+			relevantTx.Block)
+	}
+}
+
+// waitForSync waits until the client has caught up with the server's chain
+// tip.
+func waitForSync(t *testing.T, server *mockServer, client *electrum.Client) {
+	t.Helper()
+
+	tipHash, tipHeight := server.tip()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		hash, height, err := client.GetBestBlock()
+		if err == nil && height == tipHeight && *hash == *tipHash {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatalf("client didn't sync to tip %v", tipHash)
+}
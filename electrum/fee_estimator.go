@@ -0,0 +1,109 @@
+package electrum
+
+import (
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+// FeeEstimator is an implementation of the lnwallet.FeeEstimator interface
+// backed by the fee estimates of an Electrum server, which in turn proxies
+// them from its full node.
+type FeeEstimator struct {
+	// fallbackFeePerKW is the fee rate in sat/kw that is returned if the
+	// server can't produce an estimate.
+	fallbackFeePerKW lnwallet.SatPerKWeight
+
+	// minFeePerKW is the minimum fee rate in sat/kw we'll return. It's
+	// the server's relay fee, but at least our fee floor.
+	minFeePerKW lnwallet.SatPerKWeight
+
+	client *Client
+}
+
+// A compile time check to ensure FeeEstimator implements the
+// lnwallet.FeeEstimator interface.
+var _ lnwallet.FeeEstimator = (*FeeEstimator)(nil)
+
+// NewFeeEstimator creates a new fee estimator backed by the given client. The
+// fallback fee rate is returned whenever the server has no estimate for a
+// confirmation target.
+func NewFeeEstimator(client *Client,
+	fallbackFeePerKW lnwallet.SatPerKWeight) *FeeEstimator {
+
+	return &FeeEstimator{
+		fallbackFeePerKW: fallbackFeePerKW,
+		minFeePerKW:      lnwallet.FeePerKwFloor,
+		client:           client,
+	}
+}
+
+// Start queries the server for its relay fee, which is used as the minimum
+// fee rate.
+//
+// NOTE: This is part of the lnwallet.FeeEstimator interface.
+func (f *FeeEstimator) Start() error {
+	btcPerKB, err := f.client.RelayFee()
+	if err != nil {
+		return err
+	}
+
+	relayFee, err := btcutil.NewAmount(btcPerKB)
+	if err != nil {
+		return err
+	}
+
+	minRelayFeePerKW := lnwallet.SatPerKVByte(relayFee).FeePerKWeight()
+	if minRelayFeePerKW > f.minFeePerKW {
+		f.minFeePerKW = minRelayFeePerKW
+	}
+
+	log.Debugf("Using minimum fee rate of %v sat/kw", int64(f.minFeePerKW))
+
+	return nil
+}
+
+// Stop stops the fee estimator. The client isn't owned by the fee estimator,
+// so it's left running.
+//
+// NOTE: This is part of the lnwallet.FeeEstimator interface.
+func (f *FeeEstimator) Stop() error {
+	return nil
+}
+
+// EstimateFeePerKW returns the server's fee estimate in sat/kw for a
+// transaction to confirm within the given number of blocks.
+//
+// NOTE: This is part of the lnwallet.FeeEstimator interface.
+func (f *FeeEstimator) EstimateFeePerKW(
+	numBlocks uint32) (lnwallet.SatPerKWeight, error) {
+
+	btcPerKB, err := f.client.EstimateFee(numBlocks)
+	switch {
+	// If the server doesn't have enough data, or returns an error, then
+	// we'll return the fall back fee rate.
+	case err != nil:
+		log.Errorf("Unable to query fee estimate: %v", err)
+		fallthrough
+
+	case btcPerKB <= 0:
+		return f.fallbackFeePerKW, nil
+	}
+
+	satPerKB, err := btcutil.NewAmount(btcPerKB)
+	if err != nil {
+		return 0, err
+	}
+
+	satPerKW := lnwallet.SatPerKVByte(satPerKB).FeePerKWeight()
+	if satPerKW < f.minFeePerKW {
+		log.Debugf("Estimated fee rate of %v sat/kw is too low, "+
+			"using fee floor of %v sat/kw instead", int64(satPerKW),
+			int64(f.minFeePerKW))
+		satPerKW = f.minFeePerKW
+	}
+
+	log.Debugf("Returning %v sat/kw for conf target of %v",
+		int64(satPerKW), numBlocks)
+
+	return satPerKW, nil
+}
@@ -0,0 +1,278 @@
+package electrum
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// reorgSafetyDepth is the depth below which blocks are assumed to not
+	// be reorganized out of the chain anymore. Only headers of such blocks
+	// are cached by height, all others are always fetched from the
+	// server.
+	reorgSafetyDepth = 100
+
+	// headerChunkSize is the number of headers fetched at once when a
+	// header below the reorg safety depth is requested. This speeds up
+	// callers walking the chain, like the wallet during its initial sync.
+	headerChunkSize = 2016
+
+	// maxHeaderChunks is the maximum number of header chunks kept in the
+	// cache.
+	maxHeaderChunks = 8
+
+	// maxCachedHashes is the maximum number of headers kept indexed by
+	// their hash.
+	maxCachedHashes = maxHeaderChunks*headerChunkSize + 2000
+)
+
+// ErrUnknownBlock is returned when a block is requested by a hash the client
+// hasn't seen before. As the Electrum protocol only allows to look up blocks
+// by height, blocks can only be looked up by hash after their header has been
+// fetched by height, or has been announced as the chain tip.
+var ErrUnknownBlock = errors.New("unknown block hash")
+
+// cachedHeader is a header along with its height.
+type cachedHeader struct {
+	header wire.BlockHeader
+	height int32
+}
+
+// headerCache caches the headers fetched from the server, both by hash and,
+// for headers below the reorg safety depth, by height.
+type headerCache struct {
+	sync.Mutex
+
+	// chunks are the cached chunks of consecutive headers, keyed by the
+	// height of their first header.
+	chunks     map[int32][]wire.BlockHeader
+	chunkOrder []int32
+
+	byHash    map[chainhash.Hash]cachedHeader
+	hashOrder []chainhash.Hash
+}
+
+// newHeaderCache creates a new empty header cache.
+func newHeaderCache() *headerCache {
+	return &headerCache{
+		chunks: make(map[int32][]wire.BlockHeader),
+		byHash: make(map[chainhash.Hash]cachedHeader),
+	}
+}
+
+// addHeader adds a header to the cache, evicting the oldest header if the
+// cache is full.
+func (h *headerCache) addHeader(header *wire.BlockHeader, height int32) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.addHeaderLocked(header, height)
+}
+
+// addHeaderLocked adds a header to the cache.
+//
+// NOTE: The cache's lock MUST be held.
+func (h *headerCache) addHeaderLocked(header *wire.BlockHeader,
+	height int32) {
+
+	hash := header.BlockHash()
+	if _, ok := h.byHash[hash]; ok {
+		return
+	}
+
+	h.byHash[hash] = cachedHeader{
+		header: *header,
+		height: height,
+	}
+	h.hashOrder = append(h.hashOrder, hash)
+
+	for len(h.hashOrder) > maxCachedHashes {
+		delete(h.byHash, h.hashOrder[0])
+		h.hashOrder = h.hashOrder[1:]
+	}
+}
+
+// addChunk adds a chunk of consecutive headers starting at the given height.
+func (h *headerCache) addChunk(start int32, headers []wire.BlockHeader) {
+	h.Lock()
+	defer h.Unlock()
+
+	if _, ok := h.chunks[start]; !ok {
+		h.chunkOrder = append(h.chunkOrder, start)
+	}
+	h.chunks[start] = headers
+
+	for len(h.chunkOrder) > maxHeaderChunks {
+		delete(h.chunks, h.chunkOrder[0])
+		h.chunkOrder = h.chunkOrder[1:]
+	}
+
+	for i := range headers {
+		h.addHeaderLocked(&headers[i], start+int32(i))
+	}
+}
+
+// headerByHeight returns the cached header at the given height, if any.
+func (h *headerCache) headerByHeight(height int32) (*wire.BlockHeader, bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	start := height - height%headerChunkSize
+	chunk, ok := h.chunks[start]
+	if !ok || int(height-start) >= len(chunk) {
+		return nil, false
+	}
+
+	header := chunk[height-start]
+	return &header, true
+}
+
+// headerByHash returns the cached header with the given hash, if any.
+func (h *headerCache) headerByHash(hash *chainhash.Hash) (*cachedHeader,
+	bool) {
+
+	h.Lock()
+	defer h.Unlock()
+
+	cached, ok := h.byHash[*hash]
+	if !ok {
+		return nil, false
+	}
+
+	return &cached, true
+}
+
+// bestHeight returns the height of the tip of the server's main chain.
+func (c *Client) bestHeight() int32 {
+	c.tipMtx.RLock()
+	defer c.tipMtx.RUnlock()
+
+	return c.tipHeight
+}
+
+// GetBestBlock returns the hash and height of the tip of the server's main
+// chain.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (c *Client) GetBestBlock() (*chainhash.Hash, int32, error) {
+	c.tipMtx.RLock()
+	defer c.tipMtx.RUnlock()
+
+	hash := c.tipHeader.BlockHash()
+	return &hash, c.tipHeight, nil
+}
+
+// GetBlockHeaderByHeight returns the header of the block at the given height
+// within the server's main chain.
+func (c *Client) GetBlockHeaderByHeight(height int32) (*wire.BlockHeader,
+	error) {
+
+	tipHeight := c.bestHeight()
+	if height < 0 || height > tipHeight {
+		return nil, fmt.Errorf("no block at height %v, chain tip is "+
+			"at height %v", height, tipHeight)
+	}
+
+	// Blocks close to the tip may still be reorganized out of the chain,
+	// so we'll always fetch their headers from the server.
+	safeHeight := tipHeight - reorgSafetyDepth
+	if height > safeHeight {
+		header, err := c.fetchHeader(height)
+		if err != nil {
+			return nil, err
+		}
+		c.headers.addHeader(header, height)
+
+		return header, nil
+	}
+
+	if header, ok := c.headers.headerByHeight(height); ok {
+		return header, nil
+	}
+
+	// Otherwise, we'll fetch the whole chunk the header is part of, up to
+	// the reorg safety depth.
+	start := height - height%headerChunkSize
+	count := int32(headerChunkSize)
+	if start+count-1 > safeHeight {
+		count = safeHeight - start + 1
+	}
+	headers, err := c.fetchHeaders(start, count)
+	if err != nil {
+		return nil, err
+	}
+	if int32(len(headers)) <= height-start {
+		return nil, fmt.Errorf("server returned %v headers starting "+
+			"at height %v, expected %v", len(headers), start, count)
+	}
+	c.headers.addChunk(start, headers)
+
+	header := headers[height-start]
+	return &header, nil
+}
+
+// GetBlockHash returns the hash of the block at the given height within the
+// server's main chain.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO and chainntnfs.ChainConn
+// interfaces.
+func (c *Client) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
+	header, err := c.GetBlockHeaderByHeight(int32(blockHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	hash := header.BlockHash()
+	return &hash, nil
+}
+
+// GetBlockHeader returns the header of the block with the given hash. The
+// block doesn't need to be part of the main chain anymore, but its header
+// must have been seen by the client before.
+//
+// NOTE: This is part of the chainntnfs.ChainConn interface.
+func (c *Client) GetBlockHeader(blockHash *chainhash.Hash) (*wire.BlockHeader,
+	error) {
+
+	cached, ok := c.headers.headerByHash(blockHash)
+	if !ok {
+		return nil, fmt.Errorf("%v: %v", ErrUnknownBlock, blockHash)
+	}
+
+	return &cached.header, nil
+}
+
+// BlockHeight returns the height of the block with the given hash. The block
+// doesn't need to be part of the main chain anymore, but its header must have
+// been seen by the client before.
+func (c *Client) BlockHeight(blockHash *chainhash.Hash) (int32, error) {
+	cached, ok := c.headers.headerByHash(blockHash)
+	if !ok {
+		return 0, fmt.Errorf("%v: %v", ErrUnknownBlock, blockHash)
+	}
+
+	return cached.height, nil
+}
+
+// GetBlockHeaderVerbose returns the verbose header of the block with the
+// given hash. Only the hash and height of the result are populated.
+//
+// NOTE: This is part of the chainntnfs.ChainConn interface.
+func (c *Client) GetBlockHeaderVerbose(blockHash *chainhash.Hash) (
+	*btcjson.GetBlockHeaderVerboseResult, error) {
+
+	height, err := c.BlockHeight(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &btcjson.GetBlockHeaderVerboseResult{
+		Hash:   blockHash.String(),
+		Height: height,
+	}, nil
+}
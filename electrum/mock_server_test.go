@@ -0,0 +1,808 @@
+package electrum_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/electrum"
+)
+
+// mockRequest is a JSON-RPC request received by the mock server.
+type mockRequest struct {
+	ID     uint64            `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// mockError is the error object returned by the mock server.
+type mockError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// mockConn is a client connection to the mock server, along with its
+// subscriptions.
+type mockConn struct {
+	conn net.Conn
+
+	writeMtx sync.Mutex
+
+	// headers indicates whether the client subscribed to the chain tip.
+	headers bool
+
+	// scriptHashes maps the script hashes the client subscribed to, to
+	// the last status it was sent.
+	scriptHashes map[string]string
+}
+
+// send writes a single message to the client.
+func (c *mockConn) send(msg interface{}) {
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
+
+	c.conn.Write(append(msgBytes, '\n'))
+}
+
+// mockServer is an in-process Electrum server backed by an in-memory chain.
+// Blocks aren't validated in any way, which allows the tests to confirm
+// arbitrary transactions.
+type mockServer struct {
+	t *testing.T
+
+	listener net.Listener
+
+	mtx sync.Mutex
+
+	// blocks is the main chain, indexed by height.
+	blocks []*wire.MsgBlock
+
+	// mempool holds the unconfirmed transactions in the order they were
+	// broadcast.
+	mempool []*wire.MsgTx
+
+	// txns holds all transactions the server has ever seen, including
+	// those of stale blocks.
+	txns map[chainhash.Hash]*wire.MsgTx
+
+	// feeRate is the fee estimate in BTC/kB returned for all confirmation
+	// targets.
+	feeRate float64
+
+	// relayFee is the relay fee in BTC/kB.
+	relayFee float64
+
+	conns map[*mockConn]struct{}
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// newMockServer starts a new mock Electrum server with a chain of the given
+// number of blocks on top of the regtest genesis block.
+func newMockServer(t *testing.T, numBlocks int) *mockServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+
+	genesis := chaincfg.RegressionNetParams.GenesisBlock
+	s := &mockServer{
+		t:        t,
+		listener: listener,
+		blocks:   []*wire.MsgBlock{genesis},
+		txns:     make(map[chainhash.Hash]*wire.MsgTx),
+		feeRate:  -1,
+		relayFee: 0.00001,
+		conns:    make(map[*mockConn]struct{}),
+		quit:     make(chan struct{}),
+	}
+	for _, tx := range genesis.Transactions {
+		s.txns[tx.TxHash()] = tx
+	}
+	for i := 0; i < numBlocks; i++ {
+		s.connectBlock(nil, 0)
+	}
+
+	s.wg.Add(1)
+	go s.acceptConns()
+
+	return s
+}
+
+// addr returns the address the server is listening on.
+func (s *mockServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+// stop shuts down the server and closes all client connections.
+func (s *mockServer) stop() {
+	close(s.quit)
+	s.listener.Close()
+	s.dropConns()
+	s.wg.Wait()
+}
+
+// dropConns closes all client connections, as if the server restarted.
+func (s *mockServer) dropConns() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for c := range s.conns {
+		c.conn.Close()
+		delete(s.conns, c)
+	}
+}
+
+// newClient creates and starts a client connected to the server.
+func (s *mockServer) newClient() *electrum.Client {
+	s.t.Helper()
+
+	client, err := electrum.NewClient(&electrum.Config{
+		Server:            s.addr(),
+		NetParams:         &chaincfg.RegressionNetParams,
+		RequestTimeout:    5 * time.Second,
+		ReconnectInterval: 100 * time.Millisecond,
+	})
+	if err != nil {
+		s.t.Fatalf("unable to create client: %v", err)
+	}
+	if err := client.Start(); err != nil {
+		s.t.Fatalf("unable to start client: %v", err)
+	}
+
+	return client
+}
+
+// tip returns the hash and height of the best block.
+func (s *mockServer) tip() (*chainhash.Hash, int32) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	hash := s.blocks[len(s.blocks)-1].BlockHash()
+	return &hash, int32(len(s.blocks) - 1)
+}
+
+// block returns the block at the given height of the main chain.
+func (s *mockServer) block(height int32) *wire.MsgBlock {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.blocks[height]
+}
+
+// setFeeRate sets the fee estimate in BTC/kB returned by the server.
+func (s *mockServer) setFeeRate(feeRate float64) {
+	s.mtx.Lock()
+	s.feeRate = feeRate
+	s.mtx.Unlock()
+}
+
+// addToMempool adds the transaction to the mempool without any checks, and
+// notifies all subscribers.
+func (s *mockServer) addToMempool(tx *wire.MsgTx) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.mempool = append(s.mempool, tx)
+	s.txns[tx.TxHash()] = tx
+	s.notifyScriptHashes()
+}
+
+// mineBlocks mines the given number of blocks, the first of which includes
+// all mempool transactions.
+func (s *mockServer) mineBlocks(n int) []*wire.MsgBlock {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	blocks := make([]*wire.MsgBlock, 0, n)
+	for i := 0; i < n; i++ {
+		blocks = append(blocks, s.connectBlock(s.mempool, 0))
+		s.mempool = nil
+	}
+	s.notifyTip()
+
+	return blocks
+}
+
+// reorg disconnects the given number of blocks and replaces them with the
+// given number of empty blocks. The transactions of the disconnected blocks
+// are returned to the mempool.
+func (s *mockServer) reorg(depth, numBlocks int) []*wire.MsgBlock {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var mempool []*wire.MsgTx
+	for _, block := range s.blocks[len(s.blocks)-depth:] {
+		mempool = append(mempool, block.Transactions[1:]...)
+	}
+	s.mempool = append(mempool, s.mempool...)
+	s.blocks = s.blocks[:len(s.blocks)-depth]
+
+	blocks := make([]*wire.MsgBlock, 0, numBlocks)
+	for i := 0; i < numBlocks; i++ {
+		// Vary the nonce to make sure the new blocks don't match the
+		// stale ones.
+		blocks = append(blocks, s.connectBlock(nil, 1))
+	}
+	s.notifyTip()
+
+	return blocks
+}
+
+// connectBlock appends a new block including the given transactions to the
+// main chain. The nonce allows creating a block that differs from a stale one
+// at the same height.
+//
+// NOTE: The server's mutex must be held, unless the server isn't running
+// yet.
+func (s *mockServer) connectBlock(txns []*wire.MsgTx,
+	nonce uint32) *wire.MsgBlock {
+
+	prev := s.blocks[len(s.blocks)-1]
+	height := len(s.blocks)
+
+	// Each coinbase commits to the height of its block to keep it unique.
+	heightScript := make([]byte, 4)
+	binary.LittleEndian.PutUint32(heightScript, uint32(height))
+	coinbase := wire.NewMsgTx(1)
+	coinbase.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		SignatureScript:  append([]byte{0x04}, heightScript...),
+	})
+	coinbase.AddTxOut(&wire.TxOut{Value: 50e8, PkScript: []byte{0x51}})
+
+	block := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   4,
+			PrevBlock: prev.BlockHash(),
+			Timestamp: prev.Header.Timestamp.Add(10 * time.Minute),
+			Bits:      prev.Header.Bits,
+			Nonce:     nonce,
+		},
+		Transactions: append([]*wire.MsgTx{coinbase}, txns...),
+	}
+	levels := merkleLevels(blockTxIDs(block))
+	block.Header.MerkleRoot = levels[len(levels)-1][0]
+
+	for _, tx := range block.Transactions {
+		s.txns[tx.TxHash()] = tx
+	}
+	s.blocks = append(s.blocks, block)
+
+	return block
+}
+
+// blockTxIDs returns the hashes of all transactions in the block.
+func blockTxIDs(block *wire.MsgBlock) []chainhash.Hash {
+	txids := make([]chainhash.Hash, 0, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		txids = append(txids, tx.TxHash())
+	}
+
+	return txids
+}
+
+// merkleLevels returns all levels of the merkle tree over the given hashes,
+// starting with the hashes themselves and ending with the root.
+func merkleLevels(txids []chainhash.Hash) [][]chainhash.Hash {
+	levels := [][]chainhash.Hash{txids}
+	for level := txids; len(level) > 1; {
+		next := make([]chainhash.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			right := level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			next = append(
+				next, *blockchain.HashMerkleBranches(
+					&level[i], &right,
+				),
+			)
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	return levels
+}
+
+// acceptConns accepts new client connections until the server is stopped.
+func (s *mockServer) acceptConns() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &mockConn{
+			conn:         conn,
+			scriptHashes: make(map[string]string),
+		}
+		s.mtx.Lock()
+		s.conns[c] = struct{}{}
+		s.mtx.Unlock()
+
+		s.wg.Add(1)
+		go s.handleConn(c)
+	}
+}
+
+// handleConn serves the requests of a single client.
+func (s *mockServer) handleConn(c *mockConn) {
+	defer s.wg.Done()
+	defer c.conn.Close()
+
+	reader := bufio.NewReader(c.conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+
+		var req mockRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.t.Errorf("invalid request: %v", err)
+			return
+		}
+
+		s.mtx.Lock()
+		result, err := s.handleRequest(c, &req)
+		s.mtx.Unlock()
+
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+		}
+		if err != nil {
+			resp["error"] = &mockError{Code: 1, Message: err.Error()}
+		} else {
+			resp["result"] = result
+		}
+		c.send(resp)
+	}
+}
+
+// handleRequest handles a single request.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) handleRequest(c *mockConn,
+	req *mockRequest) (interface{}, error) {
+
+	var (
+		str    string
+		height int32
+		count  int32
+	)
+	parse := func(params ...interface{}) error {
+		if len(req.Params) < len(params) {
+			return fmt.Errorf("expected %d params", len(params))
+		}
+		for i, param := range params {
+			err := json.Unmarshal(req.Params[i], param)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch req.Method {
+	case "server.version":
+		return []string{"MockElectrum 1.0", electrum.ProtocolVersion},
+			nil
+
+	case "blockchain.headers.subscribe":
+		c.headers = true
+		return s.tipResult(), nil
+
+	case "blockchain.block.header":
+		if err := parse(&height); err != nil {
+			return nil, err
+		}
+		if height < 0 || int(height) >= len(s.blocks) {
+			return nil, fmt.Errorf("height %d out of range", height)
+		}
+		return serializeHeader(&s.blocks[height].Header), nil
+
+	case "blockchain.block.headers":
+		if err := parse(&height, &count); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		n := 0
+		for h := height; h < height+count && int(h) < len(s.blocks); h++ {
+			s.blocks[h].Header.Serialize(&buf)
+			n++
+		}
+		return map[string]interface{}{
+			"count": n,
+			"hex":   hex.EncodeToString(buf.Bytes()),
+			"max":   2016,
+		}, nil
+
+	case "blockchain.scripthash.subscribe":
+		if err := parse(&str); err != nil {
+			return nil, err
+		}
+		status := s.status(str)
+		c.scriptHashes[str] = status
+		if status == "" {
+			return nil, nil
+		}
+		return status, nil
+
+	case "blockchain.scripthash.get_history":
+		if err := parse(&str); err != nil {
+			return nil, err
+		}
+		return s.history(str), nil
+
+	case "blockchain.scripthash.listunspent":
+		if err := parse(&str); err != nil {
+			return nil, err
+		}
+		return s.unspent(str), nil
+
+	case "blockchain.transaction.get":
+		if err := parse(&str); err != nil {
+			return nil, err
+		}
+		txid, err := chainhash.NewHashFromStr(str)
+		if err != nil {
+			return nil, err
+		}
+		tx, ok := s.txns[*txid]
+		if !ok {
+			return nil, fmt.Errorf("unknown transaction %v", txid)
+		}
+		var buf bytes.Buffer
+		tx.Serialize(&buf)
+		return hex.EncodeToString(buf.Bytes()), nil
+
+	case "blockchain.transaction.get_merkle":
+		if err := parse(&str, &height); err != nil {
+			return nil, err
+		}
+		return s.merkle(str, height)
+
+	case "blockchain.transaction.id_from_pos":
+		var pos int
+		if err := parse(&height, &pos); err != nil {
+			return nil, err
+		}
+		if height < 0 || int(height) >= len(s.blocks) {
+			return nil, fmt.Errorf("height %d out of range", height)
+		}
+		txns := s.blocks[height].Transactions
+		if pos >= len(txns) {
+			return nil, fmt.Errorf("no tx at position %d", pos)
+		}
+		return txns[pos].TxHash().String(), nil
+
+	case "blockchain.transaction.broadcast":
+		if err := parse(&str); err != nil {
+			return nil, err
+		}
+		return s.broadcast(str)
+
+	case "blockchain.estimatefee":
+		return s.feeRate, nil
+
+	case "blockchain.relayfee":
+		return s.relayFee, nil
+
+	default:
+		return nil, fmt.Errorf("unknown method %v", req.Method)
+	}
+}
+
+// tipResult returns the best block in the format of a header notification.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) tipResult() map[string]interface{} {
+	height := len(s.blocks) - 1
+	return map[string]interface{}{
+		"height": height,
+		"hex":    serializeHeader(&s.blocks[height].Header),
+	}
+}
+
+// serializeHeader returns the hex encoded header.
+func serializeHeader(header *wire.BlockHeader) string {
+	var buf bytes.Buffer
+	header.Serialize(&buf)
+	return hex.EncodeToString(buf.Bytes())
+}
+
+// relevant returns whether the transaction pays to or spends from the given
+// script hash.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) relevant(tx *wire.MsgTx, scriptHash string) bool {
+	for _, txOut := range tx.TxOut {
+		if electrum.ScriptHash(txOut.PkScript) == scriptHash {
+			return true
+		}
+	}
+	for _, txIn := range tx.TxIn {
+		prevTx, ok := s.txns[txIn.PreviousOutPoint.Hash]
+		if !ok || int(txIn.PreviousOutPoint.Index) >= len(prevTx.TxOut) {
+			continue
+		}
+
+		pkScript := prevTx.TxOut[txIn.PreviousOutPoint.Index].PkScript
+		if electrum.ScriptHash(pkScript) == scriptHash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inMempool returns whether the transaction with the given hash is in the
+// mempool.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) inMempool(txid chainhash.Hash) bool {
+	for _, tx := range s.mempool {
+		if tx.TxHash() == txid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// history returns the history of the given script hash.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) history(scriptHash string) []electrum.HistoryEntry {
+	history := []electrum.HistoryEntry{}
+	for height, block := range s.blocks {
+		for _, tx := range block.Transactions {
+			if !s.relevant(tx, scriptHash) {
+				continue
+			}
+			history = append(history, electrum.HistoryEntry{
+				TxHash: tx.TxHash().String(),
+				Height: int32(height),
+			})
+		}
+	}
+
+	for _, tx := range s.mempool {
+		if !s.relevant(tx, scriptHash) {
+			continue
+		}
+
+		// Transactions spending unconfirmed outputs are reported with
+		// a height of -1.
+		var height int32
+		for _, txIn := range tx.TxIn {
+			if s.inMempool(txIn.PreviousOutPoint.Hash) {
+				height = -1
+			}
+		}
+		history = append(history, electrum.HistoryEntry{
+			TxHash: tx.TxHash().String(),
+			Height: height,
+		})
+	}
+
+	return history
+}
+
+// status returns the status of the given script hash, or an empty string if
+// it has no history.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) status(scriptHash string) string {
+	history := s.history(scriptHash)
+	if len(history) == 0 {
+		return ""
+	}
+
+	var statusStr string
+	for _, entry := range history {
+		statusStr += fmt.Sprintf("%s:%d:", entry.TxHash, entry.Height)
+	}
+	status := sha256.Sum256([]byte(statusStr))
+
+	return hex.EncodeToString(status[:])
+}
+
+// unspent returns the unspent outputs of the given script hash.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) unspent(scriptHash string) []electrum.UnspentEntry {
+	spent := make(map[wire.OutPoint]struct{})
+	txHeights := make(map[chainhash.Hash]int32)
+	var txns []*wire.MsgTx
+	for height, block := range s.blocks {
+		for _, tx := range block.Transactions {
+			txHeights[tx.TxHash()] = int32(height)
+			txns = append(txns, tx)
+		}
+	}
+	txns = append(txns, s.mempool...)
+
+	for _, tx := range txns {
+		for _, txIn := range tx.TxIn {
+			spent[txIn.PreviousOutPoint] = struct{}{}
+		}
+	}
+
+	unspent := []electrum.UnspentEntry{}
+	for _, tx := range txns {
+		txHash := tx.TxHash()
+		for i, txOut := range tx.TxOut {
+			if electrum.ScriptHash(txOut.PkScript) != scriptHash {
+				continue
+			}
+			op := wire.OutPoint{Hash: txHash, Index: uint32(i)}
+			if _, ok := spent[op]; ok {
+				continue
+			}
+			unspent = append(unspent, electrum.UnspentEntry{
+				TxHash: txHash.String(),
+				TxPos:  uint32(i),
+				Height: txHeights[txHash],
+				Value:  txOut.Value,
+			})
+		}
+	}
+
+	return unspent
+}
+
+// merkle returns the merkle branch of the transaction in the block at the
+// given height.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) merkle(txidStr string,
+	height int32) (interface{}, error) {
+
+	if height < 0 || int(height) >= len(s.blocks) {
+		return nil, fmt.Errorf("height %d out of range", height)
+	}
+
+	txids := blockTxIDs(s.blocks[height])
+	pos := -1
+	for i, txid := range txids {
+		if txid.String() == txidStr {
+			pos = i
+		}
+	}
+	if pos == -1 {
+		return nil, fmt.Errorf("tx %v not in block %d", txidStr, height)
+	}
+
+	var branch []string
+	levels := merkleLevels(txids)
+	idx := pos
+	for _, level := range levels[:len(levels)-1] {
+		sibling := idx ^ 1
+		if sibling >= len(level) {
+			sibling = idx
+		}
+		branch = append(branch, level[sibling].String())
+		idx >>= 1
+	}
+
+	return map[string]interface{}{
+		"block_height": height,
+		"merkle":       branch,
+		"pos":          pos,
+	}, nil
+}
+
+// broadcast adds the serialized transaction to the mempool, unless it
+// conflicts with a known transaction.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) broadcast(txHex string) (interface{}, error) {
+	txBytes, err := hex.DecodeString(txHex)
+	if err != nil {
+		return nil, err
+	}
+	tx := wire.NewMsgTx(1)
+	if err := tx.Deserialize(bytes.NewReader(txBytes)); err != nil {
+		return nil, err
+	}
+
+	txHash := tx.TxHash()
+	if s.inMempool(txHash) {
+		return nil, fmt.Errorf("txn-already-in-mempool")
+	}
+
+	var txns []*wire.MsgTx
+	for _, block := range s.blocks {
+		txns = append(txns, block.Transactions...)
+	}
+	txns = append(txns, s.mempool...)
+	for _, other := range txns {
+		if other.TxHash() == txHash {
+			return nil, fmt.Errorf("transaction already in block " +
+				"chain")
+		}
+		for _, otherIn := range other.TxIn {
+			for _, txIn := range tx.TxIn {
+				if otherIn.PreviousOutPoint == txIn.PreviousOutPoint {
+					return nil, fmt.Errorf("txn-mempool-conflict")
+				}
+			}
+		}
+	}
+
+	s.mempool = append(s.mempool, tx)
+	s.txns[txHash] = tx
+	s.notifyScriptHashes()
+
+	return txHash.String(), nil
+}
+
+// notifyTip notifies all subscribers of the new chain tip, followed by the
+// script hashes affected by it, in the same order as ElectrumX does.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) notifyTip() {
+	tip := s.tipResult()
+	for c := range s.conns {
+		if !c.headers {
+			continue
+		}
+		c.send(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "blockchain.headers.subscribe",
+			"params":  []interface{}{tip},
+		})
+	}
+
+	s.notifyScriptHashes()
+}
+
+// notifyScriptHashes notifies all subscribers of script hashes whose status
+// changed.
+//
+// NOTE: The server's mutex must be held.
+func (s *mockServer) notifyScriptHashes() {
+	for c := range s.conns {
+		for scriptHash, oldStatus := range c.scriptHashes {
+			status := s.status(scriptHash)
+			if status == oldStatus {
+				continue
+			}
+			c.scriptHashes[scriptHash] = status
+
+			var statusParam interface{}
+			if status != "" {
+				statusParam = status
+			}
+			c.send(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "blockchain.scripthash.subscribe",
+				"params":  []interface{}{scriptHash, statusParam},
+			})
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package electrum
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// headerResult is the result of a header subscription, as well as the
+// payload of each header notification.
+type headerResult struct {
+	Height int32  `json:"height"`
+	Hex    string `json:"hex"`
+}
+
+// headersResult is the result of a request for a range of headers.
+type headersResult struct {
+	Count int32  `json:"count"`
+	Hex   string `json:"hex"`
+	Max   int32  `json:"max"`
+}
+
+// merkleResult is the result of a request for the merkle branch of a
+// transaction.
+type merkleResult struct {
+	BlockHeight int32    `json:"block_height"`
+	Merkle      []string `json:"merkle"`
+	Pos         uint32   `json:"pos"`
+}
+
+// HistoryEntry is a transaction in the history of a script hash.
+type HistoryEntry struct {
+	// TxHash is the hash of the transaction.
+	TxHash string `json:"tx_hash"`
+
+	// Height is the height of the block that includes the transaction.
+	// It is 0 for transactions in the mempool, and -1 for transactions in
+	// the mempool that spend unconfirmed outputs.
+	Height int32 `json:"height"`
+}
+
+// UnspentEntry is an unspent output of a script hash.
+type UnspentEntry struct {
+	// TxHash is the hash of the transaction that created the output.
+	TxHash string `json:"tx_hash"`
+
+	// TxPos is the index of the output within the transaction.
+	TxPos uint32 `json:"tx_pos"`
+
+	// Height is the height of the block that includes the transaction, or
+	// 0 if the transaction is in the mempool.
+	Height int32 `json:"height"`
+
+	// Value is the value of the output in satoshis.
+	Value int64 `json:"value"`
+}
+
+// ScriptHash returns the Electrum script hash of the passed output script,
+// which is the hex encoded SHA256 hash of the script in reversed byte order.
+func ScriptHash(pkScript []byte) string {
+	hash := sha256.Sum256(pkScript)
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+
+	return hex.EncodeToString(hash[:])
+}
+
+// decodeHeader decodes a hex encoded block header.
+func decodeHeader(headerHex string) (*wire.BlockHeader, error) {
+	headerBytes, err := hex.DecodeString(headerHex)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &wire.BlockHeader{}
+	if err := header.Deserialize(bytes.NewReader(headerBytes)); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// ScriptHashHistory returns the confirmed and unconfirmed transactions that
+// pay to or spend from the given script hash. Confirmed transactions are
+// ordered by height, followed by the transactions in the mempool.
+func (c *Client) ScriptHashHistory(scriptHash string) ([]HistoryEntry, error) {
+	var history []HistoryEntry
+	err := c.call("blockchain.scripthash.get_history", &history, scriptHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// ScriptHashUnspent returns the unspent outputs of the given script hash,
+// including those in the mempool.
+func (c *Client) ScriptHashUnspent(scriptHash string) ([]UnspentEntry, error) {
+	var unspent []UnspentEntry
+	err := c.call("blockchain.scripthash.listunspent", &unspent, scriptHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return unspent, nil
+}
+
+// GetTransaction returns the transaction with the given hash. The transaction
+// may be confirmed or in the mempool.
+func (c *Client) GetTransaction(txid *chainhash.Hash) (*wire.MsgTx, error) {
+	var txHex string
+	err := c.call("blockchain.transaction.get", &txHex, txid.String())
+	if err != nil {
+		return nil, err
+	}
+
+	txBytes, err := hex.DecodeString(txHex)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(txBytes)); err != nil {
+		return nil, err
+	}
+
+	// Make sure the server returned the transaction we asked for.
+	if tx.TxHash() != *txid {
+		return nil, fmt.Errorf("server returned transaction %v "+
+			"instead of %v", tx.TxHash(), txid)
+	}
+
+	return tx, nil
+}
+
+// TxPosition returns the index of the given transaction within the block at
+// the given height. The merkle branch returned by the server is checked
+// against the header of the block, proving that the transaction is included
+// in it.
+func (c *Client) TxPosition(txid *chainhash.Hash, height int32) (uint32,
+	error) {
+
+	var result merkleResult
+	err := c.call(
+		"blockchain.transaction.get_merkle", &result, txid.String(),
+		height,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	header, err := c.GetBlockHeaderByHeight(height)
+	if err != nil {
+		return 0, err
+	}
+
+	// Starting with the transaction itself, we'll hash our way up the
+	// merkle tree. The position of the transaction determines whether
+	// each branch is the left or right sibling.
+	root := *txid
+	pos := result.Pos
+	for _, branchHex := range result.Merkle {
+		branch, err := chainhash.NewHashFromStr(branchHex)
+		if err != nil {
+			return 0, err
+		}
+
+		if pos&1 == 0 {
+			root = *blockchain.HashMerkleBranches(&root, branch)
+		} else {
+			root = *blockchain.HashMerkleBranches(branch, &root)
+		}
+		pos >>= 1
+	}
+
+	if root != header.MerkleRoot {
+		return 0, fmt.Errorf("invalid merkle proof for transaction %v "+
+			"in block %v", txid, header.BlockHash())
+	}
+
+	return result.Pos, nil
+}
+
+// TxIDFromPos returns the hash of the transaction at the given index within
+// the block at the given height.
+func (c *Client) TxIDFromPos(height int32, pos uint32) (*chainhash.Hash,
+	error) {
+
+	var txid string
+	err := c.call("blockchain.transaction.id_from_pos", &txid, height, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainhash.NewHashFromStr(txid)
+}
+
+// Broadcast publishes the given transaction and returns its hash.
+func (c *Client) Broadcast(tx *wire.MsgTx) (*chainhash.Hash, error) {
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+
+	var txid string
+	err := c.call(
+		"blockchain.transaction.broadcast", &txid,
+		hex.EncodeToString(buf.Bytes()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainhash.NewHashFromStr(txid)
+}
+
+// EstimateFee returns the server's estimate of the fee rate in BTC/kB needed
+// for a transaction to confirm within the given number of blocks. A negative
+// value is returned if the server can't produce an estimate.
+func (c *Client) EstimateFee(numBlocks uint32) (float64, error) {
+	var feeRate float64
+	err := c.call("blockchain.estimatefee", &feeRate, numBlocks)
+	if err != nil {
+		return 0, err
+	}
+
+	return feeRate, nil
+}
+
+// RelayFee returns the minimum fee rate in BTC/kB a transaction must pay to
+// be accepted into the server's mempool.
+func (c *Client) RelayFee() (float64, error) {
+	var feeRate float64
+	if err := c.call("blockchain.relayfee", &feeRate); err != nil {
+		return 0, err
+	}
+
+	return feeRate, nil
+}
+
+// fetchHeader requests the header at the given height.
+func (c *Client) fetchHeader(height int32) (*wire.BlockHeader, error) {
+	var headerHex string
+	err := c.call("blockchain.block.header", &headerHex, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeHeader(headerHex)
+}
+
+// fetchHeaders requests up to count consecutive headers starting at the given
+// height. The server may return less headers than requested.
+func (c *Client) fetchHeaders(start, count int32) ([]wire.BlockHeader,
+	error) {
+
+	var result headersResult
+	err := c.call("blockchain.block.headers", &result, start, count)
+	if err != nil {
+		return nil, err
+	}
+
+	headerBytes, err := hex.DecodeString(result.Hex)
+	if err != nil {
+		return nil, err
+	}
+	if len(headerBytes) != int(result.Count)*wire.MaxBlockHeaderPayload {
+		return nil, fmt.Errorf("expected %v headers, got %v bytes",
+			result.Count, len(headerBytes))
+	}
+
+	headers := make([]wire.BlockHeader, result.Count)
+	reader := bytes.NewReader(headerBytes)
+	for i := range headers {
+		if err := headers[i].Deserialize(reader); err != nil {
+			return nil, err
+		}
+
+		// The headers must form a chain.
+		if i > 0 && headers[i].PrevBlock != headers[i-1].BlockHash() {
+			return nil, fmt.Errorf("header at height %v doesn't "+
+				"connect to its predecessor", start+int32(i))
+		}
+	}
+
+	return headers, nil
+}
@@ -0,0 +1,889 @@
+package electrum
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// backendName is the name of the Electrum chain backend.
+const backendName = "electrum"
+
+// relayedTx records a transaction that was relayed to the wallet.
+type relayedTx struct {
+	// height is the height the transaction was confirmed at when it was
+	// relayed, or 0 if it was unconfirmed.
+	height int32
+
+	// scriptHash is the watched script hash whose history contained the
+	// transaction.
+	scriptHash string
+}
+
+// relevantTx is a transaction from the history of a watched script hash.
+type relevantTx struct {
+	tx         *wire.MsgTx
+	height     int32
+	pos        uint32
+	block      *wtxmgr.BlockMeta
+	scriptHash string
+}
+
+// scriptHashEntry is an entry of the history of a script hash.
+type scriptHashEntry struct {
+	HistoryEntry
+	scriptHash string
+}
+
+// WalletClient is an implementation of btcwallet's chain.Interface backed by
+// an Electrum server, which allows the on-chain wallet to sync through the
+// server. Rather than matching blocks against the addresses of the wallet,
+// the script hashes of the addresses are watched at the server, and the
+// transactions in their histories are relayed to the wallet.
+type WalletClient struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	// notifyBlocks is set once the wallet requested to be notified of
+	// connected and disconnected blocks.
+	notifyBlocks uint32 // To be used atomically.
+
+	client      *Client
+	chainParams *chaincfg.Params
+
+	headerSub     *HeaderSubscription
+	scriptHashSub *ScriptHashSubscription
+
+	notifications *chainntnfs.ConcurrentQueue
+
+	// watched maps the script hashes watched on behalf of the wallet to
+	// their addresses. relayed records the transactions that were relayed
+	// to the wallet, so they're only relayed again if their confirmation
+	// status changes.
+	watchMtx sync.Mutex
+	watched  map[string]btcutil.Address
+	relayed  map[chainhash.Hash]relayedTx
+
+	// bestBlock is the last block the wallet was notified of. The hashes
+	// of the recently notified blocks are kept to detect reorgs.
+	blockMtx    sync.Mutex
+	bestBlock   waddrmgr.BlockStamp
+	blockHashes map[int32]chainhash.Hash
+
+	// historyCache caches the histories of script hashes queried by
+	// FilterBlocks. It's cleared whenever the chain tip changes.
+	historyMtx      sync.Mutex
+	historyCache    map[string][]HistoryEntry
+	historyCacheTip chainhash.Hash
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// A compile time check to ensure WalletClient implements the chain.Interface
+// interface.
+var _ chain.Interface = (*WalletClient)(nil)
+
+// NewWalletClient creates a new wallet chain source backed by the given
+// Electrum client.
+func NewWalletClient(client *Client,
+	chainParams *chaincfg.Params) *WalletClient {
+
+	return &WalletClient{
+		client:        client,
+		chainParams:   chainParams,
+		notifications: chainntnfs.NewConcurrentQueue(20),
+		watched:       make(map[string]btcutil.Address),
+		relayed:       make(map[chainhash.Hash]relayedTx),
+		blockHashes:   make(map[int32]chainhash.Hash),
+		historyCache:  make(map[string][]HistoryEntry),
+		quit:          make(chan struct{}),
+	}
+}
+
+// Start subscribes to the chain tip and the watched script hashes, and
+// signals the wallet that it can start syncing.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) Start() error {
+	if atomic.AddInt32(&w.started, 1) != 1 {
+		return nil
+	}
+
+	w.notifications.Start()
+
+	w.headerSub = w.client.SubscribeHeaders()
+	w.scriptHashSub = w.client.SubscribeScriptHashUpdates()
+
+	w.wg.Add(1)
+	go w.notificationHandler()
+
+	w.notify(chain.ClientConnected{})
+
+	return nil
+}
+
+// Stop cancels all subscriptions. The Electrum client itself is shared with
+// other subsystems, so it's left running.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) Stop() {
+	if atomic.AddInt32(&w.stopped, 1) != 1 {
+		return
+	}
+
+	close(w.quit)
+	w.wg.Wait()
+
+	w.headerSub.Cancel()
+	w.scriptHashSub.Cancel()
+	w.notifications.Stop()
+}
+
+// WaitForShutdown blocks until the client has been stopped.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) WaitForShutdown() {
+	w.wg.Wait()
+}
+
+// BackEnd returns the name of the chain backend.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) BackEnd() string {
+	return backendName
+}
+
+// Notifications returns the channel over which notifications for the wallet
+// are delivered.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) Notifications() <-chan interface{} {
+	return w.notifications.ChanOut()
+}
+
+// GetBestBlock returns the hash and height of the tip of the main chain.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	return w.client.GetBestBlock()
+}
+
+// GetBlock returns the block with the given hash.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) GetBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	return w.client.GetBlock(hash)
+}
+
+// GetBlockHash returns the hash of the block at the given height.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	return w.client.GetBlockHash(height)
+}
+
+// GetBlockHeader returns the header of the block with the given hash.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) GetBlockHeader(
+	hash *chainhash.Hash) (*wire.BlockHeader, error) {
+
+	return w.client.GetBlockHeader(hash)
+}
+
+// BlockStamp returns the tip of the main chain.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	hash, height, err := w.client.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+	header, err := w.client.GetBlockHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &waddrmgr.BlockStamp{
+		Height:    height,
+		Hash:      *hash,
+		Timestamp: header.Timestamp,
+	}, nil
+}
+
+// SendRawTransaction broadcasts the given transaction through the server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) SendRawTransaction(tx *wire.MsgTx,
+	allowHighFees bool) (*chainhash.Hash, error) {
+
+	return w.client.Broadcast(tx)
+}
+
+// GetUtxo returns the original output referenced by the passed outpoint that
+// creates the target pkScript.
+func (w *WalletClient) GetUtxo(op *wire.OutPoint, pkScript []byte,
+	heightHint uint32) (*wire.TxOut, error) {
+
+	return w.client.GetUtxo(op, pkScript, heightHint)
+}
+
+// NotifyReceived watches the given addresses for transactions paying to or
+// spending from them. Transactions already in their histories are relayed to
+// the wallet right away.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) NotifyReceived(addrs []btcutil.Address) error {
+	scriptHashes, hasHistory, err := w.watchAddresses(addrs)
+	if err != nil {
+		return err
+	}
+
+	for i, scriptHash := range scriptHashes {
+		if !hasHistory[i] {
+			continue
+		}
+
+		if err := w.relayHistory(scriptHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NotifyBlocks starts notifying the wallet of connected and disconnected
+// blocks, starting after the current chain tip.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) NotifyBlocks() error {
+	hash, height, err := w.client.GetBestBlock()
+	if err != nil {
+		return err
+	}
+
+	w.blockMtx.Lock()
+	w.bestBlock = waddrmgr.BlockStamp{
+		Height: height,
+		Hash:   *hash,
+	}
+	w.blockHashes = map[int32]chainhash.Hash{height: *hash}
+	w.blockMtx.Unlock()
+
+	atomic.StoreUint32(&w.notifyBlocks, 1)
+
+	return nil
+}
+
+// Rescan relays all transactions paying to or spending from the given
+// addresses and outpoints that were confirmed after the given block, as well
+// as those in the mempool. The addresses are watched from then on.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) Rescan(startHash *chainhash.Hash, addrs []btcutil.Address,
+	outPoints map[wire.OutPoint]btcutil.Address) error {
+
+	startHeight, err := w.client.BlockHeight(startHash)
+	if err != nil {
+		log.Warnf("Unable to find rescan start block %v, rescanning "+
+			"from genesis: %v", startHash, err)
+		startHeight = 0
+	}
+
+	// As the history of a script also contains the transactions spending
+	// from it, we can watch the outpoints through their addresses.
+	watchAddrs := append([]btcutil.Address{}, addrs...)
+	for _, addr := range outPoints {
+		watchAddrs = append(watchAddrs, addr)
+	}
+
+	// We'll start watching the addresses before querying their histories,
+	// so we won't miss any transactions arriving during the rescan.
+	scriptHashes, _, err := w.watchAddresses(watchAddrs)
+	if err != nil {
+		return err
+	}
+
+	histories := make([][]HistoryEntry, len(scriptHashes))
+	err = parallel(len(scriptHashes), func(i int) error {
+		var err error
+		histories[i], err = w.client.ScriptHashHistory(scriptHashes[i])
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var entries []scriptHashEntry
+	for i, history := range histories {
+		for _, entry := range history {
+			if entry.Height > 0 && entry.Height < startHeight {
+				continue
+			}
+
+			entries = append(entries, scriptHashEntry{
+				HistoryEntry: entry,
+				scriptHash:   scriptHashes[i],
+			})
+		}
+	}
+
+	txns, err := w.fetchRelevantTxns(entries)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txns {
+		if err := w.relayTx(tx); err != nil {
+			return err
+		}
+	}
+
+	stamp, err := w.BlockStamp()
+	if err != nil {
+		return err
+	}
+	w.notify(&chain.RescanFinished{
+		Hash:   &stamp.Hash,
+		Height: stamp.Height,
+		Time:   stamp.Timestamp,
+	})
+
+	return nil
+}
+
+// FilterBlocks scans the given blocks for transactions paying to or spending
+// from the addresses and outpoints of the request, and returns the details of
+// the first block that contains any. If none does, nil is returned. The
+// blocks are matched against the histories of the addresses rather than
+// scanned in full.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (w *WalletClient) FilterBlocks(
+	req *chain.FilterBlocksRequest) (*chain.FilterBlocksResponse, error) {
+
+	type watchedAddr struct {
+		addr     btcutil.Address
+		index    *waddrmgr.ScopedIndex
+		internal bool
+	}
+
+	watched := make(map[string]*watchedAddr)
+	addAddr := func(addr btcutil.Address, index *waddrmgr.ScopedIndex,
+		internal bool) error {
+
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return err
+		}
+		watched[ScriptHash(pkScript)] = &watchedAddr{
+			addr:     addr,
+			index:    index,
+			internal: internal,
+		}
+
+		return nil
+	}
+	for index, addr := range req.ExternalAddrs {
+		index := index
+		if err := addAddr(addr, &index, false); err != nil {
+			return nil, err
+		}
+	}
+	for index, addr := range req.InternalAddrs {
+		index := index
+		if err := addAddr(addr, &index, true); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range req.WatchedOutPoints {
+		if err := addAddr(addr, nil, false); err != nil {
+			return nil, err
+		}
+	}
+
+	scriptHashes := make([]string, 0, len(watched))
+	for scriptHash := range watched {
+		scriptHashes = append(scriptHashes, scriptHash)
+	}
+	histories := make([][]HistoryEntry, len(scriptHashes))
+	err := parallel(len(scriptHashes), func(i int) error {
+		var err error
+		histories[i], err = w.cachedHistory(scriptHashes[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entriesByHeight := make(map[int32][]scriptHashEntry)
+	for i, history := range histories {
+		for _, entry := range history {
+			entriesByHeight[entry.Height] = append(
+				entriesByHeight[entry.Height], scriptHashEntry{
+					HistoryEntry: entry,
+					scriptHash:   scriptHashes[i],
+				},
+			)
+		}
+	}
+
+	for i, block := range req.Blocks {
+		entries, ok := entriesByHeight[block.Height]
+		if !ok {
+			continue
+		}
+
+		txns, err := w.fetchRelevantTxns(entries)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &chain.FilterBlocksResponse{
+			BatchIndex: uint32(i),
+			BlockMeta:  block,
+			FoundExternalAddrs: make(
+				map[waddrmgr.KeyScope]map[uint32]struct{},
+			),
+			FoundInternalAddrs: make(
+				map[waddrmgr.KeyScope]map[uint32]struct{},
+			),
+			FoundOutPoints: make(map[wire.OutPoint]btcutil.Address),
+		}
+		for _, tx := range txns {
+			resp.RelevantTxns = append(resp.RelevantTxns, tx.tx)
+
+			txHash := tx.tx.TxHash()
+			for outIndex, txOut := range tx.tx.TxOut {
+				addr, ok := watched[ScriptHash(txOut.PkScript)]
+				if !ok {
+					continue
+				}
+
+				op := wire.OutPoint{
+					Hash:  txHash,
+					Index: uint32(outIndex),
+				}
+				resp.FoundOutPoints[op] = addr.addr
+
+				if addr.index == nil {
+					continue
+				}
+				found := resp.FoundExternalAddrs
+				if addr.internal {
+					found = resp.FoundInternalAddrs
+				}
+				scope := addr.index.Scope
+				if _, ok := found[scope]; !ok {
+					found[scope] = make(map[uint32]struct{})
+				}
+				found[scope][addr.index.Index] = struct{}{}
+			}
+		}
+
+		return resp, nil
+	}
+
+	return nil, nil
+}
+
+// notify delivers a notification to the wallet.
+func (w *WalletClient) notify(ntfn interface{}) {
+	select {
+	case w.notifications.ChanIn() <- ntfn:
+	case <-w.quit:
+	}
+}
+
+// notificationHandler relays new blocks and the transactions of watched
+// script hashes to the wallet.
+//
+// NOTE: This MUST be run as a goroutine.
+func (w *WalletClient) notificationHandler() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case item := <-w.headerSub.Updates():
+			if atomic.LoadUint32(&w.notifyBlocks) == 0 {
+				continue
+			}
+
+			update := item.(*TipUpdate)
+			if err := w.handleTipUpdate(update); err != nil {
+				log.Errorf("Unable to handle new chain tip %v: "+
+					"%v", update.Header.BlockHash(), err)
+			}
+
+		case item := <-w.scriptHashSub.Updates():
+			update := item.(*ScriptHashUpdate)
+
+			w.watchMtx.Lock()
+			_, ok := w.watched[update.ScriptHash]
+			w.watchMtx.Unlock()
+			if !ok {
+				continue
+			}
+
+			if err := w.relayHistory(update.ScriptHash); err != nil {
+				log.Errorf("Unable to relay history of script "+
+					"hash %v: %v", update.ScriptHash, err)
+			}
+
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+// handleTipUpdate notifies the wallet of the blocks disconnected and
+// connected since the last tip it was notified of.
+func (w *WalletClient) handleTipUpdate(update *TipUpdate) error {
+	w.blockMtx.Lock()
+	defer w.blockMtx.Unlock()
+
+	// Walking back from our best block, we'll find the last block we
+	// notified the wallet of that's still part of the main chain.
+	forkHeight := w.bestBlock.Height
+	if update.Height-1 < forkHeight {
+		forkHeight = update.Height - 1
+	}
+	for ; forkHeight > w.bestBlock.Height-reorgSafetyDepth; forkHeight-- {
+		hash, ok := w.blockHashes[forkHeight]
+		if !ok {
+			break
+		}
+
+		if forkHeight == update.Height-1 &&
+			update.Header.PrevBlock == hash {
+
+			break
+		}
+
+		mainHash, err := w.client.GetBlockHash(int64(forkHeight))
+		if err != nil {
+			return err
+		}
+		if *mainHash == hash {
+			break
+		}
+	}
+
+	for height := w.bestBlock.Height; height > forkHeight; height-- {
+		hash := w.blockHashes[height]
+		log.Debugf("Block disconnected: height=%v, hash=%v", height,
+			hash)
+
+		block := wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   hash,
+				Height: height,
+			},
+		}
+		if header, err := w.client.GetBlockHeader(&hash); err == nil {
+			block.Time = header.Timestamp
+		}
+		w.notify(chain.BlockDisconnected(block))
+
+		delete(w.blockHashes, height)
+	}
+
+	// Transactions confirmed in the disconnected blocks are now
+	// unconfirmed from the wallet's point of view. We'll forget them, and
+	// relay the histories of their scripts again once the new blocks have
+	// been connected.
+	var reorgedScriptHashes []string
+	w.watchMtx.Lock()
+	for txid, tx := range w.relayed {
+		if tx.height > forkHeight {
+			delete(w.relayed, txid)
+			reorgedScriptHashes = append(
+				reorgedScriptHashes, tx.scriptHash,
+			)
+		}
+	}
+	w.watchMtx.Unlock()
+
+	for height := forkHeight + 1; height <= update.Height; height++ {
+		header := &update.Header
+		if height != update.Height {
+			var err error
+			header, err = w.client.GetBlockHeaderByHeight(height)
+			if err != nil {
+				return err
+			}
+		}
+		hash := header.BlockHash()
+
+		w.notify(chain.BlockConnected(wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   hash,
+				Height: height,
+			},
+			Time: header.Timestamp,
+		}))
+
+		w.blockHashes[height] = hash
+		delete(w.blockHashes, height-reorgSafetyDepth)
+		w.bestBlock = waddrmgr.BlockStamp{
+			Height:    height,
+			Hash:      hash,
+			Timestamp: header.Timestamp,
+		}
+	}
+
+	for _, scriptHash := range reorgedScriptHashes {
+		if err := w.relayHistory(scriptHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// watchAddresses watches the script hashes of the given addresses at the
+// server. The script hashes are returned along with whether they already had
+// a history when they were first watched.
+func (w *WalletClient) watchAddresses(addrs []btcutil.Address) ([]string,
+	[]bool, error) {
+
+	scriptHashes := make([]string, len(addrs))
+	isNew := make([]bool, len(addrs))
+	w.watchMtx.Lock()
+	for i, addr := range addrs {
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			w.watchMtx.Unlock()
+			return nil, nil, err
+		}
+		scriptHashes[i] = ScriptHash(pkScript)
+
+		if _, ok := w.watched[scriptHashes[i]]; !ok {
+			w.watched[scriptHashes[i]] = addr
+			isNew[i] = true
+		}
+	}
+	w.watchMtx.Unlock()
+
+	hasHistory := make([]bool, len(addrs))
+	err := parallel(len(addrs), func(i int) error {
+		if !isNew[i] {
+			return nil
+		}
+
+		status, err := w.client.WatchScriptHash(scriptHashes[i])
+		if err != nil {
+			return err
+		}
+		hasHistory[i] = status != ""
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return scriptHashes, hasHistory, nil
+}
+
+// relayHistory relays the transactions in the history of the given script
+// hash that haven't been relayed with their current confirmation status yet.
+func (w *WalletClient) relayHistory(scriptHash string) error {
+	history, err := w.client.ScriptHashHistory(scriptHash)
+	if err != nil {
+		return err
+	}
+
+	var entries []scriptHashEntry
+	w.watchMtx.Lock()
+	for _, entry := range history {
+		txid, err := chainhash.NewHashFromStr(entry.TxHash)
+		if err != nil {
+			w.watchMtx.Unlock()
+			return err
+		}
+
+		tx, ok := w.relayed[*txid]
+		if ok && tx.height == confirmedHeight(entry.Height) {
+			continue
+		}
+
+		entries = append(entries, scriptHashEntry{
+			HistoryEntry: entry,
+			scriptHash:   scriptHash,
+		})
+	}
+	w.watchMtx.Unlock()
+
+	txns, err := w.fetchRelevantTxns(entries)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txns {
+		if err := w.relayTx(tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// relayTx relays a relevant transaction to the wallet.
+func (w *WalletClient) relayTx(tx *relevantTx) error {
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx.tx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	w.notify(chain.RelevantTx{
+		TxRecord: rec,
+		Block:    tx.block,
+	})
+
+	w.watchMtx.Lock()
+	w.relayed[tx.tx.TxHash()] = relayedTx{
+		height:     tx.height,
+		scriptHash: tx.scriptHash,
+	}
+	w.watchMtx.Unlock()
+
+	return nil
+}
+
+// cachedHistory returns the history of the given script hash, which is cached
+// until the chain tip changes.
+func (w *WalletClient) cachedHistory(scriptHash string) ([]HistoryEntry,
+	error) {
+
+	tipHash, _, err := w.client.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	w.historyMtx.Lock()
+	if w.historyCacheTip != *tipHash {
+		w.historyCache = make(map[string][]HistoryEntry)
+		w.historyCacheTip = *tipHash
+	}
+	history, ok := w.historyCache[scriptHash]
+	w.historyMtx.Unlock()
+	if ok {
+		return history, nil
+	}
+
+	history, err = w.client.ScriptHashHistory(scriptHash)
+	if err != nil {
+		return nil, err
+	}
+
+	w.historyMtx.Lock()
+	w.historyCache[scriptHash] = history
+	w.historyMtx.Unlock()
+
+	return history, nil
+}
+
+// fetchRelevantTxns fetches the transactions of the given history entries,
+// along with their blocks and positions if they're confirmed. The
+// transactions are returned in the order they were confirmed in, followed by
+// the unconfirmed ones, so that transactions are always preceded by the
+// transactions they spend from.
+func (w *WalletClient) fetchRelevantTxns(
+	entries []scriptHashEntry) ([]*relevantTx, error) {
+
+	// The same transaction may be part of the histories of several
+	// scripts, so we'll only fetch it once.
+	seen := make(map[string]struct{})
+	var unique []scriptHashEntry
+	for _, entry := range entries {
+		if _, ok := seen[entry.TxHash]; ok {
+			continue
+		}
+		seen[entry.TxHash] = struct{}{}
+		unique = append(unique, entry)
+	}
+
+	txns := make([]*relevantTx, len(unique))
+	err := parallel(len(unique), func(i int) error {
+		entry := unique[i]
+		txid, err := chainhash.NewHashFromStr(entry.TxHash)
+		if err != nil {
+			return err
+		}
+
+		tx, err := w.client.GetTransaction(txid)
+		if err != nil {
+			return err
+		}
+		txns[i] = &relevantTx{
+			tx:         tx,
+			height:     confirmedHeight(entry.Height),
+			scriptHash: entry.scriptHash,
+		}
+
+		if entry.Height <= 0 {
+			return nil
+		}
+
+		txns[i].pos, err = w.client.TxPosition(txid, entry.Height)
+		if err != nil {
+			return err
+		}
+		header, err := w.client.GetBlockHeaderByHeight(entry.Height)
+		if err != nil {
+			return err
+		}
+		txns[i].block = &wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   header.BlockHash(),
+				Height: entry.Height,
+			},
+			Time: header.Timestamp,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Unconfirmed transactions are ordered after all confirmed ones, with
+	// those spending unconfirmed outputs last.
+	sortKey := func(tx *relevantTx, entry scriptHashEntry) int64 {
+		if entry.Height > 0 {
+			return int64(entry.Height)<<32 | int64(tx.pos)
+		}
+		return math.MaxInt64 + int64(entry.Height)
+	}
+	keys := make(map[*relevantTx]int64, len(txns))
+	for i, tx := range txns {
+		keys[tx] = sortKey(tx, unique[i])
+	}
+	sort.SliceStable(txns, func(i, j int) bool {
+		return keys[txns[i]] < keys[txns[j]]
+	})
+
+	return txns, nil
+}
+
+// confirmedHeight maps the height of a history entry to the height we record
+// for relayed transactions, which is 0 for all unconfirmed transactions.
+func confirmedHeight(height int32) int32 {
+	if height < 0 {
+		return 0
+	}
+
+	return height
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/channeldb"
@@ -226,6 +227,11 @@ type ChannelLinkConfig struct {
 	// fee rate. A random timeout will be selected between these values.
 	MinFeeUpdateTimeout time.Duration
 	MaxFeeUpdateTimeout time.Duration
+
+	// NotifyChannelUpdate is an optional function closure that will be
+	// called with a snapshot of the channel each time a new local
+	// commitment has been locked in.
+	NotifyChannelUpdate func(*channeldb.ChannelSnapshot)
 }
 
 // channelLink is the service which drives a channel's commitment update
@@ -1495,6 +1501,12 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 		}
 		l.cfg.Peer.SendMessage(false, nextRevocation)
 
+		// With our prior state revoked, the balances of our new
+		// commitment are now locked in.
+		if l.cfg.NotifyChannelUpdate != nil {
+			l.cfg.NotifyChannelUpdate(l.channel.StateSnapshot())
+		}
+
 		// Since we just revoked our commitment, we may have a new set
 		// of HTLC's on our commitment, so we'll send them over our
 		// HTLC update channel so any callers can be notified.
@@ -1791,6 +1803,13 @@ func (l *channelLink) ChanID() lnwire.ChannelID {
 	return lnwire.NewChanIDFromOutPoint(l.channel.ChannelPoint())
 }
 
+// ChannelPoint returns the channel outpoint for the channel link.
+//
+// NOTE: Part of the ChannelLink interface.
+func (l *channelLink) ChannelPoint() *wire.OutPoint {
+	return l.channel.ChannelPoint()
+}
+
 // Bandwidth returns the total amount that can flow through the channel link at
 // this given instance. The value returned is expressed in millisatoshi and can
 // be used by callers when making forwarding decisions to determine if a link
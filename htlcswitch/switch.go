@@ -117,6 +117,19 @@ type ChanClose struct {
 	// process for the cooperative closure transaction kicks off.
 	TargetFeePerKw lnwallet.SatPerKWeight
 
+	// MaxFeePerKw is the highest fee rate the caller is willing to pay for
+	// the cooperative closure transaction. Offers of the remote party
+	// above this fee rate won't be signed. A value of zero signals that no
+	// cap applies. This value is only utilized if the closure type is
+	// CloseRegular.
+	MaxFeePerKw lnwallet.SatPerKWeight
+
+	// DeliveryScript is the script our settled funds should be paid out
+	// to within the cooperative closure transaction. If nil, a fresh
+	// script of the wallet will be used. This value is only utilized if
+	// the closure type is CloseRegular.
+	DeliveryScript lnwire.DeliveryAddress
+
 	// Updates is used by request creator to receive the notifications about
 	// execution of the close channel request.
 	Updates chan *lnrpc.CloseStatusUpdate
@@ -176,6 +189,16 @@ type Config struct {
 	// LogEventTicker is a signal instructing the htlcswitch to log
 	// aggregate stats about it's forwarding during the last interval.
 	LogEventTicker ticker.Ticker
+
+	// NotifyActiveChannel is an optional function closure that will be
+	// called each time the link of a channel has been added to the
+	// switch.
+	NotifyActiveChannel func(wire.OutPoint)
+
+	// NotifyInactiveChannel is an optional function closure that will be
+	// called each time the link of a channel has been removed from the
+	// switch.
+	NotifyInactiveChannel func(wire.OutPoint)
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -1412,10 +1435,13 @@ func (s *Switch) teardownCircuit(pkt *htlcPacket) error {
 
 // CloseLink creates and sends the close channel command to the target link
 // directing the specified closure type. If the closure type if CloseRegular,
-// then the last parameter should be the ideal fee-per-kw that will be used as
-// a starting point for close negotiation.
+// then the targetFeePerKw parameter should be the ideal fee-per-kw that will
+// be used as a starting point for close negotiation, maxFeePerKw the highest
+// fee-per-kw we're willing to pay (or zero for no cap), and deliveryScript the
+// script to pay our settled funds to (or nil for a fresh wallet script).
 func (s *Switch) CloseLink(chanPoint *wire.OutPoint, closeType ChannelCloseType,
-	targetFeePerKw lnwallet.SatPerKWeight) (chan *lnrpc.CloseStatusUpdate,
+	targetFeePerKw, maxFeePerKw lnwallet.SatPerKWeight,
+	deliveryScript lnwire.DeliveryAddress) (chan *lnrpc.CloseStatusUpdate,
 	chan error) {
 
 	// TODO(roasbeef) abstract out the close updates.
@@ -1427,6 +1453,8 @@ func (s *Switch) CloseLink(chanPoint *wire.OutPoint, closeType ChannelCloseType,
 		ChanPoint:      chanPoint,
 		Updates:        updateChan,
 		TargetFeePerKw: targetFeePerKw,
+		MaxFeePerKw:    maxFeePerKw,
+		DeliveryScript: deliveryScript,
 		Err:            errChan,
 	}
 
@@ -1932,6 +1960,10 @@ func (s *Switch) AddLink(link ChannelLink) error {
 		)
 	}
 
+	if s.cfg.NotifyActiveChannel != nil {
+		s.cfg.NotifyActiveChannel(*link.ChannelPoint())
+	}
+
 	return nil
 }
 
@@ -2042,6 +2074,10 @@ func (s *Switch) removeLink(chanID lnwire.ChannelID) ChannelLink {
 		}
 	}
 
+	if s.cfg.NotifyInactiveChannel != nil {
+		s.cfg.NotifyInactiveChannel(*link.ChannelPoint())
+	}
+
 	return link
 }
 
@@ -269,24 +269,52 @@ func (b *BtcWalletKeyRing) DerivePrivKey(keyDesc KeyDescriptor) (*btcec.PrivateK
 			return err
 		}
 
-		// Now that we know the account exists, we can safely derive
-		// the full private key from the given path.
+		// If the public key isn't set or they have a non-zero index,
+		// then we know that the caller knows the full derivation path
+		// of the key.
+		if keyDesc.PubKey == nil || keyDesc.Index > 0 {
+			// Now that we know the account exists, we can safely
+			// derive the full private key from the given path.
+			path := waddrmgr.DerivationPath{
+				Account: uint32(keyDesc.Family),
+				Branch:  0,
+				Index:   uint32(keyDesc.Index),
+			}
+			addr, err := scope.DeriveFromKeyPath(addrmgrNs, path)
+			if err != nil {
+				return err
+			}
+
+			key, err = addr.(waddrmgr.ManagedPubKeyAddress).PrivKey()
+			if err != nil {
+				return err
+			}
+
+			return nil
+		}
+
+		// Otherwise, the caller only knows the public key and the key
+		// family, so we'll scan the family for the matching key.
 		path := waddrmgr.DerivationPath{
 			Account: uint32(keyDesc.Family),
 			Branch:  0,
-			Index:   uint32(keyDesc.Index),
-		}
-		addr, err := scope.DeriveFromKeyPath(addrmgrNs, path)
-		if err != nil {
-			return err
 		}
-
-		key, err = addr.(waddrmgr.ManagedPubKeyAddress).PrivKey()
-		if err != nil {
-			return err
+		for i := 0; i < MaxKeyRangeScan; i++ {
+			addr, err := scope.DeriveFromKeyPath(addrmgrNs, path)
+			if err != nil {
+				return err
+			}
+
+			pubKeyAddr := addr.(waddrmgr.ManagedPubKeyAddress)
+			if keyDesc.PubKey.IsEqual(pubKeyAddr.PubKey()) {
+				key, err = pubKeyAddr.PrivKey()
+				return err
+			}
+
+			path.Index++
 		}
 
-		return nil
+		return ErrCannotDerivePrivKey
 	})
 	if err != nil {
 		return nil, err
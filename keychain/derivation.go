@@ -1,6 +1,10 @@
 package keychain
 
-import "github.com/btcsuite/btcd/btcec"
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+)
 
 const (
 	// KeyDerivationVersion is the version of the key derivation schema
@@ -18,6 +22,17 @@ const (
 	//
 	// NOTE: BRICK SQUUUUUAD.
 	BIP0043Purpose = 1017
+
+	// MaxKeyRangeScan is the maximum number of keys that we'll attempt to
+	// scan with if a caller knows the public key, but not the key locator
+	// of a key.
+	MaxKeyRangeScan = 100000
+)
+
+var (
+	// ErrCannotDerivePrivKey is returned when DerivePrivKey is unable to
+	// derive a private key given only the public key and key family.
+	ErrCannotDerivePrivKey = fmt.Errorf("unable to derive private key")
 )
 
 // KeyFamily represents a "family" of keys that will be used within various
@@ -67,6 +82,13 @@ const (
 	// in order to establish a transport session with us on the Lightning
 	// p2p level (BOLT-0008).
 	KeyFamilyNodeKey KeyFamily = 6
+
+	// KeyFamilyStaticBackup is the family of keys that will be used to
+	// derive keys that we use to encrypt and decrypt our set of static
+	// backups. These backups may either be stored within watch towers for
+	// a payment, or self stored on disk in a single file containing all
+	// the static channel backups.
+	KeyFamilyStaticBackup KeyFamily = 7
 )
 
 // KeyLocator is a two-tuple that can be used to derive *any* key that has ever
@@ -141,7 +163,9 @@ type SecretKeyRing interface {
 	KeyRing
 
 	// DerivePrivKey attempts to derive the private key that corresponds to
-	// the passed key descriptor.
+	// the passed key descriptor. If the public key is set, but the index
+	// of the key locator is zero, then the key family will be scanned for
+	// the private key matching the public key.
 	DerivePrivKey(keyDesc KeyDescriptor) (*btcec.PrivateKey, error)
 
 	// ScalarMult performs a scalar multiplication (ECDH-like operation)
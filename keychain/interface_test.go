@@ -28,6 +28,7 @@ var versionZeroKeyFamilies = []KeyFamily{
 	KeyFamilyDelayBase,
 	KeyFamilyRevocationRoot,
 	KeyFamilyNodeKey,
+	KeyFamilyStaticBackup,
 }
 
 var (
@@ -358,6 +359,32 @@ func TestSecretKeyRingDerivation(t *testing.T) {
 
 				// TODO(roasbeef): scalar mult once integrated
 			}
+
+			// We'll also ensure that we're able to derive a
+			// private key when only knowing its public key and
+			// family, by scanning the family.
+			keyDesc, err := secretKeyRing.DeriveKey(KeyLocator{
+				Family: KeyFamilyRevocationRoot,
+				Index:  3,
+			})
+			if err != nil {
+				t.Fatalf("unable to derive pubkey: %v", err)
+			}
+			privKey, err := secretKeyRing.DerivePrivKey(KeyDescriptor{
+				KeyLocator: KeyLocator{
+					Family: KeyFamilyRevocationRoot,
+				},
+				PubKey: keyDesc.PubKey,
+			})
+			if err != nil {
+				t.Fatalf("unable to derive priv key by scanning: %v",
+					err)
+			}
+			if !keyDesc.PubKey.IsEqual(privKey.PubKey()) {
+				t.Fatalf("pubkeys mismatched: expected %x, got %x",
+					keyDesc.PubKey.SerializeCompressed(),
+					privKey.PubKey().SerializeCompressed())
+			}
 		})
 		if !success {
 			break
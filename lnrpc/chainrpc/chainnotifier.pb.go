@@ -0,0 +1,834 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: chainnotifier.proto
+
+/*
+Package chainrpc is a generated protocol buffer package.
+
+It is generated from these files:
+	chainnotifier.proto
+
+It has these top-level messages:
+	ConfRequest
+	ConfDetails
+	Reorg
+	ConfEvent
+	Outpoint
+	SpendRequest
+	SpendDetails
+	SpendEvent
+	BlockEpoch
+*/
+package chainrpc
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ConfRequest struct {
+	// / The hash of the transaction to watch. If empty, the first transaction
+	// / paying to the script is watched instead.
+	Txid []byte `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// / An output script of the transaction, used by light clients to match
+	// / the transaction.
+	Script []byte `protobuf:"bytes,2,opt,name=script,proto3" json:"script,omitempty"`
+	// / The number of confirmations the transaction should reach.
+	NumConfs uint32 `protobuf:"varint,3,opt,name=num_confs,json=numConfs" json:"num_confs,omitempty"`
+	// / The earliest height the transaction could have been included at.
+	HeightHint uint32 `protobuf:"varint,4,opt,name=height_hint,json=heightHint" json:"height_hint,omitempty"`
+}
+
+func (m *ConfRequest) Reset()                    { *m = ConfRequest{} }
+func (m *ConfRequest) String() string            { return proto.CompactTextString(m) }
+func (*ConfRequest) ProtoMessage()               {}
+func (*ConfRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ConfRequest) GetTxid() []byte {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+func (m *ConfRequest) GetScript() []byte {
+	if m != nil {
+		return m.Script
+	}
+	return nil
+}
+
+func (m *ConfRequest) GetNumConfs() uint32 {
+	if m != nil {
+		return m.NumConfs
+	}
+	return 0
+}
+
+func (m *ConfRequest) GetHeightHint() uint32 {
+	if m != nil {
+		return m.HeightHint
+	}
+	return 0
+}
+
+type ConfDetails struct {
+	// / The hash of the block the transaction was confirmed in.
+	BlockHash []byte `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	// / The height of the block the transaction was confirmed in.
+	BlockHeight uint32 `protobuf:"varint,2,opt,name=block_height,json=blockHeight" json:"block_height,omitempty"`
+	// / The index of the transaction within the block.
+	TxIndex uint32 `protobuf:"varint,3,opt,name=tx_index,json=txIndex" json:"tx_index,omitempty"`
+	// / The serialized transaction. Only set if the request was keyed on the
+	// / script, as the transaction wasn't known in advance.
+	RawTx []byte `protobuf:"bytes,4,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+}
+
+func (m *ConfDetails) Reset()                    { *m = ConfDetails{} }
+func (m *ConfDetails) String() string            { return proto.CompactTextString(m) }
+func (*ConfDetails) ProtoMessage()               {}
+func (*ConfDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *ConfDetails) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *ConfDetails) GetBlockHeight() uint32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ConfDetails) GetTxIndex() uint32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *ConfDetails) GetRawTx() []byte {
+	if m != nil {
+		return m.RawTx
+	}
+	return nil
+}
+
+type Reorg struct {
+	// / The number of blocks that were disconnected. Only set for reorgs of
+	// / confirmations.
+	Depth int32 `protobuf:"varint,1,opt,name=depth" json:"depth,omitempty"`
+}
+
+func (m *Reorg) Reset()                    { *m = Reorg{} }
+func (m *Reorg) String() string            { return proto.CompactTextString(m) }
+func (*Reorg) ProtoMessage()               {}
+func (*Reorg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Reorg) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+type ConfEvent struct {
+	// Types that are valid to be assigned to Event:
+	//	*ConfEvent_Conf
+	//	*ConfEvent_Reorg
+	Event isConfEvent_Event `protobuf_oneof:"event"`
+}
+
+func (m *ConfEvent) Reset()                    { *m = ConfEvent{} }
+func (m *ConfEvent) String() string            { return proto.CompactTextString(m) }
+func (*ConfEvent) ProtoMessage()               {}
+func (*ConfEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type isConfEvent_Event interface{ isConfEvent_Event() }
+
+type ConfEvent_Conf struct {
+	Conf *ConfDetails `protobuf:"bytes,1,opt,name=conf,oneof"`
+}
+type ConfEvent_Reorg struct {
+	Reorg *Reorg `protobuf:"bytes,2,opt,name=reorg,oneof"`
+}
+
+func (*ConfEvent_Conf) isConfEvent_Event()  {}
+func (*ConfEvent_Reorg) isConfEvent_Event() {}
+
+func (m *ConfEvent) GetEvent() isConfEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *ConfEvent) GetConf() *ConfDetails {
+	if x, ok := m.GetEvent().(*ConfEvent_Conf); ok {
+		return x.Conf
+	}
+	return nil
+}
+
+func (m *ConfEvent) GetReorg() *Reorg {
+	if x, ok := m.GetEvent().(*ConfEvent_Reorg); ok {
+		return x.Reorg
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ConfEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ConfEvent_OneofMarshaler, _ConfEvent_OneofUnmarshaler, _ConfEvent_OneofSizer, []interface{}{
+		(*ConfEvent_Conf)(nil),
+		(*ConfEvent_Reorg)(nil),
+	}
+}
+
+func _ConfEvent_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ConfEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *ConfEvent_Conf:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Conf); err != nil {
+			return err
+		}
+	case *ConfEvent_Reorg:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Reorg); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ConfEvent.Event has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ConfEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ConfEvent)
+	switch tag {
+	case 1: // event.conf
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfDetails)
+		err := b.DecodeMessage(msg)
+		m.Event = &ConfEvent_Conf{msg}
+		return true, err
+	case 2: // event.reorg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Reorg)
+		err := b.DecodeMessage(msg)
+		m.Event = &ConfEvent_Reorg{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ConfEvent_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ConfEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *ConfEvent_Conf:
+		s := proto.Size(x.Conf)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ConfEvent_Reorg:
+		s := proto.Size(x.Reorg)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type Outpoint struct {
+	// / The hash of the transaction the output belongs to.
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// / The index of the output.
+	Index uint32 `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *Outpoint) Reset()                    { *m = Outpoint{} }
+func (m *Outpoint) String() string            { return proto.CompactTextString(m) }
+func (*Outpoint) ProtoMessage()               {}
+func (*Outpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Outpoint) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Outpoint) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type SpendRequest struct {
+	// / The outpoint to watch. If not set, the first spend of any output
+	// / paying to the script is watched instead.
+	Outpoint *Outpoint `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The output script of the outpoint, used by light clients to match
+	// / the spend.
+	Script []byte `protobuf:"bytes,2,opt,name=script,proto3" json:"script,omitempty"`
+	// / The earliest height the outpoint could have been created at.
+	HeightHint uint32 `protobuf:"varint,3,opt,name=height_hint,json=heightHint" json:"height_hint,omitempty"`
+}
+
+func (m *SpendRequest) Reset()                    { *m = SpendRequest{} }
+func (m *SpendRequest) String() string            { return proto.CompactTextString(m) }
+func (*SpendRequest) ProtoMessage()               {}
+func (*SpendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *SpendRequest) GetOutpoint() *Outpoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *SpendRequest) GetScript() []byte {
+	if m != nil {
+		return m.Script
+	}
+	return nil
+}
+
+func (m *SpendRequest) GetHeightHint() uint32 {
+	if m != nil {
+		return m.HeightHint
+	}
+	return 0
+}
+
+type SpendDetails struct {
+	// / The outpoint that was spent.
+	SpendingOutpoint *Outpoint `protobuf:"bytes,1,opt,name=spending_outpoint,json=spendingOutpoint" json:"spending_outpoint,omitempty"`
+	// / The serialized spending transaction.
+	RawSpendingTx []byte `protobuf:"bytes,2,opt,name=raw_spending_tx,json=rawSpendingTx,proto3" json:"raw_spending_tx,omitempty"`
+	// / The hash of the spending transaction.
+	SpendingTxHash []byte `protobuf:"bytes,3,opt,name=spending_tx_hash,json=spendingTxHash,proto3" json:"spending_tx_hash,omitempty"`
+	// / The index of the input spending the outpoint.
+	SpendingInputIndex uint32 `protobuf:"varint,4,opt,name=spending_input_index,json=spendingInputIndex" json:"spending_input_index,omitempty"`
+	// / The height the spending transaction was confirmed at.
+	SpendingHeight uint32 `protobuf:"varint,5,opt,name=spending_height,json=spendingHeight" json:"spending_height,omitempty"`
+}
+
+func (m *SpendDetails) Reset()                    { *m = SpendDetails{} }
+func (m *SpendDetails) String() string            { return proto.CompactTextString(m) }
+func (*SpendDetails) ProtoMessage()               {}
+func (*SpendDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *SpendDetails) GetSpendingOutpoint() *Outpoint {
+	if m != nil {
+		return m.SpendingOutpoint
+	}
+	return nil
+}
+
+func (m *SpendDetails) GetRawSpendingTx() []byte {
+	if m != nil {
+		return m.RawSpendingTx
+	}
+	return nil
+}
+
+func (m *SpendDetails) GetSpendingTxHash() []byte {
+	if m != nil {
+		return m.SpendingTxHash
+	}
+	return nil
+}
+
+func (m *SpendDetails) GetSpendingInputIndex() uint32 {
+	if m != nil {
+		return m.SpendingInputIndex
+	}
+	return 0
+}
+
+func (m *SpendDetails) GetSpendingHeight() uint32 {
+	if m != nil {
+		return m.SpendingHeight
+	}
+	return 0
+}
+
+type SpendEvent struct {
+	// Types that are valid to be assigned to Event:
+	//	*SpendEvent_Spend
+	//	*SpendEvent_Reorg
+	Event isSpendEvent_Event `protobuf_oneof:"event"`
+}
+
+func (m *SpendEvent) Reset()                    { *m = SpendEvent{} }
+func (m *SpendEvent) String() string            { return proto.CompactTextString(m) }
+func (*SpendEvent) ProtoMessage()               {}
+func (*SpendEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type isSpendEvent_Event interface{ isSpendEvent_Event() }
+
+type SpendEvent_Spend struct {
+	Spend *SpendDetails `protobuf:"bytes,1,opt,name=spend,oneof"`
+}
+type SpendEvent_Reorg struct {
+	Reorg *Reorg `protobuf:"bytes,2,opt,name=reorg,oneof"`
+}
+
+func (*SpendEvent_Spend) isSpendEvent_Event() {}
+func (*SpendEvent_Reorg) isSpendEvent_Event() {}
+
+func (m *SpendEvent) GetEvent() isSpendEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *SpendEvent) GetSpend() *SpendDetails {
+	if x, ok := m.GetEvent().(*SpendEvent_Spend); ok {
+		return x.Spend
+	}
+	return nil
+}
+
+func (m *SpendEvent) GetReorg() *Reorg {
+	if x, ok := m.GetEvent().(*SpendEvent_Reorg); ok {
+		return x.Reorg
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*SpendEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _SpendEvent_OneofMarshaler, _SpendEvent_OneofUnmarshaler, _SpendEvent_OneofSizer, []interface{}{
+		(*SpendEvent_Spend)(nil),
+		(*SpendEvent_Reorg)(nil),
+	}
+}
+
+func _SpendEvent_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*SpendEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *SpendEvent_Spend:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Spend); err != nil {
+			return err
+		}
+	case *SpendEvent_Reorg:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Reorg); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("SpendEvent.Event has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _SpendEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*SpendEvent)
+	switch tag {
+	case 1: // event.spend
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SpendDetails)
+		err := b.DecodeMessage(msg)
+		m.Event = &SpendEvent_Spend{msg}
+		return true, err
+	case 2: // event.reorg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Reorg)
+		err := b.DecodeMessage(msg)
+		m.Event = &SpendEvent_Reorg{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _SpendEvent_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*SpendEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *SpendEvent_Spend:
+		s := proto.Size(x.Spend)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *SpendEvent_Reorg:
+		s := proto.Size(x.Reorg)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type BlockEpoch struct {
+	// / The hash of the block.
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// / The height of the block.
+	Height uint32 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *BlockEpoch) Reset()                    { *m = BlockEpoch{} }
+func (m *BlockEpoch) String() string            { return proto.CompactTextString(m) }
+func (*BlockEpoch) ProtoMessage()               {}
+func (*BlockEpoch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *BlockEpoch) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *BlockEpoch) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ConfRequest)(nil), "chainrpc.ConfRequest")
+	proto.RegisterType((*ConfDetails)(nil), "chainrpc.ConfDetails")
+	proto.RegisterType((*Reorg)(nil), "chainrpc.Reorg")
+	proto.RegisterType((*ConfEvent)(nil), "chainrpc.ConfEvent")
+	proto.RegisterType((*Outpoint)(nil), "chainrpc.Outpoint")
+	proto.RegisterType((*SpendRequest)(nil), "chainrpc.SpendRequest")
+	proto.RegisterType((*SpendDetails)(nil), "chainrpc.SpendDetails")
+	proto.RegisterType((*SpendEvent)(nil), "chainrpc.SpendEvent")
+	proto.RegisterType((*BlockEpoch)(nil), "chainrpc.BlockEpoch")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for ChainNotifier service
+
+type ChainNotifierClient interface {
+	// *
+	// RegisterConfirmationsNtfn notifies the client once the transaction with
+	// the given txid and output script reaches the requested number of
+	// confirmations. The stream stays open afterwards, so that the client is
+	// notified if the transaction is reorged out of the chain, as well as of
+	// its confirmation within the new chain.
+	RegisterConfirmationsNtfn(ctx context.Context, in *ConfRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterConfirmationsNtfnClient, error)
+	// *
+	// RegisterSpendNtfn notifies the client once the given outpoint with the
+	// given output script is spent by a confirmed transaction. The stream is
+	// closed after the spend has been sent.
+	RegisterSpendNtfn(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterSpendNtfnClient, error)
+	// *
+	// RegisterBlockEpochNtfn notifies the client of each block connected to the
+	// tip of the main chain. If the client passes its best known block, it is
+	// first sent all blocks it missed, starting at the common ancestor of its
+	// best block and the main chain if its block was reorged out.
+	RegisterBlockEpochNtfn(ctx context.Context, in *BlockEpoch, opts ...grpc.CallOption) (ChainNotifier_RegisterBlockEpochNtfnClient, error)
+}
+
+type chainNotifierClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChainNotifierClient(cc *grpc.ClientConn) ChainNotifierClient {
+	return &chainNotifierClient{cc}
+}
+
+func (c *chainNotifierClient) RegisterConfirmationsNtfn(ctx context.Context, in *ConfRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterConfirmationsNtfnClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ChainNotifier_serviceDesc.Streams[0], c.cc, "/chainrpc.ChainNotifier/RegisterConfirmationsNtfn", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chainNotifierRegisterConfirmationsNtfnClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ChainNotifier_RegisterConfirmationsNtfnClient interface {
+	Recv() (*ConfEvent, error)
+	grpc.ClientStream
+}
+
+type chainNotifierRegisterConfirmationsNtfnClient struct {
+	grpc.ClientStream
+}
+
+func (x *chainNotifierRegisterConfirmationsNtfnClient) Recv() (*ConfEvent, error) {
+	m := new(ConfEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *chainNotifierClient) RegisterSpendNtfn(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterSpendNtfnClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ChainNotifier_serviceDesc.Streams[1], c.cc, "/chainrpc.ChainNotifier/RegisterSpendNtfn", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chainNotifierRegisterSpendNtfnClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ChainNotifier_RegisterSpendNtfnClient interface {
+	Recv() (*SpendEvent, error)
+	grpc.ClientStream
+}
+
+type chainNotifierRegisterSpendNtfnClient struct {
+	grpc.ClientStream
+}
+
+func (x *chainNotifierRegisterSpendNtfnClient) Recv() (*SpendEvent, error) {
+	m := new(SpendEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *chainNotifierClient) RegisterBlockEpochNtfn(ctx context.Context, in *BlockEpoch, opts ...grpc.CallOption) (ChainNotifier_RegisterBlockEpochNtfnClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ChainNotifier_serviceDesc.Streams[2], c.cc, "/chainrpc.ChainNotifier/RegisterBlockEpochNtfn", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chainNotifierRegisterBlockEpochNtfnClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ChainNotifier_RegisterBlockEpochNtfnClient interface {
+	Recv() (*BlockEpoch, error)
+	grpc.ClientStream
+}
+
+type chainNotifierRegisterBlockEpochNtfnClient struct {
+	grpc.ClientStream
+}
+
+func (x *chainNotifierRegisterBlockEpochNtfnClient) Recv() (*BlockEpoch, error) {
+	m := new(BlockEpoch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for ChainNotifier service
+
+type ChainNotifierServer interface {
+	// *
+	// RegisterConfirmationsNtfn notifies the client once the transaction with
+	// the given txid and output script reaches the requested number of
+	// confirmations. The stream stays open afterwards, so that the client is
+	// notified if the transaction is reorged out of the chain, as well as of
+	// its confirmation within the new chain.
+	RegisterConfirmationsNtfn(*ConfRequest, ChainNotifier_RegisterConfirmationsNtfnServer) error
+	// *
+	// RegisterSpendNtfn notifies the client once the given outpoint with the
+	// given output script is spent by a confirmed transaction. The stream is
+	// closed after the spend has been sent.
+	RegisterSpendNtfn(*SpendRequest, ChainNotifier_RegisterSpendNtfnServer) error
+	// *
+	// RegisterBlockEpochNtfn notifies the client of each block connected to the
+	// tip of the main chain. If the client passes its best known block, it is
+	// first sent all blocks it missed, starting at the common ancestor of its
+	// best block and the main chain if its block was reorged out.
+	RegisterBlockEpochNtfn(*BlockEpoch, ChainNotifier_RegisterBlockEpochNtfnServer) error
+}
+
+func RegisterChainNotifierServer(s *grpc.Server, srv ChainNotifierServer) {
+	s.RegisterService(&_ChainNotifier_serviceDesc, srv)
+}
+
+func _ChainNotifier_RegisterConfirmationsNtfn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConfRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainNotifierServer).RegisterConfirmationsNtfn(m, &chainNotifierRegisterConfirmationsNtfnServer{stream})
+}
+
+type ChainNotifier_RegisterConfirmationsNtfnServer interface {
+	Send(*ConfEvent) error
+	grpc.ServerStream
+}
+
+type chainNotifierRegisterConfirmationsNtfnServer struct {
+	grpc.ServerStream
+}
+
+func (x *chainNotifierRegisterConfirmationsNtfnServer) Send(m *ConfEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ChainNotifier_RegisterSpendNtfn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SpendRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainNotifierServer).RegisterSpendNtfn(m, &chainNotifierRegisterSpendNtfnServer{stream})
+}
+
+type ChainNotifier_RegisterSpendNtfnServer interface {
+	Send(*SpendEvent) error
+	grpc.ServerStream
+}
+
+type chainNotifierRegisterSpendNtfnServer struct {
+	grpc.ServerStream
+}
+
+func (x *chainNotifierRegisterSpendNtfnServer) Send(m *SpendEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ChainNotifier_RegisterBlockEpochNtfn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockEpoch)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainNotifierServer).RegisterBlockEpochNtfn(m, &chainNotifierRegisterBlockEpochNtfnServer{stream})
+}
+
+type ChainNotifier_RegisterBlockEpochNtfnServer interface {
+	Send(*BlockEpoch) error
+	grpc.ServerStream
+}
+
+type chainNotifierRegisterBlockEpochNtfnServer struct {
+	grpc.ServerStream
+}
+
+func (x *chainNotifierRegisterBlockEpochNtfnServer) Send(m *BlockEpoch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ChainNotifier_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "chainrpc.ChainNotifier",
+	HandlerType: (*ChainNotifierServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RegisterConfirmationsNtfn",
+			Handler:       _ChainNotifier_RegisterConfirmationsNtfn_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RegisterSpendNtfn",
+			Handler:       _ChainNotifier_RegisterSpendNtfn_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RegisterBlockEpochNtfn",
+			Handler:       _ChainNotifier_RegisterBlockEpochNtfn_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "chainnotifier.proto",
+}
+
+func init() { proto.RegisterFile("chainnotifier.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 577 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xc1, 0x6e, 0xda, 0x40,
+	0x10, 0x8d, 0x1b, 0x4c, 0x60, 0x80, 0x92, 0x6c, 0x08, 0x22, 0xa9, 0xa2, 0xa6, 0x3e, 0x34, 0x48,
+	0x95, 0x10, 0xa2, 0x3d, 0xf4, 0x56, 0x29, 0x34, 0x15, 0x5c, 0x52, 0xc9, 0xc9, 0xdd, 0x72, 0xcc,
+	0x82, 0xb7, 0x0d, 0xbb, 0xee, 0xee, 0x52, 0x7c, 0xed, 0xd7, 0xf6, 0x27, 0x7a, 0xa8, 0x76, 0x76,
+	0x6d, 0x08, 0x4d, 0xa5, 0xaa, 0x37, 0x66, 0xe6, 0xf9, 0xcd, 0x7b, 0x9e, 0x87, 0xe1, 0x38, 0x49,
+	0x63, 0xc6, 0xb9, 0xd0, 0x6c, 0xce, 0xa8, 0x1c, 0x64, 0x52, 0x68, 0x41, 0x6a, 0xd8, 0x94, 0x59,
+	0x12, 0xac, 0xa1, 0x31, 0x16, 0x7c, 0x1e, 0xd2, 0x6f, 0x2b, 0xaa, 0x34, 0x21, 0x50, 0xd1, 0x39,
+	0x9b, 0xf5, 0xbc, 0x0b, 0xaf, 0xdf, 0x0c, 0xf1, 0x37, 0xe9, 0x42, 0x55, 0x25, 0x92, 0x65, 0xba,
+	0xf7, 0x0c, 0xbb, 0xae, 0x22, 0x2f, 0xa0, 0xce, 0x57, 0xcb, 0x28, 0x11, 0x7c, 0xae, 0x7a, 0xfb,
+	0x17, 0x5e, 0xbf, 0x15, 0xd6, 0xf8, 0x6a, 0x69, 0xe8, 0x14, 0x79, 0x09, 0x8d, 0x94, 0xb2, 0x45,
+	0xaa, 0xa3, 0x94, 0x71, 0xdd, 0xab, 0xe0, 0x18, 0x6c, 0x6b, 0xc2, 0xb8, 0x0e, 0x7e, 0x78, 0x76,
+	0xf3, 0x47, 0xaa, 0x63, 0xf6, 0xa0, 0xc8, 0x39, 0xc0, 0xfd, 0x83, 0x48, 0xbe, 0x46, 0x69, 0xac,
+	0x52, 0xb7, 0xbf, 0x8e, 0x9d, 0x49, 0xac, 0x52, 0xf2, 0x0a, 0x9a, 0x6e, 0x8c, 0x14, 0x28, 0xa5,
+	0x15, 0x36, 0x2c, 0x00, 0x5b, 0xe4, 0x14, 0x6a, 0x3a, 0x8f, 0x18, 0x9f, 0xd1, 0xdc, 0xc9, 0x39,
+	0xd0, 0xf9, 0xd4, 0x94, 0xe4, 0x04, 0xaa, 0x32, 0x5e, 0x47, 0x3a, 0x47, 0x21, 0xcd, 0xd0, 0x97,
+	0xf1, 0xfa, 0x2e, 0x0f, 0xce, 0xc1, 0x0f, 0xa9, 0x90, 0x0b, 0xd2, 0x01, 0x7f, 0x46, 0x33, 0x6d,
+	0xf7, 0xfa, 0xa1, 0x2d, 0x82, 0x2f, 0x50, 0x37, 0x0a, 0xaf, 0xbf, 0x53, 0xae, 0xc9, 0x1b, 0xa8,
+	0x18, 0xa7, 0x88, 0x68, 0x8c, 0x4e, 0x06, 0xc5, 0x1b, 0x1c, 0x6c, 0x99, 0x98, 0xec, 0x85, 0x08,
+	0x22, 0x97, 0xe0, 0x4b, 0x43, 0x8c, 0x32, 0x1b, 0xa3, 0xf6, 0x06, 0x8d, 0xfb, 0x26, 0x7b, 0xa1,
+	0x9d, 0x5f, 0x1d, 0x80, 0x4f, 0x0d, 0x7d, 0xf0, 0x0e, 0x6a, 0x9f, 0x57, 0x3a, 0x13, 0x8c, 0xe3,
+	0x11, 0xb6, 0x5e, 0x02, 0xfe, 0x36, 0x0a, 0xad, 0x33, 0x6b, 0xdc, 0x16, 0xc1, 0x1a, 0x9a, 0xb7,
+	0x19, 0xe5, 0xb3, 0xe2, 0x7c, 0x03, 0xa8, 0x09, 0xc7, 0xe2, 0x84, 0x92, 0xcd, 0xea, 0x82, 0x3f,
+	0x2c, 0x31, 0x7f, 0x3d, 0xed, 0xce, 0xf5, 0xf6, 0xff, 0xb8, 0xde, 0x2f, 0xcf, 0x6d, 0x2e, 0xce,
+	0xf7, 0x01, 0x8e, 0x94, 0xa9, 0x19, 0x5f, 0x44, 0xff, 0x20, 0xe1, 0xb0, 0x00, 0x97, 0xa6, 0x5f,
+	0x43, 0xdb, 0x9c, 0xa8, 0x24, 0xd1, 0xb9, 0xd3, 0xd4, 0x92, 0xf1, 0xfa, 0xd6, 0x75, 0xef, 0x72,
+	0xd2, 0x87, 0xc3, 0x2d, 0x8c, 0x4d, 0xcb, 0x3e, 0x02, 0x9f, 0xab, 0x12, 0x85, 0x91, 0x19, 0x42,
+	0xa7, 0x44, 0x32, 0x9e, 0xad, 0xb4, 0xcb, 0x86, 0xcd, 0x22, 0x29, 0x66, 0x53, 0x33, 0xb2, 0x31,
+	0xb9, 0x84, 0x76, 0xf9, 0x84, 0xcb, 0x99, 0x8f, 0xe0, 0x92, 0xda, 0x46, 0x2d, 0xe0, 0x00, 0x28,
+	0xc9, 0x46, 0x63, 0x00, 0x3e, 0xce, 0x9d, 0xdf, 0xee, 0xc6, 0xef, 0xf6, 0x2b, 0x32, 0x47, 0x47,
+	0xd8, 0x7f, 0xa4, 0xe3, 0x3d, 0xc0, 0x95, 0x49, 0xfa, 0x75, 0x26, 0x92, 0xf4, 0xc9, 0x7c, 0x74,
+	0xa1, 0xfa, 0xe8, 0x9f, 0xe1, 0xaa, 0xd1, 0x4f, 0x0f, 0x5a, 0x63, 0x43, 0x7f, 0xe3, 0xbe, 0x00,
+	0x64, 0x0a, 0xa7, 0x21, 0x5d, 0x30, 0xa5, 0xa9, 0x34, 0xd1, 0x65, 0x72, 0x19, 0x6b, 0x26, 0xb8,
+	0xba, 0xd1, 0x73, 0x4e, 0x76, 0x72, 0xed, 0x72, 0x75, 0x76, 0xfc, 0xb8, 0x8d, 0xb6, 0x87, 0x1e,
+	0x19, 0xc3, 0x51, 0x41, 0x85, 0x4e, 0x91, 0x62, 0xd7, 0x7e, 0xc1, 0xd1, 0xd9, 0xe9, 0x17, 0x24,
+	0x9f, 0xa0, 0x5b, 0x90, 0x6c, 0x3c, 0x22, 0xd3, 0xd6, 0x13, 0x9b, 0xc9, 0xd9, 0x93, 0xdd, 0xa1,
+	0x77, 0x5f, 0xc5, 0x4f, 0xdb, 0xdb, 0xdf, 0x03, 0x00, 0x72, 0x6e, 0x51, 0xb2, 0xf1, 0x04, 0x00,
+	0x00,
+}
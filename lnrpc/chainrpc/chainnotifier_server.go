@@ -0,0 +1,311 @@
+package chainrpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"golang.org/x/net/context"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+var (
+	// macPermissions maps RPC calls to the permissions they require.
+	macPermissions = map[string][]bakery.Op{
+		"/chainrpc.ChainNotifier/RegisterConfirmationsNtfn": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/chainrpc.ChainNotifier/RegisterSpendNtfn": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/chainrpc.ChainNotifier/RegisterBlockEpochNtfn": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+	}
+
+	// ErrChainNotifierShuttingDown is returned when a notification can't
+	// be delivered because the chain notifier is shutting down.
+	ErrChainNotifierShuttingDown = errors.New("chain notifier shutting " +
+		"down")
+)
+
+// Config holds the dependencies of the ChainNotifier service.
+type Config struct {
+	// ChainNotifier is the chain notifier whose notifications are
+	// streamed to clients.
+	ChainNotifier chainntnfs.ChainNotifier
+
+	// MacService is used to validate the macaroon of each request. If
+	// nil, macaroons are disabled and requests aren't authenticated.
+	MacService *macaroons.Service
+}
+
+// Server is the gRPC ChainNotifier service.
+type Server struct {
+	cfg *Config
+}
+
+// A compile time check to ensure Server fully implements the
+// ChainNotifierServer gRPC service.
+var _ ChainNotifierServer = (*Server)(nil)
+
+// New creates a new ChainNotifier service backed by the passed config.
+func New(cfg *Config) *Server {
+	return &Server{cfg: cfg}
+}
+
+// checkMacaroon validates the macaroon of a request against the permissions
+// of the passed RPC method. The check is skipped if macaroons are disabled.
+func (s *Server) checkMacaroon(ctx context.Context, method string) error {
+	if s.cfg.MacService == nil {
+		return nil
+	}
+
+	ops, ok := macPermissions[method]
+	if !ok {
+		return fmt.Errorf("%s: unknown permissions required for "+
+			"method", method)
+	}
+
+	return s.cfg.MacService.ValidateMacaroon(ctx, ops)
+}
+
+// RegisterConfirmationsNtfn streams the confirmation of the requested
+// transaction to the client, as well as any reorg of it, until the client
+// disconnects. If no txid is given, the first transaction paying to the
+// requested script is watched instead, and sent along with its confirmation.
+// The registration is canceled once the client disconnects.
+func (s *Server) RegisterConfirmationsNtfn(in *ConfRequest,
+	confStream ChainNotifier_RegisterConfirmationsNtfnServer) error {
+
+	method := "/chainrpc.ChainNotifier/RegisterConfirmationsNtfn"
+	if err := s.checkMacaroon(confStream.Context(), method); err != nil {
+		return err
+	}
+
+	var txid *chainhash.Hash
+	if len(in.Txid) != 0 {
+		var err error
+		txid, err = chainhash.NewHash(in.Txid)
+		if err != nil {
+			return err
+		}
+	} else if len(in.Script) == 0 {
+		return fmt.Errorf("either txid or script must be set")
+	}
+	if in.NumConfs == 0 {
+		return fmt.Errorf("number of confirmations must be positive")
+	}
+
+	confEvent, err := s.cfg.ChainNotifier.RegisterConfirmationsNtfn(
+		txid, in.Script, in.NumConfs, in.HeightHint,
+	)
+	if err != nil {
+		return err
+	}
+	defer confEvent.Cancel()
+
+	for {
+		select {
+		case conf, ok := <-confEvent.Confirmed:
+			if !ok {
+				return ErrChainNotifierShuttingDown
+			}
+
+			details := &ConfDetails{
+				BlockHash:   conf.BlockHash[:],
+				BlockHeight: conf.BlockHeight,
+				TxIndex:     conf.TxIndex,
+			}
+			if conf.Tx != nil {
+				var rawTx bytes.Buffer
+				if err := conf.Tx.Serialize(&rawTx); err != nil {
+					return err
+				}
+				details.RawTx = rawTx.Bytes()
+			}
+
+			err := confStream.Send(&ConfEvent{
+				Event: &ConfEvent_Conf{
+					Conf: details,
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+		case depth, ok := <-confEvent.NegativeConf:
+			if !ok {
+				return ErrChainNotifierShuttingDown
+			}
+
+			err := confStream.Send(&ConfEvent{
+				Event: &ConfEvent_Reorg{
+					Reorg: &Reorg{Depth: depth},
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+		case <-confStream.Context().Done():
+			return confStream.Context().Err()
+		}
+	}
+}
+
+// RegisterSpendNtfn streams the spend of the requested outpoint to the client,
+// as well as any reorg of it, until the client disconnects. If no outpoint is
+// given, the first spend of any output paying to the requested script is
+// streamed instead. After a reorg, the spend in the new chain is streamed.
+func (s *Server) RegisterSpendNtfn(in *SpendRequest,
+	spendStream ChainNotifier_RegisterSpendNtfnServer) error {
+
+	method := "/chainrpc.ChainNotifier/RegisterSpendNtfn"
+	if err := s.checkMacaroon(spendStream.Context(), method); err != nil {
+		return err
+	}
+
+	var outpoint *wire.OutPoint
+	if in.Outpoint != nil {
+		hash, err := chainhash.NewHash(in.Outpoint.Hash)
+		if err != nil {
+			return err
+		}
+		outpoint = &wire.OutPoint{Hash: *hash, Index: in.Outpoint.Index}
+	} else if len(in.Script) == 0 {
+		return fmt.Errorf("either outpoint or script must be set")
+	}
+
+	// A spend notification ends with a reorg of the spend, so we'll
+	// register a new one each time to stream the spend in the new chain.
+	for {
+		spendEvent, err := s.cfg.ChainNotifier.RegisterSpendNtfn(
+			outpoint, in.Script, in.HeightHint,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = streamSpend(spendEvent, spendStream)
+		spendEvent.Cancel()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// streamSpend streams the spend of a single spend notification to the client,
+// and returns once the spend is reorged out of the chain. An error is
+// returned if the client disconnects.
+func streamSpend(spendEvent *chainntnfs.SpendEvent,
+	spendStream ChainNotifier_RegisterSpendNtfnServer) error {
+
+	spendChan := spendEvent.Spend
+	for {
+		select {
+		case spend, ok := <-spendChan:
+			if !ok {
+				return ErrChainNotifierShuttingDown
+			}
+
+			var rawTx bytes.Buffer
+			if err := spend.SpendingTx.Serialize(&rawTx); err != nil {
+				return err
+			}
+
+			err := spendStream.Send(&SpendEvent{
+				Event: &SpendEvent_Spend{
+					Spend: &SpendDetails{
+						SpendingOutpoint: &Outpoint{
+							Hash:  spend.SpentOutPoint.Hash[:],
+							Index: spend.SpentOutPoint.Index,
+						},
+						RawSpendingTx:      rawTx.Bytes(),
+						SpendingTxHash:     spend.SpenderTxHash[:],
+						SpendingInputIndex: spend.SpenderInputIndex,
+						SpendingHeight:     uint32(spend.SpendingHeight),
+					},
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			// The notifier closes the channel once the spend is
+			// dispatched, so we'll only wait for a reorg from now
+			// on.
+			spendChan = nil
+
+		case <-spendEvent.Reorg:
+			return spendStream.Send(&SpendEvent{
+				Event: &SpendEvent_Reorg{
+					Reorg: &Reorg{},
+				},
+			})
+
+		case <-spendStream.Context().Done():
+			return spendStream.Context().Err()
+		}
+	}
+}
+
+// RegisterBlockEpochNtfn streams each block connected to the tip of the main
+// chain to the client, until the client disconnects.
+func (s *Server) RegisterBlockEpochNtfn(in *BlockEpoch,
+	epochStream ChainNotifier_RegisterBlockEpochNtfnServer) error {
+
+	method := "/chainrpc.ChainNotifier/RegisterBlockEpochNtfn"
+	if err := s.checkMacaroon(epochStream.Context(), method); err != nil {
+		return err
+	}
+
+	// If the client passed its best known block, the notifier first sends
+	// the blocks it missed.
+	var bestBlock *chainntnfs.BlockEpoch
+	if len(in.Hash) != 0 {
+		hash, err := chainhash.NewHash(in.Hash)
+		if err != nil {
+			return err
+		}
+		bestBlock = &chainntnfs.BlockEpoch{
+			Hash:   hash,
+			Height: int32(in.Height),
+		}
+	}
+
+	epochEvent, err := s.cfg.ChainNotifier.RegisterBlockEpochNtfn(
+		bestBlock,
+	)
+	if err != nil {
+		return err
+	}
+	defer epochEvent.Cancel()
+
+	for {
+		select {
+		case epoch, ok := <-epochEvent.Epochs:
+			if !ok {
+				return ErrChainNotifierShuttingDown
+			}
+
+			err := epochStream.Send(&BlockEpoch{
+				Hash:   epoch.Hash[:],
+				Height: uint32(epoch.Height),
+			})
+			if err != nil {
+				return err
+			}
+
+		case <-epochStream.Context().Done():
+			return epochStream.Context().Err()
+		}
+	}
+}
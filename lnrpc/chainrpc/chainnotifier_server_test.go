@@ -0,0 +1,275 @@
+package chainrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// mockNotifier hands out the events it was created with.
+type mockNotifier struct {
+	chainntnfs.ChainNotifier
+
+	confEvent  *chainntnfs.ConfirmationEvent
+	spendChan  chan *chainntnfs.SpendDetail
+	reorgChan  chan struct{}
+	epochChan  chan *chainntnfs.BlockEpoch
+	bestBlocks chan *chainntnfs.BlockEpoch
+	canceled   chan struct{}
+}
+
+func newMockNotifier() *mockNotifier {
+	m := &mockNotifier{
+		spendChan:  make(chan *chainntnfs.SpendDetail, 1),
+		reorgChan:  make(chan struct{}, 1),
+		epochChan:  make(chan *chainntnfs.BlockEpoch, 1),
+		bestBlocks: make(chan *chainntnfs.BlockEpoch, 1),
+		canceled:   make(chan struct{}, 1),
+	}
+	m.confEvent = chainntnfs.NewConfirmationEvent(1, func() {
+		m.canceled <- struct{}{}
+	})
+
+	return m
+}
+
+func (m *mockNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	pkScript []byte, numConfs,
+	heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	return m.confEvent, nil
+}
+
+func (m *mockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
+	pkScript []byte, heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	return &chainntnfs.SpendEvent{
+		Spend: m.spendChan,
+		Reorg: m.reorgChan,
+		Cancel: func() {
+			m.canceled <- struct{}{}
+		},
+	}, nil
+}
+
+func (m *mockNotifier) RegisterBlockEpochNtfn(
+	bestBlock *chainntnfs.BlockEpoch) (*chainntnfs.BlockEpochEvent, error) {
+
+	m.bestBlocks <- bestBlock
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: m.epochChan,
+		Cancel: func() {
+			m.canceled <- struct{}{}
+		},
+	}, nil
+}
+
+// mockStream collects the events sent on a server stream.
+type mockStream struct {
+	grpc.ServerStream
+
+	ctx    context.Context
+	events chan interface{}
+}
+
+func (m *mockStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockStream) send(event interface{}) error {
+	m.events <- event
+	return nil
+}
+
+type confStream struct{ *mockStream }
+
+func (c confStream) Send(e *ConfEvent) error { return c.send(e) }
+
+type spendStream struct{ *mockStream }
+
+func (s spendStream) Send(e *SpendEvent) error { return s.send(e) }
+
+type epochStream struct{ *mockStream }
+
+func (e epochStream) Send(b *BlockEpoch) error { return e.send(b) }
+
+func newMockStream() (*mockStream, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &mockStream{
+		ctx:    ctx,
+		events: make(chan interface{}, 10),
+	}, cancel
+}
+
+// nextEvent returns the next event sent on the stream.
+func nextEvent(t *testing.T, stream *mockStream) interface{} {
+	select {
+	case event := <-stream.events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no event sent")
+		return nil
+	}
+}
+
+// waitCanceled waits for a registration with the notifier to be canceled.
+func waitCanceled(t *testing.T, notifier *mockNotifier) {
+	select {
+	case <-notifier.canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("registration not canceled")
+	}
+}
+
+// TestConfirmationsNtfnReorg asserts that confirmations and reorgs of a
+// transaction are streamed until the client disconnects.
+func TestConfirmationsNtfnReorg(t *testing.T) {
+	t.Parallel()
+
+	notifier := newMockNotifier()
+	server := New(&Config{ChainNotifier: notifier})
+
+	stream, cancel := newMockStream()
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.RegisterConfirmationsNtfn(&ConfRequest{
+			Txid:     make([]byte, chainhash.HashSize),
+			NumConfs: 1,
+		}, confStream{stream})
+	}()
+
+	notifier.confEvent.Confirmed <- &chainntnfs.TxConfirmation{
+		BlockHash:   &chainhash.Hash{1},
+		BlockHeight: 100,
+	}
+	event := nextEvent(t, stream).(*ConfEvent)
+	if event.GetConf() == nil || event.GetConf().BlockHeight != 100 {
+		t.Fatalf("expected confirmation, got %v", event)
+	}
+
+	// The transaction is reorged out and confirmed again in a different
+	// block.
+	notifier.confEvent.NegativeConf <- 2
+	event = nextEvent(t, stream).(*ConfEvent)
+	if event.GetReorg() == nil || event.GetReorg().Depth != 2 {
+		t.Fatalf("expected reorg, got %v", event)
+	}
+	notifier.confEvent.Confirmed <- &chainntnfs.TxConfirmation{
+		BlockHash:   &chainhash.Hash{2},
+		BlockHeight: 101,
+	}
+	event = nextEvent(t, stream).(*ConfEvent)
+	if event.GetConf() == nil || event.GetConf().BlockHeight != 101 {
+		t.Fatalf("expected confirmation, got %v", event)
+	}
+
+	// Once the client disconnects, the stream must return and the
+	// registration must be canceled.
+	cancel()
+	select {
+	case <-errChan:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("stream not closed after client disconnected")
+	}
+	waitCanceled(t, notifier)
+}
+
+// TestSpendAndEpochNtfnCancel asserts that spend and block notifications are
+// streamed along with reorgs of the spend, and that their registrations are
+// canceled once the client disconnects.
+func TestSpendAndEpochNtfnCancel(t *testing.T) {
+	t.Parallel()
+
+	notifier := newMockNotifier()
+	server := New(&Config{ChainNotifier: notifier})
+
+	// A client that disconnects before the spend must cancel the
+	// registration.
+	stream, cancel := newMockStream()
+	errChan := make(chan error, 1)
+	req := &SpendRequest{
+		Outpoint: &Outpoint{
+			Hash:  make([]byte, chainhash.HashSize),
+			Index: 1,
+		},
+	}
+	go func() {
+		errChan <- server.RegisterSpendNtfn(req, spendStream{stream})
+	}()
+	cancel()
+	waitCanceled(t, notifier)
+	<-errChan
+
+	// Otherwise the spend is sent.
+	stream, cancel = newMockStream()
+	go func() {
+		errChan <- server.RegisterSpendNtfn(req, spendStream{stream})
+	}()
+	spendingTx := wire.NewMsgTx(2)
+	spenderHash := spendingTx.TxHash()
+	notifier.spendChan <- &chainntnfs.SpendDetail{
+		SpentOutPoint:  &wire.OutPoint{Index: 1},
+		SpenderTxHash:  &spenderHash,
+		SpendingTx:     spendingTx,
+		SpendingHeight: 100,
+	}
+	spend := nextEvent(t, stream).(*SpendEvent).GetSpend()
+	if spend == nil || spend.SpendingHeight != 100 {
+		t.Fatalf("expected spend at height 100, got %v", spend)
+	}
+
+	// If the spending transaction is reorged out, the reorg is sent, and
+	// the spend in the new chain is sent once it happens.
+	notifier.reorgChan <- struct{}{}
+	event := nextEvent(t, stream).(*SpendEvent)
+	if event.GetReorg() == nil {
+		t.Fatalf("expected reorg, got %v", event)
+	}
+	waitCanceled(t, notifier)
+
+	notifier.spendChan <- &chainntnfs.SpendDetail{
+		SpentOutPoint:  &wire.OutPoint{Index: 1},
+		SpenderTxHash:  &spenderHash,
+		SpendingTx:     spendingTx,
+		SpendingHeight: 101,
+	}
+	spend = nextEvent(t, stream).(*SpendEvent).GetSpend()
+	if spend == nil || spend.SpendingHeight != 101 {
+		t.Fatalf("expected spend at height 101, got %v", spend)
+	}
+
+	cancel()
+	waitCanceled(t, notifier)
+	<-errChan
+
+	// The best block of the client is passed on to the notifier, so that
+	// it is sent the blocks it missed.
+	stream, cancel = newMockStream()
+	go func() {
+		errChan <- server.RegisterBlockEpochNtfn(&BlockEpoch{
+			Hash:   make([]byte, chainhash.HashSize),
+			Height: 99,
+		}, epochStream{stream})
+	}()
+	bestBlock := <-notifier.bestBlocks
+	if bestBlock == nil || bestBlock.Height != 99 {
+		t.Fatalf("best block of client not passed to notifier")
+	}
+	notifier.epochChan <- &chainntnfs.BlockEpoch{
+		Hash:   &chainhash.Hash{1},
+		Height: 100,
+	}
+	epoch := nextEvent(t, stream).(*BlockEpoch)
+	if epoch.Height != 100 {
+		t.Fatalf("expected block 100, got %v", epoch.Height)
+	}
+
+	cancel()
+	waitCanceled(t, notifier)
+	<-errChan
+}
@@ -23,3 +23,18 @@ protoc -I/usr/local/include -I. \
        -I$GOPATH/src/github.com/grpc-ecosystem/grpc-gateway/third_party/googleapis \
        --swagger_out=logtostderr=true:. \
        rpc.proto
+
+# Generate the protos of the sub-services, which don't expose a REST API.
+for file in signrpc/signer.proto remotesignerrpc/remotesigner.proto \
+       chainrpc/chainnotifier.proto; do
+       protoc -I/usr/local/include -I$(dirname $file) \
+              -I$GOPATH/src \
+              --go_out=plugins=grpc:$(dirname $file) \
+              $(basename $file)
+done
+
+# The WalletKit service reuses the key messages of the Signer service.
+protoc -I/usr/local/include -Iwalletrpc -I. \
+       -I$GOPATH/src \
+       --go_out=plugins=grpc,Msignrpc/signer.proto=github.com/lightningnetwork/lnd/lnrpc/signrpc:walletrpc \
+       walletkit.proto
@@ -0,0 +1,1332 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: remotesigner.proto
+
+/*
+Package remotesignerrpc is a generated protocol buffer package.
+
+It is generated from these files:
+	remotesigner.proto
+
+It has these top-level messages:
+	KeyFamily
+	KeyLocator
+	KeyDescriptor
+	PrivKeyResponse
+	ScalarMultRequest
+	ScalarMultResponse
+	SignRequest
+	WalletOutput
+	SignResponse
+	SignMessageRequest
+	SignMessageResponse
+	RegisterChannelRequest
+	RegisterChannelResponse
+	CommitmentPointRequest
+	CommitmentPointResponse
+	RevokeCommitmentRequest
+	RevokeCommitmentResponse
+	KeyPath
+	InputScriptRequest
+	InputScriptResponse
+	ExportWatchOnlyWalletRequest
+	ExportWatchOnlyWalletResponse
+*/
+package remotesignerrpc
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type KeyFamily struct {
+	// / The key family (BIP43 account) to derive a key from.
+	KeyFamily int32 `protobuf:"varint,1,opt,name=key_family,json=keyFamily" json:"key_family,omitempty"`
+}
+
+func (m *KeyFamily) Reset()                    { *m = KeyFamily{} }
+func (m *KeyFamily) String() string            { return proto.CompactTextString(m) }
+func (*KeyFamily) ProtoMessage()               {}
+func (*KeyFamily) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *KeyFamily) GetKeyFamily() int32 {
+	if m != nil {
+		return m.KeyFamily
+	}
+	return 0
+}
+
+type KeyLocator struct {
+	// / The key family of the key.
+	KeyFamily int32 `protobuf:"varint,1,opt,name=key_family,json=keyFamily" json:"key_family,omitempty"`
+	// / The index of the key within its family.
+	KeyIndex int32 `protobuf:"varint,2,opt,name=key_index,json=keyIndex" json:"key_index,omitempty"`
+}
+
+func (m *KeyLocator) Reset()                    { *m = KeyLocator{} }
+func (m *KeyLocator) String() string            { return proto.CompactTextString(m) }
+func (*KeyLocator) ProtoMessage()               {}
+func (*KeyLocator) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *KeyLocator) GetKeyFamily() int32 {
+	if m != nil {
+		return m.KeyFamily
+	}
+	return 0
+}
+
+func (m *KeyLocator) GetKeyIndex() int32 {
+	if m != nil {
+		return m.KeyIndex
+	}
+	return 0
+}
+
+type KeyDescriptor struct {
+	// / The compressed public key. Either this or the key locator must be set.
+	RawKeyBytes []byte `protobuf:"bytes,1,opt,name=raw_key_bytes,json=rawKeyBytes,proto3" json:"raw_key_bytes,omitempty"`
+	// / The key locator of the key.
+	KeyLoc *KeyLocator `protobuf:"bytes,2,opt,name=key_loc,json=keyLoc" json:"key_loc,omitempty"`
+}
+
+func (m *KeyDescriptor) Reset()                    { *m = KeyDescriptor{} }
+func (m *KeyDescriptor) String() string            { return proto.CompactTextString(m) }
+func (*KeyDescriptor) ProtoMessage()               {}
+func (*KeyDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *KeyDescriptor) GetRawKeyBytes() []byte {
+	if m != nil {
+		return m.RawKeyBytes
+	}
+	return nil
+}
+
+func (m *KeyDescriptor) GetKeyLoc() *KeyLocator {
+	if m != nil {
+		return m.KeyLoc
+	}
+	return nil
+}
+
+type PrivKeyResponse struct {
+	// / The serialized private key.
+	PrivKey []byte `protobuf:"bytes,1,opt,name=priv_key,json=privKey,proto3" json:"priv_key,omitempty"`
+}
+
+func (m *PrivKeyResponse) Reset()                    { *m = PrivKeyResponse{} }
+func (m *PrivKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*PrivKeyResponse) ProtoMessage()               {}
+func (*PrivKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *PrivKeyResponse) GetPrivKey() []byte {
+	if m != nil {
+		return m.PrivKey
+	}
+	return nil
+}
+
+type ScalarMultRequest struct {
+	// / The key descriptor of our private key.
+	KeyDesc *KeyDescriptor `protobuf:"bytes,1,opt,name=key_desc,json=keyDesc" json:"key_desc,omitempty"`
+	// / The compressed public key of the other party.
+	PubKey []byte `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+}
+
+func (m *ScalarMultRequest) Reset()                    { *m = ScalarMultRequest{} }
+func (m *ScalarMultRequest) String() string            { return proto.CompactTextString(m) }
+func (*ScalarMultRequest) ProtoMessage()               {}
+func (*ScalarMultRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *ScalarMultRequest) GetKeyDesc() *KeyDescriptor {
+	if m != nil {
+		return m.KeyDesc
+	}
+	return nil
+}
+
+func (m *ScalarMultRequest) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+type ScalarMultResponse struct {
+	// / The sha256 of the compressed shared point.
+	SharedKey []byte `protobuf:"bytes,1,opt,name=shared_key,json=sharedKey,proto3" json:"shared_key,omitempty"`
+}
+
+func (m *ScalarMultResponse) Reset()                    { *m = ScalarMultResponse{} }
+func (m *ScalarMultResponse) String() string            { return proto.CompactTextString(m) }
+func (*ScalarMultResponse) ProtoMessage()               {}
+func (*ScalarMultResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *ScalarMultResponse) GetSharedKey() []byte {
+	if m != nil {
+		return m.SharedKey
+	}
+	return nil
+}
+
+type SignRequest struct {
+	// / The serialized transaction to sign.
+	RawTx []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	// / The serialized sign descriptor, as written by WriteSignDescriptor.
+	SignDesc []byte `protobuf:"bytes,2,opt,name=sign_desc,json=signDesc,proto3" json:"sign_desc,omitempty"`
+	// / The index of the input to sign.
+	InputIndex int32 `protobuf:"varint,3,opt,name=input_index,json=inputIndex" json:"input_index,omitempty"`
+	// / The outputs of the transaction that pay to the on-chain wallet.
+	WalletOutputs []*WalletOutput `protobuf:"bytes,4,rep,name=wallet_outputs,json=walletOutputs" json:"wallet_outputs,omitempty"`
+}
+
+func (m *SignRequest) Reset()                    { *m = SignRequest{} }
+func (m *SignRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignRequest) ProtoMessage()               {}
+func (*SignRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *SignRequest) GetRawTx() []byte {
+	if m != nil {
+		return m.RawTx
+	}
+	return nil
+}
+
+func (m *SignRequest) GetSignDesc() []byte {
+	if m != nil {
+		return m.SignDesc
+	}
+	return nil
+}
+
+func (m *SignRequest) GetInputIndex() int32 {
+	if m != nil {
+		return m.InputIndex
+	}
+	return 0
+}
+
+func (m *SignRequest) GetWalletOutputs() []*WalletOutput {
+	if m != nil {
+		return m.WalletOutputs
+	}
+	return nil
+}
+
+type WalletOutput struct {
+	// / The index of the output within the transaction.
+	OutputIndex uint32 `protobuf:"varint,1,opt,name=output_index,json=outputIndex" json:"output_index,omitempty"`
+	// / The derivation path of the key the output pays to.
+	KeyPath *KeyPath `protobuf:"bytes,2,opt,name=key_path,json=keyPath" json:"key_path,omitempty"`
+}
+
+func (m *WalletOutput) Reset()                    { *m = WalletOutput{} }
+func (m *WalletOutput) String() string            { return proto.CompactTextString(m) }
+func (*WalletOutput) ProtoMessage()               {}
+func (*WalletOutput) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *WalletOutput) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+func (m *WalletOutput) GetKeyPath() *KeyPath {
+	if m != nil {
+		return m.KeyPath
+	}
+	return nil
+}
+
+type SignResponse struct {
+	// / The DER encoded signature, without a sighash byte.
+	RawSig []byte `protobuf:"bytes,1,opt,name=raw_sig,json=rawSig,proto3" json:"raw_sig,omitempty"`
+}
+
+func (m *SignResponse) Reset()                    { *m = SignResponse{} }
+func (m *SignResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignResponse) ProtoMessage()               {}
+func (*SignResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *SignResponse) GetRawSig() []byte {
+	if m != nil {
+		return m.RawSig
+	}
+	return nil
+}
+
+type SignMessageRequest struct {
+	// / The compressed public key of the key to sign with.
+	PubKey []byte `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	// / The message to sign.
+	Msg []byte `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (m *SignMessageRequest) Reset()                    { *m = SignMessageRequest{} }
+func (m *SignMessageRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageRequest) ProtoMessage()               {}
+func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *SignMessageRequest) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *SignMessageRequest) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+type SignMessageResponse struct {
+	// / The DER encoded signature.
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignMessageResponse) Reset()                    { *m = SignMessageResponse{} }
+func (m *SignMessageResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageResponse) ProtoMessage()               {}
+func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *SignMessageResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type RegisterChannelRequest struct {
+	// / The txid of the funding transaction.
+	FundingTxid []byte `protobuf:"bytes,1,opt,name=funding_txid,json=fundingTxid,proto3" json:"funding_txid,omitempty"`
+	// / The index of the funding output.
+	OutputIndex uint32 `protobuf:"varint,2,opt,name=output_index,json=outputIndex" json:"output_index,omitempty"`
+	// / The capacity of the channel in satoshis.
+	Capacity int64 `protobuf:"varint,3,opt,name=capacity" json:"capacity,omitempty"`
+	// / The 2-of-2 multi-sig witness script of the funding output.
+	WitnessScript []byte `protobuf:"bytes,4,opt,name=witness_script,json=witnessScript,proto3" json:"witness_script,omitempty"`
+	// / Whether we initiated the channel.
+	Initiator bool `protobuf:"varint,5,opt,name=initiator" json:"initiator,omitempty"`
+	// / The type of the channel, as stored in the channel database.
+	ChanType uint32 `protobuf:"varint,6,opt,name=chan_type,json=chanType" json:"chan_type,omitempty"`
+	// / Our multi-sig key of the funding output.
+	MultiSigKey *KeyDescriptor `protobuf:"bytes,7,opt,name=multi_sig_key,json=multiSigKey" json:"multi_sig_key,omitempty"`
+	// / Our revocation base point.
+	RevocationBasePoint *KeyDescriptor `protobuf:"bytes,8,opt,name=revocation_base_point,json=revocationBasePoint" json:"revocation_base_point,omitempty"`
+	// / Our payment base point.
+	PaymentBasePoint *KeyDescriptor `protobuf:"bytes,9,opt,name=payment_base_point,json=paymentBasePoint" json:"payment_base_point,omitempty"`
+	// / Our delay base point.
+	DelayBasePoint *KeyDescriptor `protobuf:"bytes,10,opt,name=delay_base_point,json=delayBasePoint" json:"delay_base_point,omitempty"`
+	// / Our HTLC base point.
+	HtlcBasePoint *KeyDescriptor `protobuf:"bytes,11,opt,name=htlc_base_point,json=htlcBasePoint" json:"htlc_base_point,omitempty"`
+	// / The CSV delay of the to_local output of our commitments.
+	CsvDelay uint32 `protobuf:"varint,12,opt,name=csv_delay,json=csvDelay" json:"csv_delay,omitempty"`
+	// / The compressed revocation base point of the remote party.
+	RemoteRevocationBasePoint []byte `protobuf:"bytes,13,opt,name=remote_revocation_base_point,json=remoteRevocationBasePoint,proto3" json:"remote_revocation_base_point,omitempty"`
+	// / The compressed payment base point of the remote party.
+	RemotePaymentBasePoint []byte `protobuf:"bytes,14,opt,name=remote_payment_base_point,json=remotePaymentBasePoint,proto3" json:"remote_payment_base_point,omitempty"`
+	// / Our upfront shutdown script, if any.
+	LocalShutdownScript []byte `protobuf:"bytes,15,opt,name=local_shutdown_script,json=localShutdownScript,proto3" json:"local_shutdown_script,omitempty"`
+	// *
+	// Our serialized initial commitment transaction. Its outputs tell the
+	// initial balances of the channel.
+	LocalCommitTx []byte `protobuf:"bytes,16,opt,name=local_commit_tx,json=localCommitTx,proto3" json:"local_commit_tx,omitempty"`
+}
+
+func (m *RegisterChannelRequest) Reset()                    { *m = RegisterChannelRequest{} }
+func (m *RegisterChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*RegisterChannelRequest) ProtoMessage()               {}
+func (*RegisterChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *RegisterChannelRequest) GetFundingTxid() []byte {
+	if m != nil {
+		return m.FundingTxid
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+func (m *RegisterChannelRequest) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *RegisterChannelRequest) GetWitnessScript() []byte {
+	if m != nil {
+		return m.WitnessScript
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetInitiator() bool {
+	if m != nil {
+		return m.Initiator
+	}
+	return false
+}
+
+func (m *RegisterChannelRequest) GetChanType() uint32 {
+	if m != nil {
+		return m.ChanType
+	}
+	return 0
+}
+
+func (m *RegisterChannelRequest) GetMultiSigKey() *KeyDescriptor {
+	if m != nil {
+		return m.MultiSigKey
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetRevocationBasePoint() *KeyDescriptor {
+	if m != nil {
+		return m.RevocationBasePoint
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetPaymentBasePoint() *KeyDescriptor {
+	if m != nil {
+		return m.PaymentBasePoint
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetDelayBasePoint() *KeyDescriptor {
+	if m != nil {
+		return m.DelayBasePoint
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetHtlcBasePoint() *KeyDescriptor {
+	if m != nil {
+		return m.HtlcBasePoint
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetCsvDelay() uint32 {
+	if m != nil {
+		return m.CsvDelay
+	}
+	return 0
+}
+
+func (m *RegisterChannelRequest) GetRemoteRevocationBasePoint() []byte {
+	if m != nil {
+		return m.RemoteRevocationBasePoint
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetRemotePaymentBasePoint() []byte {
+	if m != nil {
+		return m.RemotePaymentBasePoint
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetLocalShutdownScript() []byte {
+	if m != nil {
+		return m.LocalShutdownScript
+	}
+	return nil
+}
+
+func (m *RegisterChannelRequest) GetLocalCommitTx() []byte {
+	if m != nil {
+		return m.LocalCommitTx
+	}
+	return nil
+}
+
+type RegisterChannelResponse struct {
+}
+
+func (m *RegisterChannelResponse) Reset()                    { *m = RegisterChannelResponse{} }
+func (m *RegisterChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*RegisterChannelResponse) ProtoMessage()               {}
+func (*RegisterChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+type CommitmentPointRequest struct {
+	// / Our multi-sig key of the channel.
+	MultiSigKey *KeyDescriptor `protobuf:"bytes,1,opt,name=multi_sig_key,json=multiSigKey" json:"multi_sig_key,omitempty"`
+	// / The height of the commitment.
+	Height uint64 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *CommitmentPointRequest) Reset()                    { *m = CommitmentPointRequest{} }
+func (m *CommitmentPointRequest) String() string            { return proto.CompactTextString(m) }
+func (*CommitmentPointRequest) ProtoMessage()               {}
+func (*CommitmentPointRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *CommitmentPointRequest) GetMultiSigKey() *KeyDescriptor {
+	if m != nil {
+		return m.MultiSigKey
+	}
+	return nil
+}
+
+func (m *CommitmentPointRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type CommitmentPointResponse struct {
+	// / The compressed commitment point.
+	CommitPoint []byte `protobuf:"bytes,1,opt,name=commit_point,json=commitPoint,proto3" json:"commit_point,omitempty"`
+}
+
+func (m *CommitmentPointResponse) Reset()                    { *m = CommitmentPointResponse{} }
+func (m *CommitmentPointResponse) String() string            { return proto.CompactTextString(m) }
+func (*CommitmentPointResponse) ProtoMessage()               {}
+func (*CommitmentPointResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *CommitmentPointResponse) GetCommitPoint() []byte {
+	if m != nil {
+		return m.CommitPoint
+	}
+	return nil
+}
+
+type RevokeCommitmentRequest struct {
+	// / Our multi-sig key of the channel.
+	MultiSigKey *KeyDescriptor `protobuf:"bytes,1,opt,name=multi_sig_key,json=multiSigKey" json:"multi_sig_key,omitempty"`
+	// / The height of the commitment to revoke.
+	Height uint64 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+	// / Our serialized commitment transaction that replaces the revoked one.
+	NextCommitTx []byte `protobuf:"bytes,3,opt,name=next_commit_tx,json=nextCommitTx,proto3" json:"next_commit_tx,omitempty"`
+	// / The signature of the remote party for the next commitment transaction.
+	NextCommitSig []byte `protobuf:"bytes,4,opt,name=next_commit_sig,json=nextCommitSig,proto3" json:"next_commit_sig,omitempty"`
+}
+
+func (m *RevokeCommitmentRequest) Reset()                    { *m = RevokeCommitmentRequest{} }
+func (m *RevokeCommitmentRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevokeCommitmentRequest) ProtoMessage()               {}
+func (*RevokeCommitmentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *RevokeCommitmentRequest) GetMultiSigKey() *KeyDescriptor {
+	if m != nil {
+		return m.MultiSigKey
+	}
+	return nil
+}
+
+func (m *RevokeCommitmentRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RevokeCommitmentRequest) GetNextCommitTx() []byte {
+	if m != nil {
+		return m.NextCommitTx
+	}
+	return nil
+}
+
+func (m *RevokeCommitmentRequest) GetNextCommitSig() []byte {
+	if m != nil {
+		return m.NextCommitSig
+	}
+	return nil
+}
+
+type RevokeCommitmentResponse struct {
+	// / The revocation secret of the commitment.
+	CommitSecret []byte `protobuf:"bytes,1,opt,name=commit_secret,json=commitSecret,proto3" json:"commit_secret,omitempty"`
+}
+
+func (m *RevokeCommitmentResponse) Reset()                    { *m = RevokeCommitmentResponse{} }
+func (m *RevokeCommitmentResponse) String() string            { return proto.CompactTextString(m) }
+func (*RevokeCommitmentResponse) ProtoMessage()               {}
+func (*RevokeCommitmentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *RevokeCommitmentResponse) GetCommitSecret() []byte {
+	if m != nil {
+		return m.CommitSecret
+	}
+	return nil
+}
+
+type KeyPath struct {
+	// / The purpose of the key scope.
+	Purpose uint32 `protobuf:"varint,1,opt,name=purpose" json:"purpose,omitempty"`
+	// / The coin type of the key scope.
+	CoinType uint32 `protobuf:"varint,2,opt,name=coin_type,json=coinType" json:"coin_type,omitempty"`
+	// / The account of the key.
+	Account uint32 `protobuf:"varint,3,opt,name=account" json:"account,omitempty"`
+	// / The branch of the key, 0 for external and 1 for internal addresses.
+	Branch uint32 `protobuf:"varint,4,opt,name=branch" json:"branch,omitempty"`
+	// / The index of the key within its branch.
+	Index uint32 `protobuf:"varint,5,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *KeyPath) Reset()                    { *m = KeyPath{} }
+func (m *KeyPath) String() string            { return proto.CompactTextString(m) }
+func (*KeyPath) ProtoMessage()               {}
+func (*KeyPath) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *KeyPath) GetPurpose() uint32 {
+	if m != nil {
+		return m.Purpose
+	}
+	return 0
+}
+
+func (m *KeyPath) GetCoinType() uint32 {
+	if m != nil {
+		return m.CoinType
+	}
+	return 0
+}
+
+func (m *KeyPath) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *KeyPath) GetBranch() uint32 {
+	if m != nil {
+		return m.Branch
+	}
+	return 0
+}
+
+func (m *KeyPath) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type InputScriptRequest struct {
+	// / The serialized transaction to sign.
+	RawTx []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	// / The serialized sign descriptor, as written by WriteSignDescriptor.
+	SignDesc []byte `protobuf:"bytes,2,opt,name=sign_desc,json=signDesc,proto3" json:"sign_desc,omitempty"`
+	// / The index of the input to sign.
+	InputIndex int32 `protobuf:"varint,3,opt,name=input_index,json=inputIndex" json:"input_index,omitempty"`
+	// / The derivation path of the key of the spent wallet output.
+	KeyPath *KeyPath `protobuf:"bytes,4,opt,name=key_path,json=keyPath" json:"key_path,omitempty"`
+}
+
+func (m *InputScriptRequest) Reset()                    { *m = InputScriptRequest{} }
+func (m *InputScriptRequest) String() string            { return proto.CompactTextString(m) }
+func (*InputScriptRequest) ProtoMessage()               {}
+func (*InputScriptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *InputScriptRequest) GetRawTx() []byte {
+	if m != nil {
+		return m.RawTx
+	}
+	return nil
+}
+
+func (m *InputScriptRequest) GetSignDesc() []byte {
+	if m != nil {
+		return m.SignDesc
+	}
+	return nil
+}
+
+func (m *InputScriptRequest) GetInputIndex() int32 {
+	if m != nil {
+		return m.InputIndex
+	}
+	return 0
+}
+
+func (m *InputScriptRequest) GetKeyPath() *KeyPath {
+	if m != nil {
+		return m.KeyPath
+	}
+	return nil
+}
+
+type InputScriptResponse struct {
+	// / The signature script of the input, if it spends a nested output.
+	SigScript []byte `protobuf:"bytes,1,opt,name=sig_script,json=sigScript,proto3" json:"sig_script,omitempty"`
+	// / The witness stack of the input.
+	Witness [][]byte `protobuf:"bytes,2,rep,name=witness,proto3" json:"witness,omitempty"`
+}
+
+func (m *InputScriptResponse) Reset()                    { *m = InputScriptResponse{} }
+func (m *InputScriptResponse) String() string            { return proto.CompactTextString(m) }
+func (*InputScriptResponse) ProtoMessage()               {}
+func (*InputScriptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *InputScriptResponse) GetSigScript() []byte {
+	if m != nil {
+		return m.SigScript
+	}
+	return nil
+}
+
+func (m *InputScriptResponse) GetWitness() [][]byte {
+	if m != nil {
+		return m.Witness
+	}
+	return nil
+}
+
+type ExportWatchOnlyWalletRequest struct {
+}
+
+func (m *ExportWatchOnlyWalletRequest) Reset()                    { *m = ExportWatchOnlyWalletRequest{} }
+func (m *ExportWatchOnlyWalletRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportWatchOnlyWalletRequest) ProtoMessage()               {}
+func (*ExportWatchOnlyWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+type ExportWatchOnlyWalletResponse struct {
+	// / The serialized watch-only wallet database.
+	WalletDb []byte `protobuf:"bytes,1,opt,name=wallet_db,json=walletDb,proto3" json:"wallet_db,omitempty"`
+}
+
+func (m *ExportWatchOnlyWalletResponse) Reset()                    { *m = ExportWatchOnlyWalletResponse{} }
+func (m *ExportWatchOnlyWalletResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportWatchOnlyWalletResponse) ProtoMessage()               {}
+func (*ExportWatchOnlyWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ExportWatchOnlyWalletResponse) GetWalletDb() []byte {
+	if m != nil {
+		return m.WalletDb
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*KeyFamily)(nil), "remotesignerrpc.KeyFamily")
+	proto.RegisterType((*KeyLocator)(nil), "remotesignerrpc.KeyLocator")
+	proto.RegisterType((*KeyDescriptor)(nil), "remotesignerrpc.KeyDescriptor")
+	proto.RegisterType((*PrivKeyResponse)(nil), "remotesignerrpc.PrivKeyResponse")
+	proto.RegisterType((*ScalarMultRequest)(nil), "remotesignerrpc.ScalarMultRequest")
+	proto.RegisterType((*ScalarMultResponse)(nil), "remotesignerrpc.ScalarMultResponse")
+	proto.RegisterType((*SignRequest)(nil), "remotesignerrpc.SignRequest")
+	proto.RegisterType((*WalletOutput)(nil), "remotesignerrpc.WalletOutput")
+	proto.RegisterType((*SignResponse)(nil), "remotesignerrpc.SignResponse")
+	proto.RegisterType((*SignMessageRequest)(nil), "remotesignerrpc.SignMessageRequest")
+	proto.RegisterType((*SignMessageResponse)(nil), "remotesignerrpc.SignMessageResponse")
+	proto.RegisterType((*RegisterChannelRequest)(nil), "remotesignerrpc.RegisterChannelRequest")
+	proto.RegisterType((*RegisterChannelResponse)(nil), "remotesignerrpc.RegisterChannelResponse")
+	proto.RegisterType((*CommitmentPointRequest)(nil), "remotesignerrpc.CommitmentPointRequest")
+	proto.RegisterType((*CommitmentPointResponse)(nil), "remotesignerrpc.CommitmentPointResponse")
+	proto.RegisterType((*RevokeCommitmentRequest)(nil), "remotesignerrpc.RevokeCommitmentRequest")
+	proto.RegisterType((*RevokeCommitmentResponse)(nil), "remotesignerrpc.RevokeCommitmentResponse")
+	proto.RegisterType((*KeyPath)(nil), "remotesignerrpc.KeyPath")
+	proto.RegisterType((*InputScriptRequest)(nil), "remotesignerrpc.InputScriptRequest")
+	proto.RegisterType((*InputScriptResponse)(nil), "remotesignerrpc.InputScriptResponse")
+	proto.RegisterType((*ExportWatchOnlyWalletRequest)(nil), "remotesignerrpc.ExportWatchOnlyWalletRequest")
+	proto.RegisterType((*ExportWatchOnlyWalletResponse)(nil), "remotesignerrpc.ExportWatchOnlyWalletResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for RemoteSigner service
+
+type RemoteSignerClient interface {
+	// *
+	// DeriveNextKey derives the next key within the given key family.
+	DeriveNextKey(ctx context.Context, in *KeyFamily, opts ...grpc.CallOption) (*KeyDescriptor, error)
+	// *
+	// DeriveKey derives the key described by the given key locator.
+	DeriveKey(ctx context.Context, in *KeyLocator, opts ...grpc.CallOption) (*KeyDescriptor, error)
+	// *
+	// DerivePrivKey returns the private key of the given key descriptor. Only
+	// the node key, which doesn't control any funds, is handed out.
+	DerivePrivKey(ctx context.Context, in *KeyDescriptor, opts ...grpc.CallOption) (*PrivKeyResponse, error)
+	// *
+	// ScalarMult performs an ECDH operation between the private key of the
+	// given key descriptor and the passed public key.
+	ScalarMult(ctx context.Context, in *ScalarMultRequest, opts ...grpc.CallOption) (*ScalarMultResponse, error)
+	// *
+	// SignOutputRaw signs an input of the passed transaction as described by
+	// the serialized sign descriptor. Spends of channel funding outputs are
+	// only signed for channels that have been registered with RegisterChannel,
+	// and our commitment transactions are only signed as long as they haven't
+	// been revoked. Any other spend of a funding output, such as a cooperative
+	// close or a splice, must pay at least our balance to our upfront shutdown
+	// script, to the on-chain wallet or back into the channel. Base point keys
+	// may only sign spends of outputs of the commitment and HTLC transactions
+	// the signer signed for their channel. Unless the spend is an HTLC
+	// transaction, it must pay to the on-chain wallet.
+	SignOutputRaw(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	// *
+	// SignMessage signs the double SHA-256 of the passed message with the node
+	// key.
+	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
+	// *
+	// RegisterChannel informs the signer about a channel, allowing it to sign
+	// spends of the channel's funding output.
+	RegisterChannel(ctx context.Context, in *RegisterChannelRequest, opts ...grpc.CallOption) (*RegisterChannelResponse, error)
+	// *
+	// CommitmentPoint returns our commitment point at the given height of the
+	// channel that uses the given multi-sig key. The revocation secrets of our
+	// commitments never leave the signer before they are revoked.
+	CommitmentPoint(ctx context.Context, in *CommitmentPointRequest, opts ...grpc.CallOption) (*CommitmentPointResponse, error)
+	// *
+	// RevokeCommitment revokes our commitment at the given height of a
+	// registered channel and returns its revocation secret. Commitments must be
+	// revoked in order, and a revoked commitment is never signed again. The
+	// next commitment, signed by the remote party, tells the signer our
+	// balance of the channel.
+	RevokeCommitment(ctx context.Context, in *RevokeCommitmentRequest, opts ...grpc.CallOption) (*RevokeCommitmentResponse, error)
+	// *
+	// ComputeInputScript generates the input script of an input that spends an
+	// output of the on-chain wallet. The key of the output is identified by its
+	// derivation path, only keys of the default account of the BIP84 and BIP49
+	// key scopes are used.
+	ComputeInputScript(ctx context.Context, in *InputScriptRequest, opts ...grpc.CallOption) (*InputScriptResponse, error)
+	// *
+	// ExportWatchOnlyWallet returns a copy of the database of the on-chain
+	// wallet that has all of its private key material removed. The watch-only
+	// instance runs its wallet from the account public keys it contains.
+	ExportWatchOnlyWallet(ctx context.Context, in *ExportWatchOnlyWalletRequest, opts ...grpc.CallOption) (*ExportWatchOnlyWalletResponse, error)
+}
+
+type remoteSignerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRemoteSignerClient(cc *grpc.ClientConn) RemoteSignerClient {
+	return &remoteSignerClient{cc}
+}
+
+func (c *remoteSignerClient) DeriveNextKey(ctx context.Context, in *KeyFamily, opts ...grpc.CallOption) (*KeyDescriptor, error) {
+	out := new(KeyDescriptor)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/DeriveNextKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) DeriveKey(ctx context.Context, in *KeyLocator, opts ...grpc.CallOption) (*KeyDescriptor, error) {
+	out := new(KeyDescriptor)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/DeriveKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) DerivePrivKey(ctx context.Context, in *KeyDescriptor, opts ...grpc.CallOption) (*PrivKeyResponse, error) {
+	out := new(PrivKeyResponse)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/DerivePrivKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) ScalarMult(ctx context.Context, in *ScalarMultRequest, opts ...grpc.CallOption) (*ScalarMultResponse, error) {
+	out := new(ScalarMultResponse)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/ScalarMult", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) SignOutputRaw(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/SignOutputRaw", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
+	out := new(SignMessageResponse)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/SignMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) RegisterChannel(ctx context.Context, in *RegisterChannelRequest, opts ...grpc.CallOption) (*RegisterChannelResponse, error) {
+	out := new(RegisterChannelResponse)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/RegisterChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) CommitmentPoint(ctx context.Context, in *CommitmentPointRequest, opts ...grpc.CallOption) (*CommitmentPointResponse, error) {
+	out := new(CommitmentPointResponse)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/CommitmentPoint", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) RevokeCommitment(ctx context.Context, in *RevokeCommitmentRequest, opts ...grpc.CallOption) (*RevokeCommitmentResponse, error) {
+	out := new(RevokeCommitmentResponse)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/RevokeCommitment", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) ComputeInputScript(ctx context.Context, in *InputScriptRequest, opts ...grpc.CallOption) (*InputScriptResponse, error) {
+	out := new(InputScriptResponse)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/ComputeInputScript", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) ExportWatchOnlyWallet(ctx context.Context, in *ExportWatchOnlyWalletRequest, opts ...grpc.CallOption) (*ExportWatchOnlyWalletResponse, error) {
+	out := new(ExportWatchOnlyWalletResponse)
+	err := grpc.Invoke(ctx, "/remotesignerrpc.RemoteSigner/ExportWatchOnlyWallet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for RemoteSigner service
+
+type RemoteSignerServer interface {
+	// *
+	// DeriveNextKey derives the next key within the given key family.
+	DeriveNextKey(context.Context, *KeyFamily) (*KeyDescriptor, error)
+	// *
+	// DeriveKey derives the key described by the given key locator.
+	DeriveKey(context.Context, *KeyLocator) (*KeyDescriptor, error)
+	// *
+	// DerivePrivKey returns the private key of the given key descriptor. Only
+	// the node key, which doesn't control any funds, is handed out.
+	DerivePrivKey(context.Context, *KeyDescriptor) (*PrivKeyResponse, error)
+	// *
+	// ScalarMult performs an ECDH operation between the private key of the
+	// given key descriptor and the passed public key.
+	ScalarMult(context.Context, *ScalarMultRequest) (*ScalarMultResponse, error)
+	// *
+	// SignOutputRaw signs an input of the passed transaction as described by
+	// the serialized sign descriptor. Spends of channel funding outputs are
+	// only signed for channels that have been registered with RegisterChannel,
+	// and our commitment transactions are only signed as long as they haven't
+	// been revoked. Any other spend of a funding output, such as a cooperative
+	// close or a splice, must pay at least our balance to our upfront shutdown
+	// script, to the on-chain wallet or back into the channel. Base point keys
+	// may only sign spends of outputs of the commitment and HTLC transactions
+	// the signer signed for their channel. Unless the spend is an HTLC
+	// transaction, it must pay to the on-chain wallet.
+	SignOutputRaw(context.Context, *SignRequest) (*SignResponse, error)
+	// *
+	// SignMessage signs the double SHA-256 of the passed message with the node
+	// key.
+	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
+	// *
+	// RegisterChannel informs the signer about a channel, allowing it to sign
+	// spends of the channel's funding output.
+	RegisterChannel(context.Context, *RegisterChannelRequest) (*RegisterChannelResponse, error)
+	// *
+	// CommitmentPoint returns our commitment point at the given height of the
+	// channel that uses the given multi-sig key. The revocation secrets of our
+	// commitments never leave the signer before they are revoked.
+	CommitmentPoint(context.Context, *CommitmentPointRequest) (*CommitmentPointResponse, error)
+	// *
+	// RevokeCommitment revokes our commitment at the given height of a
+	// registered channel and returns its revocation secret. Commitments must be
+	// revoked in order, and a revoked commitment is never signed again. The
+	// next commitment, signed by the remote party, tells the signer our
+	// balance of the channel.
+	RevokeCommitment(context.Context, *RevokeCommitmentRequest) (*RevokeCommitmentResponse, error)
+	// *
+	// ComputeInputScript generates the input script of an input that spends an
+	// output of the on-chain wallet. The key of the output is identified by its
+	// derivation path, only keys of the default account of the BIP84 and BIP49
+	// key scopes are used.
+	ComputeInputScript(context.Context, *InputScriptRequest) (*InputScriptResponse, error)
+	// *
+	// ExportWatchOnlyWallet returns a copy of the database of the on-chain
+	// wallet that has all of its private key material removed. The watch-only
+	// instance runs its wallet from the account public keys it contains.
+	ExportWatchOnlyWallet(context.Context, *ExportWatchOnlyWalletRequest) (*ExportWatchOnlyWalletResponse, error)
+}
+
+func RegisterRemoteSignerServer(s *grpc.Server, srv RemoteSignerServer) {
+	s.RegisterService(&_RemoteSigner_serviceDesc, srv)
+}
+
+func _RemoteSigner_DeriveNextKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyFamily)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).DeriveNextKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/DeriveNextKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).DeriveNextKey(ctx, req.(*KeyFamily))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_DeriveKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyLocator)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).DeriveKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/DeriveKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).DeriveKey(ctx, req.(*KeyLocator))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_DerivePrivKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyDescriptor)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).DerivePrivKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/DerivePrivKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).DerivePrivKey(ctx, req.(*KeyDescriptor))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_ScalarMult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScalarMultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).ScalarMult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/ScalarMult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).ScalarMult(ctx, req.(*ScalarMultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_SignOutputRaw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).SignOutputRaw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/SignOutputRaw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).SignOutputRaw(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).SignMessage(ctx, req.(*SignMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_RegisterChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).RegisterChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/RegisterChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).RegisterChannel(ctx, req.(*RegisterChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_CommitmentPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitmentPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).CommitmentPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/CommitmentPoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).CommitmentPoint(ctx, req.(*CommitmentPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_RevokeCommitment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCommitmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).RevokeCommitment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/RevokeCommitment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).RevokeCommitment(ctx, req.(*RevokeCommitmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_ComputeInputScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InputScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).ComputeInputScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/ComputeInputScript",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).ComputeInputScript(ctx, req.(*InputScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_ExportWatchOnlyWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportWatchOnlyWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).ExportWatchOnlyWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesignerrpc.RemoteSigner/ExportWatchOnlyWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).ExportWatchOnlyWallet(ctx, req.(*ExportWatchOnlyWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RemoteSigner_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "remotesignerrpc.RemoteSigner",
+	HandlerType: (*RemoteSignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeriveNextKey",
+			Handler:    _RemoteSigner_DeriveNextKey_Handler,
+		},
+		{
+			MethodName: "DeriveKey",
+			Handler:    _RemoteSigner_DeriveKey_Handler,
+		},
+		{
+			MethodName: "DerivePrivKey",
+			Handler:    _RemoteSigner_DerivePrivKey_Handler,
+		},
+		{
+			MethodName: "ScalarMult",
+			Handler:    _RemoteSigner_ScalarMult_Handler,
+		},
+		{
+			MethodName: "SignOutputRaw",
+			Handler:    _RemoteSigner_SignOutputRaw_Handler,
+		},
+		{
+			MethodName: "SignMessage",
+			Handler:    _RemoteSigner_SignMessage_Handler,
+		},
+		{
+			MethodName: "RegisterChannel",
+			Handler:    _RemoteSigner_RegisterChannel_Handler,
+		},
+		{
+			MethodName: "CommitmentPoint",
+			Handler:    _RemoteSigner_CommitmentPoint_Handler,
+		},
+		{
+			MethodName: "RevokeCommitment",
+			Handler:    _RemoteSigner_RevokeCommitment_Handler,
+		},
+		{
+			MethodName: "ComputeInputScript",
+			Handler:    _RemoteSigner_ComputeInputScript_Handler,
+		},
+		{
+			MethodName: "ExportWatchOnlyWallet",
+			Handler:    _RemoteSigner_ExportWatchOnlyWallet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "remotesigner.proto",
+}
+
+func init() { proto.RegisterFile("remotesigner.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 1277 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcd, 0x6e, 0xdb, 0xc6,
+	0x13, 0x87, 0x2c, 0x5b, 0x1f, 0x23, 0xd1, 0xf2, 0x7f, 0xfd, 0xb7, 0xcd, 0x30, 0x76, 0xea, 0x30,
+	0x69, 0xe3, 0x16, 0xad, 0x0f, 0x76, 0x2f, 0x01, 0x02, 0x04, 0x48, 0xdc, 0xc0, 0x81, 0x9c, 0xc4,
+	0xa5, 0x5c, 0xa4, 0x37, 0x62, 0x45, 0xad, 0xa9, 0x85, 0x28, 0x92, 0x25, 0x97, 0x92, 0xf8, 0x00,
+	0x05, 0xfa, 0x14, 0x3d, 0xf7, 0x3d, 0x7a, 0xea, 0x5b, 0x15, 0xfb, 0xc1, 0x90, 0x16, 0x19, 0x4b,
+	0x3d, 0xb4, 0x37, 0xcd, 0xec, 0xcc, 0x6f, 0x66, 0x7f, 0x3b, 0x33, 0x1c, 0x01, 0x8a, 0xc8, 0x34,
+	0x60, 0x24, 0xa6, 0xae, 0x4f, 0xa2, 0xd3, 0x30, 0x0a, 0x58, 0x80, 0x7a, 0x45, 0x5d, 0x14, 0x3a,
+	0xe6, 0x37, 0xd0, 0xee, 0x93, 0xf4, 0x0d, 0x9e, 0x52, 0x2f, 0x45, 0x47, 0x00, 0x13, 0x92, 0xda,
+	0xb7, 0x42, 0xd2, 0x6b, 0xc7, 0xb5, 0x93, 0x2d, 0xab, 0x3d, 0xc9, 0x8e, 0xcd, 0x4b, 0x80, 0x3e,
+	0x49, 0xaf, 0x02, 0x07, 0xb3, 0x20, 0x5a, 0x61, 0x8c, 0x1e, 0x02, 0x17, 0x6c, 0xea, 0x8f, 0xc8,
+	0x42, 0xdf, 0x10, 0xa7, 0xad, 0x09, 0x49, 0xdf, 0x72, 0xd9, 0xa4, 0xa0, 0xf5, 0x49, 0x7a, 0x41,
+	0x62, 0x27, 0xa2, 0x21, 0x07, 0x33, 0x41, 0x8b, 0xf0, 0xdc, 0xe6, 0x1e, 0xc3, 0x94, 0x91, 0x58,
+	0xe0, 0x75, 0xad, 0x4e, 0x84, 0xe7, 0x7d, 0x92, 0xbe, 0xe2, 0x2a, 0xf4, 0x3d, 0x34, 0xf9, 0xb9,
+	0x17, 0x38, 0x02, 0xaf, 0x73, 0xf6, 0xf0, 0x74, 0xe9, 0x36, 0xa7, 0x79, 0x7a, 0x56, 0x63, 0x22,
+	0x7e, 0x9b, 0xdf, 0x42, 0xef, 0x3a, 0xa2, 0xb3, 0x3e, 0x49, 0x2d, 0x12, 0x87, 0x81, 0x1f, 0x13,
+	0xf4, 0x00, 0x5a, 0x61, 0x44, 0x67, 0x3c, 0x9a, 0x8a, 0xd3, 0x0c, 0xa5, 0x89, 0xe9, 0xc2, 0xff,
+	0x06, 0x0e, 0xf6, 0x70, 0xf4, 0x2e, 0xf1, 0x98, 0x45, 0x7e, 0x49, 0x48, 0xcc, 0xd0, 0x73, 0xe0,
+	0x99, 0xdb, 0x23, 0x12, 0x3b, 0xc2, 0xbe, 0x73, 0xf6, 0xa8, 0x2a, 0x72, 0x7e, 0x1d, 0xab, 0x39,
+	0x91, 0x22, 0x3a, 0x80, 0x66, 0x98, 0x0c, 0x45, 0xa4, 0x0d, 0x11, 0xa9, 0x11, 0x26, 0x43, 0x1e,
+	0xe8, 0x1c, 0x50, 0x31, 0x90, 0xca, 0xec, 0x08, 0x20, 0x1e, 0xe3, 0x88, 0x8c, 0x0a, 0xb9, 0xb5,
+	0xa5, 0x86, 0x3b, 0xfd, 0x51, 0x83, 0xce, 0x80, 0xba, 0x7e, 0x96, 0xd8, 0x1e, 0x34, 0x38, 0x6b,
+	0x6c, 0xa1, 0x4c, 0xb7, 0x22, 0x3c, 0xbf, 0x59, 0x70, 0xea, 0x79, 0x62, 0x32, 0x61, 0x19, 0xb6,
+	0xc5, 0x15, 0x22, 0xa3, 0x2f, 0xa0, 0x43, 0xfd, 0x30, 0x61, 0xea, 0x65, 0xea, 0xe2, 0x65, 0x40,
+	0xa8, 0xc4, 0xdb, 0xa0, 0x0b, 0xd8, 0x9e, 0x63, 0xcf, 0x23, 0xcc, 0x0e, 0x12, 0x16, 0x26, 0x2c,
+	0xd6, 0x37, 0x8f, 0xeb, 0x27, 0x9d, 0xb3, 0xa3, 0xd2, 0x9d, 0x3f, 0x0a, 0xb3, 0x0f, 0xc2, 0xca,
+	0xd2, 0xe6, 0x05, 0x29, 0x36, 0x6f, 0xa1, 0x5b, 0x3c, 0x46, 0x8f, 0xa1, 0x2b, 0xe1, 0x54, 0x5c,
+	0x9e, 0xb0, 0x66, 0x75, 0xa4, 0x4e, 0x06, 0x3e, 0x97, 0x34, 0x87, 0x98, 0x8d, 0xd5, 0x03, 0xeb,
+	0x55, 0x34, 0x5f, 0x63, 0x36, 0x16, 0x04, 0xf3, 0x1f, 0xe6, 0x33, 0xe8, 0x4a, 0x46, 0x14, 0x83,
+	0x07, 0xd0, 0xe4, 0x94, 0xc4, 0xd4, 0x55, 0x9c, 0x70, 0x86, 0x06, 0xd4, 0x35, 0x5f, 0x02, 0xe2,
+	0x86, 0xef, 0x48, 0x1c, 0x63, 0x97, 0x64, 0x0c, 0x16, 0xde, 0xa7, 0x56, 0x7c, 0x1f, 0xb4, 0x03,
+	0xf5, 0x69, 0xec, 0x2a, 0xf6, 0xf8, 0x4f, 0xf3, 0x1c, 0x76, 0xef, 0x00, 0xa8, 0x80, 0x87, 0x92,
+	0x6c, 0xcc, 0x92, 0x88, 0x7c, 0x7a, 0xb1, 0x4c, 0x61, 0xfe, 0xd5, 0x80, 0x7d, 0x8b, 0xb8, 0x34,
+	0x66, 0x24, 0x7a, 0x3d, 0xc6, 0xbe, 0x4f, 0xbc, 0x2c, 0xf4, 0x63, 0xe8, 0xde, 0x26, 0xfe, 0x88,
+	0xfa, 0xae, 0xcd, 0x16, 0x74, 0x94, 0x55, 0xbc, 0xd2, 0xdd, 0x2c, 0xe8, 0xa8, 0x44, 0xda, 0x46,
+	0x99, 0x34, 0x03, 0x5a, 0x0e, 0x0e, 0xb1, 0x43, 0x59, 0x2a, 0xde, 0xb2, 0x6e, 0x7d, 0x92, 0xd1,
+	0x97, 0xb0, 0x3d, 0xa7, 0xcc, 0x27, 0x71, 0x6c, 0xcb, 0xca, 0xd4, 0x37, 0x45, 0x0c, 0x4d, 0x69,
+	0x07, 0x42, 0xc9, 0x6f, 0x40, 0x7d, 0xca, 0x28, 0x6f, 0x1b, 0x7d, 0xeb, 0xb8, 0x76, 0xd2, 0xb2,
+	0x72, 0x05, 0x2f, 0x26, 0x67, 0x8c, 0x7d, 0x9b, 0xa5, 0x21, 0xd1, 0x1b, 0x22, 0x81, 0x16, 0x57,
+	0xdc, 0xa4, 0x21, 0x41, 0xaf, 0x40, 0x9b, 0x26, 0x1e, 0xa3, 0x9c, 0x6f, 0x41, 0x62, 0x73, 0xad,
+	0xf6, 0xe8, 0x08, 0xa7, 0x01, 0x75, 0x39, 0xd3, 0x16, 0xec, 0x45, 0x64, 0xc6, 0xbb, 0x96, 0x06,
+	0xbe, 0x3d, 0xc4, 0x31, 0xb1, 0xc3, 0x80, 0xfa, 0x4c, 0x6f, 0xad, 0x85, 0xb5, 0x9b, 0x3b, 0xbf,
+	0xc2, 0x31, 0xb9, 0xe6, 0xae, 0xe8, 0x0a, 0x50, 0x88, 0xd3, 0x29, 0xf1, 0x59, 0x11, 0xb0, 0xbd,
+	0x16, 0xe0, 0x8e, 0xf2, 0xcc, 0xd1, 0x2e, 0x61, 0x67, 0x44, 0x3c, 0x9c, 0x16, 0xb1, 0x60, 0x2d,
+	0xac, 0x6d, 0xe1, 0x97, 0x23, 0xbd, 0x81, 0xde, 0x98, 0x79, 0x4e, 0x11, 0xa8, 0xb3, 0x16, 0x90,
+	0xc6, 0xdd, 0x72, 0x1c, 0xfe, 0x28, 0xf1, 0xcc, 0x16, 0xe8, 0x7a, 0x57, 0x3d, 0x4a, 0x3c, 0xbb,
+	0xe0, 0x32, 0x7a, 0x09, 0x87, 0x12, 0xcc, 0xae, 0xe6, 0x55, 0x13, 0x45, 0xf0, 0x40, 0xda, 0x58,
+	0x15, 0xec, 0x3d, 0x07, 0x75, 0x68, 0x57, 0x90, 0xb8, 0x2d, 0xbc, 0xf7, 0xa5, 0xc1, 0xf5, 0x32,
+	0x55, 0x67, 0xb0, 0xe7, 0x05, 0x0e, 0xf6, 0xec, 0x78, 0x9c, 0xb0, 0x51, 0x30, 0xf7, 0xb3, 0xca,
+	0xeb, 0x09, 0xb7, 0x5d, 0x71, 0x38, 0x50, 0x67, 0xaa, 0xfe, 0xbe, 0x82, 0x9e, 0xf4, 0x71, 0x82,
+	0xe9, 0x94, 0x32, 0x3e, 0xce, 0x76, 0x64, 0x9d, 0x0a, 0xf5, 0x6b, 0xa1, 0xbd, 0x59, 0x98, 0x0f,
+	0xe0, 0xa0, 0xd4, 0x4a, 0xb2, 0x09, 0x4d, 0x06, 0xfb, 0xd2, 0x8c, 0x67, 0x23, 0x32, 0xc9, 0xba,
+	0xac, 0x54, 0xa1, 0xb5, 0x7f, 0x5e, 0xa1, 0xfb, 0xd0, 0x18, 0x13, 0xea, 0x8e, 0x99, 0x68, 0xc0,
+	0x4d, 0x4b, 0x49, 0xe6, 0x0b, 0x38, 0x28, 0x45, 0x55, 0x53, 0xe1, 0x31, 0x74, 0xd5, 0x6d, 0x24,
+	0x6b, 0xaa, 0xb9, 0xa5, 0x4e, 0x98, 0x9a, 0x7f, 0xd6, 0xf8, 0x7d, 0x66, 0xc1, 0x84, 0xe4, 0x20,
+	0xff, 0x41, 0xd6, 0xe8, 0x29, 0x6c, 0xfb, 0x64, 0xc1, 0x0a, 0x6c, 0xd7, 0x45, 0x72, 0x5d, 0xae,
+	0xcd, 0xc8, 0xe6, 0x8f, 0x52, 0xb4, 0xe2, 0xf3, 0x54, 0x0d, 0x8f, 0xdc, 0x4c, 0x8e, 0x55, 0xbd,
+	0x7c, 0x09, 0x45, 0xc2, 0x13, 0xd0, 0x32, 0x77, 0xe2, 0x44, 0x24, 0x63, 0x41, 0x31, 0x33, 0x10,
+	0x3a, 0xf3, 0xb7, 0x1a, 0x34, 0xd5, 0x54, 0x47, 0x3a, 0x9f, 0xc6, 0x51, 0x18, 0xc4, 0x44, 0x7d,
+	0x1f, 0x32, 0x51, 0x14, 0x7c, 0x40, 0xd5, 0x14, 0xda, 0x50, 0x05, 0x1f, 0x50, 0x39, 0x85, 0x74,
+	0x68, 0x62, 0xc7, 0x09, 0x12, 0x9f, 0x89, 0xab, 0x68, 0x56, 0x26, 0x72, 0x0e, 0x86, 0x11, 0xf6,
+	0x9d, 0xb1, 0x48, 0x5e, 0xb3, 0x94, 0x84, 0xfe, 0x0f, 0x5b, 0x72, 0xa2, 0x6e, 0x09, 0xb5, 0x14,
+	0xcc, 0xdf, 0x6b, 0x80, 0xde, 0xf2, 0x0f, 0xa1, 0x2c, 0xcc, 0x7f, 0xf5, 0x2b, 0x5b, 0xfc, 0xd8,
+	0x6d, 0xae, 0xfb, 0xb1, 0x7b, 0x0f, 0xbb, 0x77, 0xf2, 0x2b, 0x6c, 0x0d, 0xd4, 0xcd, 0x3a, 0x2d,
+	0xff, 0x06, 0xa9, 0xfe, 0xd2, 0xa1, 0xa9, 0x06, 0xbe, 0xbe, 0x71, 0x5c, 0xe7, 0xdb, 0x8e, 0x12,
+	0xcd, 0x47, 0x70, 0xf8, 0xc3, 0x22, 0x0c, 0x22, 0xf6, 0x11, 0x33, 0x67, 0xfc, 0xc1, 0xf7, 0x52,
+	0xf9, 0xcd, 0x56, 0x37, 0x37, 0x5f, 0xc0, 0xd1, 0x67, 0xce, 0x55, 0xe4, 0x87, 0xd0, 0x56, 0xbb,
+	0xc2, 0x68, 0xa8, 0x02, 0xb7, 0xa4, 0xe2, 0x62, 0x78, 0xf6, 0x6b, 0x0b, 0xba, 0x96, 0xb8, 0xd2,
+	0x40, 0x5c, 0x09, 0xf5, 0x41, 0xbb, 0x20, 0x11, 0x9d, 0x91, 0xf7, 0x64, 0xc1, 0x78, 0x89, 0x1a,
+	0x55, 0x57, 0x96, 0xfb, 0xa3, 0xb1, 0xa2, 0xd6, 0xd1, 0x25, 0xb4, 0x25, 0x18, 0x07, 0xba, 0x6f,
+	0x13, 0x5c, 0x89, 0xf4, 0x63, 0x96, 0x96, 0xda, 0x13, 0xd1, 0x0a, 0x07, 0xe3, 0xb8, 0x74, 0xbe,
+	0xbc, 0x61, 0xfe, 0x04, 0x90, 0x6f, 0x77, 0xc8, 0x2c, 0xd9, 0x97, 0x76, 0x4c, 0xe3, 0xc9, 0xbd,
+	0x36, 0x0a, 0xf6, 0x0a, 0x34, 0x4e, 0xa5, 0xda, 0xb8, 0xf0, 0x1c, 0x1d, 0x96, 0xbd, 0xf2, 0xf5,
+	0xd0, 0x38, 0xfa, 0xcc, 0xa9, 0x42, 0xfb, 0x19, 0x3a, 0x85, 0x85, 0x06, 0x3d, 0xa9, 0xb4, 0xbe,
+	0xbb, 0x2f, 0x19, 0x4f, 0xef, 0x37, 0x52, 0xc8, 0x23, 0xe8, 0x2d, 0x4d, 0x6a, 0xf4, 0xac, 0xe4,
+	0x58, 0xbd, 0x16, 0x19, 0x27, 0xab, 0x0d, 0xf3, 0x28, 0x4b, 0xe3, 0xb7, 0x22, 0x4a, 0xf5, 0x67,
+	0xc1, 0x38, 0x59, 0x6d, 0xa8, 0xa2, 0xb8, 0xb0, 0xb3, 0x3c, 0xe0, 0x50, 0x55, 0x8e, 0x95, 0x83,
+	0xdc, 0xf8, 0x7a, 0x0d, 0x4b, 0x15, 0xc8, 0x06, 0xf4, 0x3a, 0x98, 0x86, 0x09, 0x23, 0x85, 0x1e,
+	0xaf, 0x78, 0x95, 0xf2, 0x84, 0x32, 0x9e, 0xde, 0x6f, 0xa4, 0x02, 0xcc, 0x60, 0xaf, 0xb2, 0x9b,
+	0xd1, 0x77, 0x25, 0xf7, 0xfb, 0xa6, 0x82, 0x71, 0xba, 0xae, 0xb9, 0x8c, 0x3b, 0x6c, 0x88, 0xbf,
+	0x9e, 0xe7, 0x7f, 0x0f, 0x00, 0xdc, 0x89, 0x61, 0x85, 0x90, 0x0e, 0x00, 0x00,
+}
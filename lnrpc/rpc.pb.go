@@ -18,6 +18,8 @@ It has these top-level messages:
 	ChangePasswordResponse
 	Transaction
 	GetTransactionsRequest
+	LabelTransactionRequest
+	LabelTransactionResponse
 	TransactionDetails
 	FeeLimit
 	SendRequest
@@ -29,8 +31,18 @@ It has these top-level messages:
 	SendManyResponse
 	SendCoinsRequest
 	SendCoinsResponse
+	OutPoint
+	BumpFeeRequest
+	BumpFeeResponse
 	NewAddressRequest
 	NewAddressResponse
+	ListUnspentRequest
+	ListUnspentResponse
+	Utxo
+	LeaseOutputRequest
+	LeaseOutputResponse
+	ReleaseOutputRequest
+	ReleaseOutputResponse
 	SignMessageRequest
 	SignMessageResponse
 	VerifyMessageRequest
@@ -46,16 +58,29 @@ It has these top-level messages:
 	ChannelCloseSummary
 	ClosedChannelsRequest
 	ClosedChannelsResponse
+	ChannelEventSubscription
+	ChannelEventUpdate
+	ClosingChannelUpdate
+	ChannelBalanceUpdate
+	SplicedChannelUpdate
 	Peer
 	ListPeersRequest
 	ListPeersResponse
 	GetInfoRequest
 	GetInfoResponse
+	GetRecoveryInfoRequest
+	KeyScopeRecovery
+	GetRecoveryInfoResponse
 	ConfirmationUpdate
 	ChannelOpenUpdate
 	ChannelCloseUpdate
 	CloseChannelRequest
+	SpliceOutRequest
+	SpliceOutResponse
+	BumpPendingChannelRequest
+	BumpPendingChannelResponse
 	CloseStatusUpdate
+	ClosingFeeOffer
 	PendingUpdate
 	OpenChannelRequest
 	OpenStatusUpdate
@@ -103,6 +128,12 @@ It has these top-level messages:
 	DeleteAllPaymentsResponse
 	AbandonChannelRequest
 	AbandonChannelResponse
+	DebugChannelStateRequest
+	DebugHTLC
+	DebugCommitment
+	DebugLogUpdate
+	DebugForwardingPackage
+	DebugChannelStateResponse
 	DebugLevelRequest
 	DebugLevelResponse
 	PayReqString
@@ -115,6 +146,14 @@ It has these top-level messages:
 	ForwardingHistoryRequest
 	ForwardingEvent
 	ForwardingHistoryResponse
+	ExportChannelBackupRequest
+	ChannelBackup
+	MultiChanBackup
+	ChanBackupSnapshot
+	ChannelBackups
+	RestoreChanBackupRequest
+	RestoreBackupResponse
+	VerifyChanBackupResponse
 */
 package lnrpc
 
@@ -159,7 +198,7 @@ func (x NewAddressRequest_AddressType) String() string {
 	return proto.EnumName(NewAddressRequest_AddressType_name, int32(x))
 }
 func (NewAddressRequest_AddressType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{21, 0}
+	return fileDescriptor0, []int{26, 0}
 }
 
 type ChannelCloseSummary_ClosureType int32
@@ -194,7 +233,48 @@ func (x ChannelCloseSummary_ClosureType) String() string {
 	return proto.EnumName(ChannelCloseSummary_ClosureType_name, int32(x))
 }
 func (ChannelCloseSummary_ClosureType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{35, 0}
+	return fileDescriptor0, []int{47, 0}
+}
+
+type ChannelEventUpdate_UpdateType int32
+
+const (
+	ChannelEventUpdate_PENDING_OPEN_CHANNEL ChannelEventUpdate_UpdateType = 0
+	ChannelEventUpdate_OPEN_CHANNEL         ChannelEventUpdate_UpdateType = 1
+	ChannelEventUpdate_ACTIVE_CHANNEL       ChannelEventUpdate_UpdateType = 2
+	ChannelEventUpdate_INACTIVE_CHANNEL     ChannelEventUpdate_UpdateType = 3
+	ChannelEventUpdate_CLOSING_CHANNEL      ChannelEventUpdate_UpdateType = 4
+	ChannelEventUpdate_CLOSED_CHANNEL       ChannelEventUpdate_UpdateType = 5
+	ChannelEventUpdate_BALANCE_UPDATE       ChannelEventUpdate_UpdateType = 6
+	ChannelEventUpdate_SPLICED_CHANNEL      ChannelEventUpdate_UpdateType = 7
+)
+
+var ChannelEventUpdate_UpdateType_name = map[int32]string{
+	0: "PENDING_OPEN_CHANNEL",
+	1: "OPEN_CHANNEL",
+	2: "ACTIVE_CHANNEL",
+	3: "INACTIVE_CHANNEL",
+	4: "CLOSING_CHANNEL",
+	5: "CLOSED_CHANNEL",
+	6: "BALANCE_UPDATE",
+	7: "SPLICED_CHANNEL",
+}
+var ChannelEventUpdate_UpdateType_value = map[string]int32{
+	"PENDING_OPEN_CHANNEL": 0,
+	"OPEN_CHANNEL":         1,
+	"ACTIVE_CHANNEL":       2,
+	"INACTIVE_CHANNEL":     3,
+	"CLOSING_CHANNEL":      4,
+	"CLOSED_CHANNEL":       5,
+	"BALANCE_UPDATE":       6,
+	"SPLICED_CHANNEL":      7,
+}
+
+func (x ChannelEventUpdate_UpdateType) String() string {
+	return proto.EnumName(ChannelEventUpdate_UpdateType_name, int32(x))
+}
+func (ChannelEventUpdate_UpdateType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{51, 0}
 }
 
 type GenSeedRequest struct {
@@ -422,6 +502,8 @@ type Transaction struct {
 	TotalFees int64 `protobuf:"varint,7,opt,name=total_fees" json:"total_fees,omitempty"`
 	// / Addresses that received funds for this transaction
 	DestAddresses []string `protobuf:"bytes,8,rep,name=dest_addresses" json:"dest_addresses,omitempty"`
+	// / The label of the transaction, describing why it was created.
+	Label string `protobuf:"bytes,9,opt,name=label" json:"label,omitempty"`
 }
 
 func (m *Transaction) Reset()                    { *m = Transaction{} }
@@ -485,6 +567,13 @@ func (m *Transaction) GetDestAddresses() []string {
 	return nil
 }
 
+func (m *Transaction) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
 type GetTransactionsRequest struct {
 }
 
@@ -493,6 +582,49 @@ func (m *GetTransactionsRequest) String() string            { return proto.Compa
 func (*GetTransactionsRequest) ProtoMessage()               {}
 func (*GetTransactionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
+type LabelTransactionRequest struct {
+	// / The txid of the transaction to label.
+	Txid []byte `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// / The label to assign, limited to 500 characters.
+	Label string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+	// / Whether to replace an existing label of the transaction.
+	Overwrite bool `protobuf:"varint,3,opt,name=overwrite" json:"overwrite,omitempty"`
+}
+
+func (m *LabelTransactionRequest) Reset()                    { *m = LabelTransactionRequest{} }
+func (m *LabelTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*LabelTransactionRequest) ProtoMessage()               {}
+func (*LabelTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *LabelTransactionRequest) GetTxid() []byte {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+func (m *LabelTransactionRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *LabelTransactionRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+type LabelTransactionResponse struct {
+}
+
+func (m *LabelTransactionResponse) Reset()                    { *m = LabelTransactionResponse{} }
+func (m *LabelTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*LabelTransactionResponse) ProtoMessage()               {}
+func (*LabelTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
 type TransactionDetails struct {
 	// / The list of transactions relevant to the wallet.
 	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
@@ -501,7 +633,7 @@ type TransactionDetails struct {
 func (m *TransactionDetails) Reset()                    { *m = TransactionDetails{} }
 func (m *TransactionDetails) String() string            { return proto.CompactTextString(m) }
 func (*TransactionDetails) ProtoMessage()               {}
-func (*TransactionDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*TransactionDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *TransactionDetails) GetTransactions() []*Transaction {
 	if m != nil {
@@ -520,7 +652,7 @@ type FeeLimit struct {
 func (m *FeeLimit) Reset()                    { *m = FeeLimit{} }
 func (m *FeeLimit) String() string            { return proto.CompactTextString(m) }
 func (*FeeLimit) ProtoMessage()               {}
-func (*FeeLimit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*FeeLimit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 type isFeeLimit_Limit interface{ isFeeLimit_Limit() }
 
@@ -650,7 +782,7 @@ type SendRequest struct {
 func (m *SendRequest) Reset()                    { *m = SendRequest{} }
 func (m *SendRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendRequest) ProtoMessage()               {}
-func (*SendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*SendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *SendRequest) GetDest() []byte {
 	if m != nil {
@@ -717,7 +849,7 @@ type SendResponse struct {
 func (m *SendResponse) Reset()                    { *m = SendResponse{} }
 func (m *SendResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendResponse) ProtoMessage()               {}
-func (*SendResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*SendResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *SendResponse) GetPaymentError() string {
 	if m != nil {
@@ -752,7 +884,7 @@ type SendToRouteRequest struct {
 func (m *SendToRouteRequest) Reset()                    { *m = SendToRouteRequest{} }
 func (m *SendToRouteRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendToRouteRequest) ProtoMessage()               {}
-func (*SendToRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*SendToRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *SendToRouteRequest) GetPaymentHash() []byte {
 	if m != nil {
@@ -787,7 +919,7 @@ type ChannelPoint struct {
 func (m *ChannelPoint) Reset()                    { *m = ChannelPoint{} }
 func (m *ChannelPoint) String() string            { return proto.CompactTextString(m) }
 func (*ChannelPoint) ProtoMessage()               {}
-func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 type isChannelPoint_FundingTxid interface{ isChannelPoint_FundingTxid() }
 
@@ -905,7 +1037,7 @@ type LightningAddress struct {
 func (m *LightningAddress) Reset()                    { *m = LightningAddress{} }
 func (m *LightningAddress) String() string            { return proto.CompactTextString(m) }
 func (*LightningAddress) ProtoMessage()               {}
-func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *LightningAddress) GetPubkey() string {
 	if m != nil {
@@ -928,12 +1060,18 @@ type SendManyRequest struct {
 	TargetConf int32 `protobuf:"varint,3,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
 	// / A manual fee rate set in sat/byte that should be used when crafting the transaction.
 	SatPerByte int64 `protobuf:"varint,5,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	// / An optional set of wallet outputs to spend. If set, all of them are spent and no automatic coin selection is performed.
+	Outpoints []*OutPoint `protobuf:"bytes,6,rep,name=outpoints" json:"outpoints,omitempty"`
+	// / An optional label for the transaction, limited to 500 characters.
+	Label string `protobuf:"bytes,7,opt,name=label" json:"label,omitempty"`
+	// / The 32 byte lock ID the outputs set in outpoints are leased under, if any. Outputs leased under a different lock ID can't be spent.
+	LockId []byte `protobuf:"bytes,8,opt,name=lock_id,proto3" json:"lock_id,omitempty"`
 }
 
 func (m *SendManyRequest) Reset()                    { *m = SendManyRequest{} }
 func (m *SendManyRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendManyRequest) ProtoMessage()               {}
-func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *SendManyRequest) GetAddrToAmount() map[string]int64 {
 	if m != nil {
@@ -956,6 +1094,27 @@ func (m *SendManyRequest) GetSatPerByte() int64 {
 	return 0
 }
 
+func (m *SendManyRequest) GetOutpoints() []*OutPoint {
+	if m != nil {
+		return m.Outpoints
+	}
+	return nil
+}
+
+func (m *SendManyRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *SendManyRequest) GetLockId() []byte {
+	if m != nil {
+		return m.LockId
+	}
+	return nil
+}
+
 type SendManyResponse struct {
 	// / The id of the transaction
 	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
@@ -964,7 +1123,7 @@ type SendManyResponse struct {
 func (m *SendManyResponse) Reset()                    { *m = SendManyResponse{} }
 func (m *SendManyResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendManyResponse) ProtoMessage()               {}
-func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *SendManyResponse) GetTxid() string {
 	if m != nil {
@@ -982,12 +1141,23 @@ type SendCoinsRequest struct {
 	TargetConf int32 `protobuf:"varint,3,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
 	// / A manual fee rate set in sat/byte that should be used when crafting the transaction.
 	SatPerByte int64 `protobuf:"varint,5,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	// / An optional set of wallet outputs to spend. If set, all of them are spent and no automatic coin selection is performed.
+	Outpoints []*OutPoint `protobuf:"bytes,6,rep,name=outpoints" json:"outpoints,omitempty"`
+	// *
+	// If set, the amount field must be unset. All confirmed outputs of the
+	// wallet, or the outputs set in outpoints, are sent to the address in a
+	// single output, minus the fee.
+	SendAll bool `protobuf:"varint,7,opt,name=send_all" json:"send_all,omitempty"`
+	// / An optional label for the transaction, limited to 500 characters.
+	Label string `protobuf:"bytes,8,opt,name=label" json:"label,omitempty"`
+	// / The 32 byte lock ID the outputs set in outpoints are leased under, if any. Outputs leased under a different lock ID can't be spent.
+	LockId []byte `protobuf:"bytes,9,opt,name=lock_id,proto3" json:"lock_id,omitempty"`
 }
 
 func (m *SendCoinsRequest) Reset()                    { *m = SendCoinsRequest{} }
 func (m *SendCoinsRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendCoinsRequest) ProtoMessage()               {}
-func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *SendCoinsRequest) GetAddr() string {
 	if m != nil {
@@ -1017,6 +1187,34 @@ func (m *SendCoinsRequest) GetSatPerByte() int64 {
 	return 0
 }
 
+func (m *SendCoinsRequest) GetOutpoints() []*OutPoint {
+	if m != nil {
+		return m.Outpoints
+	}
+	return nil
+}
+
+func (m *SendCoinsRequest) GetSendAll() bool {
+	if m != nil {
+		return m.SendAll
+	}
+	return false
+}
+
+func (m *SendCoinsRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *SendCoinsRequest) GetLockId() []byte {
+	if m != nil {
+		return m.LockId
+	}
+	return nil
+}
+
 type SendCoinsResponse struct {
 	// / The transaction ID of the transaction
 	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
@@ -1025,7 +1223,7 @@ type SendCoinsResponse struct {
 func (m *SendCoinsResponse) Reset()                    { *m = SendCoinsResponse{} }
 func (m *SendCoinsResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendCoinsResponse) ProtoMessage()               {}
-func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *SendCoinsResponse) GetTxid() string {
 	if m != nil {
@@ -1034,6 +1232,187 @@ func (m *SendCoinsResponse) GetTxid() string {
 	return ""
 }
 
+type OutPoint struct {
+	// Types that are valid to be assigned to Txid:
+	//	*OutPoint_TxidBytes
+	//	*OutPoint_TxidStr
+	Txid isOutPoint_Txid `protobuf_oneof:"txid"`
+	// / The index of the output on the transaction.
+	OutputIndex uint32 `protobuf:"varint,3,opt,name=output_index" json:"output_index,omitempty"`
+}
+
+func (m *OutPoint) Reset()                    { *m = OutPoint{} }
+func (m *OutPoint) String() string            { return proto.CompactTextString(m) }
+func (*OutPoint) ProtoMessage()               {}
+func (*OutPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+type isOutPoint_Txid interface{ isOutPoint_Txid() }
+
+type OutPoint_TxidBytes struct {
+	TxidBytes []byte `protobuf:"bytes,1,opt,name=txid_bytes,proto3,oneof"`
+}
+type OutPoint_TxidStr struct {
+	TxidStr string `protobuf:"bytes,2,opt,name=txid_str,oneof"`
+}
+
+func (*OutPoint_TxidBytes) isOutPoint_Txid() {}
+func (*OutPoint_TxidStr) isOutPoint_Txid()   {}
+
+func (m *OutPoint) GetTxid() isOutPoint_Txid {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+func (m *OutPoint) GetTxidBytes() []byte {
+	if x, ok := m.GetTxid().(*OutPoint_TxidBytes); ok {
+		return x.TxidBytes
+	}
+	return nil
+}
+
+func (m *OutPoint) GetTxidStr() string {
+	if x, ok := m.GetTxid().(*OutPoint_TxidStr); ok {
+		return x.TxidStr
+	}
+	return ""
+}
+
+func (m *OutPoint) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*OutPoint) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _OutPoint_OneofMarshaler, _OutPoint_OneofUnmarshaler, _OutPoint_OneofSizer, []interface{}{
+		(*OutPoint_TxidBytes)(nil),
+		(*OutPoint_TxidStr)(nil),
+	}
+}
+
+func _OutPoint_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*OutPoint)
+	// txid
+	switch x := m.Txid.(type) {
+	case *OutPoint_TxidBytes:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		b.EncodeRawBytes(x.TxidBytes)
+	case *OutPoint_TxidStr:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.TxidStr)
+	case nil:
+	default:
+		return fmt.Errorf("OutPoint.Txid has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _OutPoint_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*OutPoint)
+	switch tag {
+	case 1: // txid.txid_bytes
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeRawBytes(true)
+		m.Txid = &OutPoint_TxidBytes{x}
+		return true, err
+	case 2: // txid.txid_str
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Txid = &OutPoint_TxidStr{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _OutPoint_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*OutPoint)
+	// txid
+	switch x := m.Txid.(type) {
+	case *OutPoint_TxidBytes:
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.TxidBytes)))
+		n += len(x.TxidBytes)
+	case *OutPoint_TxidStr:
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.TxidStr)))
+		n += len(x.TxidStr)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type BumpFeeRequest struct {
+	// / An output of the transaction to bump. For CPFP, this output is spent by the child transaction.
+	Outpoint *OutPoint `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The target number of blocks that the transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,2,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that should be used.
+	SatPerByte int64 `protobuf:"varint,3,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+}
+
+func (m *BumpFeeRequest) Reset()                    { *m = BumpFeeRequest{} }
+func (m *BumpFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeRequest) ProtoMessage()               {}
+func (*BumpFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *BumpFeeRequest) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *BumpFeeRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *BumpFeeRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+type BumpFeeResponse struct {
+	// / The txid of the published replacement or child transaction.
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	// / Whether the transaction was replaced (RBF) instead of being bumped by a child transaction (CPFP).
+	Replaced bool `protobuf:"varint,2,opt,name=replaced" json:"replaced,omitempty"`
+}
+
+func (m *BumpFeeResponse) Reset()                    { *m = BumpFeeResponse{} }
+func (m *BumpFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeResponse) ProtoMessage()               {}
+func (*BumpFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *BumpFeeResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *BumpFeeResponse) GetReplaced() bool {
+	if m != nil {
+		return m.Replaced
+	}
+	return false
+}
+
 // *
 // `AddressType` has to be one of:
 //
@@ -1047,7 +1426,7 @@ type NewAddressRequest struct {
 func (m *NewAddressRequest) Reset()                    { *m = NewAddressRequest{} }
 func (m *NewAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressRequest) ProtoMessage()               {}
-func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *NewAddressRequest) GetType() NewAddressRequest_AddressType {
 	if m != nil {
@@ -1064,7 +1443,7 @@ type NewAddressResponse struct {
 func (m *NewAddressResponse) Reset()                    { *m = NewAddressResponse{} }
 func (m *NewAddressResponse) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressResponse) ProtoMessage()               {}
-func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *NewAddressResponse) GetAddress() string {
 	if m != nil {
@@ -1073,117 +1452,308 @@ func (m *NewAddressResponse) GetAddress() string {
 	return ""
 }
 
-type SignMessageRequest struct {
-	// / The message to be signed
-	Msg []byte `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+type ListUnspentRequest struct {
+	// / The minimum number of confirmations to be included.
+	MinConfs int32 `protobuf:"varint,1,opt,name=min_confs" json:"min_confs,omitempty"`
+	// / The maximum number of confirmations to be included. If this is not set, there is no upper limit.
+	MaxConfs int32 `protobuf:"varint,2,opt,name=max_confs" json:"max_confs,omitempty"`
 }
 
-func (m *SignMessageRequest) Reset()                    { *m = SignMessageRequest{} }
-func (m *SignMessageRequest) String() string            { return proto.CompactTextString(m) }
-func (*SignMessageRequest) ProtoMessage()               {}
-func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (m *ListUnspentRequest) Reset()                    { *m = ListUnspentRequest{} }
+func (m *ListUnspentRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListUnspentRequest) ProtoMessage()               {}
+func (*ListUnspentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
-func (m *SignMessageRequest) GetMsg() []byte {
+func (m *ListUnspentRequest) GetMinConfs() int32 {
 	if m != nil {
-		return m.Msg
+		return m.MinConfs
 	}
-	return nil
+	return 0
 }
 
-type SignMessageResponse struct {
-	// / The signature for the given message
-	Signature string `protobuf:"bytes,1,opt,name=signature" json:"signature,omitempty"`
+func (m *ListUnspentRequest) GetMaxConfs() int32 {
+	if m != nil {
+		return m.MaxConfs
+	}
+	return 0
 }
 
-func (m *SignMessageResponse) Reset()                    { *m = SignMessageResponse{} }
-func (m *SignMessageResponse) String() string            { return proto.CompactTextString(m) }
-func (*SignMessageResponse) ProtoMessage()               {}
-func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+type ListUnspentResponse struct {
+	// / A list of unspent outputs of the wallet.
+	Utxos []*Utxo `protobuf:"bytes,1,rep,name=utxos" json:"utxos,omitempty"`
+}
 
-func (m *SignMessageResponse) GetSignature() string {
+func (m *ListUnspentResponse) Reset()                    { *m = ListUnspentResponse{} }
+func (m *ListUnspentResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListUnspentResponse) ProtoMessage()               {}
+func (*ListUnspentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *ListUnspentResponse) GetUtxos() []*Utxo {
 	if m != nil {
-		return m.Signature
+		return m.Utxos
 	}
-	return ""
+	return nil
 }
 
-type VerifyMessageRequest struct {
-	// / The message over which the signature is to be verified
-	Msg []byte `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
-	// / The signature to be verified over the given message
-	Signature string `protobuf:"bytes,2,opt,name=signature" json:"signature,omitempty"`
+type Utxo struct {
+	// / The type of address
+	Type NewAddressRequest_AddressType `protobuf:"varint,1,opt,name=type,json=address_type,enum=lnrpc.NewAddressRequest_AddressType" json:"type,omitempty"`
+	// / The address
+	Address string `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	// / The value of the unspent coin in satoshis
+	AmountSat int64 `protobuf:"varint,3,opt,name=amount_sat" json:"amount_sat,omitempty"`
+	// / The hex-encoded script of the output
+	PkScript string `protobuf:"bytes,4,opt,name=pk_script" json:"pk_script,omitempty"`
+	// / The outpoint of the output
+	Outpoint *OutPoint `protobuf:"bytes,5,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The number of confirmations of the output
+	Confirmations int64 `protobuf:"varint,6,opt,name=confirmations" json:"confirmations,omitempty"`
 }
 
-func (m *VerifyMessageRequest) Reset()                    { *m = VerifyMessageRequest{} }
-func (m *VerifyMessageRequest) String() string            { return proto.CompactTextString(m) }
-func (*VerifyMessageRequest) ProtoMessage()               {}
-func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (m *Utxo) Reset()                    { *m = Utxo{} }
+func (m *Utxo) String() string            { return proto.CompactTextString(m) }
+func (*Utxo) ProtoMessage()               {}
+func (*Utxo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
-func (m *VerifyMessageRequest) GetMsg() []byte {
+func (m *Utxo) GetType() NewAddressRequest_AddressType {
 	if m != nil {
-		return m.Msg
+		return m.Type
 	}
-	return nil
+	return NewAddressRequest_WITNESS_PUBKEY_HASH
 }
 
-func (m *VerifyMessageRequest) GetSignature() string {
+func (m *Utxo) GetAddress() string {
 	if m != nil {
-		return m.Signature
+		return m.Address
 	}
 	return ""
 }
 
-type VerifyMessageResponse struct {
-	// / Whether the signature was valid over the given message
-	Valid bool `protobuf:"varint,1,opt,name=valid" json:"valid,omitempty"`
-	// / The pubkey recovered from the signature
-	Pubkey string `protobuf:"bytes,2,opt,name=pubkey" json:"pubkey,omitempty"`
+func (m *Utxo) GetAmountSat() int64 {
+	if m != nil {
+		return m.AmountSat
+	}
+	return 0
 }
 
-func (m *VerifyMessageResponse) Reset()                    { *m = VerifyMessageResponse{} }
-func (m *VerifyMessageResponse) String() string            { return proto.CompactTextString(m) }
-func (*VerifyMessageResponse) ProtoMessage()               {}
-func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (m *Utxo) GetPkScript() string {
+	if m != nil {
+		return m.PkScript
+	}
+	return ""
+}
 
-func (m *VerifyMessageResponse) GetValid() bool {
+func (m *Utxo) GetOutpoint() *OutPoint {
 	if m != nil {
-		return m.Valid
+		return m.Outpoint
 	}
-	return false
+	return nil
 }
 
-func (m *VerifyMessageResponse) GetPubkey() string {
+func (m *Utxo) GetConfirmations() int64 {
 	if m != nil {
-		return m.Pubkey
+		return m.Confirmations
 	}
-	return ""
+	return 0
 }
 
-type ConnectPeerRequest struct {
-	// / Lightning address of the peer, in the format `<pubkey>@host`
-	Addr *LightningAddress `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
-	// * If set, the daemon will attempt to persistently connect to the target
-	// peer.  Otherwise, the call will be synchronous.
-	Perm bool `protobuf:"varint,2,opt,name=perm" json:"perm,omitempty"`
+type LeaseOutputRequest struct {
+	// / A 32 byte lock ID identifying the lease. The same ID is required to release the output.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// / The output to lease.
+	Outpoint *OutPoint `protobuf:"bytes,2,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The duration of the lease in seconds. If this is not set, the lease expires after 10 minutes.
+	ExpirationSeconds uint64 `protobuf:"varint,3,opt,name=expiration_seconds" json:"expiration_seconds,omitempty"`
 }
 
-func (m *ConnectPeerRequest) Reset()                    { *m = ConnectPeerRequest{} }
-func (m *ConnectPeerRequest) String() string            { return proto.CompactTextString(m) }
-func (*ConnectPeerRequest) ProtoMessage()               {}
-func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (m *LeaseOutputRequest) Reset()                    { *m = LeaseOutputRequest{} }
+func (m *LeaseOutputRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaseOutputRequest) ProtoMessage()               {}
+func (*LeaseOutputRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
-func (m *ConnectPeerRequest) GetAddr() *LightningAddress {
+func (m *LeaseOutputRequest) GetId() []byte {
 	if m != nil {
-		return m.Addr
+		return m.Id
 	}
 	return nil
 }
 
-func (m *ConnectPeerRequest) GetPerm() bool {
+func (m *LeaseOutputRequest) GetOutpoint() *OutPoint {
 	if m != nil {
-		return m.Perm
+		return m.Outpoint
 	}
-	return false
+	return nil
+}
+
+func (m *LeaseOutputRequest) GetExpirationSeconds() uint64 {
+	if m != nil {
+		return m.ExpirationSeconds
+	}
+	return 0
+}
+
+type LeaseOutputResponse struct {
+	// / The absolute unix timestamp at which the lease expires.
+	Expiration uint64 `protobuf:"varint,1,opt,name=expiration" json:"expiration,omitempty"`
+}
+
+func (m *LeaseOutputResponse) Reset()                    { *m = LeaseOutputResponse{} }
+func (m *LeaseOutputResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseOutputResponse) ProtoMessage()               {}
+func (*LeaseOutputResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *LeaseOutputResponse) GetExpiration() uint64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+type ReleaseOutputRequest struct {
+	// / The lock ID the output was leased with.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// / The output to release.
+	Outpoint *OutPoint `protobuf:"bytes,2,opt,name=outpoint" json:"outpoint,omitempty"`
+}
+
+func (m *ReleaseOutputRequest) Reset()                    { *m = ReleaseOutputRequest{} }
+func (m *ReleaseOutputRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseOutputRequest) ProtoMessage()               {}
+func (*ReleaseOutputRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *ReleaseOutputRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ReleaseOutputRequest) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+type ReleaseOutputResponse struct {
+}
+
+func (m *ReleaseOutputResponse) Reset()                    { *m = ReleaseOutputResponse{} }
+func (m *ReleaseOutputResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseOutputResponse) ProtoMessage()               {}
+func (*ReleaseOutputResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+type SignMessageRequest struct {
+	// / The message to be signed
+	Msg []byte `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (m *SignMessageRequest) Reset()                    { *m = SignMessageRequest{} }
+func (m *SignMessageRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageRequest) ProtoMessage()               {}
+func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *SignMessageRequest) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+type SignMessageResponse struct {
+	// / The signature for the given message
+	Signature string `protobuf:"bytes,1,opt,name=signature" json:"signature,omitempty"`
+}
+
+func (m *SignMessageResponse) Reset()                    { *m = SignMessageResponse{} }
+func (m *SignMessageResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageResponse) ProtoMessage()               {}
+func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *SignMessageResponse) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type VerifyMessageRequest struct {
+	// / The message over which the signature is to be verified
+	Msg []byte `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	// / The signature to be verified over the given message
+	Signature string `protobuf:"bytes,2,opt,name=signature" json:"signature,omitempty"`
+}
+
+func (m *VerifyMessageRequest) Reset()                    { *m = VerifyMessageRequest{} }
+func (m *VerifyMessageRequest) String() string            { return proto.CompactTextString(m) }
+func (*VerifyMessageRequest) ProtoMessage()               {}
+func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *VerifyMessageRequest) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *VerifyMessageRequest) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type VerifyMessageResponse struct {
+	// / Whether the signature was valid over the given message
+	Valid bool `protobuf:"varint,1,opt,name=valid" json:"valid,omitempty"`
+	// / The pubkey recovered from the signature
+	Pubkey string `protobuf:"bytes,2,opt,name=pubkey" json:"pubkey,omitempty"`
+}
+
+func (m *VerifyMessageResponse) Reset()                    { *m = VerifyMessageResponse{} }
+func (m *VerifyMessageResponse) String() string            { return proto.CompactTextString(m) }
+func (*VerifyMessageResponse) ProtoMessage()               {}
+func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *VerifyMessageResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *VerifyMessageResponse) GetPubkey() string {
+	if m != nil {
+		return m.Pubkey
+	}
+	return ""
+}
+
+type ConnectPeerRequest struct {
+	// / Lightning address of the peer, in the format `<pubkey>@host`
+	Addr *LightningAddress `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	// * If set, the daemon will attempt to persistently connect to the target
+	// peer.  Otherwise, the call will be synchronous.
+	Perm bool `protobuf:"varint,2,opt,name=perm" json:"perm,omitempty"`
+}
+
+func (m *ConnectPeerRequest) Reset()                    { *m = ConnectPeerRequest{} }
+func (m *ConnectPeerRequest) String() string            { return proto.CompactTextString(m) }
+func (*ConnectPeerRequest) ProtoMessage()               {}
+func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *ConnectPeerRequest) GetAddr() *LightningAddress {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+func (m *ConnectPeerRequest) GetPerm() bool {
+	if m != nil {
+		return m.Perm
+	}
+	return false
 }
 
 type ConnectPeerResponse struct {
@@ -1192,7 +1762,7 @@ type ConnectPeerResponse struct {
 func (m *ConnectPeerResponse) Reset()                    { *m = ConnectPeerResponse{} }
 func (m *ConnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*ConnectPeerResponse) ProtoMessage()               {}
-func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 type DisconnectPeerRequest struct {
 	// / The pubkey of the node to disconnect from
@@ -1202,7 +1772,7 @@ type DisconnectPeerRequest struct {
 func (m *DisconnectPeerRequest) Reset()                    { *m = DisconnectPeerRequest{} }
 func (m *DisconnectPeerRequest) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerRequest) ProtoMessage()               {}
-func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 func (m *DisconnectPeerRequest) GetPubKey() string {
 	if m != nil {
@@ -1217,7 +1787,7 @@ type DisconnectPeerResponse struct {
 func (m *DisconnectPeerResponse) Reset()                    { *m = DisconnectPeerResponse{} }
 func (m *DisconnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerResponse) ProtoMessage()               {}
-func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
 type HTLC struct {
 	Incoming         bool   `protobuf:"varint,1,opt,name=incoming" json:"incoming,omitempty"`
@@ -1229,7 +1799,7 @@ type HTLC struct {
 func (m *HTLC) Reset()                    { *m = HTLC{} }
 func (m *HTLC) String() string            { return proto.CompactTextString(m) }
 func (*HTLC) ProtoMessage()               {}
-func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
 
 func (m *HTLC) GetIncoming() bool {
 	if m != nil {
@@ -1319,7 +1889,7 @@ type Channel struct {
 func (m *Channel) Reset()                    { *m = Channel{} }
 func (m *Channel) String() string            { return proto.CompactTextString(m) }
 func (*Channel) ProtoMessage()               {}
-func (*Channel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*Channel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 func (m *Channel) GetActive() bool {
 	if m != nil {
@@ -1450,7 +2020,7 @@ type ListChannelsRequest struct {
 func (m *ListChannelsRequest) Reset()                    { *m = ListChannelsRequest{} }
 func (m *ListChannelsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsRequest) ProtoMessage()               {}
-func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 func (m *ListChannelsRequest) GetActiveOnly() bool {
 	if m != nil {
@@ -1488,7 +2058,7 @@ type ListChannelsResponse struct {
 func (m *ListChannelsResponse) Reset()                    { *m = ListChannelsResponse{} }
 func (m *ListChannelsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsResponse) ProtoMessage()               {}
-func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
 func (m *ListChannelsResponse) GetChannels() []*Channel {
 	if m != nil {
@@ -1523,7 +2093,7 @@ type ChannelCloseSummary struct {
 func (m *ChannelCloseSummary) Reset()                    { *m = ChannelCloseSummary{} }
 func (m *ChannelCloseSummary) String() string            { return proto.CompactTextString(m) }
 func (*ChannelCloseSummary) ProtoMessage()               {}
-func (*ChannelCloseSummary) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*ChannelCloseSummary) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 func (m *ChannelCloseSummary) GetChannelPoint() string {
 	if m != nil {
@@ -1607,7 +2177,7 @@ type ClosedChannelsRequest struct {
 func (m *ClosedChannelsRequest) Reset()                    { *m = ClosedChannelsRequest{} }
 func (m *ClosedChannelsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ClosedChannelsRequest) ProtoMessage()               {}
-func (*ClosedChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*ClosedChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
 
 func (m *ClosedChannelsRequest) GetCooperative() bool {
 	if m != nil {
@@ -1658,7 +2228,7 @@ type ClosedChannelsResponse struct {
 func (m *ClosedChannelsResponse) Reset()                    { *m = ClosedChannelsResponse{} }
 func (m *ClosedChannelsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ClosedChannelsResponse) ProtoMessage()               {}
-func (*ClosedChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*ClosedChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
 func (m *ClosedChannelsResponse) GetChannels() []*ChannelCloseSummary {
 	if m != nil {
@@ -1667,118 +2237,536 @@ func (m *ClosedChannelsResponse) GetChannels() []*ChannelCloseSummary {
 	return nil
 }
 
-type Peer struct {
-	// / The identity pubkey of the peer
-	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
-	// / Network address of the peer; eg `127.0.0.1:10011`
-	Address string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
-	// / Bytes of data transmitted to this peer
-	BytesSent uint64 `protobuf:"varint,4,opt,name=bytes_sent" json:"bytes_sent,omitempty"`
-	// / Bytes of data transmitted from this peer
-	BytesRecv uint64 `protobuf:"varint,5,opt,name=bytes_recv" json:"bytes_recv,omitempty"`
-	// / Satoshis sent to this peer
-	SatSent int64 `protobuf:"varint,6,opt,name=sat_sent" json:"sat_sent,omitempty"`
-	// / Satoshis received from this peer
-	SatRecv int64 `protobuf:"varint,7,opt,name=sat_recv" json:"sat_recv,omitempty"`
-	// / A channel is inbound if the counterparty initiated the channel
-	Inbound bool `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
-	// / Ping time to this peer
-	PingTime int64 `protobuf:"varint,9,opt,name=ping_time" json:"ping_time,omitempty"`
+type ChannelEventSubscription struct {
 }
 
-func (m *Peer) Reset()                    { *m = Peer{} }
-func (m *Peer) String() string            { return proto.CompactTextString(m) }
-func (*Peer) ProtoMessage()               {}
-func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (m *ChannelEventSubscription) Reset()                    { *m = ChannelEventSubscription{} }
+func (m *ChannelEventSubscription) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEventSubscription) ProtoMessage()               {}
+func (*ChannelEventSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
-func (m *Peer) GetPubKey() string {
-	if m != nil {
-		return m.PubKey
-	}
-	return ""
+type ChannelEventUpdate struct {
+	// Types that are valid to be assigned to Channel:
+	//	*ChannelEventUpdate_PendingOpenChannel
+	//	*ChannelEventUpdate_OpenChannel
+	//	*ChannelEventUpdate_ActiveChannel
+	//	*ChannelEventUpdate_InactiveChannel
+	//	*ChannelEventUpdate_ClosingChannel
+	//	*ChannelEventUpdate_ClosedChannel
+	//	*ChannelEventUpdate_BalanceUpdate
+	//	*ChannelEventUpdate_SplicedChannel
+	Channel isChannelEventUpdate_Channel `protobuf_oneof:"channel"`
+	// / The type of the event.
+	Type ChannelEventUpdate_UpdateType `protobuf:"varint,9,opt,name=type,enum=lnrpc.ChannelEventUpdate_UpdateType" json:"type,omitempty"`
 }
 
-func (m *Peer) GetAddress() string {
-	if m != nil {
-		return m.Address
-	}
-	return ""
+func (m *ChannelEventUpdate) Reset()                    { *m = ChannelEventUpdate{} }
+func (m *ChannelEventUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEventUpdate) ProtoMessage()               {}
+func (*ChannelEventUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+type isChannelEventUpdate_Channel interface{ isChannelEventUpdate_Channel() }
+
+type ChannelEventUpdate_PendingOpenChannel struct {
+	PendingOpenChannel *PendingUpdate `protobuf:"bytes,1,opt,name=pending_open_channel,oneof"`
+}
+type ChannelEventUpdate_OpenChannel struct {
+	OpenChannel *Channel `protobuf:"bytes,2,opt,name=open_channel,oneof"`
+}
+type ChannelEventUpdate_ActiveChannel struct {
+	ActiveChannel *ChannelPoint `protobuf:"bytes,3,opt,name=active_channel,oneof"`
+}
+type ChannelEventUpdate_InactiveChannel struct {
+	InactiveChannel *ChannelPoint `protobuf:"bytes,4,opt,name=inactive_channel,oneof"`
+}
+type ChannelEventUpdate_ClosingChannel struct {
+	ClosingChannel *ClosingChannelUpdate `protobuf:"bytes,5,opt,name=closing_channel,oneof"`
+}
+type ChannelEventUpdate_ClosedChannel struct {
+	ClosedChannel *ChannelCloseSummary `protobuf:"bytes,6,opt,name=closed_channel,oneof"`
+}
+type ChannelEventUpdate_BalanceUpdate struct {
+	BalanceUpdate *ChannelBalanceUpdate `protobuf:"bytes,7,opt,name=balance_update,oneof"`
+}
+type ChannelEventUpdate_SplicedChannel struct {
+	SplicedChannel *SplicedChannelUpdate `protobuf:"bytes,8,opt,name=spliced_channel,oneof"`
 }
 
-func (m *Peer) GetBytesSent() uint64 {
+func (*ChannelEventUpdate_PendingOpenChannel) isChannelEventUpdate_Channel() {}
+func (*ChannelEventUpdate_OpenChannel) isChannelEventUpdate_Channel()        {}
+func (*ChannelEventUpdate_ActiveChannel) isChannelEventUpdate_Channel()      {}
+func (*ChannelEventUpdate_InactiveChannel) isChannelEventUpdate_Channel()    {}
+func (*ChannelEventUpdate_ClosingChannel) isChannelEventUpdate_Channel()     {}
+func (*ChannelEventUpdate_ClosedChannel) isChannelEventUpdate_Channel()      {}
+func (*ChannelEventUpdate_BalanceUpdate) isChannelEventUpdate_Channel()      {}
+func (*ChannelEventUpdate_SplicedChannel) isChannelEventUpdate_Channel()     {}
+
+func (m *ChannelEventUpdate) GetChannel() isChannelEventUpdate_Channel {
 	if m != nil {
-		return m.BytesSent
+		return m.Channel
 	}
-	return 0
+	return nil
 }
 
-func (m *Peer) GetBytesRecv() uint64 {
-	if m != nil {
-		return m.BytesRecv
+func (m *ChannelEventUpdate) GetPendingOpenChannel() *PendingUpdate {
+	if x, ok := m.GetChannel().(*ChannelEventUpdate_PendingOpenChannel); ok {
+		return x.PendingOpenChannel
 	}
-	return 0
+	return nil
 }
 
-func (m *Peer) GetSatSent() int64 {
-	if m != nil {
-		return m.SatSent
+func (m *ChannelEventUpdate) GetOpenChannel() *Channel {
+	if x, ok := m.GetChannel().(*ChannelEventUpdate_OpenChannel); ok {
+		return x.OpenChannel
 	}
-	return 0
+	return nil
 }
 
-func (m *Peer) GetSatRecv() int64 {
-	if m != nil {
-		return m.SatRecv
+func (m *ChannelEventUpdate) GetActiveChannel() *ChannelPoint {
+	if x, ok := m.GetChannel().(*ChannelEventUpdate_ActiveChannel); ok {
+		return x.ActiveChannel
 	}
-	return 0
+	return nil
 }
 
-func (m *Peer) GetInbound() bool {
-	if m != nil {
-		return m.Inbound
+func (m *ChannelEventUpdate) GetInactiveChannel() *ChannelPoint {
+	if x, ok := m.GetChannel().(*ChannelEventUpdate_InactiveChannel); ok {
+		return x.InactiveChannel
 	}
-	return false
+	return nil
 }
 
-func (m *Peer) GetPingTime() int64 {
-	if m != nil {
-		return m.PingTime
+func (m *ChannelEventUpdate) GetClosingChannel() *ClosingChannelUpdate {
+	if x, ok := m.GetChannel().(*ChannelEventUpdate_ClosingChannel); ok {
+		return x.ClosingChannel
 	}
-	return 0
+	return nil
 }
 
-type ListPeersRequest struct {
+func (m *ChannelEventUpdate) GetClosedChannel() *ChannelCloseSummary {
+	if x, ok := m.GetChannel().(*ChannelEventUpdate_ClosedChannel); ok {
+		return x.ClosedChannel
+	}
+	return nil
 }
 
-func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
-func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListPeersRequest) ProtoMessage()               {}
-func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
-
-type ListPeersResponse struct {
-	// / The list of currently connected peers
-	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+func (m *ChannelEventUpdate) GetBalanceUpdate() *ChannelBalanceUpdate {
+	if x, ok := m.GetChannel().(*ChannelEventUpdate_BalanceUpdate); ok {
+		return x.BalanceUpdate
+	}
+	return nil
 }
 
-func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
-func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
-func (*ListPeersResponse) ProtoMessage()               {}
-func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (m *ChannelEventUpdate) GetSplicedChannel() *SplicedChannelUpdate {
+	if x, ok := m.GetChannel().(*ChannelEventUpdate_SplicedChannel); ok {
+		return x.SplicedChannel
+	}
+	return nil
+}
 
-func (m *ListPeersResponse) GetPeers() []*Peer {
+func (m *ChannelEventUpdate) GetType() ChannelEventUpdate_UpdateType {
 	if m != nil {
-		return m.Peers
+		return m.Type
 	}
-	return nil
+	return ChannelEventUpdate_PENDING_OPEN_CHANNEL
 }
 
-type GetInfoRequest struct {
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ChannelEventUpdate) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ChannelEventUpdate_OneofMarshaler, _ChannelEventUpdate_OneofUnmarshaler, _ChannelEventUpdate_OneofSizer, []interface{}{
+		(*ChannelEventUpdate_PendingOpenChannel)(nil),
+		(*ChannelEventUpdate_OpenChannel)(nil),
+		(*ChannelEventUpdate_ActiveChannel)(nil),
+		(*ChannelEventUpdate_InactiveChannel)(nil),
+		(*ChannelEventUpdate_ClosingChannel)(nil),
+		(*ChannelEventUpdate_ClosedChannel)(nil),
+		(*ChannelEventUpdate_BalanceUpdate)(nil),
+		(*ChannelEventUpdate_SplicedChannel)(nil),
+	}
+}
+
+func _ChannelEventUpdate_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ChannelEventUpdate)
+	// channel
+	switch x := m.Channel.(type) {
+	case *ChannelEventUpdate_PendingOpenChannel:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PendingOpenChannel); err != nil {
+			return err
+		}
+	case *ChannelEventUpdate_OpenChannel:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OpenChannel); err != nil {
+			return err
+		}
+	case *ChannelEventUpdate_ActiveChannel:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ActiveChannel); err != nil {
+			return err
+		}
+	case *ChannelEventUpdate_InactiveChannel:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.InactiveChannel); err != nil {
+			return err
+		}
+	case *ChannelEventUpdate_ClosingChannel:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ClosingChannel); err != nil {
+			return err
+		}
+	case *ChannelEventUpdate_ClosedChannel:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ClosedChannel); err != nil {
+			return err
+		}
+	case *ChannelEventUpdate_BalanceUpdate:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BalanceUpdate); err != nil {
+			return err
+		}
+	case *ChannelEventUpdate_SplicedChannel:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SplicedChannel); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ChannelEventUpdate.Channel has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ChannelEventUpdate_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ChannelEventUpdate)
+	switch tag {
+	case 1: // channel.pending_open_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PendingUpdate)
+		err := b.DecodeMessage(msg)
+		m.Channel = &ChannelEventUpdate_PendingOpenChannel{msg}
+		return true, err
+	case 2: // channel.open_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Channel)
+		err := b.DecodeMessage(msg)
+		m.Channel = &ChannelEventUpdate_OpenChannel{msg}
+		return true, err
+	case 3: // channel.active_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelPoint)
+		err := b.DecodeMessage(msg)
+		m.Channel = &ChannelEventUpdate_ActiveChannel{msg}
+		return true, err
+	case 4: // channel.inactive_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelPoint)
+		err := b.DecodeMessage(msg)
+		m.Channel = &ChannelEventUpdate_InactiveChannel{msg}
+		return true, err
+	case 5: // channel.closing_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ClosingChannelUpdate)
+		err := b.DecodeMessage(msg)
+		m.Channel = &ChannelEventUpdate_ClosingChannel{msg}
+		return true, err
+	case 6: // channel.closed_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelCloseSummary)
+		err := b.DecodeMessage(msg)
+		m.Channel = &ChannelEventUpdate_ClosedChannel{msg}
+		return true, err
+	case 7: // channel.balance_update
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelBalanceUpdate)
+		err := b.DecodeMessage(msg)
+		m.Channel = &ChannelEventUpdate_BalanceUpdate{msg}
+		return true, err
+	case 8: // channel.spliced_channel
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SplicedChannelUpdate)
+		err := b.DecodeMessage(msg)
+		m.Channel = &ChannelEventUpdate_SplicedChannel{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ChannelEventUpdate_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ChannelEventUpdate)
+	// channel
+	switch x := m.Channel.(type) {
+	case *ChannelEventUpdate_PendingOpenChannel:
+		s := proto.Size(x.PendingOpenChannel)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ChannelEventUpdate_OpenChannel:
+		s := proto.Size(x.OpenChannel)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ChannelEventUpdate_ActiveChannel:
+		s := proto.Size(x.ActiveChannel)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ChannelEventUpdate_InactiveChannel:
+		s := proto.Size(x.InactiveChannel)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ChannelEventUpdate_ClosingChannel:
+		s := proto.Size(x.ClosingChannel)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ChannelEventUpdate_ClosedChannel:
+		s := proto.Size(x.ClosedChannel)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ChannelEventUpdate_BalanceUpdate:
+		s := proto.Size(x.BalanceUpdate)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ChannelEventUpdate_SplicedChannel:
+		s := proto.Size(x.SplicedChannel)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type ClosingChannelUpdate struct {
+	// / The outpoint (txid:index) of the funding transaction.
+	ChannelPoint string `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+	// / Details on how the channel is being closed.
+	CloseType ChannelCloseSummary_ClosureType `protobuf:"varint,2,opt,name=close_type,enum=lnrpc.ChannelCloseSummary_ClosureType" json:"close_type,omitempty"`
+}
+
+func (m *ClosingChannelUpdate) Reset()                    { *m = ClosingChannelUpdate{} }
+func (m *ClosingChannelUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ClosingChannelUpdate) ProtoMessage()               {}
+func (*ClosingChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *ClosingChannelUpdate) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *ClosingChannelUpdate) GetCloseType() ChannelCloseSummary_ClosureType {
+	if m != nil {
+		return m.CloseType
+	}
+	return ChannelCloseSummary_COOPERATIVE_CLOSE
+}
+
+type ChannelBalanceUpdate struct {
+	// / The outpoint (txid:index) of the funding transaction.
+	ChannelPoint string `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+	// / Our balance within the new commitment.
+	LocalBalance int64 `protobuf:"varint,2,opt,name=local_balance" json:"local_balance,omitempty"`
+	// / The remote party's balance within the new commitment.
+	RemoteBalance int64 `protobuf:"varint,3,opt,name=remote_balance" json:"remote_balance,omitempty"`
+	// / The height of the new commitment.
+	CommitHeight uint64 `protobuf:"varint,4,opt,name=commit_height" json:"commit_height,omitempty"`
+}
+
+func (m *ChannelBalanceUpdate) Reset()                    { *m = ChannelBalanceUpdate{} }
+func (m *ChannelBalanceUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ChannelBalanceUpdate) ProtoMessage()               {}
+func (*ChannelBalanceUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *ChannelBalanceUpdate) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *ChannelBalanceUpdate) GetLocalBalance() int64 {
+	if m != nil {
+		return m.LocalBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceUpdate) GetRemoteBalance() int64 {
+	if m != nil {
+		return m.RemoteBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceUpdate) GetCommitHeight() uint64 {
+	if m != nil {
+		return m.CommitHeight
+	}
+	return 0
+}
+
+type SplicedChannelUpdate struct {
+	// / The outpoint (txid:index) the channel had before the splice.
+	OldChannelPoint string `protobuf:"bytes,1,opt,name=old_channel_point" json:"old_channel_point,omitempty"`
+	// / The channel at its new channel point.
+	Channel *Channel `protobuf:"bytes,2,opt,name=channel" json:"channel,omitempty"`
+}
+
+func (m *SplicedChannelUpdate) Reset()                    { *m = SplicedChannelUpdate{} }
+func (m *SplicedChannelUpdate) String() string            { return proto.CompactTextString(m) }
+func (*SplicedChannelUpdate) ProtoMessage()               {}
+func (*SplicedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *SplicedChannelUpdate) GetOldChannelPoint() string {
+	if m != nil {
+		return m.OldChannelPoint
+	}
+	return ""
+}
+
+func (m *SplicedChannelUpdate) GetChannel() *Channel {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+type Peer struct {
+	// / The identity pubkey of the peer
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// / Network address of the peer; eg `127.0.0.1:10011`
+	Address string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
+	// / Bytes of data transmitted to this peer
+	BytesSent uint64 `protobuf:"varint,4,opt,name=bytes_sent" json:"bytes_sent,omitempty"`
+	// / Bytes of data transmitted from this peer
+	BytesRecv uint64 `protobuf:"varint,5,opt,name=bytes_recv" json:"bytes_recv,omitempty"`
+	// / Satoshis sent to this peer
+	SatSent int64 `protobuf:"varint,6,opt,name=sat_sent" json:"sat_sent,omitempty"`
+	// / Satoshis received from this peer
+	SatRecv int64 `protobuf:"varint,7,opt,name=sat_recv" json:"sat_recv,omitempty"`
+	// / A channel is inbound if the counterparty initiated the channel
+	Inbound bool `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
+	// / Ping time to this peer
+	PingTime int64 `protobuf:"varint,9,opt,name=ping_time" json:"ping_time,omitempty"`
+}
+
+func (m *Peer) Reset()                    { *m = Peer{} }
+func (m *Peer) String() string            { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()               {}
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *Peer) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *Peer) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Peer) GetBytesSent() uint64 {
+	if m != nil {
+		return m.BytesSent
+	}
+	return 0
+}
+
+func (m *Peer) GetBytesRecv() uint64 {
+	if m != nil {
+		return m.BytesRecv
+	}
+	return 0
+}
+
+func (m *Peer) GetSatSent() int64 {
+	if m != nil {
+		return m.SatSent
+	}
+	return 0
+}
+
+func (m *Peer) GetSatRecv() int64 {
+	if m != nil {
+		return m.SatRecv
+	}
+	return 0
+}
+
+func (m *Peer) GetInbound() bool {
+	if m != nil {
+		return m.Inbound
+	}
+	return false
+}
+
+func (m *Peer) GetPingTime() int64 {
+	if m != nil {
+		return m.PingTime
+	}
+	return 0
+}
+
+type ListPeersRequest struct {
+}
+
+func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
+func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPeersRequest) ProtoMessage()               {}
+func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+type ListPeersResponse struct {
+	// / The list of currently connected peers
+	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
+func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListPeersResponse) ProtoMessage()               {}
+func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *ListPeersResponse) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type GetInfoRequest struct {
 }
 
 func (m *GetInfoRequest) Reset()                    { *m = GetInfoRequest{} }
 func (m *GetInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoRequest) ProtoMessage()               {}
-func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
 
 type GetInfoResponse struct {
 	// / The identity pubkey of the current node.
@@ -1812,7 +2800,7 @@ type GetInfoResponse struct {
 func (m *GetInfoResponse) Reset()                    { *m = GetInfoResponse{} }
 func (m *GetInfoResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoResponse) ProtoMessage()               {}
-func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
 
 func (m *GetInfoResponse) GetIdentityPubkey() string {
 	if m != nil {
@@ -1905,34 +2893,157 @@ func (m *GetInfoResponse) GetVersion() string {
 	return ""
 }
 
-type ConfirmationUpdate struct {
-	BlockSha     []byte `protobuf:"bytes,1,opt,name=block_sha,json=blockSha,proto3" json:"block_sha,omitempty"`
-	BlockHeight  int32  `protobuf:"varint,2,opt,name=block_height,json=blockHeight" json:"block_height,omitempty"`
-	NumConfsLeft uint32 `protobuf:"varint,3,opt,name=num_confs_left,json=numConfsLeft" json:"num_confs_left,omitempty"`
+type GetRecoveryInfoRequest struct {
 }
 
-func (m *ConfirmationUpdate) Reset()                    { *m = ConfirmationUpdate{} }
-func (m *ConfirmationUpdate) String() string            { return proto.CompactTextString(m) }
-func (*ConfirmationUpdate) ProtoMessage()               {}
-func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (m *GetRecoveryInfoRequest) Reset()                    { *m = GetRecoveryInfoRequest{} }
+func (m *GetRecoveryInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetRecoveryInfoRequest) ProtoMessage()               {}
+func (*GetRecoveryInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
 
-func (m *ConfirmationUpdate) GetBlockSha() []byte {
-	if m != nil {
-		return m.BlockSha
-	}
-	return nil
+type KeyScopeRecovery struct {
+	// / The BIP 43 purpose of the key scope.
+	Purpose uint32 `protobuf:"varint,1,opt,name=purpose" json:"purpose,omitempty"`
+	// / The BIP 44 coin type of the key scope.
+	CoinType uint32 `protobuf:"varint,2,opt,name=coin_type" json:"coin_type,omitempty"`
+	// / The number of external addresses derived so far, including all receiving addresses found.
+	ExternalAddresses uint32 `protobuf:"varint,3,opt,name=external_addresses" json:"external_addresses,omitempty"`
+	// / The number of internal (change) addresses derived so far.
+	InternalAddresses uint32 `protobuf:"varint,4,opt,name=internal_addresses" json:"internal_addresses,omitempty"`
 }
 
-func (m *ConfirmationUpdate) GetBlockHeight() int32 {
+func (m *KeyScopeRecovery) Reset()                    { *m = KeyScopeRecovery{} }
+func (m *KeyScopeRecovery) String() string            { return proto.CompactTextString(m) }
+func (*KeyScopeRecovery) ProtoMessage()               {}
+func (*KeyScopeRecovery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *KeyScopeRecovery) GetPurpose() uint32 {
 	if m != nil {
-		return m.BlockHeight
+		return m.Purpose
 	}
 	return 0
 }
 
-func (m *ConfirmationUpdate) GetNumConfsLeft() uint32 {
+func (m *KeyScopeRecovery) GetCoinType() uint32 {
 	if m != nil {
-		return m.NumConfsLeft
+		return m.CoinType
+	}
+	return 0
+}
+
+func (m *KeyScopeRecovery) GetExternalAddresses() uint32 {
+	if m != nil {
+		return m.ExternalAddresses
+	}
+	return 0
+}
+
+func (m *KeyScopeRecovery) GetInternalAddresses() uint32 {
+	if m != nil {
+		return m.InternalAddresses
+	}
+	return 0
+}
+
+type GetRecoveryInfoResponse struct {
+	// / Whether the wallet was started in recovery mode.
+	RecoveryMode bool `protobuf:"varint,1,opt,name=recovery_mode" json:"recovery_mode,omitempty"`
+	// / Whether the wallet has finished scanning the chain and is fully synced.
+	RecoveryFinished bool `protobuf:"varint,2,opt,name=recovery_finished" json:"recovery_finished,omitempty"`
+	// / The height the wallet has scanned the chain up to.
+	ScannedHeight uint32 `protobuf:"varint,3,opt,name=scanned_height" json:"scanned_height,omitempty"`
+	// / The height of the current chain tip.
+	BestHeight uint32 `protobuf:"varint,4,opt,name=best_height" json:"best_height,omitempty"`
+	// / The fraction of the chain that has been scanned, between 0 and 1.
+	Progress float64 `protobuf:"fixed64,5,opt,name=progress" json:"progress,omitempty"`
+	// / The addresses recovered so far for each key scope being restored.
+	KeyScopes []*KeyScopeRecovery `protobuf:"bytes,6,rep,name=key_scopes" json:"key_scopes,omitempty"`
+	// / The estimated unix timestamp at which the recovery will finish, or 0 if no estimate is available yet.
+	EstimatedCompletion int64 `protobuf:"varint,7,opt,name=estimated_completion" json:"estimated_completion,omitempty"`
+}
+
+func (m *GetRecoveryInfoResponse) Reset()                    { *m = GetRecoveryInfoResponse{} }
+func (m *GetRecoveryInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetRecoveryInfoResponse) ProtoMessage()               {}
+func (*GetRecoveryInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *GetRecoveryInfoResponse) GetRecoveryMode() bool {
+	if m != nil {
+		return m.RecoveryMode
+	}
+	return false
+}
+
+func (m *GetRecoveryInfoResponse) GetRecoveryFinished() bool {
+	if m != nil {
+		return m.RecoveryFinished
+	}
+	return false
+}
+
+func (m *GetRecoveryInfoResponse) GetScannedHeight() uint32 {
+	if m != nil {
+		return m.ScannedHeight
+	}
+	return 0
+}
+
+func (m *GetRecoveryInfoResponse) GetBestHeight() uint32 {
+	if m != nil {
+		return m.BestHeight
+	}
+	return 0
+}
+
+func (m *GetRecoveryInfoResponse) GetProgress() float64 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func (m *GetRecoveryInfoResponse) GetKeyScopes() []*KeyScopeRecovery {
+	if m != nil {
+		return m.KeyScopes
+	}
+	return nil
+}
+
+func (m *GetRecoveryInfoResponse) GetEstimatedCompletion() int64 {
+	if m != nil {
+		return m.EstimatedCompletion
+	}
+	return 0
+}
+
+type ConfirmationUpdate struct {
+	BlockSha     []byte `protobuf:"bytes,1,opt,name=block_sha,json=blockSha,proto3" json:"block_sha,omitempty"`
+	BlockHeight  int32  `protobuf:"varint,2,opt,name=block_height,json=blockHeight" json:"block_height,omitempty"`
+	NumConfsLeft uint32 `protobuf:"varint,3,opt,name=num_confs_left,json=numConfsLeft" json:"num_confs_left,omitempty"`
+}
+
+func (m *ConfirmationUpdate) Reset()                    { *m = ConfirmationUpdate{} }
+func (m *ConfirmationUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ConfirmationUpdate) ProtoMessage()               {}
+func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *ConfirmationUpdate) GetBlockSha() []byte {
+	if m != nil {
+		return m.BlockSha
+	}
+	return nil
+}
+
+func (m *ConfirmationUpdate) GetBlockHeight() int32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ConfirmationUpdate) GetNumConfsLeft() uint32 {
+	if m != nil {
+		return m.NumConfsLeft
 	}
 	return 0
 }
@@ -1944,7 +3055,7 @@ type ChannelOpenUpdate struct {
 func (m *ChannelOpenUpdate) Reset()                    { *m = ChannelOpenUpdate{} }
 func (m *ChannelOpenUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelOpenUpdate) ProtoMessage()               {}
-func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
 
 func (m *ChannelOpenUpdate) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -1961,7 +3072,7 @@ type ChannelCloseUpdate struct {
 func (m *ChannelCloseUpdate) Reset()                    { *m = ChannelCloseUpdate{} }
 func (m *ChannelCloseUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelCloseUpdate) ProtoMessage()               {}
-func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
 
 func (m *ChannelCloseUpdate) GetClosingTxid() []byte {
 	if m != nil {
@@ -1989,12 +3100,23 @@ type CloseChannelRequest struct {
 	TargetConf int32 `protobuf:"varint,3,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
 	// / A manual fee rate set in sat/byte that should be used when crafting the closure transaction.
 	SatPerByte int64 `protobuf:"varint,4,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	// *
+	// An optional address to send our settled funds to in the case of a
+	// cooperative close. If empty, a new address of the wallet will be used. If
+	// an upfront shutdown address was committed to when opening the channel,
+	// only that address can be used.
+	DeliveryAddress string `protobuf:"bytes,5,opt,name=delivery_address,json=deliveryAddress" json:"delivery_address,omitempty"`
+	// *
+	// The highest fee rate in sat/byte we're willing to pay for the closure
+	// transaction of a cooperative close. Offers of the remote party above this
+	// fee rate won't be signed. If zero, no cap applies.
+	MaxSatPerByte int64 `protobuf:"varint,6,opt,name=max_sat_per_byte,json=maxSatPerByte" json:"max_sat_per_byte,omitempty"`
 }
 
 func (m *CloseChannelRequest) Reset()                    { *m = CloseChannelRequest{} }
 func (m *CloseChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*CloseChannelRequest) ProtoMessage()               {}
-func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
 
 func (m *CloseChannelRequest) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -2024,18 +3146,164 @@ func (m *CloseChannelRequest) GetSatPerByte() int64 {
 	return 0
 }
 
+func (m *CloseChannelRequest) GetDeliveryAddress() string {
+	if m != nil {
+		return m.DeliveryAddress
+	}
+	return ""
+}
+
+func (m *CloseChannelRequest) GetMaxSatPerByte() int64 {
+	if m != nil {
+		return m.MaxSatPerByte
+	}
+	return 0
+}
+
+type SpliceOutRequest struct {
+	// / The outpoint (txid:index) of the funding transaction of the channel to splice.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	// / The amount in satoshis to move out of the channel.
+	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	// / The address to send the spliced out funds to. If empty, a new address of the wallet will be used.
+	Addr string `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
+	// / The target number of blocks that the splice transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,4,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that should be used when crafting the splice transaction.
+	SatPerByte int64 `protobuf:"varint,5,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+}
+
+func (m *SpliceOutRequest) Reset()                    { *m = SpliceOutRequest{} }
+func (m *SpliceOutRequest) String() string            { return proto.CompactTextString(m) }
+func (*SpliceOutRequest) ProtoMessage()               {}
+func (*SpliceOutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *SpliceOutRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+func (m *SpliceOutRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *SpliceOutRequest) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *SpliceOutRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *SpliceOutRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+type SpliceOutResponse struct {
+	// / The txid of the splice transaction.
+	SpliceTxid string `protobuf:"bytes,1,opt,name=splice_txid" json:"splice_txid,omitempty"`
+}
+
+func (m *SpliceOutResponse) Reset()                    { *m = SpliceOutResponse{} }
+func (m *SpliceOutResponse) String() string            { return proto.CompactTextString(m) }
+func (*SpliceOutResponse) ProtoMessage()               {}
+func (*SpliceOutResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *SpliceOutResponse) GetSpliceTxid() string {
+	if m != nil {
+		return m.SpliceTxid
+	}
+	return ""
+}
+
+type BumpPendingChannelRequest struct {
+	// / The outpoint (txid:index) of the funding transaction of the pending channel.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	// / The target number of blocks that the funding transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,2,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that should be used.
+	SatPerByte int64 `protobuf:"varint,3,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	// / If set, the inputs of the funding transaction are double spent back to the wallet instead.
+	DoubleSpend bool `protobuf:"varint,4,opt,name=double_spend,json=doubleSpend" json:"double_spend,omitempty"`
+}
+
+func (m *BumpPendingChannelRequest) Reset()                    { *m = BumpPendingChannelRequest{} }
+func (m *BumpPendingChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*BumpPendingChannelRequest) ProtoMessage()               {}
+func (*BumpPendingChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *BumpPendingChannelRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+func (m *BumpPendingChannelRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *BumpPendingChannelRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *BumpPendingChannelRequest) GetDoubleSpend() bool {
+	if m != nil {
+		return m.DoubleSpend
+	}
+	return false
+}
+
+type BumpPendingChannelResponse struct {
+	// / The txid of the published CPFP child or double spending transaction.
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+}
+
+func (m *BumpPendingChannelResponse) Reset()                    { *m = BumpPendingChannelResponse{} }
+func (m *BumpPendingChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*BumpPendingChannelResponse) ProtoMessage()               {}
+func (*BumpPendingChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+func (m *BumpPendingChannelResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
 type CloseStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*CloseStatusUpdate_ClosePending
 	//	*CloseStatusUpdate_Confirmation
 	//	*CloseStatusUpdate_ChanClose
+	//	*CloseStatusUpdate_FeeOffer
 	Update isCloseStatusUpdate_Update `protobuf_oneof:"update"`
 }
 
 func (m *CloseStatusUpdate) Reset()                    { *m = CloseStatusUpdate{} }
 func (m *CloseStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*CloseStatusUpdate) ProtoMessage()               {}
-func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
 
 type isCloseStatusUpdate_Update interface{ isCloseStatusUpdate_Update() }
 
@@ -2048,10 +3316,14 @@ type CloseStatusUpdate_Confirmation struct {
 type CloseStatusUpdate_ChanClose struct {
 	ChanClose *ChannelCloseUpdate `protobuf:"bytes,3,opt,name=chan_close,oneof"`
 }
+type CloseStatusUpdate_FeeOffer struct {
+	FeeOffer *ClosingFeeOffer `protobuf:"bytes,4,opt,name=fee_offer,oneof"`
+}
 
 func (*CloseStatusUpdate_ClosePending) isCloseStatusUpdate_Update() {}
 func (*CloseStatusUpdate_Confirmation) isCloseStatusUpdate_Update() {}
 func (*CloseStatusUpdate_ChanClose) isCloseStatusUpdate_Update()    {}
+func (*CloseStatusUpdate_FeeOffer) isCloseStatusUpdate_Update()     {}
 
 func (m *CloseStatusUpdate) GetUpdate() isCloseStatusUpdate_Update {
 	if m != nil {
@@ -2081,12 +3353,20 @@ func (m *CloseStatusUpdate) GetChanClose() *ChannelCloseUpdate {
 	return nil
 }
 
+func (m *CloseStatusUpdate) GetFeeOffer() *ClosingFeeOffer {
+	if x, ok := m.GetUpdate().(*CloseStatusUpdate_FeeOffer); ok {
+		return x.FeeOffer
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*CloseStatusUpdate) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _CloseStatusUpdate_OneofMarshaler, _CloseStatusUpdate_OneofUnmarshaler, _CloseStatusUpdate_OneofSizer, []interface{}{
 		(*CloseStatusUpdate_ClosePending)(nil),
 		(*CloseStatusUpdate_Confirmation)(nil),
 		(*CloseStatusUpdate_ChanClose)(nil),
+		(*CloseStatusUpdate_FeeOffer)(nil),
 	}
 }
 
@@ -2109,6 +3389,11 @@ func _CloseStatusUpdate_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.ChanClose); err != nil {
 			return err
 		}
+	case *CloseStatusUpdate_FeeOffer:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.FeeOffer); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("CloseStatusUpdate.Update has unexpected type %T", x)
@@ -2143,6 +3428,14 @@ func _CloseStatusUpdate_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pr
 		err := b.DecodeMessage(msg)
 		m.Update = &CloseStatusUpdate_ChanClose{msg}
 		return true, err
+	case 4: // update.fee_offer
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ClosingFeeOffer)
+		err := b.DecodeMessage(msg)
+		m.Update = &CloseStatusUpdate_FeeOffer{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -2167,6 +3460,11 @@ func _CloseStatusUpdate_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(3<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *CloseStatusUpdate_FeeOffer:
+		s := proto.Size(x.FeeOffer)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -2174,6 +3472,50 @@ func _CloseStatusUpdate_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+type ClosingFeeOffer struct {
+	// / The fee in satoshis last offered by the remote party for the closure transaction.
+	RemoteFeeSat int64 `protobuf:"varint,1,opt,name=remote_fee_sat" json:"remote_fee_sat,omitempty"`
+	// / The fee in satoshis we last offered for the closure transaction.
+	LocalFeeSat int64 `protobuf:"varint,2,opt,name=local_fee_sat" json:"local_fee_sat,omitempty"`
+	// / The highest fee in satoshis we're willing to pay for the closure transaction, or zero if no cap applies.
+	MaxFeeSat int64 `protobuf:"varint,3,opt,name=max_fee_sat" json:"max_fee_sat,omitempty"`
+	// / Whether the remote party's offer exceeds our maximum fee, and thus won't be accepted.
+	AboveMaxFee bool `protobuf:"varint,4,opt,name=above_max_fee" json:"above_max_fee,omitempty"`
+}
+
+func (m *ClosingFeeOffer) Reset()                    { *m = ClosingFeeOffer{} }
+func (m *ClosingFeeOffer) String() string            { return proto.CompactTextString(m) }
+func (*ClosingFeeOffer) ProtoMessage()               {}
+func (*ClosingFeeOffer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *ClosingFeeOffer) GetRemoteFeeSat() int64 {
+	if m != nil {
+		return m.RemoteFeeSat
+	}
+	return 0
+}
+
+func (m *ClosingFeeOffer) GetLocalFeeSat() int64 {
+	if m != nil {
+		return m.LocalFeeSat
+	}
+	return 0
+}
+
+func (m *ClosingFeeOffer) GetMaxFeeSat() int64 {
+	if m != nil {
+		return m.MaxFeeSat
+	}
+	return 0
+}
+
+func (m *ClosingFeeOffer) GetAboveMaxFee() bool {
+	if m != nil {
+		return m.AboveMaxFee
+	}
+	return false
+}
+
 type PendingUpdate struct {
 	Txid        []byte `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
 	OutputIndex uint32 `protobuf:"varint,2,opt,name=output_index" json:"output_index,omitempty"`
@@ -2182,7 +3524,7 @@ type PendingUpdate struct {
 func (m *PendingUpdate) Reset()                    { *m = PendingUpdate{} }
 func (m *PendingUpdate) String() string            { return proto.CompactTextString(m) }
 func (*PendingUpdate) ProtoMessage()               {}
-func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
 
 func (m *PendingUpdate) GetTxid() []byte {
 	if m != nil {
@@ -2219,12 +3561,24 @@ type OpenChannelRequest struct {
 	RemoteCsvDelay uint32 `protobuf:"varint,10,opt,name=remote_csv_delay" json:"remote_csv_delay,omitempty"`
 	// / The minimum number of confirmations each one of your outputs used for the funding transaction must satisfy.
 	MinConfs int32 `protobuf:"varint,11,opt,name=min_confs" json:"min_confs,omitempty"`
+	// / An optional address to commit to paying our funds out to upon a cooperative close of the channel. If set, the remote peer will refuse to cooperatively close the channel to any other address.
+	CloseAddress string `protobuf:"bytes,12,opt,name=close_address" json:"close_address,omitempty"`
+	// / The channel reserve in satoshis we require the remote peer to maintain. If this is not set, it will be 1% of the channel size, but no less than the remote peer's dust limit.
+	RemoteChanReserveSat int64 `protobuf:"varint,13,opt,name=remote_chan_reserve_sat" json:"remote_chan_reserve_sat,omitempty"`
+	// / The maximum value in millisatoshi we allow the remote peer to have in outstanding HTLCs towards us. If this is not set, the full channel size minus the channel reserve will be allowed.
+	RemoteMaxValueInFlightMsat uint64 `protobuf:"varint,14,opt,name=remote_max_value_in_flight_msat" json:"remote_max_value_in_flight_msat,omitempty"`
+	// / The maximum number of concurrent HTLCs we allow the remote peer to offer us. If this is not set, the protocol maximum of 483 will be allowed.
+	RemoteMaxHtlcs uint32 `protobuf:"varint,15,opt,name=remote_max_htlcs" json:"remote_max_htlcs,omitempty"`
+	// / An optional set of wallet outputs to fund the channel with. If set, all of them are spent and no automatic coin selection is performed.
+	Outpoints []*OutPoint `protobuf:"bytes,16,rep,name=outpoints" json:"outpoints,omitempty"`
+	// / The 32 byte lock ID the outputs set in outpoints are leased under, if any. Outputs leased under a different lock ID can't be spent.
+	LockId []byte `protobuf:"bytes,17,opt,name=lock_id,proto3" json:"lock_id,omitempty"`
 }
 
 func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
 func (m *OpenChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*OpenChannelRequest) ProtoMessage()               {}
-func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
 
 func (m *OpenChannelRequest) GetNodePubkey() []byte {
 	if m != nil {
@@ -2296,6 +3650,48 @@ func (m *OpenChannelRequest) GetMinConfs() int32 {
 	return 0
 }
 
+func (m *OpenChannelRequest) GetCloseAddress() string {
+	if m != nil {
+		return m.CloseAddress
+	}
+	return ""
+}
+
+func (m *OpenChannelRequest) GetRemoteChanReserveSat() int64 {
+	if m != nil {
+		return m.RemoteChanReserveSat
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetRemoteMaxValueInFlightMsat() uint64 {
+	if m != nil {
+		return m.RemoteMaxValueInFlightMsat
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetRemoteMaxHtlcs() uint32 {
+	if m != nil {
+		return m.RemoteMaxHtlcs
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetOutpoints() []*OutPoint {
+	if m != nil {
+		return m.Outpoints
+	}
+	return nil
+}
+
+func (m *OpenChannelRequest) GetLockId() []byte {
+	if m != nil {
+		return m.LockId
+	}
+	return nil
+}
+
 type OpenStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*OpenStatusUpdate_ChanPending
@@ -2307,7 +3703,7 @@ type OpenStatusUpdate struct {
 func (m *OpenStatusUpdate) Reset()                    { *m = OpenStatusUpdate{} }
 func (m *OpenStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*OpenStatusUpdate) ProtoMessage()               {}
-func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
 
 type isOpenStatusUpdate_Update interface{ isOpenStatusUpdate_Update() }
 
@@ -2467,7 +3863,7 @@ type PendingHTLC struct {
 func (m *PendingHTLC) Reset()                    { *m = PendingHTLC{} }
 func (m *PendingHTLC) String() string            { return proto.CompactTextString(m) }
 func (*PendingHTLC) ProtoMessage()               {}
-func (*PendingHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+func (*PendingHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
 
 func (m *PendingHTLC) GetIncoming() bool {
 	if m != nil {
@@ -2517,7 +3913,7 @@ type PendingChannelsRequest struct {
 func (m *PendingChannelsRequest) Reset()                    { *m = PendingChannelsRequest{} }
 func (m *PendingChannelsRequest) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelsRequest) ProtoMessage()               {}
-func (*PendingChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+func (*PendingChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
 
 type PendingChannelsResponse struct {
 	// / The balance in satoshis encumbered in pending channels
@@ -2535,7 +3931,7 @@ type PendingChannelsResponse struct {
 func (m *PendingChannelsResponse) Reset()                    { *m = PendingChannelsResponse{} }
 func (m *PendingChannelsResponse) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelsResponse) ProtoMessage()               {}
-func (*PendingChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+func (*PendingChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
 
 func (m *PendingChannelsResponse) GetTotalLimboBalance() int64 {
 	if m != nil {
@@ -2586,7 +3982,7 @@ func (m *PendingChannelsResponse_PendingChannel) Reset() {
 func (m *PendingChannelsResponse_PendingChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelsResponse_PendingChannel) ProtoMessage()    {}
 func (*PendingChannelsResponse_PendingChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{53, 0}
+	return fileDescriptor0, []int{78, 0}
 }
 
 func (m *PendingChannelsResponse_PendingChannel) GetRemoteNodePub() string {
@@ -2643,6 +4039,12 @@ type PendingChannelsResponse_PendingOpenChannel struct {
 	// pay at all times, for both the funding transaction and commitment
 	// transaction. This value can later be updated once the channel is open.
 	FeePerKw int64 `protobuf:"varint,6,opt,name=fee_per_kw" json:"fee_per_kw,omitempty"`
+	// / The height at which the funding transaction was broadcast
+	FundingBroadcastHeight uint32 `protobuf:"varint,7,opt,name=funding_broadcast_height" json:"funding_broadcast_height,omitempty"`
+	// / The number of blocks mined since the funding transaction was broadcast
+	BlocksSinceBroadcast uint32 `protobuf:"varint,8,opt,name=blocks_since_broadcast" json:"blocks_since_broadcast,omitempty"`
+	// / Whether we initiated the channel, and are thus able to bump its funding transaction
+	Initiator bool `protobuf:"varint,9,opt,name=initiator" json:"initiator,omitempty"`
 }
 
 func (m *PendingChannelsResponse_PendingOpenChannel) Reset() {
@@ -2653,7 +4055,7 @@ func (m *PendingChannelsResponse_PendingOpenChannel) String() string {
 }
 func (*PendingChannelsResponse_PendingOpenChannel) ProtoMessage() {}
 func (*PendingChannelsResponse_PendingOpenChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{53, 1}
+	return fileDescriptor0, []int{78, 1}
 }
 
 func (m *PendingChannelsResponse_PendingOpenChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2691,6 +4093,27 @@ func (m *PendingChannelsResponse_PendingOpenChannel) GetFeePerKw() int64 {
 	return 0
 }
 
+func (m *PendingChannelsResponse_PendingOpenChannel) GetFundingBroadcastHeight() uint32 {
+	if m != nil {
+		return m.FundingBroadcastHeight
+	}
+	return 0
+}
+
+func (m *PendingChannelsResponse_PendingOpenChannel) GetBlocksSinceBroadcast() uint32 {
+	if m != nil {
+		return m.BlocksSinceBroadcast
+	}
+	return 0
+}
+
+func (m *PendingChannelsResponse_PendingOpenChannel) GetInitiator() bool {
+	if m != nil {
+		return m.Initiator
+	}
+	return false
+}
+
 type PendingChannelsResponse_WaitingCloseChannel struct {
 	// / The pending channel waiting for closing tx to confirm
 	Channel *PendingChannelsResponse_PendingChannel `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
@@ -2706,7 +4129,7 @@ func (m *PendingChannelsResponse_WaitingCloseChannel) String() string {
 }
 func (*PendingChannelsResponse_WaitingCloseChannel) ProtoMessage() {}
 func (*PendingChannelsResponse_WaitingCloseChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{53, 2}
+	return fileDescriptor0, []int{78, 2}
 }
 
 func (m *PendingChannelsResponse_WaitingCloseChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2734,7 +4157,7 @@ func (m *PendingChannelsResponse_ClosedChannel) Reset()         { *m = PendingCh
 func (m *PendingChannelsResponse_ClosedChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelsResponse_ClosedChannel) ProtoMessage()    {}
 func (*PendingChannelsResponse_ClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{53, 3}
+	return fileDescriptor0, []int{78, 3}
 }
 
 func (m *PendingChannelsResponse_ClosedChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2778,7 +4201,7 @@ func (m *PendingChannelsResponse_ForceClosedChannel) String() string {
 }
 func (*PendingChannelsResponse_ForceClosedChannel) ProtoMessage() {}
 func (*PendingChannelsResponse_ForceClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{53, 4}
+	return fileDescriptor0, []int{78, 4}
 }
 
 func (m *PendingChannelsResponse_ForceClosedChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2836,7 +4259,7 @@ type WalletBalanceRequest struct {
 func (m *WalletBalanceRequest) Reset()                    { *m = WalletBalanceRequest{} }
 func (m *WalletBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceRequest) ProtoMessage()               {}
-func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
 
 type WalletBalanceResponse struct {
 	// / The balance of the wallet
@@ -2850,7 +4273,7 @@ type WalletBalanceResponse struct {
 func (m *WalletBalanceResponse) Reset()                    { *m = WalletBalanceResponse{} }
 func (m *WalletBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceResponse) ProtoMessage()               {}
-func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
 
 func (m *WalletBalanceResponse) GetTotalBalance() int64 {
 	if m != nil {
@@ -2879,7 +4302,7 @@ type ChannelBalanceRequest struct {
 func (m *ChannelBalanceRequest) Reset()                    { *m = ChannelBalanceRequest{} }
 func (m *ChannelBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceRequest) ProtoMessage()               {}
-func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
 
 type ChannelBalanceResponse struct {
 	// / Sum of channels balances denominated in satoshis
@@ -2891,7 +4314,7 @@ type ChannelBalanceResponse struct {
 func (m *ChannelBalanceResponse) Reset()                    { *m = ChannelBalanceResponse{} }
 func (m *ChannelBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceResponse) ProtoMessage()               {}
-func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
 
 func (m *ChannelBalanceResponse) GetBalance() int64 {
 	if m != nil {
@@ -2927,7 +4350,7 @@ type QueryRoutesRequest struct {
 func (m *QueryRoutesRequest) Reset()                    { *m = QueryRoutesRequest{} }
 func (m *QueryRoutesRequest) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesRequest) ProtoMessage()               {}
-func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
 
 func (m *QueryRoutesRequest) GetPubKey() string {
 	if m != nil {
@@ -2971,7 +4394,7 @@ type QueryRoutesResponse struct {
 func (m *QueryRoutesResponse) Reset()                    { *m = QueryRoutesResponse{} }
 func (m *QueryRoutesResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesResponse) ProtoMessage()               {}
-func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
 
 func (m *QueryRoutesResponse) GetRoutes() []*Route {
 	if m != nil {
@@ -2997,7 +4420,7 @@ type Hop struct {
 func (m *Hop) Reset()                    { *m = Hop{} }
 func (m *Hop) String() string            { return proto.CompactTextString(m) }
 func (*Hop) ProtoMessage()               {}
-func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
 
 func (m *Hop) GetChanId() uint64 {
 	if m != nil {
@@ -3087,7 +4510,7 @@ type Route struct {
 func (m *Route) Reset()                    { *m = Route{} }
 func (m *Route) String() string            { return proto.CompactTextString(m) }
 func (*Route) ProtoMessage()               {}
-func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
 
 func (m *Route) GetTotalTimeLock() uint32 {
 	if m != nil {
@@ -3139,7 +4562,7 @@ type NodeInfoRequest struct {
 func (m *NodeInfoRequest) Reset()                    { *m = NodeInfoRequest{} }
 func (m *NodeInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfoRequest) ProtoMessage()               {}
-func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
 
 func (m *NodeInfoRequest) GetPubKey() string {
 	if m != nil {
@@ -3162,7 +4585,7 @@ type NodeInfo struct {
 func (m *NodeInfo) Reset()                    { *m = NodeInfo{} }
 func (m *NodeInfo) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfo) ProtoMessage()               {}
-func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
 
 func (m *NodeInfo) GetNode() *LightningNode {
 	if m != nil {
@@ -3201,7 +4624,7 @@ type LightningNode struct {
 func (m *LightningNode) Reset()                    { *m = LightningNode{} }
 func (m *LightningNode) String() string            { return proto.CompactTextString(m) }
 func (*LightningNode) ProtoMessage()               {}
-func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
 
 func (m *LightningNode) GetLastUpdate() uint32 {
 	if m != nil {
@@ -3246,7 +4669,7 @@ type NodeAddress struct {
 func (m *NodeAddress) Reset()                    { *m = NodeAddress{} }
 func (m *NodeAddress) String() string            { return proto.CompactTextString(m) }
 func (*NodeAddress) ProtoMessage()               {}
-func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
 
 func (m *NodeAddress) GetNetwork() string {
 	if m != nil {
@@ -3273,7 +4696,7 @@ type RoutingPolicy struct {
 func (m *RoutingPolicy) Reset()                    { *m = RoutingPolicy{} }
 func (m *RoutingPolicy) String() string            { return proto.CompactTextString(m) }
 func (*RoutingPolicy) ProtoMessage()               {}
-func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
 
 func (m *RoutingPolicy) GetTimeLockDelta() uint32 {
 	if m != nil {
@@ -3334,7 +4757,7 @@ type ChannelEdge struct {
 func (m *ChannelEdge) Reset()                    { *m = ChannelEdge{} }
 func (m *ChannelEdge) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdge) ProtoMessage()               {}
-func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
 
 func (m *ChannelEdge) GetChannelId() uint64 {
 	if m != nil {
@@ -3398,7 +4821,7 @@ type ChannelGraphRequest struct {
 func (m *ChannelGraphRequest) Reset()                    { *m = ChannelGraphRequest{} }
 func (m *ChannelGraphRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraphRequest) ProtoMessage()               {}
-func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
 
 // / Returns a new instance of the directed channel graph.
 type ChannelGraph struct {
@@ -3411,7 +4834,7 @@ type ChannelGraph struct {
 func (m *ChannelGraph) Reset()                    { *m = ChannelGraph{} }
 func (m *ChannelGraph) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraph) ProtoMessage()               {}
-func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
 
 func (m *ChannelGraph) GetNodes() []*LightningNode {
 	if m != nil {
@@ -3438,7 +4861,7 @@ type ChanInfoRequest struct {
 func (m *ChanInfoRequest) Reset()                    { *m = ChanInfoRequest{} }
 func (m *ChanInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChanInfoRequest) ProtoMessage()               {}
-func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
 
 func (m *ChanInfoRequest) GetChanId() uint64 {
 	if m != nil {
@@ -3453,7 +4876,7 @@ type NetworkInfoRequest struct {
 func (m *NetworkInfoRequest) Reset()                    { *m = NetworkInfoRequest{} }
 func (m *NetworkInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfoRequest) ProtoMessage()               {}
-func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
 
 type NetworkInfo struct {
 	GraphDiameter        uint32  `protobuf:"varint,1,opt,name=graph_diameter" json:"graph_diameter,omitempty"`
@@ -3470,7 +4893,7 @@ type NetworkInfo struct {
 func (m *NetworkInfo) Reset()                    { *m = NetworkInfo{} }
 func (m *NetworkInfo) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfo) ProtoMessage()               {}
-func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
 
 func (m *NetworkInfo) GetGraphDiameter() uint32 {
 	if m != nil {
@@ -3541,7 +4964,7 @@ type StopRequest struct {
 func (m *StopRequest) Reset()                    { *m = StopRequest{} }
 func (m *StopRequest) String() string            { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()               {}
-func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
 
 type StopResponse struct {
 }
@@ -3549,7 +4972,7 @@ type StopResponse struct {
 func (m *StopResponse) Reset()                    { *m = StopResponse{} }
 func (m *StopResponse) String() string            { return proto.CompactTextString(m) }
 func (*StopResponse) ProtoMessage()               {}
-func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
 
 type GraphTopologySubscription struct {
 }
@@ -3557,7 +4980,7 @@ type GraphTopologySubscription struct {
 func (m *GraphTopologySubscription) Reset()                    { *m = GraphTopologySubscription{} }
 func (m *GraphTopologySubscription) String() string            { return proto.CompactTextString(m) }
 func (*GraphTopologySubscription) ProtoMessage()               {}
-func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
 
 type GraphTopologyUpdate struct {
 	NodeUpdates    []*NodeUpdate          `protobuf:"bytes,1,rep,name=node_updates,json=nodeUpdates" json:"node_updates,omitempty"`
@@ -3568,7 +4991,7 @@ type GraphTopologyUpdate struct {
 func (m *GraphTopologyUpdate) Reset()                    { *m = GraphTopologyUpdate{} }
 func (m *GraphTopologyUpdate) String() string            { return proto.CompactTextString(m) }
 func (*GraphTopologyUpdate) ProtoMessage()               {}
-func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
 
 func (m *GraphTopologyUpdate) GetNodeUpdates() []*NodeUpdate {
 	if m != nil {
@@ -3601,7 +5024,7 @@ type NodeUpdate struct {
 func (m *NodeUpdate) Reset()                    { *m = NodeUpdate{} }
 func (m *NodeUpdate) String() string            { return proto.CompactTextString(m) }
 func (*NodeUpdate) ProtoMessage()               {}
-func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
 
 func (m *NodeUpdate) GetAddresses() []string {
 	if m != nil {
@@ -3647,7 +5070,7 @@ type ChannelEdgeUpdate struct {
 func (m *ChannelEdgeUpdate) Reset()                    { *m = ChannelEdgeUpdate{} }
 func (m *ChannelEdgeUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdgeUpdate) ProtoMessage()               {}
-func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
 
 func (m *ChannelEdgeUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -3705,7 +5128,7 @@ type ClosedChannelUpdate struct {
 func (m *ClosedChannelUpdate) Reset()                    { *m = ClosedChannelUpdate{} }
 func (m *ClosedChannelUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ClosedChannelUpdate) ProtoMessage()               {}
-func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
 
 func (m *ClosedChannelUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -3753,7 +5176,7 @@ type HopHint struct {
 func (m *HopHint) Reset()                    { *m = HopHint{} }
 func (m *HopHint) String() string            { return proto.CompactTextString(m) }
 func (*HopHint) ProtoMessage()               {}
-func (*HopHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+func (*HopHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
 
 func (m *HopHint) GetNodeId() string {
 	if m != nil {
@@ -3800,7 +5223,7 @@ type RouteHint struct {
 func (m *RouteHint) Reset()                    { *m = RouteHint{} }
 func (m *RouteHint) String() string            { return proto.CompactTextString(m) }
 func (*RouteHint) ProtoMessage()               {}
-func (*RouteHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+func (*RouteHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
 
 func (m *RouteHint) GetHopHints() []*HopHint {
 	if m != nil {
@@ -3889,7 +5312,7 @@ type Invoice struct {
 func (m *Invoice) Reset()                    { *m = Invoice{} }
 func (m *Invoice) String() string            { return proto.CompactTextString(m) }
 func (*Invoice) ProtoMessage()               {}
-func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
 
 func (m *Invoice) GetMemo() string {
 	if m != nil {
@@ -3898,476 +5321,902 @@ func (m *Invoice) GetMemo() string {
 	return ""
 }
 
-func (m *Invoice) GetReceipt() []byte {
-	if m != nil {
-		return m.Receipt
-	}
-	return nil
-}
+func (m *Invoice) GetReceipt() []byte {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+func (m *Invoice) GetRPreimage() []byte {
+	if m != nil {
+		return m.RPreimage
+	}
+	return nil
+}
+
+func (m *Invoice) GetRHash() []byte {
+	if m != nil {
+		return m.RHash
+	}
+	return nil
+}
+
+func (m *Invoice) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Invoice) GetSettled() bool {
+	if m != nil {
+		return m.Settled
+	}
+	return false
+}
+
+func (m *Invoice) GetCreationDate() int64 {
+	if m != nil {
+		return m.CreationDate
+	}
+	return 0
+}
+
+func (m *Invoice) GetSettleDate() int64 {
+	if m != nil {
+		return m.SettleDate
+	}
+	return 0
+}
+
+func (m *Invoice) GetPaymentRequest() string {
+	if m != nil {
+		return m.PaymentRequest
+	}
+	return ""
+}
+
+func (m *Invoice) GetDescriptionHash() []byte {
+	if m != nil {
+		return m.DescriptionHash
+	}
+	return nil
+}
+
+func (m *Invoice) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
+func (m *Invoice) GetFallbackAddr() string {
+	if m != nil {
+		return m.FallbackAddr
+	}
+	return ""
+}
+
+func (m *Invoice) GetCltvExpiry() uint64 {
+	if m != nil {
+		return m.CltvExpiry
+	}
+	return 0
+}
+
+func (m *Invoice) GetRouteHints() []*RouteHint {
+	if m != nil {
+		return m.RouteHints
+	}
+	return nil
+}
+
+func (m *Invoice) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *Invoice) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+func (m *Invoice) GetSettleIndex() uint64 {
+	if m != nil {
+		return m.SettleIndex
+	}
+	return 0
+}
+
+func (m *Invoice) GetAmtPaid() int64 {
+	if m != nil {
+		return m.AmtPaid
+	}
+	return 0
+}
+
+func (m *Invoice) GetAmtPaidSat() int64 {
+	if m != nil {
+		return m.AmtPaidSat
+	}
+	return 0
+}
+
+func (m *Invoice) GetAmtPaidMsat() int64 {
+	if m != nil {
+		return m.AmtPaidMsat
+	}
+	return 0
+}
+
+type AddInvoiceResponse struct {
+	RHash []byte `protobuf:"bytes,1,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
+	// *
+	// A bare-bones invoice for a payment within the Lightning Network.  With the
+	// details of the invoice, the sender has all the data necessary to send a
+	// payment to the recipient.
+	PaymentRequest string `protobuf:"bytes,2,opt,name=payment_request" json:"payment_request,omitempty"`
+	// *
+	// The "add" index of this invoice. Each newly created invoice will increment
+	// this index making it monotonically increasing. Callers to the
+	// SubscribeInvoices call can use this to instantly get notified of all added
+	// invoices with an add_index greater than this one.
+	AddIndex uint64 `protobuf:"varint,16,opt,name=add_index" json:"add_index,omitempty"`
+}
+
+func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
+func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddInvoiceResponse) ProtoMessage()               {}
+func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
+
+func (m *AddInvoiceResponse) GetRHash() []byte {
+	if m != nil {
+		return m.RHash
+	}
+	return nil
+}
+
+func (m *AddInvoiceResponse) GetPaymentRequest() string {
+	if m != nil {
+		return m.PaymentRequest
+	}
+	return ""
+}
+
+func (m *AddInvoiceResponse) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+type PaymentHash struct {
+	// *
+	// The hex-encoded payment hash of the invoice to be looked up. The passed
+	// payment hash must be exactly 32 bytes, otherwise an error is returned.
+	RHashStr string `protobuf:"bytes,1,opt,name=r_hash_str" json:"r_hash_str,omitempty"`
+	// / The payment hash of the invoice to be looked up.
+	RHash []byte `protobuf:"bytes,2,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
+}
+
+func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
+func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
+func (*PaymentHash) ProtoMessage()               {}
+func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
+
+func (m *PaymentHash) GetRHashStr() string {
+	if m != nil {
+		return m.RHashStr
+	}
+	return ""
+}
+
+func (m *PaymentHash) GetRHash() []byte {
+	if m != nil {
+		return m.RHash
+	}
+	return nil
+}
+
+type ListInvoiceRequest struct {
+	// / If set, only unsettled invoices will be returned in the response.
+	PendingOnly bool `protobuf:"varint,1,opt,name=pending_only" json:"pending_only,omitempty"`
+	// *
+	// The index of an invoice that will be used as either the start or end of a
+	// query to determine which invoices should be returned in the response.
+	IndexOffset uint64 `protobuf:"varint,4,opt,name=index_offset" json:"index_offset,omitempty"`
+	// / The max number of invoices to return in the response to this query.
+	NumMaxInvoices uint64 `protobuf:"varint,5,opt,name=num_max_invoices" json:"num_max_invoices,omitempty"`
+	// *
+	// If set, the invoices returned will result from seeking backwards from the
+	// specified index offset. This can be used to paginate backwards.
+	Reversed bool `protobuf:"varint,6,opt,name=reversed" json:"reversed,omitempty"`
+}
+
+func (m *ListInvoiceRequest) Reset()                    { *m = ListInvoiceRequest{} }
+func (m *ListInvoiceRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListInvoiceRequest) ProtoMessage()               {}
+func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
+
+func (m *ListInvoiceRequest) GetPendingOnly() bool {
+	if m != nil {
+		return m.PendingOnly
+	}
+	return false
+}
+
+func (m *ListInvoiceRequest) GetIndexOffset() uint64 {
+	if m != nil {
+		return m.IndexOffset
+	}
+	return 0
+}
+
+func (m *ListInvoiceRequest) GetNumMaxInvoices() uint64 {
+	if m != nil {
+		return m.NumMaxInvoices
+	}
+	return 0
+}
+
+func (m *ListInvoiceRequest) GetReversed() bool {
+	if m != nil {
+		return m.Reversed
+	}
+	return false
+}
+
+type ListInvoiceResponse struct {
+	// *
+	// A list of invoices from the time slice of the time series specified in the
+	// request.
+	Invoices []*Invoice `protobuf:"bytes,1,rep,name=invoices" json:"invoices,omitempty"`
+	// *
+	// The index of the last item in the set of returned invoices. This can be used
+	// to seek further, pagination style.
+	LastIndexOffset uint64 `protobuf:"varint,2,opt,name=last_index_offset" json:"last_index_offset,omitempty"`
+	// *
+	// The index of the last item in the set of returned invoices. This can be used
+	// to seek backwards, pagination style.
+	FirstIndexOffset uint64 `protobuf:"varint,3,opt,name=first_index_offset" json:"first_index_offset,omitempty"`
+}
+
+func (m *ListInvoiceResponse) Reset()                    { *m = ListInvoiceResponse{} }
+func (m *ListInvoiceResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListInvoiceResponse) ProtoMessage()               {}
+func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
+
+func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
+	if m != nil {
+		return m.Invoices
+	}
+	return nil
+}
+
+func (m *ListInvoiceResponse) GetLastIndexOffset() uint64 {
+	if m != nil {
+		return m.LastIndexOffset
+	}
+	return 0
+}
+
+func (m *ListInvoiceResponse) GetFirstIndexOffset() uint64 {
+	if m != nil {
+		return m.FirstIndexOffset
+	}
+	return 0
+}
+
+type InvoiceSubscription struct {
+	// *
+	// If specified (non-zero), then we'll first start by sending out
+	// notifications for all added indexes with an add_index greater than this
+	// value. This allows callers to catch up on any events they missed while they
+	// weren't connected to the streaming RPC.
+	AddIndex uint64 `protobuf:"varint,1,opt,name=add_index" json:"add_index,omitempty"`
+	// *
+	// If specified (non-zero), then we'll first start by sending out
+	// notifications for all settled indexes with an settle_index greater than
+	// this value. This allows callers to catch up on any events they missed while
+	// they weren't connected to the streaming RPC.
+	SettleIndex uint64 `protobuf:"varint,2,opt,name=settle_index" json:"settle_index,omitempty"`
+}
+
+func (m *InvoiceSubscription) Reset()                    { *m = InvoiceSubscription{} }
+func (m *InvoiceSubscription) String() string            { return proto.CompactTextString(m) }
+func (*InvoiceSubscription) ProtoMessage()               {}
+func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
+
+func (m *InvoiceSubscription) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+func (m *InvoiceSubscription) GetSettleIndex() uint64 {
+	if m != nil {
+		return m.SettleIndex
+	}
+	return 0
+}
+
+type Payment struct {
+	// / The payment hash
+	PaymentHash string `protobuf:"bytes,1,opt,name=payment_hash" json:"payment_hash,omitempty"`
+	// / Deprecated, use value_sat or value_msat.
+	Value int64 `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
+	// / The date of this payment
+	CreationDate int64 `protobuf:"varint,3,opt,name=creation_date" json:"creation_date,omitempty"`
+	// / The path this payment took
+	Path []string `protobuf:"bytes,4,rep,name=path" json:"path,omitempty"`
+	// / The fee paid for this payment in satoshis
+	Fee int64 `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
+	// / The payment preimage
+	PaymentPreimage string `protobuf:"bytes,6,opt,name=payment_preimage" json:"payment_preimage,omitempty"`
+	// / The value of the payment in satoshis
+	ValueSat int64 `protobuf:"varint,7,opt,name=value_sat" json:"value_sat,omitempty"`
+	// / The value of the payment in milli-satoshis
+	ValueMsat int64 `protobuf:"varint,8,opt,name=value_msat" json:"value_msat,omitempty"`
+}
+
+func (m *Payment) Reset()                    { *m = Payment{} }
+func (m *Payment) String() string            { return proto.CompactTextString(m) }
+func (*Payment) ProtoMessage()               {}
+func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
+
+func (m *Payment) GetPaymentHash() string {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return ""
+}
+
+func (m *Payment) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Payment) GetCreationDate() int64 {
+	if m != nil {
+		return m.CreationDate
+	}
+	return 0
+}
+
+func (m *Payment) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *Payment) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *Payment) GetPaymentPreimage() string {
+	if m != nil {
+		return m.PaymentPreimage
+	}
+	return ""
+}
+
+func (m *Payment) GetValueSat() int64 {
+	if m != nil {
+		return m.ValueSat
+	}
+	return 0
+}
+
+func (m *Payment) GetValueMsat() int64 {
+	if m != nil {
+		return m.ValueMsat
+	}
+	return 0
+}
+
+type ListPaymentsRequest struct {
+}
+
+func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
+func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPaymentsRequest) ProtoMessage()               {}
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
+
+type ListPaymentsResponse struct {
+	// / The list of payments
+	Payments []*Payment `protobuf:"bytes,1,rep,name=payments" json:"payments,omitempty"`
+}
+
+func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
+func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListPaymentsResponse) ProtoMessage()               {}
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
+
+func (m *ListPaymentsResponse) GetPayments() []*Payment {
+	if m != nil {
+		return m.Payments
+	}
+	return nil
+}
+
+type DeleteAllPaymentsRequest struct {
+}
+
+func (m *DeleteAllPaymentsRequest) Reset()                    { *m = DeleteAllPaymentsRequest{} }
+func (m *DeleteAllPaymentsRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAllPaymentsRequest) ProtoMessage()               {}
+func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
+
+type DeleteAllPaymentsResponse struct {
+}
+
+func (m *DeleteAllPaymentsResponse) Reset()                    { *m = DeleteAllPaymentsResponse{} }
+func (m *DeleteAllPaymentsResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAllPaymentsResponse) ProtoMessage()               {}
+func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
+
+type AbandonChannelRequest struct {
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+}
+
+func (m *AbandonChannelRequest) Reset()                    { *m = AbandonChannelRequest{} }
+func (m *AbandonChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*AbandonChannelRequest) ProtoMessage()               {}
+func (*AbandonChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
+
+func (m *AbandonChannelRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+type AbandonChannelResponse struct {
+}
+
+func (m *AbandonChannelResponse) Reset()                    { *m = AbandonChannelResponse{} }
+func (m *AbandonChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*AbandonChannelResponse) ProtoMessage()               {}
+func (*AbandonChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
+
+type DebugChannelStateRequest struct {
+	// / The outpoint (txid:index) of the funding transaction of the channel to dump.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+}
+
+func (m *DebugChannelStateRequest) Reset()                    { *m = DebugChannelStateRequest{} }
+func (m *DebugChannelStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*DebugChannelStateRequest) ProtoMessage()               {}
+func (*DebugChannelStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
 
-func (m *Invoice) GetRPreimage() []byte {
+func (m *DebugChannelStateRequest) GetChannelPoint() *ChannelPoint {
 	if m != nil {
-		return m.RPreimage
+		return m.ChannelPoint
 	}
 	return nil
 }
 
-func (m *Invoice) GetRHash() []byte {
-	if m != nil {
-		return m.RHash
-	}
-	return nil
+type DebugHTLC struct {
+	// / The index of the HTLC within the update log of the party that offered it
+	HtlcIndex uint64 `protobuf:"varint,1,opt,name=htlc_index" json:"htlc_index,omitempty"`
+	// / The log index of the update that added the HTLC
+	LogIndex uint64 `protobuf:"varint,2,opt,name=log_index" json:"log_index,omitempty"`
+	// / The amount of the HTLC in millisatoshis
+	AmountMsat int64 `protobuf:"varint,3,opt,name=amount_msat" json:"amount_msat,omitempty"`
+	// / The absolute block height at which the HTLC expires
+	Expiry uint32 `protobuf:"varint,4,opt,name=expiry" json:"expiry,omitempty"`
+	// / Whether the HTLC was offered to us by the remote party
+	Incoming bool `protobuf:"varint,5,opt,name=incoming" json:"incoming,omitempty"`
+	// / The payment hash of the HTLC
+	HashLock []byte `protobuf:"bytes,6,opt,name=hash_lock,proto3" json:"hash_lock,omitempty"`
+	// / The index of the HTLC output on the commitment transaction, or -1 if it is dust
+	OutputIndex int32 `protobuf:"varint,7,opt,name=output_index" json:"output_index,omitempty"`
 }
 
-func (m *Invoice) GetValue() int64 {
+func (m *DebugHTLC) Reset()                    { *m = DebugHTLC{} }
+func (m *DebugHTLC) String() string            { return proto.CompactTextString(m) }
+func (*DebugHTLC) ProtoMessage()               {}
+func (*DebugHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
+
+func (m *DebugHTLC) GetHtlcIndex() uint64 {
 	if m != nil {
-		return m.Value
+		return m.HtlcIndex
 	}
 	return 0
 }
 
-func (m *Invoice) GetSettled() bool {
+func (m *DebugHTLC) GetLogIndex() uint64 {
 	if m != nil {
-		return m.Settled
+		return m.LogIndex
 	}
-	return false
+	return 0
 }
 
-func (m *Invoice) GetCreationDate() int64 {
+func (m *DebugHTLC) GetAmountMsat() int64 {
 	if m != nil {
-		return m.CreationDate
+		return m.AmountMsat
 	}
 	return 0
 }
 
-func (m *Invoice) GetSettleDate() int64 {
+func (m *DebugHTLC) GetExpiry() uint32 {
 	if m != nil {
-		return m.SettleDate
+		return m.Expiry
 	}
 	return 0
 }
 
-func (m *Invoice) GetPaymentRequest() string {
+func (m *DebugHTLC) GetIncoming() bool {
 	if m != nil {
-		return m.PaymentRequest
+		return m.Incoming
 	}
-	return ""
+	return false
 }
 
-func (m *Invoice) GetDescriptionHash() []byte {
+func (m *DebugHTLC) GetHashLock() []byte {
 	if m != nil {
-		return m.DescriptionHash
+		return m.HashLock
 	}
 	return nil
 }
 
-func (m *Invoice) GetExpiry() int64 {
+func (m *DebugHTLC) GetOutputIndex() int32 {
 	if m != nil {
-		return m.Expiry
+		return m.OutputIndex
 	}
 	return 0
 }
 
-func (m *Invoice) GetFallbackAddr() string {
-	if m != nil {
-		return m.FallbackAddr
-	}
-	return ""
+type DebugCommitment struct {
+	// / The height of the commitment
+	CommitHeight uint64 `protobuf:"varint,1,opt,name=commit_height" json:"commit_height,omitempty"`
+	// / The serialized commitment transaction in hex
+	CommitTx string `protobuf:"bytes,2,opt,name=commit_tx" json:"commit_tx,omitempty"`
+	// / Our balance on the commitment in millisatoshis
+	LocalBalanceMsat int64 `protobuf:"varint,3,opt,name=local_balance_msat" json:"local_balance_msat,omitempty"`
+	// / The balance of the remote party on the commitment in millisatoshis
+	RemoteBalanceMsat int64 `protobuf:"varint,4,opt,name=remote_balance_msat" json:"remote_balance_msat,omitempty"`
+	// / The fee paid by the commitment transaction
+	CommitFee int64 `protobuf:"varint,5,opt,name=commit_fee" json:"commit_fee,omitempty"`
+	// / The fee rate of the commitment transaction in sat/kw
+	FeePerKw int64 `protobuf:"varint,6,opt,name=fee_per_kw" json:"fee_per_kw,omitempty"`
+	// / The index of our update log this commitment includes updates up to
+	LocalLogIndex uint64 `protobuf:"varint,7,opt,name=local_log_index" json:"local_log_index,omitempty"`
+	// / The next HTLC index we'll use within our update log
+	LocalHtlcIndex uint64 `protobuf:"varint,8,opt,name=local_htlc_index" json:"local_htlc_index,omitempty"`
+	// / The index of the remote update log this commitment includes updates up to
+	RemoteLogIndex uint64 `protobuf:"varint,9,opt,name=remote_log_index" json:"remote_log_index,omitempty"`
+	// / The next HTLC index the remote party will use within their update log
+	RemoteHtlcIndex uint64 `protobuf:"varint,10,opt,name=remote_htlc_index" json:"remote_htlc_index,omitempty"`
+	// / The HTLCs active on the commitment
+	Htlcs []*DebugHTLC `protobuf:"bytes,11,rep,name=htlcs" json:"htlcs,omitempty"`
 }
 
-func (m *Invoice) GetCltvExpiry() uint64 {
-	if m != nil {
-		return m.CltvExpiry
-	}
-	return 0
-}
+func (m *DebugCommitment) Reset()                    { *m = DebugCommitment{} }
+func (m *DebugCommitment) String() string            { return proto.CompactTextString(m) }
+func (*DebugCommitment) ProtoMessage()               {}
+func (*DebugCommitment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
 
-func (m *Invoice) GetRouteHints() []*RouteHint {
+func (m *DebugCommitment) GetCommitHeight() uint64 {
 	if m != nil {
-		return m.RouteHints
+		return m.CommitHeight
 	}
-	return nil
+	return 0
 }
 
-func (m *Invoice) GetPrivate() bool {
+func (m *DebugCommitment) GetCommitTx() string {
 	if m != nil {
-		return m.Private
+		return m.CommitTx
 	}
-	return false
+	return ""
 }
 
-func (m *Invoice) GetAddIndex() uint64 {
+func (m *DebugCommitment) GetLocalBalanceMsat() int64 {
 	if m != nil {
-		return m.AddIndex
+		return m.LocalBalanceMsat
 	}
 	return 0
 }
 
-func (m *Invoice) GetSettleIndex() uint64 {
+func (m *DebugCommitment) GetRemoteBalanceMsat() int64 {
 	if m != nil {
-		return m.SettleIndex
+		return m.RemoteBalanceMsat
 	}
 	return 0
 }
 
-func (m *Invoice) GetAmtPaid() int64 {
+func (m *DebugCommitment) GetCommitFee() int64 {
 	if m != nil {
-		return m.AmtPaid
+		return m.CommitFee
 	}
 	return 0
 }
 
-func (m *Invoice) GetAmtPaidSat() int64 {
+func (m *DebugCommitment) GetFeePerKw() int64 {
 	if m != nil {
-		return m.AmtPaidSat
+		return m.FeePerKw
 	}
 	return 0
 }
 
-func (m *Invoice) GetAmtPaidMsat() int64 {
+func (m *DebugCommitment) GetLocalLogIndex() uint64 {
 	if m != nil {
-		return m.AmtPaidMsat
+		return m.LocalLogIndex
 	}
 	return 0
 }
 
-type AddInvoiceResponse struct {
-	RHash []byte `protobuf:"bytes,1,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
-	// *
-	// A bare-bones invoice for a payment within the Lightning Network.  With the
-	// details of the invoice, the sender has all the data necessary to send a
-	// payment to the recipient.
-	PaymentRequest string `protobuf:"bytes,2,opt,name=payment_request" json:"payment_request,omitempty"`
-	// *
-	// The "add" index of this invoice. Each newly created invoice will increment
-	// this index making it monotonically increasing. Callers to the
-	// SubscribeInvoices call can use this to instantly get notified of all added
-	// invoices with an add_index greater than this one.
-	AddIndex uint64 `protobuf:"varint,16,opt,name=add_index" json:"add_index,omitempty"`
-}
-
-func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
-func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
-func (*AddInvoiceResponse) ProtoMessage()               {}
-func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
-
-func (m *AddInvoiceResponse) GetRHash() []byte {
-	if m != nil {
-		return m.RHash
-	}
-	return nil
-}
-
-func (m *AddInvoiceResponse) GetPaymentRequest() string {
+func (m *DebugCommitment) GetLocalHtlcIndex() uint64 {
 	if m != nil {
-		return m.PaymentRequest
+		return m.LocalHtlcIndex
 	}
-	return ""
+	return 0
 }
 
-func (m *AddInvoiceResponse) GetAddIndex() uint64 {
+func (m *DebugCommitment) GetRemoteLogIndex() uint64 {
 	if m != nil {
-		return m.AddIndex
+		return m.RemoteLogIndex
 	}
 	return 0
 }
 
-type PaymentHash struct {
-	// *
-	// The hex-encoded payment hash of the invoice to be looked up. The passed
-	// payment hash must be exactly 32 bytes, otherwise an error is returned.
-	RHashStr string `protobuf:"bytes,1,opt,name=r_hash_str" json:"r_hash_str,omitempty"`
-	// / The payment hash of the invoice to be looked up.
-	RHash []byte `protobuf:"bytes,2,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
-}
-
-func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
-func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
-func (*PaymentHash) ProtoMessage()               {}
-func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
-
-func (m *PaymentHash) GetRHashStr() string {
+func (m *DebugCommitment) GetRemoteHtlcIndex() uint64 {
 	if m != nil {
-		return m.RHashStr
+		return m.RemoteHtlcIndex
 	}
-	return ""
+	return 0
 }
 
-func (m *PaymentHash) GetRHash() []byte {
+func (m *DebugCommitment) GetHtlcs() []*DebugHTLC {
 	if m != nil {
-		return m.RHash
+		return m.Htlcs
 	}
 	return nil
 }
 
-type ListInvoiceRequest struct {
-	// / If set, only unsettled invoices will be returned in the response.
-	PendingOnly bool `protobuf:"varint,1,opt,name=pending_only" json:"pending_only,omitempty"`
-	// *
-	// The index of an invoice that will be used as either the start or end of a
-	// query to determine which invoices should be returned in the response.
-	IndexOffset uint64 `protobuf:"varint,4,opt,name=index_offset" json:"index_offset,omitempty"`
-	// / The max number of invoices to return in the response to this query.
-	NumMaxInvoices uint64 `protobuf:"varint,5,opt,name=num_max_invoices" json:"num_max_invoices,omitempty"`
-	// *
-	// If set, the invoices returned will result from seeking backwards from the
-	// specified index offset. This can be used to paginate backwards.
-	Reversed bool `protobuf:"varint,6,opt,name=reversed" json:"reversed,omitempty"`
+type DebugLogUpdate struct {
+	// / The index of the update within its update log
+	LogIndex uint64 `protobuf:"varint,1,opt,name=log_index" json:"log_index,omitempty"`
+	// / The type of the update message
+	MsgType string `protobuf:"bytes,2,opt,name=msg_type" json:"msg_type,omitempty"`
+	// / The HTLC index the update refers to
+	HtlcIndex uint64 `protobuf:"varint,3,opt,name=htlc_index" json:"htlc_index,omitempty"`
+	// / The amount of the added HTLC in millisatoshis, if the update adds an HTLC
+	AmountMsat int64 `protobuf:"varint,4,opt,name=amount_msat" json:"amount_msat,omitempty"`
 }
 
-func (m *ListInvoiceRequest) Reset()                    { *m = ListInvoiceRequest{} }
-func (m *ListInvoiceRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListInvoiceRequest) ProtoMessage()               {}
-func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+func (m *DebugLogUpdate) Reset()                    { *m = DebugLogUpdate{} }
+func (m *DebugLogUpdate) String() string            { return proto.CompactTextString(m) }
+func (*DebugLogUpdate) ProtoMessage()               {}
+func (*DebugLogUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
 
-func (m *ListInvoiceRequest) GetPendingOnly() bool {
+func (m *DebugLogUpdate) GetLogIndex() uint64 {
 	if m != nil {
-		return m.PendingOnly
+		return m.LogIndex
 	}
-	return false
+	return 0
 }
 
-func (m *ListInvoiceRequest) GetIndexOffset() uint64 {
+func (m *DebugLogUpdate) GetMsgType() string {
 	if m != nil {
-		return m.IndexOffset
+		return m.MsgType
 	}
-	return 0
+	return ""
 }
 
-func (m *ListInvoiceRequest) GetNumMaxInvoices() uint64 {
+func (m *DebugLogUpdate) GetHtlcIndex() uint64 {
 	if m != nil {
-		return m.NumMaxInvoices
+		return m.HtlcIndex
 	}
 	return 0
 }
 
-func (m *ListInvoiceRequest) GetReversed() bool {
+func (m *DebugLogUpdate) GetAmountMsat() int64 {
 	if m != nil {
-		return m.Reversed
+		return m.AmountMsat
 	}
-	return false
+	return 0
 }
 
-type ListInvoiceResponse struct {
-	// *
-	// A list of invoices from the time slice of the time series specified in the
-	// request.
-	Invoices []*Invoice `protobuf:"bytes,1,rep,name=invoices" json:"invoices,omitempty"`
-	// *
-	// The index of the last item in the set of returned invoices. This can be used
-	// to seek further, pagination style.
-	LastIndexOffset uint64 `protobuf:"varint,2,opt,name=last_index_offset" json:"last_index_offset,omitempty"`
-	// *
-	// The index of the last item in the set of returned invoices. This can be used
-	// to seek backwards, pagination style.
-	FirstIndexOffset uint64 `protobuf:"varint,3,opt,name=first_index_offset" json:"first_index_offset,omitempty"`
+type DebugForwardingPackage struct {
+	// / The short channel ID of the channel that received the updates
+	SourceChanId uint64 `protobuf:"varint,1,opt,name=source_chan_id" json:"source_chan_id,omitempty"`
+	// / The remote commitment height at which the updates were locked in
+	Height uint64 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+	// / The state of the forwarding package
+	State string `protobuf:"bytes,3,opt,name=state" json:"state,omitempty"`
+	// / The HTLCs added within the package
+	Adds []*DebugLogUpdate `protobuf:"bytes,4,rep,name=adds" json:"adds,omitempty"`
+	// / The settles and fails received within the package
+	SettleFails []*DebugLogUpdate `protobuf:"bytes,5,rep,name=settle_fails" json:"settle_fails,omitempty"`
+	// / The number of adds that were forwarded to the switch
+	NumAddsForwarded uint32 `protobuf:"varint,6,opt,name=num_adds_forwarded" json:"num_adds_forwarded,omitempty"`
+	// / The number of adds that have been acked
+	NumAddsAcked uint32 `protobuf:"varint,7,opt,name=num_adds_acked" json:"num_adds_acked,omitempty"`
+	// / The number of settles and fails that have been acked
+	NumSettleFailsAcked uint32 `protobuf:"varint,8,opt,name=num_settle_fails_acked" json:"num_settle_fails_acked,omitempty"`
 }
 
-func (m *ListInvoiceResponse) Reset()                    { *m = ListInvoiceResponse{} }
-func (m *ListInvoiceResponse) String() string            { return proto.CompactTextString(m) }
-func (*ListInvoiceResponse) ProtoMessage()               {}
-func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
-
-func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
-	if m != nil {
-		return m.Invoices
-	}
-	return nil
-}
+func (m *DebugForwardingPackage) Reset()                    { *m = DebugForwardingPackage{} }
+func (m *DebugForwardingPackage) String() string            { return proto.CompactTextString(m) }
+func (*DebugForwardingPackage) ProtoMessage()               {}
+func (*DebugForwardingPackage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{124} }
 
-func (m *ListInvoiceResponse) GetLastIndexOffset() uint64 {
+func (m *DebugForwardingPackage) GetSourceChanId() uint64 {
 	if m != nil {
-		return m.LastIndexOffset
+		return m.SourceChanId
 	}
 	return 0
 }
 
-func (m *ListInvoiceResponse) GetFirstIndexOffset() uint64 {
+func (m *DebugForwardingPackage) GetHeight() uint64 {
 	if m != nil {
-		return m.FirstIndexOffset
+		return m.Height
 	}
 	return 0
 }
 
-type InvoiceSubscription struct {
-	// *
-	// If specified (non-zero), then we'll first start by sending out
-	// notifications for all added indexes with an add_index greater than this
-	// value. This allows callers to catch up on any events they missed while they
-	// weren't connected to the streaming RPC.
-	AddIndex uint64 `protobuf:"varint,1,opt,name=add_index" json:"add_index,omitempty"`
-	// *
-	// If specified (non-zero), then we'll first start by sending out
-	// notifications for all settled indexes with an settle_index greater than
-	// this value. This allows callers to catch up on any events they missed while
-	// they weren't connected to the streaming RPC.
-	SettleIndex uint64 `protobuf:"varint,2,opt,name=settle_index" json:"settle_index,omitempty"`
-}
-
-func (m *InvoiceSubscription) Reset()                    { *m = InvoiceSubscription{} }
-func (m *InvoiceSubscription) String() string            { return proto.CompactTextString(m) }
-func (*InvoiceSubscription) ProtoMessage()               {}
-func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
-
-func (m *InvoiceSubscription) GetAddIndex() uint64 {
+func (m *DebugForwardingPackage) GetState() string {
 	if m != nil {
-		return m.AddIndex
+		return m.State
 	}
-	return 0
+	return ""
 }
 
-func (m *InvoiceSubscription) GetSettleIndex() uint64 {
+func (m *DebugForwardingPackage) GetAdds() []*DebugLogUpdate {
 	if m != nil {
-		return m.SettleIndex
+		return m.Adds
 	}
-	return 0
-}
-
-type Payment struct {
-	// / The payment hash
-	PaymentHash string `protobuf:"bytes,1,opt,name=payment_hash" json:"payment_hash,omitempty"`
-	// / Deprecated, use value_sat or value_msat.
-	Value int64 `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
-	// / The date of this payment
-	CreationDate int64 `protobuf:"varint,3,opt,name=creation_date" json:"creation_date,omitempty"`
-	// / The path this payment took
-	Path []string `protobuf:"bytes,4,rep,name=path" json:"path,omitempty"`
-	// / The fee paid for this payment in satoshis
-	Fee int64 `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
-	// / The payment preimage
-	PaymentPreimage string `protobuf:"bytes,6,opt,name=payment_preimage" json:"payment_preimage,omitempty"`
-	// / The value of the payment in satoshis
-	ValueSat int64 `protobuf:"varint,7,opt,name=value_sat" json:"value_sat,omitempty"`
-	// / The value of the payment in milli-satoshis
-	ValueMsat int64 `protobuf:"varint,8,opt,name=value_msat" json:"value_msat,omitempty"`
+	return nil
 }
 
-func (m *Payment) Reset()                    { *m = Payment{} }
-func (m *Payment) String() string            { return proto.CompactTextString(m) }
-func (*Payment) ProtoMessage()               {}
-func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
-
-func (m *Payment) GetPaymentHash() string {
+func (m *DebugForwardingPackage) GetSettleFails() []*DebugLogUpdate {
 	if m != nil {
-		return m.PaymentHash
+		return m.SettleFails
 	}
-	return ""
+	return nil
 }
 
-func (m *Payment) GetValue() int64 {
+func (m *DebugForwardingPackage) GetNumAddsForwarded() uint32 {
 	if m != nil {
-		return m.Value
+		return m.NumAddsForwarded
 	}
 	return 0
 }
 
-func (m *Payment) GetCreationDate() int64 {
+func (m *DebugForwardingPackage) GetNumAddsAcked() uint32 {
 	if m != nil {
-		return m.CreationDate
+		return m.NumAddsAcked
 	}
 	return 0
 }
 
-func (m *Payment) GetPath() []string {
+func (m *DebugForwardingPackage) GetNumSettleFailsAcked() uint32 {
 	if m != nil {
-		return m.Path
+		return m.NumSettleFailsAcked
 	}
-	return nil
+	return 0
 }
 
-func (m *Payment) GetFee() int64 {
+type DebugChannelStateResponse struct {
+	// / The outpoint (txid:index) of the funding transaction
+	ChannelPoint string `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+	// / The unique channel ID for the channel
+	ChanId uint64 `protobuf:"varint,2,opt,name=chan_id" json:"chan_id,omitempty"`
+	// / The identity pubkey of the remote node
+	RemotePubkey string `protobuf:"bytes,3,opt,name=remote_pubkey" json:"remote_pubkey,omitempty"`
+	// / The status flags of the channel
+	ChanStatus string `protobuf:"bytes,4,opt,name=chan_status" json:"chan_status,omitempty"`
+	// / Our latest commitment
+	LocalCommitment *DebugCommitment `protobuf:"bytes,5,opt,name=local_commitment" json:"local_commitment,omitempty"`
+	// / The latest commitment of the remote party that they have acked
+	RemoteCommitment *DebugCommitment `protobuf:"bytes,6,opt,name=remote_commitment" json:"remote_commitment,omitempty"`
+	// / A commitment we signed for the remote party that they haven't yet revoked their prior state for
+	PendingRemoteCommitment *DebugCommitment `protobuf:"bytes,7,opt,name=pending_remote_commitment" json:"pending_remote_commitment,omitempty"`
+	// / The updates included within the pending remote commitment
+	PendingRemoteLogUpdates []*DebugLogUpdate `protobuf:"bytes,8,rep,name=pending_remote_log_updates" json:"pending_remote_log_updates,omitempty"`
+	// / The height up to which our revocation producer has revealed revocation secrets
+	RevocationProducerHeight uint64 `protobuf:"varint,9,opt,name=revocation_producer_height" json:"revocation_producer_height,omitempty"`
+	// / The height of the latest revoked commitment of the remote party
+	RemoteRevocationHeight uint64 `protobuf:"varint,10,opt,name=remote_revocation_height" json:"remote_revocation_height,omitempty"`
+	// / All forwarding packages of the channel that have not yet been removed
+	ForwardingPackages []*DebugForwardingPackage `protobuf:"bytes,11,rep,name=forwarding_packages" json:"forwarding_packages,omitempty"`
+}
+
+func (m *DebugChannelStateResponse) Reset()                    { *m = DebugChannelStateResponse{} }
+func (m *DebugChannelStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*DebugChannelStateResponse) ProtoMessage()               {}
+func (*DebugChannelStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{125} }
+
+func (m *DebugChannelStateResponse) GetChannelPoint() string {
 	if m != nil {
-		return m.Fee
+		return m.ChannelPoint
 	}
-	return 0
+	return ""
 }
 
-func (m *Payment) GetPaymentPreimage() string {
+func (m *DebugChannelStateResponse) GetChanId() uint64 {
 	if m != nil {
-		return m.PaymentPreimage
+		return m.ChanId
 	}
-	return ""
+	return 0
 }
 
-func (m *Payment) GetValueSat() int64 {
+func (m *DebugChannelStateResponse) GetRemotePubkey() string {
 	if m != nil {
-		return m.ValueSat
+		return m.RemotePubkey
 	}
-	return 0
+	return ""
 }
 
-func (m *Payment) GetValueMsat() int64 {
+func (m *DebugChannelStateResponse) GetChanStatus() string {
 	if m != nil {
-		return m.ValueMsat
+		return m.ChanStatus
 	}
-	return 0
+	return ""
 }
 
-type ListPaymentsRequest struct {
+func (m *DebugChannelStateResponse) GetLocalCommitment() *DebugCommitment {
+	if m != nil {
+		return m.LocalCommitment
+	}
+	return nil
 }
 
-func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
-func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListPaymentsRequest) ProtoMessage()               {}
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
-
-type ListPaymentsResponse struct {
-	// / The list of payments
-	Payments []*Payment `protobuf:"bytes,1,rep,name=payments" json:"payments,omitempty"`
+func (m *DebugChannelStateResponse) GetRemoteCommitment() *DebugCommitment {
+	if m != nil {
+		return m.RemoteCommitment
+	}
+	return nil
 }
 
-func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
-func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
-func (*ListPaymentsResponse) ProtoMessage()               {}
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
-
-func (m *ListPaymentsResponse) GetPayments() []*Payment {
+func (m *DebugChannelStateResponse) GetPendingRemoteCommitment() *DebugCommitment {
 	if m != nil {
-		return m.Payments
+		return m.PendingRemoteCommitment
 	}
 	return nil
 }
 
-type DeleteAllPaymentsRequest struct {
+func (m *DebugChannelStateResponse) GetPendingRemoteLogUpdates() []*DebugLogUpdate {
+	if m != nil {
+		return m.PendingRemoteLogUpdates
+	}
+	return nil
 }
 
-func (m *DeleteAllPaymentsRequest) Reset()                    { *m = DeleteAllPaymentsRequest{} }
-func (m *DeleteAllPaymentsRequest) String() string            { return proto.CompactTextString(m) }
-func (*DeleteAllPaymentsRequest) ProtoMessage()               {}
-func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
-
-type DeleteAllPaymentsResponse struct {
+func (m *DebugChannelStateResponse) GetRevocationProducerHeight() uint64 {
+	if m != nil {
+		return m.RevocationProducerHeight
+	}
+	return 0
 }
 
-func (m *DeleteAllPaymentsResponse) Reset()                    { *m = DeleteAllPaymentsResponse{} }
-func (m *DeleteAllPaymentsResponse) String() string            { return proto.CompactTextString(m) }
-func (*DeleteAllPaymentsResponse) ProtoMessage()               {}
-func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
-
-type AbandonChannelRequest struct {
-	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+func (m *DebugChannelStateResponse) GetRemoteRevocationHeight() uint64 {
+	if m != nil {
+		return m.RemoteRevocationHeight
+	}
+	return 0
 }
 
-func (m *AbandonChannelRequest) Reset()                    { *m = AbandonChannelRequest{} }
-func (m *AbandonChannelRequest) String() string            { return proto.CompactTextString(m) }
-func (*AbandonChannelRequest) ProtoMessage()               {}
-func (*AbandonChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
-
-func (m *AbandonChannelRequest) GetChannelPoint() *ChannelPoint {
+func (m *DebugChannelStateResponse) GetForwardingPackages() []*DebugForwardingPackage {
 	if m != nil {
-		return m.ChannelPoint
+		return m.ForwardingPackages
 	}
 	return nil
 }
 
-type AbandonChannelResponse struct {
-}
-
-func (m *AbandonChannelResponse) Reset()                    { *m = AbandonChannelResponse{} }
-func (m *AbandonChannelResponse) String() string            { return proto.CompactTextString(m) }
-func (*AbandonChannelResponse) ProtoMessage()               {}
-func (*AbandonChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
-
 type DebugLevelRequest struct {
 	Show      bool   `protobuf:"varint,1,opt,name=show" json:"show,omitempty"`
 	LevelSpec string `protobuf:"bytes,2,opt,name=level_spec,json=levelSpec" json:"level_spec,omitempty"`
@@ -4376,7 +6225,7 @@ type DebugLevelRequest struct {
 func (m *DebugLevelRequest) Reset()                    { *m = DebugLevelRequest{} }
 func (m *DebugLevelRequest) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelRequest) ProtoMessage()               {}
-func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
+func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{126} }
 
 func (m *DebugLevelRequest) GetShow() bool {
 	if m != nil {
@@ -4399,7 +6248,7 @@ type DebugLevelResponse struct {
 func (m *DebugLevelResponse) Reset()                    { *m = DebugLevelResponse{} }
 func (m *DebugLevelResponse) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelResponse) ProtoMessage()               {}
-func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{127} }
 
 func (m *DebugLevelResponse) GetSubSystems() string {
 	if m != nil {
@@ -4416,7 +6265,7 @@ type PayReqString struct {
 func (m *PayReqString) Reset()                    { *m = PayReqString{} }
 func (m *PayReqString) String() string            { return proto.CompactTextString(m) }
 func (*PayReqString) ProtoMessage()               {}
-func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
+func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{128} }
 
 func (m *PayReqString) GetPayReq() string {
 	if m != nil {
@@ -4441,7 +6290,7 @@ type PayReq struct {
 func (m *PayReq) Reset()                    { *m = PayReq{} }
 func (m *PayReq) String() string            { return proto.CompactTextString(m) }
 func (*PayReq) ProtoMessage()               {}
-func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
+func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{129} }
 
 func (m *PayReq) GetDestination() string {
 	if m != nil {
@@ -4519,7 +6368,7 @@ type FeeReportRequest struct {
 func (m *FeeReportRequest) Reset()                    { *m = FeeReportRequest{} }
 func (m *FeeReportRequest) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportRequest) ProtoMessage()               {}
-func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
+func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{130} }
 
 type ChannelFeeReport struct {
 	// / The channel that this fee report belongs to.
@@ -4535,7 +6384,7 @@ type ChannelFeeReport struct {
 func (m *ChannelFeeReport) Reset()                    { *m = ChannelFeeReport{} }
 func (m *ChannelFeeReport) String() string            { return proto.CompactTextString(m) }
 func (*ChannelFeeReport) ProtoMessage()               {}
-func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{131} }
 
 func (m *ChannelFeeReport) GetChanPoint() string {
 	if m != nil {
@@ -4579,7 +6428,7 @@ type FeeReportResponse struct {
 func (m *FeeReportResponse) Reset()                    { *m = FeeReportResponse{} }
 func (m *FeeReportResponse) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportResponse) ProtoMessage()               {}
-func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
+func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{132} }
 
 func (m *FeeReportResponse) GetChannelFees() []*ChannelFeeReport {
 	if m != nil {
@@ -4625,7 +6474,7 @@ type PolicyUpdateRequest struct {
 func (m *PolicyUpdateRequest) Reset()                    { *m = PolicyUpdateRequest{} }
 func (m *PolicyUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*PolicyUpdateRequest) ProtoMessage()               {}
-func (*PolicyUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
+func (*PolicyUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{133} }
 
 type isPolicyUpdateRequest_Scope interface{ isPolicyUpdateRequest_Scope() }
 
@@ -4760,7 +6609,7 @@ type PolicyUpdateResponse struct {
 func (m *PolicyUpdateResponse) Reset()                    { *m = PolicyUpdateResponse{} }
 func (m *PolicyUpdateResponse) String() string            { return proto.CompactTextString(m) }
 func (*PolicyUpdateResponse) ProtoMessage()               {}
-func (*PolicyUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
+func (*PolicyUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{134} }
 
 type ForwardingHistoryRequest struct {
 	// / Start time is the starting point of the forwarding history request. All records beyond this point will be included, respecting the end time, and the index offset.
@@ -4776,7 +6625,7 @@ type ForwardingHistoryRequest struct {
 func (m *ForwardingHistoryRequest) Reset()                    { *m = ForwardingHistoryRequest{} }
 func (m *ForwardingHistoryRequest) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingHistoryRequest) ProtoMessage()               {}
-func (*ForwardingHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
+func (*ForwardingHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{135} }
 
 func (m *ForwardingHistoryRequest) GetStartTime() uint64 {
 	if m != nil {
@@ -4824,7 +6673,7 @@ type ForwardingEvent struct {
 func (m *ForwardingEvent) Reset()                    { *m = ForwardingEvent{} }
 func (m *ForwardingEvent) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingEvent) ProtoMessage()               {}
-func (*ForwardingEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
+func (*ForwardingEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{136} }
 
 func (m *ForwardingEvent) GetTimestamp() uint64 {
 	if m != nil {
@@ -4878,7 +6727,7 @@ type ForwardingHistoryResponse struct {
 func (m *ForwardingHistoryResponse) Reset()                    { *m = ForwardingHistoryResponse{} }
 func (m *ForwardingHistoryResponse) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingHistoryResponse) ProtoMessage()               {}
-func (*ForwardingHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
+func (*ForwardingHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{137} }
 
 func (m *ForwardingHistoryResponse) GetForwardingEvents() []*ForwardingEvent {
 	if m != nil {
@@ -4894,6 +6743,259 @@ func (m *ForwardingHistoryResponse) GetLastOffsetIndex() uint32 {
 	return 0
 }
 
+type ExportChannelBackupRequest struct {
+	// / The target channel point to obtain a back up for.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point,json=chanPoint" json:"chan_point,omitempty"`
+}
+
+func (m *ExportChannelBackupRequest) Reset()                    { *m = ExportChannelBackupRequest{} }
+func (m *ExportChannelBackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportChannelBackupRequest) ProtoMessage()               {}
+func (*ExportChannelBackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{138} }
+
+func (m *ExportChannelBackupRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+type ChannelBackup struct {
+	// *
+	// Identifies the channel that this backup belongs to.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+	// *
+	// Is an encrypted single-chan backup. This can be passed to
+	// RestoreChannelBackups in order to trigger the recovery protocol.
+	ChanBackup []byte `protobuf:"bytes,2,opt,name=chan_backup,proto3" json:"chan_backup,omitempty"`
+}
+
+func (m *ChannelBackup) Reset()                    { *m = ChannelBackup{} }
+func (m *ChannelBackup) String() string            { return proto.CompactTextString(m) }
+func (*ChannelBackup) ProtoMessage()               {}
+func (*ChannelBackup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{139} }
+
+func (m *ChannelBackup) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *ChannelBackup) GetChanBackup() []byte {
+	if m != nil {
+		return m.ChanBackup
+	}
+	return nil
+}
+
+type MultiChanBackup struct {
+	// *
+	// Is the set of all channels that are included in this multi-channel backup.
+	ChanPoints []*ChannelPoint `protobuf:"bytes,1,rep,name=chan_points" json:"chan_points,omitempty"`
+	// *
+	// A single encrypted blob containing all the static channel backups of the
+	// channels listed above. This can be stored as a single file or blob, and
+	// safely be replaced with any prior/future versions.
+	MultiChanBackup []byte `protobuf:"bytes,2,opt,name=multi_chan_backup,proto3" json:"multi_chan_backup,omitempty"`
+}
+
+func (m *MultiChanBackup) Reset()                    { *m = MultiChanBackup{} }
+func (m *MultiChanBackup) String() string            { return proto.CompactTextString(m) }
+func (*MultiChanBackup) ProtoMessage()               {}
+func (*MultiChanBackup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{140} }
+
+func (m *MultiChanBackup) GetChanPoints() []*ChannelPoint {
+	if m != nil {
+		return m.ChanPoints
+	}
+	return nil
+}
+
+func (m *MultiChanBackup) GetMultiChanBackup() []byte {
+	if m != nil {
+		return m.MultiChanBackup
+	}
+	return nil
+}
+
+type ChanBackupSnapshot struct {
+	// *
+	// A set of single-chan static channel backups to verify.
+	SingleChanBackups *ChannelBackups `protobuf:"bytes,1,opt,name=single_chan_backups" json:"single_chan_backups,omitempty"`
+	// *
+	// A multi-channel backup to verify.
+	MultiChanBackup *MultiChanBackup `protobuf:"bytes,2,opt,name=multi_chan_backup" json:"multi_chan_backup,omitempty"`
+}
+
+func (m *ChanBackupSnapshot) Reset()                    { *m = ChanBackupSnapshot{} }
+func (m *ChanBackupSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*ChanBackupSnapshot) ProtoMessage()               {}
+func (*ChanBackupSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{141} }
+
+func (m *ChanBackupSnapshot) GetSingleChanBackups() *ChannelBackups {
+	if m != nil {
+		return m.SingleChanBackups
+	}
+	return nil
+}
+
+func (m *ChanBackupSnapshot) GetMultiChanBackup() *MultiChanBackup {
+	if m != nil {
+		return m.MultiChanBackup
+	}
+	return nil
+}
+
+type ChannelBackups struct {
+	// *
+	// A set of single-chan static channel backups.
+	ChanBackups []*ChannelBackup `protobuf:"bytes,1,rep,name=chan_backups" json:"chan_backups,omitempty"`
+}
+
+func (m *ChannelBackups) Reset()                    { *m = ChannelBackups{} }
+func (m *ChannelBackups) String() string            { return proto.CompactTextString(m) }
+func (*ChannelBackups) ProtoMessage()               {}
+func (*ChannelBackups) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{142} }
+
+func (m *ChannelBackups) GetChanBackups() []*ChannelBackup {
+	if m != nil {
+		return m.ChanBackups
+	}
+	return nil
+}
+
+type RestoreChanBackupRequest struct {
+	// Types that are valid to be assigned to Backup:
+	//	*RestoreChanBackupRequest_ChanBackups
+	//	*RestoreChanBackupRequest_MultiChanBackup
+	Backup isRestoreChanBackupRequest_Backup `protobuf_oneof:"backup"`
+}
+
+func (m *RestoreChanBackupRequest) Reset()                    { *m = RestoreChanBackupRequest{} }
+func (m *RestoreChanBackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*RestoreChanBackupRequest) ProtoMessage()               {}
+func (*RestoreChanBackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{143} }
+
+type isRestoreChanBackupRequest_Backup interface{ isRestoreChanBackupRequest_Backup() }
+
+type RestoreChanBackupRequest_ChanBackups struct {
+	ChanBackups *ChannelBackups `protobuf:"bytes,1,opt,name=chan_backups,oneof"`
+}
+type RestoreChanBackupRequest_MultiChanBackup struct {
+	MultiChanBackup []byte `protobuf:"bytes,2,opt,name=multi_chan_backup,proto3,oneof"`
+}
+
+func (*RestoreChanBackupRequest_ChanBackups) isRestoreChanBackupRequest_Backup()     {}
+func (*RestoreChanBackupRequest_MultiChanBackup) isRestoreChanBackupRequest_Backup() {}
+
+func (m *RestoreChanBackupRequest) GetBackup() isRestoreChanBackupRequest_Backup {
+	if m != nil {
+		return m.Backup
+	}
+	return nil
+}
+
+func (m *RestoreChanBackupRequest) GetChanBackups() *ChannelBackups {
+	if x, ok := m.GetBackup().(*RestoreChanBackupRequest_ChanBackups); ok {
+		return x.ChanBackups
+	}
+	return nil
+}
+
+func (m *RestoreChanBackupRequest) GetMultiChanBackup() []byte {
+	if x, ok := m.GetBackup().(*RestoreChanBackupRequest_MultiChanBackup); ok {
+		return x.MultiChanBackup
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RestoreChanBackupRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RestoreChanBackupRequest_OneofMarshaler, _RestoreChanBackupRequest_OneofUnmarshaler, _RestoreChanBackupRequest_OneofSizer, []interface{}{
+		(*RestoreChanBackupRequest_ChanBackups)(nil),
+		(*RestoreChanBackupRequest_MultiChanBackup)(nil),
+	}
+}
+
+func _RestoreChanBackupRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RestoreChanBackupRequest)
+	// backup
+	switch x := m.Backup.(type) {
+	case *RestoreChanBackupRequest_ChanBackups:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChanBackups); err != nil {
+			return err
+		}
+	case *RestoreChanBackupRequest_MultiChanBackup:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeRawBytes(x.MultiChanBackup)
+	case nil:
+	default:
+		return fmt.Errorf("RestoreChanBackupRequest.Backup has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _RestoreChanBackupRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RestoreChanBackupRequest)
+	switch tag {
+	case 1: // backup.chan_backups
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelBackups)
+		err := b.DecodeMessage(msg)
+		m.Backup = &RestoreChanBackupRequest_ChanBackups{msg}
+		return true, err
+	case 2: // backup.multi_chan_backup
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeRawBytes(true)
+		m.Backup = &RestoreChanBackupRequest_MultiChanBackup{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _RestoreChanBackupRequest_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RestoreChanBackupRequest)
+	// backup
+	switch x := m.Backup.(type) {
+	case *RestoreChanBackupRequest_ChanBackups:
+		s := proto.Size(x.ChanBackups)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RestoreChanBackupRequest_MultiChanBackup:
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.MultiChanBackup)))
+		n += len(x.MultiChanBackup)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type RestoreBackupResponse struct {
+}
+
+func (m *RestoreBackupResponse) Reset()                    { *m = RestoreBackupResponse{} }
+func (m *RestoreBackupResponse) String() string            { return proto.CompactTextString(m) }
+func (*RestoreBackupResponse) ProtoMessage()               {}
+func (*RestoreBackupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{144} }
+
+type VerifyChanBackupResponse struct {
+}
+
+func (m *VerifyChanBackupResponse) Reset()                    { *m = VerifyChanBackupResponse{} }
+func (m *VerifyChanBackupResponse) String() string            { return proto.CompactTextString(m) }
+func (*VerifyChanBackupResponse) ProtoMessage()               {}
+func (*VerifyChanBackupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{145} }
+
 func init() {
 	proto.RegisterType((*GenSeedRequest)(nil), "lnrpc.GenSeedRequest")
 	proto.RegisterType((*GenSeedResponse)(nil), "lnrpc.GenSeedResponse")
@@ -4905,6 +7007,8 @@ func init() {
 	proto.RegisterType((*ChangePasswordResponse)(nil), "lnrpc.ChangePasswordResponse")
 	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
 	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
+	proto.RegisterType((*LabelTransactionRequest)(nil), "lnrpc.LabelTransactionRequest")
+	proto.RegisterType((*LabelTransactionResponse)(nil), "lnrpc.LabelTransactionResponse")
 	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
 	proto.RegisterType((*FeeLimit)(nil), "lnrpc.FeeLimit")
 	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
@@ -4916,8 +7020,18 @@ func init() {
 	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
 	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
 	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
+	proto.RegisterType((*OutPoint)(nil), "lnrpc.OutPoint")
+	proto.RegisterType((*BumpFeeRequest)(nil), "lnrpc.BumpFeeRequest")
+	proto.RegisterType((*BumpFeeResponse)(nil), "lnrpc.BumpFeeResponse")
 	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
 	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
+	proto.RegisterType((*ListUnspentRequest)(nil), "lnrpc.ListUnspentRequest")
+	proto.RegisterType((*ListUnspentResponse)(nil), "lnrpc.ListUnspentResponse")
+	proto.RegisterType((*Utxo)(nil), "lnrpc.Utxo")
+	proto.RegisterType((*LeaseOutputRequest)(nil), "lnrpc.LeaseOutputRequest")
+	proto.RegisterType((*LeaseOutputResponse)(nil), "lnrpc.LeaseOutputResponse")
+	proto.RegisterType((*ReleaseOutputRequest)(nil), "lnrpc.ReleaseOutputRequest")
+	proto.RegisterType((*ReleaseOutputResponse)(nil), "lnrpc.ReleaseOutputResponse")
 	proto.RegisterType((*SignMessageRequest)(nil), "lnrpc.SignMessageRequest")
 	proto.RegisterType((*SignMessageResponse)(nil), "lnrpc.SignMessageResponse")
 	proto.RegisterType((*VerifyMessageRequest)(nil), "lnrpc.VerifyMessageRequest")
@@ -4933,16 +7047,29 @@ func init() {
 	proto.RegisterType((*ChannelCloseSummary)(nil), "lnrpc.ChannelCloseSummary")
 	proto.RegisterType((*ClosedChannelsRequest)(nil), "lnrpc.ClosedChannelsRequest")
 	proto.RegisterType((*ClosedChannelsResponse)(nil), "lnrpc.ClosedChannelsResponse")
+	proto.RegisterType((*ChannelEventSubscription)(nil), "lnrpc.ChannelEventSubscription")
+	proto.RegisterType((*ChannelEventUpdate)(nil), "lnrpc.ChannelEventUpdate")
+	proto.RegisterType((*ClosingChannelUpdate)(nil), "lnrpc.ClosingChannelUpdate")
+	proto.RegisterType((*ChannelBalanceUpdate)(nil), "lnrpc.ChannelBalanceUpdate")
+	proto.RegisterType((*SplicedChannelUpdate)(nil), "lnrpc.SplicedChannelUpdate")
 	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
 	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
 	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
 	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
 	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
+	proto.RegisterType((*GetRecoveryInfoRequest)(nil), "lnrpc.GetRecoveryInfoRequest")
+	proto.RegisterType((*KeyScopeRecovery)(nil), "lnrpc.KeyScopeRecovery")
+	proto.RegisterType((*GetRecoveryInfoResponse)(nil), "lnrpc.GetRecoveryInfoResponse")
 	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
 	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
 	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
 	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
+	proto.RegisterType((*SpliceOutRequest)(nil), "lnrpc.SpliceOutRequest")
+	proto.RegisterType((*SpliceOutResponse)(nil), "lnrpc.SpliceOutResponse")
+	proto.RegisterType((*BumpPendingChannelRequest)(nil), "lnrpc.BumpPendingChannelRequest")
+	proto.RegisterType((*BumpPendingChannelResponse)(nil), "lnrpc.BumpPendingChannelResponse")
 	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
+	proto.RegisterType((*ClosingFeeOffer)(nil), "lnrpc.ClosingFeeOffer")
 	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
 	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
 	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
@@ -4995,6 +7122,12 @@ func init() {
 	proto.RegisterType((*DeleteAllPaymentsResponse)(nil), "lnrpc.DeleteAllPaymentsResponse")
 	proto.RegisterType((*AbandonChannelRequest)(nil), "lnrpc.AbandonChannelRequest")
 	proto.RegisterType((*AbandonChannelResponse)(nil), "lnrpc.AbandonChannelResponse")
+	proto.RegisterType((*DebugChannelStateRequest)(nil), "lnrpc.DebugChannelStateRequest")
+	proto.RegisterType((*DebugHTLC)(nil), "lnrpc.DebugHTLC")
+	proto.RegisterType((*DebugCommitment)(nil), "lnrpc.DebugCommitment")
+	proto.RegisterType((*DebugLogUpdate)(nil), "lnrpc.DebugLogUpdate")
+	proto.RegisterType((*DebugForwardingPackage)(nil), "lnrpc.DebugForwardingPackage")
+	proto.RegisterType((*DebugChannelStateResponse)(nil), "lnrpc.DebugChannelStateResponse")
 	proto.RegisterType((*DebugLevelRequest)(nil), "lnrpc.DebugLevelRequest")
 	proto.RegisterType((*DebugLevelResponse)(nil), "lnrpc.DebugLevelResponse")
 	proto.RegisterType((*PayReqString)(nil), "lnrpc.PayReqString")
@@ -5007,8 +7140,17 @@ func init() {
 	proto.RegisterType((*ForwardingHistoryRequest)(nil), "lnrpc.ForwardingHistoryRequest")
 	proto.RegisterType((*ForwardingEvent)(nil), "lnrpc.ForwardingEvent")
 	proto.RegisterType((*ForwardingHistoryResponse)(nil), "lnrpc.ForwardingHistoryResponse")
+	proto.RegisterType((*ExportChannelBackupRequest)(nil), "lnrpc.ExportChannelBackupRequest")
+	proto.RegisterType((*ChannelBackup)(nil), "lnrpc.ChannelBackup")
+	proto.RegisterType((*MultiChanBackup)(nil), "lnrpc.MultiChanBackup")
+	proto.RegisterType((*ChanBackupSnapshot)(nil), "lnrpc.ChanBackupSnapshot")
+	proto.RegisterType((*ChannelBackups)(nil), "lnrpc.ChannelBackups")
+	proto.RegisterType((*RestoreChanBackupRequest)(nil), "lnrpc.RestoreChanBackupRequest")
+	proto.RegisterType((*RestoreBackupResponse)(nil), "lnrpc.RestoreBackupResponse")
+	proto.RegisterType((*VerifyChanBackupResponse)(nil), "lnrpc.VerifyChanBackupResponse")
 	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
 	proto.RegisterEnum("lnrpc.ChannelCloseSummary_ClosureType", ChannelCloseSummary_ClosureType_name, ChannelCloseSummary_ClosureType_value)
+	proto.RegisterEnum("lnrpc.ChannelEventUpdate_UpdateType", ChannelEventUpdate_UpdateType_name, ChannelEventUpdate_UpdateType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -5254,12 +7396,18 @@ type LightningClient interface {
 	// GetTransactions returns a list describing all the known transactions
 	// relevant to the wallet.
 	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error)
+	// * lncli: `labeltx`
+	// LabelTransaction assigns a label to a transaction of the wallet, for
+	// example to describe an external deposit. Existing labels are only
+	// replaced if overwrite is set.
+	LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error)
 	// * lncli: `sendcoins`
 	// SendCoins executes a request to send coins to a particular address. Unlike
 	// SendMany, this RPC call only allows creating a single output at a time. If
 	// neither target_conf, or sat_per_byte are set, then the internal wallet will
 	// consult its fee model to determine a fee for the default confirmation
-	// target.
+	// target. If send_all is set, the entire balance of the wallet is swept to
+	// the address.
 	SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error)
 	// *
 	// SubscribeTransactions creates a uni-directional stream from the server to
@@ -5272,6 +7420,32 @@ type LightningClient interface {
 	// the internal wallet will consult its fee model to determine a fee for the
 	// default confirmation target.
 	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
+	// * lncli: `bumpfee`
+	// BumpFee attempts to speed up the confirmation of an unconfirmed on-chain
+	// transaction created by lnd, identified by one of its outputs. If the
+	// transaction is a sweep of lnd that signals replaceability, it is replaced
+	// by a transaction spending the same inputs at the new fee rate (RBF).
+	// Otherwise, the passed output, which must belong to the wallet, is spent
+	// in a child transaction that pays for both itself and its parent (CPFP).
+	// If neither target_conf, or sat_per_byte are set, then the internal wallet
+	// will consult its fee model to determine a fee for the default
+	// confirmation target.
+	BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error)
+	// * lncli: `listunspent`
+	// ListUnspent returns a list of all witness outputs of the wallet that have
+	// between min_confs and max_confs confirmations. Outputs that are locked,
+	// e.g. because they are leased, are not returned.
+	ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error)
+	// * lncli: `leaseoutput`
+	// LeaseOutput locks an output of the wallet under the passed lock ID for the
+	// given duration, excluding it from automatic coin selection. Leasing an
+	// output that is already leased under the same ID extends the lease. Leases
+	// are persisted and survive restarts.
+	LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error)
+	// * lncli: `releaseoutput`
+	// ReleaseOutput releases a lease of an output before it expires, making the
+	// output available for automatic coin selection again.
+	ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error)
 	// * lncli: `newaddress`
 	// NewAddress creates a new address under control of the local wallet.
 	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
@@ -5304,6 +7478,12 @@ type LightningClient interface {
 	// it's identity pubkey, alias, the chains it is connected to, and information
 	// concerning the number of open+pending channels.
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// * lncli: `getrecoveryinfo`
+	// GetRecoveryInfo returns the progress of the wallet's address recovery when
+	// it was restored from a seed with a recovery window, including the height
+	// the chain has been scanned up to, the number of addresses found per key
+	// scope and an estimate of when the recovery will be finished.
+	GetRecoveryInfo(ctx context.Context, in *GetRecoveryInfoRequest, opts ...grpc.CallOption) (*GetRecoveryInfoResponse, error)
 	// * lncli: `pendingchannels`
 	// PendingChannels returns a list of all the channels that are currently
 	// considered "pending". A channel is pending if it has finished the funding
@@ -5319,6 +7499,12 @@ type LightningClient interface {
 	// this node was a participant in.
 	ClosedChannels(ctx context.Context, in *ClosedChannelsRequest, opts ...grpc.CallOption) (*ClosedChannelsResponse, error)
 	// *
+	// SubscribeChannelEvents creates a uni-directional stream from the server to
+	// the client in which any updates relevant to the state of the channels are
+	// sent over. Events include new active channels, inactive channels, closing
+	// and closed channels, and balance changes of open channels.
+	SubscribeChannelEvents(ctx context.Context, in *ChannelEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelEventsClient, error)
+	// *
 	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
 	// call is meant to be consumed by clients to the REST proxy. As with all
 	// other sync calls, all byte slices are intended to be populated as hex
@@ -5340,6 +7526,25 @@ type LightningClient interface {
 	// closure transaction is confirmed, or a manual fee rate. If neither are
 	// specified, then a default lax, block confirmation target is used.
 	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
+	// * lncli: `spliceout`
+	// SpliceOut attempts to move funds out of an active channel identified by
+	// its channel outpoint (ChannelPoint), without closing the channel. Both
+	// parties agree upon a splice transaction that spends the current funding
+	// output into a smaller funding output, and an output paying the spliced out
+	// funds on-chain. The channel remains usable in its prior state until the
+	// splice transaction confirms. Only the initiator of the channel can splice
+	// out funds, and both peers must support splicing.
+	SpliceOut(ctx context.Context, in *SpliceOutRequest, opts ...grpc.CallOption) (*SpliceOutResponse, error)
+	// * lncli: `bumppendingchannel`
+	// BumpPendingChannel attempts to unstick the unconfirmed funding transaction
+	// of a pending channel we initiated. By default, the fee of the funding
+	// transaction is bumped by spending its change output in a child
+	// transaction (CPFP). Alternatively, all inputs of the funding transaction
+	// can be double spent back to the wallet, which is only accepted by the
+	// network if the funding transaction signals replaceability or has been
+	// evicted from the mempool. Once the double spend confirms, the pending
+	// channel is forgotten.
+	BumpPendingChannel(ctx context.Context, in *BumpPendingChannelRequest, opts ...grpc.CallOption) (*BumpPendingChannelResponse, error)
 	// * lncli: `abandonchannel`
 	// AbandonChannel removes all channel state from the database except for a
 	// close summary. This method can be used to get rid of permanently unusable
@@ -5458,6 +7663,15 @@ type LightningClient interface {
 	// level, or in a granular fashion to specify the logging for a target
 	// sub-system.
 	DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error)
+	// * lncli: `debugchannelstate`
+	// DebugChannelState returns a dump of the persisted state of a channel
+	// identified by its channel outpoint (ChannelPoint): both commitment
+	// transactions along with their HTLCs, the update log indexes, the
+	// revocation heights and all forwarding packages of the channel. This
+	// allows debugging desynchronized channels without a copy of the database.
+	// As the dump contains the commitment transactions, only the admin
+	// macaroon grants access to this method.
+	DebugChannelState(ctx context.Context, in *DebugChannelStateRequest, opts ...grpc.CallOption) (*DebugChannelStateResponse, error)
 	// * lncli: `feereport`
 	// FeeReport allows the caller to obtain a report detailing the current fee
 	// schedule enforced by the node globally for each channel.
@@ -5478,6 +7692,24 @@ type LightningClient interface {
 	// the index offset of the last entry. The index offset can be provided to the
 	// request to allow the caller to skip a series of records.
 	ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error)
+	// * lncli: `exportchanbackup`
+	// ExportChannelBackup attempts to return an encrypted static channel backup
+	// for the target channel identified by its channel point. The backup is
+	// encrypted with a key generated from the aezeed seed of the user. The
+	// returned backup can be restored using the RestoreChannelBackups method.
+	ExportChannelBackup(ctx context.Context, in *ExportChannelBackupRequest, opts ...grpc.CallOption) (*ChannelBackup, error)
+	// * lncli: `verifychanbackup`
+	// VerifyChanBackup allows a caller to verify the integrity of a channel backup
+	// snapshot. This method will accept either a packed Single or a packed Multi.
+	// Specifying both will result in an error.
+	VerifyChanBackup(ctx context.Context, in *ChanBackupSnapshot, opts ...grpc.CallOption) (*VerifyChanBackupResponse, error)
+	// * lncli: `restorechanbackup`
+	// RestoreChannelBackups accepts a set of singular channel backups, or a
+	// single encrypted multi-chan backup and attempts to recover any funds
+	// remaining within the channel. If we are able to unpack the backup, then
+	// we'll connect to each channel peer and request that they force close the
+	// channel, such that our funds can be swept once the commitment confirms.
+	RestoreChannelBackups(ctx context.Context, in *RestoreChanBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
 }
 
 type lightningClient struct {
@@ -5515,6 +7747,15 @@ func (c *lightningClient) GetTransactions(ctx context.Context, in *GetTransactio
 	return out, nil
 }
 
+func (c *lightningClient) LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error) {
+	out := new(LabelTransactionResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LabelTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error) {
 	out := new(SendCoinsResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCoins", in, out, c.cc, opts...)
@@ -5565,6 +7806,42 @@ func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opt
 	return out, nil
 }
 
+func (c *lightningClient) BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error) {
+	out := new(BumpFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BumpFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error) {
+	out := new(ListUnspentResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListUnspent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error) {
+	out := new(LeaseOutputResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LeaseOutput", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error) {
+	out := new(ReleaseOutputResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ReleaseOutput", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
 	out := new(NewAddressResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
@@ -5628,31 +7905,72 @@ func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts
 	return out, nil
 }
 
+func (c *lightningClient) GetRecoveryInfo(ctx context.Context, in *GetRecoveryInfoRequest, opts ...grpc.CallOption) (*GetRecoveryInfoResponse, error) {
+	out := new(GetRecoveryInfoResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetRecoveryInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error) {
 	out := new(PendingChannelsResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
+	out := new(ListChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ClosedChannels(ctx context.Context, in *ClosedChannelsRequest, opts ...grpc.CallOption) (*ClosedChannelsResponse, error) {
+	out := new(ClosedChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ClosedChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeChannelEvents(ctx context.Context, in *ChannelEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/SubscribeChannelEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeChannelEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
-	out := new(ListChannelsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type Lightning_SubscribeChannelEventsClient interface {
+	Recv() (*ChannelEventUpdate, error)
+	grpc.ClientStream
 }
 
-func (c *lightningClient) ClosedChannels(ctx context.Context, in *ClosedChannelsRequest, opts ...grpc.CallOption) (*ClosedChannelsResponse, error) {
-	out := new(ClosedChannelsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ClosedChannels", in, out, c.cc, opts...)
-	if err != nil {
+type lightningSubscribeChannelEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeChannelEventsClient) Recv() (*ChannelEventUpdate, error) {
+	m := new(ChannelEventUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
 func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error) {
@@ -5665,7 +7983,7 @@ func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRe
 }
 
 func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5697,7 +8015,7 @@ func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
 }
 
 func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5728,6 +8046,24 @@ func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
 	return m, nil
 }
 
+func (c *lightningClient) SpliceOut(ctx context.Context, in *SpliceOutRequest, opts ...grpc.CallOption) (*SpliceOutResponse, error) {
+	out := new(SpliceOutResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SpliceOut", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) BumpPendingChannel(ctx context.Context, in *BumpPendingChannelRequest, opts ...grpc.CallOption) (*BumpPendingChannelResponse, error) {
+	out := new(BumpPendingChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BumpPendingChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) AbandonChannel(ctx context.Context, in *AbandonChannelRequest, opts ...grpc.CallOption) (*AbandonChannelResponse, error) {
 	out := new(AbandonChannelResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AbandonChannel", in, out, c.cc, opts...)
@@ -5738,7 +8074,7 @@ func (c *lightningClient) AbandonChannel(ctx context.Context, in *AbandonChannel
 }
 
 func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5778,7 +8114,7 @@ func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest,
 }
 
 func (c *lightningClient) SendToRoute(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendToRouteClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SendToRoute", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SendToRoute", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5845,7 +8181,7 @@ func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, op
 }
 
 func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5958,7 +8294,7 @@ func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts
 }
 
 func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[7], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5998,6 +8334,15 @@ func (c *lightningClient) DebugLevel(ctx context.Context, in *DebugLevelRequest,
 	return out, nil
 }
 
+func (c *lightningClient) DebugChannelState(ctx context.Context, in *DebugChannelStateRequest, opts ...grpc.CallOption) (*DebugChannelStateResponse, error) {
+	out := new(DebugChannelStateResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DebugChannelState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error) {
 	out := new(FeeReportResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FeeReport", in, out, c.cc, opts...)
@@ -6025,6 +8370,33 @@ func (c *lightningClient) ForwardingHistory(ctx context.Context, in *ForwardingH
 	return out, nil
 }
 
+func (c *lightningClient) ExportChannelBackup(ctx context.Context, in *ExportChannelBackupRequest, opts ...grpc.CallOption) (*ChannelBackup, error) {
+	out := new(ChannelBackup)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportChannelBackup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) VerifyChanBackup(ctx context.Context, in *ChanBackupSnapshot, opts ...grpc.CallOption) (*VerifyChanBackupResponse, error) {
+	out := new(VerifyChanBackupResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/VerifyChanBackup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) RestoreChannelBackups(ctx context.Context, in *RestoreChanBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
+	out := new(RestoreBackupResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/RestoreChannelBackups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Lightning service
 
 type LightningServer interface {
@@ -6041,12 +8413,18 @@ type LightningServer interface {
 	// GetTransactions returns a list describing all the known transactions
 	// relevant to the wallet.
 	GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionDetails, error)
+	// * lncli: `labeltx`
+	// LabelTransaction assigns a label to a transaction of the wallet, for
+	// example to describe an external deposit. Existing labels are only
+	// replaced if overwrite is set.
+	LabelTransaction(context.Context, *LabelTransactionRequest) (*LabelTransactionResponse, error)
 	// * lncli: `sendcoins`
 	// SendCoins executes a request to send coins to a particular address. Unlike
 	// SendMany, this RPC call only allows creating a single output at a time. If
 	// neither target_conf, or sat_per_byte are set, then the internal wallet will
 	// consult its fee model to determine a fee for the default confirmation
-	// target.
+	// target. If send_all is set, the entire balance of the wallet is swept to
+	// the address.
 	SendCoins(context.Context, *SendCoinsRequest) (*SendCoinsResponse, error)
 	// *
 	// SubscribeTransactions creates a uni-directional stream from the server to
@@ -6059,6 +8437,32 @@ type LightningServer interface {
 	// the internal wallet will consult its fee model to determine a fee for the
 	// default confirmation target.
 	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
+	// * lncli: `bumpfee`
+	// BumpFee attempts to speed up the confirmation of an unconfirmed on-chain
+	// transaction created by lnd, identified by one of its outputs. If the
+	// transaction is a sweep of lnd that signals replaceability, it is replaced
+	// by a transaction spending the same inputs at the new fee rate (RBF).
+	// Otherwise, the passed output, which must belong to the wallet, is spent
+	// in a child transaction that pays for both itself and its parent (CPFP).
+	// If neither target_conf, or sat_per_byte are set, then the internal wallet
+	// will consult its fee model to determine a fee for the default
+	// confirmation target.
+	BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error)
+	// * lncli: `listunspent`
+	// ListUnspent returns a list of all witness outputs of the wallet that have
+	// between min_confs and max_confs confirmations. Outputs that are locked,
+	// e.g. because they are leased, are not returned.
+	ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error)
+	// * lncli: `leaseoutput`
+	// LeaseOutput locks an output of the wallet under the passed lock ID for the
+	// given duration, excluding it from automatic coin selection. Leasing an
+	// output that is already leased under the same ID extends the lease. Leases
+	// are persisted and survive restarts.
+	LeaseOutput(context.Context, *LeaseOutputRequest) (*LeaseOutputResponse, error)
+	// * lncli: `releaseoutput`
+	// ReleaseOutput releases a lease of an output before it expires, making the
+	// output available for automatic coin selection again.
+	ReleaseOutput(context.Context, *ReleaseOutputRequest) (*ReleaseOutputResponse, error)
 	// * lncli: `newaddress`
 	// NewAddress creates a new address under control of the local wallet.
 	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
@@ -6091,6 +8495,12 @@ type LightningServer interface {
 	// it's identity pubkey, alias, the chains it is connected to, and information
 	// concerning the number of open+pending channels.
 	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// * lncli: `getrecoveryinfo`
+	// GetRecoveryInfo returns the progress of the wallet's address recovery when
+	// it was restored from a seed with a recovery window, including the height
+	// the chain has been scanned up to, the number of addresses found per key
+	// scope and an estimate of when the recovery will be finished.
+	GetRecoveryInfo(context.Context, *GetRecoveryInfoRequest) (*GetRecoveryInfoResponse, error)
 	// * lncli: `pendingchannels`
 	// PendingChannels returns a list of all the channels that are currently
 	// considered "pending". A channel is pending if it has finished the funding
@@ -6106,6 +8516,12 @@ type LightningServer interface {
 	// this node was a participant in.
 	ClosedChannels(context.Context, *ClosedChannelsRequest) (*ClosedChannelsResponse, error)
 	// *
+	// SubscribeChannelEvents creates a uni-directional stream from the server to
+	// the client in which any updates relevant to the state of the channels are
+	// sent over. Events include new active channels, inactive channels, closing
+	// and closed channels, and balance changes of open channels.
+	SubscribeChannelEvents(*ChannelEventSubscription, Lightning_SubscribeChannelEventsServer) error
+	// *
 	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
 	// call is meant to be consumed by clients to the REST proxy. As with all
 	// other sync calls, all byte slices are intended to be populated as hex
@@ -6127,6 +8543,25 @@ type LightningServer interface {
 	// closure transaction is confirmed, or a manual fee rate. If neither are
 	// specified, then a default lax, block confirmation target is used.
 	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
+	// * lncli: `spliceout`
+	// SpliceOut attempts to move funds out of an active channel identified by
+	// its channel outpoint (ChannelPoint), without closing the channel. Both
+	// parties agree upon a splice transaction that spends the current funding
+	// output into a smaller funding output, and an output paying the spliced out
+	// funds on-chain. The channel remains usable in its prior state until the
+	// splice transaction confirms. Only the initiator of the channel can splice
+	// out funds, and both peers must support splicing.
+	SpliceOut(context.Context, *SpliceOutRequest) (*SpliceOutResponse, error)
+	// * lncli: `bumppendingchannel`
+	// BumpPendingChannel attempts to unstick the unconfirmed funding transaction
+	// of a pending channel we initiated. By default, the fee of the funding
+	// transaction is bumped by spending its change output in a child
+	// transaction (CPFP). Alternatively, all inputs of the funding transaction
+	// can be double spent back to the wallet, which is only accepted by the
+	// network if the funding transaction signals replaceability or has been
+	// evicted from the mempool. Once the double spend confirms, the pending
+	// channel is forgotten.
+	BumpPendingChannel(context.Context, *BumpPendingChannelRequest) (*BumpPendingChannelResponse, error)
 	// * lncli: `abandonchannel`
 	// AbandonChannel removes all channel state from the database except for a
 	// close summary. This method can be used to get rid of permanently unusable
@@ -6245,6 +8680,15 @@ type LightningServer interface {
 	// level, or in a granular fashion to specify the logging for a target
 	// sub-system.
 	DebugLevel(context.Context, *DebugLevelRequest) (*DebugLevelResponse, error)
+	// * lncli: `debugchannelstate`
+	// DebugChannelState returns a dump of the persisted state of a channel
+	// identified by its channel outpoint (ChannelPoint): both commitment
+	// transactions along with their HTLCs, the update log indexes, the
+	// revocation heights and all forwarding packages of the channel. This
+	// allows debugging desynchronized channels without a copy of the database.
+	// As the dump contains the commitment transactions, only the admin
+	// macaroon grants access to this method.
+	DebugChannelState(context.Context, *DebugChannelStateRequest) (*DebugChannelStateResponse, error)
 	// * lncli: `feereport`
 	// FeeReport allows the caller to obtain a report detailing the current fee
 	// schedule enforced by the node globally for each channel.
@@ -6265,6 +8709,24 @@ type LightningServer interface {
 	// the index offset of the last entry. The index offset can be provided to the
 	// request to allow the caller to skip a series of records.
 	ForwardingHistory(context.Context, *ForwardingHistoryRequest) (*ForwardingHistoryResponse, error)
+	// * lncli: `exportchanbackup`
+	// ExportChannelBackup attempts to return an encrypted static channel backup
+	// for the target channel identified by its channel point. The backup is
+	// encrypted with a key generated from the aezeed seed of the user. The
+	// returned backup can be restored using the RestoreChannelBackups method.
+	ExportChannelBackup(context.Context, *ExportChannelBackupRequest) (*ChannelBackup, error)
+	// * lncli: `verifychanbackup`
+	// VerifyChanBackup allows a caller to verify the integrity of a channel backup
+	// snapshot. This method will accept either a packed Single or a packed Multi.
+	// Specifying both will result in an error.
+	VerifyChanBackup(context.Context, *ChanBackupSnapshot) (*VerifyChanBackupResponse, error)
+	// * lncli: `restorechanbackup`
+	// RestoreChannelBackups accepts a set of singular channel backups, or a
+	// single encrypted multi-chan backup and attempts to recover any funds
+	// remaining within the channel. If we are able to unpack the backup, then
+	// we'll connect to each channel peer and request that they force close the
+	// channel, such that our funds can be swept once the commitment confirms.
+	RestoreChannelBackups(context.Context, *RestoreChanBackupRequest) (*RestoreBackupResponse, error)
 }
 
 func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
@@ -6325,6 +8787,24 @@ func _Lightning_GetTransactions_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_LabelTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LabelTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LabelTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LabelTransaction(ctx, req.(*LabelTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_SendCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendCoinsRequest)
 	if err := dec(in); err != nil {
@@ -6382,6 +8862,78 @@ func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_BumpFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BumpFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BumpFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BumpFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BumpFee(ctx, req.(*BumpFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListUnspent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnspentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListUnspent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListUnspent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListUnspent(ctx, req.(*ListUnspentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_LeaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LeaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LeaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LeaseOutput(ctx, req.(*LeaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ReleaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ReleaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ReleaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ReleaseOutput(ctx, req.(*ReleaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NewAddressRequest)
 	if err := dec(in); err != nil {
@@ -6508,6 +9060,24 @@ func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_GetRecoveryInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecoveryInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetRecoveryInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetRecoveryInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetRecoveryInfo(ctx, req.(*GetRecoveryInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PendingChannelsRequest)
 	if err := dec(in); err != nil {
@@ -6562,6 +9132,27 @@ func _Lightning_ClosedChannels_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_SubscribeChannelEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChannelEventSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeChannelEvents(m, &lightningSubscribeChannelEventsServer{stream})
+}
+
+type Lightning_SubscribeChannelEventsServer interface {
+	Send(*ChannelEventUpdate) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeChannelEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeChannelEventsServer) Send(m *ChannelEventUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Lightning_OpenChannelSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(OpenChannelRequest)
 	if err := dec(in); err != nil {
@@ -6622,6 +9213,42 @@ func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Lightning_SpliceOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpliceOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SpliceOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SpliceOut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SpliceOut(ctx, req.(*SpliceOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_BumpPendingChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BumpPendingChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BumpPendingChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BumpPendingChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BumpPendingChannel(ctx, req.(*BumpPendingChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_AbandonChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AbandonChannelRequest)
 	if err := dec(in); err != nil {
@@ -7004,6 +9631,24 @@ func _Lightning_DebugLevel_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_DebugChannelState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugChannelStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DebugChannelState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DebugChannelState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DebugChannelState(ctx, req.(*DebugChannelStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_FeeReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FeeReportRequest)
 	if err := dec(in); err != nil {
@@ -7058,6 +9703,60 @@ func _Lightning_ForwardingHistory_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_ExportChannelBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportChannelBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ExportChannelBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ExportChannelBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ExportChannelBackup(ctx, req.(*ExportChannelBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_VerifyChanBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanBackupSnapshot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).VerifyChanBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/VerifyChanBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).VerifyChanBackup(ctx, req.(*ChanBackupSnapshot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_RestoreChannelBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreChanBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).RestoreChannelBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/RestoreChannelBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).RestoreChannelBackups(ctx, req.(*RestoreChanBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Lightning_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "lnrpc.Lightning",
 	HandlerType: (*LightningServer)(nil),
@@ -7074,6 +9773,10 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactions",
 			Handler:    _Lightning_GetTransactions_Handler,
 		},
+		{
+			MethodName: "LabelTransaction",
+			Handler:    _Lightning_LabelTransaction_Handler,
+		},
 		{
 			MethodName: "SendCoins",
 			Handler:    _Lightning_SendCoins_Handler,
@@ -7082,6 +9785,22 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SendMany",
 			Handler:    _Lightning_SendMany_Handler,
 		},
+		{
+			MethodName: "BumpFee",
+			Handler:    _Lightning_BumpFee_Handler,
+		},
+		{
+			MethodName: "ListUnspent",
+			Handler:    _Lightning_ListUnspent_Handler,
+		},
+		{
+			MethodName: "LeaseOutput",
+			Handler:    _Lightning_LeaseOutput_Handler,
+		},
+		{
+			MethodName: "ReleaseOutput",
+			Handler:    _Lightning_ReleaseOutput_Handler,
+		},
 		{
 			MethodName: "NewAddress",
 			Handler:    _Lightning_NewAddress_Handler,
@@ -7110,6 +9829,10 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetInfo",
 			Handler:    _Lightning_GetInfo_Handler,
 		},
+		{
+			MethodName: "GetRecoveryInfo",
+			Handler:    _Lightning_GetRecoveryInfo_Handler,
+		},
 		{
 			MethodName: "PendingChannels",
 			Handler:    _Lightning_PendingChannels_Handler,
@@ -7126,6 +9849,14 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "OpenChannelSync",
 			Handler:    _Lightning_OpenChannelSync_Handler,
 		},
+		{
+			MethodName: "SpliceOut",
+			Handler:    _Lightning_SpliceOut_Handler,
+		},
+		{
+			MethodName: "BumpPendingChannel",
+			Handler:    _Lightning_BumpPendingChannel_Handler,
+		},
 		{
 			MethodName: "AbandonChannel",
 			Handler:    _Lightning_AbandonChannel_Handler,
@@ -7190,6 +9921,10 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DebugLevel",
 			Handler:    _Lightning_DebugLevel_Handler,
 		},
+		{
+			MethodName: "DebugChannelState",
+			Handler:    _Lightning_DebugChannelState_Handler,
+		},
 		{
 			MethodName: "FeeReport",
 			Handler:    _Lightning_FeeReport_Handler,
@@ -7202,6 +9937,18 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ForwardingHistory",
 			Handler:    _Lightning_ForwardingHistory_Handler,
 		},
+		{
+			MethodName: "ExportChannelBackup",
+			Handler:    _Lightning_ExportChannelBackup_Handler,
+		},
+		{
+			MethodName: "VerifyChanBackup",
+			Handler:    _Lightning_VerifyChanBackup_Handler,
+		},
+		{
+			MethodName: "RestoreChannelBackups",
+			Handler:    _Lightning_RestoreChannelBackups_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -7209,6 +9956,11 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Lightning_SubscribeTransactions_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeChannelEvents",
+			Handler:       _Lightning_SubscribeChannelEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "OpenChannel",
 			Handler:       _Lightning_OpenChannel_Handler,
@@ -7248,405 +10000,539 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 6385 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7c, 0xdf, 0x6f, 0x24, 0xd9,
-	0x55, 0xff, 0x54, 0xbb, 0xdb, 0x76, 0x9f, 0x6e, 0xdb, 0xed, 0xeb, 0x5f, 0x3d, 0xbd, 0xb3, 0xb3,
-	0xb3, 0x95, 0xd5, 0xce, 0xc4, 0xdf, 0xfd, 0xce, 0xcc, 0x3a, 0xc9, 0x6a, 0xb3, 0x0b, 0x09, 0x1e,
-	0xdb, 0x33, 0x9e, 0xc4, 0x3b, 0xe3, 0x94, 0x67, 0xb3, 0x24, 0x01, 0x75, 0xca, 0xdd, 0xd7, 0xed,
-	0xca, 0x74, 0x57, 0x75, 0xaa, 0xaa, 0xed, 0xed, 0x2c, 0x23, 0x01, 0x41, 0x3c, 0x20, 0x22, 0x84,
-	0x40, 0x42, 0x41, 0x42, 0x88, 0xc0, 0x43, 0xf8, 0x03, 0xc8, 0x0b, 0xf0, 0xc6, 0x0b, 0x48, 0x88,
-	0x87, 0x7d, 0x8a, 0x90, 0x78, 0x81, 0x17, 0x40, 0xbc, 0x20, 0xf1, 0x08, 0x42, 0xe7, 0xdc, 0x1f,
-	0x75, 0x6f, 0x55, 0x79, 0x3c, 0xf9, 0xc5, 0x5b, 0xdf, 0xcf, 0x3d, 0x75, 0x7f, 0x9e, 0x5f, 0xf7,
-	0xdc, 0x73, 0x1b, 0xea, 0xf1, 0xb8, 0x77, 0x7b, 0x1c, 0x47, 0x69, 0xc4, 0x6a, 0xc3, 0x30, 0x1e,
-	0xf7, 0x3a, 0xd7, 0x06, 0x51, 0x34, 0x18, 0xf2, 0x3b, 0xfe, 0x38, 0xb8, 0xe3, 0x87, 0x61, 0x94,
-	0xfa, 0x69, 0x10, 0x85, 0x89, 0x20, 0x72, 0xbf, 0x0e, 0x8b, 0x0f, 0x78, 0x78, 0xc4, 0x79, 0xdf,
-	0xe3, 0xdf, 0x9c, 0xf0, 0x24, 0x65, 0xff, 0x0f, 0x96, 0x7d, 0xfe, 0x2d, 0xce, 0xfb, 0xdd, 0xb1,
-	0x9f, 0x24, 0xe3, 0xd3, 0xd8, 0x4f, 0x78, 0xdb, 0xb9, 0xe1, 0xdc, 0x6a, 0x7a, 0x2d, 0x51, 0x71,
-	0xa8, 0x71, 0xf6, 0x2a, 0x34, 0x13, 0x24, 0xe5, 0x61, 0x1a, 0x47, 0xe3, 0x69, 0xbb, 0x42, 0x74,
-	0x0d, 0xc4, 0xf6, 0x04, 0xe4, 0x0e, 0x61, 0x49, 0xf7, 0x90, 0x8c, 0xa3, 0x30, 0xe1, 0xec, 0x2e,
-	0xac, 0xf6, 0x82, 0xf1, 0x29, 0x8f, 0xbb, 0xf4, 0xf1, 0x28, 0xe4, 0xa3, 0x28, 0x0c, 0x7a, 0x6d,
-	0xe7, 0xc6, 0xcc, 0xad, 0xba, 0xc7, 0x44, 0x1d, 0x7e, 0xf1, 0x9e, 0xac, 0x61, 0x37, 0x61, 0x89,
-	0x87, 0x02, 0xe7, 0x7d, 0xfa, 0x4a, 0x76, 0xb5, 0x98, 0xc1, 0xf8, 0x81, 0xfb, 0x37, 0x0e, 0x2c,
-	0x3f, 0x0c, 0x83, 0xf4, 0x03, 0x7f, 0x38, 0xe4, 0xa9, 0x9a, 0xd3, 0x4d, 0x58, 0x3a, 0x27, 0x80,
-	0xe6, 0x74, 0x1e, 0xc5, 0x7d, 0x39, 0xa3, 0x45, 0x01, 0x1f, 0x4a, 0xf4, 0xc2, 0x91, 0x55, 0x2e,
-	0x1c, 0x59, 0xe9, 0x72, 0xcd, 0x5c, 0xb0, 0x5c, 0x37, 0x61, 0x29, 0xe6, 0xbd, 0xe8, 0x8c, 0xc7,
-	0xd3, 0xee, 0x79, 0x10, 0xf6, 0xa3, 0xf3, 0x76, 0xf5, 0x86, 0x73, 0xab, 0xe6, 0x2d, 0x2a, 0xf8,
-	0x03, 0x42, 0xdd, 0x55, 0x60, 0xe6, 0x2c, 0xc4, 0xba, 0xb9, 0x03, 0x58, 0x79, 0x3f, 0x1c, 0x46,
-	0xbd, 0xa7, 0x3f, 0xe6, 0xec, 0x4a, 0xba, 0xaf, 0x94, 0x76, 0xbf, 0x0e, 0xab, 0x76, 0x47, 0x72,
-	0x00, 0x1c, 0xd6, 0x76, 0x4e, 0xfd, 0x70, 0xc0, 0x55, 0x93, 0x6a, 0x08, 0x9f, 0x84, 0x56, 0x6f,
-	0x12, 0xc7, 0x3c, 0x2c, 0x8c, 0x61, 0x49, 0xe2, 0x7a, 0x10, 0xaf, 0x42, 0x33, 0xe4, 0xe7, 0x19,
-	0x99, 0x64, 0x99, 0x90, 0x9f, 0x2b, 0x12, 0xb7, 0x0d, 0xeb, 0xf9, 0x6e, 0xe4, 0x00, 0xbe, 0x5b,
-	0x81, 0xc6, 0x93, 0xd8, 0x0f, 0x13, 0xbf, 0x87, 0x5c, 0xcc, 0xda, 0x30, 0x97, 0x7e, 0xd8, 0x3d,
-	0xf5, 0x93, 0x53, 0xea, 0xae, 0xee, 0xa9, 0x22, 0x5b, 0x87, 0x59, 0x7f, 0x14, 0x4d, 0xc2, 0x94,
-	0x3a, 0x98, 0xf1, 0x64, 0x89, 0xbd, 0x01, 0xcb, 0xe1, 0x64, 0xd4, 0xed, 0x45, 0xe1, 0x49, 0x10,
-	0x8f, 0x84, 0x2c, 0xd0, 0x7e, 0xd5, 0xbc, 0x62, 0x05, 0xbb, 0x0e, 0x70, 0x8c, 0xeb, 0x20, 0xba,
-	0xa8, 0x52, 0x17, 0x06, 0xc2, 0x5c, 0x68, 0xca, 0x12, 0x0f, 0x06, 0xa7, 0x69, 0xbb, 0x46, 0x0d,
-	0x59, 0x18, 0xb6, 0x91, 0x06, 0x23, 0xde, 0x4d, 0x52, 0x7f, 0x34, 0x6e, 0xcf, 0xd2, 0x68, 0x0c,
-	0x84, 0xea, 0xa3, 0xd4, 0x1f, 0x76, 0x4f, 0x38, 0x4f, 0xda, 0x73, 0xb2, 0x5e, 0x23, 0xec, 0x75,
-	0x58, 0xec, 0xf3, 0x24, 0xed, 0xfa, 0xfd, 0x7e, 0xcc, 0x93, 0x84, 0x27, 0xed, 0x79, 0xe2, 0xc6,
-	0x1c, 0x8a, 0xab, 0xf6, 0x80, 0xa7, 0xc6, 0xea, 0x24, 0x72, 0x77, 0xdc, 0x03, 0x60, 0x06, 0xbc,
-	0xcb, 0x53, 0x3f, 0x18, 0x26, 0xec, 0x2d, 0x68, 0xa6, 0x06, 0x31, 0x49, 0x5f, 0x63, 0x8b, 0xdd,
-	0x26, 0xb5, 0x71, 0xdb, 0xf8, 0xc0, 0xb3, 0xe8, 0xdc, 0x07, 0x30, 0x7f, 0x9f, 0xf3, 0x83, 0x60,
-	0x14, 0xa4, 0x6c, 0x1d, 0x6a, 0x27, 0xc1, 0x87, 0x5c, 0x6c, 0xf6, 0xcc, 0xfe, 0x15, 0x4f, 0x14,
-	0x59, 0x07, 0xe6, 0xc6, 0x3c, 0xee, 0x71, 0xb5, 0xfc, 0xfb, 0x57, 0x3c, 0x05, 0xdc, 0x9b, 0x83,
-	0xda, 0x10, 0x3f, 0x76, 0xbf, 0x5f, 0x81, 0xc6, 0x11, 0x0f, 0x35, 0x13, 0x31, 0xa8, 0xe2, 0x94,
-	0x24, 0xe3, 0xd0, 0x6f, 0xf6, 0x0a, 0x34, 0x68, 0x9a, 0x49, 0x1a, 0x07, 0xe1, 0x80, 0x1a, 0xab,
-	0x7b, 0x80, 0xd0, 0x11, 0x21, 0xac, 0x05, 0x33, 0xfe, 0x28, 0xa5, 0x1d, 0x9c, 0xf1, 0xf0, 0x27,
-	0x32, 0xd8, 0xd8, 0x9f, 0x8e, 0x90, 0x17, 0xf5, 0xae, 0x35, 0xbd, 0x86, 0xc4, 0xf6, 0x71, 0xdb,
-	0x6e, 0xc3, 0x8a, 0x49, 0xa2, 0x5a, 0xaf, 0x51, 0xeb, 0xcb, 0x06, 0xa5, 0xec, 0xe4, 0x26, 0x2c,
-	0x29, 0xfa, 0x58, 0x0c, 0x96, 0xf6, 0xb1, 0xee, 0x2d, 0x4a, 0x58, 0x4d, 0xe1, 0x16, 0xb4, 0x4e,
-	0x82, 0xd0, 0x1f, 0x76, 0x7b, 0xc3, 0xf4, 0xac, 0xdb, 0xe7, 0xc3, 0xd4, 0xa7, 0x1d, 0xad, 0x79,
-	0x8b, 0x84, 0xef, 0x0c, 0xd3, 0xb3, 0x5d, 0x44, 0xd9, 0x1b, 0x50, 0x3f, 0xe1, 0xbc, 0x4b, 0x2b,
-	0xd1, 0x9e, 0xbf, 0xe1, 0xdc, 0x6a, 0x6c, 0x2d, 0xc9, 0xa5, 0x57, 0xab, 0xeb, 0xcd, 0x9f, 0xc8,
-	0x5f, 0xee, 0xef, 0x3b, 0xd0, 0x14, 0x4b, 0x25, 0x55, 0xe8, 0x6b, 0xb0, 0xa0, 0x46, 0xc4, 0xe3,
-	0x38, 0x8a, 0x25, 0xfb, 0xdb, 0x20, 0xdb, 0x84, 0x96, 0x02, 0xc6, 0x31, 0x0f, 0x46, 0xfe, 0x80,
-	0x4b, 0x79, 0x2b, 0xe0, 0x6c, 0x2b, 0x6b, 0x31, 0x8e, 0x26, 0xa9, 0x50, 0x62, 0x8d, 0xad, 0xa6,
-	0x1c, 0x94, 0x87, 0x98, 0x67, 0x93, 0xb8, 0xdf, 0x71, 0x80, 0xe1, 0xb0, 0x9e, 0x44, 0xa2, 0x5a,
-	0xae, 0x42, 0x7e, 0x07, 0x9c, 0x17, 0xde, 0x81, 0xca, 0x45, 0x3b, 0xf0, 0x1a, 0xcc, 0x52, 0x97,
-	0x28, 0xab, 0x33, 0x85, 0x61, 0xc9, 0x3a, 0xf7, 0x7b, 0x0e, 0x34, 0x51, 0x73, 0x84, 0x7c, 0x78,
-	0x18, 0x05, 0x61, 0xca, 0xee, 0x02, 0x3b, 0x99, 0x84, 0xfd, 0x20, 0x1c, 0x74, 0xd3, 0x0f, 0x83,
-	0x7e, 0xf7, 0x78, 0x8a, 0x4d, 0xd0, 0x78, 0xf6, 0xaf, 0x78, 0x25, 0x75, 0xec, 0x0d, 0x68, 0x59,
-	0x68, 0x92, 0xc6, 0x62, 0x54, 0xfb, 0x57, 0xbc, 0x42, 0x0d, 0xca, 0x7f, 0x34, 0x49, 0xc7, 0x93,
-	0xb4, 0x1b, 0x84, 0x7d, 0xfe, 0x21, 0xad, 0xd9, 0x82, 0x67, 0x61, 0xf7, 0x16, 0xa1, 0x69, 0x7e,
-	0xe7, 0x7e, 0x0e, 0x5a, 0x07, 0xa8, 0x18, 0xc2, 0x20, 0x1c, 0x6c, 0x0b, 0xe9, 0x45, 0x6d, 0x35,
-	0x9e, 0x1c, 0x3f, 0xe5, 0x53, 0xb9, 0x8f, 0xb2, 0x84, 0x22, 0x71, 0x1a, 0x25, 0xa9, 0x5c, 0x17,
-	0xfa, 0xed, 0xfe, 0xb3, 0x03, 0x4b, 0xb8, 0xe8, 0xef, 0xf9, 0xe1, 0x54, 0xad, 0xf8, 0x01, 0x34,
-	0xb1, 0xa9, 0x27, 0xd1, 0xb6, 0xd0, 0x79, 0x42, 0x96, 0x6f, 0xc9, 0x45, 0xca, 0x51, 0xdf, 0x36,
-	0x49, 0xd1, 0x4c, 0x4f, 0x3d, 0xeb, 0x6b, 0x14, 0xba, 0xd4, 0x8f, 0x07, 0x3c, 0x25, 0x6d, 0x28,
-	0xb5, 0x23, 0x08, 0x68, 0x27, 0x0a, 0x4f, 0xd8, 0x0d, 0x68, 0x26, 0x7e, 0xda, 0x1d, 0xf3, 0x98,
-	0x56, 0x8d, 0x04, 0x67, 0xc6, 0x83, 0xc4, 0x4f, 0x0f, 0x79, 0x7c, 0x6f, 0x9a, 0xf2, 0xce, 0xe7,
-	0x61, 0xb9, 0xd0, 0x0b, 0xca, 0x6a, 0x36, 0x45, 0xfc, 0xc9, 0x56, 0xa1, 0x76, 0xe6, 0x0f, 0x27,
-	0x5c, 0x2a, 0x69, 0x51, 0x78, 0xa7, 0xf2, 0xb6, 0xe3, 0xbe, 0x0e, 0xad, 0x6c, 0xd8, 0x92, 0xe9,
-	0x19, 0x54, 0x71, 0x05, 0x65, 0x03, 0xf4, 0xdb, 0xfd, 0x35, 0x47, 0x10, 0xee, 0x44, 0x81, 0x56,
-	0x78, 0x48, 0x88, 0x7a, 0x51, 0x11, 0xe2, 0xef, 0x0b, 0x0d, 0xc2, 0x4f, 0x3e, 0x59, 0xf7, 0x26,
-	0x2c, 0x1b, 0x43, 0x78, 0xce, 0x60, 0xbf, 0xe3, 0xc0, 0xf2, 0x23, 0x7e, 0x2e, 0x77, 0x5d, 0x8d,
-	0xf6, 0x6d, 0xa8, 0xa6, 0xd3, 0xb1, 0x70, 0xb2, 0x16, 0xb7, 0x5e, 0x93, 0x9b, 0x56, 0xa0, 0xbb,
-	0x2d, 0x8b, 0x4f, 0xa6, 0x63, 0xee, 0xd1, 0x17, 0xee, 0xe7, 0xa0, 0x61, 0x80, 0x6c, 0x03, 0x56,
-	0x3e, 0x78, 0xf8, 0xe4, 0xd1, 0xde, 0xd1, 0x51, 0xf7, 0xf0, 0xfd, 0x7b, 0x5f, 0xdc, 0xfb, 0x4a,
-	0x77, 0x7f, 0xfb, 0x68, 0xbf, 0x75, 0x85, 0xad, 0x03, 0x7b, 0xb4, 0x77, 0xf4, 0x64, 0x6f, 0xd7,
-	0xc2, 0x1d, 0xf7, 0x36, 0x30, 0xb3, 0x1b, 0x39, 0xf2, 0x36, 0xcc, 0x49, 0xab, 0xa2, 0x8c, 0xaa,
-	0x2c, 0xba, 0xaf, 0x03, 0x3b, 0x0a, 0x06, 0xe1, 0x7b, 0x3c, 0x49, 0xfc, 0x81, 0x16, 0xf7, 0x16,
-	0xcc, 0x8c, 0x92, 0x81, 0x94, 0x72, 0xfc, 0xe9, 0x7e, 0x0a, 0x56, 0x2c, 0x3a, 0xd9, 0xf0, 0x35,
-	0xa8, 0x27, 0xc1, 0x20, 0xf4, 0xd3, 0x49, 0xcc, 0x65, 0xd3, 0x19, 0xe0, 0xde, 0x87, 0xd5, 0x2f,
-	0xf3, 0x38, 0x38, 0x99, 0x5e, 0xd6, 0xbc, 0xdd, 0x4e, 0x25, 0xdf, 0xce, 0x1e, 0xac, 0xe5, 0xda,
-	0x91, 0xdd, 0x0b, 0x66, 0x93, 0x5b, 0x32, 0xef, 0x89, 0x82, 0x21, 0x7a, 0x15, 0x53, 0xf4, 0xdc,
-	0xf7, 0x81, 0xed, 0x44, 0x61, 0xc8, 0x7b, 0xe9, 0x21, 0xe7, 0x71, 0xe6, 0x1d, 0x67, 0x9c, 0xd5,
-	0xd8, 0xda, 0x90, 0x7b, 0x95, 0x97, 0x67, 0xc9, 0x72, 0x0c, 0xaa, 0x63, 0x1e, 0x8f, 0xa8, 0xe1,
-	0x79, 0x8f, 0x7e, 0xbb, 0x6b, 0xb0, 0x62, 0x35, 0x2b, 0x1d, 0x9b, 0x37, 0x61, 0x6d, 0x37, 0x48,
-	0x7a, 0xc5, 0x0e, 0xdb, 0x30, 0x37, 0x9e, 0x1c, 0x77, 0x33, 0xb9, 0x51, 0x45, 0xb4, 0xf7, 0xf9,
-	0x4f, 0x64, 0x63, 0xbf, 0xe9, 0x40, 0x75, 0xff, 0xc9, 0xc1, 0x0e, 0xeb, 0xc0, 0x7c, 0x10, 0xf6,
-	0xa2, 0x11, 0xaa, 0x56, 0x31, 0x69, 0x5d, 0xbe, 0x50, 0x1e, 0xae, 0x41, 0x9d, 0x34, 0x32, 0xba,
-	0x30, 0xd2, 0x91, 0xcd, 0x00, 0x74, 0x9f, 0xf8, 0x87, 0xe3, 0x20, 0x26, 0xff, 0x48, 0x79, 0x3d,
-	0x55, 0xd2, 0x7a, 0xc5, 0x0a, 0xf7, 0x7f, 0xaa, 0x30, 0x27, 0xf5, 0x31, 0xf5, 0xd7, 0x4b, 0x83,
-	0x33, 0x2e, 0x47, 0x22, 0x4b, 0x68, 0xc9, 0x62, 0x3e, 0x8a, 0x52, 0xde, 0xb5, 0xb6, 0xc1, 0x06,
-	0x91, 0xaa, 0x27, 0x1a, 0xea, 0x8e, 0x51, 0xb3, 0xd3, 0xc8, 0xea, 0x9e, 0x0d, 0xe2, 0x62, 0x21,
-	0xd0, 0x0d, 0xfa, 0x34, 0xa6, 0xaa, 0xa7, 0x8a, 0xb8, 0x12, 0x3d, 0x7f, 0xec, 0xf7, 0x82, 0x74,
-	0x2a, 0x05, 0x58, 0x97, 0xb1, 0xed, 0x61, 0xd4, 0xf3, 0x87, 0xdd, 0x63, 0x7f, 0xe8, 0x87, 0x3d,
-	0x2e, 0x7d, 0x34, 0x1b, 0x44, 0x37, 0x4c, 0x0e, 0x49, 0x91, 0x09, 0x57, 0x2d, 0x87, 0xa2, 0x3b,
-	0xd7, 0x8b, 0x46, 0xa3, 0x20, 0x45, 0xef, 0x8d, 0x2c, 0xfb, 0x8c, 0x67, 0x20, 0x34, 0x13, 0x51,
-	0x3a, 0x17, 0xab, 0x57, 0x17, 0xbd, 0x59, 0x20, 0xb6, 0x82, 0xee, 0x01, 0x2a, 0x9d, 0xa7, 0xe7,
-	0x6d, 0x10, 0xad, 0x64, 0x08, 0xee, 0xc3, 0x24, 0x4c, 0x78, 0x9a, 0x0e, 0x79, 0x5f, 0x0f, 0xa8,
-	0x41, 0x64, 0xc5, 0x0a, 0x76, 0x17, 0x56, 0x84, 0x43, 0x99, 0xf8, 0x69, 0x94, 0x9c, 0x06, 0x49,
-	0x37, 0x41, 0xd7, 0xac, 0x49, 0xf4, 0x65, 0x55, 0xec, 0x6d, 0xd8, 0xc8, 0xc1, 0x31, 0xef, 0xf1,
-	0xe0, 0x8c, 0xf7, 0xdb, 0x0b, 0xf4, 0xd5, 0x45, 0xd5, 0xec, 0x06, 0x34, 0xd0, 0x8f, 0x9e, 0x8c,
-	0xfb, 0x3e, 0xda, 0xda, 0x45, 0xda, 0x07, 0x13, 0x62, 0x6f, 0xc2, 0xc2, 0x98, 0x0b, 0x83, 0x78,
-	0x9a, 0x0e, 0x7b, 0x49, 0x7b, 0x89, 0xac, 0x55, 0x43, 0x0a, 0x13, 0x72, 0xae, 0x67, 0x53, 0x20,
-	0x53, 0xf6, 0x12, 0x72, 0xa8, 0xfc, 0x69, 0xbb, 0x45, 0xec, 0x96, 0x01, 0x24, 0x23, 0x71, 0x70,
-	0xe6, 0xa7, 0xbc, 0xbd, 0x4c, 0xbc, 0xa5, 0x8a, 0xee, 0x1f, 0x3b, 0xb0, 0x72, 0x10, 0x24, 0xa9,
-	0x64, 0x42, 0xad, 0x72, 0x5f, 0x81, 0x86, 0x60, 0xbf, 0x6e, 0x14, 0x0e, 0xa7, 0x92, 0x23, 0x41,
-	0x40, 0x8f, 0xc3, 0xe1, 0x94, 0x7d, 0x02, 0x16, 0x82, 0xd0, 0x24, 0x11, 0x32, 0xdc, 0x54, 0x20,
-	0x11, 0xbd, 0x02, 0x8d, 0xf1, 0xe4, 0x78, 0x18, 0xf4, 0x04, 0xc9, 0x8c, 0x68, 0x45, 0x40, 0x44,
-	0x80, 0x8e, 0x90, 0x18, 0x89, 0xa0, 0xa8, 0x12, 0x45, 0x43, 0x62, 0x48, 0xe2, 0xde, 0x83, 0x55,
-	0x7b, 0x80, 0x52, 0x59, 0x6d, 0xc2, 0xbc, 0xe4, 0xed, 0xa4, 0xdd, 0xa0, 0xf5, 0x59, 0x94, 0xeb,
-	0x23, 0x49, 0x3d, 0x5d, 0xef, 0xfe, 0xa0, 0x0a, 0x2b, 0x12, 0xdd, 0x19, 0x46, 0x09, 0x3f, 0x9a,
-	0x8c, 0x46, 0x7e, 0x5c, 0x22, 0x34, 0xce, 0x25, 0x42, 0x53, 0xb1, 0x85, 0x06, 0x59, 0xf9, 0xd4,
-	0x0f, 0x42, 0xe1, 0xc5, 0x09, 0x89, 0x33, 0x10, 0x76, 0x0b, 0x96, 0x7a, 0xc3, 0x28, 0x11, 0x9e,
-	0x8d, 0x79, 0x44, 0xca, 0xc3, 0x45, 0x21, 0xaf, 0x95, 0x09, 0xb9, 0x29, 0xa4, 0xb3, 0x39, 0x21,
-	0x75, 0xa1, 0x89, 0x8d, 0x72, 0xa5, 0x73, 0xe6, 0x84, 0xa7, 0x65, 0x62, 0x38, 0x9e, 0xbc, 0x48,
-	0x08, 0xf9, 0x5b, 0x2a, 0x13, 0x08, 0x3c, 0x81, 0xa1, 0x4e, 0x33, 0xa8, 0xeb, 0x52, 0x20, 0x8a,
-	0x55, 0xec, 0x3e, 0x80, 0xe8, 0x8b, 0x4c, 0x35, 0x90, 0xa9, 0x7e, 0xdd, 0xde, 0x11, 0x73, 0xed,
-	0x6f, 0x63, 0x61, 0x12, 0x73, 0x32, 0xd6, 0xc6, 0x97, 0xee, 0x6f, 0x39, 0xd0, 0x30, 0xea, 0xd8,
-	0x1a, 0x2c, 0xef, 0x3c, 0x7e, 0x7c, 0xb8, 0xe7, 0x6d, 0x3f, 0x79, 0xf8, 0xe5, 0xbd, 0xee, 0xce,
-	0xc1, 0xe3, 0xa3, 0xbd, 0xd6, 0x15, 0x84, 0x0f, 0x1e, 0xef, 0x6c, 0x1f, 0x74, 0xef, 0x3f, 0xf6,
-	0x76, 0x14, 0xec, 0xa0, 0x21, 0xf7, 0xf6, 0xde, 0x7b, 0xfc, 0x64, 0xcf, 0xc2, 0x2b, 0xac, 0x05,
-	0xcd, 0x7b, 0xde, 0xde, 0xf6, 0xce, 0xbe, 0x44, 0x66, 0xd8, 0x2a, 0xb4, 0xee, 0xbf, 0xff, 0x68,
-	0xf7, 0xe1, 0xa3, 0x07, 0xdd, 0x9d, 0xed, 0x47, 0x3b, 0x7b, 0x07, 0x7b, 0xbb, 0xad, 0x2a, 0x5b,
-	0x80, 0xfa, 0xf6, 0xbd, 0xed, 0x47, 0xbb, 0x8f, 0x1f, 0xed, 0xed, 0xb6, 0x6a, 0xee, 0x3f, 0x39,
-	0xb0, 0x46, 0xa3, 0xee, 0xe7, 0x05, 0xe4, 0x06, 0x34, 0x7a, 0x51, 0x34, 0xe6, 0xa8, 0xcf, 0xb5,
-	0xca, 0x36, 0x21, 0x64, 0x7e, 0xa1, 0x20, 0x4f, 0xa2, 0xb8, 0xc7, 0xa5, 0x7c, 0x00, 0x41, 0xf7,
-	0x11, 0x41, 0xe6, 0x97, 0xdb, 0x2b, 0x28, 0x84, 0x78, 0x34, 0x04, 0x26, 0x48, 0xd6, 0x61, 0xf6,
-	0x38, 0xe6, 0x7e, 0xef, 0x54, 0x4a, 0x86, 0x2c, 0xb1, 0x4f, 0x66, 0x4e, 0x78, 0x0f, 0x57, 0x7f,
-	0xc8, 0xfb, 0xc4, 0x31, 0xf3, 0xde, 0x92, 0xc4, 0x77, 0x24, 0x8c, 0x9a, 0xc1, 0x3f, 0xf6, 0xc3,
-	0x7e, 0x14, 0xf2, 0x3e, 0x31, 0xcd, 0xbc, 0x97, 0x01, 0xee, 0x21, 0xac, 0xe7, 0xe7, 0x27, 0xe5,
-	0xeb, 0x2d, 0x43, 0xbe, 0x84, 0xb7, 0xdc, 0xb9, 0x78, 0x37, 0x0d, 0x59, 0xfb, 0x37, 0x07, 0xaa,
-	0x68, 0x6c, 0x2f, 0x36, 0xcc, 0xa6, 0xff, 0x34, 0x63, 0xf9, 0x4f, 0x14, 0x4e, 0xc0, 0x53, 0x86,
-	0x50, 0xbf, 0xc2, 0x44, 0x19, 0x48, 0x56, 0x1f, 0xf3, 0xde, 0x19, 0xcd, 0x58, 0xd7, 0x23, 0x82,
-	0x02, 0x82, 0xae, 0x28, 0x7d, 0x2d, 0x05, 0x44, 0x95, 0x55, 0x1d, 0x7d, 0x39, 0x97, 0xd5, 0xd1,
-	0x77, 0x6d, 0x98, 0x0b, 0xc2, 0xe3, 0x68, 0x12, 0xf6, 0x49, 0x20, 0xe6, 0x3d, 0x55, 0xc4, 0xe5,
-	0x1b, 0x93, 0xa0, 0x06, 0x23, 0xc5, 0xfe, 0x19, 0xe0, 0x32, 0x3c, 0xaa, 0x24, 0xe4, 0x5c, 0xe8,
-	0x60, 0xc2, 0x5b, 0xb0, 0x6c, 0x60, 0x72, 0x35, 0x5f, 0x85, 0xda, 0x18, 0x01, 0xb9, 0x94, 0x4a,
-	0x95, 0x93, 0x57, 0x22, 0x6a, 0xdc, 0x16, 0x2c, 0x3e, 0xe0, 0xe9, 0xc3, 0xf0, 0x24, 0x52, 0x2d,
-	0xfd, 0x70, 0x06, 0x96, 0x34, 0x24, 0x1b, 0xba, 0x05, 0x4b, 0x41, 0x9f, 0x87, 0x69, 0x90, 0x4e,
-	0xbb, 0xd6, 0x89, 0x28, 0x0f, 0xa3, 0x37, 0xe7, 0x0f, 0x03, 0x3f, 0x91, 0xfe, 0x82, 0x28, 0xb0,
-	0x2d, 0x58, 0x45, 0x53, 0xa3, 0xac, 0x87, 0xde, 0x62, 0x71, 0x30, 0x2b, 0xad, 0x43, 0x65, 0x80,
-	0xb8, 0xd4, 0xf6, 0xfa, 0x13, 0xe1, 0xd5, 0x94, 0x55, 0xe1, 0xaa, 0x89, 0x96, 0x70, 0xca, 0x35,
-	0x61, 0x8e, 0x34, 0x50, 0x08, 0x0a, 0xcd, 0x0a, 0x55, 0x95, 0x0f, 0x0a, 0x19, 0x81, 0xa5, 0xf9,
-	0x42, 0x60, 0x09, 0x55, 0xd9, 0x34, 0xec, 0xf1, 0x7e, 0x37, 0x8d, 0xba, 0xa4, 0x72, 0x69, 0x77,
-	0xe6, 0xbd, 0x3c, 0x4c, 0x21, 0x30, 0x9e, 0xa4, 0x21, 0x4f, 0x49, 0x2b, 0xcd, 0x7b, 0xaa, 0x88,
-	0xd2, 0x45, 0x24, 0xc2, 0x80, 0xd4, 0x3d, 0x59, 0x42, 0xb7, 0x74, 0x12, 0x07, 0x49, 0xbb, 0x49,
-	0x28, 0xfd, 0x66, 0x9f, 0x86, 0xb5, 0x63, 0x9e, 0xa4, 0xdd, 0x53, 0xee, 0xf7, 0x79, 0x4c, 0xbb,
-	0x2f, 0xe2, 0x55, 0xc2, 0xda, 0x97, 0x57, 0x62, 0xdf, 0x67, 0x3c, 0x4e, 0x82, 0x28, 0x24, 0x3b,
-	0x5f, 0xf7, 0x54, 0xd1, 0xfd, 0x16, 0x79, 0xcf, 0x3a, 0x92, 0xf6, 0x3e, 0x99, 0x7e, 0xf6, 0x12,
-	0xd4, 0xc5, 0x1c, 0x93, 0x53, 0x5f, 0x3a, 0xf4, 0xf3, 0x04, 0x1c, 0x9d, 0xfa, 0xa8, 0x2f, 0xac,
-	0x65, 0x13, 0xa1, 0xc9, 0x06, 0x61, 0xfb, 0x62, 0xd5, 0x5e, 0x83, 0x45, 0x15, 0xa3, 0x4b, 0xba,
-	0x43, 0x7e, 0x92, 0xaa, 0x03, 0x77, 0x38, 0x19, 0x61, 0x77, 0xc9, 0x01, 0x3f, 0x49, 0xdd, 0x47,
-	0xb0, 0x2c, 0x65, 0xf8, 0xf1, 0x98, 0xab, 0xae, 0x3f, 0x5b, 0x66, 0x0b, 0x1b, 0x5b, 0x2b, 0xb6,
-	0xd0, 0x53, 0xd4, 0x20, 0x67, 0x20, 0x5d, 0x0f, 0x98, 0xa9, 0x13, 0x64, 0x83, 0xd2, 0x20, 0xa9,
-	0x63, 0xbd, 0x9c, 0x8e, 0x85, 0xe1, 0xfa, 0x24, 0x93, 0x5e, 0x0f, 0x35, 0x81, 0xd0, 0x8f, 0xaa,
-	0xe8, 0x7e, 0xdf, 0x81, 0x15, 0x6a, 0x4d, 0x59, 0x73, 0x7d, 0x16, 0x7c, 0xf1, 0x61, 0x36, 0x7b,
-	0x66, 0xa8, 0x63, 0x15, 0x6a, 0xa6, 0x26, 0x16, 0x85, 0x1f, 0xfd, 0x74, 0x5b, 0x2d, 0x9c, 0x6e,
-	0x7f, 0xe8, 0xc0, 0xb2, 0x50, 0x86, 0xa9, 0x9f, 0x4e, 0x12, 0x39, 0xfd, 0x9f, 0x83, 0x05, 0x61,
-	0xd5, 0xa4, 0x38, 0xc9, 0x81, 0xae, 0x6a, 0xc9, 0x27, 0x54, 0x10, 0xef, 0x5f, 0xf1, 0x6c, 0x62,
-	0xf6, 0x79, 0x68, 0x9a, 0x81, 0x56, 0x1a, 0x73, 0x63, 0xeb, 0xaa, 0x9a, 0x65, 0x81, 0x73, 0xf6,
-	0xaf, 0x78, 0xd6, 0x07, 0xec, 0x5d, 0x72, 0x4d, 0xc2, 0x2e, 0x35, 0x2b, 0x43, 0x55, 0x57, 0x4b,
-	0x14, 0xb8, 0xfe, 0xdc, 0x20, 0xbf, 0x37, 0x0f, 0xb3, 0xc2, 0x17, 0x75, 0x1f, 0xc0, 0x82, 0x35,
-	0x52, 0xeb, 0xd4, 0xde, 0x14, 0xa7, 0xf6, 0x42, 0x90, 0xa7, 0x52, 0x0c, 0xf2, 0xb8, 0xdf, 0x9e,
-	0x01, 0x86, 0xdc, 0x96, 0xdb, 0x4e, 0x74, 0x86, 0xa3, 0xbe, 0x75, 0xb4, 0x69, 0x7a, 0x26, 0xc4,
-	0x6e, 0x03, 0x33, 0x8a, 0x2a, 0x0e, 0x26, 0xec, 0x46, 0x49, 0x0d, 0x2a, 0x38, 0x69, 0x76, 0xa5,
-	0x81, 0x94, 0x87, 0x38, 0xb1, 0x6f, 0xa5, 0x75, 0x68, 0x1a, 0xc6, 0x93, 0xe4, 0x14, 0x9d, 0x75,
-	0x75, 0xf8, 0x51, 0xe5, 0x3c, 0x83, 0xcc, 0x5e, 0xca, 0x20, 0x73, 0x79, 0x06, 0x31, 0xdd, 0xef,
-	0x79, 0xcb, 0xfd, 0x46, 0xb7, 0x6f, 0x84, 0xce, 0x62, 0x3a, 0xec, 0x75, 0x47, 0xd8, 0xbb, 0x3c,
-	0xeb, 0x58, 0x20, 0xdb, 0x84, 0x96, 0x74, 0x14, 0x32, 0x1f, 0x1f, 0x68, 0x8d, 0x0b, 0x38, 0x6a,
-	0x5e, 0xfc, 0x98, 0x34, 0x00, 0x9d, 0x77, 0x6a, 0x5e, 0x06, 0xb8, 0x1f, 0x3b, 0xd0, 0xc2, 0x5d,
-	0xb0, 0x38, 0xf5, 0x1d, 0x20, 0x41, 0x79, 0x41, 0x46, 0xb5, 0x68, 0x7f, 0x72, 0x3e, 0x7d, 0x1b,
-	0xea, 0xd4, 0x60, 0x34, 0xe6, 0xa1, 0x64, 0xd3, 0xb6, 0xcd, 0xa6, 0x99, 0x8e, 0xda, 0xbf, 0xe2,
-	0x65, 0xc4, 0x06, 0x93, 0xfe, 0x83, 0x03, 0x0d, 0x39, 0xcc, 0x1f, 0xfb, 0x54, 0xdf, 0x81, 0x79,
-	0xe4, 0x57, 0xe3, 0xe8, 0xac, 0xcb, 0x68, 0x6b, 0x46, 0x7e, 0x3a, 0x89, 0xd1, 0xb8, 0x5a, 0x27,
-	0xfa, 0x3c, 0x8c, 0x96, 0x92, 0xd4, 0x71, 0xd2, 0x4d, 0x83, 0x61, 0x57, 0xd5, 0xca, 0x5b, 0x8f,
-	0xb2, 0x2a, 0xd4, 0x4a, 0x49, 0xea, 0x0f, 0xb8, 0x34, 0x82, 0xa2, 0xe0, 0xb6, 0x61, 0x5d, 0x4e,
-	0x28, 0xe7, 0x77, 0xba, 0x7f, 0xdd, 0x84, 0x8d, 0x42, 0x95, 0xbe, 0x36, 0x94, 0x47, 0xd5, 0x61,
-	0x30, 0x3a, 0x8e, 0xb4, 0xd3, 0xee, 0x98, 0xa7, 0x58, 0xab, 0x8a, 0x0d, 0x60, 0x4d, 0x59, 0x7b,
-	0x5c, 0xd3, 0xcc, 0xb6, 0x57, 0xc8, 0x4d, 0x79, 0xd3, 0xe6, 0x81, 0x7c, 0x87, 0x0a, 0x37, 0xe5,
-	0xba, 0xbc, 0x3d, 0x76, 0x0a, 0x6d, 0xed, 0x56, 0x48, 0x03, 0x60, 0xb8, 0x1e, 0xd8, 0xd7, 0x1b,
-	0x97, 0xf4, 0x65, 0xb9, 0xa9, 0xde, 0x85, 0xad, 0xb1, 0x29, 0x5c, 0x57, 0x75, 0xa4, 0xe1, 0x8b,
-	0xfd, 0x55, 0x5f, 0x68, 0x6e, 0xe4, 0x80, 0xdb, 0x9d, 0x5e, 0xd2, 0x30, 0xfb, 0x06, 0xac, 0x9f,
-	0xfb, 0x41, 0xaa, 0x86, 0x65, 0xb8, 0x4a, 0x35, 0xea, 0x72, 0xeb, 0x92, 0x2e, 0x3f, 0x10, 0x1f,
-	0x5b, 0x66, 0xef, 0x82, 0x16, 0x3b, 0x7f, 0xe7, 0xc0, 0xa2, 0xdd, 0x0e, 0xb2, 0xa9, 0x54, 0x07,
-	0x4a, 0x2d, 0x2a, 0xd7, 0x30, 0x07, 0x17, 0xcf, 0xbd, 0x95, 0xb2, 0x73, 0xaf, 0x79, 0xda, 0x9c,
-	0xb9, 0x2c, 0x24, 0x54, 0x7d, 0xb1, 0x90, 0x50, 0xad, 0x2c, 0x24, 0xd4, 0xf9, 0x2f, 0x07, 0x58,
-	0x91, 0x97, 0xd8, 0x03, 0x71, 0xf0, 0x0e, 0xf9, 0x50, 0xea, 0xa4, 0xff, 0xff, 0x62, 0xfc, 0xa8,
-	0xd6, 0x4e, 0x7d, 0x8d, 0x82, 0x61, 0x2a, 0x1d, 0xd3, 0x81, 0x5a, 0xf0, 0xca, 0xaa, 0x72, 0x41,
-	0xaa, 0xea, 0xe5, 0x41, 0xaa, 0xda, 0xe5, 0x41, 0xaa, 0xd9, 0x7c, 0x90, 0xaa, 0xf3, 0x1b, 0x0e,
-	0xac, 0x94, 0x6c, 0xfa, 0x4f, 0x6f, 0xe2, 0xb8, 0x4d, 0x96, 0x2e, 0xa8, 0xc8, 0x6d, 0x32, 0xc1,
-	0xce, 0xaf, 0xc0, 0x82, 0xc5, 0xe8, 0x3f, 0xbd, 0xfe, 0xf3, 0x3e, 0xa0, 0xe0, 0x33, 0x0b, 0xeb,
-	0xfc, 0x7b, 0x05, 0x58, 0x51, 0xd8, 0xfe, 0x4f, 0xc7, 0x50, 0x5c, 0xa7, 0x99, 0x92, 0x75, 0xfa,
-	0x99, 0xda, 0x81, 0x37, 0x60, 0x59, 0xe6, 0x18, 0x18, 0xe1, 0x16, 0xc1, 0x31, 0xc5, 0x0a, 0xf4,
-	0x82, 0xed, 0x08, 0xe1, 0xbc, 0x75, 0x37, 0x6d, 0x18, 0xc3, 0x5c, 0xa0, 0xd0, 0x5d, 0x87, 0x55,
-	0x91, 0xb3, 0x70, 0x4f, 0x34, 0xa5, 0xec, 0xca, 0x1f, 0x39, 0xb0, 0x96, 0xab, 0xc8, 0x6e, 0x52,
-	0x85, 0xe9, 0xb0, 0xed, 0x89, 0x0d, 0xe2, 0xf8, 0xa5, 0x1c, 0x19, 0xe3, 0x17, 0xdc, 0x56, 0xac,
-	0xc0, 0xf5, 0x99, 0x84, 0x45, 0x7a, 0xb1, 0xea, 0x65, 0x55, 0xee, 0x86, 0xc8, 0xac, 0x08, 0xf9,
-	0x30, 0x37, 0xf0, 0x13, 0x91, 0x0b, 0x61, 0x56, 0x64, 0xd7, 0x34, 0xf6, 0x90, 0x55, 0x11, 0x7d,
-	0x44, 0xcb, 0x4c, 0xd9, 0xe3, 0x2d, 0xad, 0x73, 0x7f, 0xe0, 0x00, 0xfb, 0xd2, 0x84, 0xc7, 0x53,
-	0xba, 0x51, 0xd5, 0x71, 0xa0, 0x8d, 0x7c, 0x94, 0x63, 0x76, 0x3c, 0x39, 0xfe, 0x22, 0x9f, 0xaa,
-	0x7b, 0xf7, 0x4a, 0x76, 0xef, 0xfe, 0x32, 0x00, 0x1e, 0xce, 0xf4, 0x35, 0x2d, 0xf9, 0x66, 0xe1,
-	0x64, 0x24, 0x1a, 0x2c, 0xbd, 0x1a, 0xaf, 0x5e, 0x7e, 0x35, 0x5e, 0xbb, 0xec, 0x6a, 0xfc, 0x5d,
-	0x58, 0xb1, 0xc6, 0xad, 0xb7, 0x55, 0x5d, 0x18, 0x3b, 0xcf, 0xb9, 0x30, 0xfe, 0x0f, 0x07, 0x66,
-	0xf6, 0xa3, 0xb1, 0x19, 0x03, 0x75, 0xec, 0x18, 0xa8, 0xb4, 0x25, 0x5d, 0x6d, 0x2a, 0xa4, 0x8a,
-	0xb1, 0x40, 0xb6, 0x09, 0x8b, 0xfe, 0x28, 0xc5, 0x43, 0xf9, 0x49, 0x14, 0x9f, 0xfb, 0x71, 0x5f,
-	0xec, 0xf5, 0xbd, 0x4a, 0xdb, 0xf1, 0x72, 0x35, 0x6c, 0x15, 0x66, 0xb4, 0xd2, 0x25, 0x02, 0x2c,
-	0xa2, 0xe3, 0x46, 0xf7, 0x27, 0x53, 0x19, 0x4f, 0x90, 0x25, 0x64, 0x25, 0xfb, 0x7b, 0xe1, 0x48,
-	0x0b, 0xd1, 0x29, 0xab, 0x42, 0xbb, 0x86, 0xcb, 0x47, 0x64, 0x32, 0x10, 0xa4, 0xca, 0xee, 0xbf,
-	0x3a, 0x50, 0xa3, 0x15, 0x40, 0x61, 0x17, 0x1c, 0xae, 0x83, 0x9d, 0x34, 0xf3, 0x05, 0x2f, 0x0f,
-	0x33, 0xd7, 0xca, 0x4f, 0xa9, 0xe8, 0x61, 0x9b, 0x39, 0x2a, 0x37, 0xa0, 0x2e, 0x4a, 0x3a, 0x17,
-	0x83, 0x48, 0x32, 0x90, 0x5d, 0x87, 0xea, 0x69, 0x34, 0x56, 0xde, 0x09, 0xa8, 0x58, 0x7f, 0x34,
-	0xf6, 0x08, 0xcf, 0xc6, 0x83, 0xed, 0x89, 0xc1, 0x0b, 0x9b, 0x93, 0x87, 0xd1, 0xea, 0xea, 0x66,
-	0xcd, 0xc5, 0xc8, 0xa1, 0xee, 0x26, 0x2c, 0x3d, 0x8a, 0xfa, 0xdc, 0x88, 0x38, 0x5d, 0xc8, 0xcd,
-	0xee, 0xaf, 0x3a, 0x30, 0xaf, 0x88, 0xd9, 0x2d, 0xa8, 0xa2, 0x2b, 0x91, 0x3b, 0x28, 0xe8, 0x3b,
-	0x3e, 0xa4, 0xf3, 0x88, 0x02, 0x75, 0x2f, 0xc5, 0x23, 0x32, 0xb7, 0x52, 0x45, 0x23, 0x32, 0xaf,
-	0x49, 0x0f, 0x37, 0xe7, 0x6c, 0xe4, 0x50, 0xf7, 0xcf, 0x1d, 0x58, 0xb0, 0xfa, 0xc0, 0xc3, 0xe3,
-	0xd0, 0x4f, 0x52, 0x79, 0x6f, 0x22, 0xb7, 0xc7, 0x84, 0xcc, 0x18, 0x64, 0xc5, 0x8e, 0x41, 0xea,
-	0xe8, 0xd8, 0x8c, 0x19, 0x1d, 0xbb, 0x0b, 0xf5, 0x2c, 0x8b, 0xa8, 0x6a, 0xe9, 0x54, 0xec, 0x51,
-	0xdd, 0x5e, 0x66, 0x44, 0xd8, 0x4e, 0x2f, 0x1a, 0x46, 0xb1, 0x0c, 0xd8, 0x8b, 0x82, 0xfb, 0x2e,
-	0x34, 0x0c, 0x7a, 0x1c, 0x46, 0xc8, 0xd3, 0xf3, 0x28, 0x7e, 0xaa, 0x42, 0xa1, 0xb2, 0xa8, 0x2f,
-	0xe2, 0x2b, 0xd9, 0x45, 0xbc, 0xfb, 0xb7, 0x0e, 0x2c, 0x20, 0x0f, 0x06, 0xe1, 0xe0, 0x30, 0x1a,
-	0x06, 0xbd, 0x29, 0xed, 0xbd, 0x62, 0x37, 0xa9, 0x19, 0x14, 0x2f, 0xda, 0x30, 0xf2, 0xb6, 0x3a,
-	0x3b, 0x4a, 0x41, 0xd4, 0x65, 0x94, 0x54, 0xe4, 0xf3, 0x63, 0x3f, 0x91, 0xcc, 0x2f, 0x8d, 0x9c,
-	0x05, 0xa2, 0x3c, 0x21, 0x10, 0xfb, 0x29, 0xef, 0x8e, 0x82, 0xe1, 0x30, 0x10, 0xb4, 0xc2, 0x05,
-	0x2a, 0xab, 0xc2, 0x3e, 0xfb, 0x41, 0xe2, 0x1f, 0x67, 0x41, 0x68, 0x5d, 0x76, 0xff, 0xb2, 0x02,
-	0x0d, 0xa9, 0x9e, 0xf7, 0xfa, 0x03, 0x2e, 0x6f, 0x4c, 0xc8, 0xc9, 0xd4, 0xaa, 0xc4, 0x40, 0x54,
-	0xbd, 0xe5, 0x96, 0x1a, 0x48, 0x7e, 0xcb, 0x67, 0x8a, 0x5b, 0x7e, 0x0d, 0xea, 0xc8, 0x7a, 0x6f,
-	0x92, 0xff, 0x2b, 0x6e, 0x5b, 0x32, 0x40, 0xd5, 0x6e, 0x51, 0x6d, 0x2d, 0xab, 0x25, 0xe0, 0xb9,
-	0xf7, 0x2b, 0x6f, 0x43, 0x53, 0x36, 0x43, 0x7b, 0x42, 0x9a, 0x23, 0x63, 0x7e, 0x6b, 0xbf, 0x3c,
-	0x8b, 0x52, 0x7d, 0xb9, 0xa5, 0xbe, 0x9c, 0xbf, 0xec, 0x4b, 0x45, 0x49, 0x77, 0xe1, 0x62, 0x6d,
-	0x1e, 0xc4, 0xfe, 0xf8, 0x54, 0x99, 0xbc, 0xbe, 0x4e, 0xe2, 0x21, 0x98, 0x6d, 0x42, 0x0d, 0x3f,
-	0x53, 0x9a, 0xbc, 0x5c, 0x20, 0x05, 0x09, 0xbb, 0x05, 0x35, 0xde, 0x1f, 0x70, 0x75, 0xc2, 0x63,
-	0xf6, 0x59, 0x1b, 0xf7, 0xc8, 0x13, 0x04, 0xa8, 0x1e, 0x10, 0xcd, 0xa9, 0x07, 0xdb, 0x0a, 0xcc,
-	0x62, 0xf1, 0x61, 0xdf, 0x5d, 0x05, 0xf6, 0x48, 0x70, 0xb4, 0x19, 0xbf, 0xfe, 0xf6, 0x0c, 0x34,
-	0x0c, 0x18, 0x25, 0x7d, 0x80, 0x03, 0xee, 0xf6, 0x03, 0x7f, 0xc4, 0x53, 0x1e, 0x4b, 0x2e, 0xce,
-	0xa1, 0x48, 0xe7, 0x9f, 0x0d, 0xba, 0xd1, 0x24, 0xed, 0xf6, 0xf9, 0x20, 0xe6, 0xc2, 0x30, 0xa3,
-	0xa1, 0xb0, 0x50, 0xa4, 0x1b, 0xf9, 0x1f, 0x9a, 0x74, 0x82, 0x1f, 0x72, 0xa8, 0x8a, 0x46, 0x8b,
-	0x35, 0xaa, 0x66, 0xd1, 0x68, 0xb1, 0x22, 0x79, 0x1d, 0x55, 0x2b, 0xd1, 0x51, 0x6f, 0xc1, 0xba,
-	0xd0, 0x46, 0x52, 0x6e, 0xbb, 0x39, 0x36, 0xb9, 0xa0, 0x96, 0x6d, 0x42, 0x0b, 0xc7, 0xac, 0x18,
-	0x3c, 0x09, 0xbe, 0x25, 0xe2, 0x43, 0x8e, 0x57, 0xc0, 0x91, 0x96, 0x02, 0x35, 0x26, 0xad, 0xb8,
-	0x9d, 0x2b, 0xe0, 0x44, 0xeb, 0x7f, 0x68, 0xd3, 0xd6, 0x25, 0x6d, 0x0e, 0x77, 0x17, 0xa0, 0x71,
-	0x94, 0x46, 0x63, 0xb5, 0x29, 0x8b, 0xd0, 0x14, 0x45, 0x99, 0x0b, 0xf1, 0x12, 0x5c, 0x25, 0x2e,
-	0x7a, 0x12, 0x8d, 0xa3, 0x61, 0x34, 0x98, 0x1e, 0x4d, 0x8e, 0x93, 0x5e, 0x1c, 0x8c, 0xf1, 0x34,
-	0xe4, 0xfe, 0xbd, 0x03, 0x2b, 0x56, 0xad, 0x0c, 0x19, 0x7d, 0x5a, 0xb0, 0xb4, 0xbe, 0xc4, 0x16,
-	0x8c, 0xb7, 0x6c, 0xa8, 0x4a, 0x41, 0x28, 0x42, 0x79, 0xef, 0xcb, 0x7b, 0xed, 0x6d, 0x58, 0x52,
-	0x23, 0x53, 0x1f, 0x0a, 0x2e, 0x6c, 0x17, 0xb9, 0x50, 0x7e, 0xbf, 0x28, 0x3f, 0x50, 0x4d, 0xfc,
-	0xbc, 0xbc, 0xe5, 0xec, 0xd3, 0x1c, 0x55, 0xec, 0x40, 0xdf, 0x4c, 0x99, 0x27, 0x08, 0x35, 0x82,
-	0x9e, 0x06, 0x13, 0xf7, 0xb7, 0x1d, 0x80, 0x6c, 0x74, 0x74, 0x37, 0xa6, 0xd5, 0xbd, 0x48, 0xae,
-	0x36, 0x54, 0xfb, 0xab, 0xd0, 0xd4, 0x77, 0x2a, 0x99, 0x05, 0x69, 0x28, 0x0c, 0x9d, 0xbc, 0x9b,
-	0xb0, 0x34, 0x18, 0x46, 0xc7, 0x64, 0x7e, 0x29, 0xb9, 0x26, 0x91, 0x19, 0x21, 0x8b, 0x02, 0xbe,
-	0x2f, 0xd1, 0xcc, 0xdc, 0x54, 0x0d, 0x73, 0xe3, 0x7e, 0xa7, 0xa2, 0x23, 0xf1, 0xd9, 0x9c, 0x2f,
-	0x94, 0x32, 0xb6, 0x55, 0x50, 0x8e, 0x17, 0x04, 0xbe, 0x29, 0x4a, 0x76, 0x78, 0xe9, 0x21, 0xfe,
-	0x5d, 0x58, 0x8c, 0x85, 0xf6, 0x51, 0xaa, 0xa9, 0xfa, 0x1c, 0xd5, 0xb4, 0x10, 0x5b, 0x36, 0xe9,
-	0x93, 0xd0, 0xf2, 0xfb, 0x67, 0x3c, 0x4e, 0x03, 0x3a, 0x46, 0x91, 0x43, 0x20, 0x14, 0xea, 0x92,
-	0x81, 0x93, 0x9d, 0xbe, 0x09, 0x4b, 0x32, 0x0b, 0x47, 0x53, 0xca, 0xec, 0xd0, 0x0c, 0x46, 0x42,
-	0xf7, 0x4f, 0x55, 0xd0, 0xdf, 0xde, 0xc3, 0x8b, 0x57, 0xc4, 0x9c, 0x5d, 0x25, 0x37, 0xbb, 0x4f,
-	0xc8, 0x00, 0x7c, 0x5f, 0x9d, 0xd5, 0x66, 0x8c, 0x1b, 0xf1, 0xbe, 0xbc, 0x30, 0xb1, 0x97, 0xb4,
-	0xfa, 0x22, 0x4b, 0xea, 0x7e, 0xec, 0xc0, 0xdc, 0x7e, 0x34, 0xde, 0x97, 0xb9, 0x01, 0x24, 0x08,
-	0x3a, 0x8f, 0x4d, 0x15, 0x9f, 0x93, 0x35, 0x50, 0x6a, 0x87, 0x17, 0xf2, 0x76, 0xf8, 0x17, 0xe0,
-	0x25, 0x8a, 0x14, 0xc4, 0xd1, 0x38, 0x8a, 0x51, 0x18, 0xfd, 0xa1, 0x30, 0xba, 0x51, 0x98, 0x9e,
-	0x2a, 0x35, 0xf6, 0x3c, 0x12, 0x3a, 0x92, 0xe1, 0x51, 0x42, 0x38, 0xca, 0xd2, 0x6f, 0x10, 0xda,
-	0xad, 0x58, 0xe1, 0x7e, 0x16, 0xea, 0xe4, 0xf8, 0xd2, 0xb4, 0xde, 0x80, 0xfa, 0x69, 0x34, 0xee,
-	0x9e, 0x06, 0x61, 0xaa, 0x84, 0x7b, 0x31, 0xf3, 0x48, 0xf7, 0x69, 0x41, 0x34, 0x81, 0xfb, 0x07,
-	0x35, 0x98, 0x7b, 0x18, 0x9e, 0x45, 0x41, 0x8f, 0xee, 0x07, 0x46, 0x7c, 0x14, 0xa9, 0xac, 0x3e,
-	0xfc, 0x8d, 0x4b, 0x41, 0xd9, 0x2f, 0xe3, 0x54, 0x06, 0xf8, 0x55, 0x11, 0xcd, 0x7d, 0x9c, 0x65,
-	0xde, 0x0a, 0xd1, 0x31, 0x10, 0x74, 0xfa, 0x63, 0x33, 0x49, 0x59, 0x96, 0xb2, 0xb4, 0xc8, 0x9a,
-	0x91, 0x16, 0x49, 0xb7, 0x49, 0x22, 0x8f, 0x41, 0x5e, 0x74, 0xab, 0x22, 0x1d, 0x52, 0x62, 0x2e,
-	0x22, 0x3c, 0xe4, 0x38, 0xcc, 0xc9, 0x43, 0x8a, 0x09, 0xa2, 0x73, 0x21, 0x3e, 0x10, 0x34, 0x42,
-	0xf9, 0x9a, 0x10, 0x3a, 0x62, 0xf9, 0x3c, 0xe7, 0xba, 0xe0, 0xf9, 0x1c, 0x8c, 0x1a, 0xba, 0xcf,
-	0xb5, 0x22, 0x15, 0x73, 0x00, 0x91, 0x59, 0x9c, 0xc7, 0x8d, 0xa3, 0x8d, 0x48, 0x50, 0x52, 0x47,
-	0x1b, 0x64, 0x14, 0x7f, 0x38, 0x3c, 0xf6, 0x7b, 0x4f, 0x29, 0x8d, 0x9d, 0xf2, 0x91, 0xea, 0x9e,
-	0x0d, 0x52, 0x26, 0x42, 0xb6, 0x9b, 0x74, 0x1f, 0x59, 0xf5, 0x4c, 0x88, 0x6d, 0x41, 0x83, 0x8e,
-	0x73, 0x72, 0x3f, 0x17, 0x69, 0x3f, 0x5b, 0xe6, 0x79, 0x8f, 0x76, 0xd4, 0x24, 0x32, 0xef, 0x2c,
-	0x96, 0xec, 0x3b, 0x0b, 0xa1, 0x34, 0xe5, 0x55, 0x4f, 0x8b, 0x7a, 0xcb, 0x00, 0xb4, 0xa6, 0x72,
-	0xc1, 0x04, 0xc1, 0x32, 0x11, 0x58, 0x18, 0xbb, 0x0e, 0xf3, 0x78, 0x08, 0x19, 0xfb, 0x41, 0xbf,
-	0xcd, 0xf4, 0x59, 0x48, 0x63, 0xd8, 0x86, 0xfa, 0x4d, 0x57, 0x32, 0x2b, 0xb4, 0x2a, 0x16, 0x86,
-	0x6b, 0xa3, 0xcb, 0x24, 0x44, 0xab, 0x62, 0x47, 0x2d, 0xd0, 0x4d, 0x81, 0x6d, 0xf7, 0xfb, 0x92,
-	0x37, 0xf5, 0xd1, 0x37, 0xe3, 0x2a, 0xc7, 0xe2, 0xaa, 0x92, 0xdd, 0xad, 0x94, 0xef, 0xee, 0x73,
-	0xd7, 0xc0, 0xdd, 0x83, 0xc6, 0xa1, 0x91, 0xca, 0x4d, 0x4c, 0xae, 0x92, 0xb8, 0xa5, 0x60, 0x18,
-	0x88, 0x31, 0x9c, 0x8a, 0x39, 0x1c, 0xf7, 0xcf, 0x1c, 0x60, 0x07, 0x41, 0x92, 0xea, 0xe1, 0x8b,
-	0xbe, 0x5d, 0x68, 0xea, 0x00, 0x45, 0x96, 0x9b, 0x65, 0x61, 0x48, 0x43, 0x43, 0xe9, 0x46, 0x27,
-	0x27, 0x09, 0x57, 0x99, 0x14, 0x16, 0x86, 0x1c, 0x8a, 0x3e, 0x0e, 0xfa, 0x0b, 0x81, 0xe8, 0x21,
-	0x91, 0x19, 0x15, 0x05, 0x1c, 0xf5, 0x6c, 0xcc, 0xcf, 0x78, 0x9c, 0x68, 0xd1, 0xd2, 0x65, 0x9d,
-	0x42, 0x96, 0x5f, 0xe5, 0x4d, 0x98, 0xd7, 0xed, 0xda, 0x2a, 0x44, 0x51, 0xea, 0x7a, 0x54, 0x55,
-	0xe4, 0xc3, 0x5b, 0x83, 0x16, 0x6a, 0xb3, 0x58, 0xc1, 0x6e, 0x03, 0x3b, 0x09, 0xe2, 0x3c, 0xf9,
-	0x0c, 0x91, 0x97, 0xd4, 0xb8, 0x1f, 0xc0, 0x8a, 0xec, 0xd2, 0x74, 0x6e, 0xec, 0x4d, 0x74, 0x2e,
-	0x63, 0xe4, 0x4a, 0x91, 0x91, 0xdd, 0xff, 0x76, 0x60, 0x4e, 0xee, 0x34, 0x6d, 0x4b, 0x3e, 0xa7,
-	0xbf, 0xee, 0x59, 0x18, 0x6b, 0x5b, 0xd9, 0xdc, 0xc4, 0xf5, 0x52, 0x75, 0x15, 0x14, 0xd4, 0x4c,
-	0x99, 0x82, 0x62, 0x50, 0x1d, 0xfb, 0xe9, 0x29, 0x9d, 0x4c, 0xeb, 0x1e, 0xfd, 0x66, 0x2d, 0x11,
-	0x2d, 0x11, 0x8a, 0x90, 0x22, 0x25, 0x65, 0x8f, 0x1a, 0x84, 0xbd, 0x2d, 0x3e, 0x6a, 0xb8, 0x06,
-	0x75, 0x1a, 0x40, 0x37, 0x0b, 0x86, 0x64, 0x00, 0x72, 0xae, 0x28, 0x90, 0x84, 0xc9, 0x54, 0xcd,
-	0x0c, 0xc1, 0xf3, 0x09, 0xa5, 0xba, 0x88, 0x56, 0xf5, 0x1d, 0x95, 0x4c, 0xd9, 0xcb, 0xe0, 0x8c,
-	0x23, 0xe4, 0x00, 0xf2, 0x1c, 0x21, 0x49, 0x3d, 0x5d, 0xef, 0x76, 0xa0, 0xbd, 0xcb, 0x87, 0x3c,
-	0xe5, 0xdb, 0xc3, 0x61, 0xbe, 0xfd, 0x97, 0xe0, 0x6a, 0x49, 0x9d, 0xf4, 0x67, 0xbf, 0x04, 0x6b,
-	0xdb, 0x22, 0xbd, 0xe9, 0xa7, 0x95, 0x39, 0xe0, 0xb6, 0x61, 0x3d, 0xdf, 0xa4, 0xec, 0xec, 0x3e,
-	0x2c, 0xef, 0xf2, 0xe3, 0xc9, 0xe0, 0x80, 0x9f, 0x65, 0x1d, 0x31, 0xa8, 0x26, 0xa7, 0xd1, 0xb9,
-	0x14, 0x4c, 0xfa, 0xcd, 0x5e, 0x06, 0x18, 0x22, 0x4d, 0x37, 0x19, 0xf3, 0x9e, 0x4a, 0xc9, 0x26,
-	0xe4, 0x68, 0xcc, 0x7b, 0xee, 0x5b, 0xc0, 0xcc, 0x76, 0xe4, 0x7a, 0xa1, 0x3d, 0x9a, 0x1c, 0x77,
-	0x93, 0x69, 0x92, 0xf2, 0x91, 0xca, 0x35, 0x37, 0x21, 0xf7, 0x26, 0x34, 0x0f, 0xfd, 0xa9, 0xc7,
-	0xbf, 0x29, 0x5f, 0x81, 0x6c, 0xc0, 0xdc, 0xd8, 0x9f, 0xa2, 0x9a, 0xd2, 0xf1, 0x1b, 0xaa, 0x76,
-	0xff, 0xb3, 0x02, 0xb3, 0x82, 0x12, 0x5b, 0xed, 0xf3, 0x24, 0x0d, 0x42, 0x71, 0x63, 0x2b, 0x5b,
-	0x35, 0xa0, 0x02, 0x2b, 0x57, 0x4a, 0x58, 0x59, 0x9e, 0x9a, 0x54, 0x7a, 0xab, 0xe4, 0x57, 0x0b,
-	0x43, 0xe6, 0xca, 0xf2, 0x64, 0x44, 0x00, 0x21, 0x03, 0x72, 0x01, 0xbd, 0xcc, 0xea, 0x89, 0xf1,
-	0x29, 0x29, 0x95, 0x9c, 0x6b, 0x42, 0xa5, 0xb6, 0x75, 0x4e, 0x30, 0x78, 0xc1, 0xb6, 0x16, 0x6c,
-	0xe8, 0xfc, 0x0b, 0xd8, 0x50, 0x71, 0x94, 0x7a, 0x9e, 0x0d, 0x85, 0x17, 0xb0, 0xa1, 0x2e, 0x83,
-	0xd6, 0x7d, 0xce, 0x3d, 0x8e, 0xde, 0x99, 0xe2, 0xdd, 0xef, 0x3a, 0xd0, 0x92, 0x5c, 0xa4, 0xeb,
-	0xd8, 0xab, 0x96, 0x17, 0x5a, 0x9a, 0x84, 0xfa, 0x1a, 0x2c, 0x90, 0x6f, 0xa8, 0x23, 0x97, 0x32,
-	0xcc, 0x6a, 0x81, 0x38, 0x0f, 0x75, 0xbd, 0x34, 0x0a, 0x86, 0x72, 0x53, 0x4c, 0x48, 0x05, 0x3f,
-	0x63, 0x5f, 0xa6, 0xb2, 0x38, 0x9e, 0x2e, 0xbb, 0x7f, 0xe5, 0xc0, 0xb2, 0x31, 0x60, 0xc9, 0x85,
-	0xef, 0x82, 0x92, 0x06, 0x11, 0xe0, 0x14, 0x92, 0xbb, 0x61, 0x8b, 0x4d, 0xf6, 0x99, 0x45, 0x4c,
-	0x9b, 0xe9, 0x4f, 0x69, 0x80, 0xc9, 0x64, 0x24, 0x95, 0xa8, 0x09, 0x21, 0x23, 0x9d, 0x73, 0xfe,
-	0x54, 0x93, 0x08, 0x35, 0x6e, 0x61, 0x94, 0x26, 0x81, 0x3e, 0xad, 0x26, 0x12, 0xf6, 0xcc, 0x06,
-	0xdd, 0x7f, 0x74, 0x60, 0x45, 0x1c, 0x4e, 0xe4, 0xd1, 0x4f, 0xbf, 0x10, 0x98, 0x15, 0xa7, 0x31,
-	0x21, 0x91, 0xfb, 0x57, 0x3c, 0x59, 0x66, 0x9f, 0x79, 0xc1, 0x03, 0x95, 0x4e, 0x8f, 0xb9, 0x60,
-	0x2f, 0x66, 0xca, 0xf6, 0xe2, 0x39, 0x2b, 0x5d, 0x16, 0xd0, 0xab, 0x95, 0x06, 0xf4, 0xee, 0xcd,
-	0x41, 0x2d, 0xe9, 0x45, 0x63, 0xee, 0xae, 0xc3, 0xaa, 0x3d, 0x39, 0xa9, 0x82, 0xbe, 0xe7, 0x40,
-	0xfb, 0xbe, 0x08, 0x6f, 0x07, 0xe1, 0x60, 0x3f, 0x48, 0xd2, 0x28, 0xd6, 0xcf, 0x9e, 0xae, 0x03,
-	0x24, 0xa9, 0x1f, 0xa7, 0x22, 0x7d, 0x51, 0x86, 0xdb, 0x32, 0x04, 0xc7, 0xc8, 0xc3, 0xbe, 0xa8,
-	0x15, 0x7b, 0xa3, 0xcb, 0x05, 0x1f, 0x42, 0x1e, 0x9f, 0x2c, 0x4b, 0xfc, 0xba, 0xc8, 0x37, 0x43,
-	0x5f, 0x81, 0x9f, 0x91, 0x5e, 0x17, 0xe7, 0x92, 0x1c, 0xea, 0xfe, 0x85, 0x03, 0x4b, 0xd9, 0x20,
-	0xf7, 0x10, 0xb4, 0xb5, 0x83, 0x34, 0xbf, 0x99, 0x76, 0x50, 0x81, 0xc0, 0x00, 0xed, 0xb1, 0x1c,
-	0x9b, 0x81, 0x90, 0xc4, 0xca, 0x52, 0x34, 0x51, 0x0e, 0x8e, 0x09, 0x89, 0x4c, 0x0f, 0xf4, 0x04,
-	0xa4, 0x57, 0x23, 0x4b, 0x94, 0x7d, 0x3a, 0x4a, 0xe9, 0xab, 0x59, 0x71, 0x30, 0x93, 0x45, 0x65,
-	0x4a, 0xe7, 0x08, 0xc5, 0x9f, 0xee, 0xef, 0x38, 0x70, 0xb5, 0x64, 0x71, 0xa5, 0x64, 0xec, 0xc2,
-	0xf2, 0x89, 0xae, 0x54, 0x0b, 0x20, 0xc4, 0x63, 0x5d, 0xdd, 0xc7, 0xd8, 0x93, 0xf6, 0x8a, 0x1f,
-	0x68, 0xdf, 0x47, 0x2c, 0xa9, 0x95, 0x42, 0x55, 0xac, 0xd8, 0xfa, 0xdd, 0x19, 0x58, 0x14, 0xf7,
-	0x74, 0xe2, 0x01, 0x32, 0x8f, 0xd9, 0x7b, 0x30, 0x27, 0x1f, 0x90, 0xb3, 0x35, 0xd9, 0xad, 0xfd,
-	0x64, 0xbd, 0xb3, 0x9e, 0x87, 0x25, 0xef, 0xac, 0xfc, 0xfa, 0xc7, 0xff, 0xf2, 0x7b, 0x95, 0x05,
-	0xd6, 0xb8, 0x73, 0xf6, 0xe6, 0x9d, 0x01, 0x0f, 0x13, 0x6c, 0xe3, 0x97, 0x00, 0xb2, 0xa7, 0xd5,
-	0xac, 0xad, 0x7d, 0xb6, 0xdc, 0x9b, 0xf1, 0xce, 0xd5, 0x92, 0x1a, 0xd9, 0xee, 0x55, 0x6a, 0x77,
-	0xc5, 0x5d, 0xc4, 0x76, 0x83, 0x30, 0x48, 0xc5, 0x3b, 0xeb, 0x77, 0x9c, 0x4d, 0xd6, 0x87, 0xa6,
-	0xf9, 0x72, 0x9a, 0xa9, 0xd0, 0x4d, 0xc9, 0xbb, 0xed, 0xce, 0x4b, 0xa5, 0x75, 0x2a, 0x6e, 0x45,
-	0x7d, 0xac, 0xb9, 0x2d, 0xec, 0x63, 0x42, 0x14, 0x59, 0x2f, 0x43, 0x58, 0xb4, 0x1f, 0x48, 0xb3,
-	0x6b, 0x86, 0x58, 0x17, 0x9e, 0x67, 0x77, 0x5e, 0xbe, 0xa0, 0x56, 0xf6, 0xf5, 0x32, 0xf5, 0xb5,
-	0xe1, 0x32, 0xec, 0xab, 0x47, 0x34, 0xea, 0x79, 0xf6, 0x3b, 0xce, 0xe6, 0xd6, 0xc7, 0xd7, 0xa1,
-	0xae, 0x83, 0xad, 0xec, 0x1b, 0xb0, 0x60, 0x5d, 0xa4, 0x32, 0x35, 0x8d, 0xb2, 0x7b, 0xd7, 0xce,
-	0xb5, 0xf2, 0x4a, 0xd9, 0xf1, 0x75, 0xea, 0xb8, 0xcd, 0xd6, 0xb1, 0x63, 0x79, 0x13, 0x79, 0x87,
-	0xae, 0x8f, 0x45, 0x6e, 0xeb, 0x53, 0x31, 0xcf, 0xec, 0xf2, 0xd3, 0x9a, 0x67, 0xe1, 0xb2, 0xd4,
-	0x9a, 0x67, 0xf1, 0xc6, 0xd4, 0xbd, 0x46, 0xdd, 0xad, 0xb3, 0x55, 0xb3, 0x3b, 0x1d, 0x04, 0xe5,
-	0x94, 0x8d, 0x6c, 0xbe, 0x9f, 0x66, 0x2f, 0x6b, 0xc6, 0x2a, 0x7b, 0x57, 0xad, 0x59, 0xa4, 0xf8,
-	0xb8, 0xda, 0x6d, 0x53, 0x57, 0x8c, 0xd1, 0xf6, 0x99, 0xcf, 0xa7, 0xd9, 0xd7, 0xa0, 0xae, 0x1f,
-	0x0b, 0xb2, 0x0d, 0xe3, 0x85, 0xa6, 0xf9, 0x82, 0xb1, 0xd3, 0x2e, 0x56, 0x94, 0x31, 0x86, 0xd9,
-	0x32, 0x32, 0xc6, 0x01, 0xac, 0xc9, 0x33, 0xc0, 0x31, 0xff, 0x51, 0x66, 0x52, 0xf2, 0xea, 0xfb,
-	0xae, 0xc3, 0xde, 0x85, 0x79, 0xf5, 0x06, 0x93, 0xad, 0x97, 0xbf, 0x25, 0xed, 0x6c, 0x14, 0x70,
-	0xa9, 0x3d, 0xbe, 0x02, 0x90, 0xbd, 0x2d, 0xd4, 0x72, 0x56, 0x78, 0xd5, 0xa8, 0x17, 0xb1, 0xf8,
-	0x10, 0xd1, 0x5d, 0xa7, 0xa9, 0xb6, 0x18, 0xc9, 0x59, 0xc8, 0xcf, 0x55, 0x1a, 0xfd, 0x2e, 0x34,
-	0x8c, 0xe7, 0x85, 0x4c, 0xb5, 0x50, 0x7c, 0x9a, 0xd8, 0xe9, 0x94, 0x55, 0xc9, 0x01, 0x7e, 0x01,
-	0x16, 0xac, 0x77, 0x82, 0x9a, 0x91, 0xcb, 0x5e, 0x21, 0x6a, 0x46, 0x2e, 0x7f, 0x5a, 0xf8, 0x55,
-	0x68, 0x18, 0xaf, 0xfa, 0x98, 0x91, 0x20, 0x98, 0x7b, 0xcf, 0xa7, 0x47, 0x54, 0xf6, 0x08, 0x70,
-	0x95, 0xe6, 0xbb, 0xe8, 0xd6, 0x71, 0xbe, 0x94, 0x4b, 0x8e, 0x7b, 0xfa, 0x0d, 0x58, 0xb4, 0xdf,
-	0xf9, 0x69, 0x21, 0x28, 0x7d, 0x31, 0xa8, 0x85, 0xe0, 0x82, 0xc7, 0x81, 0x92, 0x7f, 0x36, 0x57,
-	0x74, 0x27, 0x77, 0x3e, 0x92, 0xb7, 0x86, 0xcf, 0xd8, 0x97, 0x50, 0xd2, 0x65, 0x72, 0x3f, 0xcb,
-	0x5e, 0x37, 0xda, 0x4f, 0x00, 0x34, 0x73, 0x16, 0xde, 0x01, 0xb8, 0xcb, 0xd4, 0x78, 0x83, 0x65,
-	0x33, 0x10, 0xea, 0x9b, 0x92, 0xfc, 0x0d, 0xf5, 0x6d, 0xbe, 0x03, 0x30, 0xd4, 0xb7, 0xf5, 0x16,
-	0x20, 0xaf, 0xbe, 0xd3, 0x00, 0xdb, 0x08, 0x61, 0x29, 0x97, 0x21, 0xa3, 0x79, 0xbb, 0x3c, 0xa5,
-	0xb0, 0x73, 0xfd, 0xf9, 0x89, 0x35, 0xb6, 0x56, 0x50, 0xda, 0xe0, 0x8e, 0xca, 0x00, 0xfd, 0x65,
-	0x68, 0x9a, 0xef, 0xb3, 0xb4, 0x42, 0x2f, 0x79, 0x55, 0xa6, 0x15, 0x7a, 0xd9, 0x83, 0x2e, 0xb5,
-	0xb9, 0xac, 0x69, 0x76, 0x83, 0x9b, 0x6b, 0x3f, 0x50, 0xc9, 0x34, 0x5c, 0xd9, 0xbb, 0x9c, 0x4c,
-	0xc3, 0x95, 0xbe, 0x6a, 0x51, 0x9b, 0xcb, 0x56, 0xac, 0xb9, 0x88, 0x90, 0x30, 0xfb, 0x2a, 0x2c,
-	0x19, 0xe9, 0x67, 0x47, 0xd3, 0xb0, 0xa7, 0x19, 0xb5, 0x98, 0xba, 0xdc, 0x29, 0x73, 0x14, 0xdd,
-	0x0d, 0x6a, 0x7f, 0xd9, 0xb5, 0x26, 0x81, 0x4c, 0xba, 0x03, 0x0d, 0x33, 0xb5, 0xed, 0x39, 0xed,
-	0x6e, 0x18, 0x55, 0x66, 0x9e, 0xee, 0x5d, 0x87, 0xfd, 0xa1, 0x03, 0x4d, 0x2b, 0x51, 0xcc, 0xba,
-	0xf8, 0xc8, 0xb5, 0xd3, 0x36, 0xeb, 0xcc, 0x86, 0x5c, 0x8f, 0x06, 0x79, 0xb0, 0xf9, 0x05, 0x6b,
-	0x11, 0x3e, 0xb2, 0x0e, 0x1c, 0xb7, 0xf3, 0x4f, 0xf9, 0x9f, 0xe5, 0x09, 0xcc, 0xf4, 0xee, 0x67,
-	0x77, 0x1d, 0xf6, 0x27, 0x0e, 0x2c, 0xda, 0xc7, 0x64, 0xbd, 0x55, 0xa5, 0x07, 0x72, 0xbd, 0x55,
-	0x17, 0x9c, 0xad, 0x7f, 0x06, 0xa3, 0x64, 0xef, 0x88, 0x3f, 0xd4, 0x50, 0x31, 0x1b, 0x66, 0xe8,
-	0xe6, 0xfc, 0xb6, 0x9a, 0xff, 0x26, 0x71, 0xcb, 0xb9, 0xeb, 0xb0, 0xaf, 0x8b, 0x7f, 0x15, 0x90,
-	0xdf, 0x12, 0x77, 0xbc, 0xe8, 0xf7, 0xee, 0x6b, 0x34, 0x97, 0xeb, 0xee, 0x55, 0x6b, 0x2e, 0x79,
-	0xe3, 0xb4, 0x2d, 0x46, 0x27, 0xff, 0x2c, 0x22, 0x53, 0xdb, 0x85, 0x3f, 0x90, 0xb8, 0x78, 0x90,
-	0x23, 0x31, 0x48, 0x49, 0x6e, 0xb1, 0xf0, 0x0b, 0x36, 0xe3, 0x6e, 0xd2, 0x58, 0x5f, 0x73, 0x5f,
-	0xb9, 0x70, 0xac, 0x77, 0xe8, 0x90, 0x8b, 0x23, 0x3e, 0x04, 0xc8, 0xe2, 0xab, 0x2c, 0x17, 0xdf,
-	0xd3, 0x96, 0xab, 0x18, 0x82, 0xb5, 0xe5, 0x44, 0x85, 0x01, 0xb1, 0xc5, 0xaf, 0x09, 0x75, 0xf2,
-	0x50, 0x45, 0x06, 0xaf, 0x1a, 0x2a, 0xc3, 0x0e, 0x84, 0x76, 0x3a, 0x65, 0x55, 0x65, 0xca, 0x44,
-	0x87, 0x19, 0xdf, 0x87, 0x85, 0x83, 0x28, 0x7a, 0x3a, 0x19, 0xeb, 0xdb, 0x0a, 0x3b, 0xfe, 0xb4,
-	0xef, 0x27, 0xa7, 0x9d, 0xdc, 0x2c, 0xdc, 0x1b, 0xd4, 0x54, 0x87, 0xb5, 0x8d, 0xa6, 0xee, 0x7c,
-	0x94, 0xc5, 0x6f, 0x9f, 0x31, 0x1f, 0x96, 0xb5, 0x53, 0xa1, 0x07, 0xde, 0xb1, 0x9b, 0x31, 0x23,
-	0x8f, 0x85, 0x2e, 0x2c, 0x37, 0x4f, 0x8d, 0xf6, 0x4e, 0xa2, 0xda, 0xbc, 0xeb, 0xb0, 0x43, 0x68,
-	0xee, 0xf2, 0x5e, 0xd4, 0xe7, 0x32, 0x88, 0xb3, 0x92, 0x0d, 0x5c, 0x47, 0x7f, 0x3a, 0x0b, 0x16,
-	0x68, 0xeb, 0xed, 0xb1, 0x3f, 0x8d, 0xf9, 0x37, 0xef, 0x7c, 0x24, 0xc3, 0x43, 0xcf, 0x94, 0xde,
-	0x56, 0xf1, 0x33, 0x4b, 0x6f, 0xe7, 0x02, 0x6e, 0x96, 0xde, 0x2e, 0x04, 0xdc, 0xac, 0xa5, 0x56,
-	0xf1, 0x3b, 0x36, 0x84, 0xe5, 0x42, 0x8c, 0x8e, 0xbd, 0xa2, 0x2c, 0xef, 0x05, 0x91, 0xbd, 0xce,
-	0x8d, 0x8b, 0x09, 0xec, 0xde, 0x36, 0xed, 0xde, 0x8e, 0x60, 0x61, 0x97, 0x8b, 0xc5, 0x12, 0x29,
-	0x11, 0xb9, 0xb7, 0x8a, 0x66, 0xfa, 0x44, 0x5e, 0x71, 0x53, 0x9d, 0x6d, 0x98, 0x29, 0x1f, 0x81,
-	0x7d, 0x0d, 0x1a, 0x0f, 0x78, 0xaa, 0x72, 0x20, 0xb4, 0x83, 0x97, 0x4b, 0x8a, 0xe8, 0x94, 0xa4,
-	0x50, 0xd8, 0x3c, 0x43, 0xad, 0xdd, 0xe1, 0xfd, 0x01, 0x17, 0xca, 0xa9, 0x1b, 0xf4, 0x9f, 0xb1,
-	0x5f, 0xa4, 0xc6, 0x75, 0x4a, 0xd5, 0xba, 0x71, 0x75, 0x6e, 0x36, 0xbe, 0x94, 0xc3, 0xcb, 0x5a,
-	0x0e, 0xa3, 0x3e, 0x37, 0x5c, 0x94, 0x10, 0x1a, 0x46, 0xbe, 0x9f, 0x16, 0xa0, 0x62, 0xee, 0xa2,
-	0x16, 0xa0, 0x92, 0xf4, 0x40, 0xf7, 0x16, 0xf5, 0xe3, 0xb2, 0x1b, 0x59, 0x3f, 0x22, 0x25, 0x30,
-	0xeb, 0xe9, 0xce, 0x47, 0xfe, 0x28, 0x7d, 0xc6, 0x3e, 0xa0, 0x77, 0x8b, 0x66, 0x9e, 0x47, 0xe6,
-	0xb1, 0xe6, 0x53, 0x42, 0xf4, 0x62, 0x19, 0x55, 0xb6, 0x17, 0x2b, 0xba, 0x22, 0x4f, 0xe6, 0x33,
-	0x00, 0x47, 0x69, 0x34, 0xde, 0xf5, 0xf9, 0x28, 0x0a, 0x33, 0x5d, 0x9b, 0xe5, 0x32, 0x64, 0xfa,
-	0xcb, 0x48, 0x68, 0x60, 0x1f, 0x18, 0x2e, 0xbe, 0x95, 0x26, 0xa3, 0x98, 0xeb, 0xc2, 0x74, 0x07,
-	0xbd, 0x20, 0x25, 0x29, 0x0f, 0x77, 0x1d, 0xb6, 0x0d, 0x90, 0x05, 0x69, 0xb5, 0xc3, 0x5e, 0x88,
-	0xff, 0x6a, 0xb5, 0x57, 0x12, 0xd1, 0x3d, 0x84, 0x7a, 0x16, 0xf5, 0xdb, 0xc8, 0x72, 0x36, 0xad,
-	0x18, 0xa1, 0xb6, 0xdc, 0x85, 0x58, 0x9c, 0xdb, 0xa2, 0xa5, 0x02, 0x36, 0x8f, 0x4b, 0x45, 0x01,
-	0xb6, 0x00, 0x56, 0xc4, 0x00, 0xb5, 0x1b, 0x42, 0xb7, 0xf3, 0x6a, 0x26, 0x25, 0xf1, 0x30, 0x2d,
-	0xcd, 0xa5, 0xe1, 0x24, 0xeb, 0xe8, 0x8e, 0xdc, 0x2a, 0x32, 0x03, 0x50, 0x35, 0x8f, 0x60, 0xb9,
-	0x10, 0x0b, 0xd1, 0x22, 0x7d, 0x51, 0x08, 0x4a, 0x8b, 0xf4, 0x85, 0x61, 0x14, 0x77, 0x8d, 0xba,
-	0x5c, 0x72, 0x01, 0xbb, 0x4c, 0xce, 0x83, 0xb4, 0x77, 0xfa, 0x8e, 0xb3, 0x79, 0x3c, 0x4b, 0x7f,
-	0xc0, 0xf7, 0xa9, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x63, 0x24, 0x7e, 0xd9, 0xb2, 0x4f, 0x00,
-	0x00,
+	// 8534 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7d, 0x5b, 0x6c, 0x24, 0xc9,
+	0x71, 0xe0, 0x54, 0x3f, 0xc8, 0xee, 0xe8, 0x66, 0x77, 0x33, 0xf9, 0x98, 0x9e, 0x9a, 0x17, 0xb7,
+	0x34, 0xb7, 0x33, 0x9a, 0x5b, 0xcd, 0xcc, 0x8e, 0xb4, 0x7b, 0xab, 0xdd, 0xd3, 0x83, 0x43, 0x72,
+	0x86, 0xb3, 0xcb, 0x9d, 0xa1, 0x8a, 0x33, 0x1a, 0x3d, 0xee, 0xae, 0x55, 0xec, 0x4e, 0x92, 0xa5,
+	0xe9, 0xae, 0x6a, 0x55, 0x55, 0x93, 0x43, 0xed, 0xed, 0x3d, 0x85, 0x3b, 0xe0, 0x70, 0xc2, 0x41,
+	0xb8, 0x03, 0x0e, 0x77, 0x80, 0x61, 0x58, 0x36, 0x20, 0xdb, 0x3f, 0x16, 0x60, 0x5b, 0x3f, 0xf2,
+	0x87, 0x61, 0xe8, 0xc7, 0x06, 0x0c, 0x7d, 0xe8, 0xcb, 0x30, 0x60, 0xc0, 0x80, 0x7e, 0x6c, 0xc3,
+	0x3f, 0xf6, 0xaf, 0x6d, 0x18, 0x91, 0xaf, 0xca, 0xac, 0xaa, 0x26, 0xb9, 0x0f, 0xe9, 0x8b, 0x9d,
+	0x11, 0x51, 0xf9, 0x88, 0x8c, 0x8c, 0x8c, 0x8c, 0x88, 0x4c, 0x42, 0x3d, 0x1a, 0xf7, 0x6f, 0x8d,
+	0xa3, 0x30, 0x09, 0x49, 0x75, 0x18, 0x44, 0xe3, 0xbe, 0x7d, 0x69, 0x3f, 0x0c, 0xf7, 0x87, 0xf4,
+	0xb6, 0x37, 0xf6, 0x6f, 0x7b, 0x41, 0x10, 0x26, 0x5e, 0xe2, 0x87, 0x41, 0xcc, 0x89, 0x9c, 0x6f,
+	0x40, 0xeb, 0x01, 0x0d, 0x76, 0x28, 0x1d, 0xb8, 0xf4, 0x5b, 0x13, 0x1a, 0x27, 0xe4, 0x5f, 0xc2,
+	0xbc, 0x47, 0xbf, 0x4d, 0xe9, 0xa0, 0x37, 0xf6, 0xe2, 0x78, 0x7c, 0x10, 0x79, 0x31, 0xed, 0x5a,
+	0x2b, 0xd6, 0x8d, 0xa6, 0xdb, 0xe1, 0x88, 0x6d, 0x05, 0x27, 0x2f, 0x41, 0x33, 0x46, 0x52, 0x1a,
+	0x24, 0x51, 0x38, 0x3e, 0xee, 0x96, 0x18, 0x5d, 0x03, 0x61, 0x1b, 0x1c, 0xe4, 0x0c, 0xa1, 0xad,
+	0x5a, 0x88, 0xc7, 0x61, 0x10, 0x53, 0x72, 0x07, 0x16, 0xfb, 0xfe, 0xf8, 0x80, 0x46, 0x3d, 0xf6,
+	0xf1, 0x28, 0xa0, 0xa3, 0x30, 0xf0, 0xfb, 0x5d, 0x6b, 0xa5, 0x7c, 0xa3, 0xee, 0x12, 0x8e, 0xc3,
+	0x2f, 0xde, 0x15, 0x18, 0x72, 0x1d, 0xda, 0x34, 0xe0, 0x70, 0x3a, 0x60, 0x5f, 0x89, 0xa6, 0x5a,
+	0x29, 0x18, 0x3f, 0x70, 0x7e, 0x62, 0xc1, 0xfc, 0xc3, 0xc0, 0x4f, 0x9e, 0x79, 0xc3, 0x21, 0x4d,
+	0xe4, 0x98, 0xae, 0x43, 0xfb, 0x88, 0x01, 0xd8, 0x98, 0x8e, 0xc2, 0x68, 0x20, 0x46, 0xd4, 0xe2,
+	0xe0, 0x6d, 0x01, 0x9d, 0xda, 0xb3, 0xd2, 0xd4, 0x9e, 0x15, 0xb2, 0xab, 0x3c, 0x85, 0x5d, 0xd7,
+	0xa1, 0x1d, 0xd1, 0x7e, 0x78, 0x48, 0xa3, 0xe3, 0xde, 0x91, 0x1f, 0x0c, 0xc2, 0xa3, 0x6e, 0x65,
+	0xc5, 0xba, 0x51, 0x75, 0x5b, 0x12, 0xfc, 0x8c, 0x41, 0x9d, 0x45, 0x20, 0xfa, 0x28, 0x38, 0xdf,
+	0x9c, 0x7d, 0x58, 0x78, 0x1a, 0x0c, 0xc3, 0xfe, 0xf3, 0x0f, 0x39, 0xba, 0x82, 0xe6, 0x4b, 0x85,
+	0xcd, 0x2f, 0xc3, 0xa2, 0xd9, 0x90, 0xe8, 0x00, 0x85, 0xa5, 0xb5, 0x03, 0x2f, 0xd8, 0xa7, 0xb2,
+	0x4a, 0xd9, 0x85, 0x4f, 0x42, 0xa7, 0x3f, 0x89, 0x22, 0x1a, 0xe4, 0xfa, 0xd0, 0x16, 0x70, 0xd5,
+	0x89, 0x97, 0xa0, 0x19, 0xd0, 0xa3, 0x94, 0x4c, 0x88, 0x4c, 0x40, 0x8f, 0x24, 0x89, 0xd3, 0x85,
+	0xe5, 0x6c, 0x33, 0xa2, 0x03, 0xbf, 0x53, 0x82, 0xc6, 0x93, 0xc8, 0x0b, 0x62, 0xaf, 0x8f, 0x52,
+	0x4c, 0xba, 0x30, 0x9b, 0xbc, 0xe8, 0x1d, 0x78, 0xf1, 0x01, 0x6b, 0xae, 0xee, 0xca, 0x22, 0x59,
+	0x86, 0x19, 0x6f, 0x14, 0x4e, 0x82, 0x84, 0x35, 0x50, 0x76, 0x45, 0x89, 0xbc, 0x02, 0xf3, 0xc1,
+	0x64, 0xd4, 0xeb, 0x87, 0xc1, 0x9e, 0x1f, 0x8d, 0xf8, 0x5a, 0x60, 0xf3, 0x55, 0x75, 0xf3, 0x08,
+	0x72, 0x05, 0x60, 0x17, 0xf9, 0xc0, 0x9b, 0xa8, 0xb0, 0x26, 0x34, 0x08, 0x71, 0xa0, 0x29, 0x4a,
+	0xd4, 0xdf, 0x3f, 0x48, 0xba, 0x55, 0x56, 0x91, 0x01, 0xc3, 0x3a, 0x12, 0x7f, 0x44, 0x7b, 0x71,
+	0xe2, 0x8d, 0xc6, 0xdd, 0x19, 0xd6, 0x1b, 0x0d, 0xc2, 0xf0, 0x61, 0xe2, 0x0d, 0x7b, 0x7b, 0x94,
+	0xc6, 0xdd, 0x59, 0x81, 0x57, 0x10, 0xf2, 0x32, 0xb4, 0x06, 0x34, 0x4e, 0x7a, 0xde, 0x60, 0x10,
+	0xd1, 0x38, 0xa6, 0x71, 0xb7, 0xc6, 0xa4, 0x31, 0x03, 0x25, 0x8b, 0x50, 0x1d, 0x7a, 0xbb, 0x74,
+	0xd8, 0xad, 0xb3, 0x6e, 0xf2, 0x02, 0xf2, 0xf2, 0x01, 0x4d, 0x34, 0x9e, 0xc5, 0x62, 0xce, 0x1c,
+	0x0f, 0xce, 0x6f, 0x21, 0x89, 0x86, 0x93, 0xd3, 0x49, 0xa0, 0x92, 0xbc, 0xf0, 0xe5, 0x14, 0xb2,
+	0xdf, 0x69, 0xf5, 0x25, 0xad, 0x7a, 0x72, 0x09, 0xea, 0x28, 0x38, 0x47, 0x91, 0x9f, 0x70, 0xb1,
+	0xaf, 0xb9, 0x29, 0xc0, 0xb1, 0xa1, 0x9b, 0x6f, 0x42, 0x4c, 0xe5, 0x16, 0x10, 0x0d, 0xbc, 0x4e,
+	0x13, 0xcf, 0x1f, 0xc6, 0xe4, 0x75, 0x68, 0x26, 0x5a, 0x5f, 0x99, 0x4a, 0x68, 0xdc, 0x25, 0xb7,
+	0x98, 0x2e, 0xbb, 0xa5, 0xd7, 0x63, 0xd0, 0x39, 0x0f, 0xa0, 0x76, 0x9f, 0xd2, 0x2d, 0x7f, 0xe4,
+	0x27, 0x64, 0x19, 0xaa, 0x7b, 0xfe, 0x0b, 0xca, 0xbb, 0x5f, 0xde, 0x3c, 0xe7, 0xf2, 0x22, 0xb1,
+	0x61, 0x76, 0x4c, 0xa3, 0x3e, 0x95, 0x32, 0xb1, 0x79, 0xce, 0x95, 0x80, 0x7b, 0xb3, 0x50, 0x1d,
+	0xe2, 0xc7, 0xce, 0x6f, 0x96, 0xa0, 0xb1, 0x43, 0x83, 0x81, 0xc6, 0x0a, 0xe4, 0xb3, 0x64, 0x05,
+	0xfe, 0x26, 0x57, 0xa1, 0x81, 0x7f, 0x7b, 0x71, 0x12, 0xf9, 0xc1, 0xbe, 0x60, 0x08, 0x20, 0x68,
+	0x87, 0x41, 0x48, 0x07, 0xca, 0xde, 0x28, 0x61, 0xfc, 0x28, 0xbb, 0xf8, 0x13, 0xa5, 0x7e, 0xec,
+	0x1d, 0x8f, 0x70, 0x81, 0x28, 0x51, 0x6a, 0xba, 0x0d, 0x01, 0xdb, 0x44, 0x59, 0xba, 0x05, 0x0b,
+	0x3a, 0x89, 0xac, 0xbd, 0xca, 0x6a, 0x9f, 0xd7, 0x28, 0x45, 0x23, 0xd7, 0xa1, 0x2d, 0xe9, 0x23,
+	0xde, 0x59, 0x26, 0x5c, 0x75, 0xb7, 0x25, 0xc0, 0x72, 0x08, 0x37, 0xa0, 0xb3, 0xe7, 0x07, 0xde,
+	0xb0, 0xd7, 0x1f, 0x26, 0x87, 0xbd, 0x01, 0x1d, 0x26, 0x1e, 0x13, 0xb3, 0xaa, 0xdb, 0x62, 0xf0,
+	0xb5, 0x61, 0x72, 0xb8, 0x8e, 0x50, 0xf2, 0x0a, 0xd4, 0xf7, 0x28, 0xed, 0x31, 0x4e, 0x74, 0x6b,
+	0x2b, 0xd6, 0x8d, 0xc6, 0xdd, 0xb6, 0x60, 0xbd, 0xe4, 0xae, 0x5b, 0xdb, 0x13, 0xbf, 0x9c, 0xff,
+	0x63, 0x41, 0x93, 0xb3, 0x4a, 0xe8, 0xf5, 0x6b, 0x30, 0x27, 0x7b, 0x44, 0xa3, 0x28, 0x8c, 0xc4,
+	0x9a, 0x34, 0x81, 0xe4, 0x26, 0x74, 0x24, 0x60, 0x1c, 0x51, 0x7f, 0xe4, 0xed, 0x53, 0xa1, 0x04,
+	0x72, 0x70, 0x72, 0x37, 0xad, 0x31, 0x0a, 0x27, 0x42, 0xc4, 0x1a, 0x77, 0x9b, 0xa2, 0x53, 0x2e,
+	0xc2, 0x5c, 0x93, 0xc4, 0xf9, 0xae, 0x05, 0x04, 0xbb, 0xf5, 0x24, 0xe4, 0x68, 0xc1, 0x85, 0xec,
+	0x0c, 0x58, 0x67, 0x9e, 0x81, 0xd2, 0xb4, 0x19, 0xb8, 0x06, 0x33, 0xac, 0x49, 0x54, 0x20, 0xe5,
+	0x5c, 0xb7, 0x04, 0xce, 0xf9, 0xbe, 0x05, 0x4d, 0x54, 0x67, 0x01, 0x1d, 0x6e, 0x87, 0x7e, 0x90,
+	0x90, 0x3b, 0x40, 0xf6, 0x26, 0xc1, 0xc0, 0x0f, 0xf6, 0x7b, 0xb8, 0xb2, 0x7a, 0xbb, 0xc7, 0x58,
+	0x05, 0xeb, 0xcf, 0xe6, 0x39, 0xb7, 0x00, 0x47, 0x5e, 0x81, 0x8e, 0x01, 0x8d, 0x93, 0x88, 0xf7,
+	0x6a, 0xf3, 0x9c, 0x9b, 0xc3, 0xa0, 0x52, 0x0a, 0x27, 0xc9, 0x78, 0x92, 0xf4, 0xfc, 0x60, 0x40,
+	0x5f, 0x30, 0x9e, 0xcd, 0xb9, 0x06, 0xec, 0x5e, 0x0b, 0x9a, 0xfa, 0x77, 0xce, 0xe7, 0xa1, 0xb3,
+	0x85, 0xda, 0x2a, 0xf0, 0x83, 0xfd, 0x55, 0xae, 0x52, 0x50, 0x85, 0x8e, 0x27, 0xbb, 0xcf, 0xe9,
+	0xb1, 0x98, 0x47, 0x51, 0xc2, 0x25, 0x71, 0x10, 0xc6, 0x89, 0xe0, 0x0b, 0xfb, 0xed, 0xfc, 0x51,
+	0x09, 0xda, 0xc8, 0xf4, 0x77, 0xbd, 0xe0, 0x58, 0x72, 0x7c, 0x0b, 0x9a, 0x58, 0xd5, 0x93, 0x70,
+	0x95, 0x2b, 0x62, 0xbe, 0x96, 0x6f, 0x08, 0x26, 0x65, 0xa8, 0x6f, 0xe9, 0xa4, 0x68, 0x3b, 0x1c,
+	0xbb, 0xc6, 0xd7, 0xb8, 0xe8, 0x12, 0x2f, 0xda, 0xa7, 0x09, 0x53, 0xd1, 0x42, 0x65, 0x03, 0x07,
+	0xad, 0x85, 0xc1, 0x1e, 0x59, 0x81, 0x66, 0xec, 0x25, 0xbd, 0x31, 0x8d, 0x18, 0xd7, 0xd8, 0xc2,
+	0x29, 0xbb, 0x10, 0x7b, 0xc9, 0x36, 0x8d, 0xee, 0x1d, 0x27, 0x94, 0x7c, 0x0a, 0xea, 0xc8, 0x04,
+	0x9c, 0x84, 0xb8, 0x3b, 0xb3, 0x52, 0xd6, 0xc4, 0xfb, 0xf1, 0x24, 0x61, 0x93, 0xe3, 0xa6, 0x14,
+	0xa9, 0xc6, 0x9b, 0xd5, 0x35, 0x5e, 0x17, 0x66, 0x99, 0x76, 0xf7, 0x07, 0x6c, 0x85, 0x34, 0x5d,
+	0x59, 0xb4, 0xbf, 0x00, 0xf3, 0xb9, 0x41, 0xa0, 0x2a, 0x48, 0x39, 0x88, 0x3f, 0xb1, 0xda, 0x43,
+	0x6f, 0x38, 0xa1, 0x62, 0x63, 0xe2, 0x85, 0x37, 0x4b, 0x6f, 0x58, 0xce, 0xcb, 0xd0, 0x49, 0xb9,
+	0x22, 0xd6, 0x94, 0xae, 0x8a, 0xeb, 0x5c, 0x15, 0x3b, 0xff, 0x60, 0x71, 0xc2, 0xb5, 0xd0, 0x57,
+	0xea, 0x1c, 0x09, 0x71, 0x2f, 0x90, 0x84, 0xf8, 0x7b, 0xea, 0x26, 0xf8, 0xcb, 0xe7, 0xa5, 0x0d,
+	0xb5, 0x98, 0x06, 0x83, 0x9e, 0x37, 0xe4, 0xec, 0xac, 0xb9, 0xaa, 0x9c, 0xf2, 0xb9, 0x36, 0x85,
+	0xcf, 0x75, 0x83, 0xcf, 0xce, 0x75, 0x98, 0xd7, 0x46, 0x7f, 0x02, 0x9f, 0x0e, 0xa1, 0x26, 0xfb,
+	0x42, 0x56, 0x00, 0x0a, 0x16, 0x9b, 0x06, 0x23, 0x97, 0xa0, 0x96, 0x5b, 0x5c, 0xb5, 0x0f, 0xb4,
+	0xa8, 0x66, 0x78, 0x1f, 0x9c, 0xff, 0x64, 0x41, 0xeb, 0xde, 0x64, 0x34, 0xbe, 0x4f, 0x69, 0x6a,
+	0x55, 0xd7, 0x24, 0x33, 0x58, 0xe3, 0x05, 0xdc, 0x52, 0x04, 0xd9, 0xe9, 0x29, 0x9d, 0x3a, 0x3d,
+	0xe5, 0xec, 0xf4, 0x38, 0xab, 0xd0, 0x56, 0x3d, 0x98, 0xce, 0x21, 0x9c, 0x96, 0x88, 0x8e, 0x87,
+	0x5e, 0x5f, 0x18, 0xd4, 0x35, 0x57, 0x95, 0x51, 0x8f, 0xce, 0x3f, 0xa2, 0x47, 0x42, 0x1b, 0xc8,
+	0x81, 0xbc, 0x01, 0x95, 0xe4, 0x78, 0xcc, 0x4f, 0x04, 0xad, 0xbb, 0xd7, 0xc4, 0x20, 0x72, 0x74,
+	0xb7, 0x44, 0xf1, 0xc9, 0xf1, 0x98, 0xba, 0xec, 0x0b, 0xe7, 0xf3, 0xd0, 0xd0, 0x80, 0xe4, 0x3c,
+	0x2c, 0x3c, 0x7b, 0xf8, 0xe4, 0xd1, 0xc6, 0xce, 0x4e, 0x6f, 0xfb, 0xe9, 0xbd, 0x77, 0x36, 0xbe,
+	0xda, 0xdb, 0x5c, 0xdd, 0xd9, 0xec, 0x9c, 0x23, 0xcb, 0x40, 0x1e, 0x6d, 0xec, 0x3c, 0xd9, 0x58,
+	0x37, 0xe0, 0x96, 0x73, 0x0b, 0x88, 0xde, 0x8c, 0x18, 0x55, 0x17, 0x66, 0x85, 0x09, 0x24, 0x2d,
+	0x40, 0x51, 0x74, 0xb6, 0x81, 0x6c, 0xf9, 0x71, 0xf2, 0x34, 0x88, 0xc7, 0xda, 0x66, 0x78, 0x09,
+	0xea, 0x23, 0x3f, 0x60, 0x8c, 0xe5, 0x5f, 0x54, 0xdd, 0x14, 0xc0, 0xb0, 0xde, 0x0b, 0x81, 0x2d,
+	0x09, 0xac, 0x04, 0x38, 0x6f, 0xc0, 0x82, 0x51, 0xa3, 0xe8, 0xc2, 0x4b, 0x50, 0x9d, 0x24, 0x2f,
+	0x42, 0x69, 0xac, 0x34, 0x04, 0x4f, 0x9e, 0x26, 0x2f, 0x42, 0x97, 0x63, 0x9c, 0xbf, 0xb7, 0xa0,
+	0x82, 0x65, 0xf2, 0xc5, 0x0f, 0xc1, 0xbe, 0xa6, 0x18, 0x51, 0x0f, 0xbf, 0xd4, 0x07, 0x5c, 0x32,
+	0x06, 0x8c, 0x86, 0x24, 0x5f, 0xdf, 0xbd, 0xd8, 0x93, 0xc6, 0x87, 0x06, 0xc1, 0xc1, 0x8d, 0x9f,
+	0xf7, 0xe2, 0x7e, 0xe4, 0x8f, 0x13, 0x61, 0xcb, 0xa6, 0x00, 0x43, 0x42, 0xab, 0xa7, 0x49, 0xe8,
+	0x35, 0x98, 0x33, 0x2d, 0x68, 0x6e, 0xd6, 0x9a, 0x40, 0xe7, 0x3f, 0x5b, 0x40, 0xb6, 0xa8, 0x17,
+	0xd3, 0xc7, 0x6c, 0x95, 0xc8, 0x29, 0x68, 0x41, 0x49, 0xd9, 0x96, 0x25, 0x7f, 0x60, 0xb4, 0x5c,
+	0x3a, 0xad, 0xe5, 0x5b, 0x40, 0xe8, 0x8b, 0xb1, 0x1f, 0xb1, 0x26, 0x7a, 0x31, 0xed, 0x87, 0xc1,
+	0x80, 0x1b, 0xf0, 0x15, 0xb7, 0x00, 0xe3, 0xbc, 0x06, 0x0b, 0x46, 0x17, 0xc4, 0x9c, 0x5d, 0x01,
+	0x48, 0x89, 0x59, 0x5f, 0x2a, 0xae, 0x06, 0x71, 0x76, 0x60, 0xd1, 0xa5, 0xc3, 0x8f, 0xb7, 0xef,
+	0xce, 0x79, 0x58, 0xca, 0x54, 0x2a, 0x6c, 0xe1, 0x97, 0x81, 0xec, 0xf8, 0xfb, 0xc1, 0xbb, 0x34,
+	0x8e, 0xbd, 0x7d, 0xa5, 0x33, 0x3a, 0x50, 0x1e, 0xc5, 0xfb, 0xa2, 0x31, 0xfc, 0xe9, 0x7c, 0x1a,
+	0x16, 0x0c, 0x3a, 0x31, 0x98, 0x4b, 0x50, 0x8f, 0xfd, 0xfd, 0xc0, 0x4b, 0x26, 0x11, 0x15, 0xab,
+	0x20, 0x05, 0x38, 0xf7, 0x61, 0xf1, 0xcb, 0x34, 0xf2, 0xf7, 0x8e, 0x4f, 0xab, 0xde, 0xac, 0xa7,
+	0x94, 0xad, 0x67, 0x03, 0x96, 0x32, 0xf5, 0x88, 0xe6, 0xf9, 0x86, 0x26, 0xd8, 0x52, 0x73, 0x79,
+	0x41, 0xb3, 0x1e, 0x4a, 0xba, 0xf5, 0xe0, 0x3c, 0x05, 0xb2, 0x16, 0x06, 0x01, 0xed, 0x27, 0xdb,
+	0x94, 0x46, 0xa9, 0x7e, 0x4c, 0x77, 0xaf, 0xc6, 0xdd, 0xf3, 0x82, 0x87, 0x59, 0x93, 0x44, 0x6c,
+	0x6b, 0x04, 0x2a, 0x63, 0x1a, 0x8d, 0x84, 0xc6, 0x62, 0xbf, 0x9d, 0x25, 0x58, 0x30, 0xaa, 0x15,
+	0x9c, 0x7d, 0x15, 0x96, 0xd6, 0xfd, 0xb8, 0x9f, 0x6f, 0xb0, 0x0b, 0xb3, 0xe3, 0xc9, 0x6e, 0x2f,
+	0xdd, 0x9b, 0x65, 0x11, 0x4f, 0x4c, 0xd9, 0x4f, 0x44, 0x65, 0xff, 0xcd, 0x82, 0xca, 0xe6, 0x93,
+	0xad, 0x35, 0x54, 0x9b, 0x7e, 0xd0, 0x0f, 0x47, 0x68, 0x1d, 0xf2, 0x41, 0xab, 0xf2, 0xd4, 0x3d,
+	0xf7, 0x12, 0xd4, 0x99, 0x51, 0x89, 0xbb, 0x98, 0x70, 0x10, 0xa4, 0x00, 0x3c, 0x96, 0x6a, 0xc2,
+	0x2b, 0x4e, 0x93, 0x15, 0xb6, 0xc7, 0xe4, 0x11, 0xce, 0x3f, 0x55, 0x60, 0x56, 0x98, 0x94, 0xac,
+	0xbd, 0x7e, 0xe2, 0x1f, 0x52, 0xd1, 0x13, 0x51, 0xc2, 0x25, 0x1a, 0xd1, 0x51, 0x98, 0xd0, 0x9e,
+	0x31, 0x0d, 0x26, 0x90, 0x2d, 0x64, 0x5e, 0x51, 0x8f, 0x0b, 0x71, 0x99, 0x53, 0x19, 0x40, 0x64,
+	0x16, 0x02, 0x70, 0x2f, 0xae, 0xb0, 0xa5, 0x22, 0x8b, 0xc8, 0x89, 0xbe, 0x37, 0xf6, 0xfa, 0x7e,
+	0x72, 0x2c, 0x8c, 0x04, 0x55, 0xc6, 0xba, 0x87, 0x61, 0xdf, 0x1b, 0xf6, 0x76, 0xbd, 0xa1, 0x17,
+	0xf4, 0xa9, 0x54, 0x12, 0x06, 0x10, 0x8f, 0xb7, 0xa2, 0x4b, 0x92, 0x8c, 0x1f, 0x81, 0x33, 0x50,
+	0x5c, 0xb1, 0xfd, 0x70, 0x34, 0xf2, 0x13, 0x3c, 0x15, 0x33, 0x53, 0xa1, 0xec, 0x6a, 0x10, 0xae,
+	0x92, 0x58, 0xe9, 0x88, 0x73, 0xaf, 0x2e, 0x55, 0x92, 0x06, 0xc4, 0x5a, 0xf0, 0x84, 0x83, 0x3b,
+	0xe7, 0xf3, 0xa3, 0x2e, 0xf0, 0x5a, 0x52, 0x08, 0xce, 0xc3, 0x24, 0x88, 0x69, 0x92, 0x0c, 0xe9,
+	0x40, 0x75, 0xa8, 0xc1, 0xc8, 0xf2, 0x08, 0x72, 0x07, 0x16, 0xf8, 0x41, 0x3d, 0xf6, 0x92, 0x30,
+	0x3e, 0xf0, 0xe3, 0x5e, 0x8c, 0xa7, 0xcb, 0x26, 0xa3, 0x2f, 0x42, 0x91, 0x37, 0xe0, 0x7c, 0x06,
+	0x1c, 0xd1, 0x3e, 0xf5, 0x0f, 0xe9, 0xa0, 0x3b, 0xc7, 0xbe, 0x9a, 0x86, 0x26, 0x2b, 0xd0, 0x40,
+	0xff, 0xc4, 0x64, 0x3c, 0xf0, 0xd0, 0x82, 0x69, 0xb1, 0x79, 0xd0, 0x41, 0xe4, 0x55, 0x98, 0x1b,
+	0x53, 0x6e, 0xd3, 0x1f, 0x24, 0xc3, 0x7e, 0xdc, 0x6d, 0x1b, 0xfb, 0x11, 0x4a, 0xae, 0x6b, 0x52,
+	0xa0, 0x50, 0xf6, 0x63, 0x76, 0x26, 0xf4, 0x8e, 0xbb, 0x1d, 0x26, 0x6e, 0x29, 0x80, 0xad, 0x91,
+	0xc8, 0x3f, 0xf4, 0x12, 0xda, 0x9d, 0x67, 0xb2, 0x25, 0x8b, 0xce, 0xaf, 0x5a, 0x7c, 0x2b, 0x14,
+	0x42, 0xa8, 0xac, 0x83, 0xab, 0xd0, 0xe0, 0xe2, 0xd7, 0x0b, 0x83, 0xe1, 0xb1, 0x90, 0x48, 0xe0,
+	0xa0, 0xc7, 0xc1, 0xf0, 0x98, 0x7c, 0x02, 0xe6, 0xfc, 0x40, 0x27, 0xe1, 0x6b, 0xb8, 0xe9, 0x07,
+	0x1a, 0xd1, 0x55, 0x68, 0x8c, 0x27, 0xbb, 0x43, 0xbf, 0xcf, 0x49, 0xb8, 0x5b, 0x01, 0x38, 0x88,
+	0x11, 0xe0, 0x59, 0x8e, 0xf7, 0x84, 0x53, 0x54, 0x18, 0x45, 0x43, 0xc0, 0x90, 0xc4, 0xb9, 0x07,
+	0x8b, 0x66, 0x07, 0x85, 0xb2, 0xba, 0x09, 0x35, 0x21, 0xdb, 0x71, 0xb7, 0xc1, 0xf8, 0xd3, 0x12,
+	0xfc, 0x11, 0xa4, 0xae, 0xc2, 0x3b, 0x3f, 0xaa, 0xc0, 0x82, 0x80, 0xae, 0x0d, 0xc3, 0x98, 0xee,
+	0x4c, 0x46, 0x23, 0x2f, 0x2a, 0x58, 0x34, 0xd6, 0x29, 0x8b, 0xa6, 0x64, 0x2e, 0x1a, 0x14, 0xe5,
+	0x03, 0xcf, 0x0f, 0xf8, 0x41, 0x94, 0xaf, 0x38, 0x0d, 0x42, 0x6e, 0x40, 0xbb, 0x3f, 0x0c, 0x63,
+	0x7e, 0x38, 0xd3, 0x5d, 0x4f, 0x59, 0x70, 0x7e, 0x91, 0x57, 0x8b, 0x16, 0xb9, 0xbe, 0x48, 0x67,
+	0x32, 0x8b, 0xd4, 0x81, 0x26, 0x56, 0x4a, 0xa5, 0xce, 0x99, 0xe5, 0x76, 0xad, 0x0e, 0xc3, 0xfe,
+	0x64, 0x97, 0x04, 0x5f, 0x7f, 0xed, 0xa2, 0x05, 0x81, 0x9e, 0x2d, 0xd4, 0x69, 0x1a, 0x75, 0x5d,
+	0x2c, 0x88, 0x3c, 0x8a, 0xdc, 0x07, 0xe0, 0x6d, 0x31, 0xb3, 0x08, 0x98, 0x59, 0xf4, 0xb2, 0x39,
+	0x23, 0x3a, 0xef, 0x6f, 0x61, 0x61, 0x12, 0x51, 0x66, 0x18, 0x69, 0x5f, 0x3a, 0xff, 0xc3, 0x82,
+	0x86, 0x86, 0x23, 0x4b, 0x30, 0xbf, 0xf6, 0xf8, 0xf1, 0xf6, 0x86, 0xbb, 0xfa, 0xe4, 0xe1, 0x97,
+	0x37, 0x7a, 0x6b, 0x5b, 0x8f, 0x77, 0x36, 0x3a, 0xe7, 0x10, 0xbc, 0xf5, 0x78, 0x6d, 0x75, 0xab,
+	0x77, 0xff, 0xb1, 0xbb, 0x26, 0xc1, 0x16, 0xda, 0x9c, 0xee, 0xc6, 0xbb, 0x8f, 0x9f, 0x6c, 0x18,
+	0xf0, 0x12, 0xe9, 0x40, 0xf3, 0x9e, 0xbb, 0xb1, 0xba, 0xb6, 0x29, 0x20, 0x65, 0xb2, 0x08, 0x9d,
+	0xfb, 0x4f, 0x1f, 0xad, 0x3f, 0x7c, 0xf4, 0xa0, 0xb7, 0xb6, 0xfa, 0x68, 0x6d, 0x63, 0x6b, 0x63,
+	0xbd, 0x53, 0x21, 0x73, 0x50, 0x5f, 0xbd, 0xb7, 0xfa, 0x68, 0xfd, 0xf1, 0xa3, 0x8d, 0xf5, 0x4e,
+	0xd5, 0xf9, 0x0b, 0x0b, 0x96, 0x58, 0xaf, 0x07, 0xd9, 0x05, 0xb2, 0x02, 0x8d, 0x7e, 0x18, 0x8e,
+	0x69, 0xe4, 0x69, 0x2a, 0x5b, 0x07, 0xa1, 0xf0, 0x73, 0x05, 0xb9, 0x17, 0x46, 0x7d, 0x2a, 0xd6,
+	0x07, 0x30, 0xd0, 0x7d, 0x84, 0xa0, 0xf0, 0x8b, 0xe9, 0xe5, 0x14, 0x7c, 0x79, 0x34, 0x38, 0x8c,
+	0x93, 0x2c, 0xc3, 0xcc, 0x6e, 0x44, 0xbd, 0xfe, 0x81, 0x58, 0x19, 0xa2, 0x84, 0x6e, 0x5a, 0x79,
+	0xea, 0xef, 0x23, 0xf7, 0x87, 0x74, 0xc0, 0x24, 0xa6, 0xe6, 0xb6, 0x05, 0x7c, 0x4d, 0x80, 0x51,
+	0x33, 0x78, 0xbb, 0x5e, 0x30, 0x08, 0x03, 0x3a, 0x60, 0x42, 0x53, 0x73, 0x53, 0x80, 0xb3, 0x0d,
+	0xcb, 0xd9, 0xf1, 0x89, 0xf5, 0xf5, 0xba, 0xb6, 0xbe, 0xb8, 0x3d, 0x6c, 0x4f, 0x9f, 0x4d, 0x6d,
+	0xad, 0xd9, 0xd0, 0x15, 0x04, 0x1b, 0x87, 0x34, 0x48, 0x76, 0x26, 0xbb, 0xdc, 0x2e, 0x45, 0x63,
+	0xec, 0x77, 0x67, 0x80, 0xe8, 0xc8, 0xa7, 0x4c, 0xe1, 0x91, 0xb7, 0x61, 0x51, 0x6a, 0xb3, 0x70,
+	0x4c, 0x83, 0x9e, 0xa8, 0x4b, 0xd8, 0x10, 0x8b, 0xa2, 0xd9, 0x6d, 0x4e, 0xc2, 0xbf, 0xd9, 0x3c,
+	0xe7, 0x16, 0x7e, 0x43, 0x3e, 0x03, 0x4d, 0xa3, 0x0e, 0x6e, 0xcb, 0x65, 0x54, 0xc3, 0xe6, 0x39,
+	0xd7, 0xa0, 0x22, 0x9f, 0x83, 0x96, 0xd0, 0x65, 0xf2, 0x3b, 0xee, 0x9f, 0x5a, 0x30, 0xbf, 0x63,
+	0x76, 0xe0, 0xe6, 0x39, 0x37, 0x43, 0x4c, 0x56, 0xa1, 0xe3, 0x07, 0x26, 0xac, 0x5b, 0x39, 0xa9,
+	0x82, 0x1c, 0x39, 0x79, 0x90, 0xaa, 0x0a, 0x59, 0x03, 0x37, 0xde, 0x2f, 0xca, 0x1a, 0x38, 0x56,
+	0x54, 0xa4, 0xb8, 0x90, 0xfd, 0x8a, 0xac, 0x43, 0xab, 0xcf, 0x66, 0x54, 0xd5, 0x33, 0xb3, 0x62,
+	0x9d, 0x3c, 0x7b, 0x38, 0x22, 0xf3, 0x1b, 0xb2, 0x01, 0x2d, 0xb1, 0xb0, 0xc5, 0xae, 0xd4, 0x9d,
+	0x35, 0x7b, 0xc3, 0xe9, 0xee, 0x71, 0x1a, 0xd5, 0x9b, 0xcc, 0x47, 0x38, 0xaa, 0x78, 0x3c, 0xf4,
+	0xfb, 0x5a, 0x6f, 0x6a, 0x46, 0x3d, 0x3b, 0x1c, 0x9b, 0x1b, 0x55, 0xe6, 0x2b, 0x75, 0x5a, 0xad,
+	0x1b, 0xc7, 0xad, 0xbc, 0x2c, 0xdd, 0xe2, 0x7f, 0xb4, 0xd3, 0xea, 0xef, 0x59, 0x00, 0x29, 0x90,
+	0x74, 0x61, 0x71, 0x7b, 0x83, 0x2f, 0xfb, 0xc7, 0xdb, 0x1b, 0x8f, 0x7a, 0x6b, 0x9b, 0xab, 0x8f,
+	0x1e, 0x6d, 0x6c, 0x75, 0xce, 0xa1, 0x8a, 0x30, 0x20, 0x16, 0x21, 0xd0, 0x5a, 0x5d, 0xe3, 0x5a,
+	0x47, 0xc0, 0x4a, 0xa8, 0x36, 0x1e, 0x3e, 0xca, 0x40, 0xcb, 0x64, 0x01, 0xda, 0xa8, 0x57, 0x98,
+	0x32, 0x11, 0xc0, 0x0a, 0x7e, 0xce, 0x94, 0xcd, 0xba, 0x82, 0x55, 0x11, 0x76, 0x6f, 0x75, 0x0b,
+	0xf5, 0x4d, 0xef, 0xe9, 0xf6, 0xfa, 0xea, 0x93, 0x8d, 0xce, 0x0c, 0x7e, 0xbc, 0xb3, 0xbd, 0xf5,
+	0x70, 0x4d, 0x23, 0x9c, 0xbd, 0x57, 0xe7, 0x9b, 0x4e, 0x40, 0x87, 0xce, 0x77, 0x2c, 0x58, 0x2c,
+	0x9a, 0xfd, 0x33, 0x6e, 0x5f, 0xa6, 0x62, 0x2e, 0x7d, 0x68, 0xc5, 0xfc, 0x43, 0xec, 0x46, 0xc1,
+	0xb4, 0x9f, 0xb1, 0x1b, 0x39, 0x23, 0xb2, 0x74, 0x36, 0x23, 0xb2, 0x5c, 0x68, 0x44, 0xa6, 0x46,
+	0xa2, 0x66, 0x62, 0x57, 0x5c, 0x13, 0xe8, 0x04, 0xb0, 0x58, 0x24, 0x60, 0x68, 0x1c, 0x86, 0xc3,
+	0x41, 0xcf, 0xe8, 0xa0, 0xe8, 0x75, 0x1e, 0x41, 0x6e, 0xa8, 0xa9, 0x28, 0xd6, 0x26, 0xae, 0x9a,
+	0xa9, 0xbf, 0xb6, 0xa0, 0x82, 0x07, 0x8d, 0xe9, 0x87, 0x12, 0xfd, 0xd4, 0x5f, 0xce, 0x9d, 0xfa,
+	0x99, 0xff, 0x8a, 0x9b, 0x9e, 0x7c, 0x3c, 0x1a, 0x24, 0xc5, 0x47, 0xb4, 0x7f, 0xd8, 0xad, 0xea,
+	0x78, 0x84, 0x30, 0xcf, 0x9c, 0x97, 0xf0, 0xaf, 0x85, 0x71, 0x20, 0xcb, 0x12, 0xc7, 0xbe, 0x9c,
+	0x4d, 0x71, 0xec, 0xbb, 0x2e, 0xcc, 0xfa, 0xc1, 0x6e, 0x38, 0x09, 0xb8, 0x1f, 0xb4, 0xe6, 0xca,
+	0x22, 0xf3, 0x33, 0x30, 0x23, 0xc5, 0x1f, 0xc9, 0xad, 0x3f, 0x05, 0x38, 0x04, 0x3d, 0xcd, 0x31,
+	0x3b, 0x58, 0xa9, 0x50, 0xd4, 0xeb, 0x30, 0xaf, 0xc1, 0x52, 0xb7, 0xca, 0x18, 0x01, 0x19, 0xb7,
+	0x0a, 0x12, 0xb9, 0x1c, 0xe3, 0x74, 0x30, 0x7a, 0x9d, 0x3c, 0x0c, 0xf6, 0x42, 0x59, 0xd3, 0x9f,
+	0x95, 0xa1, 0xad, 0x40, 0xa2, 0xa2, 0x1b, 0xd0, 0xf6, 0x07, 0x34, 0x48, 0xfc, 0xe4, 0xb8, 0x67,
+	0x38, 0xb4, 0xb3, 0x60, 0x3c, 0xc9, 0x7a, 0x43, 0xdf, 0x93, 0x9e, 0x15, 0x5e, 0x20, 0x77, 0x61,
+	0x11, 0xcd, 0x6c, 0xb9, 0x6f, 0xa8, 0xed, 0x8d, 0xbb, 0x00, 0x0b, 0x71, 0x68, 0x08, 0x21, 0xdc,
+	0xd4, 0xd6, 0xb1, 0x38, 0xd1, 0x15, 0xa1, 0x90, 0x6b, 0xbc, 0x26, 0x1c, 0x72, 0x95, 0x9b, 0xe2,
+	0x0a, 0x90, 0x0b, 0x34, 0xce, 0x70, 0x33, 0x2d, 0x1b, 0x68, 0xd4, 0x82, 0x95, 0xb5, 0x5c, 0xb0,
+	0x12, 0xcd, 0xb8, 0xe3, 0x00, 0xd5, 0x63, 0x12, 0xf6, 0x98, 0xb9, 0xc9, 0x66, 0xa7, 0xe6, 0x66,
+	0xc1, 0x38, 0xb7, 0x09, 0x8d, 0x93, 0x80, 0x26, 0xcc, 0x22, 0xab, 0xb9, 0xb2, 0x88, 0x96, 0x05,
+	0x23, 0xe1, 0xc6, 0x73, 0xdd, 0x15, 0x25, 0x3c, 0x92, 0x4f, 0x22, 0x3f, 0xee, 0x36, 0x19, 0x94,
+	0xfd, 0x26, 0x9f, 0x81, 0xa5, 0x5d, 0x1a, 0xe3, 0xaa, 0xf2, 0x06, 0x34, 0x62, 0xb3, 0xcf, 0x63,
+	0xa0, 0xfc, 0xa4, 0x53, 0x8c, 0xc4, 0xb6, 0x0f, 0x69, 0x14, 0xa3, 0x5b, 0xa6, 0xc5, 0x25, 0x5d,
+	0x14, 0x45, 0x28, 0xd3, 0x15, 0xa1, 0x6a, 0x7d, 0xd6, 0x7f, 0xdb, 0x82, 0xce, 0x3b, 0xf4, 0x78,
+	0xa7, 0x1f, 0x8e, 0xa9, 0xc4, 0xf3, 0xc5, 0x14, 0x8d, 0x43, 0x91, 0xbe, 0x30, 0xe7, 0xca, 0x22,
+	0x3b, 0xf5, 0x84, 0x7e, 0x90, 0x6a, 0xb6, 0x39, 0x37, 0x05, 0x70, 0x0f, 0x53, 0x42, 0xa3, 0xc0,
+	0x1b, 0x6a, 0x31, 0x57, 0x3e, 0xd9, 0x05, 0x18, 0xa4, 0xf7, 0x83, 0x1c, 0x3d, 0x9f, 0xe9, 0x02,
+	0x8c, 0xf3, 0x87, 0x25, 0x38, 0x9f, 0x1b, 0x47, 0x1a, 0x41, 0x53, 0x11, 0xfa, 0x51, 0x38, 0x90,
+	0x06, 0xa2, 0x09, 0x44, 0x3d, 0xa4, 0x00, 0x7b, 0x7e, 0xe0, 0xc7, 0x07, 0xca, 0x7d, 0x9b, 0x47,
+	0xa0, 0x6e, 0x8c, 0xfb, 0x28, 0x64, 0x03, 0x29, 0x3c, 0x7c, 0x2c, 0x19, 0x28, 0x9a, 0xa6, 0x62,
+	0x46, 0x34, 0xe7, 0x83, 0x0e, 0x42, 0x75, 0x30, 0x8e, 0xc2, 0x7d, 0xa6, 0x85, 0x50, 0x42, 0x2d,
+	0x57, 0x95, 0xc9, 0xbf, 0x02, 0x78, 0x4e, 0x8f, 0x7b, 0x31, 0x4e, 0x81, 0x0c, 0x08, 0x48, 0x37,
+	0x4e, 0x76, 0x6a, 0x5c, 0x8d, 0x14, 0x57, 0x17, 0x8d, 0x13, 0x7f, 0xe4, 0x25, 0xb8, 0x6f, 0x87,
+	0xa3, 0xf1, 0x90, 0x32, 0x9f, 0x1c, 0xd7, 0x37, 0x85, 0x38, 0xe7, 0xdb, 0xcc, 0x87, 0xa4, 0x3c,
+	0x8d, 0x42, 0x3d, 0x5f, 0x84, 0x3a, 0x97, 0xf6, 0xf8, 0xc0, 0x13, 0x6e, 0xad, 0x1a, 0x03, 0xec,
+	0x1c, 0x78, 0x68, 0x35, 0x1b, 0x0b, 0x88, 0x3b, 0x77, 0x1b, 0x0c, 0xb6, 0xc9, 0x87, 0x77, 0x0d,
+	0x5a, 0x32, 0x03, 0x20, 0xee, 0x0d, 0xe9, 0x9e, 0x64, 0x54, 0x33, 0x98, 0x8c, 0xb0, 0xb9, 0x78,
+	0x8b, 0xee, 0x25, 0xce, 0x23, 0x98, 0x17, 0x0a, 0xfc, 0xf1, 0x98, 0xca, 0xa6, 0x3f, 0x5b, 0xb4,
+	0x97, 0x15, 0x9b, 0x71, 0x99, 0x0d, 0xce, 0x71, 0x95, 0x6d, 0xcb, 0xb6, 0x53, 0x51, 0xa1, 0x38,
+	0x96, 0xc9, 0xf8, 0x9c, 0x18, 0x8e, 0x01, 0x43, 0x01, 0x8f, 0x27, 0xfd, 0xbe, 0xf4, 0x04, 0xd7,
+	0x5c, 0x59, 0xc4, 0x00, 0xd1, 0x02, 0xab, 0x4d, 0x6e, 0x35, 0xca, 0x79, 0x7f, 0xf6, 0x6e, 0x36,
+	0xfb, 0x5a, 0x09, 0x35, 0xa3, 0x7e, 0x1e, 0xe1, 0x85, 0x0f, 0x1e, 0x47, 0xaa, 0xe4, 0xe2, 0x48,
+	0x9f, 0x84, 0xce, 0x80, 0x0e, 0x7d, 0x26, 0xb2, 0x72, 0x87, 0xe3, 0x87, 0xd8, 0xb6, 0x84, 0xcb,
+	0x78, 0xe4, 0x75, 0xe8, 0xa0, 0x2f, 0xde, 0xa8, 0x50, 0xb8, 0x94, 0x46, 0xde, 0x8b, 0x9d, 0x34,
+	0xf8, 0xf1, 0x63, 0x8c, 0x8f, 0xb1, 0x0d, 0xfc, 0xf1, 0x24, 0xf9, 0xe8, 0x63, 0x9f, 0xe6, 0xd1,
+	0x93, 0x11, 0xb7, 0xb2, 0x16, 0x71, 0xcb, 0x70, 0xa4, 0xf2, 0xc1, 0x23, 0x6b, 0xce, 0x6b, 0x30,
+	0xaf, 0x75, 0x5e, 0x28, 0x86, 0x15, 0x68, 0x70, 0xdb, 0xb6, 0xa7, 0xc5, 0x70, 0x74, 0x10, 0x0e,
+	0xfa, 0x02, 0x86, 0x7c, 0xc4, 0x79, 0xe7, 0x63, 0x9b, 0xf9, 0x8f, 0x1e, 0x8c, 0xc2, 0xb5, 0x37,
+	0x08, 0x27, 0xbb, 0x43, 0xda, 0x8b, 0x71, 0xa3, 0x94, 0xee, 0x1a, 0x0e, 0xdb, 0x41, 0x90, 0x73,
+	0x07, 0xec, 0xa2, 0xce, 0x9f, 0x10, 0xdc, 0xfb, 0x5e, 0x09, 0xe6, 0xb9, 0x01, 0x9a, 0x78, 0xc9,
+	0x24, 0x16, 0xeb, 0xe6, 0x5f, 0xc3, 0x1c, 0xb7, 0x3d, 0xc5, 0x8e, 0x7c, 0xca, 0x61, 0xd0, 0x24,
+	0x26, 0x5f, 0x80, 0xa6, 0x1e, 0xc1, 0x10, 0x76, 0xdb, 0x05, 0xc9, 0xa4, 0x9c, 0xca, 0xc1, 0x03,
+	0xa1, 0xfe, 0x01, 0x79, 0x8b, 0x79, 0x76, 0x82, 0x1e, 0xab, 0xb6, 0x5b, 0x36, 0x3f, 0xcf, 0xad,
+	0x72, 0x0c, 0x40, 0xa6, 0xe4, 0xe4, 0x75, 0x9e, 0x7d, 0x11, 0xee, 0xed, 0xd1, 0x48, 0x9c, 0x03,
+	0x97, 0xcd, 0x53, 0xdc, 0x7d, 0x4a, 0x1f, 0x23, 0x76, 0xf3, 0x9c, 0x9b, 0x92, 0xde, 0xab, 0xc1,
+	0x0c, 0x3f, 0x37, 0x39, 0x3f, 0xb0, 0xa0, 0x9d, 0x21, 0xd5, 0x4c, 0x63, 0xfc, 0x02, 0x23, 0x43,
+	0x96, 0x61, 0x1a, 0x0b, 0x68, 0x6a, 0x68, 0x4b, 0x32, 0xc3, 0xd0, 0x96, 0x54, 0x2b, 0xd0, 0xc0,
+	0x35, 0x28, 0x69, 0xf8, 0x5c, 0xeb, 0x20, 0xac, 0xc7, 0xdb, 0x0d, 0x0f, 0x69, 0x4f, 0x00, 0xc5,
+	0x6c, 0x9b, 0x40, 0xe7, 0x01, 0xcc, 0x19, 0x73, 0x51, 0x98, 0x72, 0x94, 0x8d, 0xb9, 0x96, 0xf2,
+	0x31, 0x57, 0xe7, 0xfb, 0x55, 0x20, 0xa8, 0x88, 0x33, 0xf2, 0x8e, 0xde, 0xd2, 0x70, 0x60, 0xf8,
+	0xbe, 0x9b, 0xae, 0x0e, 0xc2, 0x6d, 0x5b, 0x2b, 0xca, 0x5c, 0x0f, 0xbe, 0x96, 0x0b, 0x30, 0xb8,
+	0x4f, 0x09, 0x56, 0x08, 0x0f, 0x8a, 0xd0, 0x09, 0x5c, 0xa5, 0x15, 0xe2, 0xd8, 0x86, 0x39, 0xc1,
+	0x44, 0x12, 0x2f, 0x91, 0xde, 0x71, 0x59, 0xce, 0xae, 0xab, 0x99, 0x53, 0xd7, 0xd5, 0x6c, 0x6e,
+	0x5d, 0x69, 0xfe, 0xd9, 0x9a, 0xe1, 0x9f, 0xc5, 0x49, 0xc0, 0xa0, 0x26, 0x3a, 0x79, 0x7b, 0x23,
+	0x6c, 0x5d, 0x38, 0xc3, 0x0d, 0x20, 0x66, 0xe2, 0x08, 0x21, 0x48, 0x9d, 0xc0, 0xc0, 0x78, 0x9c,
+	0x83, 0x9b, 0x71, 0xd3, 0x46, 0x36, 0x6e, 0x7a, 0x4d, 0x2e, 0x3b, 0xa9, 0xc2, 0x9b, 0xe2, 0x2c,
+	0xa7, 0x03, 0xd1, 0xf9, 0x2d, 0xeb, 0x45, 0xa9, 0x8f, 0x68, 0x4c, 0xa3, 0x43, 0x2e, 0x48, 0xc2,
+	0xf9, 0x3d, 0x05, 0x4d, 0x36, 0xe1, 0xaa, 0x40, 0xa1, 0x00, 0xb1, 0x8c, 0x89, 0x9e, 0x1f, 0xf4,
+	0xf6, 0x86, 0xb8, 0x71, 0xf3, 0x11, 0x72, 0x87, 0xf8, 0x69, 0x64, 0xda, 0x98, 0x91, 0x44, 0xfa,
+	0xc9, 0xf5, 0x31, 0x2b, 0xb8, 0x99, 0xe3, 0xd0, 0x39, 0x35, 0xc7, 0x41, 0xcb, 0x58, 0x98, 0x37,
+	0x33, 0x16, 0x7e, 0x66, 0x41, 0x07, 0x85, 0xd4, 0x50, 0x55, 0x6f, 0x02, 0x53, 0xb4, 0x67, 0xd4,
+	0x54, 0x06, 0xed, 0x47, 0x57, 0x54, 0x6f, 0x40, 0x9d, 0x55, 0x18, 0x8e, 0x69, 0x20, 0xf4, 0x54,
+	0xd7, 0xd4, 0x53, 0xa9, 0x75, 0x83, 0xda, 0x46, 0x11, 0x6b, 0xda, 0xe6, 0xa7, 0x16, 0x34, 0x44,
+	0x37, 0x3f, 0x74, 0x54, 0xcc, 0xd6, 0xe2, 0xa7, 0x7c, 0xed, 0xa9, 0x32, 0x9e, 0x57, 0x46, 0x18,
+	0x7a, 0xc4, 0x03, 0x9a, 0x61, 0x94, 0x66, 0xc1, 0x78, 0xda, 0x62, 0x86, 0x5c, 0xdc, 0x4b, 0xfc,
+	0x61, 0x4f, 0x62, 0x45, 0x36, 0x66, 0x11, 0x0a, 0xed, 0x99, 0x38, 0xc1, 0xcc, 0x33, 0x7e, 0x90,
+	0xe2, 0x05, 0x3c, 0x61, 0x98, 0x3b, 0x90, 0x3a, 0xa1, 0xfe, 0x74, 0x0e, 0xce, 0xe7, 0x50, 0x2a,
+	0x9d, 0x59, 0x84, 0x7a, 0x86, 0xfe, 0x68, 0x37, 0x54, 0x1e, 0x08, 0x4b, 0x8f, 0x02, 0x19, 0x28,
+	0xb2, 0x0f, 0x4b, 0x45, 0x5e, 0xc8, 0x98, 0xe5, 0x19, 0x37, 0xee, 0xbe, 0x6a, 0xca, 0x40, 0xb6,
+	0x41, 0x09, 0xd7, 0xd5, 0x5e, 0x71, 0x7d, 0xe4, 0x00, 0xba, 0x12, 0x91, 0x71, 0xf8, 0xc9, 0x9c,
+	0xb5, 0x57, 0x4e, 0x69, 0xcb, 0x70, 0xf3, 0xba, 0x53, 0x6b, 0x23, 0xc7, 0x70, 0x45, 0xe2, 0x98,
+	0x6d, 0x98, 0x6f, 0xaf, 0x72, 0xa6, 0xb1, 0x31, 0x07, 0xb6, 0xd9, 0xe8, 0x29, 0x15, 0x93, 0x6f,
+	0xc2, 0xf2, 0x91, 0xe7, 0x27, 0xb2, 0x5b, 0xda, 0x71, 0xbb, 0xca, 0x9a, 0xbc, 0x7b, 0x4a, 0x93,
+	0xcf, 0xf8, 0xc7, 0x86, 0xc1, 0x3c, 0xa5, 0x46, 0xfb, 0x4f, 0x2c, 0x68, 0x99, 0xf5, 0xa0, 0x98,
+	0x0a, 0xcd, 0x21, 0x77, 0x0d, 0xe9, 0x5e, 0xc8, 0x80, 0xf3, 0x1e, 0xaf, 0x52, 0x91, 0xc7, 0x4b,
+	0x8f, 0xd6, 0x94, 0x4f, 0x0b, 0xa9, 0x56, 0xce, 0xe6, 0x0d, 0xab, 0x16, 0x79, 0xc3, 0xec, 0xff,
+	0x5e, 0x06, 0x92, 0x97, 0x25, 0xf2, 0x20, 0x75, 0x5c, 0x71, 0x9d, 0xf4, 0xa9, 0xb3, 0xc9, 0x63,
+	0xd6, 0xaf, 0x85, 0x0b, 0x43, 0x57, 0x3a, 0xfa, 0xd1, 0x6b, 0xce, 0x2d, 0x42, 0x65, 0x82, 0xbc,
+	0x95, 0xd3, 0x83, 0xbc, 0xd5, 0xd3, 0x83, 0xbc, 0x33, 0xb9, 0x20, 0xef, 0x9b, 0xd0, 0x95, 0x1b,
+	0xf5, 0x6e, 0x14, 0x7a, 0x83, 0xbe, 0x97, 0x1e, 0x7b, 0x79, 0xfc, 0x6b, 0x2a, 0x9e, 0xbc, 0x0e,
+	0xcb, 0x42, 0x9f, 0xc4, 0x7e, 0xd0, 0xa7, 0x29, 0x01, 0xdb, 0x82, 0xe7, 0xdc, 0x29, 0x58, 0xdc,
+	0x3f, 0xfd, 0xc0, 0x4f, 0x7c, 0x2f, 0x09, 0x23, 0xe1, 0x76, 0x49, 0x01, 0xf6, 0x77, 0x2c, 0x58,
+	0x28, 0x10, 0xc3, 0x8f, 0x6f, 0x2a, 0x50, 0x70, 0x0c, 0xed, 0x24, 0xad, 0x3b, 0x1d, 0x68, 0xff,
+	0x7b, 0x98, 0x33, 0x96, 0xde, 0xc7, 0xd7, 0x7e, 0xf6, 0x3c, 0xcb, 0x25, 0xdf, 0x80, 0xd9, 0x7f,
+	0x53, 0x02, 0x92, 0x5f, 0xfe, 0xbf, 0xd4, 0x3e, 0xe4, 0xf9, 0x54, 0x2e, 0xe0, 0xd3, 0x2f, 0x74,
+	0x67, 0x4a, 0x9d, 0x3b, 0x5a, 0x00, 0x95, 0xcb, 0x70, 0x1e, 0x81, 0xe7, 0x3a, 0x33, 0xe6, 0x5f,
+	0x33, 0x12, 0xe6, 0xb5, 0xed, 0x39, 0x13, 0xfa, 0xc7, 0x3b, 0x1e, 0xfc, 0x76, 0x87, 0xf0, 0xca,
+	0xcb, 0x9d, 0xee, 0x57, 0x2c, 0x58, 0xca, 0x20, 0x52, 0xe7, 0x14, 0xdf, 0xcc, 0xcc, 0x1d, 0xce,
+	0x04, 0x62, 0xff, 0xc5, 0xca, 0xd6, 0xfa, 0xcf, 0xa5, 0x2d, 0x8f, 0x40, 0xfe, 0x4c, 0x82, 0x3c,
+	0x3d, 0xe7, 0x7a, 0x11, 0x0a, 0x93, 0xa8, 0xcc, 0x70, 0x82, 0xec, 0xf8, 0x1e, 0x2c, 0x67, 0x11,
+	0x69, 0x8e, 0xa0, 0xd9, 0x65, 0x59, 0x44, 0xa3, 0xde, 0xd8, 0x38, 0xcd, 0xfe, 0x16, 0xe2, 0x9c,
+	0x1f, 0x59, 0x40, 0xbe, 0x34, 0x41, 0x37, 0x16, 0xcb, 0xef, 0x16, 0xcd, 0x93, 0xf3, 0x59, 0xdf,
+	0x3d, 0x26, 0x3c, 0xbd, 0x43, 0x8f, 0xe5, 0x65, 0x80, 0x52, 0x7a, 0x19, 0xe0, 0x32, 0x00, 0x3a,
+	0x9a, 0x54, 0xee, 0x38, 0x33, 0xa6, 0x83, 0xc9, 0x88, 0x57, 0x58, 0x98, 0xaf, 0x5f, 0x39, 0x3d,
+	0x5f, 0xbf, 0x7a, 0x5a, 0xbe, 0xfe, 0x5b, 0xb0, 0x60, 0xf4, 0x5b, 0x4d, 0xab, 0xcc, 0x62, 0xb7,
+	0x4e, 0xc8, 0x62, 0xff, 0x5b, 0x0b, 0xca, 0x9b, 0xe1, 0x58, 0xcf, 0x6a, 0xb0, 0xcc, 0xac, 0x06,
+	0xb1, 0xbb, 0xf5, 0xd4, 0xe6, 0x25, 0x54, 0x8c, 0x01, 0x24, 0x37, 0xa1, 0xe5, 0x8d, 0x12, 0x74,
+	0x35, 0xef, 0x85, 0xd1, 0x91, 0x17, 0x0d, 0xf8, 0x5c, 0xdf, 0x2b, 0x75, 0x2d, 0x37, 0x83, 0x21,
+	0x8b, 0x50, 0x56, 0xdb, 0x00, 0x23, 0xc0, 0x22, 0x9a, 0x92, 0x2c, 0x23, 0xea, 0x58, 0x78, 0xc9,
+	0x45, 0x09, 0x45, 0xc9, 0xfc, 0x9e, 0x9f, 0x0b, 0xf8, 0xd2, 0x29, 0x42, 0xe1, 0x4e, 0x8b, 0xec,
+	0x63, 0x64, 0x22, 0xbc, 0x21, 0xcb, 0xce, 0x5f, 0x59, 0x50, 0x65, 0x1c, 0xc0, 0xc5, 0xce, 0x25,
+	0x5c, 0xa5, 0x2f, 0x08, 0x7f, 0x72, 0x16, 0x4c, 0x1c, 0xe3, 0x26, 0x4f, 0x49, 0x75, 0x5b, 0x83,
+	0x92, 0x15, 0xa8, 0xf3, 0x92, 0xba, 0x20, 0xc2, 0x48, 0x52, 0x20, 0xb9, 0x82, 0xe9, 0xf5, 0x63,
+	0x69, 0x2f, 0x81, 0xcc, 0xde, 0x09, 0xc7, 0x2e, 0x83, 0xa7, 0xfd, 0xc1, 0xfa, 0x78, 0xe7, 0xf9,
+	0x2e, 0x98, 0x05, 0xa3, 0x1d, 0xa0, 0xaa, 0xd5, 0x99, 0x91, 0x81, 0x3a, 0x37, 0xa1, 0xfd, 0x28,
+	0x1c, 0x50, 0xcd, 0xa3, 0x3e, 0x55, 0x9a, 0x31, 0xb7, 0xb9, 0x26, 0x89, 0xc9, 0x0d, 0xa8, 0x04,
+	0xd2, 0x4b, 0x9d, 0x1e, 0x5d, 0x54, 0xd6, 0x1e, 0xd2, 0xb9, 0x8c, 0x02, 0x75, 0x2f, 0xf3, 0xad,
+	0xa6, 0x86, 0xae, 0xf4, 0xac, 0x2a, 0x58, 0xda, 0xdd, 0x8c, 0xf9, 0x93, 0x81, 0x3a, 0xbf, 0x65,
+	0xc1, 0x9c, 0xd1, 0x06, 0x9e, 0xf6, 0x87, 0xb8, 0x47, 0x8b, 0x98, 0x33, 0x9f, 0x1e, 0x1d, 0xa4,
+	0x47, 0xd6, 0x4a, 0x66, 0x64, 0x4d, 0xc5, 0x7c, 0xca, 0x7a, 0xcc, 0xe7, 0x0e, 0xd4, 0x75, 0x5f,
+	0xbe, 0xae, 0x53, 0xb1, 0x45, 0x99, 0x8f, 0x58, 0x37, 0xae, 0x5f, 0xf5, 0xc3, 0x61, 0x18, 0x09,
+	0xef, 0x25, 0x2f, 0x38, 0x6f, 0x41, 0x43, 0xa3, 0xc7, 0x6e, 0x04, 0x34, 0x39, 0x0a, 0xa3, 0xe7,
+	0x32, 0xc0, 0x27, 0x8a, 0xca, 0x99, 0x58, 0x4a, 0x9d, 0x89, 0xce, 0x1f, 0x5b, 0x30, 0x87, 0x32,
+	0xe8, 0x07, 0xfb, 0xdb, 0xe1, 0xd0, 0xef, 0x1f, 0xb3, 0xb9, 0x97, 0xe2, 0x26, 0x34, 0x83, 0x94,
+	0x45, 0x13, 0x8c, 0xb2, 0x2d, 0x0f, 0xfb, 0x62, 0x21, 0xaa, 0x32, 0xae, 0x54, 0x94, 0xf3, 0x5d,
+	0x2f, 0x16, 0xc2, 0x2f, 0x36, 0x39, 0x03, 0x88, 0xeb, 0x09, 0x01, 0x91, 0x87, 0x67, 0x62, 0x7f,
+	0x38, 0xf4, 0x39, 0x2d, 0x37, 0xca, 0x8a, 0x50, 0xd8, 0xe6, 0xc0, 0x8f, 0xbd, 0xdd, 0x34, 0xad,
+	0x44, 0x95, 0x9d, 0x1f, 0x97, 0xa0, 0x21, 0xe3, 0xee, 0x83, 0x7d, 0x2a, 0x72, 0xa0, 0xb0, 0x98,
+	0xaa, 0x12, 0x0d, 0x22, 0xf1, 0x86, 0xa1, 0xac, 0x41, 0xb2, 0x53, 0x5e, 0xce, 0x4f, 0x39, 0x06,
+	0xd4, 0xc2, 0x01, 0x7d, 0x95, 0x59, 0xe4, 0x22, 0xdd, 0x59, 0x01, 0x24, 0xf6, 0x2e, 0xc3, 0x56,
+	0x53, 0x2c, 0x03, 0x9c, 0x98, 0x31, 0xf5, 0x06, 0x34, 0x45, 0x35, 0x6c, 0x4e, 0xba, 0xb3, 0x86,
+	0xf0, 0x1b, 0xf3, 0xe5, 0x1a, 0x94, 0xf2, 0xcb, 0xbb, 0xf2, 0xcb, 0xda, 0x69, 0x5f, 0x4a, 0x4a,
+	0x96, 0xdd, 0xca, 0x79, 0xf3, 0x20, 0xf2, 0xc6, 0x07, 0x72, 0xcb, 0x1b, 0x40, 0x53, 0x07, 0x93,
+	0x9b, 0x50, 0xc5, 0xcf, 0xa4, 0x26, 0x2f, 0x5e, 0x90, 0x9c, 0x84, 0xdc, 0x80, 0x2a, 0x1d, 0xec,
+	0x53, 0x79, 0xe6, 0x24, 0x99, 0xdc, 0x88, 0xc1, 0x3e, 0x75, 0x39, 0x01, 0xaa, 0x07, 0x84, 0x66,
+	0xd4, 0x83, 0xb9, 0x0b, 0x60, 0x1c, 0x30, 0x78, 0x38, 0xc0, 0x8b, 0xab, 0x8f, 0xb8, 0x44, 0x6b,
+	0xe4, 0xce, 0x7f, 0x2d, 0x43, 0x43, 0x03, 0xe3, 0x4a, 0xdf, 0xc7, 0x0e, 0xf7, 0x06, 0xbe, 0x37,
+	0xa2, 0x09, 0x8d, 0x84, 0x14, 0x67, 0xa0, 0x48, 0xe7, 0x1d, 0xee, 0xf7, 0xc2, 0x49, 0xd2, 0x1b,
+	0xd0, 0xfd, 0x88, 0xf2, 0x8d, 0xd9, 0x72, 0x33, 0x50, 0xa4, 0x43, 0xaf, 0x8d, 0x46, 0x27, 0x42,
+	0x5c, 0x26, 0x54, 0xc6, 0x58, 0x39, 0x8f, 0x2a, 0x69, 0x8c, 0x95, 0x73, 0x24, 0xab, 0xa3, 0xaa,
+	0x05, 0x3a, 0xea, 0x75, 0x58, 0xe6, 0xda, 0x48, 0xac, 0xdb, 0x5e, 0x46, 0x4c, 0xa6, 0x60, 0xd1,
+	0xed, 0x84, 0x7d, 0x96, 0x02, 0x1e, 0xfb, 0xdf, 0xe6, 0x0e, 0x3d, 0xcb, 0xcd, 0xc1, 0x91, 0x96,
+	0x79, 0xd6, 0x74, 0x5a, 0x9e, 0x6f, 0x97, 0x83, 0x33, 0x5a, 0xef, 0x85, 0x01, 0x13, 0xbe, 0xbe,
+	0x1c, 0xdc, 0x99, 0x83, 0xc6, 0x4e, 0x12, 0x8e, 0xe5, 0xa4, 0xb4, 0xa0, 0xc9, 0x8b, 0x22, 0xbb,
+	0xf9, 0x22, 0x5c, 0x60, 0x52, 0xf4, 0x24, 0x1c, 0x87, 0xc3, 0x70, 0xff, 0xd8, 0x48, 0xc1, 0xfa,
+	0x53, 0x0b, 0x16, 0x0c, 0xac, 0x70, 0x62, 0x7d, 0x86, 0x8b, 0xb4, 0x4a, 0x4b, 0xe5, 0x82, 0x37,
+	0xaf, 0xa9, 0x4a, 0x4e, 0xc8, 0x7d, 0xaf, 0xfc, 0x77, 0x4c, 0x56, 0xa1, 0x2d, 0x7b, 0x26, 0x3f,
+	0xe4, 0x52, 0xd8, 0xcd, 0x4b, 0xa1, 0xf8, 0xbe, 0xd5, 0xd7, 0x53, 0x31, 0x62, 0xf2, 0x39, 0x91,
+	0xb7, 0xc8, 0xb3, 0x2e, 0xa4, 0x37, 0xc3, 0xd6, 0xfc, 0xe5, 0x99, 0xec, 0x0d, 0xb7, 0xd1, 0x57,
+	0xc0, 0xd8, 0xf9, 0x9f, 0x16, 0x40, 0xda, 0x3b, 0x14, 0x8c, 0x54, 0xdd, 0xf3, 0x6b, 0xe8, 0x29,
+	0x00, 0xe3, 0x17, 0x2a, 0x53, 0x20, 0xdd, 0x41, 0x1a, 0x12, 0x86, 0x46, 0xde, 0x75, 0x68, 0xef,
+	0x0f, 0xc3, 0x5d, 0xb6, 0xfd, 0xb2, 0x74, 0xf9, 0x58, 0xe4, 0x78, 0xb7, 0x38, 0xf8, 0xbe, 0x80,
+	0xa6, 0xdb, 0x4d, 0x45, 0xdb, 0x6e, 0x9c, 0xef, 0x96, 0x60, 0x3e, 0x37, 0xe6, 0xa9, 0xab, 0x8c,
+	0xdc, 0xcd, 0x29, 0xc7, 0x29, 0xa1, 0x1c, 0xe6, 0xb7, 0xdb, 0x3e, 0xd5, 0xad, 0xf0, 0x16, 0xb4,
+	0x22, 0xae, 0x7d, 0xa4, 0x6a, 0xaa, 0x9c, 0xa0, 0x9a, 0xe6, 0x22, 0xbd, 0x88, 0x11, 0x3c, 0x6f,
+	0x70, 0x48, 0xa3, 0xc4, 0x67, 0xc7, 0x28, 0x66, 0x10, 0x88, 0x08, 0x9e, 0x06, 0x67, 0xfb, 0xf4,
+	0x75, 0x68, 0x8b, 0xbc, 0x7a, 0x45, 0x29, 0xae, 0xac, 0xa6, 0x60, 0x24, 0x74, 0x7e, 0x5d, 0x06,
+	0x30, 0xcd, 0x39, 0x9c, 0xce, 0x11, 0x7d, 0x74, 0xa5, 0xcc, 0xe8, 0x3e, 0x21, 0x9c, 0xd3, 0x99,
+	0xf8, 0xb7, 0x90, 0x1f, 0x11, 0xfc, 0x35, 0x59, 0x5a, 0x39, 0x0b, 0x4b, 0xd1, 0xad, 0x3b, 0xbb,
+	0x19, 0x8e, 0x37, 0x45, 0xb6, 0x2f, 0x5b, 0x08, 0x2a, 0x4a, 0x25, 0x8b, 0x27, 0xe4, 0x01, 0x17,
+	0xee, 0xc3, 0x73, 0xd9, 0x7d, 0xf8, 0x8b, 0x70, 0x11, 0x01, 0xe3, 0x28, 0x1c, 0x87, 0x11, 0x2e,
+	0x46, 0x6f, 0xc8, 0x37, 0xdd, 0x30, 0x48, 0x0e, 0xa4, 0x1a, 0x3b, 0x89, 0x84, 0x1d, 0xc9, 0xf0,
+	0x28, 0xc1, 0x0d, 0x65, 0x61, 0x37, 0x70, 0xed, 0x96, 0x47, 0x38, 0x9f, 0x85, 0x3a, 0x33, 0x7c,
+	0xd9, 0xb0, 0x5e, 0x81, 0xfa, 0x41, 0x38, 0xee, 0x1d, 0x30, 0x17, 0xb8, 0x65, 0xe4, 0x4b, 0x8b,
+	0x91, 0xbb, 0x29, 0x81, 0xf3, 0x7f, 0xab, 0x30, 0xfb, 0x30, 0x38, 0x0c, 0xfd, 0x3e, 0x0b, 0xe8,
+	0x8c, 0xe8, 0x28, 0x94, 0x31, 0x3b, 0xfc, 0x8d, 0xac, 0x60, 0xf9, 0xec, 0xe3, 0x44, 0x44, 0x64,
+	0x64, 0x11, 0xb7, 0xfb, 0x28, 0xbd, 0x0e, 0xcc, 0x97, 0x8e, 0x06, 0x41, 0xa3, 0x3f, 0xd2, 0x6f,
+	0x4e, 0x8b, 0x52, 0x7a, 0x99, 0xb2, 0xaa, 0x5d, 0xa6, 0xc4, 0x76, 0x44, 0x66, 0xb2, 0x48, 0x5d,
+	0x95, 0x45, 0x76, 0x48, 0x89, 0x28, 0xf7, 0x39, 0xa9, 0xfc, 0xc4, 0xb2, 0x6b, 0x02, 0x59, 0xb4,
+	0x95, 0x7d, 0xc0, 0x69, 0xb8, 0xf2, 0xd5, 0x41, 0x68, 0x88, 0x65, 0x2f, 0x5f, 0xf3, 0x6b, 0xf7,
+	0x59, 0x30, 0x6a, 0xe8, 0x01, 0x55, 0x8a, 0x94, 0x8f, 0x01, 0xf8, 0x75, 0xe7, 0x2c, 0x5c, 0x3b,
+	0xda, 0xf0, 0x2b, 0x07, 0xa2, 0xc4, 0x04, 0xc5, 0x1b, 0x0e, 0x77, 0xbd, 0xfe, 0x73, 0x16, 0x4c,
+	0x91, 0xe1, 0x15, 0x03, 0x88, 0xbd, 0xd6, 0x66, 0x93, 0x85, 0x54, 0x2a, 0xae, 0x0e, 0x22, 0x77,
+	0xa1, 0xc1, 0x8e, 0x73, 0x62, 0x3e, 0x5b, 0x6c, 0x3e, 0x3b, 0xfa, 0x79, 0x8f, 0xcd, 0xa8, 0x4e,
+	0xa4, 0x07, 0x99, 0xda, 0x66, 0x90, 0x89, 0x2b, 0x4d, 0x11, 0x9b, 0xeb, 0xb0, 0xd6, 0x52, 0x00,
+	0xee, 0xa6, 0x82, 0x61, 0x9c, 0x60, 0x9e, 0x11, 0x18, 0x30, 0x72, 0x05, 0x6a, 0x78, 0x08, 0x19,
+	0x7b, 0xfe, 0xa0, 0x4b, 0xd4, 0x59, 0x48, 0xc1, 0xb0, 0x0e, 0xf9, 0x9b, 0x45, 0x89, 0x16, 0x18,
+	0x57, 0x0c, 0x18, 0xf2, 0x46, 0x95, 0xd9, 0x22, 0x5a, 0xe4, 0x33, 0x6a, 0x00, 0x9d, 0x04, 0xc8,
+	0xea, 0x60, 0x20, 0x64, 0x53, 0x1d, 0x7d, 0x53, 0xa9, 0xb2, 0x0c, 0xa9, 0x2a, 0x98, 0xdd, 0x52,
+	0xf1, 0xec, 0x9e, 0xc8, 0x03, 0x67, 0x03, 0x1a, 0xdb, 0xda, 0xfd, 0x72, 0x26, 0xe4, 0xf2, 0x66,
+	0xb9, 0x58, 0x18, 0x1a, 0x44, 0xeb, 0x4e, 0x49, 0xef, 0x8e, 0xf3, 0x1b, 0x16, 0xbf, 0xca, 0xa8,
+	0xba, 0xcf, 0xdb, 0x76, 0xa0, 0xa9, 0x1c, 0x14, 0xe9, 0x6d, 0x0b, 0x03, 0x86, 0x34, 0xac, 0x2b,
+	0x18, 0x2a, 0x8e, 0xa9, 0xcc, 0x0f, 0x34, 0x60, 0x28, 0xa1, 0x68, 0xe3, 0xa0, 0xbd, 0xe0, 0xf3,
+	0x16, 0x62, 0x91, 0x27, 0x98, 0x83, 0xf3, 0x0b, 0xa3, 0x98, 0x90, 0xa5, 0x96, 0x96, 0x2a, 0xab,
+	0x4b, 0x21, 0x59, 0x2e, 0xdf, 0xc4, 0xb8, 0x90, 0xa8, 0xd7, 0x54, 0x21, 0x92, 0x52, 0xe1, 0x51,
+	0x55, 0x31, 0x1b, 0xde, 0xe8, 0x34, 0x57, 0x9b, 0x79, 0x04, 0xc6, 0x70, 0xf7, 0xfc, 0x28, 0x4b,
+	0x2e, 0x2e, 0x03, 0xe6, 0x31, 0xce, 0x33, 0x58, 0x10, 0x4d, 0xea, 0xc6, 0x8d, 0x39, 0x89, 0xd6,
+	0x69, 0x82, 0x5c, 0xca, 0x0b, 0xb2, 0xf3, 0x8f, 0x16, 0xcc, 0x8a, 0x99, 0x66, 0xd3, 0x92, 0x7d,
+	0x68, 0xa0, 0xee, 0x1a, 0x30, 0xd2, 0x35, 0xee, 0x80, 0x33, 0xa9, 0xe7, 0x80, 0xbc, 0x82, 0x2a,
+	0x17, 0x29, 0x28, 0xbc, 0x01, 0xe7, 0x25, 0x07, 0xec, 0x64, 0x5a, 0x77, 0xd9, 0x6f, 0xd2, 0xe1,
+	0xde, 0x12, 0xae, 0x08, 0xf1, 0x67, 0xe1, 0x4b, 0x0b, 0x7c, 0xbf, 0xcd, 0xc1, 0x91, 0x07, 0xac,
+	0x03, 0xbd, 0xd4, 0x19, 0x92, 0x02, 0x50, 0x72, 0x79, 0x81, 0xad, 0x30, 0x71, 0xf9, 0x2a, 0x85,
+	0x38, 0x4b, 0x7c, 0xe6, 0x05, 0x0b, 0x54, 0xd4, 0x4c, 0x5c, 0xc2, 0x49, 0xc1, 0xa9, 0x44, 0x88,
+	0x0e, 0x64, 0x25, 0x42, 0x90, 0xba, 0x0a, 0x8f, 0x17, 0x03, 0xd6, 0xe9, 0x90, 0x26, 0x74, 0x75,
+	0x38, 0xcc, 0xd6, 0x7f, 0x11, 0x2e, 0x14, 0xe0, 0x84, 0x3d, 0xfb, 0x25, 0x58, 0x5a, 0xe5, 0x17,
+	0x16, 0x3e, 0xae, 0x5c, 0x18, 0x8c, 0x0f, 0x66, 0xab, 0x14, 0x8d, 0x3d, 0xc1, 0x5e, 0xee, 0x4e,
+	0xa4, 0xd3, 0x19, 0x03, 0xbd, 0xf4, 0xa3, 0xb7, 0xf7, 0x97, 0x16, 0xd4, 0x59, 0xb5, 0x2c, 0xbe,
+	0x7a, 0x05, 0x80, 0xc5, 0xee, 0x75, 0x39, 0xd5, 0x20, 0x38, 0x85, 0xc3, 0x70, 0xdf, 0x90, 0xd2,
+	0x14, 0x80, 0xbb, 0x83, 0xb8, 0x0b, 0xac, 0x1d, 0xf9, 0x75, 0x90, 0xb6, 0xfb, 0x54, 0x0c, 0xc7,
+	0x9a, 0x1e, 0xd7, 0xad, 0x66, 0xe2, 0xba, 0xc6, 0xad, 0xc6, 0x99, 0xec, 0xad, 0xc6, 0x6c, 0x02,
+	0x07, 0x7f, 0x75, 0xc4, 0x80, 0x39, 0x3f, 0x2d, 0x43, 0x9b, 0xb3, 0x8e, 0xc5, 0x70, 0xd8, 0x12,
+	0xca, 0xa5, 0x69, 0x5b, 0x05, 0x69, 0xda, 0x3c, 0x8d, 0x93, 0x01, 0x92, 0x17, 0xf2, 0xba, 0xaa,
+	0x02, 0xa0, 0x6e, 0x30, 0xa2, 0x62, 0xfa, 0xb0, 0x0b, 0x30, 0xe8, 0xee, 0x30, 0xc3, 0x63, 0x86,
+	0xbb, 0xa3, 0x00, 0x95, 0x09, 0x56, 0x55, 0x73, 0xc1, 0xaa, 0xd3, 0xc2, 0x50, 0x37, 0xa0, 0xcd,
+	0xfb, 0x91, 0xce, 0xda, 0x2c, 0x1b, 0x67, 0x16, 0x8c, 0x0b, 0x99, 0x83, 0xb4, 0xf9, 0xaf, 0x71,
+	0x0d, 0x9d, 0x85, 0x6b, 0x09, 0x0e, 0x69, 0xb5, 0x75, 0x4e, 0x9b, 0x85, 0xf3, 0x58, 0x03, 0x83,
+	0x69, 0x15, 0x03, 0xd7, 0xb6, 0x39, 0x04, 0x79, 0x19, 0xaa, 0x3c, 0xc6, 0xd0, 0x30, 0xec, 0x06,
+	0x25, 0xa0, 0x2e, 0x47, 0xe3, 0xd9, 0xaa, 0xc5, 0x80, 0x5b, 0xe1, 0x7e, 0x7a, 0xbe, 0x4a, 0x7b,
+	0x63, 0x65, 0x45, 0x13, 0x7d, 0x55, 0xf1, 0x7e, 0x9a, 0x8e, 0x5b, 0x77, 0x55, 0x39, 0x23, 0xf4,
+	0xe5, 0x9c, 0xd0, 0x67, 0xc4, 0xba, 0x92, 0x13, 0x6b, 0xe7, 0xe7, 0x25, 0x58, 0x66, 0xdd, 0xb9,
+	0xcf, 0x7d, 0xbf, 0x78, 0x72, 0xf1, 0xfa, 0xcf, 0x51, 0xe9, 0x61, 0x6a, 0x6c, 0x38, 0x61, 0x41,
+	0x65, 0xe3, 0x54, 0x91, 0x81, 0xe2, 0xca, 0xd0, 0x62, 0x97, 0x15, 0x57, 0x94, 0x44, 0x16, 0x81,
+	0x50, 0xd2, 0x75, 0x97, 0x17, 0xc8, 0x27, 0x99, 0x2b, 0x4f, 0xba, 0x0d, 0x97, 0x74, 0x36, 0x29,
+	0x8e, 0x30, 0x0f, 0x5f, 0x4c, 0x3e, 0xab, 0xf6, 0x96, 0x3d, 0xcf, 0x57, 0x01, 0xeb, 0x29, 0x9f,
+	0x18, 0xa4, 0x28, 0xdf, 0x2c, 0x8d, 0x7c, 0x30, 0x88, 0xa5, 0x57, 0x5b, 0xec, 0xc9, 0x73, 0x6e,
+	0x01, 0x06, 0xc7, 0xaa, 0xa0, 0x1e, 0x5e, 0xc1, 0x13, 0xa1, 0xce, 0x0c, 0x14, 0x3d, 0x1c, 0x08,
+	0xd1, 0xdb, 0x12, 0xf4, 0x22, 0xc0, 0x59, 0x8c, 0x75, 0x7e, 0x50, 0x85, 0x0b, 0x7c, 0x1d, 0x1b,
+	0x2a, 0x30, 0x8d, 0x1d, 0x7d, 0xa4, 0x2b, 0x93, 0xb9, 0x8b, 0x8e, 0xe5, 0xa2, 0x8b, 0x8e, 0x68,
+	0x01, 0xe3, 0x07, 0x31, 0xcb, 0xb3, 0x11, 0x47, 0x6c, 0x1d, 0x44, 0xee, 0xc9, 0x95, 0xd4, 0x57,
+	0xda, 0xa6, 0x5b, 0x35, 0x52, 0xed, 0x32, 0xba, 0xc8, 0xcd, 0xd1, 0x93, 0x75, 0xb5, 0x6a, 0xb4,
+	0x4a, 0x66, 0x4e, 0xac, 0x24, 0xff, 0x01, 0x79, 0x02, 0x17, 0xa4, 0xa5, 0x96, 0xaf, 0x6d, 0xf6,
+	0xc4, 0xda, 0xa6, 0x7f, 0x48, 0x9e, 0x82, 0x9d, 0x41, 0xe2, 0x32, 0x93, 0x4e, 0x96, 0xda, 0x49,
+	0xe2, 0x75, 0xc2, 0x87, 0xe4, 0xf3, 0x60, 0x47, 0xf4, 0x30, 0xec, 0x73, 0x13, 0x64, 0x1c, 0x85,
+	0x83, 0x49, 0x9f, 0x46, 0x52, 0x3b, 0x73, 0xf5, 0x72, 0x02, 0x05, 0x46, 0xdc, 0x45, 0xad, 0x1a,
+	0x91, 0xf8, 0x9a, 0xeb, 0x9b, 0xa9, 0x78, 0xf2, 0x18, 0x16, 0xf6, 0xd4, 0xca, 0xed, 0x8d, 0xf9,
+	0xd2, 0x95, 0x4a, 0xe8, 0xb2, 0x3e, 0x96, 0xdc, 0x02, 0x77, 0x8b, 0xbe, 0x74, 0xee, 0xc3, 0x3c,
+	0x1f, 0x3a, 0x3d, 0x4c, 0x8d, 0x02, 0x02, 0x95, 0xf8, 0x20, 0x3c, 0x12, 0x46, 0x34, 0xfb, 0x8d,
+	0x71, 0xba, 0x21, 0xd2, 0xf4, 0xe2, 0x31, 0xed, 0xcb, 0x1d, 0x86, 0x41, 0x76, 0xc6, 0xb4, 0xef,
+	0xbc, 0x0e, 0x44, 0xaf, 0x47, 0xcb, 0xd4, 0x9d, 0xec, 0xf6, 0xe2, 0xe3, 0x38, 0xa1, 0xa3, 0x58,
+	0x65, 0xea, 0xa6, 0x20, 0xe7, 0x3a, 0x34, 0xb7, 0x3d, 0x7c, 0xf7, 0x48, 0x3c, 0x23, 0x85, 0xb1,
+	0x16, 0xef, 0x18, 0x8f, 0x14, 0x2a, 0xd6, 0xc2, 0xd0, 0xce, 0xdf, 0x95, 0x60, 0x86, 0x53, 0x62,
+	0xad, 0x03, 0x1a, 0x27, 0x7e, 0x90, 0x3e, 0x58, 0x51, 0x77, 0x75, 0x50, 0xce, 0xec, 0x2c, 0x15,
+	0x98, 0x9d, 0xc2, 0xc3, 0x29, 0x2f, 0x97, 0x8b, 0xdd, 0xd0, 0x80, 0xa1, 0xaa, 0x4e, 0x6f, 0x6a,
+	0x70, 0x75, 0x9a, 0x02, 0x32, 0xc1, 0xb7, 0xf4, 0x84, 0xca, 0xfb, 0x27, 0x2d, 0x6a, 0x61, 0x65,
+	0xea, 0xa0, 0xc2, 0x73, 0x30, 0x7f, 0x58, 0x29, 0x07, 0xcf, 0x9f, 0x77, 0x6b, 0x67, 0x38, 0xef,
+	0x72, 0xb7, 0xe7, 0x49, 0xe7, 0x5d, 0x38, 0xc3, 0x79, 0x17, 0xef, 0x27, 0xb1, 0x57, 0x73, 0xd0,
+	0x93, 0x22, 0xed, 0xcc, 0xff, 0x67, 0x41, 0x47, 0xa8, 0x35, 0x85, 0x23, 0x2f, 0x19, 0x1e, 0xa3,
+	0x69, 0x97, 0xd7, 0x98, 0x1f, 0x47, 0x45, 0x19, 0x45, 0x48, 0xd4, 0x00, 0xe2, 0x38, 0xa4, 0x55,
+	0x30, 0xf2, 0x87, 0xd2, 0x32, 0xd3, 0x40, 0x32, 0x50, 0x19, 0x79, 0x22, 0x85, 0xde, 0x72, 0x55,
+	0xd9, 0xf9, 0x03, 0x0b, 0xe6, 0xb5, 0x0e, 0x0b, 0x29, 0x7c, 0x0b, 0xa4, 0x25, 0xc9, 0x83, 0x91,
+	0x96, 0x71, 0x21, 0x23, 0x3b, 0x16, 0xd7, 0x20, 0x66, 0x93, 0xe9, 0x1d, 0xb3, 0x0e, 0xc6, 0x93,
+	0x91, 0x50, 0xc5, 0x3a, 0x08, 0x05, 0xe9, 0x88, 0xd2, 0xe7, 0x8a, 0x84, 0xef, 0xcb, 0x06, 0x0c,
+	0x07, 0x3f, 0x42, 0xff, 0x93, 0x22, 0x12, 0x77, 0xed, 0x0c, 0xa0, 0xf3, 0xe7, 0x16, 0x2c, 0x70,
+	0x47, 0xa2, 0x50, 0x43, 0xea, 0x7d, 0x8e, 0x19, 0xee, 0x39, 0xe5, 0x2b, 0x72, 0xf3, 0x9c, 0x2b,
+	0xca, 0xe4, 0xb5, 0x33, 0x3a, 0x3f, 0x55, 0x76, 0xf5, 0x94, 0xb9, 0x28, 0x17, 0xcd, 0xc5, 0x09,
+	0x9c, 0x2e, 0x0a, 0xbe, 0x55, 0x0b, 0x83, 0x6f, 0xf8, 0x9a, 0x20, 0xbb, 0xdd, 0x82, 0x49, 0x16,
+	0xe6, 0xe0, 0xc4, 0x71, 0xe1, 0xfb, 0x16, 0x74, 0x53, 0x6d, 0xb5, 0xe9, 0xc7, 0x49, 0x18, 0xa9,
+	0x77, 0xd3, 0xae, 0x00, 0xc4, 0x89, 0x17, 0x25, 0xfc, 0x02, 0x9d, 0xb0, 0xf3, 0x53, 0x08, 0xf6,
+	0x91, 0x06, 0x03, 0x8e, 0xe5, 0x73, 0xa3, 0xca, 0xb9, 0xf3, 0xbe, 0x70, 0x75, 0xea, 0x30, 0x69,
+	0x09, 0xe0, 0xb9, 0x9e, 0x1e, 0xb2, 0x33, 0x58, 0x25, 0xb5, 0x04, 0x52, 0xa8, 0xf3, 0xfb, 0x16,
+	0xb4, 0xd3, 0x4e, 0xb2, 0xdb, 0xb2, 0xa6, 0x76, 0x10, 0x86, 0x9c, 0x02, 0xa8, 0xa0, 0x9d, 0x8f,
+	0x67, 0x67, 0xd1, 0x37, 0x0d, 0xa2, 0xf6, 0x67, 0x7f, 0x80, 0x51, 0x19, 0x21, 0x10, 0x3a, 0x88,
+	0xe7, 0x89, 0xe2, 0xd1, 0x40, 0x78, 0x20, 0x44, 0x89, 0xdd, 0x7f, 0x1c, 0x25, 0xec, 0xab, 0x19,
+	0x6e, 0x19, 0x88, 0xa2, 0x3c, 0xf6, 0x72, 0xcb, 0x19, 0x7f, 0x3a, 0xff, 0xcb, 0x82, 0x0b, 0x05,
+	0xcc, 0x15, 0x2b, 0x63, 0x1d, 0xe6, 0xb5, 0x4d, 0x41, 0x30, 0x80, 0x2f, 0x0f, 0xb9, 0xdf, 0x66,
+	0x06, 0xed, 0xe6, 0x3f, 0x50, 0x7e, 0x0a, 0xce, 0x52, 0x23, 0x3f, 0x3d, 0x8f, 0x70, 0xb6, 0xc1,
+	0xde, 0x78, 0x81, 0x0b, 0x4d, 0x25, 0xa8, 0xf4, 0x9f, 0x4f, 0x64, 0x20, 0x26, 0xe3, 0x7a, 0xb6,
+	0xce, 0xe4, 0x7a, 0xde, 0x83, 0x39, 0xa3, 0x2e, 0xf2, 0xe9, 0xb3, 0x56, 0x92, 0x09, 0xa2, 0xb2,
+	0xd2, 0x2e, 0xab, 0x43, 0x66, 0xc9, 0x6b, 0x20, 0xe7, 0x10, 0xda, 0xef, 0x4e, 0x86, 0x89, 0x8f,
+	0x55, 0x88, 0x96, 0x5e, 0x83, 0x46, 0x5a, 0x85, 0x64, 0x5d, 0x61, 0x53, 0x3a, 0x1d, 0x72, 0x6c,
+	0x84, 0x35, 0xf5, 0xf2, 0x2d, 0xe6, 0x11, 0x18, 0x00, 0x20, 0x69, 0x9b, 0x3b, 0x81, 0x37, 0x8e,
+	0x0f, 0xc2, 0x84, 0x3c, 0x80, 0x05, 0x0c, 0x26, 0x0c, 0xa9, 0x4e, 0x1c, 0x8b, 0xe1, 0x2e, 0x65,
+	0x2f, 0x99, 0x33, 0xa4, 0x5b, 0xf4, 0x05, 0x4a, 0x41, 0x71, 0x6f, 0x52, 0x29, 0xc8, 0x8c, 0xbb,
+	0xa8, 0x97, 0x6f, 0x43, 0xcb, 0x6c, 0x0c, 0x43, 0xbc, 0x99, 0x9e, 0xe9, 0x81, 0x58, 0x73, 0xfa,
+	0x0d, 0x4a, 0xe7, 0x7b, 0x16, 0x74, 0x5d, 0x8a, 0xb2, 0x4a, 0xb5, 0x46, 0x85, 0x88, 0xbc, 0x95,
+	0xab, 0x76, 0xfa, 0x80, 0x55, 0xb2, 0xb8, 0x1c, 0xeb, 0xad, 0xa9, 0x9c, 0xdf, 0x3c, 0x57, 0x30,
+	0x2a, 0xcc, 0xf0, 0x16, 0xe3, 0x63, 0x0f, 0x56, 0xb1, 0x2e, 0xc9, 0xee, 0x08, 0xfd, 0x65, 0x43,
+	0x97, 0xbf, 0x05, 0xa5, 0x77, 0x95, 0xe3, 0xee, 0x7e, 0xaf, 0x0c, 0x2d, 0x9e, 0x40, 0xc6, 0xdf,
+	0x10, 0xa6, 0x11, 0x79, 0x17, 0x66, 0xc5, 0x1b, 0xd0, 0x44, 0xf6, 0xd9, 0x7c, 0x75, 0xda, 0x5e,
+	0xce, 0x82, 0x45, 0x43, 0x0b, 0xff, 0xe5, 0x67, 0x3f, 0xff, 0xdf, 0xa5, 0x39, 0xd2, 0xb8, 0x7d,
+	0xf8, 0xea, 0xed, 0x7d, 0x1a, 0xc4, 0x58, 0xc7, 0xbf, 0x01, 0x48, 0x5f, 0x47, 0x26, 0x5d, 0xe5,
+	0x4c, 0xcc, 0x3c, 0xfb, 0x6c, 0x5f, 0x28, 0xc0, 0x88, 0x7a, 0x2f, 0xb0, 0x7a, 0x17, 0x9c, 0x16,
+	0xd6, 0xeb, 0x07, 0x7e, 0xc2, 0x9f, 0x4a, 0x7e, 0xd3, 0xba, 0x49, 0x06, 0xd0, 0xd4, 0x1f, 0x3f,
+	0x26, 0x32, 0xa6, 0x58, 0xf0, 0xf4, 0xb2, 0x7d, 0xb1, 0x10, 0x27, 0x03, 0xaa, 0xac, 0x8d, 0x25,
+	0xa7, 0x83, 0x6d, 0x4c, 0x18, 0x45, 0xda, 0xca, 0x10, 0x5a, 0xe6, 0x1b, 0xc7, 0xe4, 0x92, 0x36,
+	0x9b, 0xb9, 0x17, 0x96, 0xed, 0xcb, 0x53, 0xb0, 0xa2, 0xad, 0xcb, 0xac, 0xad, 0xf3, 0x0e, 0xc1,
+	0xb6, 0xfa, 0x8c, 0x46, 0xbe, 0xb0, 0xfc, 0xa6, 0x75, 0xf3, 0xee, 0x4f, 0x6e, 0x42, 0x5d, 0x65,
+	0x01, 0x90, 0x6f, 0xc2, 0x9c, 0x91, 0xe1, 0x47, 0xe4, 0x30, 0x8a, 0x12, 0x02, 0xed, 0x4b, 0xc5,
+	0x48, 0xd1, 0xf0, 0x15, 0xd6, 0x70, 0x97, 0x2c, 0x63, 0xc3, 0xc2, 0x3d, 0x72, 0x9b, 0xe5, 0x35,
+	0xf2, 0xab, 0xc4, 0xcf, 0xb5, 0x25, 0xc2, 0x1b, 0xbb, 0x54, 0xf8, 0x16, 0x44, 0xd1, 0x38, 0xf3,
+	0xa9, 0x7c, 0xce, 0x25, 0xd6, 0xdc, 0x32, 0x59, 0xd4, 0x9b, 0x53, 0xd1, 0x79, 0xca, 0x2e, 0x7f,
+	0xeb, 0x8f, 0x1d, 0x93, 0xcb, 0x4a, 0xb0, 0x8a, 0x1e, 0x41, 0x56, 0x22, 0x92, 0x7f, 0x8a, 0xd8,
+	0xe9, 0xb2, 0xa6, 0x08, 0x61, 0xd3, 0xa7, 0x3f, 0x36, 0x4c, 0x0e, 0xa1, 0x93, 0x7d, 0xd6, 0x98,
+	0x5c, 0x91, 0xb9, 0x16, 0xc5, 0x4f, 0x2a, 0xdb, 0x57, 0xa7, 0xe2, 0xc5, 0xc8, 0x5e, 0x62, 0xcd,
+	0x5d, 0x74, 0x96, 0xb3, 0xcd, 0xdd, 0x66, 0xef, 0x61, 0xa2, 0xcc, 0x7c, 0x1d, 0xea, 0xea, 0xe1,
+	0x4b, 0x72, 0x5e, 0x7b, 0x47, 0x55, 0x7f, 0x08, 0xd4, 0xee, 0xe6, 0x11, 0x45, 0x02, 0xa9, 0x37,
+	0x81, 0x95, 0x6f, 0xc1, 0x92, 0x70, 0x8a, 0xef, 0xd2, 0x0f, 0xc2, 0xc1, 0x82, 0xb7, 0x99, 0xef,
+	0x58, 0xe4, 0x2d, 0xa8, 0xc9, 0xa7, 0x4c, 0xc9, 0x72, 0xf1, 0x8b, 0xaf, 0xf6, 0xf9, 0x1c, 0x5c,
+	0x6c, 0xd1, 0x5f, 0x83, 0x59, 0xf1, 0x78, 0xa5, 0x52, 0x17, 0xe6, 0x73, 0x9a, 0xf6, 0x72, 0x16,
+	0x2c, 0x46, 0xb8, 0xc2, 0x46, 0x68, 0x3b, 0x4b, 0x39, 0x26, 0xee, 0x4e, 0x46, 0x63, 0x1c, 0xe6,
+	0x33, 0x68, 0x68, 0x6f, 0x38, 0x12, 0x39, 0xff, 0xf9, 0x97, 0x22, 0x6d, 0xbb, 0x08, 0x25, 0xda,
+	0x99, 0x67, 0xed, 0x34, 0x48, 0x9d, 0x2d, 0x6d, 0x7c, 0xe2, 0x91, 0x7c, 0x03, 0x1a, 0xda, 0x43,
+	0x83, 0x69, 0xc5, 0xb9, 0x37, 0x04, 0x6d, 0xbb, 0x08, 0x25, 0x15, 0x2b, 0xab, 0x78, 0xd1, 0x69,
+	0xab, 0x8a, 0x6f, 0xb3, 0x07, 0x03, 0xb1, 0xeb, 0x07, 0x30, 0x67, 0x3c, 0x1f, 0xa8, 0x96, 0x6d,
+	0xd1, 0x4b, 0x85, 0xf6, 0xa5, 0x62, 0xa4, 0xb9, 0x8e, 0x9c, 0xf9, 0xb4, 0x9d, 0x88, 0xaa, 0x96,
+	0xbe, 0x0a, 0x90, 0x3e, 0x49, 0xa9, 0x14, 0x6c, 0xee, 0x95, 0x4a, 0xfb, 0x42, 0x01, 0x46, 0x34,
+	0xb0, 0xcc, 0x1a, 0xe8, 0x10, 0xa6, 0x60, 0x03, 0x7a, 0x24, 0xef, 0x80, 0xad, 0x43, 0x43, 0x7b,
+	0xc2, 0x50, 0xb1, 0x29, 0xff, 0xfc, 0xa1, 0x6d, 0x17, 0xa1, 0x84, 0x84, 0xbc, 0x0d, 0x73, 0xc6,
+	0x5b, 0x84, 0x8a, 0x15, 0x45, 0x2f, 0x1d, 0xda, 0x97, 0x8a, 0x91, 0x4a, 0xda, 0x1a, 0xda, 0xcb,
+	0x81, 0x44, 0xbb, 0x44, 0x95, 0x79, 0x33, 0xd0, 0xb6, 0x8b, 0x50, 0x62, 0xbc, 0x8b, 0x6c, 0xbc,
+	0x2d, 0x87, 0x49, 0x04, 0x7b, 0xb3, 0x01, 0x19, 0xf9, 0x4d, 0x68, 0x99, 0x6f, 0x09, 0x2a, 0xed,
+	0x57, 0xf8, 0x2a, 0xa1, 0x7d, 0x79, 0x0a, 0xd6, 0x5c, 0xc0, 0x37, 0x17, 0x54, 0x23, 0xb7, 0xdf,
+	0x13, 0x79, 0x8c, 0xef, 0x93, 0x2f, 0x41, 0x5d, 0x3d, 0xa2, 0x41, 0xce, 0x6b, 0xc2, 0xab, 0x3f,
+	0xb5, 0x61, 0x77, 0xf3, 0x88, 0x22, 0x99, 0x66, 0x95, 0xf3, 0x7d, 0x9b, 0x3d, 0xa6, 0xa1, 0xed,
+	0xdb, 0xfa, 0x7b, 0x1b, 0xf6, 0x72, 0x16, 0x5c, 0xbc, 0x6f, 0x27, 0x3e, 0xd6, 0x31, 0x62, 0xea,
+	0x59, 0x7f, 0xf8, 0x40, 0x57, 0x2e, 0x05, 0x0f, 0x3b, 0xd8, 0x57, 0xa6, 0xa1, 0x4d, 0x86, 0x90,
+	0x05, 0xd1, 0x8c, 0x7c, 0xfd, 0x80, 0x35, 0x17, 0x40, 0x3b, 0x73, 0x45, 0x40, 0x35, 0x57, 0x7c,
+	0xcb, 0xcb, 0xbe, 0x32, 0x0d, 0x5d, 0xb4, 0xfb, 0xc8, 0x5d, 0xe7, 0xb6, 0xbc, 0x94, 0xf7, 0x6f,
+	0xa1, 0xa9, 0x3f, 0x39, 0x47, 0x74, 0x05, 0x92, 0x6d, 0xe9, 0x62, 0x21, 0xce, 0x94, 0x25, 0xd2,
+	0xd4, 0x9b, 0x41, 0x59, 0x32, 0xdf, 0xdc, 0x4a, 0x77, 0xd2, 0xa2, 0xa7, 0xc6, 0xec, 0xcb, 0x53,
+	0xb0, 0x45, 0xac, 0x53, 0x63, 0xe1, 0x39, 0x31, 0xe4, 0xcb, 0xb0, 0xac, 0x36, 0x03, 0xfd, 0xb5,
+	0xa4, 0x98, 0x5c, 0x2d, 0x78, 0x43, 0x49, 0x0f, 0xa6, 0xda, 0x17, 0xa6, 0x3e, 0xb2, 0x74, 0xc7,
+	0x22, 0x5f, 0x83, 0xb6, 0x76, 0xd3, 0x68, 0xe7, 0x38, 0xe8, 0xab, 0xf5, 0x96, 0xbf, 0xc4, 0x6b,
+	0x17, 0x1d, 0x2a, 0x9c, 0xf3, 0xac, 0xdf, 0xf3, 0x8e, 0xc1, 0x1c, 0x5c, 0x6b, 0x6b, 0xd0, 0xd0,
+	0xea, 0x38, 0xa9, 0xde, 0xf3, 0x1a, 0x4a, 0xbf, 0x92, 0x79, 0xc7, 0x22, 0xff, 0x1f, 0x1f, 0x6b,
+	0xd7, 0x6f, 0xe0, 0x18, 0x19, 0x65, 0x99, 0x7a, 0xba, 0x3a, 0x4e, 0xaf, 0xc8, 0x71, 0x59, 0x27,
+	0xb7, 0x6e, 0xbe, 0x6d, 0x30, 0xf7, 0x3d, 0xc3, 0x3b, 0x74, 0x2b, 0xfb, 0x70, 0xfb, 0xfb, 0x59,
+	0x02, 0x3d, 0x4e, 0xf6, 0xfe, 0x1d, 0x8b, 0xfc, 0x3b, 0xa8, 0xab, 0x97, 0x01, 0xd2, 0xfd, 0x3f,
+	0xf3, 0xd0, 0x81, 0xdd, 0xcd, 0x23, 0x4c, 0x5b, 0xcd, 0x31, 0xa7, 0x9c, 0x3f, 0x22, 0x80, 0x1c,
+	0xfc, 0x8f, 0x40, 0xf2, 0x97, 0xf0, 0xc9, 0x8a, 0xb6, 0xd7, 0x16, 0x3e, 0x2e, 0x60, 0xbf, 0x74,
+	0x02, 0x85, 0x68, 0xfa, 0x1a, 0x6b, 0xfa, 0x8a, 0x73, 0xa1, 0x68, 0xe5, 0xa8, 0xcd, 0xf9, 0xd7,
+	0x2c, 0x68, 0x99, 0x01, 0x56, 0x25, 0xe3, 0x85, 0xa1, 0x5c, 0xfb, 0xf2, 0x14, 0xac, 0x68, 0xf5,
+	0x17, 0x30, 0x0d, 0xe4, 0x4d, 0xfe, 0xff, 0x21, 0x64, 0xb4, 0x9f, 0x68, 0x46, 0x4c, 0x56, 0x6e,
+	0xf5, 0x7f, 0x8e, 0x70, 0xc3, 0xba, 0x63, 0x91, 0x6f, 0x40, 0x5b, 0xfb, 0x96, 0x89, 0xff, 0x59,
+	0xbf, 0x9f, 0xc2, 0xc1, 0xac, 0x15, 0xb7, 0x0a, 0x0d, 0xed, 0x7f, 0x1f, 0xa4, 0xdb, 0x6b, 0xee,
+	0xff, 0x21, 0x4c, 0xef, 0xe4, 0x08, 0xda, 0x1a, 0xb9, 0xb1, 0x46, 0xcf, 0x58, 0x8d, 0x73, 0x93,
+	0xf5, 0xf5, 0x9a, 0x73, 0x75, 0x6a, 0x5f, 0x6f, 0x33, 0x97, 0x2b, 0xf6, 0x78, 0x1b, 0x20, 0xcd,
+	0xcc, 0x21, 0x99, 0xcc, 0x10, 0xa5, 0x4d, 0xf2, 0xc9, 0x3b, 0xa6, 0x22, 0x90, 0x09, 0x24, 0xdc,
+	0x4c, 0x6e, 0x6a, 0x69, 0x28, 0xb1, 0x61, 0xe3, 0x99, 0x29, 0x34, 0xb6, 0x5d, 0x84, 0x2a, 0xd2,
+	0xc2, 0xb2, 0x7e, 0xf2, 0x14, 0xe6, 0xb6, 0xc2, 0xf0, 0xf9, 0x64, 0x2c, 0x7b, 0x4c, 0xcc, 0xcc,
+	0x05, 0x4c, 0xf4, 0xb1, 0x33, 0xa3, 0x90, 0x66, 0x29, 0xe9, 0x6a, 0x55, 0xdd, 0x7e, 0x2f, 0xcd,
+	0xfc, 0x79, 0x9f, 0x78, 0x30, 0xaf, 0x14, 0xae, 0xea, 0xb8, 0x6d, 0x56, 0x63, 0xa8, 0xd9, 0x6c,
+	0x13, 0xc6, 0x39, 0x4c, 0xf6, 0xf6, 0x76, 0x2c, 0xeb, 0xbc, 0x63, 0x91, 0x6d, 0x68, 0xae, 0xd3,
+	0x7e, 0x38, 0xa0, 0x22, 0xa4, 0xb0, 0x90, 0x76, 0x5c, 0xc5, 0x22, 0xec, 0x39, 0x03, 0x68, 0x6e,
+	0x78, 0x63, 0xef, 0x38, 0xa2, 0xdf, 0xba, 0xfd, 0x9e, 0x08, 0x56, 0xbc, 0x2f, 0x37, 0x3c, 0x31,
+	0x72, 0x73, 0xc3, 0xcb, 0xa4, 0x6a, 0xd8, 0x17, 0x0b, 0x71, 0x45, 0xac, 0x96, 0x99, 0x1f, 0x64,
+	0x08, 0xf3, 0xb9, 0xec, 0x0e, 0xb5, 0xff, 0x4c, 0xcb, 0x09, 0xb1, 0x57, 0xa6, 0x13, 0x98, 0xad,
+	0xdd, 0x34, 0x5b, 0xdb, 0x81, 0xb9, 0x75, 0xca, 0x99, 0xc5, 0x93, 0xe9, 0x33, 0x2f, 0x1f, 0xea,
+	0x89, 0xf7, 0xf6, 0x42, 0x01, 0xce, 0x34, 0xa0, 0x58, 0x26, 0x3b, 0xf9, 0x3a, 0x34, 0x1e, 0xd0,
+	0x44, 0x66, 0xcf, 0xab, 0x93, 0x50, 0x26, 0x9d, 0xde, 0x2e, 0x48, 0xbe, 0x37, 0x65, 0x86, 0xd5,
+	0x76, 0x1b, 0xd3, 0xf1, 0xb9, 0x72, 0xea, 0xf9, 0x83, 0xf7, 0xc9, 0x57, 0x58, 0xe5, 0xea, 0x32,
+	0xce, 0xb2, 0x96, 0x74, 0xad, 0x57, 0xde, 0xce, 0xc0, 0x8b, 0x6a, 0x0e, 0xc2, 0x01, 0xd5, 0x4c,
+	0xc9, 0x00, 0x1a, 0xda, 0x4d, 0x31, 0xb5, 0x80, 0xf2, 0xb7, 0xde, 0x6c, 0xbb, 0x08, 0x25, 0xf8,
+	0x7c, 0x83, 0xb5, 0xe3, 0x90, 0x95, 0xb4, 0x1d, 0x7e, 0x99, 0x2c, 0x6d, 0xe9, 0xf6, 0x7b, 0xde,
+	0x28, 0x79, 0x9f, 0x3c, 0x63, 0xef, 0xb8, 0xe9, 0x37, 0x04, 0xd2, 0x93, 0x45, 0xf6, 0x32, 0x81,
+	0x4d, 0xf2, 0x28, 0xf3, 0xb4, 0xc1, 0x9b, 0x62, 0x26, 0xe0, 0x6b, 0x00, 0x98, 0xe3, 0xbe, 0xee,
+	0xd1, 0x51, 0x18, 0xa4, 0xba, 0x36, 0xcd, 0x82, 0xb7, 0x17, 0x0c, 0x98, 0x38, 0x12, 0x3c, 0xd3,
+	0xce, 0xc2, 0xfa, 0x14, 0xab, 0xbd, 0x70, 0x6a, 0xa2, 0xbc, 0x6d, 0x17, 0x51, 0x28, 0xf3, 0x62,
+	0x15, 0x20, 0x0d, 0x19, 0xaa, 0x83, 0x55, 0x2e, 0x1a, 0x69, 0x5f, 0x28, 0xc0, 0x88, 0xbe, 0xfd,
+	0xd0, 0x12, 0xe1, 0x4b, 0x3d, 0xce, 0xae, 0x2d, 0x8b, 0xe2, 0x24, 0x24, 0x7b, 0x65, 0x3a, 0x81,
+	0x98, 0xae, 0xaf, 0x30, 0x1e, 0xba, 0x64, 0xdb, 0x50, 0xda, 0x03, 0xa4, 0xff, 0x88, 0x5b, 0xe6,
+	0x36, 0xd4, 0xd3, 0xb0, 0xd9, 0xf9, 0xf4, 0x82, 0xa2, 0x11, 0x64, 0xb3, 0xbb, 0x79, 0x84, 0xe8,
+	0x59, 0x87, 0xf5, 0x0c, 0x48, 0x0d, 0x7b, 0xc6, 0x22, 0x54, 0x3e, 0x2c, 0x70, 0x9e, 0x2a, 0xd3,
+	0x90, 0xa5, 0xa2, 0x4b, 0xe6, 0x17, 0x04, 0x94, 0xec, 0x8b, 0x85, 0xb8, 0x22, 0x77, 0x20, 0x0e,
+	0x85, 0xa7, 0xc1, 0xe3, 0x6e, 0x32, 0x82, 0xf9, 0x5c, 0x30, 0x41, 0xb1, 0x7b, 0x5a, 0x0c, 0xc7,
+	0x5e, 0x99, 0x4e, 0x20, 0x9a, 0x5c, 0x62, 0x4d, 0xb6, 0x1d, 0xc0, 0x26, 0xe3, 0x23, 0x3f, 0xe9,
+	0x1f, 0x60, 0x73, 0x98, 0xf9, 0x5e, 0x10, 0x2b, 0x20, 0xd2, 0xc6, 0x9a, 0x1e, 0x47, 0xb0, 0x0b,
+	0xbd, 0xcc, 0xce, 0x0e, 0x6b, 0xe7, 0x5d, 0xf2, 0x8e, 0x31, 0xad, 0xdc, 0xc1, 0x2b, 0x94, 0xc9,
+	0x89, 0x93, 0x5a, 0x38, 0xa3, 0x13, 0xe8, 0x64, 0xfd, 0xbf, 0x44, 0x37, 0xfc, 0x4d, 0xb7, 0xbd,
+	0x72, 0x7e, 0x4d, 0xf3, 0x19, 0x3b, 0xff, 0x82, 0x75, 0xf2, 0xaa, 0x63, 0x17, 0x75, 0xf2, 0x90,
+	0x7d, 0x85, 0xcc, 0xf9, 0x0f, 0xca, 0x1f, 0x9d, 0x71, 0xbb, 0x5f, 0x55, 0xce, 0x8e, 0x62, 0x07,
+	0xba, 0x7d, 0xc9, 0x24, 0xc8, 0x34, 0xff, 0x32, 0x6b, 0x7e, 0xc5, 0xb9, 0x58, 0xd4, 0x7c, 0xc4,
+	0x3f, 0x79, 0xd3, 0xba, 0xb9, 0x3b, 0xc3, 0xfe, 0x69, 0xe2, 0xa7, 0xff, 0x79, 0x00, 0x3f, 0x68,
+	0x9b, 0x0d, 0x66, 0x71, 0x00, 0x00,
 }
@@ -0,0 +1,460 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: signer.proto
+
+/*
+Package signrpc is a generated protocol buffer package.
+
+It is generated from these files:
+	signer.proto
+
+It has these top-level messages:
+	KeyLocator
+	KeyDescriptor
+	SignInput
+	SignReq
+	SignResp
+	InputScript
+	InputScriptResp
+	SignMessageReq
+	SignMessageResp
+*/
+package signrpc
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type KeyLocator struct {
+	// / The key family of the key.
+	KeyFamily int32 `protobuf:"varint,1,opt,name=key_family,json=keyFamily" json:"key_family,omitempty"`
+	// / The index of the key within its family.
+	KeyIndex int32 `protobuf:"varint,2,opt,name=key_index,json=keyIndex" json:"key_index,omitempty"`
+}
+
+func (m *KeyLocator) Reset()                    { *m = KeyLocator{} }
+func (m *KeyLocator) String() string            { return proto.CompactTextString(m) }
+func (*KeyLocator) ProtoMessage()               {}
+func (*KeyLocator) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *KeyLocator) GetKeyFamily() int32 {
+	if m != nil {
+		return m.KeyFamily
+	}
+	return 0
+}
+
+func (m *KeyLocator) GetKeyIndex() int32 {
+	if m != nil {
+		return m.KeyIndex
+	}
+	return 0
+}
+
+type KeyDescriptor struct {
+	// / The compressed public key. Either this or the key locator must be set.
+	RawKeyBytes []byte `protobuf:"bytes,1,opt,name=raw_key_bytes,json=rawKeyBytes,proto3" json:"raw_key_bytes,omitempty"`
+	// / The key locator of the key.
+	KeyLoc *KeyLocator `protobuf:"bytes,2,opt,name=key_loc,json=keyLoc" json:"key_loc,omitempty"`
+}
+
+func (m *KeyDescriptor) Reset()                    { *m = KeyDescriptor{} }
+func (m *KeyDescriptor) String() string            { return proto.CompactTextString(m) }
+func (*KeyDescriptor) ProtoMessage()               {}
+func (*KeyDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *KeyDescriptor) GetRawKeyBytes() []byte {
+	if m != nil {
+		return m.RawKeyBytes
+	}
+	return nil
+}
+
+func (m *KeyDescriptor) GetKeyLoc() *KeyLocator {
+	if m != nil {
+		return m.KeyLoc
+	}
+	return nil
+}
+
+type SignInput struct {
+	// / The serialized sign descriptor describing how to sign the input.
+	SignDesc []byte `protobuf:"bytes,1,opt,name=sign_desc,json=signDesc,proto3" json:"sign_desc,omitempty"`
+	// / The index of the input within the transaction.
+	InputIndex int32 `protobuf:"varint,2,opt,name=input_index,json=inputIndex" json:"input_index,omitempty"`
+}
+
+func (m *SignInput) Reset()                    { *m = SignInput{} }
+func (m *SignInput) String() string            { return proto.CompactTextString(m) }
+func (*SignInput) ProtoMessage()               {}
+func (*SignInput) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *SignInput) GetSignDesc() []byte {
+	if m != nil {
+		return m.SignDesc
+	}
+	return nil
+}
+
+func (m *SignInput) GetInputIndex() int32 {
+	if m != nil {
+		return m.InputIndex
+	}
+	return 0
+}
+
+type SignReq struct {
+	// / The serialized transaction to sign.
+	RawTxBytes []byte `protobuf:"bytes,1,opt,name=raw_tx_bytes,json=rawTxBytes,proto3" json:"raw_tx_bytes,omitempty"`
+	// / The inputs of the transaction to sign.
+	Inputs []*SignInput `protobuf:"bytes,2,rep,name=inputs" json:"inputs,omitempty"`
+}
+
+func (m *SignReq) Reset()                    { *m = SignReq{} }
+func (m *SignReq) String() string            { return proto.CompactTextString(m) }
+func (*SignReq) ProtoMessage()               {}
+func (*SignReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *SignReq) GetRawTxBytes() []byte {
+	if m != nil {
+		return m.RawTxBytes
+	}
+	return nil
+}
+
+func (m *SignReq) GetInputs() []*SignInput {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+type SignResp struct {
+	// / The DER encoded signatures of the inputs, in the order of the request.
+	RawSigs [][]byte `protobuf:"bytes,1,rep,name=raw_sigs,json=rawSigs,proto3" json:"raw_sigs,omitempty"`
+}
+
+func (m *SignResp) Reset()                    { *m = SignResp{} }
+func (m *SignResp) String() string            { return proto.CompactTextString(m) }
+func (*SignResp) ProtoMessage()               {}
+func (*SignResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *SignResp) GetRawSigs() [][]byte {
+	if m != nil {
+		return m.RawSigs
+	}
+	return nil
+}
+
+type InputScript struct {
+	// / The witness stack of the input.
+	Witness [][]byte `protobuf:"bytes,1,rep,name=witness,proto3" json:"witness,omitempty"`
+	// / The sigScript of the input, only set for nested p2wkh outputs.
+	SigScript []byte `protobuf:"bytes,2,opt,name=sig_script,json=sigScript,proto3" json:"sig_script,omitempty"`
+}
+
+func (m *InputScript) Reset()                    { *m = InputScript{} }
+func (m *InputScript) String() string            { return proto.CompactTextString(m) }
+func (*InputScript) ProtoMessage()               {}
+func (*InputScript) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *InputScript) GetWitness() [][]byte {
+	if m != nil {
+		return m.Witness
+	}
+	return nil
+}
+
+func (m *InputScript) GetSigScript() []byte {
+	if m != nil {
+		return m.SigScript
+	}
+	return nil
+}
+
+type InputScriptResp struct {
+	// / The input scripts of the inputs, in the order of the request.
+	InputScripts []*InputScript `protobuf:"bytes,1,rep,name=input_scripts,json=inputScripts" json:"input_scripts,omitempty"`
+}
+
+func (m *InputScriptResp) Reset()                    { *m = InputScriptResp{} }
+func (m *InputScriptResp) String() string            { return proto.CompactTextString(m) }
+func (*InputScriptResp) ProtoMessage()               {}
+func (*InputScriptResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *InputScriptResp) GetInputScripts() []*InputScript {
+	if m != nil {
+		return m.InputScripts
+	}
+	return nil
+}
+
+type SignMessageReq struct {
+	// / The message to sign.
+	Msg []byte `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	// / The key to sign the message with.
+	KeyDesc *KeyDescriptor `protobuf:"bytes,2,opt,name=key_desc,json=keyDesc" json:"key_desc,omitempty"`
+}
+
+func (m *SignMessageReq) Reset()                    { *m = SignMessageReq{} }
+func (m *SignMessageReq) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageReq) ProtoMessage()               {}
+func (*SignMessageReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *SignMessageReq) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *SignMessageReq) GetKeyDesc() *KeyDescriptor {
+	if m != nil {
+		return m.KeyDesc
+	}
+	return nil
+}
+
+type SignMessageResp struct {
+	// / The DER encoded signature.
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignMessageResp) Reset()                    { *m = SignMessageResp{} }
+func (m *SignMessageResp) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageResp) ProtoMessage()               {}
+func (*SignMessageResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *SignMessageResp) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*KeyLocator)(nil), "signrpc.KeyLocator")
+	proto.RegisterType((*KeyDescriptor)(nil), "signrpc.KeyDescriptor")
+	proto.RegisterType((*SignInput)(nil), "signrpc.SignInput")
+	proto.RegisterType((*SignReq)(nil), "signrpc.SignReq")
+	proto.RegisterType((*SignResp)(nil), "signrpc.SignResp")
+	proto.RegisterType((*InputScript)(nil), "signrpc.InputScript")
+	proto.RegisterType((*InputScriptResp)(nil), "signrpc.InputScriptResp")
+	proto.RegisterType((*SignMessageReq)(nil), "signrpc.SignMessageReq")
+	proto.RegisterType((*SignMessageResp)(nil), "signrpc.SignMessageResp")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Signer service
+
+type SignerClient interface {
+	// *
+	// SignOutputRaw generates a signature for each of the passed inputs of the
+	// transaction as described by their serialized sign descriptors. The
+	// returned signatures don't include the sighash byte.
+	SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error)
+	// *
+	// ComputeInputScript generates the complete witness and, for nested p2wkh
+	// outputs, the sigScript for each of the passed inputs of the transaction.
+	// Only inputs spending outputs of the on-chain wallet can be signed this
+	// way.
+	ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error)
+	// *
+	// SignMessage signs the double SHA-256 of the passed message with the key
+	// described by the key descriptor. If only the public key is set, it is
+	// used to look up the key.
+	SignMessage(ctx context.Context, in *SignMessageReq, opts ...grpc.CallOption) (*SignMessageResp, error)
+}
+
+type signerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSignerClient(cc *grpc.ClientConn) SignerClient {
+	return &signerClient{cc}
+}
+
+func (c *signerClient) SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error) {
+	out := new(SignResp)
+	err := grpc.Invoke(ctx, "/signrpc.Signer/SignOutputRaw", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error) {
+	out := new(InputScriptResp)
+	err := grpc.Invoke(ctx, "/signrpc.Signer/ComputeInputScript", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) SignMessage(ctx context.Context, in *SignMessageReq, opts ...grpc.CallOption) (*SignMessageResp, error) {
+	out := new(SignMessageResp)
+	err := grpc.Invoke(ctx, "/signrpc.Signer/SignMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Signer service
+
+type SignerServer interface {
+	// *
+	// SignOutputRaw generates a signature for each of the passed inputs of the
+	// transaction as described by their serialized sign descriptors. The
+	// returned signatures don't include the sighash byte.
+	SignOutputRaw(context.Context, *SignReq) (*SignResp, error)
+	// *
+	// ComputeInputScript generates the complete witness and, for nested p2wkh
+	// outputs, the sigScript for each of the passed inputs of the transaction.
+	// Only inputs spending outputs of the on-chain wallet can be signed this
+	// way.
+	ComputeInputScript(context.Context, *SignReq) (*InputScriptResp, error)
+	// *
+	// SignMessage signs the double SHA-256 of the passed message with the key
+	// described by the key descriptor. If only the public key is set, it is
+	// used to look up the key.
+	SignMessage(context.Context, *SignMessageReq) (*SignMessageResp, error)
+}
+
+func RegisterSignerServer(s *grpc.Server, srv SignerServer) {
+	s.RegisterService(&_Signer_serviceDesc, srv)
+}
+
+func _Signer_SignOutputRaw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).SignOutputRaw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/SignOutputRaw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).SignOutputRaw(ctx, req.(*SignReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_ComputeInputScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).ComputeInputScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/ComputeInputScript",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).ComputeInputScript(ctx, req.(*SignReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).SignMessage(ctx, req.(*SignMessageReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Signer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "signrpc.Signer",
+	HandlerType: (*SignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SignOutputRaw",
+			Handler:    _Signer_SignOutputRaw_Handler,
+		},
+		{
+			MethodName: "ComputeInputScript",
+			Handler:    _Signer_ComputeInputScript_Handler,
+		},
+		{
+			MethodName: "SignMessage",
+			Handler:    _Signer_SignMessage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "signer.proto",
+}
+
+func init() { proto.RegisterFile("signer.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 467 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xd1, 0x8b, 0xd3, 0x40,
+	0x10, 0xc6, 0xe9, 0x15, 0x9b, 0xde, 0x24, 0xf5, 0xce, 0x55, 0x34, 0x9e, 0x8a, 0x25, 0x20, 0x14,
+	0x91, 0x8a, 0xd5, 0x17, 0xdf, 0x44, 0xe5, 0xb0, 0xf4, 0x44, 0xd8, 0x2a, 0x3e, 0x96, 0xbd, 0xdc,
+	0x18, 0x96, 0x5c, 0x93, 0xbd, 0xdd, 0x2d, 0x69, 0xfe, 0x3d, 0xff, 0x32, 0x99, 0xdd, 0xd0, 0x24,
+	0xde, 0xbd, 0xed, 0xcc, 0xce, 0xf7, 0xf5, 0xb7, 0xdf, 0xa4, 0x10, 0x19, 0x99, 0x15, 0xa8, 0xe7,
+	0x4a, 0x97, 0xb6, 0x64, 0x01, 0x55, 0x5a, 0xa5, 0xc9, 0x37, 0x80, 0x15, 0xd6, 0x17, 0x65, 0x2a,
+	0x6c, 0xa9, 0xd9, 0x0b, 0x80, 0x1c, 0xeb, 0xcd, 0x1f, 0xb1, 0x95, 0xd7, 0x75, 0x3c, 0x98, 0x0e,
+	0x66, 0xf7, 0xf8, 0x71, 0x8e, 0xf5, 0xb9, 0x6b, 0xb0, 0x67, 0x40, 0xc5, 0x46, 0x16, 0x57, 0xb8,
+	0x8f, 0x8f, 0xdc, 0xed, 0x38, 0xc7, 0x7a, 0x49, 0x75, 0x22, 0x60, 0xb2, 0xc2, 0xfa, 0x2b, 0x9a,
+	0x54, 0x4b, 0x45, 0x66, 0x09, 0x4c, 0xb4, 0xa8, 0x36, 0xa4, 0xb8, 0xac, 0x2d, 0x1a, 0xe7, 0x17,
+	0xf1, 0x50, 0x8b, 0x6a, 0x85, 0xf5, 0x67, 0x6a, 0xb1, 0x37, 0x10, 0xd0, 0xfd, 0x75, 0x99, 0x3a,
+	0xbf, 0x70, 0xf1, 0x70, 0xde, 0x90, 0xcd, 0x5b, 0x2c, 0x3e, 0xca, 0xdd, 0x39, 0x59, 0xc2, 0xf1,
+	0x5a, 0x66, 0xc5, 0xb2, 0x50, 0x3b, 0x4b, 0x30, 0x34, 0xba, 0xb9, 0x42, 0x93, 0x36, 0xd6, 0x63,
+	0x6a, 0x10, 0x01, 0x7b, 0x09, 0xa1, 0xa4, 0xa9, 0x1e, 0x2b, 0xb8, 0x96, 0xa7, 0xfd, 0x0d, 0x01,
+	0x59, 0x71, 0xbc, 0x61, 0x53, 0x88, 0x88, 0xd3, 0xee, 0x7b, 0x98, 0xa0, 0x45, 0xf5, 0x73, 0xef,
+	0x29, 0x5f, 0xc3, 0xc8, 0x49, 0x4d, 0x7c, 0x34, 0x1d, 0xce, 0xc2, 0x05, 0x3b, 0x40, 0x1e, 0x70,
+	0x78, 0x33, 0x91, 0xbc, 0x82, 0xb1, 0x37, 0x36, 0x8a, 0x3d, 0x85, 0x31, 0x39, 0x1b, 0x99, 0x91,
+	0xeb, 0x70, 0x16, 0xf1, 0x40, 0x8b, 0x6a, 0x2d, 0x33, 0x93, 0x9c, 0x43, 0xe8, 0x74, 0x6b, 0x97,
+	0x16, 0x8b, 0x21, 0xa8, 0xa4, 0x2d, 0xd0, 0x1c, 0x06, 0x9b, 0x92, 0x56, 0x62, 0x64, 0xb6, 0xf1,
+	0xa9, 0xba, 0x87, 0x44, 0x9c, 0x1e, 0xee, 0x85, 0xc9, 0x05, 0x9c, 0x74, 0x7c, 0xdc, 0xaf, 0x7e,
+	0x84, 0x89, 0x7f, 0xbb, 0xd7, 0x78, 0xc7, 0x70, 0xf1, 0xe8, 0x00, 0xdd, 0x15, 0x44, 0xb2, 0x2d,
+	0x4c, 0xf2, 0x0b, 0xee, 0x13, 0xfc, 0x77, 0x34, 0x46, 0x64, 0x48, 0xe1, 0x9c, 0xc2, 0x70, 0x6b,
+	0xb2, 0x26, 0x13, 0x3a, 0xb2, 0x77, 0x40, 0x3b, 0xf7, 0xb1, 0xfb, 0x9d, 0x3d, 0xee, 0xee, 0xac,
+	0xfd, 0x00, 0x78, 0x90, 0xfb, 0x32, 0x79, 0x0b, 0x27, 0x3d, 0x5b, 0xa3, 0xd8, 0x73, 0xbf, 0x3d,
+	0x61, 0x77, 0x1a, 0x1b, 0xf7, 0xb6, 0xb1, 0xf8, 0x3b, 0x80, 0xd1, 0xda, 0x7d, 0xaf, 0xec, 0x03,
+	0x4c, 0xe8, 0xf4, 0x63, 0x67, 0x29, 0x65, 0x51, 0xb1, 0xd3, 0x5e, 0xf8, 0x1c, 0x6f, 0xce, 0x1e,
+	0xfc, 0xd7, 0x31, 0x8a, 0x7d, 0x02, 0xf6, 0xa5, 0xdc, 0xaa, 0x9d, 0xc5, 0x6e, 0xca, 0xb7, 0xa5,
+	0xf1, 0x9d, 0xa1, 0x78, 0x87, 0xb0, 0xc3, 0xcc, 0x9e, 0xf4, 0xa4, 0x6d, 0x40, 0x67, 0xf1, 0xdd,
+	0x17, 0x46, 0x5d, 0x8e, 0xdc, 0x5f, 0xed, 0xfd, 0xbf, 0x01, 0x00, 0xe1, 0x90, 0xd2, 0xe5, 0x7a,
+	0x03, 0x00, 0x00,
+}
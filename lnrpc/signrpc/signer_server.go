@@ -0,0 +1,269 @@
+package signrpc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"golang.org/x/net/context"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+var (
+	// macPermissions maps RPC calls to the permissions they require.
+	macPermissions = map[string][]bakery.Op{
+		"/signrpc.Signer/SignOutputRaw": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signrpc.Signer/ComputeInputScript": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signrpc.Signer/SignMessage": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+	}
+
+	// MacaroonOps are the permissions of the signer macaroon, which only
+	// grants access to the Signer service.
+	MacaroonOps = []bakery.Op{
+		{
+			Entity: "signer",
+			Action: "generate",
+		},
+	}
+)
+
+// Config holds the dependencies of the Signer service.
+type Config struct {
+	// Signer is used to sign the inputs of transactions.
+	Signer lnwallet.Signer
+
+	// MessageSigner is used to sign messages with keys that are only
+	// described by their public key.
+	MessageSigner lnwallet.MessageSigner
+
+	// KeyRing is used to derive the private keys of key locators passed to
+	// SignMessage.
+	KeyRing keychain.SecretKeyRing
+
+	// MacService is used to validate the macaroon of each request. If
+	// nil, macaroons are disabled and requests aren't authenticated.
+	MacService *macaroons.Service
+}
+
+// Server is the gRPC Signer service. It exposes the signer of lnd to external
+// tools.
+type Server struct {
+	cfg *Config
+}
+
+// A compile time check to ensure Server fully implements the SignerServer
+// gRPC service.
+var _ SignerServer = (*Server)(nil)
+
+// New creates a new Signer service backed by the passed config.
+func New(cfg *Config) *Server {
+	return &Server{cfg: cfg}
+}
+
+// checkMacaroon validates the macaroon of a request against the permissions
+// of the passed RPC method. The check is skipped if macaroons are disabled.
+func (s *Server) checkMacaroon(ctx context.Context, method string) error {
+	if s.cfg.MacService == nil {
+		return nil
+	}
+
+	ops, ok := macPermissions[method]
+	if !ok {
+		return fmt.Errorf("%s: unknown permissions required for "+
+			"method", method)
+	}
+
+	return s.cfg.MacService.ValidateMacaroon(ctx, ops)
+}
+
+// parseSignReq parses the transaction and the sign descriptors of a sign
+// request. The sighash midstate is computed once for the transaction and
+// shared by all sign descriptors.
+func parseSignReq(req *SignReq) (*wire.MsgTx, []*lnwallet.SignDescriptor,
+	error) {
+
+	if len(req.Inputs) == 0 {
+		return nil, nil, fmt.Errorf("no inputs to sign")
+	}
+
+	tx := wire.NewMsgTx(2)
+	if err := tx.Deserialize(bytes.NewReader(req.RawTxBytes)); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse tx: %v", err)
+	}
+	sigHashes := txscript.NewTxSigHashes(tx)
+
+	signDescs := make([]*lnwallet.SignDescriptor, 0, len(req.Inputs))
+	for _, input := range req.Inputs {
+		signDesc := &lnwallet.SignDescriptor{}
+		err := lnwallet.ReadSignDescriptor(
+			bytes.NewReader(input.SignDesc), signDesc,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse sign "+
+				"descriptor: %v", err)
+		}
+		if signDesc.Output == nil {
+			return nil, nil, fmt.Errorf("sign descriptor is " +
+				"missing the output")
+		}
+
+		if input.InputIndex < 0 ||
+			int(input.InputIndex) >= len(tx.TxIn) {
+
+			return nil, nil, fmt.Errorf("invalid input index %v",
+				input.InputIndex)
+		}
+		signDesc.InputIndex = int(input.InputIndex)
+		signDesc.SigHashes = sigHashes
+
+		signDescs = append(signDescs, signDesc)
+	}
+
+	return tx, signDescs, nil
+}
+
+// SignOutputRaw generates a signature for each of the passed inputs of the
+// transaction as described by their serialized sign descriptors.
+func (s *Server) SignOutputRaw(ctx context.Context,
+	req *SignReq) (*SignResp, error) {
+
+	err := s.checkMacaroon(ctx, "/signrpc.Signer/SignOutputRaw")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, signDescs, err := parseSignReq(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sigs := make([][]byte, 0, len(signDescs))
+	for _, signDesc := range signDescs {
+		sig, err := s.cfg.Signer.SignOutputRaw(tx, signDesc)
+		if err != nil {
+			return nil, fmt.Errorf("unable to sign input %v: %v",
+				signDesc.InputIndex, err)
+		}
+		sigs = append(sigs, sig)
+	}
+
+	return &SignResp{RawSigs: sigs}, nil
+}
+
+// ComputeInputScript generates the complete input script for each of the
+// passed inputs of the transaction, which must spend outputs of the on-chain
+// wallet.
+func (s *Server) ComputeInputScript(ctx context.Context,
+	req *SignReq) (*InputScriptResp, error) {
+
+	err := s.checkMacaroon(ctx, "/signrpc.Signer/ComputeInputScript")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, signDescs, err := parseSignReq(req)
+	if err != nil {
+		return nil, err
+	}
+
+	inputScripts := make([]*InputScript, 0, len(signDescs))
+	for _, signDesc := range signDescs {
+		inputScript, err := s.cfg.Signer.ComputeInputScript(
+			tx, signDesc,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to sign input %v: %v",
+				signDesc.InputIndex, err)
+		}
+
+		// The wallet signer returns a nil input script if the output
+		// doesn't belong to the wallet.
+		if inputScript == nil {
+			return nil, fmt.Errorf("output spent by input %v "+
+				"doesn't belong to the wallet",
+				signDesc.InputIndex)
+		}
+
+		inputScripts = append(inputScripts, &InputScript{
+			Witness:   inputScript.Witness,
+			SigScript: inputScript.ScriptSig,
+		})
+	}
+
+	return &InputScriptResp{InputScripts: inputScripts}, nil
+}
+
+// SignMessage signs the double SHA-256 of the passed message with the key
+// described by the key descriptor.
+func (s *Server) SignMessage(ctx context.Context,
+	req *SignMessageReq) (*SignMessageResp, error) {
+
+	err := s.checkMacaroon(ctx, "/signrpc.Signer/SignMessage")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.KeyDesc == nil {
+		return nil, fmt.Errorf("key descriptor must be set")
+	}
+
+	var keyDesc keychain.KeyDescriptor
+	if req.KeyDesc.KeyLoc != nil {
+		keyDesc.Family = keychain.KeyFamily(req.KeyDesc.KeyLoc.KeyFamily)
+		keyDesc.Index = uint32(req.KeyDesc.KeyLoc.KeyIndex)
+	}
+	if len(req.KeyDesc.RawKeyBytes) != 0 {
+		pubKey, err := btcec.ParsePubKey(
+			req.KeyDesc.RawKeyBytes, btcec.S256(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		keyDesc.PubKey = pubKey
+	}
+
+	var sig *btcec.Signature
+	switch {
+
+	// If the key is only described by its public key, the message signer
+	// looks it up.
+	case keyDesc.KeyLocator.IsEmpty() && keyDesc.PubKey != nil:
+		sig, err = s.cfg.MessageSigner.SignMessage(
+			keyDesc.PubKey, req.Msg,
+		)
+
+	// Otherwise we derive the private key of the key locator and sign
+	// with it directly.
+	case !keyDesc.KeyLocator.IsEmpty():
+		var privKey *btcec.PrivateKey
+		privKey, err = s.cfg.KeyRing.DerivePrivKey(keyDesc)
+		if err != nil {
+			break
+		}
+		sig, err = privKey.Sign(chainhash.DoubleHashB(req.Msg))
+
+	default:
+		return nil, fmt.Errorf("either the public key or the key " +
+			"locator must be set")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign message: %v", err)
+	}
+
+	return &SignMessageResp{Signature: sig.Serialize()}, nil
+}
@@ -0,0 +1,258 @@
+package signrpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// mockSigner records the sign descriptors it is asked to sign with and
+// returns the input index as signature.
+type mockSigner struct {
+	signDescs []*lnwallet.SignDescriptor
+}
+
+func (m *mockSigner) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	m.signDescs = append(m.signDescs, signDesc)
+	return []byte{byte(signDesc.InputIndex)}, nil
+}
+
+func (m *mockSigner) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	// Only the first input spends an output of the wallet.
+	if signDesc.InputIndex != 0 {
+		return nil, nil
+	}
+
+	return &lnwallet.InputScript{Witness: [][]byte{{1}}}, nil
+}
+
+// mockKeyRing derives the same private key for every key locator.
+type mockKeyRing struct {
+	keychain.SecretKeyRing
+
+	privKey *btcec.PrivateKey
+}
+
+func (m *mockKeyRing) DerivePrivKey(
+	keyDesc keychain.KeyDescriptor) (*btcec.PrivateKey, error) {
+
+	return m.privKey, nil
+}
+
+// signReq creates a request to sign the given inputs of a transaction with
+// two inputs.
+func signReq(t *testing.T, inputIndexes ...int32) *SignReq {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 0}, nil, nil))
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0}})
+
+	var rawTx bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+
+	req := &SignReq{RawTxBytes: rawTx.Bytes()}
+	for _, inputIndex := range inputIndexes {
+		var signDesc bytes.Buffer
+		err := lnwallet.WriteSignDescriptor(
+			&signDesc, &lnwallet.SignDescriptor{
+				KeyDesc: keychain.KeyDescriptor{
+					KeyLocator: keychain.KeyLocator{
+						Family: keychain.KeyFamilyHtlcBase,
+						Index:  uint32(inputIndex),
+					},
+				},
+				Output: &wire.TxOut{Value: 2000},
+			},
+		)
+		if err != nil {
+			t.Fatalf("unable to serialize sign descriptor: %v", err)
+		}
+
+		req.Inputs = append(req.Inputs, &SignInput{
+			SignDesc:   signDesc.Bytes(),
+			InputIndex: inputIndex,
+		})
+	}
+
+	return req
+}
+
+// TestSignerServer asserts that sign requests are parsed into sign
+// descriptors for the requested inputs, and that messages can be signed with
+// keys described by their key locator.
+func TestSignerServer(t *testing.T) {
+	t.Parallel()
+
+	privKey, _ := btcec.NewPrivateKey(btcec.S256())
+	signer := &mockSigner{}
+	server := New(&Config{
+		Signer:  signer,
+		KeyRing: &mockKeyRing{privKey: privKey},
+	})
+	ctx := context.Background()
+
+	resp, err := server.SignOutputRaw(ctx, signReq(t, 1, 0))
+	if err != nil {
+		t.Fatalf("unable to sign inputs: %v", err)
+	}
+	if len(resp.RawSigs) != 2 || resp.RawSigs[0][0] != 1 ||
+		resp.RawSigs[1][0] != 0 {
+
+		t.Fatalf("unexpected signatures: %v", resp.RawSigs)
+	}
+	for _, signDesc := range signer.signDescs {
+		if signDesc.SigHashes == nil {
+			t.Fatalf("sighash midstate not computed")
+		}
+		if signDesc.KeyDesc.Index != uint32(signDesc.InputIndex) {
+			t.Fatalf("sign descriptor doesn't match input %v",
+				signDesc.InputIndex)
+		}
+	}
+
+	// Inputs that don't exist in the transaction must be rejected.
+	if _, err := server.SignOutputRaw(ctx, signReq(t, 2)); err == nil {
+		t.Fatalf("expected invalid input index to be rejected")
+	}
+
+	// Computing the input script of an input that doesn't spend an output
+	// of the wallet must fail.
+	scripts, err := server.ComputeInputScript(ctx, signReq(t, 0))
+	if err != nil {
+		t.Fatalf("unable to compute input script: %v", err)
+	}
+	if len(scripts.InputScripts) != 1 {
+		t.Fatalf("expected a single input script")
+	}
+	if _, err := server.ComputeInputScript(ctx, signReq(t, 1)); err == nil {
+		t.Fatalf("expected foreign input to be rejected")
+	}
+
+	// Sign a message with the key of a key locator and verify the
+	// signature against its public key.
+	msg := []byte("message")
+	sigResp, err := server.SignMessage(ctx, &SignMessageReq{
+		Msg: msg,
+		KeyDesc: &KeyDescriptor{
+			KeyLoc: &KeyLocator{
+				KeyFamily: int32(keychain.KeyFamilyNodeKey),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to sign message: %v", err)
+	}
+	sig, err := btcec.ParseDERSignature(sigResp.Signature, btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to parse signature: %v", err)
+	}
+	if !sig.Verify(chainhash.DoubleHashB(msg), privKey.PubKey()) {
+		t.Fatalf("invalid message signature")
+	}
+}
+
+// macaroonContext bakes a macaroon granting the passed permissions and returns
+// an incoming request context that carries it.
+func macaroonContext(t *testing.T, svc *macaroons.Service,
+	ops ...bakery.Op) context.Context {
+
+	mac, err := svc.Oven.NewMacaroon(
+		context.Background(), bakery.LatestVersion, nil, ops...,
+	)
+	if err != nil {
+		t.Fatalf("unable to bake macaroon: %v", err)
+	}
+	macBytes, err := mac.M().MarshalBinary()
+	if err != nil {
+		t.Fatalf("unable to serialize macaroon: %v", err)
+	}
+
+	md := metadata.New(map[string]string{
+		"macaroon": hex.EncodeToString(macBytes),
+	})
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+// TestSignerServerMacaroon asserts that the Signer service only serves
+// requests that carry a macaroon granting the signer permissions.
+func TestSignerServerMacaroon(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "signrpc")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	macService, err := macaroons.NewService(tempDir)
+	if err != nil {
+		t.Fatalf("unable to create macaroon service: %v", err)
+	}
+	defer macService.Close()
+
+	pw := []byte("password")
+	if err := macService.CreateUnlock(&pw); err != nil {
+		t.Fatalf("unable to unlock macaroon service: %v", err)
+	}
+
+	privKey, _ := btcec.NewPrivateKey(btcec.S256())
+	server := New(&Config{
+		Signer:     &mockSigner{},
+		KeyRing:    &mockKeyRing{privKey: privKey},
+		MacService: macService,
+	})
+
+	// A request without any macaroon must be rejected.
+	_, err = server.SignOutputRaw(context.Background(), signReq(t, 0))
+	if err == nil {
+		t.Fatalf("expected request without macaroon to be rejected")
+	}
+
+	// So must a request whose macaroon doesn't grant the signer
+	// permissions.
+	readCtx := macaroonContext(t, macService, bakery.Op{
+		Entity: "onchain",
+		Action: "read",
+	})
+	if _, err := server.SignOutputRaw(readCtx, signReq(t, 0)); err == nil {
+		t.Fatalf("expected request with read-only macaroon to be " +
+			"rejected")
+	}
+	msgReq := &SignMessageReq{
+		Msg:     []byte("msg"),
+		KeyDesc: &KeyDescriptor{KeyLoc: &KeyLocator{KeyFamily: 1}},
+	}
+	if _, err := server.SignMessage(readCtx, msgReq); err == nil {
+		t.Fatalf("expected message signing with read-only macaroon " +
+			"to be rejected")
+	}
+
+	// The signer macaroon grants access to all calls of the service.
+	signerCtx := macaroonContext(t, macService, MacaroonOps...)
+	_, err = server.SignOutputRaw(signerCtx, signReq(t, 0))
+	if err != nil {
+		t.Fatalf("unable to sign with signer macaroon: %v", err)
+	}
+	if _, err := server.SignMessage(signerCtx, msgReq); err != nil {
+		t.Fatalf("unable to sign message with signer macaroon: %v",
+			err)
+	}
+}
@@ -0,0 +1,484 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: walletkit.proto
+
+/*
+Package walletrpc is a generated protocol buffer package.
+
+It is generated from these files:
+	walletkit.proto
+
+It has these top-level messages:
+	KeyReq
+	Transaction
+	PublishResponse
+	TxOut
+	SendOutputsRequest
+	SendOutputsResponse
+	EstimateFeeRequest
+	EstimateFeeResponse
+*/
+package walletrpc
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import signrpc "github.com/lightningnetwork/lnd/lnrpc/signrpc"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type KeyReq struct {
+	// / The key family (BIP43 account) to derive the next key from.
+	KeyFamily int32 `protobuf:"varint,1,opt,name=key_family,json=keyFamily" json:"key_family,omitempty"`
+}
+
+func (m *KeyReq) Reset()                    { *m = KeyReq{} }
+func (m *KeyReq) String() string            { return proto.CompactTextString(m) }
+func (*KeyReq) ProtoMessage()               {}
+func (*KeyReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *KeyReq) GetKeyFamily() int32 {
+	if m != nil {
+		return m.KeyFamily
+	}
+	return 0
+}
+
+type Transaction struct {
+	// / The serialized transaction.
+	TxHex []byte `protobuf:"bytes,1,opt,name=tx_hex,json=txHex,proto3" json:"tx_hex,omitempty"`
+	// / An optional label to attach to the transaction.
+	Label string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+}
+
+func (m *Transaction) Reset()                    { *m = Transaction{} }
+func (m *Transaction) String() string            { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()               {}
+func (*Transaction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Transaction) GetTxHex() []byte {
+	if m != nil {
+		return m.TxHex
+	}
+	return nil
+}
+
+func (m *Transaction) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type PublishResponse struct {
+}
+
+func (m *PublishResponse) Reset()                    { *m = PublishResponse{} }
+func (m *PublishResponse) String() string            { return proto.CompactTextString(m) }
+func (*PublishResponse) ProtoMessage()               {}
+func (*PublishResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type TxOut struct {
+	// / The value of the output in satoshis.
+	Value int64 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	// / The script of the output.
+	PkScript []byte `protobuf:"bytes,2,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+}
+
+func (m *TxOut) Reset()                    { *m = TxOut{} }
+func (m *TxOut) String() string            { return proto.CompactTextString(m) }
+func (*TxOut) ProtoMessage()               {}
+func (*TxOut) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *TxOut) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *TxOut) GetPkScript() []byte {
+	if m != nil {
+		return m.PkScript
+	}
+	return nil
+}
+
+type SendOutputsRequest struct {
+	// / The fee rate in satoshis per kilo weight unit.
+	SatPerKw int64 `protobuf:"varint,1,opt,name=sat_per_kw,json=satPerKw" json:"sat_per_kw,omitempty"`
+	// / The outputs to pay to.
+	Outputs []*TxOut `protobuf:"bytes,2,rep,name=outputs" json:"outputs,omitempty"`
+	// / An optional label to attach to the transaction.
+	Label string `protobuf:"bytes,3,opt,name=label" json:"label,omitempty"`
+}
+
+func (m *SendOutputsRequest) Reset()                    { *m = SendOutputsRequest{} }
+func (m *SendOutputsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendOutputsRequest) ProtoMessage()               {}
+func (*SendOutputsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *SendOutputsRequest) GetSatPerKw() int64 {
+	if m != nil {
+		return m.SatPerKw
+	}
+	return 0
+}
+
+func (m *SendOutputsRequest) GetOutputs() []*TxOut {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *SendOutputsRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type SendOutputsResponse struct {
+	// / The txid of the published transaction.
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+}
+
+func (m *SendOutputsResponse) Reset()                    { *m = SendOutputsResponse{} }
+func (m *SendOutputsResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendOutputsResponse) ProtoMessage()               {}
+func (*SendOutputsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *SendOutputsResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+type EstimateFeeRequest struct {
+	// / The number of blocks the transaction should confirm within.
+	ConfTarget int32 `protobuf:"varint,1,opt,name=conf_target,json=confTarget" json:"conf_target,omitempty"`
+}
+
+func (m *EstimateFeeRequest) Reset()                    { *m = EstimateFeeRequest{} }
+func (m *EstimateFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*EstimateFeeRequest) ProtoMessage()               {}
+func (*EstimateFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *EstimateFeeRequest) GetConfTarget() int32 {
+	if m != nil {
+		return m.ConfTarget
+	}
+	return 0
+}
+
+type EstimateFeeResponse struct {
+	// / The fee rate in satoshis per kilo weight unit.
+	SatPerKw int64 `protobuf:"varint,1,opt,name=sat_per_kw,json=satPerKw" json:"sat_per_kw,omitempty"`
+}
+
+func (m *EstimateFeeResponse) Reset()                    { *m = EstimateFeeResponse{} }
+func (m *EstimateFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*EstimateFeeResponse) ProtoMessage()               {}
+func (*EstimateFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *EstimateFeeResponse) GetSatPerKw() int64 {
+	if m != nil {
+		return m.SatPerKw
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*KeyReq)(nil), "walletrpc.KeyReq")
+	proto.RegisterType((*Transaction)(nil), "walletrpc.Transaction")
+	proto.RegisterType((*PublishResponse)(nil), "walletrpc.PublishResponse")
+	proto.RegisterType((*TxOut)(nil), "walletrpc.TxOut")
+	proto.RegisterType((*SendOutputsRequest)(nil), "walletrpc.SendOutputsRequest")
+	proto.RegisterType((*SendOutputsResponse)(nil), "walletrpc.SendOutputsResponse")
+	proto.RegisterType((*EstimateFeeRequest)(nil), "walletrpc.EstimateFeeRequest")
+	proto.RegisterType((*EstimateFeeResponse)(nil), "walletrpc.EstimateFeeResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for WalletKit service
+
+type WalletKitClient interface {
+	// *
+	// DeriveNextKey derives the next key within the given key family and
+	// returns its key descriptor.
+	DeriveNextKey(ctx context.Context, in *KeyReq, opts ...grpc.CallOption) (*signrpc.KeyDescriptor, error)
+	// *
+	// DeriveKey derives the key described by the given key locator and returns
+	// its key descriptor.
+	DeriveKey(ctx context.Context, in *signrpc.KeyLocator, opts ...grpc.CallOption) (*signrpc.KeyDescriptor, error)
+	// *
+	// PublishTransaction broadcasts the passed fully signed transaction to the
+	// network.
+	PublishTransaction(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*PublishResponse, error)
+	// *
+	// SendOutputs funds, signs and broadcasts a transaction paying to the
+	// passed outputs, which may use arbitrary scripts, at the given fee rate.
+	SendOutputs(ctx context.Context, in *SendOutputsRequest, opts ...grpc.CallOption) (*SendOutputsResponse, error)
+	// *
+	// EstimateFee returns the fee rate that is expected to get a transaction
+	// confirmed within the given number of blocks.
+	EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error)
+}
+
+type walletKitClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWalletKitClient(cc *grpc.ClientConn) WalletKitClient {
+	return &walletKitClient{cc}
+}
+
+func (c *walletKitClient) DeriveNextKey(ctx context.Context, in *KeyReq, opts ...grpc.CallOption) (*signrpc.KeyDescriptor, error) {
+	out := new(signrpc.KeyDescriptor)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletKit/DeriveNextKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) DeriveKey(ctx context.Context, in *signrpc.KeyLocator, opts ...grpc.CallOption) (*signrpc.KeyDescriptor, error) {
+	out := new(signrpc.KeyDescriptor)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletKit/DeriveKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) PublishTransaction(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*PublishResponse, error) {
+	out := new(PublishResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletKit/PublishTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) SendOutputs(ctx context.Context, in *SendOutputsRequest, opts ...grpc.CallOption) (*SendOutputsResponse, error) {
+	out := new(SendOutputsResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletKit/SendOutputs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error) {
+	out := new(EstimateFeeResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletKit/EstimateFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for WalletKit service
+
+type WalletKitServer interface {
+	// *
+	// DeriveNextKey derives the next key within the given key family and
+	// returns its key descriptor.
+	DeriveNextKey(context.Context, *KeyReq) (*signrpc.KeyDescriptor, error)
+	// *
+	// DeriveKey derives the key described by the given key locator and returns
+	// its key descriptor.
+	DeriveKey(context.Context, *signrpc.KeyLocator) (*signrpc.KeyDescriptor, error)
+	// *
+	// PublishTransaction broadcasts the passed fully signed transaction to the
+	// network.
+	PublishTransaction(context.Context, *Transaction) (*PublishResponse, error)
+	// *
+	// SendOutputs funds, signs and broadcasts a transaction paying to the
+	// passed outputs, which may use arbitrary scripts, at the given fee rate.
+	SendOutputs(context.Context, *SendOutputsRequest) (*SendOutputsResponse, error)
+	// *
+	// EstimateFee returns the fee rate that is expected to get a transaction
+	// confirmed within the given number of blocks.
+	EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error)
+}
+
+func RegisterWalletKitServer(s *grpc.Server, srv WalletKitServer) {
+	s.RegisterService(&_WalletKit_serviceDesc, srv)
+}
+
+func _WalletKit_DeriveNextKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).DeriveNextKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/DeriveNextKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).DeriveNextKey(ctx, req.(*KeyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_DeriveKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(signrpc.KeyLocator)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).DeriveKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/DeriveKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).DeriveKey(ctx, req.(*signrpc.KeyLocator))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_PublishTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Transaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).PublishTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/PublishTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).PublishTransaction(ctx, req.(*Transaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_SendOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendOutputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).SendOutputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/SendOutputs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).SendOutputs(ctx, req.(*SendOutputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_EstimateFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).EstimateFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/EstimateFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).EstimateFee(ctx, req.(*EstimateFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WalletKit_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "walletrpc.WalletKit",
+	HandlerType: (*WalletKitServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeriveNextKey",
+			Handler:    _WalletKit_DeriveNextKey_Handler,
+		},
+		{
+			MethodName: "DeriveKey",
+			Handler:    _WalletKit_DeriveKey_Handler,
+		},
+		{
+			MethodName: "PublishTransaction",
+			Handler:    _WalletKit_PublishTransaction_Handler,
+		},
+		{
+			MethodName: "SendOutputs",
+			Handler:    _WalletKit_SendOutputs_Handler,
+		},
+		{
+			MethodName: "EstimateFee",
+			Handler:    _WalletKit_EstimateFee_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "walletkit.proto",
+}
+
+func init() { proto.RegisterFile("walletkit.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 450 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0x51, 0x4f, 0xdb, 0x30,
+	0x10, 0x56, 0xdb, 0xb5, 0x23, 0x57, 0x26, 0x86, 0xcb, 0x50, 0x95, 0x0d, 0x56, 0xe5, 0x65, 0xdd,
+	0x1e, 0x3a, 0x09, 0x34, 0x69, 0xea, 0x33, 0x43, 0x48, 0x45, 0x03, 0x99, 0x4a, 0x7b, 0x8c, 0xdc,
+	0x70, 0x80, 0x95, 0x90, 0x04, 0xfb, 0x42, 0x93, 0x5f, 0xbc, 0xbf, 0x31, 0xc5, 0x0e, 0xab, 0xbb,
+	0xaa, 0x7b, 0x6a, 0xfd, 0xf9, 0xbb, 0xef, 0xfc, 0x7d, 0x77, 0x81, 0xbd, 0xa5, 0x48, 0x12, 0xa4,
+	0x58, 0xd2, 0x24, 0x57, 0x19, 0x65, 0xcc, 0xb3, 0x80, 0xca, 0x23, 0xff, 0x40, 0xcb, 0xfb, 0x54,
+	0xe5, 0xd1, 0xd7, 0xfa, 0x17, 0x95, 0x25, 0x04, 0x9f, 0xa0, 0x37, 0xc3, 0x8a, 0xe3, 0x13, 0x3b,
+	0x02, 0x88, 0xb1, 0x0a, 0xef, 0xc4, 0xa3, 0x4c, 0xaa, 0x61, 0x6b, 0xd4, 0x1a, 0x77, 0xb9, 0x17,
+	0x63, 0x75, 0x6e, 0x80, 0x60, 0x0a, 0xfd, 0xb9, 0x12, 0xa9, 0x16, 0x11, 0xc9, 0x2c, 0x65, 0xef,
+	0xa0, 0x47, 0x65, 0xf8, 0x80, 0xa5, 0x61, 0xee, 0xf2, 0x2e, 0x95, 0x17, 0x58, 0xb2, 0x03, 0xe8,
+	0x26, 0x62, 0x81, 0xc9, 0xb0, 0x3d, 0x6a, 0x8d, 0x3d, 0x6e, 0x0f, 0xc1, 0x3e, 0xec, 0x5d, 0x17,
+	0x8b, 0x44, 0xea, 0x07, 0x8e, 0x3a, 0xcf, 0x52, 0x8d, 0xc1, 0x14, 0xba, 0xf3, 0xf2, 0xaa, 0xa0,
+	0xba, 0xe2, 0x59, 0x24, 0x05, 0x1a, 0x9d, 0x0e, 0xb7, 0x07, 0xf6, 0x1e, 0xbc, 0x3c, 0x0e, 0x75,
+	0xa4, 0x64, 0x4e, 0x46, 0x6b, 0x97, 0xef, 0xe4, 0xf1, 0x8d, 0x39, 0x07, 0x04, 0xec, 0x06, 0xd3,
+	0xdb, 0xab, 0x82, 0xf2, 0x82, 0x34, 0xc7, 0xa7, 0x02, 0x35, 0xb1, 0x0f, 0x00, 0x5a, 0x50, 0x98,
+	0xa3, 0x0a, 0xe3, 0x65, 0xa3, 0xb6, 0xa3, 0x05, 0x5d, 0xa3, 0x9a, 0x2d, 0xd9, 0x17, 0x78, 0x9d,
+	0x59, 0xfe, 0xb0, 0x3d, 0xea, 0x8c, 0xfb, 0x27, 0x6f, 0x27, 0x7f, 0xa3, 0x99, 0x98, 0x97, 0xf0,
+	0x17, 0xc2, 0xca, 0x44, 0xc7, 0x35, 0xf1, 0x19, 0x06, 0x6b, 0x5d, 0xad, 0x11, 0xc6, 0xe0, 0x15,
+	0x95, 0xf2, 0xd6, 0x34, 0xf4, 0xb8, 0xf9, 0x1f, 0x7c, 0x03, 0xf6, 0x43, 0x93, 0x7c, 0x14, 0x84,
+	0xe7, 0x88, 0x2f, 0x0f, 0xfc, 0x08, 0xfd, 0x28, 0x4b, 0xef, 0x42, 0x12, 0xea, 0x1e, 0xa9, 0x49,
+	0x18, 0x6a, 0x68, 0x6e, 0x90, 0xe0, 0x14, 0x06, 0x6b, 0x65, 0x4d, 0x87, 0xff, 0x1a, 0x3b, 0xf9,
+	0xdd, 0x06, 0xef, 0x97, 0x71, 0x32, 0x93, 0xc4, 0xa6, 0xf0, 0xe6, 0x0c, 0x95, 0x7c, 0xc6, 0x9f,
+	0x58, 0xd2, 0x0c, 0x2b, 0xb6, 0xef, 0xd8, 0xb4, 0x83, 0xf6, 0x0f, 0x27, 0xcd, 0x26, 0xd4, 0xc0,
+	0x19, 0xda, 0x90, 0x33, 0xc5, 0xbe, 0x83, 0x67, 0x6b, 0xeb, 0xba, 0x81, 0x4b, 0xba, 0xcc, 0x22,
+	0x41, 0x99, 0xda, 0x5a, 0x79, 0x01, 0xac, 0x99, 0xaf, 0xbb, 0x22, 0x87, 0x6e, 0xc2, 0x2b, 0xdc,
+	0xf7, 0x1d, 0xfc, 0x9f, 0xb5, 0x60, 0x97, 0xd0, 0x77, 0x42, 0x66, 0x47, 0x0e, 0x75, 0x73, 0xe4,
+	0xfe, 0xf1, 0xb6, 0xeb, 0x95, 0x9a, 0x13, 0xe8, 0x9a, 0xda, 0xe6, 0x7c, 0xfc, 0xe3, 0x6d, 0xd7,
+	0x56, 0x6d, 0xd1, 0x33, 0x5f, 0xcc, 0xe9, 0x9f, 0x01, 0x00, 0xbb, 0x32, 0xb4, 0x69, 0x65, 0x03,
+	0x00, 0x00,
+}
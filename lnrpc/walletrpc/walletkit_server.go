@@ -0,0 +1,288 @@
+package walletrpc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"golang.org/x/net/context"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+var (
+	// macPermissions maps RPC calls to the permissions they require.
+	macPermissions = map[string][]bakery.Op{
+		"/walletrpc.WalletKit/DeriveNextKey": {{
+			Entity: "address",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/DeriveKey": {{
+			Entity: "address",
+			Action: "read",
+		}},
+		"/walletrpc.WalletKit/PublishTransaction": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/SendOutputs": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/EstimateFee": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+	}
+)
+
+// Wallet is the part of the wallet used by the WalletKit service.
+type Wallet interface {
+	// PublishTransaction broadcasts the passed transaction.
+	PublishTransaction(tx *wire.MsgTx) error
+
+	// SendOutputs funds, signs and broadcasts a transaction paying to the
+	// passed outputs.
+	SendOutputs(outputs []*wire.TxOut,
+		feeRate lnwallet.SatPerKWeight) (*chainhash.Hash, error)
+
+	// LabelTransaction assigns a label to the transaction with the given
+	// hash.
+	LabelTransaction(txid chainhash.Hash, label string,
+		overwrite bool) error
+}
+
+// Config holds the dependencies of the WalletKit service.
+type Config struct {
+	// KeyRing is used to derive keys.
+	KeyRing keychain.KeyRing
+
+	// Wallet is used to publish and fund transactions.
+	Wallet Wallet
+
+	// FeeEstimator is used to estimate fee rates by confirmation target.
+	FeeEstimator lnwallet.FeeEstimator
+
+	// MacService is used to validate the macaroon of each request. If
+	// nil, macaroons are disabled and requests aren't authenticated.
+	MacService *macaroons.Service
+}
+
+// WalletKit is the gRPC WalletKit service.
+type WalletKit struct {
+	cfg *Config
+}
+
+// A compile time check to ensure WalletKit fully implements the
+// WalletKitServer gRPC service.
+var _ WalletKitServer = (*WalletKit)(nil)
+
+// New creates a new WalletKit service backed by the passed config.
+func New(cfg *Config) *WalletKit {
+	return &WalletKit{cfg: cfg}
+}
+
+// checkMacaroon validates the macaroon of a request against the permissions
+// of the passed RPC method. The check is skipped if macaroons are disabled.
+func (w *WalletKit) checkMacaroon(ctx context.Context, method string) error {
+	if w.cfg.MacService == nil {
+		return nil
+	}
+
+	ops, ok := macPermissions[method]
+	if !ok {
+		return fmt.Errorf("%s: unknown permissions required for "+
+			"method", method)
+	}
+
+	return w.cfg.MacService.ValidateMacaroon(ctx, ops)
+}
+
+// marshalKeyDesc converts a key descriptor into its RPC representation.
+func marshalKeyDesc(keyDesc keychain.KeyDescriptor) *signrpc.KeyDescriptor {
+	rpcDesc := &signrpc.KeyDescriptor{
+		KeyLoc: &signrpc.KeyLocator{
+			KeyFamily: int32(keyDesc.Family),
+			KeyIndex:  int32(keyDesc.Index),
+		},
+	}
+	if keyDesc.PubKey != nil {
+		rpcDesc.RawKeyBytes = keyDesc.PubKey.SerializeCompressed()
+	}
+
+	return rpcDesc
+}
+
+// DeriveNextKey derives the next key within the given key family.
+func (w *WalletKit) DeriveNextKey(ctx context.Context,
+	req *KeyReq) (*signrpc.KeyDescriptor, error) {
+
+	err := w.checkMacaroon(ctx, "/walletrpc.WalletKit/DeriveNextKey")
+	if err != nil {
+		return nil, err
+	}
+
+	keyDesc, err := w.cfg.KeyRing.DeriveNextKey(
+		keychain.KeyFamily(req.KeyFamily),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalKeyDesc(keyDesc), nil
+}
+
+// DeriveKey derives the key described by the given key locator.
+func (w *WalletKit) DeriveKey(ctx context.Context,
+	req *signrpc.KeyLocator) (*signrpc.KeyDescriptor, error) {
+
+	err := w.checkMacaroon(ctx, "/walletrpc.WalletKit/DeriveKey")
+	if err != nil {
+		return nil, err
+	}
+
+	keyDesc, err := w.cfg.KeyRing.DeriveKey(keychain.KeyLocator{
+		Family: keychain.KeyFamily(req.KeyFamily),
+		Index:  uint32(req.KeyIndex),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalKeyDesc(keyDesc), nil
+}
+
+// validateLabel returns an error if the passed label is too long to be
+// stored.
+func validateLabel(label string) error {
+	if len(label) > channeldb.MaxTxLabelLength {
+		return fmt.Errorf("label length %v exceeds maximum of %v",
+			len(label), channeldb.MaxTxLabelLength)
+	}
+
+	return nil
+}
+
+// labelTx labels a transaction that has already been published with the
+// label passed by the caller, if any.
+func (w *WalletKit) labelTx(txid chainhash.Hash, label string) error {
+	if label == "" {
+		return nil
+	}
+
+	err := w.cfg.Wallet.LabelTransaction(txid, label, true)
+	if err != nil {
+		return fmt.Errorf("transaction %v was published, but could "+
+			"not be labelled: %v", txid, err)
+	}
+
+	return nil
+}
+
+// PublishTransaction broadcasts the passed fully signed transaction.
+func (w *WalletKit) PublishTransaction(ctx context.Context,
+	req *Transaction) (*PublishResponse, error) {
+
+	err := w.checkMacaroon(ctx, "/walletrpc.WalletKit/PublishTransaction")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateLabel(req.Label); err != nil {
+		return nil, err
+	}
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(req.TxHex)); err != nil {
+		return nil, fmt.Errorf("unable to parse tx: %v", err)
+	}
+
+	if err := w.cfg.Wallet.PublishTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	if err := w.labelTx(tx.TxHash(), req.Label); err != nil {
+		return nil, err
+	}
+
+	return &PublishResponse{}, nil
+}
+
+// SendOutputs funds, signs and broadcasts a transaction paying to the passed
+// outputs at the given fee rate.
+func (w *WalletKit) SendOutputs(ctx context.Context,
+	req *SendOutputsRequest) (*SendOutputsResponse, error) {
+
+	err := w.checkMacaroon(ctx, "/walletrpc.WalletKit/SendOutputs")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateLabel(req.Label); err != nil {
+		return nil, err
+	}
+
+	if len(req.Outputs) == 0 {
+		return nil, fmt.Errorf("at least one output must be specified")
+	}
+
+	feeRate := lnwallet.SatPerKWeight(req.SatPerKw)
+	if feeRate < lnwallet.FeePerKwFloor {
+		return nil, fmt.Errorf("fee rate of %v sat/kw is below the "+
+			"minimum of %v sat/kw", feeRate, lnwallet.FeePerKwFloor)
+	}
+
+	outputs := make([]*wire.TxOut, 0, len(req.Outputs))
+	for _, output := range req.Outputs {
+		if output.Value <= 0 || len(output.PkScript) == 0 {
+			return nil, fmt.Errorf("outputs must have a positive " +
+				"value and a script")
+		}
+
+		outputs = append(outputs, &wire.TxOut{
+			Value:    output.Value,
+			PkScript: output.PkScript,
+		})
+	}
+
+	txid, err := w.cfg.Wallet.SendOutputs(outputs, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.labelTx(*txid, req.Label); err != nil {
+		return nil, err
+	}
+
+	return &SendOutputsResponse{Txid: txid.String()}, nil
+}
+
+// EstimateFee returns the fee rate that is expected to get a transaction
+// confirmed within the given number of blocks.
+func (w *WalletKit) EstimateFee(ctx context.Context,
+	req *EstimateFeeRequest) (*EstimateFeeResponse, error) {
+
+	err := w.checkMacaroon(ctx, "/walletrpc.WalletKit/EstimateFee")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ConfTarget < 1 {
+		return nil, fmt.Errorf("confirmation target must be at " +
+			"least 1")
+	}
+
+	feeRate, err := w.cfg.FeeEstimator.EstimateFeePerKW(
+		uint32(req.ConfTarget),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EstimateFeeResponse{SatPerKw: int64(feeRate)}, nil
+}
@@ -0,0 +1,121 @@
+package walletrpc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"golang.org/x/net/context"
+)
+
+// mockWallet records the transactions it publishes and the labels it stores.
+type mockWallet struct {
+	published []*wire.MsgTx
+	sent      [][]*wire.TxOut
+	labels    map[chainhash.Hash]string
+}
+
+func (m *mockWallet) PublishTransaction(tx *wire.MsgTx) error {
+	m.published = append(m.published, tx)
+	return nil
+}
+
+func (m *mockWallet) SendOutputs(outputs []*wire.TxOut,
+	feeRate lnwallet.SatPerKWeight) (*chainhash.Hash, error) {
+
+	m.sent = append(m.sent, outputs)
+	return &chainhash.Hash{byte(len(m.sent))}, nil
+}
+
+func (m *mockWallet) LabelTransaction(txid chainhash.Hash, label string,
+	overwrite bool) error {
+
+	m.labels[txid] = label
+	return nil
+}
+
+// TestWalletKitSend asserts that raw transactions are published and outputs
+// with arbitrary scripts are sent, labelling them if requested, and that
+// invalid requests are rejected.
+func TestWalletKitSend(t *testing.T) {
+	t.Parallel()
+
+	wallet := &mockWallet{labels: make(map[chainhash.Hash]string)}
+	walletKit := New(&Config{
+		Wallet:       wallet,
+		FeeEstimator: lnwallet.StaticFeeEstimator{FeePerKW: 5000},
+	})
+	ctx := context.Background()
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0x51}})
+	var rawTx bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+
+	_, err := walletKit.PublishTransaction(ctx, &Transaction{
+		TxHex: rawTx.Bytes(),
+		Label: "swap",
+	})
+	if err != nil {
+		t.Fatalf("unable to publish tx: %v", err)
+	}
+	if len(wallet.published) != 1 ||
+		wallet.published[0].TxHash() != tx.TxHash() {
+
+		t.Fatalf("tx not published")
+	}
+	if wallet.labels[tx.TxHash()] != "swap" {
+		t.Fatalf("published tx not labelled")
+	}
+
+	// Oversized labels are rejected before anything is published.
+	_, err = walletKit.PublishTransaction(ctx, &Transaction{
+		TxHex: rawTx.Bytes(),
+		Label: strings.Repeat("a", channeldb.MaxTxLabelLength+1),
+	})
+	if err == nil || len(wallet.published) != 1 {
+		t.Fatalf("expected oversized label to be rejected")
+	}
+
+	// Outputs with arbitrary scripts can be sent, as long as the fee rate
+	// isn't below the floor.
+	outputs := []*TxOut{{Value: 5000, PkScript: []byte{0x51}}}
+	_, err = walletKit.SendOutputs(ctx, &SendOutputsRequest{
+		SatPerKw: int64(lnwallet.FeePerKwFloor) - 1,
+		Outputs:  outputs,
+	})
+	if err == nil {
+		t.Fatalf("expected fee rate below floor to be rejected")
+	}
+	resp, err := walletKit.SendOutputs(ctx, &SendOutputsRequest{
+		SatPerKw: int64(lnwallet.FeePerKwFloor),
+		Outputs:  outputs,
+	})
+	if err != nil {
+		t.Fatalf("unable to send outputs: %v", err)
+	}
+	if len(wallet.sent) != 1 || wallet.sent[0][0].Value != 5000 {
+		t.Fatalf("outputs not sent")
+	}
+	if resp.Txid != (chainhash.Hash{1}).String() {
+		t.Fatalf("unexpected txid %v", resp.Txid)
+	}
+
+	feeResp, err := walletKit.EstimateFee(ctx, &EstimateFeeRequest{
+		ConfTarget: 6,
+	})
+	if err != nil {
+		t.Fatalf("unable to estimate fee: %v", err)
+	}
+	if feeResp.SatPerKw != 5000 {
+		t.Fatalf("expected fee rate of 5000 sat/kw, got %v",
+			feeResp.SatPerKw)
+	}
+}
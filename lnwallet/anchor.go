@@ -0,0 +1,506 @@
+package lnwallet
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// anchorSweepSequence is the sequence number used for all inputs of an anchor
+// sweep. It opts in to replace-by-fee, so the sweep can be replaced with one
+// paying a higher fee as the deadline of the commitment approaches.
+const anchorSweepSequence = wire.MaxTxInSequenceNum - 2
+
+// anchorSweepLeaseDuration is the duration of the leases of the wallet coins
+// spent by an anchor sweep. Each replacement of the sweep extends the leases,
+// so they only expire if the sweep is abandoned without being canceled.
+const anchorSweepLeaseDuration = 14 * 24 * time.Hour
+
+// anchorSweepLockID is the lock ID the wallet coins spent by anchor sweeps
+// are leased under. As the leases are persisted, the coins stay locked across
+// restarts while the sweep is waiting to confirm.
+var anchorSweepLockID = [32]byte(chainhash.HashH([]byte("anchorsweep")))
+
+// AnchorResolution houses the information required to spend our anchor output
+// on a commitment transaction, in order to bump the fee of the commitment
+// using CPFP.
+type AnchorResolution struct {
+	// CommitAnchor is the outpoint of our anchor output on the commitment
+	// transaction.
+	CommitAnchor wire.OutPoint
+
+	// AnchorSignDesc is a fully populated sign descriptor capable of
+	// spending our anchor output using our funding key.
+	AnchorSignDesc SignDescriptor
+
+	// CommitWeight is the weight of the fully signed commitment
+	// transaction.
+	CommitWeight int64
+
+	// CommitFee is the absolute fee paid by the commitment transaction.
+	CommitFee btcutil.Amount
+
+	// RemoteAnchor is the outpoint of the anchor output of the remote
+	// party on the commitment transaction, if it has one. Once the
+	// commitment has AnchorCSVDelay confirmations, it can be spent by
+	// anyone, so we'll sweep it if the remote party doesn't.
+	RemoteAnchor *wire.OutPoint
+
+	// RemoteAnchorSignDesc is a sign descriptor carrying the witness
+	// script and output of the remote anchor. As no signature is required
+	// to spend it after the CSV delay, it doesn't specify a key.
+	RemoteAnchorSignDesc SignDescriptor
+}
+
+// CommitFeeRate returns the effective fee rate of the commitment transaction
+// the anchor output is attached to.
+func (a *AnchorResolution) CommitFeeRate() SatPerKWeight {
+	return SatPerKWeight(int64(a.CommitFee) * 1000 / a.CommitWeight)
+}
+
+// NewAnchorResolution returns the information required to sweep our anchor
+// output on the passed commitment transaction. If the channel doesn't use
+// anchor outputs, or our anchor can't be found within the transaction, then
+// nil is returned. The commitment may either be fully signed, or be one of
+// the unsigned commitments stored within the channel state, in which case the
+// weight of the witness spending the funding output is estimated.
+func NewAnchorResolution(chanState *channeldb.OpenChannel,
+	commitTx *wire.MsgTx) (*AnchorResolution, error) {
+
+	if !chanState.ChanType.HasAnchors() {
+		return nil, nil
+	}
+
+	// Our anchor output is spendable by our funding key, so we'll
+	// re-create its script in order to locate it within the commitment.
+	localFundingKey := chanState.LocalChanCfg.MultiSigKey
+	anchorScript, err := CommitScriptAnchor(localFundingKey.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	anchorScriptHash, err := WitnessScriptHash(anchorScript)
+	if err != nil {
+		return nil, err
+	}
+
+	// The anchor of the remote party is encumbered by their funding key.
+	remoteAnchorScript, err := CommitScriptAnchor(
+		chanState.RemoteChanCfg.MultiSigKey.PubKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	remoteAnchorScriptHash, err := WitnessScriptHash(remoteAnchorScript)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		anchorIndex       = -1
+		remoteAnchorIndex = -1
+		totalOutput       btcutil.Amount
+	)
+	for i, txOut := range commitTx.TxOut {
+		totalOutput += btcutil.Amount(txOut.Value)
+
+		switch {
+		case bytes.Equal(txOut.PkScript, anchorScriptHash):
+			anchorIndex = i
+
+		case bytes.Equal(txOut.PkScript, remoteAnchorScriptHash):
+			remoteAnchorIndex = i
+		}
+	}
+	if anchorIndex < 0 {
+		return nil, nil
+	}
+
+	// The commitment spends the funding output, so its fee is the
+	// difference between the capacity of the channel and the total value
+	// of all outputs.
+	commitFee := chanState.Capacity - totalOutput
+	commitWeight := blockchain.GetTransactionWeight(btcutil.NewTx(commitTx))
+	if len(commitTx.TxIn[0].Witness) == 0 {
+		commitWeight += WitnessCommitmentTxWeight
+	}
+
+	commitHash := commitTx.TxHash()
+	resolution := &AnchorResolution{
+		CommitAnchor: wire.OutPoint{
+			Hash:  commitHash,
+			Index: uint32(anchorIndex),
+		},
+		AnchorSignDesc: SignDescriptor{
+			KeyDesc:       localFundingKey,
+			WitnessScript: anchorScript,
+			Output: &wire.TxOut{
+				PkScript: anchorScriptHash,
+				Value:    int64(AnchorSize),
+			},
+			HashType: txscript.SigHashAll,
+		},
+		CommitWeight: commitWeight,
+		CommitFee:    commitFee,
+	}
+
+	if remoteAnchorIndex >= 0 {
+		resolution.RemoteAnchor = &wire.OutPoint{
+			Hash:  commitHash,
+			Index: uint32(remoteAnchorIndex),
+		}
+		resolution.RemoteAnchorSignDesc = SignDescriptor{
+			WitnessScript: remoteAnchorScript,
+			Output: &wire.TxOut{
+				PkScript: remoteAnchorScriptHash,
+				Value:    int64(AnchorSize),
+			},
+			HashType: txscript.SigHashAll,
+		}
+	}
+
+	return resolution, nil
+}
+
+// AnchorResolutions houses the anchor resolutions of all commitment
+// transactions of a channel that may currently be broadcast.
+type AnchorResolutions struct {
+	// Local is the resolution for our anchor on our latest commitment.
+	Local *AnchorResolution
+
+	// Remote is the resolution for our anchor on the latest commitment of
+	// the remote party.
+	Remote *AnchorResolution
+
+	// RemotePending is the resolution for our anchor on the pending
+	// commitment of the remote party, if they haven't yet revoked their
+	// prior state.
+	RemotePending *AnchorResolution
+}
+
+// NewAnchorResolutions returns the anchor resolutions for all commitment
+// transactions of the channel that are still valid, such that the fee of
+// whichever of them made it into the mempool can be bumped.
+func NewAnchorResolutions(
+	chanState *channeldb.OpenChannel) (*AnchorResolutions, error) {
+
+	if !chanState.ChanType.HasAnchors() {
+		return &AnchorResolutions{}, nil
+	}
+
+	var (
+		resolutions AnchorResolutions
+		err         error
+	)
+	resolutions.Local, err = NewAnchorResolution(
+		chanState, chanState.LocalCommitment.CommitTx,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resolutions.Remote, err = NewAnchorResolution(
+		chanState, chanState.RemoteCommitment.CommitTx,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	remotePending, err := chanState.RemoteCommitChainTip()
+	switch {
+	case err == channeldb.ErrNoPendingCommit:
+		return &resolutions, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	resolutions.RemotePending, err = NewAnchorResolution(
+		chanState, remotePending.Commitment.CommitTx,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resolutions, nil
+}
+
+// AnchorSweep is a signed transaction spending an anchor output along with
+// coins of the wallet in order to bump the fee of a commitment transaction.
+type AnchorSweep struct {
+	// Anchor is the resolution of the anchor output spent by the sweep.
+	Anchor *AnchorResolution
+
+	// Tx is the fully signed sweep transaction.
+	Tx *wire.MsgTx
+
+	// Coins are the wallet coins spent by the sweep. They're leased until
+	// released using CancelAnchorSweep.
+	Coins []*Utxo
+
+	// Fee is the absolute fee paid by the sweep.
+	Fee btcutil.Amount
+
+	// FeeRate is the fee rate the sweep bumps the package to.
+	FeeRate SatPerKWeight
+}
+
+// CreateAnchorSweep creates a fully signed transaction that spends our anchor
+// output described by the passed resolution, along with enough coins from the
+// wallet to bump the effective fee rate of the commitment transaction and the
+// sweep itself (the package) to the passed fee rate. The funds left over are
+// sent back to a change address of the wallet. The selected coins are leased,
+// such that they won't be used by any concurrent funding flows, even after a
+// restart. It's up to
+// the caller to broadcast the returned transaction, and to release the coins
+// using CancelAnchorSweep if it can't be broadcast.
+//
+// If replaces is non-nil, then the sweep is created to replace the given,
+// already broadcast sweep of the same anchor. It'll then re-use the coins of
+// the prior sweep, and pay enough fees to be accepted as a replacement.
+func (l *LightningWallet) CreateAnchorSweep(anchor *AnchorResolution,
+	feeRate SatPerKWeight, replaces *AnchorSweep) (*AnchorSweep, error) {
+
+	// We hold the coin select mutex while querying for outputs, and
+	// performing coin selection in order to avoid inadvertent double
+	// spends across funding transactions.
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	walletLog.Infof("Creating anchor sweep for commitment %v using %v "+
+		"sat/kw as package fee rate", anchor.CommitAnchor.Hash,
+		int64(feeRate))
+
+	coins, err := l.ListUnspentWitness(1)
+	if err != nil {
+		return nil, err
+	}
+
+	// The coins of a sweep we're replacing are already leased by us, and
+	// are spent by the sweep in the mempool, so they won't show up as
+	// unspent. We'll select them before any others, as the replacement
+	// must conflict with all of them anyway.
+	var replacedFee btcutil.Amount
+	if replaces != nil {
+		coins = append(
+			append([]*Utxo{}, replaces.Coins...), coins...,
+		)
+		replacedFee = replaces.Fee
+	}
+
+	// We'll add coins one by one until they're sufficient to pay for the
+	// fee of the package, while leaving a non-dust change output.
+	var (
+		selectedCoins []*Utxo
+		totalInput    = AnchorSize
+		changeAmt     btcutil.Amount
+		fee           btcutil.Amount
+	)
+	for _, coin := range coins {
+		switch coin.AddressType {
+		case WitnessPubKey, NestedWitnessPubKey:
+		default:
+			continue
+		}
+
+		selectedCoins = append(selectedCoins, coin)
+		totalInput += coin.Value
+
+		// The sweep needs to pay for the weight of the entire package,
+		// minus the fee already paid by the commitment. It'll always
+		// need to pay for its own weight though. A replacement must
+		// additionally pay more than the sweep it replaces, plus the
+		// relay fee of its own weight.
+		sweepWeight := anchorSweepWeight(selectedCoins)
+		requiredFee := feeRate.FeeForWeight(
+			anchor.CommitWeight+sweepWeight,
+		) - anchor.CommitFee
+		if minFee := feeRate.FeeForWeight(sweepWeight); requiredFee < minFee {
+			requiredFee = minFee
+		}
+		if replaces != nil {
+			minFee := replacedFee + FeePerKwFloor.FeeForWeight(
+				sweepWeight,
+			)
+			if requiredFee < minFee {
+				requiredFee = minFee
+			}
+		}
+
+		if totalInput-requiredFee >= DefaultDustLimit() {
+			changeAmt = totalInput - requiredFee
+			fee = requiredFee
+			break
+		}
+	}
+	if changeAmt == 0 {
+		return nil, fmt.Errorf("not enough witness outputs to pay for "+
+			"anchor sweep, only have %v available",
+			totalInput-AnchorSize)
+	}
+
+	sweep := &AnchorSweep{
+		Anchor:  anchor,
+		Coins:   selectedCoins,
+		Fee:     fee,
+		FeeRate: feeRate,
+	}
+
+	// Lease the selected coins right away, so they won't be selected by
+	// any concurrent funding flows. The leases of coins re-used from the
+	// sweep we're replacing are extended. If we fail to produce the sweep,
+	// we'll release the new ones again.
+	for i, coin := range selectedCoins {
+		_, err := l.leaseOutput(
+			anchorSweepLockID, coin.OutPoint,
+			anchorSweepLeaseDuration,
+		)
+		if err != nil {
+			sweep.Coins = selectedCoins[:i]
+			l.cancelAnchorSweep(sweep, replaces)
+			return nil, err
+		}
+	}
+
+	sweep.Tx, err = l.signAnchorSweep(anchor, selectedCoins, changeAmt)
+	if err != nil {
+		l.cancelAnchorSweep(sweep, replaces)
+		return nil, err
+	}
+
+	return sweep, nil
+}
+
+// signAnchorSweep assembles and signs a transaction spending the anchor
+// output along with the selected wallet coins, sending changeAmt back to a
+// change address of the wallet.
+func (l *LightningWallet) signAnchorSweep(anchor *AnchorResolution,
+	selectedCoins []*Utxo, changeAmt btcutil.Amount) (*wire.MsgTx, error) {
+
+	changeAddr, err := l.NewAddress(WitnessPubKey, true)
+	if err != nil {
+		return nil, err
+	}
+	changeScript, err := txscript.PayToAddrScript(changeAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// With the coins selected, we can now assemble the sweep transaction,
+	// placing the anchor as the first input. All inputs signal
+	// replaceability, such that we can bump the fee of the sweep later on.
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: anchor.CommitAnchor,
+		Sequence:         anchorSweepSequence,
+	})
+	for _, coin := range selectedCoins {
+		sweepTx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: coin.OutPoint,
+			Sequence:         anchorSweepSequence,
+		})
+	}
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: changeScript,
+		Value:    int64(changeAmt),
+	})
+
+	// Finally, we'll sign the anchor input using our funding key, and all
+	// wallet inputs using the keys of the wallet.
+	sigHashes := txscript.NewTxSigHashes(sweepTx)
+
+	anchorSignDesc := anchor.AnchorSignDesc
+	anchorSignDesc.SigHashes = sigHashes
+	anchorSignDesc.InputIndex = 0
+	sweepTx.TxIn[0].Witness, err = CommitSpendAnchor(
+		l.Cfg.Signer, &anchorSignDesc, sweepTx,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, coin := range selectedCoins {
+		signDesc := SignDescriptor{
+			Output: &wire.TxOut{
+				PkScript: coin.PkScript,
+				Value:    int64(coin.Value),
+			},
+			HashType:   txscript.SigHashAll,
+			SigHashes:  sigHashes,
+			InputIndex: i + 1,
+		}
+		inputScript, err := l.Cfg.Signer.ComputeInputScript(
+			sweepTx, &signDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sweepTx.TxIn[i+1].SignatureScript = inputScript.ScriptSig
+		sweepTx.TxIn[i+1].Witness = inputScript.Witness
+	}
+
+	return sweepTx, nil
+}
+
+// CancelAnchorSweep releases the wallet coins spent by an anchor sweep created
+// by CreateAnchorSweep that won't confirm, so that they can be used by other
+// transactions again. Coins that are also spent by keep, which is either nil
+// or the sweep that is still live in the mempool, remain leased.
+func (l *LightningWallet) CancelAnchorSweep(sweep, keep *AnchorSweep) {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	l.cancelAnchorSweep(sweep, keep)
+}
+
+// cancelAnchorSweep releases the coins of the passed sweep as described by
+// CancelAnchorSweep.
+//
+// NOTE: The coin select mutex MUST be held when calling this method.
+func (l *LightningWallet) cancelAnchorSweep(sweep, keep *AnchorSweep) {
+	kept := make(map[wire.OutPoint]struct{})
+	if keep != nil {
+		for _, coin := range keep.Coins {
+			kept[coin.OutPoint] = struct{}{}
+		}
+	}
+
+	for _, coin := range sweep.Coins {
+		if _, ok := kept[coin.OutPoint]; ok {
+			continue
+		}
+
+		// The lease may already have expired, in which case the coin
+		// is available again anyway.
+		err := l.releaseOutput(anchorSweepLockID, coin.OutPoint)
+		if err != nil && err != ErrOutputNotLeased {
+			walletLog.Errorf("Unable to release coin %v of anchor "+
+				"sweep: %v", coin.OutPoint, err)
+		}
+	}
+}
+
+// anchorSweepWeight returns the estimated weight of a transaction that spends
+// an anchor output along with the passed wallet coins to a single p2wkh
+// change output.
+func anchorSweepWeight(coins []*Utxo) int64 {
+	var weightEstimate TxWeightEstimator
+	weightEstimate.AddWitnessInput(AnchorWitnessSize)
+	for _, coin := range coins {
+		if coin.AddressType == NestedWitnessPubKey {
+			weightEstimate.AddNestedP2WKHInput()
+		} else {
+			weightEstimate.AddP2WKHInput()
+		}
+	}
+	weightEstimate.AddP2WKHOutput()
+
+	return int64(weightEstimate.Weight())
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/btcsuite/btcwallet/chain"
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/lightninglabs/neutrino"
+	"github.com/lightningnetwork/lnd/electrum"
 	"github.com/lightningnetwork/lnd/lnwallet"
 )
 
@@ -121,6 +122,22 @@ func (b *BtcWallet) GetUtxo(op *wire.OutPoint, pkScript []byte,
 			PkScript: pkScript,
 		}, nil
 
+	case *electrum.WalletClient:
+		txout, err := backend.GetUtxo(op, pkScript, heightHint)
+		switch err {
+		case nil:
+			return txout, nil
+
+		case electrum.ErrOutputSpent:
+			return nil, ErrOutputSpent
+
+		case electrum.ErrOutputNotFound:
+			return nil, ErrOutputNotFound
+
+		default:
+			return nil, err
+		}
+
 	default:
 		return nil, fmt.Errorf("unknown backend")
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	base "github.com/btcsuite/btcwallet/wallet"
 	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/electrum"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet"
 )
@@ -31,6 +32,10 @@ var (
 	// stored within the top-level waleltdb buckets of btcwallet.
 	waddrmgrNamespaceKey = []byte("waddrmgr")
 
+	// wtxmgrNamespaceKey is the namespace key that the wtxmgr state is
+	// stored within the top-level walletdb buckets of btcwallet.
+	wtxmgrNamespaceKey = []byte("wtxmgr")
+
 	// lightningAddrSchema is the scope addr schema for all keys that we
 	// derive. We'll treat them all as p2wkh addresses, as atm we must
 	// specify a particular type.
@@ -62,6 +67,12 @@ type BtcWallet struct {
 	// FetchInputInfo.
 	utxoCache map[wire.OutPoint]*wire.TxOut
 	cacheMtx  sync.RWMutex
+
+	// recoveryStartTime and recoveryStartHeight record when and from
+	// which height the wallet started syncing in recovery mode. They're
+	// used to estimate the remaining time of the recovery.
+	recoveryStartTime   time.Time
+	recoveryStartHeight int32
 }
 
 // A compile time check to ensure that BtcWallet implements the
@@ -99,7 +110,14 @@ func New(cfg Config) (*BtcWallet, error) {
 			return nil, err
 		}
 
-		if !walletExists {
+		switch {
+		// A watch-only wallet can't be created here, as it must be
+		// imported from the remote signer holding its seed.
+		case !walletExists && cfg.WatchOnly:
+			return nil, fmt.Errorf("watch-only wallet doesn't "+
+				"exist in %v", netDir)
+
+		case !walletExists:
 			// Wallet has never been created, perform initial
 			// set up.
 			wallet, err = loader.CreateNewWallet(
@@ -109,7 +127,8 @@ func New(cfg Config) (*BtcWallet, error) {
 			if err != nil {
 				return nil, err
 			}
-		} else {
+
+		default:
 			// Wallet has been created and been initialized at
 			// this point, open it along with all the required DB
 			// namespaces, and the DB itself.
@@ -120,6 +139,15 @@ func New(cfg Config) (*BtcWallet, error) {
 		}
 	}
 
+	// Make sure a watch-only instance never operates a wallet that holds
+	// private keys, such as the one it used before the seed was moved
+	// to the remote signer.
+	if cfg.WatchOnly && !wallet.Manager.WatchOnly() {
+		return nil, fmt.Errorf("wallet in %v holds private keys, "+
+			"it must be replaced by the watch-only wallet of the "+
+			"remote signer", netDir)
+	}
+
 	return &BtcWallet{
 		cfg:           &cfg,
 		wallet:        wallet,
@@ -162,17 +190,46 @@ func (b *BtcWallet) Start() error {
 	// Start the underlying btcwallet core.
 	b.wallet.Start()
 
+	// If we're restoring from a seed, note where the chain scan starts so
+	// we can report the progress of the recovery.
+	if b.cfg.RecoveryWindow > 0 {
+		b.recoveryStartTime = time.Now()
+		b.recoveryStartHeight = b.wallet.Manager.SyncedTo().Height
+	}
+
 	// Pass the rpc client into the wallet so it can sync up to the
 	// current main chain.
 	b.wallet.SynchronizeRPC(b.chain)
 
+	// A watch-only wallet has no private keys to unlock. Its addresses
+	// are derived from the account public keys, and the key scope of the
+	// lightning keys is only used by the remote signer.
+	if b.wallet.Manager.WatchOnly() {
+		return nil
+	}
+
 	if err := b.wallet.Unlock(b.cfg.PrivatePass, nil); err != nil {
 		return err
 	}
 
-	// We'll now ensure that the KeyScope: (1017, 1) exists within the
-	// internal waddrmgr. We'll need this in order to properly generate the
-	// keys required for signing various contracts.
+	return b.ensureKeyScope()
+}
+
+// StartSigner unlocks the wallet without connecting to the chain backend, so
+// that it can only be used to derive keys and produce signatures. This is used
+// by lnd instances that run in signer-only mode.
+func (b *BtcWallet) StartSigner() error {
+	if err := b.wallet.Unlock(b.cfg.PrivatePass, nil); err != nil {
+		return err
+	}
+
+	return b.ensureKeyScope()
+}
+
+// ensureKeyScope makes sure that the KeyScope: (1017, 1) exists within the
+// internal waddrmgr. We'll need this in order to properly generate the keys
+// required for signing various contracts.
+func (b *BtcWallet) ensureKeyScope() error {
 	_, err := b.wallet.Manager.FetchScopedKeyManager(b.chainKeyScope)
 	if err != nil {
 		// If the scope hasn't yet been created (it wouldn't been
@@ -342,9 +399,10 @@ func (b *BtcWallet) ListUnspentWitness(minConfs int32) ([]*lnwallet.Utxo, error)
 			}
 
 			utxo := &lnwallet.Utxo{
-				AddressType: addressType,
-				Value:       amt,
-				PkScript:    pkScript,
+				AddressType:   addressType,
+				Value:         amt,
+				Confirmations: output.Confirmations,
+				PkScript:      pkScript,
 				OutPoint: wire.OutPoint{
 					Hash:  *txid,
 					Index: output.Vout,
@@ -365,7 +423,7 @@ func (b *BtcWallet) ListUnspentWitness(minConfs int32) ([]*lnwallet.Utxo, error)
 // published to the network (either in the mempool or chain) no error
 // will be returned.
 func (b *BtcWallet) PublishTransaction(tx *wire.MsgTx) error {
-	if err := b.wallet.PublishTransaction(tx); err != nil {
+	if err := b.publishTransaction(tx); err != nil {
 		switch b.chain.(type) {
 		case *chain.RPCClient:
 			if strings.Contains(err.Error(), "already have") {
@@ -441,6 +499,37 @@ func (b *BtcWallet) PublishTransaction(tx *wire.MsgTx) error {
 				return lnwallet.ErrDoubleSpend
 			}
 
+		case *electrum.WalletClient:
+			// The server relays the rejection reason of its full
+			// node, which is usually bitcoind.
+			if strings.Contains(err.Error(), "txn-already-in-mempool") {
+				// Transaction in mempool, treat as non-error.
+				return nil
+			}
+			if strings.Contains(err.Error(), "txn-already-known") {
+				// Transaction in mempool, treat as non-error.
+				return nil
+			}
+			if strings.Contains(err.Error(), "already in block") {
+				// Transaction was already mined, we don't
+				// consider this an error.
+				return nil
+			}
+			if strings.Contains(err.Error(), "txn-mempool-conflict") {
+				// Output was spent by other transaction
+				// already in the mempool.
+				return lnwallet.ErrDoubleSpend
+			}
+			if strings.Contains(err.Error(), "insufficient fee") {
+				// RBF enabled transaction did not have enough fee.
+				return lnwallet.ErrDoubleSpend
+			}
+			if strings.Contains(err.Error(), "Missing inputs") {
+				// Transaction is spending either output that
+				// is missing or already spent.
+				return lnwallet.ErrDoubleSpend
+			}
+
 		default:
 		}
 		return err
@@ -504,6 +593,7 @@ func minedTransactionsToDetails(
 			Timestamp:        block.Timestamp,
 			TotalFees:        int64(tx.Fee),
 			DestAddresses:    destAddresses,
+			RawTx:            wireTx,
 		}
 
 		balanceDelta, err := extractBalanceDelta(tx, wireTx)
@@ -534,6 +624,7 @@ func unminedTransactionsToDetail(
 		Hash:      *summary.Hash,
 		TotalFees: int64(summary.Fee),
 		Timestamp: summary.Timestamp,
+		RawTx:     wireTx,
 	}
 
 	balanceDelta, err := extractBalanceDelta(summary, wireTx)
@@ -79,6 +79,13 @@ type Config struct {
 	// notifications for received funds, etc.
 	ChainSource chain.Interface
 
+	// TxPublisher, if set, broadcasts the transactions published by the
+	// wallet in place of the chain source, such as through the active
+	// node of a chain backend failover. It must be backed by a node of
+	// the same kind as the chain source, as its rejections are
+	// interpreted accordingly.
+	TxPublisher func(*wire.MsgTx) error
+
 	// FeeEstimator is an instance of the fee estimator interface which
 	// will be used by the wallet to dynamically set transaction fees when
 	// crafting transactions.
@@ -96,6 +103,12 @@ type Config struct {
 	// encrypted at all, in which case it should be attempted to be loaded
 	// normally when creating the BtcWallet.
 	Wallet *wallet.Wallet
+
+	// WatchOnly indicates that the wallet doesn't hold any private keys,
+	// as it has been imported from the export of a remote signer. A
+	// watch-only wallet is never created from scratch, and inputs
+	// spending its outputs must be signed by the remote signer.
+	WatchOnly bool
 }
 
 // NetworkDir returns the directory name of a network directory to hold wallet
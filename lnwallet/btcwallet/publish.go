@@ -0,0 +1,72 @@
+package btcwallet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+)
+
+// publishTransaction broadcasts the passed transaction. Without a configured
+// TxPublisher, the wallet broadcasts it through its chain source. Otherwise,
+// we'll mimic the wallet: the transaction is recorded as unconfirmed, so it's
+// rebroadcast upon restarts, before it's handed to the TxPublisher.
+func (b *BtcWallet) publishTransaction(tx *wire.MsgTx) error {
+	if b.cfg.TxPublisher == nil {
+		return b.wallet.PublishTransaction(tx)
+	}
+
+	txRec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+	if err != nil {
+		return err
+	}
+	err = walletdb.Update(b.db, func(dbTx walletdb.ReadWriteTx) error {
+		txmgrNs := dbTx.ReadWriteBucket(wtxmgrNamespaceKey)
+		return b.wallet.TxStore.InsertTx(txmgrNs, txRec, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = b.cfg.TxPublisher(tx)
+	if err == nil || !isTxRejected(err) {
+		return err
+	}
+
+	// If the transaction was rejected, then we'll remove it from the
+	// store, as otherwise we'd continually attempt to rebroadcast it, and
+	// the utxo state of the wallet wouldn't be accurate.
+	dbErr := walletdb.Update(b.db, func(dbTx walletdb.ReadWriteTx) error {
+		txmgrNs := dbTx.ReadWriteBucket(wtxmgrNamespaceKey)
+		return b.wallet.TxStore.RemoveUnminedTx(txmgrNs, txRec)
+	})
+	if dbErr != nil {
+		return fmt.Errorf("unable to broadcast tx: %v, unable to "+
+			"remove invalid tx: %v", err, dbErr)
+	}
+
+	return err
+}
+
+// isTxRejected returns true if the passed broadcast error means that the
+// mempool of the backend rejected the transaction as invalid. These are the
+// same errors btcwallet removes a published transaction from its store for.
+func isTxRejected(err error) bool {
+	rejections := []string{
+		// The following are errors returned from btcd's mempool.
+		"spent", "orphan", "conflict",
+
+		// The following errors are returned from bitcoind's mempool.
+		"fee not met", "Missing inputs", "already in block chain",
+	}
+	for _, rejection := range rejections {
+		if strings.Contains(err.Error(), rejection) {
+			return true
+		}
+	}
+
+	return false
+}
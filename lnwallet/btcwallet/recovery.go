@@ -0,0 +1,127 @@
+package btcwallet
+
+import (
+	"time"
+
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+// RecoveryProgress returns the progress of the wallet's address recovery if
+// it was restored from a seed with a recovery window. The scanned height is
+// taken from the wallet's sync state, which btcwallet advances block by block
+// while it filters the chain for the addresses of the default key scopes.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) RecoveryProgress() (*lnwallet.RecoveryProgress, error) {
+	if b.cfg.RecoveryWindow == 0 {
+		return &lnwallet.RecoveryProgress{}, nil
+	}
+
+	_, bestHeight, err := b.chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	scannedHeight := b.wallet.Manager.SyncedTo().Height
+	finished := b.wallet.ChainSynced() && scannedHeight >= bestHeight
+
+	progress := &lnwallet.RecoveryProgress{
+		RecoveryMode:     true,
+		RecoveryFinished: finished,
+		ScannedHeight:    scannedHeight,
+		BestHeight:       bestHeight,
+		Progress: recoveryFraction(
+			b.recoveryStartHeight, scannedHeight, bestHeight,
+		),
+	}
+
+	// Report the number of addresses derived for the default account of
+	// each scope btcwallet recovers. As found addresses extend the
+	// account's branches, these counts grow as the recovery finds funds.
+	err = walletdb.View(b.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+
+		for _, scope := range waddrmgr.DefaultKeyScopes {
+			scopedMgr, err := b.wallet.Manager.FetchScopedKeyManager(
+				scope,
+			)
+			if err != nil {
+				return err
+			}
+
+			props, err := scopedMgr.AccountProperties(
+				addrmgrNs, defaultAccount,
+			)
+			if err != nil {
+				return err
+			}
+
+			progress.Scopes = append(
+				progress.Scopes, lnwallet.ScopeRecoveryProgress{
+					Purpose:       scope.Purpose,
+					Coin:          scope.Coin,
+					ExternalAddrs: props.ExternalKeyCount,
+					InternalAddrs: props.InternalKeyCount,
+				},
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !finished {
+		progress.EstimatedCompletion = estimateCompletion(
+			b.recoveryStartTime, time.Now(), b.recoveryStartHeight,
+			scannedHeight, bestHeight,
+		)
+	}
+
+	return progress, nil
+}
+
+// recoveryFraction returns the fraction of the blocks between startHeight and
+// bestHeight that have been scanned, clamped to the range [0, 1].
+func recoveryFraction(startHeight, scannedHeight, bestHeight int32) float64 {
+	if bestHeight <= startHeight {
+		return 1
+	}
+
+	fraction := float64(scannedHeight-startHeight) /
+		float64(bestHeight-startHeight)
+
+	switch {
+	case fraction < 0:
+		return 0
+	case fraction > 1:
+		return 1
+	default:
+		return fraction
+	}
+}
+
+// estimateCompletion extrapolates the scan rate observed between startTime and
+// now to the blocks that remain to be scanned. The zero time is returned if no
+// blocks have been scanned yet, as no rate is known.
+func estimateCompletion(startTime, now time.Time, startHeight, scannedHeight,
+	bestHeight int32) time.Time {
+
+	scanned := scannedHeight - startHeight
+	if scanned <= 0 || startTime.IsZero() {
+		return time.Time{}
+	}
+
+	remaining := bestHeight - scannedHeight
+	if remaining <= 0 {
+		return now
+	}
+
+	elapsed := now.Sub(startTime)
+	perBlock := float64(elapsed) / float64(scanned)
+
+	return now.Add(time.Duration(perBlock * float64(remaining)))
+}
@@ -0,0 +1,61 @@
+package btcwallet
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRecoveryFraction ensures the scanned fraction of the chain is computed
+// relative to the height the recovery started at and is clamped to [0, 1].
+func TestRecoveryFraction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		start, scanned, best int32
+		expected             float64
+	}{
+		{start: 0, scanned: 0, best: 100, expected: 0},
+		{start: 0, scanned: 25, best: 100, expected: 0.25},
+		{start: 100, scanned: 150, best: 200, expected: 0.5},
+		{start: 100, scanned: 50, best: 200, expected: 0},
+		{start: 100, scanned: 250, best: 200, expected: 1},
+		{start: 200, scanned: 200, best: 200, expected: 1},
+	}
+
+	for i, test := range tests {
+		fraction := recoveryFraction(test.start, test.scanned, test.best)
+		if fraction != test.expected {
+			t.Fatalf("test %d: expected fraction %v, got %v", i,
+				test.expected, fraction)
+		}
+	}
+}
+
+// TestEstimateCompletion ensures the completion time is extrapolated from the
+// scan rate observed since the recovery started.
+func TestEstimateCompletion(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1000000, 0)
+	now := start.Add(10 * time.Minute)
+
+	// Without any scanned blocks, no rate is known yet.
+	eta := estimateCompletion(start, now, 100, 100, 200)
+	if !eta.IsZero() {
+		t.Fatalf("expected no estimate, got %v", eta)
+	}
+
+	// Having scanned 100 blocks in 10 minutes, the remaining 300 blocks
+	// should take another 30 minutes.
+	eta = estimateCompletion(start, now, 100, 200, 500)
+	if !eta.Equal(now.Add(30 * time.Minute)) {
+		t.Fatalf("expected estimate %v, got %v",
+			now.Add(30*time.Minute), eta)
+	}
+
+	// Once the tip is reached, the recovery is expected to finish now.
+	eta = estimateCompletion(start, now, 100, 500, 500)
+	if !eta.Equal(now) {
+		t.Fatalf("expected estimate %v, got %v", now, eta)
+	}
+}
@@ -1,6 +1,8 @@
 package btcwallet
 
 import (
+	"bytes"
+
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
@@ -199,7 +201,114 @@ func (b *BtcWallet) ComputeInputScript(tx *wire.MsgTx,
 		return nil, nil
 	}
 
-	pka := walletAddr.(waddrmgr.ManagedPubKeyAddress)
+	return b.computeInputScript(
+		tx, signDesc, walletAddr.(waddrmgr.ManagedPubKeyAddress),
+	)
+}
+
+// ComputeInputScriptAtPath generates the input script of an input that spends
+// a wallet output whose key is derived from the passed key scope and
+// derivation path. Unlike ComputeInputScript, the key doesn't need to be known
+// to the wallet, which allows signing for the addresses handed out by a
+// watch-only copy of the wallet.
+func (b *BtcWallet) ComputeInputScriptAtPath(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor, keyScope waddrmgr.KeyScope,
+	path waddrmgr.DerivationPath) (*lnwallet.InputScript, error) {
+
+	walletAddr, err := b.deriveAddrAtPath(keyScope, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The derived key must control the output that is spent, otherwise
+	// the input script would be of no use.
+	pkScript, err := txscript.PayToAddrScript(walletAddr.Address())
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pkScript, signDesc.Output.PkScript) {
+		return nil, errors.Errorf("key %v/%v/%v of scope %v doesn't "+
+			"control output script %x", path.Account, path.Branch,
+			path.Index, keyScope, signDesc.Output.PkScript)
+	}
+
+	return b.computeInputScript(
+		tx, signDesc, walletAddr.(waddrmgr.ManagedPubKeyAddress),
+	)
+}
+
+// ScriptAtPath returns the output script of the wallet address whose key is
+// derived from the passed key scope and derivation path.
+func (b *BtcWallet) ScriptAtPath(keyScope waddrmgr.KeyScope,
+	path waddrmgr.DerivationPath) ([]byte, error) {
+
+	walletAddr, err := b.deriveAddrAtPath(keyScope, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return txscript.PayToAddrScript(walletAddr.Address())
+}
+
+// deriveAddrAtPath derives the wallet address whose key is derived from the
+// passed key scope and derivation path.
+func (b *BtcWallet) deriveAddrAtPath(keyScope waddrmgr.KeyScope,
+	path waddrmgr.DerivationPath) (waddrmgr.ManagedAddress, error) {
+
+	scopedMgr, err := b.wallet.Manager.FetchScopedKeyManager(keyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	var walletAddr waddrmgr.ManagedAddress
+	err = walletdb.View(b.db, func(dbTx walletdb.ReadTx) error {
+		addrmgrNs := dbTx.ReadBucket(waddrmgrNamespaceKey)
+
+		var err error
+		walletAddr, err = scopedMgr.DeriveFromKeyPath(addrmgrNs, path)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return walletAddr, nil
+}
+
+// DerivationPath returns the key scope and derivation path of the key that
+// controls the wallet output with the passed output script.
+func (b *BtcWallet) DerivationPath(pkScript []byte) (waddrmgr.KeyScope,
+	waddrmgr.DerivationPath, error) {
+
+	walletAddr, err := b.fetchOutputAddr(pkScript)
+	if err != nil {
+		return waddrmgr.KeyScope{}, waddrmgr.DerivationPath{}, err
+	}
+
+	pka, ok := walletAddr.(waddrmgr.ManagedPubKeyAddress)
+	if !ok {
+		return waddrmgr.KeyScope{}, waddrmgr.DerivationPath{},
+			errors.Errorf("address %v isn't a public key address",
+				walletAddr.Address())
+	}
+
+	keyScope, path, ok := pka.DerivationInfo()
+	if !ok {
+		return waddrmgr.KeyScope{}, waddrmgr.DerivationPath{},
+			errors.Errorf("address %v isn't derived from the "+
+				"seed", walletAddr.Address())
+	}
+
+	return keyScope, path, nil
+}
+
+// computeInputScript generates the input script of an input that spends an
+// output of the passed wallet address.
+func (b *BtcWallet) computeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor,
+	pka waddrmgr.ManagedPubKeyAddress) (*lnwallet.InputScript, error) {
+
+	outputScript := signDesc.Output.PkScript
 	privKey, err := pka.PrivKey()
 	if err != nil {
 		return nil, err
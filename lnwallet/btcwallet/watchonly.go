@@ -0,0 +1,199 @@
+package btcwallet
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	base "github.com/btcsuite/btcwallet/wallet"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/coreos/bbolt"
+)
+
+const (
+	// loaderDbName is the name of the database file that the wallet loader
+	// of btcwallet opens within the network directory.
+	loaderDbName = "wallet.db"
+
+	// walletDbPermissions is the file mode of an imported wallet database.
+	walletDbPermissions = 0600
+)
+
+// ExportWatchOnly writes a watch-only copy of the wallet database to the
+// passed writer. The copy has all private key material removed, so it only
+// contains the account public keys from which the addresses of the wallet
+// are derived. Its public passphrase is reset to the default one, as a
+// watch-only instance doesn't know the passphrase of the signer.
+func (b *BtcWallet) ExportWatchOnly(w io.Writer) error {
+	// The private key material is removed from a copy of the database,
+	// which is kept next to the wallet, so that it never leaves the
+	// directory it is stored in.
+	netDir := NetworkDir(b.cfg.DataDir, b.netParams)
+	tempDir, err := ioutil.TempDir(netDir, "watchonly")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	pubPass := b.cfg.PublicPass
+	if pubPass == nil {
+		pubPass = defaultPubPassphrase
+	}
+
+	return exportWatchOnly(b.db, pubPass, b.netParams, tempDir, w)
+}
+
+// exportWatchOnly writes a watch-only copy of the passed wallet database to
+// the writer, using the given directory for its intermediate copies.
+func exportWatchOnly(walletDB walletdb.DB, pubPass []byte,
+	netParams *chaincfg.Params, tempDir string, w io.Writer) error {
+
+	dbPath := filepath.Join(tempDir, "wallet-copy.db")
+	dbFile, err := os.OpenFile(
+		dbPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, walletDbPermissions,
+	)
+	if err != nil {
+		return err
+	}
+	err = walletDB.Copy(dbFile)
+	dbFile.Close()
+	if err != nil {
+		return err
+	}
+
+	db, err := walletdb.Open("bdb", dbPath)
+	if err != nil {
+		return err
+	}
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+
+		manager, err := waddrmgr.Open(addrmgrNs, pubPass, netParams)
+		if err != nil {
+			return err
+		}
+		defer manager.Close()
+
+		err = manager.ChangePassphrase(
+			addrmgrNs, pubPass, defaultPubPassphrase, false,
+			&waddrmgr.DefaultScryptOptions,
+		)
+		if err != nil {
+			return err
+		}
+
+		return manager.ConvertToWatchingOnly(addrmgrNs)
+	})
+	db.Close()
+	if err != nil {
+		return err
+	}
+
+	// The pages that held the deleted private keys are only put on the
+	// free list of the copy, so they still contain the encrypted keys. We
+	// therefore export a compacted database, which only consists of the
+	// remaining data.
+	exportPath := filepath.Join(tempDir, loaderDbName)
+	if err := compactDB(dbPath, exportPath); err != nil {
+		return err
+	}
+
+	exportFile, err := os.Open(exportPath)
+	if err != nil {
+		return err
+	}
+	defer exportFile.Close()
+
+	_, err = io.Copy(w, exportFile)
+	return err
+}
+
+// compactDB writes all buckets of the bolt database at srcPath to a new
+// database at dstPath. Unlike a copy of the file, the new database doesn't
+// contain any of the data that was deleted from the source.
+func compactDB(srcPath, dstPath string) error {
+	src, err := bolt.Open(srcPath, walletDbPermissions, nil)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := bolt.Open(dstPath, walletDbPermissions, nil)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	return src.View(func(srcTx *bolt.Tx) error {
+		return dst.Update(func(dstTx *bolt.Tx) error {
+			return srcTx.ForEach(func(name []byte,
+				srcBucket *bolt.Bucket) error {
+
+				dstBucket, err := dstTx.CreateBucket(name)
+				if err != nil {
+					return err
+				}
+
+				return copyBucket(dstBucket, srcBucket)
+			})
+		})
+	})
+}
+
+// copyBucket copies all key/value pairs and nested buckets of src to dst.
+func copyBucket(dst, src *bolt.Bucket) error {
+	if err := dst.SetSequence(src.Sequence()); err != nil {
+		return err
+	}
+
+	return src.ForEach(func(k, v []byte) error {
+		// Nested buckets have a nil value.
+		if v == nil {
+			nested, err := dst.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+
+			return copyBucket(nested, src.Bucket(k))
+		}
+
+		return dst.Put(k, v)
+	})
+}
+
+// WalletExists returns whether the wallet described by the passed
+// configuration has already been created.
+func WalletExists(cfg Config) (bool, error) {
+	netDir := NetworkDir(cfg.DataDir, cfg.NetParams)
+	loader := base.NewLoader(cfg.NetParams, netDir, cfg.RecoveryWindow)
+
+	return loader.WalletExists()
+}
+
+// ImportWatchOnly stores the passed watch-only wallet database, as written by
+// ExportWatchOnly, as the wallet described by the passed configuration. An
+// existing wallet is never overwritten.
+func ImportWatchOnly(cfg Config, walletDB []byte) error {
+	netDir := NetworkDir(cfg.DataDir, cfg.NetParams)
+	if err := os.MkdirAll(netDir, 0700); err != nil {
+		return err
+	}
+
+	dbFile, err := os.OpenFile(
+		filepath.Join(netDir, loaderDbName),
+		os.O_WRONLY|os.O_CREATE|os.O_EXCL, walletDbPermissions,
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := dbFile.Write(walletDB); err != nil {
+		dbFile.Close()
+		return err
+	}
+
+	return dbFile.Close()
+}
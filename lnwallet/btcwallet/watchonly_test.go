@@ -0,0 +1,178 @@
+package btcwallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	base "github.com/btcsuite/btcwallet/wallet"
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+// accountKeys returns the encrypted private and public keys of all accounts
+// stored within the passed address manager namespace.
+func accountKeys(t *testing.T, addrmgrNs walletdb.ReadBucket) ([][]byte,
+	[][]byte) {
+
+	var privKeys, pubKeys [][]byte
+	scopes := addrmgrNs.NestedReadBucket([]byte("scope"))
+	err := scopes.ForEach(func(scopeKey, _ []byte) error {
+		if len(scopeKey) != 8 {
+			return nil
+		}
+		scope := scopes.NestedReadBucket(scopeKey)
+
+		if ctPriv := scope.Get([]byte("ctpriv")); ctPriv != nil {
+			privKeys = append(privKeys, ctPriv)
+		}
+
+		accounts := scope.NestedReadBucket([]byte("acct"))
+		return accounts.ForEach(func(k, v []byte) error {
+			if v == nil {
+				return nil
+			}
+
+			// The row starts with its type and the length of its
+			// data, followed by the encrypted public and private
+			// key of the account, each prefixed by its length.
+			data := v[5:]
+			pubLen := binary.LittleEndian.Uint32(data[:4])
+			pubKeys = append(pubKeys, data[4:4+pubLen])
+
+			data = data[4+pubLen:]
+			privLen := binary.LittleEndian.Uint32(data[:4])
+			if privLen != 0 {
+				privKeys = append(privKeys, data[4:4+privLen])
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to read accounts: %v", err)
+	}
+
+	return privKeys, pubKeys
+}
+
+// TestExportWatchOnly asserts that the exported watch-only wallet doesn't
+// contain any of the encrypted private keys of the wallet, not even within
+// pages of the database that are no longer in use.
+func TestExportWatchOnly(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "watchonly")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	netParams := &chaincfg.RegressionNetParams
+	pubPass := []byte("pub")
+
+	db, err := walletdb.Create("bdb", filepath.Join(tempDir, "wallet.db"))
+	if err != nil {
+		t.Fatalf("unable to create wallet db: %v", err)
+	}
+	defer db.Close()
+
+	seed := bytes.Repeat([]byte{1}, 32)
+	err = base.Create(
+		db, pubPass, []byte("priv"), seed, netParams, time.Now(),
+	)
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+
+	// Collect the encrypted private key material of the wallet, which
+	// must not be part of the export, as well as the encrypted account
+	// public keys, which must be.
+	var privKeys, pubKeys [][]byte
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+
+		main := addrmgrNs.NestedReadBucket([]byte("main"))
+		for _, name := range []string{
+			"mpriv", "cpriv", "cscript", "mhdpriv",
+		} {
+			privKey := main.Get([]byte(name))
+			if privKey == nil {
+				t.Fatalf("missing %v", name)
+			}
+			privKeys = append(privKeys, privKey)
+		}
+
+		accountPrivKeys, accountPubKeys := accountKeys(t, addrmgrNs)
+		privKeys = append(privKeys, accountPrivKeys...)
+		pubKeys = accountPubKeys
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read wallet: %v", err)
+	}
+	if len(privKeys) <= 4 || len(pubKeys) == 0 {
+		t.Fatalf("expected account keys within the wallet")
+	}
+
+	exportDir := filepath.Join(tempDir, "export")
+	if err := os.Mkdir(exportDir, 0700); err != nil {
+		t.Fatalf("unable to create export dir: %v", err)
+	}
+	var export bytes.Buffer
+	err = exportWatchOnly(db, pubPass, netParams, exportDir, &export)
+	if err != nil {
+		t.Fatalf("unable to export watch-only wallet: %v", err)
+	}
+
+	for _, privKey := range privKeys {
+		if bytes.Contains(export.Bytes(), privKey) {
+			t.Fatalf("export contains encrypted private key %x",
+				privKey)
+		}
+	}
+	for _, pubKey := range pubKeys {
+		if !bytes.Contains(export.Bytes(), pubKey) {
+			t.Fatalf("export is missing encrypted account public "+
+				"key %x", pubKey)
+		}
+	}
+
+	// The export is a watch-only wallet that opens with the default
+	// public passphrase.
+	importPath := filepath.Join(tempDir, "import.db")
+	err = ioutil.WriteFile(importPath, export.Bytes(), walletDbPermissions)
+	if err != nil {
+		t.Fatalf("unable to write export: %v", err)
+	}
+	importDB, err := walletdb.Open("bdb", importPath)
+	if err != nil {
+		t.Fatalf("unable to open export: %v", err)
+	}
+	defer importDB.Close()
+
+	err = walletdb.View(importDB, func(tx walletdb.ReadTx) error {
+		manager, err := waddrmgr.Open(
+			tx.ReadBucket(waddrmgrNamespaceKey),
+			defaultPubPassphrase, netParams,
+		)
+		if err != nil {
+			return err
+		}
+		defer manager.Close()
+
+		if !manager.WatchOnly() {
+			t.Fatalf("exported wallet isn't watch-only")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to open exported wallet: %v", err)
+	}
+}
@@ -847,11 +847,13 @@ func (lc *LightningChannel) diskCommitToMemCommit(isLocal bool,
 	var localCommitKeys, remoteCommitKeys *CommitmentKeyRing
 	if localCommitPoint != nil {
 		localCommitKeys = deriveCommitmentKeys(localCommitPoint, true,
-			lc.localChanCfg, lc.remoteChanCfg)
+			lc.channelState.ChanType, lc.localChanCfg,
+			lc.remoteChanCfg)
 	}
 	if remoteCommitPoint != nil {
 		remoteCommitKeys = deriveCommitmentKeys(remoteCommitPoint, false,
-			lc.localChanCfg, lc.remoteChanCfg)
+			lc.channelState.ChanType, lc.localChanCfg,
+			lc.remoteChanCfg)
 	}
 
 	// With the key rings re-created, we'll now convert all the on-disk
@@ -913,6 +915,9 @@ type CommitmentKeyRing struct {
 	// from the local payment base point or the local private key from the
 	// base point secret. This may be included in a SignDescriptor to
 	// generate signatures for the local payment key.
+	//
+	// NOTE: This will be nil for tweakless channels, as the local payment
+	// key is then used as is.
 	LocalCommitKeyTweak []byte
 
 	// TODO(roasbeef): need delay tweak as well?
@@ -945,12 +950,26 @@ type CommitmentKeyRing struct {
 	// redeem outputs from a revoked commitment transaction if it were to
 	// be published.
 	RevocationKey *btcec.PublicKey
+
+	// DelayAnchorKey is the commitment transaction owner's funding key,
+	// which is used to spend the anchor output of the owner.
+	//
+	// NOTE: This is only set for channels that use anchor outputs.
+	DelayAnchorKey *btcec.PublicKey
+
+	// NoDelayAnchorKey is the other party's funding key, which is used to
+	// spend the anchor output of the other party.
+	//
+	// NOTE: This is only set for channels that use anchor outputs.
+	NoDelayAnchorKey *btcec.PublicKey
 }
 
 // deriveCommitmentKey generates a new commitment key set using the base points
 // and commitment point. The keys are derived differently depending whether the
-// commitment transaction is ours or the remote peer's.
+// commitment transaction is ours or the remote peer's, and whether the
+// channel type uses a tweakless non-delay output.
 func deriveCommitmentKeys(commitPoint *btcec.PublicKey, isOurCommit bool,
+	chanType channeldb.ChannelType,
 	localChanCfg, remoteChanCfg *channeldb.ChannelConfig) *CommitmentKeyRing {
 
 	// First, we'll derive all the keys that don't depend on the context of
@@ -958,9 +977,6 @@ func deriveCommitmentKeys(commitPoint *btcec.PublicKey, isOurCommit bool,
 	keyRing := &CommitmentKeyRing{
 		CommitPoint: commitPoint,
 
-		LocalCommitKeyTweak: SingleTweakBytes(
-			commitPoint, localChanCfg.PaymentBasePoint.PubKey,
-		),
 		LocalHtlcKeyTweak: SingleTweakBytes(
 			commitPoint, localChanCfg.HtlcBasePoint.PubKey,
 		),
@@ -995,11 +1011,35 @@ func deriveCommitmentKeys(commitPoint *btcec.PublicKey, isOurCommit bool,
 	// With the base points assigned, we can now derive the actual keys
 	// using the base point, and the current commitment tweak.
 	keyRing.DelayKey = TweakPubKey(delayBasePoint, commitPoint)
-	keyRing.NoDelayKey = TweakPubKey(noDelayBasePoint, commitPoint)
 	keyRing.RevocationKey = DeriveRevocationPubkey(
 		revocationBasePoint, commitPoint,
 	)
 
+	// If this is a tweakless channel, then the non-delay output pays
+	// directly to the payment base point of the non-delayed party, so
+	// there's no tweak that we need to apply. Otherwise, both the key and
+	// our tweak are derived from the current commitment point.
+	if chanType.IsTweakless() {
+		keyRing.NoDelayKey = noDelayBasePoint
+	} else {
+		keyRing.NoDelayKey = TweakPubKey(noDelayBasePoint, commitPoint)
+		keyRing.LocalCommitKeyTweak = SingleTweakBytes(
+			commitPoint, localChanCfg.PaymentBasePoint.PubKey,
+		)
+	}
+
+	// If the channel uses anchor outputs, then each anchor is spendable
+	// by the funding key of its owner.
+	if chanType.HasAnchors() {
+		if isOurCommit {
+			keyRing.DelayAnchorKey = localChanCfg.MultiSigKey.PubKey
+			keyRing.NoDelayAnchorKey = remoteChanCfg.MultiSigKey.PubKey
+		} else {
+			keyRing.DelayAnchorKey = remoteChanCfg.MultiSigKey.PubKey
+			keyRing.NoDelayAnchorKey = localChanCfg.MultiSigKey.PubKey
+		}
+	}
+
 	return keyRing
 }
 
@@ -1614,13 +1654,12 @@ func (lc *LightningChannel) restoreCommitState(
 	// outputs (if any) we'll need to regenerate the current revocation for
 	// this current un-revoked state as well as retrieve the current
 	// revocation for the remote party.
-	ourRevPreImage, err := lc.channelState.RevocationProducer.AtIndex(
-		lc.currentHeight,
+	localCommitPoint, err := deriveCommitPoint(
+		lc.Signer, lc.channelState, lc.currentHeight,
 	)
 	if err != nil {
 		return err
 	}
-	localCommitPoint := ComputeCommitmentPoint(ourRevPreImage[:])
 	remoteCommitPoint := lc.channelState.RemoteCurrentRevocation
 
 	// With the revocation state reconstructed, we can now convert the disk
@@ -1696,8 +1735,8 @@ func (lc *LightningChannel) restoreCommitState(
 		// We'll also re-create the set of commitment keys needed to
 		// fully re-derive the state.
 		pendingRemoteKeyChain = deriveCommitmentKeys(
-			pendingCommitPoint, false, lc.localChanCfg,
-			lc.remoteChanCfg,
+			pendingCommitPoint, false, lc.channelState.ChanType,
+			lc.localChanCfg, lc.remoteChanCfg,
 		)
 	}
 
@@ -1925,6 +1964,10 @@ type BreachRetribution struct {
 	// HtlcRetributions is a slice of HTLC retributions for each output
 	// active HTLC output within the breached commitment transaction.
 	HtlcRetributions []HtlcRetribution
+
+	// ChanType is the type of the breached channel. It determines how
+	// the output paying to us is spent.
+	ChanType channeldb.ChannelType
 }
 
 // NewBreachRetribution creates a new fully populated BreachRetribution for the
@@ -1957,7 +2000,8 @@ func NewBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 	// With the commitment point generated, we can now generate the four
 	// keys we'll need to reconstruct the commitment state,
 	keyRing := deriveCommitmentKeys(commitmentPoint, false,
-		&chanState.LocalChanCfg, &chanState.RemoteChanCfg)
+		chanState.ChanType, &chanState.LocalChanCfg,
+		&chanState.RemoteChanCfg)
 
 	// Next, reconstruct the scripts as they were present at this state
 	// number so we can have the proper witness script to sign and include
@@ -2133,6 +2177,7 @@ func NewBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 		RemoteOutpoint:       remoteOutpoint,
 		RemoteOutputSignDesc: remoteSignDesc,
 		HtlcRetributions:     htlcRetributions,
+		ChanType:             chanState.ChanType,
 	}, nil
 }
 
@@ -2339,11 +2384,14 @@ func (lc *LightningChannel) createCommitmentTx(c *commitment,
 	// on its total weight. Once we have the total weight, we'll multiply
 	// by the current fee-per-kw, then divide by 1000 to get the proper
 	// fee.
-	totalCommitWeight := CommitWeight + (HtlcWeight * numHTLCs)
+	chanType := lc.channelState.ChanType
+	totalCommitWeight := baseCommitWeight(chanType) + (HtlcWeight * numHTLCs)
 
 	// With the weight known, we can now calculate the commitment fee,
 	// ensuring that we account for any dust outputs trimmed above.
-	commitFee := c.feePerKw.FeeForWeight(totalCommitWeight)
+	commitFee := commitFeeForWeight(
+		chanType, c.feePerKw, totalCommitWeight,
+	)
 	commitFeeMSat := lnwire.NewMSatFromSatoshis(commitFee)
 
 	// Currently, within the protocol, the initiator always pays the fees.
@@ -2997,8 +3045,8 @@ func (lc *LightningChannel) SignNextCommitment() (lnwire.Sig, []lnwire.Sig, erro
 	// Grab the next commitment point for the remote party. This will be
 	// used within fetchCommitmentView to derive all the keys necessary to
 	// construct the commitment state.
-	keyRing := deriveCommitmentKeys(commitPoint, false, lc.localChanCfg,
-		lc.remoteChanCfg)
+	keyRing := deriveCommitmentKeys(commitPoint, false,
+		lc.channelState.ChanType, lc.localChanCfg, lc.remoteChanCfg)
 
 	// Create a new commitment view which will calculate the evaluated
 	// state of the remote node's new commitment including our latest added
@@ -3029,33 +3077,32 @@ func (lc *LightningChannel) SignNextCommitment() (lnwire.Sig, []lnwire.Sig, erro
 		}),
 	)
 
-	// With the commitment view constructed, if there are any HTLC's, we'll
-	// need to generate signatures of each of them for the remote party's
-	// commitment state. We do so in two phases: first we generate and
-	// submit the set of signature jobs to the worker pool.
-	sigBatch, cancelChan, err := genRemoteHtlcSigJobs(keyRing,
-		lc.localChanCfg, lc.remoteChanCfg, newCommitView,
-	)
-	if err != nil {
-		return sig, htlcSigs, err
-	}
-	lc.sigPool.SubmitSignBatch(sigBatch)
-
-	// While the jobs are being carried out, we'll Sign their version of
-	// the new commitment transaction while we're waiting for the rest of
-	// the HTLC signatures to be processed.
+	// With the commitment view constructed, we'll sign their version of
+	// the new commitment transaction. We do so before signing any of the
+	// HTLC transactions that spend it, as a remote signer only signs
+	// spends of the commitments it has signed.
 	lc.signDesc.SigHashes = txscript.NewTxSigHashes(newCommitView.txn)
 	rawSig, err := lc.Signer.SignOutputRaw(newCommitView.txn, lc.signDesc)
 	if err != nil {
-		close(cancelChan)
 		return sig, htlcSigs, err
 	}
 	sig, err = lnwire.NewSigFromRawSignature(rawSig)
 	if err != nil {
-		close(cancelChan)
 		return sig, htlcSigs, err
 	}
 
+	// If there are any HTLC's, we'll need to generate signatures of each
+	// of them for the remote party's commitment state. We do so in two
+	// phases: first we generate and submit the set of signature jobs to
+	// the worker pool.
+	sigBatch, cancelChan, err := genRemoteHtlcSigJobs(keyRing,
+		lc.localChanCfg, lc.remoteChanCfg, newCommitView,
+	)
+	if err != nil {
+		return sig, htlcSigs, err
+	}
+	lc.sigPool.SubmitSignBatch(sigBatch)
+
 	// We'll need to send over the signatures to the remote party in the
 	// order as they appear on the commitment transaction after BIP 69
 	// sorting.
@@ -3155,15 +3202,17 @@ func (lc *LightningChannel) ProcessChanSyncMsg(
 	if hasRecoveryOptions && msg.RemoteCommitTailHeight != 0 {
 		// We'll check that they've really sent a valid commit
 		// secret from our shachain for our prior height, but only if
-		// this isn't the first state.
-		heightSecret, err := lc.channelState.RevocationProducer.AtIndex(
-			msg.RemoteCommitTailHeight - 1,
+		// this isn't the first state. As the signer may keep our
+		// secrets, we compare the commitment points they belong to.
+		heightPoint, err := deriveCommitPoint(
+			lc.Signer, lc.channelState,
+			msg.RemoteCommitTailHeight-1,
 		)
 		if err != nil {
 			return nil, nil, nil, err
 		}
-		commitSecretCorrect := bytes.Equal(
-			heightSecret[:], msg.LastRemoteCommitSecret[:],
+		commitSecretCorrect := heightPoint.IsEqual(
+			ComputeCommitmentPoint(msg.LastRemoteCommitSecret[:]),
 		)
 
 		// If the commit secret they sent is incorrect then we'll fail
@@ -3447,26 +3496,53 @@ func (lc *LightningChannel) ProcessChanSyncMsg(
 //   3. We didn't get the last RevokeAndAck message they sent, so they'll
 //      re-send it.
 func (lc *LightningChannel) ChanSyncMsg() (*lnwire.ChannelReestablish, error) {
+	return chanSyncMsg(
+		lc.channelState, lc.Signer, lc.localCommitChain.tip().height,
+		lc.remoteCommitChain.tail().height,
+	)
+}
+
+// ChanSyncMsg returns the ChannelReestablish message that should be sent to
+// the remote peer for the passed channel, based on the commitment heights
+// stored on disk. This is used for channels that aren't backed by an active
+// LightningChannel, such as channels restored from a static channel backup.
+// For those channels, the message will signal to the remote party that we've
+// lost state, prompting it to force close the channel. The signer is used to
+// derive our commitment point if it keeps the revocation secrets of the
+// channel.
+func ChanSyncMsg(c *channeldb.OpenChannel,
+	signer Signer) (*lnwire.ChannelReestablish, error) {
+
+	return chanSyncMsg(
+		c, signer, c.LocalCommitment.CommitHeight,
+		c.RemoteCommitment.CommitHeight,
+	)
+}
+
+// chanSyncMsg creates the ChannelReestablish message for the passed channel,
+// given the height of our local commitment, and the tail height of the remote
+// commitment chain.
+func chanSyncMsg(c *channeldb.OpenChannel, signer Signer, localHeight,
+	remoteChainTipHeight uint64) (*lnwire.ChannelReestablish, error) {
+
 	// The remote commitment height that we'll send in the
 	// ChannelReestablish message is our current commitment height plus
 	// one. If the receiver thinks that our commitment height is actually
 	// *equal* to this value, then they'll re-send the last commitment that
 	// they sent but we never fully processed.
-	localHeight := lc.localCommitChain.tip().height
 	nextLocalCommitHeight := localHeight + 1
 
 	// The second value we'll send is the height of the remote commitment
 	// from our PoV. If the receiver thinks that their height is actually
 	// *one plus* this value, then they'll re-send their last revocation.
-	remoteChainTipHeight := lc.remoteCommitChain.tail().height
-
+	//
 	// If this channel has undergone a commitment update, then in order to
 	// prove to the remote party our knowledge of their prior commitment
 	// state, we'll also send over the last commitment secret that the
 	// remote party sent.
 	var lastCommitSecret [32]byte
 	if remoteChainTipHeight != 0 {
-		remoteSecret, err := lc.channelState.RevocationStore.LookUp(
+		remoteSecret, err := c.RevocationStore.LookUp(
 			remoteChainTipHeight - 1,
 		)
 		if err != nil {
@@ -3477,23 +3553,17 @@ func (lc *LightningChannel) ChanSyncMsg() (*lnwire.ChannelReestablish, error) {
 
 	// Additionally, we'll send over the current unrevoked commitment on
 	// our local commitment transaction.
-	currentCommitSecret, err := lc.channelState.RevocationProducer.AtIndex(
-		localHeight,
-	)
+	currentCommitPoint, err := deriveCommitPoint(signer, c, localHeight)
 	if err != nil {
 		return nil, err
 	}
 
 	return &lnwire.ChannelReestablish{
-		ChanID: lnwire.NewChanIDFromOutPoint(
-			&lc.channelState.FundingOutpoint,
-		),
+		ChanID:                 lnwire.NewChanIDFromOutPoint(&c.FundingOutpoint),
 		NextLocalCommitHeight:  nextLocalCommitHeight,
 		RemoteCommitTailHeight: remoteChainTipHeight,
 		LastRemoteCommitSecret: lastCommitSecret,
-		LocalUnrevokedCommitPoint: ComputeCommitmentPoint(
-			currentCommitSecret[:],
-		),
+		LocalUnrevokedCommitPoint: currentCommitPoint,
 	}, nil
 }
 
@@ -3597,7 +3667,8 @@ func (lc *LightningChannel) computeView(view *htlcView, remoteChain bool,
 		totalHtlcWeight += HtlcWeight
 	}
 
-	totalCommitWeight := CommitWeight + totalHtlcWeight
+	totalCommitWeight := baseCommitWeight(lc.channelState.ChanType) +
+		totalHtlcWeight
 	return ourBalance, theirBalance, totalCommitWeight, filteredHTLCView, feePerKw
 }
 
@@ -3635,7 +3706,9 @@ func (lc *LightningChannel) validateCommitmentSanity(theirLogCounter,
 
 	// Calculate the commitment fee, and subtract it from the initiator's
 	// balance.
-	commitFee := feePerKw.FeeForWeight(commitWeight)
+	commitFee := commitFeeForWeight(
+		lc.channelState.ChanType, feePerKw, commitWeight,
+	)
 	commitFeeMsat := lnwire.NewMSatFromSatoshis(commitFee)
 	if lc.channelState.IsInitiator {
 		ourBalance -= commitFeeMsat
@@ -3985,13 +4058,14 @@ func (lc *LightningChannel) ReceiveNewCommitment(commitSig lnwire.Sig,
 	// as this will be needed to derive the keys required to construct the
 	// commitment.
 	nextHeight := lc.currentHeight + 1
-	commitSecret, err := lc.channelState.RevocationProducer.AtIndex(nextHeight)
+	commitPoint, err := deriveCommitPoint(
+		lc.Signer, lc.channelState, nextHeight,
+	)
 	if err != nil {
 		return err
 	}
-	commitPoint := ComputeCommitmentPoint(commitSecret[:])
-	keyRing := deriveCommitmentKeys(commitPoint, true, lc.localChanCfg,
-		lc.remoteChanCfg)
+	keyRing := deriveCommitmentKeys(commitPoint, true,
+		lc.channelState.ChanType, lc.localChanCfg, lc.remoteChanCfg)
 
 	// With the current commitment point re-calculated, construct the new
 	// commitment view which includes all the entries (pending or committed)
@@ -4471,12 +4545,7 @@ func (lc *LightningChannel) NextRevocationKey() (*btcec.PublicKey, error) {
 	defer lc.RUnlock()
 
 	nextHeight := lc.currentHeight + 1
-	revocation, err := lc.channelState.RevocationProducer.AtIndex(nextHeight)
-	if err != nil {
-		return nil, err
-	}
-
-	return ComputeCommitmentPoint(revocation[:]), nil
+	return deriveCommitPoint(lc.Signer, lc.channelState, nextHeight)
 }
 
 // InitNextRevocation inserts the passed commitment point as the _next_
@@ -4977,6 +5046,10 @@ type CommitOutputResolution struct {
 	// transaction. This value will be non-zero iff, this output was on our
 	// commitment transaction.
 	MaturityDelay uint32
+
+	// ChanType is the type of the closed channel. It determines how the
+	// output paying to us is spent.
+	ChanType channeldb.ChannelType
 }
 
 // UnilateralCloseSummary describes the details of a detected unilateral
@@ -5013,6 +5086,11 @@ type UnilateralCloseSummary struct {
 	// RemoteCommit is the exact commitment state that the remote party
 	// broadcast.
 	RemoteCommit channeldb.ChannelCommitment
+
+	// AnchorResolution contains the data required to sweep our anchor
+	// output on the remote commitment. If the channel doesn't use anchor
+	// outputs, then this will be nil.
+	AnchorResolution *AnchorResolution
 }
 
 // NewUnilateralCloseSummary creates a new summary that provides the caller
@@ -5034,8 +5112,8 @@ func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 	// First, we'll generate the commitment point and the revocation point
 	// so we can re-construct the HTLC state and also our payment key.
 	keyRing := deriveCommitmentKeys(
-		commitPoint, false, &chanState.LocalChanCfg,
-		&chanState.RemoteChanCfg,
+		commitPoint, false, chanState.ChanType,
+		&chanState.LocalChanCfg, &chanState.RemoteChanCfg,
 	)
 
 	// Next, we'll obtain HTLC resolutions for all the outgoing HTLC's we
@@ -5094,6 +5172,7 @@ func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 				HashType: txscript.SigHashAll,
 			},
 			MaturityDelay: 0,
+			ChanType:      chanState.ChanType,
 		}
 	}
 
@@ -5112,12 +5191,22 @@ func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 		LocalChanConfig:         chanState.LocalChanCfg,
 	}
 
+	// Our anchor output on their commitment is encumbered by our funding
+	// key as well, so we'll prepare the materials needed to sweep it.
+	anchorResolution, err := NewAnchorResolution(
+		chanState, commitTxBroadcast,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &UnilateralCloseSummary{
 		SpendDetail:         commitSpend,
 		ChannelCloseSummary: closeSummary,
 		CommitResolution:    commitResolution,
 		HtlcResolutions:     htlcResolutions,
 		RemoteCommit:        remoteCommit,
+		AnchorResolution:    anchorResolution,
 	}, nil
 }
 
@@ -5587,6 +5676,13 @@ type LocalForceCloseSummary struct {
 	// HTLC's, we'll need to go to the second level to sweep them fully.
 	HtlcResolutions *HtlcResolutions
 
+	// AnchorResolution contains the data required to spend our anchor
+	// output on the commitment transaction, allowing its fee to be bumped
+	// using CPFP.
+	//
+	// NOTE: This will be nil if the channel doesn't use anchor outputs.
+	AnchorResolution *AnchorResolution
+
 	// ChanSnapshot is a snapshot of the final state of the channel at the
 	// time the summary was created.
 	ChanSnapshot channeldb.ChannelSnapshot
@@ -5637,15 +5733,14 @@ func NewLocalForceCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 	// output in the commitment transaction and potentially for creating
 	// the sign descriptor.
 	csvTimeout := uint32(chanState.LocalChanCfg.CsvDelay)
-	revocation, err := chanState.RevocationProducer.AtIndex(
-		localCommit.CommitHeight,
+	commitPoint, err := deriveCommitPoint(
+		signer, chanState, localCommit.CommitHeight,
 	)
 	if err != nil {
 		return nil, err
 	}
-	commitPoint := ComputeCommitmentPoint(revocation[:])
-	keyRing := deriveCommitmentKeys(commitPoint, true, &chanState.LocalChanCfg,
-		&chanState.RemoteChanCfg)
+	keyRing := deriveCommitmentKeys(commitPoint, true, chanState.ChanType,
+		&chanState.LocalChanCfg, &chanState.RemoteChanCfg)
 	selfScript, err := CommitScriptToSelf(csvTimeout, keyRing.DelayKey,
 		keyRing.RevocationKey)
 	if err != nil {
@@ -5701,6 +5796,7 @@ func NewLocalForceCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 				HashType: txscript.SigHashAll,
 			},
 			MaturityDelay: csvTimeout,
+			ChanType:      chanState.ChanType,
 		}
 	}
 
@@ -5716,11 +5812,19 @@ func NewLocalForceCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
 		return nil, err
 	}
 
+	// Finally, if the channel uses anchor outputs, we'll also prepare the
+	// materials needed to bump the fee of the commitment using our anchor.
+	anchorResolution, err := NewAnchorResolution(chanState, commitTx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &LocalForceCloseSummary{
 		ChanPoint:        chanState.FundingOutpoint,
 		CloseTx:          commitTx,
 		CommitResolution: commitResolution,
 		HtlcResolutions:  htlcResolutions,
+		AnchorResolution: anchorResolution,
 		ChanSnapshot:     *chanState.Snapshot(),
 	}, nil
 }
@@ -5906,7 +6010,9 @@ func (lc *LightningChannel) availableBalance() (lnwire.MilliSatoshi, int64) {
 
 	// If we are the channel initiator, we must remember to subtract the
 	// commitment fee from our available balance.
-	commitFee := feePerKw.FeeForWeight(commitWeight)
+	commitFee := commitFeeForWeight(
+		lc.channelState.ChanType, feePerKw, commitWeight,
+	)
 	if lc.channelState.IsInitiator {
 		ourBalance -= lnwire.NewMSatFromSatoshis(commitFee)
 	}
@@ -5941,7 +6047,7 @@ func (lc *LightningChannel) validateFeeRate(feePerKw SatPerKWeight) error {
 	// a commitment now, we'll compute our remaining balance if we apply
 	// this new fee update.
 	newFee := lnwire.NewMSatFromSatoshis(
-		feePerKw.FeeForWeight(txWeight),
+		commitFeeForWeight(lc.channelState.ChanType, feePerKw, txWeight),
 	)
 
 	// If the total fee exceeds our available balance (taking into account
@@ -6019,8 +6125,12 @@ func (lc *LightningChannel) generateRevocation(height uint64) (*lnwire.RevokeAnd
 
 	// Now that we've accept a new state transition, we send the remote
 	// party the revocation for our current commitment state.
+	// The commitment that replaces the revoked one is always the tip of
+	// our commitment chain.
 	revocationMsg := &lnwire.RevokeAndAck{}
-	commitSecret, err := lc.channelState.RevocationProducer.AtIndex(height)
+	commitSecret, err := revokeCommitment(
+		lc.Signer, lc.channelState, height, lc.localCommitChain.tip(),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -6039,14 +6149,12 @@ func (lc *LightningChannel) generateRevocation(height uint64) (*lnwire.RevokeAnd
 	// revocation.
 	//
 	// Put simply in the window slides to the left by one.
-	nextCommitSecret, err := lc.channelState.RevocationProducer.AtIndex(
-		height + 2,
+	revocationMsg.NextRevocationKey, err = deriveCommitPoint(
+		lc.Signer, lc.channelState, height+2,
 	)
 	if err != nil {
 		return nil, err
 	}
-
-	revocationMsg.NextRevocationKey = ComputeCommitmentPoint(nextCommitSecret[:])
 	revocationMsg.ChanID = lnwire.NewChanIDFromOutPoint(
 		&lc.channelState.FundingOutpoint)
 
@@ -6104,9 +6212,57 @@ func CreateCommitTx(fundingOutput wire.TxIn,
 		})
 	}
 
+	// If the key ring carries anchor keys, then we'll also add an anchor
+	// output for each party. Their value is paid for by the initiator as
+	// part of the commitment fee, so we always add both of them.
+	if keyRing.DelayAnchorKey != nil && keyRing.NoDelayAnchorKey != nil {
+		for _, anchorKey := range []*btcec.PublicKey{
+			keyRing.DelayAnchorKey, keyRing.NoDelayAnchorKey,
+		} {
+			anchorScript, err := CommitScriptAnchor(anchorKey)
+			if err != nil {
+				return nil, err
+			}
+			anchorScriptHash, err := WitnessScriptHash(anchorScript)
+			if err != nil {
+				return nil, err
+			}
+
+			commitTx.AddTxOut(&wire.TxOut{
+				PkScript: anchorScriptHash,
+				Value:    int64(AnchorSize),
+			})
+		}
+	}
+
 	return commitTx, nil
 }
 
+// baseCommitWeight returns the weight of the base commitment transaction,
+// without any HTLC outputs, for the given channel type.
+func baseCommitWeight(chanType channeldb.ChannelType) int64 {
+	if chanType.HasAnchors() {
+		return AnchorCommitWeight
+	}
+
+	return CommitWeight
+}
+
+// commitFeeForWeight returns the fee that the initiator pays for a commitment
+// transaction of the given weight at the given fee rate. For channels with
+// anchor outputs, this also includes the value of both anchors, as they're
+// paid for by the initiator as well.
+func commitFeeForWeight(chanType channeldb.ChannelType, feePerKw SatPerKWeight,
+	weight int64) btcutil.Amount {
+
+	fee := feePerKw.FeeForWeight(weight)
+	if chanType.HasAnchors() {
+		fee += 2 * AnchorSize
+	}
+
+	return fee
+}
+
 // CreateCooperativeCloseTx creates a transaction which if signed by both
 // parties, then broadcast cooperatively closes an active channel. The creation
 // of the closure transaction is modified by a boolean indicating if the party
@@ -6145,8 +6301,10 @@ func CreateCooperativeCloseTx(fundingTxIn wire.TxIn,
 	return closeTx
 }
 
-// CalcFee returns the commitment fee to use for the given
-// fee rate (fee-per-kw).
+// CalcFee returns the cooperative close fee to use for the given fee rate
+// (fee-per-kw). The closing transaction has neither anchor outputs nor HTLCs,
+// so its fee is based on the plain commitment weight regardless of the
+// channel type.
 func (lc *LightningChannel) CalcFee(feeRate SatPerKWeight) btcutil.Amount {
 	return feeRate.FeeForWeight(CommitWeight)
 }
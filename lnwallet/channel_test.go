@@ -17,6 +17,7 @@ import (
 	"github.com/btcsuite/btcutil"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
@@ -4819,7 +4820,7 @@ func TestChannelUnilateralClosePendingCommit(t *testing.T) {
 	})
 	aliceSignDesc.SigHashes = txscript.NewTxSigHashes(sweepTx)
 	sweepTx.TxIn[0].Witness, err = CommitSpendNoDelay(
-		aliceChannel.Signer, &aliceSignDesc, sweepTx,
+		aliceChannel.Signer, &aliceSignDesc, sweepTx, false,
 	)
 	if err != nil {
 		t.Fatalf("unable to generate sweep witness: %v", err)
@@ -4840,6 +4841,236 @@ func TestChannelUnilateralClosePendingCommit(t *testing.T) {
 	}
 }
 
+// TestChannelUnilateralCloseTweakless tests that for a channel using the
+// tweakless commitment format, we're able to locate and sweep our output on
+// the remote party's commitment transaction even if we use the wrong
+// commitment point, as would be the case after data loss.
+func TestChannelUnilateralCloseTweakless(t *testing.T) {
+	t.Parallel()
+
+	// Create a test channel which will be used for the duration of this
+	// unittest. The channel will be funded evenly with Alice having 5 BTC,
+	// and Bob having 5 BTC.
+	aliceChannel, bobChannel, cleanUp, err := CreateTestChannelsWithType(
+		channeldb.SingleFunderTweakless,
+	)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// We'll add an HTLC from Alice to Bob and fully lock it in, so that
+	// the commitment point of Bob's current commitment differs from the
+	// one Alice started out with.
+	htlcAmount := lnwire.NewMSatFromSatoshis(20000)
+	htlcAlice, _ := createHTLC(0, htlcAmount)
+	if _, err := aliceChannel.AddHTLC(htlcAlice, nil); err != nil {
+		t.Fatalf("alice unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlcAlice); err != nil {
+		t.Fatalf("bob unable to recv add htlc: %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to complete state transition: %v", err)
+	}
+
+	// Bob now force closes the channel by broadcasting his latest
+	// commitment.
+	bobCommit := bobChannel.channelState.LocalCommitment.CommitTx
+	bobTxHash := bobCommit.TxHash()
+	spendDetail := &chainntnfs.SpendDetail{
+		SpenderTxHash: &bobTxHash,
+		SpendingTx:    bobCommit,
+	}
+
+	// As the output paying to Alice isn't tweaked, she should be able to
+	// locate it using an unrelated commitment point.
+	_, unknownPoint := btcec.PrivKeyFromBytes(
+		btcec.S256(), bytes.Repeat([]byte{0x7}, 32),
+	)
+	aliceCloseSummary, err := NewUnilateralCloseSummary(
+		aliceChannel.channelState, aliceChannel.Signer,
+		aliceChannel.pCache, spendDetail, channeldb.ChannelCommitment{},
+		unknownPoint,
+	)
+	if err != nil {
+		t.Fatalf("unable to create alice close summary: %v", err)
+	}
+	if aliceCloseSummary.CommitResolution == nil {
+		t.Fatalf("unable to find alice's commit resolution")
+	}
+
+	aliceSignDesc := aliceCloseSummary.CommitResolution.SelfOutputSignDesc
+	if aliceSignDesc.SingleTweak != nil {
+		t.Fatalf("expected no single tweak for tweakless channel")
+	}
+
+	// Finally, we'll ensure that Alice is able to sweep the output using
+	// the tweakless witness.
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: aliceCloseSummary.CommitResolution.SelfOutPoint,
+	})
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: testHdSeed[:],
+		Value:    aliceSignDesc.Output.Value,
+	})
+	aliceSignDesc.SigHashes = txscript.NewTxSigHashes(sweepTx)
+	sweepTx.TxIn[0].Witness, err = CommitSpendNoDelay(
+		aliceChannel.Signer, &aliceSignDesc, sweepTx, true,
+	)
+	if err != nil {
+		t.Fatalf("unable to generate sweep witness: %v", err)
+	}
+
+	vm, err := txscript.NewEngine(
+		aliceSignDesc.Output.PkScript,
+		sweepTx, 0, txscript.StandardVerifyFlags, nil,
+		nil, aliceSignDesc.Output.Value,
+	)
+	if err != nil {
+		t.Fatalf("unable to create engine: %v", err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("tweakless sweep is invalid: %v", err)
+	}
+}
+
+// TestForceCloseAnchors tests that the commitment transaction of a channel
+// using anchor outputs carries an anchor for each party, and that we're able
+// to spend our own anchor in order to bump the fee of the commitment.
+func TestForceCloseAnchors(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := CreateTestChannelsWithType(
+		channeldb.SingleFunderAnchors,
+	)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	htlcAmount := lnwire.NewMSatFromSatoshis(20000)
+	htlcAlice, _ := createHTLC(0, htlcAmount)
+	if _, err := aliceChannel.AddHTLC(htlcAlice, nil); err != nil {
+		t.Fatalf("alice unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlcAlice); err != nil {
+		t.Fatalf("bob unable to recv add htlc: %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to complete state transition: %v", err)
+	}
+
+	closeSummary, err := aliceChannel.ForceClose()
+	if err != nil {
+		t.Fatalf("unable to force close channel: %v", err)
+	}
+
+	// The commitment should hold both anchors, each carrying the same
+	// value.
+	var numAnchors int
+	for _, txOut := range closeSummary.CloseTx.TxOut {
+		if btcutil.Amount(txOut.Value) == AnchorSize {
+			numAnchors++
+		}
+	}
+	if numAnchors != 2 {
+		t.Fatalf("expected 2 anchor outputs, found %v", numAnchors)
+	}
+
+	anchor := closeSummary.AnchorResolution
+	if anchor == nil {
+		t.Fatalf("expected anchor resolution for anchor channel")
+	}
+	if anchor.CommitFeeRate() <= 0 {
+		t.Fatalf("expected positive commitment fee rate, got %v",
+			anchor.CommitFeeRate())
+	}
+
+	// Finally, we'll ensure that Alice is able to spend her anchor using
+	// her funding key.
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: anchor.CommitAnchor,
+	})
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: testHdSeed[:],
+		Value:    int64(AnchorSize) / 2,
+	})
+	signDesc := anchor.AnchorSignDesc
+	signDesc.SigHashes = txscript.NewTxSigHashes(sweepTx)
+	sweepTx.TxIn[0].Witness, err = CommitSpendAnchor(
+		aliceChannel.Signer, &signDesc, sweepTx,
+	)
+	if err != nil {
+		t.Fatalf("unable to generate anchor witness: %v", err)
+	}
+
+	vm, err := txscript.NewEngine(
+		signDesc.Output.PkScript, sweepTx, 0,
+		txscript.StandardVerifyFlags, nil, nil, signDesc.Output.Value,
+	)
+	if err != nil {
+		t.Fatalf("unable to create engine: %v", err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("anchor sweep is invalid: %v", err)
+	}
+
+	// Bob's anchor can be spent by anyone once it has AnchorCSVDelay
+	// confirmations, without any signature.
+	if anchor.RemoteAnchor == nil {
+		t.Fatalf("expected remote anchor on commitment")
+	}
+	anyoneTx := wire.NewMsgTx(2)
+	anyoneTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *anchor.RemoteAnchor,
+		Sequence:         AnchorCSVDelay,
+	})
+	anyoneTx.AddTxOut(&wire.TxOut{
+		PkScript: testHdSeed[:],
+		Value:    int64(AnchorSize) / 2,
+	})
+	remoteSignDesc := anchor.RemoteAnchorSignDesc
+	witnessFunc := CommitmentAnchorAnyone.GenWitnessFunc(
+		aliceChannel.Signer, &remoteSignDesc,
+	)
+	anyoneTx.TxIn[0].Witness, err = witnessFunc(
+		anyoneTx, txscript.NewTxSigHashes(anyoneTx), 0,
+	)
+	if err != nil {
+		t.Fatalf("unable to generate anchor witness: %v", err)
+	}
+
+	vm, err = txscript.NewEngine(
+		remoteSignDesc.Output.PkScript, anyoneTx, 0,
+		txscript.StandardVerifyFlags, nil, nil,
+		remoteSignDesc.Output.Value,
+	)
+	if err != nil {
+		t.Fatalf("unable to create engine: %v", err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("remote anchor sweep is invalid: %v", err)
+	}
+
+	// Before the CSV delay has passed, the anchor can't be spent by
+	// anyone.
+	anyoneTx.TxIn[0].Sequence = AnchorCSVDelay - 1
+	vm, err = txscript.NewEngine(
+		remoteSignDesc.Output.PkScript, anyoneTx, 0,
+		txscript.StandardVerifyFlags, nil, nil,
+		remoteSignDesc.Output.Value,
+	)
+	if err != nil {
+		t.Fatalf("unable to create engine: %v", err)
+	}
+	if err := vm.Execute(); err == nil {
+		t.Fatalf("expected immature remote anchor sweep to be invalid")
+	}
+}
+
 // TestDesyncHTLCs checks that we cannot add HTLCs that would make the
 // balance negative, when the remote and local update logs are desynced.
 func TestDesyncHTLCs(t *testing.T) {
@@ -6146,3 +6377,158 @@ func TestChannelRestoreCommitHeight(t *testing.T) {
 	aliceChannel.Stop()
 	bobChannel.Stop()
 }
+
+// TestSpliceOut tests that both parties to a channel arrive at the same splice
+// transaction and commitments when splicing funds out of the channel, and
+// that the splice can only be completed with valid signatures.
+func TestSpliceOut(t *testing.T) {
+	t.Parallel()
+
+	// Create a test channel which will be used for the duration of this
+	// unittest. The channel will be funded evenly with Alice having 5 BTC,
+	// and Bob having 5 BTC. Alice is the initiator of the channel.
+	aliceChannel, bobChannel, cleanUp, err := CreateTestChannels()
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	payoutScript := append(
+		[]byte{txscript.OP_0, txscript.OP_DATA_20},
+		bytes.Repeat([]byte{0x01}, 20)...,
+	)
+	payoutAmt := btcutil.Amount(btcutil.SatoshiPerBitcoin)
+	feePerKw := SatPerKWeight(6000)
+
+	// Bob isn't the initiator of the channel, so the payout is deducted
+	// from Alice's balance. Splicing out more than she owns should fail.
+	_, err = aliceChannel.NewSpliceOut(payoutAmt*6, payoutScript, feePerKw)
+	if err != ErrSpliceBelowReserve {
+		t.Fatalf("expected ErrSpliceBelowReserve, got %v", err)
+	}
+
+	aliceSplice, err := aliceChannel.NewSpliceOut(
+		payoutAmt, payoutScript, feePerKw,
+	)
+	if err != nil {
+		t.Fatalf("unable to create splice: %v", err)
+	}
+	bobSplice, err := bobChannel.NewSpliceOut(
+		payoutAmt, payoutScript, feePerKw,
+	)
+	if err != nil {
+		t.Fatalf("unable to create splice: %v", err)
+	}
+
+	// Both parties should arrive at the same splice transaction, with the
+	// capacity reduced by the payout and the fee.
+	if aliceSplice.SpliceTx().TxHash() != bobSplice.SpliceTx().TxHash() {
+		t.Fatalf("splice transactions don't match: %v vs %v",
+			spew.Sdump(aliceSplice.SpliceTx()),
+			spew.Sdump(bobSplice.SpliceTx()))
+	}
+	spliceFee := feePerKw.FeeForWeight(spliceTxWeight)
+	expectedCapacity := aliceChannel.Capacity - payoutAmt - spliceFee
+	if aliceSplice.Capacity() != expectedCapacity {
+		t.Fatalf("expected capacity %v, got %v", expectedCapacity,
+			aliceSplice.Capacity())
+	}
+	fundingOutpoint := aliceSplice.FundingOutpoint()
+	fundingOutput := aliceSplice.SpliceTx().TxOut[fundingOutpoint.Index]
+	if btcutil.Amount(fundingOutput.Value) != expectedCapacity {
+		t.Fatalf("expected funding output of %v, got %v",
+			expectedCapacity, fundingOutput.Value)
+	}
+
+	// Alice shouldn't be able to sign the splice transaction before she
+	// holds Bob's signature for her new commitment.
+	if _, err := aliceSplice.SignSpliceTx(); err == nil {
+		t.Fatalf("expected signing splice tx to fail")
+	}
+
+	// Bob will now sign Alice's new commitment. His signature should be
+	// rejected for his own commitment.
+	bobCommitSig, err := bobSplice.SignRemoteCommit()
+	if err != nil {
+		t.Fatalf("unable to sign commitment: %v", err)
+	}
+	if err := bobSplice.ReceiveCommitSig(bobCommitSig); err == nil {
+		t.Fatalf("expected invalid commit sig to be rejected")
+	}
+	if err := aliceSplice.ReceiveCommitSig(bobCommitSig); err != nil {
+		t.Fatalf("unable to receive commit sig: %v", err)
+	}
+	aliceCommitSig, err := aliceSplice.SignRemoteCommit()
+	if err != nil {
+		t.Fatalf("unable to sign commitment: %v", err)
+	}
+	if err := bobSplice.ReceiveCommitSig(aliceCommitSig); err != nil {
+		t.Fatalf("unable to receive commit sig: %v", err)
+	}
+
+	// With the commitments signed, both parties should be able to
+	// complete the splice transaction using each other's signature.
+	aliceSpliceSig, err := aliceSplice.SignSpliceTx()
+	if err != nil {
+		t.Fatalf("unable to sign splice tx: %v", err)
+	}
+	bobSpliceSig, err := bobSplice.SignSpliceTx()
+	if err != nil {
+		t.Fatalf("unable to sign splice tx: %v", err)
+	}
+	if _, err := bobSplice.CompleteSpliceTx(bobSpliceSig); err == nil {
+		t.Fatalf("expected invalid splice sig to be rejected")
+	}
+	if _, err := bobSplice.CompleteSpliceTx(aliceSpliceSig); err != nil {
+		t.Fatalf("unable to complete splice tx: %v", err)
+	}
+	if _, err := aliceSplice.CompleteSpliceTx(bobSpliceSig); err != nil {
+		t.Fatalf("unable to complete splice tx: %v", err)
+	}
+
+	// Finally, the new commitments should spend the new funding output,
+	// with Alice's balance reduced by the payout and fee.
+	aliceState := aliceSplice.PendingSplice(100)
+	bobState := bobSplice.PendingSplice(100)
+	if aliceState.LocalCommitment.CommitTx.TxHash() !=
+		bobState.RemoteCommitment.CommitTx.TxHash() {
+
+		t.Fatalf("alice's commitment doesn't match")
+	}
+	commitIn := aliceState.LocalCommitment.CommitTx.TxIn[0]
+	if commitIn.PreviousOutPoint != fundingOutpoint {
+		t.Fatalf("commitment doesn't spend new funding output")
+	}
+
+	deduction := lnwire.NewMSatFromSatoshis(payoutAmt + spliceFee)
+	oldBalance := aliceChannel.channelState.LocalCommitment.LocalBalance
+	if aliceState.LocalCommitment.LocalBalance != oldBalance-deduction {
+		t.Fatalf("expected balance %v, got %v", oldBalance-deduction,
+			aliceState.LocalCommitment.LocalBalance)
+	}
+	if bobState.LocalCommitment.RemoteBalance != oldBalance-deduction {
+		t.Fatalf("expected balance %v, got %v", oldBalance-deduction,
+			bobState.LocalCommitment.RemoteBalance)
+	}
+}
+
+// TestCloseFeeAnchors tests that the cooperative close fee of a channel using
+// anchor outputs doesn't include the anchors, as the closing transaction has
+// none.
+func TestCloseFeeAnchors(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, _, cleanUp, err := CreateTestChannelsWithType(
+		channeldb.SingleFunderAnchors,
+	)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	feeRate := SatPerKWeight(5000)
+	expectedFee := feeRate.FeeForWeight(CommitWeight)
+	if fee := aliceChannel.CalcFee(feeRate); fee != expectedFee {
+		t.Fatalf("expected close fee of %v, got %v", expectedFee, fee)
+	}
+}
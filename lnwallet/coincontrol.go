@@ -0,0 +1,347 @@
+package lnwallet
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/txsort"
+)
+
+// fetchWalletUtxos looks up the passed wallet outputs so they can be spent
+// explicitly. Each output must be an unspent witness output of the wallet.
+// Outputs that are reserved by a pending funding flow are rejected, as are
+// leased outputs unless they're leased under the passed lock ID.
+//
+// NOTE: The coin select mutex MUST be held when calling this method.
+func (l *LightningWallet) fetchWalletUtxos(outpoints []wire.OutPoint,
+	lockID *[32]byte) ([]*Utxo, error) {
+
+	seen := make(map[wire.OutPoint]struct{}, len(outpoints))
+	for _, op := range outpoints {
+		if _, ok := seen[op]; ok {
+			return nil, fmt.Errorf("duplicate outpoint %v", op)
+		}
+		seen[op] = struct{}{}
+
+		if _, ok := l.lockedOutPoints[op]; ok {
+			return nil, fmt.Errorf("outpoint %v is reserved by a "+
+				"pending channel", op)
+		}
+
+		id, ok := l.leaseID(op)
+		if !ok {
+			continue
+		}
+		if lockID == nil || id != *lockID {
+			return nil, fmt.Errorf("outpoint %v is leased under "+
+				"a different lock ID", op)
+		}
+
+		// Leased outputs are locked in the wallet, which hides them
+		// from the list of unspent outputs. We unlock the outputs
+		// leased by the caller until we've looked them up. As the
+		// coin select mutex is held, they can't be selected by anyone
+		// else in the meantime.
+		l.UnlockOutpoint(op)
+		defer l.LockOutpoint(op)
+	}
+
+	unspent, err := l.ListUnspentWitness(0)
+	if err != nil {
+		return nil, err
+	}
+	unspentByOutPoint := make(map[wire.OutPoint]*Utxo, len(unspent))
+	for _, utxo := range unspent {
+		unspentByOutPoint[utxo.OutPoint] = utxo
+	}
+
+	utxos := make([]*Utxo, 0, len(outpoints))
+	for _, op := range outpoints {
+		utxo, ok := unspentByOutPoint[op]
+		if !ok {
+			return nil, fmt.Errorf("outpoint %v is not an unspent "+
+				"witness output of the wallet", op)
+		}
+
+		utxos = append(utxos, utxo)
+	}
+
+	return utxos, nil
+}
+
+// SendOutputsWithInputs creates, signs and broadcasts a transaction that
+// spends exactly the passed wallet outputs to the given outputs, adhering to
+// the specified fee rate. Any remainder that isn't dust is sent to a new
+// change address of the wallet. Outputs leased under the passed lock ID, if
+// any, may be spent as well.
+func (l *LightningWallet) SendOutputsWithInputs(outpoints []wire.OutPoint,
+	lockID *[32]byte, outputs []*wire.TxOut,
+	feeRate SatPerKWeight) (*chainhash.Hash, error) {
+
+	if len(outpoints) == 0 {
+		return nil, fmt.Errorf("no outpoints specified")
+	}
+
+	// We hold the coin select mutex until the transaction is published to
+	// make sure the inputs aren't concurrently used to fund a channel.
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	utxos, err := l.fetchWalletUtxos(outpoints, lockID)
+	if err != nil {
+		return nil, err
+	}
+
+	txid, err := l.sendUtxos(utxos, outputs, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	walletLog.Infof("Published tx %v spending %v selected outputs", txid,
+		len(utxos))
+
+	return txid, nil
+}
+
+// SendOutputs funds, signs, and broadcasts a transaction paying out to the
+// specified outputs. A watch-only wallet can't sign the transaction itself,
+// so in that case the coins are selected here and the inputs are signed
+// through the Signer. Otherwise this is handled by the WalletController.
+func (l *LightningWallet) SendOutputs(outputs []*wire.TxOut,
+	feeRate SatPerKWeight) (*chainhash.Hash, error) {
+
+	if !l.Cfg.WatchOnly {
+		return l.WalletController.SendOutputs(outputs, feeRate)
+	}
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	// Like the WalletController, we only spend confirmed outputs.
+	coins, err := l.ListUnspentWitness(1)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalOut btcutil.Amount
+	for _, output := range outputs {
+		totalOut += btcutil.Amount(output.Value)
+	}
+
+	amtNeeded := totalOut
+	for {
+		totalIn, utxos, err := selectInputs(amtNeeded, coins)
+		if err != nil {
+			return nil, err
+		}
+
+		// We'll assume a change output to estimate the fee of the
+		// selected coins. If they don't suffice to pay for it, we
+		// perform another round of coin selection.
+		var weightEstimate TxWeightEstimator
+		for _, utxo := range utxos {
+			err := addWalletInputWeight(
+				&weightEstimate, utxo.PkScript,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, output := range outputs {
+			weightEstimate.AddTxOutput(output)
+		}
+		weightEstimate.AddP2WKHOutput()
+
+		fee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+		if totalIn < totalOut+fee {
+			amtNeeded = totalOut + fee
+			continue
+		}
+
+		return l.sendUtxos(utxos, outputs, feeRate)
+	}
+}
+
+// sendUtxos creates, signs and broadcasts a transaction that spends exactly
+// the passed wallet outputs to the given outputs, adhering to the specified
+// fee rate. Any remainder that isn't dust is sent to a new change address of
+// the wallet.
+//
+// NOTE: The coin select mutex MUST be held when calling this method.
+func (l *LightningWallet) sendUtxos(utxos []*Utxo, outputs []*wire.TxOut,
+	feeRate SatPerKWeight) (*chainhash.Hash, error) {
+
+	var (
+		weightEstimate TxWeightEstimator
+		totalIn        btcutil.Amount
+		totalOut       btcutil.Amount
+	)
+	tx := wire.NewMsgTx(2)
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut, len(utxos))
+	for _, utxo := range utxos {
+		err := addWalletInputWeight(&weightEstimate, utxo.PkScript)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.AddTxIn(wire.NewTxIn(&utxo.OutPoint, nil, nil))
+		prevOuts[utxo.OutPoint] = &wire.TxOut{
+			Value:    int64(utxo.Value),
+			PkScript: utxo.PkScript,
+		}
+		totalIn += utxo.Value
+	}
+	for _, output := range outputs {
+		weightEstimate.AddTxOutput(output)
+		tx.AddTxOut(output)
+		totalOut += btcutil.Amount(output.Value)
+	}
+
+	// Without a change output, all of the excess goes to the miners.
+	// We'll add a change output only if its value after paying for its
+	// own weight isn't dust.
+	fee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+	if totalIn < totalOut+fee {
+		return nil, &ErrInsufficientFunds{totalOut + fee, totalIn}
+	}
+
+	weightEstimate.AddP2WKHOutput()
+	feeWithChange := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+	if totalIn > totalOut+feeWithChange {
+		changeAmt := totalIn - totalOut - feeWithChange
+		if changeAmt > DefaultDustLimit() {
+			changeAddr, err := l.NewAddress(WitnessPubKey, true)
+			if err != nil {
+				return nil, err
+			}
+			changeScript, err := txscript.PayToAddrScript(changeAddr)
+			if err != nil {
+				return nil, err
+			}
+
+			tx.AddTxOut(&wire.TxOut{
+				Value:    int64(changeAmt),
+				PkScript: changeScript,
+			})
+		}
+	}
+
+	return l.publishWalletTx(tx, prevOuts)
+}
+
+// SweepAllOutputs creates, signs and broadcasts a transaction that sends the
+// passed wallet outputs, or all confirmed and unlocked outputs of the wallet
+// if none are passed, to a single output paying to pkScript. The value of the
+// output is the total value of the inputs minus the fee required by the
+// passed fee rate for the exact weight of the transaction. Passed outputs
+// leased under the given lock ID, if any, may be spent as well.
+func (l *LightningWallet) SweepAllOutputs(outpoints []wire.OutPoint,
+	lockID *[32]byte, pkScript []byte,
+	feeRate SatPerKWeight) (*chainhash.Hash, error) {
+
+	// We hold the coin select mutex until the transaction is published to
+	// make sure the inputs aren't concurrently used to fund a channel.
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	var (
+		utxos []*Utxo
+		err   error
+	)
+	if len(outpoints) != 0 {
+		utxos, err = l.fetchWalletUtxos(outpoints, lockID)
+	} else {
+		utxos, err = l.ListUnspentWitness(1)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tx, prevOuts, err := createSweepAllTx(utxos, pkScript, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	txid, err := l.publishWalletTx(tx, prevOuts)
+	if err != nil {
+		return nil, err
+	}
+
+	walletLog.Infof("Published tx %v sweeping %v wallet outputs", txid,
+		len(utxos))
+
+	return txid, nil
+}
+
+// createSweepAllTx creates an unsigned transaction spending all of the passed
+// wallet outputs to a single output paying to pkScript, minus the fee for the
+// estimated weight of the signed transaction. The spent outputs are returned
+// as well, indexed by their outpoint.
+func createSweepAllTx(utxos []*Utxo, pkScript []byte,
+	feeRate SatPerKWeight) (*wire.MsgTx, map[wire.OutPoint]*wire.TxOut,
+	error) {
+
+	if len(utxos) == 0 {
+		return nil, nil, fmt.Errorf("no outputs to sweep")
+	}
+
+	var (
+		weightEstimate TxWeightEstimator
+		totalIn        btcutil.Amount
+	)
+	tx := wire.NewMsgTx(2)
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut, len(utxos))
+	for _, utxo := range utxos {
+		err := addWalletInputWeight(&weightEstimate, utxo.PkScript)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tx.AddTxIn(wire.NewTxIn(&utxo.OutPoint, nil, nil))
+		prevOuts[utxo.OutPoint] = &wire.TxOut{
+			Value:    int64(utxo.Value),
+			PkScript: utxo.PkScript,
+		}
+		totalIn += utxo.Value
+	}
+
+	sweepOutput := &wire.TxOut{PkScript: pkScript}
+	weightEstimate.AddTxOutput(sweepOutput)
+
+	fee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+	if totalIn-fee <= DefaultDustLimit() {
+		return nil, nil, &ErrInsufficientFunds{
+			fee + DefaultDustLimit(), totalIn,
+		}
+	}
+	sweepOutput.Value = int64(totalIn - fee)
+	tx.AddTxOut(sweepOutput)
+
+	return tx, prevOuts, nil
+}
+
+// publishWalletTx sorts the passed transaction, signs all of its inputs which
+// spend the passed wallet outputs and broadcasts it.
+func (l *LightningWallet) publishWalletTx(tx *wire.MsgTx,
+	prevOuts map[wire.OutPoint]*wire.TxOut) (*chainhash.Hash, error) {
+
+	txsort.InPlaceSort(tx)
+
+	signOuts := make([]*wire.TxOut, len(tx.TxIn))
+	for i, txIn := range tx.TxIn {
+		signOuts[i] = prevOuts[txIn.PreviousOutPoint]
+	}
+	if err := l.signWalletInputs(tx, signOuts); err != nil {
+		return nil, err
+	}
+
+	if err := l.PublishTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	txid := tx.TxHash()
+	return &txid, nil
+}
@@ -0,0 +1,218 @@
+package lnwallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// TestCreateSweepAllTx asserts that sweeping the entire wallet results in a
+// single output that pays the fee for the exact weight of the transaction,
+// and that dust sweeps are rejected.
+func TestCreateSweepAllTx(t *testing.T) {
+	t.Parallel()
+
+	p2wkhScript := make([]byte, P2WPKHSize)
+	p2wkhScript[1] = 0x14
+	np2wkhScript := make([]byte, 23)
+	np2wkhScript[0] = 0xa9
+	np2wkhScript[1] = 0x14
+	np2wkhScript[22] = 0x87
+
+	utxos := []*Utxo{
+		{
+			AddressType: WitnessPubKey,
+			Value:       btcutil.Amount(100000),
+			PkScript:    p2wkhScript,
+			OutPoint:    wire.OutPoint{Index: 1},
+		},
+		{
+			AddressType: NestedWitnessPubKey,
+			Value:       btcutil.Amount(50000),
+			PkScript:    np2wkhScript,
+			OutPoint:    wire.OutPoint{Index: 2},
+		},
+	}
+	sweepScript := make([]byte, P2WSHSize)
+	feeRate := SatPerKWeight(2500)
+
+	tx, prevOuts, err := createSweepAllTx(utxos, sweepScript, feeRate)
+	if err != nil {
+		t.Fatalf("unable to create sweep tx: %v", err)
+	}
+
+	if len(tx.TxIn) != 2 || len(prevOuts) != 2 {
+		t.Fatalf("expected 2 inputs, got %v", len(tx.TxIn))
+	}
+	if len(tx.TxOut) != 1 {
+		t.Fatalf("expected a single output, got %v", len(tx.TxOut))
+	}
+
+	var weightEstimate TxWeightEstimator
+	weightEstimate.AddP2WKHInput()
+	weightEstimate.AddNestedP2WKHInput()
+	weightEstimate.AddP2WSHOutput()
+	fee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+
+	expectedValue := int64(150000 - fee)
+	if tx.TxOut[0].Value != expectedValue {
+		t.Fatalf("expected output value %v, got %v", expectedValue,
+			tx.TxOut[0].Value)
+	}
+
+	// Sweeping an output whose value minus the fee would be dust must
+	// fail rather than creating a dust output.
+	dustUtxo := []*Utxo{{
+		AddressType: WitnessPubKey,
+		Value:       DefaultDustLimit(),
+		PkScript:    p2wkhScript,
+	}}
+	_, _, err = createSweepAllTx(dustUtxo, sweepScript, feeRate)
+	if _, ok := err.(*ErrInsufficientFunds); !ok {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+}
+
+// mockCoinWallet is a WalletController whose unspent outputs are a fixed set,
+// hiding the locked ones like the wallet does.
+type mockCoinWallet struct {
+	WalletController
+
+	unspent []*Utxo
+	locked  map[wire.OutPoint]struct{}
+}
+
+func (m *mockCoinWallet) ListUnspentWitness(int32) ([]*Utxo, error) {
+	var utxos []*Utxo
+	for _, utxo := range m.unspent {
+		if _, ok := m.locked[utxo.OutPoint]; ok {
+			continue
+		}
+		utxos = append(utxos, utxo)
+	}
+	return utxos, nil
+}
+
+func (m *mockCoinWallet) LockOutpoint(op wire.OutPoint) {
+	m.locked[op] = struct{}{}
+}
+
+func (m *mockCoinWallet) UnlockOutpoint(op wire.OutPoint) {
+	delete(m.locked, op)
+}
+
+// TestFetchWalletUtxos asserts that explicitly selected outputs must be
+// unspent wallet outputs, and that outputs reserved by a funding flow or
+// leased under a different lock ID are rejected.
+func TestFetchWalletUtxos(t *testing.T) {
+	t.Parallel()
+
+	var (
+		unspentOp  = wire.OutPoint{Index: 1}
+		leasedOp   = wire.OutPoint{Index: 2}
+		reservedOp = wire.OutPoint{Index: 3}
+		spentOp    = wire.OutPoint{Index: 4}
+
+		lockID      = [32]byte{1}
+		otherLockID = [32]byte{2}
+	)
+
+	wallet := &mockCoinWallet{
+		unspent: []*Utxo{
+			{OutPoint: unspentOp, Value: 1000},
+			{OutPoint: leasedOp, Value: 2000},
+			{OutPoint: reservedOp, Value: 3000},
+		},
+		locked: map[wire.OutPoint]struct{}{
+			leasedOp:   {},
+			reservedOp: {},
+		},
+	}
+	l := &LightningWallet{
+		WalletController: wallet,
+		lockedOutPoints: map[wire.OutPoint]struct{}{
+			reservedOp: {},
+		},
+		leases: leaseState{
+			leases: map[wire.OutPoint]*outputLease{
+				leasedOp: {
+					lockID:     lockID,
+					expiration: time.Now().Add(time.Hour),
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		outpoints []wire.OutPoint
+		lockID    *[32]byte
+		valid     bool
+	}{
+		{
+			name:      "unspent",
+			outpoints: []wire.OutPoint{unspentOp},
+			valid:     true,
+		},
+		{
+			name:      "spent",
+			outpoints: []wire.OutPoint{spentOp},
+		},
+		{
+			name:      "duplicate",
+			outpoints: []wire.OutPoint{unspentOp, unspentOp},
+		},
+		{
+			name:      "reserved",
+			outpoints: []wire.OutPoint{reservedOp},
+			lockID:    &lockID,
+		},
+		{
+			name:      "leased without lock id",
+			outpoints: []wire.OutPoint{leasedOp},
+		},
+		{
+			name:      "leased under other lock id",
+			outpoints: []wire.OutPoint{unspentOp, leasedOp},
+			lockID:    &otherLockID,
+		},
+		{
+			name:      "leased under lock id",
+			outpoints: []wire.OutPoint{unspentOp, leasedOp},
+			lockID:    &lockID,
+			valid:     true,
+		},
+	}
+
+	for _, test := range testCases {
+		utxos, err := l.fetchWalletUtxos(test.outpoints, test.lockID)
+		if !test.valid {
+			if err == nil {
+				t.Fatalf("%v: expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v: unable to fetch utxos: %v", test.name, err)
+		}
+
+		if len(utxos) != len(test.outpoints) {
+			t.Fatalf("%v: expected %v utxos, got %v", test.name,
+				len(test.outpoints), len(utxos))
+		}
+		for i, utxo := range utxos {
+			if utxo.OutPoint != test.outpoints[i] {
+				t.Fatalf("%v: expected utxo %v, got %v",
+					test.name, test.outpoints[i],
+					utxo.OutPoint)
+			}
+		}
+
+		// The leased output must be locked again.
+		if _, ok := wallet.locked[leasedOp]; !ok {
+			t.Fatalf("%v: leased output was unlocked", test.name)
+		}
+	}
+}
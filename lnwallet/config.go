@@ -54,4 +54,9 @@ type Config struct {
 	// NetParams is the set of parameters that tells the wallet which chain
 	// it will be operating on.
 	NetParams chaincfg.Params
+
+	// WatchOnly indicates that the WalletController doesn't hold any
+	// private keys. All wallet inputs are then signed through the Signer,
+	// which means the wallet can't fund and sign transactions internally.
+	WatchOnly bool
 }
@@ -126,6 +126,26 @@ func ErrChanTooSmall(chanSize, minChanSize btcutil.Amount) ReservationError {
 	}
 }
 
+// ErrChanTooLarge returns an error indicating that a channel request was too
+// large. We'll reject any channels above the max channel size negotiated with
+// the remote peer.
+func ErrChanTooLarge(chanSize, maxChanSize btcutil.Amount) ReservationError {
+	return ReservationError{
+		fmt.Errorf("chan size of %v exceeds max chan size of %v",
+			chanSize, maxChanSize),
+	}
+}
+
+// ErrInvalidUpfrontShutdown returns an error indicating that the upfront
+// shutdown script sent by the remote party isn't one of the standard script
+// types we're able to pay out to upon a cooperative close.
+func ErrInvalidUpfrontShutdown(script []byte) ReservationError {
+	return ReservationError{
+		fmt.Errorf("upfront shutdown script %x is not a standard "+
+			"p2pkh, p2sh, p2wpkh or p2wsh script", script),
+	}
+}
+
 // ErrHtlcIndexAlreadyFailed is returned when the HTLC index has already been
 // failed, but has not been committed by our commitment state.
 type ErrHtlcIndexAlreadyFailed uint64
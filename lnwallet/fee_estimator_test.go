@@ -1,7 +1,13 @@
 package lnwallet_test
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcutil"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -91,3 +97,232 @@ func TestStaticFeeEstimator(t *testing.T) {
 		t.Fatalf("expected fee rate %v, got %v", feePerKw, feeRate)
 	}
 }
+
+// TestWebAPIFeeEstimator checks that the WebAPIFeeEstimator picks the fee rate
+// of the closest confirmation target of the fee table served by a web API.
+func TestWebAPIFeeEstimator(t *testing.T) {
+	t.Parallel()
+
+	// available is accessed atomically, as it is read by the server.
+	available := int32(1)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if atomic.LoadInt32(&available) == 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			fmt.Fprint(w, `{"fee_by_block_target": `+
+				`{"2": 20000, "6": 10000, "144": 1000}}`)
+		},
+	))
+	defer server.Close()
+
+	feeEstimator := lnwallet.NewWebAPIFeeEstimator(
+		server.URL, lnwallet.DefaultWebAPITimeout,
+	)
+	if err := feeEstimator.Start(); err != nil {
+		t.Fatalf("unable to start fee estimator: %v", err)
+	}
+	defer feeEstimator.Stop()
+
+	testCases := []struct {
+		numBlocks uint32
+		feePerKw  lnwallet.SatPerKWeight
+	}{
+		// Targets below the lowest target of the table use the
+		// fastest fee rate.
+		{numBlocks: 1, feePerKw: 5000},
+		{numBlocks: 2, feePerKw: 5000},
+		{numBlocks: 5, feePerKw: 5000},
+		{numBlocks: 6, feePerKw: 2500},
+		{numBlocks: 100, feePerKw: 2500},
+		{numBlocks: 1008, feePerKw: 250},
+	}
+	for _, test := range testCases {
+		feeRate, err := feeEstimator.EstimateFeePerKW(test.numBlocks)
+		if err != nil {
+			t.Fatalf("unable to estimate fee: %v", err)
+		}
+		if feeRate != test.feePerKw {
+			t.Fatalf("expected fee rate of %v sat/kw for target "+
+				"%v, got %v", test.feePerKw, test.numBlocks,
+				feeRate)
+		}
+	}
+
+	atomic.StoreInt32(&available, 0)
+	if _, err := feeEstimator.EstimateFeePerKW(6); err == nil {
+		t.Fatalf("expected error for unavailable web API")
+	}
+}
+
+// mockFeeSource is a fee estimator whose estimate can be changed.
+type mockFeeSource struct {
+	lnwallet.StaticFeeEstimator
+	err error
+}
+
+func (m *mockFeeSource) EstimateFeePerKW(
+	numBlocks uint32) (lnwallet.SatPerKWeight, error) {
+
+	return m.FeePerKW, m.err
+}
+
+// TestCompositeFeeEstimator checks that the CompositeFeeEstimator falls back
+// to later sources, caches, smooths and clamps estimates.
+func TestCompositeFeeEstimator(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockFeeSource{err: errors.New("unavailable")}
+	secondary := &mockFeeSource{}
+	secondary.FeePerKW = 1000
+
+	newEstimator := func(cacheDuration time.Duration,
+		smoothing float64) *lnwallet.CompositeFeeEstimator {
+
+		feeEstimator, err := lnwallet.NewCompositeFeeEstimator(
+			lnwallet.CompositeFeeEstimatorConfig{
+				Sources: []lnwallet.FeeEstimator{
+					primary, secondary,
+				},
+				MinFeePerKW:     lnwallet.FeePerKwFloor,
+				MaxFeePerKW:     20000,
+				CacheDuration:   cacheDuration,
+				SmoothingFactor: smoothing,
+			},
+		)
+		if err != nil {
+			t.Fatalf("unable to create fee estimator: %v", err)
+		}
+
+		return feeEstimator
+	}
+	assertFeeRate := func(feeEstimator lnwallet.FeeEstimator,
+		expected lnwallet.SatPerKWeight) {
+
+		t.Helper()
+
+		feeRate, err := feeEstimator.EstimateFeePerKW(6)
+		if err != nil {
+			t.Fatalf("unable to estimate fee: %v", err)
+		}
+		if feeRate != expected {
+			t.Fatalf("expected fee rate of %v sat/kw, got %v",
+				expected, feeRate)
+		}
+	}
+
+	// As long as the primary source fails, the secondary one is used.
+	// Once the primary source recovers, it is preferred again.
+	feeEstimator := newEstimator(0, 1)
+	assertFeeRate(feeEstimator, 1000)
+	primary.err = nil
+	primary.FeePerKW = 2000
+	assertFeeRate(feeEstimator, 2000)
+
+	// Estimates are clamped to the floor and the maximum.
+	primary.FeePerKW = 100
+	assertFeeRate(feeEstimator, lnwallet.FeePerKwFloor)
+	primary.FeePerKW = 50000
+	assertFeeRate(feeEstimator, 20000)
+
+	// If all sources fail, the previous estimate is used.
+	primary.err = errors.New("unavailable")
+	secondary.err = errors.New("unavailable")
+	assertFeeRate(feeEstimator, 20000)
+
+	// Without a previous estimate, the composite estimator fails.
+	if _, err := newEstimator(0, 1).EstimateFeePerKW(6); err == nil {
+		t.Fatalf("expected error without available sources")
+	}
+
+	// Estimates are cached for the cache duration.
+	primary.err = nil
+	primary.FeePerKW = 4000
+	feeEstimator = newEstimator(time.Hour, 1)
+	assertFeeRate(feeEstimator, 4000)
+	primary.FeePerKW = 8000
+	assertFeeRate(feeEstimator, 4000)
+
+	// Sudden jumps are smoothed by only moving part of the way to the new
+	// estimate.
+	primary.FeePerKW = 4000
+	feeEstimator = newEstimator(0, 0.25)
+	assertFeeRate(feeEstimator, 4000)
+	primary.FeePerKW = 8000
+	assertFeeRate(feeEstimator, 5000)
+	assertFeeRate(feeEstimator, 5750)
+	primary.FeePerKW = 1000
+	assertFeeRate(feeEstimator, 4563)
+}
+
+// blockingFeeSource is a fee estimator that blocks estimates for a target
+// until it is released.
+type blockingFeeSource struct {
+	lnwallet.StaticFeeEstimator
+	blockTarget uint32
+	release     chan struct{}
+}
+
+func (b *blockingFeeSource) EstimateFeePerKW(
+	numBlocks uint32) (lnwallet.SatPerKWeight, error) {
+
+	if numBlocks == b.blockTarget {
+		<-b.release
+	}
+
+	return b.FeePerKW, nil
+}
+
+// TestCompositeFeeEstimatorSlowSource checks that a slow fee source doesn't
+// hold up lookups of estimates that are already cached.
+func TestCompositeFeeEstimatorSlowSource(t *testing.T) {
+	t.Parallel()
+
+	source := &blockingFeeSource{
+		blockTarget: 2,
+		release:     make(chan struct{}),
+	}
+	source.FeePerKW = 1000
+
+	feeEstimator, err := lnwallet.NewCompositeFeeEstimator(
+		lnwallet.CompositeFeeEstimatorConfig{
+			Sources:         []lnwallet.FeeEstimator{source},
+			MinFeePerKW:     lnwallet.FeePerKwFloor,
+			CacheDuration:   time.Hour,
+			SmoothingFactor: 1,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to create fee estimator: %v", err)
+	}
+
+	// Populate the cache for a target that doesn't block.
+	if _, err := feeEstimator.EstimateFeePerKW(6); err != nil {
+		t.Fatalf("unable to estimate fee: %v", err)
+	}
+
+	// Start a lookup that blocks within the source.
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		feeEstimator.EstimateFeePerKW(2)
+	}()
+
+	// The cached estimate should still be returned promptly.
+	cachedDone := make(chan struct{})
+	go func() {
+		defer close(cachedDone)
+		feeEstimator.EstimateFeePerKW(6)
+	}()
+
+	select {
+	case <-cachedDone:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("cached lookup blocked by slow fee source")
+	}
+
+	close(source.release)
+	<-slowDone
+}
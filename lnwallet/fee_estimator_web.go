@@ -0,0 +1,323 @@
+package lnwallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWebAPITimeout is the time after which a request for the fee
+	// table of a web API is aborted.
+	DefaultWebAPITimeout = 10 * time.Second
+)
+
+// WebAPIFeeTable is the JSON fee table served by a fee web API. It maps
+// confirmation targets, in blocks, to fee rates in sat/kvbyte:
+//
+//   {"fee_by_block_target": {"2": 20000, "6": 10000, "144": 1000}}
+type WebAPIFeeTable struct {
+	// FeeByBlockTarget maps confirmation targets to fee rates in
+	// sat/kvbyte.
+	FeeByBlockTarget map[uint32]uint32 `json:"fee_by_block_target"`
+}
+
+// WebAPIFeeEstimator is an implementation of the FeeEstimator interface that
+// queries a fee table from an HTTP API. This allows light clients, which
+// don't have a mempool of their own, to use live fee estimates.
+type WebAPIFeeEstimator struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebAPIFeeEstimator creates a new fee estimator that queries the fee table
+// served at the given URL. Requests are aborted after the given timeout.
+func NewWebAPIFeeEstimator(url string,
+	timeout time.Duration) *WebAPIFeeEstimator {
+
+	return &WebAPIFeeEstimator{
+		url: url,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Start signals the FeeEstimator to start any processes or goroutines
+// it needs to perform its duty.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (w *WebAPIFeeEstimator) Start() error {
+	return nil
+}
+
+// Stop stops any spawned goroutines and cleans up the resources used
+// by the fee estimator.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (w *WebAPIFeeEstimator) Stop() error {
+	return nil
+}
+
+// EstimateFeePerKW takes in a target for the number of blocks until an initial
+// confirmation and returns the estimated fee expressed in sat/kw. The fee rate
+// of the largest target of the fee table that doesn't exceed the requested
+// target is used. If the requested target is lower than all targets of the
+// table, the fee rate of the lowest target is used.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (w *WebAPIFeeEstimator) EstimateFeePerKW(
+	numBlocks uint32) (SatPerKWeight, error) {
+
+	feeTable, err := w.fetchFeeTable()
+	if err != nil {
+		return 0, err
+	}
+	if len(feeTable.FeeByBlockTarget) == 0 {
+		return 0, fmt.Errorf("fee table of %v is empty", w.url)
+	}
+
+	targets := make([]uint32, 0, len(feeTable.FeeByBlockTarget))
+	for target := range feeTable.FeeByBlockTarget {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i] < targets[j]
+	})
+
+	target := targets[0]
+	for _, t := range targets {
+		if t > numBlocks {
+			break
+		}
+		target = t
+	}
+
+	satPerKB := SatPerKVByte(feeTable.FeeByBlockTarget[target])
+	satPerKw := satPerKB.FeePerKWeight()
+
+	walletLog.Debugf("Web API returned %v sat/kw for conf target of %v "+
+		"(table target %v)", int64(satPerKw), numBlocks, target)
+
+	return satPerKw, nil
+}
+
+// fetchFeeTable queries the fee table from the web API.
+func (w *WebAPIFeeEstimator) fetchFeeTable() (*WebAPIFeeTable, error) {
+	resp, err := w.client.Get(w.url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query fee table: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to query fee table: %v",
+			resp.Status)
+	}
+
+	var feeTable WebAPIFeeTable
+	if err := json.NewDecoder(resp.Body).Decode(&feeTable); err != nil {
+		return nil, fmt.Errorf("unable to parse fee table: %v", err)
+	}
+
+	return &feeTable, nil
+}
+
+// A compile-time assertion to ensure that WebAPIFeeEstimator implements the
+// FeeEstimator interface.
+var _ FeeEstimator = (*WebAPIFeeEstimator)(nil)
+
+// CompositeFeeEstimatorConfig houses the parameters of a
+// CompositeFeeEstimator.
+type CompositeFeeEstimatorConfig struct {
+	// Sources are the fee estimators that are queried in order until one
+	// of them returns an estimate.
+	Sources []FeeEstimator
+
+	// MinFeePerKW is the fee floor that is enforced for all estimates.
+	MinFeePerKW SatPerKWeight
+
+	// MaxFeePerKW is the maximum fee rate that is returned. A value of
+	// zero disables the maximum.
+	MaxFeePerKW SatPerKWeight
+
+	// CacheDuration is the time an estimate for a confirmation target is
+	// reused before the sources are queried again.
+	CacheDuration time.Duration
+
+	// SmoothingFactor is the weight, between 0 and 1, of a new estimate
+	// when it is combined with the previous estimate for the same target.
+	// Lower values dampen sudden jumps more strongly, a value of 1
+	// disables smoothing.
+	SmoothingFactor float64
+}
+
+// cachedFeeEstimate is the last estimate for a confirmation target.
+type cachedFeeEstimate struct {
+	feeRate   SatPerKWeight
+	updatedAt time.Time
+}
+
+// CompositeFeeEstimator is an implementation of the FeeEstimator interface
+// that combines several fee estimators. The sources are tried in order until
+// one returns an estimate, which is then smoothed against the previous
+// estimate for the same target, clamped to the configured floor and maximum
+// and cached.
+type CompositeFeeEstimator struct {
+	cfg CompositeFeeEstimatorConfig
+
+	mtx   sync.Mutex
+	cache map[uint32]*cachedFeeEstimate
+}
+
+// NewCompositeFeeEstimator creates a new composite fee estimator from the
+// given config.
+func NewCompositeFeeEstimator(
+	cfg CompositeFeeEstimatorConfig) (*CompositeFeeEstimator, error) {
+
+	switch {
+	case len(cfg.Sources) == 0:
+		return nil, fmt.Errorf("at least one fee source is required")
+
+	case cfg.MaxFeePerKW != 0 && cfg.MaxFeePerKW < cfg.MinFeePerKW:
+		return nil, fmt.Errorf("maximum fee rate %v sat/kw is below "+
+			"the floor of %v sat/kw", int64(cfg.MaxFeePerKW),
+			int64(cfg.MinFeePerKW))
+
+	case cfg.SmoothingFactor <= 0 || cfg.SmoothingFactor > 1:
+		return nil, fmt.Errorf("smoothing factor must be within (0, 1]")
+	}
+
+	return &CompositeFeeEstimator{
+		cfg:   cfg,
+		cache: make(map[uint32]*cachedFeeEstimate),
+	}, nil
+}
+
+// Start starts all fee sources.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (c *CompositeFeeEstimator) Start() error {
+	for _, source := range c.cfg.Sources {
+		if err := source.Start(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop stops all fee sources.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (c *CompositeFeeEstimator) Stop() error {
+	for _, source := range c.cfg.Sources {
+		if err := source.Stop(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EstimateFeePerKW takes in a target for the number of blocks until an initial
+// confirmation and returns the estimated fee expressed in sat/kw.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (c *CompositeFeeEstimator) EstimateFeePerKW(
+	numBlocks uint32) (SatPerKWeight, error) {
+
+	c.mtx.Lock()
+	cached, ok := c.cache[numBlocks]
+	c.mtx.Unlock()
+
+	if ok && time.Since(cached.updatedAt) < c.cfg.CacheDuration {
+		return cached.feeRate, nil
+	}
+
+	// The sources may include web APIs that take a while to respond, so
+	// we query them without holding the lock to not hold up concurrent
+	// lookups of other targets or of cached estimates.
+	feeRate, err := c.querySources(numBlocks)
+	if err != nil {
+		// If none of the sources is available, we'll stick to our
+		// last estimate for as long as they are down.
+		if ok {
+			walletLog.Warnf("Unable to estimate fee, using "+
+				"previous estimate of %v sat/kw: %v",
+				int64(cached.feeRate), err)
+			return cached.feeRate, nil
+		}
+
+		return 0, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	// Another lookup may have updated the estimate for this target while
+	// we were querying the sources, so we smooth against the latest one.
+	cached, ok = c.cache[numBlocks]
+
+	// Dampen sudden jumps by moving only part of the way from the
+	// previous estimate to the new one.
+	if ok {
+		delta := float64(feeRate - cached.feeRate)
+		feeRate = cached.feeRate + SatPerKWeight(
+			delta*c.cfg.SmoothingFactor,
+		)
+	}
+
+	switch {
+	case feeRate < c.cfg.MinFeePerKW:
+		feeRate = c.cfg.MinFeePerKW
+
+	case c.cfg.MaxFeePerKW != 0 && feeRate > c.cfg.MaxFeePerKW:
+		walletLog.Debugf("Estimated fee rate of %v sat/kw exceeds "+
+			"maximum, using %v sat/kw instead", int64(feeRate),
+			int64(c.cfg.MaxFeePerKW))
+
+		feeRate = c.cfg.MaxFeePerKW
+	}
+
+	c.cache[numBlocks] = &cachedFeeEstimate{
+		feeRate:   feeRate,
+		updatedAt: time.Now(),
+	}
+
+	return feeRate, nil
+}
+
+// querySources returns the estimate of the first source that is able to
+// estimate the fee for the given target.
+func (c *CompositeFeeEstimator) querySources(
+	numBlocks uint32) (SatPerKWeight, error) {
+
+	var lastErr error
+	for i, source := range c.cfg.Sources {
+		feeRate, err := source.EstimateFeePerKW(numBlocks)
+		switch {
+		case err != nil:
+			walletLog.Debugf("Fee source %v unable to estimate "+
+				"fee: %v", i, err)
+			lastErr = err
+			continue
+
+		case feeRate == 0:
+			lastErr = fmt.Errorf("fee source %v returned no "+
+				"estimate", i)
+			continue
+		}
+
+		return feeRate, nil
+	}
+
+	return 0, fmt.Errorf("no fee source available: %v", lastErr)
+}
+
+// A compile-time assertion to ensure that CompositeFeeEstimator implements the
+// FeeEstimator interface.
+var _ FeeEstimator = (*CompositeFeeEstimator)(nil)
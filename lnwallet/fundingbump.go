@@ -0,0 +1,283 @@
+package lnwallet
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// fundingTxFee returns the absolute fee paid by the passed funding
+// transaction, along with the outputs spent by each of its inputs. All inputs
+// of the funding transaction are expected to be under the control of the
+// wallet.
+func (l *LightningWallet) fundingTxFee(
+	fundingTx *wire.MsgTx) (btcutil.Amount, []*wire.TxOut, error) {
+
+	var (
+		totalInput btcutil.Amount
+		prevOuts   = make([]*wire.TxOut, 0, len(fundingTx.TxIn))
+	)
+	for _, txIn := range fundingTx.TxIn {
+		prevOut, err := l.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err != nil {
+			return 0, nil, fmt.Errorf("unable to fetch funding "+
+				"input %v: %v", txIn.PreviousOutPoint, err)
+		}
+
+		totalInput += btcutil.Amount(prevOut.Value)
+		prevOuts = append(prevOuts, prevOut)
+	}
+
+	var totalOutput btcutil.Amount
+	for _, txOut := range fundingTx.TxOut {
+		totalOutput += btcutil.Amount(txOut.Value)
+	}
+
+	return totalInput - totalOutput, prevOuts, nil
+}
+
+// addWalletInputWeight adds the weight of an input spending the passed wallet
+// output to the weight estimate. Only (nested) p2wkh outputs are supported.
+func addWalletInputWeight(weightEstimate *TxWeightEstimator,
+	pkScript []byte) error {
+
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(pkScript):
+		weightEstimate.AddP2WKHInput()
+	case txscript.IsPayToScriptHash(pkScript):
+		weightEstimate.AddNestedP2WKHInput()
+	default:
+		return fmt.Errorf("unsupported wallet output script %x",
+			pkScript)
+	}
+
+	return nil
+}
+
+// signWalletInputs signs all inputs of the passed transaction using the keys
+// of the wallet. The passed outputs are the ones spent by each input, in
+// order.
+func (l *LightningWallet) signWalletInputs(tx *wire.MsgTx,
+	prevOuts []*wire.TxOut) error {
+
+	sigHashes := txscript.NewTxSigHashes(tx)
+	for i, prevOut := range prevOuts {
+		signDesc := SignDescriptor{
+			Output:     prevOut,
+			HashType:   txscript.SigHashAll,
+			SigHashes:  sigHashes,
+			InputIndex: i,
+		}
+		inputScript, err := l.Cfg.Signer.ComputeInputScript(
+			tx, &signDesc,
+		)
+		if err != nil {
+			return err
+		}
+
+		tx.TxIn[i].SignatureScript = inputScript.ScriptSig
+		tx.TxIn[i].Witness = inputScript.Witness
+	}
+
+	return nil
+}
+
+// CreateFundingCPFP creates a fully signed transaction that spends the change
+// output of the passed unconfirmed funding transaction back to the wallet.
+// The fee of the child is chosen such that the effective fee rate of the
+// funding transaction and the child (the package) reaches the passed fee
+// rate. It's up to the caller to broadcast the returned transaction.
+func (l *LightningWallet) CreateFundingCPFP(fundingTx *wire.MsgTx,
+	feeRate SatPerKWeight) (*wire.MsgTx, error) {
+
+	// We'll locate the change output by checking each output of the
+	// funding transaction for an address of our wallet. The funding
+	// output itself is a p2wsh multi-sig output, so it'll never match.
+	changeIndex := -1
+	for i, txOut := range fundingTx.TxOut {
+		if l.isOurOutput(txOut) {
+			changeIndex = i
+			break
+		}
+	}
+	if changeIndex < 0 {
+		return nil, fmt.Errorf("funding transaction %v has no change "+
+			"output to bump its fee with", fundingTx.TxHash())
+	}
+
+	fundingFee, _, err := l.fundingTxFee(fundingTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.CreateCPFP(fundingTx, uint32(changeIndex), fundingFee, feeRate)
+}
+
+// isOurOutput returns whether the passed output pays to an address of the
+// wallet.
+func (l *LightningWallet) isOurOutput(txOut *wire.TxOut) bool {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		txOut.PkScript, &l.Cfg.NetParams,
+	)
+	if err != nil || len(addrs) != 1 {
+		return false
+	}
+
+	return l.IsOurAddress(addrs[0])
+}
+
+// CreateCPFP creates a fully signed transaction that spends the output with
+// the passed index of an unconfirmed parent transaction back to the wallet.
+// The output must pay to an address of the wallet. The fee of the child is
+// chosen such that the effective fee rate of the parent and the child (the
+// package) reaches the passed fee rate, taking into account the fee already
+// paid by the parent. If the fee of the parent isn't known, zero can be
+// passed, in which case the child pays for the entire package. It's up to the
+// caller to broadcast the returned transaction.
+func (l *LightningWallet) CreateCPFP(parentTx *wire.MsgTx, outputIndex uint32,
+	parentFee btcutil.Amount, feeRate SatPerKWeight) (*wire.MsgTx, error) {
+
+	if int(outputIndex) >= len(parentTx.TxOut) {
+		return nil, fmt.Errorf("transaction %v has no output %v",
+			parentTx.TxHash(), outputIndex)
+	}
+	parentOutput := parentTx.TxOut[outputIndex]
+	if !l.isOurOutput(parentOutput) {
+		return nil, fmt.Errorf("output %v of transaction %v doesn't "+
+			"belong to the wallet", outputIndex, parentTx.TxHash())
+	}
+
+	parentWeight := blockchain.GetTransactionWeight(
+		btcutil.NewTx(parentTx),
+	)
+
+	var weightEstimate TxWeightEstimator
+	err := addWalletInputWeight(&weightEstimate, parentOutput.PkScript)
+	if err != nil {
+		return nil, err
+	}
+	weightEstimate.AddP2WKHOutput()
+	childWeight := int64(weightEstimate.Weight())
+
+	// The child needs to pay for the weight of the entire package, minus
+	// the fee already paid by the parent. It'll always need to pay for
+	// its own weight though.
+	requiredFee := feeRate.FeeForWeight(parentWeight+childWeight) -
+		parentFee
+	if minFee := feeRate.FeeForWeight(childWeight); requiredFee < minFee {
+		requiredFee = minFee
+	}
+
+	sweepAmt := btcutil.Amount(parentOutput.Value) - requiredFee
+	if sweepAmt < DefaultDustLimit() {
+		return nil, fmt.Errorf("output of %v is too small to pay the "+
+			"required fee of %v", btcutil.Amount(parentOutput.Value),
+			requiredFee)
+	}
+
+	walletLog.Infof("Creating CPFP child for tx %v using %v sat/kw as "+
+		"package fee rate", parentTx.TxHash(), int64(feeRate))
+
+	sweepAddr, err := l.NewAddress(WitnessPubKey, true)
+	if err != nil {
+		return nil, err
+	}
+	sweepScript, err := txscript.PayToAddrScript(sweepAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	childTx := wire.NewMsgTx(2)
+	childTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash:  parentTx.TxHash(),
+			Index: outputIndex,
+		},
+	})
+	childTx.AddTxOut(&wire.TxOut{
+		PkScript: sweepScript,
+		Value:    int64(sweepAmt),
+	})
+
+	err = l.signWalletInputs(childTx, []*wire.TxOut{parentOutput})
+	if err != nil {
+		return nil, err
+	}
+
+	return childTx, nil
+}
+
+// CreateFundingDoubleSpend creates a fully signed transaction that spends all
+// inputs of the passed unconfirmed funding transaction back to the wallet at
+// the passed fee rate. Once confirmed, the funding transaction can no longer
+// confirm, which releases the funds that would otherwise be stuck in the
+// pending channel. As the replacement must pay a higher absolute fee than the
+// funding transaction, an error is returned if the fee rate is too low. It's
+// up to the caller to broadcast the returned transaction.
+func (l *LightningWallet) CreateFundingDoubleSpend(fundingTx *wire.MsgTx,
+	feeRate SatPerKWeight) (*wire.MsgTx, error) {
+
+	fundingFee, prevOuts, err := l.fundingTxFee(fundingTx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		weightEstimate TxWeightEstimator
+		totalInput     btcutil.Amount
+	)
+	for _, prevOut := range prevOuts {
+		err := addWalletInputWeight(&weightEstimate, prevOut.PkScript)
+		if err != nil {
+			return nil, err
+		}
+
+		totalInput += btcutil.Amount(prevOut.Value)
+	}
+	weightEstimate.AddP2WKHOutput()
+
+	fee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+	if fee <= fundingFee {
+		return nil, fmt.Errorf("fee of %v at %v sat/kw doesn't exceed "+
+			"the fee of %v paid by the funding transaction", fee,
+			int64(feeRate), fundingFee)
+	}
+
+	sweepAmt := totalInput - fee
+	if sweepAmt < DefaultDustLimit() {
+		return nil, fmt.Errorf("funding inputs of %v are too small to "+
+			"pay the required fee of %v", totalInput, fee)
+	}
+
+	walletLog.Infof("Creating double spend of funding tx %v using %v "+
+		"sat/kw", fundingTx.TxHash(), int64(feeRate))
+
+	sweepAddr, err := l.NewAddress(WitnessPubKey, true)
+	if err != nil {
+		return nil, err
+	}
+	sweepScript, err := txscript.PayToAddrScript(sweepAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	sweepTx := wire.NewMsgTx(2)
+	for _, txIn := range fundingTx.TxIn {
+		sweepTx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: txIn.PreviousOutPoint,
+		})
+	}
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: sweepScript,
+		Value:    int64(sweepAmt),
+	})
+
+	if err := l.signWalletInputs(sweepTx, prevOuts); err != nil {
+		return nil, err
+	}
+
+	return sweepTx, nil
+}
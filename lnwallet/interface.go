@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
 )
 
 // AddressType is an enum-like type which denotes the possible address types
@@ -45,6 +48,11 @@ var (
 	// ErrNotMine is an error denoting that a WalletController instance is
 	// unable to spend a specified output.
 	ErrNotMine = errors.New("the passed output doesn't belong to the wallet")
+
+	// ErrNoRevocationProducer is returned when the revocation secrets of a
+	// channel are neither stored along with it, nor kept by the signer.
+	ErrNoRevocationProducer = errors.New("channel has no revocation " +
+		"producer and the signer doesn't keep its revocation secrets")
 )
 
 // Utxo is an unspent output denoted by its outpoint, and output value of the
@@ -52,6 +60,7 @@ var (
 type Utxo struct {
 	AddressType   AddressType
 	Value         btcutil.Amount
+	Confirmations int64
 	PkScript      []byte
 	RedeemScript  []byte
 	WitnessScript []byte
@@ -94,6 +103,14 @@ type TransactionDetail struct {
 
 	// DestAddresses are the destinations for a transaction
 	DestAddresses []btcutil.Address
+
+	// RawTx is the transaction itself.
+	RawTx *wire.MsgTx
+
+	// Label is the label assigned to the transaction by lnd or the user,
+	// if any. It is populated by the LightningWallet rather than the
+	// WalletController.
+	Label string
 }
 
 // TransactionSubscription is an interface which describes an object capable of
@@ -113,6 +130,56 @@ type TransactionSubscription interface {
 	Cancel()
 }
 
+// ScopeRecoveryProgress describes the addresses recovered so far for the
+// default account of a single key scope.
+type ScopeRecoveryProgress struct {
+	// Purpose is the BIP 43 purpose of the key scope.
+	Purpose uint32
+
+	// Coin is the BIP 44 coin type of the key scope.
+	Coin uint32
+
+	// ExternalAddrs is the number of external addresses derived so far,
+	// which includes every receiving address found during the recovery.
+	ExternalAddrs uint32
+
+	// InternalAddrs is the number of internal (change) addresses derived
+	// so far.
+	InternalAddrs uint32
+}
+
+// RecoveryProgress is a snapshot of the wallet's progress when restoring from
+// a seed with a non-zero recovery window.
+type RecoveryProgress struct {
+	// RecoveryMode indicates whether the wallet was started in recovery
+	// mode. If false, none of the other fields are populated.
+	RecoveryMode bool
+
+	// RecoveryFinished indicates whether the wallet has finished scanning
+	// the chain and is fully synced.
+	RecoveryFinished bool
+
+	// ScannedHeight is the height the wallet has scanned the chain up to.
+	ScannedHeight int32
+
+	// BestHeight is the height of the current chain tip.
+	BestHeight int32
+
+	// Progress is the fraction of the chain between the height the
+	// recovery started at and the chain tip that has been scanned, in the
+	// range [0, 1].
+	Progress float64
+
+	// Scopes holds the number of addresses recovered for each key scope
+	// that is being restored.
+	Scopes []ScopeRecoveryProgress
+
+	// EstimatedCompletion is the time the recovery is expected to finish
+	// at, based on the scan rate observed so far. It is the zero time if
+	// no estimate can be made yet or the recovery has finished.
+	EstimatedCompletion time.Time
+}
+
 // WalletController defines an abstract interface for controlling a local Pure
 // Go wallet, a local or remote wallet via an RPC mechanism, or possibly even
 // a daemon assisted hardware wallet. This interface serves the purpose of
@@ -206,6 +273,10 @@ type WalletController interface {
 	// known to the wallet, expressed in Unix epoch time
 	IsSynced() (bool, int64, error)
 
+	// RecoveryProgress returns the progress of the wallet's address
+	// recovery if it was restored from a seed with a recovery window.
+	RecoveryProgress() (*RecoveryProgress, error)
+
 	// Start initializes the wallet, making any necessary connections,
 	// starting up required goroutines etc.
 	Start() error
@@ -274,6 +345,99 @@ type Signer interface {
 	ComputeInputScript(tx *wire.MsgTx, signDesc *SignDescriptor) (*InputScript, error)
 }
 
+// ChannelRegistrar is implemented by signers that only sign spends of the
+// funding outputs of channels they have been told about, such as a remote
+// signer that holds the keys of the node in a separate process.
+type ChannelRegistrar interface {
+	// RegisterChannel informs the signer about the funding output of a
+	// channel, identified by its outpoint and capacity, along with the
+	// configuration of the channel. The local and remote channel configs
+	// of the passed channel must be populated.
+	RegisterChannel(chanPoint wire.OutPoint, capacity btcutil.Amount,
+		channel *channeldb.OpenChannel) error
+}
+
+// registerChannel informs the passed signer about the funding output of a
+// channel if it requires so.
+func registerChannel(signer Signer, chanPoint wire.OutPoint,
+	capacity btcutil.Amount, channel *channeldb.OpenChannel) error {
+
+	registrar, ok := signer.(ChannelRegistrar)
+	if !ok {
+		return nil
+	}
+
+	return registrar.RegisterChannel(chanPoint, capacity, channel)
+}
+
+// RevocationSigner is implemented by signers that keep the revocation secrets
+// of our commitments themselves, such as a remote signer. Channels of such a
+// signer don't carry a revocation producer. The signer only reveals the secret
+// of a commitment when revoking it, and refuses to sign our revoked
+// commitments afterwards.
+type RevocationSigner interface {
+	// CommitmentPoint returns our commitment point at the given height of
+	// the channel that uses the passed multi-sig key.
+	CommitmentPoint(multiSigKey keychain.KeyDescriptor,
+		height uint64) (*btcec.PublicKey, error)
+
+	// RevokeCommitment revokes our commitment at the given height of the
+	// channel that uses the passed multi-sig key and returns its
+	// revocation secret. Commitments must be revoked in order. The next
+	// commitment, along with the signature of the remote party for it,
+	// tells the signer our balance of the channel.
+	RevokeCommitment(multiSigKey keychain.KeyDescriptor, height uint64,
+		nextCommitTx *wire.MsgTx,
+		nextCommitSig []byte) (*chainhash.Hash, error)
+}
+
+// deriveCommitPoint returns our commitment point at the given height of the
+// passed channel, either from its revocation producer or from the signer
+// keeping its revocation secrets.
+func deriveCommitPoint(signer Signer, chanState *channeldb.OpenChannel,
+	height uint64) (*btcec.PublicKey, error) {
+
+	if chanState.RevocationProducer != nil {
+		commitSecret, err := chanState.RevocationProducer.AtIndex(height)
+		if err != nil {
+			return nil, err
+		}
+
+		return ComputeCommitmentPoint(commitSecret[:]), nil
+	}
+
+	revSigner, ok := signer.(RevocationSigner)
+	if !ok {
+		return nil, ErrNoRevocationProducer
+	}
+
+	return revSigner.CommitmentPoint(
+		chanState.LocalChanCfg.MultiSigKey, height,
+	)
+}
+
+// revokeCommitment returns the revocation secret of our commitment at the
+// given height of the passed channel. If the signer keeps the revocation
+// secrets of the channel, it will refuse to sign the commitment afterwards,
+// and learns our balance from the passed next commitment.
+func revokeCommitment(signer Signer, chanState *channeldb.OpenChannel,
+	height uint64, nextCommit *commitment) (*chainhash.Hash, error) {
+
+	if chanState.RevocationProducer != nil {
+		return chanState.RevocationProducer.AtIndex(height)
+	}
+
+	revSigner, ok := signer.(RevocationSigner)
+	if !ok {
+		return nil, ErrNoRevocationProducer
+	}
+
+	return revSigner.RevokeCommitment(
+		chanState.LocalChanCfg.MultiSigKey, height, nextCommit.txn,
+		nextCommit.sig,
+	)
+}
+
 // MessageSigner represents an abstract object capable of signing arbitrary
 // messages. The capabilities of this interface are used to sign announcements
 // to the network, or just arbitrary messages that leverage the wallet's keys
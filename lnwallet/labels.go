@@ -0,0 +1,138 @@
+package lnwallet
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+const (
+	// LabelSweep is the label of transactions that sweep outputs back
+	// into the wallet.
+	LabelSweep = "sweep"
+
+	// LabelJustice is the label of justice transactions that claim the
+	// outputs of a breached channel.
+	LabelJustice = "justice"
+)
+
+// ChannelOpenLabel returns the label of the funding transaction of the channel
+// with the given channel point.
+func ChannelOpenLabel(chanPoint wire.OutPoint) string {
+	return fmt.Sprintf("channel open %v", chanPoint)
+}
+
+// CoopCloseLabel returns the label of the cooperative close transaction of the
+// channel with the given channel point.
+func CoopCloseLabel(chanPoint wire.OutPoint) string {
+	return fmt.Sprintf("coop close %v", chanPoint)
+}
+
+// ForceCloseLabel returns the label of our commitment transaction that force
+// closes the channel with the given channel point.
+func ForceCloseLabel(chanPoint wire.OutPoint) string {
+	return fmt.Sprintf("force close %v", chanPoint)
+}
+
+// AnchorSweepLabel returns the label of the transaction that sweeps an anchor
+// of a commitment transaction of the channel with the given channel point to
+// bump its fee.
+func AnchorSweepLabel(chanPoint wire.OutPoint) string {
+	return fmt.Sprintf("anchor sweep %v", chanPoint)
+}
+
+// HtlcTimeoutLabel returns the label of an HTLC timeout transaction of the
+// channel with the given channel point.
+func HtlcTimeoutLabel(chanPoint wire.OutPoint) string {
+	return fmt.Sprintf("htlc timeout %v", chanPoint)
+}
+
+// HtlcSuccessLabel returns the label of an HTLC success transaction of the
+// channel with the given channel point.
+func HtlcSuccessLabel(chanPoint wire.OutPoint) string {
+	return fmt.Sprintf("htlc success %v", chanPoint)
+}
+
+// SpliceLabel returns the label of the transaction that splices the channel
+// with the given channel point.
+func SpliceLabel(chanPoint wire.OutPoint) string {
+	return fmt.Sprintf("splice %v", chanPoint)
+}
+
+// ChannelBumpLabel returns the label of a transaction that bumps the fee of
+// the funding transaction of the pending channel with the given channel
+// point, or double spends it.
+func ChannelBumpLabel(chanPoint wire.OutPoint) string {
+	return fmt.Sprintf("channel bump %v", chanPoint)
+}
+
+// CPFPLabel returns the label of a child transaction that bumps the fee of
+// its parent by spending the given output of it.
+func CPFPLabel(op wire.OutPoint) string {
+	return fmt.Sprintf("cpfp %v", op)
+}
+
+// LabelTransaction assigns a label to the transaction with the given hash. If
+// the transaction is already labelled, the label is only replaced if
+// overwrite is set.
+func (l *LightningWallet) LabelTransaction(txid chainhash.Hash, label string,
+	overwrite bool) error {
+
+	return l.Cfg.Database.PutTxLabel(txid, label, overwrite)
+}
+
+// TransactionLabel returns the label of the transaction with the given hash,
+// or an empty string if it isn't labelled.
+func (l *LightningWallet) TransactionLabel(txid chainhash.Hash) (string,
+	error) {
+
+	return l.Cfg.Database.FetchTxLabel(txid)
+}
+
+// LabelledPublisher returns a function that labels transactions with the
+// passed label before publishing them. Transactions that already have a label
+// keep it.
+func (l *LightningWallet) LabelledPublisher(
+	label string) func(*wire.MsgTx) error {
+
+	return func(tx *wire.MsgTx) error {
+		err := l.LabelTransaction(tx.TxHash(), label, false)
+		if err != nil && err != channeldb.ErrTxLabelExists {
+			walletLog.Warnf("Unable to label tx %v: %v",
+				tx.TxHash(), err)
+		}
+
+		return l.PublishTransaction(tx)
+	}
+}
+
+// PublishLabelledTransaction labels the transaction with the passed label
+// before publishing it, unless it already has a label.
+func (l *LightningWallet) PublishLabelledTransaction(tx *wire.MsgTx,
+	label string) error {
+
+	return l.LabelledPublisher(label)(tx)
+}
+
+// ListTransactionDetails returns a list of all transactions which are
+// relevant to the wallet, including their labels.
+func (l *LightningWallet) ListTransactionDetails() ([]*TransactionDetail,
+	error) {
+
+	txDetails, err := l.WalletController.ListTransactionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := l.Cfg.Database.FetchTxLabels()
+	if err != nil {
+		return nil, err
+	}
+	for _, txDetail := range txDetails {
+		txDetail.Label = labels[txDetail.Hash]
+	}
+
+	return txDetails, nil
+}
@@ -0,0 +1,268 @@
+package lnwallet
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// DefaultLeaseDuration is the duration of an output lease if the caller
+// doesn't specify one.
+const DefaultLeaseDuration = 10 * time.Minute
+
+var (
+	// ErrOutputAlreadyLeased is returned when attempting to lease an output
+	// that is already leased under a different lock ID, or that is
+	// reserved by a pending channel funding flow.
+	ErrOutputAlreadyLeased = errors.New("output already leased")
+
+	// ErrOutputNotLeased is returned when attempting to release an output
+	// that isn't currently leased.
+	ErrOutputNotLeased = errors.New("output not leased")
+
+	// ErrLockIDMismatch is returned when attempting to release an output
+	// under a different lock ID than the one it was leased with.
+	ErrLockIDMismatch = errors.New("lock ID doesn't match lease")
+)
+
+// outputLease is the in-memory state of an active output lease.
+type outputLease struct {
+	lockID     [32]byte
+	expiration time.Time
+
+	// timer fires once the lease expires.
+	timer *time.Timer
+}
+
+// leaseState tracks all active output leases of the wallet.
+type leaseState struct {
+	sync.Mutex
+
+	leases map[wire.OutPoint]*outputLease
+}
+
+// LeaseOutput locks the given wallet output under the passed lock ID for the
+// given duration, excluding it from automatic coin selection. If the output is
+// already leased under the same lock ID, the lease is extended. The time at
+// which the lease expires is returned.
+func (l *LightningWallet) LeaseOutput(id [32]byte, op wire.OutPoint,
+	duration time.Duration) (time.Time, error) {
+
+	// Only outputs controlled by the wallet can be leased.
+	if _, err := l.FetchInputInfo(&op); err != nil {
+		return time.Time{}, err
+	}
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	return l.leaseOutput(id, op, duration)
+}
+
+// leaseOutput leases the passed output as described by LeaseOutput, without
+// checking whether it belongs to the wallet.
+//
+// NOTE: The coin select mutex MUST be held when calling this method.
+func (l *LightningWallet) leaseOutput(id [32]byte, op wire.OutPoint,
+	duration time.Duration) (time.Time, error) {
+
+	if duration <= 0 {
+		duration = DefaultLeaseDuration
+	}
+
+	// Outputs that are reserved by a pending funding flow are already in
+	// use and can't be leased.
+	if _, ok := l.lockedOutPoints[op]; ok {
+		return time.Time{}, ErrOutputAlreadyLeased
+	}
+
+	l.leases.Lock()
+	defer l.leases.Unlock()
+
+	lease, ok := l.leases.leases[op]
+	if ok && lease.lockID != id {
+		return time.Time{}, ErrOutputAlreadyLeased
+	}
+
+	expiration := time.Now().Add(duration)
+	err := l.Cfg.Database.PutOutputLease(&channeldb.OutputLease{
+		OutPoint:   op,
+		LockID:     id,
+		Expiration: expiration,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if ok {
+		lease.timer.Stop()
+	}
+	l.LockOutpoint(op)
+	l.scheduleLease(op, id, expiration)
+
+	walletLog.Debugf("Leased output %v until %v", op, expiration)
+
+	return expiration, nil
+}
+
+// ReleaseOutput releases a lease of the given output before it expires,
+// making the output available for coin selection again.
+func (l *LightningWallet) ReleaseOutput(id [32]byte, op wire.OutPoint) error {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	return l.releaseOutput(id, op)
+}
+
+// releaseOutput releases a lease of the given output as described by
+// ReleaseOutput.
+//
+// NOTE: The coin select mutex MUST be held when calling this method.
+func (l *LightningWallet) releaseOutput(id [32]byte, op wire.OutPoint) error {
+	l.leases.Lock()
+	defer l.leases.Unlock()
+
+	lease, ok := l.leases.leases[op]
+	if !ok {
+		return ErrOutputNotLeased
+	}
+	if lease.lockID != id {
+		return ErrLockIDMismatch
+	}
+
+	if err := l.Cfg.Database.DeleteOutputLease(op); err != nil {
+		return err
+	}
+
+	lease.timer.Stop()
+	l.removeLease(op)
+
+	walletLog.Debugf("Released output %v", op)
+
+	return nil
+}
+
+// restoreLeases re-establishes all persisted leases that haven't expired yet
+// and removes the expired ones.
+func (l *LightningWallet) restoreLeases() error {
+	leases, err := l.Cfg.Database.FetchOutputLeases()
+	if err != nil {
+		return err
+	}
+
+	l.leases.Lock()
+	defer l.leases.Unlock()
+
+	now := time.Now()
+	for _, lease := range leases {
+		if !lease.Expiration.After(now) {
+			err := l.Cfg.Database.DeleteOutputLease(lease.OutPoint)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		l.LockOutpoint(lease.OutPoint)
+		l.scheduleLease(lease.OutPoint, lease.LockID, lease.Expiration)
+	}
+
+	return nil
+}
+
+// stopLeases stops the expiry timers of all active leases.
+func (l *LightningWallet) stopLeases() {
+	l.leases.Lock()
+	defer l.leases.Unlock()
+
+	for _, lease := range l.leases.leases {
+		lease.timer.Stop()
+	}
+}
+
+// scheduleLease tracks the lease of an output and expires it at the given
+// time.
+//
+// NOTE: The lease mutex MUST be held when calling this method.
+func (l *LightningWallet) scheduleLease(op wire.OutPoint, id [32]byte,
+	expiration time.Time) {
+
+	lease := &outputLease{
+		lockID:     id,
+		expiration: expiration,
+	}
+	lease.timer = time.AfterFunc(time.Until(expiration), func() {
+		l.expireLease(op, lease)
+	})
+
+	l.leases.leases[op] = lease
+}
+
+// expireLease removes the passed lease once it expired, unless it has been
+// extended or released in the meantime.
+func (l *LightningWallet) expireLease(op wire.OutPoint, lease *outputLease) {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	l.leases.Lock()
+	defer l.leases.Unlock()
+
+	if l.leases.leases[op] != lease {
+		return
+	}
+
+	if err := l.Cfg.Database.DeleteOutputLease(op); err != nil {
+		walletLog.Errorf("Unable to delete expired lease of %v: %v",
+			op, err)
+	}
+	l.removeLease(op)
+
+	walletLog.Debugf("Lease of output %v expired", op)
+}
+
+// removeLease forgets the lease of the passed output and unlocks it, unless
+// it has been reserved by a funding flow in the meantime.
+//
+// NOTE: The coin selection and lease mutexes MUST be held when calling this
+// method.
+func (l *LightningWallet) removeLease(op wire.OutPoint) {
+	delete(l.leases.leases, op)
+
+	if _, ok := l.lockedOutPoints[op]; ok {
+		return
+	}
+	l.UnlockOutpoint(op)
+}
+
+// isLeased returns true if the passed output is currently leased.
+func (l *LightningWallet) isLeased(op wire.OutPoint) bool {
+	l.leases.Lock()
+	defer l.leases.Unlock()
+
+	_, ok := l.leases.leases[op]
+	return ok
+}
+
+// leaseID returns the lock ID the passed output is leased under, if any.
+func (l *LightningWallet) leaseID(op wire.OutPoint) ([32]byte, bool) {
+	l.leases.Lock()
+	defer l.leases.Unlock()
+
+	lease, ok := l.leases.leases[op]
+	if !ok {
+		return [32]byte{}, false
+	}
+	return lease.lockID, true
+}
+
+// unlockUnleasedOutpoint unlocks the passed output if it isn't leased.
+// Outputs that are leased stay locked until the lease is released or expires.
+func (l *LightningWallet) unlockUnleasedOutpoint(op wire.OutPoint) {
+	if l.isLeased(op) {
+		return
+	}
+	l.UnlockOutpoint(op)
+}
@@ -1,6 +1,7 @@
 package lnwallet
 
 import (
+	"errors"
 	"net"
 	"sync"
 
@@ -12,6 +13,57 @@ import (
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
+// CommitmentType is an enum indicating the commitment format that should be
+// used for a single funder channel we're creating.
+type CommitmentType int
+
+const (
+	// CommitmentTypeLegacy is the original commitment format, where the
+	// key of the non-delay output is tweaked with the commitment point.
+	CommitmentTypeLegacy CommitmentType = iota
+
+	// CommitmentTypeTweakless is a commitment format that doesn't tweak
+	// the key of the non-delay output, such that it pays to a static key.
+	CommitmentTypeTweakless
+
+	// CommitmentTypeAnchors is a tweakless commitment format that in
+	// addition carries an anchor output for each party, allowing the
+	// commitment transaction to be fee bumped using CPFP.
+	CommitmentTypeAnchors
+)
+
+// chanType returns the single funder channel type corresponding to the
+// commitment type.
+func (c CommitmentType) chanType() channeldb.ChannelType {
+	switch c {
+	case CommitmentTypeTweakless:
+		return channeldb.SingleFunderTweakless
+
+	case CommitmentTypeAnchors:
+		return channeldb.SingleFunderAnchors
+
+	default:
+		return channeldb.SingleFunder
+	}
+}
+
+// String returns a human readable name of the commitment type.
+func (c CommitmentType) String() string {
+	switch c {
+	case CommitmentTypeLegacy:
+		return "legacy"
+
+	case CommitmentTypeTweakless:
+		return "tweakless"
+
+	case CommitmentTypeAnchors:
+		return "anchors"
+
+	default:
+		return "unknown"
+	}
+}
+
 // ChannelContribution is the primary constituent of the funding workflow
 // within lnwallet. Each side first exchanges their respective contributions
 // along with channel specific parameters like the min fee/KB. Once
@@ -41,6 +93,11 @@ type ChannelContribution struct {
 	// such as the min HTLC, and also all the keys which will be used for
 	// the duration of the channel.
 	*channeldb.ChannelConfig
+
+	// UpfrontShutdown is the script that this node commits to paying its
+	// funds out to upon a cooperative close of the channel. If empty, no
+	// script has been committed to.
+	UpfrontShutdown lnwire.DeliveryAddress
 }
 
 // toChanConfig returns the raw channel configuration generated by a node's
@@ -133,11 +190,13 @@ type ChannelReservation struct {
 // NewChannelReservation creates a new channel reservation. This function is
 // used only internally by lnwallet. In order to concurrent safety, the
 // creation of all channel reservations should be carried out via the
-// lnwallet.InitChannelReservation interface.
+// lnwallet.InitChannelReservation interface. The passed commitment type
+// determines the commitment format of a single funder channel.
 func NewChannelReservation(capacity, fundingAmt btcutil.Amount,
 	commitFeePerKw SatPerKWeight, wallet *LightningWallet,
 	id uint64, pushMSat lnwire.MilliSatoshi, chainHash *chainhash.Hash,
-	flags lnwire.FundingFlag) (*ChannelReservation, error) {
+	flags lnwire.FundingFlag,
+	commitType CommitmentType) (*ChannelReservation, error) {
 
 	var (
 		ourBalance   lnwire.MilliSatoshi
@@ -145,7 +204,14 @@ func NewChannelReservation(capacity, fundingAmt btcutil.Amount,
 		initiator    bool
 	)
 
-	commitFee := commitFeePerKw.FeeForWeight(CommitWeight)
+	// The fee of the initial commitment depends on its format, as
+	// channels with anchor outputs have a heavier commitment, and the
+	// initiator pays for the anchors as well.
+	singleFunderType := commitType.chanType()
+	commitFee := commitFeeForWeight(
+		singleFunderType, commitFeePerKw,
+		baseCommitWeight(singleFunderType),
+	)
 	fundingMSat := lnwire.NewMSatFromSatoshis(fundingAmt)
 	capacityMSat := lnwire.NewMSatFromSatoshis(capacity)
 	feeMSat := lnwire.NewMSatFromSatoshis(commitFee)
@@ -218,12 +284,19 @@ func NewChannelReservation(capacity, fundingAmt btcutil.Amount,
 	// non-zero push amt (there's no pushing for dual funder), then this is
 	// a single-funder channel.
 	if ourBalance == 0 || theirBalance == 0 || pushMSat != 0 {
-		chanType = channeldb.SingleFunder
+		chanType = singleFunderType
 	} else {
 		// Otherwise, this is a dual funder channel, and no side is
 		// technically the "initiator"
 		initiator = false
 		chanType = channeldb.DualFunder
+
+		// Dual funder channels only support the original commitment
+		// format.
+		if commitType != CommitmentTypeLegacy {
+			return nil, errors.New("dual funder channels only " +
+				"support the legacy commitment type")
+		}
 	}
 
 	return &ChannelReservation{
@@ -289,6 +362,36 @@ func (r *ChannelReservation) CommitConstraints(csvDelay, maxHtlcs uint16,
 	r.Lock()
 	defer r.Unlock()
 
+	err := VerifyConstraints(
+		csvDelay, maxHtlcs, maxValueInFlight, minHtlc, chanReserve,
+		dustLimit, r.partialState.Capacity,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Our dust limit should always be less than or equal our proposed
+	// channel reserve.
+	if r.ourContribution.DustLimit > chanReserve {
+		r.ourContribution.DustLimit = chanReserve
+	}
+
+	r.ourContribution.ChannelConfig.CsvDelay = csvDelay
+	r.ourContribution.ChannelConfig.ChanReserve = chanReserve
+	r.ourContribution.ChannelConfig.MaxAcceptedHtlcs = maxHtlcs
+	r.ourContribution.ChannelConfig.MaxPendingAmount = maxValueInFlight
+	r.ourContribution.ChannelConfig.MinHTLC = minHtlc
+
+	return nil
+}
+
+// VerifyConstraints checks the channel constraints one party requires of the
+// other for a channel of the given capacity for sanity, returning an error if
+// they are outside the bounds we're willing to accept or to propose.
+func VerifyConstraints(csvDelay, maxHtlcs uint16, maxValueInFlight,
+	minHtlc lnwire.MilliSatoshi, chanReserve, dustLimit,
+	capacity btcutil.Amount) error {
+
 	// Fail if we consider csvDelay excessively large.
 	// TODO(halseth): find a more scientific choice of value.
 	const maxDelay = 10000
@@ -304,7 +407,7 @@ func (r *ChannelReservation) CommitConstraints(csvDelay, maxHtlcs uint16,
 
 	// Fail if we consider the channel reserve to be too large.  We
 	// currently fail if it is greater than 20% of the channel capacity.
-	maxChanReserve := r.partialState.Capacity / 5
+	maxChanReserve := capacity / 5
 	if chanReserve > maxChanReserve {
 		return ErrChanReserveTooLarge(chanReserve, maxChanReserve)
 	}
@@ -337,18 +440,6 @@ func (r *ChannelReservation) CommitConstraints(csvDelay, maxHtlcs uint16,
 			minNumHtlc*minHtlc)
 	}
 
-	// Our dust limit should always be less than or equal our proposed
-	// channel reserve.
-	if r.ourContribution.DustLimit > chanReserve {
-		r.ourContribution.DustLimit = chanReserve
-	}
-
-	r.ourContribution.ChannelConfig.CsvDelay = csvDelay
-	r.ourContribution.ChannelConfig.ChanReserve = chanReserve
-	r.ourContribution.ChannelConfig.MaxAcceptedHtlcs = maxHtlcs
-	r.ourContribution.ChannelConfig.MaxPendingAmount = maxValueInFlight
-	r.ourContribution.ChannelConfig.MinHTLC = minHtlc
-
 	return nil
 }
 
@@ -1031,9 +1031,10 @@ func CommitSpendRevoke(signer Signer, signDesc *SignDescriptor,
 //
 // NOTE: The passed SignDescriptor should include the raw (untweaked) public
 // key of the receiver and also the proper single tweak value based on the
-// current commitment point.
+// current commitment point. If tweakless is true, then the output pays
+// directly to the raw public key, and no single tweak should be set.
 func CommitSpendNoDelay(signer Signer, signDesc *SignDescriptor,
-	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+	sweepTx *wire.MsgTx, tweakless bool) (wire.TxWitness, error) {
 
 	if signDesc.KeyDesc.PubKey == nil {
 		return nil, fmt.Errorf("cannot generate witness with nil " +
@@ -1048,14 +1049,100 @@ func CommitSpendNoDelay(signer Signer, signDesc *SignDescriptor,
 	}
 
 	// Finally, we'll manually craft the witness. The witness here is the
-	// exact same as a regular p2wkh witness, but we'll need to ensure that
-	// we use the tweaked public key as the last item in the witness stack
-	// which was originally used to created the pkScript we're spending.
+	// exact same as a regular p2wkh witness, depending on the value of the
+	// tweakless bool. If the output isn't tweakless, we'll need to ensure
+	// that we use the tweaked public key as the last item in the witness
+	// stack which was originally used to created the pkScript we're
+	// spending.
 	witness := make([][]byte, 2)
 	witness[0] = append(sweepSig, byte(signDesc.HashType))
-	witness[1] = TweakPubKeyWithTweak(
-		signDesc.KeyDesc.PubKey, signDesc.SingleTweak,
-	).SerializeCompressed()
+	if tweakless {
+		witness[1] = signDesc.KeyDesc.PubKey.SerializeCompressed()
+	} else {
+		witness[1] = TweakPubKeyWithTweak(
+			signDesc.KeyDesc.PubKey, signDesc.SingleTweak,
+		).SerializeCompressed()
+	}
+
+	return witness, nil
+}
+
+// AnchorCSVDelay is the relative delay (in blocks) after which anybody is able
+// to spend an anchor output on the commitment transaction.
+const AnchorCSVDelay = 16
+
+// CommitScriptAnchor constructs the script for the anchor output spendable by
+// the given key immediately, or by anyone after AnchorCSVDelay blocks. The
+// anchor outputs allow either party to attach a child transaction to the
+// commitment in order to bump its fee (CPFP). The anyone-can-spend clause
+// ensures that the anchors don't clutter the UTXO set in case they're never
+// swept by their owner.
+//
+// Possible Input Scripts:
+//    By owner:		<sig>
+//    By anyone (after 16 conf):	<emptyvector>
+//
+// Output Script:
+//	<funding_pubkey> OP_CHECKSIG OP_IFDUP
+//	OP_NOTIF
+//		OP_16 OP_CSV
+//	OP_ENDIF
+func CommitScriptAnchor(key *btcec.PublicKey) ([]byte, error) {
+	builder := txscript.NewScriptBuilder()
+
+	// Spend immediately with key.
+	builder.AddData(key.SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIG)
+
+	// Duplicate the value if true, since it will be consumed by the
+	// NOTIF.
+	builder.AddOp(txscript.OP_IFDUP)
+
+	// Otherwise spendable by anyone after 16 blocks.
+	builder.AddOp(txscript.OP_NOTIF)
+	builder.AddInt64(AnchorCSVDelay)
+	builder.AddOp(txscript.OP_CHECKSEQUENCEVERIFY)
+	builder.AddOp(txscript.OP_ENDIF)
+
+	return builder.Script()
+}
+
+// CommitSpendAnchor constructs a valid witness allowing a node to spend their
+// anchor output on the commitment transaction using their funding key. This
+// is used for the anchor channel type.
+//
+// NOTE: The passed SignDescriptor should include the funding key of the node
+// and the witness script of the anchor output.
+func CommitSpendAnchor(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+	if signDesc.KeyDesc.PubKey == nil {
+		return nil, fmt.Errorf("cannot generate witness with nil " +
+			"KeyDesc pubkey")
+	}
+
+	// Create a signature.
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	// The witness here is just a signature and the witness script.
+	witness := make([][]byte, 2)
+	witness[0] = append(sweepSig, byte(signDesc.HashType))
+	witness[1] = signDesc.WitnessScript
+
+	return witness, nil
+}
+
+// CommitSpendAnchorAnyone constructs a witness allowing anyone to spend the
+// anchor output after it has gotten AnchorCSVDelay confirmations. As such,
+// this witness requires no signature.
+func CommitSpendAnchorAnyone(script []byte) (wire.TxWitness, error) {
+	// The witness here is just the nil signature and the witness script.
+	witness := make([][]byte, 2)
+	witness[0] = nil
+	witness[1] = script
 
 	return witness, nil
 }
@@ -205,7 +205,7 @@ func TestCommitmentSpendValidation(t *testing.T) {
 		InputIndex: 0,
 	}
 	bobRegularSpend, err := CommitSpendNoDelay(bobSigner, signDesc,
-		sweepTx)
+		sweepTx, false)
 	if err != nil {
 		t.Fatalf("unable to create bob regular spend: %v", err)
 	}
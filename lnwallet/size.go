@@ -3,6 +3,7 @@ package lnwallet
 import (
 	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
 )
 
 const (
@@ -10,8 +11,19 @@ const (
 	// includes: one p2wsh input, out p2wkh output, and one p2wsh output.
 	CommitWeight int64 = 724
 
+	// AnchorCommitWeight is the weight of the base commitment transaction
+	// of a channel using anchor outputs, which includes two additional
+	// p2wsh anchor outputs on top of the outputs included in
+	// CommitWeight.
+	AnchorCommitWeight int64 = CommitWeight + 2*witnessScaleFactor*
+		P2WSHOutputSize
+
 	// HtlcWeight is the weight of an HTLC output.
 	HtlcWeight int64 = 172
+
+	// AnchorSize is the constant value of each of the two anchor outputs
+	// on the commitment transaction of a channel using anchor outputs.
+	AnchorSize btcutil.Amount = 330
 )
 
 const (
@@ -219,6 +231,32 @@ const (
 	//      - witness_script (to_local_script)
 	ToLocalPenaltyWitnessSize = 1 + 1 + 73 + 1 + 1 + ToLocalScriptSize
 
+	// AnchorScriptSize 40 bytes
+	//      - pubkey_length: 1 byte
+	//      - pubkey: 33 bytes
+	//      - OP_CHECKSIG: 1 byte
+	//      - OP_IFDUP: 1 byte
+	//      - OP_NOTIF: 1 byte
+	//              - OP_16: 1 byte
+	//              - OP_CSV: 1 byte
+	//      - OP_ENDIF: 1 byte
+	AnchorScriptSize = 1 + 33 + 6*1
+
+	// AnchorWitnessSize 116 bytes
+	//      - number_of_witness_elements: 1 byte
+	//      - signature_length: 1 byte
+	//      - signature: 73 bytes
+	//      - witness_script_length: 1 byte
+	//      - witness_script (anchor_script)
+	AnchorWitnessSize = 1 + 1 + 73 + 1 + AnchorScriptSize
+
+	// AnchorAnyoneWitnessSize 43 bytes
+	//      - number_of_witness_elements: 1 byte
+	//      - empty_vector_length: 1 byte
+	//      - witness_script_length: 1 byte
+	//      - witness_script (anchor_script)
+	AnchorAnyoneWitnessSize = 1 + 1 + 1 + AnchorScriptSize
+
 	// AcceptedHtlcScriptSize 139 bytes
 	//      - OP_DUP: 1 byte
 	//      - OP_HASH160: 1 byte
@@ -478,6 +516,15 @@ func (twe *TxWeightEstimator) AddP2SHOutput() *TxWeightEstimator {
 	return twe
 }
 
+// AddTxOutput updates the weight estimate to account for an additional
+// output with an arbitrary script.
+func (twe *TxWeightEstimator) AddTxOutput(txOut *wire.TxOut) *TxWeightEstimator {
+	twe.outputSize += txOut.SerializeSize()
+	twe.outputCount++
+
+	return twe
+}
+
 // Weight gets the estimated weight of the transaction.
 func (twe *TxWeightEstimator) Weight() int {
 	txSizeStripped := BaseTxSize +
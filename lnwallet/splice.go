@@ -0,0 +1,430 @@
+package lnwallet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/txsort"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// ErrSpliceActiveHtlcs is returned when a splice is attempted while
+	// the channel still carries HTLCs.
+	ErrSpliceActiveHtlcs = fmt.Errorf("unable to splice channel with " +
+		"active HTLCs")
+
+	// ErrSpliceNotSynced is returned when a splice is attempted while the
+	// commitment chains of both parties aren't fully in sync.
+	ErrSpliceNotSynced = fmt.Errorf("unable to splice channel with " +
+		"pending updates")
+
+	// ErrSpliceBelowReserve is returned when the balance of the
+	// initiator would drop below its channel reserve due to the splice.
+	ErrSpliceBelowReserve = fmt.Errorf("splice would drop initiator " +
+		"balance below channel reserve")
+)
+
+// spliceTxWeight is the estimated weight of a splice transaction, which
+// spends the 2-of-2 multi-sig funding output into a new funding output, and
+// a payout output. As the payout script is chosen by the initiator, we'll
+// conservatively estimate it as a P2WSH output.
+var spliceTxWeight = func() int64 {
+	var weightEstimate TxWeightEstimator
+	weightEstimate.AddWitnessInput(WitnessSize)
+	weightEstimate.AddP2WSHOutput()
+	weightEstimate.AddP2WSHOutput()
+
+	return int64(weightEstimate.Weight())
+}()
+
+// SpliceOut houses the state of a splice of funds out of an active channel,
+// as seen by either party. The splice transaction spends the current funding
+// output into a new funding output of reduced value, and a payout to a script
+// chosen by the channel initiator. The payout, as well as the fee of the
+// splice transaction, are deducted from the balance of the channel
+// initiator. A new pair of commitment transactions spending the new funding
+// output is created at the current commitment height, such that both the
+// prior and the new commitments remain valid until the splice transaction
+// confirms.
+type SpliceOut struct {
+	lc *LightningChannel
+
+	// spliceTx is the splice transaction. It will only carry a witness
+	// once CompleteSpliceTx has been called.
+	spliceTx *wire.MsgTx
+
+	// fundingOutpoint is the outpoint of the new funding output.
+	fundingOutpoint wire.OutPoint
+
+	// capacity is the capacity of the channel after the splice.
+	capacity btcutil.Amount
+
+	// localCommit and remoteCommit are the new commitments of both
+	// parties that spend the new funding output.
+	localCommit  channeldb.ChannelCommitment
+	remoteCommit channeldb.ChannelCommitment
+}
+
+// NewSpliceOut creates a new splice that moves payoutAmt out of the channel
+// to payoutScript, paying a fee according to the passed fee rate. The channel
+// must not carry any HTLCs, and both commitment chains must be fully in sync.
+func (lc *LightningChannel) NewSpliceOut(payoutAmt btcutil.Amount,
+	payoutScript []byte, feePerKw SatPerKWeight) (*SpliceOut, error) {
+
+	if len(lc.ActiveHtlcs()) != 0 {
+		return nil, ErrSpliceActiveHtlcs
+	}
+	if !lc.FullySynced() || lc.remoteCommitChain.hasUnackedCommitment() {
+		return nil, ErrSpliceNotSynced
+	}
+
+	lc.RLock()
+	defer lc.RUnlock()
+
+	if lc.status == channelClosing || lc.status == channelClosed {
+		return nil, ErrChanClosing
+	}
+
+	if feePerKw < FeePerKwFloor {
+		return nil, fmt.Errorf("splice fee rate of %v below floor of "+
+			"%v", feePerKw, FeePerKwFloor)
+	}
+
+	// The payout must be standard for both parties, so it may not be
+	// below either dust limit.
+	if payoutAmt < lc.localChanCfg.DustLimit ||
+		payoutAmt < lc.remoteChanCfg.DustLimit {
+
+		return nil, fmt.Errorf("splice payout of %v is dust", payoutAmt)
+	}
+
+	// The initiator pays for both the payout and the fee of the splice
+	// transaction, so we'll ensure its balance remains above the channel
+	// reserve it has to maintain.
+	spliceFee := feePerKw.FeeForWeight(spliceTxWeight)
+	deduction := lnwire.NewMSatFromSatoshis(payoutAmt + spliceFee)
+
+	localCommit := lc.channelState.LocalCommitment
+	remoteCommit := lc.channelState.RemoteCommitment
+
+	var (
+		initiatorBalance lnwire.MilliSatoshi
+		initiatorReserve btcutil.Amount
+	)
+	if lc.channelState.IsInitiator {
+		initiatorBalance = localCommit.LocalBalance
+		initiatorReserve = lc.localChanCfg.ChanReserve
+	} else {
+		initiatorBalance = localCommit.RemoteBalance
+		initiatorReserve = lc.remoteChanCfg.ChanReserve
+	}
+	if initiatorBalance < deduction ||
+		(initiatorBalance-deduction).ToSatoshis() < initiatorReserve {
+
+		return nil, ErrSpliceBelowReserve
+	}
+
+	// With the amounts validated, we'll create the splice transaction,
+	// which pays the reduced capacity back into the 2-of-2 multi-sig
+	// funding script.
+	capacity := lc.channelState.Capacity - payoutAmt - spliceFee
+	spliceTx := wire.NewMsgTx(2)
+	spliceTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: lc.channelState.FundingOutpoint,
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	spliceTx.AddTxOut(&wire.TxOut{
+		PkScript: lc.signDesc.Output.PkScript,
+		Value:    int64(capacity),
+	})
+	spliceTx.AddTxOut(&wire.TxOut{
+		PkScript: payoutScript,
+		Value:    int64(payoutAmt),
+	})
+	txsort.InPlaceSort(spliceTx)
+
+	if err := blockchain.CheckTransactionSanity(
+		btcutil.NewTx(spliceTx),
+	); err != nil {
+		return nil, err
+	}
+
+	fundingOutpoint := wire.OutPoint{Hash: spliceTx.TxHash()}
+	for i, txOut := range spliceTx.TxOut {
+		if txOut.Value == int64(capacity) &&
+			bytes.Equal(txOut.PkScript, lc.signDesc.Output.PkScript) {
+
+			fundingOutpoint.Index = uint32(i)
+			break
+		}
+	}
+
+	// Next, we'll deduct the payout and fee from the initiator's balance
+	// within both commitments. As the channel doesn't carry any HTLCs,
+	// the commitment fees remain unchanged.
+	if lc.channelState.IsInitiator {
+		localCommit.LocalBalance -= deduction
+		remoteCommit.LocalBalance -= deduction
+	} else {
+		localCommit.RemoteBalance -= deduction
+		remoteCommit.RemoteBalance -= deduction
+	}
+
+	s := &SpliceOut{
+		lc:              lc,
+		spliceTx:        spliceTx,
+		fundingOutpoint: fundingOutpoint,
+		capacity:        capacity,
+		localCommit:     localCommit,
+		remoteCommit:    remoteCommit,
+	}
+
+	// Finally, we'll create the new commitment transactions at the
+	// current commitment heights of both parties.
+	localCommitPoint, err := deriveCommitPoint(
+		lc.Signer, lc.channelState, localCommit.CommitHeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+	remoteCommitPoint := lc.channelState.RemoteCurrentRevocation
+
+	localKeyRing := deriveCommitmentKeys(
+		localCommitPoint, true, lc.channelState.ChanType,
+		lc.localChanCfg, lc.remoteChanCfg,
+	)
+	remoteKeyRing := deriveCommitmentKeys(
+		remoteCommitPoint, false, lc.channelState.ChanType,
+		lc.localChanCfg, lc.remoteChanCfg,
+	)
+
+	s.localCommit.CommitTx, err = lc.createSpliceCommitTx(
+		fundingOutpoint, localKeyRing, &s.localCommit, true,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.localCommit.CommitSig = nil
+
+	s.remoteCommit.CommitTx, err = lc.createSpliceCommitTx(
+		fundingOutpoint, remoteKeyRing, &s.remoteCommit, false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.remoteCommit.CommitSig = nil
+
+	return s, nil
+}
+
+// createSpliceCommitTx creates a commitment transaction for the passed
+// commitment state that spends the new funding output of a splice.
+func (lc *LightningChannel) createSpliceCommitTx(fundingOutpoint wire.OutPoint,
+	keyRing *CommitmentKeyRing, c *channeldb.ChannelCommitment,
+	isOurs bool) (*wire.MsgTx, error) {
+
+	var (
+		delay                      uint32
+		delayBalance, p2wkhBalance btcutil.Amount
+		dustLimit                  btcutil.Amount
+	)
+	if isOurs {
+		delay = uint32(lc.localChanCfg.CsvDelay)
+		delayBalance = c.LocalBalance.ToSatoshis()
+		p2wkhBalance = c.RemoteBalance.ToSatoshis()
+		dustLimit = lc.localChanCfg.DustLimit
+	} else {
+		delay = uint32(lc.remoteChanCfg.CsvDelay)
+		delayBalance = c.RemoteBalance.ToSatoshis()
+		p2wkhBalance = c.LocalBalance.ToSatoshis()
+		dustLimit = lc.remoteChanCfg.DustLimit
+	}
+
+	fundingTxIn := *wire.NewTxIn(&fundingOutpoint, nil, nil)
+	commitTx, err := CreateCommitTx(
+		fundingTxIn, keyRing, delay, delayBalance, p2wkhBalance,
+		dustLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = SetStateNumHint(commitTx, c.CommitHeight, lc.stateHintObfuscator)
+	if err != nil {
+		return nil, err
+	}
+
+	txsort.InPlaceSort(commitTx)
+
+	uTx := btcutil.NewTx(commitTx)
+	if err := blockchain.CheckTransactionSanity(uTx); err != nil {
+		return nil, err
+	}
+
+	return commitTx, nil
+}
+
+// fundingSignDesc returns a sign descriptor for the 2-of-2 multi-sig output
+// created by the splice transaction.
+func (s *SpliceOut) fundingSignDesc() *SignDescriptor {
+	signDesc := *s.lc.signDesc
+	signDesc.Output = &wire.TxOut{
+		PkScript: s.lc.signDesc.Output.PkScript,
+		Value:    int64(s.capacity),
+	}
+
+	return &signDesc
+}
+
+// SpliceTx returns the splice transaction. Until CompleteSpliceTx has been
+// called, the returned transaction won't carry a witness.
+func (s *SpliceOut) SpliceTx() *wire.MsgTx {
+	return s.spliceTx
+}
+
+// FundingOutpoint returns the outpoint of the new funding output.
+func (s *SpliceOut) FundingOutpoint() wire.OutPoint {
+	return s.fundingOutpoint
+}
+
+// Capacity returns the capacity of the channel once the splice confirms.
+func (s *SpliceOut) Capacity() btcutil.Amount {
+	return s.capacity
+}
+
+// SignRemoteCommit returns our signature for the remote party's new
+// commitment transaction.
+func (s *SpliceOut) SignRemoteCommit() (lnwire.Sig, error) {
+	signDesc := s.fundingSignDesc()
+	signDesc.SigHashes = txscript.NewTxSigHashes(s.remoteCommit.CommitTx)
+
+	// The new funding output needs to be known to the signer before it
+	// signs a spend of it.
+	err := registerChannel(
+		s.lc.Signer, s.fundingOutpoint, s.capacity, s.lc.channelState,
+	)
+	if err != nil {
+		return lnwire.Sig{}, err
+	}
+
+	rawSig, err := s.lc.Signer.SignOutputRaw(
+		s.remoteCommit.CommitTx, signDesc,
+	)
+	if err != nil {
+		return lnwire.Sig{}, err
+	}
+
+	sig, err := lnwire.NewSigFromRawSignature(rawSig)
+	if err != nil {
+		return lnwire.Sig{}, err
+	}
+	s.remoteCommit.CommitSig = sig.ToSignatureBytes()
+
+	return sig, nil
+}
+
+// ReceiveCommitSig verifies the remote party's signature for our new
+// commitment transaction, and stores it if valid.
+func (s *SpliceOut) ReceiveCommitSig(commitSig lnwire.Sig) error {
+	signDesc := s.fundingSignDesc()
+	commitTx := s.localCommit.CommitTx
+	sigHash, err := txscript.CalcWitnessSigHash(
+		signDesc.WitnessScript, txscript.NewTxSigHashes(commitTx),
+		txscript.SigHashAll, commitTx, 0, int64(s.capacity),
+	)
+	if err != nil {
+		return err
+	}
+
+	sig, err := commitSig.ToSignature()
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(sigHash, s.lc.remoteChanCfg.MultiSigKey.PubKey) {
+		return fmt.Errorf("invalid splice commitment signature")
+	}
+
+	s.localCommit.CommitSig = commitSig.ToSignatureBytes()
+
+	return nil
+}
+
+// SignSpliceTx returns our signature for the input of the splice
+// transaction. It must only be called once the remote party's signature for
+// our new commitment has been received, as otherwise the remote party would
+// be able to hold our funds hostage.
+func (s *SpliceOut) SignSpliceTx() (lnwire.Sig, error) {
+	if s.localCommit.CommitSig == nil {
+		return lnwire.Sig{}, fmt.Errorf("splice commitment not signed")
+	}
+
+	signDesc := *s.lc.signDesc
+	signDesc.SigHashes = txscript.NewTxSigHashes(s.spliceTx)
+
+	rawSig, err := s.lc.Signer.SignOutputRaw(s.spliceTx, &signDesc)
+	if err != nil {
+		return lnwire.Sig{}, err
+	}
+
+	return lnwire.NewSigFromRawSignature(rawSig)
+}
+
+// CompleteSpliceTx assembles the witness of the splice transaction using our
+// own signature, and the passed signature of the remote party. The fully
+// signed splice transaction is validated, and returned.
+func (s *SpliceOut) CompleteSpliceTx(remoteSig lnwire.Sig) (*wire.MsgTx,
+	error) {
+
+	localSig, err := s.SignSpliceTx()
+	if err != nil {
+		return nil, err
+	}
+
+	localSigBytes := append(localSig.ToSignatureBytes(),
+		byte(txscript.SigHashAll))
+	remoteSigBytes := append(remoteSig.ToSignatureBytes(),
+		byte(txscript.SigHashAll))
+
+	ourKey := s.lc.localChanCfg.MultiSigKey.PubKey.SerializeCompressed()
+	theirKey := s.lc.remoteChanCfg.MultiSigKey.PubKey.SerializeCompressed()
+	s.spliceTx.TxIn[0].Witness = SpendMultiSig(
+		s.lc.signDesc.WitnessScript, ourKey, localSigBytes, theirKey,
+		remoteSigBytes,
+	)
+
+	// Validate the finalized transaction to ensure the funding output is
+	// properly spent, and that the remote party supplied a valid
+	// signature.
+	prevOut := s.lc.signDesc.Output
+	vm, err := txscript.NewEngine(prevOut.PkScript, s.spliceTx, 0,
+		txscript.StandardVerifyFlags, nil,
+		txscript.NewTxSigHashes(s.spliceTx), prevOut.Value)
+	if err != nil {
+		return nil, err
+	}
+	if err := vm.Execute(); err != nil {
+		s.spliceTx.TxIn[0].Witness = nil
+		return nil, err
+	}
+
+	return s.spliceTx, nil
+}
+
+// PendingSplice returns the persistable state of the splice, given the height
+// at which the splice transaction was broadcast.
+func (s *SpliceOut) PendingSplice(broadcastHeight uint32) *channeldb.PendingSplice {
+	return &channeldb.PendingSplice{
+		SpliceTx:         s.spliceTx,
+		FundingOutpoint:  s.fundingOutpoint,
+		Capacity:         s.capacity,
+		BroadcastHeight:  broadcastHeight,
+		LocalCommitment:  s.localCommit,
+		RemoteCommitment: s.remoteCommit,
+	}
+}
@@ -98,6 +98,15 @@ var (
 // the test has been finalized. The clean up function will remote all temporary
 // files created
 func CreateTestChannels() (*LightningChannel, *LightningChannel, func(), error) {
+	return CreateTestChannelsWithType(channeldb.SingleFunder)
+}
+
+// CreateTestChannelsWithType is identical to CreateTestChannels, but allows
+// the caller to specify the type of the channels created, e.g. to create
+// channels that use the tweakless commitment format.
+func CreateTestChannelsWithType(chanType channeldb.ChannelType) (
+	*LightningChannel, *LightningChannel, func(), error) {
+
 	channelCapacity, err := btcutil.NewAmount(10)
 	if err != nil {
 		return nil, nil, nil, err
@@ -212,7 +221,7 @@ func CreateTestChannels() (*LightningChannel, *LightningChannel, func(), error)
 
 	aliceCommitTx, bobCommitTx, err := CreateCommitmentTxns(channelBal,
 		channelBal, &aliceCfg, &bobCfg, aliceCommitPoint, bobCommitPoint,
-		*fundingTxIn)
+		*fundingTxIn, chanType)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -235,6 +244,11 @@ func CreateTestChannels() (*LightningChannel, *LightningChannel, func(), error)
 		return nil, nil, nil, err
 	}
 	commitFee := calcStaticFee(0)
+	if chanType.HasAnchors() {
+		commitFee = commitFeeForWeight(
+			chanType, feePerKw, baseCommitWeight(chanType),
+		)
+	}
 
 	aliceCommit := channeldb.ChannelCommitment{
 		CommitHeight:  0,
@@ -270,7 +284,7 @@ func CreateTestChannels() (*LightningChannel, *LightningChannel, func(), error)
 		IdentityPub:             aliceKeys[0].PubKey(),
 		FundingOutpoint:         *prevOut,
 		ShortChannelID:          shortChanID,
-		ChanType:                channeldb.SingleFunder,
+		ChanType:                chanType,
 		IsInitiator:             true,
 		Capacity:                channelCapacity,
 		RemoteCurrentRevocation: bobCommitPoint,
@@ -288,7 +302,7 @@ func CreateTestChannels() (*LightningChannel, *LightningChannel, func(), error)
 		IdentityPub:             bobKeys[0].PubKey(),
 		FundingOutpoint:         *prevOut,
 		ShortChannelID:          shortChanID,
-		ChanType:                channeldb.SingleFunder,
+		ChanType:                chanType,
 		IsInitiator:             false,
 		Capacity:                channelCapacity,
 		RemoteCurrentRevocation: aliceCommitPoint,
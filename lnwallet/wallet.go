@@ -97,6 +97,25 @@ type InitFundingReserveMsg struct {
 	// output selected to fund the channel should satisfy.
 	MinConfs int32
 
+	// Outpoints is an optional set of wallet outputs that should be used
+	// to fund the channel. If set, all of them are spent and no automatic
+	// coin selection is performed.
+	Outpoints []wire.OutPoint
+
+	// LockID is the lock ID the passed outpoints are leased under, if
+	// any. Outputs leased under a different lock ID can't be spent.
+	LockID *[32]byte
+
+	// UpfrontShutdown is the script that we commit to paying our funds
+	// out to upon a cooperative close of the channel. If empty, we won't
+	// commit to any particular script.
+	UpfrontShutdown lnwire.DeliveryAddress
+
+	// CommitType indicates the commitment format that both parties agreed
+	// upon based on the features they signaled, e.g. a commitment that
+	// doesn't tweak the key of the non-delay output.
+	CommitType CommitmentType
+
 	// err is a channel in which all errors will be sent across. Will be
 	// nil if this initial set is successful.
 	//
@@ -274,6 +293,10 @@ type LightningWallet struct {
 	// the currently locked outpoints.
 	lockedOutPoints map[wire.OutPoint]struct{}
 
+	// leases tracks the outputs that are leased through LeaseOutput. These
+	// outputs stay locked until their lease is released or expires.
+	leases leaseState
+
 	quit chan struct{}
 
 	wg sync.WaitGroup
@@ -294,7 +317,10 @@ func NewLightningWallet(Cfg Config) (*LightningWallet, error) {
 		nextFundingID:    0,
 		fundingLimbo:     make(map[uint64]*ChannelReservation),
 		lockedOutPoints:  make(map[wire.OutPoint]struct{}),
-		quit:             make(chan struct{}),
+		leases: leaseState{
+			leases: make(map[wire.OutPoint]*outputLease),
+		},
+		quit: make(chan struct{}),
 	}, nil
 }
 
@@ -311,6 +337,12 @@ func (l *LightningWallet) Startup() error {
 		return err
 	}
 
+	// Re-lock all outputs that are still leased, as the underlying wallet
+	// doesn't persist locked outputs across restarts.
+	if err := l.restoreLeases(); err != nil {
+		return err
+	}
+
 	l.wg.Add(1)
 	// TODO(roasbeef): multiple request handlers?
 	go l.requestHandler()
@@ -330,6 +362,8 @@ func (l *LightningWallet) Shutdown() error {
 		return err
 	}
 
+	l.stopLeases()
+
 	close(l.quit)
 	l.wg.Wait()
 	return nil
@@ -352,7 +386,7 @@ func (l *LightningWallet) ResetReservations() {
 	l.fundingLimbo = make(map[uint64]*ChannelReservation)
 
 	for outpoint := range l.lockedOutPoints {
-		l.UnlockOutpoint(outpoint)
+		l.unlockUnleasedOutpoint(outpoint)
 	}
 	l.lockedOutPoints = make(map[wire.OutPoint]struct{})
 }
@@ -456,6 +490,7 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 	reservation, err := NewChannelReservation(
 		req.Capacity, req.FundingAmount, req.CommitFeePerKw, l, id,
 		req.PushMSat, l.Cfg.NetParams.GenesisHash, req.Flags,
+		req.CommitType,
 	)
 	if err != nil {
 		req.err <- err
@@ -478,7 +513,7 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 		// the fee rate passed in to perform coin selection.
 		err := l.selectCoinsAndChange(
 			req.FundingFeePerKw, req.FundingAmount, req.MinConfs,
-			reservation.ourContribution,
+			req.Outpoints, req.LockID, reservation.ourContribution,
 		)
 		if err != nil {
 			req.err <- err
@@ -536,42 +571,19 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 
 	// With the above keys created, we'll also need to initialization our
 	// initial revocation tree state.
-	nextRevocationKeyDesc, err := l.DeriveNextKey(
-		keychain.KeyFamilyRevocationRoot,
+	producer, firstCommitPoint, err := l.initRevocationState(
+		reservation.ourContribution.MultiSigKey,
 	)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
 		return
 	}
-	revocationRoot, err := l.DerivePrivKey(nextRevocationKeyDesc)
-	if err != nil {
-		req.err <- err
-		req.resp <- nil
-		return
-	}
-
-	// Once we have the root, we can then generate our shachain producer
-	// and from that generate the per-commitment point.
-	revRoot, err := chainhash.NewHash(revocationRoot.Serialize())
-	if err != nil {
-		req.err <- err
-		req.resp <- nil
-		return
-	}
-	producer := shachain.NewRevocationProducer(*revRoot)
-	firstPreimage, err := producer.AtIndex(0)
-	if err != nil {
-		req.err <- err
-		req.resp <- nil
-		return
-	}
-	reservation.ourContribution.FirstCommitmentPoint = ComputeCommitmentPoint(
-		firstPreimage[:],
-	)
+	reservation.ourContribution.FirstCommitmentPoint = firstCommitPoint
 
 	reservation.partialState.RevocationProducer = producer
 	reservation.ourContribution.ChannelConstraints = l.Cfg.DefaultConstraints
+	reservation.ourContribution.UpfrontShutdown = req.UpfrontShutdown
 
 	// TODO(roasbeef): turn above into: initContribution()
 
@@ -588,6 +600,52 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 	req.err <- nil
 }
 
+// initRevocationState creates the revocation producer of a new channel that
+// uses the passed multi-sig key, and returns it along with our first
+// commitment point. If the signer keeps the revocation secrets of our
+// channels, no producer is created and the commitment point is fetched from
+// the signer instead.
+func (l *LightningWallet) initRevocationState(
+	multiSigKey keychain.KeyDescriptor) (shachain.Producer,
+	*btcec.PublicKey, error) {
+
+	if revSigner, ok := l.Cfg.Signer.(RevocationSigner); ok {
+		firstCommitPoint, err := revSigner.CommitmentPoint(
+			multiSigKey, 0,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, firstCommitPoint, nil
+	}
+
+	nextRevocationKeyDesc, err := l.DeriveNextKey(
+		keychain.KeyFamilyRevocationRoot,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	revocationRoot, err := l.DerivePrivKey(nextRevocationKeyDesc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Once we have the root, we can then generate our shachain producer
+	// and from that generate the per-commitment point.
+	revRoot, err := chainhash.NewHash(revocationRoot.Serialize())
+	if err != nil {
+		return nil, nil, err
+	}
+	producer := shachain.NewRevocationProducer(*revRoot)
+	firstPreimage, err := producer.AtIndex(0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return producer, ComputeCommitmentPoint(firstPreimage[:]), nil
+}
+
 // handleFundingReserveCancel cancels an existing channel reservation. As part
 // of the cancellation, outputs previously selected as inputs for the funding
 // transaction via coin selection are freed allowing future reservations to
@@ -612,7 +670,7 @@ func (l *LightningWallet) handleFundingCancelRequest(req *fundingReserveCancelMs
 	// requests.
 	for _, unusedInput := range pendingReservation.ourContribution.Inputs {
 		delete(l.lockedOutPoints, unusedInput.PreviousOutPoint)
-		l.UnlockOutpoint(unusedInput.PreviousOutPoint)
+		l.unlockUnleasedOutpoint(unusedInput.PreviousOutPoint)
 	}
 
 	// TODO(roasbeef): is it even worth it to keep track of unused keys?
@@ -633,12 +691,13 @@ func (l *LightningWallet) handleFundingCancelRequest(req *fundingReserveCancelMs
 func CreateCommitmentTxns(localBalance, remoteBalance btcutil.Amount,
 	ourChanCfg, theirChanCfg *channeldb.ChannelConfig,
 	localCommitPoint, remoteCommitPoint *btcec.PublicKey,
-	fundingTxIn wire.TxIn) (*wire.MsgTx, *wire.MsgTx, error) {
+	fundingTxIn wire.TxIn,
+	chanType channeldb.ChannelType) (*wire.MsgTx, *wire.MsgTx, error) {
 
 	localCommitmentKeys := deriveCommitmentKeys(localCommitPoint, true,
-		ourChanCfg, theirChanCfg)
+		chanType, ourChanCfg, theirChanCfg)
 	remoteCommitmentKeys := deriveCommitmentKeys(remoteCommitPoint, false,
-		ourChanCfg, theirChanCfg)
+		chanType, ourChanCfg, theirChanCfg)
 
 	ourCommitTx, err := CreateCommitTx(fundingTxIn, localCommitmentKeys,
 		uint32(ourChanCfg.CsvDelay), localBalance, remoteBalance,
@@ -806,6 +865,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 		theirContribution.ChannelConfig,
 		ourContribution.FirstCommitmentPoint,
 		theirContribution.FirstCommitmentPoint, fundingTxIn,
+		pendingReservation.partialState.ChanType,
 	)
 	if err != nil {
 		req.err <- err
@@ -816,7 +876,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	// obfuscator then use it to encode the current state number within
 	// both commitment transactions.
 	var stateObfuscator [StateHintSize]byte
-	if chanState.ChanType == channeldb.SingleFunder {
+	if chanState.ChanType.IsSingleFunder() {
 		stateObfuscator = DeriveStateHintObfuscator(
 			ourContribution.PaymentBasePoint.PubKey,
 			theirContribution.PaymentBasePoint.PubKey,
@@ -859,6 +919,21 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	chanState.LocalCommitment.CommitTx = ourCommitTx
 	chanState.RemoteCommitment.CommitTx = theirCommitTx
 
+	// Make sure the signer knows about the channel before asking it to
+	// sign a spend of the funding output. As it needs the channel configs
+	// to recognize our commitments, and our upfront shutdown script to
+	// sign a cooperative close, we populate them now.
+	chanState.LocalChanCfg = ourContribution.toChanConfig()
+	chanState.RemoteChanCfg = theirContribution.toChanConfig()
+	chanState.LocalShutdownScript = ourContribution.UpfrontShutdown
+	err = registerChannel(
+		l.Cfg.Signer, *fundingOutpoint, chanState.Capacity, chanState,
+	)
+	if err != nil {
+		req.err <- err
+		return
+	}
+
 	// Generate a signature for their version of the initial commitment
 	// transaction.
 	signDesc = SignDescriptor{
@@ -1076,6 +1151,8 @@ func (l *LightningWallet) handleFundingCounterPartySigs(msg *addCounterPartySigs
 	// he stored within the database.
 	res.partialState.LocalChanCfg = res.ourContribution.toChanConfig()
 	res.partialState.RemoteChanCfg = res.theirContribution.toChanConfig()
+	res.partialState.LocalShutdownScript = res.ourContribution.UpfrontShutdown
+	res.partialState.RemoteShutdownScript = res.theirContribution.UpfrontShutdown
 
 	// We'll also record the finalized funding txn, which will allow us to
 	// rebroadcast on startup in case we fail.
@@ -1129,7 +1206,7 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 		pendingReservation.theirContribution.ChannelConfig,
 		pendingReservation.ourContribution.FirstCommitmentPoint,
 		pendingReservation.theirContribution.FirstCommitmentPoint,
-		*fundingTxIn,
+		*fundingTxIn, pendingReservation.partialState.ChanType,
 	)
 	if err != nil {
 		req.err <- err
@@ -1221,6 +1298,23 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 		SigHashes:  txscript.NewTxSigHashes(theirCommitTx),
 		InputIndex: 0,
 	}
+	// Make sure the signer knows about the channel before asking it to
+	// sign a spend of the funding output. As it needs the channel configs
+	// to recognize our commitments, and our upfront shutdown script to
+	// sign a cooperative close, we populate them now.
+	chanState.LocalChanCfg = pendingReservation.ourContribution.toChanConfig()
+	chanState.RemoteChanCfg = pendingReservation.theirContribution.toChanConfig()
+	chanState.LocalShutdownScript = pendingReservation.ourContribution.UpfrontShutdown
+	err = registerChannel(
+		l.Cfg.Signer, *req.fundingOutpoint,
+		btcutil.Amount(channelValue), chanState,
+	)
+	if err != nil {
+		req.err <- err
+		req.completeChan <- nil
+		return
+	}
+
 	sigTheirCommit, err := l.Cfg.Signer.SignOutputRaw(theirCommitTx, &signDesc)
 	if err != nil {
 		req.err <- err
@@ -1240,6 +1334,8 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 	// which will be used for the lifetime of this channel.
 	chanState.LocalChanCfg = pendingReservation.ourContribution.toChanConfig()
 	chanState.RemoteChanCfg = pendingReservation.theirContribution.toChanConfig()
+	chanState.LocalShutdownScript = pendingReservation.ourContribution.UpfrontShutdown
+	chanState.RemoteShutdownScript = pendingReservation.theirContribution.UpfrontShutdown
 	err = chanState.SyncPending(pendingReservation.nodeAddr, uint32(bestHeight))
 	if err != nil {
 		req.err <- err
@@ -1259,11 +1355,12 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 // outputs which sum to at least 'numCoins' amount of satoshis. If coin
 // selection is successful/possible, then the selected coins are available
 // within the passed contribution's inputs. If necessary, a change address will
-// also be generated.
+// also be generated. If outpoints are passed, all of them are used to fund the
+// amount instead of selecting coins automatically.
 // TODO(roasbeef): remove hardcoded fees.
 func (l *LightningWallet) selectCoinsAndChange(feeRate SatPerKWeight,
-	amt btcutil.Amount, minConfs int32,
-	contribution *ChannelContribution) error {
+	amt btcutil.Amount, minConfs int32, outpoints []wire.OutPoint,
+	lockID *[32]byte, contribution *ChannelContribution) error {
 
 	// We hold the coin select mutex while querying for outputs, and
 	// performing coin selection in order to avoid inadvertent double
@@ -1274,19 +1371,38 @@ func (l *LightningWallet) selectCoinsAndChange(feeRate SatPerKWeight,
 	walletLog.Infof("Performing funding tx coin selection using %v "+
 		"sat/kw as fee rate", int64(feeRate))
 
-	// Find all unlocked unspent witness outputs that satisfy the minimum
-	// number of confirmations required.
-	coins, err := l.ListUnspentWitness(minConfs)
-	if err != nil {
-		return err
-	}
+	var (
+		selectedCoins []*Utxo
+		changeAmt     btcutil.Amount
+	)
+	if len(outpoints) != 0 {
+		// The caller picked the coins to spend, so we'll use all of
+		// them and only compute the change.
+		coins, err := l.fetchWalletUtxos(outpoints, lockID)
+		if err != nil {
+			return err
+		}
 
-	// Perform coin selection over our available, unlocked unspent outputs
-	// in order to find enough coins to meet the funding amount
-	// requirements.
-	selectedCoins, changeAmt, err := coinSelect(feeRate, amt, coins)
-	if err != nil {
-		return err
+		changeAmt, err = coinSelectExact(feeRate, amt, coins)
+		if err != nil {
+			return err
+		}
+		selectedCoins = coins
+	} else {
+		// Find all unlocked unspent witness outputs that satisfy the
+		// minimum number of confirmations required.
+		coins, err := l.ListUnspentWitness(minConfs)
+		if err != nil {
+			return err
+		}
+
+		// Perform coin selection over our available, unlocked unspent
+		// outputs in order to find enough coins to meet the funding
+		// amount requirements.
+		selectedCoins, changeAmt, err = coinSelect(feeRate, amt, coins)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Lock the selected coins. These coins are now "reserved", this
@@ -1440,3 +1556,41 @@ func coinSelect(feeRate SatPerKWeight, amt btcutil.Amount,
 		return selectedUtxos, changeAmt, nil
 	}
 }
+
+// coinSelectExact computes the change of a funding transaction that spends
+// all of the passed coins to fund amt satoshis, adhering to the specified fee
+// rate. An error is returned if the coins don't suffice to pay for the amount
+// and the fees.
+func coinSelectExact(feeRate SatPerKWeight, amt btcutil.Amount,
+	coins []*Utxo) (btcutil.Amount, error) {
+
+	var (
+		weightEstimate TxWeightEstimator
+		totalSat       btcutil.Amount
+	)
+	for _, utxo := range coins {
+		switch utxo.AddressType {
+		case WitnessPubKey:
+			weightEstimate.AddP2WKHInput()
+		case NestedWitnessPubKey:
+			weightEstimate.AddNestedP2WKHInput()
+		default:
+			return 0, fmt.Errorf("Unsupported address type: %v",
+				utxo.AddressType)
+		}
+
+		totalSat += utxo.Value
+	}
+
+	// Channel funding multisig output is P2WSH, and we'll assume a P2WKH
+	// change output.
+	weightEstimate.AddP2WSHOutput()
+	weightEstimate.AddP2WKHOutput()
+
+	requiredFee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+	if totalSat < amt+requiredFee {
+		return 0, &ErrInsufficientFunds{amt + requiredFee, totalSat}
+	}
+
+	return totalSat - amt - requiredFee, nil
+}
@@ -69,6 +69,22 @@ const (
 	// broadcast a revoked commitment, but then also immediately attempt to
 	// go to the second level to claim the HTLC.
 	HtlcSecondLevelRevoke WitnessType = 9
+
+	// CommitSpendNoDelayTweakless is similar to the CommitmentNoDelay
+	// type, but it omits the tweak that randomizes the key we need to
+	// spend with a channel peer supplied set of randomness. It is used to
+	// sweep our settled output on a commitment transaction of a channel
+	// that uses the tweakless commitment format.
+	CommitSpendNoDelayTweakless WitnessType = 10
+
+	// CommitmentAnchor is a witness that allows us to spend our anchor on
+	// the commitment transaction, e.g. to attach a CPFP child transaction.
+	CommitmentAnchor WitnessType = 11
+
+	// CommitmentAnchorAnyone is a witness that allows anyone to spend an
+	// anchor on the commitment transaction once it has AnchorCSVDelay
+	// confirmations. We use it to sweep the anchor of the remote party.
+	CommitmentAnchorAnyone WitnessType = 12
 )
 
 // WitnessGenerator represents a function which is able to generate the final
@@ -94,7 +110,16 @@ func (wt WitnessType) GenWitnessFunc(signer Signer,
 			return CommitSpendTimeout(signer, desc, tx)
 
 		case CommitmentNoDelay:
-			return CommitSpendNoDelay(signer, desc, tx)
+			return CommitSpendNoDelay(signer, desc, tx, false)
+
+		case CommitSpendNoDelayTweakless:
+			return CommitSpendNoDelay(signer, desc, tx, true)
+
+		case CommitmentAnchor:
+			return CommitSpendAnchor(signer, desc, tx)
+
+		case CommitmentAnchorAnyone:
+			return CommitSpendAnchorAnyone(desc.WitnessScript)
 
 		case CommitmentRevoke:
 			return CommitSpendRevoke(signer, desc, tx)
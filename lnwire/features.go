@@ -35,6 +35,18 @@ const (
 	// connection is established.
 	InitialRoutingSync FeatureBit = 3
 
+	// UpfrontShutdownScriptRequired is a feature bit that indicates that
+	// the receiving peer MUST know of, and enforce, the upfront shutdown
+	// script that can be committed to within the OpenChannel and
+	// AcceptChannel messages.
+	UpfrontShutdownScriptRequired FeatureBit = 4
+
+	// UpfrontShutdownScriptOptional is an optional feature bit that
+	// signals that the sending peer will enforce any upfront shutdown
+	// script committed to during the funding workflow, refusing to
+	// cooperatively close the channel to any other script.
+	UpfrontShutdownScriptOptional FeatureBit = 5
+
 	// GossipQueriesRequired is a feature bit that indicates that the
 	// receiving peer MUST know of the set of features that allows nodes to
 	// more efficiently query the network view of peers on the network for
@@ -46,6 +58,52 @@ const (
 	// efficient network view reconciliation.
 	GossipQueriesOptional FeatureBit = 7
 
+	// StaticRemoteKeyRequired is a required feature bit that signals that
+	// within one's commitment transaction, the key used for the remote
+	// party's non-delay output should not be tweaked.
+	StaticRemoteKeyRequired FeatureBit = 12
+
+	// StaticRemoteKeyOptional is an optional feature bit that signals that
+	// within one's commitment transaction, the key used for the remote
+	// party's non-delay output should not be tweaked. Channels created
+	// with this format allow funds to be swept after data loss without
+	// knowledge of the remote party's current commitment point.
+	StaticRemoteKeyOptional FeatureBit = 13
+
+	// LargeChannelsRequired is a feature bit that indicates that the
+	// receiving peer MUST know of, and be willing to negotiate, channels
+	// with a capacity above the 2^24 satoshi soft-limit defined in
+	// BOLT-0002.
+	LargeChannelsRequired FeatureBit = 18
+
+	// LargeChannelsOptional is an optional feature bit that signals that
+	// the sending peer is willing to create channels with a capacity above
+	// the 2^24 satoshi soft-limit defined in BOLT-0002. The larger limit
+	// only applies if both peers signal the feature.
+	LargeChannelsOptional FeatureBit = 19
+
+	// AnchorOutputsRequired is a required feature bit that signals that
+	// the sending peer MUST be able to negotiate channels that use the
+	// anchor output commitment format.
+	AnchorOutputsRequired FeatureBit = 20
+
+	// AnchorOutputsOptional is an optional feature bit that signals that
+	// the sending peer is able to create channels whose commitment
+	// transactions carry an anchor output for each party, allowing the
+	// commitment fee to be bumped using CPFP. The format is only used if
+	// both peers signal the feature, in addition to static remote keys.
+	AnchorOutputsOptional FeatureBit = 21
+
+	// SpliceRequired is a required feature bit that signals that the
+	// sending peer MUST be able to take part in the splice protocol.
+	SpliceRequired FeatureBit = 22
+
+	// SpliceOptional is an optional feature bit that signals that the
+	// sending peer is able to take part in the splice protocol, allowing
+	// funds to be moved out of a live channel without closing it. Splices
+	// can only be initiated if both peers signal the feature.
+	SpliceOptional FeatureBit = 23
+
 	// maxAllowedSize is a maximum allowed size of feature vector.
 	//
 	// NOTE: Within the protocol, the maximum allowed message size is 65535
@@ -67,11 +125,21 @@ const (
 // not advertised to the entire network. A full description of these feature
 // bits is provided in the BOLT-09 specification.
 var LocalFeatures = map[FeatureBit]string{
-	DataLossProtectRequired: "data-loss-protect-required",
-	DataLossProtectOptional: "data-loss-protect-optional",
-	InitialRoutingSync:      "initial-routing-sync",
-	GossipQueriesRequired:   "gossip-queries-required",
-	GossipQueriesOptional:   "gossip-queries-optional",
+	DataLossProtectRequired:       "data-loss-protect-required",
+	DataLossProtectOptional:       "data-loss-protect-optional",
+	InitialRoutingSync:            "initial-routing-sync",
+	UpfrontShutdownScriptRequired: "upfront-shutdown-script-required",
+	UpfrontShutdownScriptOptional: "upfront-shutdown-script-optional",
+	GossipQueriesRequired:         "gossip-queries-required",
+	GossipQueriesOptional:         "gossip-queries-optional",
+	StaticRemoteKeyRequired:       "static-remote-key-required",
+	StaticRemoteKeyOptional:       "static-remote-key-optional",
+	LargeChannelsRequired:         "large-channels-required",
+	LargeChannelsOptional:         "large-channels-optional",
+	AnchorOutputsRequired:         "anchor-outputs-required",
+	AnchorOutputsOptional:         "anchor-outputs-optional",
+	SpliceRequired:                "splice-required",
+	SpliceOptional:                "splice-optional",
 }
 
 // GlobalFeatures is a mapping of known global feature bits to a descriptive
@@ -73,6 +73,21 @@ func randRawFeatureVector(r *rand.Rand) *RawFeatureVector {
 	return featureVec
 }
 
+// randUpfrontShutdownScript returns a random upfront shutdown script, or an
+// empty script to signal that no script was committed to.
+func randUpfrontShutdownScript(r *rand.Rand) (DeliveryAddress, error) {
+	if r.Int31n(2) == 0 {
+		return nil, nil
+	}
+
+	script := make(DeliveryAddress, r.Int31n(34)+1)
+	if _, err := r.Read(script); err != nil {
+		return nil, err
+	}
+
+	return script, nil
+}
+
 func randTCP4Addr(r *rand.Rand) (*net.TCPAddr, error) {
 	var ip [4]byte
 	if _, err := r.Read(ip[:]); err != nil {
@@ -304,6 +319,11 @@ func TestLightningWireProtocol(t *testing.T) {
 				t.Fatalf("unable to generate key: %v", err)
 				return
 			}
+			req.UpfrontShutdownScript, err = randUpfrontShutdownScript(r)
+			if err != nil {
+				t.Fatalf("unable to generate script: %v", err)
+				return
+			}
 
 			v[0] = reflect.ValueOf(req)
 		},
@@ -354,6 +374,11 @@ func TestLightningWireProtocol(t *testing.T) {
 				t.Fatalf("unable to generate key: %v", err)
 				return
 			}
+			req.UpfrontShutdownScript, err = randUpfrontShutdownScript(r)
+			if err != nil {
+				t.Fatalf("unable to generate script: %v", err)
+				return
+			}
 
 			v[0] = reflect.ValueOf(req)
 		},
@@ -435,6 +460,77 @@ func TestLightningWireProtocol(t *testing.T) {
 
 			v[0] = reflect.ValueOf(req)
 		},
+		MsgSpliceInit: func(v []reflect.Value, r *rand.Rand) {
+			req := SpliceInit{
+				PayoutAmount:     btcutil.Amount(r.Int63()),
+				FeePerKiloWeight: uint32(r.Int31()),
+			}
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+
+			req.PayoutScript = make(DeliveryAddress, r.Int31n(34)+1)
+			if _, err := r.Read(req.PayoutScript); err != nil {
+				t.Fatalf("unable to generate payout script: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgSpliceAck: func(v []reflect.Value, r *rand.Rand) {
+			var req SpliceAck
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+
+			var err error
+			req.CommitSig, err = NewSigFromSignature(testSig)
+			if err != nil {
+				t.Fatalf("unable to parse sig: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgSpliceCreated: func(v []reflect.Value, r *rand.Rand) {
+			var req SpliceCreated
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+
+			var err error
+			req.CommitSig, err = NewSigFromSignature(testSig)
+			if err != nil {
+				t.Fatalf("unable to parse sig: %v", err)
+				return
+			}
+			req.SpliceSig, err = NewSigFromSignature(testSig)
+			if err != nil {
+				t.Fatalf("unable to parse sig: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgSpliceSigned: func(v []reflect.Value, r *rand.Rand) {
+			var req SpliceSigned
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+
+			var err error
+			req.SpliceSig, err = NewSigFromSignature(testSig)
+			if err != nil {
+				t.Fatalf("unable to parse sig: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
 		MsgCommitSig: func(v []reflect.Value, r *rand.Rand) {
 			req := NewCommitSig()
 			if _, err := r.Read(req.ChanID[:]); err != nil {
@@ -826,6 +922,30 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgSpliceInit,
+			scenario: func(m SpliceInit) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgSpliceAck,
+			scenario: func(m SpliceAck) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgSpliceCreated,
+			scenario: func(m SpliceCreated) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgSpliceSigned,
+			scenario: func(m SpliceSigned) bool {
+				return mainScenario(&m)
+			},
+		},
 		{
 			msgType: MsgUpdateAddHTLC,
 			scenario: func(m UpdateAddHTLC) bool {
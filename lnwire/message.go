@@ -37,6 +37,10 @@ const (
 	MsgFundingLocked                       = 36
 	MsgShutdown                            = 38
 	MsgClosingSigned                       = 39
+	MsgSpliceInit                          = 80
+	MsgSpliceAck                           = 81
+	MsgSpliceCreated                       = 82
+	MsgSpliceSigned                        = 83
 	MsgUpdateAddHTLC                       = 128
 	MsgUpdateFulfillHTLC                   = 130
 	MsgUpdateFailHTLC                      = 131
@@ -75,6 +79,14 @@ func (t MessageType) String() string {
 		return "Shutdown"
 	case MsgClosingSigned:
 		return "ClosingSigned"
+	case MsgSpliceInit:
+		return "SpliceInit"
+	case MsgSpliceAck:
+		return "SpliceAck"
+	case MsgSpliceCreated:
+		return "SpliceCreated"
+	case MsgSpliceSigned:
+		return "SpliceSigned"
 	case MsgUpdateAddHTLC:
 		return "UpdateAddHTLC"
 	case MsgUpdateFailHTLC:
@@ -176,6 +188,14 @@ func makeEmptyMessage(msgType MessageType) (Message, error) {
 		msg = &Shutdown{}
 	case MsgClosingSigned:
 		msg = &ClosingSigned{}
+	case MsgSpliceInit:
+		msg = &SpliceInit{}
+	case MsgSpliceAck:
+		msg = &SpliceAck{}
+	case MsgSpliceCreated:
+		msg = &SpliceCreated{}
+	case MsgSpliceSigned:
+		msg = &SpliceSigned{}
 	case MsgUpdateAddHTLC:
 		msg = &UpdateAddHTLC{}
 	case MsgUpdateFailHTLC:
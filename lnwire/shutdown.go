@@ -22,6 +22,30 @@ type Shutdown struct {
 // p2wpkh.
 type DeliveryAddress []byte
 
+// readUpfrontShutdownScript reads the optional upfront shutdown script that
+// may trail the OpenChannel and AcceptChannel messages. As peers that don't
+// yet know of the field won't send it, we'll leave the script empty if the
+// message ends before it.
+func readUpfrontShutdownScript(r io.Reader, script *DeliveryAddress) error {
+	err := readElement(r, script)
+	switch {
+	case err == io.EOF:
+		*script = nil
+		return nil
+
+	case err != nil:
+		return err
+	}
+
+	// An empty script signals that the sender hasn't committed to any
+	// particular script, so we'll normalize it to nil.
+	if len(*script) == 0 {
+		*script = nil
+	}
+
+	return nil
+}
+
 // NewShutdown creates a new Shutdown message.
 func NewShutdown(cid ChannelID, addr DeliveryAddress) *Shutdown {
 	return &Shutdown{
@@ -0,0 +1,62 @@
+package lnwire
+
+import "io"
+
+// SpliceAck is sent by the responder of a splice in reply to a SpliceInit
+// message. It carries the responder's signature for the initiator's new
+// commitment transaction, which spends the funding output of the splice
+// transaction. Only once the initiator holds this signature will it provide
+// its own signature for the splice transaction itself.
+type SpliceAck struct {
+	// ChanID serves to identify which channel is being spliced.
+	ChanID ChannelID
+
+	// CommitSig is the responder's signature for the initiator's version
+	// of the commitment transaction that spends the new funding output.
+	CommitSig Sig
+}
+
+// NewSpliceAck creates a new SpliceAck message.
+func NewSpliceAck(cid ChannelID, commitSig Sig) *SpliceAck {
+	return &SpliceAck{
+		ChanID:    cid,
+		CommitSig: commitSig,
+	}
+}
+
+// A compile time check to ensure SpliceAck implements the lnwire.Message
+// interface.
+var _ Message = (*SpliceAck)(nil)
+
+// Decode deserializes a serialized SpliceAck message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceAck) Decode(r io.Reader, pver uint32) error {
+	return readElements(r, &s.ChanID, &s.CommitSig)
+}
+
+// Encode serializes the target SpliceAck into the passed io.Writer observing
+// the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceAck) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w, s.ChanID, s.CommitSig)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceAck) MsgType() MessageType {
+	return MsgSpliceAck
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a SpliceAck
+// complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceAck) MaxPayloadLength(uint32) uint32 {
+	// 32 + 64
+	return 96
+}
@@ -0,0 +1,67 @@
+package lnwire
+
+import "io"
+
+// SpliceCreated is sent by the initiator of a splice once it has received and
+// verified the responder's SpliceAck. It carries the initiator's signature for
+// the responder's new commitment transaction, as well as the initiator's
+// signature for the input of the splice transaction that spends the current
+// funding output.
+type SpliceCreated struct {
+	// ChanID serves to identify which channel is being spliced.
+	ChanID ChannelID
+
+	// CommitSig is the initiator's signature for the responder's version
+	// of the commitment transaction that spends the new funding output.
+	CommitSig Sig
+
+	// SpliceSig is the initiator's signature for the splice transaction,
+	// spending the current funding output.
+	SpliceSig Sig
+}
+
+// NewSpliceCreated creates a new SpliceCreated message.
+func NewSpliceCreated(cid ChannelID, commitSig, spliceSig Sig) *SpliceCreated {
+	return &SpliceCreated{
+		ChanID:    cid,
+		CommitSig: commitSig,
+		SpliceSig: spliceSig,
+	}
+}
+
+// A compile time check to ensure SpliceCreated implements the lnwire.Message
+// interface.
+var _ Message = (*SpliceCreated)(nil)
+
+// Decode deserializes a serialized SpliceCreated message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceCreated) Decode(r io.Reader, pver uint32) error {
+	return readElements(r, &s.ChanID, &s.CommitSig, &s.SpliceSig)
+}
+
+// Encode serializes the target SpliceCreated into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceCreated) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w, s.ChanID, s.CommitSig, s.SpliceSig)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceCreated) MsgType() MessageType {
+	return MsgSpliceCreated
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// SpliceCreated complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceCreated) MaxPayloadLength(uint32) uint32 {
+	// 32 + 64 + 64
+	return 160
+}
@@ -0,0 +1,102 @@
+package lnwire
+
+import (
+	"io"
+
+	"github.com/btcsuite/btcutil"
+)
+
+// SpliceInit is sent by the initiator of a channel in order to begin splicing
+// funds out of the channel without closing it. The new funding transaction
+// spends the current funding output into a smaller channel output, and an
+// on-chain payout to the specified script. The payout, as well as the fee of
+// the splice transaction, are deducted from the initiator's balance.
+type SpliceInit struct {
+	// ChanID serves to identify which channel is to be spliced.
+	ChanID ChannelID
+
+	// PayoutAmount is the amount in satoshis that should be moved out of
+	// the channel, and paid to PayoutScript.
+	PayoutAmount btcutil.Amount
+
+	// FeePerKiloWeight is the fee rate in satoshis per kw that the
+	// splice transaction should pay.
+	FeePerKiloWeight uint32
+
+	// PayoutScript is the script that the spliced out funds should be
+	// paid to.
+	PayoutScript DeliveryAddress
+}
+
+// NewSpliceInit creates a new SpliceInit message.
+func NewSpliceInit(cid ChannelID, amt btcutil.Amount, feePerKw uint32,
+	payoutScript DeliveryAddress) *SpliceInit {
+
+	return &SpliceInit{
+		ChanID:           cid,
+		PayoutAmount:     amt,
+		FeePerKiloWeight: feePerKw,
+		PayoutScript:     payoutScript,
+	}
+}
+
+// A compile time check to ensure SpliceInit implements the lnwire.Message
+// interface.
+var _ Message = (*SpliceInit)(nil)
+
+// Decode deserializes a serialized SpliceInit message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceInit) Decode(r io.Reader, pver uint32) error {
+	return readElements(r,
+		&s.ChanID,
+		&s.PayoutAmount,
+		&s.FeePerKiloWeight,
+		&s.PayoutScript)
+}
+
+// Encode serializes the target SpliceInit into the passed io.Writer observing
+// the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceInit) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		s.ChanID,
+		s.PayoutAmount,
+		s.FeePerKiloWeight,
+		s.PayoutScript)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceInit) MsgType() MessageType {
+	return MsgSpliceInit
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a SpliceInit
+// complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceInit) MaxPayloadLength(uint32) uint32 {
+	var length uint32
+
+	// ChanID - 32 bytes
+	length += 32
+
+	// PayoutAmount - 8 bytes
+	length += 8
+
+	// FeePerKiloWeight - 4 bytes
+	length += 4
+
+	// Len - 2 bytes
+	length += 2
+
+	// PayoutScript - 34 bytes for pay to witness script hash
+	length += 34
+
+	return length
+}
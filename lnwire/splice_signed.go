@@ -0,0 +1,61 @@
+package lnwire
+
+import "io"
+
+// SpliceSigned is the final message of the splice workflow, sent by the
+// responder once it has verified the initiator's SpliceCreated message. It
+// carries the responder's signature for the input of the splice transaction,
+// allowing both parties to assemble the fully signed splice transaction.
+type SpliceSigned struct {
+	// ChanID serves to identify which channel is being spliced.
+	ChanID ChannelID
+
+	// SpliceSig is the responder's signature for the splice transaction,
+	// spending the current funding output.
+	SpliceSig Sig
+}
+
+// NewSpliceSigned creates a new SpliceSigned message.
+func NewSpliceSigned(cid ChannelID, spliceSig Sig) *SpliceSigned {
+	return &SpliceSigned{
+		ChanID:    cid,
+		SpliceSig: spliceSig,
+	}
+}
+
+// A compile time check to ensure SpliceSigned implements the lnwire.Message
+// interface.
+var _ Message = (*SpliceSigned)(nil)
+
+// Decode deserializes a serialized SpliceSigned message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceSigned) Decode(r io.Reader, pver uint32) error {
+	return readElements(r, &s.ChanID, &s.SpliceSig)
+}
+
+// Encode serializes the target SpliceSigned into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceSigned) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w, s.ChanID, s.SpliceSig)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceSigned) MsgType() MessageType {
+	return MsgSpliceSigned
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// SpliceSigned complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *SpliceSigned) MaxPayloadLength(uint32) uint32 {
+	// 32 + 64
+	return 96
+}
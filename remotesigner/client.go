@@ -0,0 +1,435 @@
+package remotesigner
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc/remotesignerrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// DefaultRPCTimeout is the time after which a call to the remote
+	// signer is aborted.
+	DefaultRPCTimeout = 30 * time.Second
+
+	// maxWalletExportSize is the maximum size of the watch-only wallet
+	// database the client accepts from the remote signer.
+	maxWalletExportSize = 512 * 1024 * 1024
+)
+
+// WalletKeyPaths looks up the derivation paths of the keys of the on-chain
+// wallet, so that the remote signer can derive them to sign wallet inputs.
+type WalletKeyPaths interface {
+	// DerivationPath returns the key scope and derivation path of the
+	// key that controls the wallet output with the passed output script.
+	DerivationPath(pkScript []byte) (waddrmgr.KeyScope,
+		waddrmgr.DerivationPath, error)
+}
+
+// Client forwards all key derivation and signing operations of a watch-only
+// lnd instance to a remote signer. It implements the keychain.SecretKeyRing,
+// lnwallet.Signer, lnwallet.MessageSigner, lnwallet.ChannelRegistrar and
+// lnwallet.RevocationSigner interfaces.
+type Client struct {
+	conn   *grpc.ClientConn
+	client remotesignerrpc.RemoteSignerClient
+
+	// wallet is the watch-only on-chain wallet, which is used to look up
+	// the keys of the wallet inputs the remote signer signs.
+	wallet WalletKeyPaths
+}
+
+// A compile time check to ensure Client implements all interfaces required
+// to replace the local key material.
+var (
+	_ keychain.SecretKeyRing    = (*Client)(nil)
+	_ lnwallet.Signer           = (*Client)(nil)
+	_ lnwallet.MessageSigner    = (*Client)(nil)
+	_ lnwallet.ChannelRegistrar = (*Client)(nil)
+	_ lnwallet.RevocationSigner = (*Client)(nil)
+)
+
+// Dial connects to the remote signer listening on the given host using the
+// passed transport credentials. The passed watch-only wallet is used to look
+// up the keys of the wallet inputs to sign.
+func Dial(host string, creds credentials.TransportCredentials,
+	wallet WalletKeyPaths) (*Client, error) {
+
+	conn, err := grpc.Dial(host, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Connected to remote signer at %v", host)
+
+	return &Client{
+		conn:   conn,
+		client: remotesignerrpc.NewRemoteSignerClient(conn),
+		wallet: wallet,
+	}, nil
+}
+
+// FetchWatchOnlyWallet connects to the remote signer listening on the given
+// host and fetches the watch-only copy of its on-chain wallet database.
+func FetchWatchOnlyWallet(host string,
+	creds credentials.TransportCredentials) ([]byte, error) {
+
+	conn, err := grpc.Dial(host, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	client := remotesignerrpc.NewRemoteSignerClient(conn)
+	resp, err := client.ExportWatchOnlyWallet(
+		ctx, &remotesignerrpc.ExportWatchOnlyWalletRequest{},
+		grpc.MaxCallRecvMsgSize(maxWalletExportSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Fetched watch-only wallet from remote signer at %v", host)
+
+	return resp.WalletDb, nil
+}
+
+// Close closes the connection to the remote signer.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// DeriveNextKey derives the next key within the given key family on the
+// remote signer.
+//
+// NOTE: This is part of the keychain.KeyRing interface.
+func (c *Client) DeriveNextKey(
+	keyFam keychain.KeyFamily) (keychain.KeyDescriptor, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.DeriveNextKey(ctx, &remotesignerrpc.KeyFamily{
+		KeyFamily: int32(keyFam),
+	})
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return unmarshalKeyDesc(resp)
+}
+
+// DeriveKey derives the key described by the passed key locator on the
+// remote signer.
+//
+// NOTE: This is part of the keychain.KeyRing interface.
+func (c *Client) DeriveKey(
+	keyLoc keychain.KeyLocator) (keychain.KeyDescriptor, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.DeriveKey(ctx, &remotesignerrpc.KeyLocator{
+		KeyFamily: int32(keyLoc.Family),
+		KeyIndex:  int32(keyLoc.Index),
+	})
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return unmarshalKeyDesc(resp)
+}
+
+// DerivePrivKey fetches the private key of the passed key descriptor from the
+// remote signer. The signer only hands out keys that don't control any funds.
+//
+// NOTE: This is part of the keychain.SecretKeyRing interface.
+func (c *Client) DerivePrivKey(
+	keyDesc keychain.KeyDescriptor) (*btcec.PrivateKey, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.DerivePrivKey(ctx, marshalKeyDesc(keyDesc))
+	if err != nil {
+		return nil, err
+	}
+
+	privKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), resp.PrivKey)
+	return privKey, nil
+}
+
+// ScalarMult performs an ECDH operation between the private key of the passed
+// key descriptor and the given public key on the remote signer.
+//
+// NOTE: This is part of the keychain.SecretKeyRing interface.
+func (c *Client) ScalarMult(keyDesc keychain.KeyDescriptor,
+	pubKey *btcec.PublicKey) ([]byte, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.ScalarMult(ctx, &remotesignerrpc.ScalarMultRequest{
+		KeyDesc: marshalKeyDesc(keyDesc),
+		PubKey:  pubKey.SerializeCompressed(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SharedKey, nil
+}
+
+// serializeSignRequest serializes the passed transaction and sign
+// descriptor for a signing request.
+func serializeSignRequest(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, []byte, error) {
+
+	var rawTx, rawSignDesc bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		return nil, nil, err
+	}
+	if err := lnwallet.WriteSignDescriptor(&rawSignDesc, signDesc); err != nil {
+		return nil, nil, err
+	}
+
+	return rawTx.Bytes(), rawSignDesc.Bytes(), nil
+}
+
+// SignOutputRaw has the remote signer sign the input of the passed
+// transaction described by the sign descriptor.
+//
+// NOTE: This is part of the lnwallet.Signer interface.
+func (c *Client) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	rawTx, rawSignDesc, err := serializeSignRequest(tx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.SignOutputRaw(ctx, &remotesignerrpc.SignRequest{
+		RawTx:         rawTx,
+		SignDesc:      rawSignDesc,
+		InputIndex:    int32(signDesc.InputIndex),
+		WalletOutputs: c.walletOutputs(tx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.RawSig, nil
+}
+
+// walletOutputs returns the outputs of the passed transaction that pay to the
+// on-chain wallet along with the derivation paths of their keys, so that the
+// remote signer can tell which outputs pay to us.
+func (c *Client) walletOutputs(
+	tx *wire.MsgTx) []*remotesignerrpc.WalletOutput {
+
+	var outputs []*remotesignerrpc.WalletOutput
+	for i, txOut := range tx.TxOut {
+		keyScope, path, err := c.wallet.DerivationPath(txOut.PkScript)
+		if err != nil {
+			continue
+		}
+
+		outputs = append(outputs, &remotesignerrpc.WalletOutput{
+			OutputIndex: uint32(i),
+			KeyPath:     marshalKeyPath(keyScope, path),
+		})
+	}
+
+	return outputs
+}
+
+// ComputeInputScript has the remote signer generate the input script of an
+// input that spends an output of the on-chain wallet. The key of the output
+// is identified by its derivation path, as looked up in the watch-only
+// wallet.
+//
+// NOTE: This is part of the lnwallet.Signer interface.
+func (c *Client) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	keyScope, path, err := c.wallet.DerivationPath(signDesc.Output.PkScript)
+	if err != nil {
+		return nil, err
+	}
+
+	rawTx, rawSignDesc, err := serializeSignRequest(tx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.ComputeInputScript(
+		ctx, &remotesignerrpc.InputScriptRequest{
+			RawTx:      rawTx,
+			SignDesc:   rawSignDesc,
+			InputIndex: int32(signDesc.InputIndex),
+			KeyPath:    marshalKeyPath(keyScope, path),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnwallet.InputScript{
+		ScriptSig: resp.SigScript,
+		Witness:   resp.Witness,
+	}, nil
+}
+
+// SignMessage has the remote signer sign the double SHA-256 of the passed
+// message with the private key of the given public key.
+//
+// NOTE: This is part of the lnwallet.MessageSigner interface.
+func (c *Client) SignMessage(pubKey *btcec.PublicKey,
+	msg []byte) (*btcec.Signature, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.SignMessage(ctx, &remotesignerrpc.SignMessageRequest{
+		PubKey: pubKey.SerializeCompressed(),
+		Msg:    msg,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParseDERSignature(resp.Signature, btcec.S256())
+}
+
+// RegisterChannel informs the remote signer about the funding output of a
+// channel along with its configuration, allowing it to sign spends of the
+// funding output and to recognize our commitment transactions. The initial
+// balances of the channel are taken from our current commitment.
+//
+// NOTE: This is part of the lnwallet.ChannelRegistrar interface.
+func (c *Client) RegisterChannel(chanPoint wire.OutPoint,
+	capacity btcutil.Amount, channel *channeldb.OpenChannel) error {
+
+	localCfg := &channel.LocalChanCfg
+	remoteCfg := &channel.RemoteChanCfg
+
+	witnessScript, err := lnwallet.GenMultiSigScript(
+		localCfg.MultiSigKey.PubKey.SerializeCompressed(),
+		remoteCfg.MultiSigKey.PubKey.SerializeCompressed(),
+	)
+	if err != nil {
+		return err
+	}
+
+	var commitTx bytes.Buffer
+	err = channel.LocalCommitment.CommitTx.Serialize(&commitTx)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	_, err = c.client.RegisterChannel(
+		ctx, &remotesignerrpc.RegisterChannelRequest{
+			FundingTxid:   chanPoint.Hash[:],
+			OutputIndex:   chanPoint.Index,
+			Capacity:      int64(capacity),
+			WitnessScript: witnessScript,
+			Initiator:     channel.IsInitiator,
+			ChanType:      uint32(channel.ChanType),
+			MultiSigKey:   marshalKeyDesc(localCfg.MultiSigKey),
+			RevocationBasePoint: marshalKeyDesc(
+				localCfg.RevocationBasePoint,
+			),
+			PaymentBasePoint: marshalKeyDesc(
+				localCfg.PaymentBasePoint,
+			),
+			DelayBasePoint: marshalKeyDesc(localCfg.DelayBasePoint),
+			HtlcBasePoint:  marshalKeyDesc(localCfg.HtlcBasePoint),
+			CsvDelay:       uint32(localCfg.CsvDelay),
+			RemoteRevocationBasePoint: remoteCfg.RevocationBasePoint.
+				PubKey.SerializeCompressed(),
+			RemotePaymentBasePoint: remoteCfg.PaymentBasePoint.
+				PubKey.SerializeCompressed(),
+			LocalShutdownScript: channel.LocalShutdownScript,
+			LocalCommitTx:       commitTx.Bytes(),
+		},
+	)
+	return err
+}
+
+// CommitmentPoint fetches our commitment point at the given height of the
+// channel that uses the passed multi-sig key from the remote signer.
+//
+// NOTE: This is part of the lnwallet.RevocationSigner interface.
+func (c *Client) CommitmentPoint(multiSigKey keychain.KeyDescriptor,
+	height uint64) (*btcec.PublicKey, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.CommitmentPoint(
+		ctx, &remotesignerrpc.CommitmentPointRequest{
+			MultiSigKey: marshalKeyDesc(multiSigKey),
+			Height:      height,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParsePubKey(resp.CommitPoint, btcec.S256())
+}
+
+// RevokeCommitment has the remote signer revoke our commitment at the given
+// height of the channel that uses the passed multi-sig key, returning its
+// revocation secret.
+//
+// NOTE: This is part of the lnwallet.RevocationSigner interface.
+func (c *Client) RevokeCommitment(multiSigKey keychain.KeyDescriptor,
+	height uint64, nextCommitTx *wire.MsgTx,
+	nextCommitSig []byte) (*chainhash.Hash, error) {
+
+	var commitTx bytes.Buffer
+	if err := nextCommitTx.Serialize(&commitTx); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRPCTimeout)
+	defer cancel()
+
+	resp, err := c.client.RevokeCommitment(
+		ctx, &remotesignerrpc.RevokeCommitmentRequest{
+			MultiSigKey:   marshalKeyDesc(multiSigKey),
+			Height:        height,
+			NextCommitTx:  commitTx.Bytes(),
+			NextCommitSig: nextCommitSig,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainhash.NewHash(resp.CommitSecret)
+}
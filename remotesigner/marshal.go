@@ -0,0 +1,82 @@
+package remotesigner
+
+import (
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc/remotesignerrpc"
+)
+
+// marshalKeyDesc converts a key descriptor into its RPC representation.
+func marshalKeyDesc(keyDesc keychain.KeyDescriptor) *remotesignerrpc.KeyDescriptor {
+	rpcDesc := &remotesignerrpc.KeyDescriptor{
+		KeyLoc: &remotesignerrpc.KeyLocator{
+			KeyFamily: int32(keyDesc.Family),
+			KeyIndex:  int32(keyDesc.Index),
+		},
+	}
+	if keyDesc.PubKey != nil {
+		rpcDesc.RawKeyBytes = keyDesc.PubKey.SerializeCompressed()
+	}
+
+	return rpcDesc
+}
+
+// unmarshalKeyDesc parses the RPC representation of a key descriptor.
+func unmarshalKeyDesc(
+	rpcDesc *remotesignerrpc.KeyDescriptor) (keychain.KeyDescriptor, error) {
+
+	var keyDesc keychain.KeyDescriptor
+	if rpcDesc == nil {
+		return keyDesc, nil
+	}
+
+	if rpcDesc.KeyLoc != nil {
+		keyDesc.Family = keychain.KeyFamily(rpcDesc.KeyLoc.KeyFamily)
+		keyDesc.Index = uint32(rpcDesc.KeyLoc.KeyIndex)
+	}
+	if len(rpcDesc.RawKeyBytes) != 0 {
+		pubKey, err := btcec.ParsePubKey(
+			rpcDesc.RawKeyBytes, btcec.S256(),
+		)
+		if err != nil {
+			return keyDesc, err
+		}
+		keyDesc.PubKey = pubKey
+	}
+
+	return keyDesc, nil
+}
+
+// marshalKeyPath converts the key scope and derivation path of a wallet key
+// into their RPC representation.
+func marshalKeyPath(keyScope waddrmgr.KeyScope,
+	path waddrmgr.DerivationPath) *remotesignerrpc.KeyPath {
+
+	return &remotesignerrpc.KeyPath{
+		Purpose:  keyScope.Purpose,
+		CoinType: keyScope.Coin,
+		Account:  path.Account,
+		Branch:   path.Branch,
+		Index:    path.Index,
+	}
+}
+
+// unmarshalKeyPath parses the RPC representation of the key scope and
+// derivation path of a wallet key.
+func unmarshalKeyPath(
+	rpcPath *remotesignerrpc.KeyPath) (waddrmgr.KeyScope,
+	waddrmgr.DerivationPath) {
+
+	keyScope := waddrmgr.KeyScope{
+		Purpose: rpcPath.Purpose,
+		Coin:    rpcPath.CoinType,
+	}
+	path := waddrmgr.DerivationPath{
+		Account: rpcPath.Account,
+		Branch:  rpcPath.Branch,
+		Index:   rpcPath.Index,
+	}
+
+	return keyScope, path
+}
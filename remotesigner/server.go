@@ -0,0 +1,1256 @@
+package remotesigner
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc/remotesignerrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/shachain"
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrUnknownChannel is returned when the signer is asked to sign a
+	// spend of a funding output it hasn't been told about.
+	ErrUnknownChannel = errors.New("spend of unknown channel funding " +
+		"output")
+
+	// ErrKeyFamilyNotAllowed is returned when a request uses a key of a
+	// family that the signer doesn't use for the requested operation.
+	ErrKeyFamilyNotAllowed = errors.New("key family not allowed")
+
+	// ErrUnknownKey is returned when the signer is asked to sign with a
+	// base point that isn't used by any registered channel.
+	ErrUnknownKey = errors.New("key isn't used by any registered channel")
+
+	// ErrRevokedCommitment is returned when the signer is asked to sign
+	// one of our commitment transactions that has already been revoked.
+	ErrRevokedCommitment = errors.New("commitment has been revoked")
+
+	// ErrKeyPathNotAllowed is returned when the signer is asked to sign a
+	// wallet input with a key outside of the accounts of the on-chain
+	// wallet.
+	ErrKeyPathNotAllowed = errors.New("key path not allowed")
+
+	// ErrBalanceNotPaid is returned when the signer is asked to sign a
+	// cooperative close or a splice that doesn't pay our balance of the
+	// channel to us.
+	ErrBalanceNotPaid = errors.New("spend doesn't pay our balance to us")
+
+	// ErrUnknownOutput is returned when the signer is asked to sign with a
+	// base point for an output that isn't an output of a commitment or
+	// HTLC transaction of the channel that uses the base point.
+	ErrUnknownOutput = errors.New("output doesn't belong to the channel " +
+		"of the key")
+
+	// ErrSweepNotPaid is returned when the signer is asked to sign a sweep
+	// of a channel output that doesn't pay to the on-chain wallet.
+	ErrSweepNotPaid = errors.New("sweep doesn't pay to the wallet")
+
+	// ErrInvalidCommitment is returned when the commitment that replaces a
+	// revoked one isn't a valid commitment of the channel signed by the
+	// remote party.
+	ErrInvalidCommitment = errors.New("invalid commitment")
+)
+
+// WalletSigner signs the inputs that spend outputs of the on-chain wallet of
+// the watch-only instance, and exports the watch-only copy of the wallet the
+// instance runs from.
+type WalletSigner interface {
+	// ComputeInputScriptAtPath generates the input script of an input
+	// that spends a wallet output whose key is derived from the passed
+	// key scope and derivation path.
+	ComputeInputScriptAtPath(tx *wire.MsgTx,
+		signDesc *lnwallet.SignDescriptor, keyScope waddrmgr.KeyScope,
+		path waddrmgr.DerivationPath) (*lnwallet.InputScript, error)
+
+	// ScriptAtPath returns the output script of the wallet address whose
+	// key is derived from the passed key scope and derivation path.
+	ScriptAtPath(keyScope waddrmgr.KeyScope,
+		path waddrmgr.DerivationPath) ([]byte, error)
+
+	// ExportWatchOnly writes a copy of the wallet database without any
+	// private key material to the passed writer.
+	ExportWatchOnly(w io.Writer) error
+}
+
+// Server is the signer-only side of the remote signer. It holds the seed of
+// the node and serves key derivation and signing requests of a watch-only lnd
+// instance, enforcing the following policy:
+//
+//   * Funding outputs, signed with keys of the multi-sig family, may only be
+//     spent if the channel has been registered and the witness script and
+//     value of the spent output match the registered channel.
+//   * Our commitment transactions are never signed once they have been
+//     revoked.
+//   * Any other spend of a funding output, such as a cooperative close or a
+//     splice, must pay at least our balance of the channel to our upfront
+//     shutdown script, to the on-chain wallet or back into the channel. Our
+//     balance is taken from our latest commitment signed by the remote
+//     party.
+//   * The base points may only sign spends of outputs of the commitment and
+//     HTLC transactions the signer signed for the registered channel they
+//     belong to, and such sweeps must pay to the on-chain wallet. The HTLC
+//     base point also signs HTLC transactions, whose single P2WSH output
+//     pays to the delayed script of the party that broadcasts them. As the
+//     signer doesn't know the commitment points of the remote party, it
+//     can't check that script, so a spend of an HTLC output into a single
+//     P2WSH output is signed wherever it pays to.
+//   * The revocation secrets of our commitments are derived by the signer
+//     and only handed out once a commitment is revoked.
+//   * Inputs of the on-chain wallet may only be signed with untweaked keys
+//     of the default account of the BIP84 and BIP49 key scopes.
+//   * Only the node key may leave the signer, as it doesn't control any
+//     funds. The on-chain wallet is only exported without its private keys.
+//   * Messages may only be signed with the node key.
+type Server struct {
+	signer    lnwallet.Signer
+	msgSigner lnwallet.MessageSigner
+	keyRing   keychain.SecretKeyRing
+
+	// walletSigner signs the inputs of the on-chain wallet.
+	walletSigner WalletSigner
+
+	nodeKey *btcec.PublicKey
+
+	// revocationRoot is the root from which the revocation secrets of
+	// all our channels are derived. It never leaves the signer.
+	revocationRoot *btcec.PrivateKey
+
+	// store persists the channels the watch-only instance registered and
+	// the revocation state of their commitments.
+	store *Store
+}
+
+// A compile time check to ensure Server implements the RemoteSignerServer
+// gRPC service.
+var _ remotesignerrpc.RemoteSignerServer = (*Server)(nil)
+
+// NewServer creates a new remote signer backed by the passed signer, message
+// signer, wallet signer and key ring. Registered channels are persisted in
+// the given store.
+func NewServer(signer lnwallet.Signer, msgSigner lnwallet.MessageSigner,
+	walletSigner WalletSigner, keyRing keychain.SecretKeyRing,
+	store *Store) (*Server, error) {
+
+	nodeKey, err := keyRing.DeriveKey(keychain.KeyLocator{
+		Family: keychain.KeyFamilyNodeKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revocationRoot, err := keyRing.DerivePrivKey(keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{
+			Family: keychain.KeyFamilyRevocationRoot,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		signer:         signer,
+		msgSigner:      msgSigner,
+		keyRing:        keyRing,
+		walletSigner:   walletSigner,
+		nodeKey:        nodeKey.PubKey,
+		revocationRoot: revocationRoot,
+		store:          store,
+	}, nil
+}
+
+// verifyKey makes sure the passed key descriptor describes one of our keys of
+// the given family.
+func (s *Server) verifyKey(keyDesc keychain.KeyDescriptor,
+	family keychain.KeyFamily) error {
+
+	if keyDesc.Family != family {
+		return fmt.Errorf("%v: expected key of family %v, got %v",
+			ErrKeyFamilyNotAllowed, family, keyDesc.Family)
+	}
+	if keyDesc.PubKey == nil {
+		return fmt.Errorf("key of family %v is missing its public key",
+			family)
+	}
+
+	derived, err := s.keyRing.DeriveKey(keyDesc.KeyLocator)
+	if err != nil {
+		return err
+	}
+	if !derived.PubKey.IsEqual(keyDesc.PubKey) {
+		return fmt.Errorf("key %x isn't our key %v/%v",
+			keyDesc.PubKey.SerializeCompressed(), keyDesc.Family,
+			keyDesc.Index)
+	}
+
+	return nil
+}
+
+// commitSecret returns our revocation secret of the commitment at the given
+// height of the channel that uses the given multi-sig key. The shachain root
+// of each channel is derived from the revocation root and the multi-sig key,
+// so it never needs to be stored.
+func (s *Server) commitSecret(multiSigKey *btcec.PublicKey,
+	height uint64) (*chainhash.Hash, error) {
+
+	mac := hmac.New(sha256.New, s.revocationRoot.Serialize())
+	mac.Write(multiSigKey.SerializeCompressed())
+
+	var root chainhash.Hash
+	copy(root[:], mac.Sum(nil))
+
+	return shachain.NewRevocationProducer(root).AtIndex(height)
+}
+
+// DeriveNextKey derives the next key within the given key family.
+func (s *Server) DeriveNextKey(ctx context.Context,
+	req *remotesignerrpc.KeyFamily) (*remotesignerrpc.KeyDescriptor, error) {
+
+	keyDesc, err := s.keyRing.DeriveNextKey(
+		keychain.KeyFamily(req.KeyFamily),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalKeyDesc(keyDesc), nil
+}
+
+// DeriveKey derives the key described by the given key locator.
+func (s *Server) DeriveKey(ctx context.Context,
+	req *remotesignerrpc.KeyLocator) (*remotesignerrpc.KeyDescriptor, error) {
+
+	keyDesc, err := s.keyRing.DeriveKey(keychain.KeyLocator{
+		Family: keychain.KeyFamily(req.KeyFamily),
+		Index:  uint32(req.KeyIndex),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalKeyDesc(keyDesc), nil
+}
+
+// DerivePrivKey returns the private key of the given key descriptor, as long
+// as it is the node key.
+func (s *Server) DerivePrivKey(ctx context.Context,
+	req *remotesignerrpc.KeyDescriptor) (*remotesignerrpc.PrivKeyResponse,
+	error) {
+
+	keyDesc, err := unmarshalKeyDesc(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyDesc.Family != keychain.KeyFamilyNodeKey {
+		return nil, fmt.Errorf("%v: private keys of family %v can't "+
+			"be exported", ErrKeyFamilyNotAllowed, keyDesc.Family)
+	}
+
+	privKey, err := s.keyRing.DerivePrivKey(keyDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Since a public key may be used to scan the family for the private
+	// key, we make sure the returned key actually belongs to it.
+	if keyDesc.PubKey != nil && !privKey.PubKey().IsEqual(keyDesc.PubKey) {
+		return nil, fmt.Errorf("key %x not found in family %v",
+			keyDesc.PubKey.SerializeCompressed(), keyDesc.Family)
+	}
+
+	return &remotesignerrpc.PrivKeyResponse{
+		PrivKey: privKey.Serialize(),
+	}, nil
+}
+
+// ScalarMult performs an ECDH operation between the private node key and the
+// passed public key.
+func (s *Server) ScalarMult(ctx context.Context,
+	req *remotesignerrpc.ScalarMultRequest) (
+	*remotesignerrpc.ScalarMultResponse, error) {
+
+	keyDesc, err := unmarshalKeyDesc(req.KeyDesc)
+	if err != nil {
+		return nil, err
+	}
+	if keyDesc.Family != keychain.KeyFamilyNodeKey {
+		return nil, fmt.Errorf("%v: ECDH with keys of family %v",
+			ErrKeyFamilyNotAllowed, keyDesc.Family)
+	}
+
+	pubKey, err := btcec.ParsePubKey(req.PubKey, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	sharedKey, err := s.keyRing.ScalarMult(keyDesc, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remotesignerrpc.ScalarMultResponse{SharedKey: sharedKey}, nil
+}
+
+// SignOutputRaw signs an input of the passed transaction as described by the
+// serialized sign descriptor, if the policy of the signer allows it.
+func (s *Server) SignOutputRaw(ctx context.Context,
+	req *remotesignerrpc.SignRequest) (*remotesignerrpc.SignResponse, error) {
+
+	tx, signDesc, err := parseSignRequest(
+		req.RawTx, req.SignDesc, req.InputIndex,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkSignPolicy(tx, signDesc, req.WalletOutputs)
+	if err != nil {
+		log.Warnf("Refusing to sign input %v of tx %v: %v",
+			signDesc.InputIndex, tx.TxHash(), err)
+		return nil, err
+	}
+
+	sig, err := s.signer.SignOutputRaw(tx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remotesignerrpc.SignResponse{RawSig: sig}, nil
+}
+
+// parseSignRequest parses the transaction and the sign descriptor of a
+// request to sign the input at the given index.
+func parseSignRequest(rawTx, rawSignDesc []byte, inputIndex int32) (
+	*wire.MsgTx, *lnwallet.SignDescriptor, error) {
+
+	tx := wire.NewMsgTx(2)
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return nil, nil, err
+	}
+
+	signDesc := &lnwallet.SignDescriptor{}
+	err := lnwallet.ReadSignDescriptor(
+		bytes.NewReader(rawSignDesc), signDesc,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if signDesc.Output == nil {
+		return nil, nil, fmt.Errorf("sign descriptor is missing the " +
+			"output")
+	}
+	if inputIndex < 0 || int(inputIndex) >= len(tx.TxIn) {
+		return nil, nil, fmt.Errorf("invalid input index %v",
+			inputIndex)
+	}
+	signDesc.InputIndex = int(inputIndex)
+	signDesc.SigHashes = txscript.NewTxSigHashes(tx)
+
+	return tx, signDesc, nil
+}
+
+// checkSignPolicy returns an error if the signer isn't allowed to sign the
+// passed input. The passed wallet outputs are the outputs of the transaction
+// that pay to the on-chain wallet according to the watch-only instance.
+func (s *Server) checkSignPolicy(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor,
+	walletOutputs []*remotesignerrpc.WalletOutput) error {
+
+	keyDesc := signDesc.KeyDesc
+	switch keyDesc.Family {
+	case keychain.KeyFamilyMultiSig, keychain.KeyFamilyRevocationBase,
+		keychain.KeyFamilyHtlcBase, keychain.KeyFamilyPaymentBase,
+		keychain.KeyFamilyDelayBase:
+
+	default:
+		return fmt.Errorf("%v: signing with keys of family %v",
+			ErrKeyFamilyNotAllowed, keyDesc.Family)
+	}
+
+	// The policy depends on the actual key we sign with, so we resolve
+	// the public key of keys that are only described by their locator.
+	pubKey := keyDesc.PubKey
+	if pubKey == nil {
+		derived, err := s.keyRing.DeriveKey(keyDesc.KeyLocator)
+		if err != nil {
+			return err
+		}
+		pubKey = derived.PubKey
+	}
+
+	if keyDesc.Family == keychain.KeyFamilyMultiSig {
+		return s.checkMultiSigSpend(tx, signDesc, pubKey, walletOutputs)
+	}
+
+	// The base points are used to sign the outputs of commitment and
+	// HTLC transactions, so they may only sign for the channel they have
+	// been registered with.
+	family, multiSigKey, err := s.store.fetchKeyOwner(pubKey)
+	if err != nil {
+		return err
+	}
+	if family != keyDesc.Family {
+		return fmt.Errorf("%v: key %x is registered with family %v, "+
+			"not %v", ErrKeyFamilyNotAllowed,
+			pubKey.SerializeCompressed(), family, keyDesc.Family)
+	}
+
+	return s.checkBasePointSpend(
+		tx, signDesc, family, multiSigKey, walletOutputs,
+	)
+}
+
+// checkBasePointSpend returns an error if the signer isn't allowed to sign the
+// passed input with a base point of the given family of the channel that uses
+// the passed multi-sig key. The spent output must be an output of one of the
+// commitment or HTLC transactions the signer signed for the channel, and
+// unless the spend is an HTLC transaction signed with the HTLC base point, it
+// must pay to the on-chain wallet.
+func (s *Server) checkBasePointSpend(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor, family keychain.KeyFamily,
+	multiSigKey *btcec.PublicKey,
+	walletOutputs []*remotesignerrpc.WalletOutput) error {
+
+	prevOut := tx.TxIn[signDesc.InputIndex].PreviousOutPoint
+	known, err := s.store.hasTx(multiSigKey, prevOut.Hash)
+	if err != nil {
+		return err
+	}
+	if !known {
+		return fmt.Errorf("%v: %v isn't an output of the channel with "+
+			"multi-sig key %x", ErrUnknownOutput, prevOut,
+			multiSigKey.SerializeCompressed())
+	}
+
+	// The HTLC transactions of both parties spend an HTLC output of a
+	// commitment into a single delayed output. Their outputs are swept
+	// with our base points in turn, so we record them.
+	if family == keychain.KeyFamilyHtlcBase && isHtlcTx(tx) {
+		return s.store.putTx(multiSigKey, tx.TxHash())
+	}
+
+	ourOutputs, err := s.verifyWalletOutputs(tx, walletOutputs)
+	if err != nil {
+		return err
+	}
+	if len(ourOutputs) != len(tx.TxOut) {
+		return fmt.Errorf("%v: only %v of %v outputs of the sweep of "+
+			"%v pay to the wallet", ErrSweepNotPaid,
+			len(ourOutputs), len(tx.TxOut), prevOut)
+	}
+
+	return nil
+}
+
+// isHtlcTx returns true if the passed transaction has the form of an HTLC
+// transaction, which spends an HTLC output with its only input into a single
+// P2WSH output.
+func isHtlcTx(tx *wire.MsgTx) bool {
+	return len(tx.TxIn) == 1 && len(tx.TxOut) == 1 &&
+		txscript.IsPayToWitnessScriptHash(tx.TxOut[0].PkScript)
+}
+
+// checkMultiSigSpend returns an error if the signer isn't allowed to sign the
+// passed input with the given multi-sig key. Keys of the multi-sig family
+// only control channel funding outputs and our anchor outputs.
+func (s *Server) checkMultiSigSpend(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor, pubKey *btcec.PublicKey,
+	walletOutputs []*remotesignerrpc.WalletOutput) error {
+
+	// Our anchor outputs are spent with the multi-sig key of a registered
+	// channel. As the signature commits to the witness script, it can't
+	// be used to spend anything else.
+	anchorScript, err := lnwallet.CommitScriptAnchor(pubKey)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(signDesc.WitnessScript, anchorScript) {
+		_, err := s.store.fetchConfig(pubKey)
+		return err
+	}
+
+	// Otherwise the spent output must match a registered channel.
+	prevOut := tx.TxIn[signDesc.InputIndex].PreviousOutPoint
+	channel, err := s.store.fetchChannel(prevOut)
+	if err != nil {
+		return err
+	}
+
+	if !channel.multiSigKey.IsEqual(pubKey) {
+		return fmt.Errorf("key %x isn't the multi-sig key of %v",
+			pubKey.SerializeCompressed(), prevOut)
+	}
+	if !bytes.Equal(channel.witnessScript, signDesc.WitnessScript) {
+		return fmt.Errorf("witness script of %v doesn't match "+
+			"registered channel", prevOut)
+	}
+	if signDesc.Output.Value != int64(channel.capacity) {
+		return fmt.Errorf("value %v of %v doesn't match "+
+			"registered capacity %v",
+			btcutil.Amount(signDesc.Output.Value), prevOut,
+			channel.capacity)
+	}
+
+	// The remote party builds the commitments it signs from our base
+	// points and commitment points, so unlike the outputs of the spends
+	// below, their outputs can't be chosen by the watch-only instance.
+	// They are only checked for revocation.
+	// The outputs of the commitments we sign are swept with our base
+	// points, so we record them.
+	if isCommitmentTx(tx) {
+		if err := s.checkRevoked(tx, pubKey); err != nil {
+			return err
+		}
+
+		return s.store.putTx(pubKey, tx.TxHash())
+	}
+
+	return s.checkFundingPayout(tx, prevOut, channel, walletOutputs)
+}
+
+// isCommitmentTx returns true if the passed transaction has the form of a
+// commitment transaction, which spends the funding output with its only
+// input and encodes its state hint in the lock time.
+func isCommitmentTx(tx *wire.MsgTx) bool {
+	return len(tx.TxIn) == 1 &&
+		tx.LockTime&lnwallet.TimelockShift == lnwallet.TimelockShift
+}
+
+// checkFundingPayout returns an error if the passed spend of a funding output
+// that isn't a commitment transaction, such as a cooperative close or a
+// splice, doesn't pay our balance of the channel to us. As the outputs of such
+// spends are chosen by the watch-only instance, our balance must be paid to
+// our upfront shutdown script, to the on-chain wallet, or back into the
+// funding script of the channel. In the latter case, the balance of the
+// remote party stays in the channel as well if we initiated it, as we pay for
+// the splice then.
+func (s *Server) checkFundingPayout(tx *wire.MsgTx, prevOut wire.OutPoint,
+	channel *channelInfo,
+	walletOutputs []*remotesignerrpc.WalletOutput) error {
+
+	// Any other input could fund the outputs that pay to us, so the
+	// funding output must be spent on its own.
+	if len(tx.TxIn) != 1 {
+		return fmt.Errorf("%v: spend of %v has %v inputs",
+			ErrBalanceNotPaid, prevOut, len(tx.TxIn))
+	}
+
+	cfg, err := s.store.fetchConfig(channel.multiSigKey)
+	if err != nil {
+		return err
+	}
+	balance, err := s.store.fetchBalance(channel.multiSigKey)
+	if err != nil {
+		return err
+	}
+
+	// The fees of the spend and of prior splices are paid by the
+	// initiator of the channel, so if we initiated it, everything the
+	// funding output lost since our current commitment, as well as the
+	// fee of this spend, is deducted from our balance.
+	ourBalance := balance.local
+	if cfg.initiator {
+		deducted := balance.capacity - channel.capacity
+		if channel.capacity > balance.capacity {
+			deducted = 0
+		}
+
+		var outputs btcutil.Amount
+		for _, txOut := range tx.TxOut {
+			outputs += btcutil.Amount(txOut.Value)
+		}
+		if outputs < channel.capacity {
+			deducted += channel.capacity - outputs
+		}
+
+		if deducted > ourBalance {
+			deducted = ourBalance
+		}
+		ourBalance -= deducted
+	}
+
+	ourOutputs, err := s.verifyWalletOutputs(tx, walletOutputs)
+	if err != nil {
+		return err
+	}
+	fundingScript, err := lnwallet.WitnessScriptHash(channel.witnessScript)
+	if err != nil {
+		return err
+	}
+
+	var paid btcutil.Amount
+	for i, txOut := range tx.TxOut {
+		value := btcutil.Amount(txOut.Value)
+
+		_, isWalletOutput := ourOutputs[uint32(i)]
+		isShutdownOutput := len(cfg.localShutdownScript) != 0 &&
+			bytes.Equal(txOut.PkScript, cfg.localShutdownScript)
+
+		switch {
+		case isWalletOutput || isShutdownOutput:
+			paid += value
+
+		case bytes.Equal(txOut.PkScript, fundingScript):
+			remote := balance.remote
+			if !cfg.initiator {
+				remote = 0
+			} else if remote > value {
+				remote = value
+			}
+			paid += value - remote
+		}
+	}
+
+	if paid < ourBalance {
+		return fmt.Errorf("%v: spend of %v pays %v to us, while our "+
+			"balance is %v", ErrBalanceNotPaid, prevOut, paid,
+			ourBalance)
+	}
+
+	return nil
+}
+
+// verifyWalletOutputs returns the indexes of the passed outputs of the
+// transaction that pay to the on-chain wallet. The signer derives the output
+// script of each of them from the key path claimed by the watch-only
+// instance, which must be a key path of the wallet.
+func (s *Server) verifyWalletOutputs(tx *wire.MsgTx,
+	walletOutputs []*remotesignerrpc.WalletOutput) (map[uint32]struct{},
+	error) {
+
+	outputs := make(map[uint32]struct{}, len(walletOutputs))
+	for _, walletOutput := range walletOutputs {
+		index := walletOutput.OutputIndex
+		if int(index) >= len(tx.TxOut) {
+			return nil, fmt.Errorf("invalid wallet output index %v",
+				index)
+		}
+		if walletOutput.KeyPath == nil {
+			return nil, fmt.Errorf("wallet output %v is missing "+
+				"the key path", index)
+		}
+
+		keyScope, path := unmarshalKeyPath(walletOutput.KeyPath)
+		if err := checkWalletKeyPath(keyScope, path); err != nil {
+			return nil, err
+		}
+
+		pkScript, err := s.walletSigner.ScriptAtPath(keyScope, path)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(pkScript, tx.TxOut[index].PkScript) {
+			return nil, fmt.Errorf("output %v doesn't pay to key "+
+				"%v/%v/%v of scope %v", index, path.Account,
+				path.Branch, path.Index, keyScope)
+		}
+
+		outputs[index] = struct{}{}
+	}
+
+	return outputs, nil
+}
+
+// checkRevoked returns ErrRevokedCommitment if the passed transaction is one
+// of our revoked commitment transactions of the channel that uses the given
+// multi-sig key.
+//
+// A commitment is recognized by its state hint, which tells its height, and
+// by our to_local or to_remote output at that height. Commitments of the
+// remote party don't carry these outputs, so they are always signed. As we
+// can't tell the commitments of both parties apart if neither output is
+// above the dust limit, such commitments are signed as well.
+func (s *Server) checkRevoked(tx *wire.MsgTx,
+	multiSigKey *btcec.PublicKey) error {
+
+	revoked, err := s.store.fetchRevoked(multiSigKey)
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return nil
+	}
+
+	cfg, err := s.store.fetchConfig(multiSigKey)
+	if err != nil {
+		return err
+	}
+
+	for _, obfuscator := range stateHintObfuscators(cfg) {
+		height := lnwallet.GetStateNumHint(tx, obfuscator)
+		if height >= revoked {
+			continue
+		}
+
+		isOurs, err := s.isOurCommitment(tx, cfg, height)
+		if err != nil {
+			return err
+		}
+		if isOurs {
+			return fmt.Errorf("%v: height %v of channel with "+
+				"multi-sig key %x", ErrRevokedCommitment,
+				height, multiSigKey.SerializeCompressed())
+		}
+	}
+
+	return nil
+}
+
+// stateHintObfuscators returns the obfuscators the state hints of the
+// commitments of the channel may be obfuscated with. The state hints of later
+// commitments are obfuscated with the payment base point of the initiator
+// first, while the first commitment of a dual funded channel orders them
+// lexicographically, so both orders are returned.
+func stateHintObfuscators(
+	cfg *channelConfig) [][lnwallet.StateHintSize]byte {
+
+	localPayBase := cfg.paymentBasePoint.PubKey
+	remotePayBase := cfg.remotePaymentBasePoint
+
+	return [][lnwallet.StateHintSize]byte{
+		lnwallet.DeriveStateHintObfuscator(localPayBase, remotePayBase),
+		lnwallet.DeriveStateHintObfuscator(remotePayBase, localPayBase),
+	}
+}
+
+// hasStateHint returns true if the state hint of the passed commitment
+// transaction of the channel encodes the given height.
+func hasStateHint(tx *wire.MsgTx, cfg *channelConfig, height uint64) bool {
+	for _, obfuscator := range stateHintObfuscators(cfg) {
+		if lnwallet.GetStateNumHint(tx, obfuscator) == height {
+			return true
+		}
+	}
+
+	return false
+}
+
+// commitScripts returns the output scripts of our to_local output and of the
+// to_remote output of the remote party of our commitment at the given height.
+func (s *Server) commitScripts(cfg *channelConfig,
+	height uint64) ([]byte, []byte, error) {
+
+	commitSecret, err := s.commitSecret(cfg.multiSigKey.PubKey, height)
+	if err != nil {
+		return nil, nil, err
+	}
+	commitPoint := lnwallet.ComputeCommitmentPoint(commitSecret[:])
+
+	toLocalScript, err := lnwallet.CommitScriptToSelf(
+		cfg.csvDelay,
+		lnwallet.TweakPubKey(cfg.delayBasePoint.PubKey, commitPoint),
+		lnwallet.DeriveRevocationPubkey(
+			cfg.remoteRevocationBasePoint, commitPoint,
+		),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	toLocal, err := lnwallet.WitnessScriptHash(toLocalScript)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toRemoteKey := cfg.remotePaymentBasePoint
+	if !cfg.chanType.IsTweakless() {
+		toRemoteKey = lnwallet.TweakPubKey(toRemoteKey, commitPoint)
+	}
+	toRemote, err := lnwallet.CommitScriptUnencumbered(toRemoteKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toLocal, toRemote, nil
+}
+
+// commitBalance returns the balances of the passed commitment transaction at
+// the given height of the channel, which spends a funding output of the
+// given capacity.
+func (s *Server) commitBalance(tx *wire.MsgTx, cfg *channelConfig,
+	height uint64, capacity btcutil.Amount) (*channelBalance, error) {
+
+	toLocal, toRemote, err := s.commitScripts(cfg, height)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := &channelBalance{capacity: capacity}
+	for _, txOut := range tx.TxOut {
+		switch {
+		case bytes.Equal(txOut.PkScript, toLocal):
+			balance.local = btcutil.Amount(txOut.Value)
+
+		case bytes.Equal(txOut.PkScript, toRemote):
+			balance.remote = btcutil.Amount(txOut.Value)
+		}
+	}
+
+	return balance, nil
+}
+
+// isOurCommitment returns true if the passed transaction carries our to_local
+// or to_remote output of our commitment at the given height.
+func (s *Server) isOurCommitment(tx *wire.MsgTx, cfg *channelConfig,
+	height uint64) (bool, error) {
+
+	toLocal, toRemote, err := s.commitScripts(cfg, height)
+	if err != nil {
+		return false, err
+	}
+
+	for _, txOut := range tx.TxOut {
+		if bytes.Equal(txOut.PkScript, toLocal) ||
+			bytes.Equal(txOut.PkScript, toRemote) {
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// SignMessage signs the double SHA-256 of the passed message with the node
+// key.
+func (s *Server) SignMessage(ctx context.Context,
+	req *remotesignerrpc.SignMessageRequest) (
+	*remotesignerrpc.SignMessageResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(req.PubKey, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	if !pubKey.IsEqual(s.nodeKey) {
+		return nil, fmt.Errorf("%v: messages can only be signed with "+
+			"the node key", ErrKeyFamilyNotAllowed)
+	}
+
+	sig, err := s.msgSigner.SignMessage(pubKey, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remotesignerrpc.SignMessageResponse{
+		Signature: sig.Serialize(),
+	}, nil
+}
+
+// RegisterChannel adds a channel to the set of channels whose funding outputs
+// the signer may sign spends of. Registrations are persisted, so they survive
+// restarts of the signer.
+func (s *Server) RegisterChannel(ctx context.Context,
+	req *remotesignerrpc.RegisterChannelRequest) (
+	*remotesignerrpc.RegisterChannelResponse, error) {
+
+	txid, err := chainhash.NewHash(req.FundingTxid)
+	if err != nil {
+		return nil, err
+	}
+	if req.Capacity <= 0 {
+		return nil, fmt.Errorf("invalid channel capacity %v",
+			req.Capacity)
+	}
+
+	cfg := &channelConfig{
+		initiator:           req.Initiator,
+		chanType:            channeldb.ChannelType(req.ChanType),
+		csvDelay:            req.CsvDelay,
+		localShutdownScript: req.LocalShutdownScript,
+	}
+
+	// All of our keys of the channel must be keys of the signer of their
+	// respective family.
+	for _, key := range []struct {
+		rpcDesc *remotesignerrpc.KeyDescriptor
+		keyDesc *keychain.KeyDescriptor
+		family  keychain.KeyFamily
+	}{
+		{
+			req.MultiSigKey, &cfg.multiSigKey,
+			keychain.KeyFamilyMultiSig,
+		},
+		{
+			req.RevocationBasePoint, &cfg.revocationBasePoint,
+			keychain.KeyFamilyRevocationBase,
+		},
+		{
+			req.PaymentBasePoint, &cfg.paymentBasePoint,
+			keychain.KeyFamilyPaymentBase,
+		},
+		{
+			req.DelayBasePoint, &cfg.delayBasePoint,
+			keychain.KeyFamilyDelayBase,
+		},
+		{
+			req.HtlcBasePoint, &cfg.htlcBasePoint,
+			keychain.KeyFamilyHtlcBase,
+		},
+	} {
+		*key.keyDesc, err = unmarshalKeyDesc(key.rpcDesc)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.verifyKey(*key.keyDesc, key.family); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.remoteRevocationBasePoint, err = btcec.ParsePubKey(
+		req.RemoteRevocationBasePoint, btcec.S256(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote revocation base "+
+			"point: %v", err)
+	}
+	cfg.remotePaymentBasePoint, err = btcec.ParsePubKey(
+		req.RemotePaymentBasePoint, btcec.S256(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote payment base point: %v",
+			err)
+	}
+
+	// The witness script must be a 2-of-2 multi-sig script that contains
+	// our multi-sig key. The network parameters don't matter as we only
+	// look at the keys.
+	class, addrs, reqSigs, err := txscript.ExtractPkScriptAddrs(
+		req.WitnessScript, &chaincfg.MainNetParams,
+	)
+	if err != nil || class != txscript.MultiSigTy || len(addrs) != 2 ||
+		reqSigs != 2 {
+
+		return nil, fmt.Errorf("witness script isn't a 2-of-2 " +
+			"multi-sig script")
+	}
+	ourKey := cfg.multiSigKey.PubKey.SerializeCompressed()
+	var hasOurKey bool
+	for _, addr := range addrs {
+		hasOurKey = hasOurKey || bytes.Equal(addr.ScriptAddress(), ourKey)
+	}
+	if !hasOurKey {
+		return nil, fmt.Errorf("witness script doesn't contain our " +
+			"multi-sig key")
+	}
+
+	chanPoint := wire.OutPoint{Hash: *txid, Index: req.OutputIndex}
+	capacity := btcutil.Amount(req.Capacity)
+
+	balance, err := s.initialBalance(
+		chanPoint, capacity, req.LocalCommitTx, cfg,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.putChannel(chanPoint, &channelInfo{
+		capacity:      capacity,
+		witnessScript: req.WitnessScript,
+		multiSigKey:   cfg.multiSigKey.PubKey,
+	}, cfg, balance)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Registered channel %v with capacity %v", chanPoint,
+		btcutil.Amount(req.Capacity))
+
+	return &remotesignerrpc.RegisterChannelResponse{}, nil
+}
+
+// initialBalance returns the balances of the passed initial commitment of a
+// channel that is registered with the given funding output. A splice
+// continues with the balances of the channel, so the commitment passed along
+// with it, which spends the prior funding output, isn't used and nil is
+// returned.
+func (s *Server) initialBalance(chanPoint wire.OutPoint,
+	capacity btcutil.Amount, rawCommitTx []byte,
+	cfg *channelConfig) (*channelBalance, error) {
+
+	commitTx := wire.NewMsgTx(2)
+	err := commitTx.Deserialize(bytes.NewReader(rawCommitTx))
+	if err != nil {
+		return nil, fmt.Errorf("invalid initial commitment: %v", err)
+	}
+	if !isCommitmentTx(commitTx) ||
+		commitTx.TxIn[0].PreviousOutPoint != chanPoint {
+
+		return nil, nil
+	}
+
+	if !hasStateHint(commitTx, cfg, 0) {
+		return nil, fmt.Errorf("%v: initial commitment of %v isn't "+
+			"at height 0", ErrInvalidCommitment, chanPoint)
+	}
+
+	return s.commitBalance(commitTx, cfg, 0, capacity)
+}
+
+// verifyCommitment verifies that the passed transaction is our commitment at
+// the given height of the channel that uses the given multi-sig key, signed
+// by the remote party with the passed signature, and returns its balances.
+// As the remote party signed the commitment, the watch-only instance can't
+// make up the balances.
+func (s *Server) verifyCommitment(multiSigKey *btcec.PublicKey,
+	height uint64, rawCommitTx, rawSig []byte) (*channelBalance, error) {
+
+	commitTx := wire.NewMsgTx(2)
+	err := commitTx.Deserialize(bytes.NewReader(rawCommitTx))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", ErrInvalidCommitment, err)
+	}
+	if !isCommitmentTx(commitTx) {
+		return nil, fmt.Errorf("%v: transaction %v isn't a commitment",
+			ErrInvalidCommitment, commitTx.TxHash())
+	}
+
+	fundingOutpoint := commitTx.TxIn[0].PreviousOutPoint
+	channel, err := s.store.fetchChannel(fundingOutpoint)
+	if err != nil {
+		return nil, err
+	}
+	if !channel.multiSigKey.IsEqual(multiSigKey) {
+		return nil, fmt.Errorf("%v: commitment spends %v of another "+
+			"channel", ErrInvalidCommitment, fundingOutpoint)
+	}
+
+	cfg, err := s.store.fetchConfig(multiSigKey)
+	if err != nil {
+		return nil, err
+	}
+	if !hasStateHint(commitTx, cfg, height) {
+		return nil, fmt.Errorf("%v: commitment isn't at height %v",
+			ErrInvalidCommitment, height)
+	}
+
+	remoteKey, err := remoteMultiSigKey(channel.witnessScript, multiSigKey)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := btcec.ParseDERSignature(rawSig, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", ErrInvalidCommitment, err)
+	}
+	sigHash, err := txscript.CalcWitnessSigHash(
+		channel.witnessScript, txscript.NewTxSigHashes(commitTx),
+		txscript.SigHashAll, commitTx, 0, int64(channel.capacity),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !sig.Verify(sigHash, remoteKey) {
+		return nil, fmt.Errorf("%v: invalid signature of the remote "+
+			"party", ErrInvalidCommitment)
+	}
+
+	return s.commitBalance(commitTx, cfg, height, channel.capacity)
+}
+
+// remoteMultiSigKey returns the multi-sig key of the remote party within the
+// passed 2-of-2 multi-sig witness script that contains our given key.
+func remoteMultiSigKey(witnessScript []byte,
+	ourKey *btcec.PublicKey) (*btcec.PublicKey, error) {
+
+	// The network parameters don't matter as we only look at the keys.
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		witnessScript, &chaincfg.MainNetParams,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		pubKeyAddr, ok := addr.(*btcutil.AddressPubKey)
+		if !ok || pubKeyAddr.PubKey().IsEqual(ourKey) {
+			continue
+		}
+
+		return pubKeyAddr.PubKey(), nil
+	}
+
+	return nil, fmt.Errorf("witness script doesn't contain the " +
+		"multi-sig key of the remote party")
+}
+
+// CommitmentPoint returns our commitment point at the given height of the
+// channel that uses the given multi-sig key.
+func (s *Server) CommitmentPoint(ctx context.Context,
+	req *remotesignerrpc.CommitmentPointRequest) (
+	*remotesignerrpc.CommitmentPointResponse, error) {
+
+	multiSigKey, err := unmarshalKeyDesc(req.MultiSigKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// The channel doesn't need to be registered yet, as the first
+	// commitment point is exchanged while the channel is negotiated.
+	err = s.verifyKey(multiSigKey, keychain.KeyFamilyMultiSig)
+	if err != nil {
+		return nil, err
+	}
+
+	commitSecret, err := s.commitSecret(multiSigKey.PubKey, req.Height)
+	if err != nil {
+		return nil, err
+	}
+	commitPoint := lnwallet.ComputeCommitmentPoint(commitSecret[:])
+
+	return &remotesignerrpc.CommitmentPointResponse{
+		CommitPoint: commitPoint.SerializeCompressed(),
+	}, nil
+}
+
+// RevokeCommitment revokes our commitment at the given height of the
+// registered channel that uses the given multi-sig key, and returns its
+// revocation secret. The revocation is persisted before the secret is handed
+// out, so a revoked commitment is never signed again. Along with it, the
+// balances of the next commitment, which the remote party must have signed,
+// are stored.
+func (s *Server) RevokeCommitment(ctx context.Context,
+	req *remotesignerrpc.RevokeCommitmentRequest) (
+	*remotesignerrpc.RevokeCommitmentResponse, error) {
+
+	multiSigKey, err := unmarshalKeyDesc(req.MultiSigKey)
+	if err != nil {
+		return nil, err
+	}
+	err = s.verifyKey(multiSigKey, keychain.KeyFamilyMultiSig)
+	if err != nil {
+		return nil, err
+	}
+
+	nextBalance, err := s.verifyCommitment(
+		multiSigKey.PubKey, req.Height+1, req.NextCommitTx,
+		req.NextCommitSig,
+	)
+	if err == nil {
+		err = s.store.revoke(multiSigKey.PubKey, req.Height, nextBalance)
+	}
+	if err != nil {
+		log.Warnf("Refusing to revoke commitment %v: %v", req.Height,
+			err)
+		return nil, err
+	}
+
+	commitSecret, err := s.commitSecret(multiSigKey.PubKey, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remotesignerrpc.RevokeCommitmentResponse{
+		CommitSecret: commitSecret[:],
+	}, nil
+}
+
+// ComputeInputScript generates the input script of an input that spends an
+// output of the on-chain wallet, as long as its key belongs to the default
+// account of the BIP84 or BIP49 key scope.
+func (s *Server) ComputeInputScript(ctx context.Context,
+	req *remotesignerrpc.InputScriptRequest) (
+	*remotesignerrpc.InputScriptResponse, error) {
+
+	tx, signDesc, err := parseSignRequest(
+		req.RawTx, req.SignDesc, req.InputIndex,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if req.KeyPath == nil {
+		return nil, fmt.Errorf("request is missing the key path")
+	}
+
+	keyScope, path := unmarshalKeyPath(req.KeyPath)
+	if err := checkWalletInput(keyScope, path, signDesc); err != nil {
+		log.Warnf("Refusing to sign wallet input %v of tx %v: %v",
+			signDesc.InputIndex, tx.TxHash(), err)
+		return nil, err
+	}
+
+	inputScript, err := s.walletSigner.ComputeInputScriptAtPath(
+		tx, signDesc, keyScope, path,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remotesignerrpc.InputScriptResponse{
+		SigScript: inputScript.ScriptSig,
+		Witness:   inputScript.Witness,
+	}, nil
+}
+
+// checkWalletInput returns an error if the signer isn't allowed to sign a
+// wallet input with the key of the given derivation path.
+func checkWalletInput(keyScope waddrmgr.KeyScope,
+	path waddrmgr.DerivationPath, signDesc *lnwallet.SignDescriptor) error {
+
+	if err := checkWalletKeyPath(keyScope, path); err != nil {
+		return err
+	}
+	if signDesc.SingleTweak != nil || signDesc.DoubleTweak != nil {
+		return fmt.Errorf("%v: wallet keys are never tweaked",
+			ErrKeyPathNotAllowed)
+	}
+
+	return nil
+}
+
+// checkWalletKeyPath returns an error if the given derivation path isn't a
+// key path of the on-chain wallet. Only the keys of the on-chain wallet may
+// be used to sign wallet inputs, so that the keys of our channels, which
+// share the seed, can't be used to sign around the policy of SignOutputRaw.
+func checkWalletKeyPath(keyScope waddrmgr.KeyScope,
+	path waddrmgr.DerivationPath) error {
+
+	if keyScope != waddrmgr.KeyScopeBIP0084 &&
+		keyScope != waddrmgr.KeyScopeBIP0049Plus {
+
+		return fmt.Errorf("%v: key scope %v isn't used by the wallet",
+			ErrKeyPathNotAllowed, keyScope)
+	}
+	if path.Account != waddrmgr.DefaultAccountNum {
+		return fmt.Errorf("%v: account %v isn't used by the wallet",
+			ErrKeyPathNotAllowed, path.Account)
+	}
+	if path.Branch != waddrmgr.ExternalBranch &&
+		path.Branch != waddrmgr.InternalBranch {
+
+		return fmt.Errorf("%v: unknown branch %v",
+			ErrKeyPathNotAllowed, path.Branch)
+	}
+
+	return nil
+}
+
+// ExportWatchOnlyWallet returns a copy of the database of the on-chain wallet
+// without any private key material.
+func (s *Server) ExportWatchOnlyWallet(ctx context.Context,
+	req *remotesignerrpc.ExportWatchOnlyWalletRequest) (
+	*remotesignerrpc.ExportWatchOnlyWalletResponse, error) {
+
+	var walletDB bytes.Buffer
+	if err := s.walletSigner.ExportWatchOnly(&walletDB); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Exported watch-only wallet")
+
+	return &remotesignerrpc.ExportWatchOnlyWalletResponse{
+		WalletDb: walletDB.Bytes(),
+	}, nil
+}
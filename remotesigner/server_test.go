@@ -0,0 +1,1098 @@
+package remotesigner
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc/remotesignerrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"golang.org/x/net/context"
+)
+
+// mockKeyRing is a key ring that holds a single private key per key family.
+type mockKeyRing struct {
+	keys map[keychain.KeyFamily]*btcec.PrivateKey
+}
+
+func newMockKeyRing() *mockKeyRing {
+	keys := make(map[keychain.KeyFamily]*btcec.PrivateKey)
+	for fam := keychain.KeyFamilyMultiSig; fam <= keychain.KeyFamilyStaticBackup; fam++ {
+		privKey, _ := btcec.PrivKeyFromBytes(
+			btcec.S256(), bytes.Repeat([]byte{byte(fam) + 1}, 32),
+		)
+		keys[fam] = privKey
+	}
+
+	return &mockKeyRing{keys: keys}
+}
+
+func (m *mockKeyRing) DeriveNextKey(
+	keyFam keychain.KeyFamily) (keychain.KeyDescriptor, error) {
+
+	return m.DeriveKey(keychain.KeyLocator{Family: keyFam})
+}
+
+func (m *mockKeyRing) DeriveKey(
+	keyLoc keychain.KeyLocator) (keychain.KeyDescriptor, error) {
+
+	return keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+		PubKey:     m.keys[keyLoc.Family].PubKey(),
+	}, nil
+}
+
+func (m *mockKeyRing) DerivePrivKey(
+	keyDesc keychain.KeyDescriptor) (*btcec.PrivateKey, error) {
+
+	return m.keys[keyDesc.Family], nil
+}
+
+func (m *mockKeyRing) ScalarMult(keyDesc keychain.KeyDescriptor,
+	pubKey *btcec.PublicKey) ([]byte, error) {
+
+	return nil, nil
+}
+
+// mockSigner signs every input and message with a fixed signature.
+type mockSigner struct{}
+
+func (m *mockSigner) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	return []byte{1}, nil
+}
+
+func (m *mockSigner) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	return &lnwallet.InputScript{}, nil
+}
+
+func (m *mockSigner) SignMessage(pubKey *btcec.PublicKey,
+	msg []byte) (*btcec.Signature, error) {
+
+	return &btcec.Signature{R: pubKey.X, S: pubKey.Y}, nil
+}
+
+func (m *mockSigner) ComputeInputScriptAtPath(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor, keyScope waddrmgr.KeyScope,
+	path waddrmgr.DerivationPath) (*lnwallet.InputScript, error) {
+
+	return &lnwallet.InputScript{Witness: wire.TxWitness{{1}}}, nil
+}
+
+func (m *mockSigner) ScriptAtPath(keyScope waddrmgr.KeyScope,
+	path waddrmgr.DerivationPath) ([]byte, error) {
+
+	return walletScript(path), nil
+}
+
+func (m *mockSigner) ExportWatchOnly(w io.Writer) error {
+	_, err := w.Write([]byte("watch-only"))
+	return err
+}
+
+// walletScript returns the output script the mock wallet derives for the
+// given derivation path.
+func walletScript(path waddrmgr.DerivationPath) []byte {
+	return append(
+		[]byte{0x00, 0x14},
+		bytes.Repeat([]byte{byte(path.Branch<<4) | byte(path.Index)}, 20)...,
+	)
+}
+
+// walletPath is the derivation path of the wallet outputs of the tests.
+var walletPath = waddrmgr.DerivationPath{
+	Account: waddrmgr.DefaultAccountNum,
+	Branch:  waddrmgr.InternalBranch,
+	Index:   7,
+}
+
+// walletOutput returns the RPC description of the output at the given index
+// that pays to the wallet output of the tests.
+func walletOutput(index uint32) *remotesignerrpc.WalletOutput {
+	return &remotesignerrpc.WalletOutput{
+		OutputIndex: index,
+		KeyPath: marshalKeyPath(
+			waddrmgr.KeyScopeBIP0084, walletPath,
+		),
+	}
+}
+
+// signRequest creates a request to sign the first input of a transaction
+// spending prevOut.
+func signRequest(t *testing.T, prevOut wire.OutPoint,
+	signDesc *lnwallet.SignDescriptor) *remotesignerrpc.SignRequest {
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(&prevOut, nil, nil))
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0}})
+
+	return txSignRequest(t, tx, signDesc)
+}
+
+// txSignRequest creates a request to sign the first input of the passed
+// transaction, whose given outputs pay to the wallet.
+func txSignRequest(t *testing.T, tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor,
+	walletOutputs ...*remotesignerrpc.WalletOutput) *remotesignerrpc.SignRequest {
+
+	var rawTx, rawSignDesc bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+	err := lnwallet.WriteSignDescriptor(&rawSignDesc, signDesc)
+	if err != nil {
+		t.Fatalf("unable to serialize sign descriptor: %v", err)
+	}
+
+	return &remotesignerrpc.SignRequest{
+		RawTx:         rawTx.Bytes(),
+		SignDesc:      rawSignDesc.Bytes(),
+		WalletOutputs: walletOutputs,
+	}
+}
+
+// testChannel describes the keys of a test channel between us and a remote
+// party.
+type testChannel struct {
+	keyRing *mockKeyRing
+	server  *Server
+
+	remoteMultiSigPriv   *btcec.PrivateKey
+	remoteMultiSig       *btcec.PublicKey
+	remoteRevocationBase *btcec.PublicKey
+	remotePaymentBase    *btcec.PublicKey
+
+	witnessScript []byte
+}
+
+func newTestChannel(t *testing.T, keyRing *mockKeyRing,
+	server *Server) *testChannel {
+
+	remoteKey := func(b byte) *btcec.PublicKey {
+		_, pubKey := btcec.PrivKeyFromBytes(
+			btcec.S256(), bytes.Repeat([]byte{b}, 32),
+		)
+		return pubKey
+	}
+	remoteMultiSigPriv, _ := btcec.PrivKeyFromBytes(
+		btcec.S256(), bytes.Repeat([]byte{0x51}, 32),
+	)
+
+	c := &testChannel{
+		keyRing:              keyRing,
+		server:               server,
+		remoteMultiSigPriv:   remoteMultiSigPriv,
+		remoteMultiSig:       remoteMultiSigPriv.PubKey(),
+		remoteRevocationBase: remoteKey(0x52),
+		remotePaymentBase:    remoteKey(0x53),
+	}
+
+	var err error
+	c.witnessScript, err = lnwallet.GenMultiSigScript(
+		c.pubKey(keychain.KeyFamilyMultiSig).SerializeCompressed(),
+		c.remoteMultiSig.SerializeCompressed(),
+	)
+	if err != nil {
+		t.Fatalf("unable to create multi-sig script: %v", err)
+	}
+
+	return c
+}
+
+// pubKey returns our key of the given family.
+func (c *testChannel) pubKey(family keychain.KeyFamily) *btcec.PublicKey {
+	return c.keyRing.keys[family].PubKey()
+}
+
+// keyDesc returns the RPC key descriptor of our key of the given family.
+func (c *testChannel) keyDesc(
+	family keychain.KeyFamily) *remotesignerrpc.KeyDescriptor {
+
+	return marshalKeyDesc(keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{Family: family},
+		PubKey:     c.pubKey(family),
+	})
+}
+
+// commitTx creates our commitment transaction at the given height that
+// spends the funding output with the given outpoint.
+func (c *testChannel) commitTx(t *testing.T, chanPoint wire.OutPoint,
+	height uint64, toLocal, toRemote btcutil.Amount) *wire.MsgTx {
+
+	commitSecret, err := c.server.commitSecret(
+		c.pubKey(keychain.KeyFamilyMultiSig), height,
+	)
+	if err != nil {
+		t.Fatalf("unable to derive commitment secret: %v", err)
+	}
+	commitPoint := lnwallet.ComputeCommitmentPoint(commitSecret[:])
+
+	keyRing := &lnwallet.CommitmentKeyRing{
+		DelayKey: lnwallet.TweakPubKey(
+			c.pubKey(keychain.KeyFamilyDelayBase), commitPoint,
+		),
+		RevocationKey: lnwallet.DeriveRevocationPubkey(
+			c.remoteRevocationBase, commitPoint,
+		),
+		NoDelayKey: lnwallet.TweakPubKey(
+			c.remotePaymentBase, commitPoint,
+		),
+	}
+	tx, err := lnwallet.CreateCommitTx(
+		*wire.NewTxIn(&chanPoint, nil, nil), keyRing, 144, toLocal,
+		toRemote, 546,
+	)
+	if err != nil {
+		t.Fatalf("unable to create commitment: %v", err)
+	}
+
+	obfuscator := lnwallet.DeriveStateHintObfuscator(
+		c.pubKey(keychain.KeyFamilyPaymentBase), c.remotePaymentBase,
+	)
+	err = lnwallet.SetStateNumHint(tx, height, obfuscator)
+	if err != nil {
+		t.Fatalf("unable to set state hint: %v", err)
+	}
+
+	return tx
+}
+
+// remoteSig returns the signature of the remote party for the passed
+// transaction, which spends a funding output of the given capacity.
+func (c *testChannel) remoteSig(t *testing.T, tx *wire.MsgTx,
+	capacity int64) []byte {
+
+	sig, err := txscript.RawTxInWitnessSignature(
+		tx, txscript.NewTxSigHashes(tx), 0, capacity, c.witnessScript,
+		txscript.SigHashAll, c.remoteMultiSigPriv,
+	)
+	if err != nil {
+		t.Fatalf("unable to sign commitment: %v", err)
+	}
+
+	// Strip the sighash flag.
+	return sig[:len(sig)-1]
+}
+
+// registerRequest creates a request to register the funding output with the
+// given outpoint and capacity. The initial commitment pays half of the
+// capacity to us and 40% to the remote party.
+func (c *testChannel) registerRequest(t *testing.T, chanPoint wire.OutPoint,
+	capacity int64) *remotesignerrpc.RegisterChannelRequest {
+
+	commitTx := c.commitTx(
+		t, chanPoint, 0, btcutil.Amount(capacity/2),
+		btcutil.Amount(capacity*2/5),
+	)
+	var rawCommitTx bytes.Buffer
+	if err := commitTx.Serialize(&rawCommitTx); err != nil {
+		t.Fatalf("unable to serialize commitment: %v", err)
+	}
+
+	return &remotesignerrpc.RegisterChannelRequest{
+		FundingTxid:         chanPoint.Hash[:],
+		OutputIndex:         chanPoint.Index,
+		Capacity:            capacity,
+		WitnessScript:       c.witnessScript,
+		Initiator:           true,
+		MultiSigKey:         c.keyDesc(keychain.KeyFamilyMultiSig),
+		RevocationBasePoint: c.keyDesc(keychain.KeyFamilyRevocationBase),
+		PaymentBasePoint:    c.keyDesc(keychain.KeyFamilyPaymentBase),
+		DelayBasePoint:      c.keyDesc(keychain.KeyFamilyDelayBase),
+		HtlcBasePoint:       c.keyDesc(keychain.KeyFamilyHtlcBase),
+		CsvDelay:            144,
+		RemoteRevocationBasePoint: c.remoteRevocationBase.
+			SerializeCompressed(),
+		RemotePaymentBasePoint: c.remotePaymentBase.
+			SerializeCompressed(),
+		LocalCommitTx: rawCommitTx.Bytes(),
+	}
+}
+
+// fundingSignDesc returns the sign descriptor of a spend of the funding
+// output with the given capacity.
+func (c *testChannel) fundingSignDesc(
+	capacity int64) *lnwallet.SignDescriptor {
+
+	return &lnwallet.SignDescriptor{
+		KeyDesc: keychain.KeyDescriptor{
+			KeyLocator: keychain.KeyLocator{
+				Family: keychain.KeyFamilyMultiSig,
+			},
+			PubKey: c.pubKey(keychain.KeyFamilyMultiSig),
+		},
+		WitnessScript: c.witnessScript,
+		Output:        &wire.TxOut{Value: capacity},
+	}
+}
+
+// openTestStore opens a signer database within a new temporary directory. The
+// returned function removes the directory.
+func openTestStore(t *testing.T) (*Store, string, func()) {
+	tempDir, err := ioutil.TempDir("", "remotesigner")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	dbPath := filepath.Join(tempDir, "remotesigner.db")
+
+	store, err := OpenStore(dbPath)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to open store: %v", err)
+	}
+
+	return store, dbPath, func() { os.RemoveAll(tempDir) }
+}
+
+// TestServerSignPolicy asserts that the signer only signs spends of funding
+// outputs of registered channels that match the registered state, and that
+// it refuses to hand out keys that control funds.
+func TestServerSignPolicy(t *testing.T) {
+	t.Parallel()
+
+	store, dbPath, cleanUp := openTestStore(t)
+	defer cleanUp()
+
+	keyRing := newMockKeyRing()
+	server, err := NewServer(
+		&mockSigner{}, &mockSigner{}, &mockSigner{}, keyRing, store,
+	)
+	if err != nil {
+		t.Fatalf("unable to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	channel := newTestChannel(t, keyRing, server)
+	localKey := channel.pubKey(keychain.KeyFamilyMultiSig)
+	remoteKey := keyRing.keys[keychain.KeyFamilyNodeKey].PubKey()
+
+	chanPoint := wire.OutPoint{Index: 1}
+	fundingSignDesc := channel.fundingSignDesc(100000)
+
+	// A spend of the funding output must be refused as long as the
+	// channel isn't registered.
+	_, err = server.SignOutputRaw(
+		ctx, signRequest(t, chanPoint, fundingSignDesc),
+	)
+	if err == nil || !strings.Contains(err.Error(), ErrUnknownChannel.Error()) {
+		t.Fatalf("expected unknown channel error, got %v", err)
+	}
+
+	_, err = server.RegisterChannel(
+		ctx, channel.registerRequest(t, chanPoint, 100000),
+	)
+	if err != nil {
+		t.Fatalf("unable to register channel: %v", err)
+	}
+
+	// Scripts that aren't 2-of-2 multi-sig scripts can't be registered.
+	badScript := channel.registerRequest(t, wire.OutPoint{Index: 2}, 100000)
+	badScript.WitnessScript = []byte{0x51}
+	_, err = server.RegisterChannel(ctx, badScript)
+	if err == nil {
+		t.Fatalf("expected invalid witness script to be rejected")
+	}
+
+	// Neither can channels with keys that aren't ours, or that are used
+	// with another family.
+	badKey := channel.registerRequest(t, wire.OutPoint{Index: 2}, 100000)
+	badKey.HtlcBasePoint.RawKeyBytes = remoteKey.SerializeCompressed()
+	_, err = server.RegisterChannel(ctx, badKey)
+	if err == nil {
+		t.Fatalf("expected foreign htlc base point to be rejected")
+	}
+	badFamily := channel.registerRequest(t, wire.OutPoint{Index: 2}, 100000)
+	badFamily.HtlcBasePoint = channel.keyDesc(
+		keychain.KeyFamilyPaymentBase,
+	)
+	_, err = server.RegisterChannel(ctx, badFamily)
+	if err == nil {
+		t.Fatalf("expected payment base point to be rejected as " +
+			"htlc base point")
+	}
+
+	// Once registered, our commitment is signed.
+	commitTx := channel.commitTx(t, chanPoint, 0, 50000, 40000)
+	_, err = server.SignOutputRaw(
+		ctx, txSignRequest(t, commitTx, fundingSignDesc),
+	)
+	if err != nil {
+		t.Fatalf("unable to sign funding spend: %v", err)
+	}
+
+	// The registration is persisted, so the spend is still signed after
+	// the signer is restarted.
+	if err := store.Close(); err != nil {
+		t.Fatalf("unable to close store: %v", err)
+	}
+	store, err = OpenStore(dbPath)
+	if err != nil {
+		t.Fatalf("unable to reopen store: %v", err)
+	}
+	defer store.Close()
+	server, err = NewServer(
+		&mockSigner{}, &mockSigner{}, &mockSigner{}, keyRing, store,
+	)
+	if err != nil {
+		t.Fatalf("unable to create server: %v", err)
+	}
+	_, err = server.SignOutputRaw(
+		ctx, txSignRequest(t, commitTx, fundingSignDesc),
+	)
+	if err != nil {
+		t.Fatalf("unable to sign funding spend after restart: %v", err)
+	}
+
+	// A registered channel can't be changed by registering it again.
+	_, err = server.RegisterChannel(
+		ctx, channel.registerRequest(t, chanPoint, 200000),
+	)
+	if err == nil {
+		t.Fatalf("expected change of registered channel to be refused")
+	}
+
+	// A splice registers a new funding output with the same keys, but
+	// can't change the configuration of the channel.
+	splicePoint := wire.OutPoint{Index: 4}
+	_, err = server.RegisterChannel(
+		ctx, channel.registerRequest(t, splicePoint, 50000),
+	)
+	if err != nil {
+		t.Fatalf("unable to register splice: %v", err)
+	}
+	badSplice := channel.registerRequest(t, wire.OutPoint{Index: 5}, 50000)
+	badSplice.CsvDelay = 1
+	_, err = server.RegisterChannel(ctx, badSplice)
+	if err == nil {
+		t.Fatalf("expected change of channel config to be refused")
+	}
+
+	// A spend claiming a different value of the funding output must be
+	// refused, as well as a spend of a different outpoint.
+	badValue := *fundingSignDesc
+	badValue.Output = &wire.TxOut{Value: 200000}
+	_, err = server.SignOutputRaw(ctx, signRequest(t, chanPoint, &badValue))
+	if err == nil {
+		t.Fatalf("expected spend with mismatching value to be refused")
+	}
+	_, err = server.SignOutputRaw(
+		ctx, signRequest(t, wire.OutPoint{Index: 2}, fundingSignDesc),
+	)
+	if err == nil {
+		t.Fatalf("expected spend of unknown outpoint to be refused")
+	}
+
+	// Base points may sign sweeps of outputs of the commitments signed for
+	// their channel to the wallet, while keys of other families may not
+	// sign at all.
+	baseSignDesc := func(
+		family keychain.KeyFamily) *lnwallet.SignDescriptor {
+
+		return &lnwallet.SignDescriptor{
+			KeyDesc: keychain.KeyDescriptor{
+				KeyLocator: keychain.KeyLocator{Family: family},
+			},
+			Output: &wire.TxOut{Value: 1000},
+		}
+	}
+	htlcSignDesc := baseSignDesc(keychain.KeyFamilyHtlcBase)
+	commitOut := wire.OutPoint{Hash: commitTx.TxHash(), Index: 1}
+	sweepTx := func(prevOut wire.OutPoint, pkScript []byte) *wire.MsgTx {
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(wire.NewTxIn(&prevOut, nil, nil))
+		tx.AddTxOut(&wire.TxOut{Value: 900, PkScript: pkScript})
+		return tx
+	}
+	_, err = server.SignOutputRaw(ctx, txSignRequest(
+		t, sweepTx(commitOut, walletScript(walletPath)), htlcSignDesc,
+		walletOutput(0),
+	))
+	if err != nil {
+		t.Fatalf("unable to sign htlc sweep: %v", err)
+	}
+
+	// Sweeps to foreign scripts, as well as sweeps of outputs that don't
+	// belong to a commitment of the channel, are refused.
+	_, err = server.SignOutputRaw(ctx, txSignRequest(
+		t, sweepTx(commitOut, []byte{0x00, 0x14, 0x03}), htlcSignDesc,
+	))
+	if err == nil || !strings.Contains(err.Error(), ErrSweepNotPaid.Error()) {
+		t.Fatalf("expected sweep to foreign script to be refused, "+
+			"got %v", err)
+	}
+	_, err = server.SignOutputRaw(ctx, txSignRequest(
+		t, sweepTx(wire.OutPoint{Index: 3}, walletScript(walletPath)),
+		htlcSignDesc, walletOutput(0),
+	))
+	if err == nil || !strings.Contains(err.Error(), ErrUnknownOutput.Error()) {
+		t.Fatalf("expected sweep of unknown output to be refused, "+
+			"got %v", err)
+	}
+
+	// The HTLC base point signs HTLC transactions, whose delayed output
+	// may then be swept with the delay base point.
+	htlcTx := sweepTx(commitOut, append(
+		[]byte{0x00, 0x20}, bytes.Repeat([]byte{0x05}, 32)...,
+	))
+	_, err = server.SignOutputRaw(
+		ctx, txSignRequest(t, htlcTx, htlcSignDesc),
+	)
+	if err != nil {
+		t.Fatalf("unable to sign htlc transaction: %v", err)
+	}
+	delaySignDesc := baseSignDesc(keychain.KeyFamilyDelayBase)
+	htlcOut := wire.OutPoint{Hash: htlcTx.TxHash()}
+	_, err = server.SignOutputRaw(ctx, txSignRequest(
+		t, sweepTx(htlcOut, walletScript(walletPath)), delaySignDesc,
+		walletOutput(0),
+	))
+	if err != nil {
+		t.Fatalf("unable to sign sweep of htlc transaction: %v", err)
+	}
+
+	// The delay base point doesn't sign HTLC transactions.
+	_, err = server.SignOutputRaw(
+		ctx, txSignRequest(t, htlcTx, delaySignDesc),
+	)
+	if err == nil || !strings.Contains(err.Error(), ErrSweepNotPaid.Error()) {
+		t.Fatalf("expected htlc transaction signed with delay base "+
+			"point to be refused, got %v", err)
+	}
+
+	// A base point that isn't used by a registered channel may not sign,
+	// nor may a base point sign as a key of another family.
+	unknownSignDesc := *htlcSignDesc
+	unknownSignDesc.KeyDesc.PubKey = remoteKey
+	_, err = server.SignOutputRaw(
+		ctx, signRequest(t, wire.OutPoint{Index: 3}, &unknownSignDesc),
+	)
+	if err == nil || !strings.Contains(err.Error(), ErrUnknownKey.Error()) {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+	otherFamilySignDesc := *htlcSignDesc
+	otherFamilySignDesc.KeyDesc.PubKey = channel.pubKey(
+		keychain.KeyFamilyPaymentBase,
+	)
+	_, err = server.SignOutputRaw(
+		ctx, signRequest(t, wire.OutPoint{Index: 3}, &otherFamilySignDesc),
+	)
+	if err == nil {
+		t.Fatalf("expected signing with payment base point as htlc " +
+			"base point to be refused")
+	}
+
+	nodeSignDesc := *htlcSignDesc
+	nodeSignDesc.KeyDesc.Family = keychain.KeyFamilyNodeKey
+	_, err = server.SignOutputRaw(
+		ctx, signRequest(t, wire.OutPoint{Index: 3}, &nodeSignDesc),
+	)
+	if err == nil {
+		t.Fatalf("expected signing with node key to be refused")
+	}
+
+	// Only the private key of the node key may be exported.
+	for _, family := range []keychain.KeyFamily{
+		keychain.KeyFamilyMultiSig, keychain.KeyFamilyRevocationRoot,
+	} {
+		_, err = server.DerivePrivKey(ctx, &remotesignerrpc.KeyDescriptor{
+			KeyLoc: &remotesignerrpc.KeyLocator{
+				KeyFamily: int32(family),
+			},
+		})
+		if err == nil {
+			t.Fatalf("expected export of key of family %v to be "+
+				"refused", family)
+		}
+	}
+	resp, err := server.DerivePrivKey(ctx, &remotesignerrpc.KeyDescriptor{
+		KeyLoc: &remotesignerrpc.KeyLocator{
+			KeyFamily: int32(keychain.KeyFamilyNodeKey),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to derive node key: %v", err)
+	}
+	if !bytes.Equal(resp.PrivKey, keyRing.keys[keychain.KeyFamilyNodeKey].Serialize()) {
+		t.Fatalf("unexpected node key")
+	}
+
+	// Messages may only be signed with the node key.
+	_, err = server.SignMessage(ctx, &remotesignerrpc.SignMessageRequest{
+		PubKey: localKey.SerializeCompressed(),
+		Msg:    []byte("msg"),
+	})
+	if err == nil {
+		t.Fatalf("expected signing with multi-sig key to be refused")
+	}
+	_, err = server.SignMessage(ctx, &remotesignerrpc.SignMessageRequest{
+		PubKey: remoteKey.SerializeCompressed(),
+		Msg:    []byte("msg"),
+	})
+	if err != nil {
+		t.Fatalf("unable to sign message: %v", err)
+	}
+}
+
+// TestServerRevocation asserts that the signer hands out the revocation
+// secrets of our commitments in order, and refuses to sign our commitments
+// once they have been revoked.
+func TestServerRevocation(t *testing.T) {
+	t.Parallel()
+
+	store, _, cleanUp := openTestStore(t)
+	defer cleanUp()
+	defer store.Close()
+
+	keyRing := newMockKeyRing()
+	server, err := NewServer(
+		&mockSigner{}, &mockSigner{}, &mockSigner{}, keyRing, store,
+	)
+	if err != nil {
+		t.Fatalf("unable to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	channel := newTestChannel(t, keyRing, server)
+	multiSigKey := channel.keyDesc(keychain.KeyFamilyMultiSig)
+	chanPoint := wire.OutPoint{Index: 1}
+
+	// The commitment points are available before the channel is
+	// registered, as the first one is exchanged while the channel is
+	// negotiated.
+	commitPoints := make([]*btcec.PublicKey, 3)
+	for height := range commitPoints {
+		resp, err := server.CommitmentPoint(
+			ctx, &remotesignerrpc.CommitmentPointRequest{
+				MultiSigKey: multiSigKey,
+				Height:      uint64(height),
+			},
+		)
+		if err != nil {
+			t.Fatalf("unable to fetch commitment point: %v", err)
+		}
+		commitPoints[height], err = btcec.ParsePubKey(
+			resp.CommitPoint, btcec.S256(),
+		)
+		if err != nil {
+			t.Fatalf("invalid commitment point: %v", err)
+		}
+	}
+
+	// commitTx creates our commitment transaction at the given height.
+	commitTx := func(height uint64) *wire.MsgTx {
+		return channel.commitTx(t, chanPoint, height, 50000, 40000)
+	}
+
+	// revokeWith revokes our commitment at the given height, replacing
+	// it with the passed commitment signed by the remote party.
+	revokeWith := func(height uint64, next *wire.MsgTx,
+		sig []byte) ([]byte, error) {
+
+		var rawNext bytes.Buffer
+		if err := next.Serialize(&rawNext); err != nil {
+			t.Fatalf("unable to serialize commitment: %v", err)
+		}
+
+		resp, err := server.RevokeCommitment(
+			ctx, &remotesignerrpc.RevokeCommitmentRequest{
+				MultiSigKey:   multiSigKey,
+				Height:        height,
+				NextCommitTx:  rawNext.Bytes(),
+				NextCommitSig: sig,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return resp.CommitSecret, nil
+	}
+	revoke := func(height uint64) ([]byte, error) {
+		next := commitTx(height + 1)
+		return revokeWith(height, next, channel.remoteSig(t, next, 100000))
+	}
+
+	// Commitments of unregistered channels can't be revoked.
+	if _, err := revoke(0); err == nil {
+		t.Fatalf("expected revocation of unregistered channel to fail")
+	}
+
+	_, err = server.RegisterChannel(
+		ctx, channel.registerRequest(t, chanPoint, 100000),
+	)
+	if err != nil {
+		t.Fatalf("unable to register channel: %v", err)
+	}
+
+	signCommit := func(tx *wire.MsgTx) error {
+		_, err := server.SignOutputRaw(ctx, txSignRequest(
+			t, tx, channel.fundingSignDesc(100000),
+		))
+		return err
+	}
+
+	// Before it is revoked, our first commitment is signed.
+	if err := signCommit(commitTx(0)); err != nil {
+		t.Fatalf("unable to sign commitment: %v", err)
+	}
+
+	// Commitments must be revoked in order.
+	if _, err := revoke(1); err == nil {
+		t.Fatalf("expected revocation out of order to fail")
+	}
+
+	// A commitment may only be revoked in favor of the next commitment,
+	// signed by the remote party.
+	next, later := commitTx(1), commitTx(2)
+	_, err = revokeWith(0, next, channel.remoteSig(t, later, 100000))
+	if err == nil || !strings.Contains(
+		err.Error(), ErrInvalidCommitment.Error(),
+	) {
+		t.Fatalf("expected invalid commitment error, got %v", err)
+	}
+	_, err = revokeWith(0, later, channel.remoteSig(t, later, 100000))
+	if err == nil || !strings.Contains(
+		err.Error(), ErrInvalidCommitment.Error(),
+	) {
+		t.Fatalf("expected invalid commitment error, got %v", err)
+	}
+
+	secret, err := revoke(0)
+	if err != nil {
+		t.Fatalf("unable to revoke commitment: %v", err)
+	}
+	if !lnwallet.ComputeCommitmentPoint(secret).IsEqual(commitPoints[0]) {
+		t.Fatalf("revocation secret doesn't match commitment point")
+	}
+
+	// The revocation may be re-sent.
+	resent, err := revoke(0)
+	if err != nil {
+		t.Fatalf("unable to re-send revocation: %v", err)
+	}
+	if !bytes.Equal(secret, resent) {
+		t.Fatalf("re-sent revocation secret doesn't match")
+	}
+
+	// Once revoked, our first commitment is no longer signed, while our
+	// next commitment is.
+	err = signCommit(commitTx(0))
+	if err == nil || !strings.Contains(
+		err.Error(), ErrRevokedCommitment.Error(),
+	) {
+		t.Fatalf("expected revoked commitment error, got %v", err)
+	}
+	if err := signCommit(commitTx(1)); err != nil {
+		t.Fatalf("unable to sign commitment: %v", err)
+	}
+
+	// A commitment of the remote party at a revoked height of ours doesn't
+	// carry our outputs, so it is signed.
+	remoteCommit := commitTx(0)
+	for _, txOut := range remoteCommit.TxOut {
+		txOut.PkScript = []byte{0x00, 0x14, 0x01}
+	}
+	if err := signCommit(remoteCommit); err != nil {
+		t.Fatalf("unable to sign remote commitment: %v", err)
+	}
+}
+
+// TestServerFundingPayout asserts that the signer only signs cooperative
+// closes and splices that pay our balance of the channel to us, taking the
+// balance from our latest commitment signed by the remote party.
+func TestServerFundingPayout(t *testing.T) {
+	t.Parallel()
+
+	store, _, cleanUp := openTestStore(t)
+	defer cleanUp()
+	defer store.Close()
+
+	keyRing := newMockKeyRing()
+	server, err := NewServer(
+		&mockSigner{}, &mockSigner{}, &mockSigner{}, keyRing, store,
+	)
+	if err != nil {
+		t.Fatalf("unable to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	channel := newTestChannel(t, keyRing, server)
+	chanPoint := wire.OutPoint{Index: 1}
+	shutdownScript := []byte{0x00, 0x14, 0x02}
+
+	req := channel.registerRequest(t, chanPoint, 100000)
+	req.LocalShutdownScript = shutdownScript
+	if _, err := server.RegisterChannel(ctx, req); err != nil {
+		t.Fatalf("unable to register channel: %v", err)
+	}
+
+	foreignScript := []byte{0x00, 0x14, 0x03}
+	remoteScript := []byte{0x00, 0x14, 0x04}
+	fundingScript, err := lnwallet.WitnessScriptHash(channel.witnessScript)
+	if err != nil {
+		t.Fatalf("unable to create funding script: %v", err)
+	}
+
+	// closeTx creates a cooperative close that pays the given amounts to
+	// the passed scripts.
+	closeTx := func(lockTime uint32, outputs ...*wire.TxOut) *wire.MsgTx {
+		tx := wire.NewMsgTx(2)
+		tx.LockTime = lockTime
+		tx.AddTxIn(wire.NewTxIn(&chanPoint, nil, nil))
+		for _, txOut := range outputs {
+			tx.AddTxOut(txOut)
+		}
+		return tx
+	}
+	sign := func(tx *wire.MsgTx,
+		walletOutputs ...*remotesignerrpc.WalletOutput) error {
+
+		_, err := server.SignOutputRaw(ctx, txSignRequest(
+			t, tx, channel.fundingSignDesc(100000), walletOutputs...,
+		))
+		return err
+	}
+	expectNotPaid := func(err error, desc string) {
+		t.Helper()
+		if err == nil || !strings.Contains(
+			err.Error(), ErrBalanceNotPaid.Error(),
+		) {
+			t.Fatalf("expected %v to be refused, got %v", desc, err)
+		}
+	}
+
+	// Our balance of the initial commitment is 50000. A cooperative close
+	// that pays it to a foreign script is refused, as well as any other
+	// spend of the funding output that isn't a commitment.
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 50000, PkScript: foreignScript},
+		&wire.TxOut{Value: 40000, PkScript: remoteScript},
+	))
+	expectNotPaid(err, "cooperative close to foreign script")
+
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 99000, PkScript: foreignScript},
+	))
+	expectNotPaid(err, "spend to foreign script")
+
+	// Claiming that a foreign output pays to the wallet doesn't help, as
+	// the signer derives the script of wallet outputs itself.
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 50000, PkScript: foreignScript},
+		&wire.TxOut{Value: 40000, PkScript: remoteScript},
+	), walletOutput(0))
+	if err == nil {
+		t.Fatalf("expected foreign output claimed as wallet output " +
+			"to be refused")
+	}
+
+	// Paying less than our balance to us is refused as well, beyond the
+	// fee we pay as the initiator of the channel.
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 30000, PkScript: shutdownScript},
+		&wire.TxOut{Value: 60000, PkScript: remoteScript},
+	))
+	expectNotPaid(err, "cooperative close paying less than our balance")
+
+	// The funding output must be spent on its own.
+	twoInputs := closeTx(0,
+		&wire.TxOut{Value: 50000, PkScript: shutdownScript},
+		&wire.TxOut{Value: 40000, PkScript: remoteScript},
+	)
+	twoInputs.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 9}, nil, nil))
+	expectNotPaid(sign(twoInputs), "spend with other inputs")
+
+	// A cooperative close paying our balance, less the fee, to our
+	// upfront shutdown script or to the wallet is signed.
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 49000, PkScript: shutdownScript},
+		&wire.TxOut{Value: 40000, PkScript: remoteScript},
+	))
+	if err != nil {
+		t.Fatalf("unable to sign cooperative close to shutdown "+
+			"script: %v", err)
+	}
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 40000, PkScript: remoteScript},
+		&wire.TxOut{Value: 49000, PkScript: walletScript(walletPath)},
+	), walletOutput(1))
+	if err != nil {
+		t.Fatalf("unable to sign cooperative close to wallet: %v", err)
+	}
+
+	// A splice that keeps our balance in the channel and pays out to the
+	// wallet is signed, while one paying out to a foreign script is not.
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 80000, PkScript: fundingScript},
+		&wire.TxOut{Value: 19000, PkScript: walletScript(walletPath)},
+	), walletOutput(1))
+	if err != nil {
+		t.Fatalf("unable to sign splice: %v", err)
+	}
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 80000, PkScript: fundingScript},
+		&wire.TxOut{Value: 19000, PkScript: foreignScript},
+	))
+	expectNotPaid(err, "splice to foreign script")
+
+	// Once our commitment is revoked in favor of one that pays us less,
+	// a cooperative close only needs to pay our new balance.
+	next := channel.commitTx(t, chanPoint, 1, 20000, 70000)
+	var rawNext bytes.Buffer
+	if err := next.Serialize(&rawNext); err != nil {
+		t.Fatalf("unable to serialize commitment: %v", err)
+	}
+	_, err = server.RevokeCommitment(
+		ctx, &remotesignerrpc.RevokeCommitmentRequest{
+			MultiSigKey:   channel.keyDesc(keychain.KeyFamilyMultiSig),
+			Height:        0,
+			NextCommitTx:  rawNext.Bytes(),
+			NextCommitSig: channel.remoteSig(t, next, 100000),
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to revoke commitment: %v", err)
+	}
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 20000, PkScript: shutdownScript},
+		&wire.TxOut{Value: 79000, PkScript: remoteScript},
+	))
+	if err != nil {
+		t.Fatalf("unable to sign cooperative close with new "+
+			"balance: %v", err)
+	}
+	err = sign(closeTx(0,
+		&wire.TxOut{Value: 10000, PkScript: shutdownScript},
+		&wire.TxOut{Value: 89000, PkScript: remoteScript},
+	))
+	expectNotPaid(err, "cooperative close paying less than new balance")
+}
+
+// TestServerWalletInputs asserts that the signer only signs wallet inputs with
+// keys of the on-chain wallet, and exports the watch-only wallet.
+func TestServerWalletInputs(t *testing.T) {
+	t.Parallel()
+
+	store, _, cleanUp := openTestStore(t)
+	defer cleanUp()
+	defer store.Close()
+
+	server, err := NewServer(
+		&mockSigner{}, &mockSigner{}, &mockSigner{}, newMockKeyRing(),
+		store,
+	)
+	if err != nil {
+		t.Fatalf("unable to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	inputScriptRequest := func(keyScope waddrmgr.KeyScope,
+		path waddrmgr.DerivationPath,
+		signDesc *lnwallet.SignDescriptor) *remotesignerrpc.InputScriptRequest {
+
+		req := signRequest(t, wire.OutPoint{Index: 1}, signDesc)
+		return &remotesignerrpc.InputScriptRequest{
+			RawTx:    req.RawTx,
+			SignDesc: req.SignDesc,
+			KeyPath: &remotesignerrpc.KeyPath{
+				Purpose:  keyScope.Purpose,
+				CoinType: keyScope.Coin,
+				Account:  path.Account,
+				Branch:   path.Branch,
+				Index:    path.Index,
+			},
+		}
+	}
+
+	signDesc := &lnwallet.SignDescriptor{
+		Output:   &wire.TxOut{Value: 100000, PkScript: []byte{0}},
+		HashType: txscript.SigHashAll,
+	}
+	walletPath := waddrmgr.DerivationPath{
+		Account: waddrmgr.DefaultAccountNum,
+		Branch:  waddrmgr.InternalBranch,
+		Index:   7,
+	}
+
+	// Inputs of both the native and the nested witness accounts of the
+	// wallet are signed.
+	for _, keyScope := range []waddrmgr.KeyScope{
+		waddrmgr.KeyScopeBIP0084, waddrmgr.KeyScopeBIP0049Plus,
+	} {
+		resp, err := server.ComputeInputScript(
+			ctx, inputScriptRequest(keyScope, walletPath, signDesc),
+		)
+		if err != nil {
+			t.Fatalf("unable to sign wallet input of scope %v: %v",
+				keyScope, err)
+		}
+		if len(resp.Witness) != 1 {
+			t.Fatalf("expected witness of the wallet signer")
+		}
+	}
+
+	// The keys of our channels share the seed with the wallet, so they
+	// must never be used to sign wallet inputs.
+	lightningScope := waddrmgr.KeyScope{
+		Purpose: keychain.BIP0043Purpose,
+		Coin:    0,
+	}
+	_, err = server.ComputeInputScript(
+		ctx, inputScriptRequest(lightningScope, walletPath, signDesc),
+	)
+	if err == nil || !strings.Contains(err.Error(),
+		ErrKeyPathNotAllowed.Error()) {
+
+		t.Fatalf("expected lightning key scope to be refused, got %v",
+			err)
+	}
+
+	otherAccount := walletPath
+	otherAccount.Account = 1
+	_, err = server.ComputeInputScript(ctx, inputScriptRequest(
+		waddrmgr.KeyScopeBIP0084, otherAccount, signDesc,
+	))
+	if err == nil {
+		t.Fatalf("expected other account to be refused")
+	}
+
+	otherBranch := walletPath
+	otherBranch.Branch = 2
+	_, err = server.ComputeInputScript(ctx, inputScriptRequest(
+		waddrmgr.KeyScopeBIP0084, otherBranch, signDesc,
+	))
+	if err == nil {
+		t.Fatalf("expected unknown branch to be refused")
+	}
+
+	tweakedDesc := *signDesc
+	tweakedDesc.SingleTweak = bytes.Repeat([]byte{1}, 32)
+	_, err = server.ComputeInputScript(ctx, inputScriptRequest(
+		waddrmgr.KeyScopeBIP0084, walletPath, &tweakedDesc,
+	))
+	if err == nil {
+		t.Fatalf("expected tweaked wallet key to be refused")
+	}
+
+	resp, err := server.ExportWatchOnlyWallet(
+		ctx, &remotesignerrpc.ExportWatchOnlyWalletRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unable to export watch-only wallet: %v", err)
+	}
+	if string(resp.WalletDb) != "watch-only" {
+		t.Fatalf("unexpected watch-only wallet %q", resp.WalletDb)
+	}
+}
@@ -0,0 +1,503 @@
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	// dbPermissions is the file mode of the database of the signer.
+	dbPermissions = 0600
+)
+
+var (
+	// channelsBucketKey is the key of the top level bucket that stores the
+	// funding outputs registered by the watch-only instance, keyed by
+	// their outpoint.
+	channelsBucketKey = []byte("remotesigner-channels")
+
+	// configsBucketKey is the key of the top level bucket that stores the
+	// configuration of each registered channel, keyed by our multi-sig
+	// key. As a splice keeps the keys of a channel, all funding outputs of
+	// a channel share the same configuration.
+	configsBucketKey = []byte("remotesigner-configs")
+
+	// keysBucketKey is the key of the top level bucket that maps each of
+	// our keys used by a registered channel to its key family, followed
+	// by the multi-sig key of the channel.
+	keysBucketKey = []byte("remotesigner-keys")
+
+	// revocationsBucketKey is the key of the top level bucket that stores
+	// the number of revoked commitments of each registered channel, keyed
+	// by our multi-sig key.
+	revocationsBucketKey = []byte("remotesigner-revocations")
+
+	// balancesBucketKey is the key of the top level bucket that stores the
+	// balances of our current commitment of each registered channel, keyed
+	// by our multi-sig key.
+	balancesBucketKey = []byte("remotesigner-balances")
+
+	// txsBucketKey is the key of the top level bucket that stores the
+	// txids of the commitment and HTLC transactions the signer signed for
+	// each registered channel, keyed by our multi-sig key followed by the
+	// txid. The base points only sign spends of their outputs.
+	txsBucketKey = []byte("remotesigner-txs")
+)
+
+// channelInfo is the state of a channel funding output that the signer
+// compares each spend of it against.
+type channelInfo struct {
+	capacity      btcutil.Amount
+	witnessScript []byte
+	multiSigKey   *btcec.PublicKey
+}
+
+// channelConfig is the configuration of a registered channel. It contains
+// everything the signer needs to recognize our commitment transactions.
+type channelConfig struct {
+	initiator bool
+	chanType  channeldb.ChannelType
+
+	multiSigKey         keychain.KeyDescriptor
+	revocationBasePoint keychain.KeyDescriptor
+	paymentBasePoint    keychain.KeyDescriptor
+	delayBasePoint      keychain.KeyDescriptor
+	htlcBasePoint       keychain.KeyDescriptor
+
+	csvDelay uint32
+
+	remoteRevocationBasePoint *btcec.PublicKey
+	remotePaymentBasePoint    *btcec.PublicKey
+
+	// localShutdownScript is our upfront shutdown script. It is empty if
+	// the channel doesn't commit to one.
+	localShutdownScript []byte
+}
+
+// channelBalance holds the balances of our current commitment of a channel,
+// which bound what a cooperative close or a splice must pay to us.
+type channelBalance struct {
+	// local is the value of our to_local output, and remote the value
+	// of the to_remote output of the remote party. Outputs below the dust
+	// limit count as zero.
+	local  btcutil.Amount
+	remote btcutil.Amount
+
+	// capacity is the capacity of the funding output spent by the
+	// commitment. A splice initiated by us deducts the difference to the
+	// capacity of a later funding output from our balance.
+	capacity btcutil.Amount
+}
+
+// keys returns all of our keys used by the channel.
+func (c *channelConfig) keys() []keychain.KeyDescriptor {
+	return []keychain.KeyDescriptor{
+		c.multiSigKey, c.revocationBasePoint, c.paymentBasePoint,
+		c.delayBasePoint, c.htlcBasePoint,
+	}
+}
+
+// Store persists the channels that the watch-only instance registered with
+// the signer, as well as the revocation state of our commitments, so that the
+// signer doesn't depend on the watch-only instance to learn about them again
+// after a restart.
+type Store struct {
+	db *bolt.DB
+}
+
+// OpenStore opens the signer database at the given path, creating it if it
+// doesn't exist yet.
+func OpenStore(dbPath string) (*Store, error) {
+	db, err := bolt.Open(dbPath, dbPermissions, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open signer database: %v",
+			err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, key := range [][]byte{
+			channelsBucketKey, configsBucketKey, keysBucketKey,
+			revocationsBucketKey, balancesBucketKey, txsBucketKey,
+		} {
+			if _, err := tx.CreateBucketIfNotExists(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the signer database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// putImmutable stores the value under the given key of the bucket. Storing
+// the same value again is a no-op, but a stored value must never change.
+func putImmutable(bucket *bolt.Bucket, k, v []byte, name string) error {
+	stored := bucket.Get(k)
+	if stored == nil {
+		return bucket.Put(k, v)
+	}
+
+	if !bytes.Equal(stored, v) {
+		return fmt.Errorf("%v is already registered with a different "+
+			"state", name)
+	}
+
+	return nil
+}
+
+// putChannel stores the funding output with the given outpoint along with the
+// configuration of its channel. A channel that is already registered can't be
+// changed. The passed balance is only stored for a new channel, the balance
+// of a registered channel is updated as its commitments are revoked.
+func (s *Store) putChannel(chanPoint wire.OutPoint, info *channelInfo,
+	cfg *channelConfig, balance *channelBalance) error {
+
+	var k bytes.Buffer
+	if err := channeldb.WriteElement(&k, chanPoint); err != nil {
+		return err
+	}
+
+	var v bytes.Buffer
+	err := channeldb.WriteElements(
+		&v, info.capacity, info.witnessScript, info.multiSigKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	var c bytes.Buffer
+	err = channeldb.WriteElements(
+		&c, cfg.initiator, cfg.chanType, cfg.multiSigKey,
+		cfg.revocationBasePoint, cfg.paymentBasePoint,
+		cfg.delayBasePoint, cfg.htlcBasePoint, cfg.csvDelay,
+		cfg.remoteRevocationBasePoint, cfg.remotePaymentBasePoint,
+		cfg.localShutdownScript,
+	)
+	if err != nil {
+		return err
+	}
+
+	multiSigKey := info.multiSigKey.SerializeCompressed()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		err := putImmutable(
+			tx.Bucket(channelsBucketKey), k.Bytes(), v.Bytes(),
+			fmt.Sprintf("channel %v", chanPoint),
+		)
+		if err != nil {
+			return err
+		}
+
+		// All funding outputs of a channel, such as the ones created
+		// by splices, must share the same configuration.
+		err = putImmutable(
+			tx.Bucket(configsBucketKey), multiSigKey, c.Bytes(),
+			fmt.Sprintf("channel %v", chanPoint),
+		)
+		if err != nil {
+			return err
+		}
+
+		// Each of our keys may only be used for the purpose and the
+		// channel it has been registered with.
+		keys := tx.Bucket(keysBucketKey)
+		for _, keyDesc := range cfg.keys() {
+			var owner [4]byte
+			binary.BigEndian.PutUint32(
+				owner[:], uint32(keyDesc.Family),
+			)
+
+			err := putImmutable(
+				keys, keyDesc.PubKey.SerializeCompressed(),
+				append(owner[:], multiSigKey...),
+				fmt.Sprintf("key %x",
+					keyDesc.PubKey.SerializeCompressed()),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		// A channel starts without any revoked commitments and with
+		// the balance of its initial commitment. A splice continues
+		// with the revocation state and the balance of the channel.
+		revocations := tx.Bucket(revocationsBucketKey)
+		if revocations.Get(multiSigKey) != nil {
+			return nil
+		}
+
+		if balance == nil {
+			return fmt.Errorf("%v: channel %v is registered "+
+				"without its initial commitment",
+				ErrInvalidCommitment, chanPoint)
+		}
+
+		var b bytes.Buffer
+		if err := writeBalance(&b, balance); err != nil {
+			return err
+		}
+
+		var revoked [8]byte
+		if err := revocations.Put(multiSigKey, revoked[:]); err != nil {
+			return err
+		}
+
+		return tx.Bucket(balancesBucketKey).Put(multiSigKey, b.Bytes())
+	})
+}
+
+// writeBalance serializes the passed channel balance.
+func writeBalance(w io.Writer, balance *channelBalance) error {
+	return channeldb.WriteElements(
+		w, balance.local, balance.remote, balance.capacity,
+	)
+}
+
+// readBalance deserializes a channel balance.
+func readBalance(r io.Reader) (*channelBalance, error) {
+	balance := &channelBalance{}
+	err := channeldb.ReadElements(
+		r, &balance.local, &balance.remote, &balance.capacity,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// fetchChannel returns the state of the funding output with the given
+// outpoint. ErrUnknownChannel is returned if it hasn't been registered.
+func (s *Store) fetchChannel(chanPoint wire.OutPoint) (*channelInfo, error) {
+	var k bytes.Buffer
+	if err := channeldb.WriteElement(&k, chanPoint); err != nil {
+		return nil, err
+	}
+
+	info := &channelInfo{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(channelsBucketKey).Get(k.Bytes())
+		if v == nil {
+			return fmt.Errorf("%v: %v", ErrUnknownChannel,
+				chanPoint)
+		}
+
+		return channeldb.ReadElements(
+			bytes.NewReader(v), &info.capacity, &info.witnessScript,
+			&info.multiSigKey,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// fetchConfig returns the configuration of the channel that uses the given
+// multi-sig key. ErrUnknownChannel is returned if it hasn't been registered.
+func (s *Store) fetchConfig(
+	multiSigKey *btcec.PublicKey) (*channelConfig, error) {
+
+	cfg := &channelConfig{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(configsBucketKey).Get(
+			multiSigKey.SerializeCompressed(),
+		)
+		if v == nil {
+			return fmt.Errorf("%v: multi-sig key %x",
+				ErrUnknownChannel,
+				multiSigKey.SerializeCompressed())
+		}
+
+		return channeldb.ReadElements(
+			bytes.NewReader(v), &cfg.initiator, &cfg.chanType,
+			&cfg.multiSigKey, &cfg.revocationBasePoint,
+			&cfg.paymentBasePoint, &cfg.delayBasePoint,
+			&cfg.htlcBasePoint, &cfg.csvDelay,
+			&cfg.remoteRevocationBasePoint,
+			&cfg.remotePaymentBasePoint, &cfg.localShutdownScript,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// fetchKeyOwner returns the key family the given key has been registered
+// with, along with the multi-sig key of the channel that uses it.
+// ErrUnknownKey is returned if the key isn't used by any registered channel.
+func (s *Store) fetchKeyOwner(pubKey *btcec.PublicKey) (keychain.KeyFamily,
+	*btcec.PublicKey, error) {
+
+	var (
+		family      keychain.KeyFamily
+		multiSigKey *btcec.PublicKey
+	)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(keysBucketKey).Get(pubKey.SerializeCompressed())
+		if v == nil {
+			return fmt.Errorf("%v: %x", ErrUnknownKey,
+				pubKey.SerializeCompressed())
+		}
+
+		family = keychain.KeyFamily(binary.BigEndian.Uint32(v[:4]))
+
+		var err error
+		multiSigKey, err = btcec.ParsePubKey(v[4:], btcec.S256())
+		return err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return family, multiSigKey, nil
+}
+
+// txKey returns the key of the given transaction of the channel that uses the
+// passed multi-sig key within the transactions bucket.
+func txKey(multiSigKey *btcec.PublicKey, txid chainhash.Hash) []byte {
+	return append(multiSigKey.SerializeCompressed(), txid[:]...)
+}
+
+// putTx records that the signer signed the commitment or HTLC transaction
+// with the given txid of the channel that uses the passed multi-sig key.
+func (s *Store) putTx(multiSigKey *btcec.PublicKey,
+	txid chainhash.Hash) error {
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(txsBucketKey).Put(
+			txKey(multiSigKey, txid), []byte{1},
+		)
+	})
+}
+
+// hasTx returns true if the signer signed the commitment or HTLC transaction
+// with the given txid of the channel that uses the passed multi-sig key.
+func (s *Store) hasTx(multiSigKey *btcec.PublicKey,
+	txid chainhash.Hash) (bool, error) {
+
+	var known bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		known = tx.Bucket(txsBucketKey).Get(
+			txKey(multiSigKey, txid),
+		) != nil
+		return nil
+	})
+
+	return known, err
+}
+
+// fetchRevoked returns the number of revoked commitments of the channel that
+// uses the given multi-sig key. All of our commitments below this height have
+// been revoked.
+func (s *Store) fetchRevoked(multiSigKey *btcec.PublicKey) (uint64, error) {
+	var revoked uint64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(revocationsBucketKey).Get(
+			multiSigKey.SerializeCompressed(),
+		)
+		if v == nil {
+			return fmt.Errorf("%v: multi-sig key %x",
+				ErrUnknownChannel,
+				multiSigKey.SerializeCompressed())
+		}
+
+		revoked = binary.BigEndian.Uint64(v)
+		return nil
+	})
+
+	return revoked, err
+}
+
+// fetchBalance returns the balance of our current commitment of the channel
+// that uses the given multi-sig key.
+func (s *Store) fetchBalance(
+	multiSigKey *btcec.PublicKey) (*channelBalance, error) {
+
+	var balance *channelBalance
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(balancesBucketKey).Get(
+			multiSigKey.SerializeCompressed(),
+		)
+		if v == nil {
+			return fmt.Errorf("%v: multi-sig key %x",
+				ErrUnknownChannel,
+				multiSigKey.SerializeCompressed())
+		}
+
+		var err error
+		balance, err = readBalance(bytes.NewReader(v))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// revoke marks our commitment at the given height of the channel that uses
+// the given multi-sig key as revoked, and stores the balance of the next
+// commitment that replaces it. Commitments are revoked in order, so only the
+// lowest unrevoked commitment can be revoked. Revoking a commitment again is
+// allowed, so that the revocation can be re-sent.
+func (s *Store) revoke(multiSigKey *btcec.PublicKey, height uint64,
+	nextBalance *channelBalance) error {
+
+	var b bytes.Buffer
+	if err := writeBalance(&b, nextBalance); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		revocations := tx.Bucket(revocationsBucketKey)
+
+		k := multiSigKey.SerializeCompressed()
+		v := revocations.Get(k)
+		if v == nil {
+			return fmt.Errorf("%v: multi-sig key %x",
+				ErrUnknownChannel, k)
+		}
+
+		revoked := binary.BigEndian.Uint64(v)
+		switch {
+		case height < revoked:
+			return nil
+
+		case height > revoked:
+			return fmt.Errorf("unable to revoke commitment %v "+
+				"before commitment %v", height, revoked)
+		}
+
+		var newRevoked [8]byte
+		binary.BigEndian.PutUint64(newRevoked[:], height+1)
+		if err := revocations.Put(k, newRevoked[:]); err != nil {
+			return err
+		}
+
+		return tx.Bucket(balancesBucketKey).Put(k, b.Bytes())
+	})
+}
@@ -0,0 +1,73 @@
+package remotesigner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// loadPeerCertPool returns a certificate pool that only contains the pinned
+// TLS certificate of the other lnd instance.
+func loadPeerCertPool(peerCertPath string) (*x509.CertPool, error) {
+	peerCert, err := ioutil.ReadFile(peerCertPath)
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(peerCert) {
+		return nil, fmt.Errorf("unable to parse peer certificate %v",
+			peerCertPath)
+	}
+
+	return certPool, nil
+}
+
+// ServerCredentials returns the transport credentials of the signer-only
+// instance. The signer authenticates itself with its own TLS certificate and
+// only accepts clients that present the pinned certificate of the watch-only
+// instance.
+func ServerCredentials(certPath, keyPath,
+	peerCertPath string) (credentials.TransportCredentials, error) {
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, err
+	}
+	clientCAs, err := loadPeerCertPool(peerCertPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// ClientCredentials returns the transport credentials of the watch-only
+// instance. The client authenticates itself with its own TLS certificate and
+// only trusts the pinned certificate of the signer-only instance.
+func ClientCredentials(certPath, keyPath,
+	peerCertPath string) (credentials.TransportCredentials, error) {
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, err
+	}
+	rootCAs, err := loadPeerCertPool(peerCertPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
@@ -0,0 +1,609 @@
+package chainview
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/electrum"
+)
+
+// electrumReorgSafetyLimit is the number of recent block hashes kept to
+// detect reorgs of blocks we've already connected.
+const electrumReorgSafetyLimit = 100
+
+// ElectrumFilteredChainView is an implementation of the FilteredChainView
+// interface which is backed by an Electrum server. As the server can't filter
+// blocks for us, the funding scripts of all watched outpoints are watched at
+// the server instead. Whenever a new block is connected, the histories of the
+// scripts whose status changed since the last block are consulted for spends
+// of the watched outpoints.
+//
+// NOTE: The server notifies us of new blocks and status changes
+// independently, so a spend may only be reported with the block following the
+// one it was confirmed in. As spends can't be undone, this is safe for the
+// purpose of pruning the channel graph.
+type ElectrumFilteredChainView struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	// bestHeight is the height of the latest block added to the
+	// blockQueue. It is used to determine up to what height we would need
+	// to rescan in case of a filter update.
+	bestHeightMtx sync.Mutex
+	bestHeight    uint32
+
+	// blockHashes are the hashes of the recently connected blocks, which
+	// are used to detect reorgs.
+	blockHashes map[uint32]chainhash.Hash
+
+	client        *electrum.Client
+	headerSub     *electrum.HeaderSubscription
+	scriptHashSub *electrum.ScriptHashSubscription
+
+	// blockEventQueue is the ordered queue used to keep the order
+	// of connected and disconnected blocks sent to the reader of the
+	// chainView.
+	blockQueue *blockEventQueue
+
+	// filterUpdates is a channel in which updates to the utxo filter
+	// attached to this instance are sent over.
+	filterUpdates chan electrumFilterUpdate
+
+	// chainFilter is the set of utox's that we're currently watching
+	// spends for within the chain, along with their funding scripts.
+	filterMtx   sync.RWMutex
+	chainFilter map[wire.OutPoint][]byte
+
+	// scriptHashes is the set of script hashes watched at the server.
+	scriptHashes map[string]struct{}
+
+	// histories caches the histories of the watched script hashes. A
+	// history is evicted whenever the status of its script hash changes.
+	histories map[string][]electrum.HistoryEntry
+
+	// dirty is the set of script hashes whose status changed since the
+	// last block was connected.
+	dirty map[string]struct{}
+
+	// filterBlockReqs is a channel in which requests to filter select
+	// blocks will be sent over.
+	filterBlockReqs chan *filterBlockReq
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile time check to ensure ElectrumFilteredChainView implements the
+// chainview.FilteredChainView.
+var _ FilteredChainView = (*ElectrumFilteredChainView)(nil)
+
+// NewElectrumFilteredChainView creates a new instance of a FilteredChainView
+// backed by the given Electrum client.
+//
+// NOTE: The passed client should already be running before being passed into
+// this function.
+func NewElectrumFilteredChainView(
+	client *electrum.Client) (*ElectrumFilteredChainView, error) {
+
+	return &ElectrumFilteredChainView{
+		blockHashes:     make(map[uint32]chainhash.Hash),
+		client:          client,
+		blockQueue:      newBlockEventQueue(),
+		filterUpdates:   make(chan electrumFilterUpdate),
+		chainFilter:     make(map[wire.OutPoint][]byte),
+		scriptHashes:    make(map[string]struct{}),
+		histories:       make(map[string][]electrum.HistoryEntry),
+		dirty:           make(map[string]struct{}),
+		filterBlockReqs: make(chan *filterBlockReq),
+		quit:            make(chan struct{}),
+	}, nil
+}
+
+// Start starts all goroutines necessary for normal operation.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (e *ElectrumFilteredChainView) Start() error {
+	// Already started?
+	if atomic.AddInt32(&e.started, 1) != 1 {
+		return nil
+	}
+
+	log.Infof("FilteredChainView starting")
+
+	// We'll subscribe to new blocks before fetching our starting point,
+	// so we won't miss any block connected in between.
+	e.headerSub = e.client.SubscribeHeaders()
+	e.scriptHashSub = e.client.SubscribeScriptHashUpdates()
+
+	bestHash, bestHeight, err := e.client.GetBestBlock()
+	if err != nil {
+		e.headerSub.Cancel()
+		e.scriptHashSub.Cancel()
+		return err
+	}
+
+	e.bestHeightMtx.Lock()
+	e.bestHeight = uint32(bestHeight)
+	e.bestHeightMtx.Unlock()
+	e.blockHashes[uint32(bestHeight)] = *bestHash
+
+	e.blockQueue.Start()
+
+	e.wg.Add(1)
+	go e.chainFilterer()
+
+	return nil
+}
+
+// Stop stops all goroutines which we launched by the prior call to the Start
+// method.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (e *ElectrumFilteredChainView) Stop() error {
+	// Already shutting down?
+	if atomic.AddInt32(&e.stopped, 1) != 1 {
+		return nil
+	}
+
+	e.blockQueue.Stop()
+
+	log.Infof("FilteredChainView stopping")
+
+	close(e.quit)
+	e.wg.Wait()
+
+	e.headerSub.Cancel()
+	e.scriptHashSub.Cancel()
+
+	return nil
+}
+
+// FilterBlock takes a block hash, and returns a FilteredBlocks which is the
+// result of applying the current registered UTXO sub-set on the block
+// corresponding to that block hash. If any watched UTOX's are spent by the
+// selected lock, then the internal chainFilter will also be updated.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (e *ElectrumFilteredChainView) FilterBlock(
+	blockHash *chainhash.Hash) (*FilteredBlock, error) {
+
+	req := &filterBlockReq{
+		blockHash: blockHash,
+		resp:      make(chan *FilteredBlock, 1),
+		err:       make(chan error, 1),
+	}
+
+	select {
+	case e.filterBlockReqs <- req:
+	case <-e.quit:
+		return nil, fmt.Errorf("FilteredChainView shutting down")
+	}
+
+	return <-req.resp, <-req.err
+}
+
+// chainFilterer is the primary goroutine which: listens for new blocks coming
+// and dispatches the relevant FilteredBlock notifications, updates the filter
+// due to requests by callers, and finally is able to preform targeted block
+// filtration.
+func (e *ElectrumFilteredChainView) chainFilterer() {
+	defer e.wg.Done()
+
+	for {
+		select {
+		// The status of a watched script changed, so we'll evict its
+		// history and check it for spends once the next block is
+		// connected.
+		case item := <-e.scriptHashSub.Updates():
+			update := item.(*electrum.ScriptHashUpdate)
+			if _, ok := e.scriptHashes[update.ScriptHash]; !ok {
+				continue
+			}
+
+			delete(e.histories, update.ScriptHash)
+			e.dirty[update.ScriptHash] = struct{}{}
+
+		case item := <-e.headerSub.Updates():
+			update := item.(*electrum.TipUpdate)
+			if err := e.handleTipUpdate(update); err != nil {
+				log.Errorf("Unable to handle new chain tip %v: "+
+					"%v", update.Header.BlockHash(), err)
+			}
+
+		// The caller has just sent an update to the current chain
+		// filter, so we'll apply the update, possibly rewinding our
+		// state partially.
+		case update := <-e.filterUpdates:
+			if err := e.handleFilterUpdate(update); err != nil {
+				log.Errorf("Unable to update chain filter: %v",
+					err)
+			}
+
+		// We've received a new request to manually filter a block.
+		case req := <-e.filterBlockReqs:
+			height, err := e.client.BlockHeight(req.blockHash)
+			if err != nil {
+				req.err <- err
+				req.resp <- nil
+				continue
+			}
+
+			scriptHashes := make([]string, 0, len(e.scriptHashes))
+			for scriptHash := range e.scriptHashes {
+				scriptHashes = append(scriptHashes, scriptHash)
+			}
+			txns, err := e.spendsAtHeights(
+				scriptHashes, height, height,
+			)
+			if err != nil {
+				req.err <- err
+				req.resp <- nil
+				continue
+			}
+
+			req.resp <- &FilteredBlock{
+				Hash:         *req.blockHash,
+				Height:       uint32(height),
+				Transactions: txns[height],
+			}
+			req.err <- nil
+
+		case <-e.quit:
+			return
+		}
+	}
+}
+
+// handleTipUpdate processes a new chain tip announced by the server. Blocks
+// that are no longer part of the main chain are disconnected, after which all
+// blocks up to the new tip are connected.
+func (e *ElectrumFilteredChainView) handleTipUpdate(
+	update *electrum.TipUpdate) error {
+
+	e.bestHeightMtx.Lock()
+	bestHeight := e.bestHeight
+	e.bestHeightMtx.Unlock()
+
+	// First, we'll find the most recent block we've connected that's
+	// still part of the main chain.
+	tipHeight := uint32(update.Height)
+	forkHeight := bestHeight
+	if tipHeight-1 < forkHeight {
+		forkHeight = tipHeight - 1
+	}
+	for ; forkHeight > 0; forkHeight-- {
+		hash, ok := e.blockHashes[forkHeight]
+		if !ok {
+			break
+		}
+
+		if forkHeight == tipHeight-1 && update.Header.PrevBlock == hash {
+			break
+		}
+
+		mainHash, err := e.client.GetBlockHash(int64(forkHeight))
+		if err != nil {
+			return err
+		}
+		if *mainHash == hash {
+			break
+		}
+	}
+
+	for height := bestHeight; height > forkHeight; height-- {
+		hash := e.blockHashes[height]
+		log.Debugf("got disconnected block at height %d: %v", height,
+			hash)
+
+		e.blockQueue.Add(&blockEvent{
+			eventType: disconnected,
+			block: &FilteredBlock{
+				Hash:   hash,
+				Height: height,
+			},
+		})
+		delete(e.blockHashes, height)
+
+		e.bestHeightMtx.Lock()
+		e.bestHeight = height - 1
+		e.bestHeightMtx.Unlock()
+	}
+
+	if tipHeight <= forkHeight {
+		return nil
+	}
+
+	// Next, we'll look up the spends of our watched outpoints within the
+	// new blocks. Spends confirmed in blocks we've already connected are
+	// reported with the first new block.
+	scriptHashes := make([]string, 0, len(e.dirty))
+	for scriptHash := range e.dirty {
+		scriptHashes = append(scriptHashes, scriptHash)
+	}
+	txns, err := e.spendsAtHeights(scriptHashes, 1, update.Height)
+	if err != nil {
+		return err
+	}
+	e.dirty = make(map[string]struct{})
+	for height, heightTxns := range txns {
+		if uint32(height) <= forkHeight {
+			txns[int32(forkHeight+1)] = append(
+				heightTxns, txns[int32(forkHeight+1)]...,
+			)
+		}
+	}
+
+	for height := forkHeight + 1; height <= tipHeight; height++ {
+		var hash chainhash.Hash
+		if height == tipHeight {
+			hash = update.Header.BlockHash()
+		} else {
+			blockHash, err := e.client.GetBlockHash(int64(height))
+			if err != nil {
+				return err
+			}
+			hash = *blockHash
+		}
+
+		e.blockHashes[height] = hash
+		delete(e.blockHashes, height-electrumReorgSafetyLimit)
+
+		// We record the height of the last connected block added to
+		// the blockQueue such that we can scan up to this height in
+		// case of a rescan. It must be protected by a mutex since a
+		// filter update might be trying to read it concurrently.
+		e.bestHeightMtx.Lock()
+		e.bestHeight = height
+		e.bestHeightMtx.Unlock()
+
+		e.blockQueue.Add(&blockEvent{
+			eventType: connected,
+			block: &FilteredBlock{
+				Hash:         hash,
+				Height:       height,
+				Transactions: txns[int32(height)],
+			},
+		})
+	}
+
+	return nil
+}
+
+// handleFilterUpdate adds the outpoints of the given update to the chain
+// filter, and rescans the blocks after the update height for their spends.
+func (e *ElectrumFilteredChainView) handleFilterUpdate(
+	update electrumFilterUpdate) error {
+
+	// First, we'll add all the new UTXO's to the set of watched UTXO's,
+	// eliminating any duplicates in the process.
+	log.Debugf("Updating chain filter with new UTXO's: %v", update.ops)
+
+	var newScriptHashes []string
+	e.filterMtx.Lock()
+	for _, op := range update.ops {
+		e.chainFilter[op.OutPoint] = op.FundingPkScript
+
+		scriptHash := electrum.ScriptHash(op.FundingPkScript)
+		if _, ok := e.scriptHashes[scriptHash]; ok {
+			continue
+		}
+		e.scriptHashes[scriptHash] = struct{}{}
+		newScriptHashes = append(newScriptHashes, scriptHash)
+	}
+	e.filterMtx.Unlock()
+
+	// We'll watch the new scripts at the server, so we'll be notified of
+	// any future spends. As a spend may already have happened by the time
+	// the subscription is in place, which the server won't notify us of,
+	// we'll also check the new scripts once the next block is connected.
+	for _, scriptHash := range newScriptHashes {
+		e.dirty[scriptHash] = struct{}{}
+	}
+	if _, err := e.client.WatchScriptHashes(newScriptHashes); err != nil {
+		return err
+	}
+
+	e.bestHeightMtx.Lock()
+	bestHeight := e.bestHeight
+	e.bestHeightMtx.Unlock()
+
+	// If the update height matches our best known height, then we don't
+	// need to do any rewinding.
+	if update.updateHeight >= bestHeight {
+		return nil
+	}
+
+	// Otherwise, we'll rewind the state to ensure the caller doesn't miss
+	// any relevant notifications, by dispatching the blocks after the
+	// update height that spend any of the new outpoints.
+	txns, err := e.spendsAtHeights(
+		newScriptHashes, int32(update.updateHeight+1),
+		int32(bestHeight),
+	)
+	if err != nil {
+		return err
+	}
+
+	heights := make([]int32, 0, len(txns))
+	for height := range txns {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	for _, height := range heights {
+		blockHash, err := e.client.GetBlockHash(int64(height))
+		if err != nil {
+			log.Warnf("Unable to get block hash for block at "+
+				"height %d: %v", height, err)
+			continue
+		}
+
+		e.blockQueue.Add(&blockEvent{
+			eventType: connected,
+			block: &FilteredBlock{
+				Hash:         *blockHash,
+				Height:       uint32(height),
+				Transactions: txns[height],
+			},
+		})
+	}
+
+	return nil
+}
+
+// spendsAtHeights returns the transactions spending any of the watched
+// outpoints that were confirmed within the given range of heights, as found
+// in the histories of the given script hashes. The transactions are keyed by
+// the height of their block, and sorted by their position within it. Any
+// spent outpoints are removed from the chain filter.
+func (e *ElectrumFilteredChainView) spendsAtHeights(scriptHashes []string,
+	startHeight, endHeight int32) (map[int32][]*wire.MsgTx, error) {
+
+	heights := make(map[chainhash.Hash]int32)
+	for _, scriptHash := range scriptHashes {
+		history, ok := e.histories[scriptHash]
+		if !ok {
+			var err error
+			history, err = e.client.ScriptHashHistory(scriptHash)
+			if err != nil {
+				return nil, err
+			}
+			e.histories[scriptHash] = history
+		}
+
+		for _, entry := range history {
+			if entry.Height < startHeight || entry.Height > endHeight {
+				continue
+			}
+
+			txid, err := chainhash.NewHashFromStr(entry.TxHash)
+			if err != nil {
+				return nil, err
+			}
+			heights[*txid] = entry.Height
+		}
+	}
+
+	type positionedTx struct {
+		tx  *wire.MsgTx
+		pos uint32
+	}
+	positioned := make(map[int32][]positionedTx)
+	for txid, height := range heights {
+		txid := txid
+
+		tx, err := e.client.GetTransaction(&txid)
+		if err != nil {
+			return nil, err
+		}
+		if !e.spendsWatchedOutPoint(tx) {
+			continue
+		}
+
+		pos, err := e.client.TxPosition(&txid, height)
+		if err != nil {
+			return nil, err
+		}
+		positioned[height] = append(positioned[height], positionedTx{
+			tx:  tx,
+			pos: pos,
+		})
+	}
+
+	txns := make(map[int32][]*wire.MsgTx, len(positioned))
+	for height, blockTxns := range positioned {
+		blockTxns := blockTxns
+		sort.Slice(blockTxns, func(i, j int) bool {
+			return blockTxns[i].pos < blockTxns[j].pos
+		})
+
+		for _, tx := range blockTxns {
+			txns[height] = append(txns[height], tx.tx)
+		}
+	}
+
+	return txns, nil
+}
+
+// spendsWatchedOutPoint returns whether the given transaction spends any of
+// the watched outpoints. If so, the spent outpoints are removed from the
+// chain filter.
+func (e *ElectrumFilteredChainView) spendsWatchedOutPoint(tx *wire.MsgTx) bool {
+	e.filterMtx.Lock()
+	defer e.filterMtx.Unlock()
+
+	var spends bool
+	for _, txIn := range tx.TxIn {
+		prevOp := txIn.PreviousOutPoint
+		if _, ok := e.chainFilter[prevOp]; !ok {
+			continue
+		}
+
+		// We can delete this outpoint from the chainFilter, as we
+		// just found a transaction spending it. In case of a reorg,
+		// this outpoint might get "un-spent", but that's okay since
+		// it would never be wise to consider the channel open again
+		// (since a spending transaction exists on the network).
+		delete(e.chainFilter, prevOp)
+		spends = true
+	}
+
+	return spends
+}
+
+// electrumFilterUpdate is a message sent to the chainFilterer to update the
+// current chainFilter state. Unlike other backends, we need the funding
+// scripts of the outpoints to watch them.
+type electrumFilterUpdate struct {
+	ops          []channeldb.EdgePoint
+	updateHeight uint32
+}
+
+// UpdateFilter updates the UTXO filter which is to be consulted when creating
+// FilteredBlocks to be sent to subscribed clients. This method is cumulative
+// meaning repeated calls to this method should _expand_ the size of the UTXO
+// sub-set currently being watched.  If the set updateHeight is _lower_ than
+// the best known height of the implementation, then the state should be
+// rewound to ensure all relevant notifications are dispatched.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (e *ElectrumFilteredChainView) UpdateFilter(ops []channeldb.EdgePoint,
+	updateHeight uint32) error {
+
+	select {
+	case e.filterUpdates <- electrumFilterUpdate{
+		ops:          ops,
+		updateHeight: updateHeight,
+	}:
+		return nil
+
+	case <-e.quit:
+		return fmt.Errorf("chain filter shutting down")
+	}
+}
+
+// FilteredBlocks returns the channel that filtered blocks are to be sent over.
+// Each time a block is connected to the end of a main chain, and appropriate
+// FilteredBlock which contains the transactions which mutate our watched UTXO
+// set is to be returned.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (e *ElectrumFilteredChainView) FilteredBlocks() <-chan *FilteredBlock {
+	return e.blockQueue.newBlocks
+}
+
+// DisconnectedBlocks returns a receive only channel which will be sent upon
+// with the empty filtered blocks of blocks which are disconnected from the
+// main chain in the case of a re-org.
+//
+// NOTE: This is part of the FilteredChainView interface.
+func (e *ElectrumFilteredChainView) DisconnectedBlocks() <-chan *FilteredBlock {
+	return e.blockQueue.staleBlocks
+}
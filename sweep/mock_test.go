@@ -0,0 +1,286 @@
+package sweep
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+var (
+	defaultTestTimeout = 5 * time.Second
+)
+
+// mockSigner is a signer that returns a fixed dummy signature.
+type mockSigner struct{}
+
+func (m *mockSigner) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	return []byte{}, nil
+}
+
+func (m *mockSigner) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	return &lnwallet.InputScript{}, nil
+}
+
+// mockChainIO implements the lnwallet.BlockChainIO interface, returning a
+// fixed best height.
+type mockChainIO struct {
+	bestHeight int32
+}
+
+func (m *mockChainIO) GetBestBlock() (*chainhash.Hash, int32, error) {
+	return nil, m.bestHeight, nil
+}
+
+func (m *mockChainIO) GetUtxo(op *wire.OutPoint, pkScript []byte,
+	heightHint uint32) (*wire.TxOut, error) {
+
+	return nil, nil
+}
+
+func (m *mockChainIO) GetBlockHash(blockHeight int64) (*chainhash.Hash,
+	error) {
+
+	return nil, nil
+}
+
+func (m *mockChainIO) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock,
+	error) {
+
+	return nil, nil
+}
+
+// mockNotifier delivers block epochs and spends that are triggered by the
+// test.
+type mockNotifier struct {
+	mtx        sync.Mutex
+	epochChan  chan *chainntnfs.BlockEpoch
+	spendChans map[wire.OutPoint][]chan *chainntnfs.SpendDetail
+	spends     map[wire.OutPoint]*chainntnfs.SpendDetail
+}
+
+func newMockNotifier() *mockNotifier {
+	return &mockNotifier{
+		epochChan:  make(chan *chainntnfs.BlockEpoch),
+		spendChans: make(map[wire.OutPoint][]chan *chainntnfs.SpendDetail),
+		spends:     make(map[wire.OutPoint]*chainntnfs.SpendDetail),
+	}
+}
+
+func (m *mockNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	_ []byte, numConfs, heightHint uint32) (*chainntnfs.ConfirmationEvent,
+	error) {
+
+	return &chainntnfs.ConfirmationEvent{
+		Confirmed: make(chan *chainntnfs.TxConfirmation),
+	}, nil
+}
+
+func (m *mockNotifier) RegisterBlockEpochNtfn(
+	_ *chainntnfs.BlockEpoch) (*chainntnfs.BlockEpochEvent, error) {
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: m.epochChan,
+		Cancel: func() {},
+	}, nil
+}
+
+func (m *mockNotifier) Start() error {
+	return nil
+}
+
+func (m *mockNotifier) Stop() error {
+	return nil
+}
+
+func (m *mockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint, _ []byte,
+	heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	spendChan := make(chan *chainntnfs.SpendDetail, 1)
+	if spend, ok := m.spends[*outpoint]; ok {
+		spendChan <- spend
+	}
+	m.spendChans[*outpoint] = append(m.spendChans[*outpoint], spendChan)
+
+	return &chainntnfs.SpendEvent{
+		Spend:  spendChan,
+		Cancel: func() {},
+	}, nil
+}
+
+// notifyBlock delivers a new block epoch at the given height.
+func (m *mockNotifier) notifyBlock(t *testing.T, height int32) {
+	select {
+	case m.epochChan <- &chainntnfs.BlockEpoch{Height: height}:
+	case <-time.After(defaultTestTimeout):
+		t.Fatalf("block epoch not consumed")
+	}
+}
+
+// spendTx delivers a spend notification for each input of the passed tx.
+func (m *mockNotifier) spendTx(tx *wire.MsgTx) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	txHash := tx.TxHash()
+	for i, txIn := range tx.TxIn {
+		spend := &chainntnfs.SpendDetail{
+			SpentOutPoint:     &txIn.PreviousOutPoint,
+			SpenderTxHash:     &txHash,
+			SpendingTx:        tx,
+			SpenderInputIndex: uint32(i),
+		}
+		m.spends[txIn.PreviousOutPoint] = spend
+
+		for _, spendChan := range m.spendChans[txIn.PreviousOutPoint] {
+			spendChan <- spend
+		}
+		delete(m.spendChans, txIn.PreviousOutPoint)
+	}
+}
+
+// mockSweeperStore is an in-memory implementation of the SweeperStore.
+type mockSweeperStore struct {
+	mtx     sync.Mutex
+	inputs  map[wire.OutPoint]*PersistedInput
+	ourTxes map[chainhash.Hash]struct{}
+}
+
+func newMockSweeperStore() *mockSweeperStore {
+	return &mockSweeperStore{
+		inputs:  make(map[wire.OutPoint]*PersistedInput),
+		ourTxes: make(map[chainhash.Hash]struct{}),
+	}
+}
+
+func (s *mockSweeperStore) AddPendingInput(input Input,
+	feePref FeePreference) error {
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.inputs[*input.OutPoint()] = &PersistedInput{
+		Input:         input,
+		FeePreference: feePref,
+	}
+	return nil
+}
+
+func (s *mockSweeperStore) RemovePendingInput(op wire.OutPoint) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.inputs, op)
+	return nil
+}
+
+func (s *mockSweeperStore) FetchPendingInputs() ([]*PersistedInput, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var inputs []*PersistedInput
+	for _, input := range s.inputs {
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+func (s *mockSweeperStore) NotifyPublishTx(tx *wire.MsgTx) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.ourTxes[tx.TxHash()] = struct{}{}
+	return nil
+}
+
+func (s *mockSweeperStore) IsOurTx(hash chainhash.Hash) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	_, ok := s.ourTxes[hash]
+	return ok, nil
+}
+
+// mockBackend captures the published transactions and reported
+// replacements, and allows the test to let the publication fail.
+type mockBackend struct {
+	publishChan chan *wire.MsgTx
+
+	mtx          sync.Mutex
+	publishErr   error
+	replacements map[chainhash.Hash]chainhash.Hash
+}
+
+func newMockBackend() *mockBackend {
+	return &mockBackend{
+		publishChan:  make(chan *wire.MsgTx, 10),
+		replacements: make(map[chainhash.Hash]chainhash.Hash),
+	}
+}
+
+func (b *mockBackend) publishTransaction(tx *wire.MsgTx) error {
+	b.mtx.Lock()
+	err := b.publishErr
+	b.mtx.Unlock()
+
+	b.publishChan <- tx
+	return err
+}
+
+func (b *mockBackend) notifyReplacement(replacedTxid chainhash.Hash,
+	replacementTx *wire.MsgTx) error {
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.replacements[replacedTxid] = replacementTx.TxHash()
+	return nil
+}
+
+// replacedBy returns the hash of the tx that was reported to replace the
+// passed tx.
+func (b *mockBackend) replacedBy(txid chainhash.Hash) (chainhash.Hash, bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	replacement, ok := b.replacements[txid]
+	return replacement, ok
+}
+
+func (b *mockBackend) setPublishErr(err error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.publishErr = err
+}
+
+// receiveTx waits for the next published transaction.
+func (b *mockBackend) receiveTx(t *testing.T) *wire.MsgTx {
+	select {
+	case tx := <-b.publishChan:
+		return tx
+	case <-time.After(defaultTestTimeout):
+		t.Fatalf("no tx published")
+	}
+	return nil
+}
+
+// assertNoTx asserts that no transaction is published.
+func (b *mockBackend) assertNoTx(t *testing.T) {
+	select {
+	case tx := <-b.publishChan:
+		t.Fatalf("unexpected tx published: %v", tx.TxHash())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,335 @@
+package sweep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+var (
+	// pendingInputsBucketKey is the key of the top level bucket that
+	// stores all inputs that have been handed to the sweeper, but of which
+	// the spend hasn't been confirmed yet. The inputs are keyed by their
+	// outpoint.
+	pendingInputsBucketKey = []byte("sweeper-pending-inputs")
+
+	// txHashesBucketKey is the key of the top level bucket that stores the
+	// hashes of all sweep transactions that the sweeper published. This
+	// allows the sweeper to recognize its own transactions after a
+	// restart.
+	txHashesBucketKey = []byte("sweeper-tx-hashes")
+
+	// byteOrder is the byte order used to serialize integers.
+	byteOrder = binary.BigEndian
+
+	// ErrUnknownInputType is returned when a persisted input can't be
+	// deserialized because its type is unknown.
+	ErrUnknownInputType = errors.New("unknown persisted input type")
+)
+
+// inputType identifies the concrete input type of a persisted input.
+type inputType uint8
+
+const (
+	// baseInputType is the type of all inputs that can be spent using the
+	// witness generator of their witness type.
+	baseInputType inputType = 0
+
+	// htlcSucceedInputType is the type of an HtlcSucceedInput, which
+	// additionally requires the preimage of the htlc to be spent.
+	htlcSucceedInputType inputType = 1
+)
+
+// PersistedInput is an input that was read back from the SweeperStore,
+// together with the fee preference it was offered with.
+type PersistedInput struct {
+	// Input is the input to sweep.
+	Input Input
+
+	// FeePreference is the fee preference the input was offered with.
+	FeePreference FeePreference
+}
+
+// SweeperStore stores the state of the sweeper that needs to survive
+// restarts.
+type SweeperStore interface {
+	// AddPendingInput persists an input that is to be swept using the
+	// passed fee preference. If the input is already persisted, it will be
+	// overwritten.
+	AddPendingInput(input Input, feePref FeePreference) error
+
+	// RemovePendingInput removes the input with the passed outpoint from
+	// the store.
+	RemovePendingInput(op wire.OutPoint) error
+
+	// FetchPendingInputs returns all inputs that are currently persisted.
+	FetchPendingInputs() ([]*PersistedInput, error)
+
+	// NotifyPublishTx signals that we are about to publish a tx.
+	NotifyPublishTx(tx *wire.MsgTx) error
+
+	// IsOurTx determines whether a tx is published by us, based on its
+	// hash.
+	IsOurTx(hash chainhash.Hash) (bool, error)
+}
+
+// sweeperStore is the bolt backed implementation of the SweeperStore.
+type sweeperStore struct {
+	db *channeldb.DB
+}
+
+// NewSweeperStore returns a new store instance that is backed by the passed
+// database.
+func NewSweeperStore(db *channeldb.DB) (SweeperStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(pendingInputsBucketKey)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateBucketIfNotExists(txHashesBucketKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sweeperStore{
+		db: db,
+	}, nil
+}
+
+// AddPendingInput persists an input that is to be swept using the passed fee
+// preference.
+//
+// NOTE: This method is part of the SweeperStore interface.
+func (s *sweeperStore) AddPendingInput(input Input,
+	feePref FeePreference) error {
+
+	var b bytes.Buffer
+	if err := serializeInput(&b, input, feePref); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		inputs := tx.Bucket(pendingInputsBucketKey)
+		if inputs == nil {
+			return errors.New("pending inputs bucket not found")
+		}
+
+		return inputs.Put(outpointKey(input.OutPoint()), b.Bytes())
+	})
+}
+
+// RemovePendingInput removes the input with the passed outpoint from the
+// store.
+//
+// NOTE: This method is part of the SweeperStore interface.
+func (s *sweeperStore) RemovePendingInput(op wire.OutPoint) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		inputs := tx.Bucket(pendingInputsBucketKey)
+		if inputs == nil {
+			return errors.New("pending inputs bucket not found")
+		}
+
+		return inputs.Delete(outpointKey(&op))
+	})
+}
+
+// FetchPendingInputs returns all inputs that are currently persisted.
+//
+// NOTE: This method is part of the SweeperStore interface.
+func (s *sweeperStore) FetchPendingInputs() ([]*PersistedInput, error) {
+	var pendingInputs []*PersistedInput
+	err := s.db.View(func(tx *bolt.Tx) error {
+		inputs := tx.Bucket(pendingInputsBucketKey)
+		if inputs == nil {
+			return errors.New("pending inputs bucket not found")
+		}
+
+		return inputs.ForEach(func(_, v []byte) error {
+			input, err := deserializeInput(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			pendingInputs = append(pendingInputs, input)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pendingInputs, nil
+}
+
+// NotifyPublishTx signals that we are about to publish a tx.
+//
+// NOTE: This method is part of the SweeperStore interface.
+func (s *sweeperStore) NotifyPublishTx(sweepTx *wire.MsgTx) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		txHashes := tx.Bucket(txHashesBucketKey)
+		if txHashes == nil {
+			return errors.New("tx hashes bucket not found")
+		}
+
+		hash := sweepTx.TxHash()
+
+		return txHashes.Put(hash[:], []byte{})
+	})
+}
+
+// IsOurTx determines whether a tx is published by us, based on its hash.
+//
+// NOTE: This method is part of the SweeperStore interface.
+func (s *sweeperStore) IsOurTx(hash chainhash.Hash) (bool, error) {
+	var ours bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		txHashes := tx.Bucket(txHashesBucketKey)
+		if txHashes == nil {
+			return errors.New("tx hashes bucket not found")
+		}
+
+		ours = txHashes.Get(hash[:]) != nil
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return ours, nil
+}
+
+// outpointKey returns the key under which the input spending the passed
+// outpoint is stored.
+func outpointKey(op *wire.OutPoint) []byte {
+	var key [chainhash.HashSize + 4]byte
+	copy(key[:], op.Hash[:])
+	byteOrder.PutUint32(key[chainhash.HashSize:], op.Index)
+
+	return key[:]
+}
+
+// serializeInput writes the passed input and its fee preference to the
+// writer. Inputs are stored by the information needed to spend them, so after
+// reading them back they are represented by one of the input types of this
+// package.
+func serializeInput(w io.Writer, input Input, feePref FeePreference) error {
+	var (
+		typ      = baseInputType
+		preimage []byte
+	)
+	if htlcInput, ok := input.(*HtlcSucceedInput); ok {
+		typ = htlcSucceedInputType
+		preimage = htlcInput.preimage
+	}
+
+	lockTime, _ := input.RequiredLockTime()
+
+	var scratch [8]byte
+	if _, err := w.Write([]byte{byte(typ)}); err != nil {
+		return err
+	}
+
+	op := input.OutPoint()
+	if _, err := w.Write(op.Hash[:]); err != nil {
+		return err
+	}
+
+	for _, v := range []uint32{
+		op.Index, uint32(input.WitnessType()), input.HeightHint(),
+		input.BlocksToMaturity(), lockTime, feePref.ConfTarget,
+	} {
+		byteOrder.PutUint32(scratch[:4], v)
+		if _, err := w.Write(scratch[:4]); err != nil {
+			return err
+		}
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(feePref.FeeRate))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if err := wire.WriteVarBytes(w, 0, preimage); err != nil {
+		return err
+	}
+
+	return lnwallet.WriteSignDescriptor(w, input.SignDesc())
+}
+
+// deserializeInput reads back an input that was written by serializeInput.
+func deserializeInput(r io.Reader) (*PersistedInput, error) {
+	var typ [1]byte
+	if _, err := io.ReadFull(r, typ[:]); err != nil {
+		return nil, err
+	}
+
+	var op wire.OutPoint
+	if _, err := io.ReadFull(r, op.Hash[:]); err != nil {
+		return nil, err
+	}
+
+	var values [6]uint32
+	var scratch [8]byte
+	for i := range values {
+		if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+			return nil, err
+		}
+		values[i] = byteOrder.Uint32(scratch[:4])
+	}
+	op.Index = values[0]
+	witnessType := lnwallet.WitnessType(values[1])
+	heightHint := values[2]
+	blocksToMaturity := values[3]
+	lockTime := values[4]
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	feePref := FeePreference{
+		ConfTarget: values[5],
+		FeeRate:    lnwallet.SatPerKWeight(byteOrder.Uint64(scratch[:])),
+	}
+
+	preimage, err := wire.ReadVarBytes(r, 0, 32, "preimage")
+	if err != nil {
+		return nil, err
+	}
+
+	var signDesc lnwallet.SignDescriptor
+	if err := lnwallet.ReadSignDescriptor(r, &signDesc); err != nil {
+		return nil, err
+	}
+
+	var input Input
+	switch inputType(typ[0]) {
+	case baseInputType:
+		baseInput := MakeBaseInput(&op, witnessType, &signDesc, heightHint)
+		baseInput.blocksToMaturity = blocksToMaturity
+		baseInput.lockTime = lockTime
+		input = &baseInput
+
+	case htlcSucceedInputType:
+		htlcInput := MakeHtlcSucceedInput(
+			&op, &signDesc, preimage, heightHint,
+		)
+		input = &htlcInput
+
+	default:
+		return nil, ErrUnknownInputType
+	}
+
+	return &PersistedInput{
+		Input:         input,
+		FeePreference: feePref,
+	}, nil
+}
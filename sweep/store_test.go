@@ -0,0 +1,192 @@
+package sweep
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+// makeTestDB creates a new instance of the ChannelDB for testing purposes. A
+// callback which cleans up the created temporary directories is also returned
+// and intended to be executed after the test completes.
+func makeTestDB() (*channeldb.DB, func(), error) {
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cdb, err := channeldb.Open(tempDirName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanUp := func() {
+		cdb.Close()
+		os.RemoveAll(tempDirName)
+	}
+
+	return cdb, cleanUp, nil
+}
+
+// assertInputsEqual asserts that the persisted input matches the input that
+// was stored.
+func assertInputsEqual(t *testing.T, expected Input, persisted Input) {
+	if *expected.OutPoint() != *persisted.OutPoint() {
+		t.Fatalf("outpoint mismatch: expected %v, got %v",
+			expected.OutPoint(), persisted.OutPoint())
+	}
+	if expected.WitnessType() != persisted.WitnessType() {
+		t.Fatalf("witness type mismatch: expected %v, got %v",
+			expected.WitnessType(), persisted.WitnessType())
+	}
+	if expected.HeightHint() != persisted.HeightHint() {
+		t.Fatalf("height hint mismatch: expected %v, got %v",
+			expected.HeightHint(), persisted.HeightHint())
+	}
+	if expected.BlocksToMaturity() != persisted.BlocksToMaturity() {
+		t.Fatalf("csv mismatch: expected %v, got %v",
+			expected.BlocksToMaturity(),
+			persisted.BlocksToMaturity())
+	}
+
+	expectedLockTime, _ := expected.RequiredLockTime()
+	persistedLockTime, _ := persisted.RequiredLockTime()
+	if expectedLockTime != persistedLockTime {
+		t.Fatalf("lock time mismatch: expected %v, got %v",
+			expectedLockTime, persistedLockTime)
+	}
+
+	var b1, b2 bytes.Buffer
+	lnwallet.WriteSignDescriptor(&b1, expected.SignDesc())
+	lnwallet.WriteSignDescriptor(&b2, persisted.SignDesc())
+	if !bytes.Equal(b1.Bytes(), b2.Bytes()) {
+		t.Fatalf("sign descriptor mismatch")
+	}
+}
+
+// TestStore asserts that the sweeper store persists pending inputs and the
+// hashes of published sweep transactions.
+func TestStore(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to open channel db: %v", err)
+	}
+	defer cleanUp()
+
+	store, err := NewSweeperStore(cdb)
+	if err != nil {
+		t.Fatalf("unable to create store: %v", err)
+	}
+
+	base := createTestInput(10000, lnwallet.CommitmentTimeLock)
+	csvInput := NewCsvInput(
+		base.OutPoint(), base.WitnessType(), base.SignDesc(), 10, 144,
+	)
+
+	base = createTestInput(20000, lnwallet.HtlcOfferedRemoteTimeout)
+	cltvInput := NewCltvInput(
+		base.OutPoint(), base.WitnessType(), base.SignDesc(), 20, 500,
+	)
+
+	base = createTestInput(30000, lnwallet.HtlcAcceptedRemoteSuccess)
+	htlcInput := MakeHtlcSucceedInput(
+		base.OutPoint(), base.SignDesc(), bytes.Repeat([]byte{1}, 32),
+		30,
+	)
+
+	inputs := map[wire.OutPoint]*PersistedInput{
+		*csvInput.OutPoint(): {
+			Input:         csvInput,
+			FeePreference: FeePreference{ConfTarget: 6},
+		},
+		*cltvInput.OutPoint(): {
+			Input:         cltvInput,
+			FeePreference: FeePreference{FeeRate: 1000},
+		},
+		*htlcInput.OutPoint(): {
+			Input:         &htlcInput,
+			FeePreference: FeePreference{ConfTarget: 2},
+		},
+	}
+	for _, input := range inputs {
+		err := store.AddPendingInput(input.Input, input.FeePreference)
+		if err != nil {
+			t.Fatalf("unable to add input: %v", err)
+		}
+	}
+
+	persisted, err := store.FetchPendingInputs()
+	if err != nil {
+		t.Fatalf("unable to fetch inputs: %v", err)
+	}
+	if len(persisted) != len(inputs) {
+		t.Fatalf("expected %v inputs, got %v", len(inputs),
+			len(persisted))
+	}
+	for _, p := range persisted {
+		expected, ok := inputs[*p.Input.OutPoint()]
+		if !ok {
+			t.Fatalf("unexpected input %v", p.Input.OutPoint())
+		}
+
+		assertInputsEqual(t, expected.Input, p.Input)
+		if expected.FeePreference != p.FeePreference {
+			t.Fatalf("fee preference mismatch: expected %v, got %v",
+				expected.FeePreference, p.FeePreference)
+		}
+	}
+
+	// The htlc input should be restored including its preimage.
+	for _, p := range persisted {
+		restored, ok := p.Input.(*HtlcSucceedInput)
+		if !ok {
+			continue
+		}
+		if !reflect.DeepEqual(restored.preimage, htlcInput.preimage) {
+			t.Fatalf("preimage mismatch")
+		}
+	}
+
+	err = store.RemovePendingInput(*cltvInput.OutPoint())
+	if err != nil {
+		t.Fatalf("unable to remove input: %v", err)
+	}
+	persisted, err = store.FetchPendingInputs()
+	if err != nil {
+		t.Fatalf("unable to fetch inputs: %v", err)
+	}
+	if len(persisted) != len(inputs)-1 {
+		t.Fatalf("expected %v inputs, got %v", len(inputs)-1,
+			len(persisted))
+	}
+
+	// Finally, check that published transactions are recognized as ours.
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: *csvInput.OutPoint()})
+
+	ours, err := store.IsOurTx(tx.TxHash())
+	if err != nil {
+		t.Fatalf("unable to query tx: %v", err)
+	}
+	if ours {
+		t.Fatalf("tx unexpectedly recognized as ours")
+	}
+
+	if err := store.NotifyPublishTx(tx); err != nil {
+		t.Fatalf("unable to notify publish: %v", err)
+	}
+
+	ours, err = store.IsOurTx(tx.TxHash())
+	if err != nil {
+		t.Fatalf("unable to query tx: %v", err)
+	}
+	if !ours {
+		t.Fatalf("tx not recognized as ours")
+	}
+}
@@ -0,0 +1,918 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+var (
+	// ErrRemoteSpend is returned in case an output that we try to sweep is
+	// confirmed in a tx of the remote party.
+	ErrRemoteSpend = errors.New("remote party swept utxo")
+
+	// ErrSweeperShuttingDown is returned when a client attempts to make a
+	// request to the UtxoSweeper, but it is unable to handle it as it is/has
+	// already been stopped.
+	ErrSweeperShuttingDown = errors.New("utxo sweeper shutting down")
+
+	// ErrInvalidFeePreference is returned when an input is offered with a
+	// fee preference that neither specifies a confirmation target nor a
+	// fee rate.
+	ErrInvalidFeePreference = errors.New("fee preference must specify " +
+		"either a confirmation target or a fee rate")
+
+	// ErrTxNotFound is returned when a fee bump is requested for a tx that
+	// isn't an unconfirmed sweep tx of the sweeper.
+	ErrTxNotFound = errors.New("unconfirmed sweep tx not found")
+
+	// ErrNotReplaceable is returned when a fee bump is requested for a
+	// sweep tx that doesn't signal replaceability.
+	ErrNotReplaceable = errors.New("sweep tx doesn't signal " +
+		"replaceability")
+)
+
+const (
+	// DefaultMaxInputsPerTx specifies the default maximum number of inputs
+	// allowed in a single sweep tx. If more need to be swept, multiple txes
+	// are created and published.
+	DefaultMaxInputsPerTx = 100
+
+	// maxAttemptDeltaExponent caps the exponential back off between two
+	// publish attempts of the same input at 2^maxAttemptDeltaExponent
+	// blocks.
+	maxAttemptDeltaExponent = 7
+)
+
+// FeePreference describes how an input is to be swept. Either a confirmation
+// target or a fee rate is to be set.
+type FeePreference struct {
+	// ConfTarget if non-zero, signals a fee preference expressed in the
+	// number of desired blocks between first broadcast, and confirmation.
+	ConfTarget uint32
+
+	// FeeRate if non-zero, signals a fee preference expressed as a fee
+	// rate in sat/kw.
+	FeeRate lnwallet.SatPerKWeight
+}
+
+// String returns a human readable version of the fee preference.
+func (p FeePreference) String() string {
+	if p.ConfTarget != 0 {
+		return fmt.Sprintf("%v blocks", p.ConfTarget)
+	}
+
+	return fmt.Sprintf("%v sat/kw", int64(p.FeeRate))
+}
+
+// Result is the struct that is pushed through the result channel. Callers
+// can use this to be informed of the final sweep result. In case of a remote
+// spend, Err will be ErrRemoteSpend.
+type Result struct {
+	// Err is the final result of the sweep. It is nil when the input is
+	// swept successfully by us. ErrRemoteSpend is returned when another
+	// party took the input.
+	Err error
+
+	// Tx is the transaction that spent the input.
+	Tx *wire.MsgTx
+}
+
+// pendingInput is created when an input is offered to the sweeper. It tracks
+// the state of the input until its spend is confirmed.
+type pendingInput struct {
+	// listeners is a list of channels over which the final outcome of the
+	// sweep needs to be broadcasted.
+	listeners []chan Result
+
+	// input is the original struct that contains the input and sign
+	// descriptor.
+	input Input
+
+	// feePreference is the fee preference of the client who requested the
+	// input to be swept.
+	feePreference FeePreference
+
+	// ntfnRegCancel is populated with a function that cancels the chain
+	// notifier spend registration.
+	ntfnRegCancel func()
+
+	// publishAttempts records the number of attempts that have already
+	// been made to sweep this tx.
+	publishAttempts int
+
+	// minPublishHeight indicates the minimum block height at which this
+	// input may be (re)published.
+	minPublishHeight int32
+
+	// lastTx is the last sweep tx that included this input.
+	lastTx *wire.MsgTx
+}
+
+// sweepInputMessage structs are used in the internal channel between the
+// SweepInput call and the sweeper main loop.
+type sweepInputMessage struct {
+	input         Input
+	feePreference FeePreference
+	resultChan    chan Result
+}
+
+// bumpFeeRequest is sent to the sweeper main loop to replace an unconfirmed
+// sweep tx by a tx that uses a new fee preference.
+type bumpFeeRequest struct {
+	txid          chainhash.Hash
+	feePreference FeePreference
+	respChan      chan *bumpFeeResponse
+}
+
+// bumpFeeResponse is the response to a bumpFeeRequest.
+type bumpFeeResponse struct {
+	tx  *wire.MsgTx
+	err error
+}
+
+// clusterKey identifies a group of inputs that can be swept together in a
+// single transaction.
+type clusterKey struct {
+	feePreference FeePreference
+	lockTime      uint32
+}
+
+// UtxoSweeperConfig contains dependencies of UtxoSweeper.
+type UtxoSweeperConfig struct {
+	// GenSweepScript generates a P2WKH script belonging to the wallet
+	// where funds can be swept.
+	GenSweepScript func() ([]byte, error)
+
+	// FeeEstimator is used when crafting sweep transactions to estimate
+	// the necessary fee relative to the expected size of the sweep
+	// transaction.
+	FeeEstimator lnwallet.FeeEstimator
+
+	// PublishTransaction facilitates the process of broadcasting a signed
+	// transaction to the appropriate network.
+	PublishTransaction func(*wire.MsgTx) error
+
+	// Signer is used by the sweeper to generate valid witnesses at the
+	// time the incubated outputs need to be spent.
+	Signer lnwallet.Signer
+
+	// ChainIO is used to determine the current block height.
+	ChainIO lnwallet.BlockChainIO
+
+	// Notifier is an instance of a chain notifier we'll use to watch for
+	// certain on-chain events.
+	Notifier chainntnfs.ChainNotifier
+
+	// Store stores the pending inputs and published sweep tx hashes.
+	Store SweeperStore
+
+	// MaxInputsPerTx specifies the default maximum number of inputs
+	// allowed in a single sweep tx. If more need to be swept, multiple
+	// txes are created and published.
+	MaxInputsPerTx int
+
+	// NextAttemptDeltaFunc returns given the number of already attempted
+	// sweeps, how many blocks to wait before retrying to sweep.
+	NextAttemptDeltaFunc func(int) int32
+
+	// NotifyReplacement is called when a published sweep tx replaces an
+	// earlier sweep tx of the same inputs, so that confirmation
+	// notifications for the earlier tx can follow the replacement. It is
+	// optional.
+	NotifyReplacement func(chainhash.Hash, *wire.MsgTx) error
+}
+
+// DefaultNextAttemptDeltaFunc is the default back off function of the
+// sweeper. The number of blocks to wait before an input is swept again
+// doubles with each attempt.
+func DefaultNextAttemptDeltaFunc(attempts int) int32 {
+	if attempts > maxAttemptDeltaExponent {
+		attempts = maxAttemptDeltaExponent
+	}
+
+	return 1 << uint(attempts)
+}
+
+// UtxoSweeper is responsible for sweeping outputs back into the wallet. All
+// on-chain subsystems hand their inputs to the sweeper, which groups them by
+// fee preference and lock time and sweeps each group in a single transaction
+// once per block. Inputs of transactions that didn't confirm, for example
+// because they were double spent or evicted from the mempool, are swept again
+// in a new transaction.
+type UtxoSweeper struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	cfg *UtxoSweeperConfig
+
+	newInputs   chan *sweepInputMessage
+	spendChan   chan *chainntnfs.SpendDetail
+	bumpFeeReqs chan *bumpFeeRequest
+
+	// pendingInputs is the total set of inputs the UtxoSweeper has been
+	// requested to sweep. It is only accessed from the collector
+	// goroutine.
+	pendingInputs map[wire.OutPoint]*pendingInput
+
+	// currentHeight is the best known height of the main chain. It is only
+	// accessed from the collector goroutine.
+	currentHeight int32
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New returns a new Sweeper instance.
+func New(cfg *UtxoSweeperConfig) *UtxoSweeper {
+	return &UtxoSweeper{
+		cfg:           cfg,
+		newInputs:     make(chan *sweepInputMessage),
+		spendChan:     make(chan *chainntnfs.SpendDetail),
+		bumpFeeReqs:   make(chan *bumpFeeRequest),
+		pendingInputs: make(map[wire.OutPoint]*pendingInput),
+		quit:          make(chan struct{}),
+	}
+}
+
+// Start starts the process of constructing and publish sweep txes.
+func (s *UtxoSweeper) Start() error {
+	if !atomic.CompareAndSwapUint32(&s.started, 0, 1) {
+		return nil
+	}
+
+	log.Tracef("Sweeper starting")
+
+	_, bestHeight, err := s.cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	s.currentHeight = bestHeight
+
+	// Register for block epochs to retry sweeping every block.
+	blockEpochs, err := s.cfg.Notifier.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		return fmt.Errorf("register block epoch ntfn: %v", err)
+	}
+
+	// Reload all inputs that were offered before the restart. Their
+	// listeners are gone, but subsystems that still care about the
+	// outcome will offer them again and be added as new listeners.
+	persistedInputs, err := s.cfg.Store.FetchPendingInputs()
+	if err != nil {
+		blockEpochs.Cancel()
+		return fmt.Errorf("unable to fetch pending inputs: %v", err)
+	}
+	for _, persisted := range persistedInputs {
+		err := s.addPendingInput(
+			persisted.Input, persisted.FeePreference, false,
+		)
+		if err != nil {
+			blockEpochs.Cancel()
+			return err
+		}
+	}
+
+	log.Infof("Sweeper resumed with %v pending inputs at height %v",
+		len(persistedInputs), bestHeight)
+
+	s.wg.Add(1)
+	go func() {
+		defer blockEpochs.Cancel()
+		defer s.wg.Done()
+
+		s.collector(blockEpochs.Epochs)
+	}()
+
+	return nil
+}
+
+// Stop stops sweeper from listening to block epochs and constructing sweep
+// txes.
+func (s *UtxoSweeper) Stop() error {
+	if !atomic.CompareAndSwapUint32(&s.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Debugf("Sweeper shutting down")
+
+	close(s.quit)
+	s.wg.Wait()
+
+	for _, pendInput := range s.pendingInputs {
+		pendInput.ntfnRegCancel()
+	}
+
+	log.Debugf("Sweeper shut down")
+
+	return nil
+}
+
+// SweepInput sweeps inputs back into the wallet. The inputs will be batched
+// and swept after the next block is connected, grouped by fee preference and
+// lock time.
+//
+// The return value is a channel that will be sent upon once the spend of the
+// input has confirmed. If the input is spent by a tx of another party,
+// ErrRemoteSpend is returned. Offering an input that is already pending adds
+// another listener, but doesn't change its fee preference.
+func (s *UtxoSweeper) SweepInput(input Input,
+	feePreference FeePreference) (chan Result, error) {
+
+	if input == nil || input.OutPoint() == nil || input.SignDesc() == nil {
+		return nil, errors.New("nil input received")
+	}
+
+	if feePreference.ConfTarget == 0 && feePreference.FeeRate == 0 {
+		return nil, ErrInvalidFeePreference
+	}
+
+	log.Infof("Sweep request received: out_point=%v, witness_type=%v, "+
+		"fee_preference=%v", input.OutPoint(), input.WitnessType(),
+		feePreference)
+
+	sweeperInput := &sweepInputMessage{
+		input:         input,
+		feePreference: feePreference,
+		resultChan:    make(chan Result, 1),
+	}
+
+	select {
+	case s.newInputs <- sweeperInput:
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+
+	return sweeperInput.resultChan, nil
+}
+
+// BumpFee replaces the unconfirmed sweep tx with the passed hash by a tx that
+// spends the same inputs using the new fee preference (RBF). The new fee
+// preference is also used for all later sweeps of these inputs. The
+// replacement tx is returned once it has been published. ErrTxNotFound is
+// returned if the tx isn't an unconfirmed sweep tx of the sweeper.
+func (s *UtxoSweeper) BumpFee(txid chainhash.Hash,
+	feePreference FeePreference) (*wire.MsgTx, error) {
+
+	if feePreference.ConfTarget == 0 && feePreference.FeeRate == 0 {
+		return nil, ErrInvalidFeePreference
+	}
+
+	log.Infof("Fee bump request received: txid=%v, fee_preference=%v",
+		txid, feePreference)
+
+	req := &bumpFeeRequest{
+		txid:          txid,
+		feePreference: feePreference,
+		respChan:      make(chan *bumpFeeResponse, 1),
+	}
+
+	select {
+	case s.bumpFeeReqs <- req:
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+
+	select {
+	case resp := <-req.respChan:
+		return resp.tx, resp.err
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+}
+
+// collector is the sweeper main loop. It processes new inputs, spend
+// notifications and counts down to publication of the sweep tx.
+func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
+	for {
+		select {
+
+		// A new input is offered to the sweeper. It will be swept
+		// with the next block.
+		case input := <-s.newInputs:
+			outpoint := *input.input.OutPoint()
+			pendInput, ok := s.pendingInputs[outpoint]
+			if ok {
+				log.Debugf("Already pending input %v received",
+					outpoint)
+
+				pendInput.listeners = append(
+					pendInput.listeners, input.resultChan,
+				)
+				continue
+			}
+
+			err := s.addPendingInput(
+				input.input, input.feePreference, true,
+			)
+			if err != nil {
+				input.resultChan <- Result{Err: err}
+				continue
+			}
+
+			pendInput = s.pendingInputs[outpoint]
+			pendInput.listeners = append(
+				pendInput.listeners, input.resultChan,
+			)
+
+		// A spend of one of our inputs is detected. Signal sweep
+		// results to the caller(s).
+		case spend := <-s.spendChan:
+			s.handleSpend(spend)
+
+		// A fee bump of one of our sweep txes is requested.
+		case req := <-s.bumpFeeReqs:
+			tx, err := s.handleBumpFee(req.txid, req.feePreference)
+			req.respChan <- &bumpFeeResponse{
+				tx:  tx,
+				err: err,
+			}
+
+		// A new block came in, time to sweep all inputs that are
+		// ready.
+		case epoch, ok := <-blockEpochs:
+			if !ok {
+				return
+			}
+
+			s.currentHeight = epoch.Height
+
+			log.Debugf("New blocks: height=%v, sha=%v",
+				epoch.Height, epoch.Hash)
+
+			s.sweepPendingInputs()
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// addPendingInput starts tracking the passed input and registers for its
+// spend. If persist is true, the input is also added to the store.
+func (s *UtxoSweeper) addPendingInput(input Input,
+	feePreference FeePreference, persist bool) error {
+
+	outpoint := *input.OutPoint()
+
+	if persist {
+		err := s.cfg.Store.AddPendingInput(input, feePreference)
+		if err != nil {
+			return fmt.Errorf("unable to persist input %v: %v",
+				outpoint, err)
+		}
+	}
+
+	cancel, err := s.waitForSpend(
+		outpoint, input.SignDesc().Output.PkScript, input.HeightHint(),
+	)
+	if err != nil {
+		return fmt.Errorf("wait for spend of %v: %v", outpoint, err)
+	}
+
+	s.pendingInputs[outpoint] = &pendingInput{
+		input:            input,
+		feePreference:    feePreference,
+		ntfnRegCancel:    cancel,
+		minPublishHeight: s.currentHeight,
+	}
+
+	return nil
+}
+
+// waitForSpend registers a spend notification with the chain notifier. It
+// returns a cancel function that can be used to cancel the registration.
+func (s *UtxoSweeper) waitForSpend(outpoint wire.OutPoint,
+	script []byte, heightHint uint32) (func(), error) {
+
+	spendEvent, err := s.cfg.Notifier.RegisterSpendNtfn(
+		&outpoint, script, heightHint,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("register spend ntfn: %v", err)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		select {
+		case spend, ok := <-spendEvent.Spend:
+			if !ok {
+				log.Debugf("Spend ntfn for %v canceled",
+					outpoint)
+				return
+			}
+
+			log.Debugf("Delivering spend ntfn for %v",
+				outpoint)
+			select {
+			case s.spendChan <- spend:
+				log.Debugf("Delivered spend ntfn for %v",
+					outpoint)
+
+			case <-s.quit:
+			}
+		case <-s.quit:
+		}
+	}()
+
+	return spendEvent.Cancel, nil
+}
+
+// handleSpend signals the result to the listeners of all pending inputs that
+// are spent by the passed spend, and stops tracking them.
+func (s *UtxoSweeper) handleSpend(spend *chainntnfs.SpendDetail) {
+	// For testing purposes.
+	if spend == nil {
+		return
+	}
+
+	spendHash := *spend.SpenderTxHash
+	isOurTx, err := s.cfg.Store.IsOurTx(spendHash)
+	if err != nil {
+		log.Errorf("cannot determine if tx %v is ours: %v", spendHash,
+			err)
+		return
+	}
+
+	log.Debugf("Detected spend related to in flight inputs (is_ours=%v): "+
+		"%v", isOurTx, spendHash)
+
+	// Signal sweep results for inputs in this confirmed tx.
+	for _, txIn := range spend.SpendingTx.TxIn {
+		outpoint := txIn.PreviousOutPoint
+
+		// Check if this input is known to us. It could probably be
+		// unknown if we canceled the registration, deleted from
+		// pendingInputs but the ntfn was in-flight already. Or this
+		// could be not one of our inputs.
+		pendInput, ok := s.pendingInputs[outpoint]
+		if !ok {
+			continue
+		}
+
+		// Return either a nil or a remote spend result.
+		var err error
+		if !isOurTx {
+			err = ErrRemoteSpend
+
+			// The sweep tx that this input was part of is no longer
+			// valid. All other inputs of that tx are swept again
+			// with the next block.
+			s.resetSweptWith(pendInput.lastTx)
+		}
+
+		// Signal result channels.
+		s.signalAndRemove(&outpoint, Result{
+			Tx:  spend.SpendingTx,
+			Err: err,
+		})
+	}
+}
+
+// handleBumpFee replaces the unconfirmed sweep tx with the passed hash by a
+// tx that sweeps the same inputs using the new fee preference.
+func (s *UtxoSweeper) handleBumpFee(txid chainhash.Hash,
+	feePreference FeePreference) (*wire.MsgTx, error) {
+
+	// Collect all inputs that were last swept by the tx. If none are
+	// left, the tx either confirmed already or was never ours.
+	var (
+		sweptWith *wire.MsgTx
+		inputs    []*pendingInput
+	)
+	for _, pendInput := range s.pendingInputs {
+		if pendInput.lastTx == nil || pendInput.lastTx.TxHash() != txid {
+			continue
+		}
+
+		sweptWith = pendInput.lastTx
+		inputs = append(inputs, pendInput)
+	}
+	if len(inputs) == 0 {
+		return nil, ErrTxNotFound
+	}
+
+	if !signalsReplacement(sweptWith) {
+		return nil, ErrNotReplaceable
+	}
+
+	feePerKw, err := s.feeRateForPreference(feePreference)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the fee preference of the inputs, so that the new preference
+	// also applies to sweeps after a restart.
+	for _, pendInput := range inputs {
+		err := s.cfg.Store.AddPendingInput(
+			pendInput.input, feePreference,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		pendInput.feePreference = feePreference
+	}
+
+	// The replacement must spend the inputs of the original tx to replace
+	// it, so they are swept in a single tx regardless of their yield at
+	// the new fee rate.
+	sweepInputs := make([]Input, 0, len(inputs))
+	for _, pendInput := range inputs {
+		sweepInputs = append(sweepInputs, pendInput.input)
+	}
+
+	tx, err := s.sweep(sweepInputs, feePerKw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to replace sweep tx %v: %v",
+			txid, err)
+	}
+
+	return tx, nil
+}
+
+// signalsReplacement returns whether the passed tx signals replaceability as
+// defined in BIP 125.
+func signalsReplacement(tx *wire.MsgTx) bool {
+	for _, txIn := range tx.TxIn {
+		if txIn.Sequence < wire.MaxTxInSequenceNum-1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resetSweptWith makes all pending inputs that were last swept in the passed
+// tx eligible for publication with the next block.
+func (s *UtxoSweeper) resetSweptWith(tx *wire.MsgTx) {
+	if tx == nil {
+		return
+	}
+
+	for _, txIn := range tx.TxIn {
+		pendInput, ok := s.pendingInputs[txIn.PreviousOutPoint]
+		if !ok {
+			continue
+		}
+
+		pendInput.minPublishHeight = s.currentHeight
+	}
+}
+
+// signalAndRemove notifies the listeners of the final result of the input
+// sweep. It cancels any pending spend notification and removes the input
+// from the list of pending inputs. When this function returns, the sweeper
+// has completely forgotten about the input.
+func (s *UtxoSweeper) signalAndRemove(outpoint *wire.OutPoint, result Result) {
+	pendInput := s.pendingInputs[*outpoint]
+	listeners := pendInput.listeners
+
+	if result.Err == nil {
+		log.Debugf("Dispatching sweep success for %v to %v listeners",
+			outpoint, len(listeners),
+		)
+	} else {
+		log.Debugf("Dispatching sweep error for %v to %v listeners: %v",
+			outpoint, len(listeners), result.Err,
+		)
+	}
+
+	// Signal all listeners. Channel is buffered. Because we only send once
+	// on every channel, it should never block.
+	for _, resultChan := range listeners {
+		resultChan <- result
+	}
+
+	// Cancel spend notification with chain notifier. This is not necessary
+	// in case of a success, except for that a reorg could still happen.
+	if pendInput.ntfnRegCancel != nil {
+		log.Debugf("Canceling spend ntfn for %v", outpoint)
+
+		pendInput.ntfnRegCancel()
+	}
+
+	if err := s.cfg.Store.RemovePendingInput(*outpoint); err != nil {
+		log.Errorf("Unable to remove input %v from store: %v",
+			outpoint, err)
+	}
+
+	// Inputs are no longer pending after result has been sent.
+	delete(s.pendingInputs, *outpoint)
+}
+
+// isMature returns whether the passed input can be included in a transaction
+// that is to be mined in the next block.
+func (s *UtxoSweeper) isMature(input Input) bool {
+	nextHeight := uint32(s.currentHeight) + 1
+
+	if csv := input.BlocksToMaturity(); csv > 0 {
+		if input.HeightHint()+csv > nextHeight {
+			return false
+		}
+	}
+
+	if lockTime, ok := input.RequiredLockTime(); ok {
+		if lockTime >= nextHeight {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sweepPendingInputs groups all pending inputs that are ready to be
+// published by their fee preference and lock time, and sweeps each group.
+func (s *UtxoSweeper) sweepPendingInputs() {
+	clusters := make(map[clusterKey][]*pendingInput)
+	for _, pendInput := range s.pendingInputs {
+		if pendInput.minPublishHeight > s.currentHeight {
+			continue
+		}
+
+		if !s.isMature(pendInput.input) {
+			continue
+		}
+
+		lockTime, _ := pendInput.input.RequiredLockTime()
+		key := clusterKey{
+			feePreference: pendInput.feePreference,
+			lockTime:      lockTime,
+		}
+		clusters[key] = append(clusters[key], pendInput)
+	}
+
+	for key, cluster := range clusters {
+		if err := s.sweepCluster(key, cluster); err != nil {
+			log.Errorf("Unable to sweep %v inputs with fee "+
+				"preference %v and lock time %v: %v",
+				len(cluster), key.feePreference, key.lockTime,
+				err)
+		}
+	}
+}
+
+// feeRateForPreference returns the fee rate in sat/kw for the passed fee
+// preference.
+func (s *UtxoSweeper) feeRateForPreference(
+	feePreference FeePreference) (lnwallet.SatPerKWeight, error) {
+
+	if feePreference.FeeRate != 0 {
+		return feePreference.FeeRate, nil
+	}
+
+	return s.cfg.FeeEstimator.EstimateFeePerKW(feePreference.ConfTarget)
+}
+
+// sweepCluster sweeps the passed group of inputs, splitting it into multiple
+// transactions if it exceeds the maximum number of inputs per tx.
+func (s *UtxoSweeper) sweepCluster(key clusterKey,
+	cluster []*pendingInput) error {
+
+	feePerKw, err := s.feeRateForPreference(key.feePreference)
+	if err != nil {
+		return err
+	}
+
+	inputs := make([]Input, 0, len(cluster))
+	for _, pendInput := range cluster {
+		inputs = append(inputs, pendInput.input)
+	}
+
+	// Sort the inputs by the outpoint first, so that the order of inputs
+	// with equal yields is deterministic.
+	sort.Slice(inputs, func(i, j int) bool {
+		a, b := inputs[i].OutPoint(), inputs[j].OutPoint()
+		if a.Hash != b.Hash {
+			return a.Hash.String() < b.Hash.String()
+		}
+		return a.Index < b.Index
+	})
+
+	// Inputs that cost more to sweep than they are worth at this fee rate
+	// are skipped. They will be reconsidered with the next block.
+	inputs = sortByYield(inputs, feePerKw)
+
+	maxInputs := s.cfg.MaxInputsPerTx
+	if maxInputs <= 0 {
+		maxInputs = DefaultMaxInputsPerTx
+	}
+
+	for len(inputs) > 0 {
+		n := len(inputs)
+		if n > maxInputs {
+			n = maxInputs
+		}
+
+		// A double spend error means that an earlier sweep tx of
+		// (some of) these inputs is still in the mempool, in which
+		// case we keep waiting for it to confirm.
+		_, err := s.sweep(inputs[:n], feePerKw)
+		if err != nil && err != lnwallet.ErrDoubleSpend {
+			return err
+		}
+
+		inputs = inputs[n:]
+	}
+
+	return nil
+}
+
+// sweep takes a set of preselected inputs, creates a sweep tx and publishes
+// the tx. The output address is only marked as used if the publish succeeds.
+// The published tx is returned, along with the error of the publish call.
+func (s *UtxoSweeper) sweep(inputs []Input,
+	feePerKw lnwallet.SatPerKWeight) (*wire.MsgTx, error) {
+
+	// Generate the receiving script to which the funds will be swept.
+	pkScript, err := s.cfg.GenSweepScript()
+	if err != nil {
+		return nil, fmt.Errorf("gen sweep script: %v", err)
+	}
+
+	// Create sweep tx.
+	tx, err := CreateSweepTx(inputs, pkScript, feePerKw, s.cfg.Signer)
+	if err != nil {
+		return nil, fmt.Errorf("create sweep tx: %v", err)
+	}
+
+	// Add tx before publication, so that we will always know that a spend
+	// by this tx is ours. Otherwise if the publish doesn't return, but did
+	// publish, we lose track of this tx. Even republication on startup
+	// doesn't prevent this, because that call returns a double spend error
+	// then and would also not add the hash to the store.
+	if err := s.cfg.Store.NotifyPublishTx(tx); err != nil {
+		return nil, fmt.Errorf("notify publish tx: %v", err)
+	}
+
+	log.Debugf("Publishing sweep tx %v, num_inputs=%v, height=%v",
+		tx.TxHash(), len(tx.TxIn), s.currentHeight)
+
+	// Publish sweep tx. A double spend error means that an earlier sweep
+	// tx of (some of) these inputs is still in the mempool, and the new
+	// tx doesn't pay enough fees to replace it.
+	err = s.cfg.PublishTransaction(tx)
+	if err != nil && err != lnwallet.ErrDoubleSpend {
+		log.Errorf("Publish sweep tx %v got error: %v", tx.TxHash(),
+			err)
+	}
+
+	// Reschedule sweep. If the tx doesn't confirm in time, the inputs are
+	// swept again in a new tx.
+	nextAttemptDelta := DefaultNextAttemptDeltaFunc
+	if s.cfg.NextAttemptDeltaFunc != nil {
+		nextAttemptDelta = s.cfg.NextAttemptDeltaFunc
+	}
+	replaced := make(map[chainhash.Hash]struct{})
+	for _, input := range tx.TxIn {
+		pi, ok := s.pendingInputs[input.PreviousOutPoint]
+		if !ok {
+			continue
+		}
+
+		// Record another publish attempt.
+		pi.publishAttempts++
+
+		// We don't care what the result of the publish call was. Even
+		// if it is published successfully, it can still be that it
+		// needs to be retried. Call next attempt delta func to get the
+		// delay.
+		pi.minPublishHeight = s.currentHeight +
+			nextAttemptDelta(pi.publishAttempts)
+
+		if err == nil {
+			if pi.lastTx != nil && pi.lastTx.TxHash() != tx.TxHash() {
+				replaced[pi.lastTx.TxHash()] = struct{}{}
+			}
+			pi.lastTx = tx
+		}
+
+		log.Debugf("Rescheduling input %v after %v attempts at "+
+			"height %v", input.PreviousOutPoint,
+			pi.publishAttempts, pi.minPublishHeight)
+	}
+
+	// The published tx double spends the earlier sweep txes of its
+	// inputs. Report the replacements, so that confirmation notifications
+	// of the earlier txes follow the new tx.
+	if s.cfg.NotifyReplacement != nil {
+		for replacedTxid := range replaced {
+			err := s.cfg.NotifyReplacement(replacedTxid, tx)
+			if err != nil {
+				log.Errorf("Unable to notify replacement of "+
+					"%v by %v: %v", replacedTxid,
+					tx.TxHash(), err)
+			}
+		}
+	}
+
+	return tx, err
+}
@@ -0,0 +1,511 @@
+package sweep
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+var (
+	testFeePref = FeePreference{ConfTarget: 6}
+
+	testStartHeight int32 = 100
+)
+
+type sweeperTestContext struct {
+	t *testing.T
+
+	sweeper  *UtxoSweeper
+	notifier *mockNotifier
+	backend  *mockBackend
+	store    *mockSweeperStore
+
+	currentHeight int32
+}
+
+func createSweeperTestContext(t *testing.T) *sweeperTestContext {
+	ctx := &sweeperTestContext{
+		t:             t,
+		notifier:      newMockNotifier(),
+		backend:       newMockBackend(),
+		store:         newMockSweeperStore(),
+		currentHeight: testStartHeight,
+	}
+	ctx.startSweeper()
+
+	return ctx
+}
+
+func (ctx *sweeperTestContext) startSweeper() {
+	ctx.sweeper = New(&UtxoSweeperConfig{
+		GenSweepScript: func() ([]byte, error) {
+			return make([]byte, 22), nil
+		},
+		FeeEstimator: lnwallet.StaticFeeEstimator{
+			FeePerKW: 2500,
+		},
+		PublishTransaction: ctx.backend.publishTransaction,
+		Signer:             &mockSigner{},
+		ChainIO:            &mockChainIO{bestHeight: ctx.currentHeight},
+		Notifier:           ctx.notifier,
+		Store:              ctx.store,
+		MaxInputsPerTx:     3,
+		NotifyReplacement:  ctx.backend.notifyReplacement,
+	})
+	if err := ctx.sweeper.Start(); err != nil {
+		ctx.t.Fatalf("unable to start sweeper: %v", err)
+	}
+}
+
+func (ctx *sweeperTestContext) restartSweeper() {
+	if err := ctx.sweeper.Stop(); err != nil {
+		ctx.t.Fatalf("unable to stop sweeper: %v", err)
+	}
+	ctx.startSweeper()
+}
+
+func (ctx *sweeperTestContext) finish() {
+	if err := ctx.sweeper.Stop(); err != nil {
+		ctx.t.Fatalf("unable to stop sweeper: %v", err)
+	}
+	ctx.backend.assertNoTx(ctx.t)
+}
+
+// nextBlock advances the chain by one block.
+func (ctx *sweeperTestContext) nextBlock() {
+	ctx.currentHeight++
+	ctx.notifier.notifyBlock(ctx.t, ctx.currentHeight)
+}
+
+func (ctx *sweeperTestContext) sweepInput(input Input,
+	feePref FeePreference) chan Result {
+
+	resultChan, err := ctx.sweeper.SweepInput(input, feePref)
+	if err != nil {
+		ctx.t.Fatalf("unable to sweep input: %v", err)
+	}
+
+	return resultChan
+}
+
+func (ctx *sweeperTestContext) expectResult(c chan Result, expected error) {
+	select {
+	case result := <-c:
+		if result.Err != expected {
+			ctx.t.Fatalf("expected %v result, but got %v",
+				expected, result.Err)
+		}
+	case <-time.After(defaultTestTimeout):
+		ctx.t.Fatalf("no result received")
+	}
+}
+
+// assertTxInputs asserts that the passed tx spends exactly the passed inputs.
+func assertTxInputs(t *testing.T, tx *wire.MsgTx, inputs ...Input) {
+	if len(tx.TxIn) != len(inputs) {
+		t.Fatalf("expected %v inputs, but tx has %v", len(inputs),
+			len(tx.TxIn))
+	}
+
+	spent := make(map[wire.OutPoint]struct{})
+	for _, txIn := range tx.TxIn {
+		spent[txIn.PreviousOutPoint] = struct{}{}
+	}
+	for _, input := range inputs {
+		if _, ok := spent[*input.OutPoint()]; !ok {
+			t.Fatalf("input %v not spent by tx", input.OutPoint())
+		}
+	}
+}
+
+var testOutputIndex uint32
+
+// createTestInput creates a new input of the passed value and witness type.
+func createTestInput(value int64, witnessType lnwallet.WitnessType) BaseInput {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		panic(err)
+	}
+
+	testOutputIndex++
+	return MakeBaseInput(
+		&wire.OutPoint{Index: testOutputIndex}, witnessType,
+		&lnwallet.SignDescriptor{
+			KeyDesc: keychain.KeyDescriptor{
+				PubKey: privKey.PubKey(),
+			},
+			Output: &wire.TxOut{
+				Value:    value,
+				PkScript: make([]byte, 22),
+			},
+		}, 0,
+	)
+}
+
+func createTestInputs(n int) []Input {
+	inputs := make([]Input, n)
+	for i := range inputs {
+		input := createTestInput(
+			10000, lnwallet.CommitSpendNoDelayTweakless,
+		)
+		inputs[i] = &input
+	}
+	return inputs
+}
+
+// TestSweepBatching asserts that inputs offered within the same block are
+// swept in a single transaction with the next block, and that the listeners
+// are notified once that transaction confirms.
+func TestSweepBatching(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	inputs := createTestInputs(3)
+	resultChans := make([]chan Result, len(inputs))
+	for i, input := range inputs {
+		resultChans[i] = ctx.sweepInput(input, testFeePref)
+	}
+
+	// Nothing should be published before the next block.
+	ctx.backend.assertNoTx(t)
+
+	ctx.nextBlock()
+	sweepTx := ctx.backend.receiveTx(t)
+	assertTxInputs(t, sweepTx, inputs...)
+
+	ctx.notifier.spendTx(sweepTx)
+	for _, c := range resultChans {
+		ctx.expectResult(c, nil)
+	}
+
+	// All inputs are resolved, so nothing should be persisted anymore.
+	persisted, _ := ctx.store.FetchPendingInputs()
+	if len(persisted) != 0 {
+		t.Fatalf("expected no persisted inputs, got %v",
+			len(persisted))
+	}
+
+	ctx.finish()
+}
+
+// TestSweepMaxInputs asserts that inputs are split over multiple transactions
+// if they exceed the maximum number of inputs per transaction.
+func TestSweepMaxInputs(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	inputs := createTestInputs(5)
+	for _, input := range inputs {
+		ctx.sweepInput(input, testFeePref)
+	}
+
+	ctx.nextBlock()
+	sweepTx1 := ctx.backend.receiveTx(t)
+	sweepTx2 := ctx.backend.receiveTx(t)
+
+	if len(sweepTx1.TxIn)+len(sweepTx2.TxIn) != len(inputs) {
+		t.Fatalf("expected all inputs to be swept")
+	}
+	if len(sweepTx1.TxIn) > 3 || len(sweepTx2.TxIn) > 3 {
+		t.Fatalf("expected at most 3 inputs per tx")
+	}
+
+	ctx.finish()
+}
+
+// TestSweepClusters asserts that inputs with different fee preferences or
+// lock times are swept in separate transactions.
+func TestSweepClusters(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	inputs := createTestInputs(2)
+	ctx.sweepInput(inputs[0], testFeePref)
+	ctx.sweepInput(inputs[1], FeePreference{FeeRate: 5000})
+
+	base := createTestInput(10000, lnwallet.HtlcOfferedRemoteTimeout)
+	cltvInput := NewCltvInput(
+		base.OutPoint(), base.WitnessType(), base.SignDesc(), 0,
+		uint32(testStartHeight),
+	)
+	ctx.sweepInput(cltvInput, testFeePref)
+
+	ctx.nextBlock()
+	sweepTxes := []*wire.MsgTx{
+		ctx.backend.receiveTx(t),
+		ctx.backend.receiveTx(t),
+		ctx.backend.receiveTx(t),
+	}
+
+	for _, tx := range sweepTxes {
+		if len(tx.TxIn) != 1 {
+			t.Fatalf("expected 1 input per tx, got %v",
+				len(tx.TxIn))
+		}
+
+		if tx.TxIn[0].PreviousOutPoint == *cltvInput.OutPoint() &&
+			tx.LockTime != uint32(testStartHeight) {
+
+			t.Fatalf("expected lock time %v, got %v",
+				testStartHeight, tx.LockTime)
+		}
+	}
+
+	ctx.finish()
+}
+
+// TestSweepImmature asserts that time locked inputs are only swept once they
+// can be included in the next block.
+func TestSweepImmature(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	base := createTestInput(10000, lnwallet.CommitmentTimeLock)
+	csvInput := NewCsvInput(
+		base.OutPoint(), base.WitnessType(), base.SignDesc(),
+		uint32(testStartHeight), 3,
+	)
+	ctx.sweepInput(csvInput, testFeePref)
+
+	// The input confirmed at the start height, so it can be included in
+	// the block at start height + 3.
+	ctx.nextBlock()
+	ctx.backend.assertNoTx(t)
+
+	ctx.nextBlock()
+	sweepTx := ctx.backend.receiveTx(t)
+	assertTxInputs(t, sweepTx, csvInput)
+
+	if sweepTx.TxIn[0].Sequence != 3 {
+		t.Fatalf("expected sequence 3, got %v",
+			sweepTx.TxIn[0].Sequence)
+	}
+
+	ctx.finish()
+}
+
+// TestSweepNegativeYield asserts that inputs that are worth less than the fee
+// needed to sweep them are not swept.
+func TestSweepNegativeYield(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	dustInput := createTestInput(100, lnwallet.CommitSpendNoDelayTweakless)
+	ctx.sweepInput(&dustInput, testFeePref)
+
+	inputs := createTestInputs(1)
+	ctx.sweepInput(inputs[0], testFeePref)
+
+	ctx.nextBlock()
+	sweepTx := ctx.backend.receiveTx(t)
+	assertTxInputs(t, sweepTx, inputs[0])
+
+	ctx.finish()
+}
+
+// TestSweepRemoteSpend asserts that a remote spend of an input is reported to
+// the listener, and that the other inputs of the invalidated sweep tx are
+// swept again with the next block.
+func TestSweepRemoteSpend(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	inputs := createTestInputs(2)
+	resultChan1 := ctx.sweepInput(inputs[0], testFeePref)
+	resultChan2 := ctx.sweepInput(inputs[1], testFeePref)
+
+	ctx.nextBlock()
+	sweepTx := ctx.backend.receiveTx(t)
+	assertTxInputs(t, sweepTx, inputs...)
+
+	// The remote party sweeps the first input.
+	remoteTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{PreviousOutPoint: *inputs[0].OutPoint()}},
+	}
+	ctx.notifier.spendTx(remoteTx)
+	ctx.expectResult(resultChan1, ErrRemoteSpend)
+
+	// Our sweep tx can't confirm anymore, so the second input should be
+	// swept again right away.
+	ctx.nextBlock()
+	sweepTx = ctx.backend.receiveTx(t)
+	assertTxInputs(t, sweepTx, inputs[1])
+
+	ctx.notifier.spendTx(sweepTx)
+	ctx.expectResult(resultChan2, nil)
+
+	ctx.finish()
+}
+
+// TestSweepRetry asserts that inputs of a sweep tx that doesn't confirm are
+// swept again with an exponential back off.
+func TestSweepRetry(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	inputs := createTestInputs(1)
+	resultChan := ctx.sweepInput(inputs[0], testFeePref)
+
+	ctx.nextBlock()
+	ctx.backend.receiveTx(t)
+
+	// The first retry takes place after two blocks.
+	ctx.nextBlock()
+	ctx.backend.assertNoTx(t)
+
+	ctx.nextBlock()
+	ctx.backend.receiveTx(t)
+
+	// The second retry takes place after four blocks. We let the publish
+	// fail with a double spend this time, which means that the earlier
+	// sweep tx is still in the mempool.
+	ctx.backend.setPublishErr(lnwallet.ErrDoubleSpend)
+	for i := 0; i < 3; i++ {
+		ctx.nextBlock()
+		ctx.backend.assertNoTx(t)
+	}
+
+	ctx.nextBlock()
+	sweepTx := ctx.backend.receiveTx(t)
+
+	ctx.notifier.spendTx(sweepTx)
+	ctx.expectResult(resultChan, nil)
+
+	ctx.finish()
+}
+
+// TestSweepDuplicateInput asserts that an input that is offered twice is
+// swept only once, and that both listeners receive the result.
+func TestSweepDuplicateInput(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	inputs := createTestInputs(1)
+	resultChan1 := ctx.sweepInput(inputs[0], testFeePref)
+	resultChan2 := ctx.sweepInput(inputs[0], testFeePref)
+
+	ctx.nextBlock()
+	sweepTx := ctx.backend.receiveTx(t)
+	assertTxInputs(t, sweepTx, inputs[0])
+
+	ctx.notifier.spendTx(sweepTx)
+	ctx.expectResult(resultChan1, nil)
+	ctx.expectResult(resultChan2, nil)
+
+	ctx.finish()
+}
+
+// TestSweepRestart asserts that pending inputs are swept after a restart, and
+// that spends by sweep transactions published before the restart are
+// recognized as ours.
+func TestSweepRestart(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	inputs := createTestInputs(2)
+	ctx.sweepInput(inputs[0], testFeePref)
+
+	ctx.nextBlock()
+	sweepTx := ctx.backend.receiveTx(t)
+
+	ctx.sweepInput(inputs[1], testFeePref)
+
+	ctx.restartSweeper()
+
+	// Both inputs are reloaded from the store, so they are swept with the
+	// next block.
+	ctx.nextBlock()
+	ctx.backend.receiveTx(t)
+
+	// Offering the first input again after the restart registers a new
+	// listener. Once the sweep tx from before the restart confirms, it
+	// should be reported as ours.
+	resultChan := ctx.sweepInput(inputs[0], testFeePref)
+	ctx.notifier.spendTx(sweepTx)
+	ctx.expectResult(resultChan, nil)
+
+	ctx.finish()
+}
+
+// TestSweepBumpFee asserts that an unconfirmed sweep tx can be replaced by a
+// tx with a higher fee rate, and that the replacement is reported.
+func TestSweepBumpFee(t *testing.T) {
+	t.Parallel()
+
+	ctx := createSweeperTestContext(t)
+
+	inputs := createTestInputs(2)
+	resultChans := make([]chan Result, len(inputs))
+	for i, input := range inputs {
+		resultChans[i] = ctx.sweepInput(input, testFeePref)
+	}
+
+	ctx.nextBlock()
+	sweepTx := ctx.backend.receiveTx(t)
+
+	// Bumping a tx that isn't a sweep tx should fail.
+	_, err := ctx.sweeper.BumpFee(
+		sweepTx.TxIn[0].PreviousOutPoint.Hash,
+		FeePreference{FeeRate: 5000},
+	)
+	if err != ErrTxNotFound {
+		t.Fatalf("expected ErrTxNotFound, got %v", err)
+	}
+
+	replacement, err := ctx.sweeper.BumpFee(
+		sweepTx.TxHash(), FeePreference{FeeRate: 5000},
+	)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	if ctx.backend.receiveTx(t).TxHash() != replacement.TxHash() {
+		t.Fatalf("replacement not published")
+	}
+	assertTxInputs(t, replacement, inputs...)
+
+	// The replacement must pay a higher fee than the original.
+	if replacement.TxOut[0].Value >= sweepTx.TxOut[0].Value {
+		t.Fatalf("replacement doesn't pay a higher fee")
+	}
+
+	replacedBy, ok := ctx.backend.replacedBy(sweepTx.TxHash())
+	if !ok || replacedBy != replacement.TxHash() {
+		t.Fatalf("replacement not reported")
+	}
+
+	// The new fee preference should have been persisted.
+	persisted, _ := ctx.store.FetchPendingInputs()
+	for _, p := range persisted {
+		if p.FeePreference.FeeRate != 5000 {
+			t.Fatalf("fee preference not updated")
+		}
+	}
+
+	// The original tx can no longer be bumped.
+	_, err = ctx.sweeper.BumpFee(
+		sweepTx.TxHash(), FeePreference{FeeRate: 10000},
+	)
+	if err != ErrTxNotFound {
+		t.Fatalf("expected ErrTxNotFound, got %v", err)
+	}
+
+	ctx.notifier.spendTx(replacement)
+	for _, c := range resultChans {
+		ctx.expectResult(c, nil)
+	}
+
+	ctx.finish()
+}
@@ -0,0 +1,227 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+var (
+	// ErrNoInputs is returned when a sweep tx is requested for a set of
+	// inputs of which none can be swept.
+	ErrNoInputs = errors.New("no sweepable inputs")
+
+	// ErrDustOutput is returned when the output of a sweep tx would be
+	// dust after paying the fee.
+	ErrDustOutput = errors.New("sweep output is dust")
+)
+
+// getInputWitnessSizeUpperBound returns the maximum length of the witness for
+// the given input if it would be included in a tx.
+func getInputWitnessSizeUpperBound(input Input) (int, error) {
+	switch input.WitnessType() {
+
+	// Outputs on a remote commitment transaction that pay directly to us.
+	case lnwallet.CommitmentNoDelay,
+		lnwallet.CommitSpendNoDelayTweakless:
+
+		return lnwallet.P2WKHWitnessSize, nil
+
+	// Outputs on a past commitment transaction that pay directly to us,
+	// and second level HTLC outputs that are now mature.
+	case lnwallet.CommitmentTimeLock,
+		lnwallet.HtlcOfferedTimeoutSecondLevel,
+		lnwallet.HtlcAcceptedSuccessSecondLevel:
+
+		return lnwallet.ToLocalTimeoutWitnessSize, nil
+
+	// An HTLC on the commitment transaction of the remote party, that has
+	// had its absolute timelock expire.
+	case lnwallet.HtlcOfferedRemoteTimeout:
+		return lnwallet.AcceptedHtlcTimeoutWitnessSize, nil
+
+	// An HTLC on the commitment transaction of the remote party, that we
+	// can redeem using the preimage.
+	case lnwallet.HtlcAcceptedRemoteSuccess:
+		return lnwallet.OfferedHtlcSuccessWitnessSize, nil
+
+	// Outputs on a revoked commitment transaction of the remote party.
+	case lnwallet.CommitmentRevoke,
+		lnwallet.HtlcSecondLevelRevoke:
+
+		return lnwallet.ToLocalPenaltyWitnessSize, nil
+
+	case lnwallet.HtlcOfferedRevoke:
+		return lnwallet.OfferedHtlcPenaltyWitnessSize, nil
+
+	case lnwallet.HtlcAcceptedRevoke:
+		return lnwallet.AcceptedHtlcPenaltyWitnessSize, nil
+
+	case lnwallet.CommitmentAnchor:
+		return lnwallet.AnchorWitnessSize, nil
+
+	case lnwallet.CommitmentAnchorAnyone:
+		return lnwallet.AnchorAnyoneWitnessSize, nil
+	}
+
+	return 0, fmt.Errorf("unexpected witness type: %v", input.WitnessType())
+}
+
+// getWeightEstimate returns a weight estimate for the given inputs.
+// Additionally, it returns the inputs that can be swept. Inputs with an
+// unknown witness type are skipped.
+func getWeightEstimate(inputs []Input) ([]Input, int64) {
+	// We initialize a weight estimator so we can accurately asses the
+	// amount of fees we need to pay for this sweep transaction.
+	var weightEstimate lnwallet.TxWeightEstimator
+
+	// Our sweep transaction will pay to a single segwit p2wkh address,
+	// ensure it contributes to our weight estimate.
+	weightEstimate.AddP2WKHOutput()
+
+	// For each output, use its witness type to determine the estimated
+	// weight of its witness, and add it to the proper set of spendable
+	// outputs.
+	var sweepInputs []Input
+	for _, input := range inputs {
+		size, err := getInputWitnessSizeUpperBound(input)
+		if err != nil {
+			log.Warnf("Skipping input %v: %v", input.OutPoint(), err)
+			continue
+		}
+		weightEstimate.AddWitnessInput(size)
+
+		sweepInputs = append(sweepInputs, input)
+	}
+
+	return sweepInputs, int64(weightEstimate.Weight())
+}
+
+// inputYield returns the value of the passed input minus the fee that needs
+// to be paid for including it in a sweep transaction at the given fee rate.
+// Inputs with an unknown witness type have no yield.
+func inputYield(input Input, feePerKw lnwallet.SatPerKWeight) btcutil.Amount {
+	size, err := getInputWitnessSizeUpperBound(input)
+	if err != nil {
+		return 0
+	}
+
+	// Next to the witness, each input adds its outpoint, sequence and an
+	// empty sig script to the base size of the transaction.
+	weight := int64(size) + 4*lnwallet.InputSize
+
+	return btcutil.Amount(input.SignDesc().Output.Value) -
+		feePerKw.FeeForWeight(weight)
+}
+
+// sortByYield sorts the passed inputs by yield at the given fee rate, highest
+// first, and drops all inputs that cost more to sweep than they are worth.
+func sortByYield(inputs []Input,
+	feePerKw lnwallet.SatPerKWeight) []Input {
+
+	sorted := make([]Input, 0, len(inputs))
+	for _, input := range inputs {
+		if inputYield(input, feePerKw) <= 0 {
+			log.Debugf("Skipping input %v with negative yield at "+
+				"fee rate %v sat/kw", input.OutPoint(),
+				int64(feePerKw))
+			continue
+		}
+
+		sorted = append(sorted, input)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return inputYield(sorted[i], feePerKw) >
+			inputYield(sorted[j], feePerKw)
+	})
+
+	return sorted
+}
+
+// CreateSweepTx builds a signed tx spending the inputs to the given output
+// script at the passed fee rate. The tx has a single output that carries the
+// total value of the inputs minus the fee. The sequence of each input is set
+// to its relative time lock, and the lock time of the transaction to the
+// highest lock time required by any of the inputs.
+func CreateSweepTx(inputs []Input, outputPkScript []byte,
+	feePerKw lnwallet.SatPerKWeight,
+	signer lnwallet.Signer) (*wire.MsgTx, error) {
+
+	inputs, txWeight := getWeightEstimate(inputs)
+	if len(inputs) == 0 {
+		return nil, ErrNoInputs
+	}
+
+	txFee := feePerKw.FeeForWeight(txWeight)
+
+	// Sum up the total value contained in the inputs.
+	var totalSum btcutil.Amount
+	for _, o := range inputs {
+		totalSum += btcutil.Amount(o.SignDesc().Output.Value)
+	}
+
+	// Sweep as much possible, after subtracting txn fees.
+	sweepAmt := totalSum - txFee
+	if sweepAmt < lnwallet.DefaultDustLimit() {
+		return nil, ErrDustOutput
+	}
+
+	log.Infof("Creating sweep transaction for %v inputs (%v) using %v "+
+		"sat/kw, tx_fee=%v", len(inputs), totalSum, int64(feePerKw),
+		txFee)
+
+	// Create the sweep transaction that we will be building. We use
+	// version 2 as it is required for CSV. The txn will sweep the amount
+	// after fees to the pkscript generated above.
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: outputPkScript,
+		Value:    int64(sweepAmt),
+	})
+
+	// Add all inputs to the sweep transaction. Ensure that for each
+	// csv input, we set the sequence number properly, and that the lock
+	// time of the transaction satisfies all cltv inputs.
+	for _, input := range inputs {
+		sweepTx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: *input.OutPoint(),
+			Sequence:         input.BlocksToMaturity(),
+		})
+
+		lockTime, ok := input.RequiredLockTime()
+		if ok && lockTime > sweepTx.LockTime {
+			sweepTx.LockTime = lockTime
+		}
+	}
+
+	// Before signing the transaction, check to ensure that it meets some
+	// basic validity requirements.
+	btx := btcutil.NewTx(sweepTx)
+	if err := blockchain.CheckTransactionSanity(btx); err != nil {
+		return nil, err
+	}
+
+	hashCache := txscript.NewTxSigHashes(sweepTx)
+
+	// With all the inputs in place, use each output's unique witness
+	// function to generate the final witness required for spending.
+	for idx, input := range inputs {
+		witness, err := input.BuildWitness(
+			signer, sweepTx, hashCache, idx,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sweepTx.TxIn[idx].Witness = witness
+	}
+
+	return sweepTx, nil
+}